@@ -6,17 +6,24 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"strings"
 	"time"
 
 	configv1client "github.com/openshift/client-go/config/clientset/versioned/typed/config/v1"
 	log "github.com/sirupsen/logrus"
 	utilclock "k8s.io/apimachinery/pkg/util/clock"
 	k8sscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 
-	"github.com/operator-framework/operator-lifecycle-manager/pkg/api/client"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/api/client/clientset/versioned"
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/operators/catalog"
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/operators/catalogtemplate"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/operators/operatorinstall"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/operators/operatoruninstall"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/clients"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/controllers"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/leaderelection"
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/operatorclient"
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/operatorstatus"
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/server"
@@ -75,10 +82,26 @@ var (
 
 	installPlanTimeout  = flag.Duration("install-plan-retry-timeout", 1*time.Minute, "time since first attempt at which plan execution errors are considered fatal")
 	bundleUnpackTimeout = flag.Duration("bundle-unpack-timeout", 10*time.Minute, "The time limit for bundle unpacking, after which InstallPlan execution is considered to have failed. 0 is considered as having no timeout.")
+	maxParallelUnpacks  = flag.Int("max-parallel-bundle-unpacks", 0, "The maximum number of bundle unpack jobs in flight across the cluster. 0 is considered as having no limit.")
+	registryBackoff     = flag.Duration("bundle-unpack-registry-backoff", 30*time.Second, "The time to back off from a registry after it rate limits or fails a bundle image pull, doubled after each consecutive one. 0 disables the backoff.")
+	maxRegistryBackoff  = flag.Duration("bundle-unpack-registry-max-backoff", 10*time.Minute, "The maximum time to back off from a registry failing bundle image pulls.")
+	maxParallelPolls    = flag.Int("max-parallel-catalog-polls", 0, "The maximum number of CatalogSource image polls in flight across the cluster. Held back polls are admitted by decreasing CatalogSource priority. 0 is considered as having no limit.")
+	syncTimeout         = flag.Duration("sync-timeout", 5*time.Minute, "The time limit for a single sync, after which its outstanding API requests are cancelled and the synced object is requeued. 0 is considered as having no timeout.")
+	clientQPS           = flag.Float64("client-qps", 50, "The maximum sustained rate of requests to the apiserver per client. A negative value disables client-side rate limiting.")
+	clientBurst         = flag.Int("client-burst", 100, "The maximum burst of requests to the apiserver per client.")
+	auditMutations      = flag.Bool("audit-mutations", false, "Record the CRDs, Deployments, RoleBindings, ClusterRoleBindings, APIServices and Secrets InstallPlans create or update as Events on the InstallPlans and as structured log entries.")
+	crdEstablishTimeout = flag.Duration("crd-establish-timeout", 2*time.Minute, "The time limit for each CRD applied by an InstallPlan to be established and served, after which the InstallPlan fails. The custom resources and CSVs of the InstallPlan aren't applied until then. 0 is considered as having no timeout.")
+	disableControllers  = flag.String("disable-controllers", "", "Comma separated list of optional controllers not to run, among configmap-catalogs, reported on the cluster OLMConfig. CatalogSources of the configmap and internal source types fail while configmap-catalogs is disabled.")
+	requiredValidators  = flag.String("required-bundle-validators", "", "Comma separated list of validators, among csv, crd, bundle, objects, operatorhub, good-practices and deprecated-apis, run against the bundles InstallPlans install before creating their resources. InstallPlans of bundles they report errors for fail.")
+	advisoryValidators  = flag.String("advisory-bundle-validators", "", "Comma separated list of validators, among those of -required-bundle-validators, whose results are only reported on InstallPlans.")
+
+	leaderElection leaderelection.Config
 )
 
 func init() {
 	metrics.RegisterCatalog()
+
+	leaderElection.AddFlags(flag.CommandLine, "catalog-operator-lock")
 }
 
 func main() {
@@ -107,52 +130,100 @@ func main() {
 		*catalogNamespace = catalogNamespaceEnvVarValue
 	}
 
-	listenAndServe, err := server.GetListenAndServeFunc(server.WithLogger(logger), server.WithTLS(tlsCertPath, tlsKeyPath, clientCAPath), server.WithDebug(*debug))
+	config, err := clientcmd.BuildConfigFromFlags("", *kubeConfigPath)
 	if err != nil {
-		logger.Fatalf("Error setting up health/metric/pprof service: %v", err)
+		log.Fatalf("error configuring client: %s", err.Error())
 	}
-
-	go func() {
-		if err := listenAndServe(); err != nil && err != http.ErrServerClosed {
-			logger.Error(err)
-		}
-	}()
+	config = clients.SetRateLimits(float32(*clientQPS), *clientBurst).TransformConfig(config)
 
 	// create a config client for operator status
-	config, err := clientcmd.BuildConfigFromFlags("", *kubeConfigPath)
+	statusConfig := clients.SetUserAgent("catalog-operator-status").TransformConfig(rest.CopyConfig(config))
+	configClient, err := configv1client.NewForConfig(statusConfig)
 	if err != nil {
 		log.Fatalf("error configuring client: %s", err.Error())
 	}
-	configClient, err := configv1client.NewForConfig(config)
+	opClient, err := operatorclient.NewClientFromRestConfig(statusConfig)
 	if err != nil {
 		log.Fatalf("error configuring client: %s", err.Error())
 	}
-	opClient := operatorclient.NewClientFromConfig(*kubeConfigPath, logger)
-	crClient, err := client.NewClient(*kubeConfigPath)
+	crClient, err := versioned.NewForConfig(statusConfig)
 	if err != nil {
 		log.Fatalf("error configuring client: %s", err.Error())
 	}
 
+	disabled, err := controllers.Parse(strings.Split(*disableControllers, ","), controllers.Catalog)
+	if err != nil {
+		log.Fatalf("error configuring disabled controllers: %s", err.Error())
+	}
+	if len(disabled) > 0 {
+		logger.Infof("disabled controllers: %s", disabled)
+	}
+	var bundleValidators catalog.BundleValidators
+	if bundleValidators.Required, err = catalog.ParseBundleValidators(strings.Split(*requiredValidators, ",")); err != nil {
+		log.Fatalf("error configuring required bundle validators: %s", err.Error())
+	}
+	if bundleValidators.Advisory, err = catalog.ParseBundleValidators(strings.Split(*advisoryValidators, ",")); err != nil {
+		log.Fatalf("error configuring advisory bundle validators: %s", err.Error())
+	}
+
 	// Create a new instance of the operator.
-	op, err := catalog.NewOperator(ctx, *kubeConfigPath, utilclock.RealClock{}, logger, *wakeupInterval, *configmapServerImage, *opmImage, *utilImage, *catalogNamespace, k8sscheme.Scheme, *installPlanTimeout, *bundleUnpackTimeout)
+	op, err := catalog.NewOperator(ctx, clients.SetUserAgent("catalog-operator").TransformConfig(rest.CopyConfig(config)), utilclock.RealClock{}, logger, *wakeupInterval, *configmapServerImage, *opmImage, *utilImage, *catalogNamespace, k8sscheme.Scheme, *installPlanTimeout, *bundleUnpackTimeout, *maxParallelUnpacks, *registryBackoff, *maxRegistryBackoff, *syncTimeout, *maxParallelPolls, *auditMutations, *crdEstablishTimeout, disabled, bundleValidators)
 	if err != nil {
 		log.Fatalf("error configuring catalog operator: %s", err.Error())
 	}
+	elector, err := leaderelection.NewElector(leaderElection, *catalogNamespace, opClient.KubernetesInterface(), logger)
+	if err != nil {
+		log.Fatalf("error configuring leader election: %s", err.Error())
+	}
 
-	opCatalogTemplate, err := catalogtemplate.NewOperator(ctx, *kubeConfigPath, logger, *wakeupInterval, *catalogNamespace)
+	// The server also serves the Subscription preview webhook, so it's started once the operator is configured. Standby
+	// replicas are ready, so that rolling updates don't wait on them.
+	listenAndServe, err := server.GetListenAndServeFunc(server.WithLogger(logger), server.WithTLS(tlsCertPath, tlsKeyPath, clientCAPath), server.WithDebug(*debug),
+		server.WithHandler(catalog.SubscriptionPreviewPath, http.HandlerFunc(op.ServeSubscriptionPreview)),
+		server.WithReadyCheck("informers", elector.WhenLeading(op.CheckSynced)), server.WithReadyCheck("discovery", elector.WhenLeading(op.CheckDiscovery)),
+		server.WithReadyCheck("catalog-sources", elector.WhenLeading(op.CheckCatalogConnectivity)))
 	if err != nil {
-		log.Fatalf("error configuring catalog template operator: %s", err.Error())
+		logger.Fatalf("Error setting up health/metric/pprof service: %v", err)
 	}
 
-	op.Run(ctx)
-	<-op.Ready()
+	go func() {
+		if err := listenAndServe(); err != nil && err != http.ErrServerClosed {
+			logger.Error(err)
+		}
+	}()
 
-	opCatalogTemplate.Run(ctx)
-	<-opCatalogTemplate.Ready()
+	opCatalogTemplate, err := catalogtemplate.NewOperator(ctx, clients.SetUserAgent("catalog-template-operator").TransformConfig(rest.CopyConfig(config)), logger, *wakeupInterval, *catalogNamespace)
+	if err != nil {
+		log.Fatalf("error configuring catalog template operator: %s", err.Error())
+	}
 
-	if *writeStatusName != "" {
-		operatorstatus.MonitorClusterStatus(*writeStatusName, op.AtLevel(), op.Done(), opClient, configClient, crClient)
+	opOperatorInstall, err := operatorinstall.NewOperator(ctx, clients.SetUserAgent("operator-install-operator").TransformConfig(rest.CopyConfig(config)), logger, *wakeupInterval, *catalogNamespace)
+	if err != nil {
+		log.Fatalf("error configuring operator install operator: %s", err.Error())
 	}
 
-	<-op.Done()
+	opOperatorUninstall, err := operatoruninstall.NewOperator(ctx, clients.SetUserAgent("operator-uninstall-operator").TransformConfig(rest.CopyConfig(config)), logger, *wakeupInterval)
+	if err != nil {
+		log.Fatalf("error configuring operator uninstall operator: %s", err.Error())
+	}
+
+	elector.Run(ctx, func(ctx context.Context) {
+		op.Run(ctx)
+		<-op.Ready()
+		go op.ReportDisabledControllers(ctx)
+
+		opCatalogTemplate.Run(ctx)
+		<-opCatalogTemplate.Ready()
+
+		// OperatorInstalls and OperatorUninstalls are reconciled once their CRDs are installed, without holding up the
+		// other controllers
+		opOperatorInstall.Run(ctx)
+		opOperatorUninstall.Run(ctx)
+
+		if *writeStatusName != "" {
+			operatorstatus.MonitorClusterStatus(*writeStatusName, op.AtLevel(), op.Done(), opClient, configClient, crClient)
+		}
+
+		<-op.Done()
+	})
 }
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/mockapiserver"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/signals"
+)
+
+func main() {
+	var (
+		apis             []string
+		mockGroupVersion string
+		mockKinds        []string
+		config           mockapiserver.Config
+		debug            bool
+	)
+	cmd := &cobra.Command{
+		Use:   "mock-extension-apiserver",
+		Short: "Serve a mock extension apiserver",
+		Long: `Serve the discovery documents of the given API group versions, and empty lists of their resources, over HTTPS.
+The serving certificate OLM generates for the deployments of APIServices is used by default.`,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if debug {
+				log.SetLevel(log.DebugLevel)
+			}
+
+			// --mock-group-version and --mock-kinds are kept for the manifests of the former mock-extension-apiserver image
+			if mockGroupVersion != "" {
+				apis = append(apis, fmt.Sprintf("%s=%s", mockGroupVersion, strings.Join(mockKinds, ",")))
+			}
+			if len(apis) == 0 {
+				return fmt.Errorf("at least one group version must be served")
+			}
+			for _, api := range apis {
+				gv, err := mockapiserver.ParseGroupVersion(api)
+				if err != nil {
+					return err
+				}
+				config.GroupVersions = append(config.GroupVersions, gv)
+			}
+
+			return mockapiserver.Run(signals.Context(), config, log.StandardLogger())
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringArrayVar(&apis, "api", nil, "group version to serve, as <group>/<version>=<kind>[,<kind>...]; may be repeated")
+	flags.StringVar(&mockGroupVersion, "mock-group-version", "", "group version to serve, as <group>/<version>, along with --mock-kinds")
+	flags.StringSliceVar(&mockKinds, "mock-kinds", nil, "kinds of the group version set by --mock-group-version")
+	flags.IntVar(&config.Port, "secure-port", mockapiserver.DefaultPort, "port to serve on")
+	flags.StringVar(&config.CertFile, "tls-cert-file", "", "serving certificate; defaults to the certificate OLM mounts in "+mockapiserver.DefaultCertDir)
+	flags.StringVar(&config.KeyFile, "tls-private-key-file", "", "serving key; defaults to the key OLM mounts in "+mockapiserver.DefaultCertDir)
+	flags.BoolVar(&debug, "debug", false, "log every request")
+	flags.IntP("v", "v", 0, "ignored, use --debug instead")
+
+	if err := cmd.Execute(); err != nil {
+		os.Exit(1)
+	}
+}
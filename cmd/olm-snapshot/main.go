@@ -0,0 +1,166 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/api/client/clientset/versioned"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/signals"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/snapshot"
+)
+
+var kubeconfig string
+
+func main() {
+	rootCmd := &cobra.Command{
+		Use:   "olm-snapshot",
+		Short: "Snapshot and restore OLM state",
+		Long: `Capture the OLM state of a cluster into a portable archive, and restore it onto another cluster.
+The archive holds the OLMConfig, the Subscriptions and OperatorGroups of the cluster, the CatalogSources
+referenced by the Subscriptions, and the identities of the installed ClusterServiceVersions.`,
+		SilenceUsage: true,
+	}
+	rootCmd.PersistentFlags().StringVar(&kubeconfig, "kubeconfig", "", "absolute path to the kubeconfig file")
+	rootCmd.AddCommand(captureCmd(), restoreCmd())
+
+	if err := rootCmd.Execute(); err != nil {
+		os.Exit(1)
+	}
+}
+
+func captureCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "capture [archive]",
+		Short: "Capture the OLM state of a cluster into an archive",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			_, client, err := clients()
+			if err != nil {
+				return err
+			}
+
+			s, err := snapshot.Capture(signals.Context(), client)
+			if err != nil {
+				return err
+			}
+
+			f, err := os.Create(args[0])
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			if err := snapshot.Write(f, s); err != nil {
+				return fmt.Errorf("error writing snapshot: %w", err)
+			}
+
+			fmt.Printf("captured %d subscriptions, %d operatorgroups, %d catalogsources and %d csvs to %s\n",
+				len(s.Subscriptions), len(s.OperatorGroups), len(s.CatalogSources), len(s.ClusterServiceVersions), args[0])
+			return nil
+		},
+	}
+}
+
+func restoreCmd() *cobra.Command {
+	var (
+		approve bool
+		timeout time.Duration
+	)
+	cmd := &cobra.Command{
+		Use:   "restore [archive]",
+		Short: "Restore the OLM state captured in an archive onto a cluster",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			f, err := os.Open(args[0])
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			s, err := snapshot.Read(f)
+			if err != nil {
+				return err
+			}
+
+			kubeClient, client, err := clients()
+			if err != nil {
+				return err
+			}
+
+			ctx := signals.Context()
+			logger := log.New()
+			if err := snapshot.Restore(ctx, kubeClient, client, s, logger); err != nil {
+				return err
+			}
+			if !approve && timeout == 0 {
+				return nil
+			}
+
+			return waitForRestore(ctx, client, s, approve, timeout, logger)
+		},
+	}
+	cmd.Flags().BoolVar(&approve, "approve-install-plans", false, "approve the InstallPlans of manually approved Subscriptions that only install captured CSVs")
+	cmd.Flags().DurationVar(&timeout, "wait", 0, "time to wait for the captured CSVs to succeed, 0 to not wait")
+	return cmd
+}
+
+// waitForRestore polls until the captured CSVs have succeeded, approving InstallPlans as OLM creates them if requested.
+func waitForRestore(ctx context.Context, client versioned.Interface, s *snapshot.Snapshot, approve bool, timeout time.Duration, logger log.FieldLogger) error {
+	if timeout == 0 {
+		// Approve the InstallPlans created so far without waiting for more
+		_, err := snapshot.ApproveInstallPlans(ctx, client, s, logger)
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var pending []snapshot.CSVIdentity
+	err := wait.PollImmediateUntil(5*time.Second, func() (bool, error) {
+		if approve {
+			if _, err := snapshot.ApproveInstallPlans(ctx, client, s, logger); err != nil {
+				return false, err
+			}
+		}
+
+		var err error
+		pending, err = snapshot.Pending(ctx, client, s)
+		if err != nil {
+			return false, err
+		}
+		return len(pending) == 0, nil
+	}, ctx.Done())
+	if err == wait.ErrWaitTimeout {
+		for _, csv := range pending {
+			logger.WithField("csv", csv.Namespace+"/"+csv.Name).Warn("csv has not succeeded")
+		}
+		return fmt.Errorf("timed out waiting for %d csvs to succeed", len(pending))
+	}
+	return err
+}
+
+func clients() (kubernetes.Interface, versioned.Interface, error) {
+	config, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+		&clientcmd.ClientConfigLoadingRules{ExplicitPath: kubeconfig, Precedence: clientcmd.NewDefaultClientConfigLoadingRules().Precedence},
+		&clientcmd.ConfigOverrides{},
+	).ClientConfig()
+	if err != nil {
+		return nil, nil, fmt.Errorf("error loading kubeconfig: %w", err)
+	}
+
+	kubeClient, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, nil, err
+	}
+	client, err := versioned.NewForConfig(config)
+	if err != nil {
+		return nil, nil, err
+	}
+	return kubeClient, client, nil
+}
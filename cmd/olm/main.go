@@ -14,6 +14,7 @@ import (
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/pflag"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/rest"
 	"k8s.io/klog"
 	ctrl "sigs.k8s.io/controller-runtime"
 
@@ -21,10 +22,14 @@ import (
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/operators/olm"
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/operators/openshift"
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/feature"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/clients"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/controllers"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/leaderelection"
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/operatorclient"
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/operatorstatus"
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/queueinformer"
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/server"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/sharding"
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/signals"
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/metrics"
 	olmversion "github.com/operator-framework/operator-lifecycle-manager/pkg/version"
@@ -69,6 +74,44 @@ var (
 
 	namespace = pflag.String(
 		"namespace", "", "namespace where cleanup runs")
+
+	resourceBaselineWindow = pflag.Duration(
+		"resource-baseline-window", 0, "how long to sample the pods of a succeeded CSV before recording their resource consumption baseline, set to 0 to disable")
+
+	syncTimeout = pflag.Duration(
+		"sync-timeout", 5*time.Minute, "the time limit for a single sync, after which its outstanding API requests are cancelled and the synced object is requeued, set to 0 to disable")
+
+	clientQPS = pflag.Float32(
+		"client-qps", 50, "the maximum sustained rate of requests to the apiserver per client, set to a negative value to disable client-side rate limiting")
+
+	clientBurst = pflag.Int(
+		"client-burst", 100, "the maximum burst of requests to the apiserver per client")
+
+	auditMutations = pflag.Bool(
+		"audit-mutations", false, "record the Deployments, RoleBindings, ClusterRoleBindings, APIServices and Secrets created, updated or deleted on behalf of CSVs as Events on the CSVs and as structured log entries")
+
+	csvRequeueInterval = pflag.Duration(
+		"csv-requeue-interval", 0, "how long to wait before re-checking the requirements of a CSV whose requirements are unmet, overridden by the operatorframework.io/csv-requeue-interval annotation of the cluster OLMConfig, set to 0 to retry with exponential backoff")
+
+	shards = pflag.Int(
+		"shards", 1, "the number of shards partitioning namespaces among olm-operator replicas, each reconciling the CSVs, copied CSVs and OperatorGroups of its namespaces")
+
+	shard = pflag.Int(
+		"shard", 0, "the shard of the namespaces reconciled by this replica, from 0 to the number of shards minus one")
+
+	copiedCSVWorkers = pflag.Int(
+		"copied-csv-workers", 2, "the number of workers copying CSVs to the target namespaces of their OperatorGroup, apart from the workers reconciling CSVs")
+
+	copiedCSVQPS = pflag.Float64(
+		"copied-csv-qps", 5, "the maximum sustained rate of retried CSV copies per second")
+
+	copiedCSVBurst = pflag.Int(
+		"copied-csv-burst", 50, "the maximum burst of retried CSV copies")
+
+	disableControllers = pflag.StringSlice(
+		"disable-controllers", nil, "comma separated list of optional controllers not to run, among copied-csvs, operators and operatorconditions, reported on the cluster OLMConfig")
+
+	leaderElection leaderelection.Config
 )
 
 func init() {
@@ -76,6 +119,8 @@ func init() {
 
 	// Add feature gates before parsing
 	feature.AddFlag(pflag.CommandLine)
+
+	leaderElection.AddFlags(pflag.CommandLine, "olm-operator-lock")
 }
 
 // main function - entrypoint to OLM operator
@@ -118,22 +163,20 @@ func main() {
 	}
 	logger.Infof("log level %s", logger.Level)
 
-	listenAndServe, err := server.GetListenAndServeFunc(server.WithLogger(logger), server.WithTLS(tlsCertPath, tlsKeyPath, clientCAPath), server.WithDebug(*debug))
+	disabled, err := controllers.Parse(*disableControllers, controllers.OLM)
 	if err != nil {
-		logger.Fatalf("Error setting up health/metric/pprof service: %v", err)
+		logger.WithError(err).Fatal("error configuring disabled controllers")
+	}
+	if len(disabled) > 0 {
+		logger.Infof("disabled controllers: %s", disabled)
 	}
 
-	go func() {
-		if err := listenAndServe(); err != nil && err != http.ErrServerClosed {
-			logger.Error(err)
-		}
-	}()
-
-	mgr, err := Manager(ctx, *debug)
+	config := clients.SetRateLimits(*clientQPS, *clientBurst).TransformConfig(ctrl.GetConfigOrDie())
+	mgr, err := Manager(ctx, clients.SetUserAgent("olm-controller-manager").TransformConfig(rest.CopyConfig(config)), *debug, disabled)
 	if err != nil {
 		logger.WithError(err).Fatal("error configuring controller manager")
 	}
-	config := mgr.GetConfig()
+	config = clients.SetUserAgent("olm-operator").TransformConfig(config)
 
 	versionedConfigClient, err := configclientset.NewForConfig(config)
 	if err != nil {
@@ -153,8 +196,7 @@ func main() {
 	}
 
 	// Create a new instance of the operator.
-	op, err := olm.NewOperator(
-		ctx,
+	options := []olm.OperatorOption{
 		olm.WithLogger(logger),
 		olm.WithWatchedNamespaces(namespaces...),
 		olm.WithResyncPeriod(queueinformer.ResyncWithJitter(*wakeupInterval, 0.2)),
@@ -162,58 +204,103 @@ func main() {
 		olm.WithOperatorClient(opClient),
 		olm.WithRestConfig(config),
 		olm.WithConfigClient(versionedConfigClient),
-	)
+		olm.WithResourceBaselineWindow(*resourceBaselineWindow),
+		olm.WithSyncTimeout(*syncTimeout),
+		olm.WithCSVRequeueInterval(*csvRequeueInterval),
+		olm.WithAuditMutations(*auditMutations),
+		olm.WithCopiedCSVWorkers(*copiedCSVWorkers),
+		olm.WithCopiedCSVRateLimit(*copiedCSVQPS, *copiedCSVBurst),
+		olm.WithDisabledControllers(disabled),
+	}
+	if *namespace != "" {
+		options = append(options, olm.WithOperatorNamespace(*namespace))
+	}
+	if *shards > 1 {
+		s, err := sharding.New(*shard, *shards)
+		if err != nil {
+			logger.WithError(err).Fatal("error configuring sharding")
+		}
+		logger.Infof("reconciling the namespaces of shard %s", s)
+		options = append(options, olm.WithShard(s))
+		metrics.SetShardInfo(s.Index, s.Count)
+
+		// Replicas of a shard elect their own leader
+		leaderElection.Name = fmt.Sprintf("%s-%d", leaderElection.Name, s.Index)
+	} else {
+		metrics.SetShardInfo(0, 1)
+	}
+	op, err := olm.NewOperator(ctx, options...)
 	if err != nil {
 		logger.WithError(err).Fatal("error configuring operator")
 		return
 	}
+	elector, err := leaderelection.NewElector(leaderElection, *namespace, opClient.KubernetesInterface(), logger)
+	if err != nil {
+		logger.WithError(err).Fatal("error configuring leader election")
+	}
 
-	op.Run(ctx)
-	<-op.Ready()
-
-	// Emit CSV metric
-	if err = op.EnsureCSVMetric(); err != nil {
-		logger.WithError(err).Fatal("error emitting metrics for existing CSV")
+	// The server checks the readiness of the operator, so it's started once the operator is configured. Standby
+	// replicas are ready, so that rolling updates don't wait on them.
+	listenAndServe, err := server.GetListenAndServeFunc(server.WithLogger(logger), server.WithTLS(tlsCertPath, tlsKeyPath, clientCAPath), server.WithDebug(*debug),
+		server.WithReadyCheck("informers", elector.WhenLeading(op.CheckSynced)), server.WithReadyCheck("discovery", elector.WhenLeading(op.CheckDiscovery)))
+	if err != nil {
+		logger.Fatalf("Error setting up health/metric/pprof service: %v", err)
 	}
 
-	if *writeStatusName != "" {
-		reconciler, err := openshift.NewClusterOperatorReconciler(
-			openshift.WithClient(mgr.GetClient()),
-			openshift.WithScheme(mgr.GetScheme()),
-			openshift.WithLog(ctrl.Log.WithName("controllers").WithName("clusteroperator")),
-			openshift.WithName(*writeStatusName),
-			openshift.WithNamespace(*namespace),
-			openshift.WithSyncChannel(op.AtLevel()),
-			openshift.WithOLMOperator(),
-		)
-		if err != nil {
-			logger.WithError(err).Fatal("error configuring openshift integration")
-			return
+	go func() {
+		if err := listenAndServe(); err != nil && err != http.ErrServerClosed {
+			logger.Error(err)
 		}
+	}()
 
-		if err := reconciler.SetupWithManager(mgr); err != nil {
-			logger.WithError(err).Fatal("error configuring openshift integration")
-			return
+	elector.Run(ctx, func(ctx context.Context) {
+		op.Run(ctx)
+		<-op.Ready()
+
+		// Emit CSV metric
+		if err = op.EnsureCSVMetric(); err != nil {
+			logger.WithError(err).Fatal("error emitting metrics for existing CSV")
 		}
-	}
 
-	if *writePackageServerStatusName != "" {
-		logger.Info("Initializing cluster operator monitor for package server")
+		if *writeStatusName != "" {
+			reconciler, err := openshift.NewClusterOperatorReconciler(
+				openshift.WithClient(mgr.GetClient()),
+				openshift.WithScheme(mgr.GetScheme()),
+				openshift.WithLog(ctrl.Log.WithName("controllers").WithName("clusteroperator")),
+				openshift.WithName(*writeStatusName),
+				openshift.WithNamespace(*namespace),
+				openshift.WithSyncChannel(op.AtLevel()),
+				openshift.WithOLMOperator(),
+			)
+			if err != nil {
+				logger.WithError(err).Fatal("error configuring openshift integration")
+				return
+			}
+
+			if err := reconciler.SetupWithManager(mgr); err != nil {
+				logger.WithError(err).Fatal("error configuring openshift integration")
+				return
+			}
+		}
 
-		names := *writePackageServerStatusName
-		discovery := opClient.KubernetesInterface().Discovery()
-		monitor, sender := operatorstatus.NewMonitor(logger, discovery, configClient, names)
+		if *writePackageServerStatusName != "" {
+			logger.Info("Initializing cluster operator monitor for package server")
 
-		handler := operatorstatus.NewCSVWatchNotificationHandler(logger, op.GetCSVSetGenerator(), op.GetReplaceFinder(), sender)
-		op.RegisterCSVWatchNotification(handler)
+			names := *writePackageServerStatusName
+			discovery := opClient.KubernetesInterface().Discovery()
+			monitor, sender := operatorstatus.NewMonitor(logger, discovery, configClient, names)
 
-		go monitor.Run(op.Done())
-	}
+			handler := operatorstatus.NewCSVWatchNotificationHandler(logger, op.GetCSVSetGenerator(), op.GetReplaceFinder(), sender)
+			op.RegisterCSVWatchNotification(handler)
 
-	// Start the controller manager
-	if err := mgr.Start(ctx); err != nil {
-		logger.WithError(err).Fatal("controller manager stopped")
-	}
+			go monitor.Run(op.Done())
+		}
+
+		// Start the controller manager
+		if err := mgr.Start(ctx); err != nil {
+			logger.WithError(err).Fatal("controller manager stopped")
+		}
 
-	<-op.Done()
+		<-op.Done()
+	})
 }
@@ -8,6 +8,7 @@ import (
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/client-go/rest"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
@@ -16,6 +17,7 @@ import (
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/install"
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/operators"
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/feature"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/controllers"
 )
 
 var (
@@ -30,7 +32,7 @@ func init() {
 	copiedLabelDoesNotExist = labels.NewSelector().Add(*requirement)
 }
 
-func Manager(ctx context.Context, debug bool) (ctrl.Manager, error) {
+func Manager(ctx context.Context, config *rest.Config, debug bool, disabled controllers.Disabled) (ctrl.Manager, error) {
 	ctrl.SetLogger(zap.New(zap.UseDevMode(debug)))
 	setupLog := ctrl.Log.WithName("setup").V(1)
 
@@ -47,7 +49,7 @@ func Manager(ctx context.Context, debug bool) (ctrl.Manager, error) {
 	}
 
 	setupLog.Info("configuring manager")
-	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
+	mgr, err := ctrl.NewManager(config, ctrl.Options{
 		Scheme:             scheme,
 		MetricsBindAddress: "0", // TODO(njhale): Enable metrics on non-conflicting port (not 8080)
 		NewCache: cache.BuilderWithOptions(cache.Options{
@@ -65,33 +67,35 @@ func Manager(ctx context.Context, debug bool) (ctrl.Manager, error) {
 		return nil, err
 	}
 
-	operatorConditionReconciler, err := operators.NewOperatorConditionReconciler(
-		mgr.GetClient(),
-		ctrl.Log.WithName("controllers").WithName("operatorcondition"),
-		mgr.GetScheme(),
-	)
-	if err != nil {
-		return nil, err
-	}
+	if disabled.Enabled(controllers.OperatorConditions) {
+		operatorConditionReconciler, err := operators.NewOperatorConditionReconciler(
+			mgr.GetClient(),
+			ctrl.Log.WithName("controllers").WithName("operatorcondition"),
+			mgr.GetScheme(),
+		)
+		if err != nil {
+			return nil, err
+		}
 
-	if err = operatorConditionReconciler.SetupWithManager(mgr); err != nil {
-		return nil, err
-	}
+		if err = operatorConditionReconciler.SetupWithManager(mgr); err != nil {
+			return nil, err
+		}
 
-	operatorConditionGeneratorReconciler, err := operators.NewOperatorConditionGeneratorReconciler(
-		mgr.GetClient(),
-		ctrl.Log.WithName("controllers").WithName("operatorcondition-generator"),
-		mgr.GetScheme(),
-	)
-	if err != nil {
-		return nil, err
-	}
+		operatorConditionGeneratorReconciler, err := operators.NewOperatorConditionGeneratorReconciler(
+			mgr.GetClient(),
+			ctrl.Log.WithName("controllers").WithName("operatorcondition-generator"),
+			mgr.GetScheme(),
+		)
+		if err != nil {
+			return nil, err
+		}
 
-	if err = operatorConditionGeneratorReconciler.SetupWithManager(mgr); err != nil {
-		return nil, err
+		if err = operatorConditionGeneratorReconciler.SetupWithManager(mgr); err != nil {
+			return nil, err
+		}
 	}
 
-	if feature.Gate.Enabled(feature.OperatorLifecycleManagerV1) {
+	if feature.Gate.Enabled(feature.OperatorLifecycleManagerV1) && disabled.Enabled(controllers.OperatorAggregator) {
 		// Setup a new controller to reconcile Operators
 		operatorReconciler, err := operators.NewOperatorReconciler(
 			mgr.GetClient(),
@@ -119,6 +123,20 @@ func Manager(ctx context.Context, debug bool) (ctrl.Manager, error) {
 			return nil, err
 		}
 	}
+	if feature.Gate.Enabled(feature.NamespacePodSecurityLabeler) {
+		namespacePodSecurityReconciler, err := operators.NewNamespacePodSecurityReconciler(
+			mgr.GetClient(),
+			ctrl.Log.WithName("controllers").WithName("namespace-pod-security"),
+			mgr.GetScheme(),
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if err = namespacePodSecurityReconciler.SetupWithManager(mgr); err != nil {
+			return nil, err
+		}
+	}
 	setupLog.Info("manager configured")
 
 	return mgr, nil
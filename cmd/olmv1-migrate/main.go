@@ -0,0 +1,80 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/ghodss/yaml"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/api/client/clientset/versioned"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/migration"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/signals"
+)
+
+func main() {
+	var kubeconfig, namespace string
+	cmd := &cobra.Command{
+		Use:   "olmv1-migrate",
+		Short: "Convert Subscriptions into OLMv1 ClusterExtensions",
+		Long: `Convert the Subscriptions of a cluster into OLMv1 ClusterExtensions and ClusterCatalogs.
+The manifests are written to stdout, and the features in use that OLMv1 can't express are
+reported on stderr. Subscriptions with blocking findings are left out of the manifests.
+Nothing is changed on the cluster.`,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+				&clientcmd.ClientConfigLoadingRules{ExplicitPath: kubeconfig, Precedence: clientcmd.NewDefaultClientConfigLoadingRules().Precedence},
+				&clientcmd.ConfigOverrides{},
+			).ClientConfig()
+			if err != nil {
+				return fmt.Errorf("error loading kubeconfig: %w", err)
+			}
+			client, err := versioned.NewForConfig(config)
+			if err != nil {
+				return err
+			}
+
+			in, err := migration.Gather(signals.Context(), client, namespace)
+			if err != nil {
+				return err
+			}
+			result := migration.Migrate(in)
+
+			for _, f := range result.Findings {
+				fmt.Fprintf(os.Stderr, "%s\t%s\t%s\n", f.Severity, f.Subscription, f.Message)
+			}
+			return writeManifests(os.Stdout, result)
+		},
+	}
+	cmd.Flags().StringVar(&kubeconfig, "kubeconfig", "", "absolute path to the kubeconfig file")
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", metav1.NamespaceAll, "only migrate the Subscriptions of this namespace")
+
+	if err := cmd.Execute(); err != nil {
+		os.Exit(1)
+	}
+}
+
+func writeManifests(w io.Writer, result *migration.Result) error {
+	var objs []interface{}
+	for i := range result.ClusterCatalogs {
+		objs = append(objs, &result.ClusterCatalogs[i])
+	}
+	for i := range result.ClusterExtensions {
+		objs = append(objs, &result.ClusterExtensions[i])
+	}
+
+	for _, obj := range objs {
+		out, err := yaml.Marshal(obj)
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(w, "---\n%s", out); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,318 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/yaml"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/operator-framework/operator-registry/pkg/client"
+
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/registry"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/registry/fbc"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/registry/resolver"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/registry/resolver/cache"
+)
+
+func main() {
+	var (
+		catalogs               []string
+		installed              string
+		namespace              string
+		globalCatalogNamespace string
+		output                 string
+		debug                  bool
+	)
+	cmd := &cobra.Command{
+		Use:   "resolver",
+		Short: "Resolve Subscriptions offline against file-based catalogs",
+		Long: `Resolve the Subscriptions of a namespace against file-based catalog directories, like the catalog operator
+resolves them in a cluster, and print the resolved operators or why resolution failed.
+
+The installed state of the namespace is read from a file of Subscription and ClusterServiceVersion manifests, e.g. the
+output of "kubectl get subscriptions,clusterserviceversions -n <namespace> -o yaml". Subscriptions reference catalogs
+by the namespace and name given to each --catalog.`,
+		Example:      `  resolver --catalog olm/operatorhubio-catalog=./catalog --installed installed.yaml`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			logger := log.New()
+			logger.SetOutput(os.Stderr)
+			if debug {
+				logger.SetLevel(log.DebugLevel)
+			} else {
+				logger.SetLevel(log.WarnLevel)
+			}
+
+			clients, stop, err := serveCatalogs(catalogs)
+			if err != nil {
+				return err
+			}
+			defer stop()
+
+			subs, csvs, err := readInstalled(installed)
+			if err != nil {
+				return err
+			}
+			if namespace == "" {
+				if namespace, err = subscriptionNamespace(subs); err != nil {
+					return err
+				}
+			}
+			subs, csvs = inNamespace(namespace, subs, csvs)
+
+			operators, report, err := resolver.ResolveOffline(clients, []string{namespace, globalCatalogNamespace}, csvs, subs, logger)
+			result := newResult(namespace, operators, report, err)
+			if output == "json" {
+				enc := json.NewEncoder(os.Stdout)
+				enc.SetIndent("", "  ")
+				if err := enc.Encode(result); err != nil {
+					return err
+				}
+			} else {
+				result.print(os.Stdout)
+			}
+			if err != nil {
+				return fmt.Errorf("resolution failed")
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringArrayVar(&catalogs, "catalog", nil, "a file-based catalog directory, as <namespace>/<name>=<directory>, repeatable")
+	cmd.Flags().StringVar(&installed, "installed", "", "a file of the Subscription and ClusterServiceVersion manifests installed in the namespace")
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "the namespace to resolve, defaults to the namespace of the Subscriptions")
+	cmd.Flags().StringVar(&globalCatalogNamespace, "global-catalog-namespace", "olm", "the namespace whose catalogs are available to all namespaces")
+	cmd.Flags().StringVarP(&output, "output", "o", "text", "the output format, text or json")
+	cmd.Flags().BoolVar(&debug, "debug", false, "log the decisions of the resolver")
+	_ = cmd.MarkFlagRequired("catalog")
+	_ = cmd.MarkFlagRequired("installed")
+
+	if err := cmd.Execute(); err != nil {
+		os.Exit(1)
+	}
+}
+
+// serveCatalogs serves the given file-based catalog directories over the registry API, like the catalog operator serves
+// the catalogs of CatalogSources of the oci source type, and returns their clients.
+func serveCatalogs(catalogs []string) (resolver.StaticRegistryClientProvider, func(), error) {
+	servers := fbc.NewServers()
+	clients := resolver.StaticRegistryClientProvider{}
+	stop := func() {
+		for key := range clients {
+			servers.Stop(key)
+		}
+	}
+	for _, c := range catalogs {
+		key, dir, err := parseCatalog(c)
+		if err != nil {
+			stop()
+			return nil, nil, err
+		}
+		index, err := fbc.LoadDir(dir)
+		if err != nil {
+			stop()
+			return nil, nil, fmt.Errorf("error loading catalog %s: %v", key, err)
+		}
+		address, err := servers.Serve(key, "", index)
+		if err != nil {
+			stop()
+			return nil, nil, fmt.Errorf("error serving catalog %s: %v", key, err)
+		}
+		if clients[key], err = client.NewClient(address); err != nil {
+			stop()
+			return nil, nil, fmt.Errorf("error connecting to catalog %s: %v", key, err)
+		}
+	}
+	return clients, stop, nil
+}
+
+func parseCatalog(catalog string) (registry.CatalogKey, string, error) {
+	ref, dir := catalog, ""
+	if i := strings.Index(catalog, "="); i >= 0 {
+		ref, dir = catalog[:i], catalog[i+1:]
+	}
+	parts := strings.Split(ref, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" || dir == "" {
+		return registry.CatalogKey{}, "", fmt.Errorf("invalid catalog %q, expected <namespace>/<name>=<directory>", catalog)
+	}
+	return registry.CatalogKey{Namespace: parts[0], Name: parts[1]}, dir, nil
+}
+
+// readInstalled reads the Subscriptions and ClusterServiceVersions of the given file of YAML or JSON documents, which
+// may be Lists. Other kinds of objects are ignored.
+func readInstalled(path string) ([]*v1alpha1.Subscription, []*v1alpha1.ClusterServiceVersion, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var objs []unstructured.Unstructured
+	dec := yaml.NewYAMLOrJSONDecoder(bytes.NewReader(content), 4096)
+	for {
+		var obj unstructured.Unstructured
+		if err := dec.Decode(&obj.Object); err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, nil, fmt.Errorf("error decoding %s: %v", path, err)
+		}
+		if obj.Object == nil {
+			continue
+		}
+		if obj.IsList() {
+			if err := obj.EachListItem(func(item runtime.Object) error {
+				objs = append(objs, *item.(*unstructured.Unstructured))
+				return nil
+			}); err != nil {
+				return nil, nil, fmt.Errorf("error decoding %s: %v", path, err)
+			}
+			continue
+		}
+		objs = append(objs, obj)
+	}
+
+	var (
+		subs []*v1alpha1.Subscription
+		csvs []*v1alpha1.ClusterServiceVersion
+	)
+	for _, obj := range objs {
+		switch obj.GetKind() {
+		case v1alpha1.SubscriptionKind:
+			sub := &v1alpha1.Subscription{}
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, sub); err != nil {
+				return nil, nil, fmt.Errorf("error decoding subscription %s: %v", obj.GetName(), err)
+			}
+			subs = append(subs, sub)
+		case v1alpha1.ClusterServiceVersionKind:
+			csv := &v1alpha1.ClusterServiceVersion{}
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, csv); err != nil {
+				return nil, nil, fmt.Errorf("error decoding csv %s: %v", obj.GetName(), err)
+			}
+			csvs = append(csvs, csv)
+		}
+	}
+	return subs, csvs, nil
+}
+
+func subscriptionNamespace(subs []*v1alpha1.Subscription) (string, error) {
+	namespaces := map[string]struct{}{}
+	for _, sub := range subs {
+		namespaces[sub.GetNamespace()] = struct{}{}
+	}
+	if len(namespaces) != 1 {
+		return "", fmt.Errorf("the subscriptions are in %d namespaces, set the namespace to resolve", len(namespaces))
+	}
+	for ns := range namespaces {
+		return ns, nil
+	}
+	return "", nil
+}
+
+// inNamespace returns the given Subscriptions and CSVs in the given namespace. Objects without a namespace are assumed
+// to be in it.
+func inNamespace(namespace string, subs []*v1alpha1.Subscription, csvs []*v1alpha1.ClusterServiceVersion) ([]*v1alpha1.Subscription, []*v1alpha1.ClusterServiceVersion) {
+	var nsSubs []*v1alpha1.Subscription
+	for _, sub := range subs {
+		if sub.GetNamespace() == "" || sub.GetNamespace() == namespace {
+			sub.SetNamespace(namespace)
+			nsSubs = append(nsSubs, sub)
+		}
+	}
+	var nsCSVs []*v1alpha1.ClusterServiceVersion
+	for _, csv := range csvs {
+		if csv.GetNamespace() == "" || csv.GetNamespace() == namespace {
+			csv.SetNamespace(namespace)
+			nsCSVs = append(nsCSVs, csv)
+		}
+	}
+	return nsSubs, nsCSVs
+}
+
+// resolvedOperator is an operator resolved for the namespace.
+type resolvedOperator struct {
+	Name    string `json:"name"`
+	Package string `json:"package,omitempty"`
+	Channel string `json:"channel,omitempty"`
+	Catalog string `json:"catalog,omitempty"`
+	// Installed is true if the operator is already installed.
+	Installed bool `json:"installed,omitempty"`
+}
+
+type result struct {
+	Namespace string                     `json:"namespace"`
+	Operators []resolvedOperator         `json:"operators,omitempty"`
+	Error     string                     `json:"error,omitempty"`
+	Report    *resolver.ResolutionReport `json:"report,omitempty"`
+}
+
+func newResult(namespace string, operators cache.OperatorSet, report *resolver.ResolutionReport, err error) *result {
+	r := &result{Namespace: namespace, Report: report}
+	if err != nil {
+		r.Error = err.Error()
+		return r
+	}
+	for _, op := range operators {
+		resolved := resolvedOperator{Name: op.Name}
+		if si := op.SourceInfo; si != nil {
+			resolved.Package = si.Package
+			resolved.Channel = si.Channel
+			if si.Catalog.Virtual() {
+				resolved.Installed = true
+			} else {
+				resolved.Catalog = si.Catalog.Namespace + "/" + si.Catalog.Name
+			}
+		}
+		r.Operators = append(r.Operators, resolved)
+	}
+	sort.Slice(r.Operators, func(i, j int) bool {
+		return r.Operators[i].Name < r.Operators[j].Name
+	})
+	return r
+}
+
+func (r *result) print(w io.Writer) {
+	if r.Error != "" {
+		fmt.Fprintf(w, "resolution of namespace %s failed: %s\n", r.Namespace, r.Error)
+		if r.Report != nil && len(r.Report.Conflicts) > 0 {
+			fmt.Fprintln(w, "conflicts:")
+			for _, c := range r.Report.Conflicts {
+				fmt.Fprintf(w, "  %s\n", c)
+			}
+		}
+		return
+	}
+
+	fmt.Fprintf(w, "resolved namespace %s:\n", r.Namespace)
+	for _, op := range r.Operators {
+		if op.Installed {
+			fmt.Fprintf(w, "  %s (installed)\n", op.Name)
+			continue
+		}
+		fmt.Fprintf(w, "  %s (package %s, channel %s, catalog %s)\n", op.Name, op.Package, op.Channel, op.Catalog)
+	}
+	if r.Report == nil {
+		return
+	}
+	var notSelected []resolver.ResolutionCandidate
+	for _, c := range r.Report.Candidates {
+		if !c.Selected && c.Reason != "" {
+			notSelected = append(notSelected, c)
+		}
+	}
+	if len(notSelected) > 0 {
+		fmt.Fprintln(w, "candidates not selected:")
+		for _, c := range notSelected {
+			fmt.Fprintf(w, "  %s: %s\n", c.Identifier, c.Reason)
+		}
+	}
+}
@@ -0,0 +1,406 @@
+// Package reasons enumerates the condition reasons OLM sets on the resources it manages.
+//
+// Clients that match on condition reasons should use these constants instead of string literals;
+// the values are part of OLM's API and are pinned by tests so they don't drift between releases.
+package reasons
+
+import (
+	"sort"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+)
+
+// Reason is a CamelCase reason for a condition transition.
+type Reason string
+
+// Kind identifies the resource a set of reasons is reported on.
+type Kind string
+
+const (
+	KindClusterServiceVersion Kind = v1alpha1.ClusterServiceVersionKind
+	KindSubscription          Kind = v1alpha1.SubscriptionKind
+	KindInstallPlan           Kind = v1alpha1.InstallPlanKind
+	KindCatalogSource         Kind = v1alpha1.CatalogSourceKind
+	KindOLMConfig             Kind = "OLMConfig"
+	KindOperatorInstall       Kind = "OperatorInstall"
+	KindOperatorUninstall     Kind = "OperatorUninstall"
+)
+
+// ClusterServiceVersion reasons.
+const (
+	CSVRequirementsUnknown                         = Reason(v1alpha1.CSVReasonRequirementsUnknown)
+	CSVRequirementsNotMet                          = Reason(v1alpha1.CSVReasonRequirementsNotMet)
+	CSVRequirementsMet                             = Reason(v1alpha1.CSVReasonRequirementsMet)
+	CSVOwnerConflict                               = Reason(v1alpha1.CSVReasonOwnerConflict)
+	CSVComponentFailed                             = Reason(v1alpha1.CSVReasonComponentFailed)
+	CSVComponentFailedNoRetry                      = Reason(v1alpha1.CSVReasonComponentFailedNoRetry)
+	CSVInvalidStrategy                             = Reason(v1alpha1.CSVReasonInvalidStrategy)
+	CSVWaiting                                     = Reason(v1alpha1.CSVReasonWaiting)
+	CSVInstallSuccessful                           = Reason(v1alpha1.CSVReasonInstallSuccessful)
+	CSVInstallCheckFailed                          = Reason(v1alpha1.CSVReasonInstallCheckFailed)
+	CSVComponentUnhealthy                          = Reason(v1alpha1.CSVReasonComponentUnhealthy)
+	CSVBeingReplaced                               = Reason(v1alpha1.CSVReasonBeingReplaced)
+	CSVReplaced                                    = Reason(v1alpha1.CSVReasonReplaced)
+	CSVNeedsReinstall                              = Reason(v1alpha1.CSVReasonNeedsReinstall)
+	CSVNeedsCertRotation                           = Reason(v1alpha1.CSVReasonNeedsCertRotation)
+	CSVAPIServiceResourceIssue                     = Reason(v1alpha1.CSVReasonAPIServiceResourceIssue)
+	CSVAPIServiceResourcesNeedReinstall            = Reason(v1alpha1.CSVReasonAPIServiceResourcesNeedReinstall)
+	CSVAPIServiceInstallFailed                     = Reason(v1alpha1.CSVReasonAPIServiceInstallFailed)
+	CSVCopied                                      = Reason(v1alpha1.CSVReasonCopied)
+	CSVInvalidInstallModes                         = Reason(v1alpha1.CSVReasonInvalidInstallModes)
+	CSVNoTargetNamespaces                          = Reason(v1alpha1.CSVReasonNoTargetNamespaces)
+	CSVUnsupportedOperatorGroup                    = Reason(v1alpha1.CSVReasonUnsupportedOperatorGroup)
+	CSVNoOperatorGroup                             = Reason(v1alpha1.CSVReasonNoOperatorGroup)
+	CSVTooManyOperatorGroups                       = Reason(v1alpha1.CSVReasonTooManyOperatorGroups)
+	CSVInterOperatorGroupOwnerConflict             = Reason(v1alpha1.CSVReasonInterOperatorGroupOwnerConflict)
+	CSVCannotModifyStaticOperatorGroupProvidedAPIs = Reason(v1alpha1.CSVReasonCannotModifyStaticOperatorGroupProvidedAPIs)
+	CSVDetectedClusterChange                       = Reason(v1alpha1.CSVReasonDetectedClusterChange)
+	CSVInvalidWebhookDescription                   = Reason(v1alpha1.CSVReasonInvalidWebhookDescription)
+	CSVOperatorConditionNotUpgradeable             = Reason(v1alpha1.CSVReasonOperatorConditionNotUpgradeable)
+	CSVWaitingForCleanupToComplete                 = Reason(v1alpha1.CSVReasonWaitingForCleanupToComplete)
+
+	// CSVInvalidAdmissionPolicyDescription is set when a CSV declares malformed or disallowed ValidatingAdmissionPolicies.
+	CSVInvalidAdmissionPolicyDescription Reason = "InvalidAdmissionPolicyDescription"
+
+	// CSVInvalidCertLifetime is set when a CSV overrides the lifetime of its generated certs with invalid values.
+	CSVInvalidCertLifetime Reason = "InvalidCertLifetime"
+
+	// CSVInvalidCertManagerIssuer is set when a CSV requests its serving certs from a malformed cert-manager issuer reference.
+	CSVInvalidCertManagerIssuer Reason = "InvalidCertManagerIssuer"
+
+	// CSVInvalidKubeconfigDescription is set when a CSV requests malformed kubeconfigs, or kubeconfigs granting more than its own permissions.
+	CSVInvalidKubeconfigDescription Reason = "InvalidKubeconfigDescription"
+
+	// CSVInvalidSelfManagedCABundle is set when a CSV managing its own serving certs provides a malformed CA bundle.
+	CSVInvalidSelfManagedCABundle Reason = "InvalidSelfManagedCABundle"
+
+	// CSVReplacedCSVNotFound is set when the CSV a CSV replaces doesn't exist in its namespace.
+	CSVReplacedCSVNotFound Reason = "ReplacedCSVNotFound"
+
+	// CSVUpgradeDisruptionPending is set when a CSV replacing another waits for the operator it replaces to be safely
+	// disrupted.
+	CSVUpgradeDisruptionPending Reason = "UpgradeDisruptionPending"
+
+	// CSVCopyFailed is set when a CSV can't be copied to some of its target namespaces, e.g. because of a quota or an
+	// admission denial.
+	CSVCopyFailed Reason = "CopyFailed"
+
+	// CSVMultiInstanceConflict is set when a CSV shares CRDs with a CSV installed in another namespace, and the two
+	// installs can't coexist, e.g. because the shared CRD no longer serves a version the other install needs.
+	CSVMultiInstanceConflict Reason = "MultiInstanceConflict"
+)
+
+// Subscription reasons.
+const (
+	SubscriptionInvalidCatalog                = Reason(v1alpha1.SubscriptionReasonInvalidCatalog)
+	SubscriptionUpgradeSucceeded              = Reason(v1alpha1.SubscriptionReasonUpgradeSucceeded)
+	SubscriptionNoCatalogSourcesFound         = Reason(v1alpha1.NoCatalogSourcesFound)
+	SubscriptionAllCatalogSourcesHealthy      = Reason(v1alpha1.AllCatalogSourcesHealthy)
+	SubscriptionCatalogSourcesAdded           = Reason(v1alpha1.CatalogSourcesAdded)
+	SubscriptionCatalogSourcesUpdated         = Reason(v1alpha1.CatalogSourcesUpdated)
+	SubscriptionCatalogSourcesDeleted         = Reason(v1alpha1.CatalogSourcesDeleted)
+	SubscriptionUnhealthyCatalogSourceFound   = Reason(v1alpha1.UnhealthyCatalogSourceFound)
+	SubscriptionReferencedInstallPlanNotFound = Reason(v1alpha1.ReferencedInstallPlanNotFound)
+	SubscriptionInstallPlanNotYetReconciled   = Reason(v1alpha1.InstallPlanNotYetReconciled)
+	SubscriptionInstallPlanFailed             = Reason(v1alpha1.InstallPlanFailed)
+
+	// SubscriptionConstraintsNotSatisfiable is set on the ResolutionFailed condition when the resolver
+	// determines that no set of bundles satisfies the namespace's constraints.
+	SubscriptionConstraintsNotSatisfiable Reason = "ConstraintsNotSatisfiable"
+
+	// SubscriptionErrorPreventedResolution is set on the ResolutionFailed condition when resolution
+	// could not be attempted, e.g. because a catalog could not be queried.
+	SubscriptionErrorPreventedResolution Reason = "ErrorPreventedResolution"
+
+	// SubscriptionNewerVersionAvailable is set on the VersionPinned condition when the head of the subscribed channel
+	// is newer than the version the Subscription is pinned to.
+	SubscriptionNewerVersionAvailable Reason = "NewerVersionAvailable"
+
+	// SubscriptionUpgradeDeadlineExceeded is set on the RolledBack condition when a CSV the Subscription upgraded to
+	// didn't reach the Succeeded phase within the rollback deadline of the Subscription.
+	SubscriptionUpgradeDeadlineExceeded Reason = "UpgradeDeadlineExceeded"
+
+	// SubscriptionWaitingForSubscriptions is set on the TransactionPending condition while some of the Subscriptions
+	// of the transaction of the Subscription don't exist yet.
+	SubscriptionWaitingForSubscriptions Reason = "WaitingForSubscriptions"
+
+	// SubscriptionTransactionFailed is set on the TransactionRolledBack condition when the InstallPlan or one of the
+	// CSVs of the transaction of the Subscription failed.
+	SubscriptionTransactionFailed Reason = "TransactionFailed"
+
+	// SubscriptionBundleDeprecated is set on the Deprecated condition when the catalog of the Subscription deprecates
+	// the package, the channel or the bundle it installs.
+	SubscriptionBundleDeprecated Reason = "BundleDeprecated"
+)
+
+// InstallPlan reasons.
+const (
+	InstallPlanPlanUnknown        = Reason(v1alpha1.InstallPlanReasonPlanUnknown)
+	InstallPlanInstallCheckFailed = Reason(v1alpha1.InstallPlanReasonInstallCheckFailed)
+	InstallPlanDependencyConflict = Reason(v1alpha1.InstallPlanReasonDependencyConflict)
+	InstallPlanComponentFailed    = Reason(v1alpha1.InstallPlanReasonComponentFailed)
+
+	// Reasons set on an InstallPlan's BundleLookup conditions while bundle content is unpacked.
+	InstallPlanCatalogSourceMissing Reason = "CatalogSourceMissing"
+	InstallPlanJobFailed            Reason = "JobFailed"
+	InstallPlanJobIncomplete        Reason = "JobIncomplete"
+	InstallPlanJobNotStarted        Reason = "JobNotStarted"
+	InstallPlanBundleNotUnpacked    Reason = "BundleNotUnpacked"
+
+	// Reasons set on an InstallPlan's BundleLookup conditions while the unpack job of a bundle is held back, because
+	// too many unpack jobs are in flight or the registry of the bundle image is backed off from after throttling pulls.
+	InstallPlanUnpackThrottled Reason = "UnpackThrottled"
+	InstallPlanRegistryBackoff Reason = "RegistryBackoff"
+
+	// InstallPlanSignatureVerificationFailed is set on an InstallPlan's BundleLookup and Installed conditions when a
+	// bundle image doesn't satisfy the image signature policy of the cluster OLMConfig.
+	InstallPlanSignatureVerificationFailed Reason = "SignatureVerificationFailed"
+
+	// Reasons set on an InstallPlan's Installed condition when the unpack job of one of its bundles fails, by running
+	// longer than its timeout, failing more than its retries or running out of memory, or can't be configured because
+	// the annotations of its CatalogSource are invalid.
+	InstallPlanBundleUnpackTimedOut         Reason = "BundleUnpackTimedOut"
+	InstallPlanBundleUnpackRetriesExhausted Reason = "BundleUnpackRetriesExhausted"
+	InstallPlanBundleUnpackOOMKilled        Reason = "BundleUnpackOOMKilled"
+	InstallPlanInvalidUnpackJobConfig       Reason = "InvalidUnpackJobConfig"
+
+	// Reasons set on an InstallPlan's WebhookConflicts condition, which reports webhooks of the CSVs it installs whose
+	// rules overlap those of webhooks other operators already installed.
+	InstallPlanConflictingWebhooks   Reason = "ConflictingWebhooks"
+	InstallPlanNoConflictingWebhooks Reason = "NoConflictingWebhooks"
+
+	// Reasons set on an InstallPlan's DryRun condition, which reports the outcome of a dry-run of its steps.
+	InstallPlanDryRunSucceeded Reason = "DryRunSucceeded"
+	InstallPlanDryRunFailed    Reason = "DryRunFailed"
+
+	// InstallPlanCRDNotEstablished is set when a CRD an InstallPlan applies isn't established, or not served by
+	// discovery, within the CRD establishment timeout.
+	InstallPlanCRDNotEstablished Reason = "CRDNotEstablished"
+
+	// Reasons set on an InstallPlan's CRDSchemaIncompatible condition, which reports changes of the CRDs it updates
+	// that existing custom resources or the apiserver would reject.
+	InstallPlanIncompatibleCRDSchema Reason = "IncompatibleCRDSchema"
+	InstallPlanCompatibleCRDSchema   Reason = "CompatibleCRDSchema"
+
+	// Reasons set on an InstallPlan's StorageVersionMigration condition, which reports the progress of the migrations
+	// of custom resources to the storage versions of the CRDs it updates.
+	InstallPlanStorageVersionMigrated        Reason = "StorageVersionMigrated"
+	InstallPlanStorageVersionMigrationFailed Reason = "StorageVersionMigrationFailed"
+
+	// Reasons set on an InstallPlan's BundleValidation condition, which reports the results of the validators run
+	// against the bundles it installs.
+	InstallPlanBundleValidationPassed Reason = "BundleValidationPassed"
+	InstallPlanBundleValidationFailed Reason = "BundleValidationFailed"
+)
+
+// CatalogSource reasons.
+const (
+	CatalogSourceSpecInvalidError    = Reason(v1alpha1.CatalogSourceSpecInvalidError)
+	CatalogSourceConfigMapError      = Reason(v1alpha1.CatalogSourceConfigMapError)
+	CatalogSourceRegistryServerError = Reason(v1alpha1.CatalogSourceRegistryServerError)
+
+	// CatalogSourceSignatureVerificationFailed is set when the catalog image doesn't satisfy the image signature policy
+	// of the cluster OLMConfig.
+	CatalogSourceSignatureVerificationFailed Reason = "SignatureVerificationFailed"
+)
+
+// OLMConfig reasons.
+const (
+	OLMConfigCopiedCSVsEnabled Reason = "CopiedCSVsEnabled"
+	OLMConfigCopiedCSVsFound   Reason = "CopiedCSVsFound"
+	OLMConfigNoCopiedCSVsFound Reason = "NoCopiedCSVsFound"
+
+	OLMConfigControllersDisabled   Reason = "ControllersDisabled"
+	OLMConfigAllControllersEnabled Reason = "AllControllersEnabled"
+)
+
+// OperatorInstall reasons, set on the Installed and OperatorGroupMismatch conditions of OperatorInstalls.
+const (
+	// OperatorInstallInvalidSpec is set when the spec of an OperatorInstall is missing a package or source, or sets
+	// both allNamespaces and targetNamespaces.
+	OperatorInstallInvalidSpec Reason = "InvalidSpec"
+	// OperatorInstallTooManyOperatorGroups is set when the namespace of an OperatorInstall has several OperatorGroups.
+	OperatorInstallTooManyOperatorGroups Reason = "TooManyOperatorGroups"
+	// OperatorInstallSubscriptionConflict is set when a Subscription named after an OperatorInstall isn't owned by it.
+	OperatorInstallSubscriptionConflict Reason = "SubscriptionConflict"
+	// OperatorInstallOperatorGroupMismatch is set when the OperatorGroup of the namespace of an OperatorInstall isn't
+	// owned by it and targets other namespaces than its spec.
+	OperatorInstallOperatorGroupMismatch Reason = "OperatorGroupMismatch"
+
+	// Reasons projected from the Subscription and installed CSV of an OperatorInstall.
+	OperatorInstallResolutionFailed  Reason = "ResolutionFailed"
+	OperatorInstallRequiresApproval  Reason = "RequiresApproval"
+	OperatorInstallInstallPlanFailed Reason = "InstallPlanFailed"
+	OperatorInstallInstalling        Reason = "Installing"
+	OperatorInstallInstallFailed     Reason = "InstallFailed"
+	OperatorInstallInstallSucceeded  Reason = "InstallSucceeded"
+)
+
+// OperatorUninstall reasons, set on the Uninstalled condition of OperatorUninstalls.
+const (
+	// OperatorUninstallInvalidSpec is set when the spec of an OperatorUninstall is missing a subscription or sets an
+	// unknown cascade.
+	OperatorUninstallInvalidSpec Reason = "InvalidSpec"
+	// OperatorUninstallSubscriptionNotFound is set when the Subscription of an OperatorUninstall that hasn't started
+	// doesn't exist.
+	OperatorUninstallSubscriptionNotFound Reason = "SubscriptionNotFound"
+
+	// Reasons reporting the step an OperatorUninstall is waiting on.
+	OperatorUninstallRemovingOperands Reason = "RemovingOperands"
+	OperatorUninstallRemovingOperator Reason = "RemovingOperator"
+	OperatorUninstallRemovingCRDs     Reason = "RemovingCRDs"
+
+	// OperatorUninstallSucceeded is set once everything the cascade of an OperatorUninstall removes is gone.
+	OperatorUninstallSucceeded Reason = "UninstallSucceeded"
+)
+
+var registry = map[Kind][]Reason{
+	KindClusterServiceVersion: {
+		CSVRequirementsUnknown,
+		CSVRequirementsNotMet,
+		CSVRequirementsMet,
+		CSVOwnerConflict,
+		CSVComponentFailed,
+		CSVComponentFailedNoRetry,
+		CSVInvalidStrategy,
+		CSVWaiting,
+		CSVInstallSuccessful,
+		CSVInstallCheckFailed,
+		CSVComponentUnhealthy,
+		CSVBeingReplaced,
+		CSVReplaced,
+		CSVNeedsReinstall,
+		CSVNeedsCertRotation,
+		CSVAPIServiceResourceIssue,
+		CSVAPIServiceResourcesNeedReinstall,
+		CSVAPIServiceInstallFailed,
+		CSVCopied,
+		CSVInvalidInstallModes,
+		CSVNoTargetNamespaces,
+		CSVUnsupportedOperatorGroup,
+		CSVNoOperatorGroup,
+		CSVTooManyOperatorGroups,
+		CSVInterOperatorGroupOwnerConflict,
+		CSVCannotModifyStaticOperatorGroupProvidedAPIs,
+		CSVDetectedClusterChange,
+		CSVInvalidWebhookDescription,
+		CSVOperatorConditionNotUpgradeable,
+		CSVWaitingForCleanupToComplete,
+		CSVInvalidAdmissionPolicyDescription,
+		CSVInvalidCertLifetime,
+		CSVInvalidCertManagerIssuer,
+		CSVInvalidKubeconfigDescription,
+		CSVInvalidSelfManagedCABundle,
+		CSVReplacedCSVNotFound,
+		CSVUpgradeDisruptionPending,
+		CSVCopyFailed,
+		CSVMultiInstanceConflict,
+	},
+	KindSubscription: {
+		SubscriptionInvalidCatalog,
+		SubscriptionUpgradeSucceeded,
+		SubscriptionNoCatalogSourcesFound,
+		SubscriptionAllCatalogSourcesHealthy,
+		SubscriptionCatalogSourcesAdded,
+		SubscriptionCatalogSourcesUpdated,
+		SubscriptionCatalogSourcesDeleted,
+		SubscriptionUnhealthyCatalogSourceFound,
+		SubscriptionReferencedInstallPlanNotFound,
+		SubscriptionInstallPlanNotYetReconciled,
+		SubscriptionInstallPlanFailed,
+		SubscriptionConstraintsNotSatisfiable,
+		SubscriptionErrorPreventedResolution,
+		SubscriptionNewerVersionAvailable,
+		SubscriptionUpgradeDeadlineExceeded,
+		SubscriptionWaitingForSubscriptions,
+		SubscriptionTransactionFailed,
+		SubscriptionBundleDeprecated,
+	},
+	KindInstallPlan: {
+		InstallPlanPlanUnknown,
+		InstallPlanInstallCheckFailed,
+		InstallPlanDependencyConflict,
+		InstallPlanComponentFailed,
+		InstallPlanCatalogSourceMissing,
+		InstallPlanJobFailed,
+		InstallPlanJobIncomplete,
+		InstallPlanJobNotStarted,
+		InstallPlanBundleNotUnpacked,
+		InstallPlanUnpackThrottled,
+		InstallPlanRegistryBackoff,
+		InstallPlanSignatureVerificationFailed,
+		InstallPlanBundleUnpackTimedOut,
+		InstallPlanBundleUnpackRetriesExhausted,
+		InstallPlanBundleUnpackOOMKilled,
+		InstallPlanInvalidUnpackJobConfig,
+		InstallPlanConflictingWebhooks,
+		InstallPlanNoConflictingWebhooks,
+		InstallPlanDryRunSucceeded,
+		InstallPlanDryRunFailed,
+		InstallPlanCRDNotEstablished,
+		InstallPlanIncompatibleCRDSchema,
+		InstallPlanCompatibleCRDSchema,
+		InstallPlanStorageVersionMigrated,
+		InstallPlanStorageVersionMigrationFailed,
+		InstallPlanBundleValidationPassed,
+		InstallPlanBundleValidationFailed,
+	},
+	KindCatalogSource: {
+		CatalogSourceSpecInvalidError,
+		CatalogSourceConfigMapError,
+		CatalogSourceRegistryServerError,
+		CatalogSourceSignatureVerificationFailed,
+	},
+	KindOLMConfig: {
+		OLMConfigCopiedCSVsEnabled,
+		OLMConfigCopiedCSVsFound,
+		OLMConfigNoCopiedCSVsFound,
+		OLMConfigControllersDisabled,
+		OLMConfigAllControllersEnabled,
+	},
+	KindOperatorInstall: {
+		OperatorInstallInvalidSpec,
+		OperatorInstallTooManyOperatorGroups,
+		OperatorInstallSubscriptionConflict,
+		OperatorInstallOperatorGroupMismatch,
+		OperatorInstallResolutionFailed,
+		OperatorInstallRequiresApproval,
+		OperatorInstallInstallPlanFailed,
+		OperatorInstallInstalling,
+		OperatorInstallInstallFailed,
+		OperatorInstallInstallSucceeded,
+	},
+	KindOperatorUninstall: {
+		OperatorUninstallInvalidSpec,
+		OperatorUninstallSubscriptionNotFound,
+		OperatorUninstallRemovingOperands,
+		OperatorUninstallRemovingOperator,
+		OperatorUninstallRemovingCRDs,
+		OperatorUninstallSucceeded,
+	},
+}
+
+// Kinds returns the kinds of resources that have registered reasons, in lexical order.
+func Kinds() []Kind {
+	kinds := make([]Kind, 0, len(registry))
+	for kind := range registry {
+		kinds = append(kinds, kind)
+	}
+	sort.Slice(kinds, func(i, j int) bool { return kinds[i] < kinds[j] })
+	return kinds
+}
+
+// For returns the reasons OLM may set on conditions of the given kind.
+func For(kind Kind) []Reason {
+	return append([]Reason(nil), registry[kind]...)
+}
+
+// Known returns true if the given reason is registered for the given kind.
+func Known(kind Kind, reason string) bool {
+	for _, r := range registry[kind] {
+		if string(r) == reason {
+			return true
+		}
+	}
+	return false
+}
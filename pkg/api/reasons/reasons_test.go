@@ -0,0 +1,176 @@
+package reasons
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// pinned holds the wire value of every registered reason. Changing a value here is a breaking change
+// for clients that match on condition reasons and must be called out in the release notes.
+var pinned = map[Kind][]string{
+	KindClusterServiceVersion: {
+		"RequirementsUnknown",
+		"RequirementsNotMet",
+		"AllRequirementsMet",
+		"OwnerConflict",
+		"InstallComponentFailed",
+		"InstallComponentFailedNoRetry",
+		"InvalidInstallStrategy",
+		"InstallWaiting",
+		"InstallSucceeded",
+		"InstallCheckFailed",
+		"ComponentUnhealthy",
+		"BeingReplaced",
+		"Replaced",
+		"NeedsReinstall",
+		"NeedsCertRotation",
+		"APIServiceResourceIssue",
+		"APIServiceResourcesNeedReinstall",
+		"APIServiceInstallFailed",
+		"Copied",
+		"InvalidInstallModes",
+		"NoTargetNamespaces",
+		"UnsupportedOperatorGroup",
+		"NoOperatorGroup",
+		"TooManyOperatorGroups",
+		"InterOperatorGroupOwnerConflict",
+		"CannotModifyStaticOperatorGroupProvidedAPIs",
+		"DetectedClusterChange",
+		"InvalidWebhookDescription",
+		"OperatorConditionNotUpgradeable",
+		"WaitingOnCleanup",
+		"InvalidAdmissionPolicyDescription",
+		"InvalidCertLifetime",
+		"InvalidCertManagerIssuer",
+		"InvalidKubeconfigDescription",
+		"InvalidSelfManagedCABundle",
+		"ReplacedCSVNotFound",
+		"UpgradeDisruptionPending",
+		"CopyFailed",
+		"MultiInstanceConflict",
+	},
+	KindSubscription: {
+		"InvalidCatalog",
+		"UpgradeSucceeded",
+		"NoCatalogSourcesFound",
+		"AllCatalogSourcesHealthy",
+		"CatalogSourcesAdded",
+		"CatalogSourcesUpdated",
+		"CatalogSourcesDeleted",
+		"UnhealthyCatalogSourceFound",
+		"ReferencedInstallPlanNotFound",
+		"InstallPlanNotYetReconciled",
+		"InstallPlanFailed",
+		"ConstraintsNotSatisfiable",
+		"ErrorPreventedResolution",
+		"NewerVersionAvailable",
+		"UpgradeDeadlineExceeded",
+		"WaitingForSubscriptions",
+		"TransactionFailed",
+		"BundleDeprecated",
+	},
+	KindInstallPlan: {
+		"PlanUnknown",
+		"InstallCheckFailed",
+		"DependenciesConflict",
+		"InstallComponentFailed",
+		"CatalogSourceMissing",
+		"JobFailed",
+		"JobIncomplete",
+		"JobNotStarted",
+		"BundleNotUnpacked",
+		"UnpackThrottled",
+		"RegistryBackoff",
+		"SignatureVerificationFailed",
+		"BundleUnpackTimedOut",
+		"BundleUnpackRetriesExhausted",
+		"BundleUnpackOOMKilled",
+		"InvalidUnpackJobConfig",
+		"ConflictingWebhooks",
+		"NoConflictingWebhooks",
+		"DryRunSucceeded",
+		"DryRunFailed",
+		"CRDNotEstablished",
+		"IncompatibleCRDSchema",
+		"CompatibleCRDSchema",
+		"StorageVersionMigrated",
+		"StorageVersionMigrationFailed",
+		"BundleValidationPassed",
+		"BundleValidationFailed",
+	},
+	KindCatalogSource: {
+		"SpecInvalidError",
+		"ConfigMapError",
+		"RegistryServerError",
+		"SignatureVerificationFailed",
+	},
+	KindOLMConfig: {
+		"CopiedCSVsEnabled",
+		"CopiedCSVsFound",
+		"NoCopiedCSVsFound",
+		"ControllersDisabled",
+		"AllControllersEnabled",
+	},
+	KindOperatorInstall: {
+		"InvalidSpec",
+		"TooManyOperatorGroups",
+		"SubscriptionConflict",
+		"OperatorGroupMismatch",
+		"ResolutionFailed",
+		"RequiresApproval",
+		"InstallPlanFailed",
+		"Installing",
+		"InstallFailed",
+		"InstallSucceeded",
+	},
+	KindOperatorUninstall: {
+		"InvalidSpec",
+		"SubscriptionNotFound",
+		"RemovingOperands",
+		"RemovingOperator",
+		"RemovingCRDs",
+		"UninstallSucceeded",
+	},
+}
+
+var camelCase = regexp.MustCompile(`^[A-Z][A-Za-z0-9]*$`)
+
+func TestReasonsArePinned(t *testing.T) {
+	require.ElementsMatch(t, Kinds(), keys(pinned), "every kind with reasons must be pinned")
+
+	for _, kind := range Kinds() {
+		var got []string
+		for _, reason := range For(kind) {
+			got = append(got, string(reason))
+		}
+		require.Equal(t, pinned[kind], got, "reasons for %s drifted", kind)
+	}
+}
+
+func TestReasonsAreWellFormed(t *testing.T) {
+	for _, kind := range Kinds() {
+		seen := map[Reason]struct{}{}
+		for _, reason := range For(kind) {
+			require.Regexp(t, camelCase, string(reason), "%s reason is not CamelCase", kind)
+			_, dup := seen[reason]
+			require.False(t, dup, "duplicate %s reason %q", kind, reason)
+			seen[reason] = struct{}{}
+		}
+	}
+}
+
+func TestKnown(t *testing.T) {
+	require.True(t, Known(KindSubscription, "ConstraintsNotSatisfiable"))
+	require.False(t, Known(KindCatalogSource, "ConstraintsNotSatisfiable"))
+	require.False(t, Known(Kind("Unknown"), "Copied"))
+}
+
+func keys(m map[Kind][]string) []Kind {
+	var out []Kind
+	for k := range m {
+		out = append(out, k)
+	}
+	return out
+}
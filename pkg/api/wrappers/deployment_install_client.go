@@ -20,12 +20,12 @@ import (
 var ErrNilObject = errors.New("Bad object supplied: <nil>")
 
 type InstallStrategyDeploymentInterface interface {
-	CreateRole(role *rbacv1.Role) (*rbacv1.Role, error)
-	CreateRoleBinding(roleBinding *rbacv1.RoleBinding) (*rbacv1.RoleBinding, error)
-	EnsureServiceAccount(serviceAccount *corev1.ServiceAccount, owner ownerutil.Owner) (*corev1.ServiceAccount, error)
-	CreateDeployment(deployment *appsv1.Deployment) (*appsv1.Deployment, error)
-	CreateOrUpdateDeployment(deployment *appsv1.Deployment) (*appsv1.Deployment, error)
-	DeleteDeployment(name string) error
+	CreateRole(ctx context.Context, role *rbacv1.Role) (*rbacv1.Role, error)
+	CreateRoleBinding(ctx context.Context, roleBinding *rbacv1.RoleBinding) (*rbacv1.RoleBinding, error)
+	EnsureServiceAccount(ctx context.Context, serviceAccount *corev1.ServiceAccount, owner ownerutil.Owner) (*corev1.ServiceAccount, error)
+	CreateDeployment(ctx context.Context, deployment *appsv1.Deployment) (*appsv1.Deployment, error)
+	CreateOrUpdateDeployment(ctx context.Context, deployment *appsv1.Deployment) (*appsv1.Deployment, error)
+	DeleteDeployment(ctx context.Context, name string) error
 	GetServiceAccountByName(serviceAccountName string) (*corev1.ServiceAccount, error)
 	FindAnyDeploymentsMatchingNames(depNames []string) ([]*appsv1.Deployment, error)
 	FindAnyDeploymentsMatchingLabels(label labels.Selector) ([]*appsv1.Deployment, error)
@@ -57,15 +57,15 @@ func (c *InstallStrategyDeploymentClientForNamespace) GetOpLister() operatorlist
 	return c.opLister
 }
 
-func (c *InstallStrategyDeploymentClientForNamespace) CreateRole(role *rbacv1.Role) (*rbacv1.Role, error) {
-	return c.opClient.KubernetesInterface().RbacV1().Roles(c.Namespace).Create(context.TODO(), role, metav1.CreateOptions{})
+func (c *InstallStrategyDeploymentClientForNamespace) CreateRole(ctx context.Context, role *rbacv1.Role) (*rbacv1.Role, error) {
+	return c.opClient.KubernetesInterface().RbacV1().Roles(c.Namespace).Create(ctx, role, metav1.CreateOptions{})
 }
 
-func (c *InstallStrategyDeploymentClientForNamespace) CreateRoleBinding(roleBinding *rbacv1.RoleBinding) (*rbacv1.RoleBinding, error) {
-	return c.opClient.KubernetesInterface().RbacV1().RoleBindings(c.Namespace).Create(context.TODO(), roleBinding, metav1.CreateOptions{})
+func (c *InstallStrategyDeploymentClientForNamespace) CreateRoleBinding(ctx context.Context, roleBinding *rbacv1.RoleBinding) (*rbacv1.RoleBinding, error) {
+	return c.opClient.KubernetesInterface().RbacV1().RoleBindings(c.Namespace).Create(ctx, roleBinding, metav1.CreateOptions{})
 }
 
-func (c *InstallStrategyDeploymentClientForNamespace) EnsureServiceAccount(serviceAccount *corev1.ServiceAccount, owner ownerutil.Owner) (*corev1.ServiceAccount, error) {
+func (c *InstallStrategyDeploymentClientForNamespace) EnsureServiceAccount(ctx context.Context, serviceAccount *corev1.ServiceAccount, owner ownerutil.Owner) (*corev1.ServiceAccount, error) {
 	if serviceAccount == nil {
 		return nil, ErrNilObject
 	}
@@ -78,7 +78,7 @@ func (c *InstallStrategyDeploymentClientForNamespace) EnsureServiceAccount(servi
 	// create if not found
 	if err != nil && apierrors.IsNotFound(err) {
 		serviceAccount.SetNamespace(c.Namespace)
-		createdAccount, err := c.opClient.CreateServiceAccount(serviceAccount)
+		createdAccount, err := c.opClient.CreateServiceAccount(ctx, serviceAccount)
 		if err != nil && !apierrors.IsAlreadyExists(err) {
 			return nil, errors.Wrap(err, "creating serviceacccount failed")
 		}
@@ -94,34 +94,34 @@ func (c *InstallStrategyDeploymentClientForNamespace) EnsureServiceAccount(servi
 	}
 	// set owner if missing
 	ownerutil.AddNonBlockingOwner(foundAccount, owner)
-	return c.opClient.UpdateServiceAccount(foundAccount)
+	return c.opClient.UpdateServiceAccount(ctx, foundAccount)
 }
 
-func (c *InstallStrategyDeploymentClientForNamespace) CreateDeployment(deployment *appsv1.Deployment) (*appsv1.Deployment, error) {
-	return c.opClient.CreateDeployment(deployment)
+func (c *InstallStrategyDeploymentClientForNamespace) CreateDeployment(ctx context.Context, deployment *appsv1.Deployment) (*appsv1.Deployment, error) {
+	return c.opClient.CreateDeployment(ctx, deployment)
 }
 
-func (c *InstallStrategyDeploymentClientForNamespace) DeleteDeployment(name string) error {
+func (c *InstallStrategyDeploymentClientForNamespace) DeleteDeployment(ctx context.Context, name string) error {
 	foregroundDelete := metav1.DeletePropagationForeground // cascading delete
 	// Note(tflannag): See https://bugzilla.redhat.com/show_bug.cgi?id=1939294.
 	immediate := int64(1)
 	immediateForegroundDelete := &metav1.DeleteOptions{GracePeriodSeconds: &immediate, PropagationPolicy: &foregroundDelete}
-	return c.opClient.DeleteDeployment(c.Namespace, name, immediateForegroundDelete)
+	return c.opClient.DeleteDeployment(ctx, c.Namespace, name, immediateForegroundDelete)
 }
 
-func (c *InstallStrategyDeploymentClientForNamespace) CreateOrUpdateDeployment(deployment *appsv1.Deployment) (*appsv1.Deployment, error) {
-	_, err := c.opClient.GetDeployment(deployment.Namespace, deployment.Name)
+func (c *InstallStrategyDeploymentClientForNamespace) CreateOrUpdateDeployment(ctx context.Context, deployment *appsv1.Deployment) (*appsv1.Deployment, error) {
+	_, err := c.opClient.GetDeployment(ctx, deployment.Namespace, deployment.Name)
 	if err != nil {
 		if !apierrors.IsNotFound(err) {
 			return nil, err
 		}
-		created, err := c.CreateDeployment(deployment)
+		created, err := c.CreateDeployment(ctx, deployment)
 		if err != nil {
 			return nil, err
 		}
 		return created, err
 	}
-	return c.opClient.KubernetesInterface().AppsV1().Deployments(deployment.GetNamespace()).Update(context.TODO(), deployment, metav1.UpdateOptions{})
+	return c.opClient.KubernetesInterface().AppsV1().Deployments(deployment.GetNamespace()).Update(ctx, deployment, metav1.UpdateOptions{})
 }
 
 func (c *InstallStrategyDeploymentClientForNamespace) GetServiceAccountByName(serviceAccountName string) (*corev1.ServiceAccount, error) {
@@ -1,6 +1,7 @@
 package wrappers
 
 import (
+	"context"
 	"fmt"
 	"testing"
 	"time"
@@ -428,16 +429,16 @@ func TestEnsureServiceAccount(t *testing.T) {
 			client := NewInstallStrategyDeploymentClient(mockOpClient, fakeLister, tt.state.namespace)
 
 			mockOpClient.EXPECT().
-				CreateServiceAccount(tt.input.serviceAccount).
+				CreateServiceAccount(gomock.Any(), tt.input.serviceAccount).
 				Return(tt.state.createServiceAccountResult, tt.state.createServiceAccountError).
 				AnyTimes()
 
 			mockOpClient.EXPECT().
-				UpdateServiceAccount(tt.input.serviceAccountToUpdate).
+				UpdateServiceAccount(gomock.Any(), tt.input.serviceAccountToUpdate).
 				Return(tt.state.updateServiceAccountResult, tt.state.updateServiceAccountError).
 				AnyTimes()
 
-			sa, err := client.EnsureServiceAccount(tt.input.serviceAccount, &mockOwner)
+			sa, err := client.EnsureServiceAccount(context.TODO(), tt.input.serviceAccount, &mockOwner)
 
 			require.True(t, equality.Semantic.DeepEqual(tt.expect.returnedServiceAccount, sa),
 				"Resources do not match <expected, actual>: %s",
@@ -2,6 +2,7 @@
 package wrappersfakes
 
 import (
+	"context"
 	"sync"
 
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/api/wrappers"
@@ -15,10 +16,11 @@ import (
 )
 
 type FakeInstallStrategyDeploymentInterface struct {
-	CreateDeploymentStub        func(*v1.Deployment) (*v1.Deployment, error)
+	CreateDeploymentStub        func(context.Context, *v1.Deployment) (*v1.Deployment, error)
 	createDeploymentMutex       sync.RWMutex
 	createDeploymentArgsForCall []struct {
-		arg1 *v1.Deployment
+		arg1 context.Context
+		arg2 *v1.Deployment
 	}
 	createDeploymentReturns struct {
 		result1 *v1.Deployment
@@ -28,10 +30,11 @@ type FakeInstallStrategyDeploymentInterface struct {
 		result1 *v1.Deployment
 		result2 error
 	}
-	CreateOrUpdateDeploymentStub        func(*v1.Deployment) (*v1.Deployment, error)
+	CreateOrUpdateDeploymentStub        func(context.Context, *v1.Deployment) (*v1.Deployment, error)
 	createOrUpdateDeploymentMutex       sync.RWMutex
 	createOrUpdateDeploymentArgsForCall []struct {
-		arg1 *v1.Deployment
+		arg1 context.Context
+		arg2 *v1.Deployment
 	}
 	createOrUpdateDeploymentReturns struct {
 		result1 *v1.Deployment
@@ -41,10 +44,11 @@ type FakeInstallStrategyDeploymentInterface struct {
 		result1 *v1.Deployment
 		result2 error
 	}
-	CreateRoleStub        func(*v1a.Role) (*v1a.Role, error)
+	CreateRoleStub        func(context.Context, *v1a.Role) (*v1a.Role, error)
 	createRoleMutex       sync.RWMutex
 	createRoleArgsForCall []struct {
-		arg1 *v1a.Role
+		arg1 context.Context
+		arg2 *v1a.Role
 	}
 	createRoleReturns struct {
 		result1 *v1a.Role
@@ -54,10 +58,11 @@ type FakeInstallStrategyDeploymentInterface struct {
 		result1 *v1a.Role
 		result2 error
 	}
-	CreateRoleBindingStub        func(*v1a.RoleBinding) (*v1a.RoleBinding, error)
+	CreateRoleBindingStub        func(context.Context, *v1a.RoleBinding) (*v1a.RoleBinding, error)
 	createRoleBindingMutex       sync.RWMutex
 	createRoleBindingArgsForCall []struct {
-		arg1 *v1a.RoleBinding
+		arg1 context.Context
+		arg2 *v1a.RoleBinding
 	}
 	createRoleBindingReturns struct {
 		result1 *v1a.RoleBinding
@@ -67,10 +72,11 @@ type FakeInstallStrategyDeploymentInterface struct {
 		result1 *v1a.RoleBinding
 		result2 error
 	}
-	DeleteDeploymentStub        func(string) error
+	DeleteDeploymentStub        func(context.Context, string) error
 	deleteDeploymentMutex       sync.RWMutex
 	deleteDeploymentArgsForCall []struct {
-		arg1 string
+		arg1 context.Context
+		arg2 string
 	}
 	deleteDeploymentReturns struct {
 		result1 error
@@ -78,11 +84,12 @@ type FakeInstallStrategyDeploymentInterface struct {
 	deleteDeploymentReturnsOnCall map[int]struct {
 		result1 error
 	}
-	EnsureServiceAccountStub        func(*v1b.ServiceAccount, ownerutil.Owner) (*v1b.ServiceAccount, error)
+	EnsureServiceAccountStub        func(context.Context, *v1b.ServiceAccount, ownerutil.Owner) (*v1b.ServiceAccount, error)
 	ensureServiceAccountMutex       sync.RWMutex
 	ensureServiceAccountArgsForCall []struct {
-		arg1 *v1b.ServiceAccount
-		arg2 ownerutil.Owner
+		arg1 context.Context
+		arg2 *v1b.ServiceAccount
+		arg3 ownerutil.Owner
 	}
 	ensureServiceAccountReturns struct {
 		result1 *v1b.ServiceAccount
@@ -155,16 +162,17 @@ type FakeInstallStrategyDeploymentInterface struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeInstallStrategyDeploymentInterface) CreateDeployment(arg1 *v1.Deployment) (*v1.Deployment, error) {
+func (fake *FakeInstallStrategyDeploymentInterface) CreateDeployment(arg1 context.Context, arg2 *v1.Deployment) (*v1.Deployment, error) {
 	fake.createDeploymentMutex.Lock()
 	ret, specificReturn := fake.createDeploymentReturnsOnCall[len(fake.createDeploymentArgsForCall)]
 	fake.createDeploymentArgsForCall = append(fake.createDeploymentArgsForCall, struct {
-		arg1 *v1.Deployment
-	}{arg1})
-	fake.recordInvocation("CreateDeployment", []interface{}{arg1})
+		arg1 context.Context
+		arg2 *v1.Deployment
+	}{arg1, arg2})
+	fake.recordInvocation("CreateDeployment", []interface{}{arg1, arg2})
 	fake.createDeploymentMutex.Unlock()
 	if fake.CreateDeploymentStub != nil {
-		return fake.CreateDeploymentStub(arg1)
+		return fake.CreateDeploymentStub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -179,17 +187,17 @@ func (fake *FakeInstallStrategyDeploymentInterface) CreateDeploymentCallCount()
 	return len(fake.createDeploymentArgsForCall)
 }
 
-func (fake *FakeInstallStrategyDeploymentInterface) CreateDeploymentCalls(stub func(*v1.Deployment) (*v1.Deployment, error)) {
+func (fake *FakeInstallStrategyDeploymentInterface) CreateDeploymentCalls(stub func(context.Context, *v1.Deployment) (*v1.Deployment, error)) {
 	fake.createDeploymentMutex.Lock()
 	defer fake.createDeploymentMutex.Unlock()
 	fake.CreateDeploymentStub = stub
 }
 
-func (fake *FakeInstallStrategyDeploymentInterface) CreateDeploymentArgsForCall(i int) *v1.Deployment {
+func (fake *FakeInstallStrategyDeploymentInterface) CreateDeploymentArgsForCall(i int) (context.Context, *v1.Deployment) {
 	fake.createDeploymentMutex.RLock()
 	defer fake.createDeploymentMutex.RUnlock()
 	argsForCall := fake.createDeploymentArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeInstallStrategyDeploymentInterface) CreateDeploymentReturns(result1 *v1.Deployment, result2 error) {
@@ -218,16 +226,17 @@ func (fake *FakeInstallStrategyDeploymentInterface) CreateDeploymentReturnsOnCal
 	}{result1, result2}
 }
 
-func (fake *FakeInstallStrategyDeploymentInterface) CreateOrUpdateDeployment(arg1 *v1.Deployment) (*v1.Deployment, error) {
+func (fake *FakeInstallStrategyDeploymentInterface) CreateOrUpdateDeployment(arg1 context.Context, arg2 *v1.Deployment) (*v1.Deployment, error) {
 	fake.createOrUpdateDeploymentMutex.Lock()
 	ret, specificReturn := fake.createOrUpdateDeploymentReturnsOnCall[len(fake.createOrUpdateDeploymentArgsForCall)]
 	fake.createOrUpdateDeploymentArgsForCall = append(fake.createOrUpdateDeploymentArgsForCall, struct {
-		arg1 *v1.Deployment
-	}{arg1})
-	fake.recordInvocation("CreateOrUpdateDeployment", []interface{}{arg1})
+		arg1 context.Context
+		arg2 *v1.Deployment
+	}{arg1, arg2})
+	fake.recordInvocation("CreateOrUpdateDeployment", []interface{}{arg1, arg2})
 	fake.createOrUpdateDeploymentMutex.Unlock()
 	if fake.CreateOrUpdateDeploymentStub != nil {
-		return fake.CreateOrUpdateDeploymentStub(arg1)
+		return fake.CreateOrUpdateDeploymentStub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -242,17 +251,17 @@ func (fake *FakeInstallStrategyDeploymentInterface) CreateOrUpdateDeploymentCall
 	return len(fake.createOrUpdateDeploymentArgsForCall)
 }
 
-func (fake *FakeInstallStrategyDeploymentInterface) CreateOrUpdateDeploymentCalls(stub func(*v1.Deployment) (*v1.Deployment, error)) {
+func (fake *FakeInstallStrategyDeploymentInterface) CreateOrUpdateDeploymentCalls(stub func(context.Context, *v1.Deployment) (*v1.Deployment, error)) {
 	fake.createOrUpdateDeploymentMutex.Lock()
 	defer fake.createOrUpdateDeploymentMutex.Unlock()
 	fake.CreateOrUpdateDeploymentStub = stub
 }
 
-func (fake *FakeInstallStrategyDeploymentInterface) CreateOrUpdateDeploymentArgsForCall(i int) *v1.Deployment {
+func (fake *FakeInstallStrategyDeploymentInterface) CreateOrUpdateDeploymentArgsForCall(i int) (context.Context, *v1.Deployment) {
 	fake.createOrUpdateDeploymentMutex.RLock()
 	defer fake.createOrUpdateDeploymentMutex.RUnlock()
 	argsForCall := fake.createOrUpdateDeploymentArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeInstallStrategyDeploymentInterface) CreateOrUpdateDeploymentReturns(result1 *v1.Deployment, result2 error) {
@@ -281,16 +290,17 @@ func (fake *FakeInstallStrategyDeploymentInterface) CreateOrUpdateDeploymentRetu
 	}{result1, result2}
 }
 
-func (fake *FakeInstallStrategyDeploymentInterface) CreateRole(arg1 *v1a.Role) (*v1a.Role, error) {
+func (fake *FakeInstallStrategyDeploymentInterface) CreateRole(arg1 context.Context, arg2 *v1a.Role) (*v1a.Role, error) {
 	fake.createRoleMutex.Lock()
 	ret, specificReturn := fake.createRoleReturnsOnCall[len(fake.createRoleArgsForCall)]
 	fake.createRoleArgsForCall = append(fake.createRoleArgsForCall, struct {
-		arg1 *v1a.Role
-	}{arg1})
-	fake.recordInvocation("CreateRole", []interface{}{arg1})
+		arg1 context.Context
+		arg2 *v1a.Role
+	}{arg1, arg2})
+	fake.recordInvocation("CreateRole", []interface{}{arg1, arg2})
 	fake.createRoleMutex.Unlock()
 	if fake.CreateRoleStub != nil {
-		return fake.CreateRoleStub(arg1)
+		return fake.CreateRoleStub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -305,17 +315,17 @@ func (fake *FakeInstallStrategyDeploymentInterface) CreateRoleCallCount() int {
 	return len(fake.createRoleArgsForCall)
 }
 
-func (fake *FakeInstallStrategyDeploymentInterface) CreateRoleCalls(stub func(*v1a.Role) (*v1a.Role, error)) {
+func (fake *FakeInstallStrategyDeploymentInterface) CreateRoleCalls(stub func(context.Context, *v1a.Role) (*v1a.Role, error)) {
 	fake.createRoleMutex.Lock()
 	defer fake.createRoleMutex.Unlock()
 	fake.CreateRoleStub = stub
 }
 
-func (fake *FakeInstallStrategyDeploymentInterface) CreateRoleArgsForCall(i int) *v1a.Role {
+func (fake *FakeInstallStrategyDeploymentInterface) CreateRoleArgsForCall(i int) (context.Context, *v1a.Role) {
 	fake.createRoleMutex.RLock()
 	defer fake.createRoleMutex.RUnlock()
 	argsForCall := fake.createRoleArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeInstallStrategyDeploymentInterface) CreateRoleReturns(result1 *v1a.Role, result2 error) {
@@ -344,16 +354,17 @@ func (fake *FakeInstallStrategyDeploymentInterface) CreateRoleReturnsOnCall(i in
 	}{result1, result2}
 }
 
-func (fake *FakeInstallStrategyDeploymentInterface) CreateRoleBinding(arg1 *v1a.RoleBinding) (*v1a.RoleBinding, error) {
+func (fake *FakeInstallStrategyDeploymentInterface) CreateRoleBinding(arg1 context.Context, arg2 *v1a.RoleBinding) (*v1a.RoleBinding, error) {
 	fake.createRoleBindingMutex.Lock()
 	ret, specificReturn := fake.createRoleBindingReturnsOnCall[len(fake.createRoleBindingArgsForCall)]
 	fake.createRoleBindingArgsForCall = append(fake.createRoleBindingArgsForCall, struct {
-		arg1 *v1a.RoleBinding
-	}{arg1})
-	fake.recordInvocation("CreateRoleBinding", []interface{}{arg1})
+		arg1 context.Context
+		arg2 *v1a.RoleBinding
+	}{arg1, arg2})
+	fake.recordInvocation("CreateRoleBinding", []interface{}{arg1, arg2})
 	fake.createRoleBindingMutex.Unlock()
 	if fake.CreateRoleBindingStub != nil {
-		return fake.CreateRoleBindingStub(arg1)
+		return fake.CreateRoleBindingStub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -368,17 +379,17 @@ func (fake *FakeInstallStrategyDeploymentInterface) CreateRoleBindingCallCount()
 	return len(fake.createRoleBindingArgsForCall)
 }
 
-func (fake *FakeInstallStrategyDeploymentInterface) CreateRoleBindingCalls(stub func(*v1a.RoleBinding) (*v1a.RoleBinding, error)) {
+func (fake *FakeInstallStrategyDeploymentInterface) CreateRoleBindingCalls(stub func(context.Context, *v1a.RoleBinding) (*v1a.RoleBinding, error)) {
 	fake.createRoleBindingMutex.Lock()
 	defer fake.createRoleBindingMutex.Unlock()
 	fake.CreateRoleBindingStub = stub
 }
 
-func (fake *FakeInstallStrategyDeploymentInterface) CreateRoleBindingArgsForCall(i int) *v1a.RoleBinding {
+func (fake *FakeInstallStrategyDeploymentInterface) CreateRoleBindingArgsForCall(i int) (context.Context, *v1a.RoleBinding) {
 	fake.createRoleBindingMutex.RLock()
 	defer fake.createRoleBindingMutex.RUnlock()
 	argsForCall := fake.createRoleBindingArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeInstallStrategyDeploymentInterface) CreateRoleBindingReturns(result1 *v1a.RoleBinding, result2 error) {
@@ -407,16 +418,17 @@ func (fake *FakeInstallStrategyDeploymentInterface) CreateRoleBindingReturnsOnCa
 	}{result1, result2}
 }
 
-func (fake *FakeInstallStrategyDeploymentInterface) DeleteDeployment(arg1 string) error {
+func (fake *FakeInstallStrategyDeploymentInterface) DeleteDeployment(arg1 context.Context, arg2 string) error {
 	fake.deleteDeploymentMutex.Lock()
 	ret, specificReturn := fake.deleteDeploymentReturnsOnCall[len(fake.deleteDeploymentArgsForCall)]
 	fake.deleteDeploymentArgsForCall = append(fake.deleteDeploymentArgsForCall, struct {
-		arg1 string
-	}{arg1})
-	fake.recordInvocation("DeleteDeployment", []interface{}{arg1})
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("DeleteDeployment", []interface{}{arg1, arg2})
 	fake.deleteDeploymentMutex.Unlock()
 	if fake.DeleteDeploymentStub != nil {
-		return fake.DeleteDeploymentStub(arg1)
+		return fake.DeleteDeploymentStub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1
@@ -431,17 +443,17 @@ func (fake *FakeInstallStrategyDeploymentInterface) DeleteDeploymentCallCount()
 	return len(fake.deleteDeploymentArgsForCall)
 }
 
-func (fake *FakeInstallStrategyDeploymentInterface) DeleteDeploymentCalls(stub func(string) error) {
+func (fake *FakeInstallStrategyDeploymentInterface) DeleteDeploymentCalls(stub func(context.Context, string) error) {
 	fake.deleteDeploymentMutex.Lock()
 	defer fake.deleteDeploymentMutex.Unlock()
 	fake.DeleteDeploymentStub = stub
 }
 
-func (fake *FakeInstallStrategyDeploymentInterface) DeleteDeploymentArgsForCall(i int) string {
+func (fake *FakeInstallStrategyDeploymentInterface) DeleteDeploymentArgsForCall(i int) (context.Context, string) {
 	fake.deleteDeploymentMutex.RLock()
 	defer fake.deleteDeploymentMutex.RUnlock()
 	argsForCall := fake.deleteDeploymentArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeInstallStrategyDeploymentInterface) DeleteDeploymentReturns(result1 error) {
@@ -467,17 +479,18 @@ func (fake *FakeInstallStrategyDeploymentInterface) DeleteDeploymentReturnsOnCal
 	}{result1}
 }
 
-func (fake *FakeInstallStrategyDeploymentInterface) EnsureServiceAccount(arg1 *v1b.ServiceAccount, arg2 ownerutil.Owner) (*v1b.ServiceAccount, error) {
+func (fake *FakeInstallStrategyDeploymentInterface) EnsureServiceAccount(arg1 context.Context, arg2 *v1b.ServiceAccount, arg3 ownerutil.Owner) (*v1b.ServiceAccount, error) {
 	fake.ensureServiceAccountMutex.Lock()
 	ret, specificReturn := fake.ensureServiceAccountReturnsOnCall[len(fake.ensureServiceAccountArgsForCall)]
 	fake.ensureServiceAccountArgsForCall = append(fake.ensureServiceAccountArgsForCall, struct {
-		arg1 *v1b.ServiceAccount
-		arg2 ownerutil.Owner
-	}{arg1, arg2})
-	fake.recordInvocation("EnsureServiceAccount", []interface{}{arg1, arg2})
+		arg1 context.Context
+		arg2 *v1b.ServiceAccount
+		arg3 ownerutil.Owner
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("EnsureServiceAccount", []interface{}{arg1, arg2, arg3})
 	fake.ensureServiceAccountMutex.Unlock()
 	if fake.EnsureServiceAccountStub != nil {
-		return fake.EnsureServiceAccountStub(arg1, arg2)
+		return fake.EnsureServiceAccountStub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -492,17 +505,17 @@ func (fake *FakeInstallStrategyDeploymentInterface) EnsureServiceAccountCallCoun
 	return len(fake.ensureServiceAccountArgsForCall)
 }
 
-func (fake *FakeInstallStrategyDeploymentInterface) EnsureServiceAccountCalls(stub func(*v1b.ServiceAccount, ownerutil.Owner) (*v1b.ServiceAccount, error)) {
+func (fake *FakeInstallStrategyDeploymentInterface) EnsureServiceAccountCalls(stub func(context.Context, *v1b.ServiceAccount, ownerutil.Owner) (*v1b.ServiceAccount, error)) {
 	fake.ensureServiceAccountMutex.Lock()
 	defer fake.ensureServiceAccountMutex.Unlock()
 	fake.EnsureServiceAccountStub = stub
 }
 
-func (fake *FakeInstallStrategyDeploymentInterface) EnsureServiceAccountArgsForCall(i int) (*v1b.ServiceAccount, ownerutil.Owner) {
+func (fake *FakeInstallStrategyDeploymentInterface) EnsureServiceAccountArgsForCall(i int) (context.Context, *v1b.ServiceAccount, ownerutil.Owner) {
 	fake.ensureServiceAccountMutex.RLock()
 	defer fake.ensureServiceAccountMutex.RUnlock()
 	argsForCall := fake.ensureServiceAccountArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
 func (fake *FakeInstallStrategyDeploymentInterface) EnsureServiceAccountReturns(result1 *v1b.ServiceAccount, result2 error) {
@@ -3,6 +3,7 @@ package bundle
 import (
 	"context"
 	"crypto/sha256"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -28,6 +29,10 @@ import (
 	listersoperatorsv1alpha1 "github.com/operator-framework/operator-lifecycle-manager/pkg/api/client/listers/operators/v1alpha1"
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/install"
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/registry/resolver/projection"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/imagearch"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/imagemirror"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/imagesig"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/metrics"
 )
 
 const (
@@ -85,7 +90,7 @@ func newBundleUnpackResult(lookup *operatorsv1alpha1.BundleLookup) *BundleUnpack
 	}
 }
 
-func (c *ConfigMapUnpacker) job(cmRef *corev1.ObjectReference, bundlePath string, secrets []corev1.LocalObjectReference, annotationUnpackTimeout time.Duration) *batchv1.Job {
+func (c *ConfigMapUnpacker) job(cmRef *corev1.ObjectReference, bundlePath, pullImage string, secrets []corev1.LocalObjectReference, config UnpackJobConfig, annotationUnpackTimeout time.Duration) *batchv1.Job {
 	job := &batchv1.Job{
 		Spec: batchv1.JobSpec{
 			//ttlSecondsAfterFinished: 0 // can use in the future to not have to clean up job
@@ -101,6 +106,10 @@ func (c *ConfigMapUnpacker) job(cmRef *corev1.ObjectReference, bundlePath string
 					// See: https://kubernetes.io/docs/concepts/workloads/controllers/job/#pod-backoff-failure-policy
 					RestartPolicy:    corev1.RestartPolicyNever,
 					ImagePullSecrets: secrets,
+					// Bundle images are unpacked by Linux images, which can't run on the Windows nodes of mixed clusters.
+					NodeSelector: map[string]string{
+						"kubernetes.io/os": "linux",
+					},
 					Containers: []corev1.Container{
 						{
 							Name:  "extract",
@@ -150,8 +159,8 @@ func (c *ConfigMapUnpacker) job(cmRef *corev1.ObjectReference, bundlePath string
 							},
 						},
 						{
-							Name:            "pull",
-							Image:           bundlePath,
+							Name:            pullContainerName,
+							Image:           c.mirrorImage.Rewrite(pullImage),
 							ImagePullPolicy: "Always",
 							Command:         []string{"/util/cpb", "/bundle"}, // Copy bundle content to its mount
 							VolumeMounts: []corev1.VolumeMount{
@@ -192,6 +201,7 @@ func (c *ConfigMapUnpacker) job(cmRef *corev1.ObjectReference, bundlePath string
 	}
 	job.SetNamespace(cmRef.Namespace)
 	job.SetName(cmRef.Name)
+	job.SetLabels(map[string]string{BundleUnpackRefLabel: cmRef.Name})
 	job.SetOwnerReferences([]metav1.OwnerReference{ownerRef(cmRef)})
 
 	// By default the BackoffLimit is set to 6 which with exponential backoff 10s + 20s + 40s ...
@@ -200,12 +210,29 @@ func (c *ConfigMapUnpacker) job(cmRef *corev1.ObjectReference, bundlePath string
 	// so we set it to 3 which is ~1m of waiting time
 	// See: https://kubernetes.io/docs/concepts/workloads/controllers/job/#pod-backoff-failure-policy
 	backOffLimit := int32(3)
+	if config.Retries != nil {
+		backOffLimit = *config.Retries
+	}
 	job.Spec.BackoffLimit = &backOffLimit
 
-	// Set ActiveDeadlineSeconds as the unpack timeout
+	if config.Resources != nil {
+		spec := &job.Spec.Template.Spec
+		for i := range spec.InitContainers {
+			spec.InitContainers[i].Resources = *config.Resources.DeepCopy()
+		}
+		for i := range spec.Containers {
+			spec.Containers[i].Resources = *config.Resources.DeepCopy()
+		}
+	}
+
+	// Set ActiveDeadlineSeconds as the unpack timeout, configured by the OLMConfig or CatalogSource if they set one
 	// Don't set a timeout if it is 0
-	if c.unpackTimeout != time.Duration(0) {
-		t := int64(c.unpackTimeout.Seconds())
+	unpackTimeout := c.unpackTimeout
+	if config.Timeout != nil {
+		unpackTimeout = *config.Timeout
+	}
+	if unpackTimeout != time.Duration(0) {
+		t := int64(unpackTimeout.Seconds())
 		job.Spec.ActiveDeadlineSeconds = &t
 	}
 
@@ -246,13 +273,19 @@ type ConfigMapUnpacker struct {
 	loader        *configmap.BundleLoader
 	now           func() metav1.Time
 	unpackTimeout time.Duration
+	throttle      *pullThrottle
+	architectures imagearch.RequiredFunc
+	verifyImage   imagesig.VerifyFunc
+	jobDefaults   UnpackJobConfigFunc
+	mirrorImage   imagemirror.RewriteFunc
 }
 
 type ConfigMapUnpackerOption func(*ConfigMapUnpacker)
 
 func NewConfigmapUnpacker(options ...ConfigMapUnpackerOption) (*ConfigMapUnpacker, error) {
 	unpacker := &ConfigMapUnpacker{
-		loader: configmap.NewBundleLoader(),
+		loader:   configmap.NewBundleLoader(),
+		throttle: newPullThrottle(),
 	}
 
 	unpacker.apply(options...)
@@ -269,6 +302,54 @@ func WithUnpackTimeout(timeout time.Duration) ConfigMapUnpackerOption {
 	}
 }
 
+// WithMaxParallelUnpacks limits the number of unpack jobs in flight. A limit of 0 or less is unlimited.
+func WithMaxParallelUnpacks(max int) ConfigMapUnpackerOption {
+	return func(unpacker *ConfigMapUnpacker) {
+		unpacker.throttle.maxParallel = max
+	}
+}
+
+// WithRegistryBackoff sets the backoff from a registry after it throttles a bundle image pull, with a 429 or 5xx
+// status, doubled after each consecutive throttled pull up to the given maximum. A zero backoff disables it.
+func WithRegistryBackoff(backoff, maxBackoff time.Duration) ConfigMapUnpackerOption {
+	return func(unpacker *ConfigMapUnpacker) {
+		unpacker.throttle.baseBackoff = backoff
+		unpacker.throttle.maxBackoff = maxBackoff
+	}
+}
+
+// WithArchitectures schedules unpack jobs onto the architectures the opm and utility images support. Bundle images only
+// hold files, copied by the utility image, so their architecture doesn't matter.
+func WithArchitectures(architectures imagearch.RequiredFunc) ConfigMapUnpackerOption {
+	return func(unpacker *ConfigMapUnpacker) {
+		unpacker.architectures = architectures
+	}
+}
+
+// WithImageVerifier verifies the signatures of bundle images before their unpack jobs are created, which pull the images
+// by the digest verified. Bundle lookups of images failing verification fail.
+func WithImageVerifier(verify imagesig.VerifyFunc) ConfigMapUnpackerOption {
+	return func(unpacker *ConfigMapUnpacker) {
+		unpacker.verifyImage = verify
+	}
+}
+
+// WithUnpackJobDefaults sets the cluster-wide resources, timeout and retries of unpack jobs, which the annotations of
+// CatalogSources override for the bundles they provide.
+func WithUnpackJobDefaults(defaults UnpackJobConfigFunc) ConfigMapUnpackerOption {
+	return func(unpacker *ConfigMapUnpacker) {
+		unpacker.jobDefaults = defaults
+	}
+}
+
+// WithImageMirrors pulls bundle images from the mirrors of their repositories. Bundle lookups keep referencing the
+// images themselves.
+func WithImageMirrors(mirror imagemirror.RewriteFunc) ConfigMapUnpackerOption {
+	return func(unpacker *ConfigMapUnpacker) {
+		unpacker.mirrorImage = mirror
+	}
+}
+
 func WithOPMImage(opmImage string) ConfigMapUnpackerOption {
 	return func(unpacker *ConfigMapUnpacker) {
 		unpacker.opmImage = opmImage
@@ -365,6 +446,8 @@ func (c *ConfigMapUnpacker) validate() (err error) {
 		err = fmt.Errorf("bundle loader is nil")
 	case c.now == nil:
 		err = fmt.Errorf("now func is nil")
+	case c.throttle.baseBackoff > c.throttle.maxBackoff:
+		err = fmt.Errorf("registry backoff %s exceeds its maximum %s", c.throttle.baseBackoff, c.throttle.maxBackoff)
 	}
 
 	return
@@ -381,6 +464,9 @@ const (
 	JobNotStartedMessage        = "unpack job not yet started"
 	NotUnpackedReason           = "BundleNotUnpacked"
 	NotUnpackedMessage          = "bundle contents have not yet been persisted to installplan status"
+
+	SignatureVerificationFailedReason = "SignatureVerificationFailed"
+	InvalidUnpackJobConfigReason      = "InvalidUnpackJobConfig"
 )
 
 func (c *ConfigMapUnpacker) UnpackBundle(lookup *operatorsv1alpha1.BundleLookup, timeout time.Duration) (result *BundleUnpackResult, err error) {
@@ -414,6 +500,23 @@ func (c *ConfigMapUnpacker) UnpackBundle(lookup *operatorsv1alpha1.BundleLookup,
 		return
 	}
 
+	// Bundles of CatalogSources misconfiguring their unpack jobs fail rather than being unpacked without their limits
+	var config UnpackJobConfig
+	if c.jobDefaults != nil {
+		config = c.jobDefaults()
+	}
+	override, err := UnpackJobConfigFor(cs.GetAnnotations())
+	if err != nil {
+		failedCond.Status = corev1.ConditionTrue
+		failedCond.Reason = InvalidUnpackJobConfigReason
+		failedCond.Message = fmt.Sprintf("catalogsource %s/%s: %v", cs.GetNamespace(), cs.GetName(), err)
+		failedCond.LastTransitionTime = &now
+		result.SetCondition(failedCond)
+		err = nil
+		return
+	}
+	config = config.Merge(override)
+
 	// Add missing info to the object reference
 	csRef := result.CatalogSourceRef.DeepCopy()
 	csRef.SetGroupVersionKind(catalogSourceGVK)
@@ -444,8 +547,50 @@ func (c *ConfigMapUnpacker) UnpackBundle(lookup *operatorsv1alpha1.BundleLookup,
 	for _, secretName := range cs.Spec.Secrets {
 		secrets = append(secrets, corev1.LocalObjectReference{Name: secretName})
 	}
+	// Bundle images failing signature verification are never pulled, and those verified are pulled by the digest verified
+	pullImage := result.Path
+	if c.verifyImage != nil {
+		if pullImage, err = c.verifyImage(context.TODO(), result.Path, cs.GetNamespace(), cs.Spec.Secrets); err != nil {
+			var verificationErr *imagesig.VerificationError
+			if !errors.As(err, &verificationErr) {
+				return
+			}
+			failedCond.Status = corev1.ConditionTrue
+			failedCond.Reason = SignatureVerificationFailedReason
+			failedCond.Message = err.Error()
+			failedCond.LastTransitionTime = &now
+			result.SetCondition(failedCond)
+			err = nil
+			return
+		}
+	}
+
+	// Hold back new unpack jobs while their registry is backed off from, or too many are in flight
+	if _, err = c.jobLister.Jobs(cmRef.Namespace).Get(cmRef.Name); apierrors.IsNotFound(err) {
+		var reason, message string
+		reason, message, err = c.admitJob(cmRef.Namespace, cmRef.Name, result.Path)
+		if err != nil {
+			return
+		}
+		if reason != "" {
+			if pendingCond.Reason != reason || pendingCond.Message != message {
+				pendingCond.Status = corev1.ConditionTrue
+				pendingCond.Reason = reason
+				pendingCond.Message = message
+				pendingCond.LastTransitionTime = &now
+				result.SetCondition(pendingCond)
+			}
+			return
+		}
+	} else if err != nil {
+		return
+	}
+
 	var job *batchv1.Job
-	job, err = c.ensureJob(cmRef, result.Path, secrets, timeout)
+	job, err = c.ensureJob(cmRef, result.Path, pullImage, secrets, config, timeout)
+	if err != nil {
+		c.throttle.release(cmRef.Namespace + "/" + cmRef.Name)
+	}
 	if err != nil || job == nil {
 		// ensureJob can return nil if the job present does not match the expected job (spec and ownerefs)
 		// The current job is deleted in that case so UnpackBundle needs to be retried
@@ -459,13 +604,51 @@ func (c *ConfigMapUnpacker) UnpackBundle(lookup *operatorsv1alpha1.BundleLookup,
 		failedCond.Status = corev1.ConditionTrue
 		failedCond.Reason = jobCond.Reason
 		failedCond.Message = jobCond.Message
+		// Pods running out of memory are reported as such, since raising the memory limit of unpack jobs fixes them
+		var oomKilled string
+		oomKilled, err = c.oomKilledContainers(job.GetNamespace(), job.GetName())
+		if err != nil {
+			return
+		}
+		if oomKilled != "" {
+			failedCond.Reason = JobOOMKilledReason
+			failedCond.Message = failedCond.Message + ": " + oomKilled
+		}
 		failedCond.LastTransitionTime = &now
 		result.SetCondition(failedCond)
 
 		return
 	}
 
+	registry := registryHost(result.Path)
 	if _, isComplete := getCondition(job, batchv1.JobComplete); !isComplete {
+		// Back off from registries rate limiting or failing pulls, deleting the job to stop its pulls until then
+		var throttledMsg string
+		throttledMsg, err = c.throttledPull(job)
+		if err != nil {
+			return
+		}
+		var backoff time.Duration
+		if throttledMsg != "" && job.GetDeletionTimestamp() == nil {
+			backoff = c.throttle.throttled(registry, now.Time)
+		}
+		if backoff > 0 {
+			metrics.EmitRegistryThrottledPull(registry, backoff)
+			background := metav1.DeletePropagationBackground
+			err = c.client.BatchV1().Jobs(job.GetNamespace()).Delete(context.TODO(), job.GetName(), metav1.DeleteOptions{PropagationPolicy: &background})
+			if err != nil && !apierrors.IsNotFound(err) {
+				return
+			}
+			err = nil
+
+			pendingCond.Status = corev1.ConditionTrue
+			pendingCond.Reason = RegistryBackoffReason
+			pendingCond.Message = fmt.Sprintf("registry %s throttled the bundle image pull, retrying after %s: %s", registry, now.Add(backoff).UTC().Format(time.RFC3339), throttledMsg)
+			pendingCond.LastTransitionTime = &now
+			result.SetCondition(pendingCond)
+			return
+		}
+
 		// In the case of an image pull failure for a non-existent image the bundle unpack job
 		// can stay pending until the ActiveDeadlineSeconds timeout ~10m
 		// To indicate why it's pending we inspect the container statuses of the
@@ -493,6 +676,10 @@ func (c *ConfigMapUnpacker) UnpackBundle(lookup *operatorsv1alpha1.BundleLookup,
 		return
 	}
 
+	if c.throttle.pulled(registry) {
+		metrics.DeleteRegistryBackoffMetric(registry)
+	}
+
 	result.bundle, err = c.loader.Load(cm)
 	if err != nil {
 		return
@@ -584,11 +771,12 @@ func (c *ConfigMapUnpacker) ensureConfigmap(csRef *corev1.ObjectReference, name
 	return
 }
 
-func (c *ConfigMapUnpacker) ensureJob(cmRef *corev1.ObjectReference, bundlePath string, secrets []corev1.LocalObjectReference, timeout time.Duration) (job *batchv1.Job, err error) {
-	fresh := c.job(cmRef, bundlePath, secrets, timeout)
+func (c *ConfigMapUnpacker) ensureJob(cmRef *corev1.ObjectReference, bundlePath, pullImage string, secrets []corev1.LocalObjectReference, config UnpackJobConfig, timeout time.Duration) (job *batchv1.Job, err error) {
+	fresh := c.job(cmRef, bundlePath, pullImage, secrets, config, timeout)
 	job, err = c.jobLister.Jobs(fresh.GetNamespace()).Get(fresh.GetName())
 	if err != nil {
 		if apierrors.IsNotFound(err) {
+			c.architectures.Apply(context.TODO(), &fresh.Spec.Template.Spec, c.opmImage, c.utilImage)
 			job, err = c.client.BatchV1().Jobs(fresh.GetNamespace()).Create(context.TODO(), fresh, metav1.CreateOptions{})
 		}
 
@@ -20,6 +20,7 @@ import (
 	crfake "github.com/operator-framework/operator-lifecycle-manager/pkg/api/client/clientset/versioned/fake"
 	crinformers "github.com/operator-framework/operator-lifecycle-manager/pkg/api/client/informers/externalversions"
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/install"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/imagesig"
 	"github.com/operator-framework/operator-registry/pkg/api"
 	"github.com/operator-framework/operator-registry/pkg/configmap"
 )
@@ -51,8 +52,9 @@ func TestConfigMapUnpacker(t *testing.T) {
 	customAnnotationTimeoutSeconds := int64(customAnnotationDuration.Seconds())
 
 	type fields struct {
-		objs []runtime.Object
-		crs  []runtime.Object
+		objs        []runtime.Object
+		crs         []runtime.Object
+		verifyImage imagesig.VerifyFunc
 	}
 	type args struct {
 		lookup *operatorsv1alpha1.BundleLookup
@@ -66,6 +68,7 @@ func TestConfigMapUnpacker(t *testing.T) {
 		jobs         []*batchv1.Job
 		roles        []*rbacv1.Role
 		roleBindings []*rbacv1.RoleBinding
+		noJobs       bool
 	}
 
 	tests := []struct {
@@ -119,6 +122,128 @@ func TestConfigMapUnpacker(t *testing.T) {
 				},
 			},
 		},
+		{
+			description: "CatalogSourcePresent/NoJob/SignatureVerificationFailed/BundleLookupFailed",
+			fields: fields{
+				crs: []runtime.Object{
+					&operatorsv1alpha1.CatalogSource{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: "ns-a",
+							Name:      "src-a",
+						},
+					},
+				},
+				verifyImage: func(_ context.Context, image, _ string, _ []string) (string, error) {
+					return "", &imagesig.VerificationError{Image: image, Reason: "no signatures found"}
+				},
+			},
+			args: args{
+				annotationTimeout: -1 * time.Minute,
+				lookup: &operatorsv1alpha1.BundleLookup{
+					Path: bundlePath,
+					CatalogSourceRef: &corev1.ObjectReference{
+						Namespace: "ns-a",
+						Name:      "src-a",
+					},
+					Conditions: []operatorsv1alpha1.BundleLookupCondition{
+						{
+							Type:    operatorsv1alpha1.BundleLookupPending,
+							Status:  corev1.ConditionTrue,
+							Reason:  JobNotStartedReason,
+							Message: JobNotStartedMessage,
+						},
+					},
+				},
+			},
+			expected: expected{
+				res: &BundleUnpackResult{
+					BundleLookup: &operatorsv1alpha1.BundleLookup{
+						Path: bundlePath,
+						CatalogSourceRef: &corev1.ObjectReference{
+							Namespace: "ns-a",
+							Name:      "src-a",
+						},
+						Conditions: []operatorsv1alpha1.BundleLookupCondition{
+							{
+								Type:    operatorsv1alpha1.BundleLookupPending,
+								Status:  corev1.ConditionTrue,
+								Reason:  JobNotStartedReason,
+								Message: JobNotStartedMessage,
+							},
+							{
+								Type:               BundleLookupFailed,
+								Status:             corev1.ConditionTrue,
+								Reason:             SignatureVerificationFailedReason,
+								Message:            "signature verification of image " + bundlePath + " failed: no signatures found",
+								LastTransitionTime: &start,
+							},
+						},
+					},
+					name: pathHash,
+				},
+				noJobs: true,
+			},
+		},
+		{
+			description: "CatalogSourcePresent/InvalidUnpackJobConfig/BundleLookupFailed",
+			fields: fields{
+				crs: []runtime.Object{
+					&operatorsv1alpha1.CatalogSource{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace:   "ns-a",
+							Name:        "src-a",
+							Annotations: map[string]string{BundleUnpackRetriesAnnotationKey: "-1"},
+						},
+					},
+				},
+			},
+			args: args{
+				annotationTimeout: -1 * time.Minute,
+				lookup: &operatorsv1alpha1.BundleLookup{
+					Path: bundlePath,
+					CatalogSourceRef: &corev1.ObjectReference{
+						Namespace: "ns-a",
+						Name:      "src-a",
+					},
+					Conditions: []operatorsv1alpha1.BundleLookupCondition{
+						{
+							Type:    operatorsv1alpha1.BundleLookupPending,
+							Status:  corev1.ConditionTrue,
+							Reason:  JobNotStartedReason,
+							Message: JobNotStartedMessage,
+						},
+					},
+				},
+			},
+			expected: expected{
+				res: &BundleUnpackResult{
+					BundleLookup: &operatorsv1alpha1.BundleLookup{
+						Path: bundlePath,
+						CatalogSourceRef: &corev1.ObjectReference{
+							Namespace: "ns-a",
+							Name:      "src-a",
+						},
+						Conditions: []operatorsv1alpha1.BundleLookupCondition{
+							{
+								Type:    operatorsv1alpha1.BundleLookupPending,
+								Status:  corev1.ConditionTrue,
+								Reason:  JobNotStartedReason,
+								Message: JobNotStartedMessage,
+							},
+							{
+								Type:               BundleLookupFailed,
+								Status:             corev1.ConditionTrue,
+								Reason:             InvalidUnpackJobConfigReason,
+								Message:            "catalogsource ns-a/src-a: invalid operatorframework.io/bundle-unpack-retries annotation: must be a non-negative integer",
+								LastTransitionTime: &start,
+							},
+						},
+					},
+					name: pathHash,
+				},
+				noJobs: true,
+			},
+		},
 		{
 			description: "CatalogSourcePresent/NoConfigMap/NoJob/JobCreated/Pending/WithCustomTimeout",
 			fields: fields{
@@ -199,6 +324,7 @@ func TestConfigMapUnpacker(t *testing.T) {
 						ObjectMeta: metav1.ObjectMeta{
 							Name:      pathHash,
 							Namespace: "ns-a",
+							Labels:    map[string]string{BundleUnpackRefLabel: pathHash},
 							OwnerReferences: []metav1.OwnerReference{
 								{
 									APIVersion:         "v1",
@@ -218,7 +344,10 @@ func TestConfigMapUnpacker(t *testing.T) {
 									Name: pathHash,
 								},
 								Spec: corev1.PodSpec{
-									RestartPolicy:    corev1.RestartPolicyNever,
+									RestartPolicy: corev1.RestartPolicyNever,
+									NodeSelector: map[string]string{
+										"kubernetes.io/os": "linux",
+									},
 									ImagePullSecrets: []corev1.LocalObjectReference{{Name: "my-secret"}},
 									Containers: []corev1.Container{
 										{
@@ -378,6 +507,7 @@ func TestConfigMapUnpacker(t *testing.T) {
 						ObjectMeta: metav1.ObjectMeta{
 							Name:      pathHash,
 							Namespace: "ns-a",
+							Labels:    map[string]string{BundleUnpackRefLabel: pathHash},
 							OwnerReferences: []metav1.OwnerReference{
 								{
 									APIVersion:         "v1",
@@ -397,6 +527,9 @@ func TestConfigMapUnpacker(t *testing.T) {
 								},
 								Spec: corev1.PodSpec{
 									RestartPolicy: corev1.RestartPolicyNever,
+									NodeSelector: map[string]string{
+										"kubernetes.io/os": "linux",
+									},
 									Containers: []corev1.Container{
 										{
 											Name:    "extract",
@@ -596,6 +729,7 @@ func TestConfigMapUnpacker(t *testing.T) {
 						ObjectMeta: metav1.ObjectMeta{
 							Name:      pathHash,
 							Namespace: "ns-a",
+							Labels:    map[string]string{BundleUnpackRefLabel: pathHash},
 							OwnerReferences: []metav1.OwnerReference{
 								{
 									APIVersion:         "v1",
@@ -615,6 +749,9 @@ func TestConfigMapUnpacker(t *testing.T) {
 								},
 								Spec: corev1.PodSpec{
 									RestartPolicy: corev1.RestartPolicyNever,
+									NodeSelector: map[string]string{
+										"kubernetes.io/os": "linux",
+									},
 									Containers: []corev1.Container{
 										{
 											Name:    "extract",
@@ -808,6 +945,7 @@ func TestConfigMapUnpacker(t *testing.T) {
 						ObjectMeta: metav1.ObjectMeta{
 							Name:      pathHash,
 							Namespace: "ns-a",
+							Labels:    map[string]string{BundleUnpackRefLabel: pathHash},
 							OwnerReferences: []metav1.OwnerReference{
 								{
 									APIVersion:         "v1",
@@ -827,6 +965,9 @@ func TestConfigMapUnpacker(t *testing.T) {
 								},
 								Spec: corev1.PodSpec{
 									RestartPolicy: corev1.RestartPolicyNever,
+									NodeSelector: map[string]string{
+										"kubernetes.io/os": "linux",
+									},
 									Containers: []corev1.Container{
 										{
 											Name:    "extract",
@@ -990,6 +1131,7 @@ func TestConfigMapUnpacker(t *testing.T) {
 						ObjectMeta: metav1.ObjectMeta{
 							Name:      pathHash,
 							Namespace: "ns-a",
+							Labels:    map[string]string{BundleUnpackRefLabel: pathHash},
 							OwnerReferences: []metav1.OwnerReference{
 								{
 									APIVersion:         "v1",
@@ -1009,6 +1151,9 @@ func TestConfigMapUnpacker(t *testing.T) {
 								},
 								Spec: corev1.PodSpec{
 									RestartPolicy: corev1.RestartPolicyNever,
+									NodeSelector: map[string]string{
+										"kubernetes.io/os": "linux",
+									},
 									Containers: []corev1.Container{
 										{
 											Name:    "extract",
@@ -1183,6 +1328,7 @@ func TestConfigMapUnpacker(t *testing.T) {
 						ObjectMeta: metav1.ObjectMeta{
 							Name:      pathHash,
 							Namespace: "ns-a",
+							Labels:    map[string]string{BundleUnpackRefLabel: pathHash},
 							OwnerReferences: []metav1.OwnerReference{
 								{
 									APIVersion:         "v1",
@@ -1202,6 +1348,9 @@ func TestConfigMapUnpacker(t *testing.T) {
 								},
 								Spec: corev1.PodSpec{
 									RestartPolicy: corev1.RestartPolicyNever,
+									NodeSelector: map[string]string{
+										"kubernetes.io/os": "linux",
+									},
 									Containers: []corev1.Container{
 										{
 											Name:    "extract",
@@ -1341,6 +1490,7 @@ func TestConfigMapUnpacker(t *testing.T) {
 				WithUtilImage(utilImage),
 				WithNow(now),
 				WithUnpackTimeout(defaultUnpackDuration),
+				WithImageVerifier(tt.fields.verifyImage),
 			)
 			require.NoError(t, err)
 
@@ -1371,6 +1521,12 @@ func TestConfigMapUnpacker(t *testing.T) {
 				require.ElementsMatch(t, tt.expected.res.Conditions, res.Conditions)
 			}
 
+			if tt.expected.noJobs {
+				jobs, err := client.BatchV1().Jobs(metav1.NamespaceAll).List(context.TODO(), metav1.ListOptions{})
+				require.NoError(t, err)
+				require.Empty(t, jobs.Items)
+			}
+
 			opts := metav1.GetOptions{}
 			for _, job := range tt.expected.jobs {
 				stored, err := client.BatchV1().Jobs(job.GetNamespace()).Get(context.TODO(), job.GetName(), opts)
@@ -1398,3 +1554,32 @@ func TestConfigMapUnpacker(t *testing.T) {
 		})
 	}
 }
+
+func TestJobImageMirrors(t *testing.T) {
+	c := &ConfigMapUnpacker{
+		opmImage:  opmImage,
+		utilImage: utilImage,
+		mirrorImage: func(image string) string {
+			return "registry.internal/" + image
+		},
+	}
+	job := c.job(&corev1.ObjectReference{Namespace: "ns-a", Name: "cm"}, bundlePath, bundlePath, nil, UnpackJobConfig{}, -1)
+
+	// the bundle image is pulled from its mirror, but extracted as the bundle image itself
+	require.Equal(t, "registry.internal/"+bundlePath, job.Spec.Template.Spec.InitContainers[1].Image)
+	require.Contains(t, job.Spec.Template.Spec.Containers[0].Env, corev1.EnvVar{Name: configmap.EnvContainerImage, Value: bundlePath})
+}
+
+func TestJobPullImage(t *testing.T) {
+	c := &ConfigMapUnpacker{
+		opmImage:    opmImage,
+		utilImage:   utilImage,
+		mirrorImage: func(image string) string { return image },
+	}
+	pullImage := "quay.io/test/bundle@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+	job := c.job(&corev1.ObjectReference{Namespace: "ns-a", Name: "cm"}, bundlePath, pullImage, nil, UnpackJobConfig{}, -1)
+
+	// the bundle image is pulled by the digest verified, but extracted as the bundle image itself
+	require.Equal(t, pullImage, job.Spec.Template.Spec.InitContainers[1].Image)
+	require.Contains(t, job.Spec.Template.Spec.Containers[0].Env, corev1.EnvVar{Name: configmap.EnvContainerImage, Value: bundlePath})
+}
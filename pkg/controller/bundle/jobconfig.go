@@ -0,0 +1,117 @@
+package bundle
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	k8slabels "k8s.io/apimachinery/pkg/labels"
+)
+
+const (
+	// BundleUnpackResourcesAnnotationKey sets, as JSON ResourceRequirements, the resources of each of the containers of
+	// unpack jobs, which run one at a time, e.g. {"limits":{"memory":"512Mi"}}. It is read on CatalogSources for the
+	// bundles they provide, and on the cluster OLMConfig for all bundles.
+	BundleUnpackResourcesAnnotationKey = "operatorframework.io/bundle-unpack-resources"
+
+	// BundleUnpackRetriesAnnotationKey sets how many times the failed pods of unpack jobs are retried before the jobs
+	// fail. It is read on CatalogSources and on the cluster OLMConfig, and defaults to 3.
+	BundleUnpackRetriesAnnotationKey = "operatorframework.io/bundle-unpack-retries"
+)
+
+// The reasons of the BundleLookupFailed conditions of failed unpack jobs.
+const (
+	// JobDeadlineExceededReason is the reason of unpack jobs running longer than their timeout.
+	JobDeadlineExceededReason = "DeadlineExceeded"
+	// JobBackoffLimitExceededReason is the reason of unpack jobs whose pods failed more than their retries.
+	JobBackoffLimitExceededReason = "BackoffLimitExceeded"
+	// JobOOMKilledReason is the reason of unpack jobs whose pods failed after running out of memory.
+	JobOOMKilledReason = "OOMKilled"
+)
+
+// UnpackJobConfig configures the unpack jobs of bundles. Unset fields keep their defaults.
+type UnpackJobConfig struct {
+	// Resources are the resources of each of the containers of unpack jobs.
+	Resources *corev1.ResourceRequirements
+	// Timeout is the active deadline of unpack jobs. A zero timeout disables it.
+	Timeout *time.Duration
+	// Retries is the backoff limit of unpack jobs.
+	Retries *int32
+}
+
+// UnpackJobConfigFunc returns the cluster-wide configuration of unpack jobs, which CatalogSources override.
+type UnpackJobConfigFunc func() UnpackJobConfig
+
+// UnpackJobConfigFor returns the unpack job configuration set in the given CatalogSource or OLMConfig annotations.
+func UnpackJobConfigFor(annotations map[string]string) (UnpackJobConfig, error) {
+	var config UnpackJobConfig
+	if value, ok := annotations[BundleUnpackResourcesAnnotationKey]; ok {
+		resources := &corev1.ResourceRequirements{}
+		if err := json.Unmarshal([]byte(value), resources); err != nil {
+			return UnpackJobConfig{}, fmt.Errorf("invalid %s annotation: %v", BundleUnpackResourcesAnnotationKey, err)
+		}
+		for name, request := range resources.Requests {
+			if limit, ok := resources.Limits[name]; ok && request.Cmp(limit) > 0 {
+				return UnpackJobConfig{}, fmt.Errorf("invalid %s annotation: %s request %s exceeds its limit %s", BundleUnpackResourcesAnnotationKey, name, request.String(), limit.String())
+			}
+		}
+		config.Resources = resources
+	}
+	if value, ok := annotations[BundleUnpackTimeoutAnnotationKey]; ok {
+		timeout, err := time.ParseDuration(value)
+		if err != nil || timeout < 0 {
+			return UnpackJobConfig{}, fmt.Errorf("invalid %s annotation: must be a non-negative duration", BundleUnpackTimeoutAnnotationKey)
+		}
+		config.Timeout = &timeout
+	}
+	if value, ok := annotations[BundleUnpackRetriesAnnotationKey]; ok {
+		retries, err := strconv.ParseInt(value, 10, 32)
+		if err != nil || retries < 0 {
+			return UnpackJobConfig{}, fmt.Errorf("invalid %s annotation: must be a non-negative integer", BundleUnpackRetriesAnnotationKey)
+		}
+		r := int32(retries)
+		config.Retries = &r
+	}
+	return config, nil
+}
+
+// Merge returns the configuration with the fields set in the given override replacing its own.
+func (c UnpackJobConfig) Merge(override UnpackJobConfig) UnpackJobConfig {
+	if override.Resources != nil {
+		c.Resources = override.Resources
+	}
+	if override.Timeout != nil {
+		c.Timeout = override.Timeout
+	}
+	if override.Retries != nil {
+		c.Retries = override.Retries
+	}
+	return c
+}
+
+// oomKilledContainers returns a message listing the containers of the pods of the given job that ran out of memory,
+// or an empty message if none did.
+func (c *ConfigMapUnpacker) oomKilledContainers(namespace, job string) (string, error) {
+	pods, err := c.podLister.Pods(namespace).List(k8slabels.SelectorFromValidatedSet(map[string]string{BundleUnpackPodLabel: job}))
+	if err != nil {
+		return "", fmt.Errorf("failed to list pods for job(%s): %v", job, err)
+	}
+	var messages []string
+	for _, pod := range pods {
+		statuses := append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...)
+		for _, status := range append(statuses, pod.Status.ContainerStatuses...) {
+			for _, state := range []corev1.ContainerState{status.State, status.LastTerminationState} {
+				if state.Terminated != nil && state.Terminated.Reason == JobOOMKilledReason {
+					messages = append(messages, fmt.Sprintf("Unpack pod(%s/%s) container(%s) was OOMKilled", pod.Namespace, pod.Name, status.Name))
+					break
+				}
+			}
+		}
+	}
+	sort.Strings(messages)
+	return strings.Join(messages, " | "), nil
+}
@@ -0,0 +1,113 @@
+package bundle
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	listerscorev1 "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+func TestUnpackJobConfigFor(t *testing.T) {
+	config, err := UnpackJobConfigFor(nil)
+	require.NoError(t, err)
+	require.Equal(t, UnpackJobConfig{}, config)
+
+	config, err = UnpackJobConfigFor(map[string]string{
+		BundleUnpackResourcesAnnotationKey: `{"requests":{"memory":"100Mi"},"limits":{"memory":"1Gi"}}`,
+		BundleUnpackTimeoutAnnotationKey:   "0s",
+		BundleUnpackRetriesAnnotationKey:   "0",
+	})
+	require.NoError(t, err)
+	require.Equal(t, resource.MustParse("1Gi"), config.Resources.Limits[corev1.ResourceMemory])
+	require.Equal(t, time.Duration(0), *config.Timeout)
+	require.Equal(t, int32(0), *config.Retries)
+
+	for _, tt := range []struct {
+		key, value, wantErr string
+	}{
+		{BundleUnpackResourcesAnnotationKey, `{"requests":{"memory":"2Gi"},"limits":{"memory":"1Gi"}}`, "invalid operatorframework.io/bundle-unpack-resources annotation: memory request 2Gi exceeds its limit 1Gi"},
+		{BundleUnpackTimeoutAnnotationKey, "-1m", "invalid operatorframework.io/bundle-unpack-timeout annotation: must be a non-negative duration"},
+		{BundleUnpackRetriesAnnotationKey, "many", "invalid operatorframework.io/bundle-unpack-retries annotation: must be a non-negative integer"},
+	} {
+		_, err := UnpackJobConfigFor(map[string]string{tt.key: tt.value})
+		require.EqualError(t, err, tt.wantErr)
+	}
+}
+
+func TestUnpackJobConfigMerge(t *testing.T) {
+	timeout, otherTimeout := time.Minute, 5*time.Minute
+	retries := int32(5)
+	defaults := UnpackJobConfig{Timeout: &timeout, Retries: &retries}
+	merged := defaults.Merge(UnpackJobConfig{Timeout: &otherTimeout})
+	require.Equal(t, otherTimeout, *merged.Timeout)
+	require.Equal(t, retries, *merged.Retries)
+	require.Nil(t, merged.Resources)
+	require.Equal(t, timeout, *defaults.Timeout)
+}
+
+func TestJobUnpackJobConfig(t *testing.T) {
+	c := &ConfigMapUnpacker{opmImage: opmImage, utilImage: utilImage, unpackTimeout: 10 * time.Minute}
+	cmRef := &corev1.ObjectReference{Namespace: "ns-a", Name: "cm"}
+
+	job := c.job(cmRef, bundlePath, bundlePath, nil, UnpackJobConfig{}, -1)
+	require.Equal(t, int32(3), *job.Spec.BackoffLimit)
+	require.Equal(t, int64(600), *job.Spec.ActiveDeadlineSeconds)
+	require.Empty(t, job.Spec.Template.Spec.Containers[0].Resources.Limits)
+
+	timeout := time.Duration(0)
+	retries := int32(1)
+	resources := &corev1.ResourceRequirements{Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")}}
+	config := UnpackJobConfig{Resources: resources, Timeout: &timeout, Retries: &retries}
+	job = c.job(cmRef, bundlePath, bundlePath, nil, config, -1)
+	require.Equal(t, int32(1), *job.Spec.BackoffLimit)
+	require.Nil(t, job.Spec.ActiveDeadlineSeconds, "a zero timeout disables the deadline")
+	for _, container := range append(job.Spec.Template.Spec.InitContainers, job.Spec.Template.Spec.Containers...) {
+		require.Equal(t, *resources, container.Resources, container.Name)
+	}
+
+	// The timeout annotation of InstallPlans overrides the configuration
+	job = c.job(cmRef, bundlePath, bundlePath, nil, config, 2*time.Minute)
+	require.Equal(t, int64(120), *job.Spec.ActiveDeadlineSeconds)
+}
+
+func TestOOMKilledContainers(t *testing.T) {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	oomKilled := corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Reason: JobOOMKilledReason, ExitCode: 137}}
+	for _, pod := range []*corev1.Pod{
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns-a", Name: "job-a-1", Labels: map[string]string{BundleUnpackPodLabel: "job-a"}},
+			Status: corev1.PodStatus{
+				InitContainerStatuses: []corev1.ContainerStatus{{Name: "util"}, {Name: pullContainerName}},
+				ContainerStatuses:     []corev1.ContainerStatus{{Name: "extract", State: oomKilled}},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns-a", Name: "job-a-2", Labels: map[string]string{BundleUnpackPodLabel: "job-a"}},
+			Status: corev1.PodStatus{
+				ContainerStatuses: []corev1.ContainerStatus{{Name: "extract", LastTerminationState: oomKilled}},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns-a", Name: "job-b-1", Labels: map[string]string{BundleUnpackPodLabel: "job-b"}},
+			Status: corev1.PodStatus{
+				ContainerStatuses: []corev1.ContainerStatus{{Name: "extract", State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Reason: "Error", ExitCode: 1}}}},
+			},
+		},
+	} {
+		require.NoError(t, indexer.Add(pod))
+	}
+	c := &ConfigMapUnpacker{podLister: listerscorev1.NewPodLister(indexer)}
+
+	msg, err := c.oomKilledContainers("ns-a", "job-a")
+	require.NoError(t, err)
+	require.Equal(t, "Unpack pod(ns-a/job-a-1) container(extract) was OOMKilled | Unpack pod(ns-a/job-a-2) container(extract) was OOMKilled", msg)
+
+	msg, err = c.oomKilledContainers("ns-a", "job-b")
+	require.NoError(t, err)
+	require.Empty(t, msg)
+}
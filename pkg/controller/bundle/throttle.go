@@ -0,0 +1,211 @@
+package bundle
+
+import (
+	"fmt"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/distribution/distribution/reference"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8slabels "k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/api/reasons"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/metrics"
+)
+
+const (
+	// BundleUnpackRefLabel is the label of bundle unpack jobs, set to the name of the ConfigMap they unpack to. It
+	// counts the unpack jobs in flight.
+	BundleUnpackRefLabel = "operatorframework.io/bundle-unpack-ref"
+
+	UnpackThrottledReason  = string(reasons.InstallPlanUnpackThrottled)
+	UnpackThrottledMessage = "waiting for in-flight bundle unpack jobs to complete"
+	RegistryBackoffReason  = string(reasons.InstallPlanRegistryBackoff)
+
+	// pullContainerName is the name of the unpack job container pulling the bundle image.
+	pullContainerName = "pull"
+)
+
+// throttledPullMessage matches the messages of image pull errors caused by a registry rate limiting or failing pulls.
+var throttledPullMessage = regexp.MustCompile(`(?i)toomanyrequests|too many requests|\b429\b|\b5\d\d\b`)
+
+// registryBackoff tracks the consecutive throttled pulls from a registry.
+type registryBackoff struct {
+	failures int
+	until    time.Time
+}
+
+// pullThrottle limits the bundle unpack jobs in flight, and backs off from the registries rate limiting or failing
+// their pulls.
+type pullThrottle struct {
+	// maxParallel is the maximum number of unpack jobs in flight, or 0 if unlimited.
+	maxParallel int
+	// baseBackoff is the backoff after a first throttled pull from a registry, doubled after each consecutive one, up
+	// to maxBackoff. A zero baseBackoff disables backoff.
+	baseBackoff time.Duration
+	maxBackoff  time.Duration
+
+	mu         sync.Mutex
+	registries map[string]*registryBackoff
+	// created are the keys of the jobs created since they were last seen by the job lister
+	created map[string]struct{}
+}
+
+func newPullThrottle() *pullThrottle {
+	return &pullThrottle{
+		registries: map[string]*registryBackoff{},
+		created:    map[string]struct{}{},
+	}
+}
+
+// registryHost returns the registry of the given image, or the image itself if it can't be parsed.
+func registryHost(image string) string {
+	named, err := reference.ParseNormalizedNamed(image)
+	if err != nil {
+		return image
+	}
+	return reference.Domain(named)
+}
+
+// backoff returns how long the given registry is still backed off from.
+func (t *pullThrottle) backoff(registry string, now time.Time) time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	b, ok := t.registries[registry]
+	if !ok || !now.Before(b.until) {
+		return 0
+	}
+	return b.until.Sub(now)
+}
+
+// throttled records a throttled pull from the given registry, and returns how long it's backed off from. Pulls
+// throttled while the registry is already backed off from don't extend the backoff.
+func (t *pullThrottle) throttled(registry string, now time.Time) time.Duration {
+	if t.baseBackoff <= 0 {
+		return 0
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	b, ok := t.registries[registry]
+	if !ok {
+		b = &registryBackoff{}
+		t.registries[registry] = b
+	}
+	if now.Before(b.until) {
+		return b.until.Sub(now)
+	}
+	d := t.baseBackoff << uint(b.failures)
+	if d > t.maxBackoff || d <= 0 {
+		d = t.maxBackoff
+	}
+	b.failures++
+	b.until = now.Add(d)
+	return d
+}
+
+// pulled resets the backoff of the given registry after a successful pull, and returns true if it had one.
+func (t *pullThrottle) pulled(registry string) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	_, ok := t.registries[registry]
+	delete(t.registries, registry)
+	return ok
+}
+
+// admit returns true if a new unpack job can be created, given the unpack jobs of the given lister, and records its
+// creation under the given key if so.
+func (t *pullThrottle) admit(jobs []*batchv1.Job, key string) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.maxParallel <= 0 {
+		return true
+	}
+
+	inFlight := 0
+	for _, job := range jobs {
+		delete(t.created, job.GetNamespace()+"/"+job.GetName())
+		if _, complete := getCondition(job, batchv1.JobComplete); complete {
+			continue
+		}
+		if _, failed := getCondition(job, batchv1.JobFailed); failed {
+			continue
+		}
+		inFlight++
+	}
+	inFlight += len(t.created)
+	if inFlight >= t.maxParallel {
+		return false
+	}
+
+	t.created[key] = struct{}{}
+	return true
+}
+
+// release forgets the creation of the unpack job of the given key, when it failed.
+func (t *pullThrottle) release(key string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.created, key)
+}
+
+// unpackJobSelector selects the bundle unpack jobs.
+func unpackJobSelector() k8slabels.Selector {
+	req, err := k8slabels.NewRequirement(BundleUnpackRefLabel, selection.Exists, nil)
+	if err != nil {
+		panic(err)
+	}
+	return k8slabels.NewSelector().Add(*req)
+}
+
+// throttledPull returns the message of the image pull error of a pod of the given job, if the registry of the bundle
+// image rate limits or fails its pull.
+func (c *ConfigMapUnpacker) throttledPull(job *batchv1.Job) (string, error) {
+	pods, err := c.podLister.Pods(job.GetNamespace()).List(k8slabels.SelectorFromValidatedSet(map[string]string{BundleUnpackPodLabel: job.GetName()}))
+	if err != nil {
+		return "", fmt.Errorf("failed to list pods for job(%s): %v", job.GetName(), err)
+	}
+	for _, pod := range pods {
+		if pod.Status.Phase != corev1.PodPending {
+			continue
+		}
+		for _, ic := range pod.Status.InitContainerStatuses {
+			if ic.Name != pullContainerName || ic.State.Waiting == nil {
+				continue
+			}
+			switch ic.State.Waiting.Reason {
+			case "ErrImagePull", "ImagePullBackOff":
+			default:
+				continue
+			}
+			if throttledPullMessage.MatchString(ic.State.Waiting.Message) {
+				return ic.State.Waiting.Message, nil
+			}
+		}
+	}
+	return "", nil
+}
+
+// admitJob returns the reason and message of the pending condition of a bundle lookup whose unpack job can't be
+// created yet, because its registry is backed off from or too many unpack jobs are in flight.
+func (c *ConfigMapUnpacker) admitJob(namespace, name, bundlePath string) (reason, message string, err error) {
+	registry := registryHost(bundlePath)
+	now := c.now().Time
+	if d := c.throttle.backoff(registry, now); d > 0 {
+		metrics.EmitBundleUnpackThrottled(RegistryBackoffReason)
+		return RegistryBackoffReason, fmt.Sprintf("registry %s throttled bundle image pulls, retrying after %s", registry, now.Add(d).UTC().Format(time.RFC3339)), nil
+	}
+
+	jobs, err := c.jobLister.List(unpackJobSelector())
+	if err != nil {
+		return "", "", err
+	}
+	if !c.throttle.admit(jobs, namespace+"/"+name) {
+		metrics.EmitBundleUnpackThrottled(UnpackThrottledReason)
+		return UnpackThrottledReason, UnpackThrottledMessage, nil
+	}
+	return "", "", nil
+}
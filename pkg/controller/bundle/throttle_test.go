@@ -0,0 +1,88 @@
+package bundle
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRegistryHost(t *testing.T) {
+	require.Equal(t, "quay.io", registryHost("quay.io/operatorhubio/etcd:v0.9.4"))
+	require.Equal(t, "docker.io", registryHost("etcd:v0.9.4"))
+	require.Equal(t, "registry.local:5000", registryHost("registry.local:5000/etcd@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"))
+	require.Equal(t, "Not/An/Image", registryHost("Not/An/Image"))
+}
+
+func TestThrottledPullMessage(t *testing.T) {
+	for _, msg := range []string{
+		`rpc error: code = Unknown desc = toomanyrequests: You have reached your pull rate limit`,
+		`failed to pull image: unexpected status code 429 Too Many Requests`,
+		`failed to pull image: received unexpected HTTP status: 503 Service Unavailable`,
+	} {
+		require.True(t, throttledPullMessage.MatchString(msg), msg)
+	}
+	for _, msg := range []string{
+		`rpc error: code = Unknown desc = manifest unknown`,
+		`failed to pull image: unexpected status code 401 Unauthorized`,
+		`failed to pull image "quay.io/etcd/bundle:v5000"`,
+	} {
+		require.False(t, throttledPullMessage.MatchString(msg), msg)
+	}
+}
+
+func TestPullThrottleBackoff(t *testing.T) {
+	now := time.Now()
+	throttle := newPullThrottle()
+	require.Zero(t, throttle.throttled("quay.io", now), "backoff disabled")
+
+	throttle.baseBackoff = 30 * time.Second
+	throttle.maxBackoff = time.Minute
+	require.Zero(t, throttle.backoff("quay.io", now))
+
+	require.Equal(t, 30*time.Second, throttle.throttled("quay.io", now))
+	require.Equal(t, 20*time.Second, throttle.throttled("quay.io", now.Add(10*time.Second)), "throttled while backed off")
+	require.Equal(t, 20*time.Second, throttle.backoff("quay.io", now.Add(10*time.Second)))
+	require.Zero(t, throttle.backoff("docker.io", now))
+
+	now = now.Add(30 * time.Second)
+	require.Zero(t, throttle.backoff("quay.io", now))
+	require.Equal(t, time.Minute, throttle.throttled("quay.io", now))
+	now = now.Add(time.Minute)
+	require.Equal(t, time.Minute, throttle.throttled("quay.io", now), "capped at the maximum backoff")
+
+	require.True(t, throttle.pulled("quay.io"))
+	require.False(t, throttle.pulled("quay.io"))
+	require.Zero(t, throttle.backoff("quay.io", now))
+	require.Equal(t, 30*time.Second, throttle.throttled("quay.io", now))
+}
+
+func TestPullThrottleAdmit(t *testing.T) {
+	job := func(name string, conditionType batchv1.JobConditionType) *batchv1.Job {
+		j := &batchv1.Job{ObjectMeta: metav1.ObjectMeta{Namespace: "ns-a", Name: name}}
+		if conditionType != "" {
+			j.Status.Conditions = []batchv1.JobCondition{{Type: conditionType, Status: corev1.ConditionTrue}}
+		}
+		return j
+	}
+
+	throttle := newPullThrottle()
+	require.True(t, throttle.admit([]*batchv1.Job{job("a", ""), job("b", "")}, "ns-a/c"), "unlimited")
+
+	throttle = newPullThrottle()
+	throttle.maxParallel = 2
+	jobs := []*batchv1.Job{job("a", ""), job("b", batchv1.JobComplete), job("c", batchv1.JobFailed)}
+	require.True(t, throttle.admit(jobs, "ns-a/d"))
+	require.False(t, throttle.admit(jobs, "ns-a/e"), "created job not listed yet")
+
+	throttle.release("ns-a/d")
+	require.True(t, throttle.admit(jobs, "ns-a/e"))
+
+	jobs = append(jobs, job("e", batchv1.JobComplete))
+	require.True(t, throttle.admit(jobs, "ns-a/f"), "created job listed as complete")
+	jobs = append(jobs, job("f", ""))
+	require.False(t, throttle.admit(jobs, "ns-a/g"))
+}
@@ -0,0 +1,239 @@
+package install
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/ownerutil"
+)
+
+const (
+	// ValidatingAdmissionPoliciesAnnotationKey is the CSV annotation holding a JSON list of
+	// ValidatingAdmissionPolicyDescriptions for OLM to install alongside the operator.
+	ValidatingAdmissionPoliciesAnnotationKey = "operatorframework.io/validating-admission-policies"
+
+	// AdmissionPolicyDescKey labels the policies and bindings created for a ValidatingAdmissionPolicyDescription.
+	AdmissionPolicyDescKey = "olm.admission-policy-description-name"
+)
+
+var (
+	ValidatingAdmissionPolicyGVR        = schema.GroupVersionResource{Group: "admissionregistration.k8s.io", Version: "v1", Resource: "validatingadmissionpolicies"}
+	ValidatingAdmissionPolicyBindingGVR = schema.GroupVersionResource{Group: "admissionregistration.k8s.io", Version: "v1", Resource: "validatingadmissionpolicybindings"}
+)
+
+// ValidatingAdmissionPolicyDescription describes a CEL ValidatingAdmissionPolicy, and the binding that enforces it,
+// owned by a ClusterServiceVersion.
+type ValidatingAdmissionPolicyDescription struct {
+	// Name is used for both the policy and its binding.
+	Name string `json:"name"`
+
+	// Policy is the spec of the ValidatingAdmissionPolicy.
+	Policy map[string]interface{} `json:"policy"`
+
+	// Binding is the spec of the ValidatingAdmissionPolicyBinding. The binding's policyName is always set to Name.
+	// If omitted, the policy is bound with its default validation actions.
+	// +optional
+	Binding map[string]interface{} `json:"binding,omitempty"`
+}
+
+// ValidatingAdmissionPolicyDescriptions returns the ValidatingAdmissionPolicyDescriptions declared by the given CSV.
+func ValidatingAdmissionPolicyDescriptions(csv *v1alpha1.ClusterServiceVersion) ([]ValidatingAdmissionPolicyDescription, error) {
+	raw, ok := csv.GetAnnotations()[ValidatingAdmissionPoliciesAnnotationKey]
+	if !ok || raw == "" {
+		return nil, nil
+	}
+
+	var descs []ValidatingAdmissionPolicyDescription
+	if err := json.Unmarshal([]byte(raw), &descs); err != nil {
+		return nil, fmt.Errorf("unable to parse %s annotation: %v", ValidatingAdmissionPoliciesAnnotationKey, err)
+	}
+
+	return descs, nil
+}
+
+// ValidAdmissionPolicyDescriptions checks that the given descriptions are uniquely named and do not
+// match OLM's own resources or admission configuration, mirroring the restrictions on webhooks.
+func ValidAdmissionPolicyDescriptions(descs []ValidatingAdmissionPolicyDescription) error {
+	names := map[string]struct{}{}
+	for _, desc := range descs {
+		if desc.Name == "" {
+			return fmt.Errorf("validating admission policy description must have a name")
+		}
+		if _, ok := names[desc.Name]; ok {
+			return fmt.Errorf("repeated validating admission policy description name %s", desc.Name)
+		}
+		names[desc.Name] = struct{}{}
+
+		if len(desc.Policy) == 0 {
+			return fmt.Errorf("validating admission policy description %s must have a policy", desc.Name)
+		}
+
+		rules, err := admissionPolicyRules(desc.Policy)
+		if err != nil {
+			return fmt.Errorf("validating admission policy description %s is invalid: %v", desc.Name, err)
+		}
+		if err := ValidWebhookRules(rules); err != nil {
+			return fmt.Errorf("validating admission policy description %s is invalid: %v", desc.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// admissionPolicyRules extracts the resource rules matched by a policy spec in the shape of webhook rules.
+func admissionPolicyRules(policy map[string]interface{}) ([]admissionregistrationv1.RuleWithOperations, error) {
+	resourceRules, _, err := unstructured.NestedSlice(policy, "matchConstraints", "resourceRules")
+	if err != nil {
+		return nil, err
+	}
+
+	var rules []admissionregistrationv1.RuleWithOperations
+	for _, r := range resourceRules {
+		rule, ok := r.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("malformed resource rule")
+		}
+		apiGroups, _, err := unstructured.NestedStringSlice(rule, "apiGroups")
+		if err != nil {
+			return nil, err
+		}
+		resources, _, err := unstructured.NestedStringSlice(rule, "resources")
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, admissionregistrationv1.RuleWithOperations{
+			Rule: admissionregistrationv1.Rule{APIGroups: apiGroups, Resources: resources},
+		})
+	}
+
+	return rules, nil
+}
+
+// AdmissionPolicyClient reconciles the ValidatingAdmissionPolicies owned by a ClusterServiceVersion.
+type AdmissionPolicyClient struct {
+	client dynamic.Interface
+}
+
+// NewAdmissionPolicyClient returns an AdmissionPolicyClient backed by the given dynamic client.
+func NewAdmissionPolicyClient(client dynamic.Interface) *AdmissionPolicyClient {
+	return &AdmissionPolicyClient{client: client}
+}
+
+// EnsurePolicies creates or updates the policies and bindings described by descs and deletes any
+// previously created for owner that are no longer described.
+func (c *AdmissionPolicyClient) EnsurePolicies(ctx context.Context, owner ownerutil.Owner, descs []ValidatingAdmissionPolicyDescription) error {
+	if err := c.cleanUpRemovedPolicies(ctx, owner, descs); err != nil {
+		return err
+	}
+
+	for _, desc := range descs {
+		policy := &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": ValidatingAdmissionPolicyGVR.GroupVersion().String(),
+			"kind":       "ValidatingAdmissionPolicy",
+			"spec":       desc.Policy,
+		}}
+		if err := c.createOrUpdate(ctx, ValidatingAdmissionPolicyGVR, owner, desc, policy); err != nil {
+			return err
+		}
+
+		bindingSpec := map[string]interface{}{}
+		for k, v := range desc.Binding {
+			bindingSpec[k] = v
+		}
+		bindingSpec["policyName"] = desc.Name
+		if _, ok := bindingSpec["validationActions"]; !ok {
+			bindingSpec["validationActions"] = []interface{}{"Deny"}
+		}
+		binding := &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": ValidatingAdmissionPolicyBindingGVR.GroupVersion().String(),
+			"kind":       "ValidatingAdmissionPolicyBinding",
+			"spec":       bindingSpec,
+		}}
+		if err := c.createOrUpdate(ctx, ValidatingAdmissionPolicyBindingGVR, owner, desc, binding); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DeletePolicies deletes all policies and bindings created for owner.
+func (c *AdmissionPolicyClient) DeletePolicies(ctx context.Context, owner ownerutil.Owner) error {
+	return c.cleanUpRemovedPolicies(ctx, owner, nil)
+}
+
+func (c *AdmissionPolicyClient) createOrUpdate(ctx context.Context, gvr schema.GroupVersionResource, owner ownerutil.Owner, desc ValidatingAdmissionPolicyDescription, obj *unstructured.Unstructured) error {
+	obj.SetName(desc.Name)
+	objLabels := ownerutil.OwnerLabel(owner, v1alpha1.ClusterServiceVersionKind)
+	objLabels[AdmissionPolicyDescKey] = desc.Name
+	obj.SetLabels(objLabels)
+
+	existing, err := c.client.Resource(gvr).Get(ctx, desc.Name, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		_, err = c.client.Resource(gvr).Create(ctx, obj, metav1.CreateOptions{})
+		return err
+	}
+	if err != nil {
+		return err
+	}
+
+	// Refuse to take over objects that weren't created by OLM for this CSV. Policies are cluster-scoped and named
+	// after their description, so another CSV may declare the same name.
+	if _, ok := existing.GetLabels()[AdmissionPolicyDescKey]; !ok {
+		return OwnerConflictError{fmt.Sprintf("%s %s already exists and is not managed by OLM", obj.GetKind(), desc.Name)}
+	}
+	if !ownedByCSVLabels(existing, owner) {
+		return OwnerConflictError{fmt.Sprintf("%s %s already exists and is owned by csv %s/%s", obj.GetKind(), desc.Name, existing.GetLabels()[ownerutil.OwnerNamespaceKey], existing.GetLabels()[ownerutil.OwnerKey])}
+	}
+
+	obj.SetResourceVersion(existing.GetResourceVersion())
+	_, err = c.client.Resource(gvr).Update(ctx, obj, metav1.UpdateOptions{})
+	return err
+}
+
+// ownedByCSVLabels returns true if the owner labels of the given object name the given CSV.
+func ownedByCSVLabels(obj metav1.Object, owner ownerutil.Owner) bool {
+	objLabels := obj.GetLabels()
+	return objLabels[ownerutil.OwnerKind] == v1alpha1.ClusterServiceVersionKind &&
+		objLabels[ownerutil.OwnerKey] == owner.GetName() &&
+		objLabels[ownerutil.OwnerNamespaceKey] == owner.GetNamespace()
+}
+
+func (c *AdmissionPolicyClient) cleanUpRemovedPolicies(ctx context.Context, owner ownerutil.Owner, descs []ValidatingAdmissionPolicyDescription) error {
+	names := make(map[string]struct{}, len(descs))
+	for _, desc := range descs {
+		names[desc.Name] = struct{}{}
+	}
+
+	selector := labels.SelectorFromSet(ownerutil.OwnerLabel(owner, v1alpha1.ClusterServiceVersionKind)).String()
+	for _, gvr := range []schema.GroupVersionResource{ValidatingAdmissionPolicyBindingGVR, ValidatingAdmissionPolicyGVR} {
+		list, err := c.client.Resource(gvr).List(ctx, metav1.ListOptions{LabelSelector: selector})
+		if k8serrors.IsNotFound(err) {
+			// The cluster doesn't serve ValidatingAdmissionPolicies, so there's nothing to clean up.
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		for _, obj := range list.Items {
+			if _, ok := names[obj.GetLabels()[AdmissionPolicyDescKey]]; ok {
+				continue
+			}
+			if err := c.client.Resource(gvr).Delete(ctx, obj.GetName(), metav1.DeleteOptions{}); err != nil && !k8serrors.IsNotFound(err) {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
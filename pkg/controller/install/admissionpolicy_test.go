@@ -0,0 +1,129 @@
+package install
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+)
+
+func policyDesc(name string, apiGroups ...interface{}) ValidatingAdmissionPolicyDescription {
+	return ValidatingAdmissionPolicyDescription{
+		Name: name,
+		Policy: map[string]interface{}{
+			"matchConstraints": map[string]interface{}{
+				"resourceRules": []interface{}{
+					map[string]interface{}{
+						"apiGroups": apiGroups,
+						"resources": []interface{}{"deployments"},
+					},
+				},
+			},
+			"validations": []interface{}{
+				map[string]interface{}{"expression": "object.spec.replicas <= 5"},
+			},
+		},
+	}
+}
+
+func TestValidatingAdmissionPolicyDescriptions(t *testing.T) {
+	csv := &v1alpha1.ClusterServiceVersion{ObjectMeta: metav1.ObjectMeta{
+		Annotations: map[string]string{
+			ValidatingAdmissionPoliciesAnnotationKey: `[{"name":"replicas","policy":{"failurePolicy":"Fail"}}]`,
+		},
+	}}
+	descs, err := ValidatingAdmissionPolicyDescriptions(csv)
+	require.NoError(t, err)
+	require.Len(t, descs, 1)
+	require.Equal(t, "replicas", descs[0].Name)
+	require.Equal(t, "Fail", descs[0].Policy["failurePolicy"])
+
+	csv.Annotations[ValidatingAdmissionPoliciesAnnotationKey] = `{`
+	_, err = ValidatingAdmissionPolicyDescriptions(csv)
+	require.Error(t, err)
+}
+
+func TestValidAdmissionPolicyDescriptions(t *testing.T) {
+	tests := []struct {
+		description string
+		descs       []ValidatingAdmissionPolicyDescription
+		wantErr     bool
+	}{
+		{
+			description: "Valid",
+			descs:       []ValidatingAdmissionPolicyDescription{policyDesc("a", "apps"), policyDesc("b", "apps")},
+		},
+		{
+			description: "RepeatedName",
+			descs:       []ValidatingAdmissionPolicyDescription{policyDesc("a", "apps"), policyDesc("a", "apps")},
+			wantErr:     true,
+		},
+		{
+			description: "MissingName",
+			descs:       []ValidatingAdmissionPolicyDescription{policyDesc("", "apps")},
+			wantErr:     true,
+		},
+		{
+			description: "MatchesOLMGroup",
+			descs:       []ValidatingAdmissionPolicyDescription{policyDesc("a", "operators.coreos.com")},
+			wantErr:     true,
+		},
+		{
+			description: "MatchesAllGroups",
+			descs:       []ValidatingAdmissionPolicyDescription{policyDesc("a", "*")},
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			err := ValidAdmissionPolicyDescriptions(tt.descs)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestAdmissionPolicyClientEnsurePolicies(t *testing.T) {
+	client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		ValidatingAdmissionPolicyGVR:        "ValidatingAdmissionPolicyList",
+		ValidatingAdmissionPolicyBindingGVR: "ValidatingAdmissionPolicyBindingList",
+	})
+	c := NewAdmissionPolicyClient(client)
+	owner := &v1alpha1.ClusterServiceVersion{ObjectMeta: metav1.ObjectMeta{Name: "csv", Namespace: "ns"}}
+
+	require.NoError(t, c.EnsurePolicies(context.TODO(), owner, []ValidatingAdmissionPolicyDescription{policyDesc("a", "apps"), policyDesc("b", "apps")}))
+
+	binding, err := client.Resource(ValidatingAdmissionPolicyBindingGVR).Get(context.TODO(), "a", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, "csv", binding.GetLabels()["olm.owner"])
+	require.Equal(t, "a", binding.Object["spec"].(map[string]interface{})["policyName"])
+
+	// Removing a description deletes its policy and binding
+	require.NoError(t, c.EnsurePolicies(context.TODO(), owner, []ValidatingAdmissionPolicyDescription{policyDesc("a", "apps")}))
+	policies, err := client.Resource(ValidatingAdmissionPolicyGVR).List(context.TODO(), metav1.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, policies.Items, 1)
+	require.Equal(t, "a", policies.Items[0].GetName())
+
+	// Another CSV declaring the same name doesn't take over the policy
+	other := &v1alpha1.ClusterServiceVersion{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "ns"}}
+	err = c.EnsurePolicies(context.TODO(), other, []ValidatingAdmissionPolicyDescription{policyDesc("a", "batch")})
+	require.ErrorAs(t, err, &OwnerConflictError{})
+	policy, err := client.Resource(ValidatingAdmissionPolicyGVR).Get(context.TODO(), "a", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, "csv", policy.GetLabels()["olm.owner"])
+
+	require.NoError(t, c.DeletePolicies(context.TODO(), owner))
+	bindings, err := client.Resource(ValidatingAdmissionPolicyBindingGVR).List(context.TODO(), metav1.ListOptions{})
+	require.NoError(t, err)
+	require.Empty(t, bindings.Items)
+}
@@ -1,6 +1,7 @@
 package install
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"strings"
@@ -15,7 +16,7 @@ import (
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/ownerutil"
 )
 
-func (i *StrategyDeploymentInstaller) createOrUpdateAPIService(caPEM []byte, desc v1alpha1.APIServiceDescription) error {
+func (i *StrategyDeploymentInstaller) createOrUpdateAPIService(ctx context.Context, caPEM []byte, desc v1alpha1.APIServiceDescription) error {
 	apiServiceName := fmt.Sprintf("%s.%s", desc.Version, desc.Group)
 	logger := log.WithFields(log.Fields{
 		"owner":      i.owner.GetName(),
@@ -79,10 +80,10 @@ func (i *StrategyDeploymentInstaller) createOrUpdateAPIService(caPEM []byte, des
 	// attempt a update or create
 	if exists {
 		logger.Debug("updating APIService")
-		_, err = i.strategyClient.GetOpClient().UpdateAPIService(apiService)
+		_, err = i.strategyClient.GetOpClient().UpdateAPIService(ctx, apiService)
 	} else {
 		logger.Debug("creating APIService")
-		_, err = i.strategyClient.GetOpClient().CreateAPIService(apiService)
+		_, err = i.strategyClient.GetOpClient().CreateAPIService(ctx, apiService)
 	}
 
 	if err != nil {
@@ -153,7 +154,7 @@ func IsAPIServiceAvailable(apiService *apiregistrationv1.APIService) bool {
 }
 
 // deleteLegacyAPIServiceResources deletes resources that were created by OLM for an APIService that used the old naming convention.
-func (i *StrategyDeploymentInstaller) deleteLegacyAPIServiceResources(desc apiServiceDescriptionsWithCAPEM) error {
+func (i *StrategyDeploymentInstaller) deleteLegacyAPIServiceResources(ctx context.Context, desc apiServiceDescriptionsWithCAPEM) error {
 	logger := log.WithFields(log.Fields{
 		"ownerName":      i.owner.GetName(),
 		"ownerNamespace": i.owner.GetNamespace(),
@@ -176,14 +177,14 @@ func (i *StrategyDeploymentInstaller) deleteLegacyAPIServiceResources(desc apiSe
 	legacyServiceName := legacyAPIServiceNameToServiceName(apiServiceName)
 	if legacyServiceName != ServiceName(desc.apiServiceDescription.DeploymentName) {
 		// Attempt to delete the legacy Service.
-		existingService, err := i.strategyClient.GetOpClient().GetService(namespace, legacyServiceName)
+		existingService, err := i.strategyClient.GetOpClient().GetService(ctx, namespace, legacyServiceName)
 		if err != nil {
 			if !k8serrors.IsNotFound(err) {
 				return err
 			}
 		} else if ownerutil.AdoptableLabels(existingService.GetLabels(), true, i.owner) {
 			logger.Infof("Deleting Service with legacy APIService name %s", existingService.Name)
-			err = i.strategyClient.GetOpClient().DeleteService(namespace, legacyServiceName, &metav1.DeleteOptions{})
+			err = i.strategyClient.GetOpClient().DeleteService(ctx, namespace, legacyServiceName, &metav1.DeleteOptions{})
 			if err != nil && !k8serrors.IsNotFound(err) {
 				return err
 			}
@@ -195,14 +196,14 @@ func (i *StrategyDeploymentInstaller) deleteLegacyAPIServiceResources(desc apiSe
 	}
 
 	// Attempt to delete the legacy Secret.
-	existingSecret, err := i.strategyClient.GetOpClient().GetSecret(namespace, SecretName(apiServiceName))
+	existingSecret, err := i.strategyClient.GetOpClient().GetSecret(ctx, namespace, SecretName(apiServiceName))
 	if err != nil {
 		if !k8serrors.IsNotFound(err) {
 			return err
 		}
 	} else if ownerutil.AdoptableLabels(existingSecret.GetLabels(), true, i.owner) {
 		logger.Infof("Deleting Secret with legacy APIService name %s", existingSecret.Name)
-		err = i.strategyClient.GetOpClient().DeleteSecret(namespace, SecretName(apiServiceName), &metav1.DeleteOptions{})
+		err = i.strategyClient.GetOpClient().DeleteSecret(ctx, namespace, SecretName(apiServiceName), &metav1.DeleteOptions{})
 		if err != nil && !k8serrors.IsNotFound(err) {
 			return err
 		}
@@ -211,14 +212,14 @@ func (i *StrategyDeploymentInstaller) deleteLegacyAPIServiceResources(desc apiSe
 	}
 
 	// Attempt to delete the legacy Role.
-	existingRole, err := i.strategyClient.GetOpClient().GetRole(namespace, SecretName(apiServiceName))
+	existingRole, err := i.strategyClient.GetOpClient().GetRole(ctx, namespace, SecretName(apiServiceName))
 	if err != nil {
 		if !k8serrors.IsNotFound(err) {
 			return err
 		}
 	} else if ownerutil.AdoptableLabels(existingRole.GetLabels(), true, i.owner) {
 		logger.Infof("Deleting Role with legacy APIService name %s", existingRole.Name)
-		err = i.strategyClient.GetOpClient().DeleteRole(namespace, SecretName(apiServiceName), &metav1.DeleteOptions{})
+		err = i.strategyClient.GetOpClient().DeleteRole(ctx, namespace, SecretName(apiServiceName), &metav1.DeleteOptions{})
 		if err != nil && !k8serrors.IsNotFound(err) {
 			return err
 		}
@@ -227,14 +228,14 @@ func (i *StrategyDeploymentInstaller) deleteLegacyAPIServiceResources(desc apiSe
 	}
 
 	// Attempt to delete the legacy secret RoleBinding.
-	existingRoleBinding, err := i.strategyClient.GetOpClient().GetRoleBinding(namespace, SecretName(apiServiceName))
+	existingRoleBinding, err := i.strategyClient.GetOpClient().GetRoleBinding(ctx, namespace, SecretName(apiServiceName))
 	if err != nil {
 		if !k8serrors.IsNotFound(err) {
 			return err
 		}
 	} else if ownerutil.AdoptableLabels(existingRoleBinding.GetLabels(), true, i.owner) {
 		logger.Infof("Deleting RoleBinding with legacy APIService name %s", existingRoleBinding.Name)
-		err = i.strategyClient.GetOpClient().DeleteRoleBinding(namespace, SecretName(apiServiceName), &metav1.DeleteOptions{})
+		err = i.strategyClient.GetOpClient().DeleteRoleBinding(ctx, namespace, SecretName(apiServiceName), &metav1.DeleteOptions{})
 		if err != nil && !k8serrors.IsNotFound(err) {
 			return err
 		}
@@ -243,14 +244,14 @@ func (i *StrategyDeploymentInstaller) deleteLegacyAPIServiceResources(desc apiSe
 	}
 
 	// Attempt to delete the legacy ClusterRoleBinding.
-	existingClusterRoleBinding, err := i.strategyClient.GetOpClient().GetClusterRoleBinding(apiServiceName + "-system:auth-delegator")
+	existingClusterRoleBinding, err := i.strategyClient.GetOpClient().GetClusterRoleBinding(ctx, apiServiceName+"-system:auth-delegator")
 	if err != nil {
 		if !k8serrors.IsNotFound(err) {
 			return err
 		}
 	} else if ownerutil.AdoptableLabels(existingClusterRoleBinding.GetLabels(), true, i.owner) {
 		logger.Infof("Deleting ClusterRoleBinding with legacy APIService name %s", existingClusterRoleBinding.Name)
-		err = i.strategyClient.GetOpClient().DeleteClusterRoleBinding(apiServiceName+"-system:auth-delegator", &metav1.DeleteOptions{})
+		err = i.strategyClient.GetOpClient().DeleteClusterRoleBinding(ctx, apiServiceName+"-system:auth-delegator", &metav1.DeleteOptions{})
 		if err != nil && !k8serrors.IsNotFound(err) {
 			return err
 		}
@@ -259,14 +260,14 @@ func (i *StrategyDeploymentInstaller) deleteLegacyAPIServiceResources(desc apiSe
 	}
 
 	// Attempt to delete the legacy AuthReadingRoleBinding.
-	existingRoleBinding, err = i.strategyClient.GetOpClient().GetRoleBinding(KubeSystem, apiServiceName+"-auth-reader")
+	existingRoleBinding, err = i.strategyClient.GetOpClient().GetRoleBinding(ctx, KubeSystem, apiServiceName+"-auth-reader")
 	if err != nil {
 		if !k8serrors.IsNotFound(err) {
 			return err
 		}
 	} else if ownerutil.AdoptableLabels(existingRoleBinding.GetLabels(), true, i.owner) {
 		logger.Infof("Deleting RoleBinding with legacy APIService name %s", existingRoleBinding.Name)
-		err = i.strategyClient.GetOpClient().DeleteRoleBinding(KubeSystem, apiServiceName+"-auth-reader", &metav1.DeleteOptions{})
+		err = i.strategyClient.GetOpClient().DeleteRoleBinding(ctx, KubeSystem, apiServiceName+"-auth-reader", &metav1.DeleteOptions{})
 		if err != nil && !k8serrors.IsNotFound(err) {
 			return err
 		}
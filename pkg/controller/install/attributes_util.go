@@ -1,6 +1,8 @@
 package install
 
 import (
+	"strings"
+
 	log "github.com/sirupsen/logrus"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
@@ -58,12 +60,18 @@ func toAttributesSet(user user.Info, namespace string, rule rbacv1.PolicyRule) [
 // attribute creates a new AttributesRecord with the given info. Currently RBAC authz only looks at user, verb, apiGroup, resource, and name.
 func attributesRecord(user user.Info, namespace, verb, apiGroup, resource, name, path string) authorizer.AttributesRecord {
 	resourceRequest := path == ""
+	// split subresources (e.g. "deployments/scale") as the API server does, so that "*/scale" grants match them
+	var subresource string
+	if i := strings.Index(resource, "/"); i >= 0 {
+		resource, subresource = resource[:i], resource[i+1:]
+	}
 	return authorizer.AttributesRecord{
 		User:            user,
 		Verb:            verb,
 		Namespace:       namespace,
 		APIGroup:        apiGroup,
 		Resource:        resource,
+		Subresource:     subresource,
 		Name:            name,
 		ResourceRequest: resourceRequest,
 		Path:            path,
@@ -71,9 +79,10 @@ func attributesRecord(user user.Info, namespace, verb, apiGroup, resource, name,
 }
 
 func toDefaultInfo(sa *corev1.ServiceAccount) *user.DefaultInfo {
-	// TODO(Nick): add Group if necessary
+	// include the groups the API server authenticates every ServiceAccount with, so that bindings to them apply
 	return &user.DefaultInfo{
-		Name: serviceaccount.MakeUsername(sa.GetNamespace(), sa.GetName()),
-		UID:  string(sa.GetUID()),
+		Name:   serviceaccount.MakeUsername(sa.GetNamespace(), sa.GetName()),
+		UID:    string(sa.GetUID()),
+		Groups: append(serviceaccount.MakeGroupNames(sa.GetNamespace()), user.AllAuthenticated),
 	}
 }
@@ -0,0 +1,210 @@
+package install
+
+import (
+	"context"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apiregistrationv1 "k8s.io/kube-aggregator/pkg/apis/apiregistration/v1"
+
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/api/wrappers"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/audit"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/operatorclient"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/ownerutil"
+)
+
+// auditor records the Deployments, RoleBindings, ClusterRoleBindings, APIServices and Secrets created, updated and
+// deleted on behalf of a CSV.
+type auditor struct {
+	recorder *audit.Recorder
+	owner    ownerutil.Owner
+}
+
+// record records the given mutation of a resource, whose changes are summarized if both of its versions are given.
+func (a auditor) record(action audit.Action, kind, namespace, name string, old, new interface{}) {
+	m := audit.Mutation{
+		Action:    action,
+		Kind:      kind,
+		Namespace: namespace,
+		Name:      name,
+		CSV:       a.owner.GetName(),
+	}
+	if old != nil && new != nil {
+		changes, err := audit.Changes(old, new)
+		if err != nil {
+			return
+		}
+		if len(changes) == 0 {
+			return
+		}
+		m.Changes = changes
+	}
+	a.recorder.Record(a.owner, m)
+}
+
+// auditedStrategyClient is an InstallStrategyDeploymentInterface recording the mutations made through it.
+type auditedStrategyClient struct {
+	wrappers.InstallStrategyDeploymentInterface
+	auditor
+}
+
+func newAuditedStrategyClient(client wrappers.InstallStrategyDeploymentInterface, recorder *audit.Recorder, owner ownerutil.Owner) wrappers.InstallStrategyDeploymentInterface {
+	return &auditedStrategyClient{
+		InstallStrategyDeploymentInterface: client,
+		auditor:                            auditor{recorder: recorder, owner: owner},
+	}
+}
+
+func (c *auditedStrategyClient) GetOpClient() operatorclient.ClientInterface {
+	return &auditedOpClient{
+		ClientInterface: c.InstallStrategyDeploymentInterface.GetOpClient(),
+		auditor:         c.auditor,
+	}
+}
+
+func (c *auditedStrategyClient) CreateRoleBinding(ctx context.Context, roleBinding *rbacv1.RoleBinding) (*rbacv1.RoleBinding, error) {
+	created, err := c.InstallStrategyDeploymentInterface.CreateRoleBinding(ctx, roleBinding)
+	if err == nil {
+		c.record(audit.Created, "RoleBinding", created.GetNamespace(), created.GetName(), nil, nil)
+	}
+	return created, err
+}
+
+func (c *auditedStrategyClient) CreateDeployment(ctx context.Context, deployment *appsv1.Deployment) (*appsv1.Deployment, error) {
+	created, err := c.InstallStrategyDeploymentInterface.CreateDeployment(ctx, deployment)
+	if err == nil {
+		c.record(audit.Created, "Deployment", created.GetNamespace(), created.GetName(), nil, nil)
+	}
+	return created, err
+}
+
+func (c *auditedStrategyClient) CreateOrUpdateDeployment(ctx context.Context, deployment *appsv1.Deployment) (*appsv1.Deployment, error) {
+	old, getErr := c.InstallStrategyDeploymentInterface.GetOpClient().GetDeployment(ctx, deployment.GetNamespace(), deployment.GetName())
+	applied, err := c.InstallStrategyDeploymentInterface.CreateOrUpdateDeployment(ctx, deployment)
+	if err != nil {
+		return applied, err
+	}
+	if getErr != nil {
+		c.record(audit.Created, "Deployment", applied.GetNamespace(), applied.GetName(), nil, nil)
+	} else {
+		c.record(audit.Updated, "Deployment", applied.GetNamespace(), applied.GetName(), old, applied)
+	}
+	return applied, nil
+}
+
+func (c *auditedStrategyClient) DeleteDeployment(ctx context.Context, name string) error {
+	err := c.InstallStrategyDeploymentInterface.DeleteDeployment(ctx, name)
+	if err == nil {
+		c.record(audit.Deleted, "Deployment", c.owner.GetNamespace(), name, nil, nil)
+	}
+	return err
+}
+
+// auditedOpClient is a ClientInterface recording the mutations of the Secrets, RoleBindings, ClusterRoleBindings and
+// APIServices made through it.
+type auditedOpClient struct {
+	operatorclient.ClientInterface
+	auditor
+}
+
+func (c *auditedOpClient) CreateSecret(ctx context.Context, secret *corev1.Secret) (*corev1.Secret, error) {
+	created, err := c.ClientInterface.CreateSecret(ctx, secret)
+	if err == nil {
+		c.record(audit.Created, "Secret", created.GetNamespace(), created.GetName(), nil, nil)
+	}
+	return created, err
+}
+
+func (c *auditedOpClient) UpdateSecret(ctx context.Context, secret *corev1.Secret) (*corev1.Secret, error) {
+	old, getErr := c.ClientInterface.GetSecret(ctx, secret.GetNamespace(), secret.GetName())
+	updated, err := c.ClientInterface.UpdateSecret(ctx, secret)
+	if err == nil && getErr == nil {
+		c.record(audit.Updated, "Secret", updated.GetNamespace(), updated.GetName(), old, updated)
+	}
+	return updated, err
+}
+
+func (c *auditedOpClient) DeleteSecret(ctx context.Context, namespace, name string, options *metav1.DeleteOptions) error {
+	err := c.ClientInterface.DeleteSecret(ctx, namespace, name, options)
+	if err == nil {
+		c.record(audit.Deleted, "Secret", namespace, name, nil, nil)
+	}
+	return err
+}
+
+func (c *auditedOpClient) CreateRoleBinding(ctx context.Context, roleBinding *rbacv1.RoleBinding) (*rbacv1.RoleBinding, error) {
+	created, err := c.ClientInterface.CreateRoleBinding(ctx, roleBinding)
+	if err == nil {
+		c.record(audit.Created, "RoleBinding", created.GetNamespace(), created.GetName(), nil, nil)
+	}
+	return created, err
+}
+
+func (c *auditedOpClient) UpdateRoleBinding(ctx context.Context, roleBinding *rbacv1.RoleBinding) (*rbacv1.RoleBinding, error) {
+	old, getErr := c.ClientInterface.GetRoleBinding(ctx, roleBinding.GetNamespace(), roleBinding.GetName())
+	updated, err := c.ClientInterface.UpdateRoleBinding(ctx, roleBinding)
+	if err == nil && getErr == nil {
+		c.record(audit.Updated, "RoleBinding", updated.GetNamespace(), updated.GetName(), old, updated)
+	}
+	return updated, err
+}
+
+func (c *auditedOpClient) DeleteRoleBinding(ctx context.Context, namespace, name string, options *metav1.DeleteOptions) error {
+	err := c.ClientInterface.DeleteRoleBinding(ctx, namespace, name, options)
+	if err == nil {
+		c.record(audit.Deleted, "RoleBinding", namespace, name, nil, nil)
+	}
+	return err
+}
+
+func (c *auditedOpClient) CreateClusterRoleBinding(ctx context.Context, roleBinding *rbacv1.ClusterRoleBinding) (*rbacv1.ClusterRoleBinding, error) {
+	created, err := c.ClientInterface.CreateClusterRoleBinding(ctx, roleBinding)
+	if err == nil {
+		c.record(audit.Created, "ClusterRoleBinding", "", created.GetName(), nil, nil)
+	}
+	return created, err
+}
+
+func (c *auditedOpClient) UpdateClusterRoleBinding(ctx context.Context, roleBinding *rbacv1.ClusterRoleBinding) (*rbacv1.ClusterRoleBinding, error) {
+	old, getErr := c.ClientInterface.GetClusterRoleBinding(ctx, roleBinding.GetName())
+	updated, err := c.ClientInterface.UpdateClusterRoleBinding(ctx, roleBinding)
+	if err == nil && getErr == nil {
+		c.record(audit.Updated, "ClusterRoleBinding", "", updated.GetName(), old, updated)
+	}
+	return updated, err
+}
+
+func (c *auditedOpClient) DeleteClusterRoleBinding(ctx context.Context, name string, options *metav1.DeleteOptions) error {
+	err := c.ClientInterface.DeleteClusterRoleBinding(ctx, name, options)
+	if err == nil {
+		c.record(audit.Deleted, "ClusterRoleBinding", "", name, nil, nil)
+	}
+	return err
+}
+
+func (c *auditedOpClient) CreateAPIService(ctx context.Context, apiService *apiregistrationv1.APIService) (*apiregistrationv1.APIService, error) {
+	created, err := c.ClientInterface.CreateAPIService(ctx, apiService)
+	if err == nil {
+		c.record(audit.Created, "APIService", "", created.GetName(), nil, nil)
+	}
+	return created, err
+}
+
+func (c *auditedOpClient) UpdateAPIService(ctx context.Context, apiService *apiregistrationv1.APIService) (*apiregistrationv1.APIService, error) {
+	old, getErr := c.ClientInterface.GetAPIService(ctx, apiService.GetName())
+	updated, err := c.ClientInterface.UpdateAPIService(ctx, apiService)
+	if err == nil && getErr == nil {
+		c.record(audit.Updated, "APIService", "", updated.GetName(), old, updated)
+	}
+	return updated, err
+}
+
+func (c *auditedOpClient) DeleteAPIService(ctx context.Context, name string, options *metav1.DeleteOptions) error {
+	err := c.ClientInterface.DeleteAPIService(ctx, name, options)
+	if err == nil {
+		c.record(audit.Deleted, "APIService", "", name, nil, nil)
+	}
+	return err
+}
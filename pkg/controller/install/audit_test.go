@@ -0,0 +1,59 @@
+package install
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/api/wrappers"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/audit"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/operatorclient"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/operatorlister"
+)
+
+func TestAuditedStrategyClient(t *testing.T) {
+	csv := &v1alpha1.ClusterServiceVersion{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "operator.v1"}}
+	opClient := operatorclient.NewClient(k8sfake.NewSimpleClientset(), nil, nil)
+	events := record.NewFakeRecorder(10)
+	logger, _ := test.NewNullLogger()
+	client := newAuditedStrategyClient(wrappers.NewInstallStrategyDeploymentClient(opClient, operatorlister.NewLister(), "ns"), audit.NewRecorder(events, logger), csv)
+
+	deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "operator"}}
+	_, err := client.CreateOrUpdateDeployment(context.TODO(), deployment)
+	require.NoError(t, err)
+	require.Equal(t, "Normal ResourceCreated created Deployment ns/operator for csv operator.v1", <-events.Events)
+
+	_, err = client.CreateOrUpdateDeployment(context.TODO(), deployment.DeepCopy())
+	require.NoError(t, err)
+	require.Empty(t, events.Events, "unchanged resources aren't recorded")
+
+	replicas := int32(2)
+	deployment.Spec.Replicas = &replicas
+	_, err = client.CreateOrUpdateDeployment(context.TODO(), deployment)
+	require.NoError(t, err)
+	require.Equal(t, "Normal ResourceUpdated updated Deployment ns/operator for csv operator.v1: changed spec.replicas", <-events.Events)
+
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "operator-service-cert"}, Data: map[string][]byte{"tls.crt": []byte("old")}}
+	_, err = client.GetOpClient().CreateSecret(context.TODO(), secret)
+	require.NoError(t, err)
+	require.Equal(t, "Normal ResourceCreated created Secret ns/operator-service-cert for csv operator.v1", <-events.Events)
+
+	secret.Data["tls.crt"] = []byte("new")
+	_, err = client.GetOpClient().UpdateSecret(context.TODO(), secret)
+	require.NoError(t, err)
+	require.Equal(t, "Normal ResourceUpdated updated Secret ns/operator-service-cert for csv operator.v1: changed data.tls.crt", <-events.Events)
+
+	require.NoError(t, client.GetOpClient().DeleteSecret(context.TODO(), "ns", "operator-service-cert", &metav1.DeleteOptions{}))
+	require.Equal(t, "Normal ResourceDeleted deleted Secret ns/operator-service-cert for csv operator.v1", <-events.Events)
+
+	require.NoError(t, client.DeleteDeployment(context.TODO(), "operator"))
+	require.Equal(t, "Normal ResourceDeleted deleted Deployment ns/operator for csv operator.v1", <-events.Events)
+}
@@ -0,0 +1,206 @@
+package install
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/ownerutil"
+)
+
+// AutoscalingAnnotationKey is the CSV annotation declaring, as a JSON object keyed by deployment name, the
+// HorizontalPodAutoscalers OLM renders for the deployments of its install strategy.
+const AutoscalingAnnotationKey = "operatorframework.io/autoscaling"
+
+// Autoscaling configures the HorizontalPodAutoscaler of a deployment of an install strategy. The replicas of an
+// autoscaled deployment are left to the autoscaler once it exists: OLM only sets them when it creates the deployment.
+type Autoscaling struct {
+	// MinReplicas defaults to 1.
+	MinReplicas *int32                                              `json:"minReplicas,omitempty"`
+	MaxReplicas int32                                               `json:"maxReplicas"`
+	Metrics     []autoscalingv2beta2.MetricSpec                     `json:"metrics,omitempty"`
+	Behavior    *autoscalingv2beta2.HorizontalPodAutoscalerBehavior `json:"behavior,omitempty"`
+}
+
+// AutoscalingFor returns the autoscaling the given owner declares for its deployments, keyed by deployment name.
+func AutoscalingFor(owner ownerutil.Owner) (map[string]Autoscaling, error) {
+	value, ok := owner.GetAnnotations()[AutoscalingAnnotationKey]
+	if !ok {
+		return nil, nil
+	}
+	var autoscaling map[string]Autoscaling
+	if err := json.Unmarshal([]byte(value), &autoscaling); err != nil {
+		return nil, fmt.Errorf("invalid %s annotation: %v", AutoscalingAnnotationKey, err)
+	}
+	for name, a := range autoscaling {
+		if a.MaxReplicas < 1 {
+			return nil, fmt.Errorf("invalid %s annotation: maxReplicas of deployment %s must be at least 1", AutoscalingAnnotationKey, name)
+		}
+		if a.MinReplicas != nil && (*a.MinReplicas < 1 || *a.MinReplicas > a.MaxReplicas) {
+			return nil, fmt.Errorf("invalid %s annotation: minReplicas of deployment %s must be between 1 and maxReplicas", AutoscalingAnnotationKey, name)
+		}
+	}
+	return autoscaling, nil
+}
+
+// horizontalPodAutoscaler returns the HorizontalPodAutoscaler of the named deployment of the given owner, named after
+// the deployment and owned by the owner.
+func horizontalPodAutoscaler(owner ownerutil.Owner, deploymentName string, a Autoscaling) *autoscalingv2beta2.HorizontalPodAutoscaler {
+	hpa := &autoscalingv2beta2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      deploymentName,
+			Namespace: owner.GetNamespace(),
+			Labels:    map[string]string{OLMManagedLabelKey: OLMManagedLabelValue},
+		},
+		Spec: autoscalingv2beta2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2beta2.CrossVersionObjectReference{
+				APIVersion: appsv1.SchemeGroupVersion.String(),
+				Kind:       "Deployment",
+				Name:       deploymentName,
+			},
+			MinReplicas: a.MinReplicas,
+			MaxReplicas: a.MaxReplicas,
+			Metrics:     a.Metrics,
+			Behavior:    a.Behavior,
+		},
+	}
+	ownerutil.AddNonBlockingOwner(hpa, owner)
+	ownerutil.AddOwnerLabelsForKind(hpa, owner, v1alpha1.ClusterServiceVersionKind)
+	return hpa
+}
+
+// autoscalerMatches returns true if the spec of the existing HorizontalPodAutoscaler matches the wanted one. Fields
+// the wanted spec leaves unset are defaulted by the API server, so they match any value.
+func autoscalerMatches(existing, wanted *autoscalingv2beta2.HorizontalPodAutoscaler) bool {
+	e, w := existing.Spec, wanted.Spec
+	if w.MinReplicas == nil {
+		e.MinReplicas = nil
+	}
+	if len(w.Metrics) == 0 {
+		e.Metrics = nil
+	}
+	if w.Behavior == nil {
+		e.Behavior = nil
+	}
+	return equality.Semantic.DeepEqual(e, w)
+}
+
+// autoscaling returns the autoscaling of the deployments of the strategy, which only applies to Deployments.
+func (i *StrategyDeploymentInstaller) autoscaling() (map[string]Autoscaling, error) {
+	if backend := i.owner.GetAnnotations()[WorkloadBackendAnnotationKey]; backend != "" && backend != DeploymentWorkloadBackend {
+		return nil, nil
+	}
+	autoscaling, err := AutoscalingFor(i.owner)
+	if err != nil {
+		return nil, StrategyError{Reason: StrategyErrReasonInvalidStrategy, Message: err.Error()}
+	}
+	return autoscaling, nil
+}
+
+// keepAutoscaledReplicas sets the replicas of the given deployment to those of the existing deployment, if any, so
+// that the scaling decisions of its autoscaler aren't reverted.
+func (i *StrategyDeploymentInstaller) keepAutoscaledReplicas(ctx context.Context, deployment *appsv1.Deployment) error {
+	existing, err := i.strategyClient.FindAnyDeploymentsMatchingNames([]string{deployment.GetName()})
+	if err != nil {
+		return err
+	}
+	for _, d := range existing {
+		if d.Spec.Replicas != nil {
+			replicas := *d.Spec.Replicas
+			deployment.Spec.Replicas = &replicas
+		}
+	}
+	return nil
+}
+
+// installAutoscalers creates or updates the HorizontalPodAutoscalers of the autoscaled deployments, and deletes those
+// of the deployments no longer autoscaled.
+func (i *StrategyDeploymentInstaller) installAutoscalers(ctx context.Context, deps []v1alpha1.StrategyDeploymentSpec) error {
+	autoscaling, err := i.autoscaling()
+	if err != nil {
+		return err
+	}
+	client := i.strategyClient.GetOpClient().KubernetesInterface().AutoscalingV2beta2().HorizontalPodAutoscalers(i.owner.GetNamespace())
+
+	wanted := map[string]struct{}{}
+	for _, d := range deps {
+		a, ok := autoscaling[d.Name]
+		if !ok {
+			continue
+		}
+		wanted[d.Name] = struct{}{}
+
+		hpa := horizontalPodAutoscaler(i.owner, d.Name, a)
+		existing, err := client.Get(ctx, hpa.GetName(), metav1.GetOptions{})
+		if k8serrors.IsNotFound(err) {
+			if _, err := client.Create(ctx, hpa, metav1.CreateOptions{}); err != nil {
+				return err
+			}
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if autoscalerMatches(existing, hpa) && equality.Semantic.DeepEqual(existing.GetOwnerReferences(), hpa.GetOwnerReferences()) {
+			continue
+		}
+		existing.Spec = hpa.Spec
+		existing.SetOwnerReferences(hpa.GetOwnerReferences())
+		existing.SetLabels(hpa.GetLabels())
+		if _, err := client.Update(ctx, existing, metav1.UpdateOptions{}); err != nil {
+			return err
+		}
+	}
+
+	csv, ok := i.owner.(*v1alpha1.ClusterServiceVersion)
+	if !ok {
+		return nil
+	}
+	owned, err := client.List(ctx, metav1.ListOptions{LabelSelector: ownerutil.CSVOwnerSelector(csv).String()})
+	if err != nil {
+		return err
+	}
+	for _, hpa := range owned.Items {
+		if _, ok := wanted[hpa.GetName()]; ok || !ownerutil.IsOwnedBy(&hpa, i.owner) {
+			continue
+		}
+		if err := client.Delete(ctx, hpa.GetName(), metav1.DeleteOptions{}); err != nil && !k8serrors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkForAutoscalers returns an error if the HorizontalPodAutoscaler of an autoscaled deployment is missing or
+// doesn't match its autoscaling.
+func (i *StrategyDeploymentInstaller) checkForAutoscalers(ctx context.Context, deps []v1alpha1.StrategyDeploymentSpec) error {
+	autoscaling, err := i.autoscaling()
+	if err != nil || len(autoscaling) == 0 {
+		return err
+	}
+	client := i.strategyClient.GetOpClient().KubernetesInterface().AutoscalingV2beta2().HorizontalPodAutoscalers(i.owner.GetNamespace())
+	for _, d := range deps {
+		a, ok := autoscaling[d.Name]
+		if !ok {
+			continue
+		}
+		existing, err := client.Get(ctx, d.Name, metav1.GetOptions{})
+		if k8serrors.IsNotFound(err) {
+			return StrategyError{Reason: StrategyErrReasonComponentMissing, Message: fmt.Sprintf("missing horizontal pod autoscaler with name=%s", d.Name)}
+		}
+		if err != nil {
+			return StrategyError{Reason: StrategyErrReasonComponentMissing, Message: fmt.Sprintf("error querying horizontal pod autoscaler %s: %s", d.Name, err)}
+		}
+		if !autoscalerMatches(existing, horizontalPodAutoscaler(i.owner, d.Name, a)) {
+			return StrategyError{Reason: StrategyErrDeploymentUpdated, Message: fmt.Sprintf("horizontal pod autoscaler %s changed", d.Name)}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package install
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/api/wrappers/wrappersfakes"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/operatorclient"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/ownerutil"
+)
+
+func TestAutoscalingFor(t *testing.T) {
+	csv := func(annotation string) *v1alpha1.ClusterServiceVersion {
+		return &v1alpha1.ClusterServiceVersion{ObjectMeta: metav1.ObjectMeta{
+			Name:        "csv",
+			Namespace:   "ns",
+			Annotations: map[string]string{AutoscalingAnnotationKey: annotation},
+		}}
+	}
+
+	autoscaling, err := AutoscalingFor(&v1alpha1.ClusterServiceVersion{})
+	require.NoError(t, err)
+	require.Empty(t, autoscaling)
+
+	autoscaling, err = AutoscalingFor(csv(`{"operator":{"minReplicas":2,"maxReplicas":5}}`))
+	require.NoError(t, err)
+	require.Equal(t, int32(2), *autoscaling["operator"].MinReplicas)
+	require.Equal(t, int32(5), autoscaling["operator"].MaxReplicas)
+
+	for _, invalid := range []string{
+		`not json`,
+		`{"operator":{}}`,
+		`{"operator":{"minReplicas":6,"maxReplicas":5}}`,
+		`{"operator":{"minReplicas":0,"maxReplicas":5}}`,
+	} {
+		_, err := AutoscalingFor(csv(invalid))
+		require.Error(t, err, invalid)
+	}
+}
+
+func TestInstallAutoscalers(t *testing.T) {
+	kubeClient := k8sfake.NewSimpleClientset()
+	client := &wrappersfakes.FakeInstallStrategyDeploymentInterface{}
+	client.GetOpClientReturns(operatorclient.NewClient(kubeClient, nil, nil))
+	csv := &v1alpha1.ClusterServiceVersion{
+		TypeMeta: metav1.TypeMeta{Kind: v1alpha1.ClusterServiceVersionKind, APIVersion: v1alpha1.ClusterServiceVersionAPIVersion},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "csv",
+			Namespace:   "ns",
+			UID:         "uid",
+			Annotations: map[string]string{AutoscalingAnnotationKey: `{"operator":{"maxReplicas":3}}`},
+		},
+	}
+	installer := NewStrategyDeploymentInstaller(client, nil, csv, nil, nil, nil, nil).(*StrategyDeploymentInstaller)
+	deps := []v1alpha1.StrategyDeploymentSpec{{Name: "operator"}, {Name: "webhook"}}
+	hpas := kubeClient.AutoscalingV2beta2().HorizontalPodAutoscalers("ns")
+
+	require.Error(t, installer.checkForAutoscalers(context.TODO(), deps), "the autoscaler is missing")
+
+	require.NoError(t, installer.installAutoscalers(context.TODO(), deps))
+	hpa, err := hpas.Get(context.TODO(), "operator", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, "Deployment", hpa.Spec.ScaleTargetRef.Kind)
+	require.Equal(t, "operator", hpa.Spec.ScaleTargetRef.Name)
+	require.Equal(t, int32(3), hpa.Spec.MaxReplicas)
+	require.True(t, ownerutil.IsOwnedBy(hpa, csv))
+	require.True(t, ownerutil.CSVOwnerSelector(csv).Matches(labels.Set(hpa.GetLabels())))
+	_, err = hpas.Get(context.TODO(), "webhook", metav1.GetOptions{})
+	require.Error(t, err, "only autoscaled deployments get an autoscaler")
+	require.NoError(t, installer.checkForAutoscalers(context.TODO(), deps))
+
+	// Fields defaulted by the API server don't count as changes
+	minReplicas := int32(1)
+	hpa.Spec.MinReplicas = &minReplicas
+	hpa.Spec.Behavior = &autoscalingv2beta2.HorizontalPodAutoscalerBehavior{}
+	_, err = hpas.Update(context.TODO(), hpa, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	require.NoError(t, installer.checkForAutoscalers(context.TODO(), deps))
+
+	// A changed autoscaling updates the autoscaler
+	csv.Annotations[AutoscalingAnnotationKey] = `{"operator":{"maxReplicas":10}}`
+	require.Error(t, installer.checkForAutoscalers(context.TODO(), deps))
+	require.NoError(t, installer.installAutoscalers(context.TODO(), deps))
+	hpa, err = hpas.Get(context.TODO(), "operator", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, int32(10), hpa.Spec.MaxReplicas)
+
+	// Deployments no longer autoscaled lose their autoscaler
+	delete(csv.Annotations, AutoscalingAnnotationKey)
+	require.NoError(t, installer.installAutoscalers(context.TODO(), deps))
+	list, err := hpas.List(context.TODO(), metav1.ListOptions{})
+	require.NoError(t, err)
+	require.Empty(t, list.Items)
+}
+
+func TestKeepAutoscaledReplicas(t *testing.T) {
+	client := &wrappersfakes.FakeInstallStrategyDeploymentInterface{}
+	owner := &v1alpha1.ClusterServiceVersion{ObjectMeta: metav1.ObjectMeta{Name: "csv", Namespace: "ns"}}
+	installer := NewStrategyDeploymentInstaller(client, nil, owner, nil, nil, nil, nil).(*StrategyDeploymentInstaller)
+
+	one, four := int32(1), int32(4)
+	deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "operator"}, Spec: appsv1.DeploymentSpec{Replicas: &one}}
+	require.NoError(t, installer.keepAutoscaledReplicas(context.TODO(), deployment))
+	require.Equal(t, one, *deployment.Spec.Replicas, "new deployments get the replicas of the strategy")
+
+	client.FindAnyDeploymentsMatchingNamesReturns([]*appsv1.Deployment{{Spec: appsv1.DeploymentSpec{Replicas: &four}}}, nil)
+	require.NoError(t, installer.keepAutoscaledReplicas(context.TODO(), deployment))
+	require.Equal(t, four, *deployment.Spec.Replicas, "existing deployments keep the replicas of their autoscaler")
+}
@@ -0,0 +1,79 @@
+package install
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/ownerutil"
+)
+
+const (
+	// CertValidForAnnotationKey overrides how long the certs OLM generates for a CSV's
+	// APIServices and webhooks are valid for, as a duration string (e.g. "2160h").
+	// It can be set on a CSV, or on the cluster OLMConfig to change the default for all CSVs.
+	CertValidForAnnotationKey = "operatorframework.io/cert-valid-for"
+
+	// CertMinFreshAnnotationKey overrides how long before expiry OLM rotates the certs it generates,
+	// as a duration string (e.g. "72h"). Like CertValidForAnnotationKey, it can be set on a CSV or on the
+	// cluster OLMConfig.
+	CertMinFreshAnnotationKey = "operatorframework.io/cert-min-fresh"
+)
+
+// CertLifetime describes the validity of the certs OLM generates and when they're rotated.
+type CertLifetime struct {
+	// ValidFor is how long a generated cert is valid for.
+	ValidFor time.Duration
+
+	// MinFresh is how long before expiry a cert is rotated.
+	MinFresh time.Duration
+}
+
+// CertLifetimeFunc returns the CertLifetime of the certs generated for the given owner.
+type CertLifetimeFunc func(owner ownerutil.Owner) CertLifetime
+
+// DefaultCertLifetime returns the CertLifetime used when none is configured.
+func DefaultCertLifetime() CertLifetime {
+	return CertLifetime{ValidFor: DefaultCertValidFor, MinFresh: DefaultCertMinFresh}
+}
+
+// RotateAt returns the time at which a cert issued at the given time should be rotated.
+func (l CertLifetime) RotateAt(issued time.Time) time.Time {
+	return issued.Add(l.ValidFor).Add(-1 * l.MinFresh)
+}
+
+// Validate returns an error if certs with this lifetime would never be fresh.
+func (l CertLifetime) Validate() error {
+	if l.ValidFor <= 0 {
+		return fmt.Errorf("cert validity %s must be positive", l.ValidFor)
+	}
+	if l.MinFresh < 0 {
+		return fmt.Errorf("cert min-fresh %s must not be negative", l.MinFresh)
+	}
+	if l.MinFresh >= l.ValidFor {
+		return fmt.Errorf("cert min-fresh %s must be shorter than cert validity %s", l.MinFresh, l.ValidFor)
+	}
+	return nil
+}
+
+// CertLifetimeFromAnnotations returns base with any overrides set in the given annotations applied.
+func CertLifetimeFromAnnotations(base CertLifetime, annotations map[string]string) (CertLifetime, error) {
+	lifetime := base
+	if value, ok := annotations[CertValidForAnnotationKey]; ok {
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return base, fmt.Errorf("invalid %s annotation: %v", CertValidForAnnotationKey, err)
+		}
+		lifetime.ValidFor = d
+	}
+	if value, ok := annotations[CertMinFreshAnnotationKey]; ok {
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return base, fmt.Errorf("invalid %s annotation: %v", CertMinFreshAnnotationKey, err)
+		}
+		lifetime.MinFresh = d
+	}
+	if err := lifetime.Validate(); err != nil {
+		return base, err
+	}
+	return lifetime, nil
+}
@@ -0,0 +1,68 @@
+package install
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCertLifetimeFromAnnotations(t *testing.T) {
+	base := DefaultCertLifetime()
+	tests := []struct {
+		description string
+		annotations map[string]string
+		want        CertLifetime
+		wantErr     bool
+	}{
+		{
+			description: "NoOverrides",
+			want:        base,
+		},
+		{
+			description: "ValidFor",
+			annotations: map[string]string{CertValidForAnnotationKey: "2160h"},
+			want:        CertLifetime{ValidFor: 2160 * time.Hour, MinFresh: DefaultCertMinFresh},
+		},
+		{
+			description: "Both",
+			annotations: map[string]string{CertValidForAnnotationKey: "48h", CertMinFreshAnnotationKey: "12h"},
+			want:        CertLifetime{ValidFor: 48 * time.Hour, MinFresh: 12 * time.Hour},
+		},
+		{
+			description: "Malformed",
+			annotations: map[string]string{CertValidForAnnotationKey: "two years"},
+			want:        base,
+			wantErr:     true,
+		},
+		{
+			description: "NeverFresh",
+			annotations: map[string]string{CertValidForAnnotationKey: "24h"},
+			want:        base,
+			wantErr:     true,
+		},
+		{
+			description: "NegativeMinFresh",
+			annotations: map[string]string{CertMinFreshAnnotationKey: "-1h"},
+			want:        base,
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			got, err := CertLifetimeFromAnnotations(base, tt.annotations)
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCertLifetimeRotateAt(t *testing.T) {
+	issued := time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC)
+	lifetime := CertLifetime{ValidFor: 48 * time.Hour, MinFresh: 12 * time.Hour}
+	require.Equal(t, issued.Add(36*time.Hour), lifetime.RotateAt(issued))
+}
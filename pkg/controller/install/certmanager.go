@@ -0,0 +1,177 @@
+package install
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/certs"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/ownerutil"
+)
+
+const (
+	// CertManagerIssuerAnnotationKey is the CSV annotation naming the cert-manager issuer that issues the serving certs
+	// of its APIServices and webhooks, as "Issuer/<name>" for an Issuer in the CSV's namespace or "ClusterIssuer/<name>".
+	// It's only honored while cert-manager integration is enabled on the cluster OLMConfig.
+	CertManagerIssuerAnnotationKey = "operatorframework.io/cert-manager-issuer"
+
+	// CertManagerEnabledAnnotationKey is the OLMConfig annotation that, when "true", lets CSVs delegate the issuance of
+	// their serving certs to cert-manager.
+	CertManagerEnabledAnnotationKey = "operatorframework.io/cert-manager-enabled"
+
+	// certManagerCAKey is the key cert-manager stores the CA of an issued cert under.
+	certManagerCAKey = "ca.crt"
+)
+
+// CertificateGVR is the cert-manager Certificate resource.
+var CertificateGVR = schema.GroupVersionResource{Group: "cert-manager.io", Version: "v1", Resource: "certificates"}
+
+// CertManagerIssuer references the cert-manager issuer of a CSV's serving certs.
+type CertManagerIssuer struct {
+	Kind string
+	Name string
+}
+
+// CertManagerIssuerFunc returns the cert-manager issuer of the serving certs of the given owner, or nil if OLM should
+// sign them itself.
+type CertManagerIssuerFunc func(owner ownerutil.Owner) *CertManagerIssuer
+
+// CertManagerIssuerFor returns the issuer requested by the owner's annotations, or nil if none is requested.
+func CertManagerIssuerFor(owner ownerutil.Owner) (*CertManagerIssuer, error) {
+	value, ok := owner.GetAnnotations()[CertManagerIssuerAnnotationKey]
+	if !ok {
+		return nil, nil
+	}
+
+	parts := strings.SplitN(value, "/", 2)
+	if len(parts) != 2 || parts[1] == "" {
+		return nil, fmt.Errorf("invalid %s annotation %q: must be of the form <kind>/<name>", CertManagerIssuerAnnotationKey, value)
+	}
+	switch parts[0] {
+	case "Issuer", "ClusterIssuer":
+	default:
+		return nil, fmt.Errorf("invalid %s annotation %q: kind must be Issuer or ClusterIssuer", CertManagerIssuerAnnotationKey, value)
+	}
+
+	return &CertManagerIssuer{Kind: parts[0], Name: parts[1]}, nil
+}
+
+func (i *StrategyDeploymentInstaller) installCertManagerRequirementsForDeployment(ctx context.Context, deploymentName string, depSpec appsv1.DeploymentSpec, ports []corev1.ServicePort) (*appsv1.DeploymentSpec, []byte, error) {
+	service, err := i.installCertService(ctx, deploymentName, depSpec, ports)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	secret, caPEM, err := i.installCertManagerCertSecret(ctx, service)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := i.installCertSecretRBAC(ctx, service, secret, &depSpec); err != nil {
+		return nil, nil, err
+	}
+	AddDefaultCertVolumeAndVolumeMounts(&depSpec, secret.GetName())
+
+	SetCAAnnotation(&depSpec, certs.PEMSHA256(caPEM))
+	return &depSpec, caPEM, nil
+}
+
+// installCertManagerCertSecret creates or updates a cert-manager Certificate for the given Service, and returns the Secret
+// cert-manager issued it into along with the PEM of the issuing CA. The Secret is annotated like the ones holding
+// self-signed certs, so that it's checked and rotated the same way.
+func (i *StrategyDeploymentInstaller) installCertManagerCertSecret(ctx context.Context, service *corev1.Service) (*corev1.Secret, []byte, error) {
+	logger := log.WithFields(log.Fields{})
+	secretName := SecretName(service.GetName())
+	lifetime := i.lifetime()
+
+	certificate := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"secretName": secretName,
+			"dnsNames": []interface{}{
+				fmt.Sprintf("%s.%s", service.GetName(), i.owner.GetNamespace()),
+				fmt.Sprintf("%s.%s.svc", service.GetName(), i.owner.GetNamespace()),
+			},
+			"duration":    lifetime.ValidFor.String(),
+			"renewBefore": lifetime.MinFresh.String(),
+			"issuerRef": map[string]interface{}{
+				"group": CertificateGVR.Group,
+				"kind":  i.certIssuer.Kind,
+				"name":  i.certIssuer.Name,
+			},
+			"secretTemplate": map[string]interface{}{
+				"labels": map[string]interface{}{OLMManagedLabelKey: OLMManagedLabelValue},
+			},
+		},
+	}}
+	certificate.SetAPIVersion(CertificateGVR.GroupVersion().String())
+	certificate.SetKind("Certificate")
+	certificate.SetName(secretName)
+	certificate.SetNamespace(i.owner.GetNamespace())
+
+	certificates := i.certManagerClient.Resource(CertificateGVR).Namespace(i.owner.GetNamespace())
+	existing, err := certificates.Get(ctx, certificate.GetName(), metav1.GetOptions{})
+	if err == nil {
+		if !ownerutil.Adoptable(i.owner, existing.GetOwnerReferences()) {
+			return nil, nil, fmt.Errorf("certificate %s not safe to replace: extraneous ownerreferences found", certificate.GetName())
+		}
+		certificate.SetOwnerReferences(existing.GetOwnerReferences())
+		ownerutil.AddNonBlockingOwner(certificate, i.owner)
+		certificate.SetResourceVersion(existing.GetResourceVersion())
+		if _, err := certificates.Update(ctx, certificate, metav1.UpdateOptions{}); err != nil {
+			logger.Warnf("could not update certificate %s", certificate.GetName())
+			return nil, nil, err
+		}
+	} else if k8serrors.IsNotFound(err) {
+		ownerutil.AddNonBlockingOwner(certificate, i.owner)
+		if _, err := certificates.Create(ctx, certificate, metav1.CreateOptions{}); err != nil {
+			logger.Warnf("could not create certificate %s", certificate.GetName())
+			return nil, nil, err
+		}
+	} else {
+		return nil, nil, err
+	}
+
+	// The Secret isn't read from the cache, since it's only cached once cert-manager has applied the secret template
+	secret, err := i.strategyClient.GetOpClient().KubernetesInterface().CoreV1().Secrets(i.owner.GetNamespace()).Get(ctx, secretName, metav1.GetOptions{})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return nil, nil, err
+	}
+	if err != nil || len(secret.Data[corev1.TLSCertKey]) == 0 {
+		return nil, nil, StrategyError{Reason: StrategyErrReasonWaiting, Message: fmt.Sprintf("waiting for cert-manager to issue certificate %s", secretName)}
+	}
+	caPEM := secret.Data[certManagerCAKey]
+	if len(caPEM) == 0 {
+		return nil, nil, fmt.Errorf("secret %s issued by cert-manager has no %s: the issuer must publish its CA", secretName, certManagerCAKey)
+	}
+
+	caHash := certs.PEMSHA256(caPEM)
+	if secret.GetAnnotations()[OLMCAHashAnnotationKey] == caHash && bytes.Equal(secret.Data[OLMCAPEMKey], caPEM) && secret.GetLabels()[OLMManagedLabelKey] == OLMManagedLabelValue {
+		return secret, caPEM, nil
+	}
+
+	secret = secret.DeepCopy()
+	if secret.Annotations == nil {
+		secret.Annotations = map[string]string{}
+	}
+	secret.Annotations[OLMCAHashAnnotationKey] = caHash
+	if secret.Labels == nil {
+		secret.Labels = map[string]string{}
+	}
+	secret.Labels[OLMManagedLabelKey] = OLMManagedLabelValue
+	secret.Data[OLMCAPEMKey] = caPEM
+	if secret, err = i.strategyClient.GetOpClient().UpdateSecret(ctx, secret); err != nil {
+		logger.Warnf("could not update secret %s", secretName)
+		return nil, nil, err
+	}
+
+	return secret, caPEM, nil
+}
@@ -0,0 +1,96 @@
+package install
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/api/wrappers/wrappersfakes"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/operatorclient"
+)
+
+func TestCertManagerIssuerFor(t *testing.T) {
+	tests := []struct {
+		annotation string
+		want       *CertManagerIssuer
+		wantErr    bool
+	}{
+		{annotation: "", wantErr: true},
+		{annotation: "ClusterIssuer/corp-ca", want: &CertManagerIssuer{Kind: "ClusterIssuer", Name: "corp-ca"}},
+		{annotation: "Issuer/local", want: &CertManagerIssuer{Kind: "Issuer", Name: "local"}},
+		{annotation: "Issuer/", wantErr: true},
+		{annotation: "Secret/ca", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.annotation, func(t *testing.T) {
+			csv := &v1alpha1.ClusterServiceVersion{ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{CertManagerIssuerAnnotationKey: tt.annotation},
+			}}
+			got, err := CertManagerIssuerFor(csv)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+
+	issuer, err := CertManagerIssuerFor(&v1alpha1.ClusterServiceVersion{})
+	require.NoError(t, err)
+	require.Nil(t, issuer)
+}
+
+func TestInstallCertManagerCertSecret(t *testing.T) {
+	kubeClient := k8sfake.NewSimpleClientset()
+	strategyClient := &wrappersfakes.FakeInstallStrategyDeploymentInterface{}
+	strategyClient.GetOpClientReturns(operatorclient.NewClient(kubeClient, nil, nil))
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		CertificateGVR: "CertificateList",
+	})
+	owner := &v1alpha1.ClusterServiceVersion{ObjectMeta: metav1.ObjectMeta{Name: "csv", Namespace: "ns", UID: "uid"}}
+	installer := &StrategyDeploymentInstaller{
+		strategyClient:    strategyClient,
+		owner:             owner,
+		certIssuer:        &CertManagerIssuer{Kind: "ClusterIssuer", Name: "corp-ca"},
+		certManagerClient: dynamicClient,
+	}
+	service := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "operator-service", Namespace: "ns"}}
+
+	// Until cert-manager issues the cert, the install waits
+	_, _, err := installer.installCertManagerCertSecret(context.TODO(), service)
+	require.Error(t, err)
+	require.Equal(t, StrategyErrReasonWaiting, ReasonForError(err))
+
+	certificate, err := dynamicClient.Resource(CertificateGVR).Namespace("ns").Get(context.TODO(), "operator-service-cert", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, "csv", certificate.GetOwnerReferences()[0].Name)
+	issuerRef := certificate.Object["spec"].(map[string]interface{})["issuerRef"].(map[string]interface{})
+	require.Equal(t, "ClusterIssuer", issuerRef["kind"])
+	require.Equal(t, "corp-ca", issuerRef["name"])
+
+	_, err = kubeClient.CoreV1().Secrets("ns").Create(context.TODO(), &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "operator-service-cert", Namespace: "ns"},
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       []byte("cert"),
+			corev1.TLSPrivateKeyKey: []byte("key"),
+			certManagerCAKey:        []byte("ca"),
+		},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	secret, caPEM, err := installer.installCertManagerCertSecret(context.TODO(), service)
+	require.NoError(t, err)
+	require.Equal(t, []byte("ca"), caPEM)
+	require.Equal(t, []byte("ca"), secret.Data[OLMCAPEMKey])
+	require.NotEmpty(t, secret.GetAnnotations()[OLMCAHashAnnotationKey])
+	require.Equal(t, OLMManagedLabelValue, secret.GetLabels()[OLMManagedLabelKey])
+}
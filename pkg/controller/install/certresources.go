@@ -1,6 +1,7 @@
 package install
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 	"time"
@@ -175,7 +176,7 @@ func (i *StrategyDeploymentInstaller) updateCertResourcesForDeployment(deploymen
 	}
 }
 
-func (i *StrategyDeploymentInstaller) installCertRequirements(strategy Strategy) (*v1alpha1.StrategyDetailsDeployment, error) {
+func (i *StrategyDeploymentInstaller) installCertRequirements(ctx context.Context, strategy Strategy) (*v1alpha1.StrategyDetailsDeployment, error) {
 	logger := log.WithFields(log.Fields{})
 
 	// Assume the strategy is for a deployment
@@ -184,14 +185,38 @@ func (i *StrategyDeploymentInstaller) installCertRequirements(strategy Strategy)
 		return nil, fmt.Errorf("unsupported InstallStrategy type")
 	}
 
-	// Create the CA
-	expiration := time.Now().Add(DefaultCertValidFor)
-	ca, err := certs.GenerateCA(expiration, Organization)
+	// Operators managing their own serving certs only need the Services and their CA bundle injected
+	caBundle, selfManaged, err := SelfManagedCABundle(i.owner)
 	if err != nil {
-		logger.Debug("failed to generate CA")
 		return nil, err
 	}
-	rotateAt := expiration.Add(-1 * DefaultCertMinFresh)
+	if selfManaged {
+		for _, sddSpec := range strategyDetailsDeployment.DeploymentSpecs {
+			certResources := i.certResourcesForDeployment(sddSpec.Name)
+			if len(certResources) == 0 {
+				continue
+			}
+			if _, err := i.installCertService(ctx, sddSpec.Name, sddSpec.Spec, getServicePorts(certResources)); err != nil {
+				return nil, err
+			}
+			i.updateCertResourcesForDeployment(sddSpec.Name, caBundle)
+		}
+		return strategyDetailsDeployment, nil
+	}
+
+	// Create the CA, unless the serving certs are issued by cert-manager
+	var ca *certs.KeyPair
+	var rotateAt time.Time
+	if i.certIssuer == nil {
+		lifetime := i.lifetime()
+		now := time.Now()
+		var err error
+		if ca, err = certs.GenerateCA(now.Add(lifetime.ValidFor), Organization); err != nil {
+			logger.Debug("failed to generate CA")
+			return nil, err
+		}
+		rotateAt = lifetime.RotateAt(now)
+	}
 
 	for n, sddSpec := range strategyDetailsDeployment.DeploymentSpecs {
 		certResources := i.certResourcesForDeployment(sddSpec.Name)
@@ -202,7 +227,14 @@ func (i *StrategyDeploymentInstaller) installCertRequirements(strategy Strategy)
 		}
 
 		// Update the deployment for each certResource
-		newDepSpec, caPEM, err := i.installCertRequirementsForDeployment(sddSpec.Name, ca, rotateAt, sddSpec.Spec, getServicePorts(certResources))
+		var newDepSpec *appsv1.DeploymentSpec
+		var caPEM []byte
+		var err error
+		if i.certIssuer != nil {
+			newDepSpec, caPEM, err = i.installCertManagerRequirementsForDeployment(ctx, sddSpec.Name, sddSpec.Spec, getServicePorts(certResources))
+		} else {
+			newDepSpec, caPEM, err = i.installCertRequirementsForDeployment(ctx, sddSpec.Name, ca, rotateAt, sddSpec.Spec, getServicePorts(certResources))
+		}
 		if err != nil {
 			return nil, err
 		}
@@ -223,7 +255,30 @@ func ShouldRotateCerts(csv *v1alpha1.ClusterServiceVersion) bool {
 	return false
 }
 
-func (i *StrategyDeploymentInstaller) installCertRequirementsForDeployment(deploymentName string, ca *certs.KeyPair, rotateAt time.Time, depSpec appsv1.DeploymentSpec, ports []corev1.ServicePort) (*appsv1.DeploymentSpec, []byte, error) {
+func (i *StrategyDeploymentInstaller) installCertRequirementsForDeployment(ctx context.Context, deploymentName string, ca *certs.KeyPair, rotateAt time.Time, depSpec appsv1.DeploymentSpec, ports []corev1.ServicePort) (*appsv1.DeploymentSpec, []byte, error) {
+	service, err := i.installCertService(ctx, deploymentName, depSpec, ports)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	secret, caPEM, err := i.installSelfSignedCertSecret(ctx, service, ca, rotateAt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := i.installCertSecretRBAC(ctx, service, secret, &depSpec); err != nil {
+		return nil, nil, err
+	}
+	AddDefaultCertVolumeAndVolumeMounts(&depSpec, secret.GetName())
+
+	// Setting the olm hash label forces a rollout and ensures that the new secret
+	// is used by the apiserver if not hot reloading.
+	SetCAAnnotation(&depSpec, certs.PEMSHA256(caPEM))
+	return &depSpec, caPEM, nil
+}
+
+// installCertService creates the Service fronting the given deployment's APIServices and webhooks.
+func (i *StrategyDeploymentInstaller) installCertService(ctx context.Context, deploymentName string, depSpec appsv1.DeploymentSpec, ports []corev1.ServicePort) (*corev1.Service, error) {
 	logger := log.WithFields(log.Fields{})
 
 	// Create a service for the deployment
@@ -240,24 +295,32 @@ func (i *StrategyDeploymentInstaller) installCertRequirementsForDeployment(deplo
 	existingService, err := i.strategyClient.GetOpLister().CoreV1().ServiceLister().Services(i.owner.GetNamespace()).Get(service.GetName())
 	if err == nil {
 		if !ownerutil.Adoptable(i.owner, existingService.GetOwnerReferences()) {
-			return nil, nil, fmt.Errorf("service %s not safe to replace: extraneous ownerreferences found", service.GetName())
+			return nil, fmt.Errorf("service %s not safe to replace: extraneous ownerreferences found", service.GetName())
 		}
 		service.SetOwnerReferences(existingService.GetOwnerReferences())
 
 		// Delete the Service to replace
-		deleteErr := i.strategyClient.GetOpClient().DeleteService(service.GetNamespace(), service.GetName(), &metav1.DeleteOptions{})
+		deleteErr := i.strategyClient.GetOpClient().DeleteService(ctx, service.GetNamespace(), service.GetName(), &metav1.DeleteOptions{})
 		if err != nil && !k8serrors.IsNotFound(deleteErr) {
-			return nil, nil, fmt.Errorf("could not delete existing service %s", service.GetName())
+			return nil, fmt.Errorf("could not delete existing service %s", service.GetName())
 		}
 	}
 
 	// Attempt to create the Service
-	_, err = i.strategyClient.GetOpClient().CreateService(service)
+	_, err = i.strategyClient.GetOpClient().CreateService(ctx, service)
 	if err != nil {
 		logger.Warnf("could not create service %s", service.GetName())
-		return nil, nil, fmt.Errorf("could not create service %s: %s", service.GetName(), err.Error())
+		return nil, fmt.Errorf("could not create service %s: %s", service.GetName(), err.Error())
 	}
 
+	return service, nil
+}
+
+// installSelfSignedCertSecret creates or updates the Secret holding a serving cert for the given Service, signed by the given CA.
+// It returns the Secret and the PEM of the CA that signed the cert it holds.
+func (i *StrategyDeploymentInstaller) installSelfSignedCertSecret(ctx context.Context, service *corev1.Service, ca *certs.KeyPair, rotateAt time.Time) (*corev1.Secret, []byte, error) {
+	logger := log.WithFields(log.Fields{})
+
 	// Create signed serving cert
 	hosts := []string{
 		fmt.Sprintf("%s.%s", service.GetName(), i.owner.GetNamespace()),
@@ -310,22 +373,21 @@ func (i *StrategyDeploymentInstaller) installCertRequirementsForDeployment(deplo
 			logger.Warnf("reusing existing cert %s", secret.GetName())
 			secret = existingSecret
 			caPEM = existingCAPEM
-			caHash = certs.PEMSHA256(caPEM)
-		} else if _, err := i.strategyClient.GetOpClient().UpdateSecret(secret); err != nil {
+		} else if _, err := i.strategyClient.GetOpClient().UpdateSecret(ctx, secret); err != nil {
 			logger.Warnf("could not update secret %s", secret.GetName())
 			return nil, nil, err
 		}
 	} else if k8serrors.IsNotFound(err) {
 		// Create the secret
 		ownerutil.AddNonBlockingOwner(secret, i.owner)
-		if _, err := i.strategyClient.GetOpClient().CreateSecret(secret); err != nil {
+		if _, err := i.strategyClient.GetOpClient().CreateSecret(ctx, secret); err != nil {
 			if !k8serrors.IsAlreadyExists(err) {
 				log.Warnf("could not create secret %s: %v", secret.GetName(), err)
 				return nil, nil, err
 			}
 			// if the secret isn't in the cache but exists in the cluster, it's missing the labels for the cache filter
 			// and just needs to be updated
-			if _, err := i.strategyClient.GetOpClient().UpdateSecret(secret); err != nil {
+			if _, err := i.strategyClient.GetOpClient().UpdateSecret(ctx, secret); err != nil {
 				log.Warnf("could not update secret %s: %v", secret.GetName(), err)
 				return nil, nil, err
 			}
@@ -334,6 +396,14 @@ func (i *StrategyDeploymentInstaller) installCertRequirementsForDeployment(deplo
 		return nil, nil, err
 	}
 
+	return secret, caPEM, nil
+}
+
+// installCertSecretRBAC grants the service account of the given deployment access to the cert Secret, and the permissions
+// needed to delegate authentication and authorization to the kube-apiserver.
+func (i *StrategyDeploymentInstaller) installCertSecretRBAC(ctx context.Context, service *corev1.Service, secret *corev1.Secret, depSpec *appsv1.DeploymentSpec) error {
+	logger := log.WithFields(log.Fields{})
+
 	// create Role and RoleBinding to allow the deployment to mount the Secret
 	secretRole := &rbacv1.Role{
 		Rules: []rbacv1.PolicyRule{
@@ -356,20 +426,20 @@ func (i *StrategyDeploymentInstaller) installCertRequirementsForDeployment(deplo
 		}
 
 		// Attempt an update
-		if _, err := i.strategyClient.GetOpClient().UpdateRole(secretRole); err != nil {
+		if _, err := i.strategyClient.GetOpClient().UpdateRole(ctx, secretRole); err != nil {
 			logger.Warnf("could not update secret role %s", secretRole.GetName())
-			return nil, nil, err
+			return err
 		}
 	} else if k8serrors.IsNotFound(err) {
 		// Create the role
 		ownerutil.AddNonBlockingOwner(secretRole, i.owner)
-		_, err = i.strategyClient.GetOpClient().CreateRole(secretRole)
+		_, err = i.strategyClient.GetOpClient().CreateRole(ctx, secretRole)
 		if err != nil {
 			log.Warnf("could not create secret role %s", secretRole.GetName())
-			return nil, nil, err
+			return err
 		}
 	} else {
-		return nil, nil, err
+		return err
 	}
 
 	if depSpec.Template.Spec.ServiceAccountName == "" {
@@ -402,20 +472,20 @@ func (i *StrategyDeploymentInstaller) installCertRequirementsForDeployment(deplo
 		}
 
 		// Attempt an update
-		if _, err := i.strategyClient.GetOpClient().UpdateRoleBinding(secretRoleBinding); err != nil {
+		if _, err := i.strategyClient.GetOpClient().UpdateRoleBinding(ctx, secretRoleBinding); err != nil {
 			logger.Warnf("could not update secret rolebinding %s", secretRoleBinding.GetName())
-			return nil, nil, err
+			return err
 		}
 	} else if k8serrors.IsNotFound(err) {
 		// Create the role
 		ownerutil.AddNonBlockingOwner(secretRoleBinding, i.owner)
-		_, err = i.strategyClient.GetOpClient().CreateRoleBinding(secretRoleBinding)
+		_, err = i.strategyClient.GetOpClient().CreateRoleBinding(ctx, secretRoleBinding)
 		if err != nil {
-			log.Warnf("could not create secret rolebinding with dep spec: %#v", depSpec)
-			return nil, nil, err
+			log.Warnf("could not create secret rolebinding with dep spec: %#v", *depSpec)
+			return err
 		}
 	} else {
-		return nil, nil, err
+		return err
 	}
 
 	// create ClusterRoleBinding to system:auth-delegator Role
@@ -442,27 +512,27 @@ func (i *StrategyDeploymentInstaller) installCertRequirementsForDeployment(deplo
 		if ownerutil.AdoptableLabels(existingAuthDelegatorClusterRoleBinding.GetLabels(), true, i.owner) {
 			logger.WithFields(log.Fields{"obj": "authDelegatorCRB", "labels": existingAuthDelegatorClusterRoleBinding.GetLabels()}).Debug("adopting")
 			if err := ownerutil.AddOwnerLabels(authDelegatorClusterRoleBinding, i.owner); err != nil {
-				return nil, nil, err
+				return err
 			}
 		}
 
 		// Attempt an update.
-		if _, err := i.strategyClient.GetOpClient().UpdateClusterRoleBinding(authDelegatorClusterRoleBinding); err != nil {
+		if _, err := i.strategyClient.GetOpClient().UpdateClusterRoleBinding(ctx, authDelegatorClusterRoleBinding); err != nil {
 			logger.Warnf("could not update auth delegator clusterrolebinding %s", authDelegatorClusterRoleBinding.GetName())
-			return nil, nil, err
+			return err
 		}
 	} else if k8serrors.IsNotFound(err) {
 		// Create the role.
 		if err := ownerutil.AddOwnerLabels(authDelegatorClusterRoleBinding, i.owner); err != nil {
-			return nil, nil, err
+			return err
 		}
-		_, err = i.strategyClient.GetOpClient().CreateClusterRoleBinding(authDelegatorClusterRoleBinding)
+		_, err = i.strategyClient.GetOpClient().CreateClusterRoleBinding(ctx, authDelegatorClusterRoleBinding)
 		if err != nil {
 			log.Warnf("could not create auth delegator clusterrolebinding %s", authDelegatorClusterRoleBinding.GetName())
-			return nil, nil, err
+			return err
 		}
 	} else {
-		return nil, nil, err
+		return err
 	}
 
 	// Create RoleBinding to extension-apiserver-authentication-reader Role in the kube-system namespace.
@@ -490,33 +560,28 @@ func (i *StrategyDeploymentInstaller) installCertRequirementsForDeployment(deplo
 		if ownerutil.AdoptableLabels(existingAuthReaderRoleBinding.GetLabels(), true, i.owner) {
 			logger.WithFields(log.Fields{"obj": "existingAuthReaderRB", "labels": existingAuthReaderRoleBinding.GetLabels()}).Debug("adopting")
 			if err := ownerutil.AddOwnerLabels(authReaderRoleBinding, i.owner); err != nil {
-				return nil, nil, err
+				return err
 			}
 		}
 		// Attempt an update.
-		if _, err := i.strategyClient.GetOpClient().UpdateRoleBinding(authReaderRoleBinding); err != nil {
+		if _, err := i.strategyClient.GetOpClient().UpdateRoleBinding(ctx, authReaderRoleBinding); err != nil {
 			logger.Warnf("could not update auth reader role binding %s", authReaderRoleBinding.GetName())
-			return nil, nil, err
+			return err
 		}
 	} else if k8serrors.IsNotFound(err) {
 		// Create the role.
 		if err := ownerutil.AddOwnerLabels(authReaderRoleBinding, i.owner); err != nil {
-			return nil, nil, err
+			return err
 		}
-		_, err = i.strategyClient.GetOpClient().CreateRoleBinding(authReaderRoleBinding)
+		_, err = i.strategyClient.GetOpClient().CreateRoleBinding(ctx, authReaderRoleBinding)
 		if err != nil {
 			log.Warnf("could not create auth reader role binding %s", authReaderRoleBinding.GetName())
-			return nil, nil, err
+			return err
 		}
 	} else {
-		return nil, nil, err
+		return err
 	}
-	AddDefaultCertVolumeAndVolumeMounts(&depSpec, secret.GetName())
-
-	// Setting the olm hash label forces a rollout and ensures that the new secret
-	// is used by the apiserver if not hot reloading.
-	SetCAAnnotation(&depSpec, caHash)
-	return &depSpec, caPEM, nil
+	return nil
 }
 
 func SetCAAnnotation(depSpec *appsv1.DeploymentSpec, caHash string) {
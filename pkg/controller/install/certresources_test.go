@@ -1,6 +1,7 @@
 package install
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 	"testing"
@@ -152,7 +153,7 @@ func TestInstallCertRequirementsForDeployment(t *testing.T) {
 		{
 			name: "adds certs to deployment spec",
 			mockExternal: func(mockOpClient *operatorclientmocks.MockClientInterface, fakeLister *operatorlisterfakes.FakeOperatorLister, namespace string, args args) {
-				mockOpClient.EXPECT().DeleteService(namespace, "test-service", &metav1.DeleteOptions{}).Return(nil)
+				mockOpClient.EXPECT().DeleteService(gomock.Any(), namespace, "test-service", &metav1.DeleteOptions{}).Return(nil)
 				service := corev1.Service{
 					ObjectMeta: metav1.ObjectMeta{
 						Name: "test-service",
@@ -165,7 +166,7 @@ func TestInstallCertRequirementsForDeployment(t *testing.T) {
 						Selector: selector(t, "test=label").MatchLabels,
 					},
 				}
-				mockOpClient.EXPECT().CreateService(&service).Return(&service, nil)
+				mockOpClient.EXPECT().CreateService(gomock.Any(), &service).Return(&service, nil)
 
 				hosts := []string{
 					fmt.Sprintf("%s.%s", service.GetName(), namespace),
@@ -192,7 +193,7 @@ func TestInstallCertRequirementsForDeployment(t *testing.T) {
 					},
 					Type: corev1.SecretTypeTLS,
 				}
-				mockOpClient.EXPECT().UpdateSecret(secret).Return(secret, nil)
+				mockOpClient.EXPECT().UpdateSecret(gomock.Any(), secret).Return(secret, nil)
 
 				secretRole := &rbacv1.Role{
 					ObjectMeta: metav1.ObjectMeta{
@@ -208,7 +209,7 @@ func TestInstallCertRequirementsForDeployment(t *testing.T) {
 						},
 					},
 				}
-				mockOpClient.EXPECT().UpdateRole(secretRole).Return(secretRole, nil)
+				mockOpClient.EXPECT().UpdateRole(gomock.Any(), secretRole).Return(secretRole, nil)
 
 				roleBinding := &rbacv1.RoleBinding{
 					ObjectMeta: metav1.ObjectMeta{
@@ -229,7 +230,7 @@ func TestInstallCertRequirementsForDeployment(t *testing.T) {
 						Name:     secretRole.GetName(),
 					},
 				}
-				mockOpClient.EXPECT().UpdateRoleBinding(roleBinding).Return(roleBinding, nil)
+				mockOpClient.EXPECT().UpdateRoleBinding(gomock.Any(), roleBinding).Return(roleBinding, nil)
 
 				authDelegatorClusterRoleBinding := &rbacv1.ClusterRoleBinding{
 					ObjectMeta: metav1.ObjectMeta{
@@ -250,7 +251,7 @@ func TestInstallCertRequirementsForDeployment(t *testing.T) {
 					},
 				}
 
-				mockOpClient.EXPECT().UpdateClusterRoleBinding(authDelegatorClusterRoleBinding).Return(authDelegatorClusterRoleBinding, nil)
+				mockOpClient.EXPECT().UpdateClusterRoleBinding(gomock.Any(), authDelegatorClusterRoleBinding).Return(authDelegatorClusterRoleBinding, nil)
 
 				authReaderRoleBinding := &rbacv1.RoleBinding{
 					Subjects: []rbacv1.Subject{
@@ -270,7 +271,7 @@ func TestInstallCertRequirementsForDeployment(t *testing.T) {
 				authReaderRoleBinding.SetName(service.GetName() + "-auth-reader")
 				authReaderRoleBinding.SetNamespace(KubeSystem)
 
-				mockOpClient.EXPECT().UpdateRoleBinding(authReaderRoleBinding).Return(authReaderRoleBinding, nil)
+				mockOpClient.EXPECT().UpdateRoleBinding(gomock.Any(), authReaderRoleBinding).Return(authReaderRoleBinding, nil)
 			},
 			state: fakeState{
 				existingService: &corev1.Service{
@@ -375,7 +376,7 @@ func TestInstallCertRequirementsForDeployment(t *testing.T) {
 		{
 			name: "doesn't add duplicate service ownerrefs",
 			mockExternal: func(mockOpClient *operatorclientmocks.MockClientInterface, fakeLister *operatorlisterfakes.FakeOperatorLister, namespace string, args args) {
-				mockOpClient.EXPECT().DeleteService(namespace, "test-service", &metav1.DeleteOptions{}).Return(nil)
+				mockOpClient.EXPECT().DeleteService(gomock.Any(), namespace, "test-service", &metav1.DeleteOptions{}).Return(nil)
 				service := corev1.Service{
 					ObjectMeta: metav1.ObjectMeta{
 						Name:      "test-service",
@@ -389,7 +390,7 @@ func TestInstallCertRequirementsForDeployment(t *testing.T) {
 						Selector: selector(t, "test=label").MatchLabels,
 					},
 				}
-				mockOpClient.EXPECT().CreateService(&service).Return(&service, nil)
+				mockOpClient.EXPECT().CreateService(gomock.Any(), &service).Return(&service, nil)
 
 				hosts := []string{
 					fmt.Sprintf("%s.%s", service.GetName(), namespace),
@@ -416,7 +417,7 @@ func TestInstallCertRequirementsForDeployment(t *testing.T) {
 					},
 					Type: corev1.SecretTypeTLS,
 				}
-				mockOpClient.EXPECT().UpdateSecret(secret).Return(secret, nil)
+				mockOpClient.EXPECT().UpdateSecret(gomock.Any(), secret).Return(secret, nil)
 
 				secretRole := &rbacv1.Role{
 					ObjectMeta: metav1.ObjectMeta{
@@ -432,7 +433,7 @@ func TestInstallCertRequirementsForDeployment(t *testing.T) {
 						},
 					},
 				}
-				mockOpClient.EXPECT().UpdateRole(secretRole).Return(secretRole, nil)
+				mockOpClient.EXPECT().UpdateRole(gomock.Any(), secretRole).Return(secretRole, nil)
 
 				roleBinding := &rbacv1.RoleBinding{
 					ObjectMeta: metav1.ObjectMeta{
@@ -453,7 +454,7 @@ func TestInstallCertRequirementsForDeployment(t *testing.T) {
 						Name:     secretRole.GetName(),
 					},
 				}
-				mockOpClient.EXPECT().UpdateRoleBinding(roleBinding).Return(roleBinding, nil)
+				mockOpClient.EXPECT().UpdateRoleBinding(gomock.Any(), roleBinding).Return(roleBinding, nil)
 
 				authDelegatorClusterRoleBinding := &rbacv1.ClusterRoleBinding{
 					ObjectMeta: metav1.ObjectMeta{
@@ -474,7 +475,7 @@ func TestInstallCertRequirementsForDeployment(t *testing.T) {
 					},
 				}
 
-				mockOpClient.EXPECT().UpdateClusterRoleBinding(authDelegatorClusterRoleBinding).Return(authDelegatorClusterRoleBinding, nil)
+				mockOpClient.EXPECT().UpdateClusterRoleBinding(gomock.Any(), authDelegatorClusterRoleBinding).Return(authDelegatorClusterRoleBinding, nil)
 
 				authReaderRoleBinding := &rbacv1.RoleBinding{
 					Subjects: []rbacv1.Subject{
@@ -494,7 +495,7 @@ func TestInstallCertRequirementsForDeployment(t *testing.T) {
 				authReaderRoleBinding.SetName(service.GetName() + "-auth-reader")
 				authReaderRoleBinding.SetNamespace(KubeSystem)
 
-				mockOpClient.EXPECT().UpdateRoleBinding(authReaderRoleBinding).Return(authReaderRoleBinding, nil)
+				mockOpClient.EXPECT().UpdateRoleBinding(gomock.Any(), authReaderRoleBinding).Return(authReaderRoleBinding, nil)
 			},
 			state: fakeState{
 				existingService: &corev1.Service{
@@ -593,7 +594,7 @@ func TestInstallCertRequirementsForDeployment(t *testing.T) {
 		{
 			name: "labels an unlabelled secret if present",
 			mockExternal: func(mockOpClient *operatorclientmocks.MockClientInterface, fakeLister *operatorlisterfakes.FakeOperatorLister, namespace string, args args) {
-				mockOpClient.EXPECT().DeleteService(namespace, "test-service", &metav1.DeleteOptions{}).Return(nil)
+				mockOpClient.EXPECT().DeleteService(gomock.Any(), namespace, "test-service", &metav1.DeleteOptions{}).Return(nil)
 				service := corev1.Service{
 					ObjectMeta: metav1.ObjectMeta{
 						Name: "test-service",
@@ -606,7 +607,7 @@ func TestInstallCertRequirementsForDeployment(t *testing.T) {
 						Selector: selector(t, "test=label").MatchLabels,
 					},
 				}
-				mockOpClient.EXPECT().CreateService(&service).Return(&service, nil)
+				mockOpClient.EXPECT().CreateService(gomock.Any(), &service).Return(&service, nil)
 
 				hosts := []string{
 					fmt.Sprintf("%s.%s", service.GetName(), namespace),
@@ -637,13 +638,13 @@ func TestInstallCertRequirementsForDeployment(t *testing.T) {
 					Type: corev1.SecretTypeTLS,
 				}
 				// secret already exists, but without label
-				mockOpClient.EXPECT().CreateSecret(secret).Return(nil, errors.NewAlreadyExists(schema.GroupResource{
+				mockOpClient.EXPECT().CreateSecret(gomock.Any(), secret).Return(nil, errors.NewAlreadyExists(schema.GroupResource{
 					Group:    "",
 					Resource: "secrets",
 				}, "test-service-cert"))
 
 				// update secret with label
-				mockOpClient.EXPECT().UpdateSecret(secret).Return(secret, nil)
+				mockOpClient.EXPECT().UpdateSecret(gomock.Any(), secret).Return(secret, nil)
 
 				secretRole := &rbacv1.Role{
 					ObjectMeta: metav1.ObjectMeta{
@@ -659,7 +660,7 @@ func TestInstallCertRequirementsForDeployment(t *testing.T) {
 						},
 					},
 				}
-				mockOpClient.EXPECT().UpdateRole(secretRole).Return(secretRole, nil)
+				mockOpClient.EXPECT().UpdateRole(gomock.Any(), secretRole).Return(secretRole, nil)
 
 				roleBinding := &rbacv1.RoleBinding{
 					ObjectMeta: metav1.ObjectMeta{
@@ -680,7 +681,7 @@ func TestInstallCertRequirementsForDeployment(t *testing.T) {
 						Name:     secretRole.GetName(),
 					},
 				}
-				mockOpClient.EXPECT().UpdateRoleBinding(roleBinding).Return(roleBinding, nil)
+				mockOpClient.EXPECT().UpdateRoleBinding(gomock.Any(), roleBinding).Return(roleBinding, nil)
 
 				authDelegatorClusterRoleBinding := &rbacv1.ClusterRoleBinding{
 					ObjectMeta: metav1.ObjectMeta{
@@ -701,7 +702,7 @@ func TestInstallCertRequirementsForDeployment(t *testing.T) {
 					},
 				}
 
-				mockOpClient.EXPECT().UpdateClusterRoleBinding(authDelegatorClusterRoleBinding).Return(authDelegatorClusterRoleBinding, nil)
+				mockOpClient.EXPECT().UpdateClusterRoleBinding(gomock.Any(), authDelegatorClusterRoleBinding).Return(authDelegatorClusterRoleBinding, nil)
 
 				authReaderRoleBinding := &rbacv1.RoleBinding{
 					Subjects: []rbacv1.Subject{
@@ -721,7 +722,7 @@ func TestInstallCertRequirementsForDeployment(t *testing.T) {
 				authReaderRoleBinding.SetName(service.GetName() + "-auth-reader")
 				authReaderRoleBinding.SetNamespace(KubeSystem)
 
-				mockOpClient.EXPECT().UpdateRoleBinding(authReaderRoleBinding).Return(authReaderRoleBinding, nil)
+				mockOpClient.EXPECT().UpdateRoleBinding(gomock.Any(), authReaderRoleBinding).Return(authReaderRoleBinding, nil)
 			},
 			state: fakeState{
 				existingService: &corev1.Service{
@@ -847,7 +848,7 @@ func TestInstallCertRequirementsForDeployment(t *testing.T) {
 				apiServiceDescriptions: tt.fields.apiServiceDescriptions,
 				webhookDescriptions:    tt.fields.webhookDescriptions,
 			}
-			got, _, err := i.installCertRequirementsForDeployment(tt.args.deploymentName, tt.args.ca, tt.args.rotateAt, tt.args.depSpec, tt.args.ports)
+			got, _, err := i.installCertRequirementsForDeployment(context.TODO(), tt.args.deploymentName, tt.args.ca, tt.args.rotateAt, tt.args.depSpec, tt.args.ports)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("installCertRequirementsForDeployment() error = %v, wantErr %v", err, tt.wantErr)
 				return
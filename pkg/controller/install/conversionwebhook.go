@@ -0,0 +1,98 @@
+package install
+
+import (
+	"bytes"
+	"fmt"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+)
+
+// conversionReviewVersions are the versions of ConversionReview the apiserver can send to conversion webhooks.
+var conversionReviewVersions = map[string]struct{}{"v1": {}, "v1beta1": {}}
+
+// ValidConversionWebhooks checks that the conversion webhooks of the given CSV can be configured on its CRDs: the CSV
+// must support only the AllNamespaces install mode, and each conversion webhook must convert CRDs owned by the CSV,
+// which no other conversion webhook of the CSV converts, with a ConversionReview version the apiserver supports.
+func ValidConversionWebhooks(csv *v1alpha1.ClusterServiceVersion) error {
+	owned := map[string]struct{}{}
+	for _, crd := range csv.Spec.CustomResourceDefinitions.Owned {
+		owned[crd.Name] = struct{}{}
+	}
+
+	converted := map[string]string{}
+	for _, desc := range csv.Spec.WebhookDefinitions {
+		if desc.Type != v1alpha1.ConversionWebhook {
+			continue
+		}
+		if !isSingletonOperator(*csv) {
+			return fmt.Errorf("CSVs with conversion webhooks must support only AllNamespaces")
+		}
+		if len(desc.ConversionCRDs) == 0 {
+			return fmt.Errorf("conversion webhook %s must have at least one CRD specified", desc.GenerateName)
+		}
+		supported := false
+		for _, v := range desc.AdmissionReviewVersions {
+			if _, ok := conversionReviewVersions[v]; ok {
+				supported = true
+			}
+		}
+		if !supported {
+			return fmt.Errorf("conversion webhook %s must support ConversionReview version v1 or v1beta1", desc.GenerateName)
+		}
+		for _, crd := range desc.ConversionCRDs {
+			if _, ok := owned[crd]; !ok {
+				return fmt.Errorf("conversion webhook %s converts CRD %s, which the CSV doesn't own", desc.GenerateName, crd)
+			}
+			if other, ok := converted[crd]; ok {
+				return fmt.Errorf("CRD %s is converted by both conversion webhooks %s and %s", crd, other, desc.GenerateName)
+			}
+			converted[crd] = desc.GenerateName
+		}
+	}
+	return nil
+}
+
+// validConversionStrategyTransition checks that the given on-cluster CRD can be switched to the Webhook conversion
+// strategy, typically from None when an upgrade introduces a conversion webhook.
+func validConversionStrategyTransition(crd *apiextensionsv1.CustomResourceDefinition) error {
+	// With preserveUnknownFields, the apiserver can't call the webhook, see
+	// https://kubernetes.io/blog/2019/06/20/crd-structural-schema/#pruning-don-t-preserve-unknown-fields
+	if crd.Spec.PreserveUnknownFields {
+		return fmt.Errorf("crd.Spec.PreserveUnknownFields must be false to let API Server call webhook to do the conversion")
+	}
+	for _, version := range crd.Spec.Versions {
+		if version.Schema == nil || version.Schema.OpenAPIV3Schema == nil {
+			return fmt.Errorf("version %s of CRD %s must have a schema to be converted by a webhook", version.Name, crd.GetName())
+		}
+	}
+	return nil
+}
+
+// ConversionWebhookConfigured returns true if the given CRD is converted by the given conversion webhook of a CSV in
+// the given namespace, with a CA bundle.
+func ConversionWebhookConfigured(crd *apiextensionsv1.CustomResourceDefinition, namespace string, desc v1alpha1.WebhookDescription) bool {
+	conversion := crd.Spec.Conversion
+	if conversion == nil || conversion.Strategy != apiextensionsv1.WebhookConverter || conversion.Webhook == nil || conversion.Webhook.ClientConfig == nil {
+		return false
+	}
+	config := conversion.Webhook.ClientConfig
+	return config.Service != nil && config.Service.Namespace == namespace && config.Service.Name == desc.DomainName()+"-service" && len(config.CABundle) > 0
+}
+
+// ConversionCABundlesMatch returns true if the given CRDs are converted with the same CA bundle, which isn't the case
+// while a rotated CA is being injected into them.
+func ConversionCABundlesMatch(crds []*apiextensionsv1.CustomResourceDefinition) bool {
+	var caBundle []byte
+	for i, crd := range crds {
+		if crd.Spec.Conversion == nil || crd.Spec.Conversion.Webhook == nil || crd.Spec.Conversion.Webhook.ClientConfig == nil {
+			return false
+		}
+		if i > 0 && !bytes.Equal(caBundle, crd.Spec.Conversion.Webhook.ClientConfig.CABundle) {
+			return false
+		}
+		caBundle = crd.Spec.Conversion.Webhook.ClientConfig.CABundle
+	}
+	return true
+}
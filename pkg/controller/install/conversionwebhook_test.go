@@ -0,0 +1,151 @@
+package install
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+)
+
+func conversionCSV(descs ...v1alpha1.WebhookDescription) *v1alpha1.ClusterServiceVersion {
+	return &v1alpha1.ClusterServiceVersion{
+		ObjectMeta: metav1.ObjectMeta{Name: "operator.v1.0.0", Namespace: "operators"},
+		Spec: v1alpha1.ClusterServiceVersionSpec{
+			InstallModes: []v1alpha1.InstallMode{
+				{Type: v1alpha1.InstallModeTypeOwnNamespace, Supported: false},
+				{Type: v1alpha1.InstallModeTypeAllNamespaces, Supported: true},
+			},
+			CustomResourceDefinitions: v1alpha1.CustomResourceDefinitions{
+				Owned: []v1alpha1.CRDDescription{{Name: "foos.example.com"}, {Name: "bars.example.com"}},
+			},
+			WebhookDefinitions: descs,
+		},
+	}
+}
+
+func conversionDesc(name string, crds ...string) v1alpha1.WebhookDescription {
+	return v1alpha1.WebhookDescription{
+		GenerateName:            name,
+		Type:                    v1alpha1.ConversionWebhook,
+		DeploymentName:          "operator",
+		AdmissionReviewVersions: []string{"v1"},
+		ConversionCRDs:          crds,
+	}
+}
+
+func TestValidConversionWebhooks(t *testing.T) {
+	singleNamespace := conversionCSV(conversionDesc("cfoos.example.com", "foos.example.com"))
+	singleNamespace.Spec.InstallModes[0].Supported = true
+	unsupportedReview := conversionDesc("cfoos.example.com", "foos.example.com")
+	unsupportedReview.AdmissionReviewVersions = []string{"v2"}
+
+	tests := []struct {
+		name    string
+		csv     *v1alpha1.ClusterServiceVersion
+		wantErr string
+	}{
+		{
+			name: "MultipleWebhooksAndCRDs",
+			csv:  conversionCSV(conversionDesc("cfoos.example.com", "foos.example.com"), conversionDesc("cbars.example.com", "bars.example.com")),
+		},
+		{
+			name: "NoConversionWebhooks",
+			csv:  &v1alpha1.ClusterServiceVersion{},
+		},
+		{
+			name:    "NotAllNamespaces",
+			csv:     singleNamespace,
+			wantErr: "CSVs with conversion webhooks must support only AllNamespaces",
+		},
+		{
+			name:    "NoCRDs",
+			csv:     conversionCSV(conversionDesc("cfoos.example.com")),
+			wantErr: "conversion webhook cfoos.example.com must have at least one CRD specified",
+		},
+		{
+			name:    "UnsupportedConversionReview",
+			csv:     conversionCSV(unsupportedReview),
+			wantErr: "conversion webhook cfoos.example.com must support ConversionReview version v1 or v1beta1",
+		},
+		{
+			name:    "NotOwned",
+			csv:     conversionCSV(conversionDesc("cfoos.example.com", "bazs.example.com")),
+			wantErr: "conversion webhook cfoos.example.com converts CRD bazs.example.com, which the CSV doesn't own",
+		},
+		{
+			name:    "ConvertedTwice",
+			csv:     conversionCSV(conversionDesc("cfoos.example.com", "foos.example.com"), conversionDesc("cfoos2.example.com", "bars.example.com", "foos.example.com")),
+			wantErr: "CRD foos.example.com is converted by both conversion webhooks cfoos.example.com and cfoos2.example.com",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidConversionWebhooks(tt.csv)
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestValidConversionStrategyTransition(t *testing.T) {
+	crd := &apiextensionsv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: "foos.example.com"},
+		Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+			Versions: []apiextensionsv1.CustomResourceDefinitionVersion{
+				{Name: "v1", Schema: &apiextensionsv1.CustomResourceValidation{OpenAPIV3Schema: &apiextensionsv1.JSONSchemaProps{Type: "object"}}},
+				{Name: "v2", Schema: &apiextensionsv1.CustomResourceValidation{OpenAPIV3Schema: &apiextensionsv1.JSONSchemaProps{Type: "object"}}},
+			},
+			Conversion: &apiextensionsv1.CustomResourceConversion{Strategy: apiextensionsv1.NoneConverter},
+		},
+	}
+	require.NoError(t, validConversionStrategyTransition(crd))
+
+	preserving := crd.DeepCopy()
+	preserving.Spec.PreserveUnknownFields = true
+	require.Error(t, validConversionStrategyTransition(preserving))
+
+	schemaless := crd.DeepCopy()
+	schemaless.Spec.Versions[1].Schema = nil
+	require.EqualError(t, validConversionStrategyTransition(schemaless), "version v2 of CRD foos.example.com must have a schema to be converted by a webhook")
+}
+
+func TestConversionWebhookConfigured(t *testing.T) {
+	desc := conversionDesc("cfoos.example.com", "foos.example.com", "bars.example.com")
+	converted := func(name, namespace, service string, caBundle []byte) *apiextensionsv1.CustomResourceDefinition {
+		return &apiextensionsv1.CustomResourceDefinition{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+				Conversion: &apiextensionsv1.CustomResourceConversion{
+					Strategy: apiextensionsv1.WebhookConverter,
+					Webhook: &apiextensionsv1.WebhookConversion{
+						ClientConfig: &apiextensionsv1.WebhookClientConfig{
+							Service:  &apiextensionsv1.ServiceReference{Namespace: namespace, Name: service},
+							CABundle: caBundle,
+						},
+					},
+				},
+			},
+		}
+	}
+	service := desc.DomainName() + "-service"
+
+	foos := converted("foos.example.com", "operators", service, []byte("ca"))
+	bars := converted("bars.example.com", "operators", service, []byte("ca"))
+	require.True(t, ConversionWebhookConfigured(foos, "operators", desc))
+	require.True(t, ConversionCABundlesMatch([]*apiextensionsv1.CustomResourceDefinition{foos, bars}))
+
+	require.False(t, ConversionWebhookConfigured(&apiextensionsv1.CustomResourceDefinition{}, "operators", desc))
+	require.False(t, ConversionWebhookConfigured(converted("foos.example.com", "other", service, []byte("ca")), "operators", desc))
+	require.False(t, ConversionWebhookConfigured(converted("foos.example.com", "operators", "other-service", []byte("ca")), "operators", desc))
+	require.False(t, ConversionWebhookConfigured(converted("foos.example.com", "operators", service, nil), "operators", desc))
+
+	// Until a rotated CA is injected into all the CRDs
+	rotated := converted("bars.example.com", "operators", service, []byte("rotated"))
+	require.False(t, ConversionCABundlesMatch([]*apiextensionsv1.CustomResourceDefinition{foos, rotated}))
+}
@@ -1,6 +1,7 @@
 package install
 
 import (
+	"context"
 	"fmt"
 	"hash/fnv"
 
@@ -10,6 +11,7 @@ import (
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	k8slabels "k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/utils/pointer"
 
 	"github.com/operator-framework/api/pkg/operators/v1alpha1"
@@ -29,6 +31,11 @@ type StrategyDeploymentInstaller struct {
 	initializers           DeploymentInitializerFuncChain
 	apiServiceDescriptions []certResource
 	webhookDescriptions    []certResource
+	workloadBackend        WorkloadBackend
+	workloadBackendErr     error
+	certLifetime           *CertLifetime
+	certIssuer             *CertManagerIssuer
+	certManagerClient      dynamic.Interface
 }
 
 var _ Strategy = &v1alpha1.StrategyDetailsDeployment{}
@@ -76,6 +83,8 @@ func NewStrategyDeploymentInstaller(strategyClient wrappers.InstallStrategyDeplo
 		webhookDescs[i] = &webhookDescriptionWithCAPEM{webhookDescriptions[i], []byte{}}
 	}
 
+	workloadBackend, workloadBackendErr := workloadBackendFor(strategyClient, owner)
+
 	return &StrategyDeploymentInstaller{
 		strategyClient:         strategyClient,
 		owner:                  owner,
@@ -84,43 +93,71 @@ func NewStrategyDeploymentInstaller(strategyClient wrappers.InstallStrategyDeplo
 		initializers:           initializers,
 		apiServiceDescriptions: apiDescs,
 		webhookDescriptions:    webhookDescs,
+		workloadBackend:        workloadBackend,
+		workloadBackendErr:     workloadBackendErr,
+	}
+}
+
+// lifetime returns the CertLifetime of the certs generated for the owner, defaulting to DefaultCertLifetime.
+func (i *StrategyDeploymentInstaller) lifetime() CertLifetime {
+	if i.certLifetime == nil {
+		return DefaultCertLifetime()
+	}
+	return *i.certLifetime
+}
+
+// backend returns the WorkloadBackend running the strategy's deployments, defaulting to Deployments.
+func (i *StrategyDeploymentInstaller) backend() WorkloadBackend {
+	if i.workloadBackend == nil {
+		i.workloadBackend = newDeploymentWorkloadBackend(i.strategyClient, i.owner)
 	}
+	return i.workloadBackend
 }
 
-func (i *StrategyDeploymentInstaller) installDeployments(deps []v1alpha1.StrategyDeploymentSpec) error {
+func (i *StrategyDeploymentInstaller) installDeployments(ctx context.Context, deps []v1alpha1.StrategyDeploymentSpec) error {
+	autoscaling, err := i.autoscaling()
+	if err != nil {
+		return err
+	}
 	for _, d := range deps {
 		deployment, _, err := i.deploymentForSpec(d.Name, d.Spec, d.Label)
 		if err != nil {
 			return err
 		}
 
-		if _, err := i.strategyClient.CreateOrUpdateDeployment(deployment); err != nil {
+		if _, ok := autoscaling[d.Name]; ok {
+			if err := i.keepAutoscaledReplicas(ctx, deployment); err != nil {
+				return err
+			}
+		}
+
+		if err := i.backend().CreateOrUpdate(ctx, deployment); err != nil {
 			return err
 		}
 
-		if err := i.createOrUpdateCertResourcesForDeployment(); err != nil {
+		if err := i.createOrUpdateCertResourcesForDeployment(ctx); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
-func (i *StrategyDeploymentInstaller) createOrUpdateCertResourcesForDeployment() error {
+func (i *StrategyDeploymentInstaller) createOrUpdateCertResourcesForDeployment(ctx context.Context) error {
 	for _, desc := range i.getCertResources() {
 		switch d := desc.(type) {
 		case *apiServiceDescriptionsWithCAPEM:
-			err := i.createOrUpdateAPIService(d.caPEM, d.apiServiceDescription)
+			err := i.createOrUpdateAPIService(ctx, d.caPEM, d.apiServiceDescription)
 			if err != nil {
 				return err
 			}
 
 			// Cleanup legacy APIService resources
-			err = i.deleteLegacyAPIServiceResources(*d)
+			err = i.deleteLegacyAPIServiceResources(ctx, *d)
 			if err != nil {
 				return err
 			}
 		case *webhookDescriptionWithCAPEM:
-			err := i.createOrUpdateWebhook(d.caPEM, d.webhookDescription)
+			err := i.createOrUpdateWebhook(ctx, d.caPEM, d.webhookDescription)
 			if err != nil {
 				return err
 			}
@@ -151,6 +188,7 @@ func (i *StrategyDeploymentInstaller) deploymentForSpec(name string, spec appsv1
 
 	ownerutil.AddNonBlockingOwner(dep, i.owner)
 	ownerutil.AddOwnerLabelsForKind(dep, i.owner, v1alpha1.ClusterServiceVersionKind)
+	propagateLabels(dep, i.owner)
 
 	if applyErr := i.initializers.Apply(dep); applyErr != nil {
 		err = applyErr
@@ -158,6 +196,12 @@ func (i *StrategyDeploymentInstaller) deploymentForSpec(name string, spec appsv1
 	}
 
 	podSpec := &dep.Spec.Template.Spec
+
+	// Keep operators off the nodes running operating systems and architectures they don't support, like Windows nodes
+	// in mixed clusters.
+	oses, archs := SupportedPlatformsFor(i.owner)
+	constrainPlatforms(podSpec, oses, archs)
+
 	if injectErr := inject.InjectEnvIntoDeployment(podSpec, []corev1.EnvVar{{
 		Name:  "OPERATOR_CONDITION_NAME",
 		Value: i.owner.GetName(),
@@ -179,60 +223,73 @@ func (i *StrategyDeploymentInstaller) deploymentForSpec(name string, spec appsv1
 	return
 }
 
-func (i *StrategyDeploymentInstaller) Install(s Strategy) error {
+func (i *StrategyDeploymentInstaller) Install(ctx context.Context, s Strategy) error {
 	strategy, ok := s.(*v1alpha1.StrategyDetailsDeployment)
 	if !ok {
 		return fmt.Errorf("attempted to install %s strategy with deployment installer", strategy.GetStrategyName())
 	}
+	if i.workloadBackendErr != nil {
+		return StrategyError{Reason: StrategyErrReasonInvalidStrategy, Message: i.workloadBackendErr.Error()}
+	}
 
 	// Install owned APIServices and update strategy with serving cert data
-	updatedStrategy, err := i.installCertRequirements(strategy)
+	updatedStrategy, err := i.installCertRequirements(ctx, strategy)
 	if err != nil {
 		return err
 	}
 
-	if err := i.installDeployments(updatedStrategy.DeploymentSpecs); err != nil {
+	if err := i.installDeployments(ctx, updatedStrategy.DeploymentSpecs); err != nil {
 		if k8serrors.IsForbidden(err) {
 			return StrategyError{Reason: StrategyErrInsufficientPermissions, Message: fmt.Sprintf("install strategy failed: %s", err)}
 		}
 		return err
 	}
 
+	if err := i.installAutoscalers(ctx, updatedStrategy.DeploymentSpecs); err != nil {
+		return err
+	}
+
 	// Clean up orphaned deployments
-	return i.cleanupOrphanedDeployments(updatedStrategy.DeploymentSpecs)
+	return i.cleanupOrphanedDeployments(ctx, updatedStrategy.DeploymentSpecs)
 }
 
 // CheckInstalled can return nil (installed), or errors
 // Errors can indicate: some component missing (keep installing), unable to query (check again later), or unrecoverable (failed in a way we know we can't recover from)
-func (i *StrategyDeploymentInstaller) CheckInstalled(s Strategy) (installed bool, err error) {
+func (i *StrategyDeploymentInstaller) CheckInstalled(ctx context.Context, s Strategy) (installed bool, err error) {
 	strategy, ok := s.(*v1alpha1.StrategyDetailsDeployment)
 	if !ok {
 		return false, StrategyError{Reason: StrategyErrReasonInvalidStrategy, Message: fmt.Sprintf("attempted to check %s strategy with deployment installer", strategy.GetStrategyName())}
 	}
+	if i.workloadBackendErr != nil {
+		return false, StrategyError{Reason: StrategyErrReasonInvalidStrategy, Message: i.workloadBackendErr.Error()}
+	}
 
 	// Check deployments
-	if err := i.checkForDeployments(strategy.DeploymentSpecs); err != nil {
+	if err := i.checkForDeployments(ctx, strategy.DeploymentSpecs); err != nil {
+		return false, err
+	}
+	if err := i.checkForAutoscalers(ctx, strategy.DeploymentSpecs); err != nil {
 		return false, err
 	}
 	return true, nil
 }
 
-func (i *StrategyDeploymentInstaller) checkForDeployments(deploymentSpecs []v1alpha1.StrategyDeploymentSpec) error {
+func (i *StrategyDeploymentInstaller) checkForDeployments(ctx context.Context, deploymentSpecs []v1alpha1.StrategyDeploymentSpec) error {
 	// Check the owner is a CSV
 	csv, ok := i.owner.(*v1alpha1.ClusterServiceVersion)
 	if !ok {
 		return StrategyError{Reason: StrategyErrReasonComponentMissing, Message: fmt.Sprintf("owner %s is not a CSV", i.owner.GetName())}
 	}
 
-	existingDeployments, err := i.strategyClient.FindAnyDeploymentsMatchingLabels(ownerutil.CSVOwnerSelector(csv))
+	existingDeployments, err := i.backend().List(ctx, ownerutil.CSVOwnerSelector(csv))
 	if err != nil {
 		return StrategyError{Reason: StrategyErrReasonComponentMissing, Message: fmt.Sprintf("error querying existing deployments for CSV %s: %s", csv.GetName(), err)}
 	}
 
 	// compare deployments to see if any need to be created/updated
-	existingMap := map[string]*appsv1.Deployment{}
+	existingMap := map[string]*Workload{}
 	for _, d := range existingDeployments {
-		existingMap[d.GetName()] = d.DeepCopy()
+		existingMap[d.GetName()] = d
 	}
 	for _, spec := range deploymentSpecs {
 		dep, exists := existingMap[spec.Name]
@@ -240,23 +297,22 @@ func (i *StrategyDeploymentInstaller) checkForDeployments(deploymentSpecs []v1al
 			log.Debugf("missing deployment with name=%s", spec.Name)
 			return StrategyError{Reason: StrategyErrReasonComponentMissing, Message: fmt.Sprintf("missing deployment with name=%s", spec.Name)}
 		}
-		reason, ready, err := DeploymentStatus(dep)
-		if err != nil {
-			log.Debugf("deployment %s not ready before timeout: %s", dep.Name, err.Error())
-			return StrategyError{Reason: StrategyErrReasonTimeout, Message: fmt.Sprintf("deployment %s not ready before timeout: %s", dep.Name, err.Error())}
+		if dep.Err != nil {
+			log.Debugf("deployment %s not ready before timeout: %s", dep.Name, dep.Err.Error())
+			return StrategyError{Reason: StrategyErrReasonTimeout, Message: fmt.Sprintf("deployment %s not ready before timeout: %s", dep.Name, dep.Err.Error())}
 		}
-		if !ready {
-			return StrategyError{Reason: StrategyErrReasonWaiting, Message: fmt.Sprintf("waiting for deployment %s to become ready: %s", dep.Name, reason)}
+		if !dep.Ready {
+			return StrategyError{Reason: StrategyErrReasonWaiting, Message: fmt.Sprintf("waiting for deployment %s to become ready: %s", dep.Name, dep.Reason)}
 		}
 
 		// check annotations
-		if len(i.templateAnnotations) > 0 && dep.Spec.Template.Annotations == nil {
+		if len(i.templateAnnotations) > 0 && dep.TemplateAnnotations == nil {
 			return StrategyError{Reason: StrategyErrReasonAnnotationsMissing, Message: "no annotations found on deployment"}
 		}
 		for key, value := range i.templateAnnotations {
-			if actualValue, ok := dep.Spec.Template.Annotations[key]; !ok {
+			if actualValue, ok := dep.TemplateAnnotations[key]; !ok {
 				return StrategyError{Reason: StrategyErrReasonAnnotationsMissing, Message: fmt.Sprintf("annotations on deployment does not contain expected key: %s", key)}
-			} else if dep.Spec.Template.Annotations[key] != value {
+			} else if dep.TemplateAnnotations[key] != value {
 				return StrategyError{Reason: StrategyErrReasonAnnotationsMissing, Message: fmt.Sprintf("unexpected annotation on deployment. Expected %s:%s, found %s:%s", key, value, key, actualValue)}
 			}
 		}
@@ -284,7 +340,7 @@ func (i *StrategyDeploymentInstaller) checkForDeployments(deploymentSpecs []v1al
 }
 
 // Clean up orphaned deployments after reinstalling deployments process
-func (i *StrategyDeploymentInstaller) cleanupOrphanedDeployments(deploymentSpecs []v1alpha1.StrategyDeploymentSpec) error {
+func (i *StrategyDeploymentInstaller) cleanupOrphanedDeployments(ctx context.Context, deploymentSpecs []v1alpha1.StrategyDeploymentSpec) error {
 	// Map of deployments
 	depNames := map[string]string{}
 	for _, dep := range deploymentSpecs {
@@ -298,7 +354,7 @@ func (i *StrategyDeploymentInstaller) cleanupOrphanedDeployments(deploymentSpecs
 	}
 
 	// Get existing deployments in CSV's namespace and owned by CSV
-	existingDeployments, err := i.strategyClient.FindAnyDeploymentsMatchingLabels(ownerutil.CSVOwnerSelector(csv))
+	existingDeployments, err := i.backend().List(ctx, ownerutil.CSVOwnerSelector(csv))
 	if err != nil {
 		return err
 	}
@@ -308,7 +364,7 @@ func (i *StrategyDeploymentInstaller) cleanupOrphanedDeployments(deploymentSpecs
 		if _, exists := depNames[d.GetName()]; !exists {
 			if ownerutil.IsOwnedBy(d, i.owner) {
 				log.Infof("found an orphaned deployment %s in namespace %s", d.GetName(), i.owner.GetNamespace())
-				if err := i.strategyClient.DeleteDeployment(d.GetName()); err != nil {
+				if err := i.backend().Delete(ctx, d.GetName()); err != nil {
 					log.Warnf("error cleaning up deployment %s", d.GetName())
 					return err
 				}
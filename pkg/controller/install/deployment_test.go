@@ -1,6 +1,7 @@
 package install
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
@@ -269,7 +270,7 @@ func TestInstallStrategyDeploymentInstallDeployments(t *testing.T) {
 			for i, m := range tt.createOrUpdateMocks {
 				fakeClient.CreateDeploymentReturns(nil, m.returnError)
 				defer func(i int, expectedDeployment appsv1.Deployment) {
-					dep := fakeClient.CreateOrUpdateDeploymentArgsForCall(i)
+					_, dep := fakeClient.CreateOrUpdateDeploymentArgsForCall(i)
 					expectedDeployment.Spec.Template.Annotations = map[string]string{}
 					require.Equal(t, expectedDeployment.OwnerReferences, dep.OwnerReferences)
 					for labelKey, labelValue := range expectedDeployment.Labels {
@@ -284,7 +285,7 @@ func TestInstallStrategyDeploymentInstallDeployments(t *testing.T) {
 				strategyClient: fakeClient,
 				owner:          &mockOwner,
 			}
-			result := installer.installDeployments(tt.inputs.strategyDeploymentSpecs)
+			result := installer.installDeployments(context.TODO(), tt.inputs.strategyDeploymentSpecs)
 			assert.Equal(t, tt.output, result)
 		})
 	}
@@ -310,8 +311,8 @@ func TestNewStrategyDeploymentInstaller(t *testing.T) {
 	fakeClient := new(clientfakes.FakeInstallStrategyDeploymentInterface)
 	strategy := NewStrategyDeploymentInstaller(fakeClient, map[string]string{"test": "annotation"}, &mockOwner, nil, nil, nil, nil)
 	require.Implements(t, (*StrategyInstaller)(nil), strategy)
-	require.Error(t, strategy.Install(&BadStrategy{}))
-	installed, err := strategy.CheckInstalled(&BadStrategy{})
+	require.Error(t, strategy.Install(context.TODO(), &BadStrategy{}))
+	installed, err := strategy.CheckInstalled(context.TODO(), &BadStrategy{})
 	require.False(t, installed)
 	require.Error(t, err)
 }
@@ -366,7 +367,7 @@ func TestInstallStrategyDeploymentCheckInstallErrors(t *testing.T) {
 				require.Equal(t, mockOwnerLabel, fakeClient.FindAnyDeploymentsMatchingLabelsArgsForCall(0))
 			}()
 
-			installed, err := installer.CheckInstalled(strategy)
+			installed, err := installer.CheckInstalled(context.TODO(), strategy)
 			require.NoError(t, err)
 			require.True(t, installed)
 
@@ -376,11 +377,12 @@ func TestInstallStrategyDeploymentCheckInstallErrors(t *testing.T) {
 			deployment.SetLabels(labels.CloneAndAddLabel(dep.ObjectMeta.GetLabels(), DeploymentSpecHashLabelKey, HashDeploymentSpec(deployment.Spec)))
 			fakeClient.CreateOrUpdateDeploymentReturns(&deployment, tt.createDeploymentErr)
 			defer func() {
-				require.Equal(t, &deployment, fakeClient.CreateOrUpdateDeploymentArgsForCall(0))
+				_, created := fakeClient.CreateOrUpdateDeploymentArgsForCall(0)
+				require.Equal(t, &deployment, created)
 			}()
 
 			if tt.createDeploymentErr != nil {
-				err := installer.Install(strategy)
+				err := installer.Install(context.TODO(), strategy)
 				require.Error(t, err)
 			}
 		})
@@ -549,12 +551,12 @@ func TestInstallStrategyDeploymentCleanupDeployments(t *testing.T) {
 
 			if tt.setup.returnError == nil && tt.cleanupMock.returnError == nil {
 				defer func() {
-					deletedDep := fakeClient.DeleteDeploymentArgsForCall(0)
+					_, deletedDep := fakeClient.DeleteDeploymentArgsForCall(0)
 					require.Equal(t, tt.cleanupMock.deletedDeploymentName, deletedDep)
 				}()
 			}
 
-			result := installer.cleanupOrphanedDeployments(tt.inputs.strategyDeploymentSpecs)
+			result := installer.cleanupOrphanedDeployments(context.TODO(), tt.inputs.strategyDeploymentSpecs)
 			assert.Equal(t, tt.output, result)
 		})
 	}
@@ -42,6 +42,19 @@ func IsErrorUnrecoverable(err error) bool {
 	return ok
 }
 
+// OwnerConflictError is returned when an object OLM would create for a CSV already exists and isn't managed by OLM
+// for that CSV.
+type OwnerConflictError struct {
+	Message string
+}
+
+var _ error = OwnerConflictError{}
+
+// Error implements the Error interface.
+func (e OwnerConflictError) Error() string {
+	return e.Message
+}
+
 func ReasonForError(err error) string {
 	switch t := err.(type) {
 	case StrategyError:
@@ -0,0 +1,355 @@
+package install
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/ghodss/yaml"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	utilclock "k8s.io/apimachinery/pkg/util/clock"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/kubernetes"
+	clientcmdapiv1 "k8s.io/client-go/tools/clientcmd/api/v1"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	rbacvalidation "github.com/operator-framework/operator-lifecycle-manager/pkg/lib/kubernetes/pkg/registry/rbac/validation"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/ownerutil"
+)
+
+const (
+	// KubeconfigsAnnotationKey is the CSV annotation holding a JSON list of KubeconfigDescriptions for OLM to generate
+	// kubeconfig Secrets for.
+	KubeconfigsAnnotationKey = "operatorframework.io/kubeconfigs"
+
+	// KubeconfigDescKey labels the objects created for a KubeconfigDescription.
+	KubeconfigDescKey = "olm.kubeconfig-description-name"
+
+	// KubeconfigSecretKey is the key of the kubeconfig in the generated Secrets.
+	KubeconfigSecretKey = "kubeconfig"
+
+	// kubeconfigRotateAtAnnotationKey records when the token of a generated kubeconfig should be replaced.
+	kubeconfigRotateAtAnnotationKey = "olm.kubeconfig-rotate-at"
+
+	// DefaultKubeconfigExpirationSeconds is the default lifetime of the token of a generated kubeconfig.
+	DefaultKubeconfigExpirationSeconds int64 = 24 * 60 * 60
+
+	// minKubeconfigExpirationSeconds is the shortest token lifetime the kube-apiserver accepts.
+	minKubeconfigExpirationSeconds int64 = 10 * 60
+
+	// kubeconfigServer is the address of the kube-apiserver from within the cluster.
+	kubeconfigServer = "https://kubernetes.default.svc"
+
+	// rootCAConfigMapName is the ConfigMap published in every namespace holding the kube-apiserver's CA.
+	rootCAConfigMapName = "kube-root-ca.crt"
+)
+
+// KubeconfigDescription describes a kubeconfig Secret, owned by a ClusterServiceVersion, granting a bound service
+// account token with the given rules in the CSV's namespace.
+type KubeconfigDescription struct {
+	// Name is used for the Secret, and for the ServiceAccount, Role, and RoleBinding backing it.
+	Name string `json:"name"`
+
+	// Rules are granted to the token in the CSV's namespace. They must be covered by the CSV's own permissions.
+	Rules []rbacv1.PolicyRule `json:"rules"`
+
+	// ExpirationSeconds is the lifetime of the token. OLM replaces the token once 80% of its lifetime has elapsed.
+	// +optional
+	ExpirationSeconds *int64 `json:"expirationSeconds,omitempty"`
+}
+
+func (d KubeconfigDescription) expiration() time.Duration {
+	if d.ExpirationSeconds == nil {
+		return time.Duration(DefaultKubeconfigExpirationSeconds) * time.Second
+	}
+	return time.Duration(*d.ExpirationSeconds) * time.Second
+}
+
+// KubeconfigDescriptions returns the KubeconfigDescriptions declared by the given CSV.
+func KubeconfigDescriptions(csv *v1alpha1.ClusterServiceVersion) ([]KubeconfigDescription, error) {
+	raw, ok := csv.GetAnnotations()[KubeconfigsAnnotationKey]
+	if !ok || raw == "" {
+		return nil, nil
+	}
+
+	var descs []KubeconfigDescription
+	if err := json.Unmarshal([]byte(raw), &descs); err != nil {
+		return nil, fmt.Errorf("unable to parse %s annotation: %v", KubeconfigsAnnotationKey, err)
+	}
+
+	return descs, nil
+}
+
+// ValidKubeconfigDescriptions checks that the given descriptions are uniquely named, have a supported token lifetime,
+// and don't grant more than the namespaced permissions of the CSV.
+func ValidKubeconfigDescriptions(csv *v1alpha1.ClusterServiceVersion, descs []KubeconfigDescription) error {
+	var ownerRules []rbacv1.PolicyRule
+	for _, permission := range csv.Spec.InstallStrategy.StrategySpec.Permissions {
+		ownerRules = append(ownerRules, permission.Rules...)
+	}
+
+	names := map[string]struct{}{}
+	for _, desc := range descs {
+		if errs := validation.IsDNS1123Subdomain(desc.Name); len(errs) > 0 {
+			return fmt.Errorf("kubeconfig description name %q is invalid: %v", desc.Name, errs)
+		}
+		if _, ok := names[desc.Name]; ok {
+			return fmt.Errorf("repeated kubeconfig description name %s", desc.Name)
+		}
+		names[desc.Name] = struct{}{}
+
+		if len(desc.Rules) == 0 {
+			return fmt.Errorf("kubeconfig description %s must have rules", desc.Name)
+		}
+		if desc.ExpirationSeconds != nil && *desc.ExpirationSeconds < minKubeconfigExpirationSeconds {
+			return fmt.Errorf("kubeconfig description %s expiration must be at least %d seconds", desc.Name, minKubeconfigExpirationSeconds)
+		}
+		if covered, uncovered := rbacvalidation.Covers(ownerRules, desc.Rules); !covered {
+			return fmt.Errorf("kubeconfig description %s grants rules not granted to the operator: %v", desc.Name, uncovered)
+		}
+	}
+
+	return nil
+}
+
+// KubeconfigClient generates and rotates the kubeconfig Secrets declared by CSVs.
+type KubeconfigClient struct {
+	client kubernetes.Interface
+	clock  utilclock.Clock
+}
+
+// NewKubeconfigClient returns a KubeconfigClient.
+func NewKubeconfigClient(client kubernetes.Interface, clock utilclock.Clock) *KubeconfigClient {
+	return &KubeconfigClient{client: client, clock: clock}
+}
+
+// EnsureKubeconfigs creates or updates the kubeconfig Secrets, and the RBAC backing them, for the given descriptions,
+// replacing tokens due for rotation, and deletes those created for descriptions the owner no longer declares.
+func (c *KubeconfigClient) EnsureKubeconfigs(ctx context.Context, owner ownerutil.Owner, descs []KubeconfigDescription) error {
+	wanted := map[string]struct{}{}
+	for _, desc := range descs {
+		wanted[desc.Name] = struct{}{}
+	}
+	if err := c.cleanup(ctx, owner, wanted); err != nil {
+		return err
+	}
+
+	for _, desc := range descs {
+		if err := c.ensureKubeconfig(ctx, owner, desc); err != nil {
+			return fmt.Errorf("kubeconfig description %s: %v", desc.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func (c *KubeconfigClient) ensureKubeconfig(ctx context.Context, owner ownerutil.Owner, desc KubeconfigDescription) error {
+	namespace := owner.GetNamespace()
+	objectMeta := func() metav1.ObjectMeta {
+		meta := metav1.ObjectMeta{Name: desc.Name, Namespace: namespace, Labels: ownerutil.OwnerLabel(owner, v1alpha1.ClusterServiceVersionKind)}
+		meta.Labels[KubeconfigDescKey] = desc.Name
+		ownerutil.AddNonBlockingOwner(&meta, owner)
+		return meta
+	}
+
+	sa := &corev1.ServiceAccount{ObjectMeta: objectMeta()}
+	if existing, err := c.client.CoreV1().ServiceAccounts(namespace).Get(ctx, sa.GetName(), metav1.GetOptions{}); err == nil {
+		if err := c.checkOwned(owner, &existing.ObjectMeta); err != nil {
+			return err
+		}
+	} else if k8serrors.IsNotFound(err) {
+		if _, err := c.client.CoreV1().ServiceAccounts(namespace).Create(ctx, sa, metav1.CreateOptions{}); err != nil {
+			return err
+		}
+	} else {
+		return err
+	}
+
+	role := &rbacv1.Role{ObjectMeta: objectMeta(), Rules: desc.Rules}
+	if existing, err := c.client.RbacV1().Roles(namespace).Get(ctx, role.GetName(), metav1.GetOptions{}); err == nil {
+		if err := c.checkOwned(owner, &existing.ObjectMeta); err != nil {
+			return err
+		}
+		role.SetResourceVersion(existing.GetResourceVersion())
+		if _, err := c.client.RbacV1().Roles(namespace).Update(ctx, role, metav1.UpdateOptions{}); err != nil {
+			return err
+		}
+	} else if k8serrors.IsNotFound(err) {
+		if _, err := c.client.RbacV1().Roles(namespace).Create(ctx, role, metav1.CreateOptions{}); err != nil {
+			return err
+		}
+	} else {
+		return err
+	}
+
+	binding := &rbacv1.RoleBinding{
+		ObjectMeta: objectMeta(),
+		Subjects:   []rbacv1.Subject{{Kind: rbacv1.ServiceAccountKind, Name: sa.GetName(), Namespace: namespace}},
+		RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "Role", Name: role.GetName()},
+	}
+	if existing, err := c.client.RbacV1().RoleBindings(namespace).Get(ctx, binding.GetName(), metav1.GetOptions{}); err == nil {
+		if err := c.checkOwned(owner, &existing.ObjectMeta); err != nil {
+			return err
+		}
+	} else if k8serrors.IsNotFound(err) {
+		if _, err := c.client.RbacV1().RoleBindings(namespace).Create(ctx, binding, metav1.CreateOptions{}); err != nil {
+			return err
+		}
+	} else {
+		return err
+	}
+
+	existing, err := c.client.CoreV1().Secrets(namespace).Get(ctx, desc.Name, metav1.GetOptions{})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return err
+	}
+	found := err == nil
+	if found {
+		if err := c.checkOwned(owner, &existing.ObjectMeta); err != nil {
+			return err
+		}
+		if rotateAt, err := time.Parse(time.RFC3339, existing.GetAnnotations()[kubeconfigRotateAtAnnotationKey]); err == nil && c.clock.Now().Before(rotateAt) {
+			return nil
+		}
+	}
+
+	secret, err := c.kubeconfigSecret(ctx, desc, objectMeta())
+	if err != nil {
+		return err
+	}
+	if found {
+		secret.SetResourceVersion(existing.GetResourceVersion())
+		_, err = c.client.CoreV1().Secrets(namespace).Update(ctx, secret, metav1.UpdateOptions{})
+		return err
+	}
+	_, err = c.client.CoreV1().Secrets(namespace).Create(ctx, secret, metav1.CreateOptions{})
+	return err
+}
+
+// kubeconfigSecret requests a new token for the description's ServiceAccount and returns a Secret holding a kubeconfig using it.
+func (c *KubeconfigClient) kubeconfigSecret(ctx context.Context, desc KubeconfigDescription, meta metav1.ObjectMeta) (*corev1.Secret, error) {
+	rootCA, err := c.client.CoreV1().ConfigMaps(meta.Namespace).Get(ctx, rootCAConfigMapName, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("unable to get cluster CA: %v", err)
+	}
+
+	expirationSeconds := int64(desc.expiration().Seconds())
+	token, err := c.client.CoreV1().ServiceAccounts(meta.Namespace).CreateToken(ctx, desc.Name, &authenticationv1.TokenRequest{
+		Spec: authenticationv1.TokenRequestSpec{ExpirationSeconds: &expirationSeconds},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("unable to request token: %v", err)
+	}
+
+	kubeconfig, err := yaml.Marshal(clientcmdapiv1.Config{
+		APIVersion:     "v1",
+		Kind:           "Config",
+		Clusters:       []clientcmdapiv1.NamedCluster{{Name: "cluster", Cluster: clientcmdapiv1.Cluster{Server: kubeconfigServer, CertificateAuthorityData: []byte(rootCA.Data["ca.crt"])}}},
+		AuthInfos:      []clientcmdapiv1.NamedAuthInfo{{Name: desc.Name, AuthInfo: clientcmdapiv1.AuthInfo{Token: token.Status.Token}}},
+		Contexts:       []clientcmdapiv1.NamedContext{{Name: "default", Context: clientcmdapiv1.Context{Cluster: "cluster", AuthInfo: desc.Name, Namespace: meta.Namespace}}},
+		CurrentContext: "default",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Rotate once 80% of the token's lifetime has elapsed
+	rotateAt := c.clock.Now().Add(desc.expiration() * 4 / 5)
+	meta.Annotations = map[string]string{kubeconfigRotateAtAnnotationKey: rotateAt.UTC().Format(time.RFC3339)}
+
+	return &corev1.Secret{
+		ObjectMeta: meta,
+		Type:       corev1.SecretTypeOpaque,
+		Data:       map[string][]byte{KubeconfigSecretKey: kubeconfig},
+	}, nil
+}
+
+// checkOwned refuses to take over objects that weren't created by OLM for a kubeconfig description of the owner.
+func (c *KubeconfigClient) checkOwned(owner ownerutil.Owner, meta *metav1.ObjectMeta) error {
+	_, ok := meta.GetLabels()[KubeconfigDescKey]
+	if !ok || meta.GetLabels()[ownerutil.OwnerKey] != owner.GetName() || meta.GetLabels()[ownerutil.OwnerNamespaceKey] != owner.GetNamespace() {
+		return fmt.Errorf("%s/%s already exists and is not managed by OLM for this operator", meta.GetNamespace(), meta.GetName())
+	}
+	return nil
+}
+
+// DeleteKubeconfigs deletes the kubeconfig Secrets, and the RBAC backing them, created for the given owner.
+func (c *KubeconfigClient) DeleteKubeconfigs(ctx context.Context, owner ownerutil.Owner) error {
+	return c.cleanup(ctx, owner, nil)
+}
+
+// deleteOwned deletes an object if it was created by OLM for a kubeconfig description of the owner, and leaves it alone
+// otherwise. The deletion is conditional on the UID of the object checked, in case it was replaced in the meantime.
+func (c *KubeconfigClient) deleteOwned(owner ownerutil.Owner, meta *metav1.ObjectMeta, del func(metav1.DeleteOptions) error) error {
+	if err := c.checkOwned(owner, meta); err != nil {
+		return nil
+	}
+	return del(metav1.DeleteOptions{Preconditions: metav1.NewUIDPreconditions(string(meta.GetUID()))})
+}
+
+func (c *KubeconfigClient) cleanup(ctx context.Context, owner ownerutil.Owner, wanted map[string]struct{}) error {
+	namespace := owner.GetNamespace()
+	selector := labels.SelectorFromSet(ownerutil.OwnerLabel(owner, v1alpha1.ClusterServiceVersionKind)).String()
+	serviceAccounts, err := c.client.CoreV1().ServiceAccounts(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return err
+	}
+
+	for i := range serviceAccounts.Items {
+		sa := &serviceAccounts.Items[i]
+		name, ok := sa.GetLabels()[KubeconfigDescKey]
+		if !ok {
+			continue
+		}
+		if _, ok := wanted[name]; ok {
+			continue
+		}
+		// The objects are named after the description, so only delete those OLM created for it
+		for _, del := range []func() error{
+			func() error {
+				secret, err := c.client.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+				if err != nil {
+					return err
+				}
+				return c.deleteOwned(owner, &secret.ObjectMeta, func(options metav1.DeleteOptions) error {
+					return c.client.CoreV1().Secrets(namespace).Delete(ctx, name, options)
+				})
+			},
+			func() error {
+				binding, err := c.client.RbacV1().RoleBindings(namespace).Get(ctx, name, metav1.GetOptions{})
+				if err != nil {
+					return err
+				}
+				return c.deleteOwned(owner, &binding.ObjectMeta, func(options metav1.DeleteOptions) error {
+					return c.client.RbacV1().RoleBindings(namespace).Delete(ctx, name, options)
+				})
+			},
+			func() error {
+				role, err := c.client.RbacV1().Roles(namespace).Get(ctx, name, metav1.GetOptions{})
+				if err != nil {
+					return err
+				}
+				return c.deleteOwned(owner, &role.ObjectMeta, func(options metav1.DeleteOptions) error {
+					return c.client.RbacV1().Roles(namespace).Delete(ctx, name, options)
+				})
+			},
+			func() error {
+				return c.deleteOwned(owner, &sa.ObjectMeta, func(options metav1.DeleteOptions) error {
+					return c.client.CoreV1().ServiceAccounts(namespace).Delete(ctx, name, options)
+				})
+			},
+		} {
+			if err := del(); err != nil && !k8serrors.IsNotFound(err) {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,126 @@
+package install
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ghodss/yaml"
+	"github.com/stretchr/testify/require"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilclock "k8s.io/apimachinery/pkg/util/clock"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	clienttesting "k8s.io/client-go/testing"
+	clientcmdapiv1 "k8s.io/client-go/tools/clientcmd/api/v1"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+)
+
+func kubeconfigDesc(name string, verbs ...string) KubeconfigDescription {
+	return KubeconfigDescription{
+		Name:  name,
+		Rules: []rbacv1.PolicyRule{{APIGroups: []string{""}, Resources: []string{"configmaps"}, Verbs: verbs}},
+	}
+}
+
+func TestValidKubeconfigDescriptions(t *testing.T) {
+	csv := &v1alpha1.ClusterServiceVersion{}
+	csv.Spec.InstallStrategy.StrategySpec.Permissions = []v1alpha1.StrategyDeploymentPermissions{{
+		ServiceAccountName: "operator",
+		Rules:              []rbacv1.PolicyRule{{APIGroups: []string{""}, Resources: []string{"configmaps"}, Verbs: []string{"get", "list"}}},
+	}}
+	short := int64(60)
+	tooShort := kubeconfigDesc("a", "get")
+	tooShort.ExpirationSeconds = &short
+
+	tests := []struct {
+		description string
+		descs       []KubeconfigDescription
+		wantErr     bool
+	}{
+		{description: "Valid", descs: []KubeconfigDescription{kubeconfigDesc("a", "get"), kubeconfigDesc("b", "get", "list")}},
+		{description: "RepeatedName", descs: []KubeconfigDescription{kubeconfigDesc("a", "get"), kubeconfigDesc("a", "list")}, wantErr: true},
+		{description: "InvalidName", descs: []KubeconfigDescription{kubeconfigDesc("A_b", "get")}, wantErr: true},
+		{description: "NoRules", descs: []KubeconfigDescription{{Name: "a"}}, wantErr: true},
+		{description: "ExpirationTooShort", descs: []KubeconfigDescription{tooShort}, wantErr: true},
+		{description: "Escalation", descs: []KubeconfigDescription{kubeconfigDesc("a", "delete")}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			err := ValidKubeconfigDescriptions(csv, tt.descs)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestKubeconfigClientEnsureKubeconfigs(t *testing.T) {
+	client := k8sfake.NewSimpleClientset(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: rootCAConfigMapName, Namespace: "ns"},
+		Data:       map[string]string{"ca.crt": "ca"},
+	})
+	tokens := 0
+	client.PrependReactor("create", "serviceaccounts", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() != "token" {
+			return false, nil, nil
+		}
+		tokens++
+		return true, &authenticationv1.TokenRequest{Status: authenticationv1.TokenRequestStatus{Token: "token"}}, nil
+	})
+	clock := utilclock.NewFakeClock(time.Now())
+	c := NewKubeconfigClient(client, clock)
+	owner := &v1alpha1.ClusterServiceVersion{ObjectMeta: metav1.ObjectMeta{Name: "csv", Namespace: "ns"}}
+
+	require.NoError(t, c.EnsureKubeconfigs(context.TODO(), owner, []KubeconfigDescription{kubeconfigDesc("a", "get"), kubeconfigDesc("b", "get")}))
+	require.Equal(t, 2, tokens)
+
+	secret, err := client.CoreV1().Secrets("ns").Get(context.TODO(), "a", metav1.GetOptions{})
+	require.NoError(t, err)
+	var config clientcmdapiv1.Config
+	require.NoError(t, yaml.Unmarshal(secret.Data[KubeconfigSecretKey], &config))
+	require.Equal(t, "token", config.AuthInfos[0].AuthInfo.Token)
+	require.Equal(t, []byte("ca"), config.Clusters[0].Cluster.CertificateAuthorityData)
+	require.Equal(t, "ns", config.Contexts[0].Context.Namespace)
+
+	// Tokens are only replaced once due for rotation
+	require.NoError(t, c.EnsureKubeconfigs(context.TODO(), owner, []KubeconfigDescription{kubeconfigDesc("a", "get"), kubeconfigDesc("b", "get")}))
+	require.Equal(t, 2, tokens)
+	clock.Step(20 * time.Hour)
+	require.NoError(t, c.EnsureKubeconfigs(context.TODO(), owner, []KubeconfigDescription{kubeconfigDesc("a", "get")}))
+	require.Equal(t, 3, tokens)
+
+	// Removed descriptions are cleaned up
+	_, err = client.CoreV1().ServiceAccounts("ns").Get(context.TODO(), "b", metav1.GetOptions{})
+	require.Error(t, err)
+
+	// Objects not created by OLM are left alone
+	_, err = client.CoreV1().ServiceAccounts("ns").Create(context.TODO(), &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "c", Namespace: "ns"}}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	require.Error(t, c.EnsureKubeconfigs(context.TODO(), owner, []KubeconfigDescription{kubeconfigDesc("c", "get")}))
+
+	require.NoError(t, c.DeleteKubeconfigs(context.TODO(), owner))
+	_, err = client.CoreV1().Secrets("ns").Get(context.TODO(), "a", metav1.GetOptions{})
+	require.Error(t, err)
+	_, err = client.CoreV1().ServiceAccounts("ns").Get(context.TODO(), "c", metav1.GetOptions{})
+	require.NoError(t, err)
+
+	// Objects named after a removed description are only deleted if OLM created them for it
+	require.NoError(t, c.EnsureKubeconfigs(context.TODO(), owner, []KubeconfigDescription{kubeconfigDesc("d", "get")}))
+	role, err := client.RbacV1().Roles("ns").Get(context.TODO(), "d", metav1.GetOptions{})
+	require.NoError(t, err)
+	role.SetLabels(nil)
+	_, err = client.RbacV1().Roles("ns").Update(context.TODO(), role, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	require.NoError(t, c.EnsureKubeconfigs(context.TODO(), owner, nil))
+	_, err = client.CoreV1().ServiceAccounts("ns").Get(context.TODO(), "d", metav1.GetOptions{})
+	require.Error(t, err)
+	_, err = client.RbacV1().Roles("ns").Get(context.TODO(), "d", metav1.GetOptions{})
+	require.NoError(t, err)
+}
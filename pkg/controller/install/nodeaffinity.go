@@ -0,0 +1,88 @@
+package install
+
+import (
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/ownerutil"
+)
+
+const (
+	// OSLabelPrefix prefixes the CSV labels declaring the operating systems an operator supports, as in
+	// operatorframework.io/os.windows=supported.
+	OSLabelPrefix = "operatorframework.io/os."
+	// ArchLabelPrefix prefixes the CSV labels declaring the architectures an operator supports, as in
+	// operatorframework.io/arch.arm64=supported.
+	ArchLabelPrefix = "operatorframework.io/arch."
+	// SupportedLabelValue is the value of the OS and architecture labels of the platforms an operator supports.
+	SupportedLabelValue = "supported"
+)
+
+// SupportedPlatformsFor returns the operating systems and architectures the given CSV declares support for, sorted.
+// CSVs declaring none are left unconstrained, so that the deployments of existing operators aren't rolled out.
+func SupportedPlatformsFor(owner ownerutil.Owner) (oses, archs []string) {
+	for key, value := range owner.GetLabels() {
+		if value != SupportedLabelValue {
+			continue
+		}
+		if os := strings.TrimPrefix(key, OSLabelPrefix); os != key && os != "" {
+			oses = append(oses, os)
+		}
+		if arch := strings.TrimPrefix(key, ArchLabelPrefix); arch != key && arch != "" {
+			archs = append(archs, arch)
+		}
+	}
+	sort.Strings(oses)
+	sort.Strings(archs)
+	return
+}
+
+// constrainPlatforms requires the pods of the given spec to be scheduled on nodes running one of the given operating
+// systems and architectures. The requirements are added to each of the required node selector terms, so that nodes
+// matching any of them still have to match the platform; keys the term or the pod's node selector already constrain
+// are left to them.
+func constrainPlatforms(podSpec *corev1.PodSpec, oses, archs []string) {
+	var requirements []corev1.NodeSelectorRequirement
+	for _, r := range []corev1.NodeSelectorRequirement{
+		{Key: corev1.LabelOSStable, Operator: corev1.NodeSelectorOpIn, Values: oses},
+		{Key: corev1.LabelArchStable, Operator: corev1.NodeSelectorOpIn, Values: archs},
+	} {
+		if _, ok := podSpec.NodeSelector[r.Key]; ok || len(r.Values) == 0 {
+			continue
+		}
+		requirements = append(requirements, r)
+	}
+	if len(requirements) == 0 {
+		return
+	}
+
+	if podSpec.Affinity == nil {
+		podSpec.Affinity = &corev1.Affinity{}
+	}
+	if podSpec.Affinity.NodeAffinity == nil {
+		podSpec.Affinity.NodeAffinity = &corev1.NodeAffinity{}
+	}
+	nodeAffinity := podSpec.Affinity.NodeAffinity
+	if nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution = &corev1.NodeSelector{}
+	}
+	selector := nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution
+	if len(selector.NodeSelectorTerms) == 0 {
+		selector.NodeSelectorTerms = []corev1.NodeSelectorTerm{{}}
+	}
+
+	for i := range selector.NodeSelectorTerms {
+		term := &selector.NodeSelectorTerms[i]
+		constrained := map[string]bool{}
+		for _, expr := range term.MatchExpressions {
+			constrained[expr.Key] = true
+		}
+		for _, r := range requirements {
+			if !constrained[r.Key] {
+				term.MatchExpressions = append(term.MatchExpressions, *r.DeepCopy())
+			}
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package install
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+)
+
+func TestSupportedPlatformsFor(t *testing.T) {
+	oses, archs := SupportedPlatformsFor(&v1alpha1.ClusterServiceVersion{})
+	require.Nil(t, oses)
+	require.Nil(t, archs)
+
+	oses, archs = SupportedPlatformsFor(&v1alpha1.ClusterServiceVersion{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{
+		"operatorframework.io/os.windows":   "supported",
+		"operatorframework.io/os.linux":     "supported",
+		"operatorframework.io/os.darwin":    "unsupported",
+		"operatorframework.io/arch.arm64":   "supported",
+		"operatorframework.io/arch.amd64":   "supported",
+		"operatorframework.io/os":           "supported",
+		"operatorframework.io/arch.":        "supported",
+		"operatorframework.io/architecture": "supported",
+	}}})
+	require.Equal(t, []string{"linux", "windows"}, oses)
+	require.Equal(t, []string{"amd64", "arm64"}, archs)
+}
+
+func TestConstrainPlatforms(t *testing.T) {
+	linux := corev1.NodeSelectorRequirement{Key: corev1.LabelOSStable, Operator: corev1.NodeSelectorOpIn, Values: []string{"linux"}}
+	arm64 := corev1.NodeSelectorRequirement{Key: corev1.LabelArchStable, Operator: corev1.NodeSelectorOpIn, Values: []string{"arm64"}}
+	zone := corev1.NodeSelectorRequirement{Key: corev1.LabelTopologyZone, Operator: corev1.NodeSelectorOpIn, Values: []string{"a"}}
+	terms := func(spec *corev1.PodSpec) []corev1.NodeSelectorTerm {
+		return spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+	}
+
+	spec := &corev1.PodSpec{}
+	constrainPlatforms(spec, nil, nil)
+	require.Nil(t, spec.Affinity, "nothing declared")
+
+	constrainPlatforms(spec, []string{"linux"}, []string{"arm64"})
+	require.Equal(t, []corev1.NodeSelectorTerm{{MatchExpressions: []corev1.NodeSelectorRequirement{linux, arm64}}}, terms(spec))
+
+	// each term is constrained, unless it already constrains the key itself
+	spec = &corev1.PodSpec{Affinity: &corev1.Affinity{NodeAffinity: &corev1.NodeAffinity{
+		RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{NodeSelectorTerms: []corev1.NodeSelectorTerm{
+			{MatchExpressions: []corev1.NodeSelectorRequirement{zone}},
+			{MatchExpressions: []corev1.NodeSelectorRequirement{{Key: corev1.LabelOSStable, Operator: corev1.NodeSelectorOpIn, Values: []string{"windows"}}}},
+		}},
+	}}}
+	constrainPlatforms(spec, []string{"linux"}, nil)
+	require.Equal(t, []corev1.NodeSelectorTerm{
+		{MatchExpressions: []corev1.NodeSelectorRequirement{zone, linux}},
+		{MatchExpressions: []corev1.NodeSelectorRequirement{{Key: corev1.LabelOSStable, Operator: corev1.NodeSelectorOpIn, Values: []string{"windows"}}}},
+	}, terms(spec))
+
+	// node selectors are left alone
+	spec = &corev1.PodSpec{NodeSelector: map[string]string{corev1.LabelOSStable: "linux"}}
+	constrainPlatforms(spec, []string{"linux", "windows"}, nil)
+	require.Nil(t, spec.Affinity)
+}
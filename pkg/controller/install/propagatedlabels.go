@@ -0,0 +1,62 @@
+package install
+
+import (
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/ownerutil"
+)
+
+// PropagatedLabelsAnnotationKey is the annotation listing, comma-separated, the keys of the labels propagated from a
+// Subscription onto the resources installed for it. Set on a Subscription, it selects the labels copied onto its
+// InstallPlans and CSVs, which carry the annotation in turn; OLM copies the labels of a CSV it lists onto the
+// deployments of the CSV and their pods.
+const PropagatedLabelsAnnotationKey = "operatorframework.io/propagated-labels"
+
+// PropagatedLabelKeysFor returns the keys of the labels the given object propagates, or that are propagated onto it from
+// its Subscription.
+func PropagatedLabelKeysFor(owner ownerutil.Owner) []string {
+	var keys []string
+	for _, key := range strings.Split(owner.GetAnnotations()[PropagatedLabelsAnnotationKey], ",") {
+		if key = strings.TrimSpace(key); key != "" {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// PropagatedLabelsFor returns the labels the given object propagates, or that are propagated onto it from its
+// Subscription.
+func PropagatedLabelsFor(owner ownerutil.Owner) map[string]string {
+	labels := map[string]string{}
+	for _, key := range PropagatedLabelKeysFor(owner) {
+		if value, ok := owner.GetLabels()[key]; ok {
+			labels[key] = value
+		}
+	}
+	return labels
+}
+
+// propagateLabels adds the labels propagated onto the owner to the given deployment and its pod template. Labels the
+// deployment already sets are kept, so that its selector keeps matching its pods.
+func propagateLabels(dep *appsv1.Deployment, owner ownerutil.Owner) {
+	labels := PropagatedLabelsFor(owner)
+	if len(labels) == 0 {
+		return
+	}
+	if dep.Labels == nil {
+		dep.Labels = map[string]string{}
+	}
+	if dep.Spec.Template.Labels == nil {
+		dep.Spec.Template.Labels = map[string]string{}
+	}
+	for key, value := range labels {
+		if _, ok := dep.Labels[key]; !ok {
+			dep.Labels[key] = value
+		}
+		if _, ok := dep.Spec.Template.Labels[key]; !ok {
+			dep.Spec.Template.Labels[key] = value
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package install
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+)
+
+func TestPropagateLabels(t *testing.T) {
+	csv := &v1alpha1.ClusterServiceVersion{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "csv",
+			Labels:      map[string]string{"team": "payments", "cost-center": "cc-42", "app": "operator", "other": "ignored"},
+			Annotations: map[string]string{PropagatedLabelsAnnotationKey: "team, cost-center,app,missing"},
+		},
+	}
+	require.Equal(t, []string{"team", "cost-center", "app", "missing"}, PropagatedLabelKeysFor(csv))
+	require.Equal(t, map[string]string{"team": "payments", "cost-center": "cc-42", "app": "operator"}, PropagatedLabelsFor(csv))
+
+	dep := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "manager"}},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "manager"}},
+			Template: corev1.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "manager"}}},
+		},
+	}
+	propagateLabels(dep, csv)
+	expected := map[string]string{"team": "payments", "cost-center": "cc-42", "app": "manager"}
+	require.Equal(t, expected, dep.GetLabels())
+	require.Equal(t, expected, dep.Spec.Template.GetLabels())
+
+	// nothing is propagated without the annotation
+	unlabeled := &appsv1.Deployment{}
+	propagateLabels(unlabeled, &v1alpha1.ClusterServiceVersion{ObjectMeta: metav1.ObjectMeta{Labels: csv.Labels}})
+	require.Nil(t, unlabeled.GetLabels())
+}
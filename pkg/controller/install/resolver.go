@@ -4,10 +4,14 @@
 package install
 
 import (
+	"context"
 	"fmt"
 
+	"k8s.io/client-go/dynamic"
+
 	"github.com/operator-framework/api/pkg/operators/v1alpha1"
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/api/wrappers"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/audit"
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/operatorclient"
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/operatorlister"
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/ownerutil"
@@ -18,8 +22,8 @@ type Strategy interface {
 }
 
 type StrategyInstaller interface {
-	Install(strategy Strategy) error
-	CheckInstalled(strategy Strategy) (bool, error)
+	Install(ctx context.Context, strategy Strategy) error
+	CheckInstalled(ctx context.Context, strategy Strategy) (bool, error)
 }
 
 type StrategyResolverInterface interface {
@@ -29,6 +33,14 @@ type StrategyResolverInterface interface {
 
 type StrategyResolver struct {
 	OverridesBuilderFunc DeploymentInitializerBuilderFunc
+	CertLifetimeFunc     CertLifetimeFunc
+
+	// CertManagerIssuerFunc selects the owners whose serving certs are issued by cert-manager through CertManagerClient.
+	CertManagerIssuerFunc CertManagerIssuerFunc
+	CertManagerClient     dynamic.Interface
+
+	// Auditor records the resources installers create, update and delete on behalf of their CSV, if set.
+	Auditor *audit.Recorder
 }
 
 func (r *StrategyResolver) UnmarshalStrategy(s v1alpha1.NamedInstallStrategy) (strategy Strategy, err error) {
@@ -44,13 +56,26 @@ func (r *StrategyResolver) InstallerForStrategy(strategyName string, opClient op
 	switch strategyName {
 	case v1alpha1.InstallStrategyNameDeployment:
 		strategyClient := wrappers.NewInstallStrategyDeploymentClient(opClient, opLister, owner.GetNamespace())
+		if r.Auditor != nil {
+			strategyClient = newAuditedStrategyClient(strategyClient, r.Auditor, owner)
+		}
 
 		initializers := []DeploymentInitializerFunc{}
 		if r.OverridesBuilderFunc != nil {
 			initializers = append(initializers, r.OverridesBuilderFunc(owner))
 		}
 
-		return NewStrategyDeploymentInstaller(strategyClient, annotations, owner, previousStrategy, initializers, apiServiceDescriptions, webhookDescriptions)
+		installer := NewStrategyDeploymentInstaller(strategyClient, annotations, owner, previousStrategy, initializers, apiServiceDescriptions, webhookDescriptions).(*StrategyDeploymentInstaller)
+		if r.CertLifetimeFunc != nil {
+			lifetime := r.CertLifetimeFunc(owner)
+			installer.certLifetime = &lifetime
+		}
+		if r.CertManagerIssuerFunc != nil && r.CertManagerClient != nil {
+			installer.certIssuer = r.CertManagerIssuerFunc(owner)
+			installer.certManagerClient = r.CertManagerClient
+		}
+
+		return installer
 	}
 
 	// Insurance against these functions being called incorrectly (unmarshal strategy will return a valid strategy name)
@@ -61,10 +86,10 @@ type NullStrategyInstaller struct{}
 
 var _ StrategyInstaller = &NullStrategyInstaller{}
 
-func (i *NullStrategyInstaller) Install(s Strategy) error {
+func (i *NullStrategyInstaller) Install(ctx context.Context, s Strategy) error {
 	return fmt.Errorf("null InstallStrategy used")
 }
 
-func (i *NullStrategyInstaller) CheckInstalled(s Strategy) (bool, error) {
+func (i *NullStrategyInstaller) CheckInstalled(ctx context.Context, s Strategy) (bool, error) {
 	return true, nil
 }
@@ -2,10 +2,15 @@ package install
 
 import (
 	"fmt"
+	"sort"
 
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/kubernetes/pkg/registry/rbac/validation"
 	rbacauthorizer "github.com/operator-framework/operator-lifecycle-manager/pkg/lib/kubernetes/plugin/pkg/auth/authorizer/rbac"
+	log "github.com/sirupsen/logrus"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apiserver/pkg/authorization/authorizer"
 	crbacv1 "k8s.io/client-go/listers/rbac/v1"
@@ -50,26 +55,89 @@ func (c *CSVRuleChecker) RuleSatisfied(sa *corev1.ServiceAccount, namespace stri
 		return false, fmt.Errorf("rule invalid: %s", err.Error())
 	}
 
-	// get attributes set for the given Role and ServiceAccount
+	// gather the rules granted to the ServiceAccount and check that they cover the rule, with the semantics the API
+	// server uses to prevent privilege escalation: wildcards only cover wildcards, "*/subresource" covers subresources
+	// and a trailing "*" in a non-resource URL covers the URLs it prefixes
 	user := toDefaultInfo(sa)
-	attributesSet := toAttributesSet(user, namespace, rule)
+	grantedRules, err := validation.NewDefaultRuleResolver(c, c, c, c).RulesFor(user, namespace)
+	if err != nil {
+		// bindings to missing roles don't grant anything, the remaining rules may still cover the rule
+		log.Debugf("error resolving rules for %s: %v", user.GetName(), err)
+	}
+	covered, uncovered := validation.Covers(grantedRules, []rbacv1.PolicyRule{coverableRule(rule)})
+	if !covered {
+		log.Debugf("rules not covered for %s in namespace %q: %+v", user.GetName(), namespace, uncovered)
+	}
+
+	return covered, nil
+}
+
+// AccessReview is the outcome of authorizing one of the requests described by a PolicyRule, as a
+// SubjectAccessReview for the same request would report it.
+type AccessReview struct {
+	Verb           string `json:"verb"`
+	APIGroup       string `json:"apiGroup,omitempty"`
+	Resource       string `json:"resource,omitempty"`
+	Name           string `json:"name,omitempty"`
+	NonResourceURL string `json:"nonResourceURL,omitempty"`
+	Namespace      string `json:"namespace,omitempty"`
+	Allowed        bool   `json:"allowed"`
+	// Reason names the binding allowing the request, or why it couldn't be authorized.
+	Reason string `json:"reason,omitempty"`
+}
+
+// ReviewRule returns the outcome of authorizing each request described by a PolicyRule for a ServiceAccount in a
+// namespace, sorted by verb, group, resource, name and URL. Unlike RuleSatisfied, it authorizes every request.
+func (c *CSVRuleChecker) ReviewRule(sa *corev1.ServiceAccount, namespace string, rule rbacv1.PolicyRule) ([]AccessReview, error) {
+	if err := ruleValid(rule); err != nil {
+		return nil, fmt.Errorf("rule invalid: %s", err.Error())
+	}
 
-	// create a new RBACAuthorizer
+	user := toDefaultInfo(sa)
 	rbacAuthorizer := rbacauthorizer.New(c, c, c, c)
 
-	// ensure all attributes are authorized
-	for _, attributes := range attributesSet {
-		decision, _, err := rbacAuthorizer.Authorize(attributes)
+	var reviews []AccessReview
+	for _, attributes := range toAttributesSet(user, namespace, rule) {
+		decision, reason, err := rbacAuthorizer.Authorize(attributes)
 		if err != nil {
-			return false, err
+			return nil, err
 		}
-
-		if decision == authorizer.DecisionDeny || decision == authorizer.DecisionNoOpinion {
-			return false, nil
+		if decision != authorizer.DecisionAllow && reason == "" {
+			reason = "RBAC: no rule grants the request"
+		}
+		resource := attributes.GetResource()
+		if subresource := attributes.GetSubresource(); subresource != "" {
+			resource = resource + "/" + subresource
 		}
+		reviews = append(reviews, AccessReview{
+			Verb:           attributes.GetVerb(),
+			APIGroup:       attributes.GetAPIGroup(),
+			Resource:       resource,
+			Name:           attributes.GetName(),
+			NonResourceURL: attributes.GetPath(),
+			Namespace:      attributes.GetNamespace(),
+			Allowed:        decision == authorizer.DecisionAllow,
+			Reason:         reason,
+		})
 	}
 
-	return true, nil
+	sort.Slice(reviews, func(i, j int) bool {
+		a, b := reviews[i], reviews[j]
+		if a.Verb != b.Verb {
+			return a.Verb < b.Verb
+		}
+		if a.APIGroup != b.APIGroup {
+			return a.APIGroup < b.APIGroup
+		}
+		if a.Resource != b.Resource {
+			return a.Resource < b.Resource
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.NonResourceURL < b.NonResourceURL
+	})
+	return reviews, nil
 }
 
 func (c *CSVRuleChecker) GetRole(namespace, name string) (*rbacv1.Role, error) {
@@ -117,9 +185,45 @@ func (c *CSVRuleChecker) GetClusterRole(name string) (*rbacv1.ClusterRole, error
 		return &rbacv1.ClusterRole{}, nil
 	}
 
+	if clusterRole != nil && clusterRole.AggregationRule != nil {
+		return c.aggregate(clusterRole)
+	}
+
 	return clusterRole, nil
 }
 
+// aggregate returns a copy of the given aggregated ClusterRole that also grants the rules of the ClusterRoles its
+// AggregationRule selects, as the aggregation controller will, so that a check made before the controller has
+// caught up doesn't fail.
+func (c *CSVRuleChecker) aggregate(clusterRole *rbacv1.ClusterRole) (*rbacv1.ClusterRole, error) {
+	aggregated := clusterRole.DeepCopy()
+	for _, labelSelector := range clusterRole.AggregationRule.ClusterRoleSelectors {
+		selector, err := metav1.LabelSelectorAsSelector(&labelSelector)
+		if err != nil {
+			return nil, err
+		}
+		selected, err := c.clusterRoleLister.List(selector)
+		if err != nil {
+			return nil, err
+		}
+		sort.Slice(selected, func(i, j int) bool {
+			return selected[i].GetName() < selected[j].GetName()
+		})
+		for _, role := range selected {
+			if role.GetName() == clusterRole.GetName() || ownerutil.HasOwnerConflict(c.csv, role.GetOwnerReferences()) {
+				continue
+			}
+			for _, rule := range role.Rules {
+				if !ruleExists(aggregated.Rules, rule) {
+					aggregated.Rules = append(aggregated.Rules, rule)
+				}
+			}
+		}
+	}
+
+	return aggregated, nil
+}
+
 func (c *CSVRuleChecker) ListClusterRoleBindings() ([]*rbacv1.ClusterRoleBinding, error) {
 	// get all RoleBindings
 	crbList, err := c.clusterRoleBindingLister.List(labels.Everything())
@@ -138,6 +242,31 @@ func (c *CSVRuleChecker) ListClusterRoleBindings() ([]*rbacv1.ClusterRoleBinding
 	return filtered, nil
 }
 
+func ruleExists(rules []rbacv1.PolicyRule, rule rbacv1.PolicyRule) bool {
+	for _, r := range rules {
+		if equality.Semantic.DeepEqual(r, rule) {
+			return true
+		}
+	}
+	return false
+}
+
+// coverableRule returns the given PolicyRule with the core group and an empty resource standing for empty groups and
+// resources, as toAttributesSet does, since validation.Covers considers a rule without any to be covered.
+func coverableRule(rule rbacv1.PolicyRule) rbacv1.PolicyRule {
+	rule = *rule.DeepCopy()
+	if len(rule.NonResourceURLs) > 0 {
+		return rule
+	}
+	if len(rule.APIGroups) == 0 {
+		rule.APIGroups = []string{""}
+	}
+	if len(rule.Resources) == 0 {
+		rule.Resources = []string{""}
+	}
+	return rule
+}
+
 // ruleValid returns an error if the given PolicyRule is not valid (resource and nonresource attributes defined)
 func ruleValid(rule rbacv1.PolicyRule) error {
 	if len(rule.Verbs) == 0 {
@@ -0,0 +1,152 @@
+package install
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/metrics"
+)
+
+// maxRuleCheckCacheEntries bounds the results held by a RuleCheckCache, which is reset once it holds that many.
+const maxRuleCheckCacheEntries = 50000
+
+type ruleCheckKey struct {
+	csv       types.UID
+	sa        types.UID
+	namespace string
+	rule      string
+}
+
+// RuleCheckCache caches whether PolicyRules are satisfied for the ServiceAccounts of CSVs, so that the RBAC of a
+// CSV is only evaluated again once it may have changed. Results are invalidated by the RBAC events it is registered
+// to handle: Role and RoleBinding changes invalidate the results for their namespace, and ClusterRole and
+// ClusterRoleBinding changes, which may affect any namespace, invalidate all results.
+type RuleCheckCache struct {
+	mu      sync.Mutex
+	results map[ruleCheckKey]bool
+	// generation is bumped on every invalidation, so that results evaluated concurrently with an RBAC change are
+	// not cached.
+	generation uint64
+}
+
+// NewRuleCheckCache returns an empty RuleCheckCache.
+func NewRuleCheckCache() *RuleCheckCache {
+	return &RuleCheckCache{results: map[ruleCheckKey]bool{}}
+}
+
+// Checker returns a RuleChecker caching the results of the given RuleChecker, which checks the rules of the given CSV.
+func (c *RuleCheckCache) Checker(checker RuleChecker, csv *v1alpha1.ClusterServiceVersion) RuleChecker {
+	return &cachedRuleChecker{cache: c, checker: checker, csv: csv.GetUID()}
+}
+
+// InvalidateNamespace drops the results for rules evaluated in the given namespace.
+func (c *RuleCheckCache) InvalidateNamespace(namespace string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generation++
+	for key := range c.results {
+		if key.namespace == namespace {
+			delete(c.results, key)
+		}
+	}
+}
+
+// InvalidateAll drops all results.
+func (c *RuleCheckCache) InvalidateAll() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generation++
+	c.results = map[ruleCheckKey]bool{}
+}
+
+// EventHandler returns an informer event handler invalidating the results affected by changes to Roles, RoleBindings,
+// ClusterRoles and ClusterRoleBindings. Resyncs, which don't change objects, are ignored.
+func (c *RuleCheckCache) EventHandler() cache.ResourceEventHandler {
+	invalidate := func(obj interface{}) {
+		if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+			obj = tombstone.Obj
+		}
+		switch o := obj.(type) {
+		case *rbacv1.Role:
+			c.InvalidateNamespace(o.GetNamespace())
+		case *rbacv1.RoleBinding:
+			c.InvalidateNamespace(o.GetNamespace())
+		default:
+			c.InvalidateAll()
+		}
+	}
+	return cache.ResourceEventHandlerFuncs{
+		AddFunc: invalidate,
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldMeta, oldOk := oldObj.(metav1.Object)
+			newMeta, newOk := newObj.(metav1.Object)
+			if oldOk && newOk && oldMeta.GetResourceVersion() == newMeta.GetResourceVersion() {
+				return
+			}
+			invalidate(newObj)
+		},
+		DeleteFunc: invalidate,
+	}
+}
+
+func (c *RuleCheckCache) get(key ruleCheckKey) (satisfied, ok bool, generation uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	satisfied, ok = c.results[key]
+	return satisfied, ok, c.generation
+}
+
+func (c *RuleCheckCache) set(key ruleCheckKey, satisfied bool, generation uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if generation != c.generation {
+		return
+	}
+	if len(c.results) >= maxRuleCheckCacheEntries {
+		c.results = map[ruleCheckKey]bool{}
+	}
+	c.results[key] = satisfied
+}
+
+type cachedRuleChecker struct {
+	cache   *RuleCheckCache
+	checker RuleChecker
+	csv     types.UID
+}
+
+// RuleSatisfied returns the cached result for the given rule if any, or checks it with the wrapped RuleChecker.
+// Errors aren't cached.
+func (c *cachedRuleChecker) RuleSatisfied(sa *corev1.ServiceAccount, namespace string, rule rbacv1.PolicyRule) (bool, error) {
+	marshalled, err := json.Marshal(rule)
+	if err != nil {
+		return c.checker.RuleSatisfied(sa, namespace, rule)
+	}
+	hash := sha256.Sum256(marshalled)
+	key := ruleCheckKey{csv: c.csv, sa: sa.GetUID(), namespace: namespace, rule: hex.EncodeToString(hash[:])}
+
+	satisfied, ok, generation := c.cache.get(key)
+	metrics.EmitRuleCheckCacheLookup(ok)
+	if ok {
+		return satisfied, nil
+	}
+
+	satisfied, err = c.checker.RuleSatisfied(sa, namespace, rule)
+	if err != nil {
+		return false, err
+	}
+	c.cache.set(key, satisfied, generation)
+	return satisfied, nil
+}
@@ -0,0 +1,121 @@
+package install
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+)
+
+type countingRuleChecker struct {
+	satisfied bool
+	err       error
+	calls     int
+}
+
+func (c *countingRuleChecker) RuleSatisfied(sa *corev1.ServiceAccount, namespace string, rule rbacv1.PolicyRule) (bool, error) {
+	c.calls++
+	return c.satisfied, c.err
+}
+
+func TestRuleCheckCache(t *testing.T) {
+	csv := &v1alpha1.ClusterServiceVersion{ObjectMeta: metav1.ObjectMeta{Name: "csv", UID: "csv"}}
+	sa := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "sa", UID: "sa"}}
+	rule := rbacv1.PolicyRule{APIGroups: []string{""}, Verbs: []string{"get"}, Resources: []string{"donuts"}}
+	otherRule := rbacv1.PolicyRule{APIGroups: []string{""}, Verbs: []string{"list"}, Resources: []string{"donuts"}}
+
+	c := NewRuleCheckCache()
+	inner := &countingRuleChecker{satisfied: true}
+	checker := c.Checker(inner, csv)
+
+	check := func(namespace string, rule rbacv1.PolicyRule) {
+		satisfied, err := checker.RuleSatisfied(sa, namespace, rule)
+		require.NoError(t, err)
+		require.Equal(t, inner.satisfied, satisfied)
+	}
+
+	// Results are cached per namespace and rule
+	check("ns", rule)
+	check("ns", rule)
+	require.Equal(t, 1, inner.calls)
+	check("ns", otherRule)
+	check(metav1.NamespaceAll, rule)
+	require.Equal(t, 3, inner.calls)
+
+	// Results aren't shared across CSVs
+	_, err := c.Checker(inner, &v1alpha1.ClusterServiceVersion{ObjectMeta: metav1.ObjectMeta{UID: "other"}}).RuleSatisfied(sa, "ns", rule)
+	require.NoError(t, err)
+	require.Equal(t, 4, inner.calls)
+
+	handler := c.EventHandler()
+
+	// Resyncs don't invalidate results
+	role := &rbacv1.Role{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "role", ResourceVersion: "1"}}
+	handler.OnUpdate(role, role)
+	check("ns", rule)
+	require.Equal(t, 4, inner.calls)
+
+	// Namespaced RBAC changes invalidate the results of their namespace
+	updated := role.DeepCopy()
+	updated.ResourceVersion = "2"
+	inner.satisfied = false
+	handler.OnUpdate(role, updated)
+	check("ns", rule)
+	require.Equal(t, 5, inner.calls)
+	satisfied, err := checker.RuleSatisfied(sa, metav1.NamespaceAll, rule)
+	require.NoError(t, err)
+	require.True(t, satisfied, "cluster scoped results should still be cached")
+	require.Equal(t, 5, inner.calls)
+	handler.OnDelete(cache.DeletedFinalStateUnknown{Key: "ns/binding", Obj: &rbacv1.RoleBinding{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "binding"}}})
+	check("ns", rule)
+	require.Equal(t, 6, inner.calls)
+
+	// Cluster RBAC changes invalidate all results
+	handler.OnAdd(&rbacv1.ClusterRoleBinding{ObjectMeta: metav1.ObjectMeta{Name: "binding"}})
+	check("ns", rule)
+	check(metav1.NamespaceAll, rule)
+	require.Equal(t, 8, inner.calls)
+
+	// Errors aren't cached
+	inner.err = errors.New("failed")
+	handler.OnAdd(&rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: "role"}})
+	_, err = checker.RuleSatisfied(sa, "ns", rule)
+	require.Error(t, err)
+	_, err = checker.RuleSatisfied(sa, "ns", rule)
+	require.Error(t, err)
+	require.Equal(t, 10, inner.calls)
+}
+
+func TestRuleCheckCacheConcurrentInvalidation(t *testing.T) {
+	csv := &v1alpha1.ClusterServiceVersion{ObjectMeta: metav1.ObjectMeta{Name: "csv", UID: "csv"}}
+	sa := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "sa", UID: "sa"}}
+	rule := rbacv1.PolicyRule{APIGroups: []string{""}, Verbs: []string{"get"}, Resources: []string{"donuts"}}
+
+	c := NewRuleCheckCache()
+	// RBAC changes while the rule is checked
+	inner := &invalidatingRuleChecker{cache: c}
+	checker := c.Checker(inner, csv)
+
+	_, err := checker.RuleSatisfied(sa, "ns", rule)
+	require.NoError(t, err)
+	_, err = checker.RuleSatisfied(sa, "ns", rule)
+	require.NoError(t, err)
+	require.Equal(t, 2, inner.calls)
+}
+
+type invalidatingRuleChecker struct {
+	cache *RuleCheckCache
+	calls int
+}
+
+func (c *invalidatingRuleChecker) RuleSatisfied(sa *corev1.ServiceAccount, namespace string, rule rbacv1.PolicyRule) (bool, error) {
+	c.calls++
+	c.cache.InvalidateAll()
+	return true, nil
+}
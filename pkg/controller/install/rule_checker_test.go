@@ -572,6 +572,268 @@ func TestRuleSatisfied(t *testing.T) {
 	}
 }
 
+func TestReviewRule(t *testing.T) {
+	csv := &v1alpha1.ClusterServiceVersion{}
+	csv.SetName("barista-operator")
+	csv.SetUID(types.UID("barista-operator"))
+
+	sa := &corev1.ServiceAccount{}
+	sa.SetNamespace("coffee-shop")
+	sa.SetName("barista-operator")
+	sa.SetUID(types.UID("barista-operator"))
+
+	k8sObjs := Objs(
+		[]*rbacv1.Role{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "barista-operator", Namespace: "coffee-shop"},
+				Rules: []rbacv1.PolicyRule{
+					{APIGroups: []string{""}, Verbs: []string{"get"}, Resources: []string{"donuts"}},
+				},
+			},
+		},
+		[]*rbacv1.RoleBinding{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "barista-operator", Namespace: "coffee-shop"},
+				Subjects:   []rbacv1.Subject{{Kind: "ServiceAccount", Name: "barista-operator", Namespace: "coffee-shop"}},
+				RoleRef:    rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "Role", Name: "barista-operator"},
+			},
+		},
+		nil,
+		nil,
+	)
+
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	ruleChecker, err := NewFakeCSVRuleChecker(k8sObjs, csv, "coffee-shop", stopCh)
+	require.NoError(t, err)
+
+	reviews, err := ruleChecker.ReviewRule(sa, "coffee-shop", rbacv1.PolicyRule{
+		APIGroups: []string{""},
+		Verbs:     []string{"list", "get"},
+		Resources: []string{"donuts"},
+	})
+	require.NoError(t, err)
+	require.Len(t, reviews, 2)
+
+	require.Equal(t, "get", reviews[0].Verb)
+	require.Equal(t, "donuts", reviews[0].Resource)
+	require.Equal(t, "coffee-shop", reviews[0].Namespace)
+	require.True(t, reviews[0].Allowed)
+	require.Contains(t, reviews[0].Reason, `RoleBinding "barista-operator/coffee-shop"`)
+
+	require.Equal(t, "list", reviews[1].Verb)
+	require.False(t, reviews[1].Allowed)
+	require.NotEmpty(t, reviews[1].Reason)
+
+	_, err = ruleChecker.ReviewRule(sa, "coffee-shop", rbacv1.PolicyRule{})
+	require.Error(t, err)
+}
+
+func TestRuleSatisfiedTrickyGrants(t *testing.T) {
+	csv := &v1alpha1.ClusterServiceVersion{}
+	csv.SetName("barista-operator")
+	csv.SetUID(types.UID("barista-operator"))
+
+	sa := &corev1.ServiceAccount{}
+	sa.SetNamespace("coffee-shop")
+	sa.SetName("barista-operator")
+	sa.SetUID(types.UID("barista-operator"))
+
+	saSubject := rbacv1.Subject{Kind: "ServiceAccount", Name: "barista-operator", Namespace: "coffee-shop"}
+	clusterRole := func(name string, labels map[string]string, rules ...rbacv1.PolicyRule) *rbacv1.ClusterRole {
+		return &rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}, Rules: rules}
+	}
+	clusterRoleBinding := func(clusterRole string, subject rbacv1.Subject) *rbacv1.ClusterRoleBinding {
+		return &rbacv1.ClusterRoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: clusterRole},
+			Subjects:   []rbacv1.Subject{subject},
+			RoleRef:    rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "ClusterRole", Name: clusterRole},
+		}
+	}
+
+	tests := []struct {
+		description         string
+		namespace           string
+		rule                rbacv1.PolicyRule
+		clusterRoles        []*rbacv1.ClusterRole
+		clusterRoleBindings []*rbacv1.ClusterRoleBinding
+		roleBindings        []*rbacv1.RoleBinding
+		satisfied           bool
+	}{
+		{
+			description:         "WildcardGrantCoversSpecificRequest",
+			namespace:           "coffee-shop",
+			rule:                rbacv1.PolicyRule{APIGroups: []string{"apps"}, Verbs: []string{"get", "list"}, Resources: []string{"deployments"}},
+			clusterRoles:        []*rbacv1.ClusterRole{clusterRole("admin", nil, rbacv1.PolicyRule{APIGroups: []string{"*"}, Verbs: []string{"*"}, Resources: []string{"*"}})},
+			clusterRoleBindings: []*rbacv1.ClusterRoleBinding{clusterRoleBinding("admin", saSubject)},
+			satisfied:           true,
+		},
+		{
+			description:         "WildcardGrantCoversWildcardRequest",
+			namespace:           "coffee-shop",
+			rule:                rbacv1.PolicyRule{APIGroups: []string{"*"}, Verbs: []string{"*"}, Resources: []string{"*"}},
+			clusterRoles:        []*rbacv1.ClusterRole{clusterRole("admin", nil, rbacv1.PolicyRule{APIGroups: []string{"*"}, Verbs: []string{"*"}, Resources: []string{"*"}})},
+			clusterRoleBindings: []*rbacv1.ClusterRoleBinding{clusterRoleBinding("admin", saSubject)},
+			satisfied:           true,
+		},
+		{
+			description: "EnumeratedVerbsDoNotCoverWildcardVerb",
+			namespace:   "coffee-shop",
+			rule:        rbacv1.PolicyRule{APIGroups: []string{""}, Verbs: []string{"*"}, Resources: []string{"donuts"}},
+			clusterRoles: []*rbacv1.ClusterRole{clusterRole("donuts", nil, rbacv1.PolicyRule{
+				APIGroups: []string{""},
+				Verbs:     []string{"get", "list", "watch", "create", "update", "patch", "delete", "deletecollection"},
+				Resources: []string{"donuts"},
+			})},
+			clusterRoleBindings: []*rbacv1.ClusterRoleBinding{clusterRoleBinding("donuts", saSubject)},
+			satisfied:           false,
+		},
+		{
+			description:         "EnumeratedGroupsDoNotCoverWildcardGroup",
+			namespace:           "coffee-shop",
+			rule:                rbacv1.PolicyRule{APIGroups: []string{"*"}, Verbs: []string{"get"}, Resources: []string{"donuts"}},
+			clusterRoles:        []*rbacv1.ClusterRole{clusterRole("donuts", nil, rbacv1.PolicyRule{APIGroups: []string{"", "bakery.io"}, Verbs: []string{"get"}, Resources: []string{"donuts"}})},
+			clusterRoleBindings: []*rbacv1.ClusterRoleBinding{clusterRoleBinding("donuts", saSubject)},
+			satisfied:           false,
+		},
+		{
+			description:         "WildcardSubresourceCoversSubresource",
+			namespace:           "coffee-shop",
+			rule:                rbacv1.PolicyRule{APIGroups: []string{"apps"}, Verbs: []string{"update"}, Resources: []string{"deployments/scale"}},
+			clusterRoles:        []*rbacv1.ClusterRole{clusterRole("scaler", nil, rbacv1.PolicyRule{APIGroups: []string{"apps"}, Verbs: []string{"update"}, Resources: []string{"*/scale"}})},
+			clusterRoleBindings: []*rbacv1.ClusterRoleBinding{clusterRoleBinding("scaler", saSubject)},
+			satisfied:           true,
+		},
+		{
+			description:         "ResourceDoesNotCoverSubresource",
+			namespace:           "coffee-shop",
+			rule:                rbacv1.PolicyRule{APIGroups: []string{"apps"}, Verbs: []string{"update"}, Resources: []string{"deployments/scale"}},
+			clusterRoles:        []*rbacv1.ClusterRole{clusterRole("deployer", nil, rbacv1.PolicyRule{APIGroups: []string{"apps"}, Verbs: []string{"update"}, Resources: []string{"deployments"}})},
+			clusterRoleBindings: []*rbacv1.ClusterRoleBinding{clusterRoleBinding("deployer", saSubject)},
+			satisfied:           false,
+		},
+		{
+			description:         "ResourceNamesCoverNamedRequest",
+			namespace:           "coffee-shop",
+			rule:                rbacv1.PolicyRule{APIGroups: []string{""}, Verbs: []string{"get"}, Resources: []string{"donuts"}, ResourceNames: []string{"glazed"}},
+			clusterRoles:        []*rbacv1.ClusterRole{clusterRole("glazed", nil, rbacv1.PolicyRule{APIGroups: []string{""}, Verbs: []string{"get"}, Resources: []string{"donuts"}, ResourceNames: []string{"glazed", "sprinkled"}})},
+			clusterRoleBindings: []*rbacv1.ClusterRoleBinding{clusterRoleBinding("glazed", saSubject)},
+			satisfied:           true,
+		},
+		{
+			description:         "ResourceNamesDoNotCoverUnnamedRequest",
+			namespace:           "coffee-shop",
+			rule:                rbacv1.PolicyRule{APIGroups: []string{""}, Verbs: []string{"get"}, Resources: []string{"donuts"}},
+			clusterRoles:        []*rbacv1.ClusterRole{clusterRole("glazed", nil, rbacv1.PolicyRule{APIGroups: []string{""}, Verbs: []string{"get"}, Resources: []string{"donuts"}, ResourceNames: []string{"glazed"}})},
+			clusterRoleBindings: []*rbacv1.ClusterRoleBinding{clusterRoleBinding("glazed", saSubject)},
+			satisfied:           false,
+		},
+		{
+			description:         "NonResourceURLPrefixCoversURL",
+			rule:                rbacv1.PolicyRule{Verbs: []string{"get"}, NonResourceURLs: []string{"/metrics/cpu"}},
+			clusterRoles:        []*rbacv1.ClusterRole{clusterRole("metrics", nil, rbacv1.PolicyRule{Verbs: []string{"get"}, NonResourceURLs: []string{"/metrics/*"}})},
+			clusterRoleBindings: []*rbacv1.ClusterRoleBinding{clusterRoleBinding("metrics", saSubject)},
+			satisfied:           true,
+		},
+		{
+			description:         "NonResourceURLDoesNotCoverPrefix",
+			rule:                rbacv1.PolicyRule{Verbs: []string{"get"}, NonResourceURLs: []string{"/metrics/*"}},
+			clusterRoles:        []*rbacv1.ClusterRole{clusterRole("metrics", nil, rbacv1.PolicyRule{Verbs: []string{"get"}, NonResourceURLs: []string{"/metrics/cpu"}})},
+			clusterRoleBindings: []*rbacv1.ClusterRoleBinding{clusterRoleBinding("metrics", saSubject)},
+			satisfied:           false,
+		},
+		{
+			description: "AggregatedClusterRoleNotYetAggregated",
+			namespace:   "coffee-shop",
+			rule:        rbacv1.PolicyRule{APIGroups: []string{""}, Verbs: []string{"get"}, Resources: []string{"donuts"}},
+			clusterRoles: []*rbacv1.ClusterRole{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "bakery"},
+					AggregationRule: &rbacv1.AggregationRule{ClusterRoleSelectors: []metav1.LabelSelector{
+						{MatchLabels: map[string]string{"aggregate-to-bakery": "true"}},
+					}},
+				},
+				clusterRole("donut-reader", map[string]string{"aggregate-to-bakery": "true"}, rbacv1.PolicyRule{APIGroups: []string{""}, Verbs: []string{"get"}, Resources: []string{"donuts"}}),
+			},
+			clusterRoleBindings: []*rbacv1.ClusterRoleBinding{clusterRoleBinding("bakery", saSubject)},
+			satisfied:           true,
+		},
+		{
+			description: "AggregatedClusterRoleIgnoresUnselected",
+			namespace:   "coffee-shop",
+			rule:        rbacv1.PolicyRule{APIGroups: []string{""}, Verbs: []string{"get"}, Resources: []string{"donuts"}},
+			clusterRoles: []*rbacv1.ClusterRole{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "bakery"},
+					AggregationRule: &rbacv1.AggregationRule{ClusterRoleSelectors: []metav1.LabelSelector{
+						{MatchLabels: map[string]string{"aggregate-to-bakery": "true"}},
+					}},
+				},
+				clusterRole("donut-reader", map[string]string{"aggregate-to-cafe": "true"}, rbacv1.PolicyRule{APIGroups: []string{""}, Verbs: []string{"get"}, Resources: []string{"donuts"}}),
+			},
+			clusterRoleBindings: []*rbacv1.ClusterRoleBinding{clusterRoleBinding("bakery", saSubject)},
+			satisfied:           false,
+		},
+		{
+			description:  "ClusterRoleBoundInNamespace",
+			namespace:    "coffee-shop",
+			rule:         rbacv1.PolicyRule{APIGroups: []string{""}, Verbs: []string{"get"}, Resources: []string{"donuts"}},
+			clusterRoles: []*rbacv1.ClusterRole{clusterRole("donut-reader", nil, rbacv1.PolicyRule{APIGroups: []string{""}, Verbs: []string{"get"}, Resources: []string{"donuts"}})},
+			roleBindings: []*rbacv1.RoleBinding{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "donut-reader", Namespace: "coffee-shop"},
+					Subjects:   []rbacv1.Subject{saSubject},
+					RoleRef:    rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "ClusterRole", Name: "donut-reader"},
+				},
+			},
+			satisfied: true,
+		},
+		{
+			description:  "ClusterRoleBoundInNamespaceNotClusterWide",
+			rule:         rbacv1.PolicyRule{APIGroups: []string{""}, Verbs: []string{"get"}, Resources: []string{"donuts"}},
+			clusterRoles: []*rbacv1.ClusterRole{clusterRole("donut-reader", nil, rbacv1.PolicyRule{APIGroups: []string{""}, Verbs: []string{"get"}, Resources: []string{"donuts"}})},
+			roleBindings: []*rbacv1.RoleBinding{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "donut-reader", Namespace: "coffee-shop"},
+					Subjects:   []rbacv1.Subject{saSubject},
+					RoleRef:    rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "ClusterRole", Name: "donut-reader"},
+				},
+			},
+			satisfied: false,
+		},
+		{
+			description:         "BoundToServiceAccountsGroup",
+			namespace:           "coffee-shop",
+			rule:                rbacv1.PolicyRule{APIGroups: []string{""}, Verbs: []string{"get"}, Resources: []string{"donuts"}},
+			clusterRoles:        []*rbacv1.ClusterRole{clusterRole("donut-reader", nil, rbacv1.PolicyRule{APIGroups: []string{""}, Verbs: []string{"get"}, Resources: []string{"donuts"}})},
+			clusterRoleBindings: []*rbacv1.ClusterRoleBinding{clusterRoleBinding("donut-reader", rbacv1.Subject{Kind: "Group", Name: "system:serviceaccounts:coffee-shop"})},
+			satisfied:           true,
+		},
+		{
+			description:         "BindingToMissingClusterRoleIgnored",
+			namespace:           "coffee-shop",
+			rule:                rbacv1.PolicyRule{APIGroups: []string{""}, Verbs: []string{"get"}, Resources: []string{"donuts"}},
+			clusterRoles:        []*rbacv1.ClusterRole{clusterRole("donut-reader", nil, rbacv1.PolicyRule{APIGroups: []string{""}, Verbs: []string{"get"}, Resources: []string{"donuts"}})},
+			clusterRoleBindings: []*rbacv1.ClusterRoleBinding{clusterRoleBinding("missing", saSubject), clusterRoleBinding("donut-reader", saSubject)},
+			satisfied:           true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			stopCh := make(chan struct{})
+			defer close(stopCh)
+
+			ruleChecker, err := NewFakeCSVRuleChecker(Objs(nil, tt.roleBindings, tt.clusterRoles, tt.clusterRoleBindings), csv, "coffee-shop", stopCh)
+			require.NoError(t, err)
+
+			satisfied, err := ruleChecker.RuleSatisfied(sa, tt.namespace, tt.rule)
+			require.NoError(t, err)
+			require.Equal(t, tt.satisfied, satisfied)
+		})
+	}
+}
+
 func NewFakeCSVRuleChecker(k8sObjs []runtime.Object, csv *v1alpha1.ClusterServiceVersion, namespace string, stopCh <-chan struct{}) (*CSVRuleChecker, error) {
 	// create client fakes
 	opClientFake := operatorclient.NewClient(k8sfake.NewSimpleClientset(k8sObjs...), apiextensionsfake.NewSimpleClientset(), apiregistrationfake.NewSimpleClientset())
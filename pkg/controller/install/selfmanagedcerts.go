@@ -0,0 +1,28 @@
+package install
+
+import (
+	"fmt"
+
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/certs"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/ownerutil"
+)
+
+// SelfManagedCABundleAnnotationKey is the CSV annotation by which an operator declares that it manages the serving
+// certs of its APIServices and webhooks itself, holding the PEM-encoded CA bundle that signs them. OLM then creates
+// neither cert Secrets nor their RBAC, never rotates the certs, and injects the given bundle as is.
+const SelfManagedCABundleAnnotationKey = "operatorframework.io/self-managed-ca-bundle"
+
+// SelfManagedCABundle returns the CA bundle of the given owner if it manages its own serving certs, and whether it
+// does. An error is returned if the bundle isn't a valid PEM-encoded certificate.
+func SelfManagedCABundle(owner ownerutil.Owner) ([]byte, bool, error) {
+	value, ok := owner.GetAnnotations()[SelfManagedCABundleAnnotationKey]
+	if !ok {
+		return nil, false, nil
+	}
+
+	caPEM := []byte(value)
+	if _, err := certs.PEMToCert(caPEM); err != nil {
+		return nil, true, fmt.Errorf("invalid %s annotation: %v", SelfManagedCABundleAnnotationKey, err)
+	}
+	return caPEM, true, nil
+}
@@ -0,0 +1,102 @@
+package install
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/api/wrappers/wrappersfakes"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/operatorclient"
+)
+
+func TestSelfManagedCABundle(t *testing.T) {
+	caPEM, _, err := keyPair(t, time.Now().Add(time.Hour)).ToPEM()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		want        []byte
+		selfManaged bool
+		wantErr     bool
+	}{
+		{name: "Unset"},
+		{name: "Valid", annotations: map[string]string{SelfManagedCABundleAnnotationKey: string(caPEM)}, want: caPEM, selfManaged: true},
+		{name: "Empty", annotations: map[string]string{SelfManagedCABundleAnnotationKey: ""}, selfManaged: true, wantErr: true},
+		{name: "NotPEM", annotations: map[string]string{SelfManagedCABundleAnnotationKey: "ca"}, selfManaged: true, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			csv := &v1alpha1.ClusterServiceVersion{ObjectMeta: metav1.ObjectMeta{Annotations: tt.annotations}}
+			got, selfManaged, err := SelfManagedCABundle(csv)
+			require.Equal(t, tt.selfManaged, selfManaged)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestInstallCertRequirementsSelfManaged(t *testing.T) {
+	caPEM, _, err := keyPair(t, time.Now().Add(time.Hour)).ToPEM()
+	require.NoError(t, err)
+
+	kubeClient := k8sfake.NewSimpleClientset()
+	strategyClient := &wrappersfakes.FakeInstallStrategyDeploymentInterface{}
+	strategyClient.GetOpClientReturns(operatorclient.NewClient(kubeClient, nil, nil))
+	strategyClient.GetOpListerReturns(newFakeLister(fakeState{
+		getServiceError: errors.NewNotFound(schema.GroupResource{Resource: "services"}, "operator-service"),
+	}))
+	owner := &v1alpha1.ClusterServiceVersion{ObjectMeta: metav1.ObjectMeta{
+		Name:        "csv",
+		Namespace:   "ns",
+		UID:         "uid",
+		Annotations: map[string]string{SelfManagedCABundleAnnotationKey: string(caPEM)},
+	}}
+	desc := &apiServiceDescriptionsWithCAPEM{apiServiceDescription: v1alpha1.APIServiceDescription{
+		Name:           "foos",
+		Group:          "example.com",
+		Version:        "v1",
+		DeploymentName: "operator",
+		ContainerPort:  5443,
+	}}
+	installer := &StrategyDeploymentInstaller{
+		strategyClient:         strategyClient,
+		owner:                  owner,
+		apiServiceDescriptions: []certResource{desc},
+	}
+	depSpec := appsv1.DeploymentSpec{
+		Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "operator"}},
+	}
+	strategy := &v1alpha1.StrategyDetailsDeployment{
+		DeploymentSpecs: []v1alpha1.StrategyDeploymentSpec{{Name: "operator", Spec: depSpec}},
+	}
+
+	out, err := installer.installCertRequirements(context.TODO(), strategy)
+	require.NoError(t, err)
+
+	// The deployment is left as is, and only the Service and CA bundle are provided by OLM
+	require.Equal(t, depSpec, out.DeploymentSpecs[0].Spec)
+	require.Equal(t, caPEM, desc.getCAPEM())
+	service, err := kubeClient.CoreV1().Services("ns").Get(context.TODO(), "operator-service", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, int32(5443), service.Spec.Ports[0].TargetPort.IntVal)
+
+	secrets, err := kubeClient.CoreV1().Secrets("ns").List(context.TODO(), metav1.ListOptions{})
+	require.NoError(t, err)
+	require.Empty(t, secrets.Items)
+	roles, err := kubeClient.RbacV1().Roles("ns").List(context.TODO(), metav1.ListOptions{})
+	require.NoError(t, err)
+	require.Empty(t, roles.Items)
+}
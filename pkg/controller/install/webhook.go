@@ -8,12 +8,15 @@ import (
 	log "github.com/sirupsen/logrus"
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/rand"
 	"k8s.io/client-go/util/retry"
 
 	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	crdlib "github.com/operator-framework/operator-lifecycle-manager/pkg/lib/crd"
 	hashutil "github.com/operator-framework/operator-lifecycle-manager/pkg/lib/kubernetes/pkg/util/hash"
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/ownerutil"
 )
@@ -55,7 +58,7 @@ func contains(m map[string]struct{}, tar string) bool {
 	return present
 }
 
-func (i *StrategyDeploymentInstaller) createOrUpdateWebhook(caPEM []byte, desc v1alpha1.WebhookDescription) error {
+func (i *StrategyDeploymentInstaller) createOrUpdateWebhook(ctx context.Context, caPEM []byte, desc v1alpha1.WebhookDescription) error {
 	operatorGroups, err := i.strategyClient.GetOpLister().OperatorsV1().OperatorGroupLister().OperatorGroups(i.owner.GetNamespace()).List(labels.Everything())
 	if err != nil || len(operatorGroups) != 1 {
 		return fmt.Errorf("error retrieving OperatorGroup info")
@@ -64,24 +67,28 @@ func (i *StrategyDeploymentInstaller) createOrUpdateWebhook(caPEM []byte, desc v
 	if err != nil {
 		return err
 	}
+	scopes, err := WebhookScopes(i.owner)
+	if err != nil {
+		return err
+	}
 
 	switch desc.Type {
 	case v1alpha1.ValidatingAdmissionWebhook:
-		i.createOrUpdateValidatingWebhook(ogNamespacelabelSelector, caPEM, desc)
+		return i.createOrUpdateValidatingWebhook(ctx, ogNamespacelabelSelector, caPEM, desc, scopes[desc.GenerateName])
 	case v1alpha1.MutatingAdmissionWebhook:
-		i.createOrUpdateMutatingWebhook(ogNamespacelabelSelector, caPEM, desc)
+		return i.createOrUpdateMutatingWebhook(ctx, ogNamespacelabelSelector, caPEM, desc, scopes[desc.GenerateName])
 	case v1alpha1.ConversionWebhook:
-		i.createOrUpdateConversionWebhook(caPEM, desc)
+		return i.createOrUpdateConversionWebhook(ctx, caPEM, desc)
 	}
 	return nil
 }
 
-func (i *StrategyDeploymentInstaller) createOrUpdateMutatingWebhook(ogNamespacelabelSelector *metav1.LabelSelector, caPEM []byte, desc v1alpha1.WebhookDescription) error {
+func (i *StrategyDeploymentInstaller) createOrUpdateMutatingWebhook(ctx context.Context, ogNamespacelabelSelector *metav1.LabelSelector, caPEM []byte, desc v1alpha1.WebhookDescription, scope WebhookScope) error {
 	webhookLabels := ownerutil.OwnerLabel(i.owner, i.owner.GetObjectKind().GroupVersionKind().Kind)
 	webhookLabels[WebhookDescKey] = desc.GenerateName
 	webhookSelector := labels.SelectorFromSet(webhookLabels).String()
 
-	existingWebhooks, err := i.strategyClient.GetOpClient().KubernetesInterface().AdmissionregistrationV1().MutatingWebhookConfigurations().List(context.TODO(), metav1.ListOptions{LabelSelector: webhookSelector})
+	existingWebhooks, err := i.strategyClient.GetOpClient().KubernetesInterface().AdmissionregistrationV1().MutatingWebhookConfigurations().List(ctx, metav1.ListOptions{LabelSelector: webhookSelector})
 	if err != nil {
 		return err
 	}
@@ -95,25 +102,60 @@ func (i *StrategyDeploymentInstaller) createOrUpdateMutatingWebhook(ogNamespacel
 				Labels:       ownerutil.OwnerLabel(i.owner, i.owner.GetObjectKind().GroupVersionKind().Kind),
 			},
 			Webhooks: []admissionregistrationv1.MutatingWebhook{
-				desc.GetMutatingWebhook(i.owner.GetNamespace(), ogNamespacelabelSelector, caPEM),
+				desc.GetMutatingWebhook(i.owner.GetNamespace(), scopedNamespaceSelector(ogNamespacelabelSelector, scope), caPEM),
 			},
 		}
-		addWebhookLabels(&webhook, desc)
+		addWebhookLabels(&webhook, desc, scope)
+
+		client := i.strategyClient.GetOpClient().KubernetesInterface().AdmissionregistrationV1().MutatingWebhookConfigurations()
+		if len(scope.MatchConditions) == 0 {
+			if _, err := client.Create(ctx, &webhook, metav1.CreateOptions{}); err != nil {
+				log.Errorf("Webhooks: Error creating MutatingWebhookConfiguration: %v", err)
+				return err
+			}
+			return nil
+		}
 
-		if _, err := i.strategyClient.GetOpClient().KubernetesInterface().AdmissionregistrationV1().MutatingWebhookConfigurations().Create(context.TODO(), &webhook, metav1.CreateOptions{}); err != nil {
+		// matchConditions aren't part of the client's types, so the webhook is created without rules, matching no
+		// request, and then patched in along with its matchConditions
+		patch, err := webhookConfigurationPatch(webhook.GetLabels(), webhook.Webhooks[0], scope)
+		if err != nil {
+			return err
+		}
+		delete(webhook.Labels, WebhookHashKey)
+		webhook.Webhooks[0].Rules = nil
+		created, err := client.Create(ctx, &webhook, metav1.CreateOptions{})
+		if err != nil {
 			log.Errorf("Webhooks: Error creating MutatingWebhookConfiguration: %v", err)
 			return err
 		}
+		if _, err := client.Patch(ctx, created.GetName(), types.MergePatchType, patch, metav1.PatchOptions{}); err != nil {
+			log.Errorf("Webhooks: Error setting matchConditions of MutatingWebhookConfiguration %s: %v", created.GetName(), err)
+			return err
+		}
+		return nil
 	}
 	for _, webhook := range existingWebhooks.Items {
 		// Update the list of webhooks
 		webhook.Webhooks = []admissionregistrationv1.MutatingWebhook{
-			desc.GetMutatingWebhook(i.owner.GetNamespace(), ogNamespacelabelSelector, caPEM),
+			desc.GetMutatingWebhook(i.owner.GetNamespace(), scopedNamespaceSelector(ogNamespacelabelSelector, scope), caPEM),
 		}
-		addWebhookLabels(&webhook, desc)
-
-		// Attempt an update
-		if _, err := i.strategyClient.GetOpClient().KubernetesInterface().AdmissionregistrationV1().MutatingWebhookConfigurations().Update(context.TODO(), &webhook, metav1.UpdateOptions{}); err != nil {
+		addWebhookLabels(&webhook, desc, scope)
+
+		// Attempt an update, patching webhooks with matchConditions, which an update would drop
+		client := i.strategyClient.GetOpClient().KubernetesInterface().AdmissionregistrationV1().MutatingWebhookConfigurations()
+		if len(scope.MatchConditions) == 0 {
+			if _, err := client.Update(ctx, &webhook, metav1.UpdateOptions{}); err != nil {
+				log.Warnf("could not update MutatingWebhookConfiguration %s", webhook.GetName())
+				return err
+			}
+			continue
+		}
+		patch, err := webhookConfigurationPatch(webhook.GetLabels(), webhook.Webhooks[0], scope)
+		if err != nil {
+			return err
+		}
+		if _, err := client.Patch(ctx, webhook.GetName(), types.MergePatchType, patch, metav1.PatchOptions{}); err != nil {
 			log.Warnf("could not update MutatingWebhookConfiguration %s", webhook.GetName())
 			return err
 		}
@@ -122,12 +164,12 @@ func (i *StrategyDeploymentInstaller) createOrUpdateMutatingWebhook(ogNamespacel
 	return nil
 }
 
-func (i *StrategyDeploymentInstaller) createOrUpdateValidatingWebhook(ogNamespacelabelSelector *metav1.LabelSelector, caPEM []byte, desc v1alpha1.WebhookDescription) error {
+func (i *StrategyDeploymentInstaller) createOrUpdateValidatingWebhook(ctx context.Context, ogNamespacelabelSelector *metav1.LabelSelector, caPEM []byte, desc v1alpha1.WebhookDescription, scope WebhookScope) error {
 	webhookLabels := ownerutil.OwnerLabel(i.owner, i.owner.GetObjectKind().GroupVersionKind().Kind)
 	webhookLabels[WebhookDescKey] = desc.GenerateName
 	webhookSelector := labels.SelectorFromSet(webhookLabels).String()
 
-	existingWebhooks, err := i.strategyClient.GetOpClient().KubernetesInterface().AdmissionregistrationV1().ValidatingWebhookConfigurations().List(context.TODO(), metav1.ListOptions{LabelSelector: webhookSelector})
+	existingWebhooks, err := i.strategyClient.GetOpClient().KubernetesInterface().AdmissionregistrationV1().ValidatingWebhookConfigurations().List(ctx, metav1.ListOptions{LabelSelector: webhookSelector})
 	if err != nil {
 		return err
 	}
@@ -141,27 +183,60 @@ func (i *StrategyDeploymentInstaller) createOrUpdateValidatingWebhook(ogNamespac
 				Labels:       ownerutil.OwnerLabel(i.owner, i.owner.GetObjectKind().GroupVersionKind().Kind),
 			},
 			Webhooks: []admissionregistrationv1.ValidatingWebhook{
-				desc.GetValidatingWebhook(i.owner.GetNamespace(), ogNamespacelabelSelector, caPEM),
+				desc.GetValidatingWebhook(i.owner.GetNamespace(), scopedNamespaceSelector(ogNamespacelabelSelector, scope), caPEM),
 			},
 		}
-		addWebhookLabels(&webhook, desc)
+		addWebhookLabels(&webhook, desc, scope)
+
+		client := i.strategyClient.GetOpClient().KubernetesInterface().AdmissionregistrationV1().ValidatingWebhookConfigurations()
+		if len(scope.MatchConditions) == 0 {
+			if _, err := client.Create(ctx, &webhook, metav1.CreateOptions{}); err != nil {
+				log.Errorf("Webhooks: Error creating ValidatingWebhookConfiguration: %v", err)
+				return err
+			}
+			return nil
+		}
 
-		if _, err := i.strategyClient.GetOpClient().KubernetesInterface().AdmissionregistrationV1().ValidatingWebhookConfigurations().Create(context.TODO(), &webhook, metav1.CreateOptions{}); err != nil {
+		// matchConditions aren't part of the client's types, so the webhook is created without rules, matching no
+		// request, and then patched in along with its matchConditions
+		patch, err := webhookConfigurationPatch(webhook.GetLabels(), webhook.Webhooks[0], scope)
+		if err != nil {
+			return err
+		}
+		delete(webhook.Labels, WebhookHashKey)
+		webhook.Webhooks[0].Rules = nil
+		created, err := client.Create(ctx, &webhook, metav1.CreateOptions{})
+		if err != nil {
 			log.Errorf("Webhooks: Error creating ValidatingWebhookConfiguration: %v", err)
 			return err
 		}
-
+		if _, err := client.Patch(ctx, created.GetName(), types.MergePatchType, patch, metav1.PatchOptions{}); err != nil {
+			log.Errorf("Webhooks: Error setting matchConditions of ValidatingWebhookConfiguration %s: %v", created.GetName(), err)
+			return err
+		}
 		return nil
 	}
 	for _, webhook := range existingWebhooks.Items {
 		// Update the list of webhooks
 		webhook.Webhooks = []admissionregistrationv1.ValidatingWebhook{
-			desc.GetValidatingWebhook(i.owner.GetNamespace(), ogNamespacelabelSelector, caPEM),
+			desc.GetValidatingWebhook(i.owner.GetNamespace(), scopedNamespaceSelector(ogNamespacelabelSelector, scope), caPEM),
 		}
-		addWebhookLabels(&webhook, desc)
-
-		// Attempt an update
-		if _, err := i.strategyClient.GetOpClient().KubernetesInterface().AdmissionregistrationV1().ValidatingWebhookConfigurations().Update(context.TODO(), &webhook, metav1.UpdateOptions{}); err != nil {
+		addWebhookLabels(&webhook, desc, scope)
+
+		// Attempt an update, patching webhooks with matchConditions, which an update would drop
+		client := i.strategyClient.GetOpClient().KubernetesInterface().AdmissionregistrationV1().ValidatingWebhookConfigurations()
+		if len(scope.MatchConditions) == 0 {
+			if _, err := client.Update(ctx, &webhook, metav1.UpdateOptions{}); err != nil {
+				log.Warnf("could not update ValidatingWebhookConfiguration %s", webhook.GetName())
+				return err
+			}
+			continue
+		}
+		patch, err := webhookConfigurationPatch(webhook.GetLabels(), webhook.Webhooks[0], scope)
+		if err != nil {
+			return err
+		}
+		if _, err := client.Patch(ctx, webhook.GetName(), types.MergePatchType, patch, metav1.PatchOptions{}); err != nil {
 			log.Warnf("could not update ValidatingWebhookConfiguration %s", webhook.GetName())
 			return err
 		}
@@ -184,41 +259,31 @@ func isSingletonOperator(csv v1alpha1.ClusterServiceVersion) bool {
 	return true
 }
 
-func (i *StrategyDeploymentInstaller) createOrUpdateConversionWebhook(caPEM []byte, desc v1alpha1.WebhookDescription) error {
+func (i *StrategyDeploymentInstaller) createOrUpdateConversionWebhook(ctx context.Context, caPEM []byte, desc v1alpha1.WebhookDescription) error {
 	// get a list of owned CRDs
 	csv, ok := i.owner.(*v1alpha1.ClusterServiceVersion)
 	if !ok {
 		return fmt.Errorf("unable to manage conversion webhook: conversion webhook owner must be a ClusterServiceVersion")
 	}
-	if !isSingletonOperator(*csv) {
-		return fmt.Errorf("unable to manage conversion webhook: CSVs with conversion webhooks must support only AllNamespaces")
+	if err := ValidConversionWebhooks(csv); err != nil {
+		return fmt.Errorf("unable to manage conversion webhook: %v", err)
 	}
 
-	if len(desc.ConversionCRDs) == 0 {
-		return fmt.Errorf("unable to manager conversion webhook: conversion webhook must have at least one CRD specified")
+	// use user defined path for CRD conversion webhook, else set default value
+	conversionWebhookPath := "/"
+	if desc.WebhookPath != nil {
+		conversionWebhookPath = *desc.WebhookPath
 	}
 
 	// iterate over all the ConversionCRDs
 	for _, conversionCRD := range desc.ConversionCRDs {
 		if err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
 			// Get existing CRD on cluster
-			crd, err := i.strategyClient.GetOpClient().ApiextensionsInterface().ApiextensionsV1().CustomResourceDefinitions().Get(context.TODO(), conversionCRD, metav1.GetOptions{})
+			crd, err := i.strategyClient.GetOpClient().ApiextensionsInterface().ApiextensionsV1().CustomResourceDefinitions().Get(ctx, conversionCRD, metav1.GetOptions{})
 			if err != nil {
 				return fmt.Errorf("unable to get CRD %s specified in Conversion Webhook: %v", conversionCRD, err)
 			}
 
-			// check if this CRD is an owned CRD
-			foundCRD := false
-			for _, ownedCRD := range csv.Spec.CustomResourceDefinitions.Owned {
-				if ownedCRD.Name == conversionCRD {
-					foundCRD = true
-					break
-				}
-			}
-			if !foundCRD {
-				return fmt.Errorf("csv %s does not own CRD %s", csv.GetName(), conversionCRD)
-			}
-
 			// crd.Spec.Conversion.Strategy specifies how custom resources are converted between versions.
 			// Allowed values are:
 			// 	- None: The converter only change the apiVersion and would not touch any other field in the custom resource.
@@ -226,27 +291,16 @@ func (i *StrategyDeploymentInstaller) createOrUpdateConversionWebhook(caPEM []by
 			// References:
 			//  - https://docs.openshift.com/container-platform/4.5/rest_api/extension_apis/customresourcedefinition-apiextensions-k8s-io-v1.html
 			// 	- https://kubernetes.io/blog/2019/06/20/crd-structural-schema/#pruning-don-t-preserve-unknown-fields
-			// By default the strategy is none
+			// By default the strategy is none, and upgrades introducing a conversion webhook switch it to Webhook
 			// Reference:
 			// 	- https://v1-15.docs.kubernetes.io/docs/tasks/access-kubernetes-api/custom-resources/custom-resource-definition-versioning/#specify-multiple-versions
-			if crd.Spec.PreserveUnknownFields {
-				return fmt.Errorf("crd.Spec.PreserveUnknownFields must be false to let API Server call webhook to do the conversion")
-			}
-
-			// Conversion WebhookClientConfig should not be set when Strategy is None
-			// https://v1-15.docs.kubernetes.io/docs/tasks/access-kubernetes-api/custom-resources/custom-resource-definition-versioning/#specify-multiple-versions
-			// Conversion WebhookClientConfig needs to be set when Strategy is None
-			// https://v1-15.docs.kubernetes.io/docs/tasks/access-kubernetes-api/custom-resources/custom-resource-definition-versioning/#configure-customresourcedefinition-to-use-conversion-webhooks
-
-			// use user defined path for CRD conversion webhook, else set default value
-			conversionWebhookPath := "/"
-			if desc.WebhookPath != nil {
-				conversionWebhookPath = *desc.WebhookPath
+			if err := validConversionStrategyTransition(crd); err != nil {
+				return err
 			}
 
 			// Override Name, Namespace, and CABundle
-			crd.Spec.Conversion = &apiextensionsv1.CustomResourceConversion{
-				Strategy: "Webhook",
+			conversion := &apiextensionsv1.CustomResourceConversion{
+				Strategy: apiextensionsv1.WebhookConverter,
 				Webhook: &apiextensionsv1.WebhookConversion{
 					ClientConfig: &apiextensionsv1.WebhookClientConfig{
 						Service: &apiextensionsv1.ServiceReference{
@@ -260,9 +314,21 @@ func (i *StrategyDeploymentInstaller) createOrUpdateConversionWebhook(caPEM []by
 					ConversionReviewVersions: desc.AdmissionReviewVersions,
 				},
 			}
+			if equality.Semantic.DeepEqual(crd.Spec.Conversion, conversion) && crd.GetAnnotations()[crdlib.ConversionWebhookAnnotationKey] == desc.GenerateName {
+				return nil
+			}
+			crd.Spec.Conversion = conversion
+
+			// Record the webhook, so that the conversion is kept across CRD updates and reset once the webhook is removed
+			annotations := crd.GetAnnotations()
+			if annotations == nil {
+				annotations = map[string]string{}
+			}
+			annotations[crdlib.ConversionWebhookAnnotationKey] = desc.GenerateName
+			crd.SetAnnotations(annotations)
 
 			// update CRD conversion Specs
-			if _, err = i.strategyClient.GetOpClient().ApiextensionsInterface().ApiextensionsV1().CustomResourceDefinitions().Update(context.TODO(), crd, metav1.UpdateOptions{}); err != nil {
+			if _, err = i.strategyClient.GetOpClient().ApiextensionsInterface().ApiextensionsV1().CustomResourceDefinitions().Update(ctx, crd, metav1.UpdateOptions{}); err != nil {
 				return fmt.Errorf("Error updating CRD with Conversion info: %w", err)
 			}
 			return nil
@@ -278,13 +344,13 @@ const WebhookDescKey = "olm.webhook-description-generate-name"
 const WebhookHashKey = "olm.webhook-description-hash"
 
 // addWebhookLabels adds webhook labels to an object
-func addWebhookLabels(object metav1.Object, webhookDesc v1alpha1.WebhookDescription) error {
+func addWebhookLabels(object metav1.Object, webhookDesc v1alpha1.WebhookDescription, scope WebhookScope) error {
 	labels := object.GetLabels()
 	if labels == nil {
 		labels = map[string]string{}
 	}
 	labels[WebhookDescKey] = webhookDesc.GenerateName
-	labels[WebhookHashKey] = HashWebhookDesc(webhookDesc)
+	labels[WebhookHashKey] = HashWebhook(webhookDesc, scope)
 	object.SetLabels(labels)
 
 	return nil
@@ -0,0 +1,166 @@
+package install
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/rand"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	hashutil "github.com/operator-framework/operator-lifecycle-manager/pkg/lib/kubernetes/pkg/util/hash"
+)
+
+const (
+	// WebhookMatchConditionsAnnotationKey is the CSV annotation holding, as a JSON object keyed by the generateName of
+	// admission webhooks, the CEL matchConditions OLM sets on those webhooks. WebhookDescriptions can't declare them
+	// themselves.
+	WebhookMatchConditionsAnnotationKey = "operatorframework.io/webhook-match-conditions"
+
+	// WebhookNamespaceSelectorsAnnotationKey is the CSV annotation holding, as a JSON object keyed by the generateName
+	// of admission webhooks, label selectors of the namespaces those webhooks apply to, on top of the target namespaces
+	// of the operator's OperatorGroup.
+	WebhookNamespaceSelectorsAnnotationKey = "operatorframework.io/webhook-namespace-selectors"
+
+	// maxWebhookMatchConditions is the maximum number of matchConditions of a webhook.
+	maxWebhookMatchConditions = 64
+)
+
+// WebhookMatchCondition is a CEL expression a request must match for an admission webhook to be called, mirroring the
+// MatchCondition of the admissionregistration.k8s.io/v1 API.
+type WebhookMatchCondition struct {
+	Name       string `json:"name"`
+	Expression string `json:"expression"`
+}
+
+// WebhookScope narrows the requests an admission webhook is called for, beyond what its WebhookDescription declares.
+type WebhookScope struct {
+	MatchConditions   []WebhookMatchCondition `json:"matchConditions,omitempty"`
+	NamespaceSelector *metav1.LabelSelector   `json:"namespaceSelector,omitempty"`
+}
+
+// IsEmpty returns true if the scope doesn't narrow its webhook.
+func (s WebhookScope) IsEmpty() bool {
+	return len(s.MatchConditions) == 0 && s.NamespaceSelector == nil
+}
+
+// WebhookScopes returns the scopes the given CSV declares for its admission webhooks, keyed by their generateName.
+func WebhookScopes(csv metav1.Object) (map[string]WebhookScope, error) {
+	scopes := map[string]WebhookScope{}
+	if raw, ok := csv.GetAnnotations()[WebhookMatchConditionsAnnotationKey]; ok {
+		var conditions map[string][]WebhookMatchCondition
+		if err := json.Unmarshal([]byte(raw), &conditions); err != nil {
+			return nil, fmt.Errorf("unable to parse %s annotation: %v", WebhookMatchConditionsAnnotationKey, err)
+		}
+		for name, c := range conditions {
+			scope := scopes[name]
+			scope.MatchConditions = c
+			scopes[name] = scope
+		}
+	}
+	if raw, ok := csv.GetAnnotations()[WebhookNamespaceSelectorsAnnotationKey]; ok {
+		var selectors map[string]*metav1.LabelSelector
+		if err := json.Unmarshal([]byte(raw), &selectors); err != nil {
+			return nil, fmt.Errorf("unable to parse %s annotation: %v", WebhookNamespaceSelectorsAnnotationKey, err)
+		}
+		for name, s := range selectors {
+			scope := scopes[name]
+			scope.NamespaceSelector = s
+			scopes[name] = scope
+		}
+	}
+	return scopes, nil
+}
+
+// ValidWebhookScopes checks that the given scopes are those of admission webhooks among the given descriptions, and
+// that their matchConditions and namespace selectors are well formed. CEL expressions are compiled by the apiserver.
+func ValidWebhookScopes(scopes map[string]WebhookScope, descs []v1alpha1.WebhookDescription) error {
+	for name, scope := range scopes {
+		found := false
+		for _, desc := range descs {
+			if desc.GenerateName != name {
+				continue
+			}
+			if desc.Type != v1alpha1.ValidatingAdmissionWebhook && desc.Type != v1alpha1.MutatingAdmissionWebhook {
+				return fmt.Errorf("webhook %s is not an admission webhook and can't be scoped", name)
+			}
+			found = true
+		}
+		if !found {
+			return fmt.Errorf("scoped webhook %s is not declared by the CSV", name)
+		}
+
+		if len(scope.MatchConditions) > maxWebhookMatchConditions {
+			return fmt.Errorf("webhook %s has %d matchConditions, at most %d are allowed", name, len(scope.MatchConditions), maxWebhookMatchConditions)
+		}
+		conditionNames := map[string]struct{}{}
+		for _, c := range scope.MatchConditions {
+			if c.Name == "" || c.Expression == "" {
+				return fmt.Errorf("matchConditions of webhook %s must have a name and an expression", name)
+			}
+			if _, ok := conditionNames[c.Name]; ok {
+				return fmt.Errorf("repeated matchCondition name %s in webhook %s", c.Name, name)
+			}
+			conditionNames[c.Name] = struct{}{}
+		}
+		if _, err := metav1.LabelSelectorAsSelector(scope.NamespaceSelector); err != nil {
+			return fmt.Errorf("invalid namespace selector of webhook %s: %v", name, err)
+		}
+	}
+	return nil
+}
+
+// HashWebhook calculates a hash given a webhookDescription and its scope. Unscoped webhooks have the hash of their
+// description alone, so that their configurations aren't rewritten.
+func HashWebhook(desc v1alpha1.WebhookDescription, scope WebhookScope) string {
+	if scope.IsEmpty() {
+		return HashWebhookDesc(desc)
+	}
+	hasher := fnv.New32a()
+	hashutil.DeepHashObject(hasher, []interface{}{&desc, &scope})
+	return rand.SafeEncodeString(fmt.Sprint(hasher.Sum32()))
+}
+
+// scopedNamespaceSelector returns the selector of the namespaces that match both the given selector of the target
+// namespaces of an OperatorGroup and the selector of the given scope.
+func scopedNamespaceSelector(ogSelector *metav1.LabelSelector, scope WebhookScope) *metav1.LabelSelector {
+	if scope.NamespaceSelector == nil {
+		return ogSelector
+	}
+	selector := &metav1.LabelSelector{}
+	if ogSelector != nil {
+		selector = ogSelector.DeepCopy()
+	}
+	// Labels are matched as expressions, so that they don't override those of the OperatorGroup
+	for key, value := range scope.NamespaceSelector.MatchLabels {
+		selector.MatchExpressions = append(selector.MatchExpressions, metav1.LabelSelectorRequirement{
+			Key:      key,
+			Operator: metav1.LabelSelectorOpIn,
+			Values:   []string{value},
+		})
+	}
+	selector.MatchExpressions = append(selector.MatchExpressions, scope.NamespaceSelector.MatchExpressions...)
+	return selector
+}
+
+// webhookConfigurationPatch returns a merge patch setting the given labels and webhook, along with the matchConditions
+// of the given scope, on a webhook configuration. The whole webhook list is replaced at once, so that the webhook is
+// never called without its matchConditions.
+func webhookConfigurationPatch(labels map[string]string, webhook interface{}, scope WebhookScope) ([]byte, error) {
+	raw, err := json.Marshal(webhook)
+	if err != nil {
+		return nil, err
+	}
+	w := map[string]interface{}{}
+	if err := json.Unmarshal(raw, &w); err != nil {
+		return nil, err
+	}
+	if len(scope.MatchConditions) > 0 {
+		w["matchConditions"] = scope.MatchConditions
+	}
+	return json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{"labels": labels},
+		"webhooks": []interface{}{w},
+	})
+}
@@ -0,0 +1,189 @@
+package install
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	clientfakes "github.com/operator-framework/operator-lifecycle-manager/pkg/api/wrappers/wrappersfakes"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/operatorclient"
+)
+
+func scopedCSV(annotations map[string]string) *v1alpha1.ClusterServiceVersion {
+	return &v1alpha1.ClusterServiceVersion{
+		TypeMeta: metav1.TypeMeta{Kind: v1alpha1.ClusterServiceVersionKind, APIVersion: v1alpha1.ClusterServiceVersionAPIVersion},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "operator.v1.0.0",
+			Namespace:   "operators",
+			Annotations: annotations,
+		},
+		Spec: v1alpha1.ClusterServiceVersionSpec{
+			WebhookDefinitions: []v1alpha1.WebhookDescription{
+				{GenerateName: "vpods.example.com", Type: v1alpha1.ValidatingAdmissionWebhook},
+				{GenerateName: "cfoos.example.com", Type: v1alpha1.ConversionWebhook},
+			},
+		},
+	}
+}
+
+func TestWebhookScopes(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		want        map[string]WebhookScope
+		wantErr     string
+	}{
+		{
+			name: "None",
+			want: map[string]WebhookScope{},
+		},
+		{
+			name: "Scoped",
+			annotations: map[string]string{
+				WebhookMatchConditionsAnnotationKey:    `{"vpods.example.com":[{"name":"not-kube-system","expression":"request.namespace != 'kube-system'"}]}`,
+				WebhookNamespaceSelectorsAnnotationKey: `{"vpods.example.com":{"matchLabels":{"team":"a"}}}`,
+			},
+			want: map[string]WebhookScope{
+				"vpods.example.com": {
+					MatchConditions:   []WebhookMatchCondition{{Name: "not-kube-system", Expression: "request.namespace != 'kube-system'"}},
+					NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}},
+				},
+			},
+		},
+		{
+			name:        "Malformed",
+			annotations: map[string]string{WebhookMatchConditionsAnnotationKey: `[]`},
+			wantErr:     "unable to parse operatorframework.io/webhook-match-conditions annotation: json: cannot unmarshal array into Go value of type map[string][]install.WebhookMatchCondition",
+		},
+		{
+			name:        "Undeclared",
+			annotations: map[string]string{WebhookNamespaceSelectorsAnnotationKey: `{"mpods.example.com":{}}`},
+			wantErr:     "scoped webhook mpods.example.com is not declared by the CSV",
+		},
+		{
+			name:        "Conversion",
+			annotations: map[string]string{WebhookMatchConditionsAnnotationKey: `{"cfoos.example.com":[{"name":"a","expression":"true"}]}`},
+			wantErr:     "webhook cfoos.example.com is not an admission webhook and can't be scoped",
+		},
+		{
+			name:        "RepeatedCondition",
+			annotations: map[string]string{WebhookMatchConditionsAnnotationKey: `{"vpods.example.com":[{"name":"a","expression":"true"},{"name":"a","expression":"false"}]}`},
+			wantErr:     "repeated matchCondition name a in webhook vpods.example.com",
+		},
+		{
+			name:        "EmptyExpression",
+			annotations: map[string]string{WebhookMatchConditionsAnnotationKey: `{"vpods.example.com":[{"name":"a"}]}`},
+			wantErr:     "matchConditions of webhook vpods.example.com must have a name and an expression",
+		},
+		{
+			name:        "InvalidSelector",
+			annotations: map[string]string{WebhookNamespaceSelectorsAnnotationKey: `{"vpods.example.com":{"matchExpressions":[{"key":"team","operator":"Near"}]}}`},
+			wantErr:     `invalid namespace selector of webhook vpods.example.com: "Near" is not a valid pod selector operator`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			csv := scopedCSV(tt.annotations)
+			scopes, err := WebhookScopes(csv)
+			if err == nil {
+				err = ValidWebhookScopes(scopes, csv.Spec.WebhookDefinitions)
+			}
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, scopes)
+		})
+	}
+}
+
+func TestScopedNamespaceSelector(t *testing.T) {
+	og := &metav1.LabelSelector{MatchLabels: map[string]string{"olm.operatorgroup.uid/1234": ""}}
+	require.Equal(t, og, scopedNamespaceSelector(og, WebhookScope{}))
+
+	scope := WebhookScope{NamespaceSelector: &metav1.LabelSelector{
+		MatchLabels:      map[string]string{"team": "a"},
+		MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "env", Operator: metav1.LabelSelectorOpNotIn, Values: []string{"ci"}}},
+	}}
+	require.Equal(t, &metav1.LabelSelector{
+		MatchLabels: map[string]string{"olm.operatorgroup.uid/1234": ""},
+		MatchExpressions: []metav1.LabelSelectorRequirement{
+			{Key: "team", Operator: metav1.LabelSelectorOpIn, Values: []string{"a"}},
+			{Key: "env", Operator: metav1.LabelSelectorOpNotIn, Values: []string{"ci"}},
+		},
+	}, scopedNamespaceSelector(og, scope))
+	require.Empty(t, og.MatchExpressions)
+
+	require.Equal(t, &metav1.LabelSelector{
+		MatchExpressions: []metav1.LabelSelectorRequirement{
+			{Key: "team", Operator: metav1.LabelSelectorOpIn, Values: []string{"a"}},
+			{Key: "env", Operator: metav1.LabelSelectorOpNotIn, Values: []string{"ci"}},
+		},
+	}, scopedNamespaceSelector(nil, scope))
+}
+
+func TestHashWebhook(t *testing.T) {
+	desc := v1alpha1.WebhookDescription{GenerateName: "vpods.example.com", Type: v1alpha1.ValidatingAdmissionWebhook}
+	require.Equal(t, HashWebhookDesc(desc), HashWebhook(desc, WebhookScope{}))
+
+	scope := WebhookScope{MatchConditions: []WebhookMatchCondition{{Name: "a", Expression: "true"}}}
+	require.NotEqual(t, HashWebhookDesc(desc), HashWebhook(desc, scope))
+	require.Equal(t, HashWebhook(desc, scope), HashWebhook(desc, scope))
+}
+
+func TestCreateOrUpdateValidatingWebhookWithMatchConditions(t *testing.T) {
+	k8sClient := k8sfake.NewSimpleClientset()
+	// The fake clientset neither generates names nor ignores the namespace of cluster-scoped objects
+	k8sClient.PrependReactor("create", "validatingwebhookconfigurations", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		obj := action.(k8stesting.CreateAction).GetObject().(*admissionregistrationv1.ValidatingWebhookConfiguration)
+		obj.SetName(obj.GetGenerateName() + "abcde")
+		obj.SetNamespace("")
+		return false, nil, nil
+	})
+	fakeClient := new(clientfakes.FakeInstallStrategyDeploymentInterface)
+	fakeClient.GetOpClientReturns(operatorclient.NewClient(k8sClient, nil, nil))
+
+	scope := WebhookScope{MatchConditions: []WebhookMatchCondition{{Name: "not-kube-system", Expression: "request.namespace != 'kube-system'"}}}
+	csv := scopedCSV(nil)
+	desc := csv.Spec.WebhookDefinitions[0]
+	desc.Rules = []admissionregistrationv1.RuleWithOperations{{
+		Operations: []admissionregistrationv1.OperationType{admissionregistrationv1.Create},
+		Rule:       admissionregistrationv1.Rule{APIGroups: []string{""}, APIVersions: []string{"v1"}, Resources: []string{"pods"}},
+	}}
+	installer := &StrategyDeploymentInstaller{strategyClient: fakeClient, owner: csv}
+
+	patched := func() map[string]interface{} {
+		var patch map[string]interface{}
+		for _, action := range k8sClient.Actions() {
+			if p, ok := action.(k8stesting.PatchAction); ok {
+				require.NoError(t, json.Unmarshal(p.GetPatch(), &patch))
+			}
+		}
+		return patch
+	}
+
+	// The webhook is created without rules, and the rules are patched in along with the matchConditions
+	require.NoError(t, installer.createOrUpdateValidatingWebhook(context.TODO(), nil, nil, desc, scope))
+	created := k8sClient.Actions()[1].(k8stesting.CreateAction).GetObject().(*admissionregistrationv1.ValidatingWebhookConfiguration)
+	require.Empty(t, created.Webhooks[0].Rules)
+	require.NotContains(t, created.GetLabels(), WebhookHashKey)
+	patch := patched()
+	require.Equal(t, HashWebhook(desc, scope), patch["metadata"].(map[string]interface{})["labels"].(map[string]interface{})[WebhookHashKey])
+	webhook := patch["webhooks"].([]interface{})[0].(map[string]interface{})
+	require.Len(t, webhook["rules"], 1)
+	require.Equal(t, []interface{}{map[string]interface{}{"name": "not-kube-system", "expression": "request.namespace != 'kube-system'"}}, webhook["matchConditions"])
+
+	// Updates are patches too
+	k8sClient.ClearActions()
+	require.NoError(t, installer.createOrUpdateValidatingWebhook(context.TODO(), nil, nil, desc, scope))
+	require.NotNil(t, patched())
+}
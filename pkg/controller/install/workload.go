@@ -0,0 +1,153 @@
+package install
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/component-base/featuregate"
+
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/api/wrappers"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/feature"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/ownerutil"
+)
+
+const (
+	// WorkloadBackendAnnotationKey is the CSV annotation used to select the backend that runs the
+	// deployments of its install strategy. When absent, the deployments are run as Deployments.
+	WorkloadBackendAnnotationKey = "operatorframework.io/workload-backend"
+
+	// DeploymentWorkloadBackend runs each deployment of an install strategy as an apps/v1 Deployment.
+	DeploymentWorkloadBackend = "deployment"
+)
+
+// Workload is the observed state of a workload created for a deployment of an install strategy.
+type Workload struct {
+	metav1.ObjectMeta
+
+	// TemplateAnnotations are the annotations of the workload's pod template.
+	TemplateAnnotations map[string]string
+
+	// Ready is true when the workload is available.
+	Ready bool
+
+	// Reason explains why the workload isn't ready.
+	Reason string
+
+	// Err is set when the workload can no longer become ready without intervention.
+	Err error
+}
+
+// WorkloadBackend creates and inspects the workloads that run an operator.
+//
+// The install strategy is always expressed as Deployments; a backend translates each Deployment into the kind
+// of workload it manages, keeping the Deployment's name, labels, and owner references so that it can be found
+// and garbage collected like a Deployment.
+type WorkloadBackend interface {
+	// CreateOrUpdate creates the workload for the given Deployment, or updates it to match.
+	CreateOrUpdate(ctx context.Context, deployment *appsv1.Deployment) error
+
+	// List returns the workloads in the owner's namespace matching the given selector.
+	List(ctx context.Context, selector labels.Selector) ([]*Workload, error)
+
+	// Delete deletes the named workload in the owner's namespace.
+	Delete(ctx context.Context, name string) error
+}
+
+// WorkloadBackendFactory returns a WorkloadBackend that manages workloads for the given owner.
+type WorkloadBackendFactory func(strategyClient wrappers.InstallStrategyDeploymentInterface, owner ownerutil.Owner) WorkloadBackend
+
+type workloadBackendRegistration struct {
+	factory WorkloadBackendFactory
+	gate    featuregate.Feature
+}
+
+var (
+	workloadBackendsLock sync.RWMutex
+	workloadBackends     = map[string]workloadBackendRegistration{
+		DeploymentWorkloadBackend: {factory: newDeploymentWorkloadBackend},
+	}
+)
+
+// RegisterWorkloadBackend makes a WorkloadBackend available to CSVs under the given name.
+// If gate is not empty, the backend can only be selected while the feature gate is enabled.
+func RegisterWorkloadBackend(name string, gate featuregate.Feature, factory WorkloadBackendFactory) {
+	workloadBackendsLock.Lock()
+	defer workloadBackendsLock.Unlock()
+	workloadBackends[name] = workloadBackendRegistration{factory: factory, gate: gate}
+}
+
+// WorkloadBackends returns the names of the registered workload backends.
+func WorkloadBackends() []string {
+	workloadBackendsLock.RLock()
+	defer workloadBackendsLock.RUnlock()
+
+	names := make([]string, 0, len(workloadBackends))
+	for name := range workloadBackends {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// workloadBackendFor returns the WorkloadBackend selected by the owner's annotations.
+func workloadBackendFor(strategyClient wrappers.InstallStrategyDeploymentInterface, owner ownerutil.Owner) (WorkloadBackend, error) {
+	name := DeploymentWorkloadBackend
+	if selected, ok := owner.GetAnnotations()[WorkloadBackendAnnotationKey]; ok && selected != "" {
+		name = selected
+	}
+
+	workloadBackendsLock.RLock()
+	registration, ok := workloadBackends[name]
+	workloadBackendsLock.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown workload backend %q", name)
+	}
+	if registration.gate != "" && !feature.Gate.Enabled(registration.gate) {
+		return nil, fmt.Errorf("workload backend %q requires the %s feature gate", name, registration.gate)
+	}
+
+	return registration.factory(strategyClient, owner), nil
+}
+
+// deploymentWorkloadBackend runs workloads as Deployments.
+type deploymentWorkloadBackend struct {
+	strategyClient wrappers.InstallStrategyDeploymentInterface
+}
+
+func newDeploymentWorkloadBackend(strategyClient wrappers.InstallStrategyDeploymentInterface, _ ownerutil.Owner) WorkloadBackend {
+	return &deploymentWorkloadBackend{strategyClient: strategyClient}
+}
+
+func (b *deploymentWorkloadBackend) CreateOrUpdate(ctx context.Context, deployment *appsv1.Deployment) error {
+	_, err := b.strategyClient.CreateOrUpdateDeployment(ctx, deployment)
+	return err
+}
+
+func (b *deploymentWorkloadBackend) List(ctx context.Context, selector labels.Selector) ([]*Workload, error) {
+	deployments, err := b.strategyClient.FindAnyDeploymentsMatchingLabels(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	workloads := make([]*Workload, 0, len(deployments))
+	for _, dep := range deployments {
+		reason, ready, err := DeploymentStatus(dep)
+		workloads = append(workloads, &Workload{
+			ObjectMeta:          *dep.ObjectMeta.DeepCopy(),
+			TemplateAnnotations: dep.Spec.Template.GetAnnotations(),
+			Ready:               ready,
+			Reason:              reason,
+			Err:                 err,
+		})
+	}
+	return workloads, nil
+}
+
+func (b *deploymentWorkloadBackend) Delete(ctx context.Context, name string) error {
+	return b.strategyClient.DeleteDeployment(ctx, name)
+}
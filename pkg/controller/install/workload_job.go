@@ -0,0 +1,148 @@
+package install
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	batchv1client "k8s.io/client-go/kubernetes/typed/batch/v1"
+
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/api/wrappers"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/feature"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/ownerutil"
+)
+
+const (
+	// JobWorkloadBackend runs each deployment of an install strategy as a Job, for operators that perform a one-time
+	// task, such as a migration, rather than run continuously.
+	JobWorkloadBackend = "job"
+
+	// JobBackoffLimitAnnotationKey is the pod template annotation of a deployment of an install strategy setting the
+	// number of retries of its Job before the Job, and the CSV, is considered failed.
+	JobBackoffLimitAnnotationKey = "operatorframework.io/job-backoff-limit"
+)
+
+func init() {
+	RegisterWorkloadBackend(JobWorkloadBackend, feature.JobWorkloadBackend, newJobWorkloadBackend)
+}
+
+// jobWorkloadBackend runs workloads as Jobs. A workload is ready once its Job has completed, and has failed once its
+// Job has. Replica counts and rollout strategies are ignored, and since a Job's template is immutable, a Job whose
+// Deployment has changed is deleted and recreated, running the task again.
+type jobWorkloadBackend struct {
+	strategyClient wrappers.InstallStrategyDeploymentInterface
+	namespace      string
+}
+
+func newJobWorkloadBackend(strategyClient wrappers.InstallStrategyDeploymentInterface, owner ownerutil.Owner) WorkloadBackend {
+	return &jobWorkloadBackend{strategyClient: strategyClient, namespace: owner.GetNamespace()}
+}
+
+func (b *jobWorkloadBackend) jobs() batchv1client.JobInterface {
+	return b.strategyClient.GetOpClient().KubernetesInterface().BatchV1().Jobs(b.namespace)
+}
+
+func (b *jobWorkloadBackend) CreateOrUpdate(ctx context.Context, deployment *appsv1.Deployment) error {
+	job, err := jobForDeployment(deployment)
+	if err != nil {
+		return err
+	}
+
+	existing, err := b.jobs().Get(ctx, job.GetName(), metav1.GetOptions{})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return err
+	}
+	if err == nil {
+		if existing.GetLabels()[DeploymentSpecHashLabelKey] == job.GetLabels()[DeploymentSpecHashLabelKey] && existing.GetDeletionTimestamp() == nil {
+			return nil
+		}
+		if err := b.Delete(ctx, existing.GetName()); err != nil {
+			return err
+		}
+		// The deleted Job may still be terminating, in which case the create below fails and is retried on the next sync.
+	}
+
+	_, err = b.jobs().Create(ctx, job, metav1.CreateOptions{})
+	return err
+}
+
+func (b *jobWorkloadBackend) List(ctx context.Context, selector labels.Selector) ([]*Workload, error) {
+	jobs, err := b.jobs().List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, err
+	}
+
+	workloads := make([]*Workload, 0, len(jobs.Items))
+	for _, job := range jobs.Items {
+		workload := &Workload{
+			ObjectMeta:          *job.ObjectMeta.DeepCopy(),
+			TemplateAnnotations: job.Spec.Template.GetAnnotations(),
+		}
+		if cond := jobCondition(&job, batchv1.JobFailed); cond != nil {
+			workload.Err = fmt.Errorf("job %s has failed: %s", job.GetName(), cond.Message)
+		} else if cond := jobCondition(&job, batchv1.JobComplete); cond != nil {
+			workload.Ready = true
+		} else {
+			workload.Reason = fmt.Sprintf("job %q has not completed: %d active, %d failed", job.GetName(), job.Status.Active, job.Status.Failed)
+		}
+		workloads = append(workloads, workload)
+	}
+	return workloads, nil
+}
+
+func (b *jobWorkloadBackend) Delete(ctx context.Context, name string) error {
+	// Delete the Job's pods along with it
+	propagation := metav1.DeletePropagationBackground
+	err := b.jobs().Delete(ctx, name, metav1.DeleteOptions{PropagationPolicy: &propagation})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// jobForDeployment returns a Job running the template of the given Deployment to completion. The Job takes the labels
+// of the Deployment, so that it's found and garbage collected like one. Pods that can't be restarted in place are
+// retried by the Job, up to the limit set by the JobBackoffLimitAnnotationKey annotation of the template.
+func jobForDeployment(deployment *appsv1.Deployment) (*batchv1.Job, error) {
+	template := deployment.Spec.Template.DeepCopy()
+	if template.Spec.RestartPolicy == "" || template.Spec.RestartPolicy == corev1.RestartPolicyAlways {
+		template.Spec.RestartPolicy = corev1.RestartPolicyOnFailure
+	}
+
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            deployment.GetName(),
+			Namespace:       deployment.GetNamespace(),
+			Labels:          deployment.GetLabels(),
+			OwnerReferences: deployment.GetOwnerReferences(),
+		},
+		Spec: batchv1.JobSpec{
+			Template: *template,
+		},
+	}
+	if value, ok := template.GetAnnotations()[JobBackoffLimitAnnotationKey]; ok {
+		limit, err := strconv.ParseInt(value, 10, 32)
+		if err != nil || limit < 0 {
+			return nil, fmt.Errorf("invalid %s annotation on deployment %s: %q", JobBackoffLimitAnnotationKey, deployment.GetName(), value)
+		}
+		backoffLimit := int32(limit)
+		job.Spec.BackoffLimit = &backoffLimit
+	}
+
+	return job, nil
+}
+
+func jobCondition(job *batchv1.Job, conditionType batchv1.JobConditionType) *batchv1.JobCondition {
+	for i, cond := range job.Status.Conditions {
+		if cond.Type == conditionType && cond.Status == corev1.ConditionTrue {
+			return &job.Status.Conditions[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package install
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/api/wrappers"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/feature"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/ownerutil"
+)
+
+// PodWorkloadBackend runs each deployment of an install strategy as a single bare Pod.
+const PodWorkloadBackend = "pod"
+
+func init() {
+	RegisterWorkloadBackend(PodWorkloadBackend, feature.PodWorkloadBackend, newPodWorkloadBackend)
+}
+
+// podWorkloadBackend runs workloads as bare Pods. Replica counts and rollout strategies are ignored, and since a Pod's
+// spec is mostly immutable, a Pod whose Deployment has changed is deleted and recreated.
+type podWorkloadBackend struct {
+	strategyClient wrappers.InstallStrategyDeploymentInterface
+	namespace      string
+}
+
+func newPodWorkloadBackend(strategyClient wrappers.InstallStrategyDeploymentInterface, owner ownerutil.Owner) WorkloadBackend {
+	return &podWorkloadBackend{strategyClient: strategyClient, namespace: owner.GetNamespace()}
+}
+
+func (b *podWorkloadBackend) pods() corev1client.PodInterface {
+	return b.strategyClient.GetOpClient().KubernetesInterface().CoreV1().Pods(b.namespace)
+}
+
+func (b *podWorkloadBackend) CreateOrUpdate(ctx context.Context, deployment *appsv1.Deployment) error {
+	pod := podForDeployment(deployment)
+
+	existing, err := b.pods().Get(ctx, pod.GetName(), metav1.GetOptions{})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return err
+	}
+	if err == nil {
+		if existing.GetLabels()[DeploymentSpecHashLabelKey] == pod.GetLabels()[DeploymentSpecHashLabelKey] && existing.GetDeletionTimestamp() == nil {
+			return nil
+		}
+		if err := b.Delete(ctx, existing.GetName()); err != nil {
+			return err
+		}
+		// The deleted Pod may still be terminating, in which case the create below fails and is retried on the next sync.
+	}
+
+	_, err = b.pods().Create(ctx, pod, metav1.CreateOptions{})
+	return err
+}
+
+func (b *podWorkloadBackend) List(ctx context.Context, selector labels.Selector) ([]*Workload, error) {
+	pods, err := b.pods().List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, err
+	}
+
+	workloads := make([]*Workload, 0, len(pods.Items))
+	for _, pod := range pods.Items {
+		workload := &Workload{
+			ObjectMeta:          *pod.ObjectMeta.DeepCopy(),
+			TemplateAnnotations: pod.GetAnnotations(),
+		}
+		switch {
+		case pod.Status.Phase == corev1.PodFailed || pod.Status.Phase == corev1.PodSucceeded:
+			workload.Err = fmt.Errorf("pod %s has terminated with phase %s", pod.GetName(), pod.Status.Phase)
+		case isPodReady(&pod):
+			workload.Ready = true
+		default:
+			workload.Reason = fmt.Sprintf("pod %q is not ready", pod.GetName())
+		}
+		workloads = append(workloads, workload)
+	}
+	return workloads, nil
+}
+
+func (b *podWorkloadBackend) Delete(ctx context.Context, name string) error {
+	err := b.pods().Delete(ctx, name, metav1.DeleteOptions{})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// podForDeployment returns a Pod running the template of the given Deployment. The Pod takes the labels of both the
+// template, so that Services select it, and the Deployment, so that it's found and garbage collected like one.
+func podForDeployment(deployment *appsv1.Deployment) *corev1.Pod {
+	template := deployment.Spec.Template.DeepCopy()
+
+	podLabels := map[string]string{}
+	for k, v := range template.GetLabels() {
+		podLabels[k] = v
+	}
+	for k, v := range deployment.GetLabels() {
+		podLabels[k] = v
+	}
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            deployment.GetName(),
+			Namespace:       deployment.GetNamespace(),
+			Labels:          podLabels,
+			Annotations:     template.GetAnnotations(),
+			OwnerReferences: deployment.GetOwnerReferences(),
+		},
+		Spec: template.Spec,
+	}
+	if pod.Spec.RestartPolicy == "" {
+		pod.Spec.RestartPolicy = corev1.RestartPolicyAlways
+	}
+
+	return pod
+}
+
+func isPodReady(pod *corev1.Pod) bool {
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == corev1.PodReady {
+			return cond.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
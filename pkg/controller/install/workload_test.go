@@ -0,0 +1,182 @@
+package install
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/component-base/featuregate"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/api/wrappers/wrappersfakes"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/feature"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/operatorclient"
+)
+
+func TestWorkloadBackendFor(t *testing.T) {
+	csv := func(backend string) *v1alpha1.ClusterServiceVersion {
+		c := &v1alpha1.ClusterServiceVersion{ObjectMeta: metav1.ObjectMeta{Name: "csv", Namespace: "ns"}}
+		if backend != "" {
+			c.SetAnnotations(map[string]string{WorkloadBackendAnnotationKey: backend})
+		}
+		return c
+	}
+	client := &wrappersfakes.FakeInstallStrategyDeploymentInterface{}
+
+	backend, err := workloadBackendFor(client, csv(""))
+	require.NoError(t, err)
+	require.IsType(t, &deploymentWorkloadBackend{}, backend)
+
+	_, err = workloadBackendFor(client, csv("knative"))
+	require.Error(t, err)
+
+	// The pod backend is gated
+	_, err = workloadBackendFor(client, csv(PodWorkloadBackend))
+	require.Error(t, err)
+
+	require.NoError(t, feature.Gate.(featuregate.MutableFeatureGate).Set(string(feature.PodWorkloadBackend)+"=true"))
+	defer func() {
+		require.NoError(t, feature.Gate.(featuregate.MutableFeatureGate).Set(string(feature.PodWorkloadBackend)+"=false"))
+	}()
+	backend, err = workloadBackendFor(client, csv(PodWorkloadBackend))
+	require.NoError(t, err)
+	require.IsType(t, &podWorkloadBackend{}, backend)
+}
+
+func TestPodWorkloadBackend(t *testing.T) {
+	kubeClient := k8sfake.NewSimpleClientset()
+	client := &wrappersfakes.FakeInstallStrategyDeploymentInterface{}
+	client.GetOpClientReturns(operatorclient.NewClient(kubeClient, nil, nil))
+	owner := &v1alpha1.ClusterServiceVersion{ObjectMeta: metav1.ObjectMeta{Name: "csv", Namespace: "ns"}}
+	backend := newPodWorkloadBackend(client, owner)
+
+	dep := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "operator",
+			Namespace: "ns",
+			Labels:    map[string]string{"olm.owner": "csv", DeploymentSpecHashLabelKey: "a"},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:      map[string]string{"app": "operator"},
+					Annotations: map[string]string{"olm.targetNamespaces": ""},
+				},
+				Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "operator", Image: "operator:v1"}}},
+			},
+		},
+	}
+	require.NoError(t, backend.CreateOrUpdate(context.TODO(), dep))
+
+	pod, err := kubeClient.CoreV1().Pods("ns").Get(context.TODO(), "operator", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, "operator", pod.GetLabels()["app"])
+	require.Equal(t, "csv", pod.GetLabels()["olm.owner"])
+	require.Equal(t, corev1.RestartPolicyAlways, pod.Spec.RestartPolicy)
+
+	workloads, err := backend.List(context.TODO(), labels.SelectorFromSet(labels.Set{"olm.owner": "csv"}))
+	require.NoError(t, err)
+	require.Len(t, workloads, 1)
+	require.False(t, workloads[0].Ready)
+	require.Contains(t, workloads[0].TemplateAnnotations, "olm.targetNamespaces")
+
+	pod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}
+	_, err = kubeClient.CoreV1().Pods("ns").UpdateStatus(context.TODO(), pod, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	workloads, err = backend.List(context.TODO(), labels.Everything())
+	require.NoError(t, err)
+	require.True(t, workloads[0].Ready)
+
+	// A changed spec replaces the pod
+	dep.Labels[DeploymentSpecHashLabelKey] = "b"
+	dep.Spec.Template.Spec.Containers[0].Image = "operator:v2"
+	require.NoError(t, backend.CreateOrUpdate(context.TODO(), dep))
+	pod, err = kubeClient.CoreV1().Pods("ns").Get(context.TODO(), "operator", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, "operator:v2", pod.Spec.Containers[0].Image)
+
+	require.NoError(t, backend.Delete(context.TODO(), "operator"))
+	require.NoError(t, backend.Delete(context.TODO(), "operator"))
+}
+
+func TestJobWorkloadBackend(t *testing.T) {
+	kubeClient := k8sfake.NewSimpleClientset()
+	client := &wrappersfakes.FakeInstallStrategyDeploymentInterface{}
+	client.GetOpClientReturns(operatorclient.NewClient(kubeClient, nil, nil))
+	owner := &v1alpha1.ClusterServiceVersion{ObjectMeta: metav1.ObjectMeta{Name: "csv", Namespace: "ns"}}
+	backend := newJobWorkloadBackend(client, owner)
+
+	dep := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "migration",
+			Namespace: "ns",
+			Labels:    map[string]string{"olm.owner": "csv", DeploymentSpecHashLabelKey: "a"},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{JobBackoffLimitAnnotationKey: "2"},
+				},
+				Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "migration", Image: "migration:v1"}}},
+			},
+		},
+	}
+	require.NoError(t, backend.CreateOrUpdate(context.TODO(), dep))
+
+	job, err := kubeClient.BatchV1().Jobs("ns").Get(context.TODO(), "migration", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, "csv", job.GetLabels()["olm.owner"])
+	require.Equal(t, corev1.RestartPolicyOnFailure, job.Spec.Template.Spec.RestartPolicy)
+	require.Equal(t, int32(2), *job.Spec.BackoffLimit)
+
+	workloads, err := backend.List(context.TODO(), labels.SelectorFromSet(labels.Set{"olm.owner": "csv"}))
+	require.NoError(t, err)
+	require.Len(t, workloads, 1)
+	require.False(t, workloads[0].Ready)
+	require.NoError(t, workloads[0].Err)
+
+	// A completed job is ready
+	job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}}
+	job, err = kubeClient.BatchV1().Jobs("ns").UpdateStatus(context.TODO(), job, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	workloads, err = backend.List(context.TODO(), labels.Everything())
+	require.NoError(t, err)
+	require.True(t, workloads[0].Ready)
+
+	// An unchanged spec doesn't rerun the job
+	require.NoError(t, backend.CreateOrUpdate(context.TODO(), dep))
+	workloads, err = backend.List(context.TODO(), labels.Everything())
+	require.NoError(t, err)
+	require.True(t, workloads[0].Ready)
+
+	// A failed job can't become ready
+	job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, Message: "BackoffLimitExceeded"}}
+	_, err = kubeClient.BatchV1().Jobs("ns").UpdateStatus(context.TODO(), job, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	workloads, err = backend.List(context.TODO(), labels.Everything())
+	require.NoError(t, err)
+	require.False(t, workloads[0].Ready)
+	require.Error(t, workloads[0].Err)
+
+	// A changed spec reruns the job
+	dep.Labels[DeploymentSpecHashLabelKey] = "b"
+	dep.Spec.Template.Spec.Containers[0].Image = "migration:v2"
+	require.NoError(t, backend.CreateOrUpdate(context.TODO(), dep))
+	job, err = kubeClient.BatchV1().Jobs("ns").Get(context.TODO(), "migration", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, "migration:v2", job.Spec.Template.Spec.Containers[0].Image)
+	require.Empty(t, job.Status.Conditions)
+
+	// An invalid backoff limit is rejected
+	dep.Spec.Template.Annotations[JobBackoffLimitAnnotationKey] = "-1"
+	require.Error(t, backend.CreateOrUpdate(context.TODO(), dep))
+
+	require.NoError(t, backend.Delete(context.TODO(), "migration"))
+	require.NoError(t, backend.Delete(context.TODO(), "migration"))
+}
@@ -0,0 +1,33 @@
+package catalog
+
+import (
+	"context"
+	"time"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// architectureLookupTimeout bounds the lookup of the architectures supported by the images of an infrastructure pod.
+const architectureLookupTimeout = 10 * time.Second
+
+// infrastructureArchitectures returns the architectures the infrastructure pods running the given images, e.g. registry
+// pods and bundle unpack jobs, must be scheduled on. Pods are scheduled on any architecture if they can't be looked up.
+func (o *Operator) infrastructureArchitectures(ctx context.Context, images ...string) []string {
+	ctx, cancel := context.WithTimeout(ctx, architectureLookupTimeout)
+	defer cancel()
+
+	var annotations map[string]string
+	olmConfig, err := o.olmConfigLister.Get("cluster")
+	if err == nil {
+		annotations = olmConfig.GetAnnotations()
+	} else if !k8serrors.IsNotFound(err) {
+		o.logger.WithError(err).Warn("unable to get olmConfig, ignoring its infrastructure architectures")
+	}
+
+	architectures, err := o.architectures.Required(ctx, annotations, images...)
+	if err != nil {
+		o.logger.WithError(err).WithField("images", images).Warn("scheduling infrastructure pods on any architecture")
+		return nil
+	}
+	return architectures
+}
@@ -0,0 +1,93 @@
+package catalog
+
+import (
+	"context"
+	"strings"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/audit"
+)
+
+// auditedKinds are the resources whose mutations by InstallPlans are audited, and whether they are namespaced.
+var auditedKinds = map[string]bool{
+	"CustomResourceDefinition": false,
+	"APIService":               false,
+	"ClusterRoleBinding":       false,
+	"Deployment":               true,
+	"RoleBinding":              true,
+	"Secret":                   true,
+}
+
+// auditedResource returns the client of the resource of the given step, if its mutations are audited.
+func auditedResource(client dynamic.Interface, resource v1alpha1.StepResource, namespace string) (dynamic.ResourceInterface, bool) {
+	namespaced, ok := auditedKinds[resource.Kind]
+	if !ok {
+		return nil, false
+	}
+	gvr := schema.GroupVersionResource{
+		Group:    resource.Group,
+		Version:  resource.Version,
+		Resource: strings.ToLower(resource.Kind) + "s",
+	}
+	if !namespaced {
+		return client.Resource(gvr), true
+	}
+	return client.Resource(gvr).Namespace(namespace), true
+}
+
+// auditStep applies the i-th step of the InstallPlan being executed with the given function, and records the
+// resource it created or updated, if its mutations are audited.
+func (o *Operator) auditStep(ctx context.Context, x *planExecution, e *stepExecutor, i int, apply func() error) error {
+	step := x.plan.Status.Plan[i]
+	if o.auditor == nil {
+		return apply()
+	}
+	client, ok := auditedResource(e.dynamicClient, step.Resource, x.plan.GetNamespace())
+	if !ok {
+		return apply()
+	}
+
+	before, err := client.Get(ctx, step.Resource.Name, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		before, err = nil, nil
+	}
+	if err != nil {
+		o.logger.WithError(err).WithField("step", step.Resource.Name).Warn("unable to audit installplan step")
+		return apply()
+	}
+
+	if err := apply(); err != nil {
+		return err
+	}
+
+	after, err := client.Get(ctx, step.Resource.Name, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		// the step was skipped, e.g. because its API isn't served
+		return nil
+	}
+	if err != nil {
+		o.logger.WithError(err).WithField("step", step.Resource.Name).Warn("unable to audit installplan step")
+		return nil
+	}
+
+	m := audit.Mutation{
+		Action:    audit.Created,
+		Kind:      step.Resource.Kind,
+		Namespace: after.GetNamespace(),
+		Name:      after.GetName(),
+		CSV:       step.Resolving,
+	}
+	if before != nil {
+		m.Action = audit.Updated
+		if m.Changes, err = audit.Changes(before, after); err != nil || len(m.Changes) == 0 {
+			return nil
+		}
+	}
+	o.auditor.Record(x.plan, m)
+	return nil
+}
@@ -0,0 +1,203 @@
+package catalog
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/yaml"
+
+	"github.com/operator-framework/api/pkg/manifests"
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/operator-framework/api/pkg/validation"
+	validator "github.com/operator-framework/api/pkg/validation/interfaces"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/api/reasons"
+	crdlib "github.com/operator-framework/operator-lifecycle-manager/pkg/lib/crd"
+)
+
+// InstallPlanBundleValidation is the type of the InstallPlan condition reporting the results of the validators run
+// against the bundles it installs. It is only set when the catalog operator is configured with bundle validators.
+const InstallPlanBundleValidation v1alpha1.InstallPlanConditionType = "BundleValidation"
+
+// bundleValidators are the static validators of operator-framework/api InstallPlans can run, by the name given to the
+// --required-bundle-validators and --advisory-bundle-validators flags.
+var bundleValidators = map[string]validator.Validator{
+	"csv":             validation.ClusterServiceVersionValidator,
+	"crd":             validation.CustomResourceDefinitionValidator,
+	"bundle":          validation.BundleValidator,
+	"objects":         validation.ObjectValidator,
+	"operatorhub":     validation.OperatorHubValidator,
+	"good-practices":  validation.GoodPracticesValidator,
+	"deprecated-apis": validation.AlphaDeprecatedAPIsValidator,
+}
+
+// BundleValidators configures the validators InstallPlans run against the bundles they install, before creating any
+// of their resources.
+type BundleValidators struct {
+	// Required validators fail the InstallPlans of the bundles they report errors for.
+	Required []string
+	// Advisory validators only report their results on the InstallPlans.
+	Advisory []string
+}
+
+// ParseBundleValidators returns the given validator names, which must be known bundle validators.
+func ParseBundleValidators(names []string) ([]string, error) {
+	var parsed []string
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if _, ok := bundleValidators[name]; !ok {
+			known := make([]string, 0, len(bundleValidators))
+			for k := range bundleValidators {
+				known = append(known, k)
+			}
+			sort.Strings(known)
+			return nil, fmt.Errorf("unknown bundle validator %q, must be one of %s", name, strings.Join(known, ", "))
+		}
+		parsed = append(parsed, name)
+	}
+	return parsed, nil
+}
+
+// Enabled returns whether any validator is configured.
+func (v BundleValidators) Enabled() bool {
+	return len(v.Required) > 0 || len(v.Advisory) > 0
+}
+
+// withBundleValidation runs the configured validators against the bundles the given plan installs. It returns the plan
+// with its BundleValidation condition updated, or the plan itself if the condition doesn't need to change, and whether
+// a required validator rejected a bundle.
+func (o *Operator) withBundleValidation(logger *logrus.Entry, plan *v1alpha1.InstallPlan) (*v1alpha1.InstallPlan, bool, error) {
+	if !o.bundleValidators.Enabled() {
+		return plan, false, nil
+	}
+	bundles, err := o.planBundles(plan)
+	if err != nil {
+		return nil, false, err
+	}
+	if len(bundles) == 0 {
+		return plan, false, nil
+	}
+
+	var errs, warnings []string
+	run := func(names []string, required bool) {
+		for _, name := range names {
+			for _, bundle := range bundles {
+				for _, result := range bundleValidators[name].Validate(bundle.ObjectsToValidate()...) {
+					for _, e := range result.Errors {
+						msg := fmt.Sprintf("%s %s: %s", bundle.Name, name, e.Error())
+						if required {
+							errs = append(errs, msg)
+						} else {
+							warnings = append(warnings, msg)
+						}
+					}
+					for _, w := range result.Warnings {
+						warnings = append(warnings, fmt.Sprintf("%s %s: %s", bundle.Name, name, w.Error()))
+					}
+				}
+			}
+		}
+	}
+	run(o.bundleValidators.Required, true)
+	run(o.bundleValidators.Advisory, false)
+
+	cond := v1alpha1.InstallPlanCondition{
+		Type:    InstallPlanBundleValidation,
+		Status:  corev1.ConditionTrue,
+		Reason:  v1alpha1.InstallPlanConditionReason(reasons.InstallPlanBundleValidationPassed),
+		Message: strings.Join(append(errs, warnings...), "; "),
+	}
+	if len(errs) > 0 {
+		cond.Status = corev1.ConditionFalse
+		cond.Reason = v1alpha1.InstallPlanConditionReason(reasons.InstallPlanBundleValidationFailed)
+	}
+	existing := plan.Status.GetCondition(InstallPlanBundleValidation)
+	if existing.Status == cond.Status && existing.Reason == cond.Reason && existing.Message == cond.Message {
+		return plan, len(errs) > 0, nil
+	}
+
+	logger.WithField("errors", len(errs)).WithField("warnings", len(warnings)).Info("bundle validation results changed")
+	now := o.now()
+	cond.LastUpdateTime = &now
+	cond.LastTransitionTime = &now
+	out := plan.DeepCopy()
+	out.Status.SetCondition(cond)
+	return out, len(errs) > 0, nil
+}
+
+// planBundles rebuilds the bundles of the CSVs the given plan installs from its steps, sorted by CSV name.
+func (o *Operator) planBundles(plan *v1alpha1.InstallPlan) ([]*manifests.Bundle, error) {
+	r := newManifestResolver(plan.GetNamespace(), o.lister.CoreV1().ConfigMapLister(), o.logger)
+	bundles := map[string]*manifests.Bundle{}
+	bundle := func(name string) *manifests.Bundle {
+		if _, ok := bundles[name]; !ok {
+			bundles[name] = &manifests.Bundle{Name: name}
+		}
+		return bundles[name]
+	}
+	for _, step := range plan.Status.Plan {
+		// Subscriptions are generated for the bundles, they aren't part of them
+		if step.Resource.Kind == v1alpha1.SubscriptionKind {
+			continue
+		}
+		manifest, err := r.ManifestForStep(step)
+		if err != nil {
+			return nil, err
+		}
+		b := bundle(step.Resolving)
+		switch step.Resource.Kind {
+		case v1alpha1.ClusterServiceVersionKind:
+			csv := &v1alpha1.ClusterServiceVersion{}
+			if err := json.Unmarshal([]byte(manifest), csv); err != nil {
+				return nil, fmt.Errorf("error parsing step manifest %s: %v", step.Resource.Name, err)
+			}
+			b.CSV = csv
+		case crdKind:
+			version, err := crdlib.Version(&manifest)
+			if err != nil {
+				return nil, fmt.Errorf("error parsing step manifest %s: %v", step.Resource.Name, err)
+			}
+			switch version {
+			case crdlib.V1Version:
+				crd, err := crdlib.UnmarshalV1(manifest)
+				if err != nil {
+					return nil, fmt.Errorf("error parsing step manifest %s: %v", step.Resource.Name, err)
+				}
+				b.V1CRDs = append(b.V1CRDs, crd)
+			case crdlib.V1Beta1Version:
+				crd, err := crdlib.UnmarshalV1Beta1(manifest)
+				if err != nil {
+					return nil, fmt.Errorf("error parsing step manifest %s: %v", step.Resource.Name, err)
+				}
+				b.V1beta1CRDs = append(b.V1beta1CRDs, crd)
+			}
+		default:
+			obj := &unstructured.Unstructured{}
+			if err := yaml.NewYAMLOrJSONDecoder(strings.NewReader(manifest), 10).Decode(obj); err != nil {
+				return nil, fmt.Errorf("error parsing step manifest %s: %v", step.Resource.Name, err)
+			}
+			b.Objects = append(b.Objects, obj)
+		}
+	}
+
+	names := make([]string, 0, len(bundles))
+	for name, b := range bundles {
+		// Steps resolving existing CSVs, e.g. for their CRDs, aren't bundles to validate
+		if b.CSV != nil {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	out := make([]*manifests.Bundle, 0, len(names))
+	for _, name := range names {
+		out = append(out, bundles[name])
+	}
+	return out, nil
+}
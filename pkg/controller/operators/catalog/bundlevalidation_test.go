@@ -0,0 +1,93 @@
+package catalog
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+)
+
+func TestParseBundleValidators(t *testing.T) {
+	names, err := ParseBundleValidators([]string{"csv", " bundle", ""})
+	require.NoError(t, err)
+	require.Equal(t, []string{"csv", "bundle"}, names)
+
+	_, err = ParseBundleValidators([]string{"scorecard"})
+	require.EqualError(t, err, `unknown bundle validator "scorecard", must be one of bundle, crd, csv, deprecated-apis, good-practices, objects, operatorhub`)
+}
+
+func TestSyncInstallPlanBundleValidation(t *testing.T) {
+	namespace := "ns"
+	// The CSV owns a CRD its bundle doesn't ship
+	csv := &v1alpha1.ClusterServiceVersion{
+		TypeMeta:   metav1.TypeMeta{APIVersion: v1alpha1.ClusterServiceVersionAPIVersion, Kind: v1alpha1.ClusterServiceVersionKind},
+		ObjectMeta: metav1.ObjectMeta{Name: "csv.v1.0.0", Namespace: namespace},
+		Spec: v1alpha1.ClusterServiceVersionSpec{
+			InstallModes: []v1alpha1.InstallMode{{Type: v1alpha1.InstallModeTypeAllNamespaces, Supported: true}},
+			CustomResourceDefinitions: v1alpha1.CustomResourceDefinitions{
+				Owned: []v1alpha1.CRDDescription{{Name: "foos.example.com", Version: "v1", Kind: "Foo"}},
+			},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		validators BundleValidators
+		phase      v1alpha1.InstallPlanPhase
+		status     corev1.ConditionStatus
+	}{
+		{
+			name:       "Required",
+			validators: BundleValidators{Required: []string{"bundle"}},
+			phase:      v1alpha1.InstallPlanPhaseFailed,
+			status:     corev1.ConditionFalse,
+		},
+		{
+			name:       "Advisory",
+			validators: BundleValidators{Advisory: []string{"bundle"}},
+			phase:      v1alpha1.InstallPlanPhaseRequiresApproval,
+			status:     corev1.ConditionTrue,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.TODO())
+			defer cancel()
+
+			plan := withSteps(installPlan("p", namespace, v1alpha1.InstallPlanPhaseRequiresApproval, csv.GetName()),
+				[]*v1alpha1.Step{
+					{
+						Resolving: csv.GetName(),
+						Resource: v1alpha1.StepResource{
+							CatalogSource:          "catalog",
+							CatalogSourceNamespace: namespace,
+							Group:                  v1alpha1.GroupName,
+							Version:                v1alpha1.GroupVersion,
+							Kind:                   v1alpha1.ClusterServiceVersionKind,
+							Name:                   csv.GetName(),
+							Manifest:               toManifest(t, csv),
+						},
+						Status: v1alpha1.StepStatusUnknown,
+					},
+				},
+			)
+
+			op, err := NewFakeOperator(ctx, namespace, []string{namespace}, withClientObjs(plan, operatorGroup("og", "", namespace, nil)))
+			require.NoError(t, err)
+			op.bundleValidators = tt.validators
+
+			require.NoError(t, op.syncInstallPlans(ctx, plan))
+
+			out, err := op.client.OperatorsV1alpha1().InstallPlans(namespace).Get(ctx, plan.GetName(), metav1.GetOptions{})
+			require.NoError(t, err)
+			require.Equal(t, tt.phase, out.Status.Phase)
+			cond := out.Status.GetCondition(InstallPlanBundleValidation)
+			require.Equal(t, tt.status, cond.Status)
+			require.Equal(t, `csv.v1.0.0 bundle: Error: Value example.com/v1, Kind=Foo: owned CRD "example.com/v1, Kind=Foo" not found in bundle "csv.v1.0.0"`, cond.Message)
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package catalog
+
+import (
+	"context"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/retry"
+
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/controllers"
+)
+
+// DisabledControllersConditionType is the type of the condition of the cluster OLMConfig reporting the optional
+// controllers of the catalog-operator that are disabled.
+const DisabledControllersConditionType = "CatalogControllersDisabled"
+
+// disabledControllersReportInterval is the interval between attempts to report the disabled controllers, e.g. while
+// the cluster OLMConfig doesn't exist yet.
+const disabledControllersReportInterval = 30 * time.Second
+
+// ReportDisabledControllers reports the disabled optional controllers of the catalog-operator on the cluster OLMConfig,
+// retrying until it succeeds or the given context is done.
+func (o *Operator) ReportDisabledControllers(ctx context.Context) {
+	condition := controllers.Condition(DisabledControllersConditionType, o.disabled)
+	_ = wait.PollImmediateUntil(disabledControllersReportInterval, func() (bool, error) {
+		err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			olmConfig, err := o.client.OperatorsV1().OLMConfigs().Get(ctx, "cluster", metav1.GetOptions{})
+			if err != nil {
+				return err
+			}
+			if c := meta.FindStatusCondition(olmConfig.Status.Conditions, condition.Type); c != nil && c.Status == condition.Status && c.Reason == condition.Reason && c.Message == condition.Message {
+				return nil
+			}
+			meta.SetStatusCondition(&olmConfig.Status.Conditions, condition)
+			_, err = o.client.OperatorsV1().OLMConfigs().UpdateStatus(ctx, olmConfig, metav1.UpdateOptions{})
+			return err
+		})
+		if err != nil {
+			o.logger.WithError(err).Warn("unable to report disabled controllers on olmConfig")
+			return false, nil
+		}
+		return true, nil
+	}, ctx.Done())
+}
@@ -0,0 +1,50 @@
+package catalog
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	operatorsv1 "github.com/operator-framework/api/pkg/operators/v1"
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/api/client/clientset/versioned/fake"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/api/reasons"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/controllers"
+)
+
+func TestCheckSourceTypeEnabled(t *testing.T) {
+	o := &Operator{disabled: controllers.Disabled{controllers.ConfigMapCatalogs: {}}}
+	logger := logrus.NewEntry(logrus.New())
+
+	for _, sourceType := range []v1alpha1.SourceType{v1alpha1.SourceTypeConfigmap, v1alpha1.SourceTypeInternal} {
+		in := &v1alpha1.CatalogSource{Spec: v1alpha1.CatalogSourceSpec{SourceType: sourceType}}
+		out, cont, err := o.checkSourceTypeEnabled(context.TODO(), logger, in)
+		require.NoError(t, err)
+		require.False(t, cont)
+		require.Equal(t, v1alpha1.CatalogSourceSpecInvalidError, out.Status.Reason)
+		require.Equal(t, "sourcetype "+string(sourceType)+" is disabled on this cluster", out.Status.Message)
+	}
+
+	in := &v1alpha1.CatalogSource{Spec: v1alpha1.CatalogSourceSpec{SourceType: v1alpha1.SourceTypeGrpc}}
+	_, cont, err := o.checkSourceTypeEnabled(context.TODO(), logger, in)
+	require.NoError(t, err)
+	require.True(t, cont)
+}
+
+func TestReportDisabledControllers(t *testing.T) {
+	client := fake.NewSimpleClientset(&operatorsv1.OLMConfig{ObjectMeta: metav1.ObjectMeta{Name: "cluster"}})
+	o := &Operator{logger: logrus.New(), client: client, disabled: controllers.Disabled{controllers.ConfigMapCatalogs: {}}}
+	o.ReportDisabledControllers(context.TODO())
+
+	olmConfig, err := client.OperatorsV1().OLMConfigs().Get(context.TODO(), "cluster", metav1.GetOptions{})
+	require.NoError(t, err)
+	c := meta.FindStatusCondition(olmConfig.Status.Conditions, DisabledControllersConditionType)
+	require.NotNil(t, c)
+	require.Equal(t, metav1.ConditionTrue, c.Status)
+	require.Equal(t, string(reasons.OLMConfigControllersDisabled), c.Reason)
+	require.Equal(t, "Disabled controllers: configmap-catalogs", c.Message)
+}
@@ -0,0 +1,182 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	crdlib "github.com/operator-framework/operator-lifecycle-manager/pkg/lib/crd"
+)
+
+// crdNotEstablishedError is returned when a CRD of an InstallPlan isn't established and served within the CRD
+// establishment timeout. It fails the InstallPlan right away, with the CRDNotEstablished reason.
+type crdNotEstablishedError struct {
+	name    string
+	timeout time.Duration
+	cause   string
+}
+
+func (e crdNotEstablishedError) Error() string {
+	return fmt.Sprintf("CRD %s not established within %s: %s", e.name, e.timeout, e.cause)
+}
+
+// crdState is the state of an applied CRD, whatever its API version.
+type crdState struct {
+	created        metav1.Time
+	group, kind    string
+	servedVersions []string
+	established    *metav1.Condition
+	namesAccepted  *metav1.Condition
+}
+
+func (o *Operator) getCRDState(ctx context.Context, resource v1alpha1.StepResource) (*crdState, error) {
+	ext := o.opClient.ApiextensionsInterface()
+	if resource.Version == crdlib.V1Beta1Version {
+		crd, err := ext.ApiextensionsV1beta1().CustomResourceDefinitions().Get(ctx, resource.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		s := &crdState{created: crd.GetCreationTimestamp(), group: crd.Spec.Group, kind: crd.Spec.Names.Kind}
+		if len(crd.Spec.Versions) == 0 {
+			s.servedVersions = []string{crd.Spec.Version}
+		}
+		for _, v := range crd.Spec.Versions {
+			if v.Served {
+				s.servedVersions = append(s.servedVersions, v.Name)
+			}
+		}
+		for _, c := range crd.Status.Conditions {
+			cond := &metav1.Condition{Type: string(c.Type), Status: metav1.ConditionStatus(c.Status), Reason: c.Reason, Message: c.Message}
+			switch c.Type {
+			case apiextensionsv1beta1.Established:
+				s.established = cond
+			case apiextensionsv1beta1.NamesAccepted:
+				s.namesAccepted = cond
+			}
+		}
+		return s, nil
+	}
+
+	crd, err := ext.ApiextensionsV1().CustomResourceDefinitions().Get(ctx, resource.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	s := &crdState{created: crd.GetCreationTimestamp(), group: crd.Spec.Group, kind: crd.Spec.Names.Kind}
+	for _, v := range crd.Spec.Versions {
+		if v.Served {
+			s.servedVersions = append(s.servedVersions, v.Name)
+		}
+	}
+	for _, c := range crd.Status.Conditions {
+		cond := &metav1.Condition{Type: string(c.Type), Status: metav1.ConditionStatus(c.Status), Reason: c.Reason, Message: c.Message}
+		switch c.Type {
+		case apiextensionsv1.Established:
+			s.established = cond
+		case apiextensionsv1.NamesAccepted:
+			s.namesAccepted = cond
+		}
+	}
+	return s, nil
+}
+
+// crdNotReady returns why the given CRD can't have custom resources applied yet, if it can't: it must be established,
+// its names accepted, and each of its served versions listed by discovery.
+func (o *Operator) crdNotReady(s *crdState) (string, error) {
+	for _, cond := range []struct {
+		name      string
+		condition *metav1.Condition
+	}{
+		{name: string(apiextensionsv1.NamesAccepted), condition: s.namesAccepted},
+		{name: string(apiextensionsv1.Established), condition: s.established},
+	} {
+		switch c := cond.condition; {
+		case c == nil:
+			return fmt.Sprintf("%s condition not reported", cond.name), nil
+		case c.Status != metav1.ConditionTrue:
+			return fmt.Sprintf("%s condition is %s: %s: %s", cond.name, c.Status, c.Reason, c.Message), nil
+		}
+	}
+
+	discovery := o.opClient.KubernetesInterface().Discovery()
+	for _, version := range s.servedVersions {
+		gv := schema.GroupVersion{Group: s.group, Version: version}
+		resources, err := discovery.ServerResourcesForGroupVersion(gv.String())
+		if k8serrors.IsNotFound(err) {
+			return fmt.Sprintf("%s not served by discovery", gv), nil
+		}
+		if err != nil {
+			return "", err
+		}
+		served := false
+		for _, r := range resources.APIResources {
+			if r.Kind == s.kind {
+				served = true
+				break
+			}
+		}
+		if !served {
+			return fmt.Sprintf("kind %s not served by discovery for %s", s.kind, gv), nil
+		}
+	}
+	return "", nil
+}
+
+// awaitCRDs checks that the CRDs applied by the given InstallPlan are established and served by discovery, so that
+// the custom resources and CSVs of the next steps don't race them. CRDs that aren't are set back to WaitingForAPI, so
+// that the InstallPlan is requeued without applying its next steps, and true is returned. A crdNotEstablishedError is
+// returned for a CRD that isn't ready once the CRD establishment timeout passed since it was created, or since the
+// InstallPlan started installing if it already existed.
+func (o *Operator) awaitCRDs(ctx context.Context, plan *v1alpha1.InstallPlan) (bool, error) {
+	var waiting []string
+	for i, step := range plan.Status.Plan {
+		if step.Resource.Kind != crdKind {
+			continue
+		}
+
+		// Check the CRDs of all the steps, as the steps of CRDs waiting for their API are set back to Unknown while
+		// they aren't established
+		var cause string
+		start := plan.Status.StartTime
+		s, err := o.getCRDState(ctx, step.Resource)
+		if k8serrors.IsNotFound(err) {
+			cause = "not found"
+		} else if err != nil {
+			return false, fmt.Errorf("error checking establishment of CRD %s: %w", step.Resource.Name, err)
+		} else {
+			if start == nil || s.created.After(start.Time) {
+				start = &s.created
+			}
+			if cause, err = o.crdNotReady(s); err != nil {
+				return false, fmt.Errorf("error checking establishment of CRD %s: %w", step.Resource.Name, err)
+			}
+		}
+		if cause == "" {
+			if step.Status != v1alpha1.StepStatusPresent {
+				plan.Status.Plan[i].Status = v1alpha1.StepStatusCreated
+			}
+			continue
+		}
+
+		if o.crdEstablishTimeout > 0 && start != nil && o.now().Sub(start.Time) >= o.crdEstablishTimeout {
+			return false, crdNotEstablishedError{name: step.Resource.Name, timeout: o.crdEstablishTimeout, cause: cause}
+		}
+		plan.Status.Plan[i].Status = v1alpha1.StepStatusWaitingForAPI
+		waiting = append(waiting, fmt.Sprintf("%s (%s)", step.Resource.Name, cause))
+	}
+	if len(waiting) == 0 {
+		return false, nil
+	}
+
+	sort.Strings(waiting)
+	plan.Status.Message = fmt.Sprintf("waiting for CRDs to be established: %s", strings.Join(waiting, ", "))
+	return true, nil
+}
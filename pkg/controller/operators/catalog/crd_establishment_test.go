@@ -0,0 +1,213 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilclock "k8s.io/apimachinery/pkg/util/clock"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/api/reasons"
+)
+
+func establishmentCRD(name string, created time.Time, established, namesAccepted apiextensionsv1beta1.ConditionStatus) *apiextensionsv1beta1.CustomResourceDefinition {
+	return &apiextensionsv1beta1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: name + "s.example.com", CreationTimestamp: metav1.NewTime(created)},
+		Spec: apiextensionsv1beta1.CustomResourceDefinitionSpec{
+			Group:    strings.ToLower(name) + ".example.com",
+			Names:    apiextensionsv1beta1.CustomResourceDefinitionNames{Kind: name, Plural: name + "s"},
+			Versions: []apiextensionsv1beta1.CustomResourceDefinitionVersion{{Name: "v1", Served: true, Storage: true}},
+		},
+		Status: apiextensionsv1beta1.CustomResourceDefinitionStatus{
+			Conditions: []apiextensionsv1beta1.CustomResourceDefinitionCondition{
+				{Type: apiextensionsv1beta1.Established, Status: established, Reason: "Installing", Message: "the initial names have been accepted"},
+				{Type: apiextensionsv1beta1.NamesAccepted, Status: namesAccepted, Reason: "NoConflicts", Message: "no conflicts found"},
+			},
+		},
+	}
+}
+
+func crdStep(name, version string, status v1alpha1.StepStatus) *v1alpha1.Step {
+	return &v1alpha1.Step{
+		Resource: v1alpha1.StepResource{
+			Group:   "apiextensions.k8s.io",
+			Version: version,
+			Kind:    crdKind,
+			Name:    name + "s.example.com",
+		},
+		Status: status,
+	}
+}
+
+func TestAwaitCRDs(t *testing.T) {
+	now := time.Date(2021, time.March, 1, 12, 0, 0, 0, time.UTC)
+	start := metav1.NewTime(now.Add(-time.Minute))
+
+	// A v1 CRD that is established but whose kind isn't served by the discovery of the fake operator, unlike the kind
+	// of another CRD of its group
+	other := establishmentCRD("Other", now, apiextensionsv1beta1.ConditionTrue, apiextensionsv1beta1.ConditionTrue)
+	other.Spec.Group = "example.com"
+	other.Spec.Versions[0].Name = "v2"
+	unserved := &apiextensionsv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: "Unserveds.example.com", CreationTimestamp: metav1.NewTime(now)},
+		Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+			Group:    "example.com",
+			Names:    apiextensionsv1.CustomResourceDefinitionNames{Kind: "Unserved", Plural: "unserveds"},
+			Versions: []apiextensionsv1.CustomResourceDefinitionVersion{{Name: "v2", Served: true, Storage: true}},
+		},
+		Status: apiextensionsv1.CustomResourceDefinitionStatus{
+			Conditions: []apiextensionsv1.CustomResourceDefinitionCondition{
+				{Type: apiextensionsv1.Established, Status: apiextensionsv1.ConditionTrue},
+				{Type: apiextensionsv1.NamesAccepted, Status: apiextensionsv1.ConditionTrue},
+			},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		crds     []runtime.Object
+		steps    []*v1alpha1.Step
+		timeout  time.Duration
+		waiting  bool
+		err      string
+		statuses []v1alpha1.StepStatus
+		message  string
+	}{
+		{
+			name: "Established",
+			crds: []runtime.Object{
+				establishmentCRD("Created", now, apiextensionsv1beta1.ConditionTrue, apiextensionsv1beta1.ConditionTrue),
+				establishmentCRD("Present", now, apiextensionsv1beta1.ConditionTrue, apiextensionsv1beta1.ConditionTrue),
+			},
+			steps: []*v1alpha1.Step{
+				crdStep("Created", "v1beta1", v1alpha1.StepStatusWaitingForAPI),
+				crdStep("Present", "v1beta1", v1alpha1.StepStatusPresent),
+			},
+			timeout:  time.Minute,
+			statuses: []v1alpha1.StepStatus{v1alpha1.StepStatusCreated, v1alpha1.StepStatusPresent},
+		},
+		{
+			name: "NotEstablished",
+			crds: []runtime.Object{
+				establishmentCRD("Established", now, apiextensionsv1beta1.ConditionTrue, apiextensionsv1beta1.ConditionTrue),
+				establishmentCRD("Pending", now, apiextensionsv1beta1.ConditionFalse, apiextensionsv1beta1.ConditionTrue),
+			},
+			steps: []*v1alpha1.Step{
+				crdStep("Established", "v1beta1", v1alpha1.StepStatusCreated),
+				crdStep("Pending", "v1beta1", v1alpha1.StepStatusUnknown),
+			},
+			timeout:  time.Minute,
+			waiting:  true,
+			statuses: []v1alpha1.StepStatus{v1alpha1.StepStatusCreated, v1alpha1.StepStatusWaitingForAPI},
+			message:  "waiting for CRDs to be established: Pendings.example.com (Established condition is False: Installing: the initial names have been accepted)",
+		},
+		{
+			name: "NamesNotAccepted",
+			crds: []runtime.Object{
+				establishmentCRD("Conflicting", now, apiextensionsv1beta1.ConditionFalse, apiextensionsv1beta1.ConditionFalse),
+			},
+			steps:    []*v1alpha1.Step{crdStep("Conflicting", "v1beta1", v1alpha1.StepStatusWaitingForAPI)},
+			timeout:  time.Minute,
+			waiting:  true,
+			statuses: []v1alpha1.StepStatus{v1alpha1.StepStatusWaitingForAPI},
+			message:  "waiting for CRDs to be established: Conflictings.example.com (NamesAccepted condition is False: NoConflicts: no conflicts found)",
+		},
+		{
+			name:     "NotServedByDiscovery",
+			crds:     []runtime.Object{unserved, other},
+			steps:    []*v1alpha1.Step{crdStep("Unserved", "v1", v1alpha1.StepStatusCreated)},
+			timeout:  time.Minute,
+			waiting:  true,
+			statuses: []v1alpha1.StepStatus{v1alpha1.StepStatusWaitingForAPI},
+			message:  "waiting for CRDs to be established: Unserveds.example.com (kind Unserved not served by discovery for example.com/v2)",
+		},
+		{
+			name: "TimedOutSinceCreated",
+			crds: []runtime.Object{
+				establishmentCRD("Pending", now.Add(-2*time.Minute), apiextensionsv1beta1.ConditionFalse, apiextensionsv1beta1.ConditionTrue),
+			},
+			steps:   []*v1alpha1.Step{crdStep("Pending", "v1beta1", v1alpha1.StepStatusWaitingForAPI)},
+			timeout: 30 * time.Second,
+			err:     "CRD Pendings.example.com not established within 30s: Established condition is False: Installing: the initial names have been accepted",
+		},
+		{
+			name: "NotTimedOutSinceRecreated",
+			crds: []runtime.Object{
+				establishmentCRD("Pending", now.Add(-10*time.Second), apiextensionsv1beta1.ConditionFalse, apiextensionsv1beta1.ConditionTrue),
+			},
+			steps:    []*v1alpha1.Step{crdStep("Pending", "v1beta1", v1alpha1.StepStatusWaitingForAPI)},
+			timeout:  30 * time.Second,
+			waiting:  true,
+			statuses: []v1alpha1.StepStatus{v1alpha1.StepStatusWaitingForAPI},
+			message:  "waiting for CRDs to be established: Pendings.example.com (Established condition is False: Installing: the initial names have been accepted)",
+		},
+		{
+			name: "NoTimeout",
+			crds: []runtime.Object{
+				establishmentCRD("Pending", now.Add(-time.Hour), apiextensionsv1beta1.ConditionFalse, apiextensionsv1beta1.ConditionTrue),
+			},
+			steps:    []*v1alpha1.Step{crdStep("Pending", "v1beta1", v1alpha1.StepStatusWaitingForAPI)},
+			waiting:  true,
+			statuses: []v1alpha1.StepStatus{v1alpha1.StepStatusWaitingForAPI},
+			message:  "waiting for CRDs to be established: Pendings.example.com (Established condition is False: Installing: the initial names have been accepted)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			op, err := NewFakeOperator(ctx, "ns", []string{"ns"}, withExtObjs(tt.crds...), withClock(utilclock.NewFakeClock(now)))
+			require.NoError(t, err)
+			op.crdEstablishTimeout = tt.timeout
+
+			plan := &v1alpha1.InstallPlan{
+				Status: v1alpha1.InstallPlanStatus{
+					Phase:     v1alpha1.InstallPlanPhaseInstalling,
+					StartTime: &start,
+					Plan:      tt.steps,
+				},
+			}
+			waiting, err := op.awaitCRDs(ctx, plan)
+			if tt.err != "" {
+				require.EqualError(t, err, tt.err)
+				require.True(t, errors.As(err, &crdNotEstablishedError{}))
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.waiting, waiting)
+			for i, status := range tt.statuses {
+				require.Equal(t, status, plan.Status.Plan[i].Status, "step %d", i)
+			}
+			require.Equal(t, tt.message, plan.Status.Message)
+		})
+	}
+}
+
+func TestTransitionInstallPlanCRDNotEstablished(t *testing.T) {
+	now := metav1.NewTime(time.Date(2021, time.March, 1, 12, 0, 0, 0, time.UTC))
+	err := fmt.Errorf("error applying crds: %w", crdNotEstablishedError{name: "a.example.com", timeout: time.Minute, cause: "not found"})
+	plan := v1alpha1.InstallPlan{
+		Status: v1alpha1.InstallPlanStatus{
+			Phase:     v1alpha1.InstallPlanPhaseInstalling,
+			StartTime: &now,
+		},
+	}
+
+	// The plan fails right away, even though its own timeout didn't pass
+	out, _ := transitionInstallPlanState(context.Background(), logrus.New(), &mockTransitioner{err: err}, plan, now, time.Hour)
+	require.Equal(t, v1alpha1.InstallPlanPhaseFailed, out.Status.Phase)
+	require.Len(t, out.Status.Conditions, 1)
+	require.Equal(t, v1alpha1.InstallPlanConditionReason(reasons.InstallPlanCRDNotEstablished), out.Status.Conditions[0].Reason)
+	require.Equal(t, "error applying crds: CRD a.example.com not established within 1m0s: not found", out.Status.Conditions[0].Message)
+}
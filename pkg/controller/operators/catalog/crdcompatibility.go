@@ -0,0 +1,163 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/api/reasons"
+	crdlib "github.com/operator-framework/operator-lifecycle-manager/pkg/lib/crd"
+)
+
+// InstallPlanCRDSchemaIncompatible is the type of the InstallPlan condition reporting changes of the CRDs it updates
+// that would make the update fail midway: stored versions removed, fields of stored versions changing type, and
+// required fields dropped from stored versions. InstallPlans with incompatible CRDs aren't executed until the
+// condition clears, e.g. once the stored versions of the CRD have been migrated.
+const InstallPlanCRDSchemaIncompatible v1alpha1.InstallPlanConditionType = "CRDSchemaIncompatible"
+
+// crdCompatibilityRecheckInterval is how often InstallPlans held for incompatible CRDs are checked again.
+const crdCompatibilityRecheckInterval = time.Minute
+
+// withCRDCompatibility returns the given plan with its CRDSchemaIncompatible condition updated, or the plan itself if
+// the condition doesn't need to change, and whether the CRDs it updates are incompatible with the existing ones.
+func (o *Operator) withCRDCompatibility(ctx context.Context, logger *logrus.Entry, plan *v1alpha1.InstallPlan) (*v1alpha1.InstallPlan, bool, error) {
+	r := newManifestResolver(plan.GetNamespace(), o.lister.CoreV1().ConfigMapLister(), o.logger)
+	var incompatibilities []string
+	checked := false
+	for _, step := range plan.Status.Plan {
+		if step.Resource.Kind != crdKind {
+			continue
+		}
+		manifest, err := r.ManifestForStep(step)
+		if err != nil {
+			return nil, false, err
+		}
+		// Only v1 CRDs are checked, v1beta1 CRDs are deprecated and validated by the CRD step itself
+		if version, err := crdlib.Version(&manifest); err != nil || version != crdlib.V1Version {
+			continue
+		}
+		updated, err := crdlib.UnmarshalV1(manifest)
+		if err != nil {
+			return nil, false, fmt.Errorf("error parsing step manifest %s: %v", step.Resource.Name, err)
+		}
+		existing, err := o.lister.APIExtensionsV1().CustomResourceDefinitionLister().Get(updated.GetName())
+		if k8serrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, false, err
+		}
+		checked = true
+		incompatibilities = append(incompatibilities, crdIncompatibilities(existing, updated)...)
+	}
+
+	existing := plan.Status.GetCondition(InstallPlanCRDSchemaIncompatible)
+	if !checked && existing.Status == corev1.ConditionUnknown {
+		return plan, false, nil
+	}
+	cond := v1alpha1.InstallPlanCondition{
+		Type:   InstallPlanCRDSchemaIncompatible,
+		Status: corev1.ConditionFalse,
+		Reason: v1alpha1.InstallPlanConditionReason(reasons.InstallPlanCompatibleCRDSchema),
+	}
+	if len(incompatibilities) > 0 {
+		cond.Status = corev1.ConditionTrue
+		cond.Reason = v1alpha1.InstallPlanConditionReason(reasons.InstallPlanIncompatibleCRDSchema)
+		cond.Message = strings.Join(incompatibilities, "; ")
+	}
+	if existing.Status == cond.Status && existing.Reason == cond.Reason && existing.Message == cond.Message {
+		return plan, len(incompatibilities) > 0, nil
+	}
+
+	logger.WithField("incompatibilities", len(incompatibilities)).Info("CRD schema compatibility changed")
+	now := o.now()
+	cond.LastUpdateTime = &now
+	cond.LastTransitionTime = &now
+	out := plan.DeepCopy()
+	out.Status.SetCondition(cond)
+	return out, len(incompatibilities) > 0, nil
+}
+
+// crdIncompatibilities describes the changes of the stored versions of the given existing CRD that the given updated
+// CRD makes and that the apiserver or existing custom resources would reject.
+func crdIncompatibilities(existing, updated *apiextensionsv1.CustomResourceDefinition) []string {
+	var incompatibilities []string
+	for _, stored := range existing.Status.StoredVersions {
+		oldVersion, newVersion := crdVersion(existing, stored), crdVersion(updated, stored)
+		if newVersion == nil {
+			incompatibilities = append(incompatibilities, fmt.Sprintf("CRD %s removes stored version %s", existing.GetName(), stored))
+			continue
+		}
+		if oldVersion == nil || oldVersion.Schema == nil || newVersion.Schema == nil {
+			continue
+		}
+		for _, i := range schemaIncompatibilities("", oldVersion.Schema.OpenAPIV3Schema, newVersion.Schema.OpenAPIV3Schema) {
+			incompatibilities = append(incompatibilities, fmt.Sprintf("CRD %s version %s %s", existing.GetName(), stored, i))
+		}
+	}
+	return incompatibilities
+}
+
+func crdVersion(crd *apiextensionsv1.CustomResourceDefinition, name string) *apiextensionsv1.CustomResourceDefinitionVersion {
+	for i, version := range crd.Spec.Versions {
+		if version.Name == name {
+			return &crd.Spec.Versions[i]
+		}
+	}
+	return nil
+}
+
+// schemaIncompatibilities describes the fields of the given old schema, at the given path, that change type or are
+// required but dropped in the given new schema.
+func schemaIncompatibilities(path string, old, new *apiextensionsv1.JSONSchemaProps) []string {
+	if old == nil || new == nil {
+		return nil
+	}
+	if old.Type != "" && new.Type != "" && old.Type != new.Type {
+		return []string{fmt.Sprintf("changes the type of %s from %s to %s", fieldPath(path), old.Type, new.Type)}
+	}
+
+	var incompatibilities []string
+	preserved := new.XPreserveUnknownFields != nil && *new.XPreserveUnknownFields
+	for _, required := range old.Required {
+		if _, ok := new.Properties[required]; !ok && !preserved {
+			incompatibilities = append(incompatibilities, fmt.Sprintf("drops required field %s", fieldPath(path+"."+required)))
+		}
+	}
+
+	names := make([]string, 0, len(old.Properties))
+	for name := range old.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		newProp, ok := new.Properties[name]
+		if !ok {
+			continue
+		}
+		oldProp := old.Properties[name]
+		incompatibilities = append(incompatibilities, schemaIncompatibilities(path+"."+name, &oldProp, &newProp)...)
+	}
+	if old.Items != nil && new.Items != nil {
+		incompatibilities = append(incompatibilities, schemaIncompatibilities(path+"[*]", old.Items.Schema, new.Items.Schema)...)
+	}
+	if old.AdditionalProperties != nil && new.AdditionalProperties != nil {
+		incompatibilities = append(incompatibilities, schemaIncompatibilities(path+"[*]", old.AdditionalProperties.Schema, new.AdditionalProperties.Schema)...)
+	}
+	return incompatibilities
+}
+
+func fieldPath(path string) string {
+	if path == "" {
+		return "the root"
+	}
+	return strings.TrimPrefix(path, ".")
+}
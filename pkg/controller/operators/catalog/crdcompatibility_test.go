@@ -0,0 +1,171 @@
+package catalog
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/workqueue"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+)
+
+func TestCRDIncompatibilities(t *testing.T) {
+	schema := func(props map[string]apiextensionsv1.JSONSchemaProps, required ...string) *apiextensionsv1.CustomResourceValidation {
+		return &apiextensionsv1.CustomResourceValidation{OpenAPIV3Schema: &apiextensionsv1.JSONSchemaProps{
+			Type: "object",
+			Properties: map[string]apiextensionsv1.JSONSchemaProps{
+				"spec": {Type: "object", Properties: props, Required: required},
+			},
+		}}
+	}
+	crd := func(stored []string, versions map[string]*apiextensionsv1.CustomResourceValidation) *apiextensionsv1.CustomResourceDefinition {
+		crd := &apiextensionsv1.CustomResourceDefinition{
+			ObjectMeta: metav1.ObjectMeta{Name: "foos.example.com"},
+			Status:     apiextensionsv1.CustomResourceDefinitionStatus{StoredVersions: stored},
+		}
+		for _, name := range []string{"v1", "v2"} {
+			if s, ok := versions[name]; ok {
+				crd.Spec.Versions = append(crd.Spec.Versions, apiextensionsv1.CustomResourceDefinitionVersion{Name: name, Served: true, Schema: s})
+			}
+		}
+		return crd
+	}
+	v1 := schema(map[string]apiextensionsv1.JSONSchemaProps{
+		"size":  {Type: "integer"},
+		"name":  {Type: "string"},
+		"ports": {Type: "array", Items: &apiextensionsv1.JSONSchemaPropsOrArray{Schema: &apiextensionsv1.JSONSchemaProps{Type: "integer"}}},
+	}, "name")
+
+	tests := []struct {
+		name     string
+		existing *apiextensionsv1.CustomResourceDefinition
+		updated  *apiextensionsv1.CustomResourceDefinition
+		want     []string
+	}{
+		{
+			name:     "Unchanged",
+			existing: crd([]string{"v1"}, map[string]*apiextensionsv1.CustomResourceValidation{"v1": v1}),
+			updated:  crd(nil, map[string]*apiextensionsv1.CustomResourceValidation{"v1": v1}),
+		},
+		{
+			name:     "NewVersionAndFields",
+			existing: crd([]string{"v1"}, map[string]*apiextensionsv1.CustomResourceValidation{"v1": v1}),
+			updated: crd(nil, map[string]*apiextensionsv1.CustomResourceValidation{
+				"v1": schema(map[string]apiextensionsv1.JSONSchemaProps{
+					"size":     {Type: "integer"},
+					"name":     {Type: "string"},
+					"ports":    {Type: "array"},
+					"replicas": {Type: "integer"},
+				}, "name"),
+				"v2": schema(nil),
+			}),
+		},
+		{
+			name:     "RemovedUnstoredVersion",
+			existing: crd([]string{"v2"}, map[string]*apiextensionsv1.CustomResourceValidation{"v1": v1, "v2": v1}),
+			updated:  crd(nil, map[string]*apiextensionsv1.CustomResourceValidation{"v2": v1}),
+		},
+		{
+			name:     "RemovedStoredVersion",
+			existing: crd([]string{"v1", "v2"}, map[string]*apiextensionsv1.CustomResourceValidation{"v1": v1, "v2": v1}),
+			updated:  crd(nil, map[string]*apiextensionsv1.CustomResourceValidation{"v2": v1}),
+			want:     []string{"CRD foos.example.com removes stored version v1"},
+		},
+		{
+			name:     "TypeChanges",
+			existing: crd([]string{"v1"}, map[string]*apiextensionsv1.CustomResourceValidation{"v1": v1}),
+			updated: crd(nil, map[string]*apiextensionsv1.CustomResourceValidation{
+				"v1": schema(map[string]apiextensionsv1.JSONSchemaProps{
+					"size":  {Type: "string"},
+					"name":  {Type: "string"},
+					"ports": {Type: "array", Items: &apiextensionsv1.JSONSchemaPropsOrArray{Schema: &apiextensionsv1.JSONSchemaProps{Type: "string"}}},
+				}, "name"),
+			}),
+			want: []string{
+				"CRD foos.example.com version v1 changes the type of spec.ports[*] from integer to string",
+				"CRD foos.example.com version v1 changes the type of spec.size from integer to string",
+			},
+		},
+		{
+			name:     "DroppedRequiredField",
+			existing: crd([]string{"v1"}, map[string]*apiextensionsv1.CustomResourceValidation{"v1": v1}),
+			updated: crd(nil, map[string]*apiextensionsv1.CustomResourceValidation{
+				"v1": schema(map[string]apiextensionsv1.JSONSchemaProps{"size": {Type: "integer"}}),
+			}),
+			want: []string{"CRD foos.example.com version v1 drops required field spec.name"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, crdIncompatibilities(tt.existing, tt.updated))
+		})
+	}
+}
+
+func TestSyncInstallPlanIncompatibleCRD(t *testing.T) {
+	namespace := "ns"
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+
+	crd := func(versions ...string) *apiextensionsv1.CustomResourceDefinition {
+		crd := &apiextensionsv1.CustomResourceDefinition{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "apiextensions.k8s.io/v1", Kind: crdKind},
+			ObjectMeta: metav1.ObjectMeta{Name: "foos.example.com"},
+			Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+				Group: "example.com",
+				Names: apiextensionsv1.CustomResourceDefinitionNames{Plural: "foos", Kind: "Foo"},
+				Scope: apiextensionsv1.NamespaceScoped,
+			},
+			Status: apiextensionsv1.CustomResourceDefinitionStatus{StoredVersions: versions},
+		}
+		for _, version := range versions {
+			crd.Spec.Versions = append(crd.Spec.Versions, apiextensionsv1.CustomResourceDefinitionVersion{
+				Name:    version,
+				Served:  true,
+				Storage: version == versions[len(versions)-1],
+				Schema:  &apiextensionsv1.CustomResourceValidation{OpenAPIV3Schema: &apiextensionsv1.JSONSchemaProps{Type: "object"}},
+			})
+		}
+		return crd
+	}
+	plan := withSteps(installPlan("p", namespace, v1alpha1.InstallPlanPhaseInstalling, "csv"),
+		[]*v1alpha1.Step{
+			{
+				Resource: v1alpha1.StepResource{
+					CatalogSource:          "catalog",
+					CatalogSourceNamespace: namespace,
+					Group:                  "apiextensions.k8s.io",
+					Version:                "v1",
+					Kind:                   crdKind,
+					Name:                   "foos.example.com",
+					Manifest:               toManifest(t, crd("v2")),
+				},
+				Status: v1alpha1.StepStatusUnknown,
+			},
+		},
+	)
+	plan.Spec.Approved = true
+
+	op, err := NewFakeOperator(ctx, namespace, []string{namespace}, withClientObjs(plan, operatorGroup("og", "", namespace, nil)), withExtObjs(crd("v1", "v2")))
+	require.NoError(t, err)
+	queue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+	defer queue.ShutDown()
+	op.ipQueueSet.Set(namespace, queue)
+
+	require.NoError(t, op.syncInstallPlans(ctx, plan))
+
+	out, err := op.client.OperatorsV1alpha1().InstallPlans(namespace).Get(ctx, plan.GetName(), metav1.GetOptions{})
+	require.NoError(t, err)
+
+	// The plan is held, and the removed stored version reported
+	require.Equal(t, v1alpha1.InstallPlanPhaseInstalling, out.Status.Phase)
+	require.Nil(t, out.Status.StartTime)
+	require.Equal(t, v1alpha1.StepStatusUnknown, out.Status.Plan[0].Status)
+	cond := out.Status.GetCondition(InstallPlanCRDSchemaIncompatible)
+	require.Equal(t, corev1.ConditionTrue, cond.Status)
+	require.Equal(t, "CRD foos.example.com removes stored version v1", cond.Message)
+}
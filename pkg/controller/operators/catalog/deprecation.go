@@ -0,0 +1,97 @@
+package catalog
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/operator-framework/operator-registry/pkg/api"
+
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/api/reasons"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/registry"
+)
+
+// SubscriptionDeprecated is the condition of a Subscription whose catalog deprecates its package, its channel or the
+// bundle it installs.
+const SubscriptionDeprecated v1alpha1.SubscriptionConditionType = "Deprecated"
+
+// deprecatedCondition returns the SubscriptionDeprecated condition of the given Subscription, with a status of
+// "Unknown" if nothing it subscribes to is deprecated.
+func (o *Operator) deprecatedCondition(ctx context.Context, logger *logrus.Entry, sub *v1alpha1.Subscription, querier SourceQuerier) v1alpha1.SubscriptionCondition {
+	source := registry.CatalogKey{Name: sub.Spec.CatalogSource, Namespace: sub.Spec.CatalogSourceNamespace}
+	name := sub.Status.InstalledCSV
+	if name == "" {
+		name = sub.Status.CurrentCSV
+	}
+
+	var bundle *api.Bundle
+	var err error
+	if name != "" {
+		bundle, err = querier.FindBundle(ctx, sub.Spec.Package, sub.Spec.Channel, name, source)
+	} else {
+		// nothing is installed yet, only the deprecations of the package and of the channel apply
+		bundle, err = querier.FindChannelHead(ctx, sub.Spec.Package, sub.Spec.Channel, source)
+	}
+	if err != nil || bundle == nil {
+		logger.WithError(err).Debug("unable to find bundle of subscription")
+		return sub.Status.GetCondition(SubscriptionDeprecated)
+	}
+
+	var messages []string
+	for _, p := range bundle.GetProperties() {
+		if p.GetType() != registry.DeprecationPropertyType {
+			continue
+		}
+		var d registry.Deprecation
+		if err := json.Unmarshal([]byte(p.GetValue()), &d); err != nil {
+			logger.WithError(err).Debug("ignoring invalid deprecation")
+			continue
+		}
+		if name == "" && d.Schema == "olm.bundle" {
+			continue
+		}
+		messages = append(messages, d.String())
+	}
+	if len(messages) == 0 {
+		return v1alpha1.SubscriptionCondition{Type: SubscriptionDeprecated, Status: corev1.ConditionUnknown}
+	}
+
+	return v1alpha1.SubscriptionCondition{
+		Type:    SubscriptionDeprecated,
+		Status:  corev1.ConditionTrue,
+		Reason:  string(reasons.SubscriptionBundleDeprecated),
+		Message: strings.Join(messages, "; "),
+	}
+}
+
+// ensureSubscriptionDeprecated sets the SubscriptionDeprecated condition on the given Subscription while its catalog
+// deprecates its package, its channel or the bundle it installs, and removes it otherwise.
+func (o *Operator) ensureSubscriptionDeprecated(ctx context.Context, logger *logrus.Entry, sub *v1alpha1.Subscription, querier SourceQuerier) (*v1alpha1.Subscription, bool, error) {
+	cond := o.deprecatedCondition(ctx, logger, sub, querier)
+	if sub.Status.GetCondition(SubscriptionDeprecated).Equals(cond) {
+		return sub, false, nil
+	}
+
+	out := sub.DeepCopy()
+	if cond.Status == corev1.ConditionUnknown {
+		out.Status.RemoveConditions(SubscriptionDeprecated)
+	} else {
+		now := o.now()
+		cond.LastTransitionTime = &now
+		out.Status.SetCondition(cond)
+	}
+	out.Status.LastUpdated = o.now()
+
+	updatedSub, err := o.client.OperatorsV1alpha1().Subscriptions(out.GetNamespace()).UpdateStatus(ctx, out, metav1.UpdateOptions{})
+	if err != nil {
+		logger.WithError(err).Info("error updating subscription status")
+		return nil, false, fmt.Errorf("error updating Subscription status: %v", err)
+	}
+	return updatedSub, true, nil
+}
@@ -0,0 +1,126 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/operator-framework/operator-registry/pkg/api"
+
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/api/reasons"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/registry"
+)
+
+type bundleQuerier struct {
+	channelHeadQuerier
+	bundles map[string]*api.Bundle
+}
+
+func (q bundleQuerier) FindBundle(ctx context.Context, pkgName, channelName, csvName string, source registry.CatalogKey) (*api.Bundle, error) {
+	b, ok := q.bundles[csvName]
+	if !ok {
+		return nil, fmt.Errorf("bundle %s not found", csvName)
+	}
+	return b, nil
+}
+
+func TestEnsureSubscriptionDeprecated(t *testing.T) {
+	deprecatedBundle := func(name string, deprecations ...string) *api.Bundle {
+		b := &api.Bundle{CsvName: name}
+		for _, d := range deprecations {
+			b.Properties = append(b.Properties, &api.Property{Type: registry.DeprecationPropertyType, Value: d})
+		}
+		return b
+	}
+	const (
+		channel = `{"schema":"olm.channel","name":"alpha","message":"use beta"}`
+		bundle  = `{"schema":"olm.bundle","name":"packageA.v1.0.0","message":"upgrade to 1.1.0"}`
+	)
+	deprecatedCond := func(message string) *v1alpha1.SubscriptionCondition {
+		return &v1alpha1.SubscriptionCondition{
+			Type:    SubscriptionDeprecated,
+			Status:  corev1.ConditionTrue,
+			Reason:  string(reasons.SubscriptionBundleDeprecated),
+			Message: message,
+		}
+	}
+	newSub := func(installed string, conds ...v1alpha1.SubscriptionCondition) *v1alpha1.Subscription {
+		return &v1alpha1.Subscription{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "sub"},
+			Spec: &v1alpha1.SubscriptionSpec{
+				CatalogSource:          "catsrc",
+				CatalogSourceNamespace: "ns",
+				Package:                "packageA",
+				Channel:                "alpha",
+			},
+			Status: v1alpha1.SubscriptionStatus{InstalledCSV: installed, Conditions: conds},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		sub      *v1alpha1.Subscription
+		querier  SourceQuerier
+		expected *v1alpha1.SubscriptionCondition
+		updated  bool
+	}{
+		{
+			name:     "InstalledBundleDeprecated",
+			sub:      newSub("packageA.v1.0.0"),
+			querier:  bundleQuerier{bundles: map[string]*api.Bundle{"packageA.v1.0.0": deprecatedBundle("packageA.v1.0.0", channel, bundle)}},
+			expected: deprecatedCond("channel alpha is deprecated: use beta; bundle packageA.v1.0.0 is deprecated: upgrade to 1.1.0"),
+			updated:  true,
+		},
+		{
+			name:     "AlreadyDeprecated",
+			sub:      newSub("packageA.v1.0.0", *deprecatedCond("channel alpha is deprecated: use beta")),
+			querier:  bundleQuerier{bundles: map[string]*api.Bundle{"packageA.v1.0.0": deprecatedBundle("packageA.v1.0.0", channel)}},
+			expected: deprecatedCond("channel alpha is deprecated: use beta"),
+		},
+		{
+			name:     "ChannelDeprecatedBeforeInstall",
+			sub:      newSub(""),
+			querier:  bundleQuerier{channelHeadQuerier: channelHeadQuerier{head: deprecatedBundle("packageA.v1.0.0", channel, bundle)}},
+			expected: deprecatedCond("channel alpha is deprecated: use beta"),
+			updated:  true,
+		},
+		{
+			name:    "NoLongerDeprecated",
+			sub:     newSub("packageA.v1.0.0", *deprecatedCond("channel alpha is deprecated: use beta")),
+			querier: bundleQuerier{bundles: map[string]*api.Bundle{"packageA.v1.0.0": deprecatedBundle("packageA.v1.0.0")}},
+			updated: true,
+		},
+		{
+			name:     "BundleUnavailable",
+			sub:      newSub("packageA.v1.0.0", *deprecatedCond("channel alpha is deprecated: use beta")),
+			querier:  bundleQuerier{},
+			expected: deprecatedCond("channel alpha is deprecated: use beta"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.TODO())
+			defer cancel()
+
+			op, err := NewFakeOperator(ctx, "ns", []string{"ns"}, withClientObjs(tt.sub))
+			require.NoError(t, err)
+
+			out, updated, err := op.ensureSubscriptionDeprecated(ctx, logrus.NewEntry(logrus.New()), tt.sub, tt.querier)
+			require.NoError(t, err)
+			require.Equal(t, tt.updated, updated)
+
+			cond := out.Status.GetCondition(SubscriptionDeprecated)
+			if tt.expected == nil {
+				require.Equal(t, corev1.ConditionUnknown, cond.Status)
+				return
+			}
+			require.True(t, cond.Equals(*tt.expected), "unexpected condition %v", cond)
+		})
+	}
+}
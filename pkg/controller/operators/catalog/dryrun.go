@@ -0,0 +1,223 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/api/reasons"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/registry/resolver"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/scoped"
+)
+
+const (
+	// InstallPlanDryRunAnnotationKey is the key of the annotation that, when set to "true" on an InstallPlan, or on
+	// the Subscriptions an InstallPlan is generated for, makes the catalog operator validate the steps of the plan
+	// against the cluster instead of executing them.
+	InstallPlanDryRunAnnotationKey = "operatorframework.io/dry-run"
+
+	// InstallPlanDryRun is the type of the InstallPlan condition reporting the outcome of a dry-run of its steps.
+	InstallPlanDryRun v1alpha1.InstallPlanConditionType = "DryRun"
+)
+
+// isDryRun returns true if the given object requests a dry-run of its InstallPlans.
+func isDryRun(obj metav1.Object) bool {
+	return obj.GetAnnotations()[InstallPlanDryRunAnnotationKey] == "true"
+}
+
+// withDryRun returns the given plan with its DryRun condition updated, or the plan itself if the condition doesn't
+// need to change.
+func (o *Operator) withDryRun(ctx context.Context, logger *logrus.Entry, plan *v1alpha1.InstallPlan) (*v1alpha1.InstallPlan, error) {
+	failures, err := o.dryRunPlan(ctx, plan)
+	if err != nil {
+		return nil, err
+	}
+
+	cond := v1alpha1.InstallPlanCondition{
+		Type:   InstallPlanDryRun,
+		Status: corev1.ConditionTrue,
+		Reason: v1alpha1.InstallPlanConditionReason(reasons.InstallPlanDryRunSucceeded),
+	}
+	if len(failures) > 0 {
+		sort.Strings(failures)
+		cond.Status = corev1.ConditionFalse
+		cond.Reason = v1alpha1.InstallPlanConditionReason(reasons.InstallPlanDryRunFailed)
+		cond.Message = strings.Join(failures, "; ")
+	}
+	existing := plan.Status.GetCondition(InstallPlanDryRun)
+	if existing.Status == cond.Status && existing.Reason == cond.Reason && existing.Message == cond.Message {
+		return plan, nil
+	}
+
+	logger.WithField("failures", len(failures)).Info("dry-run outcome changed")
+	now := o.now()
+	cond.LastUpdateTime = &now
+	cond.LastTransitionTime = &now
+	out := plan.DeepCopy()
+	out.Status.SetCondition(cond)
+	return out, nil
+}
+
+// dryRunPlan submits the resources of each step of the given plan to the apiserver with server-side dry-run, with the
+// same clients the plan would be executed with, and describes the steps that would fail.
+func (o *Operator) dryRunPlan(ctx context.Context, plan *v1alpha1.InstallPlan) ([]string, error) {
+	attenuate, err := o.clientAttenuator.AttenuateToServiceAccount(scoped.StaticQuerier(plan.Status.AttenuatedServiceAccountRef))
+	if err != nil {
+		return nil, err
+	}
+	dynamicClient, err := o.clientFactory.WithConfigTransformer(attenuate).NewDynamicClient()
+	if err != nil {
+		return nil, err
+	}
+	// CRDs are installed with the OLM client rather than the attenuated one
+	crdClient, err := o.clientFactory.NewDynamicClient()
+	if err != nil {
+		return nil, err
+	}
+	existingCRDOwners, err := o.getExistingAPIOwners(ctx, plan.GetNamespace())
+	if err != nil {
+		return nil, err
+	}
+	initialCSVNames := getCSVNameSet(plan)
+	r := newManifestResolver(plan.GetNamespace(), o.lister.CoreV1().ConfigMapLister(), o.logger)
+
+	var failures []string
+	for _, step := range plan.Status.Plan {
+		manifest, err := r.ManifestForStep(step)
+		if err != nil {
+			return nil, err
+		}
+		obj, err := stepObject(step, manifest)
+		if err == nil {
+			client := dynamicClient
+			if step.Resource.Kind == crdKind {
+				client = crdClient
+			}
+			err = o.dryRunObject(ctx, client, plan.GetNamespace(), obj, initialCSVNames, existingCRDOwners)
+		}
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("%s %s: %v", step.Resource.Kind, step.Resource.Name, err))
+		}
+	}
+	return failures, nil
+}
+
+// dryRunObject creates, updates or applies the given object with server-side dry-run.
+func (o *Operator) dryRunObject(ctx context.Context, client dynamic.Interface, namespace string, obj *unstructured.Unstructured, initialCSVNames map[string]struct{}, existingCRDOwners map[string][]string) error {
+	gvk := obj.GroupVersionKind()
+	if gvk.Kind == csvKind {
+		if _, ok := initialCSVNames[obj.GetName()]; !ok {
+			csv := &v1alpha1.ClusterServiceVersion{}
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, csv); err != nil {
+				return err
+			}
+			competingOwners, err := competingCRDOwnersExist(namespace, csv, existingCRDOwners)
+			if err != nil {
+				return err
+			}
+			if competingOwners {
+				return fmt.Errorf("pre-existing CRD owners found for owned CRD(s) of dependent CSV %s", csv.GetName())
+			}
+		}
+	}
+
+	// The CSVs of the plan aren't created yet, so references to them can't be resolved
+	var refs []metav1.OwnerReference
+	for _, ref := range obj.GetOwnerReferences() {
+		if ref.UID != "" {
+			refs = append(refs, ref)
+		}
+	}
+	obj.SetOwnerReferences(refs)
+
+	r, err := o.apiresourceFromGVK(gvk)
+	if err != nil {
+		return err
+	}
+	gvr := schema.GroupVersionResource{Group: gvk.Group, Version: gvk.Version, Resource: r.Name}
+	var resourceInterface dynamic.ResourceInterface
+	if r.Namespaced {
+		obj.SetNamespace(namespace)
+		resourceInterface = client.Resource(gvr).Namespace(namespace)
+	} else {
+		resourceInterface = client.Resource(gvr)
+	}
+
+	existing, err := resourceInterface.Get(ctx, obj.GetName(), metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		_, err = resourceInterface.Create(ctx, obj, metav1.CreateOptions{DryRun: []string{metav1.DryRunAll}})
+		return err
+	}
+	if err != nil {
+		return err
+	}
+
+	if gvk.Kind == crdKind && gvk.Version == apiextensionsv1.SchemeGroupVersion.Version {
+		oldCRD, newCRD := &apiextensionsv1.CustomResourceDefinition{}, &apiextensionsv1.CustomResourceDefinition{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(existing.Object, oldCRD); err != nil {
+			return err
+		}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, newCRD); err != nil {
+			return err
+		}
+		if err := validateV1CRDCompatibility(ctx, o.dynamicClient, oldCRD, newCRD); err != nil {
+			return err
+		}
+	}
+
+	switch gvk.Kind {
+	case crdKind, csvKind, v1alpha1.SubscriptionKind:
+		obj.SetResourceVersion(existing.GetResourceVersion())
+		_, err = resourceInterface.Update(ctx, obj, metav1.UpdateOptions{DryRun: []string{metav1.DryRunAll}})
+		return err
+	}
+
+	// The other step resources are server-side applied
+	data, err := stepApplyConfiguration(obj, gvk)
+	if err != nil {
+		return err
+	}
+	force := true
+	_, err = resourceInterface.Patch(ctx, obj.GetName(), types.ApplyPatchType, data, metav1.PatchOptions{DryRun: []string{metav1.DryRunAll}, FieldManager: InstallPlanFieldManager, Force: &force})
+	return err
+}
+
+// stepObject decodes the manifest of the given step into an object of the kind the step creates.
+func stepObject(step *v1alpha1.Step, manifest string) (*unstructured.Unstructured, error) {
+	obj := &unstructured.Unstructured{}
+	if err := yaml.NewYAMLOrJSONDecoder(strings.NewReader(manifest), 10).Decode(obj); err != nil {
+		return nil, fmt.Errorf("error decoding manifest: %v", err)
+	}
+	if obj.GetKind() == "" {
+		kind := step.Resource.Kind
+		if kind == resolver.BundleSecretKind {
+			kind = secretKind
+		}
+		obj.SetGroupVersionKind(schema.GroupVersionKind{Group: step.Resource.Group, Version: step.Resource.Version, Kind: kind})
+	}
+	return obj, nil
+}
+
+// dryRunAnnotations returns the annotations of an InstallPlan generated for the given Subscriptions.
+func dryRunAnnotations(subs []*v1alpha1.Subscription) map[string]string {
+	for _, sub := range subs {
+		if isDryRun(sub) {
+			return map[string]string{InstallPlanDryRunAnnotationKey: "true"}
+		}
+	}
+	return nil
+}
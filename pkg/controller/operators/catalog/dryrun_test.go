@@ -0,0 +1,69 @@
+package catalog
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+)
+
+func TestSyncInstallPlanDryRun(t *testing.T) {
+	namespace := "ns"
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+
+	plan := withSteps(installPlan("p", namespace, v1alpha1.InstallPlanPhaseInstalling, "csv"),
+		[]*v1alpha1.Step{
+			{
+				Resource: v1alpha1.StepResource{
+					CatalogSource:          "catalog",
+					CatalogSourceNamespace: namespace,
+					Group:                  "",
+					Version:                "v1",
+					Kind:                   "ServiceAccount",
+					Name:                   "sa",
+					Manifest:               toManifest(t, serviceAccount("sa", namespace, "", objectReference("init secret"))),
+				},
+				Status: v1alpha1.StepStatusUnknown,
+			},
+		},
+	)
+	plan.Spec.Approved = true
+	plan.SetAnnotations(map[string]string{InstallPlanDryRunAnnotationKey: "true"})
+
+	op, err := NewFakeOperator(ctx, namespace, []string{namespace}, withClientObjs(plan, operatorGroup("og", "", namespace, nil)))
+	require.NoError(t, err)
+
+	require.NoError(t, op.syncInstallPlans(ctx, plan))
+
+	out, err := op.client.OperatorsV1alpha1().InstallPlans(namespace).Get(ctx, plan.GetName(), metav1.GetOptions{})
+	require.NoError(t, err)
+
+	// The plan is held, and the step that can't be applied is reported
+	require.Equal(t, v1alpha1.InstallPlanPhaseInstalling, out.Status.Phase)
+	require.Nil(t, out.Status.StartTime)
+	require.Equal(t, v1alpha1.StepStatusUnknown, out.Status.Plan[0].Status)
+	cond := out.Status.GetCondition(InstallPlanDryRun)
+	require.Equal(t, corev1.ConditionFalse, cond.Status)
+	require.Contains(t, cond.Message, "ServiceAccount sa: ")
+
+	// Nothing is left to do until the dry-run outcome changes
+	require.NoError(t, op.syncInstallPlans(ctx, out))
+	again, err := op.client.OperatorsV1alpha1().InstallPlans(namespace).Get(ctx, plan.GetName(), metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, out.GetResourceVersion(), again.GetResourceVersion())
+}
+
+func TestDryRunAnnotations(t *testing.T) {
+	sub := func(annotations map[string]string) *v1alpha1.Subscription {
+		return &v1alpha1.Subscription{ObjectMeta: metav1.ObjectMeta{Annotations: annotations}}
+	}
+
+	require.Nil(t, dryRunAnnotations([]*v1alpha1.Subscription{sub(nil), sub(map[string]string{InstallPlanDryRunAnnotationKey: "false"})}))
+	require.Equal(t, map[string]string{InstallPlanDryRunAnnotationKey: "true"},
+		dryRunAnnotations([]*v1alpha1.Subscription{sub(nil), sub(map[string]string{InstallPlanDryRunAnnotationKey: "true"})}))
+}
@@ -0,0 +1,61 @@
+package catalog
+
+import (
+	"context"
+	"reflect"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/install"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/registry/reconciler"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/ownerutil"
+)
+
+// inlineCatalogConfigMap returns the ConfigMap holding the inline catalog of the given CatalogSource.
+func inlineCatalogConfigMap(source *v1alpha1.CatalogSource) *corev1.ConfigMap {
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      reconciler.CatalogConfigMapName(source),
+			Namespace: source.GetNamespace(),
+			Labels: map[string]string{
+				install.OLMManagedLabelKey: "false",
+			},
+		},
+		Data: map[string]string{
+			reconciler.InlineCatalogKey: source.GetAnnotations()[reconciler.InlineCatalogAnnotationKey],
+		},
+	}
+	ownerutil.AddOwner(configMap, source, false, false)
+	return configMap
+}
+
+// ensureInlineCatalog creates or updates the ConfigMap holding the inline catalog of the given CatalogSource.
+func (o *Operator) ensureInlineCatalog(ctx context.Context, source *v1alpha1.CatalogSource) error {
+	desired := inlineCatalogConfigMap(source)
+	client := o.opClient.KubernetesInterface().CoreV1().ConfigMaps(desired.GetNamespace())
+
+	current, err := o.lister.CoreV1().ConfigMapLister().ConfigMaps(desired.GetNamespace()).Get(desired.GetName())
+	if k8serrors.IsNotFound(err) {
+		_, err = client.Create(ctx, desired, metav1.CreateOptions{})
+		if !k8serrors.IsAlreadyExists(err) {
+			return err
+		}
+		// the cache is behind, fall back to the live config map
+		current, err = client.Get(ctx, desired.GetName(), metav1.GetOptions{})
+	}
+	if err != nil {
+		return err
+	}
+
+	if reflect.DeepEqual(current.Data, desired.Data) && ownerutil.IsOwnedBy(current, source) {
+		return nil
+	}
+	updated := current.DeepCopy()
+	updated.Data = desired.Data
+	ownerutil.EnsureOwner(updated, source)
+	_, err = client.Update(ctx, updated, metav1.UpdateOptions{})
+	return err
+}
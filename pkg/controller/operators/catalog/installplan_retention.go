@@ -0,0 +1,114 @@
+package catalog
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/registry/resolver"
+)
+
+const (
+	// InstallPlanRetentionCountAnnotationKey is the annotation of the cluster OLMConfig setting how many InstallPlans
+	// are kept in each namespace, in addition to those referenced by Subscriptions. It defaults to 5.
+	InstallPlanRetentionCountAnnotationKey = "operatorframework.io/installplan-retention-count"
+
+	// InstallPlanRetentionMaxAgeAnnotationKey is the annotation of the cluster OLMConfig setting, as a duration string
+	// (e.g. "168h"), how long Complete and Failed InstallPlans are kept once created, regardless of their count.
+	// InstallPlans referenced by Subscriptions are always kept. Age-based pruning is disabled by default.
+	InstallPlanRetentionMaxAgeAnnotationKey = "operatorframework.io/installplan-retention-max-age"
+)
+
+// InstallPlanRetention is the policy garbage collecting the InstallPlans of a namespace.
+type InstallPlanRetention struct {
+	// Count is the number of InstallPlans kept, other than those referenced by Subscriptions.
+	Count int
+	// MaxAge is the age past which Complete and Failed InstallPlans are deleted, if not zero.
+	MaxAge time.Duration
+}
+
+// DefaultInstallPlanRetention returns the retention policy used when the cluster OLMConfig doesn't set one.
+func DefaultInstallPlanRetention() InstallPlanRetention {
+	return InstallPlanRetention{Count: maxInstallPlanCount}
+}
+
+// InstallPlanRetentionFor returns the default retention policy with the overrides set in the given OLMConfig
+// annotations applied.
+func InstallPlanRetentionFor(annotations map[string]string) (InstallPlanRetention, error) {
+	retention := DefaultInstallPlanRetention()
+	if value, ok := annotations[InstallPlanRetentionCountAnnotationKey]; ok {
+		count, err := strconv.Atoi(value)
+		if err != nil || count < 1 {
+			return DefaultInstallPlanRetention(), fmt.Errorf("invalid %s annotation: must be a positive integer", InstallPlanRetentionCountAnnotationKey)
+		}
+		retention.Count = count
+	}
+	if value, ok := annotations[InstallPlanRetentionMaxAgeAnnotationKey]; ok {
+		maxAge, err := time.ParseDuration(value)
+		if err != nil || maxAge <= 0 {
+			return DefaultInstallPlanRetention(), fmt.Errorf("invalid %s annotation: must be a positive duration", InstallPlanRetentionMaxAgeAnnotationKey)
+		}
+		retention.MaxAge = maxAge
+	}
+	return retention, nil
+}
+
+// installPlanRetention returns the retention policy set on the cluster OLMConfig. Invalid policies are ignored.
+func (o *Operator) installPlanRetention(log logrus.FieldLogger) InstallPlanRetention {
+	olmConfig, err := o.olmConfigLister.Get("cluster")
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			log.WithError(err).Warn("unable to get olmConfig, using default installplan retention")
+		}
+		return DefaultInstallPlanRetention()
+	}
+	retention, err := InstallPlanRetentionFor(olmConfig.GetAnnotations())
+	if err != nil {
+		log.WithError(err).Warn("ignoring invalid installplan retention set on olmConfig")
+	}
+	return retention
+}
+
+// referencedInstallPlans returns the names of the InstallPlans of the given namespace referenced by its Subscriptions,
+// including those retained to roll them back.
+func (o *Operator) referencedInstallPlans(namespace string) (map[string]struct{}, error) {
+	subs, err := o.lister.OperatorsV1alpha1().SubscriptionLister().Subscriptions(namespace).List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	referenced := map[string]struct{}{}
+	for _, sub := range subs {
+		if ref := sub.Status.InstallPlanRef; ref != nil {
+			referenced[ref.Name] = struct{}{}
+		}
+		if ref := sub.Status.Install; ref != nil {
+			referenced[ref.Name] = struct{}{}
+		}
+		if rollback, err := resolver.RollbackFor(sub); err == nil && rollback != nil {
+			referenced[rollback.InstallPlan] = struct{}{}
+		}
+	}
+	return referenced, nil
+}
+
+// expiredInstallPlans returns the Complete and Failed InstallPlans among the given ones created more than maxAge ago.
+func expiredInstallPlans(ips []*v1alpha1.InstallPlan, now time.Time, maxAge time.Duration) []*v1alpha1.InstallPlan {
+	if maxAge == 0 {
+		return nil
+	}
+	var expired []*v1alpha1.InstallPlan
+	for _, ip := range ips {
+		if ip.Status.Phase != v1alpha1.InstallPlanPhaseComplete && ip.Status.Phase != v1alpha1.InstallPlanPhaseFailed {
+			continue
+		}
+		if now.Sub(ip.GetCreationTimestamp().Time) > maxAge {
+			expired = append(expired, ip)
+		}
+	}
+	return expired
+}
@@ -17,7 +17,7 @@ import (
 // Role/RoleBinding then we expect the InstallPlan that refers to the
 // ServiceAccount to be retried if it has failed to install before due to
 // permission issue(s).
-func (o *Operator) triggerInstallPlanRetry(obj interface{}) (syncError error) {
+func (o *Operator) triggerInstallPlanRetry(ctx context.Context, obj interface{}) (syncError error) {
 	metaObj, ok := obj.(metav1.Object)
 	if !ok {
 		syncError = errors.New("casting to metav1 object failed")
@@ -45,7 +45,7 @@ func (o *Operator) triggerInstallPlanRetry(obj interface{}) (syncError error) {
 	update := func(ip *v1alpha1.InstallPlan) error {
 		out := ip.DeepCopy()
 		out.Status.Phase = v1alpha1.InstallPlanPhaseInstalling
-		_, err := o.client.OperatorsV1alpha1().InstallPlans(ip.GetNamespace()).UpdateStatus(context.TODO(), out, metav1.UpdateOptions{})
+		_, err := o.client.OperatorsV1alpha1().InstallPlans(ip.GetNamespace()).UpdateStatus(ctx, out, metav1.UpdateOptions{})
 
 		return err
 	}
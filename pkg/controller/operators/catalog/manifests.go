@@ -3,6 +3,7 @@ package catalog
 import (
 	"encoding/json"
 	"fmt"
+	"sync"
 
 	"github.com/operator-framework/api/pkg/operators/v1alpha1"
 	"github.com/operator-framework/operator-registry/pkg/configmap"
@@ -26,6 +27,9 @@ type manifestResolver struct {
 	unpackedSteps   map[string][]v1alpha1.StepResource
 	namespace       string
 	logger          logrus.FieldLogger
+
+	// unpackedStepsLock guards unpackedSteps, as the steps of an InstallPlan are resolved concurrently
+	unpackedStepsLock sync.Mutex
 }
 
 func newManifestResolver(namespace string, configMapLister v1.ConfigMapLister, logger logrus.FieldLogger) *manifestResolver {
@@ -73,6 +77,9 @@ func (r *manifestResolver) ManifestForStep(step *v1alpha1.Step) (string, error)
 }
 
 func (r *manifestResolver) unpackedStepsForBundle(bundleName string, ref *UnpackedBundleReference) ([]v1alpha1.StepResource, error) {
+	r.unpackedStepsLock.Lock()
+	defer r.unpackedStepsLock.Unlock()
+
 	usteps, ok := r.unpackedSteps[bundleName]
 	if ok {
 		return usteps, nil
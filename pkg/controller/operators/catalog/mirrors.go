@@ -0,0 +1,30 @@
+package catalog
+
+import (
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/imagemirror"
+)
+
+// mirrorImage returns the given catalog or bundle image in the mirror of its repository set on the cluster OLMConfig,
+// or the image itself if it isn't mirrored.
+func (o *Operator) mirrorImage(image string) string {
+	olmConfig, err := o.olmConfigLister.Get("cluster")
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			o.logger.WithError(err).Warn("unable to get olmConfig, pulling images from their own repositories")
+		}
+		return image
+	}
+	return o.imageMirrors(olmConfig.GetAnnotations()).Rewrite(image)
+}
+
+// imageMirrors returns the repository mirrors held by the given annotations of the cluster OLMConfig. Invalid mirrors
+// are ignored.
+func (o *Operator) imageMirrors(olmConfigAnnotations map[string]string) imagemirror.Mirrors {
+	mirrors, err := imagemirror.ParseMirrors(olmConfigAnnotations)
+	if err != nil {
+		o.logger.WithError(err).Warn("ignoring invalid image mirrors set on olmConfig")
+	}
+	return mirrors
+}
@@ -0,0 +1,52 @@
+package catalog
+
+import (
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+
+	operatorsv1 "github.com/operator-framework/api/pkg/operators/v1"
+	operatorsv1listers "github.com/operator-framework/operator-lifecycle-manager/pkg/api/client/listers/operators/v1"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/imagemirror"
+)
+
+func TestMirrorImage(t *testing.T) {
+	olmConfig := func(mirrors string) *operatorsv1.OLMConfig {
+		return &operatorsv1.OLMConfig{ObjectMeta: metav1.ObjectMeta{
+			Name:        "cluster",
+			Annotations: map[string]string{imagemirror.MirrorsAnnotationKey: mirrors},
+		}}
+	}
+	for _, tt := range []struct {
+		name       string
+		olmConfigs []*operatorsv1.OLMConfig
+		expected   string
+	}{
+		{
+			name:     "NoOLMConfig",
+			expected: "quay.io/operatorhubio/catalog:latest",
+		},
+		{
+			name:       "Mirrored",
+			olmConfigs: []*operatorsv1.OLMConfig{olmConfig(`[{"source":"quay.io/operatorhubio","mirror":"registry.internal/operatorhubio"}]`)},
+			expected:   "registry.internal/operatorhubio/catalog:latest",
+		},
+		{
+			name:       "InvalidMirrors",
+			olmConfigs: []*operatorsv1.OLMConfig{olmConfig(`[{"source":"quay.io/operatorhubio"}]`)},
+			expected:   "quay.io/operatorhubio/catalog:latest",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+			for _, olmConfig := range tt.olmConfigs {
+				require.NoError(t, indexer.Add(olmConfig))
+			}
+			o := &Operator{logger: logrus.New(), olmConfigLister: operatorsv1listers.NewOLMConfigLister(indexer)}
+			require.Equal(t, tt.expected, o.mirrorImage("quay.io/operatorhubio/catalog:latest"))
+		})
+	}
+}
@@ -24,10 +24,12 @@ import (
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/types"
 	utilclock "k8s.io/apimachinery/pkg/util/clock"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
@@ -35,8 +37,9 @@ import (
 	"k8s.io/apimachinery/pkg/util/yaml"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/informers"
+	admissionregistrationv1listers "k8s.io/client-go/listers/admissionregistration/v1"
+	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/cache"
-	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/retry"
 	"k8s.io/client-go/util/workqueue"
@@ -45,22 +48,29 @@ import (
 	"github.com/operator-framework/api/pkg/operators/v1alpha1"
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/api/client/clientset/versioned"
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/api/client/informers/externalversions"
+	operatorsv1listers "github.com/operator-framework/operator-lifecycle-manager/pkg/api/client/listers/operators/v1"
 	operatorsv1alpha1listers "github.com/operator-framework/operator-lifecycle-manager/pkg/api/client/listers/operators/v1alpha1"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/api/reasons"
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/bundle"
 	olmerrors "github.com/operator-framework/operator-lifecycle-manager/pkg/controller/errors"
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/install"
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/operators/catalog/subscription"
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/operators/internal/pruning"
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/registry"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/registry/fbc"
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/registry/grpc"
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/registry/reconciler"
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/registry/resolver"
 	resolvercache "github.com/operator-framework/operator-lifecycle-manager/pkg/controller/registry/resolver/cache"
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/registry/resolver/solver"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/audit"
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/catalogsource"
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/clients"
 	controllerclient "github.com/operator-framework/operator-lifecycle-manager/pkg/lib/controller-runtime/client"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/controllers"
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/event"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/imagearch"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/imagesig"
 	index "github.com/operator-framework/operator-lifecycle-manager/pkg/lib/index"
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/operatorclient"
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/operatorlister"
@@ -93,40 +103,47 @@ const (
 type Operator struct {
 	queueinformer.Operator
 
-	logger                   *logrus.Logger
-	clock                    utilclock.Clock
-	opClient                 operatorclient.ClientInterface
-	client                   versioned.Interface
-	dynamicClient            dynamic.Interface
-	lister                   operatorlister.OperatorLister
-	catsrcQueueSet           *queueinformer.ResourceQueueSet
-	subQueueSet              *queueinformer.ResourceQueueSet
-	ipQueueSet               *queueinformer.ResourceQueueSet
-	nsResolveQueue           workqueue.RateLimitingInterface
-	namespace                string
-	recorder                 record.EventRecorder
-	sources                  *grpc.SourceStore
-	sourcesLastUpdate        sharedtime.SharedTime
-	resolver                 resolver.StepResolver
-	reconciler               reconciler.RegistryReconcilerFactory
-	catalogSubscriberIndexer map[string]cache.Indexer
-	clientAttenuator         *scoped.ClientAttenuator
-	serviceAccountQuerier    *scoped.UserDefinedServiceAccountQuerier
-	bundleUnpacker           bundle.Unpacker
-	installPlanTimeout       time.Duration
-	bundleUnpackTimeout      time.Duration
-	clientFactory            clients.Factory
+	logger                               *logrus.Logger
+	clock                                utilclock.Clock
+	opClient                             operatorclient.ClientInterface
+	client                               versioned.Interface
+	dynamicClient                        dynamic.Interface
+	lister                               operatorlister.OperatorLister
+	olmConfigLister                      operatorsv1listers.OLMConfigLister
+	validatingWebhookConfigurationLister admissionregistrationv1listers.ValidatingWebhookConfigurationLister
+	mutatingWebhookConfigurationLister   admissionregistrationv1listers.MutatingWebhookConfigurationLister
+	catsrcQueueSet                       *queueinformer.ResourceQueueSet
+	subQueueSet                          *queueinformer.ResourceQueueSet
+	ipQueueSet                           *queueinformer.ResourceQueueSet
+	nsResolveQueue                       workqueue.RateLimitingInterface
+	namespace                            string
+	recorder                             record.EventRecorder
+	sources                              *grpc.SourceStore
+	sourcesLastUpdate                    sharedtime.SharedTime
+	catalogServers                       *fbc.Servers
+	catalogPolls                         *reconciler.PollScheduler
+	architectures                        *imagearch.Resolver
+	signatures                           *imagesig.Verifier
+	auditor                              *audit.Recorder
+	resolver                             resolver.StepResolver
+	reconciler                           reconciler.RegistryReconcilerFactory
+	catalogSubscriberIndexer             map[string]cache.Indexer
+	clientAttenuator                     *scoped.ClientAttenuator
+	serviceAccountQuerier                *scoped.UserDefinedServiceAccountQuerier
+	bundleUnpacker                       bundle.Unpacker
+	installPlanTimeout                   time.Duration
+	bundleUnpackTimeout                  time.Duration
+	crdEstablishTimeout                  time.Duration
+	clientFactory                        clients.Factory
+	disabled                             controllers.Disabled
+	bundleValidators                     BundleValidators
 }
 
-type CatalogSourceSyncFunc func(logger *logrus.Entry, in *v1alpha1.CatalogSource) (out *v1alpha1.CatalogSource, continueSync bool, syncError error)
+type CatalogSourceSyncFunc func(ctx context.Context, logger *logrus.Entry, in *v1alpha1.CatalogSource) (out *v1alpha1.CatalogSource, continueSync bool, syncError error)
 
 // NewOperator creates a new Catalog Operator.
-func NewOperator(ctx context.Context, kubeconfigPath string, clock utilclock.Clock, logger *logrus.Logger, resync time.Duration, configmapRegistryImage, opmImage, utilImage string, operatorNamespace string, scheme *runtime.Scheme, installPlanTimeout time.Duration, bundleUnpackTimeout time.Duration) (*Operator, error) {
+func NewOperator(ctx context.Context, config *rest.Config, clock utilclock.Clock, logger *logrus.Logger, resync time.Duration, configmapRegistryImage, opmImage, utilImage string, operatorNamespace string, scheme *runtime.Scheme, installPlanTimeout time.Duration, bundleUnpackTimeout time.Duration, maxParallelUnpacks int, registryBackoff, maxRegistryBackoff time.Duration, syncTimeout time.Duration, maxParallelPolls int, auditMutations bool, crdEstablishTimeout time.Duration, disabled controllers.Disabled, bundleValidators BundleValidators) (*Operator, error) {
 	resyncPeriod := queueinformer.ResyncWithJitter(resync, 0.2)
-	config, err := clientcmd.BuildConfigFromFlags("", kubeconfigPath)
-	if err != nil {
-		return nil, err
-	}
 
 	// Create a new client for OLM types (CRs)
 	crClient, err := versioned.NewForConfig(config)
@@ -146,7 +163,7 @@ func NewOperator(ctx context.Context, kubeconfigPath string, clock utilclock.Clo
 		return nil, err
 	}
 
-	queueOperator, err := queueinformer.NewOperator(opClient.KubernetesInterface().Discovery(), queueinformer.WithOperatorLogger(logger))
+	queueOperator, err := queueinformer.NewOperator(opClient.KubernetesInterface().Discovery(), queueinformer.WithOperatorLogger(logger), queueinformer.WithSyncTimeout(syncTimeout))
 	if err != nil {
 		return nil, err
 	}
@@ -184,11 +201,21 @@ func NewOperator(ctx context.Context, kubeconfigPath string, clock utilclock.Clo
 		clientAttenuator:         scoped.NewClientAttenuator(logger, config, opClient),
 		installPlanTimeout:       installPlanTimeout,
 		bundleUnpackTimeout:      bundleUnpackTimeout,
+		crdEstablishTimeout:      crdEstablishTimeout,
 		clientFactory:            clients.NewFactory(config),
+		catalogServers:           fbc.NewServers(),
+		catalogPolls:             reconciler.NewPollScheduler(maxParallelPolls),
+		architectures:            imagearch.NewResolver(),
+		signatures:               imagesig.NewVerifier(),
+		disabled:                 disabled,
+		bundleValidators:         bundleValidators,
+	}
+	if auditMutations {
+		op.auditor = audit.NewRecorder(eventRecorder, logger)
 	}
 	op.sources = grpc.NewSourceStore(logger, 10*time.Second, 10*time.Minute, op.syncSourceState)
-	op.reconciler = reconciler.NewRegistryReconcilerFactory(lister, opClient, configmapRegistryImage, op.now, ssaClient)
-	res := resolver.NewOperatorStepResolver(lister, crClient, opClient.KubernetesInterface(), operatorNamespace, op.sources, logger)
+	op.reconciler = reconciler.NewRegistryReconcilerFactory(lister, opClient, configmapRegistryImage, opmImage, utilImage, op.catalogServers, op.now, ssaClient, op.catalogPolls, op.infrastructureArchitectures, op.mirrorImage)
+	res := resolver.NewOperatorStepResolver(lister, crClient, opClient.KubernetesInterface(), dynamicClient, operatorNamespace, op.sources, logger)
 	op.resolver = resolver.NewInstrumentedResolver(res, metrics.RegisterDependencyResolutionSuccess, metrics.RegisterDependencyResolutionFailure)
 
 	// Wire OLM CR sharedIndexInformers
@@ -230,6 +257,13 @@ func NewOperator(ctx context.Context, kubeconfigPath string, clock utilclock.Clo
 		return nil, err
 	}
 
+	// Wire the cluster OLMConfig, whose annotations configure the catalog operator
+	olmConfigInformer := crInformerFactory.Operators().V1().OLMConfigs()
+	op.olmConfigLister = olmConfigInformer.Lister()
+	if err := op.RegisterInformer(olmConfigInformer.Informer()); err != nil {
+		return nil, err
+	}
+
 	// TODO: Add namespace resolve sync
 
 	// Wire InstallPlans
@@ -243,7 +277,7 @@ func NewOperator(ctx context.Context, kubeconfigPath string, clock utilclock.Clo
 		queueinformer.WithLogger(op.logger),
 		queueinformer.WithQueue(ipQueue),
 		queueinformer.WithInformer(ipInformer.Informer()),
-		queueinformer.WithSyncer(queueinformer.LegacySyncHandler(op.syncInstallPlans).ToSyncer()),
+		queueinformer.WithSyncer(queueinformer.SyncHandler(op.syncInstallPlans).ToSyncer()),
 	)
 	if err != nil {
 		return nil, err
@@ -263,7 +297,7 @@ func NewOperator(ctx context.Context, kubeconfigPath string, clock utilclock.Clo
 		queueinformer.WithLogger(op.logger),
 		queueinformer.WithQueue(catsrcQueue),
 		queueinformer.WithInformer(catsrcInformer.Informer()),
-		queueinformer.WithSyncer(queueinformer.LegacySyncHandler(op.syncCatalogSources).ToSyncerWithDelete(op.handleCatSrcDeletion)),
+		queueinformer.WithSyncer(queueinformer.SyncHandler(op.syncCatalogSources).ToSyncerWithDelete(op.handleCatSrcDeletion)),
 	)
 	if err != nil {
 		return nil, err
@@ -372,12 +406,36 @@ func NewOperator(ctx context.Context, kubeconfigPath string, clock utilclock.Clo
 	op.lister.CoreV1().RegisterConfigMapLister(metav1.NamespaceAll, configMapInformer.Lister())
 	sharedIndexInformers = append(sharedIndexInformers, configMapInformer.Informer())
 
+	// Wire Secrets, whose credentials authenticate connections to external registry servers
+	secretInformer := informers.NewSharedInformerFactoryWithOptions(op.opClient.KubernetesInterface(), resyncPeriod(), informers.WithTweakListOptions(func(options *metav1.ListOptions) {
+		options.FieldSelector = fields.OneTermNotEqualSelector("type", string(corev1.SecretTypeServiceAccountToken)).String()
+	})).Core().V1().Secrets()
+	op.lister.CoreV1().RegisterSecretLister(metav1.NamespaceAll, secretInformer.Lister())
+	if err := op.RegisterInformer(secretInformer.Informer()); err != nil {
+		return nil, err
+	}
+
+	// Wire the admission webhook configurations OLM installs for CSVs, whose webhooks InstallPlans are checked against
+	webhookInformerFactory := informers.NewSharedInformerFactoryWithOptions(op.opClient.KubernetesInterface(), resyncPeriod(), informers.WithTweakListOptions(func(options *metav1.ListOptions) {
+		options.LabelSelector = labels.SelectorFromSet(map[string]string{ownerutil.OwnerKind: v1alpha1.ClusterServiceVersionKind}).String()
+	}))
+	validatingWebhookInformer := webhookInformerFactory.Admissionregistration().V1().ValidatingWebhookConfigurations()
+	op.validatingWebhookConfigurationLister = validatingWebhookInformer.Lister()
+	if err := op.RegisterInformer(validatingWebhookInformer.Informer()); err != nil {
+		return nil, err
+	}
+	mutatingWebhookInformer := webhookInformerFactory.Admissionregistration().V1().MutatingWebhookConfigurations()
+	op.mutatingWebhookConfigurationLister = mutatingWebhookInformer.Lister()
+	if err := op.RegisterInformer(mutatingWebhookInformer.Informer()); err != nil {
+		return nil, err
+	}
+
 	// Wire Jobs
 	jobInformer := k8sInformerFactory.Batch().V1().Jobs()
 	sharedIndexInformers = append(sharedIndexInformers, jobInformer.Informer())
 
 	// Generate and register QueueInformers for k8s resources
-	k8sSyncer := queueinformer.LegacySyncHandler(op.syncObject).ToSyncerWithDelete(op.handleDeletion)
+	k8sSyncer := queueinformer.SyncHandler(op.syncObject).ToSyncerWithDelete(op.handleDeletion)
 	for _, informer := range sharedIndexInformers {
 		queueInformer, err := queueinformer.NewQueueInformer(
 			ctx,
@@ -408,6 +466,12 @@ func NewOperator(ctx context.Context, kubeconfigPath string, clock utilclock.Clo
 		bundle.WithUtilImage(utilImage),
 		bundle.WithNow(op.now),
 		bundle.WithUnpackTimeout(op.bundleUnpackTimeout),
+		bundle.WithMaxParallelUnpacks(maxParallelUnpacks),
+		bundle.WithRegistryBackoff(registryBackoff, maxRegistryBackoff),
+		bundle.WithArchitectures(op.infrastructureArchitectures),
+		bundle.WithImageVerifier(op.verifyImage),
+		bundle.WithUnpackJobDefaults(op.unpackJobDefaults),
+		bundle.WithImageMirrors(op.mirrorImage),
 	)
 	if err != nil {
 		return nil, err
@@ -420,7 +484,7 @@ func NewOperator(ctx context.Context, kubeconfigPath string, clock utilclock.Clo
 		ctx,
 		queueinformer.WithLogger(op.logger),
 		queueinformer.WithInformer(crdInformer.Informer()),
-		queueinformer.WithSyncer(queueinformer.LegacySyncHandler(op.syncObject).ToSyncerWithDelete(op.handleDeletion)),
+		queueinformer.WithSyncer(queueinformer.SyncHandler(op.syncObject).ToSyncerWithDelete(op.handleDeletion)),
 	)
 	if err != nil {
 		return nil, err
@@ -438,7 +502,7 @@ func NewOperator(ctx context.Context, kubeconfigPath string, clock utilclock.Clo
 		queueinformer.WithLogger(op.logger),
 		queueinformer.WithQueue(op.nsResolveQueue),
 		queueinformer.WithInformer(namespaceInformer.Informer()),
-		queueinformer.WithSyncer(queueinformer.LegacySyncHandler(op.syncResolvingNamespace).ToSyncer()),
+		queueinformer.WithSyncer(queueinformer.SyncHandler(op.syncResolvingNamespace).ToSyncer()),
 	)
 	if err != nil {
 		return nil, err
@@ -465,6 +529,7 @@ func (o *Operator) syncSourceState(state grpc.SourceState) {
 	switch state.State {
 	case connectivity.Ready:
 		o.resolver.Expire(resolvercache.SourceKey(state.Key))
+		o.expireAggregates(state.Key)
 		if o.namespace == state.Key.Namespace {
 			namespaces, err := index.CatalogSubscriberNamespaces(o.catalogSubscriberIndexer,
 				state.Key.Name, state.Key.Namespace)
@@ -516,7 +581,7 @@ func (o *Operator) requeueOwners(obj metav1.Object) {
 	}
 }
 
-func (o *Operator) syncObject(obj interface{}) (syncError error) {
+func (o *Operator) syncObject(ctx context.Context, obj interface{}) (syncError error) {
 	// Assert as metav1.Object
 	metaObj, ok := obj.(metav1.Object)
 	if !ok {
@@ -527,10 +592,10 @@ func (o *Operator) syncObject(obj interface{}) (syncError error) {
 
 	o.requeueOwners(metaObj)
 
-	return o.triggerInstallPlanRetry(obj)
+	return o.triggerInstallPlanRetry(ctx, obj)
 }
 
-func (o *Operator) handleDeletion(obj interface{}) {
+func (o *Operator) handleDeletion(ctx context.Context, obj interface{}) {
 	metaObj, ok := obj.(metav1.Object)
 	if !ok {
 		tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
@@ -554,7 +619,7 @@ func (o *Operator) handleDeletion(obj interface{}) {
 	o.requeueOwners(metaObj)
 }
 
-func (o *Operator) handleCatSrcDeletion(obj interface{}) {
+func (o *Operator) handleCatSrcDeletion(ctx context.Context, obj interface{}) {
 	catsrc, ok := obj.(metav1.Object)
 	if !ok {
 		if !ok {
@@ -575,23 +640,30 @@ func (o *Operator) handleCatSrcDeletion(obj interface{}) {
 	if err := o.sources.Remove(sourceKey); err != nil {
 		o.logger.WithError(err).Warn("error closing client")
 	}
+	o.catalogServers.Stop(sourceKey)
 	o.logger.WithField("source", sourceKey).Info("removed client for deleted catalogsource")
 
 	metrics.DeleteCatalogSourceStateMetric(catsrc.GetName(), catsrc.GetNamespace())
 }
 
-func validateSourceType(logger *logrus.Entry, in *v1alpha1.CatalogSource) (out *v1alpha1.CatalogSource, continueSync bool, _ error) {
+func validateSourceType(ctx context.Context, logger *logrus.Entry, in *v1alpha1.CatalogSource) (out *v1alpha1.CatalogSource, continueSync bool, _ error) {
 	out = in
 	var err error
 	switch sourceType := out.Spec.SourceType; sourceType {
 	case v1alpha1.SourceTypeInternal, v1alpha1.SourceTypeConfigmap:
-		if out.Spec.ConfigMap == "" {
+		if out.Spec.ConfigMap == "" && !reconciler.HasInlineCatalog(out) {
 			err = fmt.Errorf("configmap name unset: must be set for sourcetype: %s", sourceType)
 		}
 	case v1alpha1.SourceTypeGrpc:
 		if out.Spec.Image == "" && out.Spec.Address == "" {
 			err = fmt.Errorf("image and address unset: at least one must be set for sourcetype: %s", sourceType)
 		}
+	case reconciler.SourceTypeOCI:
+		if out.Spec.Image == "" {
+			err = fmt.Errorf("image unset: must be set for sourcetype: %s", sourceType)
+		}
+	case reconciler.SourceTypeAggregate:
+		_, err = reconciler.AggregateMembers(out)
 	default:
 		err = fmt.Errorf("unknown sourcetype: %s", sourceType)
 	}
@@ -611,7 +683,104 @@ func validateSourceType(logger *logrus.Entry, in *v1alpha1.CatalogSource) (out *
 	return
 }
 
-func (o *Operator) syncConfigMap(logger *logrus.Entry, in *v1alpha1.CatalogSource) (out *v1alpha1.CatalogSource, continueSync bool, syncError error) {
+// checkSourceTypeEnabled ends the sync of CatalogSources of the configmap and internal source types with an error while
+// their controller is disabled.
+func (o *Operator) checkSourceTypeEnabled(ctx context.Context, logger *logrus.Entry, in *v1alpha1.CatalogSource) (out *v1alpha1.CatalogSource, continueSync bool, syncError error) {
+	out = in
+	switch in.Spec.SourceType {
+	case v1alpha1.SourceTypeInternal, v1alpha1.SourceTypeConfigmap:
+		if !o.disabled.Enabled(controllers.ConfigMapCatalogs) {
+			out.SetError(v1alpha1.CatalogSourceSpecInvalidError, fmt.Errorf("sourcetype %s is disabled on this cluster", in.Spec.SourceType))
+			return
+		}
+	}
+	continueSync = true
+	return
+}
+
+// syncAggregate checks the members of aggregate CatalogSources, which have neither a registry server nor a connection
+// of their own, and ends their sync.
+func (o *Operator) syncAggregate(ctx context.Context, logger *logrus.Entry, in *v1alpha1.CatalogSource) (out *v1alpha1.CatalogSource, continueSync bool, syncError error) {
+	out = in
+	if in.Spec.SourceType != reconciler.SourceTypeAggregate {
+		continueSync = true
+		return
+	}
+
+	out = in.DeepCopy()
+
+	members, err := reconciler.AggregateMembers(in)
+	if err != nil {
+		out.SetError(v1alpha1.CatalogSourceSpecInvalidError, err)
+		return
+	}
+	for _, member := range members {
+		if member.Namespace != in.GetNamespace() && member.Namespace != o.namespace {
+			out.SetError(v1alpha1.CatalogSourceSpecInvalidError, fmt.Errorf("member catalog %s/%s must be in namespace %s or %s", member.Namespace, member.Name, in.GetNamespace(), o.namespace))
+			return
+		}
+	}
+
+	srcReconciler := o.reconciler.ReconcilerForSource(in)
+	if srcReconciler == nil {
+		syncError = fmt.Errorf("no reconciler for source type %s", in.Spec.SourceType)
+		out.SetError(v1alpha1.CatalogSourceRegistryServerError, syncError)
+		return
+	}
+
+	healthy, err := srcReconciler.CheckRegistryServer(ctx, in)
+	if err != nil {
+		syncError = err
+		out.SetError(v1alpha1.CatalogSourceRegistryServerError, syncError)
+		return
+	}
+	if !healthy {
+		logger.Debug("requeueing aggregate catalog: members not yet healthy")
+		o.catsrcQueueSet.RequeueAfter(out.GetNamespace(), out.GetName(), reconciler.CatalogPollingRequeuePeriod)
+		return
+	}
+
+	if out.Status.RegistryServiceStatus == nil {
+		if err := srcReconciler.EnsureRegistryServer(ctx, out); err != nil {
+			syncError = err
+			out.SetError(v1alpha1.CatalogSourceRegistryServerError, syncError)
+			return
+		}
+	}
+
+	// Members may have changed since the aggregate was last snapshotted
+	o.resolver.Expire(resolvercache.SourceKey{Name: in.GetName(), Namespace: in.GetNamespace()})
+
+	return
+}
+
+// expireAggregates expires the cached content of the aggregate CatalogSources listing the given catalog as a member,
+// and queues their namespaces for resolution.
+func (o *Operator) expireAggregates(key registry.CatalogKey) {
+	catsrcs, err := o.lister.OperatorsV1alpha1().CatalogSourceLister().List(labels.Everything())
+	if err != nil {
+		o.logger.WithError(err).Debug("couldn't list catalogsources to expire aggregates")
+		return
+	}
+	for _, catsrc := range catsrcs {
+		if catsrc.Spec.SourceType != reconciler.SourceTypeAggregate {
+			continue
+		}
+		members, err := reconciler.AggregateMembers(catsrc)
+		if err != nil {
+			continue
+		}
+		for _, member := range members {
+			if member.Name == key.Name && member.Namespace == key.Namespace {
+				o.resolver.Expire(resolvercache.SourceKey{Name: catsrc.GetName(), Namespace: catsrc.GetNamespace()})
+				o.nsResolveQueue.Add(catsrc.GetNamespace())
+				break
+			}
+		}
+	}
+}
+
+func (o *Operator) syncConfigMap(ctx context.Context, logger *logrus.Entry, in *v1alpha1.CatalogSource) (out *v1alpha1.CatalogSource, continueSync bool, syncError error) {
 	out = in
 	if !(in.Spec.SourceType == v1alpha1.SourceTypeInternal || in.Spec.SourceType == v1alpha1.SourceTypeConfigmap) {
 		continueSync = true
@@ -622,12 +791,22 @@ func (o *Operator) syncConfigMap(logger *logrus.Entry, in *v1alpha1.CatalogSourc
 
 	logger.Debug("checking catsrc configmap state")
 
+	// Write out the catalog source's inline catalog to its config map
+	if reconciler.HasInlineCatalog(in) {
+		if err := o.ensureInlineCatalog(ctx, in); err != nil {
+			syncError = fmt.Errorf("failed to write inline catalog config map: %s", err)
+			out.SetError(v1alpha1.CatalogSourceConfigMapError, syncError)
+			return
+		}
+	}
+
 	var updateLabel bool
 	// Get the catalog source's config map
-	configMap, err := o.lister.CoreV1().ConfigMapLister().ConfigMaps(in.GetNamespace()).Get(in.Spec.ConfigMap)
+	configMapName := reconciler.CatalogConfigMapName(in)
+	configMap, err := o.lister.CoreV1().ConfigMapLister().ConfigMaps(in.GetNamespace()).Get(configMapName)
 	// Attempt to look up the CM via api call if there is a cache miss
 	if k8serrors.IsNotFound(err) {
-		configMap, err = o.opClient.KubernetesInterface().CoreV1().ConfigMaps(in.GetNamespace()).Get(context.TODO(), in.Spec.ConfigMap, metav1.GetOptions{})
+		configMap, err = o.opClient.KubernetesInterface().CoreV1().ConfigMaps(in.GetNamespace()).Get(ctx, configMapName, metav1.GetOptions{})
 		// Found cm in the cluster, add managed label to configmap
 		if err == nil {
 			labels := configMap.GetLabels()
@@ -641,13 +820,13 @@ func (o *Operator) syncConfigMap(logger *logrus.Entry, in *v1alpha1.CatalogSourc
 		}
 	}
 	if err != nil {
-		syncError = fmt.Errorf("failed to get catalog config map %s: %s", in.Spec.ConfigMap, err)
+		syncError = fmt.Errorf("failed to get catalog config map %s: %s", configMapName, err)
 		out.SetError(v1alpha1.CatalogSourceConfigMapError, syncError)
 		return
 	}
 
 	if wasOwned := ownerutil.EnsureOwner(configMap, in); !wasOwned || updateLabel {
-		configMap, err = o.opClient.KubernetesInterface().CoreV1().ConfigMaps(configMap.GetNamespace()).Update(context.TODO(), configMap, metav1.UpdateOptions{})
+		configMap, err = o.opClient.KubernetesInterface().CoreV1().ConfigMaps(configMap.GetNamespace()).Update(ctx, configMap, metav1.UpdateOptions{})
 		if err != nil {
 			syncError = fmt.Errorf("unable to write owner onto catalog source configmap - %v", err)
 			out.SetError(v1alpha1.CatalogSourceConfigMapError, syncError)
@@ -675,7 +854,7 @@ func (o *Operator) syncConfigMap(logger *logrus.Entry, in *v1alpha1.CatalogSourc
 	return
 }
 
-func (o *Operator) syncRegistryServer(logger *logrus.Entry, in *v1alpha1.CatalogSource) (out *v1alpha1.CatalogSource, continueSync bool, syncError error) {
+func (o *Operator) syncRegistryServer(ctx context.Context, logger *logrus.Entry, in *v1alpha1.CatalogSource) (out *v1alpha1.CatalogSource, continueSync bool, syncError error) {
 	out = in.DeepCopy()
 
 	sourceKey := registry.CatalogKey{Name: in.GetName(), Namespace: in.GetNamespace()}
@@ -687,7 +866,31 @@ func (o *Operator) syncRegistryServer(logger *logrus.Entry, in *v1alpha1.Catalog
 		return
 	}
 
-	healthy, err := srcReconciler.CheckRegistryServer(in)
+	// Catalog images failing signature verification are never pulled, and those verified are pulled by the digest
+	// verified: the registry server is checked and ensured for the CatalogSource pinned to it
+	pinned := func(source *v1alpha1.CatalogSource) *v1alpha1.CatalogSource { return source }
+	if in.Spec.Image != "" {
+		image, err := o.verifyImage(ctx, in.Spec.Image, in.GetNamespace(), in.Spec.Secrets)
+		if err != nil {
+			syncError = err
+			var verificationErr *imagesig.VerificationError
+			if errors.As(err, &verificationErr) {
+				out.SetError(v1alpha1.ConditionReason(reasons.CatalogSourceSignatureVerificationFailed), syncError)
+			} else {
+				out.SetError(v1alpha1.CatalogSourceRegistryServerError, syncError)
+			}
+			return
+		}
+		if image != in.Spec.Image {
+			pinned = func(source *v1alpha1.CatalogSource) *v1alpha1.CatalogSource {
+				source = source.DeepCopy()
+				source.Spec.Image = image
+				return source
+			}
+		}
+	}
+
+	healthy, err := srcReconciler.CheckRegistryServer(ctx, pinned(in))
 	if err != nil {
 		syncError = err
 		out.SetError(v1alpha1.CatalogSourceRegistryServerError, syncError)
@@ -708,13 +911,22 @@ func (o *Operator) syncRegistryServer(logger *logrus.Entry, in *v1alpha1.Catalog
 	// Registry pod hasn't been created or hasn't been updated since the last configmap update, recreate it
 	logger.Debug("ensuring registry server")
 
-	err = srcReconciler.EnsureRegistryServer(out)
+	ensured := pinned(out)
+	err = srcReconciler.EnsureRegistryServer(ctx, ensured)
+	// the CatalogSource keeps referencing its image as written rather than the digest pinned
+	ensured.Spec.Image = out.Spec.Image
+	out = ensured
 	if err != nil {
 		if _, ok := err.(reconciler.UpdateNotReadyErr); ok {
 			logger.Debug("requeueing registry server for catalog update check: update pod not yet ready")
 			o.catsrcQueueSet.RequeueAfter(out.GetNamespace(), out.GetName(), reconciler.CatalogPollingRequeuePeriod)
 			return
 		}
+		if _, ok := err.(reconciler.PollThrottledErr); ok {
+			logger.Debug("requeueing registry server for catalog update check: poll held back")
+			o.catsrcQueueSet.RequeueAfter(out.GetNamespace(), out.GetName(), o.catalogPolls.RetryAfter())
+			return
+		}
 		syncError = fmt.Errorf("couldn't ensure registry server - %v", err)
 		out.SetError(v1alpha1.CatalogSourceRegistryServerError, syncError)
 		return
@@ -722,6 +934,27 @@ func (o *Operator) syncRegistryServer(logger *logrus.Entry, in *v1alpha1.Catalog
 
 	logger.Debug("ensured registry server")
 
+	// the digests of catalog images pinned by their update strategy are recorded in an annotation, which status
+	// updates don't persist
+	if digests := out.GetAnnotations()[reconciler.CatalogImageDigestsAnnotationKey]; digests != in.GetAnnotations()[reconciler.CatalogImageDigestsAnnotationKey] {
+		patch, err := json.Marshal(map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"annotations": map[string]interface{}{reconciler.CatalogImageDigestsAnnotationKey: digests},
+			},
+		})
+		if err != nil {
+			syncError = err
+			return
+		}
+		patched, err := o.client.OperatorsV1alpha1().CatalogSources(out.GetNamespace()).Patch(ctx, out.GetName(), types.MergePatchType, patch, metav1.PatchOptions{})
+		if err != nil {
+			syncError = fmt.Errorf("couldn't record catalog image digests - %v", err)
+			out.SetError(v1alpha1.CatalogSourceRegistryServerError, syncError)
+			return
+		}
+		out.SetResourceVersion(patched.GetResourceVersion())
+	}
+
 	// requeue the catalog sync based on the polling interval, for accurate syncs of catalogs with polling enabled
 	if out.Spec.UpdateStrategy != nil {
 		logger.Debugf("requeuing registry server sync based on polling interval %s", out.Spec.UpdateStrategy.Interval.Duration.String())
@@ -737,16 +970,31 @@ func (o *Operator) syncRegistryServer(logger *logrus.Entry, in *v1alpha1.Catalog
 	return
 }
 
-func (o *Operator) syncConnection(logger *logrus.Entry, in *v1alpha1.CatalogSource) (out *v1alpha1.CatalogSource, continueSync bool, syncError error) {
+func (o *Operator) syncConnection(ctx context.Context, logger *logrus.Entry, in *v1alpha1.CatalogSource) (out *v1alpha1.CatalogSource, continueSync bool, syncError error) {
 	out = in.DeepCopy()
 
 	sourceKey := registry.CatalogKey{Name: in.GetName(), Namespace: in.GetNamespace()}
 	// update operator's view of sources
 	now := o.now()
 	address := in.Address()
+	if in.Spec.SourceType == reconciler.SourceTypeOCI {
+		// oci catalogs are served from memory by the operator itself
+		address = o.catalogServers.Address(sourceKey)
+	}
+
+	creds, err := grpc.SourceCredentials(o.lister.CoreV1().SecretLister(), in)
+	if err != nil {
+		syncError = fmt.Errorf("couldn't load registry credentials - %v", err)
+		out.SetError(v1alpha1.CatalogSourceRegistryServerError, syncError)
+		return
+	}
+	var credsRevision string
+	if creds != nil {
+		credsRevision = creds.Revision
+	}
 
 	connectFunc := func() (source *grpc.SourceMeta, connErr error) {
-		newSource, err := o.sources.Add(sourceKey, address)
+		newSource, err := o.sources.AddWithCredentials(sourceKey, address, creds)
 		if err != nil {
 			connErr = fmt.Errorf("couldn't connect to registry - %v", err)
 			return
@@ -782,7 +1030,7 @@ func (o *Operator) syncConnection(logger *logrus.Entry, in *v1alpha1.CatalogSour
 		return
 	}
 
-	if source.Address != address {
+	if source.Address != address || source.CredentialsRevision != credsRevision {
 		source, syncError = connectFunc()
 		if syncError != nil {
 			out.SetError(v1alpha1.CatalogSourceRegistryServerError, syncError)
@@ -804,7 +1052,7 @@ func (o *Operator) syncConnection(logger *logrus.Entry, in *v1alpha1.CatalogSour
 	return
 }
 
-func (o *Operator) syncCatalogSources(obj interface{}) (syncError error) {
+func (o *Operator) syncCatalogSources(ctx context.Context, obj interface{}) (syncError error) {
 	catsrc, ok := obj.(*v1alpha1.CatalogSource)
 	if !ok {
 		o.logger.Debugf("wrong type: %#v", obj)
@@ -822,7 +1070,7 @@ func (o *Operator) syncCatalogSources(obj interface{}) (syncError error) {
 		out = in
 		for _, syncFunc := range chain {
 			cont := false
-			out, cont, syncErr = syncFunc(logger, in)
+			out, cont, syncErr = syncFunc(ctx, logger, in)
 			if syncErr != nil {
 				return
 			}
@@ -843,6 +1091,8 @@ func (o *Operator) syncCatalogSources(obj interface{}) (syncError error) {
 
 	chain := []CatalogSourceSyncFunc{
 		validateSourceType,
+		o.checkSourceTypeEnabled,
+		o.syncAggregate,
 		o.syncConfigMap,
 		o.syncRegistryServer,
 		o.syncConnection,
@@ -869,7 +1119,7 @@ func (o *Operator) syncCatalogSources(obj interface{}) (syncError error) {
 	return
 }
 
-func (o *Operator) syncResolvingNamespace(obj interface{}) error {
+func (o *Operator) syncResolvingNamespace(ctx context.Context, obj interface{}) error {
 	ns, ok := obj.(*corev1.Namespace)
 	if !ok {
 		o.logger.Debugf("wrong type: %#v", obj)
@@ -882,7 +1132,7 @@ func (o *Operator) syncResolvingNamespace(obj interface{}) error {
 		"id":        queueinformer.NewLoopID(),
 	})
 
-	o.gcInstallPlans(logger, namespace)
+	o.gcInstallPlans(ctx, logger, namespace)
 
 	// get the set of sources that should be used for resolution and best-effort get their connections working
 	logger.Debug("resolving sources")
@@ -891,7 +1141,7 @@ func (o *Operator) syncResolvingNamespace(obj interface{}) error {
 
 	logger.Debug("checking if subscriptions need update")
 
-	subs, err := o.listSubscriptions(namespace)
+	subs, err := o.listSubscriptions(ctx, namespace)
 	if err != nil {
 		logger.WithError(err).Debug("couldn't list subscriptions")
 		return err
@@ -913,7 +1163,7 @@ func (o *Operator) syncResolvingNamespace(obj interface{}) error {
 		}
 
 		// ensure the installplan reference is correct
-		sub, changedIP, err := o.ensureSubscriptionInstallPlanState(logger, sub)
+		sub, changedIP, err := o.ensureSubscriptionInstallPlanState(ctx, logger, sub)
 		if err != nil {
 			logger.Debugf("error ensuring installplan state: %v", err)
 			return err
@@ -921,15 +1171,47 @@ func (o *Operator) syncResolvingNamespace(obj interface{}) error {
 		subscriptionUpdated = subscriptionUpdated || changedIP
 
 		// record the current state of the desired corresponding CSV in the status. no-op if we don't know the csv yet.
-		sub, changedCSV, err := o.ensureSubscriptionCSVState(logger, sub, querier)
+		sub, changedCSV, err := o.ensureSubscriptionCSVState(ctx, logger, sub, querier)
 		if err != nil {
 			logger.Debugf("error recording current state of CSV in status: %v", err)
 			return err
 		}
 
 		subscriptionUpdated = subscriptionUpdated || changedCSV
+
+		// report whether the version the subscription is pinned to holds back newer operators
+		sub, changedPin, err := o.ensureSubscriptionVersionPinned(ctx, logger, sub, querier)
+		if err != nil {
+			logger.Debugf("error recording version pin in status: %v", err)
+			return err
+		}
+		subscriptionUpdated = subscriptionUpdated || changedPin
+
+		// report whether the catalog deprecates what the subscription installs
+		sub, changedDeprecation, err := o.ensureSubscriptionDeprecated(ctx, logger, sub, querier)
+		if err != nil {
+			logger.Debugf("error recording deprecations in status: %v", err)
+			return err
+		}
+		subscriptionUpdated = subscriptionUpdated || changedDeprecation
+
+		// record the operator to roll back to, and roll back upgrades that missed their deadline
+		sub, changedRollback, err := o.ensureSubscriptionRollback(ctx, logger, sub)
+		if err != nil {
+			logger.Debugf("error ensuring rollback state: %v", err)
+			return err
+		}
+		subscriptionUpdated = subscriptionUpdated || changedRollback
 		subs[i] = sub
 	}
+
+	// report, commit and roll back the transactions of the subscriptions
+	changedTransactions, err := o.ensureTransactions(ctx, logger, subs)
+	if err != nil {
+		logger.Debugf("error ensuring transactions: %v", err)
+		return err
+	}
+	subscriptionUpdated = subscriptionUpdated || changedTransactions
 	if subscriptionUpdated {
 		logger.Debug("subscriptions were updated, wait for a new resolution")
 		return nil
@@ -947,7 +1229,7 @@ func (o *Operator) syncResolvingNamespace(obj interface{}) error {
 	logger.Debug("resolving subscriptions in namespace")
 
 	// resolve a set of steps to apply to a cluster, a set of subscriptions to create/update, and any errors
-	steps, bundleLookups, updatedSubs, err := o.resolver.ResolveSteps(namespace)
+	steps, bundleLookups, updatedSubs, err := o.resolver.ResolveSteps(ctx, namespace)
 	if err != nil {
 		go o.recorder.Event(ns, corev1.EventTypeWarning, "ResolutionFailed", err.Error())
 		// If the error is constraints not satisfiable, then simply project the
@@ -958,10 +1240,10 @@ func (o *Operator) syncResolvingNamespace(obj interface{}) error {
 		// not-satisfiable error
 		if _, ok := err.(solver.NotSatisfiable); ok {
 			logger.WithError(err).Debug("resolution failed")
-			_, updateErr := o.updateSubscriptionStatuses(
+			_, updateErr := o.updateSubscriptionStatuses(ctx,
 				o.setSubsCond(subs, v1alpha1.SubscriptionCondition{
 					Type:    v1alpha1.SubscriptionResolutionFailed,
-					Reason:  "ConstraintsNotSatisfiable",
+					Reason:  string(reasons.SubscriptionConstraintsNotSatisfiable),
 					Message: err.Error(),
 					Status:  corev1.ConditionTrue,
 				}))
@@ -972,10 +1254,10 @@ func (o *Operator) syncResolvingNamespace(obj interface{}) error {
 			return nil
 		}
 
-		_, updateErr := o.updateSubscriptionStatuses(
+		_, updateErr := o.updateSubscriptionStatuses(ctx,
 			o.setSubsCond(subs, v1alpha1.SubscriptionCondition{
 				Type:    v1alpha1.SubscriptionResolutionFailed,
-				Reason:  "ErrorPreventedResolution",
+				Reason:  string(reasons.SubscriptionErrorPreventedResolution),
 				Message: err.Error(),
 				Status:  corev1.ConditionTrue,
 			}))
@@ -990,7 +1272,7 @@ func (o *Operator) syncResolvingNamespace(obj interface{}) error {
 	if len(updatedSubs) > 0 {
 		logger.Debug("resolution caused subscription changes, creating installplan")
 		// Finish calculating max generation by checking the existing installplans
-		installPlans, err := o.listInstallPlans(namespace)
+		installPlans, err := o.listInstallPlans(ctx, namespace)
 		if err != nil {
 			return err
 		}
@@ -1010,7 +1292,7 @@ func (o *Operator) syncResolvingNamespace(obj interface{}) error {
 			}
 		}
 
-		installPlanReference, err := o.ensureInstallPlan(logger, namespace, maxGeneration+1, subs, installPlanApproval, steps, bundleLookups)
+		installPlanReference, err := o.ensureInstallPlan(ctx, logger, namespace, maxGeneration+1, subs, installPlanApproval, steps, bundleLookups)
 		if err != nil {
 			logger.WithError(err).Debug("error ensuring installplan")
 			return err
@@ -1040,7 +1322,7 @@ func (o *Operator) syncResolvingNamespace(obj interface{}) error {
 	}
 
 	// Update subscriptions with all changes so far
-	_, updateErr := o.updateSubscriptionStatuses(subs)
+	_, updateErr := o.updateSubscriptionStatuses(ctx, subs)
 	if updateErr != nil {
 		logger.WithError(updateErr).Warn("failed to update subscription conditions")
 		return updateErr
@@ -1069,7 +1351,7 @@ func (o *Operator) nothingToUpdate(logger *logrus.Entry, sub *v1alpha1.Subscript
 	return false
 }
 
-func (o *Operator) ensureSubscriptionInstallPlanState(logger *logrus.Entry, sub *v1alpha1.Subscription) (*v1alpha1.Subscription, bool, error) {
+func (o *Operator) ensureSubscriptionInstallPlanState(ctx context.Context, logger *logrus.Entry, sub *v1alpha1.Subscription) (*v1alpha1.Subscription, bool, error) {
 	if sub.Status.InstallPlanRef != nil || sub.Status.Install != nil {
 		return sub, false, nil
 	}
@@ -1083,7 +1365,7 @@ func (o *Operator) ensureSubscriptionInstallPlanState(logger *logrus.Entry, sub
 		return sub, false, nil
 	}
 
-	ip, err := o.client.OperatorsV1alpha1().InstallPlans(sub.GetNamespace()).Get(context.TODO(), ipName, metav1.GetOptions{})
+	ip, err := o.client.OperatorsV1alpha1().InstallPlans(sub.GetNamespace()).Get(ctx, ipName, metav1.GetOptions{})
 	if err != nil {
 		logger.WithField("installplan", ipName).Warn("unable to get installplan from cache")
 		return nil, false, err
@@ -1105,12 +1387,12 @@ func (o *Operator) ensureSubscriptionInstallPlanState(logger *logrus.Entry, sub
 	return out, true, nil
 }
 
-func (o *Operator) ensureSubscriptionCSVState(logger *logrus.Entry, sub *v1alpha1.Subscription, querier SourceQuerier) (*v1alpha1.Subscription, bool, error) {
+func (o *Operator) ensureSubscriptionCSVState(ctx context.Context, logger *logrus.Entry, sub *v1alpha1.Subscription, querier SourceQuerier) (*v1alpha1.Subscription, bool, error) {
 	if sub.Status.CurrentCSV == "" {
 		return sub, false, nil
 	}
 
-	_, err := o.client.OperatorsV1alpha1().ClusterServiceVersions(sub.GetNamespace()).Get(context.TODO(), sub.Status.CurrentCSV, metav1.GetOptions{})
+	_, err := o.client.OperatorsV1alpha1().ClusterServiceVersions(sub.GetNamespace()).Get(ctx, sub.Status.CurrentCSV, metav1.GetOptions{})
 	out := sub.DeepCopy()
 	if err != nil {
 		logger.WithError(err).WithField("currentCSV", sub.Status.CurrentCSV).Debug("error fetching csv listed in subscription status")
@@ -1131,7 +1413,7 @@ func (o *Operator) ensureSubscriptionCSVState(logger *logrus.Entry, sub *v1alpha
 	out.Status.LastUpdated = o.now()
 
 	// Update Subscription with status of transition. Log errors if we can't write them to the status.
-	updatedSub, err := o.client.OperatorsV1alpha1().Subscriptions(out.GetNamespace()).UpdateStatus(context.TODO(), out, metav1.UpdateOptions{})
+	updatedSub, err := o.client.OperatorsV1alpha1().Subscriptions(out.GetNamespace()).UpdateStatus(ctx, out, metav1.UpdateOptions{})
 	if err != nil {
 		logger.WithError(err).Info("error updating subscription status")
 		return nil, false, fmt.Errorf("error updating Subscription status: " + err.Error())
@@ -1157,13 +1439,13 @@ func (o *Operator) setIPReference(subs []*v1alpha1.Subscription, gen int, instal
 	return subs
 }
 
-func (o *Operator) ensureInstallPlan(logger *logrus.Entry, namespace string, gen int, subs []*v1alpha1.Subscription, installPlanApproval v1alpha1.Approval, steps []*v1alpha1.Step, bundleLookups []v1alpha1.BundleLookup) (*corev1.ObjectReference, error) {
+func (o *Operator) ensureInstallPlan(ctx context.Context, logger *logrus.Entry, namespace string, gen int, subs []*v1alpha1.Subscription, installPlanApproval v1alpha1.Approval, steps []*v1alpha1.Step, bundleLookups []v1alpha1.BundleLookup) (*corev1.ObjectReference, error) {
 	if len(steps) == 0 && len(bundleLookups) == 0 {
 		return nil, nil
 	}
 
 	// Check if any existing installplans are creating the same resources
-	installPlans, err := o.listInstallPlans(namespace)
+	installPlans, err := o.listInstallPlans(ctx, namespace)
 	if err != nil {
 		return nil, err
 	}
@@ -1175,10 +1457,10 @@ func (o *Operator) ensureInstallPlan(logger *logrus.Entry, namespace string, gen
 	}
 	logger.Warn("no installplan found with matching generation, creating new one")
 
-	return o.createInstallPlan(namespace, gen, subs, installPlanApproval, steps, bundleLookups)
+	return o.createInstallPlan(ctx, namespace, gen, subs, installPlanApproval, steps, bundleLookups)
 }
 
-func (o *Operator) createInstallPlan(namespace string, gen int, subs []*v1alpha1.Subscription, installPlanApproval v1alpha1.Approval, steps []*v1alpha1.Step, bundleLookups []v1alpha1.BundleLookup) (*corev1.ObjectReference, error) {
+func (o *Operator) createInstallPlan(ctx context.Context, namespace string, gen int, subs []*v1alpha1.Subscription, installPlanApproval v1alpha1.Approval, steps []*v1alpha1.Step, bundleLookups []v1alpha1.BundleLookup) (*corev1.ObjectReference, error) {
 	if len(steps) == 0 && len(bundleLookups) == 0 {
 		return nil, nil
 	}
@@ -1203,10 +1485,24 @@ func (o *Operator) createInstallPlan(namespace string, gen int, subs []*v1alpha1
 	if installPlanApproval == v1alpha1.ApprovalManual {
 		phase = v1alpha1.InstallPlanPhaseRequiresApproval
 	}
+	annotations := dryRunAnnotations(subs)
+	for k, v := range storageMigrationAnnotations(subs) {
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[k] = v
+	}
+	if provenance := o.catalogProvenanceAnnotation(o.logger.WithField("namespace", namespace), steps, bundleLookups); provenance != "" {
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[CatalogProvenanceAnnotationKey] = provenance
+	}
 	ip := &v1alpha1.InstallPlan{
 		ObjectMeta: metav1.ObjectMeta{
 			GenerateName: "install-",
 			Namespace:    namespace,
+			Annotations:  annotations,
 		},
 		Spec: v1alpha1.InstallPlanSpec{
 			ClusterServiceVersionNames: csvNames,
@@ -1218,8 +1514,9 @@ func (o *Operator) createInstallPlan(namespace string, gen int, subs []*v1alpha1
 	for _, sub := range subs {
 		ownerutil.AddNonBlockingOwner(ip, sub)
 	}
+	setPropagatedLabels(ip, propagatedLabels(subs))
 
-	res, err := o.client.OperatorsV1alpha1().InstallPlans(namespace).Create(context.TODO(), ip, metav1.CreateOptions{})
+	res, err := o.client.OperatorsV1alpha1().InstallPlans(namespace).Create(ctx, ip, metav1.CreateOptions{})
 	if err != nil {
 		return nil, err
 	}
@@ -1230,7 +1527,7 @@ func (o *Operator) createInstallPlan(namespace string, gen int, subs []*v1alpha1
 		CatalogSources: catalogSources,
 		BundleLookups:  bundleLookups,
 	}
-	res, err = o.client.OperatorsV1alpha1().InstallPlans(namespace).UpdateStatus(context.TODO(), res, metav1.UpdateOptions{})
+	res, err = o.client.OperatorsV1alpha1().InstallPlans(namespace).UpdateStatus(ctx, res, metav1.UpdateOptions{})
 	if err != nil {
 		return nil, err
 	}
@@ -1279,7 +1576,7 @@ func (o *Operator) removeSubsCond(subs []*v1alpha1.Subscription, condType v1alph
 	return subList
 }
 
-func (o *Operator) updateSubscriptionStatuses(subs []*v1alpha1.Subscription) ([]*v1alpha1.Subscription, error) {
+func (o *Operator) updateSubscriptionStatuses(ctx context.Context, subs []*v1alpha1.Subscription) ([]*v1alpha1.Subscription, error) {
 	var (
 		errs       []error
 		mu         sync.Mutex
@@ -1295,13 +1592,13 @@ func (o *Operator) updateSubscriptionStatuses(subs []*v1alpha1.Subscription) ([]
 
 			update := func() error {
 				// Update the status of the latest revision
-				latest, err := o.client.OperatorsV1alpha1().Subscriptions(sub.GetNamespace()).Get(context.TODO(), sub.GetName(), getOpts)
+				latest, err := o.client.OperatorsV1alpha1().Subscriptions(sub.GetNamespace()).Get(ctx, sub.GetName(), getOpts)
 				if err != nil {
 					return err
 				}
 				latest.Status = sub.Status
 				*sub = *latest
-				_, err = o.client.OperatorsV1alpha1().Subscriptions(sub.Namespace).UpdateStatus(context.TODO(), latest, updateOpts)
+				_, err = o.client.OperatorsV1alpha1().Subscriptions(sub.Namespace).UpdateStatus(ctx, latest, updateOpts)
 				return err
 			}
 			if err := retry.RetryOnConflict(retry.DefaultRetry, update); err != nil {
@@ -1326,7 +1623,7 @@ type UnpackedBundleReference struct {
 }
 
 // unpackBundles makes one walk through the bundlelookups and attempts to progress them
-func (o *Operator) unpackBundles(plan *v1alpha1.InstallPlan) (bool, *v1alpha1.InstallPlan, error) {
+func (o *Operator) unpackBundles(ctx context.Context, plan *v1alpha1.InstallPlan) (bool, *v1alpha1.InstallPlan, error) {
 	out := plan.DeepCopy()
 	unpacked := true
 
@@ -1417,20 +1714,70 @@ func (o *Operator) unpackBundles(plan *v1alpha1.InstallPlan) (bool, *v1alpha1.In
 // gcInstallPlans garbage collects installplans that are too old
 // installplans are ownerrefd to all subscription inputs, so they will not otherwise
 // be GCd unless all inputs have been deleted.
-func (o *Operator) gcInstallPlans(log logrus.FieldLogger, namespace string) {
+// The number of installplans kept and their maximum age are set by the retention policy of the cluster OLMConfig.
+// Installplans referenced by subscriptions are always kept.
+func (o *Operator) gcInstallPlans(ctx context.Context, log logrus.FieldLogger, namespace string) {
 	allIps, err := o.lister.OperatorsV1alpha1().InstallPlanLister().InstallPlans(namespace).List(labels.Everything())
 	if err != nil {
 		log.Warn("unable to list installplans for GC")
 	}
 
-	if len(allIps) <= maxInstallPlanCount {
+	referenced, err := o.referencedInstallPlans(namespace)
+	if err != nil {
+		log.WithError(err).Warn("unable to list subscriptions for installplan GC")
 		return
 	}
+	retention := o.installPlanRetention(log)
+
+	// referenced installplans count towards the number of installplans kept
+	candidates := make([]*v1alpha1.InstallPlan, 0, len(allIps))
+	for _, ip := range allIps {
+		if _, ok := referenced[ip.GetName()]; !ok {
+			candidates = append(candidates, ip)
+		}
+	}
+	maxCount := retention.Count - (len(allIps) - len(candidates))
+	if maxCount < 0 {
+		maxCount = 0
+	}
+
+	toDelete := expiredInstallPlans(candidates, o.clock.Now(), retention.MaxAge)
+	if len(toDelete) > maxDeletesPerSweep {
+		toDelete = toDelete[:maxDeletesPerSweep]
+	}
+	if len(toDelete) > 0 {
+		expired := make(map[string]struct{}, len(toDelete))
+		for _, ip := range toDelete {
+			expired[ip.GetName()] = struct{}{}
+		}
+		remaining := make([]*v1alpha1.InstallPlan, 0, len(candidates))
+		for _, ip := range candidates {
+			if _, ok := expired[ip.GetName()]; !ok {
+				remaining = append(remaining, ip)
+			}
+		}
+		candidates = remaining
+	}
+	toDelete = append(toDelete, installPlansOverCount(candidates, maxCount)...)
+
+	for _, i := range toDelete {
+		if err := o.client.OperatorsV1alpha1().InstallPlans(namespace).Delete(ctx, i.GetName(), metav1.DeleteOptions{}); err != nil {
+			log.WithField("deleting", i.GetName()).WithError(err).Warn("error GCing old installplan - may have already been deleted")
+		}
+	}
+}
+
+// installPlansOverCount returns the oldest installplans among the given ones, so that at most maxCount of them are
+// left once they are deleted.
+func installPlansOverCount(allIps []*v1alpha1.InstallPlan, maxCount int) []*v1alpha1.InstallPlan {
+	if len(allIps) <= maxCount {
+		return nil
+	}
 
 	// we only consider maxDeletesPerSweep more than the allowed number of installplans for delete at one time
 	ips := allIps
-	if len(ips) > maxInstallPlanCount+maxDeletesPerSweep {
-		ips = allIps[:maxInstallPlanCount+maxDeletesPerSweep]
+	if len(ips) > maxCount+maxDeletesPerSweep {
+		ips = allIps[:maxCount+maxDeletesPerSweep]
 	}
 
 	byGen := map[int][]*v1alpha1.InstallPlan{}
@@ -1454,12 +1801,12 @@ func (o *Operator) gcInstallPlans(log logrus.FieldLogger, namespace string) {
 	for _, i := range gens {
 		g := byGen[i]
 
-		if len(ips)-len(toDelete) <= maxInstallPlanCount {
+		if len(ips)-len(toDelete) <= maxCount {
 			break
 		}
 
 		// if removing all installplans at this generation doesn't dip below the max, safe to delete all of them
-		if len(ips)-len(toDelete)-len(g) >= maxInstallPlanCount {
+		if len(ips)-len(toDelete)-len(g) >= maxCount {
 			toDelete = append(toDelete, g...)
 			continue
 		}
@@ -1475,17 +1822,13 @@ func (o *Operator) gcInstallPlans(log logrus.FieldLogger, namespace string) {
 			// final fallback to lexicographic sort, in case many installplans are created with the same timestamp
 			return g[i].GetName() < g[j].GetName()
 		})
-		toDelete = append(toDelete, g[:len(ips)-len(toDelete)-maxInstallPlanCount]...)
+		toDelete = append(toDelete, g[:len(ips)-len(toDelete)-maxCount]...)
 	}
 
-	for _, i := range toDelete {
-		if err := o.client.OperatorsV1alpha1().InstallPlans(namespace).Delete(context.TODO(), i.GetName(), metav1.DeleteOptions{}); err != nil {
-			log.WithField("deleting", i.GetName()).WithError(err).Warn("error GCing old installplan - may have already been deleted")
-		}
-	}
+	return toDelete
 }
 
-func (o *Operator) syncInstallPlans(obj interface{}) (syncError error) {
+func (o *Operator) syncInstallPlans(ctx context.Context, obj interface{}) (syncError error) {
 	plan, ok := obj.(*v1alpha1.InstallPlan)
 	if !ok {
 		o.logger.Debugf("wrong type: %#v", obj)
@@ -1519,7 +1862,7 @@ func (o *Operator) syncInstallPlans(obj interface{}) (syncError error) {
 		// Set status condition/message and retry sync if any error
 		ipFailError := fmt.Errorf("attenuated service account query failed - %v", err)
 		logger.Infof(ipFailError.Error())
-		_, err := o.setInstallPlanInstalledCond(out, v1alpha1.InstallPlanReasonInstallCheckFailed, err.Error(), logger)
+		_, err := o.setInstallPlanInstalledCond(ctx, out, v1alpha1.InstallPlanReasonInstallCheckFailed, err.Error(), logger)
 		if err != nil {
 			syncError = err
 			return
@@ -1537,7 +1880,7 @@ func (o *Operator) syncInstallPlans(obj interface{}) (syncError error) {
 	// InstallPlanInstalledCheckFailed must make sure that either this assumption is not breached, or the condition being set elsewhere
 	// is not being unset here unintentionally.
 	if cond := out.Status.GetCondition(v1alpha1.InstallPlanInstalled); cond.Reason == v1alpha1.InstallPlanReasonInstallCheckFailed {
-		plan, err = o.setInstallPlanInstalledCond(out, v1alpha1.InstallPlanConditionReason(corev1.ConditionUnknown), "", logger)
+		plan, err = o.setInstallPlanInstalledCond(ctx, out, v1alpha1.InstallPlanConditionReason(corev1.ConditionUnknown), "", logger)
 		if err != nil {
 			syncError = err
 			return
@@ -1549,7 +1892,7 @@ func (o *Operator) syncInstallPlans(obj interface{}) (syncError error) {
 		out.Status.AttenuatedServiceAccountRef = ref
 
 		if !reflect.DeepEqual(plan, out) {
-			if _, updateErr := o.client.OperatorsV1alpha1().InstallPlans(out.GetNamespace()).UpdateStatus(context.TODO(), out, metav1.UpdateOptions{}); updateErr != nil {
+			if _, updateErr := o.client.OperatorsV1alpha1().InstallPlans(out.GetNamespace()).UpdateStatus(ctx, out, metav1.UpdateOptions{}); updateErr != nil {
 				syncError = fmt.Errorf("failed to attach attenuated ServiceAccount to status - %v", updateErr)
 				return
 			}
@@ -1562,7 +1905,7 @@ func (o *Operator) syncInstallPlans(obj interface{}) (syncError error) {
 	// Attempt to unpack bundles before installing
 	// Note: This should probably use the attenuated client to prevent users from resolving resources they otherwise don't have access to.
 	if len(plan.Status.BundleLookups) > 0 {
-		unpacked, out, err := o.unpackBundles(plan)
+		unpacked, out, err := o.unpackBundles(ctx, plan)
 		if err != nil {
 			// Retry sync if non-fatal error
 			syncError = fmt.Errorf("bundle unpacking failed: %v", err)
@@ -1571,7 +1914,7 @@ func (o *Operator) syncInstallPlans(obj interface{}) (syncError error) {
 
 		if !reflect.DeepEqual(plan.Status, out.Status) {
 			logger.Warnf("status not equal, updating...")
-			if _, err := o.client.OperatorsV1alpha1().InstallPlans(out.GetNamespace()).UpdateStatus(context.TODO(), out, metav1.UpdateOptions{}); err != nil {
+			if _, err := o.client.OperatorsV1alpha1().InstallPlans(out.GetNamespace()).UpdateStatus(ctx, out, metav1.UpdateOptions{}); err != nil {
 				syncError = fmt.Errorf("failed to update installplan bundle lookups: %v", err)
 			}
 
@@ -1586,7 +1929,7 @@ func (o *Operator) syncInstallPlans(obj interface{}) (syncError error) {
 			// Mark the InstallPlan as failed for a fatal bundle unpack error
 			logger.Infof("%v", err)
 
-			if err := o.transitionInstallPlanToFailed(plan, logger, v1alpha1.InstallPlanReasonInstallCheckFailed, err.Error()); err != nil {
+			if err := o.transitionInstallPlanToFailed(ctx, plan, logger, bundleLookupFailedReason(cond.Reason), err.Error()); err != nil {
 				// retry for failure to update status
 				syncError = err
 				return
@@ -1610,7 +1953,67 @@ func (o *Operator) syncInstallPlans(obj interface{}) (syncError error) {
 		}
 	}
 
-	outInstallPlan, syncError := transitionInstallPlanState(logger.Logger, o, *plan, o.now(), o.installPlanTimeout)
+	// Check plans before anything is installed
+	in := plan
+	if plan.Status.Phase == v1alpha1.InstallPlanPhaseRequiresApproval || (plan.Status.Phase == v1alpha1.InstallPlanPhaseInstalling && plan.Status.StartTime == nil) {
+		// Reject bundles failing the required validators, before approval is even asked for
+		var rejected bool
+		if plan, rejected, syncError = o.withBundleValidation(logger, plan); syncError != nil {
+			return
+		}
+		if rejected && !isDryRun(plan) {
+			cond := plan.Status.GetCondition(InstallPlanBundleValidation)
+			if syncError = o.transitionInstallPlanToFailed(ctx, plan, logger, v1alpha1.InstallPlanReasonInstallCheckFailed, fmt.Sprintf("bundle validation failed: %s", cond.Message)); syncError != nil {
+				return
+			}
+			o.requeueSubscriptionForInstallPlan(plan, logger)
+			return
+		}
+
+		// Surface conflicts with webhooks of other operators
+		if plan, syncError = o.withWebhookConflicts(logger, plan); syncError != nil {
+			return
+		}
+
+		// Migrate custom resources off the stored versions the CRDs of opted-in plans remove
+		if plan.Status.Phase == v1alpha1.InstallPlanPhaseInstalling && migratesStorageVersions(plan) && !isDryRun(plan) {
+			if plan, syncError = o.withStorageVersionMigration(ctx, logger, plan); syncError != nil {
+				return
+			}
+		}
+
+		// Hold plans updating CRDs incompatibly, instead of failing them midway
+		var incompatible bool
+		if plan, incompatible, syncError = o.withCRDCompatibility(ctx, logger, plan); syncError != nil {
+			return
+		}
+		if incompatible && plan.Status.Phase == v1alpha1.InstallPlanPhaseInstalling && !isDryRun(plan) {
+			if plan != in {
+				if _, err := o.client.OperatorsV1alpha1().InstallPlans(plan.GetNamespace()).UpdateStatus(ctx, plan, metav1.UpdateOptions{}); err != nil {
+					syncError = fmt.Errorf("failed to update installplan CRD compatibility status: %v", err)
+					return
+				}
+			}
+			logger.Info("installplan updates CRDs incompatibly, holding")
+			syncError = o.ipQueueSet.RequeueAfter(plan.GetNamespace(), plan.GetName(), crdCompatibilityRecheckInterval)
+			return
+		}
+
+		// Plans requesting a dry-run are validated against the cluster but held until the request is withdrawn
+		if isDryRun(plan) {
+			if plan, syncError = o.withDryRun(ctx, logger, plan); syncError != nil {
+				return
+			}
+			if plan != in {
+				if _, err := o.client.OperatorsV1alpha1().InstallPlans(plan.GetNamespace()).UpdateStatus(ctx, plan, metav1.UpdateOptions{}); err != nil {
+					syncError = fmt.Errorf("failed to update installplan dry-run status: %v", err)
+				}
+			}
+			return
+		}
+	}
+
+	outInstallPlan, syncError := transitionInstallPlanState(ctx, logger.Logger, o, *plan, o.now(), o.installPlanTimeout)
 
 	if syncError != nil {
 		logger = logger.WithField("syncError", syncError)
@@ -1623,7 +2026,7 @@ func (o *Operator) syncInstallPlans(obj interface{}) (syncError error) {
 	defer o.requeueSubscriptionForInstallPlan(plan, logger)
 
 	// Update InstallPlan with status of transition. Log errors if we can't write them to the status.
-	if _, err := o.client.OperatorsV1alpha1().InstallPlans(plan.GetNamespace()).UpdateStatus(context.TODO(), outInstallPlan, metav1.UpdateOptions{}); err != nil {
+	if _, err := o.client.OperatorsV1alpha1().InstallPlans(plan.GetNamespace()).UpdateStatus(ctx, outInstallPlan, metav1.UpdateOptions{}); err != nil {
 		logger = logger.WithField("updateError", err.Error())
 		updateErr := errors.New("error updating InstallPlan status: " + err.Error())
 		if syncError == nil {
@@ -1648,7 +2051,7 @@ func hasBundleLookupFailureCondition(plan *v1alpha1.InstallPlan) (bool, *v1alpha
 	return false, nil
 }
 
-func (o *Operator) transitionInstallPlanToFailed(plan *v1alpha1.InstallPlan, logger logrus.FieldLogger, reason v1alpha1.InstallPlanConditionReason, message string) error {
+func (o *Operator) transitionInstallPlanToFailed(ctx context.Context, plan *v1alpha1.InstallPlan, logger logrus.FieldLogger, reason v1alpha1.InstallPlanConditionReason, message string) error {
 	now := o.now()
 	out := plan.DeepCopy()
 	out.Status.SetCondition(v1alpha1.ConditionFailed(v1alpha1.InstallPlanInstalled,
@@ -1656,7 +2059,7 @@ func (o *Operator) transitionInstallPlanToFailed(plan *v1alpha1.InstallPlan, log
 	out.Status.Phase = v1alpha1.InstallPlanPhaseFailed
 
 	logger.Info("transitioning InstallPlan to failed")
-	_, err := o.client.OperatorsV1alpha1().InstallPlans(plan.GetNamespace()).UpdateStatus(context.TODO(), out, metav1.UpdateOptions{})
+	_, err := o.client.OperatorsV1alpha1().InstallPlans(plan.GetNamespace()).UpdateStatus(ctx, out, metav1.UpdateOptions{})
 	if err == nil {
 		return nil
 	}
@@ -1686,10 +2089,10 @@ func (o *Operator) requeueSubscriptionForInstallPlan(plan *v1alpha1.InstallPlan,
 	}
 }
 
-func (o *Operator) setInstallPlanInstalledCond(ip *v1alpha1.InstallPlan, reason v1alpha1.InstallPlanConditionReason, message string, logger *logrus.Entry) (*v1alpha1.InstallPlan, error) {
+func (o *Operator) setInstallPlanInstalledCond(ctx context.Context, ip *v1alpha1.InstallPlan, reason v1alpha1.InstallPlanConditionReason, message string, logger *logrus.Entry) (*v1alpha1.InstallPlan, error) {
 	now := o.now()
 	ip.Status.SetCondition(v1alpha1.ConditionFailed(v1alpha1.InstallPlanInstalled, reason, message, &now))
-	outIP, err := o.client.OperatorsV1alpha1().InstallPlans(ip.GetNamespace()).UpdateStatus(context.TODO(), ip, metav1.UpdateOptions{})
+	outIP, err := o.client.OperatorsV1alpha1().InstallPlans(ip.GetNamespace()).UpdateStatus(ctx, ip, metav1.UpdateOptions{})
 	if err != nil {
 		logger = logger.WithField("updateError", err.Error())
 		logger.Errorf("error updating InstallPlan status")
@@ -1699,12 +2102,12 @@ func (o *Operator) setInstallPlanInstalledCond(ip *v1alpha1.InstallPlan, reason
 }
 
 type installPlanTransitioner interface {
-	ExecutePlan(*v1alpha1.InstallPlan) error
+	ExecutePlan(context.Context, *v1alpha1.InstallPlan) error
 }
 
 var _ installPlanTransitioner = &Operator{}
 
-func transitionInstallPlanState(log logrus.FieldLogger, transitioner installPlanTransitioner, in v1alpha1.InstallPlan, now metav1.Time, timeout time.Duration) (*v1alpha1.InstallPlan, error) {
+func transitionInstallPlanState(ctx context.Context, log logrus.FieldLogger, transitioner installPlanTransitioner, in v1alpha1.InstallPlan, now metav1.Time, timeout time.Duration) (*v1alpha1.InstallPlan, error) {
 	out := in.DeepCopy()
 
 	switch in.Status.Phase {
@@ -1723,8 +2126,15 @@ func transitionInstallPlanState(log logrus.FieldLogger, transitioner installPlan
 			out.Status.StartTime = &now
 		}
 		log.Debug("attempting to install")
-		if err := transitioner.ExecutePlan(out); err != nil {
-			if now.Sub(out.Status.StartTime.Time) >= timeout {
+		if err := transitioner.ExecutePlan(ctx, out); err != nil {
+			var crdErr crdNotEstablishedError
+			if errors.As(err, &crdErr) {
+				// The CRD already had its own timeout to be established
+				out.Status.SetCondition(v1alpha1.ConditionFailed(v1alpha1.InstallPlanInstalled,
+					v1alpha1.InstallPlanConditionReason(reasons.InstallPlanCRDNotEstablished), err.Error(), &now))
+				out.Status.Phase = v1alpha1.InstallPlanPhaseFailed
+				out.Status.Message = err.Error()
+			} else if now.Sub(out.Status.StartTime.Time) >= timeout {
 				out.Status.SetCondition(v1alpha1.ConditionFailed(v1alpha1.InstallPlanInstalled,
 					v1alpha1.InstallPlanReasonComponentFailed, err.Error(), &now))
 				out.Status.Phase = v1alpha1.InstallPlanPhaseFailed
@@ -1746,7 +2156,7 @@ func transitionInstallPlanState(log logrus.FieldLogger, transitioner installPlan
 }
 
 // Validate all existing served versions against new CRD's validation (if changed)
-func validateV1CRDCompatibility(dynamicClient dynamic.Interface, oldCRD *apiextensionsv1.CustomResourceDefinition, newCRD *apiextensionsv1.CustomResourceDefinition) error {
+func validateV1CRDCompatibility(ctx context.Context, dynamicClient dynamic.Interface, oldCRD *apiextensionsv1.CustomResourceDefinition, newCRD *apiextensionsv1.CustomResourceDefinition) error {
 	logrus.Debugf("Comparing %#v to %#v", oldCRD.Spec.Versions, newCRD.Spec.Versions)
 
 	// If validation schema is unchanged, return right away
@@ -1769,7 +2179,7 @@ func validateV1CRDCompatibility(dynamicClient dynamic.Interface, oldCRD *apiexte
 	for _, version := range oldCRD.Spec.Versions {
 		if version.Served {
 			gvr := schema.GroupVersionResource{Group: oldCRD.Spec.Group, Version: version.Name, Resource: oldCRD.Spec.Names.Plural}
-			err := validateExistingCRs(dynamicClient, gvr, convertedCRD)
+			err := validateExistingCRs(ctx, dynamicClient, gvr, convertedCRD)
 			if err != nil {
 				return err
 			}
@@ -1781,7 +2191,7 @@ func validateV1CRDCompatibility(dynamicClient dynamic.Interface, oldCRD *apiexte
 }
 
 // Validate all existing served versions against new CRD's validation (if changed)
-func validateV1Beta1CRDCompatibility(dynamicClient dynamic.Interface, oldCRD *apiextensionsv1beta1.CustomResourceDefinition, newCRD *apiextensionsv1beta1.CustomResourceDefinition) error {
+func validateV1Beta1CRDCompatibility(ctx context.Context, dynamicClient dynamic.Interface, oldCRD *apiextensionsv1beta1.CustomResourceDefinition, newCRD *apiextensionsv1beta1.CustomResourceDefinition) error {
 	logrus.Debugf("Comparing %#v to %#v", oldCRD.Spec.Validation, newCRD.Spec.Validation)
 
 	// TODO return early of all versions are equal
@@ -1792,7 +2202,7 @@ func validateV1Beta1CRDCompatibility(dynamicClient dynamic.Interface, oldCRD *ap
 	for _, version := range oldCRD.Spec.Versions {
 		if version.Served {
 			gvr := schema.GroupVersionResource{Group: oldCRD.Spec.Group, Version: version.Name, Resource: oldCRD.Spec.Names.Plural}
-			err := validateExistingCRs(dynamicClient, gvr, convertedCRD)
+			err := validateExistingCRs(ctx, dynamicClient, gvr, convertedCRD)
 			if err != nil {
 				return err
 			}
@@ -1801,7 +2211,7 @@ func validateV1Beta1CRDCompatibility(dynamicClient dynamic.Interface, oldCRD *ap
 
 	if oldCRD.Spec.Version != "" {
 		gvr := schema.GroupVersionResource{Group: oldCRD.Spec.Group, Version: oldCRD.Spec.Version, Resource: oldCRD.Spec.Names.Plural}
-		err := validateExistingCRs(dynamicClient, gvr, convertedCRD)
+		err := validateExistingCRs(ctx, dynamicClient, gvr, convertedCRD)
 		if err != nil {
 			return err
 		}
@@ -1810,9 +2220,9 @@ func validateV1Beta1CRDCompatibility(dynamicClient dynamic.Interface, oldCRD *ap
 	return nil
 }
 
-func validateExistingCRs(dynamicClient dynamic.Interface, gvr schema.GroupVersionResource, newCRD *apiextensions.CustomResourceDefinition) error {
+func validateExistingCRs(ctx context.Context, dynamicClient dynamic.Interface, gvr schema.GroupVersionResource, newCRD *apiextensions.CustomResourceDefinition) error {
 	// make dynamic client
-	crList, err := dynamicClient.Resource(gvr).List(context.TODO(), metav1.ListOptions{})
+	crList, err := dynamicClient.Resource(gvr).List(ctx, metav1.ListOptions{})
 	if err != nil {
 		return fmt.Errorf("error listing resources in GroupVersionResource %#v: %s", gvr, err)
 	}
@@ -1853,24 +2263,17 @@ func (wr *warningRecorder) PopWarnings() []string {
 }
 
 // ExecutePlan applies a planned InstallPlan to a namespace.
-func (o *Operator) ExecutePlan(plan *v1alpha1.InstallPlan) error {
+func (o *Operator) ExecutePlan(ctx context.Context, plan *v1alpha1.InstallPlan) error {
 	if plan.Status.Phase != v1alpha1.InstallPlanPhaseInstalling {
 		panic("attempted to install a plan that wasn't in the installing phase")
 	}
 
-	namespace := plan.GetNamespace()
-
-	// Get the set of initial installplan csv names
-	initialCSVNames := getCSVNameSet(plan)
 	// Get pre-existing CRD owners to make decisions about applying resolved CSVs
-	existingCRDOwners, err := o.getExistingAPIOwners(plan.GetNamespace())
+	existingCRDOwners, err := o.getExistingAPIOwners(ctx, plan.GetNamespace())
 	if err != nil {
 		return err
 	}
 
-	var wr warningRecorder
-	factory := o.clientFactory.WithConfigTransformer(clients.SetWarningHandler(&wr))
-
 	// Does the namespace have an operator group that specifies a user defined
 	// service account? If so, then we should use a scoped client for plan
 	// execution.
@@ -1879,455 +2282,506 @@ func (o *Operator) ExecutePlan(plan *v1alpha1.InstallPlan) error {
 		o.logger.Errorf("failed to get a client for plan execution: %v", err)
 		return err
 	}
-	attenuatedFactory := factory.WithConfigTransformer(attenuate)
-	kubeclient, err := attenuatedFactory.NewOperatorClient()
-	if err != nil {
-		o.logger.Errorf("failed to get a client for plan execution: %v", err)
-		return err
+
+	x := &planExecution{
+		plan: plan,
+		// Get the set of initial installplan csv names
+		initialCSVNames:   getCSVNameSet(plan),
+		existingCRDOwners: existingCRDOwners,
+		manifests:         newManifestResolver(plan.GetNamespace(), o.lister.CoreV1().ConfigMapLister(), o.logger),
+		discoveryQuerier:  newDiscoveryQuerier(o.opClient.KubernetesInterface().Discovery()),
 	}
-	crclient, err := attenuatedFactory.NewKubernetesClient()
+
+	waves := stepWaves(plan.Status.Plan)
+	executors, err := o.newStepExecutors(plan, attenuate, x.manifests, waves)
 	if err != nil {
 		o.logger.Errorf("failed to get a client for plan execution: %v", err)
 		return err
 	}
-	dynamicClient, err := attenuatedFactory.NewDynamicClient()
-	if err != nil {
-		o.logger.Errorf("failed to get a client for plan execution: %v", err)
-		return err
+
+	errs := make([]error, len(plan.Status.Plan))
+	for _, wave := range waves {
+		var wg sync.WaitGroup
+		for _, chain := range wave {
+			e := <-executors
+			wg.Add(1)
+			go func(chain []int, e *stepExecutor) {
+				defer func() {
+					executors <- e
+					wg.Done()
+				}()
+				for _, i := range chain {
+					if errs[i] = o.executeStep(ctx, x, e, i); errs[i] != nil {
+						return
+					}
+				}
+			}(chain, e)
+		}
+		wg.Wait()
+
+		// Report the error of the first failed step in plan order, and don't apply the next waves
+		for _, err := range errs {
+			if err != nil {
+				return err
+			}
+		}
+
+		// Don't apply the custom resources and CSVs of the next waves until the CRDs are established and served
+		if stepWave(plan.Status.Plan[wave[0][0]]) == crdWave {
+			if waiting, err := o.awaitCRDs(ctx, plan); err != nil || waiting {
+				return err
+			}
+		}
 	}
 
-	ensurer := newStepEnsurer(kubeclient, crclient, dynamicClient)
-	r := newManifestResolver(plan.GetNamespace(), o.lister.CoreV1().ConfigMapLister(), o.logger)
+	// Loop over one final time to check and see if everything is good.
+	for _, step := range plan.Status.Plan {
+		switch step.Status {
+		case v1alpha1.StepStatusCreated, v1alpha1.StepStatusPresent:
+		case v1alpha1.StepStatusUnsupportedResource:
+			if !isOptional(step.Resource.Kind) {
+				return nil
+			}
+		default:
+			return nil
+		}
+	}
 
-	discoveryQuerier := newDiscoveryQuerier(o.opClient.KubernetesInterface().Discovery())
+	// Delete the objects of the replaced operators that the operators replacing them no longer ship
+	e := <-executors
+	defer func() { executors <- e }()
+	return o.pruneRemovedObjects(ctx, x, e.dynamicClient)
+}
 
-	// CRDs should be installed via the default OLM (cluster-admin) client and not the scoped client specified by the AttenuatedServiceAccount
-	// the StepBuilder is currently only implemented for CRD types
-	// TODO give the StepBuilder both OLM and scoped clients when it supports new scoped types
-	builderKubeClient, err := factory.NewOperatorClient()
-	if err != nil {
-		o.logger.Errorf("failed to get a client for plan execution- %v", err)
-		return err
+// executeStep applies the i-th step of the InstallPlan being executed with the given executor.
+func (o *Operator) executeStep(ctx context.Context, x *planExecution, e *stepExecutor, i int) error {
+	err := o.auditStep(ctx, x, e, i, func() error {
+		return o.applyStep(ctx, x, e, i)
+	})
+	if k8serrors.IsNotFound(err) {
+		// Check for APIVersions present in the installplan steps that are not available on the server.
+		// The check is made via discovery per step in the plan. Transient communication failures to the api-server are handled by the plan retry logic.
+		notFoundErr := x.discoveryQuerier.WithStepResource(x.plan.Status.Plan[i].Resource).QueryForGVK()
+		if notFoundErr != nil {
+			return notFoundErr
+		}
 	}
-	builderDynamicClient, err := factory.NewDynamicClient()
+	return err
+}
+
+// applyStep applies the i-th step of the InstallPlan being executed with the given executor, and records its status.
+func (o *Operator) applyStep(ctx context.Context, x *planExecution, e *stepExecutor, i int) error {
+	plan := x.plan
+	namespace := plan.GetNamespace()
+	step := plan.Status.Plan[i]
+
+	e.wr.PopWarnings()
+	defer func() {
+		warnings := e.wr.PopWarnings()
+		if len(warnings) == 0 {
+			return
+		}
+		var obj runtime.Object
+		if ref, err := reference.GetReference(plan); err != nil {
+			o.logger.WithError(err).Warnf("error getting plan reference")
+			obj = plan
+		} else {
+			ref.FieldPath = fmt.Sprintf("status.plan[%d]", i)
+			obj = ref
+		}
+		msg := fmt.Sprintf("%d warning(s) generated during operator installation (%s %q): %s", len(warnings), step.Resource.Kind, step.Resource.Name, strings.Join(warnings, ", "))
+		if step.Resolving != "" {
+			msg = fmt.Sprintf("%d warning(s) generated during installation of operator %q (%s %q): %s", len(warnings), step.Resolving, step.Resource.Kind, step.Resource.Name, strings.Join(warnings, ", "))
+		}
+		o.recorder.Event(obj, corev1.EventTypeWarning, "AppliedWithWarnings", msg)
+		metrics.EmitInstallPlanWarning()
+	}()
+
+	doStep := true
+	s, err := e.builder.create(*step)
 	if err != nil {
-		o.logger.Errorf("failed to get a client for plan execution- %v", err)
-		return err
+		if _, ok := err.(notSupportedStepperErr); ok {
+			// stepper not implemented for this type yet
+			// stepper currently only implemented for CRD types
+			doStep = false
+		} else {
+			return err
+		}
+	}
+	if doStep {
+		status, err := s.Status(ctx)
+		if err != nil {
+			return err
+		}
+		plan.Status.Plan[i].Status = status
+		return nil
 	}
-	b := newBuilder(plan, o.lister.OperatorsV1alpha1().ClusterServiceVersionLister(), builderKubeClient, builderDynamicClient, r, o.logger)
-
-	for i, step := range plan.Status.Plan {
-		if err := func(i int, step *v1alpha1.Step) error {
-			wr.PopWarnings()
-			defer func() {
-				warnings := wr.PopWarnings()
-				if len(warnings) == 0 {
-					return
-				}
-				var obj runtime.Object
-				if ref, err := reference.GetReference(plan); err != nil {
-					o.logger.WithError(err).Warnf("error getting plan reference")
-					obj = plan
-				} else {
-					ref.FieldPath = fmt.Sprintf("status.plan[%d]", i)
-					obj = ref
-				}
-				msg := fmt.Sprintf("%d warning(s) generated during operator installation (%s %q): %s", len(warnings), step.Resource.Kind, step.Resource.Name, strings.Join(warnings, ", "))
-				if step.Resolving != "" {
-					msg = fmt.Sprintf("%d warning(s) generated during installation of operator %q (%s %q): %s", len(warnings), step.Resolving, step.Resource.Kind, step.Resource.Name, strings.Join(warnings, ", "))
-				}
-				o.recorder.Event(obj, corev1.EventTypeWarning, "AppliedWithWarnings", msg)
-				metrics.EmitInstallPlanWarning()
-			}()
 
-			doStep := true
-			s, err := b.create(*step)
+	switch step.Status {
+	case v1alpha1.StepStatusPresent, v1alpha1.StepStatusCreated, v1alpha1.StepStatusWaitingForAPI:
+		return nil
+	case v1alpha1.StepStatusUnsupportedResource:
+		if isOptional(step.Resource.Kind) {
+			// skipped, since the cluster doesn't serve its API
+			return nil
+		}
+		return v1alpha1.ErrInvalidInstallPlan
+	case v1alpha1.StepStatusUnknown, v1alpha1.StepStatusNotPresent:
+		manifest, err := x.manifests.ManifestForStep(step)
+		if err != nil {
+			return err
+		}
+		o.logger.WithFields(logrus.Fields{"kind": step.Resource.Kind, "name": step.Resource.Name}).Debug("execute resource")
+		switch step.Resource.Kind {
+		case v1alpha1.ClusterServiceVersionKind:
+			// Marshal the manifest into a CSV instance.
+			var csv v1alpha1.ClusterServiceVersion
+			err := json.Unmarshal([]byte(manifest), &csv)
 			if err != nil {
-				if _, ok := err.(notSupportedStepperErr); ok {
-					// stepper not implemented for this type yet
-					// stepper currently only implemented for CRD types
-					doStep = false
-				} else {
-					return err
-				}
+				return errorwrap.Wrapf(err, "error parsing step manifest: %s", step.Resource.Name)
 			}
-			if doStep {
-				status, err := s.Status()
+
+			// Check if the resolved CSV is in the initial set
+			if _, ok := x.initialCSVNames[csv.GetName()]; !ok {
+				// Check for pre-existing CSVs that own the same CRDs
+				competingOwners, err := competingCRDOwnersExist(plan.GetNamespace(), &csv, x.existingCRDOwners)
 				if err != nil {
-					return err
+					return errorwrap.Wrapf(err, "error checking crd owners for: %s", csv.GetName())
 				}
-				plan.Status.Plan[i].Status = status
-				return nil
-			}
 
-			switch step.Status {
-			case v1alpha1.StepStatusPresent, v1alpha1.StepStatusCreated, v1alpha1.StepStatusWaitingForAPI:
-				return nil
-			case v1alpha1.StepStatusUnknown, v1alpha1.StepStatusNotPresent:
-				manifest, err := r.ManifestForStep(step)
-				if err != nil {
-					return err
+				// TODO: decide on fail/continue logic for pre-existing dependent CSVs that own the same CRD(s)
+				if competingOwners {
+					// For now, error out
+					return fmt.Errorf("pre-existing CRD owners found for owned CRD(s) of dependent CSV %s", csv.GetName())
 				}
-				o.logger.WithFields(logrus.Fields{"kind": step.Resource.Kind, "name": step.Resource.Name}).Debug("execute resource")
-				switch step.Resource.Kind {
-				case v1alpha1.ClusterServiceVersionKind:
-					// Marshal the manifest into a CSV instance.
-					var csv v1alpha1.ClusterServiceVersion
-					err := json.Unmarshal([]byte(manifest), &csv)
-					if err != nil {
-						return errorwrap.Wrapf(err, "error parsing step manifest: %s", step.Resource.Name)
-					}
+			}
 
-					// Check if the resolved CSV is in the initial set
-					if _, ok := initialCSVNames[csv.GetName()]; !ok {
-						// Check for pre-existing CSVs that own the same CRDs
-						competingOwners, err := competingCRDOwnersExist(plan.GetNamespace(), &csv, existingCRDOwners)
-						if err != nil {
-							return errorwrap.Wrapf(err, "error checking crd owners for: %s", csv.GetName())
-						}
-
-						// TODO: decide on fail/continue logic for pre-existing dependent CSVs that own the same CRD(s)
-						if competingOwners {
-							// For now, error out
-							return fmt.Errorf("pre-existing CRD owners found for owned CRD(s) of dependent CSV %s", csv.GetName())
-						}
-					}
+			// Attempt to create the CSV, with the labels the plan propagates from its Subscriptions and the provenance
+			// of its catalog.
+			csv.SetNamespace(namespace)
+			setPropagatedLabels(&csv, install.PropagatedLabelsFor(plan))
+			setCSVCatalogProvenance(&csv, plan, step)
 
-					// Attempt to create the CSV.
-					csv.SetNamespace(namespace)
+			status, err := e.ensurer.EnsureClusterServiceVersion(ctx, &csv)
+			if err != nil {
+				return err
+			}
 
-					status, err := ensurer.EnsureClusterServiceVersion(&csv)
-					if err != nil {
-						return err
-					}
+			plan.Status.Plan[i].Status = status
 
-					plan.Status.Plan[i].Status = status
+		case v1alpha1.SubscriptionKind:
+			// Marshal the manifest into a subscription instance.
+			var sub v1alpha1.Subscription
+			err := json.Unmarshal([]byte(manifest), &sub)
+			if err != nil {
+				return errorwrap.Wrapf(err, "error parsing step manifest: %s", step.Resource.Name)
+			}
 
-				case v1alpha1.SubscriptionKind:
-					// Marshal the manifest into a subscription instance.
-					var sub v1alpha1.Subscription
-					err := json.Unmarshal([]byte(manifest), &sub)
-					if err != nil {
-						return errorwrap.Wrapf(err, "error parsing step manifest: %s", step.Resource.Name)
-					}
+			// Add the InstallPlan's name as an annotation
+			if annotations := sub.GetAnnotations(); annotations != nil {
+				annotations[generatedByKey] = plan.GetName()
+			} else {
+				sub.SetAnnotations(map[string]string{generatedByKey: plan.GetName()})
+			}
 
-					// Add the InstallPlan's name as an annotation
-					if annotations := sub.GetAnnotations(); annotations != nil {
-						annotations[generatedByKey] = plan.GetName()
-					} else {
-						sub.SetAnnotations(map[string]string{generatedByKey: plan.GetName()})
-					}
+			// Attempt to create the Subscription
+			sub.SetNamespace(namespace)
 
-					// Attempt to create the Subscription
-					sub.SetNamespace(namespace)
+			status, err := e.ensurer.EnsureSubscription(ctx, &sub)
+			if err != nil {
+				return err
+			}
 
-					status, err := ensurer.EnsureSubscription(&sub)
-					if err != nil {
-						return err
-					}
+			plan.Status.Plan[i].Status = status
 
-					plan.Status.Plan[i].Status = status
+		case resolver.BundleSecretKind:
+			var s corev1.Secret
+			err := json.Unmarshal([]byte(manifest), &s)
+			if err != nil {
+				return errorwrap.Wrapf(err, "error parsing step manifest: %s", step.Resource.Name)
+			}
 
-				case resolver.BundleSecretKind:
-					var s corev1.Secret
-					err := json.Unmarshal([]byte(manifest), &s)
-					if err != nil {
-						return errorwrap.Wrapf(err, "error parsing step manifest: %s", step.Resource.Name)
-					}
+			// add ownerrefs on the secret that point to the CSV in the bundle
+			if step.Resolving != "" {
+				owner := &v1alpha1.ClusterServiceVersion{}
+				owner.SetNamespace(plan.GetNamespace())
+				owner.SetName(step.Resolving)
+				ownerutil.AddNonBlockingOwner(&s, owner)
+			}
 
-					// add ownerrefs on the secret that point to the CSV in the bundle
-					if step.Resolving != "" {
-						owner := &v1alpha1.ClusterServiceVersion{}
-						owner.SetNamespace(plan.GetNamespace())
-						owner.SetName(step.Resolving)
-						ownerutil.AddNonBlockingOwner(&s, owner)
-					}
+			// Update UIDs on all CSV OwnerReferences
+			updated, err := o.getUpdatedOwnerReferences(ctx, s.OwnerReferences, plan.Namespace)
+			if err != nil {
+				return errorwrap.Wrapf(err, "error generating ownerrefs for secret %s", s.GetName())
+			}
+			s.SetOwnerReferences(updated)
+			s.SetNamespace(namespace)
 
-					// Update UIDs on all CSV OwnerReferences
-					updated, err := o.getUpdatedOwnerReferences(s.OwnerReferences, plan.Namespace)
-					if err != nil {
-						return errorwrap.Wrapf(err, "error generating ownerrefs for secret %s", s.GetName())
-					}
-					s.SetOwnerReferences(updated)
-					s.SetNamespace(namespace)
+			status, err := e.ensurer.EnsureBundleSecret(ctx, plan.Namespace, &s)
+			if err != nil {
+				return err
+			}
 
-					status, err := ensurer.EnsureBundleSecret(plan.Namespace, &s)
-					if err != nil {
-						return err
-					}
+			plan.Status.Plan[i].Status = status
 
-					plan.Status.Plan[i].Status = status
+		case secretKind:
+			status, err := e.ensurer.EnsureSecret(ctx, o.namespace, plan.GetNamespace(), step.Resource.Name)
+			if err != nil {
+				return err
+			}
 
-				case secretKind:
-					status, err := ensurer.EnsureSecret(o.namespace, plan.GetNamespace(), step.Resource.Name)
-					if err != nil {
-						return err
-					}
+			plan.Status.Plan[i].Status = status
 
-					plan.Status.Plan[i].Status = status
+		case clusterRoleKind:
+			// Marshal the manifest into a ClusterRole instance.
+			var cr rbacv1.ClusterRole
+			err := json.Unmarshal([]byte(manifest), &cr)
+			if err != nil {
+				return errorwrap.Wrapf(err, "error parsing step manifest: %s", step.Resource.Name)
+			}
 
-				case clusterRoleKind:
-					// Marshal the manifest into a ClusterRole instance.
-					var cr rbacv1.ClusterRole
-					err := json.Unmarshal([]byte(manifest), &cr)
-					if err != nil {
-						return errorwrap.Wrapf(err, "error parsing step manifest: %s", step.Resource.Name)
-					}
+			status, err := e.ensurer.EnsureClusterRole(ctx, &cr, step)
+			if err != nil {
+				return err
+			}
 
-					status, err := ensurer.EnsureClusterRole(&cr, step)
-					if err != nil {
-						return err
-					}
+			plan.Status.Plan[i].Status = status
 
-					plan.Status.Plan[i].Status = status
+		case clusterRoleBindingKind:
+			// Marshal the manifest into a RoleBinding instance.
+			var rb rbacv1.ClusterRoleBinding
+			err := json.Unmarshal([]byte(manifest), &rb)
+			if err != nil {
+				return errorwrap.Wrapf(err, "error parsing step manifest: %s", step.Resource.Name)
+			}
 
-				case clusterRoleBindingKind:
-					// Marshal the manifest into a RoleBinding instance.
-					var rb rbacv1.ClusterRoleBinding
-					err := json.Unmarshal([]byte(manifest), &rb)
-					if err != nil {
-						return errorwrap.Wrapf(err, "error parsing step manifest: %s", step.Resource.Name)
-					}
+			status, err := e.ensurer.EnsureClusterRoleBinding(ctx, &rb, step)
+			if err != nil {
+				return err
+			}
 
-					status, err := ensurer.EnsureClusterRoleBinding(&rb, step)
-					if err != nil {
-						return err
-					}
+			plan.Status.Plan[i].Status = status
 
-					plan.Status.Plan[i].Status = status
+		case roleKind:
+			// Marshal the manifest into a Role instance.
+			var r rbacv1.Role
+			err := json.Unmarshal([]byte(manifest), &r)
+			if err != nil {
+				return errorwrap.Wrapf(err, "error parsing step manifest: %s", step.Resource.Name)
+			}
 
-				case roleKind:
-					// Marshal the manifest into a Role instance.
-					var r rbacv1.Role
-					err := json.Unmarshal([]byte(manifest), &r)
-					if err != nil {
-						return errorwrap.Wrapf(err, "error parsing step manifest: %s", step.Resource.Name)
-					}
+			// Update UIDs on all CSV OwnerReferences
+			updated, err := o.getUpdatedOwnerReferences(ctx, r.OwnerReferences, plan.Namespace)
+			if err != nil {
+				return errorwrap.Wrapf(err, "error generating ownerrefs for role %s", r.GetName())
+			}
+			r.SetOwnerReferences(updated)
+			r.SetNamespace(namespace)
 
-					// Update UIDs on all CSV OwnerReferences
-					updated, err := o.getUpdatedOwnerReferences(r.OwnerReferences, plan.Namespace)
-					if err != nil {
-						return errorwrap.Wrapf(err, "error generating ownerrefs for role %s", r.GetName())
-					}
-					r.SetOwnerReferences(updated)
-					r.SetNamespace(namespace)
+			status, err := e.ensurer.EnsureRole(ctx, plan.Namespace, &r)
+			if err != nil {
+				return err
+			}
 
-					status, err := ensurer.EnsureRole(plan.Namespace, &r)
-					if err != nil {
-						return err
-					}
+			plan.Status.Plan[i].Status = status
 
-					plan.Status.Plan[i].Status = status
+		case roleBindingKind:
+			// Marshal the manifest into a RoleBinding instance.
+			var rb rbacv1.RoleBinding
+			err := json.Unmarshal([]byte(manifest), &rb)
+			if err != nil {
+				return errorwrap.Wrapf(err, "error parsing step manifest: %s", step.Resource.Name)
+			}
 
-				case roleBindingKind:
-					// Marshal the manifest into a RoleBinding instance.
-					var rb rbacv1.RoleBinding
-					err := json.Unmarshal([]byte(manifest), &rb)
-					if err != nil {
-						return errorwrap.Wrapf(err, "error parsing step manifest: %s", step.Resource.Name)
-					}
+			// Update UIDs on all CSV OwnerReferences
+			updated, err := o.getUpdatedOwnerReferences(ctx, rb.OwnerReferences, plan.Namespace)
+			if err != nil {
+				return errorwrap.Wrapf(err, "error generating ownerrefs for rolebinding %s", rb.GetName())
+			}
+			rb.SetOwnerReferences(updated)
+			rb.SetNamespace(namespace)
 
-					// Update UIDs on all CSV OwnerReferences
-					updated, err := o.getUpdatedOwnerReferences(rb.OwnerReferences, plan.Namespace)
-					if err != nil {
-						return errorwrap.Wrapf(err, "error generating ownerrefs for rolebinding %s", rb.GetName())
-					}
-					rb.SetOwnerReferences(updated)
-					rb.SetNamespace(namespace)
+			status, err := e.ensurer.EnsureRoleBinding(ctx, plan.Namespace, &rb)
+			if err != nil {
+				return err
+			}
 
-					status, err := ensurer.EnsureRoleBinding(plan.Namespace, &rb)
-					if err != nil {
-						return err
-					}
+			plan.Status.Plan[i].Status = status
 
-					plan.Status.Plan[i].Status = status
+		case serviceAccountKind:
+			// Marshal the manifest into a ServiceAccount instance.
+			var sa corev1.ServiceAccount
+			err := json.Unmarshal([]byte(manifest), &sa)
+			if err != nil {
+				return errorwrap.Wrapf(err, "error parsing step manifest: %s", step.Resource.Name)
+			}
 
-				case serviceAccountKind:
-					// Marshal the manifest into a ServiceAccount instance.
-					var sa corev1.ServiceAccount
-					err := json.Unmarshal([]byte(manifest), &sa)
-					if err != nil {
-						return errorwrap.Wrapf(err, "error parsing step manifest: %s", step.Resource.Name)
-					}
+			// Update UIDs on all CSV OwnerReferences
+			updated, err := o.getUpdatedOwnerReferences(ctx, sa.OwnerReferences, plan.Namespace)
+			if err != nil {
+				return errorwrap.Wrapf(err, "error generating ownerrefs for service account: %s", sa.GetName())
+			}
+			sa.SetOwnerReferences(updated)
+			sa.SetNamespace(namespace)
 
-					// Update UIDs on all CSV OwnerReferences
-					updated, err := o.getUpdatedOwnerReferences(sa.OwnerReferences, plan.Namespace)
-					if err != nil {
-						return errorwrap.Wrapf(err, "error generating ownerrefs for service account: %s", sa.GetName())
-					}
-					sa.SetOwnerReferences(updated)
-					sa.SetNamespace(namespace)
+			status, err := e.ensurer.EnsureServiceAccount(ctx, namespace, &sa)
+			if err != nil {
+				return err
+			}
 
-					status, err := ensurer.EnsureServiceAccount(namespace, &sa)
-					if err != nil {
-						return err
-					}
+			plan.Status.Plan[i].Status = status
 
-					plan.Status.Plan[i].Status = status
+		case serviceKind:
+			// Marshal the manifest into a Service instance
+			var s corev1.Service
+			err := json.Unmarshal([]byte(manifest), &s)
+			if err != nil {
+				return errorwrap.Wrapf(err, "error parsing step manifest: %s", step.Resource.Name)
+			}
 
-				case serviceKind:
-					// Marshal the manifest into a Service instance
-					var s corev1.Service
-					err := json.Unmarshal([]byte(manifest), &s)
-					if err != nil {
-						return errorwrap.Wrapf(err, "error parsing step manifest: %s", step.Resource.Name)
-					}
+			// add ownerrefs on the service that point to the CSV in the bundle
+			if step.Resolving != "" {
+				owner := &v1alpha1.ClusterServiceVersion{}
+				owner.SetNamespace(plan.GetNamespace())
+				owner.SetName(step.Resolving)
+				ownerutil.AddNonBlockingOwner(&s, owner)
+			}
 
-					// add ownerrefs on the service that point to the CSV in the bundle
-					if step.Resolving != "" {
-						owner := &v1alpha1.ClusterServiceVersion{}
-						owner.SetNamespace(plan.GetNamespace())
-						owner.SetName(step.Resolving)
-						ownerutil.AddNonBlockingOwner(&s, owner)
-					}
+			// Update UIDs on all CSV OwnerReferences
+			updated, err := o.getUpdatedOwnerReferences(ctx, s.OwnerReferences, plan.Namespace)
+			if err != nil {
+				return errorwrap.Wrapf(err, "error generating ownerrefs for service: %s", s.GetName())
+			}
+			s.SetOwnerReferences(updated)
+			s.SetNamespace(namespace)
 
-					// Update UIDs on all CSV OwnerReferences
-					updated, err := o.getUpdatedOwnerReferences(s.OwnerReferences, plan.Namespace)
-					if err != nil {
-						return errorwrap.Wrapf(err, "error generating ownerrefs for service: %s", s.GetName())
-					}
-					s.SetOwnerReferences(updated)
-					s.SetNamespace(namespace)
+			status, err := e.ensurer.EnsureService(ctx, namespace, &s)
+			if err != nil {
+				return err
+			}
 
-					status, err := ensurer.EnsureService(namespace, &s)
-					if err != nil {
-						return err
-					}
+			plan.Status.Plan[i].Status = status
 
-					plan.Status.Plan[i].Status = status
+		case configMapKind:
+			var cfg corev1.ConfigMap
+			err := json.Unmarshal([]byte(manifest), &cfg)
+			if err != nil {
+				return errorwrap.Wrapf(err, "error parsing step manifest: %s", step.Resource.Name)
+			}
 
-				case configMapKind:
-					var cfg corev1.ConfigMap
-					err := json.Unmarshal([]byte(manifest), &cfg)
-					if err != nil {
-						return errorwrap.Wrapf(err, "error parsing step manifest: %s", step.Resource.Name)
-					}
+			// add ownerrefs on the configmap that point to the CSV in the bundle
+			if step.Resolving != "" {
+				owner := &v1alpha1.ClusterServiceVersion{}
+				owner.SetNamespace(plan.GetNamespace())
+				owner.SetName(step.Resolving)
+				ownerutil.AddNonBlockingOwner(&cfg, owner)
+			}
 
-					// add ownerrefs on the configmap that point to the CSV in the bundle
-					if step.Resolving != "" {
-						owner := &v1alpha1.ClusterServiceVersion{}
-						owner.SetNamespace(plan.GetNamespace())
-						owner.SetName(step.Resolving)
-						ownerutil.AddNonBlockingOwner(&cfg, owner)
-					}
+			// Update UIDs on all CSV OwnerReferences
+			updated, err := o.getUpdatedOwnerReferences(ctx, cfg.OwnerReferences, plan.Namespace)
+			if err != nil {
+				return errorwrap.Wrapf(err, "error generating ownerrefs for configmap: %s", cfg.GetName())
+			}
+			cfg.SetOwnerReferences(updated)
+			cfg.SetNamespace(namespace)
 
-					// Update UIDs on all CSV OwnerReferences
-					updated, err := o.getUpdatedOwnerReferences(cfg.OwnerReferences, plan.Namespace)
-					if err != nil {
-						return errorwrap.Wrapf(err, "error generating ownerrefs for configmap: %s", cfg.GetName())
-					}
-					cfg.SetOwnerReferences(updated)
-					cfg.SetNamespace(namespace)
+			status, err := e.ensurer.EnsureConfigMap(ctx, plan.Namespace, &cfg)
+			if err != nil {
+				return err
+			}
 
-					status, err := ensurer.EnsureConfigMap(plan.Namespace, &cfg)
-					if err != nil {
-						return err
-					}
+			plan.Status.Plan[i].Status = status
 
-					plan.Status.Plan[i].Status = status
+		default:
+			if !isSupported(step.Resource.Kind) {
+				// Not a supported resource
+				plan.Status.Plan[i].Status = v1alpha1.StepStatusUnsupportedResource
+				return v1alpha1.ErrInvalidInstallPlan
+			}
 
-				default:
-					if !isSupported(step.Resource.Kind) {
-						// Not a supported resource
-						plan.Status.Plan[i].Status = v1alpha1.StepStatusUnsupportedResource
-						return v1alpha1.ErrInvalidInstallPlan
-					}
+			// Marshal the manifest into an unstructured object
+			dec := yaml.NewYAMLOrJSONDecoder(strings.NewReader(manifest), 10)
+			unstructuredObject := &unstructured.Unstructured{}
+			if err := dec.Decode(unstructuredObject); err != nil {
+				return errorwrap.Wrapf(err, "error decoding %s object to an unstructured object", step.Resource.Name)
+			}
 
-					// Marshal the manifest into an unstructured object
-					dec := yaml.NewYAMLOrJSONDecoder(strings.NewReader(manifest), 10)
-					unstructuredObject := &unstructured.Unstructured{}
-					if err := dec.Decode(unstructuredObject); err != nil {
-						return errorwrap.Wrapf(err, "error decoding %s object to an unstructured object", step.Resource.Name)
-					}
+			// Get the resource from the GVK.
+			gvk := unstructuredObject.GroupVersionKind()
+			r, err := o.apiresourceFromGVK(gvk)
+			if err != nil {
+				if isOptional(step.Resource.Kind) && o.apiNotServed(gvk, err) {
+					o.logger.WithFields(logrus.Fields{"kind": step.Resource.Kind, "name": step.Resource.Name}).Info("api not served, skipping optional resource")
+					plan.Status.Plan[i].Status = v1alpha1.StepStatusUnsupportedResource
+					return nil
+				}
+				return err
+			}
 
-					// Get the resource from the GVK.
-					gvk := unstructuredObject.GroupVersionKind()
-					r, err := o.apiresourceFromGVK(gvk)
-					if err != nil {
-						return err
-					}
+			// Create the GVR
+			gvr := schema.GroupVersionResource{
+				Group:    gvk.Group,
+				Version:  gvk.Version,
+				Resource: r.Name,
+			}
 
-					// Create the GVR
-					gvr := schema.GroupVersionResource{
-						Group:    gvk.Group,
-						Version:  gvk.Version,
-						Resource: r.Name,
-					}
+			if step.Resolving != "" {
+				owner := &v1alpha1.ClusterServiceVersion{}
+				owner.SetNamespace(plan.GetNamespace())
+				owner.SetName(step.Resolving)
 
-					if step.Resolving != "" {
-						owner := &v1alpha1.ClusterServiceVersion{}
-						owner.SetNamespace(plan.GetNamespace())
-						owner.SetName(step.Resolving)
-
-						if r.Namespaced {
-							// Set OwnerReferences for namespace-scoped resource
-							ownerutil.AddNonBlockingOwner(unstructuredObject, owner)
-
-							// Update UIDs on all CSV OwnerReferences
-							updated, err := o.getUpdatedOwnerReferences(unstructuredObject.GetOwnerReferences(), plan.Namespace)
-							if err != nil {
-								return errorwrap.Wrapf(err, "error generating ownerrefs for unstructured object: %s", unstructuredObject.GetName())
-							}
-
-							unstructuredObject.SetOwnerReferences(updated)
-						} else {
-							// Add owner labels to cluster-scoped resource
-							if err := ownerutil.AddOwnerLabels(unstructuredObject, owner); err != nil {
-								return err
-							}
-						}
-					}
+				if r.Namespaced {
+					// Set OwnerReferences for namespace-scoped resource
+					ownerutil.AddNonBlockingOwner(unstructuredObject, owner)
 
-					// Set up the dynamic client ResourceInterface and set ownerrefs
-					var resourceInterface dynamic.ResourceInterface
-					if r.Namespaced {
-						unstructuredObject.SetNamespace(namespace)
-						resourceInterface = dynamicClient.Resource(gvr).Namespace(namespace)
-					} else {
-						resourceInterface = dynamicClient.Resource(gvr)
+					// Update UIDs on all CSV OwnerReferences
+					updated, err := o.getUpdatedOwnerReferences(ctx, unstructuredObject.GetOwnerReferences(), plan.Namespace)
+					if err != nil {
+						return errorwrap.Wrapf(err, "error generating ownerrefs for unstructured object: %s", unstructuredObject.GetName())
 					}
 
-					// Ensure Unstructured Object
-					status, err := ensurer.EnsureUnstructuredObject(resourceInterface, unstructuredObject)
-					if err != nil {
+					unstructuredObject.SetOwnerReferences(updated)
+				} else {
+					// Add owner labels to cluster-scoped resource
+					if err := ownerutil.AddOwnerLabels(unstructuredObject, owner); err != nil {
 						return err
 					}
-
-					plan.Status.Plan[i].Status = status
 				}
-			default:
-				return v1alpha1.ErrInvalidInstallPlan
 			}
-			return nil
-		}(i, step); err != nil {
-			if k8serrors.IsNotFound(err) {
-				// Check for APIVersions present in the installplan steps that are not available on the server.
-				// The check is made via discovery per step in the plan. Transient communication failures to the api-server are handled by the plan retry logic.
-				notFoundErr := discoveryQuerier.WithStepResource(step.Resource).QueryForGVK()
-				if notFoundErr != nil {
-					return notFoundErr
+
+			// Set up the dynamic client ResourceInterface and set ownerrefs
+			var resourceInterface dynamic.ResourceInterface
+			if r.Namespaced {
+				unstructuredObject.SetNamespace(namespace)
+				resourceInterface = e.dynamicClient.Resource(gvr).Namespace(namespace)
+			} else {
+				resourceInterface = e.dynamicClient.Resource(gvr)
+			}
+
+			if step.Resource.Kind == PrometheusRuleKind {
+				if err := normalizePrometheusRule(unstructuredObject, namespace); err != nil {
+					return errorwrap.Wrapf(err, "error normalizing PrometheusRule %s", unstructuredObject.GetName())
 				}
 			}
-			return err
-		}
-	}
 
-	// Loop over one final time to check and see if everything is good.
-	for _, step := range plan.Status.Plan {
-		switch step.Status {
-		case v1alpha1.StepStatusCreated, v1alpha1.StepStatusPresent:
-		default:
-			return nil
+			// Ensure Unstructured Object
+			status, err := e.ensurer.EnsureUnstructuredObject(ctx, resourceInterface, unstructuredObject)
+			if err != nil {
+				return err
+			}
+
+			plan.Status.Plan[i].Status = status
 		}
+	default:
+		return v1alpha1.ErrInvalidInstallPlan
 	}
-
 	return nil
 }
 
 // getExistingAPIOwners creates a map of CRD names to existing owner CSVs in the given namespace
-func (o *Operator) getExistingAPIOwners(namespace string) (map[string][]string, error) {
+func (o *Operator) getExistingAPIOwners(ctx context.Context, namespace string) (map[string][]string, error) {
 	// Get a list of CSVs in the namespace
-	csvList, err := o.client.OperatorsV1alpha1().ClusterServiceVersions(namespace).List(context.TODO(), metav1.ListOptions{})
+	csvList, err := o.client.OperatorsV1alpha1().ClusterServiceVersions(namespace).List(ctx, metav1.ListOptions{})
 
 	if err != nil {
 		return nil, err
@@ -2347,12 +2801,12 @@ func (o *Operator) getExistingAPIOwners(namespace string) (map[string][]string,
 	return owners, nil
 }
 
-func (o *Operator) getUpdatedOwnerReferences(refs []metav1.OwnerReference, namespace string) ([]metav1.OwnerReference, error) {
+func (o *Operator) getUpdatedOwnerReferences(ctx context.Context, refs []metav1.OwnerReference, namespace string) ([]metav1.OwnerReference, error) {
 	updated := append([]metav1.OwnerReference(nil), refs...)
 
 	for i, owner := range refs {
 		if owner.Kind == v1alpha1.ClusterServiceVersionKind {
-			csv, err := o.client.OperatorsV1alpha1().ClusterServiceVersions(namespace).Get(context.TODO(), owner.Name, metav1.GetOptions{})
+			csv, err := o.client.OperatorsV1alpha1().ClusterServiceVersions(namespace).Get(ctx, owner.Name, metav1.GetOptions{})
 			if err != nil {
 				return nil, err
 			}
@@ -2363,8 +2817,8 @@ func (o *Operator) getUpdatedOwnerReferences(refs []metav1.OwnerReference, names
 	return updated, nil
 }
 
-func (o *Operator) listSubscriptions(namespace string) (subs []*v1alpha1.Subscription, err error) {
-	list, err := o.client.OperatorsV1alpha1().Subscriptions(namespace).List(context.TODO(), metav1.ListOptions{})
+func (o *Operator) listSubscriptions(ctx context.Context, namespace string) (subs []*v1alpha1.Subscription, err error) {
+	list, err := o.client.OperatorsV1alpha1().Subscriptions(namespace).List(ctx, metav1.ListOptions{})
 	if err != nil {
 		return
 	}
@@ -2377,8 +2831,8 @@ func (o *Operator) listSubscriptions(namespace string) (subs []*v1alpha1.Subscri
 	return
 }
 
-func (o *Operator) listInstallPlans(namespace string) (ips []*v1alpha1.InstallPlan, err error) {
-	list, err := o.client.OperatorsV1alpha1().InstallPlans(namespace).List(context.TODO(), metav1.ListOptions{})
+func (o *Operator) listInstallPlans(ctx context.Context, namespace string) (ips []*v1alpha1.InstallPlan, err error) {
+	list, err := o.client.OperatorsV1alpha1().InstallPlans(namespace).List(ctx, metav1.ListOptions{})
 	if err != nil {
 		return
 	}
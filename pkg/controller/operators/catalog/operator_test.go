@@ -25,6 +25,7 @@ import (
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
 	apiextensionsfake "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/fake"
+	extinf "k8s.io/apiextensions-apiserver/pkg/client/informers/externalversions"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -37,6 +38,7 @@ import (
 	fakedynamic "k8s.io/client-go/dynamic/fake"
 	"k8s.io/client-go/informers"
 	k8sfake "k8s.io/client-go/kubernetes/fake"
+	k8sscheme "k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
@@ -48,8 +50,10 @@ import (
 	"github.com/operator-framework/api/pkg/operators/v1alpha1"
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/api/client/clientset/versioned/fake"
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/api/client/informers/externalversions"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/api/reasons"
 	olmerrors "github.com/operator-framework/operator-lifecycle-manager/pkg/controller/errors"
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/registry"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/registry/fbc"
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/registry/grpc"
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/registry/reconciler"
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/registry/resolver"
@@ -57,6 +61,7 @@ import (
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/fakes"
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/clientfake"
 	controllerclient "github.com/operator-framework/operator-lifecycle-manager/pkg/lib/controller-runtime/client"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/imagesig"
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/operatorclient"
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/operatorlister"
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/ownerutil"
@@ -70,7 +75,7 @@ type mockTransitioner struct {
 
 var _ installPlanTransitioner = &mockTransitioner{}
 
-func (m *mockTransitioner) ExecutePlan(plan *v1alpha1.InstallPlan) error {
+func (m *mockTransitioner) ExecutePlan(ctx context.Context, plan *v1alpha1.InstallPlan) error {
 	return m.err
 }
 
@@ -127,7 +132,7 @@ func TestTransitionInstallPlan(t *testing.T) {
 		transitioner := &mockTransitioner{tt.transError}
 
 		// Attempt to transition phases.
-		out, _ := transitionInstallPlanState(logrus.New(), transitioner, *plan, now, tt.timeout)
+		out, _ := transitionInstallPlanState(context.TODO(), logrus.New(), transitioner, *plan, now, tt.timeout)
 
 		// Assert that the final phase is as expected.
 		require.Equal(t, tt.expected, out.Status.Phase)
@@ -235,7 +240,7 @@ func TestSyncInstallPlanUnhappy(t *testing.T) {
 			op, err := NewFakeOperator(ctx, namespace, []string{namespace}, withClientObjs(tt.clientObjs...))
 			require.NoError(t, err)
 
-			err = op.syncInstallPlans(tt.in)
+			err = op.syncInstallPlans(ctx, tt.in)
 			require.Equal(t, tt.err, err)
 
 			ip, err := op.client.OperatorsV1alpha1().InstallPlans(namespace).Get(ctx, tt.in.Name, metav1.GetOptions{})
@@ -296,7 +301,7 @@ func TestGCInstallPlans(t *testing.T) {
 
 		var out []v1alpha1.InstallPlan
 		for {
-			op.gcInstallPlans(logrus.New(), "ns")
+			op.gcInstallPlans(ctx, logrus.New(), "ns")
 			require.NoError(t, err)
 
 			outList, err := op.client.OperatorsV1alpha1().InstallPlans("ns").List(ctx, metav1.ListOptions{})
@@ -325,6 +330,144 @@ func TestGCInstallPlans(t *testing.T) {
 	require.NoError(t, quick.Check(f, nil))
 }
 
+func TestGCInstallPlansRetention(t *testing.T) {
+	now := time.Date(2021, time.October, 1, 12, 0, 0, 0, time.UTC)
+	ip := func(name string, gen int, phase v1alpha1.InstallPlanPhase, age time.Duration) runtime.Object {
+		return &v1alpha1.InstallPlan{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:         "ns",
+				Name:              name,
+				CreationTimestamp: metav1.NewTime(now.Add(-age)),
+			},
+			Spec:   v1alpha1.InstallPlanSpec{Generation: gen},
+			Status: v1alpha1.InstallPlanStatus{Phase: phase},
+		}
+	}
+	sub := &v1alpha1.Subscription{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "sub"},
+		Status: v1alpha1.SubscriptionStatus{
+			InstallPlanRef: &corev1.ObjectReference{Namespace: "ns", Name: "old-referenced"},
+		},
+	}
+	installPlans := []runtime.Object{
+		ip("old-referenced", 1, v1alpha1.InstallPlanPhaseComplete, 72*time.Hour),
+		ip("old-complete", 2, v1alpha1.InstallPlanPhaseComplete, 48*time.Hour),
+		ip("old-failed", 3, v1alpha1.InstallPlanPhaseFailed, 48*time.Hour),
+		ip("old-installing", 4, v1alpha1.InstallPlanPhaseInstalling, 48*time.Hour),
+		ip("complete", 5, v1alpha1.InstallPlanPhaseComplete, time.Hour),
+		ip("failed", 6, v1alpha1.InstallPlanPhaseFailed, time.Hour),
+		ip("installing", 7, v1alpha1.InstallPlanPhaseInstalling, time.Hour),
+	}
+
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		expected    []string
+	}{
+		{
+			name: "Default",
+			// only referenced installplans are kept beyond the default count
+			expected: []string{"complete", "failed", "installing", "old-installing", "old-referenced"},
+		},
+		{
+			name:        "Count",
+			annotations: map[string]string{InstallPlanRetentionCountAnnotationKey: "3"},
+			expected:    []string{"failed", "installing", "old-referenced"},
+		},
+		{
+			name:        "MaxAge",
+			annotations: map[string]string{InstallPlanRetentionMaxAgeAnnotationKey: "24h"},
+			expected:    []string{"complete", "failed", "installing", "old-installing", "old-referenced"},
+		},
+		{
+			name: "Invalid",
+			annotations: map[string]string{
+				InstallPlanRetentionCountAnnotationKey:  "0",
+				InstallPlanRetentionMaxAgeAnnotationKey: "24h",
+			},
+			expected: []string{"complete", "failed", "installing", "old-installing", "old-referenced"},
+		},
+		{
+			name: "CountAndMaxAge",
+			annotations: map[string]string{
+				InstallPlanRetentionCountAnnotationKey:  "10",
+				InstallPlanRetentionMaxAgeAnnotationKey: "24h",
+			},
+			expected: []string{"complete", "failed", "installing", "old-installing", "old-referenced"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.TODO())
+			defer cancel()
+
+			objs := append([]runtime.Object{sub.DeepCopy()}, installPlans...)
+			if tt.annotations != nil {
+				objs = append(objs, &operatorsv1.OLMConfig{
+					ObjectMeta: metav1.ObjectMeta{Name: "cluster", Annotations: tt.annotations},
+				})
+			}
+			op, err := NewFakeOperator(ctx, "ns", []string{"ns"}, withClock(utilclock.NewFakeClock(now)), withClientObjs(objs...))
+			require.NoError(t, err)
+
+			op.gcInstallPlans(ctx, logrus.New(), "ns")
+
+			out, err := op.client.OperatorsV1alpha1().InstallPlans("ns").List(ctx, metav1.ListOptions{})
+			require.NoError(t, err)
+			var names []string
+			for _, ip := range out.Items {
+				names = append(names, ip.GetName())
+			}
+			require.ElementsMatch(t, tt.expected, names)
+		})
+	}
+}
+
+func TestInstallPlanRetentionFor(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		expected    InstallPlanRetention
+		expectedErr bool
+	}{
+		{
+			name:     "Default",
+			expected: DefaultInstallPlanRetention(),
+		},
+		{
+			name: "Overrides",
+			annotations: map[string]string{
+				InstallPlanRetentionCountAnnotationKey:  "10",
+				InstallPlanRetentionMaxAgeAnnotationKey: "168h",
+			},
+			expected: InstallPlanRetention{Count: 10, MaxAge: 168 * time.Hour},
+		},
+		{
+			name:        "InvalidCount",
+			annotations: map[string]string{InstallPlanRetentionCountAnnotationKey: "-1"},
+			expected:    DefaultInstallPlanRetention(),
+			expectedErr: true,
+		},
+		{
+			name:        "InvalidMaxAge",
+			annotations: map[string]string{InstallPlanRetentionMaxAgeAnnotationKey: "week"},
+			expected:    DefaultInstallPlanRetention(),
+			expectedErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			retention, err := InstallPlanRetentionFor(tt.annotations)
+			if tt.expectedErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tt.expected, retention)
+		})
+	}
+}
+
 func TestExecutePlan(t *testing.T) {
 	namespace := "ns"
 
@@ -565,6 +708,85 @@ func TestExecutePlan(t *testing.T) {
 				modify(t, decodeFile(t, "./testdata/prometheusrule.cr.yaml", &unstructured.Unstructured{}),
 					withNamespace(namespace),
 					withOwner(csv("csv", namespace, nil, nil)),
+					withNormalizedPrometheusRule(namespace),
+				),
+			},
+			err: nil,
+		},
+		{
+			testName: "OptionalResourceSkippedWithoutAPI",
+			in: withSteps(installPlan("p", namespace, v1alpha1.InstallPlanPhaseInstalling, "csv"),
+				[]*v1alpha1.Step{
+					{
+						Resolving: "csv",
+						Resource: v1alpha1.StepResource{
+							CatalogSource:          "catalog",
+							CatalogSourceNamespace: namespace,
+							Group:                  "operators.coreos.com",
+							Version:                "v1alpha1",
+							Kind:                   "ClusterServiceVersion",
+							Name:                   "csv",
+							Manifest:               toManifest(t, csv("csv", namespace, nil, nil)),
+						},
+						Status: v1alpha1.StepStatusUnknown,
+					},
+					{
+						Resolving: "csv",
+						Resource: v1alpha1.StepResource{
+							CatalogSource:          "catalog",
+							CatalogSourceNamespace: namespace,
+							Group:                  "monitoring.coreos.com",
+							Version:                "v1",
+							Kind:                   "PrometheusRule",
+							Name:                   "rule",
+							Manifest:               toManifest(t, decodeFile(t, "./testdata/prometheusrule.cr.yaml", &unstructured.Unstructured{})),
+						},
+						Status: v1alpha1.StepStatusUnknown,
+					},
+				},
+			),
+			want: []runtime.Object{csv("csv", namespace, nil, nil)},
+			err:  nil,
+		},
+		{
+			testName: "CSVAppliedBeforeObjectsItOwns",
+			in: withSteps(installPlan("p", namespace, v1alpha1.InstallPlanPhaseInstalling, "csv"),
+				[]*v1alpha1.Step{
+					{
+						Resolving: "csv",
+						Resource: v1alpha1.StepResource{
+							CatalogSource:          "catalog",
+							CatalogSourceNamespace: namespace,
+							Group:                  "monitoring.coreos.com",
+							Version:                "v1",
+							Kind:                   "PrometheusRule",
+							Name:                   "rule",
+							Manifest:               toManifest(t, decodeFile(t, "./testdata/prometheusrule.cr.yaml", &unstructured.Unstructured{})),
+						},
+						Status: v1alpha1.StepStatusUnknown,
+					},
+					{
+						Resolving: "csv",
+						Resource: v1alpha1.StepResource{
+							CatalogSource:          "catalog",
+							CatalogSourceNamespace: namespace,
+							Group:                  "operators.coreos.com",
+							Version:                "v1alpha1",
+							Kind:                   "ClusterServiceVersion",
+							Name:                   "csv",
+							Manifest:               toManifest(t, csv("csv", namespace, nil, nil)),
+						},
+						Status: v1alpha1.StepStatusUnknown,
+					},
+				},
+			),
+			extObjs: []runtime.Object{decodeFile(t, "./testdata/prometheusrule.crd.yaml", &apiextensionsv1beta1.CustomResourceDefinition{})},
+			want: []runtime.Object{
+				csv("csv", namespace, nil, nil),
+				modify(t, decodeFile(t, "./testdata/prometheusrule.cr.yaml", &unstructured.Unstructured{}),
+					withNamespace(namespace),
+					withOwner(csv("csv", namespace, nil, nil)),
+					withNormalizedPrometheusRule(namespace),
 				),
 			},
 			err: nil,
@@ -614,7 +836,7 @@ func TestExecutePlan(t *testing.T) {
 			op, err := NewFakeOperator(ctx, namespace, []string{namespace}, withClientObjs(tt.in), withExtObjs(tt.extObjs...))
 			require.NoError(t, err)
 
-			err = op.ExecutePlan(tt.in)
+			err = op.ExecutePlan(ctx, tt.in)
 			require.Equal(t, tt.err, err)
 
 			getOpts := metav1.GetOptions{}
@@ -623,23 +845,23 @@ func TestExecutePlan(t *testing.T) {
 				var fetched runtime.Object
 				switch o := obj.(type) {
 				case *appsv1.Deployment:
-					fetched, err = op.opClient.GetDeployment(namespace, o.GetName())
+					fetched, err = op.opClient.GetDeployment(context.TODO(), namespace, o.GetName())
 				case *rbacv1.ClusterRole:
-					fetched, err = op.opClient.GetClusterRole(o.GetName())
+					fetched, err = op.opClient.GetClusterRole(context.TODO(), o.GetName())
 				case *rbacv1.Role:
-					fetched, err = op.opClient.GetRole(namespace, o.GetName())
+					fetched, err = op.opClient.GetRole(context.TODO(), namespace, o.GetName())
 				case *rbacv1.ClusterRoleBinding:
-					fetched, err = op.opClient.GetClusterRoleBinding(o.GetName())
+					fetched, err = op.opClient.GetClusterRoleBinding(context.TODO(), o.GetName())
 				case *rbacv1.RoleBinding:
-					fetched, err = op.opClient.GetRoleBinding(namespace, o.GetName())
+					fetched, err = op.opClient.GetRoleBinding(context.TODO(), namespace, o.GetName())
 				case *corev1.ServiceAccount:
-					fetched, err = op.opClient.GetServiceAccount(namespace, o.GetName())
+					fetched, err = op.opClient.GetServiceAccount(context.TODO(), namespace, o.GetName())
 				case *corev1.Secret:
-					fetched, err = op.opClient.GetSecret(namespace, o.GetName())
+					fetched, err = op.opClient.GetSecret(context.TODO(), namespace, o.GetName())
 				case *corev1.Service:
-					fetched, err = op.opClient.GetService(namespace, o.GetName())
+					fetched, err = op.opClient.GetService(context.TODO(), namespace, o.GetName())
 				case *corev1.ConfigMap:
-					fetched, err = op.opClient.GetConfigMap(namespace, o.GetName())
+					fetched, err = op.opClient.GetConfigMap(context.TODO(), namespace, o.GetName())
 				case *apiextensionsv1beta1.CustomResourceDefinition:
 					fetched, err = op.opClient.ApiextensionsInterface().ApiextensionsV1beta1().CustomResourceDefinitions().Get(context.TODO(), o.GetName(), getOpts)
 				case *apiextensionsv1.CustomResourceDefinition:
@@ -670,6 +892,12 @@ func TestExecutePlan(t *testing.T) {
 				}
 
 				require.NoError(t, err, "couldn't fetch %s %v", namespace, obj)
+				if _, ok := obj.(*unstructured.Unstructured); !ok {
+					// Typed objects are compared regardless of their type meta, which depends on how they were written
+					obj, fetched = obj.DeepCopyObject(), fetched.DeepCopyObject()
+					obj.GetObjectKind().SetGroupVersionKind(schema.GroupVersionKind{})
+					fetched.GetObjectKind().SetGroupVersionKind(schema.GroupVersionKind{})
+				}
 				require.EqualValues(t, obj, fetched)
 			}
 		})
@@ -751,7 +979,7 @@ func TestExecutePlanDynamicResources(t *testing.T) {
 			op, err := NewFakeOperator(ctx, namespace, []string{namespace}, withClientObjs(tt.in))
 			require.NoError(t, err)
 
-			err = op.ExecutePlan(tt.in)
+			err = op.ExecutePlan(ctx, tt.in)
 			require.Equal(t, tt.err, err)
 		})
 	}
@@ -790,10 +1018,23 @@ func TestSyncCatalogSources(t *testing.T) {
 			SourceType: v1alpha1.SourceTypeGrpc,
 		},
 	}
+	addressCatalog := &v1alpha1.CatalogSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "cool-catalog",
+			Namespace: "cool-namespace",
+			UID:       types.UID("catalog-uid"),
+		},
+		Spec: v1alpha1.CatalogSourceSpec{
+			Address:    "registry.example.com:50051",
+			SourceType: v1alpha1.SourceTypeGrpc,
+			Secrets:    []string{"registry-token"},
+		},
+	}
 	tests := []struct {
 		testName        string
 		namespace       string
 		catalogSource   *v1alpha1.CatalogSource
+		clientObjs      []runtime.Object
 		k8sObjs         []runtime.Object
 		configMap       *corev1.ConfigMap
 		expectedStatus  *v1alpha1.CatalogSourceStatus
@@ -899,6 +1140,28 @@ func TestSyncCatalogSources(t *testing.T) {
 			},
 			expectedError: nil,
 		},
+		{
+			testName:  "CatalogSourceWithInlineCatalog",
+			namespace: "cool-namespace",
+			catalogSource: &v1alpha1.CatalogSource{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "cool-catalog",
+					Namespace:   "cool-namespace",
+					UID:         types.UID("catalog-uid"),
+					Annotations: map[string]string{reconciler.InlineCatalogAnnotationKey: `{"schema": "olm.package", "name": "cool-package"}`},
+				},
+				Spec: v1alpha1.CatalogSourceSpec{
+					SourceType: v1alpha1.SourceTypeConfigmap,
+				},
+			},
+			expectedStatus: &v1alpha1.CatalogSourceStatus{
+				ConfigMapResource: &v1alpha1.ConfigMapResourceReference{
+					Name:           "cool-catalog-inline-catalog",
+					Namespace:      "cool-namespace",
+					LastUpdateTime: now,
+				},
+			},
+		},
 		{
 			testName:      "CatalogSourceWithMissingConfigMap",
 			namespace:     "cool-namespace",
@@ -959,6 +1222,22 @@ func TestSyncCatalogSources(t *testing.T) {
 				pod(*grpcCatalog),
 			},
 		},
+		{
+			testName:      "CatalogSourceWithGrpcImage/InvalidSignaturePolicy",
+			namespace:     "cool-namespace",
+			catalogSource: grpcCatalog,
+			clientObjs: []runtime.Object{
+				&operatorsv1.OLMConfig{ObjectMeta: metav1.ObjectMeta{
+					Name:        "cluster",
+					Annotations: map[string]string{imagesig.PolicyAnnotationKey: "{}"},
+				}},
+			},
+			expectedStatus: &v1alpha1.CatalogSourceStatus{
+				Reason:  v1alpha1.ConditionReason(reasons.CatalogSourceSignatureVerificationFailed),
+				Message: "signature verification of image catalog-image failed: operatorframework.io/image-signature-policy annotation must specify public keys or keyless identities",
+			},
+			expectedError: fmt.Errorf("signature verification of image catalog-image failed: operatorframework.io/image-signature-policy annotation must specify public keys or keyless identities"),
+		},
 		{
 			testName:  "CatalogSourceWithGrpcType/EnsuresImageOrAddressIsSet",
 			namespace: "cool-namespace",
@@ -1019,6 +1298,53 @@ func TestSyncCatalogSources(t *testing.T) {
 			},
 			expectedError: nil,
 		},
+		{
+			testName:  "CatalogSourceWithGrpcAddress/MissingCredentialsSecret",
+			namespace: "cool-namespace",
+			catalogSource: withStatus(*addressCatalog, v1alpha1.CatalogSourceStatus{
+				RegistryServiceStatus: &v1alpha1.RegistryServiceStatus{
+					Protocol:  "grpc",
+					CreatedAt: now,
+				},
+			}),
+			expectedStatus: &v1alpha1.CatalogSourceStatus{
+				RegistryServiceStatus: &v1alpha1.RegistryServiceStatus{
+					Protocol:  "grpc",
+					CreatedAt: now,
+				},
+				Reason:  v1alpha1.CatalogSourceRegistryServerError,
+				Message: `couldn't load registry credentials - secret "registry-token" not found`,
+			},
+			expectedError: fmt.Errorf(`couldn't load registry credentials - secret "registry-token" not found`),
+		},
+		{
+			testName:  "CatalogSourceWithGrpcAddress/CredentialsSecret",
+			namespace: "cool-namespace",
+			catalogSource: withStatus(*addressCatalog, v1alpha1.CatalogSourceStatus{
+				RegistryServiceStatus: &v1alpha1.RegistryServiceStatus{
+					Protocol:  "grpc",
+					CreatedAt: now,
+				},
+			}),
+			k8sObjs: []runtime.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "registry-token", Namespace: "cool-namespace"},
+					Data:       map[string][]byte{grpc.TokenSecretKey: []byte("s3cr3t")},
+				},
+			},
+			expectedStatus: &v1alpha1.CatalogSourceStatus{
+				RegistryServiceStatus: &v1alpha1.RegistryServiceStatus{
+					Protocol:  "grpc",
+					CreatedAt: now,
+				},
+				GRPCConnectionState: &v1alpha1.GRPCConnectionState{
+					Address:           "registry.example.com:50051",
+					LastObservedState: "",
+					LastConnectTime:   now,
+				},
+			},
+			expectedError: nil,
+		},
 		{
 			testName:  "GRPCConnectionStateAddressIsUpdated",
 			namespace: "cool-namespace",
@@ -1064,7 +1390,7 @@ func TestSyncCatalogSources(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.testName, func(t *testing.T) {
 			// Create existing objects
-			clientObjs := []runtime.Object{tt.catalogSource}
+			clientObjs := append([]runtime.Object{tt.catalogSource}, tt.clientObjs...)
 
 			// Create test operator
 			ctx, cancel := context.WithCancel(context.TODO())
@@ -1074,7 +1400,7 @@ func TestSyncCatalogSources(t *testing.T) {
 			require.NoError(t, err)
 
 			// Run sync
-			err = op.syncCatalogSources(tt.catalogSource)
+			err = op.syncCatalogSources(ctx, tt.catalogSource)
 			if tt.expectedError != nil {
 				require.EqualError(t, err, tt.expectedError.Error())
 			} else {
@@ -1097,8 +1423,8 @@ func TestSyncCatalogSources(t *testing.T) {
 				require.NotEmpty(t, updated.Status)
 				require.Equal(t, *tt.expectedStatus, updated.Status)
 
-				if tt.catalogSource.Spec.ConfigMap != "" {
-					configMap, err := op.opClient.KubernetesInterface().CoreV1().ConfigMaps(tt.catalogSource.GetNamespace()).Get(context.TODO(), tt.catalogSource.Spec.ConfigMap, metav1.GetOptions{})
+				if configMapName := reconciler.CatalogConfigMapName(tt.catalogSource); configMapName != "" {
+					configMap, err := op.opClient.KubernetesInterface().CoreV1().ConfigMaps(tt.catalogSource.GetNamespace()).Get(context.TODO(), configMapName, metav1.GetOptions{})
 					require.NoError(t, err)
 					require.True(t, ownerutil.EnsureOwner(configMap, updated))
 				}
@@ -1245,7 +1571,7 @@ func TestSyncResolvingNamespace(t *testing.T) {
 			o.reconciler = &fakes.FakeRegistryReconcilerFactory{
 				ReconcilerForSourceStub: func(source *v1alpha1.CatalogSource) reconciler.RegistryReconciler {
 					return &fakes.FakeRegistryReconciler{
-						EnsureRegistryServerStub: func(source *v1alpha1.CatalogSource) error {
+						EnsureRegistryServerStub: func(ctx context.Context, source *v1alpha1.CatalogSource) error {
 							return nil
 						},
 					}
@@ -1254,7 +1580,7 @@ func TestSyncResolvingNamespace(t *testing.T) {
 
 			o.sourcesLastUpdate.Set(tt.fields.sourcesLastUpdate.Time)
 			o.resolver = &fakes.FakeStepResolver{
-				ResolveStepsStub: func(string) ([]*v1alpha1.Step, []v1alpha1.BundleLookup, []*v1alpha1.Subscription, error) {
+				ResolveStepsStub: func(context.Context, string) ([]*v1alpha1.Step, []v1alpha1.BundleLookup, []*v1alpha1.Subscription, error) {
 					return nil, nil, nil, tt.fields.resolveErr
 				},
 			}
@@ -1265,7 +1591,7 @@ func TestSyncResolvingNamespace(t *testing.T) {
 				},
 			}
 
-			err = o.syncResolvingNamespace(namespace)
+			err = o.syncResolvingNamespace(ctx, namespace)
 			if tt.wantErr != nil {
 				require.Equal(t, tt.wantErr, err)
 			} else {
@@ -1408,7 +1734,7 @@ func TestValidateExistingCRs(t *testing.T) {
 			client := fakedynamic.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
 				tt.gvr: "UnstructuredList",
 			}, tt.existingObjects...)
-			require.Equal(t, tt.want, validateExistingCRs(client, tt.gvr, tt.newCRD))
+			require.Equal(t, tt.want, validateExistingCRs(context.TODO(), client, tt.gvr, tt.newCRD))
 		})
 	}
 }
@@ -1511,6 +1837,10 @@ func NewFakeOperator(ctx context.Context, namespace string, namespaces []string,
 	opClientFake := operatorclient.NewClient(k8sClientFake, apiextensionsfake.NewSimpleClientset(config.extObjs...), apiregistrationfake.NewSimpleClientset(config.regObjs...))
 	dynamicClientFake := fakedynamic.NewSimpleDynamicClient(runtime.NewScheme())
 
+	// Step resources are server-side applied
+	k8sClientFake.PrependReactor("patch", "*", applyPatchReactor(k8sClientFake.Tracker(), k8sscheme.Scheme))
+	dynamicClientFake.PrependReactor("patch", "*", applyPatchReactor(dynamicClientFake.Tracker(), nil))
+
 	// Create operator namespace
 	_, err := opClientFake.KubernetesInterface().CoreV1().Namespaces().Create(context.TODO(), &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace}}, metav1.CreateOptions{})
 	if err != nil {
@@ -1535,7 +1865,8 @@ func NewFakeOperator(ctx context.Context, namespace string, namespaces []string,
 	subInformer := operatorsFactory.Operators().V1alpha1().Subscriptions()
 	ipInformer := operatorsFactory.Operators().V1alpha1().InstallPlans()
 	csvInformer := operatorsFactory.Operators().V1alpha1().ClusterServiceVersions()
-	sharedInformers = append(sharedInformers, catsrcInformer.Informer(), subInformer.Informer(), ipInformer.Informer(), csvInformer.Informer())
+	olmConfigInformer := operatorsFactory.Operators().V1().OLMConfigs()
+	sharedInformers = append(sharedInformers, catsrcInformer.Informer(), subInformer.Informer(), ipInformer.Informer(), csvInformer.Informer(), olmConfigInformer.Informer())
 
 	lister.OperatorsV1alpha1().RegisterCatalogSourceLister(metav1.NamespaceAll, catsrcInformer.Lister())
 	lister.OperatorsV1alpha1().RegisterSubscriptionLister(metav1.NamespaceAll, subInformer.Lister())
@@ -1549,7 +1880,11 @@ func NewFakeOperator(ctx context.Context, namespace string, namespaces []string,
 	serviceInformer := factory.Core().V1().Services()
 	podInformer := factory.Core().V1().Pods()
 	configMapInformer := factory.Core().V1().ConfigMaps()
-	sharedInformers = append(sharedInformers, roleInformer.Informer(), roleBindingInformer.Informer(), serviceAccountInformer.Informer(), serviceInformer.Informer(), podInformer.Informer(), configMapInformer.Informer())
+	secretInformer := factory.Core().V1().Secrets()
+	validatingWebhookInformer := factory.Admissionregistration().V1().ValidatingWebhookConfigurations()
+	mutatingWebhookInformer := factory.Admissionregistration().V1().MutatingWebhookConfigurations()
+	sharedInformers = append(sharedInformers, roleInformer.Informer(), roleBindingInformer.Informer(), serviceAccountInformer.Informer(), serviceInformer.Informer(), podInformer.Informer(), configMapInformer.Informer(), secretInformer.Informer())
+	sharedInformers = append(sharedInformers, validatingWebhookInformer.Informer(), mutatingWebhookInformer.Informer())
 
 	lister.RbacV1().RegisterRoleLister(metav1.NamespaceAll, roleInformer.Lister())
 	lister.RbacV1().RegisterRoleBindingLister(metav1.NamespaceAll, roleBindingInformer.Lister())
@@ -1557,6 +1892,11 @@ func NewFakeOperator(ctx context.Context, namespace string, namespaces []string,
 	lister.CoreV1().RegisterServiceLister(metav1.NamespaceAll, serviceInformer.Lister())
 	lister.CoreV1().RegisterPodLister(metav1.NamespaceAll, podInformer.Lister())
 	lister.CoreV1().RegisterConfigMapLister(metav1.NamespaceAll, configMapInformer.Lister())
+	lister.CoreV1().RegisterSecretLister(metav1.NamespaceAll, secretInformer.Lister())
+
+	crdInformer := extinf.NewSharedInformerFactory(opClientFake.ApiextensionsInterface(), wakeupInterval).Apiextensions().V1().CustomResourceDefinitions()
+	sharedInformers = append(sharedInformers, crdInformer.Informer())
+	lister.APIExtensionsV1().RegisterCustomResourceDefinitionLister(crdInformer.Lister())
 	logger := logrus.New()
 
 	// Create the new operator
@@ -1569,14 +1909,17 @@ func NewFakeOperator(ctx context.Context, namespace string, namespaces []string,
 	}
 
 	op := &Operator{
-		Operator:      queueOperator,
-		clock:         config.clock,
-		logger:        config.logger,
-		opClient:      opClientFake,
-		dynamicClient: dynamicClientFake,
-		client:        clientFake,
-		lister:        lister,
-		namespace:     namespace,
+		Operator:                             queueOperator,
+		clock:                                config.clock,
+		logger:                               config.logger,
+		opClient:                             opClientFake,
+		dynamicClient:                        dynamicClientFake,
+		client:                               clientFake,
+		lister:                               lister,
+		olmConfigLister:                      olmConfigInformer.Lister(),
+		validatingWebhookConfigurationLister: validatingWebhookInformer.Lister(),
+		mutatingWebhookConfigurationLister:   mutatingWebhookInformer.Lister(),
+		namespace:                            namespace,
 		nsResolveQueue: workqueue.NewNamedRateLimitingQueue(
 			workqueue.NewMaxOfRateLimiter(
 				workqueue.NewItemExponentialFailureRateLimiter(1*time.Second, 1000*time.Second),
@@ -1589,6 +1932,9 @@ func NewFakeOperator(ctx context.Context, namespace string, namespaces []string,
 		clientAttenuator:      scoped.NewClientAttenuator(logger, &rest.Config{}, opClientFake),
 		serviceAccountQuerier: scoped.NewUserDefinedServiceAccountQuerier(logger, clientFake),
 		catsrcQueueSet:        queueinformer.NewEmptyResourceQueueSet(),
+		subQueueSet:           queueinformer.NewEmptyResourceQueueSet(),
+		ipQueueSet:            queueinformer.NewEmptyResourceQueueSet(),
+		catalogServers:        fbc.NewServers(),
 		clientFactory: &stubClientFactory{
 			operatorClient:   opClientFake,
 			kubernetesClient: clientFake,
@@ -1604,7 +1950,7 @@ func NewFakeOperator(ctx context.Context, namespace string, namespaces []string,
 		}
 		applier := controllerclient.NewFakeApplier(s, "testowner")
 
-		op.reconciler = reconciler.NewRegistryReconcilerFactory(lister, op.opClient, "test:pod", op.now, applier)
+		op.reconciler = reconciler.NewRegistryReconcilerFactory(lister, op.opClient, "test:pod", "", "", op.catalogServers, op.now, applier, nil, nil, nil)
 	}
 
 	op.RunInformers(ctx)
@@ -1805,6 +2151,13 @@ func withOwner(owner ownerutil.Owner) modifierFunc {
 	})
 }
 
+func withNormalizedPrometheusRule(namespace string) modifierFunc {
+	return func(t *testing.T, obj runtime.Object) runtime.Object {
+		require.NoError(t, normalizePrometheusRule(obj.(*unstructured.Unstructured), namespace))
+		return obj
+	}
+}
+
 func apiResourcesForObjects(objs []runtime.Object) []*metav1.APIResourceList {
 	apis := []*metav1.APIResourceList{}
 	for _, o := range objs {
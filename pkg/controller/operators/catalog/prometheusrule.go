@@ -0,0 +1,95 @@
+package catalog
+
+import (
+	"errors"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	olmerrors "github.com/operator-framework/operator-lifecycle-manager/pkg/controller/errors"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/install"
+)
+
+// prometheusRuleNamespaceLabel is the label of the alerting and recording rules of PrometheusRules naming the namespace
+// their alerts and series are attributed to.
+const prometheusRuleNamespaceLabel = "namespace"
+
+// optionalKinds are the supported kinds whose steps are skipped, rather than failing the InstallPlan, when the cluster
+// doesn't serve their API, e.g. PrometheusRules on clusters without the Prometheus operator.
+var optionalKinds = map[string]struct{}{
+	PrometheusRuleKind: {},
+}
+
+// isOptional returns true if steps of the given kind are skipped when the cluster doesn't serve their API.
+func isOptional(kind string) bool {
+	_, ok := optionalKinds[kind]
+	return ok
+}
+
+// apiNotServed returns true if the given error of looking up the API of the given GVK is due to the cluster not serving
+// it.
+func (o *Operator) apiNotServed(gvk schema.GroupVersionKind, err error) bool {
+	var gvkErr olmerrors.GroupVersionKindNotFoundError
+	if errors.As(err, &gvkErr) {
+		return true
+	}
+	groups, groupsErr := o.opClient.KubernetesInterface().Discovery().ServerGroups()
+	if groupsErr != nil {
+		return false
+	}
+	for _, group := range groups.Groups {
+		if group.Name != gvk.Group {
+			continue
+		}
+		for _, version := range group.Versions {
+			if version.Version == gvk.Version {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// normalizePrometheusRule confines the given PrometheusRule, shipped by a bundle, to the namespace it's installed in,
+// so that the alerts of an operator can't pass for those of another tenant: every rule is labeled with the namespace,
+// overriding any namespace label set by the bundle, and the PrometheusRule is labeled as managed by OLM.
+func normalizePrometheusRule(obj *unstructured.Unstructured, namespace string) error {
+	obj.SetNamespace(namespace)
+	labels := obj.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	labels[install.OLMManagedLabelKey] = install.OLMManagedLabelValue
+	obj.SetLabels(labels)
+
+	groups, found, err := unstructured.NestedSlice(obj.Object, "spec", "groups")
+	if err != nil || !found {
+		return err
+	}
+	for i, group := range groups {
+		g, ok := group.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("invalid rule group %d", i)
+		}
+		rules, _, err := unstructured.NestedSlice(g, "rules")
+		if err != nil {
+			return fmt.Errorf("invalid rules of group %d: %v", i, err)
+		}
+		for j, rule := range rules {
+			r, ok := rule.(map[string]interface{})
+			if !ok {
+				return fmt.Errorf("invalid rule %d of group %d", j, i)
+			}
+			if err := unstructured.SetNestedField(r, namespace, "labels", prometheusRuleNamespaceLabel); err != nil {
+				return fmt.Errorf("invalid labels of rule %d of group %d: %v", j, i, err)
+			}
+			rules[j] = r
+		}
+		if err := unstructured.SetNestedSlice(g, rules, "rules"); err != nil {
+			return err
+		}
+		groups[i] = g
+	}
+	return unstructured.SetNestedSlice(obj.Object, groups, "spec", "groups")
+}
@@ -0,0 +1,52 @@
+package catalog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/install"
+)
+
+func TestNormalizePrometheusRule(t *testing.T) {
+	rule := decodeFile(t, "./testdata/prometheusrule.cr.yaml", &unstructured.Unstructured{}).(*unstructured.Unstructured)
+	groups, _, _ := unstructured.NestedSlice(rule.Object, "spec", "groups")
+	alert := groups[0].(map[string]interface{})["rules"].([]interface{})[0].(map[string]interface{})
+	require.NoError(t, unstructured.SetNestedField(alert, "kube-system", "labels", "namespace"))
+	groups = append(groups, map[string]interface{}{
+		"name": "olm.recording.rules",
+		"rules": []interface{}{
+			map[string]interface{}{"record": "operator:reconcile_errors:rate5m", "expr": "rate(reconcile_errors_total[5m])"},
+		},
+	})
+	require.NoError(t, unstructured.SetNestedSlice(rule.Object, groups, "spec", "groups"))
+
+	require.NoError(t, normalizePrometheusRule(rule, "operators"))
+	require.Equal(t, "operators", rule.GetNamespace())
+	require.Equal(t, install.OLMManagedLabelValue, rule.GetLabels()[install.OLMManagedLabelKey])
+	require.Equal(t, "alert-rules", rule.GetLabels()["prometheus"])
+
+	groups, _, err := unstructured.NestedSlice(rule.Object, "spec", "groups")
+	require.NoError(t, err)
+	require.Len(t, groups, 2)
+	var labels []map[string]string
+	for _, group := range groups {
+		rules, _, err := unstructured.NestedSlice(group.(map[string]interface{}), "rules")
+		require.NoError(t, err)
+		for _, r := range rules {
+			l, _, err := unstructured.NestedStringMap(r.(map[string]interface{}), "labels")
+			require.NoError(t, err)
+			labels = append(labels, l)
+		}
+	}
+	require.Equal(t, []map[string]string{
+		{"namespace": "operators", "severity": "warn"},
+		{"namespace": "operators"},
+	}, labels, "rules are attributed to the install namespace")
+}
+
+func TestIsOptional(t *testing.T) {
+	require.True(t, isOptional(PrometheusRuleKind))
+	require.False(t, isOptional(ServiceMonitorKind))
+}
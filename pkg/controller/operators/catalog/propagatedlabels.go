@@ -0,0 +1,58 @@
+package catalog
+
+import (
+	"sort"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/install"
+)
+
+// propagatedLabels returns the labels the given Subscriptions propagate onto the resources installed for them. When
+// Subscriptions sharing an InstallPlan propagate different values of a label, the first Subscription by name wins.
+func propagatedLabels(subs []*v1alpha1.Subscription) map[string]string {
+	sorted := make([]*v1alpha1.Subscription, len(subs))
+	copy(sorted, subs)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].GetName() < sorted[j].GetName() })
+
+	labels := map[string]string{}
+	for _, sub := range sorted {
+		for key, value := range install.PropagatedLabelsFor(sub) {
+			if _, ok := labels[key]; !ok {
+				labels[key] = value
+			}
+		}
+	}
+	return labels
+}
+
+// setPropagatedLabels adds the given propagated labels to the object, and records their keys in its
+// install.PropagatedLabelsAnnotationKey annotation so that they're propagated further. Labels the object already sets
+// are kept.
+func setPropagatedLabels(obj metav1.Object, labels map[string]string) {
+	if len(labels) == 0 {
+		return
+	}
+	keys := make([]string, 0, len(labels))
+	objLabels := obj.GetLabels()
+	if objLabels == nil {
+		objLabels = map[string]string{}
+	}
+	for key, value := range labels {
+		keys = append(keys, key)
+		if _, ok := objLabels[key]; !ok {
+			objLabels[key] = value
+		}
+	}
+	obj.SetLabels(objLabels)
+
+	sort.Strings(keys)
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[install.PropagatedLabelsAnnotationKey] = strings.Join(keys, ",")
+	obj.SetAnnotations(annotations)
+}
@@ -0,0 +1,55 @@
+package catalog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/install"
+)
+
+func TestPropagatedLabels(t *testing.T) {
+	subs := []*v1alpha1.Subscription{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "b",
+				Labels:      map[string]string{"team": "storage", "cost-center": "cc-7"},
+				Annotations: map[string]string{install.PropagatedLabelsAnnotationKey: "team,cost-center"},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "a",
+				Labels:      map[string]string{"team": "payments", "env": "prod"},
+				Annotations: map[string]string{install.PropagatedLabelsAnnotationKey: "team"},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "c",
+				Labels: map[string]string{"owner": "nobody"},
+			},
+		},
+	}
+	labels := propagatedLabels(subs)
+	require.Equal(t, map[string]string{"team": "payments", "cost-center": "cc-7"}, labels)
+
+	ip := &v1alpha1.InstallPlan{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"team": "kept"}}}
+	setPropagatedLabels(ip, labels)
+	require.Equal(t, map[string]string{"team": "kept", "cost-center": "cc-7"}, ip.GetLabels())
+	require.Equal(t, "cost-center,team", ip.GetAnnotations()[install.PropagatedLabelsAnnotationKey])
+
+	// the labels propagate from the plan to its csvs
+	csv := &v1alpha1.ClusterServiceVersion{}
+	setPropagatedLabels(csv, install.PropagatedLabelsFor(ip))
+	require.Equal(t, ip.GetLabels(), csv.GetLabels())
+	require.Equal(t, "cost-center,team", csv.GetAnnotations()[install.PropagatedLabelsAnnotationKey])
+
+	// nothing is set without labels to propagate
+	unlabeled := &v1alpha1.InstallPlan{}
+	setPropagatedLabels(unlabeled, propagatedLabels(subs[2:]))
+	require.Nil(t, unlabeled.GetLabels())
+	require.Nil(t, unlabeled.GetAnnotations())
+}
@@ -0,0 +1,165 @@
+package catalog
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/operator-framework/operator-registry/pkg/lib/encoding"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/registry"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/registry/reconciler"
+)
+
+// CatalogProvenanceAnnotationKey is the annotation of InstallPlans, and of the CSVs they install, recording as a
+// JSON-encoded list of CatalogProvenance the state of the catalogs their bundles were resolved from.
+const CatalogProvenanceAnnotationKey = "operatorframework.io/catalog-provenance"
+
+// CatalogProvenance is the state of a catalog when bundles were resolved from it.
+type CatalogProvenance struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	// Image is the catalog image of the CatalogSource, if any.
+	Image string `json:"image,omitempty"`
+	// ImageDigest is the digest of the catalog image served when the bundles were resolved, if known.
+	ImageDigest string `json:"imageDigest,omitempty"`
+	// ContentHash is the sha256 digest of the catalog content served when the bundles were resolved, for catalogs
+	// held in a ConfigMap.
+	ContentHash string `json:"contentHash,omitempty"`
+}
+
+// imageDigest returns the digest of the given image reference or container image ID, or an empty string if it doesn't
+// have one.
+func imageDigest(image string) string {
+	if i := strings.LastIndex(image, "@"); i >= 0 {
+		return image[i+1:]
+	}
+	if strings.HasPrefix(image, "sha256:") {
+		return image
+	}
+	return ""
+}
+
+// catalogProvenance returns the current state of the given catalog. Missing details are left out, since provenance is
+// recorded on a best effort basis.
+func (o *Operator) catalogProvenance(logger *logrus.Entry, key registry.CatalogKey) CatalogProvenance {
+	provenance := CatalogProvenance{Name: key.Name, Namespace: key.Namespace}
+	source, err := o.lister.OperatorsV1alpha1().CatalogSourceLister().CatalogSources(key.Namespace).Get(key.Name)
+	if err != nil {
+		logger.WithError(err).Debug("couldn't get catalogsource, recording partial provenance")
+		return provenance
+	}
+	provenance.Image = source.Spec.Image
+
+	switch source.Spec.SourceType {
+	case v1alpha1.SourceTypeGrpc:
+		if source.Spec.Image == "" {
+			break
+		}
+		provenance.ImageDigest = imageDigest(source.Spec.Image)
+		pods, err := o.lister.CoreV1().PodLister().Pods(key.Namespace).List(labels.SelectorFromSet(labels.Set{reconciler.CatalogSourceLabelKey: key.Name}))
+		if err != nil {
+			logger.WithError(err).Debug("couldn't list catalogsource pods, recording partial provenance")
+			break
+		}
+		for _, pod := range pods {
+			for _, status := range pod.Status.ContainerStatuses {
+				if digest := imageDigest(status.ImageID); digest != "" {
+					provenance.ImageDigest = digest
+				}
+			}
+		}
+	case v1alpha1.SourceTypeInternal, v1alpha1.SourceTypeConfigmap:
+		provenance.ContentHash = o.catalogConfigMapHash(logger, key.Namespace, reconciler.CatalogConfigMapName(source), false)
+	case reconciler.SourceTypeOCI:
+		provenance.ImageDigest = imageDigest(source.Spec.Image)
+		provenance.ContentHash = o.catalogConfigMapHash(logger, key.Namespace, key.Name+reconciler.OCICatalogPostfix, true)
+	}
+	return provenance
+}
+
+// catalogConfigMapHash returns the content hash of the catalog held by the given ConfigMap, whose binary data is
+// gzipped and base64 encoded if encoded is true.
+func (o *Operator) catalogConfigMapHash(logger *logrus.Entry, namespace, name string, encoded bool) string {
+	cm, err := o.lister.CoreV1().ConfigMapLister().ConfigMaps(namespace).Get(name)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			logger.WithError(err).Debug("couldn't get catalog configmap, recording partial provenance")
+		}
+		return ""
+	}
+	if encoded {
+		decoded := &corev1.ConfigMap{Data: cm.Data, BinaryData: make(map[string][]byte, len(cm.BinaryData))}
+		for file, content := range cm.BinaryData {
+			if decoded.BinaryData[file], err = encoding.GzipBase64Decode(content); err != nil {
+				logger.WithError(err).Debug("couldn't decode catalog configmap, recording partial provenance")
+				return ""
+			}
+		}
+		cm = decoded
+	}
+	return reconciler.CatalogContentHash(cm)
+}
+
+// catalogProvenanceAnnotation returns the value of the catalog provenance annotation of an InstallPlan with the given
+// steps and bundle lookups, or an empty string if they don't come from any catalog.
+func (o *Operator) catalogProvenanceAnnotation(logger *logrus.Entry, steps []*v1alpha1.Step, bundleLookups []v1alpha1.BundleLookup) string {
+	keys := map[registry.CatalogKey]struct{}{}
+	for _, s := range steps {
+		if s.Resource.CatalogSource != "" {
+			keys[registry.CatalogKey{Name: s.Resource.CatalogSource, Namespace: s.Resource.CatalogSourceNamespace}] = struct{}{}
+		}
+	}
+	for _, b := range bundleLookups {
+		keys[registry.CatalogKey{Name: b.CatalogSourceRef.Name, Namespace: b.CatalogSourceRef.Namespace}] = struct{}{}
+	}
+
+	var provenance []CatalogProvenance
+	for key := range keys {
+		provenance = append(provenance, o.catalogProvenance(logger, key))
+	}
+	return encodeCatalogProvenance(provenance)
+}
+
+func encodeCatalogProvenance(provenance []CatalogProvenance) string {
+	if len(provenance) == 0 {
+		return ""
+	}
+	sort.Slice(provenance, func(i, j int) bool {
+		if provenance[i].Namespace != provenance[j].Namespace {
+			return provenance[i].Namespace < provenance[j].Namespace
+		}
+		return provenance[i].Name < provenance[j].Name
+	})
+	b, err := json.Marshal(provenance)
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
+
+// setCSVCatalogProvenance records on the given CSV, installed by the given step of the given InstallPlan, the
+// provenance of the catalog it was resolved from.
+func setCSVCatalogProvenance(csv *v1alpha1.ClusterServiceVersion, plan *v1alpha1.InstallPlan, step *v1alpha1.Step) {
+	var provenance []CatalogProvenance
+	if err := json.Unmarshal([]byte(plan.GetAnnotations()[CatalogProvenanceAnnotationKey]), &provenance); err != nil {
+		return
+	}
+	for _, p := range provenance {
+		if p.Name != step.Resource.CatalogSource || p.Namespace != step.Resource.CatalogSourceNamespace {
+			continue
+		}
+		annotations := csv.GetAnnotations()
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[CatalogProvenanceAnnotationKey] = encodeCatalogProvenance([]CatalogProvenance{p})
+		csv.SetAnnotations(annotations)
+		return
+	}
+}
@@ -0,0 +1,75 @@
+package catalog
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/registry"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/registry/reconciler"
+)
+
+func TestImageDigest(t *testing.T) {
+	const digest = "sha256:4bc453b53cb3d914b45f4b250294236adba2c0e09ff6f03793949e7e39fd4cc1"
+	require.Equal(t, digest, imageDigest("quay.io/operatorhubio/catalog@"+digest))
+	require.Equal(t, digest, imageDigest("docker-pullable://quay.io/operatorhubio/catalog@"+digest))
+	require.Equal(t, digest, imageDigest(digest))
+	require.Empty(t, imageDigest("quay.io/operatorhubio/catalog:latest"))
+}
+
+func TestCatalogProvenance(t *testing.T) {
+	const digest = "sha256:4bc453b53cb3d914b45f4b250294236adba2c0e09ff6f03793949e7e39fd4cc1"
+	grpcSource := &v1alpha1.CatalogSource{
+		ObjectMeta: metav1.ObjectMeta{Name: "grpc", Namespace: "ns"},
+		Spec:       v1alpha1.CatalogSourceSpec{SourceType: v1alpha1.SourceTypeGrpc, Image: "quay.io/operatorhubio/catalog:latest"},
+	}
+	servingPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "grpc-abcde", Namespace: "ns", Labels: map[string]string{reconciler.CatalogSourceLabelKey: "grpc"}},
+		Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{
+			{Name: "registry-server", ImageID: "docker-pullable://quay.io/operatorhubio/catalog@" + digest},
+		}},
+	}
+	cmSource := &v1alpha1.CatalogSource{
+		ObjectMeta: metav1.ObjectMeta{Name: "cm", Namespace: "ns"},
+		Spec:       v1alpha1.CatalogSourceSpec{SourceType: v1alpha1.SourceTypeConfigmap, ConfigMap: "catalog"},
+	}
+	catalog := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "catalog", Namespace: "ns"},
+		Data:       map[string]string{"catalog.json": `{"schema":"olm.package","name":"etcd"}`},
+	}
+
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+	op, err := NewFakeOperator(ctx, "ns", []string{"ns"}, withClientObjs(grpcSource, cmSource), withK8sObjs(servingPod, catalog))
+	require.NoError(t, err)
+
+	logger := logrus.NewEntry(op.logger)
+	require.Equal(t, CatalogProvenance{Name: "grpc", Namespace: "ns", Image: grpcSource.Spec.Image, ImageDigest: digest},
+		op.catalogProvenance(logger, registry.CatalogKey{Name: "grpc", Namespace: "ns"}))
+	require.Equal(t, CatalogProvenance{Name: "cm", Namespace: "ns", ContentHash: reconciler.CatalogContentHash(catalog)},
+		op.catalogProvenance(logger, registry.CatalogKey{Name: "cm", Namespace: "ns"}))
+	require.Equal(t, CatalogProvenance{Name: "deleted", Namespace: "ns"},
+		op.catalogProvenance(logger, registry.CatalogKey{Name: "deleted", Namespace: "ns"}))
+
+	steps := []*v1alpha1.Step{
+		{Resource: v1alpha1.StepResource{CatalogSource: "grpc", CatalogSourceNamespace: "ns", Kind: v1alpha1.ClusterServiceVersionKind, Name: "etcd.v1"}},
+		{Resource: v1alpha1.StepResource{CatalogSource: "cm", CatalogSourceNamespace: "ns", Kind: v1alpha1.ClusterServiceVersionKind, Name: "etcd.v2"}},
+	}
+	plan := &v1alpha1.InstallPlan{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{
+		CatalogProvenanceAnnotationKey: op.catalogProvenanceAnnotation(logger, steps, nil),
+	}}}
+	require.JSONEq(t, `[
+		{"name":"cm","namespace":"ns","contentHash":"`+reconciler.CatalogContentHash(catalog)+`"},
+		{"name":"grpc","namespace":"ns","image":"quay.io/operatorhubio/catalog:latest","imageDigest":"`+digest+`"}
+	]`, plan.GetAnnotations()[CatalogProvenanceAnnotationKey])
+
+	csv := &v1alpha1.ClusterServiceVersion{}
+	setCSVCatalogProvenance(csv, plan, steps[0])
+	require.JSONEq(t, `[{"name":"grpc","namespace":"ns","image":"quay.io/operatorhubio/catalog:latest","imageDigest":"`+digest+`"}]`,
+		csv.GetAnnotations()[CatalogProvenanceAnnotationKey])
+}
@@ -0,0 +1,186 @@
+package catalog
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/ownerutil"
+)
+
+// PruneAnnotationKey is the annotation opting out of pruning when set to "false". On the CSV of a bundle, it keeps all
+// the objects the bundle it replaces shipped; on an object, it keeps that object.
+const PruneAnnotationKey = "operatorframework.io/prune"
+
+// unprunableKinds are the kinds of the step resources never pruned: CRDs, as deleting them deletes their custom
+// resources, and the CSVs, Subscriptions and RBAC that OLM cleans up with the CSVs themselves.
+var unprunableKinds = map[string]struct{}{
+	crdKind:                   {},
+	csvKind:                   {},
+	v1alpha1.SubscriptionKind: {},
+	serviceAccountKind:        {},
+	roleKind:                  {},
+	roleBindingKind:           {},
+	clusterRoleKind:           {},
+	clusterRoleBindingKind:    {},
+}
+
+// prunable is an object a replaced CSV was installed with, and that the CSV replacing it no longer ships.
+type prunable struct {
+	resource  v1alpha1.StepResource
+	replaced  string
+	replacing string
+}
+
+// prunesObjects returns false if the given object opts out of pruning.
+func prunesObjects(obj metav1.Object) bool {
+	return obj.GetAnnotations()[PruneAnnotationKey] != "false"
+}
+
+// removedObjects returns the objects shipped by the replaced CSVs of the given map, keyed by the CSV replacing them,
+// according to the steps of the given InstallPlans that the given plan doesn't apply for the replacing CSVs.
+func removedObjects(plan *v1alpha1.InstallPlan, ips []*v1alpha1.InstallPlan, replaced map[string]string) []prunable {
+	type object struct {
+		group, kind, name string
+	}
+	key := func(step *v1alpha1.Step) object {
+		return object{group: step.Resource.Group, kind: step.Resource.Kind, name: step.Resource.Name}
+	}
+
+	shipped := map[object]struct{}{}
+	for _, step := range plan.Status.Plan {
+		shipped[key(step)] = struct{}{}
+	}
+
+	var removed []prunable
+	seen := map[object]struct{}{}
+	for _, ip := range ips {
+		if ip.GetName() == plan.GetName() {
+			continue
+		}
+		for _, step := range ip.Status.Plan {
+			if step == nil {
+				continue
+			}
+			replacing, ok := replaced[step.Resolving]
+			if !ok {
+				continue
+			}
+			if _, ok := unprunableKinds[step.Resource.Kind]; ok {
+				continue
+			}
+			k := key(step)
+			if _, ok := shipped[k]; ok {
+				continue
+			}
+			if _, ok := seen[k]; ok {
+				continue
+			}
+			seen[k] = struct{}{}
+			removed = append(removed, prunable{resource: step.Resource, replaced: step.Resolving, replacing: replacing})
+		}
+	}
+	return removed
+}
+
+// ownedOnlyBy returns true if the given CSV is the only CSV owning the given object, which is in the given namespace if
+// namespaced.
+func ownedOnlyBy(obj *unstructured.Unstructured, namespaced bool, namespace, csv string) bool {
+	if !namespaced {
+		name, ns, ok := ownerutil.GetOwnerByKindLabel(obj, v1alpha1.ClusterServiceVersionKind)
+		return ok && name == csv && ns == namespace
+	}
+	owned := false
+	for _, ref := range obj.GetOwnerReferences() {
+		if ref.Kind != v1alpha1.ClusterServiceVersionKind {
+			continue
+		}
+		if ref.Name != csv {
+			return false
+		}
+		owned = true
+	}
+	return owned
+}
+
+// pruneRemovedObjects deletes the objects that the CSVs replaced by the given plan were installed with, and that the
+// CSVs replacing them no longer ship. Objects are only deleted while the replaced CSV is their only owner.
+func (o *Operator) pruneRemovedObjects(ctx context.Context, x *planExecution, dynamicClient dynamic.Interface) error {
+	plan := x.plan
+	replaced := map[string]string{}
+	for _, step := range plan.Status.Plan {
+		if step.Resource.Kind != csvKind {
+			continue
+		}
+		manifest, err := x.manifests.ManifestForStep(step)
+		if err != nil {
+			return err
+		}
+		var csv v1alpha1.ClusterServiceVersion
+		if err := json.Unmarshal([]byte(manifest), &csv); err != nil {
+			return fmt.Errorf("error parsing step manifest: %s: %v", step.Resource.Name, err)
+		}
+		if csv.Spec.Replaces != "" && prunesObjects(&csv) {
+			replaced[csv.Spec.Replaces] = csv.GetName()
+		}
+	}
+	if len(replaced) == 0 {
+		return nil
+	}
+
+	ips, err := o.listInstallPlans(ctx, plan.GetNamespace())
+	if err != nil {
+		return err
+	}
+
+	var pruned []string
+	for _, p := range removedObjects(plan, ips, replaced) {
+		gvk := schema.GroupVersionKind{Group: p.resource.Group, Version: p.resource.Version, Kind: p.resource.Kind}
+		r, err := o.apiresourceFromGVK(gvk)
+		if err != nil {
+			// the object can't exist if its api isn't served anymore
+			continue
+		}
+		var resourceInterface dynamic.ResourceInterface
+		if r.Namespaced {
+			resourceInterface = dynamicClient.Resource(gvk.GroupVersion().WithResource(r.Name)).Namespace(plan.GetNamespace())
+		} else {
+			resourceInterface = dynamicClient.Resource(gvk.GroupVersion().WithResource(r.Name))
+		}
+
+		obj, err := resourceInterface.Get(ctx, p.resource.Name, metav1.GetOptions{})
+		if k8serrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if !prunesObjects(obj) || !ownedOnlyBy(obj, r.Namespaced, plan.GetNamespace(), p.replaced) {
+			continue
+		}
+
+		uid := obj.GetUID()
+		err = resourceInterface.Delete(ctx, obj.GetName(), metav1.DeleteOptions{Preconditions: &metav1.Preconditions{UID: &uid}})
+		if err != nil && !k8serrors.IsNotFound(err) && !k8serrors.IsConflict(err) {
+			return fmt.Errorf("error pruning %s %s removed by %s: %v", p.resource.Kind, p.resource.Name, p.replacing, err)
+		}
+		pruned = append(pruned, fmt.Sprintf("%s %s", p.resource.Kind, p.resource.Name))
+	}
+
+	if len(pruned) > 0 {
+		sort.Strings(pruned)
+		o.logger.WithField("ip", plan.GetName()).WithField("pruned", pruned).Info("pruned objects removed between bundle versions")
+		o.recorder.Event(plan, corev1.EventTypeNormal, "PrunedObjects", fmt.Sprintf("deleted objects no longer shipped by upgraded operators: %s", strings.Join(pruned, ", ")))
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package catalog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/ownerutil"
+)
+
+func TestRemovedObjects(t *testing.T) {
+	step := func(resolving, kind, name string) *v1alpha1.Step {
+		return &v1alpha1.Step{Resolving: resolving, Resource: v1alpha1.StepResource{Kind: kind, Name: name, Version: "v1"}}
+	}
+	previous := &v1alpha1.InstallPlan{
+		ObjectMeta: metav1.ObjectMeta{Name: "install-a"},
+		Status: v1alpha1.InstallPlanStatus{Plan: []*v1alpha1.Step{
+			step("a.v1", csvKind, "a.v1"),
+			step("a.v1", crdKind, "widgets.example.com"),
+			step("a.v1", roleKind, "a.v1-role"),
+			step("a.v1", configMapKind, "kept"),
+			step("a.v1", configMapKind, "removed"),
+			step("a.v1", serviceKind, "removed-service"),
+			step("other.v1", configMapKind, "unrelated"),
+		}},
+	}
+	older := &v1alpha1.InstallPlan{
+		ObjectMeta: metav1.ObjectMeta{Name: "install-b"},
+		Status: v1alpha1.InstallPlanStatus{Plan: []*v1alpha1.Step{
+			step("a.v1", configMapKind, "removed"),
+		}},
+	}
+	plan := &v1alpha1.InstallPlan{
+		ObjectMeta: metav1.ObjectMeta{Name: "install-c"},
+		Status: v1alpha1.InstallPlanStatus{Plan: []*v1alpha1.Step{
+			step("a.v2", csvKind, "a.v2"),
+			step("a.v2", configMapKind, "kept"),
+		}},
+	}
+
+	removed := removedObjects(plan, []*v1alpha1.InstallPlan{previous, older, plan}, map[string]string{"a.v1": "a.v2"})
+	require.Equal(t, []prunable{
+		{resource: step("", configMapKind, "removed").Resource, replaced: "a.v1", replacing: "a.v2"},
+		{resource: step("", serviceKind, "removed-service").Resource, replaced: "a.v1", replacing: "a.v2"},
+	}, removed)
+
+	require.Empty(t, removedObjects(plan, []*v1alpha1.InstallPlan{previous}, map[string]string{}))
+}
+
+func TestOwnedOnlyBy(t *testing.T) {
+	owner := func(name string) metav1.OwnerReference {
+		return metav1.OwnerReference{Kind: v1alpha1.ClusterServiceVersionKind, Name: name}
+	}
+
+	obj := &unstructured.Unstructured{}
+	require.False(t, ownedOnlyBy(obj, true, "ns", "a.v1"))
+
+	obj.SetOwnerReferences([]metav1.OwnerReference{owner("a.v1"), {Kind: "Deployment", Name: "d"}})
+	require.True(t, ownedOnlyBy(obj, true, "ns", "a.v1"))
+
+	obj.SetOwnerReferences([]metav1.OwnerReference{owner("a.v1"), owner("a.v2")})
+	require.False(t, ownedOnlyBy(obj, true, "ns", "a.v1"))
+
+	csv := &v1alpha1.ClusterServiceVersion{ObjectMeta: metav1.ObjectMeta{Name: "a.v1", Namespace: "ns"}}
+	csv.SetGroupVersionKind(v1alpha1.SchemeGroupVersion.WithKind(v1alpha1.ClusterServiceVersionKind))
+	clusterObj := &unstructured.Unstructured{}
+	require.NoError(t, ownerutil.AddOwnerLabels(clusterObj, csv))
+	require.True(t, ownedOnlyBy(clusterObj, false, "ns", "a.v1"))
+	require.False(t, ownedOnlyBy(clusterObj, false, "other", "a.v1"))
+	require.False(t, ownedOnlyBy(clusterObj, false, "ns", "a.v2"))
+}
+
+func TestPrunesObjects(t *testing.T) {
+	require.True(t, prunesObjects(&v1alpha1.ClusterServiceVersion{}))
+	require.False(t, prunesObjects(&v1alpha1.ClusterServiceVersion{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{PruneAnnotationKey: "false"}}}))
+}
@@ -27,8 +27,10 @@ type SourceRef struct {
 
 type SourceQuerier interface {
 	// Deprecated: This FindReplacement function will be deprecated soon
-	FindReplacement(currentVersion *semver.Version, bundleName, pkgName, channelName string, initialSource registry.CatalogKey) (*api.Bundle, *registry.CatalogKey, error)
+	FindReplacement(ctx context.Context, currentVersion *semver.Version, bundleName, pkgName, channelName string, initialSource registry.CatalogKey) (*api.Bundle, *registry.CatalogKey, error)
 	Queryable() error
+	FindChannelHead(ctx context.Context, pkgName, channelName string, source registry.CatalogKey) (*api.Bundle, error)
+	FindBundle(ctx context.Context, pkgName, channelName, csvName string, source registry.CatalogKey) (*api.Bundle, error)
 }
 
 type NamespaceSourceQuerier struct {
@@ -50,8 +52,26 @@ func (q *NamespaceSourceQuerier) Queryable() error {
 	return nil
 }
 
+// FindChannelHead returns the head of the given channel of a package served by the given source.
+func (q *NamespaceSourceQuerier) FindChannelHead(ctx context.Context, pkgName, channelName string, source registry.CatalogKey) (*api.Bundle, error) {
+	client, ok := q.sources[source]
+	if !ok {
+		return nil, fmt.Errorf("CatalogSource %s not found", source.Name)
+	}
+	return client.GetBundleInPackageChannel(ctx, pkgName, channelName)
+}
+
+// FindBundle returns the bundle with the given name in the given channel of a package served by the given source.
+func (q *NamespaceSourceQuerier) FindBundle(ctx context.Context, pkgName, channelName, csvName string, source registry.CatalogKey) (*api.Bundle, error) {
+	client, ok := q.sources[source]
+	if !ok {
+		return nil, fmt.Errorf("CatalogSource %s not found", source.Name)
+	}
+	return client.GetBundle(ctx, pkgName, channelName, csvName)
+}
+
 // Deprecated: This FindReplacement function will be deprecated soon
-func (q *NamespaceSourceQuerier) FindReplacement(currentVersion *semver.Version, bundleName, pkgName, channelName string, initialSource registry.CatalogKey) (*api.Bundle, *registry.CatalogKey, error) {
+func (q *NamespaceSourceQuerier) FindReplacement(ctx context.Context, currentVersion *semver.Version, bundleName, pkgName, channelName string, initialSource registry.CatalogKey) (*api.Bundle, *registry.CatalogKey, error) {
 	errs := []error{}
 
 	if initialSource.Name != "" && initialSource.Namespace != "" {
@@ -60,7 +80,7 @@ func (q *NamespaceSourceQuerier) FindReplacement(currentVersion *semver.Version,
 			return nil, nil, fmt.Errorf("CatalogSource %s not found", initialSource.Name)
 		}
 
-		bundle, err := q.findChannelHead(currentVersion, pkgName, channelName, source)
+		bundle, err := q.findChannelHead(ctx, currentVersion, pkgName, channelName, source)
 		if bundle != nil {
 			return bundle, &initialSource, nil
 		}
@@ -68,7 +88,7 @@ func (q *NamespaceSourceQuerier) FindReplacement(currentVersion *semver.Version,
 			errs = append(errs, err)
 		}
 
-		bundle, err = source.GetReplacementBundleInPackageChannel(context.TODO(), bundleName, pkgName, channelName)
+		bundle, err = source.GetReplacementBundleInPackageChannel(ctx, bundleName, pkgName, channelName)
 		if bundle != nil {
 			return bundle, &initialSource, nil
 		}
@@ -80,7 +100,7 @@ func (q *NamespaceSourceQuerier) FindReplacement(currentVersion *semver.Version,
 	}
 
 	for key, source := range q.sources {
-		bundle, err := q.findChannelHead(currentVersion, pkgName, channelName, source)
+		bundle, err := q.findChannelHead(ctx, currentVersion, pkgName, channelName, source)
 		if bundle != nil {
 			return bundle, &initialSource, nil
 		}
@@ -88,7 +108,7 @@ func (q *NamespaceSourceQuerier) FindReplacement(currentVersion *semver.Version,
 			errs = append(errs, err)
 		}
 
-		bundle, err = source.GetReplacementBundleInPackageChannel(context.TODO(), bundleName, pkgName, channelName)
+		bundle, err = source.GetReplacementBundleInPackageChannel(ctx, bundleName, pkgName, channelName)
 		if bundle != nil {
 			return bundle, &key, nil
 		}
@@ -99,12 +119,12 @@ func (q *NamespaceSourceQuerier) FindReplacement(currentVersion *semver.Version,
 	return nil, nil, errors.NewAggregate(errs)
 }
 
-func (q *NamespaceSourceQuerier) findChannelHead(currentVersion *semver.Version, pkgName, channelName string, source client.Interface) (*api.Bundle, error) {
+func (q *NamespaceSourceQuerier) findChannelHead(ctx context.Context, currentVersion *semver.Version, pkgName, channelName string, source client.Interface) (*api.Bundle, error) {
 	if currentVersion == nil {
 		return nil, nil
 	}
 
-	latest, err := source.GetBundleInPackageChannel(context.TODO(), pkgName, channelName)
+	latest, err := source.GetBundleInPackageChannel(ctx, pkgName, channelName)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,27 @@
+package catalog
+
+import (
+	"fmt"
+
+	"google.golang.org/grpc/connectivity"
+
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/registry/grpc"
+)
+
+// CheckCatalogConnectivity returns an error if the operator tracks connections to registry servers of CatalogSources
+// but none of them is ready, i.e. if the operator can't resolve anything from a grpc catalog.
+func (o *Operator) CheckCatalogConnectivity() error {
+	return catalogConnectivity(o.sources.States())
+}
+
+func catalogConnectivity(states []grpc.SourceState) error {
+	if len(states) == 0 {
+		return nil
+	}
+	for _, s := range states {
+		if s.State == connectivity.Ready {
+			return nil
+		}
+	}
+	return fmt.Errorf("none of the %d catalog source connections is ready", len(states))
+}
@@ -0,0 +1,21 @@
+package catalog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/connectivity"
+
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/registry"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/registry/grpc"
+)
+
+func TestCatalogConnectivity(t *testing.T) {
+	state := func(name string, s connectivity.State) grpc.SourceState {
+		return grpc.SourceState{Key: registry.CatalogKey{Namespace: "olm", Name: name}, State: s}
+	}
+
+	require.NoError(t, catalogConnectivity(nil), "no grpc catalogs")
+	require.NoError(t, catalogConnectivity([]grpc.SourceState{state("a", connectivity.TransientFailure), state("b", connectivity.Ready)}))
+	require.EqualError(t, catalogConnectivity([]grpc.SourceState{state("a", connectivity.TransientFailure), state("b", connectivity.Connecting)}), "none of the 2 catalog source connections is ready")
+}
@@ -0,0 +1,245 @@
+package catalog
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/api/reasons"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/registry/resolver"
+)
+
+const (
+	// RollbackDeadlineAnnotationKey is the Subscription annotation opting it into rollbacks. Set to a duration string
+	// (e.g. "10m"), it is how long a CSV the Subscription upgrades to has to reach the Succeeded phase once created.
+	// Past the deadline, the upgraded CSV is deleted and the InstallPlan steps of the last CSV that succeeded are
+	// re-applied. Removing the annotation disables rollbacks and forgets the operator rolled back from, which the
+	// Subscription may upgrade to again.
+	RollbackDeadlineAnnotationKey = "operatorframework.io/rollback-deadline"
+
+	// SubscriptionRolledBack is the condition of a Subscription whose last upgrade was rolled back.
+	SubscriptionRolledBack v1alpha1.SubscriptionConditionType = "RolledBack"
+)
+
+// RollbackDeadlineFor returns the rollback deadline set on the given Subscription, or zero if it isn't opted into
+// rollbacks.
+func RollbackDeadlineFor(sub *v1alpha1.Subscription) (time.Duration, error) {
+	value, ok := sub.GetAnnotations()[RollbackDeadlineAnnotationKey]
+	if !ok {
+		return 0, nil
+	}
+	deadline, err := time.ParseDuration(value)
+	if err != nil || deadline <= 0 {
+		return 0, fmt.Errorf("invalid %s annotation: must be a positive duration", RollbackDeadlineAnnotationKey)
+	}
+	return deadline, nil
+}
+
+// ensureSubscriptionRollback maintains the rollback state of the given Subscription: it records the last CSV the
+// Subscription installed that succeeded along with its InstallPlan, and rolls back to them when the CSV the
+// Subscription upgraded to doesn't succeed before the rollback deadline.
+func (o *Operator) ensureSubscriptionRollback(ctx context.Context, logger *logrus.Entry, sub *v1alpha1.Subscription) (*v1alpha1.Subscription, bool, error) {
+	deadline, err := RollbackDeadlineFor(sub)
+	if err != nil {
+		logger.WithError(err).Warn("ignoring invalid rollback deadline")
+	}
+	rollback, err := resolver.RollbackFor(sub)
+	if err != nil {
+		logger.WithError(err).Warn("ignoring invalid rollback state")
+		rollback = nil
+	}
+
+	if deadline == 0 {
+		if _, ok := sub.GetAnnotations()[resolver.SubscriptionRollbackAnnotationKey]; !ok {
+			return sub, false, nil
+		}
+		out := sub.DeepCopy()
+		delete(out.Annotations, resolver.SubscriptionRollbackAnnotationKey)
+		return o.updateSubscriptionRollback(ctx, logger, out)
+	}
+
+	current := sub.Status.CurrentCSV
+	if current == "" {
+		return sub, false, nil
+	}
+	csv, err := o.lister.OperatorsV1alpha1().ClusterServiceVersionLister().ClusterServiceVersions(sub.GetNamespace()).Get(current)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return nil, false, err
+	}
+	if err != nil {
+		csv = nil
+	}
+
+	// record the installed csv once it succeeds, with the installplan that installed it
+	if csv != nil && csv.Status.Phase == v1alpha1.CSVPhaseSucceeded {
+		if rollback != nil && rollback.CSV == current || sub.Status.InstalledCSV != current || sub.Status.InstallPlanRef == nil {
+			return sub, false, nil
+		}
+		ip, err := o.client.OperatorsV1alpha1().InstallPlans(sub.GetNamespace()).Get(ctx, sub.Status.InstallPlanRef.Name, metav1.GetOptions{})
+		if err != nil || len(rollbackSteps(ip, current)) == 0 {
+			logger.WithError(err).Debug("installplan of succeeded csv can't be rolled back to")
+			return sub, false, nil
+		}
+		out := sub.DeepCopy()
+		if err := setRollback(out, &resolver.Rollback{CSV: current, InstallPlan: ip.GetName()}); err != nil {
+			return nil, false, err
+		}
+		out, _, err = o.updateSubscriptionRollback(ctx, logger, out)
+		if err != nil {
+			return nil, false, err
+		}
+		// a rollback is no longer relevant once an upgrade succeeded
+		if out.Status.GetCondition(SubscriptionRolledBack).Status != corev1.ConditionUnknown {
+			out.Status.RemoveConditions(SubscriptionRolledBack)
+			out.Status.LastUpdated = o.now()
+			if out, err = o.client.OperatorsV1alpha1().Subscriptions(out.GetNamespace()).UpdateStatus(ctx, out, metav1.UpdateOptions{}); err != nil {
+				return nil, false, fmt.Errorf("error updating Subscription status: %v", err)
+			}
+		}
+		return out, true, nil
+	}
+
+	if rollback == nil || rollback.CSV == current || rollback.FailedCSV == current {
+		return sub, false, nil
+	}
+
+	// the subscription is upgrading, check whether the upgraded csv is past its deadline
+	var started metav1.Time
+	switch {
+	case csv != nil:
+		started = csv.GetCreationTimestamp()
+	case sub.Status.InstallPlanRef != nil:
+		ip, err := o.client.OperatorsV1alpha1().InstallPlans(sub.GetNamespace()).Get(ctx, sub.Status.InstallPlanRef.Name, metav1.GetOptions{})
+		if err != nil {
+			return sub, false, nil
+		}
+		started = ip.GetCreationTimestamp()
+	default:
+		return sub, false, nil
+	}
+	if o.now().Sub(started.Time) < deadline {
+		if err := o.subQueueSet.RequeueAfter(sub.GetNamespace(), sub.GetName(), deadline-o.now().Sub(started.Time)); err != nil {
+			logger.WithError(err).Debug("unable to requeue subscription until its rollback deadline")
+		}
+		return sub, false, nil
+	}
+
+	return o.rollbackSubscription(ctx, logger, sub, rollback, deadline)
+}
+
+// rollbackSubscription deletes the CSV the given Subscription upgraded to and re-applies, in a new InstallPlan, the
+// steps of the InstallPlan that installed the CSV recorded in its rollback state.
+func (o *Operator) rollbackSubscription(ctx context.Context, logger *logrus.Entry, sub *v1alpha1.Subscription, rollback *resolver.Rollback, deadline time.Duration) (*v1alpha1.Subscription, bool, error) {
+	failed := sub.Status.CurrentCSV
+	logger = logger.WithFields(logrus.Fields{"from": failed, "to": rollback.CSV})
+
+	ip, err := o.client.OperatorsV1alpha1().InstallPlans(sub.GetNamespace()).Get(ctx, rollback.InstallPlan, metav1.GetOptions{})
+	if err != nil {
+		logger.WithError(err).Warn("unable to roll back, retained installplan not found")
+		return sub, false, nil
+	}
+	steps := rollbackSteps(ip, rollback.CSV)
+	if len(steps) == 0 {
+		logger.Warn("unable to roll back, retained installplan has no steps")
+		return sub, false, nil
+	}
+
+	logger.Info("upgraded csv missed its rollback deadline, rolling back")
+	if err := o.client.OperatorsV1alpha1().ClusterServiceVersions(sub.GetNamespace()).Delete(ctx, failed, metav1.DeleteOptions{}); err != nil && !k8serrors.IsNotFound(err) {
+		return nil, false, err
+	}
+
+	installPlans, err := o.listInstallPlans(ctx, sub.GetNamespace())
+	if err != nil {
+		return nil, false, err
+	}
+	gen := sub.Status.InstallPlanGeneration
+	for _, ip := range installPlans {
+		if ip.Spec.Generation > gen {
+			gen = ip.Spec.Generation
+		}
+	}
+	gen++
+	// the installplan was approved when first applied, so the rollback is applied without approval
+	ref, err := o.createInstallPlan(ctx, sub.GetNamespace(), gen, []*v1alpha1.Subscription{sub}, v1alpha1.ApprovalAutomatic, steps, nil)
+	if err != nil {
+		return nil, false, err
+	}
+
+	now := o.now()
+	out := sub.DeepCopy()
+	rollback.FailedCSV = failed
+	rollback.RolledBackAt = &now
+	if err := setRollback(out, rollback); err != nil {
+		return nil, false, err
+	}
+	out, _, err = o.updateSubscriptionRollback(ctx, logger, out)
+	if err != nil {
+		return nil, false, err
+	}
+
+	message := fmt.Sprintf("rolled back from %s to %s: %s did not reach the Succeeded phase within %s", failed, rollback.CSV, failed, deadline)
+	out.Status.CurrentCSV = rollback.CSV
+	out.Status.InstallPlanRef = ref
+	out.Status.Install = v1alpha1.NewInstallPlanReference(ref)
+	out.Status.InstallPlanGeneration = gen
+	out.Status.State = v1alpha1.SubscriptionStateUpgradePending
+	out.Status.LastUpdated = now
+	out.Status.SetCondition(v1alpha1.SubscriptionCondition{
+		Type:               SubscriptionRolledBack,
+		Status:             corev1.ConditionTrue,
+		Reason:             string(reasons.SubscriptionUpgradeDeadlineExceeded),
+		Message:            message,
+		LastTransitionTime: &now,
+	})
+	updated, err := o.client.OperatorsV1alpha1().Subscriptions(out.GetNamespace()).UpdateStatus(ctx, out, metav1.UpdateOptions{})
+	if err != nil {
+		return nil, false, fmt.Errorf("error updating Subscription status: %v", err)
+	}
+	o.recorder.Event(updated, corev1.EventTypeWarning, string(SubscriptionRolledBack), message)
+	return updated, true, nil
+}
+
+func (o *Operator) updateSubscriptionRollback(ctx context.Context, logger *logrus.Entry, sub *v1alpha1.Subscription) (*v1alpha1.Subscription, bool, error) {
+	updated, err := o.client.OperatorsV1alpha1().Subscriptions(sub.GetNamespace()).Update(ctx, sub, metav1.UpdateOptions{})
+	if err != nil {
+		logger.WithError(err).Info("error updating subscription rollback state")
+		return nil, false, fmt.Errorf("error updating Subscription: %v", err)
+	}
+	return updated, true, nil
+}
+
+func setRollback(sub *v1alpha1.Subscription, rollback *resolver.Rollback) error {
+	value, err := json.Marshal(rollback)
+	if err != nil {
+		return err
+	}
+	annotations := sub.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[resolver.SubscriptionRollbackAnnotationKey] = string(value)
+	sub.SetAnnotations(annotations)
+	return nil
+}
+
+// rollbackSteps returns copies of the steps of the given InstallPlan resolving the given CSV, to be applied again.
+func rollbackSteps(ip *v1alpha1.InstallPlan, csv string) []*v1alpha1.Step {
+	var steps []*v1alpha1.Step
+	for _, step := range ip.Status.Plan {
+		if step == nil || step.Resolving != csv {
+			continue
+		}
+		s := step.DeepCopy()
+		s.Status = v1alpha1.StepStatusUnknown
+		steps = append(steps, s)
+	}
+	return steps
+}
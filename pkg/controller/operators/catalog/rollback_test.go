@@ -0,0 +1,134 @@
+package catalog
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilclock "k8s.io/apimachinery/pkg/util/clock"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/registry/resolver"
+)
+
+func TestEnsureSubscriptionRollback(t *testing.T) {
+	now := time.Date(2021, time.October, 1, 12, 0, 0, 0, time.UTC)
+	csv := func(name string, phase v1alpha1.ClusterServiceVersionPhase, age time.Duration) *v1alpha1.ClusterServiceVersion {
+		return &v1alpha1.ClusterServiceVersion{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: name, CreationTimestamp: metav1.NewTime(now.Add(-age))},
+			Status:     v1alpha1.ClusterServiceVersionStatus{Phase: phase},
+		}
+	}
+	ip := &v1alpha1.InstallPlan{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "install-v1", CreationTimestamp: metav1.NewTime(now.Add(-time.Hour))},
+		Spec:       v1alpha1.InstallPlanSpec{Generation: 1},
+		Status: v1alpha1.InstallPlanStatus{
+			Phase: v1alpha1.InstallPlanPhaseComplete,
+			Plan: []*v1alpha1.Step{
+				{Resolving: "v1", Resource: v1alpha1.StepResource{Kind: "ClusterServiceVersion", Name: "v1"}, Status: v1alpha1.StepStatusCreated},
+				{Resolving: "dependency", Resource: v1alpha1.StepResource{Kind: "ClusterServiceVersion", Name: "dependency"}, Status: v1alpha1.StepStatusCreated},
+			},
+		},
+	}
+	sub := func(annotations map[string]string, current, installPlan string) *v1alpha1.Subscription {
+		return &v1alpha1.Subscription{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "sub", Annotations: annotations},
+			Spec:       &v1alpha1.SubscriptionSpec{Package: "package", Channel: "alpha"},
+			Status: v1alpha1.SubscriptionStatus{
+				CurrentCSV:            current,
+				InstalledCSV:          current,
+				InstallPlanRef:        &corev1.ObjectReference{Namespace: "ns", Name: installPlan},
+				InstallPlanGeneration: 2,
+			},
+		}
+	}
+	recorded := `{"csv":"v1","installPlan":"install-v1"}`
+
+	tests := []struct {
+		name     string
+		sub      *v1alpha1.Subscription
+		csvs     []runtime.Object
+		updated  bool
+		rollback *resolver.Rollback
+	}{
+		{
+			name:     "RecordsSucceededCSV",
+			sub:      sub(map[string]string{RollbackDeadlineAnnotationKey: "10m"}, "v1", "install-v1"),
+			csvs:     []runtime.Object{csv("v1", v1alpha1.CSVPhaseSucceeded, time.Hour)},
+			updated:  true,
+			rollback: &resolver.Rollback{CSV: "v1", InstallPlan: "install-v1"},
+		},
+		{
+			name: "WaitsForDeadline",
+			sub: sub(map[string]string{
+				RollbackDeadlineAnnotationKey:              "10m",
+				resolver.SubscriptionRollbackAnnotationKey: recorded,
+			}, "v2", "install-v2"),
+			csvs:     []runtime.Object{csv("v1", v1alpha1.CSVPhaseReplacing, time.Hour), csv("v2", v1alpha1.CSVPhaseInstalling, 5*time.Minute)},
+			rollback: &resolver.Rollback{CSV: "v1", InstallPlan: "install-v1"},
+		},
+		{
+			name: "RollsBackPastDeadline",
+			sub: sub(map[string]string{
+				RollbackDeadlineAnnotationKey:              "10m",
+				resolver.SubscriptionRollbackAnnotationKey: recorded,
+			}, "v2", "install-v2"),
+			csvs:     []runtime.Object{csv("v1", v1alpha1.CSVPhaseReplacing, time.Hour), csv("v2", v1alpha1.CSVPhaseFailed, 20*time.Minute)},
+			updated:  true,
+			rollback: &resolver.Rollback{CSV: "v1", InstallPlan: "install-v1", FailedCSV: "v2"},
+		},
+		{
+			name:    "Disabled",
+			sub:     sub(map[string]string{resolver.SubscriptionRollbackAnnotationKey: recorded}, "v2", "install-v2"),
+			csvs:    []runtime.Object{csv("v2", v1alpha1.CSVPhaseFailed, 20*time.Minute)},
+			updated: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.TODO())
+			defer cancel()
+
+			objs := append([]runtime.Object{tt.sub, ip}, tt.csvs...)
+			op, err := NewFakeOperator(ctx, "ns", []string{"ns"}, withClock(utilclock.NewFakeClock(now)), withClientObjs(objs...))
+			require.NoError(t, err)
+
+			out, updated, err := op.ensureSubscriptionRollback(ctx, logrus.NewEntry(logrus.New()), tt.sub)
+			require.NoError(t, err)
+			require.Equal(t, tt.updated, updated)
+
+			rollback, err := resolver.RollbackFor(out)
+			require.NoError(t, err)
+			if rollback != nil {
+				rollback.RolledBackAt = nil
+			}
+			require.Equal(t, tt.rollback, rollback)
+
+			if tt.rollback == nil || tt.rollback.FailedCSV == "" {
+				require.Equal(t, corev1.ConditionUnknown, out.Status.GetCondition(SubscriptionRolledBack).Status)
+				return
+			}
+
+			// the failed csv is deleted and the steps of the retained installplan are applied again
+			_, err = op.client.OperatorsV1alpha1().ClusterServiceVersions("ns").Get(ctx, "v2", metav1.GetOptions{})
+			require.True(t, k8serrors.IsNotFound(err))
+			require.Equal(t, "v1", out.Status.CurrentCSV)
+			require.EqualValues(t, v1alpha1.SubscriptionStateUpgradePending, out.Status.State)
+			require.Equal(t, corev1.ConditionTrue, out.Status.GetCondition(SubscriptionRolledBack).Status)
+
+			rollbackPlan, err := op.client.OperatorsV1alpha1().InstallPlans("ns").Get(ctx, out.Status.InstallPlanRef.Name, metav1.GetOptions{})
+			require.NoError(t, err)
+			require.Equal(t, 3, rollbackPlan.Spec.Generation)
+			require.True(t, rollbackPlan.Spec.Approved)
+			require.Len(t, rollbackPlan.Status.Plan, 1)
+			require.Equal(t, "v1", rollbackPlan.Status.Plan[0].Resource.Name)
+			require.Equal(t, v1alpha1.StepStatusUnknown, rollbackPlan.Status.Plan[0].Status)
+		})
+	}
+}
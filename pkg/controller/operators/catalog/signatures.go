@@ -0,0 +1,70 @@
+package catalog
+
+import (
+	"context"
+	"time"
+
+	"github.com/distribution/distribution/reference"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/imagesig"
+)
+
+// signatureVerificationTimeout bounds the lookup and verification of the signatures of an image.
+const signatureVerificationTimeout = 30 * time.Second
+
+// verifyImage verifies the signatures of the given catalog or bundle image against the image signature policy of the
+// cluster OLMConfig, if any, and returns the image to pull: the image at the digest verified, so that a tag moving
+// after its verification can't swap what's pulled, or the image itself without a policy. An invalid policy fails the
+// verification of all images, rather than letting them through. Mirrored images are verified in the mirror of their
+// repository, which they are pulled from, with the image pull secrets of the given names in the given namespace.
+func (o *Operator) verifyImage(ctx context.Context, image, namespace string, pullSecrets []string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, signatureVerificationTimeout)
+	defer cancel()
+
+	olmConfig, err := o.olmConfigLister.Get("cluster")
+	if k8serrors.IsNotFound(err) {
+		return image, nil
+	}
+	if err != nil {
+		return "", err
+	}
+	policy, err := imagesig.ParsePolicy(olmConfig.GetAnnotations())
+	if err != nil {
+		return "", &imagesig.VerificationError{Image: image, Reason: err.Error()}
+	}
+	if policy == nil {
+		return image, nil
+	}
+	secrets, err := o.pullSecrets(namespace, pullSecrets)
+	if err != nil {
+		return "", err
+	}
+	digest, err := o.signatures.Verify(ctx, policy, image, o.imageMirrors(olmConfig.GetAnnotations()).Rewrite(image), secrets)
+	if err != nil {
+		return "", err
+	}
+	named, err := reference.ParseNormalizedNamed(image)
+	if err != nil {
+		return "", err
+	}
+	return named.Name() + "@" + digest, nil
+}
+
+// pullSecrets returns the image pull secrets of the given names in the given namespace. Missing secrets are skipped, as
+// the kubelet does when pulling images.
+func (o *Operator) pullSecrets(namespace string, names []string) ([]*corev1.Secret, error) {
+	var secrets []*corev1.Secret
+	for _, name := range names {
+		secret, err := o.lister.CoreV1().SecretLister().Secrets(namespace).Get(name)
+		if k8serrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		secrets = append(secrets, secret)
+	}
+	return secrets, nil
+}
@@ -24,14 +24,14 @@ import (
 
 // Stepper manages cluster interactions based on the step.
 type Stepper interface {
-	Status() (v1alpha1.StepStatus, error)
+	Status(ctx context.Context) (v1alpha1.StepStatus, error)
 }
 
 // StepperFunc fulfills the Stepper interface.
-type StepperFunc func() (v1alpha1.StepStatus, error)
+type StepperFunc func(ctx context.Context) (v1alpha1.StepStatus, error)
 
-func (s StepperFunc) Status() (v1alpha1.StepStatus, error) {
-	return s()
+func (s StepperFunc) Status(ctx context.Context) (v1alpha1.StepStatus, error) {
+	return s(ctx)
 }
 
 // Builder holds clients and data structures required for the StepBuilder to work
@@ -91,14 +91,14 @@ func (b *builder) create(step v1alpha1.Step) (Stepper, error) {
 }
 
 func (b *builder) NewCRDV1Step(client apiextensionsv1client.ApiextensionsV1Interface, step *v1alpha1.Step, manifest string) StepperFunc {
-	return func() (v1alpha1.StepStatus, error) {
+	return func(ctx context.Context) (v1alpha1.StepStatus, error) {
 		switch step.Status {
 		case v1alpha1.StepStatusPresent:
 			return v1alpha1.StepStatusPresent, nil
 		case v1alpha1.StepStatusCreated:
 			return v1alpha1.StepStatusCreated, nil
 		case v1alpha1.StepStatusWaitingForAPI:
-			crd, err := client.CustomResourceDefinitions().Get(context.TODO(), step.Resource.Name, metav1.GetOptions{})
+			crd, err := client.CustomResourceDefinitions().Get(ctx, step.Resource.Name, metav1.GetOptions{})
 			if err != nil {
 				if k8serrors.IsNotFound(err) {
 					return v1alpha1.StepStatusNotPresent, nil
@@ -129,12 +129,12 @@ func (b *builder) NewCRDV1Step(client apiextensionsv1client.ApiextensionsV1Inter
 
 			setInstalledAlongsideAnnotation(b.annotator, crd, b.plan.GetNamespace(), step.Resolving, b.csvLister, crd)
 
-			_, createError := client.CustomResourceDefinitions().Create(context.TODO(), crd, metav1.CreateOptions{})
+			_, createError := client.CustomResourceDefinitions().Create(ctx, crd, metav1.CreateOptions{})
 			if k8serrors.IsAlreadyExists(createError) {
 				err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
-					currentCRD, _ := client.CustomResourceDefinitions().Get(context.TODO(), crd.GetName(), metav1.GetOptions{})
+					currentCRD, _ := client.CustomResourceDefinitions().Get(ctx, crd.GetName(), metav1.GetOptions{})
 					crd.SetResourceVersion(currentCRD.GetResourceVersion())
-					if err = validateV1CRDCompatibility(b.dynamicClient, currentCRD, crd); err != nil {
+					if err = validateV1CRDCompatibility(ctx, b.dynamicClient, currentCRD, crd); err != nil {
 						return fmt.Errorf("error validating existing CRs against new CRD's schema for %q: %w", step.Resource.Name, err)
 					}
 
@@ -148,9 +148,10 @@ func (b *builder) NewCRDV1Step(client apiextensionsv1client.ApiextensionsV1Inter
 						return fmt.Errorf("checking CRD for potential data loss updating %q: %w", step.Resource.Name, err)
 					}
 
-					// Update CRD to new version
+					// Update CRD to new version, keeping the conversion webhook OLM configured for its owner
+					crdlib.KeepManagedConversion(currentCRD, crd)
 					setInstalledAlongsideAnnotation(b.annotator, crd, b.plan.GetNamespace(), step.Resolving, b.csvLister, crd, currentCRD)
-					_, err = client.CustomResourceDefinitions().Update(context.TODO(), crd, metav1.UpdateOptions{})
+					_, err = client.CustomResourceDefinitions().Update(ctx, crd, metav1.UpdateOptions{})
 					if err != nil {
 						return fmt.Errorf("error updating CRD %q: %w", step.Resource.Name, err)
 					}
@@ -174,14 +175,14 @@ func (b *builder) NewCRDV1Step(client apiextensionsv1client.ApiextensionsV1Inter
 }
 
 func (b *builder) NewCRDV1Beta1Step(client apiextensionsv1beta1client.ApiextensionsV1beta1Interface, step *v1alpha1.Step, manifest string) StepperFunc {
-	return func() (v1alpha1.StepStatus, error) {
+	return func(ctx context.Context) (v1alpha1.StepStatus, error) {
 		switch step.Status {
 		case v1alpha1.StepStatusPresent:
 			return v1alpha1.StepStatusPresent, nil
 		case v1alpha1.StepStatusCreated:
 			return v1alpha1.StepStatusCreated, nil
 		case v1alpha1.StepStatusWaitingForAPI:
-			crd, err := client.CustomResourceDefinitions().Get(context.TODO(), step.Resource.Name, metav1.GetOptions{})
+			crd, err := client.CustomResourceDefinitions().Get(ctx, step.Resource.Name, metav1.GetOptions{})
 			if err != nil {
 				if k8serrors.IsNotFound(err) {
 					return v1alpha1.StepStatusNotPresent, nil
@@ -212,13 +213,13 @@ func (b *builder) NewCRDV1Beta1Step(client apiextensionsv1beta1client.Apiextensi
 
 			setInstalledAlongsideAnnotation(b.annotator, crd, b.plan.GetNamespace(), step.Resolving, b.csvLister, crd)
 
-			_, createError := client.CustomResourceDefinitions().Create(context.TODO(), crd, metav1.CreateOptions{})
+			_, createError := client.CustomResourceDefinitions().Create(ctx, crd, metav1.CreateOptions{})
 			if k8serrors.IsAlreadyExists(createError) {
 				err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
-					currentCRD, _ := client.CustomResourceDefinitions().Get(context.TODO(), crd.GetName(), metav1.GetOptions{})
+					currentCRD, _ := client.CustomResourceDefinitions().Get(ctx, crd.GetName(), metav1.GetOptions{})
 					crd.SetResourceVersion(currentCRD.GetResourceVersion())
 
-					if err = validateV1Beta1CRDCompatibility(b.dynamicClient, currentCRD, crd); err != nil {
+					if err = validateV1Beta1CRDCompatibility(ctx, b.dynamicClient, currentCRD, crd); err != nil {
 						return fmt.Errorf("error validating existing CRs against new CRD's schema for %q: %w", step.Resource.Name, err)
 					}
 
@@ -234,7 +235,7 @@ func (b *builder) NewCRDV1Beta1Step(client apiextensionsv1beta1client.Apiextensi
 
 					// Update CRD to new version
 					setInstalledAlongsideAnnotation(b.annotator, crd, b.plan.GetNamespace(), step.Resolving, b.csvLister, crd, currentCRD)
-					_, err = client.CustomResourceDefinitions().Update(context.TODO(), crd, metav1.UpdateOptions{})
+					_, err = client.CustomResourceDefinitions().Update(ctx, crd, metav1.UpdateOptions{})
 					if err != nil {
 						return fmt.Errorf("error updating CRD %q: %w", step.Resource.Name, err)
 					}
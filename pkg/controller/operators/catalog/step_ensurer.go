@@ -2,15 +2,19 @@ package catalog
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	errorwrap "github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
-	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/dynamic"
 
 	"github.com/operator-framework/api/pkg/operators/v1alpha1"
@@ -19,6 +23,9 @@ import (
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/ownerutil"
 )
 
+// InstallPlanFieldManager is the field manager the resources of InstallPlan steps are applied with.
+const InstallPlanFieldManager = "olm.install-plan"
+
 func newStepEnsurer(kubeClient operatorclient.ClientInterface, crClient versioned.Interface, dynamicClient dynamic.Interface) *StepEnsurer {
 	return &StepEnsurer{
 		kubeClient:    kubeClient,
@@ -35,9 +42,10 @@ type StepEnsurer struct {
 }
 
 // EnsureClusterServiceVersion writes the specified ClusterServiceVersion
-// object to the cluster.
-func (o *StepEnsurer) EnsureClusterServiceVersion(csv *v1alpha1.ClusterServiceVersion) (status v1alpha1.StepStatus, err error) {
-	_, createErr := o.crClient.OperatorsV1alpha1().ClusterServiceVersions(csv.GetNamespace()).Create(context.TODO(), csv, metav1.CreateOptions{})
+// object to the cluster. Unlike the other step resources, existing CSVs are
+// left as they are, as the olm operator manages them once created.
+func (o *StepEnsurer) EnsureClusterServiceVersion(ctx context.Context, csv *v1alpha1.ClusterServiceVersion) (status v1alpha1.StepStatus, err error) {
+	_, createErr := o.crClient.OperatorsV1alpha1().ClusterServiceVersions(csv.GetNamespace()).Create(ctx, csv, metav1.CreateOptions{FieldManager: InstallPlanFieldManager})
 	if createErr == nil {
 		status = v1alpha1.StepStatusCreated
 		return
@@ -53,8 +61,10 @@ func (o *StepEnsurer) EnsureClusterServiceVersion(csv *v1alpha1.ClusterServiceVe
 }
 
 // EnsureSubscription writes the specified Subscription object to the cluster.
-func (o *StepEnsurer) EnsureSubscription(subscription *v1alpha1.Subscription) (status v1alpha1.StepStatus, err error) {
-	_, createErr := o.crClient.OperatorsV1alpha1().Subscriptions(subscription.GetNamespace()).Create(context.TODO(), subscription, metav1.CreateOptions{})
+// Existing Subscriptions are left as they are, so that changes made by users
+// aren't reverted.
+func (o *StepEnsurer) EnsureSubscription(ctx context.Context, subscription *v1alpha1.Subscription) (status v1alpha1.StepStatus, err error) {
+	_, createErr := o.crClient.OperatorsV1alpha1().Subscriptions(subscription.GetNamespace()).Create(ctx, subscription, metav1.CreateOptions{FieldManager: InstallPlanFieldManager})
 	if createErr == nil {
 		status = v1alpha1.StepStatusCreated
 		return
@@ -71,8 +81,8 @@ func (o *StepEnsurer) EnsureSubscription(subscription *v1alpha1.Subscription) (s
 
 // EnsureSecret copies the secret from the OLM namespace and writes a new one
 // to the namespace requested.
-func (o *StepEnsurer) EnsureSecret(operatorNamespace, planNamespace, name string) (status v1alpha1.StepStatus, err error) {
-	secret, getError := o.kubeClient.KubernetesInterface().CoreV1().Secrets(operatorNamespace).Get(context.TODO(), name, metav1.GetOptions{})
+func (o *StepEnsurer) EnsureSecret(ctx context.Context, operatorNamespace, planNamespace, name string) (status v1alpha1.StepStatus, err error) {
+	secret, getError := o.kubeClient.KubernetesInterface().CoreV1().Secrets(operatorNamespace).Get(ctx, name, metav1.GetOptions{})
 	if getError != nil {
 		if k8serrors.IsNotFound(getError) {
 			err = fmt.Errorf("secret %s does not exist - %v", name, getError)
@@ -83,10 +93,7 @@ func (o *StepEnsurer) EnsureSecret(operatorNamespace, planNamespace, name string
 		return
 	}
 
-	// Set the namespace to the InstallPlan's namespace and attempt to
-	// create a new secret.
-	secret.SetNamespace(planNamespace)
-
+	// Copy the secret to the InstallPlan's namespace.
 	newSecret := &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      secret.Name,
@@ -96,259 +103,223 @@ func (o *StepEnsurer) EnsureSecret(operatorNamespace, planNamespace, name string
 		Type: secret.Type,
 	}
 
-	if _, createError := o.kubeClient.KubernetesInterface().CoreV1().Secrets(planNamespace).Create(context.TODO(), newSecret, metav1.CreateOptions{}); createError != nil {
-		if k8serrors.IsAlreadyExists(createError) {
-			status = v1alpha1.StepStatusPresent
-			return
-		}
-
-		err = fmt.Errorf("error creating secret %s - %v", secret.Name, createError)
-		return
+	secrets := o.kubeClient.KubernetesInterface().CoreV1().Secrets(planNamespace)
+	status, err = applyStepObject(newSecret, corev1.SchemeGroupVersion.WithKind(secretKind), func() error {
+		_, err := secrets.Get(ctx, newSecret.GetName(), metav1.GetOptions{})
+		return err
+	}, func(data []byte, options metav1.PatchOptions) error {
+		_, err := secrets.Patch(ctx, newSecret.GetName(), types.ApplyPatchType, data, options)
+		return err
+	})
+	if err != nil {
+		err = fmt.Errorf("error applying secret %s - %v", secret.Name, err)
 	}
-
-	status = v1alpha1.StepStatusCreated
 	return
 }
 
-// EnsureBundleSecret creates user-specified secrets from the bundle. Called when StepResource.Secret is true
-func (o *StepEnsurer) EnsureBundleSecret(namespace string, secret *corev1.Secret) (status v1alpha1.StepStatus, err error) {
-	_, createErr := o.kubeClient.KubernetesInterface().CoreV1().Secrets(namespace).Create(context.TODO(), secret, metav1.CreateOptions{})
-	if createErr == nil {
-		status = v1alpha1.StepStatusCreated
-		return
-	}
-
-	if !k8serrors.IsAlreadyExists(createErr) {
-		err = errorwrap.Wrapf(createErr, "error updating secret: %s", secret.GetName())
-		return
-	}
-
+// EnsureBundleSecret applies user-specified secrets from the bundle. Called when StepResource.Secret is true
+func (o *StepEnsurer) EnsureBundleSecret(ctx context.Context, namespace string, secret *corev1.Secret) (status v1alpha1.StepStatus, err error) {
 	secret.SetNamespace(namespace)
-	// NOTE: any annotations/changes applied to the secret are lost
-	if _, updateErr := o.kubeClient.UpdateSecret(secret); updateErr != nil {
-		err = errorwrap.Wrapf(updateErr, "error updating secret: %s", secret.GetName())
-		return
+	secrets := o.kubeClient.KubernetesInterface().CoreV1().Secrets(namespace)
+	status, err = applyStepObject(secret, corev1.SchemeGroupVersion.WithKind(secretKind), func() error {
+		_, err := secrets.Get(ctx, secret.GetName(), metav1.GetOptions{})
+		return err
+	}, func(data []byte, options metav1.PatchOptions) error {
+		_, err := secrets.Patch(ctx, secret.GetName(), types.ApplyPatchType, data, options)
+		return err
+	})
+	if err != nil {
+		err = errorwrap.Wrapf(err, "error applying secret: %s", secret.GetName())
 	}
-
-	status = v1alpha1.StepStatusPresent
 	return
 }
 
 // EnsureServiceAccount writes the specified ServiceAccount object to the cluster.
-func (o *StepEnsurer) EnsureServiceAccount(namespace string, sa *corev1.ServiceAccount) (status v1alpha1.StepStatus, err error) {
-	_, createErr := o.kubeClient.KubernetesInterface().CoreV1().ServiceAccounts(namespace).Create(context.TODO(), sa, metav1.CreateOptions{})
-	if createErr == nil {
-		status = v1alpha1.StepStatusCreated
-		return
-	}
-
-	if !k8serrors.IsAlreadyExists(createErr) {
-		err = errorwrap.Wrapf(createErr, "error creating service account: %s", sa.GetName())
-		return
-	}
-
-	// Carrying secrets through the service account update.
-	preSa, getErr := o.kubeClient.KubernetesInterface().CoreV1().ServiceAccounts(namespace).Get(context.TODO(),
-		sa.Name,
-		metav1.GetOptions{})
-	if getErr != nil {
-		err = errorwrap.Wrapf(getErr, "error getting older version of service account: %s", sa.GetName())
-		return
-	}
-	sa.Secrets = preSa.Secrets
-	sa.OwnerReferences = mergedOwnerReferences(preSa.OwnerReferences, sa.OwnerReferences)
-
+func (o *StepEnsurer) EnsureServiceAccount(ctx context.Context, namespace string, sa *corev1.ServiceAccount) (status v1alpha1.StepStatus, err error) {
 	sa.SetNamespace(namespace)
-
-	// Use DeepDerivative to check if new SA is the same as the old SA. If no field is changed, we skip the update call.
-	if !apiequality.Semantic.DeepDerivative(sa, preSa) {
-		if _, updateErr := o.kubeClient.UpdateServiceAccount(sa); updateErr != nil {
-			err = errorwrap.Wrapf(updateErr, "error updating service account: %s", sa.GetName())
-			return
+	serviceAccounts := o.kubeClient.KubernetesInterface().CoreV1().ServiceAccounts(namespace)
+	status, err = applyStepObject(sa, corev1.SchemeGroupVersion.WithKind(serviceAccountKind), func() error {
+		preSa, err := serviceAccounts.Get(ctx, sa.GetName(), metav1.GetOptions{})
+		if err != nil {
+			return err
 		}
+		// Service accounts are shared by the CSVs that need them: keep the owners applied by previous InstallPlans.
+		// The secrets of the service account are left to the token controller that manages them.
+		sa.OwnerReferences = mergedOwnerReferences(preSa.OwnerReferences, sa.OwnerReferences)
+		return nil
+	}, func(data []byte, options metav1.PatchOptions) error {
+		_, err := serviceAccounts.Patch(ctx, sa.GetName(), types.ApplyPatchType, data, options)
+		return err
+	})
+	if err != nil {
+		err = errorwrap.Wrapf(err, "error applying service account: %s", sa.GetName())
 	}
-
-	status = v1alpha1.StepStatusPresent
 	return
 }
 
 // EnsureService writes the specified Service object to the cluster.
-func (o *StepEnsurer) EnsureService(namespace string, service *corev1.Service) (status v1alpha1.StepStatus, err error) {
-	_, createErr := o.kubeClient.KubernetesInterface().CoreV1().Services(namespace).Create(context.TODO(), service, metav1.CreateOptions{})
-	if createErr == nil {
-		status = v1alpha1.StepStatusCreated
-		return
-	}
-
-	if !k8serrors.IsAlreadyExists(createErr) {
-		err = errorwrap.Wrapf(createErr, "error updating service: %s", service.GetName())
-		return
-	}
-
+func (o *StepEnsurer) EnsureService(ctx context.Context, namespace string, service *corev1.Service) (status v1alpha1.StepStatus, err error) {
 	service.SetNamespace(namespace)
-	if _, updateErr := o.kubeClient.UpdateService(service); updateErr != nil {
-		err = errorwrap.Wrapf(updateErr, "error updating service: %s", service.GetName())
-		return
+	services := o.kubeClient.KubernetesInterface().CoreV1().Services(namespace)
+	status, err = applyStepObject(service, corev1.SchemeGroupVersion.WithKind(serviceKind), func() error {
+		_, err := services.Get(ctx, service.GetName(), metav1.GetOptions{})
+		return err
+	}, func(data []byte, options metav1.PatchOptions) error {
+		_, err := services.Patch(ctx, service.GetName(), types.ApplyPatchType, data, options)
+		return err
+	})
+	if err != nil {
+		err = errorwrap.Wrapf(err, "error applying service: %s", service.GetName())
 	}
-
-	status = v1alpha1.StepStatusPresent
 	return
 }
 
 // EnsureClusterRole writes the specified ClusterRole object to the cluster.
-func (o *StepEnsurer) EnsureClusterRole(cr *rbacv1.ClusterRole, step *v1alpha1.Step) (status v1alpha1.StepStatus, err error) {
-	_, createErr := o.kubeClient.KubernetesInterface().RbacV1().ClusterRoles().Create(context.TODO(), cr, metav1.CreateOptions{})
-	if createErr == nil {
-		status = v1alpha1.StepStatusCreated
-		return
-	}
-
-	if !k8serrors.IsAlreadyExists(createErr) {
-		err = errorwrap.Wrapf(createErr, "error creating clusterrole %s", cr.GetName())
-		return
-	}
-
-	// We're updating, point owner to the newest csv
+func (o *StepEnsurer) EnsureClusterRole(ctx context.Context, cr *rbacv1.ClusterRole, step *v1alpha1.Step) (status v1alpha1.StepStatus, err error) {
+	// Point owner to the newest csv
 	if cr.ObjectMeta.Labels == nil {
 		cr.ObjectMeta.Labels = map[string]string{}
 	}
 	cr.ObjectMeta.Labels[ownerutil.OwnerKey] = step.Resolving
-	if _, updateErr := o.kubeClient.UpdateClusterRole(cr); updateErr != nil {
-		err = errorwrap.Wrapf(updateErr, "error updating clusterrole %s", cr.GetName())
-		return
-	}
 
-	status = v1alpha1.StepStatusPresent
+	clusterRoles := o.kubeClient.KubernetesInterface().RbacV1().ClusterRoles()
+	status, err = applyStepObject(cr, rbacv1.SchemeGroupVersion.WithKind(clusterRoleKind), func() error {
+		_, err := clusterRoles.Get(ctx, cr.GetName(), metav1.GetOptions{})
+		return err
+	}, func(data []byte, options metav1.PatchOptions) error {
+		_, err := clusterRoles.Patch(ctx, cr.GetName(), types.ApplyPatchType, data, options)
+		return err
+	})
+	if err != nil {
+		err = errorwrap.Wrapf(err, "error applying clusterrole %s", cr.GetName())
+	}
 	return
 }
 
 // EnsureClusterRoleBinding writes the specified ClusterRoleBinding object to the cluster.
-func (o *StepEnsurer) EnsureClusterRoleBinding(crb *rbacv1.ClusterRoleBinding, step *v1alpha1.Step) (status v1alpha1.StepStatus, err error) {
-	_, createErr := o.kubeClient.KubernetesInterface().RbacV1().ClusterRoleBindings().Create(context.TODO(), crb, metav1.CreateOptions{})
-	if createErr == nil {
-		status = v1alpha1.StepStatusCreated
-		return
-	}
-
-	if !k8serrors.IsAlreadyExists(createErr) {
-		err = errorwrap.Wrapf(createErr, "error creating clusterrolebinding %s", crb.GetName())
-		return
-	}
-
-	// if we're updating, point owner to the newest csv
+func (o *StepEnsurer) EnsureClusterRoleBinding(ctx context.Context, crb *rbacv1.ClusterRoleBinding, step *v1alpha1.Step) (status v1alpha1.StepStatus, err error) {
+	// Point owner to the newest csv
 	if crb.ObjectMeta.Labels == nil {
 		crb.ObjectMeta.Labels = map[string]string{}
 	}
 	crb.ObjectMeta.Labels[ownerutil.OwnerKey] = step.Resolving
-	if _, updateErr := o.kubeClient.UpdateClusterRoleBinding(crb); updateErr != nil {
-		err = errorwrap.Wrapf(updateErr, "error updating clusterrolebinding %s", crb.GetName())
-		return
-	}
 
-	status = v1alpha1.StepStatusPresent
+	clusterRoleBindings := o.kubeClient.KubernetesInterface().RbacV1().ClusterRoleBindings()
+	status, err = applyStepObject(crb, rbacv1.SchemeGroupVersion.WithKind(clusterRoleBindingKind), func() error {
+		_, err := clusterRoleBindings.Get(ctx, crb.GetName(), metav1.GetOptions{})
+		return err
+	}, func(data []byte, options metav1.PatchOptions) error {
+		_, err := clusterRoleBindings.Patch(ctx, crb.GetName(), types.ApplyPatchType, data, options)
+		return err
+	})
+	if err != nil {
+		err = errorwrap.Wrapf(err, "error applying clusterrolebinding %s", crb.GetName())
+	}
 	return
 }
 
 // EnsureRole writes the specified Role object to the cluster.
-func (o *StepEnsurer) EnsureRole(namespace string, role *rbacv1.Role) (status v1alpha1.StepStatus, err error) {
-	_, createErr := o.kubeClient.KubernetesInterface().RbacV1().Roles(namespace).Create(context.TODO(), role, metav1.CreateOptions{})
-	if createErr == nil {
-		status = v1alpha1.StepStatusCreated
-		return
-	}
-
-	if !k8serrors.IsAlreadyExists(createErr) {
-		err = errorwrap.Wrapf(createErr, "error creating role %s", role.GetName())
-		return
-	}
-
-	// If it already existed, mark the step as Present.
+func (o *StepEnsurer) EnsureRole(ctx context.Context, namespace string, role *rbacv1.Role) (status v1alpha1.StepStatus, err error) {
 	role.SetNamespace(namespace)
-	if _, updateErr := o.kubeClient.UpdateRole(role); updateErr != nil {
-		err = errorwrap.Wrapf(updateErr, "error updating role %s", role.GetName())
-		return
+	roles := o.kubeClient.KubernetesInterface().RbacV1().Roles(namespace)
+	status, err = applyStepObject(role, rbacv1.SchemeGroupVersion.WithKind(roleKind), func() error {
+		_, err := roles.Get(ctx, role.GetName(), metav1.GetOptions{})
+		return err
+	}, func(data []byte, options metav1.PatchOptions) error {
+		_, err := roles.Patch(ctx, role.GetName(), types.ApplyPatchType, data, options)
+		return err
+	})
+	if err != nil {
+		err = errorwrap.Wrapf(err, "error applying role %s", role.GetName())
 	}
-
-	status = v1alpha1.StepStatusPresent
 	return
 }
 
 // EnsureRoleBinding writes the specified RoleBinding object to the cluster.
-func (o *StepEnsurer) EnsureRoleBinding(namespace string, rb *rbacv1.RoleBinding) (status v1alpha1.StepStatus, err error) {
-	_, createErr := o.kubeClient.KubernetesInterface().RbacV1().RoleBindings(namespace).Create(context.TODO(), rb, metav1.CreateOptions{})
-	if createErr == nil {
-		status = v1alpha1.StepStatusCreated
-		return
-	}
-
-	if !k8serrors.IsAlreadyExists(createErr) {
-		err = errorwrap.Wrapf(createErr, "error creating rolebinding %s", rb.GetName())
-		return
-	}
-
+func (o *StepEnsurer) EnsureRoleBinding(ctx context.Context, namespace string, rb *rbacv1.RoleBinding) (status v1alpha1.StepStatus, err error) {
 	rb.SetNamespace(namespace)
-	if _, updateErr := o.kubeClient.UpdateRoleBinding(rb); updateErr != nil {
-		err = errorwrap.Wrapf(updateErr, "error updating rolebinding %s", rb.GetName())
-		return
+	roleBindings := o.kubeClient.KubernetesInterface().RbacV1().RoleBindings(namespace)
+	status, err = applyStepObject(rb, rbacv1.SchemeGroupVersion.WithKind(roleBindingKind), func() error {
+		_, err := roleBindings.Get(ctx, rb.GetName(), metav1.GetOptions{})
+		return err
+	}, func(data []byte, options metav1.PatchOptions) error {
+		_, err := roleBindings.Patch(ctx, rb.GetName(), types.ApplyPatchType, data, options)
+		return err
+	})
+	if err != nil {
+		err = errorwrap.Wrapf(err, "error applying rolebinding %s", rb.GetName())
 	}
-
-	status = v1alpha1.StepStatusPresent
 	return
 }
 
 // EnsureUnstructuredObject writes the unspecified resource object to the cluster.
-func (o *StepEnsurer) EnsureUnstructuredObject(client dynamic.ResourceInterface, obj *unstructured.Unstructured) (status v1alpha1.StepStatus, err error) {
-	_, createErr := client.Create(context.TODO(), obj, metav1.CreateOptions{})
-	if createErr == nil {
-		status = v1alpha1.StepStatusCreated
-		return
-	}
-
-	if !k8serrors.IsAlreadyExists(createErr) {
-		err = errorwrap.Wrapf(createErr, "error creating unstructured object %s", obj.GetName())
-		return
+func (o *StepEnsurer) EnsureUnstructuredObject(ctx context.Context, client dynamic.ResourceInterface, obj *unstructured.Unstructured) (status v1alpha1.StepStatus, err error) {
+	status, err = applyStepObject(obj, obj.GroupVersionKind(), func() error {
+		_, err := client.Get(ctx, obj.GetName(), metav1.GetOptions{})
+		return err
+	}, func(data []byte, options metav1.PatchOptions) error {
+		_, err := client.Patch(ctx, obj.GetName(), types.ApplyPatchType, data, options)
+		return err
+	})
+	if err != nil {
+		err = errorwrap.Wrapf(err, "error applying unstructured object %s", obj.GetName())
 	}
+	return
+}
 
-	original, getError := client.Get(context.TODO(), obj.GetName(), metav1.GetOptions{})
-	if getError != nil {
-		err = errorwrap.Wrapf(getError, "error getting unstructured object %s", obj.GetName())
-		return
-	}
-
-	// Set the objects resource version
-	obj.SetResourceVersion(original.GetResourceVersion())
-
-	_, updateError := client.Update(context.TODO(), obj, metav1.UpdateOptions{})
-	if updateError != nil {
-		err = errorwrap.Wrapf(updateError, "error updating unstructured object %s", obj.GetName())
-		return
+// EnsureConfigMap writes the specified ConfigMap object to the cluster.
+func (o *StepEnsurer) EnsureConfigMap(ctx context.Context, namespace string, configmap *corev1.ConfigMap) (status v1alpha1.StepStatus, err error) {
+	configmap.SetNamespace(namespace)
+	configMaps := o.kubeClient.KubernetesInterface().CoreV1().ConfigMaps(namespace)
+	status, err = applyStepObject(configmap, corev1.SchemeGroupVersion.WithKind(configMapKind), func() error {
+		_, err := configMaps.Get(ctx, configmap.GetName(), metav1.GetOptions{})
+		return err
+	}, func(data []byte, options metav1.PatchOptions) error {
+		_, err := configMaps.Patch(ctx, configmap.GetName(), types.ApplyPatchType, data, options)
+		return err
+	})
+	if err != nil {
+		err = errorwrap.Wrapf(err, "error applying configmap: %s", configmap.GetName())
 	}
-
-	status = v1alpha1.StepStatusPresent
 	return
 }
 
-// EnsureConfigMap writes the specified ConfigMap object to the cluster.
-func (o *StepEnsurer) EnsureConfigMap(namespace string, configmap *corev1.ConfigMap) (status v1alpha1.StepStatus, err error) {
-	_, createErr := o.kubeClient.KubernetesInterface().CoreV1().ConfigMaps(namespace).Create(context.TODO(), configmap, metav1.CreateOptions{})
-	if createErr == nil {
+// applyStepObject server-side applies the given object, as InstallPlanFieldManager, with the given patch function. Fields
+// applied by previous InstallPlans that the object no longer sets are pruned, while the fields set by other managers are
+// left alone. The object is reported as created unless the given get function, called beforehand, finds it.
+func applyStepObject(obj runtime.Object, gvk schema.GroupVersionKind, get func() error, patch func(data []byte, options metav1.PatchOptions) error) (v1alpha1.StepStatus, error) {
+	status := v1alpha1.StepStatusPresent
+	if err := get(); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return "", err
+		}
 		status = v1alpha1.StepStatusCreated
-		return
 	}
 
-	if !k8serrors.IsAlreadyExists(createErr) {
-		err = errorwrap.Wrapf(createErr, "error updating configmap: %s", configmap.GetName())
-		return
+	data, err := stepApplyConfiguration(obj, gvk)
+	if err != nil {
+		return "", err
 	}
-
-	configmap.SetNamespace(namespace)
-	if _, updateErr := o.kubeClient.UpdateConfigMap(configmap); updateErr != nil {
-		err = errorwrap.Wrapf(updateErr, "error updating configmap: %s", configmap.GetName())
-		return
+	force := true
+	if err := patch(data, metav1.PatchOptions{FieldManager: InstallPlanFieldManager, Force: &force}); err != nil {
+		return "", err
 	}
+	return status, nil
+}
 
-	status = v1alpha1.StepStatusPresent
-	return
+// stepApplyConfiguration returns the apply patch of the given object: the object with its type set and without the
+// server-populated metadata that would otherwise be treated as preconditions.
+func stepApplyConfiguration(obj runtime.Object, gvk schema.GroupVersionKind) ([]byte, error) {
+	obj = obj.DeepCopyObject()
+	obj.GetObjectKind().SetGroupVersionKind(gvk)
+	m, err := meta.Accessor(obj)
+	if err != nil {
+		return nil, err
+	}
+	m.SetResourceVersion("")
+	m.SetUID("")
+	m.SetManagedFields(nil)
+	return json.Marshal(obj)
 }
 
 func mergedOwnerReferences(in ...[]metav1.OwnerReference) []metav1.OwnerReference {
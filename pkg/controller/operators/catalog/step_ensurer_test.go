@@ -1,11 +1,23 @@
 package catalog
 
 import (
+	"encoding/json"
+	"errors"
 	"testing"
 
+	jsonpatch "github.com/evanphx/json-patch"
 	"github.com/stretchr/testify/assert"
-
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	clienttesting "k8s.io/client-go/testing"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
 )
 
 func TestMergedOwnerReferences(t *testing.T) {
@@ -188,3 +200,127 @@ func TestMergedOwnerReferences(t *testing.T) {
 		})
 	}
 }
+
+func TestApplyStepObject(t *testing.T) {
+	notFound := k8serrors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, "cm")
+	for _, tc := range []struct {
+		Name   string
+		GetErr error
+		Status v1alpha1.StepStatus
+		Err    bool
+	}{
+		{
+			Name:   "created",
+			GetErr: notFound,
+			Status: v1alpha1.StepStatusCreated,
+		},
+		{
+			Name:   "present",
+			Status: v1alpha1.StepStatusPresent,
+		},
+		{
+			Name:   "get error",
+			GetErr: errors.New("get error"),
+			Err:    true,
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			cm := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "cm", Namespace: "ns", ResourceVersion: "42", UID: "uid"},
+				Data:       map[string]string{"key": "value"},
+			}
+
+			var (
+				patched []byte
+				options metav1.PatchOptions
+			)
+			status, err := applyStepObject(cm, corev1.SchemeGroupVersion.WithKind(configMapKind), func() error {
+				return tc.GetErr
+			}, func(data []byte, o metav1.PatchOptions) error {
+				patched, options = data, o
+				return nil
+			})
+			if tc.Err {
+				require.Error(t, err)
+				require.Nil(t, patched)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.Status, status)
+
+			require.Equal(t, InstallPlanFieldManager, options.FieldManager)
+			require.NotNil(t, options.Force)
+			require.True(t, *options.Force)
+
+			var applied unstructured.Unstructured
+			require.NoError(t, json.Unmarshal(patched, &applied.Object))
+			require.Equal(t, corev1.SchemeGroupVersion.WithKind(configMapKind), applied.GroupVersionKind())
+			require.Empty(t, applied.GetResourceVersion())
+			require.Empty(t, applied.GetUID())
+			require.Equal(t, map[string]interface{}{"key": "value"}, applied.Object["data"])
+
+			// The given object is left untouched
+			require.Equal(t, "42", cm.GetResourceVersion())
+		})
+	}
+}
+
+// applyPatchReactor returns a reactor handling the server-side apply patches the fake clientsets don't support. Like
+// the fake appliers of the controller-runtime client, patches are merged into existing objects, which are created when
+// missing. Objects are decoded with the given scheme, or as unstructured objects if nil.
+func applyPatchReactor(tracker clienttesting.ObjectTracker, scheme *runtime.Scheme) clienttesting.ReactionFunc {
+	return func(action clienttesting.Action) (bool, runtime.Object, error) {
+		patch, ok := action.(clienttesting.PatchActionImpl)
+		if !ok || patch.GetPatchType() != types.ApplyPatchType {
+			return false, nil, nil
+		}
+
+		var applied unstructured.Unstructured
+		if err := json.Unmarshal(patch.GetPatch(), &applied.Object); err != nil {
+			return true, nil, err
+		}
+		var obj runtime.Object = &unstructured.Unstructured{}
+		if scheme != nil {
+			typed, err := scheme.New(applied.GroupVersionKind())
+			if err != nil {
+				return true, nil, err
+			}
+			obj = typed
+		}
+
+		// Typed objects are stored without their type, as the typed clients return them
+		decode := func(data []byte) error {
+			if err := json.Unmarshal(data, obj); err != nil {
+				return err
+			}
+			if scheme != nil {
+				obj.GetObjectKind().SetGroupVersionKind(schema.GroupVersionKind{})
+			}
+			return nil
+		}
+
+		existing, err := tracker.Get(patch.GetResource(), patch.GetNamespace(), patch.GetName())
+		if k8serrors.IsNotFound(err) {
+			if err := decode(patch.GetPatch()); err != nil {
+				return true, nil, err
+			}
+			return true, obj, tracker.Create(patch.GetResource(), obj, patch.GetNamespace())
+		}
+		if err != nil {
+			return true, nil, err
+		}
+
+		original, err := json.Marshal(existing)
+		if err != nil {
+			return true, nil, err
+		}
+		merged, err := jsonpatch.MergePatch(original, patch.GetPatch())
+		if err != nil {
+			return true, nil, err
+		}
+		if err := decode(merged); err != nil {
+			return true, nil, err
+		}
+		return true, obj, tracker.Update(patch.GetResource(), obj, patch.GetNamespace())
+	}
+}
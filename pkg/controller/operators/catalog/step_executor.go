@@ -0,0 +1,152 @@
+package catalog
+
+import (
+	"k8s.io/client-go/dynamic"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/clients"
+)
+
+// installPlanStepWorkers is the maximum number of steps of an InstallPlan applied concurrently.
+const installPlanStepWorkers = 8
+
+// The waves InstallPlan steps are applied in. The steps of a wave are applied concurrently, once all the steps of the
+// previous waves have been applied.
+const (
+	// CRDs first, so that the custom resources of a bundle can be applied
+	crdWave = iota
+	// then CSVs, which the other objects of a bundle are owned by
+	csvWave
+	// then roles, service accounts and the other objects of the bundles
+	objectWave
+	// and finally the bindings to the roles, which can only be created once the roles they grant exist
+	bindingWave
+
+	waveCount
+)
+
+// stepWave returns the wave the given step is applied in.
+func stepWave(step *v1alpha1.Step) int {
+	switch step.Resource.Kind {
+	case crdKind:
+		return crdWave
+	case csvKind:
+		return csvWave
+	case roleBindingKind, clusterRoleBindingKind:
+		return bindingWave
+	default:
+		return objectWave
+	}
+}
+
+// stepWaves returns the indices of the given steps grouped in the waves they're applied in. Each wave is a list of
+// chains of steps applied one after the other, in plan order, as they target the same object, while the chains of a
+// wave are applied concurrently. Empty waves are omitted.
+func stepWaves(steps []*v1alpha1.Step) [][][]int {
+	type object struct {
+		group, kind, name string
+	}
+	waves := make([][][]int, waveCount)
+	chains := make([]map[object]int, waveCount)
+	for i, step := range steps {
+		wave := stepWave(step)
+		if chains[wave] == nil {
+			chains[wave] = map[object]int{}
+		}
+		o := object{group: step.Resource.Group, kind: step.Resource.Kind, name: step.Resource.Name}
+		if c, ok := chains[wave][o]; ok {
+			waves[wave][c] = append(waves[wave][c], i)
+			continue
+		}
+		chains[wave][o] = len(waves[wave])
+		waves[wave] = append(waves[wave], []int{i})
+	}
+
+	nonEmpty := make([][][]int, 0, waveCount)
+	for _, wave := range waves {
+		if len(wave) > 0 {
+			nonEmpty = append(nonEmpty, wave)
+		}
+	}
+	return nonEmpty
+}
+
+// planExecution is the state shared by the steps of an InstallPlan being executed.
+type planExecution struct {
+	plan              *v1alpha1.InstallPlan
+	initialCSVNames   map[string]struct{}
+	existingCRDOwners map[string][]string
+	manifests         *manifestResolver
+	discoveryQuerier  *discoveryQuerier
+}
+
+// stepExecutor applies the steps of an InstallPlan, one at a time, with clients of its own so that the warnings
+// returned by the apiserver are attributed to the step that caused them while other executors apply other steps.
+type stepExecutor struct {
+	wr            *warningRecorder
+	ensurer       *StepEnsurer
+	builder       *builder
+	dynamicClient dynamic.Interface
+}
+
+// newStepExecutors returns a pool of executors for the steps of the given InstallPlan, one for each chain of its largest
+// wave up to installPlanStepWorkers, and at least one.
+func (o *Operator) newStepExecutors(plan *v1alpha1.InstallPlan, attenuate clients.ConfigTransformer, manifests *manifestResolver, waves [][][]int) (chan *stepExecutor, error) {
+	workers := 1
+	for _, wave := range waves {
+		if len(wave) > workers {
+			workers = len(wave)
+		}
+	}
+	if workers > installPlanStepWorkers {
+		workers = installPlanStepWorkers
+	}
+
+	executors := make(chan *stepExecutor, workers)
+	for n := 0; n < workers; n++ {
+		e, err := o.newStepExecutor(plan, attenuate, manifests)
+		if err != nil {
+			return nil, err
+		}
+		executors <- e
+	}
+	return executors, nil
+}
+
+func (o *Operator) newStepExecutor(plan *v1alpha1.InstallPlan, attenuate clients.ConfigTransformer, manifests *manifestResolver) (*stepExecutor, error) {
+	wr := &warningRecorder{}
+	factory := o.clientFactory.WithConfigTransformer(clients.SetWarningHandler(wr))
+
+	attenuatedFactory := factory.WithConfigTransformer(attenuate)
+	kubeclient, err := attenuatedFactory.NewOperatorClient()
+	if err != nil {
+		return nil, err
+	}
+	crclient, err := attenuatedFactory.NewKubernetesClient()
+	if err != nil {
+		return nil, err
+	}
+	dynamicClient, err := attenuatedFactory.NewDynamicClient()
+	if err != nil {
+		return nil, err
+	}
+
+	// CRDs should be installed via the default OLM (cluster-admin) client and not the scoped client specified by the AttenuatedServiceAccount
+	// the StepBuilder is currently only implemented for CRD types
+	// TODO give the StepBuilder both OLM and scoped clients when it supports new scoped types
+	builderKubeClient, err := factory.NewOperatorClient()
+	if err != nil {
+		return nil, err
+	}
+	builderDynamicClient, err := factory.NewDynamicClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return &stepExecutor{
+		wr:            wr,
+		ensurer:       newStepEnsurer(kubeclient, crclient, dynamicClient),
+		builder:       newBuilder(plan, o.lister.OperatorsV1alpha1().ClusterServiceVersionLister(), builderKubeClient, builderDynamicClient, manifests, o.logger),
+		dynamicClient: dynamicClient,
+	}, nil
+}
@@ -0,0 +1,65 @@
+package catalog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+)
+
+func TestStepWaves(t *testing.T) {
+	step := func(kind, name string) *v1alpha1.Step {
+		return &v1alpha1.Step{Resource: v1alpha1.StepResource{Kind: kind, Name: name}}
+	}
+
+	tests := []struct {
+		name     string
+		steps    []*v1alpha1.Step
+		expected [][][]int
+	}{
+		{
+			name:     "NoSteps",
+			expected: [][][]int{},
+		},
+		{
+			name: "Bundle",
+			steps: []*v1alpha1.Step{
+				step(csvKind, "csv"),
+				step(crdKind, "a"),
+				step(serviceKind, "svc"),
+				step(crdKind, "b"),
+				step(serviceAccountKind, "sa"),
+				step(roleKind, "role"),
+				step(roleBindingKind, "role"),
+				step(clusterRoleKind, "role"),
+				step(clusterRoleBindingKind, "role"),
+			},
+			expected: [][][]int{{{1}, {3}}, {{0}}, {{2}, {4}, {5}, {7}}, {{6}, {8}}},
+		},
+		{
+			name: "SkipsEmptyWaves",
+			steps: []*v1alpha1.Step{
+				step(roleBindingKind, "rb"),
+				step(csvKind, "csv"),
+			},
+			expected: [][][]int{{{1}}, {{0}}},
+		},
+		{
+			name: "ChainsStepsOfSameObject",
+			steps: []*v1alpha1.Step{
+				step(serviceAccountKind, "sa"),
+				step(serviceKind, "sa"),
+				step(serviceAccountKind, "sa"),
+				step(serviceAccountKind, "other"),
+			},
+			expected: [][][]int{{{0, 2}, {1}, {3}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, stepWaves(tt.steps))
+		})
+	}
+}
@@ -0,0 +1,255 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apiserver/pkg/endpoints/discovery"
+	"k8s.io/client-go/util/retry"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/api/reasons"
+	crdlib "github.com/operator-framework/operator-lifecycle-manager/pkg/lib/crd"
+)
+
+const (
+	// InstallPlanMigrateStorageVersionsAnnotationKey is the key of the annotation that, when set to "true" on an
+	// InstallPlan, or on the Subscriptions an InstallPlan is generated for, makes the catalog operator migrate the
+	// custom resources stored in versions the CRDs of the plan remove to their new storage version before executing
+	// the plan, instead of holding it.
+	InstallPlanMigrateStorageVersionsAnnotationKey = "operatorframework.io/migrate-storage-versions"
+
+	// InstallPlanStorageVersionMigration is the type of the InstallPlan condition reporting the progress of the
+	// storage version migrations of its CRDs.
+	InstallPlanStorageVersionMigration v1alpha1.InstallPlanConditionType = "StorageVersionMigration"
+
+	// storageMigrationPageSize is the number of custom resources listed at once by storage version migrations.
+	storageMigrationPageSize = 500
+
+	// storageVersionPollInterval and storageVersionTimeout are how often and how long storage version migrations wait
+	// for a new storage version to be reported in discovery before rewriting custom resources. Migrations that time
+	// out are retried on the next sync.
+	storageVersionPollInterval = time.Second
+	storageVersionTimeout      = 30 * time.Second
+)
+
+// migratesStorageVersions returns true if the given object opts its InstallPlans into storage version migrations.
+func migratesStorageVersions(obj metav1.Object) bool {
+	return obj.GetAnnotations()[InstallPlanMigrateStorageVersionsAnnotationKey] == "true"
+}
+
+// storageMigrationAnnotations returns the annotations opting an InstallPlan generated for the given Subscriptions into
+// storage version migrations, if any of them opts in.
+func storageMigrationAnnotations(subs []*v1alpha1.Subscription) map[string]string {
+	for _, sub := range subs {
+		if migratesStorageVersions(sub) {
+			return map[string]string{InstallPlanMigrateStorageVersionsAnnotationKey: "true"}
+		}
+	}
+	return nil
+}
+
+// storageMigration is the migration of the custom resources of a CRD to a new storage version.
+type storageMigration struct {
+	existing *apiextensionsv1.CustomResourceDefinition
+	target   apiextensionsv1.CustomResourceDefinitionVersion
+}
+
+// withStorageVersionMigration migrates the custom resources of the CRDs the given plan updates, and whose stored
+// versions it removes, to the storage version of the updated CRDs. It returns the plan with its
+// StorageVersionMigration condition updated, or the plan itself if there was nothing to migrate. Failed migrations are
+// reported on the condition, and retried on the next sync.
+func (o *Operator) withStorageVersionMigration(ctx context.Context, logger *logrus.Entry, plan *v1alpha1.InstallPlan) (*v1alpha1.InstallPlan, error) {
+	migrations, err := o.storageMigrations(plan)
+	if err != nil || len(migrations) == 0 {
+		return plan, err
+	}
+
+	cond := v1alpha1.InstallPlanCondition{
+		Type:   InstallPlanStorageVersionMigration,
+		Status: corev1.ConditionTrue,
+		Reason: v1alpha1.InstallPlanConditionReason(reasons.InstallPlanStorageVersionMigrated),
+	}
+	var progress []string
+	for _, m := range migrations {
+		migrated, total, err := o.migrateStorageVersion(ctx, m)
+		if err != nil {
+			logger.WithError(err).WithField("crd", m.existing.GetName()).Warn("storage version migration failed")
+			cond.Status = corev1.ConditionFalse
+			cond.Reason = v1alpha1.InstallPlanConditionReason(reasons.InstallPlanStorageVersionMigrationFailed)
+			progress = append(progress, fmt.Sprintf("migrated %d/%d %s to %s: %v", migrated, total, m.existing.GetName(), m.target.Name, err))
+			break
+		}
+		logger.WithField("crd", m.existing.GetName()).Infof("migrated %d custom resources to storage version %s", total, m.target.Name)
+		progress = append(progress, fmt.Sprintf("migrated %d/%d %s to %s", migrated, total, m.existing.GetName(), m.target.Name))
+	}
+	cond.Message = strings.Join(progress, "; ")
+
+	now := o.now()
+	cond.LastUpdateTime = &now
+	cond.LastTransitionTime = &now
+	out := plan.DeepCopy()
+	out.Status.SetCondition(cond)
+	return out, nil
+}
+
+// storageMigrations returns the migrations the v1 CRDs the given plan updates need, because they remove versions
+// still listed as stored by the CRDs on the cluster.
+func (o *Operator) storageMigrations(plan *v1alpha1.InstallPlan) ([]storageMigration, error) {
+	r := newManifestResolver(plan.GetNamespace(), o.lister.CoreV1().ConfigMapLister(), o.logger)
+	var migrations []storageMigration
+	for _, step := range plan.Status.Plan {
+		if step.Resource.Kind != crdKind {
+			continue
+		}
+		manifest, err := r.ManifestForStep(step)
+		if err != nil {
+			return nil, err
+		}
+		if version, err := crdlib.Version(&manifest); err != nil || version != crdlib.V1Version {
+			continue
+		}
+		updated, err := crdlib.UnmarshalV1(manifest)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing step manifest %s: %v", step.Resource.Name, err)
+		}
+		existing, err := o.lister.APIExtensionsV1().CustomResourceDefinitionLister().Get(updated.GetName())
+		if k8serrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if safe, _ := crdlib.SafeStorageVersionUpgrade(existing, updated); safe {
+			continue
+		}
+		for _, version := range updated.Spec.Versions {
+			if version.Storage {
+				migrations = append(migrations, storageMigration{existing: existing, target: version})
+			}
+		}
+	}
+	return migrations, nil
+}
+
+// migrateStorageVersion rewrites all the custom resources of the given migration in its target version, and then
+// records the target version as the only stored version of the CRD. The target version is first made the storage
+// version of the CRD on the cluster, and added to it if needed, and the custom resources are only rewritten once the
+// API server serves them from it. It returns the number of custom resources migrated, out of those listed so far.
+func (o *Operator) migrateStorageVersion(ctx context.Context, m storageMigration) (int, int, error) {
+	crds := o.opClient.ApiextensionsInterface().ApiextensionsV1().CustomResourceDefinitions()
+	crd, err := crds.Get(ctx, m.existing.GetName(), metav1.GetOptions{})
+	if err != nil {
+		return 0, 0, err
+	}
+	if storage := storageVersion(crd); storage != m.target.Name {
+		found := false
+		for i := range crd.Spec.Versions {
+			crd.Spec.Versions[i].Storage = crd.Spec.Versions[i].Name == m.target.Name
+			found = found || crd.Spec.Versions[i].Storage
+		}
+		if !found {
+			target := m.target.DeepCopy()
+			target.Served = true
+			crd.Spec.Versions = append(crd.Spec.Versions, *target)
+		}
+		if crd, err = crds.Update(ctx, crd, metav1.UpdateOptions{}); err != nil {
+			return 0, 0, fmt.Errorf("making %s the storage version: %v", m.target.Name, err)
+		}
+	}
+	if err := o.waitForStorageVersion(ctx, crd, m.target.Name); err != nil {
+		return 0, 0, err
+	}
+
+	// Updating a custom resource, even without changes, stores it in the storage version
+	gvr := schema.GroupVersionResource{Group: crd.Spec.Group, Version: m.target.Name, Resource: crd.Spec.Names.Plural}
+	client := o.dynamicClient.Resource(gvr)
+	migrated, total := 0, 0
+	opts := metav1.ListOptions{Limit: storageMigrationPageSize}
+	for {
+		list, err := client.Namespace(metav1.NamespaceAll).List(ctx, opts)
+		if err != nil {
+			return migrated, total, fmt.Errorf("listing %s: %v", gvr.GroupResource(), err)
+		}
+		total += len(list.Items)
+		for i := range list.Items {
+			cr := &list.Items[i]
+			// A conflicting update may have been made before the storage version changed, so rewrite the latest copy
+			if err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+				_, err := client.Namespace(cr.GetNamespace()).Update(ctx, cr, metav1.UpdateOptions{})
+				if !k8serrors.IsConflict(err) {
+					return err
+				}
+				latest, getErr := client.Namespace(cr.GetNamespace()).Get(ctx, cr.GetName(), metav1.GetOptions{})
+				if getErr != nil {
+					return getErr
+				}
+				cr = latest
+				return err
+			}); err != nil && !k8serrors.IsNotFound(err) {
+				return migrated, total, fmt.Errorf("updating %s/%s: %v", cr.GetNamespace(), cr.GetName(), err)
+			}
+			migrated++
+		}
+		if opts.Continue = list.GetContinue(); opts.Continue == "" {
+			break
+		}
+	}
+
+	// Only now that every custom resource has been rewritten can the previous versions be dropped
+	if err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		crd, err := crds.Get(ctx, m.existing.GetName(), metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		crd.Status.StoredVersions = []string{m.target.Name}
+		_, err = crds.UpdateStatus(ctx, crd, metav1.UpdateOptions{})
+		return err
+	}); err != nil {
+		return migrated, total, fmt.Errorf("updating stored versions: %v", err)
+	}
+	return migrated, total, nil
+}
+
+// waitForStorageVersion waits for the API server to report the given version as the storage version of the custom
+// resources of the given CRD in discovery, as updates made before are stored in the previous one.
+func (o *Operator) waitForStorageVersion(ctx context.Context, crd *apiextensionsv1.CustomResourceDefinition, version string) error {
+	gv := schema.GroupVersion{Group: crd.Spec.Group, Version: version}.String()
+	hash := discovery.StorageVersionHash(crd.Spec.Group, version, crd.Spec.Names.Kind)
+	ctx, cancel := context.WithTimeout(ctx, storageVersionTimeout)
+	defer cancel()
+	if err := wait.PollImmediateUntil(storageVersionPollInterval, func() (bool, error) {
+		resources, err := o.opClient.KubernetesInterface().Discovery().ServerResourcesForGroupVersion(gv)
+		if err != nil {
+			// The version may not be served yet
+			return false, nil
+		}
+		for _, resource := range resources.APIResources {
+			if resource.Name == crd.Spec.Names.Plural {
+				return resource.StorageVersionHash == hash, nil
+			}
+		}
+		return false, nil
+	}, ctx.Done()); err != nil {
+		return fmt.Errorf("waiting for %s to be stored in %s: %v", crd.GetName(), gv, err)
+	}
+	return nil
+}
+
+func storageVersion(crd *apiextensionsv1.CustomResourceDefinition) string {
+	for _, version := range crd.Spec.Versions {
+		if version.Storage {
+			return version.Name
+		}
+	}
+	return ""
+}
@@ -0,0 +1,140 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/endpoints/discovery"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	fakedynamic "k8s.io/client-go/dynamic/fake"
+	clienttesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/util/workqueue"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+)
+
+func TestStorageMigrationAnnotations(t *testing.T) {
+	sub := func(annotations map[string]string) *v1alpha1.Subscription {
+		return &v1alpha1.Subscription{ObjectMeta: metav1.ObjectMeta{Annotations: annotations}}
+	}
+	require.Nil(t, storageMigrationAnnotations([]*v1alpha1.Subscription{sub(nil)}))
+	require.Equal(t, map[string]string{InstallPlanMigrateStorageVersionsAnnotationKey: "true"}, storageMigrationAnnotations([]*v1alpha1.Subscription{
+		sub(nil),
+		sub(map[string]string{InstallPlanMigrateStorageVersionsAnnotationKey: "true"}),
+	}))
+}
+
+func TestSyncInstallPlanStorageVersionMigration(t *testing.T) {
+	namespace := "ns"
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+
+	crd := func(stored []string, versions ...string) *apiextensionsv1.CustomResourceDefinition {
+		crd := &apiextensionsv1.CustomResourceDefinition{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "apiextensions.k8s.io/v1", Kind: crdKind},
+			ObjectMeta: metav1.ObjectMeta{Name: "foos.example.com"},
+			Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+				Group: "example.com",
+				Names: apiextensionsv1.CustomResourceDefinitionNames{Plural: "foos", Kind: "Foo"},
+				Scope: apiextensionsv1.NamespaceScoped,
+			},
+			Status: apiextensionsv1.CustomResourceDefinitionStatus{StoredVersions: stored},
+		}
+		for _, version := range versions {
+			crd.Spec.Versions = append(crd.Spec.Versions, apiextensionsv1.CustomResourceDefinitionVersion{
+				Name:    version,
+				Served:  true,
+				Storage: version == versions[len(versions)-1],
+				Schema:  &apiextensionsv1.CustomResourceValidation{OpenAPIV3Schema: &apiextensionsv1.JSONSchemaProps{Type: "object"}},
+			})
+		}
+		return crd
+	}
+	foo := func(name string) runtime.Object {
+		obj := &unstructured.Unstructured{}
+		obj.SetAPIVersion("example.com/v2")
+		obj.SetKind("Foo")
+		obj.SetNamespace(namespace)
+		obj.SetName(name)
+		return obj
+	}
+	plan := withSteps(installPlan("p", namespace, v1alpha1.InstallPlanPhaseInstalling, "csv"),
+		[]*v1alpha1.Step{
+			{
+				Resource: v1alpha1.StepResource{
+					CatalogSource:          "catalog",
+					CatalogSourceNamespace: namespace,
+					Group:                  "apiextensions.k8s.io",
+					Version:                "v1",
+					Kind:                   crdKind,
+					Name:                   "foos.example.com",
+					Manifest:               toManifest(t, crd(nil, "v2")),
+				},
+				Status: v1alpha1.StepStatusUnknown,
+			},
+		},
+	)
+	plan.Spec.Approved = true
+	plan.SetAnnotations(map[string]string{InstallPlanMigrateStorageVersionsAnnotationKey: "true"})
+
+	op, err := NewFakeOperator(ctx, namespace, []string{namespace}, withClientObjs(plan, operatorGroup("og", "", namespace, nil)), withExtObjs(crd([]string{"v1"}, "v1")))
+	require.NoError(t, err)
+	dynamicClient := fakedynamic.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		{Group: "example.com", Version: "v2", Resource: "foos"}: "FooList",
+	}, foo("a"), foo("b"))
+	op.dynamicClient = dynamicClient
+	// The first update of a custom resource conflicts, and is retried on its latest copy
+	conflicted := false
+	dynamicClient.PrependReactor("update", "foos", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		if conflicted {
+			return false, nil, nil
+		}
+		conflicted = true
+		return true, nil, k8serrors.NewConflict(schema.GroupResource{Group: "example.com", Resource: "foos"}, "a", fmt.Errorf("modified"))
+	})
+	// The new storage version is reported in discovery once the CRD is updated
+	op.opClient.KubernetesInterface().Discovery().(*fakediscovery.FakeDiscovery).Resources = []*metav1.APIResourceList{{
+		GroupVersion: "example.com/v2",
+		APIResources: []metav1.APIResource{{Name: "foos", StorageVersionHash: discovery.StorageVersionHash("example.com", "v2", "Foo")}},
+	}}
+	queue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+	defer queue.ShutDown()
+	op.ipQueueSet.Set(namespace, queue)
+
+	require.NoError(t, op.syncInstallPlans(ctx, plan))
+
+	// The new version was made the storage version, and the only stored one
+	migrated, err := op.opClient.ApiextensionsInterface().ApiextensionsV1().CustomResourceDefinitions().Get(ctx, "foos.example.com", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, "v2", storageVersion(migrated))
+	require.Len(t, migrated.Spec.Versions, 2)
+	require.Equal(t, []string{"v2"}, migrated.Status.StoredVersions)
+
+	out, err := op.client.OperatorsV1alpha1().InstallPlans(namespace).Get(ctx, plan.GetName(), metav1.GetOptions{})
+	require.NoError(t, err)
+	cond := out.Status.GetCondition(InstallPlanStorageVersionMigration)
+	require.Equal(t, corev1.ConditionTrue, cond.Status)
+	require.Equal(t, "migrated 2/2 foos.example.com to v2", cond.Message)
+
+	var updates, gets int
+	for _, action := range dynamicClient.Actions() {
+		switch action.GetVerb() {
+		case "update":
+			updates++
+		case "get":
+			gets++
+		}
+	}
+	require.True(t, conflicted)
+	require.Equal(t, 3, updates)
+	require.Equal(t, 1, gets)
+}
@@ -79,11 +79,11 @@ func (c *catalogHealthReconciler) Reconcile(ctx context.Context, in kubestate.St
 				// Gather catalog health and transition state
 				ns := s.Subscription().GetNamespace()
 				var catalogHealth []v1alpha1.SubscriptionCatalogHealth
-				if catalogHealth, err = c.catalogHealth(ns); err != nil {
+				if catalogHealth, err = c.catalogHealth(ctx, ns); err != nil {
 					break
 				}
 
-				next, err = s.UpdateHealth(c.now(), c.client.OperatorsV1alpha1().Subscriptions(ns), catalogHealth...)
+				next, err = s.UpdateHealth(ctx, c.now(), c.client.OperatorsV1alpha1().Subscriptions(ns), catalogHealth...)
 			case SubscriptionExistsState:
 				if s == nil {
 					err = errors.New("nil state")
@@ -110,7 +110,7 @@ func (c *catalogHealthReconciler) Reconcile(ctx context.Context, in kubestate.St
 
 // catalogHealth gets the health of catalogs that can affect Susbcriptions in the given namespace.
 // This means all catalogs in the given namespace, as well as any catalogs in the operator's global catalog namespace.
-func (c *catalogHealthReconciler) catalogHealth(namespace string) ([]v1alpha1.SubscriptionCatalogHealth, error) {
+func (c *catalogHealthReconciler) catalogHealth(ctx context.Context, namespace string) ([]v1alpha1.SubscriptionCatalogHealth, error) {
 	catalogs, err := c.catalogLister.CatalogSources(namespace).List(labels.Everything())
 	if err != nil {
 		return nil, err
@@ -134,7 +134,7 @@ func (c *catalogHealthReconciler) catalogHealth(namespace string) ([]v1alpha1.Su
 	now := c.now()
 	var errs []error
 	for i, catalog := range catalogs {
-		h, err := c.health(now, catalog)
+		h, err := c.health(ctx, now, catalog)
 		if err != nil {
 			errs = append(errs, err)
 			continue
@@ -155,8 +155,8 @@ func (c *catalogHealthReconciler) catalogHealth(namespace string) ([]v1alpha1.Su
 }
 
 // health returns a SusbcriptionCatalogHealth for the given catalog with the given now.
-func (c *catalogHealthReconciler) health(now *metav1.Time, catalog *v1alpha1.CatalogSource) (*v1alpha1.SubscriptionCatalogHealth, error) {
-	healthy, err := c.healthy(catalog)
+func (c *catalogHealthReconciler) health(ctx context.Context, now *metav1.Time, catalog *v1alpha1.CatalogSource) (*v1alpha1.SubscriptionCatalogHealth, error) {
+	healthy, err := c.healthy(ctx, catalog)
 	if err != nil {
 		return nil, err
 	}
@@ -181,7 +181,7 @@ func (c *catalogHealthReconciler) health(now *metav1.Time, catalog *v1alpha1.Cat
 
 // healthy returns true if the given catalog is healthy, false otherwise, and any error encountered
 // while checking the catalog's registry server.
-func (c *catalogHealthReconciler) healthy(catalog *v1alpha1.CatalogSource) (bool, error) {
+func (c *catalogHealthReconciler) healthy(ctx context.Context, catalog *v1alpha1.CatalogSource) (bool, error) {
 	if catalog.Status.Reason == v1alpha1.CatalogSourceSpecInvalidError {
 		// The catalog's spec is bad, mark unhealthy
 		return false, nil
@@ -193,7 +193,7 @@ func (c *catalogHealthReconciler) healthy(catalog *v1alpha1.CatalogSource) (bool
 		return false, fmt.Errorf("could not get reconciler for catalog: %#v", catalog)
 	}
 
-	return rec.CheckRegistryServer(catalog)
+	return rec.CheckRegistryServer(ctx, catalog)
 }
 
 // installPlanReconciler reconciles InstallPlan status for Subscriptions.
@@ -231,13 +231,13 @@ func (i *installPlanReconciler) Reconcile(ctx context.Context, in kubestate.Stat
 				var plan *v1alpha1.InstallPlan
 				if plan, err = i.installPlanLister.InstallPlans(ref.Namespace).Get(ref.Name); err != nil {
 					if apierrors.IsNotFound(err) {
-						next, err = s.InstallPlanNotFound(i.now(), subClient)
+						next, err = s.InstallPlanNotFound(ctx, i.now(), subClient)
 					}
 
 					break
 				}
 
-				next, err = s.CheckInstallPlanStatus(i.now(), subClient, &plan.Status)
+				next, err = s.CheckInstallPlanStatus(ctx, i.now(), subClient, &plan.Status)
 			case InstallPlanState:
 				next = s.CheckReference()
 			case SubscriptionExistsState:
@@ -1676,7 +1676,7 @@ func fakeRegistryReconcilerFactory(healthy bool, err error) *olmfakes.FakeRegist
 	return &olmfakes.FakeRegistryReconcilerFactory{
 		ReconcilerForSourceStub: func(*v1alpha1.CatalogSource) registryreconciler.RegistryReconciler {
 			return &olmfakes.FakeRegistryReconciler{
-				CheckRegistryServerStub: func(*v1alpha1.CatalogSource) (bool, error) {
+				CheckRegistryServerStub: func(context.Context, *v1alpha1.CatalogSource) (bool, error) {
 					return healthy, err
 				},
 			}
@@ -64,7 +64,7 @@ type CatalogHealthState interface {
 
 	// UpdateHealth transitions the CatalogHealthState to another CatalogHealthState based on the given subscription catalog health.
 	// The state's underlying subscription may be updated on the cluster. If the subscription is updated, the resulting state will contain the updated version.
-	UpdateHealth(now *metav1.Time, client clientv1alpha1.SubscriptionInterface, health ...v1alpha1.SubscriptionCatalogHealth) (CatalogHealthState, error)
+	UpdateHealth(ctx context.Context, now *metav1.Time, client clientv1alpha1.SubscriptionInterface, health ...v1alpha1.SubscriptionCatalogHealth) (CatalogHealthState, error)
 }
 
 // CatalogHealthKnownState describes subscription states in which all relevant catalog health is known.
@@ -108,9 +108,9 @@ type InstallPlanReferencedState interface {
 
 	isInstallPlanReferencedState()
 
-	InstallPlanNotFound(now *metav1.Time, client clientv1alpha1.SubscriptionInterface) (InstallPlanReferencedState, error)
+	InstallPlanNotFound(ctx context.Context, now *metav1.Time, client clientv1alpha1.SubscriptionInterface) (InstallPlanReferencedState, error)
 
-	CheckInstallPlanStatus(now *metav1.Time, client clientv1alpha1.SubscriptionInterface, status *v1alpha1.InstallPlanStatus) (InstallPlanReferencedState, error)
+	CheckInstallPlanStatus(ctx context.Context, now *metav1.Time, client clientv1alpha1.SubscriptionInterface, status *v1alpha1.InstallPlanStatus) (InstallPlanReferencedState, error)
 }
 
 type InstallPlanKnownState interface {
@@ -218,7 +218,7 @@ type catalogHealthState struct {
 
 func (c *catalogHealthState) isCatalogHealthState() {}
 
-func (c *catalogHealthState) UpdateHealth(now *metav1.Time, client clientv1alpha1.SubscriptionInterface, catalogHealth ...v1alpha1.SubscriptionCatalogHealth) (CatalogHealthState, error) {
+func (c *catalogHealthState) UpdateHealth(ctx context.Context, now *metav1.Time, client clientv1alpha1.SubscriptionInterface, catalogHealth ...v1alpha1.SubscriptionCatalogHealth) (CatalogHealthState, error) {
 	in := c.Subscription()
 	out := in.DeepCopy()
 
@@ -299,7 +299,7 @@ func (c *catalogHealthState) UpdateHealth(now *metav1.Time, client clientv1alpha
 	out.Status.SetCondition(cond)
 	out.Status.CatalogHealth = catalogHealth
 
-	updated, err := client.UpdateStatus(context.TODO(), out, metav1.UpdateOptions{})
+	updated, err := client.UpdateStatus(ctx, out, metav1.UpdateOptions{})
 	if err != nil {
 		// Error occurred, transition to self
 		return c, err
@@ -377,7 +377,7 @@ func (i *installPlanReferencedState) isInstallPlanReferencedState() {}
 
 var hashEqual = comparison.NewHashEqualitor()
 
-func (i *installPlanReferencedState) InstallPlanNotFound(now *metav1.Time, client clientv1alpha1.SubscriptionInterface) (InstallPlanReferencedState, error) {
+func (i *installPlanReferencedState) InstallPlanNotFound(ctx context.Context, now *metav1.Time, client clientv1alpha1.SubscriptionInterface) (InstallPlanReferencedState, error) {
 	in := i.Subscription()
 	out := in.DeepCopy()
 
@@ -415,7 +415,7 @@ func (i *installPlanReferencedState) InstallPlanNotFound(now *metav1.Time, clien
 
 	// Update the Subscription
 	out.Status.LastUpdated = *now
-	updated, err := client.UpdateStatus(context.TODO(), out, metav1.UpdateOptions{})
+	updated, err := client.UpdateStatus(ctx, out, metav1.UpdateOptions{})
 	if err != nil {
 		return i, err
 	}
@@ -426,7 +426,7 @@ func (i *installPlanReferencedState) InstallPlanNotFound(now *metav1.Time, clien
 	return missingState, nil
 }
 
-func (i *installPlanReferencedState) CheckInstallPlanStatus(now *metav1.Time, client clientv1alpha1.SubscriptionInterface, status *v1alpha1.InstallPlanStatus) (InstallPlanReferencedState, error) {
+func (i *installPlanReferencedState) CheckInstallPlanStatus(ctx context.Context, now *metav1.Time, client clientv1alpha1.SubscriptionInterface, status *v1alpha1.InstallPlanStatus) (InstallPlanReferencedState, error) {
 	in := i.Subscription()
 	out := in.DeepCopy()
 
@@ -497,7 +497,7 @@ func (i *installPlanReferencedState) CheckInstallPlanStatus(now *metav1.Time, cl
 
 	// Update the Subscription
 	out.Status.LastUpdated = *now
-	updated, err := client.UpdateStatus(context.TODO(), out, metav1.UpdateOptions{})
+	updated, err := client.UpdateStatus(ctx, out, metav1.UpdateOptions{})
 	if err != nil {
 		return i, err
 	}
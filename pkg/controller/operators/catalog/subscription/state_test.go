@@ -474,7 +474,7 @@ func TestUpdateHealth(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.description, func(t *testing.T) {
 			fakeClient := tt.fields.existingObjs.fakeClientset(t).OperatorsV1alpha1().Subscriptions(tt.fields.namespace)
-			transitioned, err := tt.fields.state.UpdateHealth(tt.args.now, fakeClient, tt.args.catalogHealth...)
+			transitioned, err := tt.fields.state.UpdateHealth(context.TODO(), tt.args.now, fakeClient, tt.args.catalogHealth...)
 			require.Equal(t, tt.want.err, err)
 			require.Equal(t, tt.want.transitioned, transitioned)
 
@@ -907,7 +907,7 @@ func TestInstallPlanNotFound(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.description, func(t *testing.T) {
 			fakeClient := tt.fields.existingObjs.fakeClientset(t).OperatorsV1alpha1().Subscriptions(tt.fields.namespace)
-			transitioned, err := tt.fields.state.InstallPlanNotFound(tt.args.now, fakeClient)
+			transitioned, err := tt.fields.state.InstallPlanNotFound(context.TODO(), tt.args.now, fakeClient)
 			require.Equal(t, tt.want.err, err)
 			require.Equal(t, tt.want.transitioned, transitioned)
 
@@ -1759,7 +1759,7 @@ func TestCheckInstallPlanStatus(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.description, func(t *testing.T) {
 			fakeClient := tt.fields.existingObjs.fakeClientset(t).OperatorsV1alpha1().Subscriptions(tt.fields.namespace)
-			transitioned, err := tt.fields.state.CheckInstallPlanStatus(tt.args.now, fakeClient, tt.args.status)
+			transitioned, err := tt.fields.state.CheckInstallPlanStatus(context.TODO(), tt.args.now, fakeClient, tt.args.status)
 			require.Equal(t, tt.want.err, err)
 			require.Equal(t, tt.want.transitioned, transitioned)
 
@@ -0,0 +1,122 @@
+package catalog
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	admissionv1 "k8s.io/api/admission/v1"
+)
+
+const (
+	// SubscriptionPreviewPath is the path of the mutating admission webhook previewing the resolution of the
+	// Subscriptions created with server-side dry-run.
+	SubscriptionPreviewPath = "/preview-subscription"
+
+	// PreviewCSVAnnotationKey is set on a dry-run Subscription to the CSV its creation would resolve to.
+	PreviewCSVAnnotationKey = "operatorframework.io/preview-csv"
+	// PreviewStepsAnnotationKey is set to the number of steps of the InstallPlan resolved ahead of bundle unpacking.
+	PreviewStepsAnnotationKey = "operatorframework.io/preview-steps"
+	// PreviewBundleLookupsAnnotationKey is set to the number of bundle images the InstallPlan would unpack.
+	PreviewBundleLookupsAnnotationKey = "operatorframework.io/preview-bundle-lookups"
+	// PreviewErrorAnnotationKey is set to the resolution error, if the resolution fails.
+	PreviewErrorAnnotationKey = "operatorframework.io/preview-error"
+)
+
+// subscriptionPreviewer resolves a namespace as if a Subscription existed in it, without persisting anything.
+type subscriptionPreviewer interface {
+	PreviewSteps(ctx context.Context, namespace string, sub *v1alpha1.Subscription) ([]*v1alpha1.Step, []v1alpha1.BundleLookup, []*v1alpha1.Subscription, error)
+}
+
+// ServeSubscriptionPreview serves a mutating admission webhook for Subscriptions. Subscriptions created with
+// server-side dry-run are annotated with the outcome of their resolution, others are admitted unchanged. It never
+// denies a request.
+func (o *Operator) ServeSubscriptionPreview(w http.ResponseWriter, r *http.Request) {
+	var review admissionv1.AdmissionReview
+	if err := json.NewDecoder(r.Body).Decode(&review); err != nil || review.Request == nil {
+		http.Error(w, "invalid admission review", http.StatusBadRequest)
+		return
+	}
+
+	response := &admissionv1.AdmissionResponse{UID: review.Request.UID, Allowed: true}
+	patch, err := o.subscriptionPreviewPatch(r.Context(), review.Request)
+	if err != nil {
+		o.logger.WithError(err).Warnf("failed to preview subscription %s/%s", review.Request.Namespace, review.Request.Name)
+	} else if patch != nil {
+		patchType := admissionv1.PatchTypeJSONPatch
+		response.Patch = patch
+		response.PatchType = &patchType
+	}
+
+	review.Request = nil
+	review.Response = response
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(&review); err != nil {
+		o.logger.WithError(err).Warn("failed to write admission review")
+	}
+}
+
+// subscriptionPreviewPatch returns the JSON patch annotating the Subscription of the given request with the outcome of
+// its resolution, or nil if the request isn't a dry-run creation.
+func (o *Operator) subscriptionPreviewPatch(ctx context.Context, req *admissionv1.AdmissionRequest) ([]byte, error) {
+	if req.DryRun == nil || !*req.DryRun || req.Operation != admissionv1.Create {
+		return nil, nil
+	}
+	previewer, ok := o.resolver.(subscriptionPreviewer)
+	if !ok {
+		return nil, fmt.Errorf("resolver doesn't support previews")
+	}
+
+	sub := &v1alpha1.Subscription{}
+	if err := json.Unmarshal(req.Object.Raw, sub); err != nil {
+		return nil, err
+	}
+	sub.SetNamespace(req.Namespace)
+
+	preview := map[string]string{}
+	steps, bundleLookups, updatedSubs, err := previewer.PreviewSteps(ctx, req.Namespace, sub)
+	if err != nil {
+		preview[PreviewErrorAnnotationKey] = err.Error()
+	} else {
+		for _, updated := range updatedSubs {
+			if updated.GetName() == sub.GetName() {
+				preview[PreviewCSVAnnotationKey] = updated.Status.CurrentCSV
+			}
+		}
+		preview[PreviewStepsAnnotationKey] = strconv.Itoa(len(steps))
+		preview[PreviewBundleLookupsAnnotationKey] = strconv.Itoa(len(bundleLookups))
+	}
+
+	return annotationsPatch(sub.GetAnnotations(), preview)
+}
+
+type jsonPatchOperation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value"`
+}
+
+// annotationsPatch returns the JSON patch adding the given annotations to an object with the existing ones.
+func annotationsPatch(existing, added map[string]string) ([]byte, error) {
+	if len(existing) == 0 {
+		return json.Marshal([]jsonPatchOperation{{Op: "add", Path: "/metadata/annotations", Value: added}})
+	}
+
+	keys := make([]string, 0, len(added))
+	for key := range added {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	escape := strings.NewReplacer("~", "~0", "/", "~1")
+	patch := make([]jsonPatchOperation, 0, len(keys))
+	for _, key := range keys {
+		patch = append(patch, jsonPatchOperation{Op: "add", Path: "/metadata/annotations/" + escape.Replace(key), Value: added[key]})
+	}
+	return json.Marshal(patch)
+}
@@ -0,0 +1,124 @@
+package catalog
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/fakes"
+)
+
+type fakeSubscriptionPreviewer struct {
+	fakes.FakeStepResolver
+	err error
+}
+
+func (f *fakeSubscriptionPreviewer) PreviewSteps(ctx context.Context, namespace string, sub *v1alpha1.Subscription) ([]*v1alpha1.Step, []v1alpha1.BundleLookup, []*v1alpha1.Subscription, error) {
+	if f.err != nil {
+		return nil, nil, nil, f.err
+	}
+	resolved := sub.DeepCopy()
+	resolved.Status.CurrentCSV = sub.Spec.Package + ".v1"
+	return []*v1alpha1.Step{{}, {}}, []v1alpha1.BundleLookup{{}}, []*v1alpha1.Subscription{resolved}, nil
+}
+
+func TestServeSubscriptionPreview(t *testing.T) {
+	dryRun, notDryRun := true, false
+	sub := func(annotations map[string]string) runtime.RawExtension {
+		raw, err := json.Marshal(&v1alpha1.Subscription{
+			ObjectMeta: metav1.ObjectMeta{Name: "etcd", Annotations: annotations},
+			Spec:       &v1alpha1.SubscriptionSpec{Package: "etcd", Channel: "stable"},
+		})
+		require.NoError(t, err)
+		return runtime.RawExtension{Raw: raw}
+	}
+
+	tests := []struct {
+		description string
+		request     *admissionv1.AdmissionRequest
+		err         error
+		patch       string
+	}{
+		{
+			description: "NotDryRun",
+			request:     &admissionv1.AdmissionRequest{Operation: admissionv1.Create, DryRun: &notDryRun, Object: sub(nil)},
+		},
+		{
+			description: "DryRunUpdate",
+			request:     &admissionv1.AdmissionRequest{Operation: admissionv1.Update, DryRun: &dryRun, Object: sub(nil)},
+		},
+		{
+			description: "DryRunCreate",
+			request:     &admissionv1.AdmissionRequest{Operation: admissionv1.Create, DryRun: &dryRun, Object: sub(nil)},
+			patch: `[{"op": "add", "path": "/metadata/annotations", "value": {
+				"operatorframework.io/preview-csv": "etcd.v1",
+				"operatorframework.io/preview-steps": "2",
+				"operatorframework.io/preview-bundle-lookups": "1"
+			}}]`,
+		},
+		{
+			description: "DryRunCreate/ExistingAnnotations",
+			request:     &admissionv1.AdmissionRequest{Operation: admissionv1.Create, DryRun: &dryRun, Object: sub(map[string]string{"owner": "gitops"})},
+			patch: `[
+				{"op": "add", "path": "/metadata/annotations/operatorframework.io~1preview-bundle-lookups", "value": "1"},
+				{"op": "add", "path": "/metadata/annotations/operatorframework.io~1preview-csv", "value": "etcd.v1"},
+				{"op": "add", "path": "/metadata/annotations/operatorframework.io~1preview-steps", "value": "2"}
+			]`,
+		},
+		{
+			description: "DryRunCreate/ResolutionError",
+			request:     &admissionv1.AdmissionRequest{Operation: admissionv1.Create, DryRun: &dryRun, Object: sub(nil)},
+			err:         fmt.Errorf("constraints not satisfiable"),
+			patch:       `[{"op": "add", "path": "/metadata/annotations", "value": {"operatorframework.io/preview-error": "constraints not satisfiable"}}]`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			o := &Operator{logger: logrus.New(), resolver: &fakeSubscriptionPreviewer{err: tt.err}}
+
+			tt.request.UID = types.UID("uid")
+			tt.request.Namespace = "ns"
+			body, err := json.Marshal(&admissionv1.AdmissionReview{
+				TypeMeta: metav1.TypeMeta{APIVersion: "admission.k8s.io/v1", Kind: "AdmissionReview"},
+				Request:  tt.request,
+			})
+			require.NoError(t, err)
+
+			w := httptest.NewRecorder()
+			o.ServeSubscriptionPreview(w, httptest.NewRequest(http.MethodPost, SubscriptionPreviewPath, bytes.NewReader(body)))
+			require.Equal(t, http.StatusOK, w.Code)
+
+			var review admissionv1.AdmissionReview
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &review))
+			require.Equal(t, "AdmissionReview", review.Kind)
+			require.NotNil(t, review.Response)
+			require.Equal(t, types.UID("uid"), review.Response.UID)
+			require.True(t, review.Response.Allowed)
+			if tt.patch == "" {
+				require.Nil(t, review.Response.Patch)
+				return
+			}
+			require.Equal(t, admissionv1.PatchTypeJSONPatch, *review.Response.PatchType)
+			require.JSONEq(t, tt.patch, string(review.Response.Patch))
+		})
+	}
+}
+
+func TestServeSubscriptionPreviewInvalidReview(t *testing.T) {
+	o := &Operator{logger: logrus.New()}
+	w := httptest.NewRecorder()
+	o.ServeSubscriptionPreview(w, httptest.NewRequest(http.MethodPost, SubscriptionPreviewPath, bytes.NewReader([]byte("{}"))))
+	require.Equal(t, http.StatusBadRequest, w.Code)
+}
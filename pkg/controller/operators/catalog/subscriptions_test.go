@@ -1022,7 +1022,7 @@ func TestSyncSubscriptions(t *testing.T) {
 			o.reconciler = &fakes.FakeRegistryReconcilerFactory{
 				ReconcilerForSourceStub: func(source *v1alpha1.CatalogSource) reconciler.RegistryReconciler {
 					return &fakes.FakeRegistryReconciler{
-						EnsureRegistryServerStub: func(source *v1alpha1.CatalogSource) error {
+						EnsureRegistryServerStub: func(ctx context.Context, source *v1alpha1.CatalogSource) error {
 							return nil
 						},
 					}
@@ -1031,7 +1031,7 @@ func TestSyncSubscriptions(t *testing.T) {
 
 			o.sourcesLastUpdate.Set(tt.fields.sourcesLastUpdate.Time)
 			o.resolver = &fakes.FakeStepResolver{
-				ResolveStepsStub: func(string) ([]*v1alpha1.Step, []v1alpha1.BundleLookup, []*v1alpha1.Subscription, error) {
+				ResolveStepsStub: func(context.Context, string) ([]*v1alpha1.Step, []v1alpha1.BundleLookup, []*v1alpha1.Subscription, error) {
 					return tt.fields.resolveSteps, tt.fields.bundleLookups, tt.fields.resolveSubs, tt.fields.resolveErr
 				},
 			}
@@ -1044,7 +1044,7 @@ func TestSyncSubscriptions(t *testing.T) {
 			if err := o.syncSubscriptions(tt.args.obj); err != nil {
 				require.Equal(t, tt.wantErr, err)
 			} else {
-				require.Equal(t, tt.wantErr, o.syncResolvingNamespace(namespace))
+				require.Equal(t, tt.wantErr, o.syncResolvingNamespace(ctx, namespace))
 			}
 
 			for _, s := range tt.wantSubscriptions {
@@ -1130,7 +1130,7 @@ func benchmarkSyncResolvingNamespace(test srnTest, b *testing.B) {
 				Name: test.args.namespace,
 			},
 		}
-		require.NoError(b, o.syncResolvingNamespace(namespace))
+		require.NoError(b, o.syncResolvingNamespace(ctx, namespace))
 	}
 }
 
@@ -1161,14 +1161,14 @@ func BenchmarkSyncResolvingNamespace(b *testing.B) {
 			reconciler: &fakes.FakeRegistryReconcilerFactory{
 				ReconcilerForSourceStub: func(*v1alpha1.CatalogSource) reconciler.RegistryReconciler {
 					return &fakes.FakeRegistryReconciler{
-						CheckRegistryServerStub: func(*v1alpha1.CatalogSource) (bool, error) {
+						CheckRegistryServerStub: func(context.Context, *v1alpha1.CatalogSource) (bool, error) {
 							return true, nil
 						},
 					}
 				},
 			},
 			resolver: &fakes.FakeStepResolver{
-				ResolveStepsStub: func(string) ([]*v1alpha1.Step, []v1alpha1.BundleLookup, []*v1alpha1.Subscription, error) {
+				ResolveStepsStub: func(context.Context, string) ([]*v1alpha1.Step, []v1alpha1.BundleLookup, []*v1alpha1.Subscription, error) {
 					steps := []*v1alpha1.Step{
 						{
 							Resolving: "csv.v.2",
@@ -0,0 +1,290 @@
+package catalog
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/api/reasons"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/registry/resolver"
+)
+
+const (
+	// SubscriptionTransactionPending is the condition of a Subscription whose transaction waits for some of its
+	// Subscriptions to be created before being resolved.
+	SubscriptionTransactionPending v1alpha1.SubscriptionConditionType = "TransactionPending"
+
+	// SubscriptionTransactionRolledBack is the condition of a Subscription whose transaction was rolled back.
+	SubscriptionTransactionRolledBack v1alpha1.SubscriptionConditionType = "TransactionRolledBack"
+
+	// TransactionFailureDeadlineAnnotationKey is the Subscription annotation setting, as a duration, how long the CSV
+	// of a Subscription of a transaction must stay Failed before the transaction is rolled back, so that failures the
+	// CSV recovers from don't roll it back. The longest deadline set on the Subscriptions of a transaction applies.
+	TransactionFailureDeadlineAnnotationKey = "operatorframework.io/transaction-failure-deadline"
+
+	// defaultTransactionFailureDeadline is how long the CSV of a transaction must stay Failed before the transaction
+	// is rolled back, unless its Subscriptions set another deadline.
+	defaultTransactionFailureDeadline = 5 * time.Minute
+)
+
+// terminalCSVFailureReasons are the reasons of the CSV failures OLM never recovers from, which roll transactions back
+// without waiting for their failure deadline.
+var terminalCSVFailureReasons = map[v1alpha1.ConditionReason]struct{}{
+	v1alpha1.CSVReasonComponentFailedNoRetry:    {},
+	v1alpha1.CSVReasonInvalidStrategy:           {},
+	v1alpha1.CSVReasonInvalidInstallModes:       {},
+	v1alpha1.CSVReasonInvalidWebhookDescription: {},
+}
+
+// ensureTransactions maintains the transactions of the given Subscriptions: it reports the transactions waiting for
+// some of their Subscriptions, commits those whose CSVs all succeeded, and rolls back those whose InstallPlan failed
+// or one of whose CSVs failed for good. It returns true if any Subscription was updated.
+func (o *Operator) ensureTransactions(ctx context.Context, logger *logrus.Entry, subs []*v1alpha1.Subscription) (bool, error) {
+	transactions := resolver.Transactions(subs)
+	ids := make([]string, 0, len(transactions))
+	for id := range transactions {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	updated := false
+	for _, id := range ids {
+		t := transactions[id]
+		logger := logger.WithField("transaction", id)
+		if t.State() != "" {
+			continue
+		}
+
+		var changed []*v1alpha1.Subscription
+		if t.Pending() {
+			changed = o.setSubsCond(t.Subscriptions, v1alpha1.SubscriptionCondition{
+				Type:    SubscriptionTransactionPending,
+				Status:  corev1.ConditionTrue,
+				Reason:  string(reasons.SubscriptionWaitingForSubscriptions),
+				Message: fmt.Sprintf("waiting for %d more subscription(s) labeled %s=%s", t.Missing(), resolver.TransactionLabelKey, id),
+			})
+		} else {
+			changed = o.removeSubsCond(t.Subscriptions, SubscriptionTransactionPending)
+		}
+		if len(changed) > 0 {
+			if _, err := o.updateSubscriptionStatuses(ctx, changed); err != nil {
+				return false, err
+			}
+			updated = true
+			continue
+		}
+		if t.Pending() {
+			continue
+		}
+
+		failure, succeeded, retryAfter, err := o.transactionOutcome(ctx, t)
+		if err != nil {
+			return false, err
+		}
+		switch {
+		case failure != "":
+			logger.WithField("failure", failure).Info("rolling back transaction")
+			if err := o.rollbackTransaction(ctx, t, subs, failure); err != nil {
+				return false, err
+			}
+			updated = true
+		case succeeded:
+			logger.Debug("committing transaction")
+			for _, sub := range t.Subscriptions {
+				if err := o.setTransactionStatus(ctx, sub, resolver.TransactionCommitted); err != nil {
+					return false, err
+				}
+			}
+			updated = true
+		case retryAfter > 0:
+			logger.WithField("retryAfter", retryAfter).Debug("csv of transaction failed, waiting for its failure deadline")
+			for _, sub := range t.Subscriptions {
+				if err := o.subQueueSet.RequeueAfter(sub.GetNamespace(), sub.GetName(), retryAfter); err != nil {
+					logger.WithError(err).Debug("unable to requeue subscription until its transaction failure deadline")
+				}
+			}
+		}
+	}
+	return updated, nil
+}
+
+// transactionFailureDeadline returns how long the CSV of the given transaction must stay Failed before the
+// transaction is rolled back: the longest valid deadline set on its Subscriptions, or the default one.
+func transactionFailureDeadline(t *resolver.Transaction) time.Duration {
+	deadline := time.Duration(0)
+	for _, sub := range t.Subscriptions {
+		value, ok := sub.GetAnnotations()[TransactionFailureDeadlineAnnotationKey]
+		if !ok {
+			continue
+		}
+		if d, err := time.ParseDuration(value); err == nil && d > deadline {
+			deadline = d
+		}
+	}
+	if deadline == 0 {
+		return defaultTransactionFailureDeadline
+	}
+	return deadline
+}
+
+// transactionOutcome returns why the given transaction failed, if its InstallPlan failed or one of its CSVs failed for
+// good, or whether its CSVs all succeeded. A CSV fails for good when it fails for a reason OLM never recovers from, or
+// stays Failed for the failure deadline of the transaction; until then, how long to wait for the deadline is returned.
+func (o *Operator) transactionOutcome(ctx context.Context, t *resolver.Transaction) (failure string, succeeded bool, retryAfter time.Duration, err error) {
+	succeeded = true
+	deadline := transactionFailureDeadline(t)
+	for _, name := range transactionInstallPlans(t) {
+		ip, err := o.client.OperatorsV1alpha1().InstallPlans(t.Subscriptions[0].GetNamespace()).Get(ctx, name, metav1.GetOptions{})
+		if k8serrors.IsNotFound(err) {
+			succeeded = false
+			continue
+		}
+		if err != nil {
+			return "", false, 0, err
+		}
+		if ip.Status.Phase == v1alpha1.InstallPlanPhaseFailed {
+			return fmt.Sprintf("installplan %s failed", name), false, 0, nil
+		}
+	}
+
+	for _, sub := range t.Subscriptions {
+		if sub.Status.CurrentCSV == "" {
+			succeeded = false
+			continue
+		}
+		csv, err := o.lister.OperatorsV1alpha1().ClusterServiceVersionLister().ClusterServiceVersions(sub.GetNamespace()).Get(sub.Status.CurrentCSV)
+		if k8serrors.IsNotFound(err) {
+			succeeded = false
+			continue
+		}
+		if err != nil {
+			return "", false, 0, err
+		}
+		switch csv.Status.Phase {
+		case v1alpha1.CSVPhaseFailed:
+			if _, ok := terminalCSVFailureReasons[csv.Status.Reason]; ok {
+				return fmt.Sprintf("csv %s failed: %s", csv.GetName(), csv.Status.Message), false, 0, nil
+			}
+			var failedFor time.Duration
+			if csv.Status.LastTransitionTime != nil {
+				failedFor = o.now().Sub(csv.Status.LastTransitionTime.Time)
+			}
+			if failedFor >= deadline {
+				return fmt.Sprintf("csv %s failed for %s: %s", csv.GetName(), failedFor.Round(time.Second), csv.Status.Message), false, 0, nil
+			}
+			succeeded = false
+			if wait := deadline - failedFor; retryAfter == 0 || wait < retryAfter {
+				retryAfter = wait
+			}
+		case v1alpha1.CSVPhaseSucceeded:
+		default:
+			succeeded = false
+		}
+	}
+	return "", succeeded, retryAfter, nil
+}
+
+// rollbackTransaction deletes the CSVs installed by the InstallPlans of the given transaction, and records on its
+// Subscriptions that it was rolled back so that they aren't resolved again. InstallPlans are shared by the
+// Subscriptions of a namespace, so the CSVs other Subscriptions of the namespace track are kept.
+func (o *Operator) rollbackTransaction(ctx context.Context, t *resolver.Transaction, subs []*v1alpha1.Subscription, failure string) error {
+	namespace := t.Subscriptions[0].GetNamespace()
+	kept := map[string]bool{}
+	for _, sub := range subs {
+		if sub.GetLabels()[resolver.TransactionLabelKey] != t.ID && sub.Status.CurrentCSV != "" {
+			kept[sub.Status.CurrentCSV] = true
+		}
+	}
+	csvs := map[string]struct{}{}
+	for _, sub := range t.Subscriptions {
+		if sub.Status.CurrentCSV != "" {
+			csvs[sub.Status.CurrentCSV] = struct{}{}
+		}
+	}
+	for _, name := range transactionInstallPlans(t) {
+		ip, err := o.client.OperatorsV1alpha1().InstallPlans(namespace).Get(ctx, name, metav1.GetOptions{})
+		if k8serrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		for _, step := range ip.Status.Plan {
+			if step != nil && step.Resource.Kind == v1alpha1.ClusterServiceVersionKind && !kept[step.Resource.Name] {
+				csvs[step.Resource.Name] = struct{}{}
+			}
+		}
+	}
+	for name := range csvs {
+		if err := o.client.OperatorsV1alpha1().ClusterServiceVersions(namespace).Delete(ctx, name, metav1.DeleteOptions{}); err != nil && !k8serrors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	message := fmt.Sprintf("transaction %s rolled back: %s", t.ID, failure)
+	for _, sub := range t.Subscriptions {
+		if err := o.setTransactionStatus(ctx, sub, resolver.TransactionRolledBack); err != nil {
+			return err
+		}
+		now := o.now()
+		sub.Status.CurrentCSV = ""
+		sub.Status.InstalledCSV = ""
+		sub.Status.State = v1alpha1.SubscriptionStateFailed
+		sub.Status.LastUpdated = now
+		sub.Status.SetCondition(v1alpha1.SubscriptionCondition{
+			Type:               SubscriptionTransactionRolledBack,
+			Status:             corev1.ConditionTrue,
+			Reason:             string(reasons.SubscriptionTransactionFailed),
+			Message:            message,
+			LastTransitionTime: &now,
+		})
+		o.recorder.Event(sub, corev1.EventTypeWarning, string(SubscriptionTransactionRolledBack), message)
+	}
+	_, err := o.updateSubscriptionStatuses(ctx, t.Subscriptions)
+	return err
+}
+
+// setTransactionStatus records the outcome of its transaction on the given Subscription.
+func (o *Operator) setTransactionStatus(ctx context.Context, sub *v1alpha1.Subscription, state resolver.TransactionState) error {
+	value, err := json.Marshal(&resolver.TransactionStatus{ID: sub.GetLabels()[resolver.TransactionLabelKey], State: state})
+	if err != nil {
+		return err
+	}
+	out := sub.DeepCopy()
+	annotations := out.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[resolver.TransactionStateAnnotationKey] = string(value)
+	out.SetAnnotations(annotations)
+	updated, err := o.client.OperatorsV1alpha1().Subscriptions(out.GetNamespace()).Update(ctx, out, metav1.UpdateOptions{})
+	if err != nil {
+		return fmt.Errorf("error updating Subscription: %v", err)
+	}
+	updated.Status = sub.Status
+	*sub = *updated
+	return nil
+}
+
+// transactionInstallPlans returns the names of the InstallPlans referenced by the Subscriptions of the given
+// transaction, sorted.
+func transactionInstallPlans(t *resolver.Transaction) []string {
+	seen := map[string]bool{}
+	var names []string
+	for _, sub := range t.Subscriptions {
+		if ref := sub.Status.InstallPlanRef; ref != nil && !seen[ref.Name] {
+			seen[ref.Name] = true
+			names = append(names, ref.Name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,186 @@
+package catalog
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilclock "k8s.io/apimachinery/pkg/util/clock"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/registry/resolver"
+)
+
+func TestEnsureTransactions(t *testing.T) {
+	now := time.Date(2021, time.October, 1, 12, 0, 0, 0, time.UTC)
+	csv := func(name string, phase v1alpha1.ClusterServiceVersionPhase) *v1alpha1.ClusterServiceVersion {
+		return &v1alpha1.ClusterServiceVersion{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: name},
+			Status:     v1alpha1.ClusterServiceVersionStatus{Phase: phase},
+		}
+	}
+	ip := func(phase v1alpha1.InstallPlanPhase) *v1alpha1.InstallPlan {
+		return &v1alpha1.InstallPlan{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "install-1"},
+			Status: v1alpha1.InstallPlanStatus{
+				Phase: phase,
+				Plan: []*v1alpha1.Step{
+					{Resolving: "a.v1", Resource: v1alpha1.StepResource{Kind: v1alpha1.ClusterServiceVersionKind, Name: "a.v1"}},
+					{Resolving: "b.v1", Resource: v1alpha1.StepResource{Kind: v1alpha1.ClusterServiceVersionKind, Name: "b.v1"}},
+					{Resolving: "dependency.v1", Resource: v1alpha1.StepResource{Kind: v1alpha1.ClusterServiceVersionKind, Name: "dependency.v1"}},
+					{Resolving: "other.v2", Resource: v1alpha1.StepResource{Kind: v1alpha1.ClusterServiceVersionKind, Name: "other.v2"}},
+				},
+			},
+		}
+	}
+	failed := func(name string, reason v1alpha1.ConditionReason, since time.Duration) *v1alpha1.ClusterServiceVersion {
+		c := csv(name, v1alpha1.CSVPhaseFailed)
+		transitioned := metav1.NewTime(now.Add(-since))
+		c.Status.Reason = reason
+		c.Status.LastTransitionTime = &transitioned
+		return c
+	}
+	withDeadline := func(sub *v1alpha1.Subscription, deadline string) *v1alpha1.Subscription {
+		sub.Annotations[TransactionFailureDeadlineAnnotationKey] = deadline
+		return sub
+	}
+	sub := func(name, transaction, current string) *v1alpha1.Subscription {
+		s := &v1alpha1.Subscription{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   "ns",
+				Name:        name,
+				Annotations: map[string]string{resolver.TransactionSizeAnnotationKey: "2"},
+			},
+			Spec: &v1alpha1.SubscriptionSpec{Package: name},
+		}
+		if transaction != "" {
+			s.SetLabels(map[string]string{resolver.TransactionLabelKey: transaction})
+		}
+		if current != "" {
+			s.Status.CurrentCSV = current
+			s.Status.InstallPlanRef = &corev1.ObjectReference{Namespace: "ns", Name: "install-1"}
+		}
+		return s
+	}
+
+	tests := []struct {
+		name    string
+		subs    []*v1alpha1.Subscription
+		objs    []runtime.Object
+		updated bool
+		pending bool
+		state   resolver.TransactionState
+		deleted []string
+	}{
+		{
+			name:    "Pending",
+			subs:    []*v1alpha1.Subscription{sub("a", "suite", "")},
+			updated: true,
+			pending: true,
+		},
+		{
+			name: "InProgress",
+			subs: []*v1alpha1.Subscription{sub("a", "suite", "a.v1"), sub("b", "suite", "b.v1")},
+			objs: []runtime.Object{ip(v1alpha1.InstallPlanPhaseComplete), csv("a.v1", v1alpha1.CSVPhaseSucceeded), csv("b.v1", v1alpha1.CSVPhaseInstalling)},
+		},
+		{
+			name:    "Committed",
+			subs:    []*v1alpha1.Subscription{sub("a", "suite", "a.v1"), sub("b", "suite", "b.v1")},
+			objs:    []runtime.Object{ip(v1alpha1.InstallPlanPhaseComplete), csv("a.v1", v1alpha1.CSVPhaseSucceeded), csv("b.v1", v1alpha1.CSVPhaseSucceeded)},
+			updated: true,
+			state:   resolver.TransactionCommitted,
+		},
+		{
+			name: "RolledBack/CSVFailed",
+			subs: []*v1alpha1.Subscription{sub("a", "suite", "a.v1"), sub("b", "suite", "b.v1"), sub("other", "", "other.v2")},
+			objs: []runtime.Object{
+				ip(v1alpha1.InstallPlanPhaseComplete),
+				csv("a.v1", v1alpha1.CSVPhaseSucceeded), failed("b.v1", v1alpha1.CSVReasonComponentUnhealthy, 10*time.Minute), csv("dependency.v1", v1alpha1.CSVPhaseSucceeded), csv("other.v2", v1alpha1.CSVPhaseSucceeded),
+			},
+			updated: true,
+			state:   resolver.TransactionRolledBack,
+			deleted: []string{"a.v1", "b.v1", "dependency.v1"},
+		},
+		{
+			name: "CSVFailedWithinDeadline",
+			subs: []*v1alpha1.Subscription{sub("a", "suite", "a.v1"), sub("b", "suite", "b.v1")},
+			objs: []runtime.Object{ip(v1alpha1.InstallPlanPhaseComplete), csv("a.v1", v1alpha1.CSVPhaseSucceeded), failed("b.v1", v1alpha1.CSVReasonComponentUnhealthy, time.Minute)},
+		},
+		{
+			name: "CSVFailedWithinAnnotatedDeadline",
+			subs: []*v1alpha1.Subscription{sub("a", "suite", "a.v1"), withDeadline(sub("b", "suite", "b.v1"), "1h")},
+			objs: []runtime.Object{ip(v1alpha1.InstallPlanPhaseComplete), csv("a.v1", v1alpha1.CSVPhaseSucceeded), failed("b.v1", v1alpha1.CSVReasonComponentUnhealthy, 10*time.Minute)},
+		},
+		{
+			name:    "RolledBack/CSVFailedForGood",
+			subs:    []*v1alpha1.Subscription{sub("a", "suite", "a.v1"), sub("b", "suite", "b.v1"), sub("other", "", "other.v2")},
+			objs:    []runtime.Object{ip(v1alpha1.InstallPlanPhaseComplete), csv("a.v1", v1alpha1.CSVPhaseSucceeded), failed("b.v1", v1alpha1.CSVReasonInvalidStrategy, 0), csv("other.v2", v1alpha1.CSVPhaseSucceeded)},
+			updated: true,
+			state:   resolver.TransactionRolledBack,
+			deleted: []string{"a.v1", "b.v1"},
+		},
+		{
+			name:    "RolledBack/InstallPlanFailed",
+			subs:    []*v1alpha1.Subscription{sub("a", "suite", "a.v1"), sub("b", "suite", "b.v1")},
+			objs:    []runtime.Object{ip(v1alpha1.InstallPlanPhaseFailed)},
+			updated: true,
+			state:   resolver.TransactionRolledBack,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.TODO())
+			defer cancel()
+
+			objs := tt.objs
+			for _, sub := range tt.subs {
+				objs = append(objs, sub)
+			}
+			op, err := NewFakeOperator(ctx, "ns", []string{"ns"}, withClock(utilclock.NewFakeClock(now)), withClientObjs(objs...))
+			require.NoError(t, err)
+
+			updated, err := op.ensureTransactions(ctx, logrus.NewEntry(logrus.New()), tt.subs)
+			require.NoError(t, err)
+			require.Equal(t, tt.updated, updated)
+
+			for _, sub := range tt.subs {
+				if sub.GetLabels()[resolver.TransactionLabelKey] == "" {
+					continue
+				}
+				out, err := op.client.OperatorsV1alpha1().Subscriptions("ns").Get(ctx, sub.GetName(), metav1.GetOptions{})
+				require.NoError(t, err)
+
+				pending := out.Status.GetCondition(SubscriptionTransactionPending).Status == corev1.ConditionTrue
+				require.Equal(t, tt.pending, pending)
+
+				status, err := resolver.TransactionStatusFor(out)
+				require.NoError(t, err)
+				if tt.state == "" {
+					require.Nil(t, status)
+					continue
+				}
+				require.Equal(t, &resolver.TransactionStatus{ID: "suite", State: tt.state}, status)
+				if tt.state == resolver.TransactionRolledBack {
+					require.Empty(t, out.Status.CurrentCSV)
+					require.EqualValues(t, v1alpha1.SubscriptionStateFailed, out.Status.State)
+					require.Equal(t, corev1.ConditionTrue, out.Status.GetCondition(SubscriptionTransactionRolledBack).Status)
+				}
+			}
+
+			for _, name := range tt.deleted {
+				_, err := op.client.OperatorsV1alpha1().ClusterServiceVersions("ns").Get(ctx, name, metav1.GetOptions{})
+				require.True(t, k8serrors.IsNotFound(err), name)
+			}
+			if tt.deleted != nil {
+				_, err := op.client.OperatorsV1alpha1().ClusterServiceVersions("ns").Get(ctx, "other.v2", metav1.GetOptions{})
+				require.NoError(t, err, "csvs of other subscriptions are kept")
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package catalog
+
+import (
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/api/reasons"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/bundle"
+)
+
+// unpackJobDefaults returns the configuration of bundle unpack jobs set on the cluster OLMConfig, which CatalogSources
+// override. Invalid configurations are ignored.
+func (o *Operator) unpackJobDefaults() bundle.UnpackJobConfig {
+	olmConfig, err := o.olmConfigLister.Get("cluster")
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			o.logger.WithError(err).Warn("unable to get olmConfig, using default bundle unpack job configuration")
+		}
+		return bundle.UnpackJobConfig{}
+	}
+	config, err := bundle.UnpackJobConfigFor(olmConfig.GetAnnotations())
+	if err != nil {
+		o.logger.WithError(err).Warn("ignoring invalid bundle unpack job configuration set on olmConfig")
+	}
+	return config
+}
+
+// bundleLookupFailedReason returns the reason of the Installed condition of an InstallPlan failed by a bundle lookup
+// failing for the given reason.
+func bundleLookupFailedReason(reason string) v1alpha1.InstallPlanConditionReason {
+	switch reason {
+	case bundle.SignatureVerificationFailedReason:
+		return v1alpha1.InstallPlanConditionReason(reasons.InstallPlanSignatureVerificationFailed)
+	case bundle.JobDeadlineExceededReason:
+		return v1alpha1.InstallPlanConditionReason(reasons.InstallPlanBundleUnpackTimedOut)
+	case bundle.JobBackoffLimitExceededReason:
+		return v1alpha1.InstallPlanConditionReason(reasons.InstallPlanBundleUnpackRetriesExhausted)
+	case bundle.JobOOMKilledReason:
+		return v1alpha1.InstallPlanConditionReason(reasons.InstallPlanBundleUnpackOOMKilled)
+	case bundle.InvalidUnpackJobConfigReason:
+		return v1alpha1.InstallPlanConditionReason(reasons.InstallPlanInvalidUnpackJobConfig)
+	}
+	return v1alpha1.InstallPlanReasonInstallCheckFailed
+}
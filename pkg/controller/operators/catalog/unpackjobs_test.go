@@ -0,0 +1,24 @@
+package catalog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/api/reasons"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/bundle"
+)
+
+func TestBundleLookupFailedReason(t *testing.T) {
+	for lookupReason, want := range map[string]reasons.Reason{
+		bundle.JobDeadlineExceededReason:         reasons.InstallPlanBundleUnpackTimedOut,
+		bundle.JobBackoffLimitExceededReason:     reasons.InstallPlanBundleUnpackRetriesExhausted,
+		bundle.JobOOMKilledReason:                reasons.InstallPlanBundleUnpackOOMKilled,
+		bundle.InvalidUnpackJobConfigReason:      reasons.InstallPlanInvalidUnpackJobConfig,
+		bundle.SignatureVerificationFailedReason: reasons.InstallPlanSignatureVerificationFailed,
+		"Unknown":                                reasons.Reason(v1alpha1.InstallPlanReasonInstallCheckFailed),
+	} {
+		require.Equal(t, v1alpha1.InstallPlanConditionReason(want), bundleLookupFailedReason(lookupReason), lookupReason)
+	}
+}
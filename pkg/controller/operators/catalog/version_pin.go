@@ -0,0 +1,83 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/blang/semver/v4"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/api/reasons"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/registry"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/registry/resolver"
+)
+
+// SubscriptionVersionPinned is the condition of a Subscription pinned to a version, with the
+// resolver.SubscriptionVersionAnnotationKey annotation, while a newer bundle heads its channel.
+const SubscriptionVersionPinned v1alpha1.SubscriptionConditionType = "VersionPinned"
+
+// versionPinnedCondition returns the SubscriptionVersionPinned condition of the given Subscription, with a status of
+// "Unknown" if the Subscription isn't held back by its version pin.
+func (o *Operator) versionPinnedCondition(ctx context.Context, logger *logrus.Entry, sub *v1alpha1.Subscription, querier SourceQuerier) v1alpha1.SubscriptionCondition {
+	none := v1alpha1.SubscriptionCondition{Type: SubscriptionVersionPinned, Status: corev1.ConditionUnknown}
+
+	pin, err := resolver.VersionPinFor(sub)
+	if err != nil || pin == nil {
+		// invalid pins fail resolution instead
+		return none
+	}
+
+	head, err := querier.FindChannelHead(ctx, sub.Spec.Package, sub.Spec.Channel, registry.CatalogKey{Name: sub.Spec.CatalogSource, Namespace: sub.Spec.CatalogSourceNamespace})
+	if err != nil || head == nil {
+		logger.WithError(err).Debug("unable to find channel head of pinned subscription")
+		return sub.Status.GetCondition(SubscriptionVersionPinned)
+	}
+	headVersion, err := semver.ParseTolerant(head.GetVersion())
+	if err != nil || pin.Range(headVersion) {
+		return none
+	}
+
+	// the channel head is only newer than the pin if it is newer than the installed operator
+	if sub.Status.InstalledCSV != "" {
+		csv, err := o.lister.OperatorsV1alpha1().ClusterServiceVersionLister().ClusterServiceVersions(sub.GetNamespace()).Get(sub.Status.InstalledCSV)
+		if err == nil && !headVersion.GT(csv.Spec.Version.Version) {
+			return none
+		}
+	}
+
+	return v1alpha1.SubscriptionCondition{
+		Type:    SubscriptionVersionPinned,
+		Status:  corev1.ConditionTrue,
+		Reason:  string(reasons.SubscriptionNewerVersionAvailable),
+		Message: fmt.Sprintf("%s (version %s) heads channel %s but the subscription is pinned to version %s", head.GetCsvName(), headVersion, sub.Spec.Channel, pin.Constraint),
+	}
+}
+
+// ensureSubscriptionVersionPinned sets the SubscriptionVersionPinned condition on the given Subscription while newer
+// bundles than its version pin allows are available, and removes it otherwise.
+func (o *Operator) ensureSubscriptionVersionPinned(ctx context.Context, logger *logrus.Entry, sub *v1alpha1.Subscription, querier SourceQuerier) (*v1alpha1.Subscription, bool, error) {
+	cond := o.versionPinnedCondition(ctx, logger, sub, querier)
+	if sub.Status.GetCondition(SubscriptionVersionPinned).Equals(cond) {
+		return sub, false, nil
+	}
+
+	out := sub.DeepCopy()
+	if cond.Status == corev1.ConditionUnknown {
+		out.Status.RemoveConditions(SubscriptionVersionPinned)
+	} else {
+		now := o.now()
+		cond.LastTransitionTime = &now
+		out.Status.SetCondition(cond)
+	}
+	out.Status.LastUpdated = o.now()
+
+	updatedSub, err := o.client.OperatorsV1alpha1().Subscriptions(out.GetNamespace()).UpdateStatus(ctx, out, metav1.UpdateOptions{})
+	if err != nil {
+		logger.WithError(err).Info("error updating subscription status")
+		return nil, false, fmt.Errorf("error updating Subscription status: %v", err)
+	}
+	return updatedSub, true, nil
+}
@@ -0,0 +1,130 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/blang/semver/v4"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/operator-framework/api/pkg/lib/version"
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/operator-framework/operator-registry/pkg/api"
+
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/api/reasons"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/registry"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/registry/resolver"
+)
+
+type channelHeadQuerier struct {
+	SourceQuerier
+	head *api.Bundle
+}
+
+func (q channelHeadQuerier) FindChannelHead(ctx context.Context, pkgName, channelName string, source registry.CatalogKey) (*api.Bundle, error) {
+	if q.head == nil {
+		return nil, fmt.Errorf("channel %s of package %s not found", channelName, pkgName)
+	}
+	return q.head, nil
+}
+
+func TestEnsureSubscriptionVersionPinned(t *testing.T) {
+	pinned := v1alpha1.SubscriptionCondition{
+		Type:    SubscriptionVersionPinned,
+		Status:  corev1.ConditionTrue,
+		Reason:  string(reasons.SubscriptionNewerVersionAvailable),
+		Message: "packageA.v2.0.0 (version 2.0.0) heads channel alpha but the subscription is pinned to version <2.0.0",
+	}
+	newSub := func(pin string, conds ...v1alpha1.SubscriptionCondition) *v1alpha1.Subscription {
+		sub := &v1alpha1.Subscription{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "sub"},
+			Spec: &v1alpha1.SubscriptionSpec{
+				CatalogSource:          "catsrc",
+				CatalogSourceNamespace: "ns",
+				Package:                "packageA",
+				Channel:                "alpha",
+			},
+			Status: v1alpha1.SubscriptionStatus{InstalledCSV: "packageA.v1.0.0", Conditions: conds},
+		}
+		if pin != "" {
+			sub.SetAnnotations(map[string]string{resolver.SubscriptionVersionAnnotationKey: pin})
+		}
+		return sub
+	}
+	csv := &v1alpha1.ClusterServiceVersion{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "packageA.v1.0.0"},
+		Spec:       v1alpha1.ClusterServiceVersionSpec{Version: version.OperatorVersion{Version: semver.MustParse("1.0.0")}},
+	}
+	head := func(name, version string) *api.Bundle {
+		return &api.Bundle{CsvName: name, Version: version}
+	}
+
+	tests := []struct {
+		name     string
+		sub      *v1alpha1.Subscription
+		head     *api.Bundle
+		expected *v1alpha1.SubscriptionCondition
+		updated  bool
+	}{
+		{
+			name:     "Pinned",
+			sub:      newSub("<2.0.0"),
+			head:     head("packageA.v2.0.0", "2.0.0"),
+			expected: &pinned,
+			updated:  true,
+		},
+		{
+			name:     "AlreadyPinned",
+			sub:      newSub("<2.0.0", pinned),
+			head:     head("packageA.v2.0.0", "2.0.0"),
+			expected: &pinned,
+		},
+		{
+			name:    "HeadWithinPin",
+			sub:     newSub("<2.0.0", pinned),
+			head:    head("packageA.v1.1.0", "1.1.0"),
+			updated: true,
+		},
+		{
+			name: "HeadNotNewerThanInstalled",
+			sub:  newSub(">=1.1.0"),
+			head: head("packageA.v1.0.0", "1.0.0"),
+		},
+		{
+			name:    "Unpinned",
+			sub:     newSub("", pinned),
+			head:    head("packageA.v2.0.0", "2.0.0"),
+			updated: true,
+		},
+		{
+			name:     "ChannelHeadUnavailable",
+			sub:      newSub("<2.0.0", pinned),
+			expected: &pinned,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.TODO())
+			defer cancel()
+
+			op, err := NewFakeOperator(ctx, "ns", []string{"ns"}, withClientObjs([]runtime.Object{tt.sub, csv}...))
+			require.NoError(t, err)
+
+			out, updated, err := op.ensureSubscriptionVersionPinned(ctx, logrus.NewEntry(logrus.New()), tt.sub, channelHeadQuerier{head: tt.head})
+			require.NoError(t, err)
+			require.Equal(t, tt.updated, updated)
+
+			cond := out.Status.GetCondition(SubscriptionVersionPinned)
+			if tt.expected == nil {
+				require.Equal(t, corev1.ConditionUnknown, cond.Status)
+				return
+			}
+			require.True(t, cond.Equals(*tt.expected), "unexpected condition %v", cond)
+		})
+	}
+}
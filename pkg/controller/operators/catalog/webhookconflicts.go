@@ -0,0 +1,286 @@
+package catalog
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/blang/semver/v4"
+	"github.com/sirupsen/logrus"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/api/reasons"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/ownerutil"
+)
+
+// InstallPlanWebhookConflicts is the type of the InstallPlan condition reporting the webhooks of the CSVs it installs
+// whose rules overlap those of webhooks already installed by other operators, either with a different failurePolicy
+// or on OLM's own API group. Conflicts don't block the installation: they're surfaced so that admins approving the
+// InstallPlan can review the ordering and failure domains of the webhooks involved.
+const InstallPlanWebhookConflicts v1alpha1.InstallPlanConditionType = "WebhookConflicts"
+
+// skipRangeAnnotationKey is the CSV annotation holding the range of versions a CSV skips.
+const skipRangeAnnotationKey = "olm.skipRange"
+
+// installedWebhook is a webhook of an admission webhook configuration installed by OLM for a CSV.
+type installedWebhook struct {
+	configuration string
+	name          string
+	owner         string
+	ownerNS       string
+	rules         []admissionregistrationv1.RuleWithOperations
+	failurePolicy *admissionregistrationv1.FailurePolicyType
+}
+
+// withWebhookConflicts returns the given plan with its WebhookConflicts condition updated, or the plan itself if the
+// condition doesn't need to change.
+func (o *Operator) withWebhookConflicts(logger *logrus.Entry, plan *v1alpha1.InstallPlan) (*v1alpha1.InstallPlan, error) {
+	r := newManifestResolver(plan.GetNamespace(), o.lister.CoreV1().ConfigMapLister(), o.logger)
+	var csvs []*v1alpha1.ClusterServiceVersion
+	for _, step := range plan.Status.Plan {
+		if step.Resource.Kind != v1alpha1.ClusterServiceVersionKind {
+			continue
+		}
+		manifest, err := r.ManifestForStep(step)
+		if err != nil {
+			return nil, err
+		}
+		csv := &v1alpha1.ClusterServiceVersion{}
+		if err := json.Unmarshal([]byte(manifest), csv); err != nil {
+			return nil, fmt.Errorf("error parsing step manifest %s: %v", step.Resource.Name, err)
+		}
+		if len(csv.Spec.WebhookDefinitions) > 0 {
+			csvs = append(csvs, csv)
+		}
+	}
+
+	existing := plan.Status.GetCondition(InstallPlanWebhookConflicts)
+	if len(csvs) == 0 && existing.Status == corev1.ConditionUnknown {
+		return plan, nil
+	}
+
+	installed, err := o.installedWebhooks()
+	if err != nil {
+		return nil, err
+	}
+	csvsInNamespace, err := o.lister.OperatorsV1alpha1().ClusterServiceVersionLister().ClusterServiceVersions(plan.GetNamespace()).List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	var conflicts []string
+	for _, csv := range csvs {
+		conflicts = append(conflicts, webhookConflicts(plan.GetNamespace(), csv, csvsInNamespace, installed)...)
+	}
+
+	cond := v1alpha1.InstallPlanCondition{
+		Type:   InstallPlanWebhookConflicts,
+		Status: corev1.ConditionFalse,
+		Reason: v1alpha1.InstallPlanConditionReason(reasons.InstallPlanNoConflictingWebhooks),
+	}
+	if len(conflicts) > 0 {
+		sort.Strings(conflicts)
+		cond.Status = corev1.ConditionTrue
+		cond.Reason = v1alpha1.InstallPlanConditionReason(reasons.InstallPlanConflictingWebhooks)
+		cond.Message = strings.Join(conflicts, "; ")
+	}
+	if len(conflicts) == 0 && existing.Status == corev1.ConditionUnknown {
+		return plan, nil
+	}
+	if existing.Status == cond.Status && existing.Reason == cond.Reason && existing.Message == cond.Message {
+		return plan, nil
+	}
+
+	logger.WithField("conflicts", len(conflicts)).Info("webhook conflicts changed")
+	now := o.now()
+	cond.LastUpdateTime = &now
+	cond.LastTransitionTime = &now
+	out := plan.DeepCopy()
+	out.Status.SetCondition(cond)
+	return out, nil
+}
+
+// installedWebhooks returns the webhooks of the admission webhook configurations OLM installed for CSVs.
+func (o *Operator) installedWebhooks() ([]installedWebhook, error) {
+	selector := labels.SelectorFromSet(map[string]string{ownerutil.OwnerKind: v1alpha1.ClusterServiceVersionKind})
+
+	var installed []installedWebhook
+	validating, err := o.validatingWebhookConfigurationLister.List(selector)
+	if err != nil {
+		return nil, err
+	}
+	for _, configuration := range validating {
+		for _, webhook := range configuration.Webhooks {
+			installed = append(installed, installedWebhook{
+				configuration: configuration.GetName(),
+				name:          webhook.Name,
+				owner:         configuration.GetLabels()[ownerutil.OwnerKey],
+				ownerNS:       configuration.GetLabels()[ownerutil.OwnerNamespaceKey],
+				rules:         webhook.Rules,
+				failurePolicy: webhook.FailurePolicy,
+			})
+		}
+	}
+
+	mutating, err := o.mutatingWebhookConfigurationLister.List(selector)
+	if err != nil {
+		return nil, err
+	}
+	for _, configuration := range mutating {
+		for _, webhook := range configuration.Webhooks {
+			installed = append(installed, installedWebhook{
+				configuration: configuration.GetName(),
+				name:          webhook.Name,
+				owner:         configuration.GetLabels()[ownerutil.OwnerKey],
+				ownerNS:       configuration.GetLabels()[ownerutil.OwnerNamespaceKey],
+				rules:         webhook.Rules,
+				failurePolicy: webhook.FailurePolicy,
+			})
+		}
+	}
+
+	return installed, nil
+}
+
+// predecessors returns the names of the CSVs of the namespace the given CSV supersedes once installed: the CSV it
+// replaces, the CSVs listed in its skips, and the CSVs whose version is in its olm.skipRange.
+func predecessors(csv *v1alpha1.ClusterServiceVersion, csvsInNamespace []*v1alpha1.ClusterServiceVersion) map[string]bool {
+	names := map[string]bool{}
+	if csv.Spec.Replaces != "" {
+		names[csv.Spec.Replaces] = true
+	}
+	for _, name := range csv.Spec.Skips {
+		names[name] = true
+	}
+	skipRange, err := semver.ParseRange(csv.GetAnnotations()[skipRangeAnnotationKey])
+	if err != nil {
+		return names
+	}
+	for _, c := range csvsInNamespace {
+		if version := c.Spec.Version.Version; c.GetName() != csv.GetName() && !version.Equals(semver.Version{}) && skipRange(version) {
+			names[c.GetName()] = true
+		}
+	}
+	return names
+}
+
+// webhookConflicts describes the conflicts between the admission webhooks of the given CSV and the installed webhooks
+// of other operators. Webhooks of the CSV itself, or of the CSVs of the namespace it supersedes, are not considered.
+func webhookConflicts(namespace string, csv *v1alpha1.ClusterServiceVersion, csvsInNamespace []*v1alpha1.ClusterServiceVersion, installed []installedWebhook) []string {
+	superseded := predecessors(csv, csvsInNamespace)
+	var conflicts []string
+	for _, desc := range csv.Spec.WebhookDefinitions {
+		if desc.Type != v1alpha1.ValidatingAdmissionWebhook && desc.Type != v1alpha1.MutatingAdmissionWebhook {
+			continue
+		}
+		for _, webhook := range installed {
+			if webhook.ownerNS == namespace && (webhook.owner == csv.GetName() || superseded[webhook.owner]) {
+				continue
+			}
+			if !rulesOverlap(desc.Rules, webhook.rules) {
+				continue
+			}
+
+			policy, installedPolicy := effectiveFailurePolicy(desc.FailurePolicy), effectiveFailurePolicy(webhook.failurePolicy)
+			switch {
+			case policy != installedPolicy:
+				conflicts = append(conflicts, fmt.Sprintf("webhook %s of %s has failurePolicy %s, but overlaps webhook %s of %s/%s with failurePolicy %s",
+					desc.GenerateName, csv.GetName(), policy, webhook.name, webhook.ownerNS, webhook.owner, installedPolicy))
+			case rulesOverlapOnGroup(desc.Rules, webhook.rules, v1alpha1.GroupName):
+				conflicts = append(conflicts, fmt.Sprintf("webhook %s of %s overlaps webhook %s of %s/%s on %s resources",
+					desc.GenerateName, csv.GetName(), webhook.name, webhook.ownerNS, webhook.owner, v1alpha1.GroupName))
+			}
+		}
+	}
+	return conflicts
+}
+
+func effectiveFailurePolicy(policy *admissionregistrationv1.FailurePolicyType) admissionregistrationv1.FailurePolicyType {
+	if policy == nil {
+		return admissionregistrationv1.Fail
+	}
+	return *policy
+}
+
+// rulesOverlap returns true if a request could match a rule of both given lists.
+func rulesOverlap(a, b []admissionregistrationv1.RuleWithOperations) bool {
+	for _, ra := range a {
+		for _, rb := range b {
+			if ruleOverlap(ra, rb) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// rulesOverlapOnGroup returns true if a request for the given API group could match a rule of both given lists.
+func rulesOverlapOnGroup(a, b []admissionregistrationv1.RuleWithOperations, group string) bool {
+	for _, ra := range a {
+		for _, rb := range b {
+			if ruleOverlap(ra, rb) && matchesAny(ra.APIGroups, group) && matchesAny(rb.APIGroups, group) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func ruleOverlap(a, b admissionregistrationv1.RuleWithOperations) bool {
+	var opsA, opsB []string
+	for _, op := range a.Operations {
+		opsA = append(opsA, string(op))
+	}
+	for _, op := range b.Operations {
+		opsB = append(opsB, string(op))
+	}
+	return overlap(opsA, opsB, matchWildcard) &&
+		overlap(a.APIGroups, b.APIGroups, matchWildcard) &&
+		overlap(a.APIVersions, b.APIVersions, matchWildcard) &&
+		overlap(a.Resources, b.Resources, matchResource)
+}
+
+func overlap(a, b []string, match func(x, y string) bool) bool {
+	for _, x := range a {
+		for _, y := range b {
+			if match(x, y) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func matchesAny(values []string, value string) bool {
+	for _, v := range values {
+		if matchWildcard(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchWildcard(x, y string) bool {
+	return x == "*" || y == "*" || x == y
+}
+
+// matchResource matches resources of admission rules, which may be "*", "<resource>", "<resource>/<subresource>",
+// "<resource>/*" or "*/<subresource>".
+func matchResource(x, y string) bool {
+	if x == "*" || y == "*" {
+		return true
+	}
+	xs, ys := strings.SplitN(x, "/", 2), strings.SplitN(y, "/", 2)
+	if len(xs) != len(ys) {
+		return false
+	}
+	for i := range xs {
+		if !matchWildcard(xs[i], ys[i]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,121 @@
+package catalog
+
+import (
+	"testing"
+
+	"github.com/blang/semver/v4"
+	"github.com/stretchr/testify/require"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/operator-framework/api/pkg/lib/version"
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+)
+
+func TestWebhookConflicts(t *testing.T) {
+	fail, ignore := admissionregistrationv1.Fail, admissionregistrationv1.Ignore
+	rule := func(group, resource string, ops ...admissionregistrationv1.OperationType) []admissionregistrationv1.RuleWithOperations {
+		return []admissionregistrationv1.RuleWithOperations{{
+			Operations: ops,
+			Rule: admissionregistrationv1.Rule{
+				APIGroups:   []string{group},
+				APIVersions: []string{"*"},
+				Resources:   []string{resource},
+			},
+		}}
+	}
+	csv := func(policy *admissionregistrationv1.FailurePolicyType, rules []admissionregistrationv1.RuleWithOperations) *v1alpha1.ClusterServiceVersion {
+		return &v1alpha1.ClusterServiceVersion{
+			ObjectMeta: metav1.ObjectMeta{Name: "new.v2", Namespace: "ns"},
+			Spec: v1alpha1.ClusterServiceVersionSpec{
+				Replaces: "new.v1",
+				WebhookDefinitions: []v1alpha1.WebhookDescription{{
+					GenerateName:  "vnew.example.com",
+					Type:          v1alpha1.ValidatingAdmissionWebhook,
+					Rules:         rules,
+					FailurePolicy: policy,
+				}},
+			},
+		}
+	}
+	csvWithSkips := func(skips []string, skipRange string) *v1alpha1.ClusterServiceVersion {
+		c := csv(&ignore, rule("apps", "deployments", admissionregistrationv1.Create))
+		c.Spec.Skips = skips
+		c.SetAnnotations(map[string]string{skipRangeAnnotationKey: skipRange})
+		return c
+	}
+	versioned := func(name, v string) *v1alpha1.ClusterServiceVersion {
+		return &v1alpha1.ClusterServiceVersion{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ns"},
+			Spec:       v1alpha1.ClusterServiceVersionSpec{Version: version.OperatorVersion{Version: semver.MustParse(v)}},
+		}
+	}
+	installed := func(owner string, policy *admissionregistrationv1.FailurePolicyType, rules []admissionregistrationv1.RuleWithOperations) []installedWebhook {
+		return []installedWebhook{{configuration: "config", name: "vother.example.com", owner: owner, ownerNS: "ns", rules: rules, failurePolicy: policy}}
+	}
+
+	tests := []struct {
+		description string
+		csv         *v1alpha1.ClusterServiceVersion
+		csvs        []*v1alpha1.ClusterServiceVersion
+		installed   []installedWebhook
+		conflicts   int
+	}{
+		{
+			description: "DifferentFailurePolicy",
+			csv:         csv(&ignore, rule("apps", "deployments", admissionregistrationv1.Create)),
+			installed:   installed("other.v1", nil, rule("*", "*", admissionregistrationv1.OperationAll)),
+			conflicts:   1,
+		},
+		{
+			description: "SameFailurePolicy",
+			csv:         csv(&fail, rule("apps", "deployments", admissionregistrationv1.Create)),
+			installed:   installed("other.v1", &fail, rule("apps", "deployments", admissionregistrationv1.Create)),
+		},
+		{
+			description: "SameFailurePolicyOnOLMGroup",
+			csv:         csv(&fail, rule(v1alpha1.GroupName, "subscriptions", admissionregistrationv1.Create)),
+			installed:   installed("other.v1", &fail, rule("*", "*", admissionregistrationv1.OperationAll)),
+			conflicts:   1,
+		},
+		{
+			description: "DisjointOperations",
+			csv:         csv(&ignore, rule("apps", "deployments", admissionregistrationv1.Create)),
+			installed:   installed("other.v1", &fail, rule("apps", "deployments", admissionregistrationv1.Delete)),
+		},
+		{
+			description: "DisjointSubresources",
+			csv:         csv(&ignore, rule("apps", "deployments", admissionregistrationv1.Create)),
+			installed:   installed("other.v1", &fail, rule("apps", "deployments/status", admissionregistrationv1.Create)),
+		},
+		{
+			description: "ReplacedCSV",
+			csv:         csv(&ignore, rule("apps", "deployments", admissionregistrationv1.Create)),
+			installed:   installed("new.v1", &fail, rule("apps", "deployments", admissionregistrationv1.Create)),
+		},
+		{
+			description: "SkippedCSV",
+			csv:         csvWithSkips([]string{"new.v0"}, ""),
+			installed:   installed("new.v0", &fail, rule("apps", "deployments", admissionregistrationv1.Create)),
+		},
+		{
+			description: "CSVInSkipRange",
+			csv:         csvWithSkips(nil, ">=0.1.0 <2.0.0"),
+			csvs:        []*v1alpha1.ClusterServiceVersion{versioned("new.v0", "0.1.0")},
+			installed:   installed("new.v0", &fail, rule("apps", "deployments", admissionregistrationv1.Create)),
+		},
+		{
+			description: "CSVOutOfSkipRange",
+			csv:         csvWithSkips(nil, ">=0.2.0 <2.0.0"),
+			csvs:        []*v1alpha1.ClusterServiceVersion{versioned("new.v0", "0.1.0")},
+			installed:   installed("new.v0", &fail, rule("apps", "deployments", admissionregistrationv1.Create)),
+			conflicts:   1,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			require.Len(t, webhookConflicts("ns", tt.csv, tt.csvs, tt.installed), tt.conflicts)
+		})
+	}
+}
@@ -19,6 +19,7 @@ import (
 	operatorsv1 "github.com/operator-framework/api/pkg/operators/v1"
 	operatorsv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/codec"
+	csvutility "github.com/operator-framework/operator-lifecycle-manager/pkg/lib/csv"
 )
 
 const (
@@ -325,6 +326,38 @@ func (o *Operator) SetComponents(components ...runtime.Object) error {
 	return o.AddComponents(components...)
 }
 
+// SetUIExtensions lists the UI extensions declared by the CSVs among the given components in the operator's
+// annotations and returns true if they changed. Copied CSVs and CSVs with invalid declarations are ignored.
+// List type arguments are flattened to their nested elements.
+func (o *Operator) SetUIExtensions(components ...runtime.Object) (bool, error) {
+	var extensions []csvutility.UIExtension
+	var collect func(objs ...runtime.Object)
+	collect = func(objs ...runtime.Object) {
+		for _, obj := range objs {
+			if nested, err := meta.ExtractList(obj); err == nil {
+				collect(nested...)
+				continue
+			}
+			csv, ok := obj.(*operatorsv1alpha1.ClusterServiceVersion)
+			if !ok || csv.IsCopied() {
+				continue
+			}
+			declared, err := csvutility.UIExtensionsFor(csv.GetAnnotations(), nil)
+			if err != nil {
+				continue
+			}
+			for _, extension := range declared {
+				extension.Namespace = csv.GetNamespace()
+				extension.ClusterServiceVersion = csv.GetName()
+				extensions = append(extensions, extension)
+			}
+		}
+	}
+	collect(components...)
+
+	return csvutility.SetUIExtensions(o, extensions)
+}
+
 type Component struct {
 	*unstructured.Unstructured
 
@@ -1,6 +1,7 @@
 package decorators
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -12,6 +13,7 @@ import (
 
 	operatorsv1 "github.com/operator-framework/api/pkg/operators/v1"
 	operatorsv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	csvutility "github.com/operator-framework/operator-lifecycle-manager/pkg/lib/csv"
 )
 
 func TestOperatorNames(t *testing.T) {
@@ -231,3 +233,42 @@ func TestAddComponents(t *testing.T) {
 		})
 	}
 }
+
+func TestSetUIExtensions(t *testing.T) {
+	csv := func(name string, annotations map[string]string) *operatorsv1alpha1.ClusterServiceVersion {
+		return &operatorsv1alpha1.ClusterServiceVersion{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: name, Annotations: annotations}}
+	}
+	copied := csv("copied", map[string]string{csvutility.ConsolePluginsAnnotationKey: `["copied"]`})
+	copied.Status.Reason = operatorsv1alpha1.CSVReasonCopied
+
+	operator := &Operator{Operator: &operatorsv1.Operator{ObjectMeta: metav1.ObjectMeta{Name: "lobster"}}}
+	changed, err := operator.SetUIExtensions(&operatorsv1alpha1.ClusterServiceVersionList{Items: []operatorsv1alpha1.ClusterServiceVersion{
+		*csv("a", map[string]string{
+			csvutility.ConsolePluginsAnnotationKey: `["lobster-plugin"]`,
+			csvutility.UIExtensionsAnnotationKey:   `[{"type":"dashboard","name":"overview","config":{"path":"/overview"}}]`,
+		}),
+		*csv("invalid", map[string]string{csvutility.ConsolePluginsAnnotationKey: `lobster`}),
+		*copied,
+	}})
+	require.NoError(t, err)
+	require.True(t, changed)
+
+	extensions, err := csvutility.UIExtensions(operator)
+	require.NoError(t, err)
+	require.Equal(t, []csvutility.UIExtension{
+		{Type: csvutility.ConsolePluginUIExtensionType, Name: "lobster-plugin", Namespace: "ns", ClusterServiceVersion: "a"},
+		{Type: "dashboard", Name: "overview", Config: json.RawMessage(`{"path":"/overview"}`), Namespace: "ns", ClusterServiceVersion: "a"},
+	}, extensions)
+
+	changed, err = operator.SetUIExtensions(csv("a", map[string]string{
+		csvutility.UIExtensionsAnnotationKey:   `[{"type":"dashboard","name":"overview","config":{"path":"/overview"}}]`,
+		csvutility.ConsolePluginsAnnotationKey: `["lobster-plugin"]`,
+	}))
+	require.NoError(t, err)
+	require.False(t, changed, "unchanged extensions don't update the operator")
+
+	changed, err = operator.SetUIExtensions()
+	require.NoError(t, err)
+	require.True(t, changed)
+	require.NotContains(t, operator.GetAnnotations(), csvutility.UIExtensionsAnnotationKey)
+}
@@ -0,0 +1,72 @@
+// Package installfake holds the fixtures the OperatorInstall and OperatorUninstall controllers are tested with. Both
+// are served as unstructured resources, and read the OLM resources of their namespace from listers.
+package installfake
+
+import (
+	"context"
+	"testing"
+
+	operatorsv1 "github.com/operator-framework/api/pkg/operators/v1"
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/tools/cache"
+
+	operatorsv1listers "github.com/operator-framework/operator-lifecycle-manager/pkg/api/client/listers/operators/v1"
+	operatorsv1alpha1listers "github.com/operator-framework/operator-lifecycle-manager/pkg/api/client/listers/operators/v1alpha1"
+)
+
+// Listers are listers of OLM resources fed with objects rather than informers.
+type Listers struct {
+	OperatorGroups operatorsv1listers.OperatorGroupLister
+	Subscriptions  operatorsv1alpha1listers.SubscriptionLister
+	CSVs           operatorsv1alpha1listers.ClusterServiceVersionLister
+}
+
+// NewListers returns Listers of the OperatorGroups, Subscriptions and CSVs among the given objects.
+func NewListers(t testing.TB, objs ...runtime.Object) Listers {
+	indexer := func() cache.Indexer {
+		return cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	}
+	ogs, subs, csvs := indexer(), indexer(), indexer()
+	for _, obj := range objs {
+		switch obj.(type) {
+		case *operatorsv1.OperatorGroup:
+			require.NoError(t, ogs.Add(obj))
+		case *v1alpha1.Subscription:
+			require.NoError(t, subs.Add(obj))
+		case *v1alpha1.ClusterServiceVersion:
+			require.NoError(t, csvs.Add(obj))
+		}
+	}
+	return Listers{
+		OperatorGroups: operatorsv1listers.NewOperatorGroupLister(ogs),
+		Subscriptions:  operatorsv1alpha1listers.NewSubscriptionLister(subs),
+		CSVs:           operatorsv1alpha1listers.NewClusterServiceVersionLister(csvs),
+	}
+}
+
+// NewDynamicClient returns a fake dynamic client serving the given unstructured objects, of resources listed as the
+// kinds given.
+func NewDynamicClient(listKinds map[schema.GroupVersionResource]string, objs ...runtime.Object) *dynamicfake.FakeDynamicClient {
+	return dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), listKinds, objs...)
+}
+
+// ToUnstructured returns the given typed object as an unstructured one.
+func ToUnstructured(t testing.TB, obj interface{}) *unstructured.Unstructured {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	require.NoError(t, err)
+	return &unstructured.Unstructured{Object: content}
+}
+
+// Get reads the named object of the given resource from the given dynamic client into out.
+func Get(t testing.TB, client dynamic.Interface, resource schema.GroupVersionResource, namespace, name string, out interface{}) {
+	u, err := client.Resource(resource).Namespace(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+	require.NoError(t, err)
+	require.NoError(t, runtime.DefaultUnstructuredConverter.FromUnstructured(u.UnstructuredContent(), out))
+}
@@ -1,12 +1,14 @@
 package olm
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 
 	log "github.com/sirupsen/logrus"
 	appsv1 "k8s.io/api/apps/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -16,6 +18,7 @@ import (
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/certs"
 	olmerrors "github.com/operator-framework/operator-lifecycle-manager/pkg/controller/errors"
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/install"
+	crdlib "github.com/operator-framework/operator-lifecycle-manager/pkg/lib/crd"
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/ownerutil"
 )
 
@@ -46,8 +49,13 @@ func (a *Operator) checkAPIServiceResources(csv *v1alpha1.ClusterServiceVersion,
 		"namespace": csv.GetNamespace(),
 	})
 
+	selfManagedCABundle, selfManaged, err := install.SelfManagedCABundle(csv)
+	if err != nil {
+		return err
+	}
+
 	errs := []error{}
-	ruleChecker := install.NewCSVRuleChecker(a.lister.RbacV1().RoleLister(), a.lister.RbacV1().RoleBindingLister(), a.lister.RbacV1().ClusterRoleLister(), a.lister.RbacV1().ClusterRoleBindingLister(), csv)
+	ruleChecker := a.ruleChecker(csv)
 	for _, desc := range csv.GetOwnedAPIServiceDescriptions() {
 		apiServiceName := desc.GetName()
 		logger := logger.WithFields(log.Fields{
@@ -92,6 +100,15 @@ func (a *Operator) checkAPIServiceResources(csv *v1alpha1.ClusterServiceVersion,
 			continue
 		}
 
+		// The serving certs of operators managing their own aren't OLM's concern, only the injected CA bundle is
+		if selfManaged {
+			if !bytes.Equal(apiService.Spec.CABundle, selfManagedCABundle) {
+				logger.Warnf("APIService CA bundle does not match the self-managed CA bundle")
+				errs = append(errs, fmt.Errorf("found APIService CA bundle not matching the self-managed CA bundle"))
+			}
+			continue
+		}
+
 		// Check if CA is Active
 		caBundle := apiService.Spec.CABundle
 		ca, err := certs.PEMToCert(caBundle)
@@ -264,14 +281,14 @@ func (a *Operator) getAPIServiceCABundle(csv *v1alpha1.ClusterServiceVersion, de
 }
 
 // getWebhookCABundle returns the CA associated with a webhook
-func (a *Operator) getWebhookCABundle(csv *v1alpha1.ClusterServiceVersion, desc *v1alpha1.WebhookDescription) ([]byte, error) {
+func (a *Operator) getWebhookCABundle(ctx context.Context, csv *v1alpha1.ClusterServiceVersion, desc *v1alpha1.WebhookDescription) ([]byte, error) {
 	webhookLabels := ownerutil.OwnerLabel(csv, v1alpha1.ClusterServiceVersionKind)
 	webhookLabels[install.WebhookDescKey] = desc.GenerateName
 	webhookSelector := labels.SelectorFromSet(webhookLabels).String()
 
 	switch desc.Type {
 	case v1alpha1.MutatingAdmissionWebhook:
-		existingWebhooks, err := a.opClient.KubernetesInterface().AdmissionregistrationV1().MutatingWebhookConfigurations().List(context.TODO(), metav1.ListOptions{LabelSelector: webhookSelector})
+		existingWebhooks, err := a.opClient.KubernetesInterface().AdmissionregistrationV1().MutatingWebhookConfigurations().List(ctx, metav1.ListOptions{LabelSelector: webhookSelector})
 		if err != nil {
 			return nil, fmt.Errorf("could not retrieve generated MutatingWebhookConfiguration: %v", err)
 		}
@@ -280,7 +297,7 @@ func (a *Operator) getWebhookCABundle(csv *v1alpha1.ClusterServiceVersion, desc
 			return existingWebhooks.Items[0].Webhooks[0].ClientConfig.CABundle, nil
 		}
 	case v1alpha1.ValidatingAdmissionWebhook:
-		existingWebhooks, err := a.opClient.KubernetesInterface().AdmissionregistrationV1().ValidatingWebhookConfigurations().List(context.TODO(), metav1.ListOptions{LabelSelector: webhookSelector})
+		existingWebhooks, err := a.opClient.KubernetesInterface().AdmissionregistrationV1().ValidatingWebhookConfigurations().List(ctx, metav1.ListOptions{LabelSelector: webhookSelector})
 		if err != nil {
 			return nil, fmt.Errorf("could not retrieve generated ValidatingWebhookConfiguration: %v", err)
 		}
@@ -291,11 +308,11 @@ func (a *Operator) getWebhookCABundle(csv *v1alpha1.ClusterServiceVersion, desc
 	case v1alpha1.ConversionWebhook:
 		for _, conversionCRD := range desc.ConversionCRDs {
 			// check if CRD exists on cluster
-			crd, err := a.opClient.ApiextensionsInterface().ApiextensionsV1().CustomResourceDefinitions().Get(context.TODO(), conversionCRD, metav1.GetOptions{})
+			crd, err := a.opClient.ApiextensionsInterface().ApiextensionsV1().CustomResourceDefinitions().Get(ctx, conversionCRD, metav1.GetOptions{})
 			if err != nil {
 				continue
 			}
-			if crd.Spec.Conversion == nil || crd.Spec.Conversion.Webhook == nil || crd.Spec.Conversion.Webhook.ClientConfig == nil && crd.Spec.Conversion.Webhook.ClientConfig.CABundle == nil {
+			if crd.Spec.Conversion == nil || crd.Spec.Conversion.Webhook == nil || crd.Spec.Conversion.Webhook.ClientConfig == nil || crd.Spec.Conversion.Webhook.ClientConfig.CABundle == nil {
 				continue
 			}
 
@@ -309,15 +326,15 @@ func (a *Operator) getWebhookCABundle(csv *v1alpha1.ClusterServiceVersion, desc
 // updateDeploymentSpecsWithAPIServiceData transforms an install strategy to include information about apiservices
 // it is used in generating hashes for deployment specs to know when something in the spec has changed,
 // but duplicates a lot of installAPIServiceRequirements and should be refactored.
-func (a *Operator) updateDeploymentSpecsWithAPIServiceData(csv *v1alpha1.ClusterServiceVersion, strategy install.Strategy) (install.Strategy, error) {
+func (a *Operator) updateDeploymentSpecsWithAPIServiceData(ctx context.Context, csv *v1alpha1.ClusterServiceVersion, strategy install.Strategy) (install.Strategy, error) {
 	// Assume the strategy is for a deployment
 	strategyDetailsDeployment, ok := strategy.(*v1alpha1.StrategyDetailsDeployment)
 	if !ok {
 		return nil, fmt.Errorf("unsupported InstallStrategy type")
 	}
 
-	// Return early if there are no owned APIServices
-	if !csv.HasCAResources() {
+	// Return early if there are no owned APIServices, or their certs aren't mounted by OLM
+	if _, selfManaged, _ := install.SelfManagedCABundle(csv); !csv.HasCAResources() || selfManaged {
 		return strategyDetailsDeployment, nil
 	}
 
@@ -354,7 +371,7 @@ func (a *Operator) updateDeploymentSpecsWithAPIServiceData(csv *v1alpha1.Cluster
 	}
 
 	for _, desc := range csv.Spec.WebhookDefinitions {
-		caBundle, err := a.getWebhookCABundle(csv, &desc)
+		caBundle, err := a.getWebhookCABundle(ctx, csv, &desc)
 		if err != nil {
 			return nil, fmt.Errorf("could not retrieve caBundle for WebhookDescription %s: %v", desc.GenerateName, err)
 		}
@@ -389,7 +406,7 @@ func (a *Operator) updateDeploymentSpecsWithAPIServiceData(csv *v1alpha1.Cluster
 	return strategyDetailsDeployment, nil
 }
 
-func (a *Operator) cleanUpRemovedWebhooks(csv *v1alpha1.ClusterServiceVersion) error {
+func (a *Operator) cleanUpRemovedWebhooks(ctx context.Context, csv *v1alpha1.ClusterServiceVersion) error {
 	webhookLabels := ownerutil.OwnerLabel(csv, v1alpha1.ClusterServiceVersionKind)
 	webhookSelector := labels.SelectorFromSet(webhookLabels).String()
 
@@ -399,7 +416,7 @@ func (a *Operator) cleanUpRemovedWebhooks(csv *v1alpha1.ClusterServiceVersion) e
 	}
 
 	// Delete unknown ValidatingWebhooksConfigurations owned by the CSV
-	validatingWebhookConfigurationList, err := a.opClient.KubernetesInterface().AdmissionregistrationV1().ValidatingWebhookConfigurations().List(context.TODO(), metav1.ListOptions{LabelSelector: webhookSelector})
+	validatingWebhookConfigurationList, err := a.opClient.KubernetesInterface().AdmissionregistrationV1().ValidatingWebhookConfigurations().List(ctx, metav1.ListOptions{LabelSelector: webhookSelector})
 	if err != nil {
 		return err
 	}
@@ -409,7 +426,7 @@ func (a *Operator) cleanUpRemovedWebhooks(csv *v1alpha1.ClusterServiceVersion) e
 			return fmt.Errorf("validatingWebhookConfiguration %s does not have WebhookDesc key", webhook.Name)
 		}
 		if _, ok := csvWebhookGenerateNames[webhookGenerateNameLabel]; !ok {
-			err = a.opClient.KubernetesInterface().AdmissionregistrationV1().ValidatingWebhookConfigurations().Delete(context.TODO(), webhook.Name, metav1.DeleteOptions{})
+			err = a.opClient.KubernetesInterface().AdmissionregistrationV1().ValidatingWebhookConfigurations().Delete(ctx, webhook.Name, metav1.DeleteOptions{})
 			if err != nil && k8serrors.IsNotFound(err) {
 				return err
 			}
@@ -417,7 +434,7 @@ func (a *Operator) cleanUpRemovedWebhooks(csv *v1alpha1.ClusterServiceVersion) e
 	}
 
 	// Delete unknown MutatingWebhooksConfigurations owned by the CSV
-	mutatingWebhookConfigurationList, err := a.opClient.KubernetesInterface().AdmissionregistrationV1().MutatingWebhookConfigurations().List(context.TODO(), metav1.ListOptions{LabelSelector: webhookSelector})
+	mutatingWebhookConfigurationList, err := a.opClient.KubernetesInterface().AdmissionregistrationV1().MutatingWebhookConfigurations().List(ctx, metav1.ListOptions{LabelSelector: webhookSelector})
 	if err != nil {
 		return err
 	}
@@ -427,18 +444,77 @@ func (a *Operator) cleanUpRemovedWebhooks(csv *v1alpha1.ClusterServiceVersion) e
 			return fmt.Errorf("mutatingWebhookConfiguration %s does not have WebhookDesc key", webhook.Name)
 		}
 		if _, ok := csvWebhookGenerateNames[webhookGenerateNameLabel]; !ok {
-			err = a.opClient.KubernetesInterface().AdmissionregistrationV1().MutatingWebhookConfigurations().Delete(context.TODO(), webhook.Name, metav1.DeleteOptions{})
+			err = a.opClient.KubernetesInterface().AdmissionregistrationV1().MutatingWebhookConfigurations().Delete(ctx, webhook.Name, metav1.DeleteOptions{})
 			if err != nil && k8serrors.IsNotFound(err) {
 				return err
 			}
 		}
 	}
 
+	return a.cleanUpRemovedConversionWebhooks(ctx, csv)
+}
+
+// cleanUpRemovedConversionWebhooks resets the conversion strategy of the owned CRDs of the given CSV to None when the
+// conversion webhook OLM configured on them is no longer declared by the CSV, e.g. after an upgrade dropping it.
+func (a *Operator) cleanUpRemovedConversionWebhooks(ctx context.Context, csv *v1alpha1.ClusterServiceVersion) error {
+	converted := map[string]struct{}{}
+	for _, desc := range csv.Spec.WebhookDefinitions {
+		if desc.Type != v1alpha1.ConversionWebhook {
+			continue
+		}
+		for _, crd := range desc.ConversionCRDs {
+			converted[crd] = struct{}{}
+		}
+	}
+
+	for _, owned := range csv.Spec.CustomResourceDefinitions.Owned {
+		crd, err := a.lister.APIExtensionsV1().CustomResourceDefinitionLister().Get(owned.Name)
+		if err != nil {
+			if k8serrors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+		// CRDs moved to another conversion webhook are updated on install instead
+		webhook, ok := crd.GetAnnotations()[crdlib.ConversionWebhookAnnotationKey]
+		if _, converted := converted[crd.GetName()]; !ok || converted {
+			continue
+		}
+
+		crd = crd.DeepCopy()
+		crd.Spec.Conversion = &apiextensionsv1.CustomResourceConversion{Strategy: apiextensionsv1.NoneConverter}
+		delete(crd.Annotations, crdlib.ConversionWebhookAnnotationKey)
+		if _, err := a.opClient.ApiextensionsInterface().ApiextensionsV1().CustomResourceDefinitions().Update(ctx, crd, metav1.UpdateOptions{}); err != nil {
+			return fmt.Errorf("unable to remove conversion webhook %s from CRD %s: %v", webhook, crd.GetName(), err)
+		}
+	}
 	return nil
 }
 
-func (a *Operator) areWebhooksAvailable(csv *v1alpha1.ClusterServiceVersion) (bool, error) {
-	err := a.cleanUpRemovedWebhooks(csv)
+// ensureAdmissionPolicies reconciles the ValidatingAdmissionPolicies declared by the CSV, removing any it no longer declares.
+func (a *Operator) ensureAdmissionPolicies(ctx context.Context, csv *v1alpha1.ClusterServiceVersion) error {
+	descs, err := install.ValidatingAdmissionPolicyDescriptions(csv)
+	if err != nil {
+		return err
+	}
+	return a.admissionPolicyClient.EnsurePolicies(ctx, csv, descs)
+}
+
+// ensureKubeconfigs generates the kubeconfig Secrets declared by the CSV and rotates their tokens, removing any it no longer declares.
+func (a *Operator) ensureKubeconfigs(ctx context.Context, csv *v1alpha1.ClusterServiceVersion) error {
+	descs, err := install.KubeconfigDescriptions(csv)
+	if err != nil {
+		return err
+	}
+	return a.kubeconfigClient.EnsureKubeconfigs(ctx, csv, descs)
+}
+
+func (a *Operator) areWebhooksAvailable(ctx context.Context, csv *v1alpha1.ClusterServiceVersion) (bool, error) {
+	err := a.cleanUpRemovedWebhooks(ctx, csv)
+	if err != nil {
+		return false, err
+	}
+	scopes, err := install.WebhookScopes(csv)
 	if err != nil {
 		return false, err
 	}
@@ -446,37 +522,44 @@ func (a *Operator) areWebhooksAvailable(csv *v1alpha1.ClusterServiceVersion) (bo
 		// Create Webhook Label Selector
 		webhookLabels := ownerutil.OwnerLabel(csv, v1alpha1.ClusterServiceVersionKind)
 		webhookLabels[install.WebhookDescKey] = desc.GenerateName
-		webhookLabels[install.WebhookHashKey] = install.HashWebhookDesc(desc)
+		webhookLabels[install.WebhookHashKey] = install.HashWebhook(desc, scopes[desc.GenerateName])
 		webhookSelector := labels.SelectorFromSet(webhookLabels).String()
 
 		webhookCount := 0
 		switch desc.Type {
 		case v1alpha1.ValidatingAdmissionWebhook:
-			webhookList, err := a.opClient.KubernetesInterface().AdmissionregistrationV1().ValidatingWebhookConfigurations().List(context.TODO(), metav1.ListOptions{LabelSelector: webhookSelector})
+			webhookList, err := a.opClient.KubernetesInterface().AdmissionregistrationV1().ValidatingWebhookConfigurations().List(ctx, metav1.ListOptions{LabelSelector: webhookSelector})
 			if err != nil {
 				return false, err
 			}
 			webhookCount = len(webhookList.Items)
 		case v1alpha1.MutatingAdmissionWebhook:
-			webhookList, err := a.opClient.KubernetesInterface().AdmissionregistrationV1().MutatingWebhookConfigurations().List(context.TODO(), metav1.ListOptions{LabelSelector: webhookSelector})
+			webhookList, err := a.opClient.KubernetesInterface().AdmissionregistrationV1().MutatingWebhookConfigurations().List(ctx, metav1.ListOptions{LabelSelector: webhookSelector})
 			if err != nil {
 				return false, err
 			}
 			webhookCount = len(webhookList.Items)
 		case v1alpha1.ConversionWebhook:
+			var crds []*apiextensionsv1.CustomResourceDefinition
 			for _, conversionCRD := range desc.ConversionCRDs {
 				// check if CRD exists on cluster
-				crd, err := a.opClient.ApiextensionsInterface().ApiextensionsV1().CustomResourceDefinitions().Get(context.TODO(), conversionCRD, metav1.GetOptions{})
+				crd, err := a.opClient.ApiextensionsInterface().ApiextensionsV1().CustomResourceDefinitions().Get(ctx, conversionCRD, metav1.GetOptions{})
 				if err != nil {
 					log.Infof("CRD not found %v, error: %s", desc, err.Error())
 					return false, err
 				}
 
-				if crd.Spec.Conversion == nil || crd.Spec.Conversion.Strategy != "Webhook" || crd.Spec.Conversion.Webhook == nil || crd.Spec.Conversion.Webhook.ClientConfig == nil && crd.Spec.Conversion.Webhook.ClientConfig.CABundle == nil {
+				// The conversion may have been overwritten since, e.g. by an update of the CRD
+				if !install.ConversionWebhookConfigured(crd, csv.GetNamespace(), desc) {
 					return false, fmt.Errorf("conversionWebhook not ready")
 				}
+				crds = append(crds, crd)
 				webhookCount++
 			}
+			// A rotated CA must be injected into all the CRDs the webhook converts
+			if !install.ConversionCABundlesMatch(crds) {
+				return false, fmt.Errorf("conversionWebhook %s has different CAs across its CRDs", desc.GenerateName)
+			}
 		}
 		if webhookCount == 0 {
 			return false, nil
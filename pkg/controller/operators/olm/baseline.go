@@ -0,0 +1,285 @@
+package olm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	utilclock "k8s.io/apimachinery/pkg/util/clock"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/ownerutil"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/metrics"
+)
+
+const (
+	// ResourceBaselineAnnotationKey is the CSV annotation OLM records the resource consumption baseline of the
+	// operator's pods in, once the CSV has succeeded and its pods have been sampled for the baseline window.
+	ResourceBaselineAnnotationKey = "operatorframework.io/resource-baseline"
+
+	// resourceBaselineSampleInterval is the time between two samples of a CSV's pods.
+	resourceBaselineSampleInterval = 30 * time.Second
+
+	// resourceBaselineRegressionThreshold is the relative growth over the baseline of the replaced CSV above which
+	// a footprint regression is reported.
+	resourceBaselineRegressionThreshold = 0.25
+
+	// resourceBaselineRegressionReason is the reason of the event emitted for a footprint regression.
+	resourceBaselineRegressionReason = "ResourceBaselineRegression"
+)
+
+// PodMetricsGVR is the resource serving pod usage in the resource metrics API.
+var PodMetricsGVR = schema.GroupVersionResource{Group: "metrics.k8s.io", Version: "v1beta1", Resource: "pods"}
+
+// ResourceBaseline is the average resource consumption of an operator's pods, summed across pods.
+type ResourceBaseline struct {
+	CPU        resource.Quantity `json:"cpu"`
+	Memory     resource.Quantity `json:"memory"`
+	Samples    int               `json:"samples"`
+	RecordedAt metav1.Time       `json:"recordedAt"`
+}
+
+// ResourceBaselineFor returns the baseline recorded on the given CSV, or nil if none has been recorded.
+func ResourceBaselineFor(csv *v1alpha1.ClusterServiceVersion) (*ResourceBaseline, error) {
+	value, ok := csv.GetAnnotations()[ResourceBaselineAnnotationKey]
+	if !ok {
+		return nil, nil
+	}
+	baseline := &ResourceBaseline{}
+	if err := json.Unmarshal([]byte(value), baseline); err != nil {
+		return nil, fmt.Errorf("invalid %s annotation: %v", ResourceBaselineAnnotationKey, err)
+	}
+	return baseline, nil
+}
+
+// Regressions returns a description of each resource whose consumption grew by more than the regression
+// threshold over the given previous baseline.
+func (b *ResourceBaseline) Regressions(previous *ResourceBaseline) []string {
+	var regressions []string
+	compare := func(name string, current, previous resource.Quantity) {
+		if previous.IsZero() {
+			return
+		}
+		growth := (current.AsApproximateFloat64() - previous.AsApproximateFloat64()) / previous.AsApproximateFloat64()
+		if growth > resourceBaselineRegressionThreshold {
+			regressions = append(regressions, fmt.Sprintf("%s grew by %.0f%% (%s to %s)", name, growth*100, previous.String(), current.String()))
+		}
+	}
+	compare("cpu", b.CPU, previous.CPU)
+	compare("memory", b.Memory, previous.Memory)
+	return regressions
+}
+
+type baselineSampling struct {
+	started  time.Time
+	cpu      int64 // millicores, summed over samples
+	memory   int64 // bytes, summed over samples
+	samples  int
+	previous *ResourceBaseline
+}
+
+// resourceBaselineSampler samples the resource consumption of operator pods through the resource metrics API.
+// Samplings in progress are kept in memory and start over if OLM restarts.
+type resourceBaselineSampler struct {
+	client dynamic.Interface
+	clock  utilclock.Clock
+	window time.Duration
+
+	mu        sync.Mutex
+	samplings map[types.UID]*baselineSampling
+}
+
+func newResourceBaselineSampler(client dynamic.Interface, clock utilclock.Clock, window time.Duration) *resourceBaselineSampler {
+	return &resourceBaselineSampler{
+		client:    client,
+		clock:     clock,
+		window:    window,
+		samplings: map[types.UID]*baselineSampling{},
+	}
+}
+
+// sample takes a sample of the pods matching the given selectors in the CSV's namespace. Once the window has elapsed,
+// it returns the resulting baseline and the baseline of the replaced CSV, if any, until the sampling is forgotten.
+// Otherwise, it returns the time to wait before the next sample.
+func (s *resourceBaselineSampler) sample(ctx context.Context, csv *v1alpha1.ClusterServiceVersion, previous *ResourceBaseline, selectors []labels.Selector) (baseline, replaced *ResourceBaseline, next time.Duration, err error) {
+	var cpu, memory int64
+	for _, selector := range selectors {
+		podMetrics, err := s.client.Resource(PodMetricsGVR).Namespace(csv.GetNamespace()).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+		if err != nil {
+			return nil, nil, 0, err
+		}
+		for _, item := range podMetrics.Items {
+			c, m, err := podUsage(item)
+			if err != nil {
+				return nil, nil, 0, err
+			}
+			cpu += c
+			memory += m
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.clock.Now()
+	sampling, ok := s.samplings[csv.GetUID()]
+	if !ok {
+		sampling = &baselineSampling{started: now, previous: previous}
+		s.samplings[csv.GetUID()] = sampling
+	}
+	sampling.cpu += cpu
+	sampling.memory += memory
+	sampling.samples++
+
+	if elapsed := now.Sub(sampling.started); elapsed < s.window {
+		next = resourceBaselineSampleInterval
+		if remaining := s.window - elapsed; remaining < next {
+			next = remaining
+		}
+		return nil, nil, next, nil
+	}
+
+	baseline = &ResourceBaseline{
+		CPU:        *resource.NewMilliQuantity(sampling.cpu/int64(sampling.samples), resource.DecimalSI),
+		Memory:     *resource.NewQuantity(sampling.memory/int64(sampling.samples), resource.BinarySI),
+		Samples:    sampling.samples,
+		RecordedAt: metav1.NewTime(now),
+	}
+	return baseline, sampling.previous, 0, nil
+}
+
+// forget drops the sampling of the given CSV, if any.
+func (s *resourceBaselineSampler) forget(csv *v1alpha1.ClusterServiceVersion) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.samplings, csv.GetUID())
+}
+
+// podUsage returns the cpu, in millicores, and memory, in bytes, used by the containers of a PodMetrics object.
+func podUsage(podMetrics unstructured.Unstructured) (cpu, memory int64, err error) {
+	containers, _, err := unstructured.NestedSlice(podMetrics.Object, "containers")
+	if err != nil {
+		return 0, 0, err
+	}
+	for _, container := range containers {
+		fields, ok := container.(map[string]interface{})
+		if !ok {
+			return 0, 0, fmt.Errorf("unexpected container metrics type %T", container)
+		}
+		usage, _, err := unstructured.NestedStringMap(fields, "usage")
+		if err != nil {
+			return 0, 0, err
+		}
+		if value, ok := usage[string(corev1.ResourceCPU)]; ok {
+			q, err := resource.ParseQuantity(value)
+			if err != nil {
+				return 0, 0, err
+			}
+			cpu += q.MilliValue()
+		}
+		if value, ok := usage[string(corev1.ResourceMemory)]; ok {
+			q, err := resource.ParseQuantity(value)
+			if err != nil {
+				return 0, 0, err
+			}
+			memory += q.Value()
+		}
+	}
+	return cpu, memory, nil
+}
+
+// recordResourceBaseline samples the pods of a succeeded CSV until a resource baseline can be recorded on it.
+func (a *Operator) recordResourceBaseline(ctx context.Context, logger *logrus.Entry, csv *v1alpha1.ClusterServiceVersion) {
+	if a.baselineSampler == nil {
+		return
+	}
+	if csv.Status.Phase != v1alpha1.CSVPhaseSucceeded {
+		a.baselineSampler.forget(csv)
+		return
+	}
+	if _, ok := csv.GetAnnotations()[ResourceBaselineAnnotationKey]; ok {
+		return
+	}
+
+	deployments, err := a.lister.AppsV1().DeploymentLister().Deployments(csv.GetNamespace()).List(ownerutil.CSVOwnerSelector(csv))
+	if err != nil {
+		logger.WithError(err).Warn("unable to list deployments to sample resource baseline")
+		return
+	}
+	selectors := make([]labels.Selector, 0, len(deployments))
+	for _, deployment := range deployments {
+		selector, err := metav1.LabelSelectorAsSelector(deployment.Spec.Selector)
+		if err != nil {
+			logger.WithError(err).Warn("unable to sample resource baseline")
+			return
+		}
+		selectors = append(selectors, selector)
+	}
+
+	baseline, previous, next, err := a.baselineSampler.sample(ctx, csv, a.replacedResourceBaseline(csv), selectors)
+	if k8serrors.IsNotFound(err) {
+		logger.Debug("resource metrics API unavailable, not recording resource baseline")
+		return
+	}
+	if err != nil {
+		logger.WithError(err).Warn("unable to sample resource baseline")
+		_ = a.csvQueueSet.RequeueAfter(csv.GetNamespace(), csv.GetName(), resourceBaselineSampleInterval)
+		return
+	}
+	if baseline == nil {
+		_ = a.csvQueueSet.RequeueAfter(csv.GetNamespace(), csv.GetName(), next)
+		return
+	}
+
+	value, err := json.Marshal(baseline)
+	if err != nil {
+		logger.WithError(err).Warn("unable to record resource baseline")
+		return
+	}
+	out := csv.DeepCopy()
+	if out.Annotations == nil {
+		out.Annotations = map[string]string{}
+	}
+	out.Annotations[ResourceBaselineAnnotationKey] = string(value)
+	if _, err := a.client.OperatorsV1alpha1().ClusterServiceVersions(out.GetNamespace()).Update(ctx, out, metav1.UpdateOptions{}); err != nil {
+		logger.WithError(err).Warn("unable to record resource baseline")
+		return
+	}
+	a.baselineSampler.forget(csv)
+	metrics.EmitCSVResourceBaseline(out, baseline.CPU.AsApproximateFloat64(), baseline.Memory.AsApproximateFloat64())
+
+	if previous == nil {
+		return
+	}
+	for _, regression := range baseline.Regressions(previous) {
+		a.recorder.Eventf(out, corev1.EventTypeWarning, resourceBaselineRegressionReason, "resource consumption regressed since %s: %s", out.Spec.Replaces, regression)
+	}
+}
+
+// replacedResourceBaseline returns the baseline recorded on the CSV replaced by the given one, if it's still around.
+func (a *Operator) replacedResourceBaseline(csv *v1alpha1.ClusterServiceVersion) *ResourceBaseline {
+	if csv.Spec.Replaces == "" {
+		return nil
+	}
+	replaced, err := a.lister.OperatorsV1alpha1().ClusterServiceVersionLister().ClusterServiceVersions(csv.GetNamespace()).Get(csv.Spec.Replaces)
+	if err != nil {
+		return nil
+	}
+	baseline, err := ResourceBaselineFor(replaced)
+	if err != nil {
+		return nil
+	}
+	return baseline
+}
@@ -0,0 +1,102 @@
+package olm
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilclock "k8s.io/apimachinery/pkg/util/clock"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+)
+
+func podMetrics(name string, podLabels map[string]string, cpu, memory string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"containers": []interface{}{
+			map[string]interface{}{
+				"name":  "operator",
+				"usage": map[string]interface{}{"cpu": cpu, "memory": memory},
+			},
+		},
+	}}
+	obj.SetAPIVersion("metrics.k8s.io/v1beta1")
+	obj.SetKind("PodMetrics")
+	obj.SetNamespace("ns")
+	obj.SetName(name)
+	obj.SetLabels(podLabels)
+	return obj
+}
+
+func TestResourceBaselineSamplerSample(t *testing.T) {
+	client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		PodMetricsGVR: "PodMetricsList",
+	})
+	for _, obj := range []*unstructured.Unstructured{
+		podMetrics("operator-a", map[string]string{"app": "operator"}, "10m", "64Mi"),
+		podMetrics("operator-b", map[string]string{"app": "operator"}, "30m", "64Mi"),
+		podMetrics("other", map[string]string{"app": "other"}, "1", "1Gi"),
+	} {
+		_, err := client.Resource(PodMetricsGVR).Namespace("ns").Create(context.TODO(), obj, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+	clock := utilclock.NewFakeClock(time.Now())
+	sampler := newResourceBaselineSampler(client, clock, time.Minute)
+	csv := &v1alpha1.ClusterServiceVersion{ObjectMeta: metav1.ObjectMeta{Name: "csv", Namespace: "ns", UID: "uid"}}
+	selectors := []labels.Selector{labels.SelectorFromSet(labels.Set{"app": "operator"})}
+	previous := &ResourceBaseline{CPU: resource.MustParse("20m"), Memory: resource.MustParse("128Mi")}
+
+	baseline, _, next, err := sampler.sample(context.TODO(), csv, previous, selectors)
+	require.NoError(t, err)
+	require.Nil(t, baseline)
+	require.Equal(t, resourceBaselineSampleInterval, next)
+
+	clock.Step(50 * time.Second)
+	baseline, _, next, err = sampler.sample(context.TODO(), csv, nil, selectors)
+	require.NoError(t, err)
+	require.Nil(t, baseline)
+	require.Equal(t, 10*time.Second, next)
+
+	clock.Step(10 * time.Second)
+	baseline, replaced, _, err := sampler.sample(context.TODO(), csv, nil, selectors)
+	require.NoError(t, err)
+	require.NotNil(t, baseline)
+	require.Equal(t, previous, replaced)
+	require.Equal(t, 3, baseline.Samples)
+	require.Equal(t, int64(40), baseline.CPU.MilliValue())
+	require.Equal(t, int64(128*1024*1024), baseline.Memory.Value())
+
+	regressions := baseline.Regressions(previous)
+	require.Len(t, regressions, 1)
+	require.Contains(t, regressions[0], "cpu")
+
+	// Once forgotten, a new sampling starts
+	sampler.forget(csv)
+	baseline, _, _, err = sampler.sample(context.TODO(), csv, nil, selectors)
+	require.NoError(t, err)
+	require.Nil(t, baseline)
+}
+
+func TestResourceBaselineFor(t *testing.T) {
+	csv := &v1alpha1.ClusterServiceVersion{}
+	baseline, err := ResourceBaselineFor(csv)
+	require.NoError(t, err)
+	require.Nil(t, baseline)
+
+	csv.SetAnnotations(map[string]string{ResourceBaselineAnnotationKey: `{"cpu":"15m","memory":"32Mi","samples":20}`})
+	baseline, err = ResourceBaselineFor(csv)
+	require.NoError(t, err)
+	require.Equal(t, int64(15), baseline.CPU.MilliValue())
+	require.Equal(t, 20, baseline.Samples)
+
+	csv.SetAnnotations(map[string]string{ResourceBaselineAnnotationKey: `{`})
+	_, err = ResourceBaselineFor(csv)
+	require.Error(t, err)
+}
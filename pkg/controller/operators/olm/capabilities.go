@@ -0,0 +1,155 @@
+package olm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+)
+
+// RequiredCapabilitiesAnnotationKey is the CSV annotation declaring, as a JSON-encoded list of ClusterCapability, the
+// cluster capabilities an operator requires beyond its nativeAPIs. The CSV doesn't install until all of them are
+// present.
+const RequiredCapabilitiesAnnotationKey = "operatorframework.io/required-capabilities"
+
+// ClusterCapabilityKind is the kind of the requirement statuses reported for required cluster capabilities.
+const ClusterCapabilityKind = "ClusterCapability"
+
+// ClusterCapability is a cluster capability required by a CSV. It's either one of the well-known capabilities, by
+// name, or the existence of an arbitrary resource, optionally with a minimum number of objects of that resource.
+type ClusterCapability struct {
+	// Name is either the name of a well-known capability, in which case the resource fields may be left empty, or a
+	// name describing the capability in the requirement status.
+	Name string `json:"name,omitempty"`
+
+	Group    string `json:"group,omitempty"`
+	Version  string `json:"version,omitempty"`
+	Resource string `json:"resource,omitempty"`
+
+	// MinCount is the minimum number of objects of the resource, across all namespaces, for the capability to be
+	// present. Zero only requires the resource to be served.
+	MinCount int `json:"minCount,omitempty"`
+}
+
+// wellKnownCapabilities are the capabilities that can be required by name alone.
+var wellKnownCapabilities = map[string]schema.GroupVersionResource{
+	"scc":            {Group: "security.openshift.io", Version: "v1", Resource: "securitycontextconstraints"},
+	"metrics-server": PodMetricsGVR,
+}
+
+// GroupVersionResource returns the resource whose presence provides the capability.
+func (c ClusterCapability) GroupVersionResource() (schema.GroupVersionResource, error) {
+	if c.Version == "" && c.Resource == "" {
+		gvr, ok := wellKnownCapabilities[c.Name]
+		if !ok {
+			return schema.GroupVersionResource{}, fmt.Errorf("unknown capability %q", c.Name)
+		}
+		return gvr, nil
+	}
+	if c.Version == "" || c.Resource == "" {
+		return schema.GroupVersionResource{}, fmt.Errorf("capability %q must specify both a version and a resource", c.Name)
+	}
+	return schema.GroupVersionResource{Group: c.Group, Version: c.Version, Resource: c.Resource}, nil
+}
+
+// RequiredCapabilitiesFor returns the cluster capabilities the given CSV requires.
+func RequiredCapabilitiesFor(csv *v1alpha1.ClusterServiceVersion) ([]ClusterCapability, error) {
+	value, ok := csv.GetAnnotations()[RequiredCapabilitiesAnnotationKey]
+	if !ok {
+		return nil, nil
+	}
+	var capabilities []ClusterCapability
+	if err := json.Unmarshal([]byte(value), &capabilities); err != nil {
+		return nil, fmt.Errorf("invalid %s annotation: %v", RequiredCapabilitiesAnnotationKey, err)
+	}
+	return capabilities, nil
+}
+
+// capabilityStatus checks whether the cluster capabilities required by the given CSV are present.
+func (a *Operator) capabilityStatus(ctx context.Context, csv *v1alpha1.ClusterServiceVersion) (met bool, statuses []v1alpha1.RequirementStatus) {
+	capabilities, err := RequiredCapabilitiesFor(csv)
+	if err != nil {
+		statuses = append(statuses, v1alpha1.RequirementStatus{
+			Group:   "operators.coreos.com",
+			Version: "v1alpha1",
+			Kind:    "ClusterServiceVersion",
+			Name:    csv.GetName(),
+			Status:  v1alpha1.RequirementStatusReasonPresentNotSatisfied,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	met = true
+	for _, c := range capabilities {
+		gvr, err := c.GroupVersionResource()
+		name := c.Name
+		if name == "" {
+			name = gvr.GroupResource().String()
+		}
+		status := v1alpha1.RequirementStatus{
+			Group:   gvr.Group,
+			Version: gvr.Version,
+			Kind:    ClusterCapabilityKind,
+			Name:    name,
+		}
+		if err != nil {
+			status.Status = v1alpha1.RequirementStatusReasonPresentNotSatisfied
+			status.Message = err.Error()
+			met = false
+			statuses = append(statuses, status)
+			continue
+		}
+
+		if ok, err := a.isGVRServed(gvr); !ok || err != nil {
+			status.Status = v1alpha1.RequirementStatusReasonNotPresent
+			status.Message = fmt.Sprintf("Resource %s is not served", gvr.String())
+			met = false
+			statuses = append(statuses, status)
+			continue
+		}
+
+		if c.MinCount > 0 {
+			list, err := a.dynamicClient.Resource(gvr).List(ctx, metav1.ListOptions{})
+			if err != nil {
+				status.Status = v1alpha1.RequirementStatusReasonPresentNotSatisfied
+				status.Message = fmt.Sprintf("Failed to count %s: %v", gvr.String(), err)
+				met = false
+				statuses = append(statuses, status)
+				continue
+			}
+			if count := len(list.Items); count < c.MinCount {
+				status.Status = v1alpha1.RequirementStatusReasonPresentNotSatisfied
+				status.Message = fmt.Sprintf("Found %d %s, at least %d required", count, gvr.Resource, c.MinCount)
+				met = false
+				statuses = append(statuses, status)
+				continue
+			}
+		}
+
+		status.Status = v1alpha1.RequirementStatusReasonPresent
+		status.Message = "Cluster capability is present"
+		statuses = append(statuses, status)
+	}
+	return
+}
+
+// isGVRServed returns true if the given resource is served by the API server.
+func (a *Operator) isGVRServed(gvr schema.GroupVersionResource) (bool, error) {
+	resources, err := a.opClient.KubernetesInterface().Discovery().ServerResourcesForGroupVersion(gvr.GroupVersion().String())
+	if err != nil {
+		a.logger.WithField("gvr", gvr.String()).WithField("err", err).Info("could not query for GVR in api discovery")
+		return false, err
+	}
+
+	for _, r := range resources.APIResources {
+		if r.Name == gvr.Resource {
+			return true, nil
+		}
+	}
+	return false, nil
+}
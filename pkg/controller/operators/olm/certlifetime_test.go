@@ -0,0 +1,64 @@
+package olm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	operatorsv1 "github.com/operator-framework/api/pkg/operators/v1"
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/install"
+)
+
+func TestValidCertLifetime(t *testing.T) {
+	csv := &v1alpha1.ClusterServiceVersion{ObjectMeta: metav1.ObjectMeta{
+		Name:        "csv",
+		Namespace:   "ns",
+		Annotations: map[string]string{install.CertMinFreshAnnotationKey: "2h"},
+	}}
+	olmConfig := func(validFor, minFresh string) *operatorsv1.OLMConfig {
+		return &operatorsv1.OLMConfig{ObjectMeta: metav1.ObjectMeta{
+			Name: "cluster",
+			Annotations: map[string]string{
+				install.CertValidForAnnotationKey: validFor,
+				install.CertMinFreshAnnotationKey: minFresh,
+			},
+		}}
+	}
+
+	tests := []struct {
+		name      string
+		olmConfig *operatorsv1.OLMConfig
+		valid     bool
+	}{
+		{name: "NoOLMConfig", valid: true},
+		{name: "LongerClusterValidity", olmConfig: olmConfig("24h", "1h"), valid: true},
+		{name: "ShorterClusterValidity", olmConfig: olmConfig("90m", "30m"), valid: false},
+		{name: "InvalidClusterValidity", olmConfig: olmConfig("soon", "30m"), valid: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.TODO())
+			defer cancel()
+
+			var clientObjs []runtime.Object
+			if tt.olmConfig != nil {
+				clientObjs = append(clientObjs, tt.olmConfig)
+			}
+			op, err := NewFakeOperator(ctx, withClientObjs(clientObjs...))
+			require.NoError(t, err)
+
+			err = op.validCertLifetime(csv)
+			if tt.valid {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+			}
+		})
+	}
+}
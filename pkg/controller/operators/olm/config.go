@@ -14,25 +14,36 @@ import (
 	configv1client "github.com/openshift/client-go/config/clientset/versioned"
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/api/client/clientset/versioned"
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/install"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/controllers"
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/labeler"
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/operatorclient"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/sharding"
 )
 
 type OperatorOption func(*operatorConfig)
 
 type operatorConfig struct {
-	resyncPeriod      func() time.Duration
-	operatorNamespace string
-	watchedNamespaces []string
-	clock             utilclock.Clock
-	logger            *logrus.Logger
-	operatorClient    operatorclient.ClientInterface
-	externalClient    versioned.Interface
-	strategyResolver  install.StrategyResolverInterface
-	apiReconciler     APIIntersectionReconciler
-	apiLabeler        labeler.Labeler
-	restConfig        *rest.Config
-	configClient      configv1client.Interface
+	resyncPeriod       func() time.Duration
+	operatorNamespace  string
+	watchedNamespaces  []string
+	clock              utilclock.Clock
+	logger             *logrus.Logger
+	operatorClient     operatorclient.ClientInterface
+	externalClient     versioned.Interface
+	strategyResolver   install.StrategyResolverInterface
+	apiReconciler      APIIntersectionReconciler
+	apiLabeler         labeler.Labeler
+	restConfig         *rest.Config
+	configClient       configv1client.Interface
+	baselineWindow     time.Duration
+	syncTimeout        time.Duration
+	csvRequeueInterval time.Duration
+	auditMutations     bool
+	shard              *sharding.Shard
+	copiedCSVWorkers   int
+	copiedCSVQPS       float64
+	copiedCSVBurst     int
+	disabled           controllers.Disabled
 }
 
 func (o *operatorConfig) apply(options []OperatorOption) {
@@ -70,6 +81,16 @@ func (o *operatorConfig) validate() (err error) {
 		err = newInvalidConfigError("api labeler", "must not be nil")
 	case o.restConfig == nil:
 		err = newInvalidConfigError("rest config", "must not be nil")
+	case o.syncTimeout < 0:
+		err = newInvalidConfigError("sync timeout", "must not be negative")
+	case o.csvRequeueInterval < 0:
+		err = newInvalidConfigError("csv requeue interval", "must not be negative")
+	case o.copiedCSVWorkers < 1:
+		err = newInvalidConfigError("copied csv workers", "must be at least one")
+	case o.copiedCSVQPS <= 0:
+		err = newInvalidConfigError("copied csv qps", "must be positive")
+	case o.copiedCSVBurst < 1:
+		err = newInvalidConfigError("copied csv burst", "must be at least one")
 	}
 
 	return
@@ -85,6 +106,9 @@ func defaultOperatorConfig() *operatorConfig {
 		strategyResolver:  &install.StrategyResolver{},
 		apiReconciler:     APIIntersectionReconcileFunc(ReconcileAPIIntersection),
 		apiLabeler:        labeler.Func(LabelSetsFor),
+		copiedCSVWorkers:  2,
+		copiedCSVQPS:      5,
+		copiedCSVBurst:    50,
 	}
 }
 
@@ -159,3 +183,67 @@ func WithConfigClient(configClient configv1client.Interface) OperatorOption {
 		config.configClient = configClient
 	}
 }
+
+// WithResourceBaselineWindow sets how long the pods of a succeeded CSV are sampled for before recording their resource
+// consumption baseline. A zero window disables resource baselines.
+func WithResourceBaselineWindow(window time.Duration) OperatorOption {
+	return func(config *operatorConfig) {
+		config.baselineWindow = window
+	}
+}
+
+// WithSyncTimeout sets the deadline of each sync, after which its outstanding client calls are cancelled and the synced
+// object is requeued. A zero timeout disables the deadline.
+func WithSyncTimeout(timeout time.Duration) OperatorOption {
+	return func(config *operatorConfig) {
+		config.syncTimeout = timeout
+	}
+}
+
+// WithCSVRequeueInterval sets how long to wait before re-checking the requirements of a CSV whose requirements are
+// unmet. A zero interval retries with exponential backoff instead.
+func WithCSVRequeueInterval(interval time.Duration) OperatorOption {
+	return func(config *operatorConfig) {
+		config.csvRequeueInterval = interval
+	}
+}
+
+// WithAuditMutations records the resources the operator creates, updates and deletes on behalf of CSVs as Events on
+// the CSVs and as structured log entries.
+func WithAuditMutations(enabled bool) OperatorOption {
+	return func(config *operatorConfig) {
+		config.auditMutations = enabled
+	}
+}
+
+// WithShard reconciles only the CSVs, copied CSVs, OperatorGroups and Namespaces of the namespaces assigned to the
+// given shard, along with the cluster-scoped OLMConfig if it's the first shard. A nil shard reconciles all namespaces.
+func WithShard(shard *sharding.Shard) OperatorOption {
+	return func(config *operatorConfig) {
+		config.shard = shard
+	}
+}
+
+// WithCopiedCSVWorkers sets the number of workers copying CSVs to the target namespaces of their OperatorGroup, apart
+// from the workers reconciling CSVs.
+func WithCopiedCSVWorkers(workers int) OperatorOption {
+	return func(config *operatorConfig) {
+		config.copiedCSVWorkers = workers
+	}
+}
+
+// WithCopiedCSVRateLimit sets the rate, in copies per second, and the burst the retries of CSV copies are limited to.
+func WithCopiedCSVRateLimit(qps float64, burst int) OperatorOption {
+	return func(config *operatorConfig) {
+		config.copiedCSVQPS = qps
+		config.copiedCSVBurst = burst
+	}
+}
+
+// WithDisabledControllers sets the optional controllers of the olm-operator that aren't run. They are reported on the
+// cluster OLMConfig.
+func WithDisabledControllers(disabled controllers.Disabled) OperatorOption {
+	return func(config *operatorConfig) {
+		config.disabled = disabled
+	}
+}
@@ -0,0 +1,71 @@
+package olm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	crdlib "github.com/operator-framework/operator-lifecycle-manager/pkg/lib/crd"
+)
+
+func TestCleanUpRemovedConversionWebhooks(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+
+	converted := func(name, webhook string) *apiextensionsv1.CustomResourceDefinition {
+		crd := &apiextensionsv1.CustomResourceDefinition{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+				Group:    "example.com",
+				Versions: []apiextensionsv1.CustomResourceDefinitionVersion{{Name: "v1", Served: true, Storage: true}},
+				Conversion: &apiextensionsv1.CustomResourceConversion{
+					Strategy: apiextensionsv1.WebhookConverter,
+					Webhook:  &apiextensionsv1.WebhookConversion{ClientConfig: &apiextensionsv1.WebhookClientConfig{CABundle: []byte("ca")}},
+				},
+			},
+		}
+		if webhook != "" {
+			crd.SetAnnotations(map[string]string{crdlib.ConversionWebhookAnnotationKey: webhook})
+		}
+		return crd
+	}
+	csv := &v1alpha1.ClusterServiceVersion{
+		ObjectMeta: metav1.ObjectMeta{Name: "operator.v2.0.0", Namespace: "operators"},
+		Spec: v1alpha1.ClusterServiceVersionSpec{
+			CustomResourceDefinitions: v1alpha1.CustomResourceDefinitions{
+				Owned: []v1alpha1.CRDDescription{{Name: "foos.example.com"}, {Name: "bars.example.com"}, {Name: "bazs.example.com"}},
+			},
+			WebhookDefinitions: []v1alpha1.WebhookDescription{{
+				GenerateName:   "cfoos2.example.com",
+				Type:           v1alpha1.ConversionWebhook,
+				ConversionCRDs: []string{"foos.example.com"},
+			}},
+		},
+	}
+
+	op, err := NewFakeOperator(ctx, withNamespaces("operators"), withExtObjs(
+		converted("foos.example.com", "cfoos.example.com"),
+		converted("bars.example.com", "cbars.example.com"),
+		converted("bazs.example.com", ""),
+	))
+	require.NoError(t, err)
+	require.NoError(t, op.cleanUpRemovedConversionWebhooks(ctx, csv))
+
+	get := func(name string) *apiextensionsv1.CustomResourceDefinition {
+		crd, err := op.opClient.ApiextensionsInterface().ApiextensionsV1().CustomResourceDefinitions().Get(ctx, name, metav1.GetOptions{})
+		require.NoError(t, err)
+		return crd
+	}
+	// Moved to another webhook of the CSV, which reconfigures it on install
+	require.Equal(t, converted("foos.example.com", "cfoos.example.com").Spec, get("foos.example.com").Spec)
+	// No longer converted by a webhook of the CSV
+	bars := get("bars.example.com")
+	require.Equal(t, apiextensionsv1.NoneConverter, bars.Spec.Conversion.Strategy)
+	require.NotContains(t, bars.GetAnnotations(), crdlib.ConversionWebhookAnnotationKey)
+	// Not configured by OLM
+	require.Equal(t, converted("bazs.example.com", "").Spec, get("bazs.example.com").Spec)
+}
@@ -0,0 +1,118 @@
+package olm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/api/reasons"
+)
+
+const (
+	// CopyFailuresAnnotationKey is the annotation of a CSV mapping, as JSON, the target namespaces it couldn't be
+	// copied to to the reason of the failure.
+	CopyFailuresAnnotationKey = "operatorframework.io/copy-failures"
+
+	// CSVReasonCopyFailed is the reason of the events recorded on a CSV that couldn't be copied to some namespaces.
+	CSVReasonCopyFailed = v1alpha1.ConditionReason(reasons.CSVCopyFailed)
+
+	copyFailureQuotaExceeded   = "QuotaExceeded"
+	copyFailureAdmissionDenied = "AdmissionDenied"
+	copyFailureError           = "Error"
+
+	// maxCopyFailuresInEvent is the number of namespaces listed in a CopyFailed event.
+	maxCopyFailuresInEvent = 10
+)
+
+// copyFailure is why a CSV couldn't be copied to a namespace.
+type copyFailure struct {
+	Reason  string `json:"reason"`
+	Message string `json:"message"`
+}
+
+// copyFailureFor classifies an error copying a CSV: namespaces rejecting the copy because of an object count quota,
+// or denying it through admission, aren't going to accept it until they change.
+func copyFailureFor(err error) copyFailure {
+	reason := copyFailureError
+	switch {
+	case k8serrors.IsForbidden(err) && strings.Contains(err.Error(), "exceeded quota"):
+		reason = copyFailureQuotaExceeded
+	case k8serrors.IsForbidden(err), k8serrors.IsInvalid(err):
+		reason = copyFailureAdmissionDenied
+	}
+	return copyFailure{Reason: reason, Message: err.Error()}
+}
+
+// copyFailedError is returned when a CSV couldn't be copied to some namespaces, so that copying is retried with backoff.
+type copyFailedError struct {
+	namespaces int
+}
+
+func (e copyFailedError) Error() string {
+	return fmt.Sprintf("failed to copy csv to %d namespaces", e.namespaces)
+}
+
+// describeCopyFailures summarizes the given failures, listing at most maxCopyFailuresInEvent namespaces.
+func describeCopyFailures(failures map[string]copyFailure) string {
+	namespaces := make([]string, 0, len(failures))
+	for ns := range failures {
+		namespaces = append(namespaces, ns)
+	}
+	sort.Strings(namespaces)
+
+	var described []string
+	for i, ns := range namespaces {
+		if i == maxCopyFailuresInEvent {
+			described = append(described, fmt.Sprintf("and %d more", len(namespaces)-i))
+			break
+		}
+		described = append(described, fmt.Sprintf("%s (%s)", ns, failures[ns].Reason))
+	}
+	return fmt.Sprintf("failed to copy to %d namespaces: %s", len(namespaces), strings.Join(described, ", "))
+}
+
+// syncCopyFailures records the namespaces the given CSV couldn't be copied to in its CopyFailuresAnnotationKey
+// annotation, and summarizes them in a CopyFailed event when they change.
+func (a *Operator) syncCopyFailures(ctx context.Context, csv *v1alpha1.ClusterServiceVersion, failures map[string]copyFailure) error {
+	value := ""
+	if len(failures) > 0 {
+		encoded, err := json.Marshal(failures)
+		if err != nil {
+			return err
+		}
+		value = string(encoded)
+	}
+	if csv.GetAnnotations()[CopyFailuresAnnotationKey] == value {
+		return nil
+	}
+
+	out, err := a.client.OperatorsV1alpha1().ClusterServiceVersions(csv.GetNamespace()).Get(ctx, csv.GetName(), metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	annotations := out.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	if value == "" {
+		delete(annotations, CopyFailuresAnnotationKey)
+	} else {
+		annotations[CopyFailuresAnnotationKey] = value
+	}
+	out.SetAnnotations(annotations)
+	if _, err := a.client.OperatorsV1alpha1().ClusterServiceVersions(out.GetNamespace()).Update(ctx, out, metav1.UpdateOptions{}); err != nil {
+		return err
+	}
+
+	if len(failures) > 0 {
+		a.recorder.Event(csv, corev1.EventTypeWarning, string(CSVReasonCopyFailed), describeCopyFailures(failures))
+	}
+	return nil
+}
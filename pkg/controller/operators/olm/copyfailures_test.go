@@ -0,0 +1,69 @@
+package olm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+)
+
+func TestCopyFailureFor(t *testing.T) {
+	csvs := schema.GroupResource{Group: v1alpha1.GroupName, Resource: "clusterserviceversions"}
+	require.Equal(t, copyFailureQuotaExceeded, copyFailureFor(k8serrors.NewForbidden(csvs, "csv", errors.New("exceeded quota: objects, requested: count/clusterserviceversions.operators.coreos.com=1"))).Reason)
+	require.Equal(t, copyFailureAdmissionDenied, copyFailureFor(k8serrors.NewForbidden(csvs, "csv", errors.New(`admission webhook "deny.example.com" denied the request`))).Reason)
+	require.Equal(t, copyFailureAdmissionDenied, copyFailureFor(k8serrors.NewInvalid(schema.GroupKind{Group: v1alpha1.GroupName, Kind: v1alpha1.ClusterServiceVersionKind}, "csv", nil)).Reason)
+	require.Equal(t, copyFailureError, copyFailureFor(errors.New("connection refused")).Reason)
+}
+
+func TestDescribeCopyFailures(t *testing.T) {
+	failures := map[string]copyFailure{
+		"b": {Reason: copyFailureAdmissionDenied},
+		"a": {Reason: copyFailureQuotaExceeded},
+	}
+	require.Equal(t, "failed to copy to 2 namespaces: a (QuotaExceeded), b (AdmissionDenied)", describeCopyFailures(failures))
+
+	for i := 0; i < maxCopyFailuresInEvent; i++ {
+		failures[fmt.Sprintf("ns-%d", i)] = copyFailure{Reason: copyFailureError}
+	}
+	require.Contains(t, describeCopyFailures(failures), ", and 2 more")
+}
+
+func TestSyncCopyFailures(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+
+	csv := &v1alpha1.ClusterServiceVersion{ObjectMeta: metav1.ObjectMeta{Name: "csv", Namespace: "ns"}}
+	op, err := NewFakeOperator(ctx, withNamespaces("ns"), withClientObjs(csv))
+	require.NoError(t, err)
+	recorder := record.NewFakeRecorder(10)
+	op.recorder = recorder
+
+	failures := map[string]copyFailure{"quota": {Reason: copyFailureQuotaExceeded, Message: "exceeded quota"}}
+	require.NoError(t, op.syncCopyFailures(ctx, csv, failures))
+	out, err := op.client.OperatorsV1alpha1().ClusterServiceVersions("ns").Get(ctx, "csv", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"quota":{"reason":"QuotaExceeded","message":"exceeded quota"}}`, out.GetAnnotations()[CopyFailuresAnnotationKey])
+	require.Equal(t, "Warning CopyFailed failed to copy to 1 namespaces: quota (QuotaExceeded)", <-recorder.Events)
+
+	// Unchanged failures are neither recorded again nor announced
+	require.NoError(t, op.syncCopyFailures(ctx, out, failures))
+	require.Empty(t, recorder.Events)
+
+	require.NoError(t, op.syncCopyFailures(ctx, out, map[string]copyFailure{}))
+	out, err = op.client.OperatorsV1alpha1().ClusterServiceVersions("ns").Get(ctx, "csv", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.NotContains(t, out.GetAnnotations(), CopyFailuresAnnotationKey)
+
+	// Copies don't carry the failures of the original
+	var copied v1alpha1.ClusterServiceVersion
+	csvCopyPrototype(&v1alpha1.ClusterServiceVersion{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{CopyFailuresAnnotationKey: "{}"}}}, &copied)
+	require.NotContains(t, copied.GetAnnotations(), CopyFailuresAnnotationKey)
+}
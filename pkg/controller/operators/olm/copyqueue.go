@@ -0,0 +1,112 @@
+package olm
+
+import (
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+	utilclock "k8s.io/apimachinery/pkg/util/clock"
+	"k8s.io/client-go/util/workqueue"
+
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/metrics"
+)
+
+const (
+	copiedCSVBaseDelay = 100 * time.Millisecond
+	copiedCSVMaxDelay  = 10 * time.Minute
+)
+
+// copiedCSVRateLimiter returns the rate limiter of the copied CSV queues. Copies are projections of CSVs that are
+// already installed, so their retries back off slower and are allowed less throughput than the ones of CSVs, to leave
+// the API server to installs during namespace creation storms.
+func copiedCSVRateLimiter(qps float64, burst int) workqueue.RateLimiter {
+	return workqueue.NewMaxOfRateLimiter(
+		workqueue.NewItemExponentialFailureRateLimiter(copiedCSVBaseDelay, copiedCSVMaxDelay),
+		&workqueue.BucketRateLimiter{Limiter: rate.NewLimiter(rate.Limit(qps), burst)},
+	)
+}
+
+// copiedCSVQueue is the rate limited queue of the CSVs of a namespace waiting to be copied to the target namespaces
+// of their OperatorGroup. It records its depth, and how long CSVs wait in it once due, as metrics.
+type copiedCSVQueue struct {
+	workqueue.DelayingInterface
+
+	namespace   string
+	rateLimiter workqueue.RateLimiter
+	clock       utilclock.Clock
+
+	mu sync.Mutex
+	// due holds when the CSVs in the queue were due to be copied
+	due map[interface{}]time.Time
+}
+
+var _ workqueue.RateLimitingInterface = &copiedCSVQueue{}
+
+func newCopiedCSVQueue(namespace string, rateLimiter workqueue.RateLimiter, clock utilclock.Clock) *copiedCSVQueue {
+	return &copiedCSVQueue{
+		DelayingInterface: workqueue.NewNamedDelayingQueue(namespace + "/csv-copy"),
+		namespace:         namespace,
+		rateLimiter:       rateLimiter,
+		clock:             clock,
+		due:               map[interface{}]time.Time{},
+	}
+}
+
+// setDue records that the given CSV is due to be copied after the given delay, unless it's already due earlier.
+func (q *copiedCSVQueue) setDue(item interface{}, delay time.Duration) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	due := q.clock.Now().Add(delay)
+	if previous, ok := q.due[item]; !ok || due.Before(previous) {
+		q.due[item] = due
+	}
+}
+
+func (q *copiedCSVQueue) Add(item interface{}) {
+	q.setDue(item, 0)
+	q.DelayingInterface.Add(item)
+	metrics.SetCopiedCSVQueueDepth(q.namespace, q.Len())
+}
+
+func (q *copiedCSVQueue) AddAfter(item interface{}, duration time.Duration) {
+	if duration <= 0 {
+		q.Add(item)
+		return
+	}
+	q.setDue(item, duration)
+	q.DelayingInterface.AddAfter(item, duration)
+}
+
+func (q *copiedCSVQueue) AddRateLimited(item interface{}) {
+	q.AddAfter(item, q.rateLimiter.When(item))
+}
+
+func (q *copiedCSVQueue) Forget(item interface{}) {
+	q.rateLimiter.Forget(item)
+}
+
+func (q *copiedCSVQueue) NumRequeues(item interface{}) int {
+	return q.rateLimiter.NumRequeues(item)
+}
+
+func (q *copiedCSVQueue) Get() (interface{}, bool) {
+	item, shutdown := q.DelayingInterface.Get()
+	metrics.SetCopiedCSVQueueDepth(q.namespace, q.Len())
+	if shutdown {
+		return item, shutdown
+	}
+
+	q.mu.Lock()
+	due, ok := q.due[item]
+	delete(q.due, item)
+	q.mu.Unlock()
+	if ok {
+		latency := q.clock.Since(due)
+		if latency < 0 {
+			latency = 0
+		}
+		metrics.EmitCopiedCSVQueueLatency(latency)
+	}
+	return item, shutdown
+}
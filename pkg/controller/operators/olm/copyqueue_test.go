@@ -0,0 +1,37 @@
+package olm
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	utilclock "k8s.io/apimachinery/pkg/util/clock"
+)
+
+func TestCopiedCSVQueue(t *testing.T) {
+	now := time.Date(2021, time.March, 1, 12, 0, 0, 0, time.UTC)
+	clock := utilclock.NewFakeClock(now)
+	q := newCopiedCSVQueue("ns", copiedCSVRateLimiter(5, 50), clock)
+	defer q.ShutDown()
+
+	// A CSV is due as soon as it's first added, even if it's added again later on
+	q.Add("ns/a")
+	clock.Step(time.Second)
+	q.AddAfter("ns/a", time.Minute)
+	require.Equal(t, now, q.due["ns/a"])
+	require.Equal(t, 1, q.Len())
+
+	item, shutdown := q.Get()
+	require.False(t, shutdown)
+	require.Equal(t, "ns/a", item)
+	require.Empty(t, q.due)
+	q.Done(item)
+
+	// Retries back off, until they're forgotten
+	q.AddRateLimited("ns/b")
+	q.AddRateLimited("ns/b")
+	require.Equal(t, 2, q.NumRequeues("ns/b"))
+	require.Equal(t, clock.Now().Add(copiedCSVBaseDelay), q.due["ns/b"])
+	q.Forget("ns/b")
+	require.Equal(t, 0, q.NumRequeues("ns/b"))
+}
@@ -0,0 +1,150 @@
+package olm
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/api/reasons"
+)
+
+const (
+	// UpgradeDisruptionTimeoutAnnotationKey is the CSV annotation setting how long, as a duration, a CSV replacing
+	// another waits for the operator it replaces to be safely disrupted before installing anyway. Defaults to
+	// defaultUpgradeDisruptionTimeout, and "0" disables the wait.
+	UpgradeDisruptionTimeoutAnnotationKey = "operatorframework.io/upgrade-disruption-timeout"
+
+	// CSVReasonUpgradeDisruptionPending is the reason of the condition recorded on a CSV waiting for the operator it
+	// replaces to be safely disrupted.
+	CSVReasonUpgradeDisruptionPending = v1alpha1.ConditionReason(reasons.CSVUpgradeDisruptionPending)
+
+	defaultUpgradeDisruptionTimeout  = 10 * time.Minute
+	upgradeDisruptionRequeueInterval = 15 * time.Second
+)
+
+// upgradeDisruptionTimeout returns how long the given CSV waits for the operator it replaces to be safely disrupted.
+// Invalid timeouts are ignored.
+func upgradeDisruptionTimeout(csv *v1alpha1.ClusterServiceVersion) time.Duration {
+	value, ok := csv.GetAnnotations()[UpgradeDisruptionTimeoutAnnotationKey]
+	if !ok {
+		return defaultUpgradeDisruptionTimeout
+	}
+	timeout, err := time.ParseDuration(value)
+	if err != nil || timeout < 0 {
+		return defaultUpgradeDisruptionTimeout
+	}
+	return timeout
+}
+
+// upgradeDisruptionWaitSince returns when the given CSV started waiting for the operator it replaces to be safely
+// disrupted, or nil if it isn't waiting.
+func upgradeDisruptionWaitSince(csv *v1alpha1.ClusterServiceVersion) *metav1.Time {
+	conditions := csv.Status.Conditions
+	if csv.Status.Reason != CSVReasonUpgradeDisruptionPending || len(conditions) == 0 {
+		return nil
+	}
+	if last := conditions[len(conditions)-1]; last.Reason == CSVReasonUpgradeDisruptionPending {
+		return last.LastUpdateTime
+	}
+	return nil
+}
+
+// upgradeDisruptionBlocker returns why the operator of the given replaced CSV can't be safely disrupted yet, or an
+// empty string if it can: while a PodDisruptionBudget covering the pods of its deployments allows no disruption, or
+// while one of its pods has just acquired a leader lease and is likely in the middle of its first reconcile.
+func (a *Operator) upgradeDisruptionBlocker(ctx context.Context, replaced *v1alpha1.ClusterServiceVersion) (string, error) {
+	strategy, err := a.resolver.UnmarshalStrategy(replaced.Spec.InstallStrategy)
+	if err != nil {
+		return "", nil
+	}
+	strategyDetailsDeployment, ok := strategy.(*v1alpha1.StrategyDetailsDeployment)
+	if !ok {
+		return "", nil
+	}
+
+	kubeClient := a.opClient.KubernetesInterface()
+	namespace := replaced.GetNamespace()
+	pods := map[string][]corev1.Pod{}
+	for _, spec := range strategyDetailsDeployment.DeploymentSpecs {
+		selector, err := metav1.LabelSelectorAsSelector(spec.Spec.Selector)
+		if err != nil || selector.Empty() {
+			continue
+		}
+		list, err := kubeClient.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+		if err != nil {
+			return "", err
+		}
+		pods[spec.Name] = list.Items
+	}
+
+	pdbs, err := kubeClient.PolicyV1().PodDisruptionBudgets(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return "", err
+	}
+	for _, pdb := range pdbs.Items {
+		if pdb.Status.DisruptionsAllowed > 0 || pdb.Spec.Selector == nil {
+			continue
+		}
+		selector, err := metav1.LabelSelectorAsSelector(pdb.Spec.Selector)
+		if err != nil {
+			continue
+		}
+		for _, spec := range strategyDetailsDeployment.DeploymentSpecs {
+			for _, pod := range pods[spec.Name] {
+				if selector.Matches(labels.Set(pod.GetLabels())) {
+					return fmt.Sprintf("PodDisruptionBudget %s allows no disruption of the pods of deployment %s", pdb.GetName(), spec.Name), nil
+				}
+			}
+		}
+	}
+
+	leases, err := kubeClient.CoordinationV1().Leases(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return "", err
+	}
+	now := a.now().Time
+	for _, lease := range leases.Items {
+		spec := lease.Spec
+		if spec.HolderIdentity == nil || spec.AcquireTime == nil || spec.LeaseDurationSeconds == nil {
+			continue
+		}
+		if now.Sub(spec.AcquireTime.Time) >= time.Duration(*spec.LeaseDurationSeconds)*time.Second {
+			continue
+		}
+		for _, deploymentPods := range pods {
+			for _, pod := range deploymentPods {
+				// leader election identities are the pod name, possibly followed by an underscore and a unique suffix
+				if holder := *spec.HolderIdentity; holder == pod.GetName() || strings.HasPrefix(holder, pod.GetName()+"_") {
+					return fmt.Sprintf("pod %s has just acquired leader lease %s", pod.GetName(), lease.GetName()), nil
+				}
+			}
+		}
+	}
+	return "", nil
+}
+
+// waitForUpgradeDisruption holds the given CSV pending while the operator of the CSV it replaces can't be safely
+// disrupted, up to its upgrade disruption timeout. It returns true if the CSV is held.
+func (a *Operator) waitForUpgradeDisruption(ctx context.Context, csv, replaced *v1alpha1.ClusterServiceVersion, now *metav1.Time) (bool, error) {
+	timeout := upgradeDisruptionTimeout(csv)
+	if timeout == 0 {
+		return false, nil
+	}
+	blocker, err := a.upgradeDisruptionBlocker(ctx, replaced)
+	if err != nil || blocker == "" {
+		return false, err
+	}
+
+	if since := upgradeDisruptionWaitSince(csv); since != nil && now.Sub(since.Time) >= timeout {
+		a.recorder.Eventf(csv, corev1.EventTypeWarning, string(CSVReasonUpgradeDisruptionPending), "replacing %s after waiting %s: %s", replaced.GetName(), timeout, blocker)
+		return false, nil
+	}
+	csv.SetPhaseWithEventIfChanged(v1alpha1.CSVPhasePending, CSVReasonUpgradeDisruptionPending, fmt.Sprintf("waiting to replace %s: %s", replaced.GetName(), blocker), now, a.recorder)
+	return true, a.csvQueueSet.RequeueAfter(csv.GetNamespace(), csv.GetName(), upgradeDisruptionRequeueInterval)
+}
@@ -0,0 +1,121 @@
+package olm
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilclock "k8s.io/apimachinery/pkg/util/clock"
+	"k8s.io/utils/pointer"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+)
+
+func TestUpgradeDisruptionTimeout(t *testing.T) {
+	csv := func(timeout string) *v1alpha1.ClusterServiceVersion {
+		return &v1alpha1.ClusterServiceVersion{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{UpgradeDisruptionTimeoutAnnotationKey: timeout}}}
+	}
+	require.Equal(t, defaultUpgradeDisruptionTimeout, upgradeDisruptionTimeout(&v1alpha1.ClusterServiceVersion{}))
+	require.Equal(t, 2*time.Minute, upgradeDisruptionTimeout(csv("2m")))
+	require.Equal(t, time.Duration(0), upgradeDisruptionTimeout(csv("0")))
+	require.Equal(t, defaultUpgradeDisruptionTimeout, upgradeDisruptionTimeout(csv("-1m")))
+	require.Equal(t, defaultUpgradeDisruptionTimeout, upgradeDisruptionTimeout(csv("soon")))
+}
+
+func TestWaitForUpgradeDisruption(t *testing.T) {
+	now := time.Date(2021, time.June, 1, 12, 0, 0, 0, time.UTC)
+	replaced := csv("operator.v1", "ns", "0.0.0", "", installStrategy("operator", nil, nil), nil, nil, v1alpha1.CSVPhaseReplacing)
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "operator-5d8f7c9b4-x2k9p", Namespace: "ns", Labels: map[string]string{"app": "operator"}}}
+	pdb := func(disruptionsAllowed int32) *policyv1.PodDisruptionBudget {
+		return &policyv1.PodDisruptionBudget{
+			ObjectMeta: metav1.ObjectMeta{Name: "operator", Namespace: "ns"},
+			Spec:       policyv1.PodDisruptionBudgetSpec{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "operator"}}},
+			Status:     policyv1.PodDisruptionBudgetStatus{DisruptionsAllowed: disruptionsAllowed},
+		}
+	}
+	lease := func(acquired time.Time) *coordinationv1.Lease {
+		acquireTime := metav1.NewMicroTime(acquired)
+		return &coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{Name: "operator-lock", Namespace: "ns"},
+			Spec: coordinationv1.LeaseSpec{
+				HolderIdentity:       pointer.StringPtr(pod.GetName() + "_5e7a1c2d"),
+				AcquireTime:          &acquireTime,
+				LeaseDurationSeconds: pointer.Int32Ptr(15),
+			},
+		}
+	}
+	waiting := func(since time.Time) *v1alpha1.ClusterServiceVersion {
+		out := csv("operator.v2", "ns", "0.0.0", "operator.v1", installStrategy("operator", nil, nil), nil, nil, v1alpha1.CSVPhasePending)
+		t := metav1.NewTime(since)
+		out.Status.Reason = CSVReasonUpgradeDisruptionPending
+		out.Status.Conditions = []v1alpha1.ClusterServiceVersionCondition{{Phase: v1alpha1.CSVPhasePending, Reason: CSVReasonUpgradeDisruptionPending, LastUpdateTime: &t}}
+		return out
+	}
+
+	tests := []struct {
+		name    string
+		csv     *v1alpha1.ClusterServiceVersion
+		objs    []runtime.Object
+		held    bool
+		message string
+	}{
+		{
+			name: "NoBudget",
+			csv:  csv("operator.v2", "ns", "0.0.0", "operator.v1", installStrategy("operator", nil, nil), nil, nil, v1alpha1.CSVPhasePending),
+			objs: []runtime.Object{pod},
+		},
+		{
+			name: "DisruptionAllowed",
+			csv:  csv("operator.v2", "ns", "0.0.0", "operator.v1", installStrategy("operator", nil, nil), nil, nil, v1alpha1.CSVPhasePending),
+			objs: []runtime.Object{pod, pdb(1), lease(now.Add(-time.Minute))},
+		},
+		{
+			name:    "DisruptionNotAllowed",
+			csv:     csv("operator.v2", "ns", "0.0.0", "operator.v1", installStrategy("operator", nil, nil), nil, nil, v1alpha1.CSVPhasePending),
+			objs:    []runtime.Object{pod, pdb(0)},
+			held:    true,
+			message: "waiting to replace operator.v1: PodDisruptionBudget operator allows no disruption of the pods of deployment operator",
+		},
+		{
+			name:    "LeaseJustAcquired",
+			csv:     csv("operator.v2", "ns", "0.0.0", "operator.v1", installStrategy("operator", nil, nil), nil, nil, v1alpha1.CSVPhasePending),
+			objs:    []runtime.Object{pod, lease(now.Add(-5 * time.Second))},
+			held:    true,
+			message: "waiting to replace operator.v1: pod operator-5d8f7c9b4-x2k9p has just acquired leader lease operator-lock",
+		},
+		{
+			name: "TimedOut",
+			csv:  waiting(now.Add(-defaultUpgradeDisruptionTimeout)),
+			objs: []runtime.Object{pod, pdb(0)},
+		},
+		{
+			name: "Disabled",
+			csv:  withAnnotations(csv("operator.v2", "ns", "0.0.0", "operator.v1", installStrategy("operator", nil, nil), nil, nil, v1alpha1.CSVPhasePending), map[string]string{UpgradeDisruptionTimeoutAnnotationKey: "0"}).(*v1alpha1.ClusterServiceVersion),
+			objs: []runtime.Object{pod, pdb(0)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.TODO())
+			defer cancel()
+
+			op, err := NewFakeOperator(ctx, withNamespaces("ns"), withClock(utilclock.NewFakeClock(now)), withClientObjs(replaced, tt.csv), withK8sObjs(tt.objs...))
+			require.NoError(t, err)
+
+			out := tt.csv.DeepCopy()
+			held, err := op.waitForUpgradeDisruption(ctx, out, replaced, op.now())
+			require.NoError(t, err)
+			require.Equal(t, tt.held, held)
+			if tt.held {
+				require.Equal(t, CSVReasonUpgradeDisruptionPending, out.Status.Reason)
+				require.Equal(t, tt.message, out.Status.Message)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package olm
+
+import (
+	"context"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/registry/resolver"
+	index "github.com/operator-framework/operator-lifecycle-manager/pkg/lib/index"
+)
+
+// rbacOwnedBy returns the Roles or RoleBindings of the given per-namespace indexers owned by the given CSV, in any
+// namespace since they are copied to the target namespaces of its OperatorGroup, keyed by namespace and name.
+func rbacOwnedBy(indexers map[string]cache.Indexer, csv *v1alpha1.ClusterServiceVersion) (map[string]map[string]struct{}, error) {
+	owned := map[string]map[string]struct{}{}
+	for _, indexer := range indexers {
+		objs, err := indexer.ByIndex(index.CSVOwnerIndexFuncKey, index.CSVOwnerIndexKey(csv.GetNamespace(), csv.GetName()))
+		if err != nil {
+			return nil, err
+		}
+		for _, obj := range objs {
+			m, ok := obj.(metav1.Object)
+			if !ok {
+				continue
+			}
+			if owned[m.GetNamespace()] == nil {
+				owned[m.GetNamespace()] = map[string]struct{}{}
+			}
+			owned[m.GetNamespace()][m.GetName()] = struct{}{}
+		}
+	}
+	return owned, nil
+}
+
+// legacyName pairs the current name of a generated object with a legacy name of the same object.
+type legacyName struct {
+	current, legacy string
+}
+
+// deleteLegacyRBAC deletes the RBAC generated for the given CSV under the legacy names older versions of OLM gave it,
+// e.g. after the CSV was installed again. Objects under a legacy name are only deleted once the same permissions are
+// granted under the current name, so that the operator never loses them: Roles and RoleBindings in each namespace
+// they were copied to, and ClusterRoles and ClusterRoleBindings, including the lifts of Roles to the cluster scope.
+func (a *Operator) deleteLegacyRBAC(ctx context.Context, csv *v1alpha1.ClusterServiceVersion) error {
+	namespaced, cluster, err := resolver.LegacyRBACNames(csv)
+	if err != nil || len(namespaced)+len(cluster) == 0 {
+		return err
+	}
+
+	var clusterNames []legacyName
+	for current, legacy := range cluster {
+		clusterNames = append(clusterNames, legacyName{current: current, legacy: legacy})
+	}
+	if len(namespaced) > 0 {
+		roles, err := rbacOwnedBy(a.roleIndexers, csv)
+		if err != nil {
+			return err
+		}
+		roleBindings, err := rbacOwnedBy(a.roleBindingIndexers, csv)
+		if err != nil {
+			return err
+		}
+
+		for current, legacy := range namespaced {
+			for namespace, names := range roleBindings {
+				_, granted := names[current]
+				if _, ok := roles[namespace][current]; !granted || !ok {
+					continue
+				}
+				if _, ok := names[legacy]; ok {
+					if err := a.opClient.DeleteRoleBinding(ctx, namespace, legacy, &metav1.DeleteOptions{}); err != nil && !k8serrors.IsNotFound(err) {
+						return err
+					}
+					a.logger.WithField("rolebinding", namespace+"/"+legacy).Info("deleted role binding with legacy name")
+				}
+				if _, ok := roles[namespace][legacy]; ok {
+					if err := a.opClient.DeleteRole(ctx, namespace, legacy, &metav1.DeleteOptions{}); err != nil && !k8serrors.IsNotFound(err) {
+						return err
+					}
+					a.logger.WithField("role", namespace+"/"+legacy).Info("deleted role with legacy name")
+				}
+			}
+
+			// The Role may have been lifted to the cluster scope
+			lifted := singletonRBACName(csv.GetNamespace(), current)
+			for _, name := range append(legacySingletonRBACNames(csv.GetNamespace(), legacy), singletonRBACName(csv.GetNamespace(), legacy)) {
+				clusterNames = append(clusterNames, legacyName{current: lifted, legacy: name})
+			}
+		}
+	}
+
+	clusterRoles, err := clusterRBACOwnedBy(a.clusterRoleIndexer, csv)
+	if err != nil {
+		return err
+	}
+	clusterRoleBindings, err := clusterRBACOwnedBy(a.clusterRoleBindingIndexer, csv)
+	if err != nil {
+		return err
+	}
+	for _, n := range clusterNames {
+		_, granted := clusterRoleBindings[n.current]
+		if _, ok := clusterRoles[n.current]; !granted || !ok {
+			continue
+		}
+		if _, ok := clusterRoleBindings[n.legacy]; ok {
+			if err := a.opClient.DeleteClusterRoleBinding(ctx, n.legacy, &metav1.DeleteOptions{}); err != nil && !k8serrors.IsNotFound(err) {
+				return err
+			}
+			a.logger.WithField("clusterrolebinding", n.legacy).Info("deleted cluster role binding with legacy name")
+		}
+		if _, ok := clusterRoles[n.legacy]; ok {
+			if err := a.opClient.DeleteClusterRole(ctx, n.legacy, &metav1.DeleteOptions{}); err != nil && !k8serrors.IsNotFound(err) {
+				return err
+			}
+			a.logger.WithField("clusterrole", n.legacy).Info("deleted cluster role with legacy name")
+		}
+	}
+	return nil
+}
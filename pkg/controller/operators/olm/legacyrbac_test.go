@@ -0,0 +1,127 @@
+package olm
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	rbacv1 "k8s.io/api/rbac/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/registry/resolver"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/ownerutil"
+)
+
+func TestDeleteLegacyRBAC(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+
+	permissions := []v1alpha1.StrategyDeploymentPermissions{{
+		ServiceAccountName: "operator",
+		Rules:              []rbacv1.PolicyRule{{Verbs: []string{"get"}, APIGroups: []string{""}, Resources: []string{"pods"}}},
+	}}
+	csv := &v1alpha1.ClusterServiceVersion{
+		ObjectMeta: metav1.ObjectMeta{Name: strings.Repeat("operator", 8) + ".v1.0.0", Namespace: "operators"},
+		Spec: v1alpha1.ClusterServiceVersionSpec{
+			InstallStrategy: v1alpha1.NamedInstallStrategy{
+				StrategyName: v1alpha1.InstallStrategyNameDeployment,
+				StrategySpec: v1alpha1.StrategyDetailsDeployment{Permissions: permissions, ClusterPermissions: permissions},
+			},
+		},
+	}
+	ownerLabels := ownerutil.OwnerLabel(csv, v1alpha1.ClusterServiceVersionKind)
+
+	namespaced, cluster, err := resolver.LegacyRBACNames(csv)
+	require.NoError(t, err)
+	require.Len(t, namespaced, 1)
+	require.Len(t, cluster, 1)
+	only := func(names map[string]string) (string, string) {
+		for current, legacy := range names {
+			return current, legacy
+		}
+		return "", ""
+	}
+	role, legacyRole := only(namespaced)
+	clusterRole, legacyClusterRole := only(cluster)
+
+	rbac := func(namespace, name string) []runtime.Object {
+		return []runtime.Object{
+			&rbacv1.Role{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: ownerLabels}},
+			&rbacv1.RoleBinding{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: ownerLabels}},
+		}
+	}
+	clusterRBAC := func(name string) []runtime.Object {
+		return []runtime.Object{
+			&rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: ownerLabels}},
+			&rbacv1.ClusterRoleBinding{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: ownerLabels}},
+		}
+	}
+	var objs []runtime.Object
+	// Granted under both names in the operator namespace, but only under the legacy one in the tenant namespace
+	objs = append(objs, rbac("operators", role)...)
+	objs = append(objs, rbac("operators", legacyRole)...)
+	objs = append(objs, rbac("tenant", legacyRole)...)
+	// Granted under both names at the cluster scope
+	objs = append(objs, clusterRBAC(clusterRole)...)
+	objs = append(objs, clusterRBAC(legacyClusterRole)...)
+	// The legacy Role is lifted to the cluster scope, but the current one isn't yet
+	objs = append(objs, clusterRBAC(legacyRole)...)
+
+	op, err := NewFakeOperator(ctx, withNamespaces("operators", "tenant"), withK8sObjs(objs...))
+	require.NoError(t, err)
+	require.NoError(t, op.deleteLegacyRBAC(context.TODO(), csv))
+
+	client := op.opClient.KubernetesInterface().RbacV1()
+	exists := func(err error) bool {
+		if k8serrors.IsNotFound(err) {
+			return false
+		}
+		require.NoError(t, err)
+		return true
+	}
+	roleExists := func(namespace, name string) bool {
+		_, err := client.Roles(namespace).Get(ctx, name, metav1.GetOptions{})
+		_, bindingErr := client.RoleBindings(namespace).Get(ctx, name, metav1.GetOptions{})
+		require.Equal(t, exists(err), exists(bindingErr))
+		return exists(err)
+	}
+	clusterRoleExists := func(name string) bool {
+		_, err := client.ClusterRoles().Get(ctx, name, metav1.GetOptions{})
+		_, bindingErr := client.ClusterRoleBindings().Get(ctx, name, metav1.GetOptions{})
+		require.Equal(t, exists(err), exists(bindingErr))
+		return exists(err)
+	}
+
+	require.True(t, roleExists("operators", role))
+	require.False(t, roleExists("operators", legacyRole))
+	require.True(t, roleExists("tenant", legacyRole))
+	require.True(t, clusterRoleExists(clusterRole))
+	require.False(t, clusterRoleExists(legacyClusterRole))
+	require.True(t, clusterRoleExists(legacyRole))
+
+	// Once the current Role is lifted too, the lift of the legacy one goes
+	lifted := singletonRBACName("operators", role)
+	for _, obj := range clusterRBAC(lifted) {
+		switch o := obj.(type) {
+		case *rbacv1.ClusterRole:
+			_, err = client.ClusterRoles().Create(ctx, o, metav1.CreateOptions{})
+		case *rbacv1.ClusterRoleBinding:
+			_, err = client.ClusterRoleBindings().Create(ctx, o, metav1.CreateOptions{})
+		}
+		require.NoError(t, err)
+	}
+	require.Eventually(t, func() bool {
+		owned, err := clusterRBACOwnedBy(op.clusterRoleBindingIndexer, csv)
+		require.NoError(t, err)
+		_, ok := owned[lifted]
+		return ok
+	}, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, op.deleteLegacyRBAC(context.TODO(), csv))
+	require.False(t, clusterRoleExists(legacyRole))
+	require.True(t, clusterRoleExists(lifted))
+}
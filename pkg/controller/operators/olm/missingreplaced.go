@@ -0,0 +1,57 @@
+package olm
+
+import (
+	"context"
+	"errors"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/api/reasons"
+)
+
+const (
+	// MissingReplacedCSVPolicyAnnotationKey is the annotation of the cluster OLMConfig setting what OLM does with a CSV
+	// whose spec.replaces names a CSV that doesn't exist in its namespace: with MissingReplacedCSVPolicyInstall, the
+	// default, the CSV is installed as if it didn't replace anything, and with MissingReplacedCSVPolicyBlock, the CSV
+	// is held Pending until the replaced CSV exists.
+	MissingReplacedCSVPolicyAnnotationKey = "operatorframework.io/missing-replaced-csv-policy"
+
+	MissingReplacedCSVPolicyInstall = "Install"
+	MissingReplacedCSVPolicyBlock   = "Block"
+
+	// CSVReasonReplacedCSVNotFound is the reason of the condition recorded on a CSV whose replaced CSV doesn't exist.
+	CSVReasonReplacedCSVNotFound = v1alpha1.ConditionReason(reasons.CSVReplacedCSVNotFound)
+)
+
+// ErrReplacedCSVNotFound is returned when the install of a CSV is blocked until the CSV it replaces exists.
+var ErrReplacedCSVNotFound = errors.New("replaced csv not found")
+
+// replacedCSVMissing returns true if the given CSV replaces a CSV that doesn't exist in its namespace. Errors other
+// than the replaced CSV not being found are returned, so that they aren't mistaken for a missing CSV.
+func (a *Operator) replacedCSVMissing(ctx context.Context, csv *v1alpha1.ClusterServiceVersion) (bool, error) {
+	if csv.Spec.Replaces == "" {
+		return false, nil
+	}
+	// using the client instead of a lister, like the replace finder, so that cache lag isn't mistaken for a missing CSV
+	_, err := a.client.OperatorsV1alpha1().ClusterServiceVersions(csv.GetNamespace()).Get(ctx, csv.Spec.Replaces, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		return true, nil
+	}
+	return false, err
+}
+
+// missingReplacedCSVPolicy returns the policy set on the cluster OLMConfig for CSVs replacing a missing CSV. Invalid
+// policies are ignored.
+func (a *Operator) missingReplacedCSVPolicy() string {
+	switch policy := a.olmConfigAnnotations()[MissingReplacedCSVPolicyAnnotationKey]; policy {
+	case "", MissingReplacedCSVPolicyInstall:
+		return MissingReplacedCSVPolicyInstall
+	case MissingReplacedCSVPolicyBlock:
+		return MissingReplacedCSVPolicyBlock
+	default:
+		a.logger.WithField("policy", policy).Warn("ignoring invalid missing replaced csv policy set on olmConfig")
+		return MissingReplacedCSVPolicyInstall
+	}
+}
@@ -0,0 +1,168 @@
+package olm
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+
+	v1 "github.com/operator-framework/api/pkg/operators/v1"
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/api/reasons"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/registry/resolver/projection"
+)
+
+// CSVReasonMultiInstanceConflict is the reason of the condition recorded on a CSV held back because it can't coexist
+// with an instance of the same operator, or of an operator sharing its CRDs, installed in another namespace.
+const CSVReasonMultiInstanceConflict = v1alpha1.ConditionReason(reasons.CSVMultiInstanceConflict)
+
+// packagePropertyType is the type of the property naming the package of a bundle.
+const packagePropertyType = "olm.package"
+
+// ErrMultiInstanceConflict is returned when the install of a CSV is held back by a conflict with another instance.
+var ErrMultiInstanceConflict = errors.New("multi-instance conflict")
+
+// packageOf returns the package of the given CSV, from the properties the resolver recorded on it, or an empty string
+// if it isn't known.
+func packageOf(csv *v1alpha1.ClusterServiceVersion) string {
+	annotation, ok := csv.GetAnnotations()[projection.PropertiesAnnotationKey]
+	if !ok {
+		return ""
+	}
+	properties, err := projection.PropertyListFromPropertiesAnnotation(annotation)
+	if err != nil {
+		return ""
+	}
+	for _, p := range properties {
+		if p.Type != packagePropertyType {
+			continue
+		}
+		var value struct {
+			PackageName string `json:"packageName"`
+		}
+		if err := json.Unmarshal([]byte(p.Value), &value); err == nil {
+			return value.PackageName
+		}
+	}
+	return ""
+}
+
+// ownedCRDVersions returns the versions of each CRD the given CSV owns.
+func ownedCRDVersions(csv *v1alpha1.ClusterServiceVersion) map[string][]string {
+	owned := map[string][]string{}
+	for _, desc := range csv.Spec.CustomResourceDefinitions.Owned {
+		owned[desc.Name] = append(owned[desc.Name], desc.Version)
+	}
+	return owned
+}
+
+// webhookTypes returns the CRDs the given CSV defines conversion webhooks for, and whether it defines admission
+// webhooks.
+func webhookTypes(csv *v1alpha1.ClusterServiceVersion) (conversionCRDs map[string]bool, admission bool) {
+	conversionCRDs = map[string]bool{}
+	for _, desc := range csv.Spec.WebhookDefinitions {
+		switch desc.Type {
+		case v1alpha1.ConversionWebhook:
+			for _, crd := range desc.ConversionCRDs {
+				conversionCRDs[crd] = true
+			}
+		case v1alpha1.ValidatingAdmissionWebhook, v1alpha1.MutatingAdmissionWebhook:
+			admission = true
+		}
+	}
+	return conversionCRDs, admission
+}
+
+// multiInstanceConflicts returns the reasons the given CSV can't coexist with the given CSVs of other namespaces that
+// are instances of the same package or own some of the same CRDs:
+//   - a shared CRD must serve every version the other instance owns, as the last install of the CRD wins;
+//   - a shared CRD can only have one conversion webhook;
+//   - the admission webhooks of two instances of the same package must not apply to the same namespaces.
+//
+// servedVersions returns the versions served by the given CRD on the cluster, and false if it doesn't exist.
+func multiInstanceConflicts(csv *v1alpha1.ClusterServiceVersion, others []*v1alpha1.ClusterServiceVersion, servedVersions func(crd string) (map[string]bool, bool)) []string {
+	pkg := packageOf(csv)
+	owned := ownedCRDVersions(csv)
+	conversion, admission := webhookTypes(csv)
+	targets := NewNamespaceSetFromString(csv.GetAnnotations()[v1.OperatorGroupTargetsAnnotationKey])
+
+	var conflicts []string
+	for _, other := range others {
+		if other.GetNamespace() == csv.GetNamespace() || other.IsCopied() {
+			continue
+		}
+		switch other.Status.Phase {
+		case v1alpha1.CSVPhaseReplacing, v1alpha1.CSVPhaseDeleting:
+			continue
+		}
+		key := other.GetNamespace() + "/" + other.GetName()
+		samePackage := pkg != "" && packageOf(other) == pkg
+		otherConversion, otherAdmission := webhookTypes(other)
+
+		var shared []string
+		for crd := range ownedCRDVersions(other) {
+			if _, ok := owned[crd]; ok {
+				shared = append(shared, crd)
+			}
+		}
+		sort.Strings(shared)
+		if len(shared) == 0 && !samePackage {
+			continue
+		}
+
+		for _, crd := range shared {
+			if served, ok := servedVersions(crd); ok {
+				var missing []string
+				for _, version := range ownedCRDVersions(other)[crd] {
+					if !served[version] {
+						missing = append(missing, version)
+					}
+				}
+				if len(missing) > 0 {
+					conflicts = append(conflicts, fmt.Sprintf("crd %s no longer serves version %s owned by %s", crd, strings.Join(missing, ", "), key))
+				}
+			}
+			if conversion[crd] && otherConversion[crd] {
+				conflicts = append(conflicts, fmt.Sprintf("crd %s has a conversion webhook defined by %s", crd, key))
+			}
+		}
+
+		if samePackage && admission && otherAdmission {
+			otherTargets := NewNamespaceSetFromString(other.GetAnnotations()[v1.OperatorGroupTargetsAnnotationKey])
+			if len(targets.Intersection(otherTargets)) > 0 {
+				conflicts = append(conflicts, fmt.Sprintf("admission webhooks also defined by %s apply to the same namespaces", key))
+			}
+		}
+	}
+	return conflicts
+}
+
+// checkMultiInstanceConflicts returns the conflicts of the given CSV with the instances installed in other namespaces.
+func (a *Operator) checkMultiInstanceConflicts(csv *v1alpha1.ClusterServiceVersion) ([]string, error) {
+	others, err := a.lister.OperatorsV1alpha1().ClusterServiceVersionLister().List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	var lookupErr error
+	conflicts := multiInstanceConflicts(csv, others, func(name string) (map[string]bool, bool) {
+		crd, err := a.lister.APIExtensionsV1().CustomResourceDefinitionLister().Get(name)
+		if err != nil {
+			if !k8serrors.IsNotFound(err) {
+				lookupErr = err
+			}
+			return nil, false
+		}
+		served := map[string]bool{}
+		for _, version := range crd.Spec.Versions {
+			if version.Served {
+				served[version.Name] = true
+			}
+		}
+		return served, true
+	})
+	return conflicts, lookupErr
+}
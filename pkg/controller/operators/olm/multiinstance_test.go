@@ -0,0 +1,97 @@
+package olm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/operator-framework/api/pkg/operators/v1"
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/registry/resolver/projection"
+)
+
+func TestMultiInstanceConflicts(t *testing.T) {
+	instance := func(namespace, pkg, crdVersion string, webhooks ...v1alpha1.WebhookDescription) *v1alpha1.ClusterServiceVersion {
+		csv := &v1alpha1.ClusterServiceVersion{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      pkg + ".v1",
+				Annotations: map[string]string{
+					v1.OperatorGroupTargetsAnnotationKey: namespace,
+					projection.PropertiesAnnotationKey:   `{"properties":[{"type":"olm.package","value":{"packageName":"` + pkg + `","version":"1.0.0"}}]}`,
+				},
+			},
+			Spec: v1alpha1.ClusterServiceVersionSpec{WebhookDefinitions: webhooks},
+		}
+		if crdVersion != "" {
+			csv.Spec.CustomResourceDefinitions.Owned = []v1alpha1.CRDDescription{{Name: "etcdclusters.etcd.database.coreos.com", Version: crdVersion}}
+		}
+		return csv
+	}
+	conversion := v1alpha1.WebhookDescription{Type: v1alpha1.ConversionWebhook, ConversionCRDs: []string{"etcdclusters.etcd.database.coreos.com"}}
+	validating := v1alpha1.WebhookDescription{Type: v1alpha1.ValidatingAdmissionWebhook}
+	served := func(versions ...string) func(string) (map[string]bool, bool) {
+		return func(string) (map[string]bool, bool) {
+			set := map[string]bool{}
+			for _, v := range versions {
+				set[v] = true
+			}
+			return set, true
+		}
+	}
+
+	tests := []struct {
+		name     string
+		csv      *v1alpha1.ClusterServiceVersion
+		others   []*v1alpha1.ClusterServiceVersion
+		served   func(string) (map[string]bool, bool)
+		expected []string
+	}{
+		{
+			name:   "CompatibleInstances",
+			csv:    instance("team-a", "etcd", "v1beta2"),
+			others: []*v1alpha1.ClusterServiceVersion{instance("team-b", "etcd", "v1beta1"), instance("team-a", "etcd", "v1alpha1")},
+			served: served("v1beta1", "v1beta2"),
+		},
+		{
+			name:     "VersionNoLongerServed",
+			csv:      instance("team-a", "etcd", "v1beta2"),
+			others:   []*v1alpha1.ClusterServiceVersion{instance("team-b", "etcd", "v1beta1")},
+			served:   served("v1beta2"),
+			expected: []string{"crd etcdclusters.etcd.database.coreos.com no longer serves version v1beta1 owned by team-b/etcd.v1"},
+		},
+		{
+			name:     "SharedConversionWebhook",
+			csv:      instance("team-a", "etcd", "v1beta2", conversion),
+			others:   []*v1alpha1.ClusterServiceVersion{instance("team-b", "other", "v1beta2", conversion)},
+			served:   served("v1beta2"),
+			expected: []string{"crd etcdclusters.etcd.database.coreos.com has a conversion webhook defined by team-b/other.v1"},
+		},
+		{
+			name: "OverlappingAdmissionWebhooks",
+			csv:  instance("team-a", "etcd", "", validating),
+			others: func() []*v1alpha1.ClusterServiceVersion {
+				allNamespaces := instance("team-b", "etcd", "", validating)
+				allNamespaces.Annotations[v1.OperatorGroupTargetsAnnotationKey] = ""
+				return []*v1alpha1.ClusterServiceVersion{allNamespaces, instance("team-c", "etcd", "", validating)}
+			}(),
+			expected: []string{"admission webhooks also defined by team-b/etcd.v1 apply to the same namespaces"},
+		},
+		{
+			name: "ReplacedInstanceIgnored",
+			csv:  instance("team-a", "etcd", "v1beta2"),
+			others: func() []*v1alpha1.ClusterServiceVersion {
+				replaced := instance("team-b", "etcd", "v1beta1")
+				replaced.Status.Phase = v1alpha1.CSVPhaseReplacing
+				return []*v1alpha1.ClusterServiceVersion{replaced}
+			}(),
+			served: served("v1beta2"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, multiInstanceConflicts(tt.csv, tt.others, tt.served))
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package olm
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	v1 "github.com/operator-framework/api/pkg/operators/v1"
+)
+
+const (
+	// CopiedCSVsNamespaceAnnotationKey is the namespace annotation that overrides, for that namespace, whether CSVs of
+	// operators installed in AllNamespaces mode are copied into it. Its value is either "enabled" or "disabled".
+	CopiedCSVsNamespaceAnnotationKey = "operatorframework.io/copied-csvs"
+
+	// OperatorGroupLabelsNamespaceAnnotationKey is the namespace annotation that overrides, for that namespace, whether
+	// the labels of the OperatorGroups targeting it are propagated onto it. Its value is either "enabled" or "disabled".
+	// Webhooks of operators scoped to a namespace by those labels don't apply to namespaces opting out.
+	OperatorGroupLabelsNamespaceAnnotationKey = "operatorframework.io/operatorgroup-labels"
+
+	// ExcludeCopiedCSVLabelKey is the namespace label that, when "true", keeps CSVs of operators installed in
+	// AllNamespaces mode from being copied into that namespace, whatever the cluster-wide setting and the namespace's
+	// own override. It is meant for short-lived namespaces, such as those of CI jobs, where copies are only churn.
+	ExcludeCopiedCSVLabelKey = "olm.operatorframework.io/exclude-copied-csv"
+
+	// ExcludeCopiedCSVSelectorAnnotationKey is the OperatorGroup annotation holding a label selector of the namespaces
+	// the CSVs of its AllNamespaces operators aren't copied into, in addition to those labeled with
+	// ExcludeCopiedCSVLabelKey.
+	ExcludeCopiedCSVSelectorAnnotationKey = "olm.operatorframework.io/exclude-copied-csv-selector"
+
+	namespaceFeatureEnabled  = "enabled"
+	namespaceFeatureDisabled = "disabled"
+)
+
+// namespaceFeatureIsEnabled returns whether the feature overridden by the given annotation key is enabled in the given
+// namespace, falling back to the given cluster-wide default when the namespace doesn't override it.
+func (a *Operator) namespaceFeatureIsEnabled(namespace *corev1.Namespace, key string, clusterDefault bool) bool {
+	switch value, ok := namespace.GetAnnotations()[key]; {
+	case !ok:
+		return clusterDefault
+	case value == namespaceFeatureEnabled:
+		return true
+	case value == namespaceFeatureDisabled:
+		return false
+	default:
+		a.logger.WithField("namespace", namespace.GetName()).Warnf("ignoring invalid %s annotation %q: must be %q or %q", key, value, namespaceFeatureEnabled, namespaceFeatureDisabled)
+		return clusterDefault
+	}
+}
+
+// copiedCSVsAreEnabledIn returns whether CSVs of operators installed in AllNamespaces mode are copied into the named
+// namespace, given whether they are copied cluster-wide.
+func (a *Operator) copiedCSVsAreEnabledIn(namespace string, clusterDefault bool) bool {
+	ns, err := a.lister.CoreV1().NamespaceLister().Get(namespace)
+	if err != nil {
+		return clusterDefault
+	}
+	return a.namespaceFeatureIsEnabled(ns, CopiedCSVsNamespaceAnnotationKey, clusterDefault)
+}
+
+// copiedCSVExclusion returns the selector of the namespaces the CSVs of the given AllNamespaces OperatorGroup aren't
+// copied into. An empty or invalid selector of the OperatorGroup is ignored rather than matching every namespace,
+// leaving only the namespaces labeled with ExcludeCopiedCSVLabelKey excluded.
+func (a *Operator) copiedCSVExclusion(operatorGroup *v1.OperatorGroup) labels.Selector {
+	value := operatorGroup.GetAnnotations()[ExcludeCopiedCSVSelectorAnnotationKey]
+	if value == "" {
+		return labels.Nothing()
+	}
+	selector, err := labels.Parse(value)
+	if err != nil {
+		a.logger.WithField("opgroup", operatorGroup.GetNamespace()+"/"+operatorGroup.GetName()).WithError(err).Warnf("ignoring invalid %s annotation %q", ExcludeCopiedCSVSelectorAnnotationKey, value)
+		return labels.Nothing()
+	}
+	return selector
+}
+
+// copiedCSVsAreExcludedFrom returns whether the given namespace is excluded from the copies of CSVs of operators
+// installed in AllNamespaces mode, by its own label or by the given selector of their OperatorGroup.
+func copiedCSVsAreExcludedFrom(namespace *corev1.Namespace, exclusion labels.Selector) bool {
+	return namespace.GetLabels()[ExcludeCopiedCSVLabelKey] == "true" || exclusion.Matches(labels.Set(namespace.GetLabels()))
+}
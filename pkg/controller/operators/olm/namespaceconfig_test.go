@@ -0,0 +1,115 @@
+package olm
+
+import (
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/operator-framework/api/pkg/operators/v1"
+)
+
+func TestNamespaceFeatureIsEnabled(t *testing.T) {
+	tests := []struct {
+		description    string
+		annotations    map[string]string
+		clusterDefault bool
+		want           bool
+	}{
+		{
+			description:    "NoOverride",
+			clusterDefault: true,
+			want:           true,
+		},
+		{
+			description:    "Disabled",
+			annotations:    map[string]string{CopiedCSVsNamespaceAnnotationKey: "disabled"},
+			clusterDefault: true,
+			want:           false,
+		},
+		{
+			description:    "Enabled",
+			annotations:    map[string]string{CopiedCSVsNamespaceAnnotationKey: "enabled"},
+			clusterDefault: false,
+			want:           true,
+		},
+		{
+			description:    "Invalid",
+			annotations:    map[string]string{CopiedCSVsNamespaceAnnotationKey: "off"},
+			clusterDefault: false,
+			want:           false,
+		},
+		{
+			description:    "OtherFeature",
+			annotations:    map[string]string{OperatorGroupLabelsNamespaceAnnotationKey: "disabled"},
+			clusterDefault: true,
+			want:           true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			a := &Operator{logger: logrus.New()}
+			ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "tenant", Annotations: tt.annotations}}
+			require.Equal(t, tt.want, a.namespaceFeatureIsEnabled(ns, CopiedCSVsNamespaceAnnotationKey, tt.clusterDefault))
+		})
+	}
+}
+
+func TestCopiedCSVsAreExcludedFrom(t *testing.T) {
+	tests := []struct {
+		description string
+		labels      map[string]string
+		annotations map[string]string
+		want        bool
+	}{
+		{
+			description: "NotExcluded",
+			labels:      map[string]string{"ci-job": "1234"},
+			want:        false,
+		},
+		{
+			description: "Labeled",
+			labels:      map[string]string{ExcludeCopiedCSVLabelKey: "true"},
+			want:        true,
+		},
+		{
+			description: "LabeledFalse",
+			labels:      map[string]string{ExcludeCopiedCSVLabelKey: "false"},
+			want:        false,
+		},
+		{
+			description: "Selected",
+			labels:      map[string]string{"ci-job": "1234"},
+			annotations: map[string]string{ExcludeCopiedCSVSelectorAnnotationKey: "ci-job"},
+			want:        true,
+		},
+		{
+			description: "NotSelected",
+			labels:      map[string]string{"team": "a"},
+			annotations: map[string]string{ExcludeCopiedCSVSelectorAnnotationKey: "ci-job"},
+			want:        false,
+		},
+		{
+			description: "InvalidSelector",
+			labels:      map[string]string{"ci-job": "1234"},
+			annotations: map[string]string{ExcludeCopiedCSVSelectorAnnotationKey: "ci-job in ("},
+			want:        false,
+		},
+		{
+			description: "EmptySelector",
+			labels:      map[string]string{"team": "a"},
+			annotations: map[string]string{ExcludeCopiedCSVSelectorAnnotationKey: ""},
+			want:        false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			a := &Operator{logger: logrus.New()}
+			og := &v1.OperatorGroup{ObjectMeta: metav1.ObjectMeta{Name: "global", Namespace: "operators", Annotations: tt.annotations}}
+			ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "tenant", Labels: tt.labels}}
+			require.Equal(t, tt.want, copiedCSVsAreExcludedFrom(ns, a.copiedCSVExclusion(og)))
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package olm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	v1 "github.com/operator-framework/api/pkg/operators/v1"
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+)
+
+const (
+	// AnnotateOperandsAnnotationKey is the CSV annotation declaring, as a JSON-encoded list of group, version and kind,
+	// the custom resources owned by the CSV that OLM annotates with the version of the operator reconciling them each
+	// time the operator is installed or upgraded, so that behavior changes of operands can be traced back to the
+	// operator version that last reconciled them.
+	AnnotateOperandsAnnotationKey = "operatorframework.io/annotate-operands"
+
+	// OperatorVersionAnnotationKey is the annotation of operands holding the version of the CSV of the operator
+	// reconciling them, or its name if it has no version.
+	OperatorVersionAnnotationKey = "olm.operatorframework.io/operator-version"
+
+	// OperandsAnnotatedAnnotationKey is the CSV annotation OLM records the operator version its operands were last
+	// annotated with in, so that they're only annotated again once the version changes.
+	OperandsAnnotatedAnnotationKey = "operatorframework.io/operands-annotated"
+
+	// operandsNotAnnotatedReason is the reason of the event emitted when the operands of a CSV couldn't be annotated.
+	operandsNotAnnotatedReason = "OperandsNotAnnotated"
+)
+
+// operatorVersion returns the version operands of the given CSV are annotated with.
+func operatorVersion(csv *v1alpha1.ClusterServiceVersion) string {
+	if v := csv.Spec.Version.String(); v != "0.0.0" {
+		return v
+	}
+	return csv.GetName()
+}
+
+// operandResource is a resource of operands, defined by a CRD owned by their CSV.
+type operandResource struct {
+	gvr schema.GroupVersionResource
+	crd string
+}
+
+// operandResources returns the resources of the operands the given CSV declares to annotate, which must be custom
+// resources owned by the CSV.
+func operandResources(csv *v1alpha1.ClusterServiceVersion) ([]operandResource, error) {
+	value, ok := csv.GetAnnotations()[AnnotateOperandsAnnotationKey]
+	if !ok {
+		return nil, nil
+	}
+	var gvks []metav1.GroupVersionKind
+	if err := json.Unmarshal([]byte(value), &gvks); err != nil {
+		return nil, fmt.Errorf("invalid %s annotation: %v", AnnotateOperandsAnnotationKey, err)
+	}
+
+	var resources []operandResource
+	for _, gvk := range gvks {
+		found := false
+		for _, crd := range csv.Spec.CustomResourceDefinitions.Owned {
+			resource := strings.SplitN(crd.Name, ".", 2)
+			if len(resource) != 2 || resource[1] != gvk.Group || crd.Version != gvk.Version || crd.Kind != gvk.Kind {
+				continue
+			}
+			resources = append(resources, operandResource{
+				gvr: schema.GroupVersionResource{Group: gvk.Group, Version: gvk.Version, Resource: resource[0]},
+				crd: crd.Name,
+			})
+			found = true
+			break
+		}
+		if !found {
+			return nil, fmt.Errorf("invalid %s annotation: %s is not an owned custom resource", AnnotateOperandsAnnotationKey, schema.GroupVersionKind(gvk))
+		}
+	}
+	return resources, nil
+}
+
+// annotateOperands annotates the operands the given succeeded CSV declares, in the target namespaces of its
+// OperatorGroup, with the version of its operator, unless they were already annotated with that version.
+func (a *Operator) annotateOperands(ctx context.Context, logger *logrus.Entry, csv *v1alpha1.ClusterServiceVersion, operatorGroup *v1.OperatorGroup) error {
+	version := operatorVersion(csv)
+	if csv.GetAnnotations()[OperandsAnnotatedAnnotationKey] == version {
+		return nil
+	}
+	resources, err := operandResources(csv)
+	if err != nil || len(resources) == 0 {
+		if err != nil {
+			a.recorder.Event(csv, corev1.EventTypeWarning, operandsNotAnnotatedReason, err.Error())
+		}
+		return err
+	}
+
+	namespaces := operatorGroup.Status.Namespaces
+	if NewNamespaceSet(namespaces).IsAllNamespaces() {
+		namespaces = []string{metav1.NamespaceAll}
+	}
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]string{OperatorVersionAnnotationKey: version},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	annotated := 0
+	for _, r := range resources {
+		gvr, namespaces := r.gvr, namespaces
+		if crd, err := a.lister.APIExtensionsV1().CustomResourceDefinitionLister().Get(r.crd); err == nil && crd.Spec.Scope == apiextensionsv1.ClusterScoped {
+			namespaces = []string{metav1.NamespaceAll}
+		}
+		for _, namespace := range namespaces {
+			list, err := a.dynamicClient.Resource(gvr).Namespace(namespace).List(ctx, metav1.ListOptions{})
+			if err != nil {
+				errs = append(errs, fmt.Errorf("listing %s: %v", gvr.GroupResource(), err))
+				continue
+			}
+			for _, operand := range list.Items {
+				if operand.GetAnnotations()[OperatorVersionAnnotationKey] == version {
+					continue
+				}
+				if _, err := a.dynamicClient.Resource(gvr).Namespace(operand.GetNamespace()).Patch(ctx, operand.GetName(), types.MergePatchType, patch, metav1.PatchOptions{}); err != nil {
+					errs = append(errs, fmt.Errorf("annotating %s %s/%s: %v", gvr.GroupResource(), operand.GetNamespace(), operand.GetName(), err))
+					continue
+				}
+				annotated++
+			}
+		}
+	}
+	if err := utilerrors.NewAggregate(errs); err != nil {
+		a.recorder.Event(csv, corev1.EventTypeWarning, operandsNotAnnotatedReason, err.Error())
+		return err
+	}
+	logger.WithField("version", version).Infof("annotated %d operands with operator version", annotated)
+
+	// Record the version on the CSV with a patch, which doesn't conflict with the status just written
+	patch, err = json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]string{OperandsAnnotatedAnnotationKey: version},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = a.client.OperatorsV1alpha1().ClusterServiceVersions(csv.GetNamespace()).Patch(ctx, csv.GetName(), types.MergePatchType, patch, metav1.PatchOptions{})
+	return err
+}
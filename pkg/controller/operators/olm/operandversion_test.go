@@ -0,0 +1,101 @@
+package olm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/blang/semver/v4"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+
+	"github.com/operator-framework/api/pkg/lib/version"
+	v1 "github.com/operator-framework/api/pkg/operators/v1"
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+)
+
+var memcachedGVR = schema.GroupVersionResource{Group: "cache.example.com", Version: "v1", Resource: "memcacheds"}
+
+func operandCSV(annotation string) *v1alpha1.ClusterServiceVersion {
+	return &v1alpha1.ClusterServiceVersion{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "memcached.v1.2.0",
+			Namespace:   "operators",
+			Annotations: map[string]string{AnnotateOperandsAnnotationKey: annotation},
+		},
+		Spec: v1alpha1.ClusterServiceVersionSpec{
+			Version: version.OperatorVersion{Version: semver.MustParse("1.2.0")},
+			CustomResourceDefinitions: v1alpha1.CustomResourceDefinitions{
+				Owned: []v1alpha1.CRDDescription{{Name: "memcacheds.cache.example.com", Version: "v1", Kind: "Memcached"}},
+			},
+		},
+	}
+}
+
+func memcached(namespace, name, operatorVersion string) runtime.Object {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion("cache.example.com/v1")
+	obj.SetKind("Memcached")
+	obj.SetNamespace(namespace)
+	obj.SetName(name)
+	if operatorVersion != "" {
+		obj.SetAnnotations(map[string]string{OperatorVersionAnnotationKey: operatorVersion})
+	}
+	return obj
+}
+
+func TestOperandResources(t *testing.T) {
+	resources, err := operandResources(operandCSV(`[{"group":"cache.example.com","version":"v1","kind":"Memcached"}]`))
+	require.NoError(t, err)
+	require.Equal(t, []operandResource{{gvr: memcachedGVR, crd: "memcacheds.cache.example.com"}}, resources)
+
+	_, err = operandResources(operandCSV(`[{"group":"cache.example.com","version":"v1","kind":"Redis"}]`))
+	require.EqualError(t, err, "invalid operatorframework.io/annotate-operands annotation: cache.example.com/v1, Kind=Redis is not an owned custom resource")
+
+	_, err = operandResources(operandCSV(`Memcached`))
+	require.Error(t, err)
+
+	csv := operandCSV("")
+	delete(csv.Annotations, AnnotateOperandsAnnotationKey)
+	resources, err = operandResources(csv)
+	require.NoError(t, err)
+	require.Empty(t, resources)
+}
+
+func TestAnnotateOperands(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+
+	csv := operandCSV(`[{"group":"cache.example.com","version":"v1","kind":"Memcached"}]`)
+	op, err := NewFakeOperator(ctx, withNamespaces("operators", "tenant", "other"), withClientObjs(csv))
+	require.NoError(t, err)
+	op.dynamicClient = dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		memcachedGVR: "MemcachedList",
+	}, memcached("tenant", "a", ""), memcached("tenant", "b", "1.1.0"), memcached("other", "c", ""))
+	og := &v1.OperatorGroup{Status: v1.OperatorGroupStatus{Namespaces: []string{"tenant"}}}
+
+	require.NoError(t, op.annotateOperands(ctx, logrus.NewEntry(logrus.New()), csv, og))
+
+	annotation := func(namespace, name string) string {
+		obj, err := op.dynamicClient.Resource(memcachedGVR).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+		require.NoError(t, err)
+		return obj.GetAnnotations()[OperatorVersionAnnotationKey]
+	}
+	require.Equal(t, "1.2.0", annotation("tenant", "a"))
+	require.Equal(t, "1.2.0", annotation("tenant", "b"))
+	require.Equal(t, "", annotation("other", "c"))
+
+	out, err := op.client.OperatorsV1alpha1().ClusterServiceVersions("operators").Get(ctx, csv.GetName(), metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, "1.2.0", out.GetAnnotations()[OperandsAnnotatedAnnotationKey])
+
+	// Operands aren't annotated again until the version changes
+	_, err = op.dynamicClient.Resource(memcachedGVR).Namespace("tenant").Create(ctx, memcached("tenant", "d", "").(*unstructured.Unstructured), metav1.CreateOptions{})
+	require.NoError(t, err)
+	require.NoError(t, op.annotateOperands(ctx, logrus.NewEntry(logrus.New()), out, og))
+	require.Equal(t, "", annotation("tenant", "d"))
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
 	"strings"
 	"time"
 
@@ -22,6 +23,7 @@ import (
 	utilclock "k8s.io/apimachinery/pkg/util/clock"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/informers"
 	k8sscheme "k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/tools/cache"
@@ -33,12 +35,16 @@ import (
 	"github.com/operator-framework/api/pkg/operators/v1alpha1"
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/api/client/clientset/versioned"
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/api/client/informers/externalversions"
+	operatorsv1listers "github.com/operator-framework/operator-lifecycle-manager/pkg/api/client/listers/operators/v1"
 	operatorsv1alpha1listers "github.com/operator-framework/operator-lifecycle-manager/pkg/api/client/listers/operators/v1alpha1"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/api/reasons"
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/certs"
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/install"
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/operators/internal/pruning"
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/operators/olm/overrides"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/audit"
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/clients"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/controllers"
 	csvutility "github.com/operator-framework/operator-lifecycle-manager/pkg/lib/csv"
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/event"
 	index "github.com/operator-framework/operator-lifecycle-manager/pkg/lib/index"
@@ -50,6 +56,7 @@ import (
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/proxy"
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/queueinformer"
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/scoped"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/sharding"
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/metrics"
 )
 
@@ -68,11 +75,13 @@ type Operator struct {
 	client                versioned.Interface
 	lister                operatorlister.OperatorLister
 	copiedCSVLister       operatorsv1alpha1listers.ClusterServiceVersionLister
+	olmConfigLister       operatorsv1listers.OLMConfigLister
 	ogQueueSet            *queueinformer.ResourceQueueSet
 	csvQueueSet           *queueinformer.ResourceQueueSet
 	olmConfigQueue        workqueue.RateLimitingInterface
 	csvCopyQueueSet       *queueinformer.ResourceQueueSet
 	copiedCSVGCQueueSet   *queueinformer.ResourceQueueSet
+	csvChainQueueSet      *queueinformer.ResourceQueueSet
 	objGCQueueSet         *queueinformer.ResourceQueueSet
 	nsQueueSet            workqueue.RateLimitingInterface
 	apiServiceQueue       workqueue.RateLimitingInterface
@@ -88,6 +97,26 @@ type Operator struct {
 	clientAttenuator      *scoped.ClientAttenuator
 	serviceAccountQuerier *scoped.UserDefinedServiceAccountQuerier
 	clientFactory         clients.Factory
+	admissionPolicyClient *install.AdmissionPolicyClient
+	dynamicClient         dynamic.Interface
+	baselineSampler       *resourceBaselineSampler
+	requirementProber     *requirementProber
+	ruleCheckCache        *install.RuleCheckCache
+	csvRequeueInterval    time.Duration
+	kubeconfigClient      *install.KubeconfigClient
+	operatorNamespace     string
+	phaseHookClient       *http.Client
+	auditor               *audit.Recorder
+	shard                 *sharding.Shard
+	disabled              controllers.Disabled
+
+	// clusterRoleIndexer and clusterRoleBindingIndexer index cluster RBAC by the CSV owning it
+	clusterRoleIndexer        cache.Indexer
+	clusterRoleBindingIndexer cache.Indexer
+
+	// roleIndexers and roleBindingIndexers index the RBAC of each watched namespace by the CSV owning it
+	roleIndexers        map[string]cache.Indexer
+	roleBindingIndexers map[string]cache.Indexer
 }
 
 func NewOperator(ctx context.Context, options ...OperatorOption) (*Operator, error) {
@@ -102,7 +131,7 @@ func newOperatorWithConfig(ctx context.Context, config *operatorConfig) (*Operat
 		return nil, err
 	}
 
-	queueOperator, err := queueinformer.NewOperator(config.operatorClient.KubernetesInterface().Discovery(), queueinformer.WithOperatorLogger(config.logger))
+	queueOperator, err := queueinformer.NewOperator(config.operatorClient.KubernetesInterface().Discovery(), queueinformer.WithOperatorLogger(config.logger), queueinformer.WithSyncTimeout(config.syncTimeout))
 	if err != nil {
 		return nil, err
 	}
@@ -119,17 +148,23 @@ func newOperatorWithConfig(ctx context.Context, config *operatorConfig) (*Operat
 		return nil, err
 	}
 
+	dynamicClient, err := dynamic.NewForConfig(config.restConfig)
+	if err != nil {
+		return nil, err
+	}
+
 	op := &Operator{
 		Operator:              queueOperator,
 		clock:                 config.clock,
 		logger:                config.logger,
-		opClient:              config.operatorClient,
+		opClient:              operatorclient.NewCachedClient(config.operatorClient, lister.AppsV1().DeploymentLister(), lister.APIRegistrationV1().APIServiceLister()),
 		client:                config.externalClient,
 		ogQueueSet:            queueinformer.NewEmptyResourceQueueSet(),
 		csvQueueSet:           queueinformer.NewEmptyResourceQueueSet(),
 		olmConfigQueue:        workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "olmConfig"),
 		csvCopyQueueSet:       queueinformer.NewEmptyResourceQueueSet(),
 		copiedCSVGCQueueSet:   queueinformer.NewEmptyResourceQueueSet(),
+		csvChainQueueSet:      queueinformer.NewEmptyResourceQueueSet(),
 		objGCQueueSet:         queueinformer.NewEmptyResourceQueueSet(),
 		apiServiceQueue:       workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "apiservice"),
 		resolver:              config.strategyResolver,
@@ -138,16 +173,34 @@ func newOperatorWithConfig(ctx context.Context, config *operatorConfig) (*Operat
 		recorder:              eventRecorder,
 		apiLabeler:            config.apiLabeler,
 		csvIndexers:           map[string]cache.Indexer{},
+		roleIndexers:          map[string]cache.Indexer{},
+		roleBindingIndexers:   map[string]cache.Indexer{},
 		csvSetGenerator:       csvutility.NewSetGenerator(config.logger, lister),
 		csvReplaceFinder:      csvutility.NewReplaceFinder(config.logger, config.externalClient),
+		disabled:              config.disabled,
 		serviceAccountSyncer:  scoped.NewUserDefinedServiceAccountSyncer(config.logger, scheme, config.operatorClient, config.externalClient),
 		clientAttenuator:      scoped.NewClientAttenuator(config.logger, config.restConfig, config.operatorClient),
 		serviceAccountQuerier: scoped.NewUserDefinedServiceAccountQuerier(config.logger, config.externalClient),
 		clientFactory:         clients.NewFactory(config.restConfig),
+		admissionPolicyClient: install.NewAdmissionPolicyClient(dynamicClient),
+		dynamicClient:         dynamicClient,
+		requirementProber:     newRequirementProber(),
+		ruleCheckCache:        install.NewRuleCheckCache(),
+		csvRequeueInterval:    config.csvRequeueInterval,
+		kubeconfigClient:      install.NewKubeconfigClient(config.operatorClient.KubernetesInterface(), config.clock),
+		operatorNamespace:     config.operatorNamespace,
+		phaseHookClient:       &http.Client{Timeout: phaseHookTimeout},
+		shard:                 config.shard,
+	}
+	if config.auditMutations {
+		op.auditor = audit.NewRecorder(eventRecorder, config.logger)
+	}
+	if config.baselineWindow > 0 {
+		op.baselineSampler = newResourceBaselineSampler(dynamicClient, config.clock, config.baselineWindow)
 	}
 
 	// Set up syncing for namespace-scoped resources
-	k8sSyncer := queueinformer.LegacySyncHandler(op.syncObject).ToSyncerWithDelete(op.handleDeletion)
+	k8sSyncer := queueinformer.SyncHandler(op.syncObject).ToSyncerWithDelete(op.handleDeletion)
 	for _, namespace := range config.watchedNamespaces {
 		// Wire CSVs
 		csvInformer := externalversions.NewSharedInformerFactoryWithOptions(
@@ -167,7 +220,7 @@ func newOperatorWithConfig(ctx context.Context, config *operatorConfig) (*Operat
 			queueinformer.WithLogger(op.logger),
 			queueinformer.WithQueue(csvQueue),
 			queueinformer.WithInformer(csvInformer.Informer()),
-			queueinformer.WithSyncer(queueinformer.LegacySyncHandler(op.syncClusterServiceVersion).ToSyncerWithDelete(op.handleClusterServiceVersionDeletion)),
+			queueinformer.WithSyncer(op.inShard(op.syncClusterServiceVersion).ToSyncerWithDelete(op.inShardDelete(op.handleClusterServiceVersionDeletion))),
 		)
 		if err != nil {
 			return nil, err
@@ -181,20 +234,41 @@ func newOperatorWithConfig(ctx context.Context, config *operatorConfig) (*Operat
 		csvIndexer := csvInformer.Informer().GetIndexer()
 		op.csvIndexers[namespace] = csvIndexer
 
-		// Register separate queue for copying csvs
-		csvCopyQueue := workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), fmt.Sprintf("%s/csv-copy", namespace))
-		op.csvCopyQueueSet.Set(namespace, csvCopyQueue)
-		csvCopyQueueInformer, err := queueinformer.NewQueueInformer(
+		// Register separate queue for copying csvs, with a lower rate limit and its own workers so that copies don't
+		// hold back installs
+		if op.disabled.Enabled(controllers.CopiedCSVs) {
+			csvCopyQueue := newCopiedCSVQueue(namespace, copiedCSVRateLimiter(config.copiedCSVQPS, config.copiedCSVBurst), config.clock)
+			op.csvCopyQueueSet.Set(namespace, csvCopyQueue)
+			csvCopyQueueInformer, err := queueinformer.NewQueueInformer(
+				ctx,
+				queueinformer.WithLogger(op.logger),
+				queueinformer.WithQueue(csvCopyQueue),
+				queueinformer.WithWorkers(config.copiedCSVWorkers),
+				queueinformer.WithIndexer(csvIndexer),
+				queueinformer.WithSyncer(op.inShard(op.syncCopyCSV).ToSyncer()),
+			)
+			if err != nil {
+				return nil, err
+			}
+			if err := op.RegisterQueueInformer(csvCopyQueueInformer); err != nil {
+				return nil, err
+			}
+		}
+
+		// Register separate queue for cleaning up the replacement chains of succeeded csvs
+		csvChainQueue := workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), fmt.Sprintf("%s/csv-chain", namespace))
+		op.csvChainQueueSet.Set(namespace, csvChainQueue)
+		csvChainQueueInformer, err := queueinformer.NewQueueInformer(
 			ctx,
 			queueinformer.WithLogger(op.logger),
-			queueinformer.WithQueue(csvCopyQueue),
+			queueinformer.WithQueue(csvChainQueue),
 			queueinformer.WithIndexer(csvIndexer),
-			queueinformer.WithSyncer(queueinformer.LegacySyncHandler(op.syncCopyCSV).ToSyncer()),
+			queueinformer.WithSyncer(op.inShard(op.syncReplacementChain).ToSyncer()),
 		)
 		if err != nil {
 			return nil, err
 		}
-		if err := op.RegisterQueueInformer(csvCopyQueueInformer); err != nil {
+		if err := op.RegisterQueueInformer(csvChainQueueInformer); err != nil {
 			return nil, err
 		}
 
@@ -246,7 +320,7 @@ func newOperatorWithConfig(ctx context.Context, config *operatorConfig) (*Operat
 			queueinformer.WithLogger(op.logger),
 			queueinformer.WithQueue(copiedCSVGCQueue),
 			queueinformer.WithIndexer(copiedCSVInformer.GetIndexer()),
-			queueinformer.WithSyncer(queueinformer.LegacySyncHandler(op.syncGcCsv).ToSyncer()),
+			queueinformer.WithSyncer(op.inShard(op.syncGcCsv).ToSyncer()),
 		)
 		if err != nil {
 			return nil, err
@@ -266,7 +340,7 @@ func newOperatorWithConfig(ctx context.Context, config *operatorConfig) (*Operat
 			queueinformer.WithLogger(op.logger),
 			queueinformer.WithQueue(ogQueue),
 			queueinformer.WithInformer(operatorGroupInformer.Informer()),
-			queueinformer.WithSyncer(queueinformer.LegacySyncHandler(op.syncOperatorGroups).ToSyncerWithDelete(op.operatorGroupDeleted)),
+			queueinformer.WithSyncer(op.inShard(op.syncOperatorGroups).ToSyncerWithDelete(op.inShardDelete(op.operatorGroupDeleted))),
 		)
 		if err != nil {
 			return nil, err
@@ -326,6 +400,11 @@ func newOperatorWithConfig(ctx context.Context, config *operatorConfig) (*Operat
 		// Set up RBAC informers
 		roleInformer := k8sInformerFactory.Rbac().V1().Roles()
 		op.lister.RbacV1().RegisterRoleLister(namespace, roleInformer.Lister())
+		roleInformer.Informer().AddEventHandler(op.ruleCheckCache.EventHandler())
+		if err := roleInformer.Informer().AddIndexers(cache.Indexers{index.CSVOwnerIndexFuncKey: index.CSVOwnerIndexFunc}); err != nil {
+			return nil, err
+		}
+		op.roleIndexers[namespace] = roleInformer.Informer().GetIndexer()
 		roleQueueInformer, err := queueinformer.NewQueueInformer(
 			ctx,
 			queueinformer.WithLogger(op.logger),
@@ -341,6 +420,11 @@ func newOperatorWithConfig(ctx context.Context, config *operatorConfig) (*Operat
 
 		roleBindingInformer := k8sInformerFactory.Rbac().V1().RoleBindings()
 		op.lister.RbacV1().RegisterRoleBindingLister(namespace, roleBindingInformer.Lister())
+		roleBindingInformer.Informer().AddEventHandler(op.ruleCheckCache.EventHandler())
+		if err := roleBindingInformer.Informer().AddIndexers(cache.Indexers{index.CSVOwnerIndexFuncKey: index.CSVOwnerIndexFunc}); err != nil {
+			return nil, err
+		}
+		op.roleBindingIndexers[namespace] = roleBindingInformer.Informer().GetIndexer()
 		roleBindingQueueInformer, err := queueinformer.NewQueueInformer(
 			ctx,
 			queueinformer.WithLogger(op.logger),
@@ -391,6 +475,12 @@ func newOperatorWithConfig(ctx context.Context, config *operatorConfig) (*Operat
 		// Register ServiceAccount QueueInformer
 		serviceAccountInformer := k8sInformerFactory.Core().V1().ServiceAccounts()
 		op.lister.CoreV1().RegisterServiceAccountLister(metav1.NamespaceAll, serviceAccountInformer.Lister())
+		serviceAccountInformer.Informer().AddEventHandler(
+			&cache.ResourceEventHandlerFuncs{
+				AddFunc:    op.serviceAccountAddedOrRemoved,
+				DeleteFunc: op.serviceAccountAddedOrRemoved,
+			},
+		)
 		serviceAccountQueueInformer, err := queueinformer.NewQueueInformer(
 			ctx,
 			queueinformer.WithLogger(op.logger),
@@ -410,7 +500,7 @@ func newOperatorWithConfig(ctx context.Context, config *operatorConfig) (*Operat
 			ctx,
 			queueinformer.WithLogger(op.logger),
 			queueinformer.WithQueue(objGCQueue),
-			queueinformer.WithSyncer(queueinformer.LegacySyncHandler(op.syncGCObject).ToSyncer()),
+			queueinformer.WithSyncer(queueinformer.SyncHandler(op.syncGCObject).ToSyncer()),
 		)
 		if err != nil {
 			return nil, err
@@ -427,7 +517,7 @@ func newOperatorWithConfig(ctx context.Context, config *operatorConfig) (*Operat
 		ctx,
 		queueinformer.WithLogger(op.logger),
 		queueinformer.WithQueue(objGCQueue),
-		queueinformer.WithSyncer(queueinformer.LegacySyncHandler(op.syncGCObject).ToSyncer()),
+		queueinformer.WithSyncer(queueinformer.SyncHandler(op.syncGCObject).ToSyncer()),
 	)
 	if err != nil {
 		return nil, err
@@ -441,13 +531,14 @@ func newOperatorWithConfig(ctx context.Context, config *operatorConfig) (*Operat
 		op.client,
 		config.resyncPeriod(),
 	).Operators().V1().OLMConfigs().Informer()
+	op.olmConfigLister = operatorsv1listers.NewOLMConfigLister(olmConfigInformer.GetIndexer())
 	olmConfigQueueInformer, err := queueinformer.NewQueueInformer(
 		ctx,
 		queueinformer.WithInformer(olmConfigInformer),
 		queueinformer.WithLogger(op.logger),
 		queueinformer.WithQueue(op.olmConfigQueue),
 		queueinformer.WithIndexer(olmConfigInformer.GetIndexer()),
-		queueinformer.WithSyncer(queueinformer.LegacySyncHandler(op.syncOLMConfig).ToSyncer()),
+		queueinformer.WithSyncer(op.inShard(op.syncOLMConfig).ToSyncer()),
 	)
 	if err != nil {
 		return nil, err
@@ -459,6 +550,11 @@ func newOperatorWithConfig(ctx context.Context, config *operatorConfig) (*Operat
 	k8sInformerFactory := informers.NewSharedInformerFactory(op.opClient.KubernetesInterface(), config.resyncPeriod())
 	clusterRoleInformer := k8sInformerFactory.Rbac().V1().ClusterRoles()
 	op.lister.RbacV1().RegisterClusterRoleLister(clusterRoleInformer.Lister())
+	clusterRoleInformer.Informer().AddEventHandler(op.ruleCheckCache.EventHandler())
+	if err := clusterRoleInformer.Informer().AddIndexers(cache.Indexers{index.CSVOwnerIndexFuncKey: index.CSVOwnerIndexFunc}); err != nil {
+		return nil, err
+	}
+	op.clusterRoleIndexer = clusterRoleInformer.Informer().GetIndexer()
 	clusterRoleQueueInformer, err := queueinformer.NewQueueInformer(
 		ctx,
 		queueinformer.WithLogger(op.logger),
@@ -474,6 +570,11 @@ func newOperatorWithConfig(ctx context.Context, config *operatorConfig) (*Operat
 
 	clusterRoleBindingInformer := k8sInformerFactory.Rbac().V1().ClusterRoleBindings()
 	op.lister.RbacV1().RegisterClusterRoleBindingLister(clusterRoleBindingInformer.Lister())
+	clusterRoleBindingInformer.Informer().AddEventHandler(op.ruleCheckCache.EventHandler())
+	if err := clusterRoleBindingInformer.Informer().AddIndexers(cache.Indexers{index.CSVOwnerIndexFuncKey: index.CSVOwnerIndexFunc}); err != nil {
+		return nil, err
+	}
+	op.clusterRoleBindingIndexer = clusterRoleBindingInformer.Informer().GetIndexer()
 	clusterRoleBindingQueueInformer, err := queueinformer.NewQueueInformer(
 		ctx,
 		queueinformer.WithLogger(op.logger),
@@ -487,6 +588,29 @@ func newOperatorWithConfig(ctx context.Context, config *operatorConfig) (*Operat
 		return nil, err
 	}
 
+	// Register webhook configuration QueueInformers, to repair drift from the client configs OLM sets
+	webhookInformerFactory := informers.NewSharedInformerFactoryWithOptions(op.opClient.KubernetesInterface(), config.resyncPeriod(), informers.WithTweakListOptions(func(options *metav1.ListOptions) {
+		options.LabelSelector = labels.SelectorFromSet(map[string]string{ownerutil.OwnerKind: v1alpha1.ClusterServiceVersionKind}).String()
+	}))
+	webhookSyncer := queueinformer.SyncHandler(op.syncWebhookConfiguration).ToSyncerWithDelete(op.handleDeletion)
+	for _, informer := range []cache.SharedIndexInformer{
+		webhookInformerFactory.Admissionregistration().V1().ValidatingWebhookConfigurations().Informer(),
+		webhookInformerFactory.Admissionregistration().V1().MutatingWebhookConfigurations().Informer(),
+	} {
+		webhookQueueInformer, err := queueinformer.NewQueueInformer(
+			ctx,
+			queueinformer.WithLogger(op.logger),
+			queueinformer.WithInformer(informer),
+			queueinformer.WithSyncer(webhookSyncer),
+		)
+		if err != nil {
+			return nil, err
+		}
+		if err := op.RegisterQueueInformer(webhookQueueInformer); err != nil {
+			return nil, err
+		}
+	}
+
 	// register namespace queueinformer
 	namespaceInformer := k8sInformerFactory.Core().V1().Namespaces()
 	op.lister.CoreV1().RegisterNamespaceLister(namespaceInformer.Lister())
@@ -497,12 +621,15 @@ func newOperatorWithConfig(ctx context.Context, config *operatorConfig) (*Operat
 			AddFunc:    op.namespaceAddedOrRemoved,
 		},
 	)
+	if op.shard != nil {
+		namespaceInformer.Informer().AddEventHandler(op.shardNamespaceCounter())
+	}
 	namespaceQueueInformer, err := queueinformer.NewQueueInformer(
 		ctx,
 		queueinformer.WithLogger(op.logger),
 		queueinformer.WithQueue(op.nsQueueSet),
 		queueinformer.WithInformer(namespaceInformer.Informer()),
-		queueinformer.WithSyncer(queueinformer.LegacySyncHandler(op.syncNamespace).ToSyncer()),
+		queueinformer.WithSyncer(op.inShard(op.syncNamespace).ToSyncer()),
 	)
 	if err != nil {
 		return nil, err
@@ -519,7 +646,7 @@ func newOperatorWithConfig(ctx context.Context, config *operatorConfig) (*Operat
 		queueinformer.WithLogger(op.logger),
 		queueinformer.WithQueue(op.apiServiceQueue),
 		queueinformer.WithInformer(apiServiceInformer.Informer()),
-		queueinformer.WithSyncer(queueinformer.LegacySyncHandler(op.syncAPIService).ToSyncerWithDelete(op.handleDeletion)),
+		queueinformer.WithSyncer(queueinformer.SyncHandler(op.syncAPIService).ToSyncerWithDelete(op.handleDeletion)),
 	)
 	if err != nil {
 		return nil, err
@@ -579,9 +706,13 @@ func newOperatorWithConfig(ctx context.Context, config *operatorConfig) (*Operat
 		}
 	}
 
-	overridesBuilderFunc := overrides.NewDeploymentInitializer(op.logger, proxyQuerierInUse, op.lister)
+	overridesBuilderFunc := overrides.NewDeploymentInitializer(op.logger, proxyQuerierInUse, op.lister, op.olmConfigAnnotations)
 	op.resolver = &install.StrategyResolver{
-		OverridesBuilderFunc: overridesBuilderFunc.GetDeploymentInitializer,
+		OverridesBuilderFunc:  overridesBuilderFunc.GetDeploymentInitializer,
+		CertLifetimeFunc:      op.certLifetimeFor,
+		CertManagerIssuerFunc: op.certManagerIssuerFor,
+		CertManagerClient:     dynamicClient,
+		Auditor:               op.auditor,
 	}
 
 	return op, nil
@@ -618,7 +749,7 @@ func (a *Operator) syncSubscriptionDeleted(obj interface{}) {
 	}
 }
 
-func (a *Operator) syncAPIService(obj interface{}) (syncError error) {
+func (a *Operator) syncAPIService(ctx context.Context, obj interface{}) (syncError error) {
 	apiService, ok := obj.(*apiregistrationv1.APIService)
 	if !ok {
 		a.logger.Debugf("wrong type: %#v", obj)
@@ -635,14 +766,14 @@ func (a *Operator) syncAPIService(obj interface{}) (syncError error) {
 		_, err := a.lister.CoreV1().NamespaceLister().Get(ns)
 		if k8serrors.IsNotFound(err) {
 			logger.Debug("Deleting api service since owning namespace is not found")
-			syncError = a.opClient.DeleteAPIService(apiService.GetName(), &metav1.DeleteOptions{})
+			syncError = a.opClient.DeleteAPIService(ctx, apiService.GetName(), &metav1.DeleteOptions{})
 			return
 		}
 
 		_, err = a.lister.OperatorsV1alpha1().ClusterServiceVersionLister().ClusterServiceVersions(ns).Get(name)
 		if k8serrors.IsNotFound(err) {
 			logger.Debug("Deleting api service since owning CSV is not found")
-			syncError = a.opClient.DeleteAPIService(apiService.GetName(), &metav1.DeleteOptions{})
+			syncError = a.opClient.DeleteAPIService(ctx, apiService.GetName(), &metav1.DeleteOptions{})
 			return
 		} else if err != nil {
 			syncError = err
@@ -691,7 +822,7 @@ func (a *Operator) EnsureCSVMetric() error {
 	return nil
 }
 
-func (a *Operator) syncGCObject(obj interface{}) (syncError error) {
+func (a *Operator) syncGCObject(ctx context.Context, obj interface{}) (syncError error) {
 	metaObj, ok := obj.(metav1.Object)
 	if !ok {
 		a.logger.Warn("object sync: casting to metav1.Object failed")
@@ -717,7 +848,7 @@ func (a *Operator) syncGCObject(obj interface{}) (syncError error) {
 			}
 		}
 
-		if err := a.opClient.DeleteClusterRole(metaObj.GetName(), &metav1.DeleteOptions{}); err != nil {
+		if err := a.opClient.DeleteClusterRole(ctx, metaObj.GetName(), &metav1.DeleteOptions{}); err != nil {
 			logger.WithError(err).Warn("cannot delete cluster role")
 			break
 		}
@@ -735,7 +866,7 @@ func (a *Operator) syncGCObject(obj interface{}) (syncError error) {
 			}
 		}
 
-		if err := a.opClient.DeleteClusterRoleBinding(metaObj.GetName(), &metav1.DeleteOptions{}); err != nil {
+		if err := a.opClient.DeleteClusterRoleBinding(ctx, metaObj.GetName(), &metav1.DeleteOptions{}); err != nil {
 			logger.WithError(err).Warn("cannot delete cluster role binding")
 			break
 		}
@@ -754,7 +885,7 @@ func (a *Operator) syncGCObject(obj interface{}) (syncError error) {
 			}
 		}
 
-		if err := a.opClient.KubernetesInterface().AdmissionregistrationV1().MutatingWebhookConfigurations().Delete(context.TODO(), metaObj.GetName(), metav1.DeleteOptions{}); err != nil {
+		if err := a.opClient.KubernetesInterface().AdmissionregistrationV1().MutatingWebhookConfigurations().Delete(ctx, metaObj.GetName(), metav1.DeleteOptions{}); err != nil {
 			logger.WithError(err).Warn("cannot delete MutatingWebhookConfiguration")
 			break
 		}
@@ -773,7 +904,7 @@ func (a *Operator) syncGCObject(obj interface{}) (syncError error) {
 			}
 		}
 
-		if err := a.opClient.KubernetesInterface().AdmissionregistrationV1().ValidatingWebhookConfigurations().Delete(context.TODO(), metaObj.GetName(), metav1.DeleteOptions{}); err != nil {
+		if err := a.opClient.KubernetesInterface().AdmissionregistrationV1().ValidatingWebhookConfigurations().Delete(ctx, metaObj.GetName(), metav1.DeleteOptions{}); err != nil {
 			logger.WithError(err).Warn("cannot delete ValidatingWebhookConfiguration")
 			break
 		}
@@ -783,7 +914,7 @@ func (a *Operator) syncGCObject(obj interface{}) (syncError error) {
 	return
 }
 
-func (a *Operator) syncObject(obj interface{}) (syncError error) {
+func (a *Operator) syncObject(ctx context.Context, obj interface{}) (syncError error) {
 	// Assert as metav1.Object
 	metaObj, ok := obj.(metav1.Object)
 	if !ok {
@@ -846,7 +977,7 @@ func (a *Operator) syncObject(obj interface{}) (syncError error) {
 				continue
 			}
 			csv.SetPhase(v1alpha1.CSVPhasePending, v1alpha1.CSVReasonDetectedClusterChange, "Cluster resources changed state", a.now())
-			_, err := a.client.OperatorsV1alpha1().ClusterServiceVersions(csv.GetNamespace()).UpdateStatus(context.TODO(), csv, metav1.UpdateOptions{})
+			_, err := a.client.OperatorsV1alpha1().ClusterServiceVersions(csv.GetNamespace()).UpdateStatus(ctx, csv, metav1.UpdateOptions{})
 			if err != nil {
 				errs = append(errs, err)
 				continue
@@ -888,7 +1019,7 @@ func (a *Operator) namespaceAddedOrRemoved(obj interface{}) {
 	}
 }
 
-func (a *Operator) syncNamespace(obj interface{}) error {
+func (a *Operator) syncNamespace(ctx context.Context, obj interface{}) error {
 	// Check to see if any operator groups are associated with this namespace
 	namespace, ok := obj.(*corev1.Namespace)
 	if !ok {
@@ -913,11 +1044,12 @@ func (a *Operator) syncNamespace(obj interface{}) error {
 		return err
 	}
 
+	propagateLabels := a.namespaceFeatureIsEnabled(namespace, OperatorGroupLabelsNamespaceAnnotationKey, true)
 	for _, group := range operatorGroupList {
 		namespaceSet := NewNamespaceSet(group.Status.Namespaces)
 
-		// Apply the label if not an All Namespaces OperatorGroup.
-		if namespaceSet.Contains(namespace.GetName()) && !namespaceSet.IsAllNamespaces() {
+		// Apply the label if not an All Namespaces OperatorGroup, unless the namespace opted out.
+		if propagateLabels && namespaceSet.Contains(namespace.GetName()) && !namespaceSet.IsAllNamespaces() {
 			if namespace.Labels == nil {
 				namespace.Labels = make(map[string]string, 1)
 			}
@@ -930,12 +1062,12 @@ func (a *Operator) syncNamespace(obj interface{}) error {
 	}
 
 	// Update the Namespace
-	_, err = a.opClient.KubernetesInterface().CoreV1().Namespaces().Update(context.TODO(), namespace, metav1.UpdateOptions{})
+	_, err = a.opClient.KubernetesInterface().CoreV1().Namespaces().Update(ctx, namespace, metav1.UpdateOptions{})
 
 	return err
 }
 
-func (a *Operator) handleClusterServiceVersionDeletion(obj interface{}) {
+func (a *Operator) handleClusterServiceVersionDeletion(ctx context.Context, obj interface{}) {
 	clusterServiceVersion, ok := obj.(*v1alpha1.ClusterServiceVersion)
 	if !ok {
 		tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
@@ -963,6 +1095,10 @@ func (a *Operator) handleClusterServiceVersionDeletion(obj interface{}) {
 	})
 
 	metrics.DeleteCSVMetric(clusterServiceVersion)
+	if a.baselineSampler != nil {
+		a.baselineSampler.forget(clusterServiceVersion)
+	}
+	a.requirementProber.forget(clusterServiceVersion)
 
 	if clusterServiceVersion.IsCopied() {
 		logger.Warning("deleted csv is copied. skipping additional cleanup steps") // should not happen?
@@ -1012,7 +1148,7 @@ func (a *Operator) handleClusterServiceVersionDeletion(obj interface{}) {
 	logger.Info("gcing children")
 	namespaces := make([]string, 0)
 	if targetNamespaces == "" {
-		namespaceList, err := a.opClient.KubernetesInterface().CoreV1().Namespaces().List(context.TODO(), metav1.ListOptions{})
+		namespaceList, err := a.opClient.KubernetesInterface().CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
 		if err != nil {
 			logger.WithError(err).Warn("cannot list all namespaces to requeue child csvs for deletion")
 			return
@@ -1045,7 +1181,7 @@ func (a *Operator) handleClusterServiceVersionDeletion(obj interface{}) {
 		apiServiceLabels := fetched.GetLabels()
 		if clusterServiceVersion.GetName() == apiServiceLabels[ownerutil.OwnerKey] && clusterServiceVersion.GetNamespace() == apiServiceLabels[ownerutil.OwnerNamespaceKey] {
 			logger.Infof("gcing api service %v", apiServiceName)
-			err := a.opClient.DeleteAPIService(apiServiceName, &metav1.DeleteOptions{})
+			err := a.opClient.DeleteAPIService(ctx, apiServiceName, &metav1.DeleteOptions{})
 			if err != nil {
 				logger.WithError(err).Warn("cannot delete orphaned api service")
 			}
@@ -1073,8 +1209,16 @@ func (a *Operator) handleClusterServiceVersionDeletion(obj interface{}) {
 		}
 	}
 
+	if err := a.admissionPolicyClient.DeletePolicies(ctx, clusterServiceVersion); err != nil {
+		logger.WithError(err).Warn("cannot delete ValidatingAdmissionPolicies")
+	}
+
+	if err := a.kubeconfigClient.DeleteKubeconfigs(ctx, clusterServiceVersion); err != nil {
+		logger.WithError(err).Warn("cannot delete generated kubeconfigs")
+	}
+
 	webhookSelector := labels.SelectorFromSet(ownerutil.OwnerLabel(clusterServiceVersion, v1alpha1.ClusterServiceVersionKind)).String()
-	mWebhooks, err := a.opClient.KubernetesInterface().AdmissionregistrationV1().MutatingWebhookConfigurations().List(context.TODO(), metav1.ListOptions{LabelSelector: webhookSelector})
+	mWebhooks, err := a.opClient.KubernetesInterface().AdmissionregistrationV1().MutatingWebhookConfigurations().List(ctx, metav1.ListOptions{LabelSelector: webhookSelector})
 	if err != nil {
 		logger.WithError(err).Warn("cannot list MutatingWebhookConfigurations")
 	}
@@ -1085,7 +1229,7 @@ func (a *Operator) handleClusterServiceVersionDeletion(obj interface{}) {
 		}
 	}
 
-	vWebhooks, err := a.opClient.KubernetesInterface().AdmissionregistrationV1().ValidatingWebhookConfigurations().List(context.TODO(), metav1.ListOptions{LabelSelector: webhookSelector})
+	vWebhooks, err := a.opClient.KubernetesInterface().AdmissionregistrationV1().ValidatingWebhookConfigurations().List(ctx, metav1.ListOptions{LabelSelector: webhookSelector})
 	if err != nil {
 		logger.WithError(err).Warn("cannot list ValidatingWebhookConfigurations")
 	}
@@ -1097,7 +1241,7 @@ func (a *Operator) handleClusterServiceVersionDeletion(obj interface{}) {
 	}
 }
 
-func (a *Operator) removeDanglingChildCSVs(csv *v1alpha1.ClusterServiceVersion) error {
+func (a *Operator) removeDanglingChildCSVs(ctx context.Context, csv *v1alpha1.ClusterServiceVersion) error {
 	logger := a.logger.WithFields(logrus.Fields{
 		"id":          queueinformer.NewLoopID(),
 		"csv":         csv.GetName(),
@@ -1115,44 +1259,44 @@ func (a *Operator) removeDanglingChildCSVs(csv *v1alpha1.ClusterServiceVersion)
 	operatorNamespace, ok := csv.Annotations[v1.OperatorGroupNamespaceAnnotationKey]
 	if !ok {
 		logger.Debug("missing operator namespace annotation on copied CSV")
-		return a.deleteChild(csv, logger)
+		return a.deleteChild(ctx, csv, logger)
 	}
 
 	logger = logger.WithField("parentNamespace", operatorNamespace)
 	parent, err := a.lister.OperatorsV1alpha1().ClusterServiceVersionLister().ClusterServiceVersions(operatorNamespace).Get(csv.GetName())
 	if k8serrors.IsNotFound(err) || k8serrors.IsGone(err) || parent == nil {
 		logger.Debug("deleting copied CSV since parent is missing")
-		return a.deleteChild(csv, logger)
+		return a.deleteChild(ctx, csv, logger)
 	}
 
 	if parent.Status.Phase == v1alpha1.CSVPhaseFailed && parent.Status.Reason == v1alpha1.CSVReasonInterOperatorGroupOwnerConflict {
 		logger.Debug("deleting copied CSV since parent has intersecting operatorgroup conflict")
-		return a.deleteChild(csv, logger)
+		return a.deleteChild(ctx, csv, logger)
 	}
 
 	if annotations := parent.GetAnnotations(); annotations != nil {
 		if !NewNamespaceSetFromString(annotations[v1.OperatorGroupTargetsAnnotationKey]).Contains(csv.GetNamespace()) {
 			logger.WithField("parentTargets", annotations[v1.OperatorGroupTargetsAnnotationKey]).
 				Debug("deleting copied CSV since parent no longer lists this as a target namespace")
-			return a.deleteChild(csv, logger)
+			return a.deleteChild(ctx, csv, logger)
 		}
 	}
 
 	if parent.GetNamespace() == csv.GetNamespace() {
 		logger.Debug("deleting copied CSV since it has incorrect parent annotations")
-		return a.deleteChild(csv, logger)
+		return a.deleteChild(ctx, csv, logger)
 	}
 
 	return nil
 }
 
-func (a *Operator) deleteChild(csv *v1alpha1.ClusterServiceVersion, logger *logrus.Entry) error {
+func (a *Operator) deleteChild(ctx context.Context, csv *v1alpha1.ClusterServiceVersion, logger *logrus.Entry) error {
 	logger.Debug("gcing csv")
-	return a.client.OperatorsV1alpha1().ClusterServiceVersions(csv.GetNamespace()).Delete(context.TODO(), csv.GetName(), *metav1.NewDeleteOptions(0))
+	return a.client.OperatorsV1alpha1().ClusterServiceVersions(csv.GetNamespace()).Delete(ctx, csv.GetName(), *metav1.NewDeleteOptions(0))
 }
 
 // syncClusterServiceVersion is the method that gets called when we see a CSV event in the cluster
-func (a *Operator) syncClusterServiceVersion(obj interface{}) (syncError error) {
+func (a *Operator) syncClusterServiceVersion(ctx context.Context, obj interface{}) (syncError error) {
 	clusterServiceVersion, ok := obj.(*v1alpha1.ClusterServiceVersion)
 	if !ok {
 		a.logger.Debugf("wrong type: %#v", obj)
@@ -1176,7 +1320,30 @@ func (a *Operator) syncClusterServiceVersion(obj interface{}) (syncError error)
 		return
 	}
 
-	outCSV, syncError := a.transitionCSVState(*clusterServiceVersion)
+	if clusterServiceVersion.GetDeletionTimestamp() != nil && hasOperatorConditionsFinalizer(clusterServiceVersion) {
+		return a.handleBlockingConditions(ctx, logger, clusterServiceVersion)
+	}
+	if clusterServiceVersion.GetDeletionTimestamp() != nil && hasPreDeleteJobFinalizer(clusterServiceVersion) {
+		return a.handlePreDeleteJob(ctx, logger, clusterServiceVersion)
+	}
+	if updated, err := a.syncPreDeleteJobFinalizer(ctx, clusterServiceVersion); err != nil || updated {
+		// The update requeues the CSV
+		return err
+	}
+	if updated, err := a.syncOperatorConditions(ctx, logger, clusterServiceVersion); err != nil || updated {
+		// The update requeues the CSV
+		return err
+	}
+	if updated, err := a.syncUpgradeEdge(ctx, logger, clusterServiceVersion); err != nil || updated {
+		// The update requeues the CSV
+		return err
+	}
+
+	defer func(start time.Time) {
+		metrics.EmitCSVSyncDuration(clusterServiceVersion, time.Since(start))
+	}(time.Now())
+
+	outCSV, syncError := a.transitionCSVState(ctx, *clusterServiceVersion)
 
 	if outCSV == nil {
 		return
@@ -1188,7 +1355,7 @@ func (a *Operator) syncClusterServiceVersion(obj interface{}) (syncError error)
 		outCSV.Status.Reason == clusterServiceVersion.Status.Reason &&
 		outCSV.Status.Message == clusterServiceVersion.Status.Message) {
 		// Update CSV with status of transition. Log errors if we can't write them to the status.
-		_, err := a.client.OperatorsV1alpha1().ClusterServiceVersions(outCSV.GetNamespace()).UpdateStatus(context.TODO(), outCSV, metav1.UpdateOptions{})
+		_, err := a.client.OperatorsV1alpha1().ClusterServiceVersions(outCSV.GetNamespace()).UpdateStatus(ctx, outCSV, metav1.UpdateOptions{})
 		if err != nil {
 			updateErr := errors.New("error updating ClusterServiceVersion status: " + err.Error())
 			if syncError == nil {
@@ -1199,6 +1366,25 @@ func (a *Operator) syncClusterServiceVersion(obj interface{}) (syncError error)
 			}
 		} else {
 			metrics.EmitCSVMetric(clusterServiceVersion, outCSV)
+			if outCSV.Status.Phase != clusterServiceVersion.Status.Phase {
+				a.notifyPhaseHooks(ctx, logger, clusterServiceVersion, outCSV)
+			}
+		}
+	}
+
+	if outCSV.Status.Phase == v1alpha1.CSVPhaseSucceeded && outCSV.Spec.Replaces != "" {
+		if err := a.csvChainQueueSet.Requeue(outCSV.GetNamespace(), outCSV.GetName()); err != nil {
+			logger.WithError(err).Warn("unable to requeue")
+		}
+	}
+
+	a.recordResourceBaseline(ctx, logger, outCSV)
+	a.recordRequirementProbes(ctx, logger, outCSV)
+	a.recordRequirementDetails(ctx, logger, outCSV)
+
+	if outCSV.Status.Phase == v1alpha1.CSVPhaseSucceeded {
+		if err := a.deleteLegacyRBAC(ctx, outCSV); err != nil {
+			logger.WithError(err).Info("couldn't delete RBAC generated under legacy names")
 		}
 	}
 
@@ -1208,18 +1394,24 @@ func (a *Operator) syncClusterServiceVersion(obj interface{}) (syncError error)
 		return
 	}
 
+	if outCSV.Status.Phase == v1alpha1.CSVPhaseSucceeded {
+		if err := a.annotateOperands(ctx, logger, outCSV, operatorGroup); err != nil {
+			logger.WithError(err).Info("couldn't annotate operands with operator version")
+		}
+	}
+
 	if len(operatorGroup.Status.Namespaces) == 1 && operatorGroup.Status.Namespaces[0] == operatorGroup.GetNamespace() {
 		logger.Debug("skipping copy for OwnNamespace operatorgroup")
 		return
 	}
 	// Ensure operator has access to targetnamespaces with cluster RBAC
 	// (roles/rolebindings are checked for each target namespace in syncCopyCSV)
-	if err := a.ensureRBACInTargetNamespace(clusterServiceVersion, operatorGroup); err != nil {
+	if err := a.ensureRBACInTargetNamespace(ctx, clusterServiceVersion, operatorGroup); err != nil {
 		logger.WithError(err).Info("couldn't ensure RBAC in target namespaces")
 		syncError = err
 	}
 
-	if !outCSV.IsUncopiable() {
+	if !outCSV.IsUncopiable() && a.disabled.Enabled(controllers.CopiedCSVs) {
 		if err := a.csvCopyQueueSet.Requeue(outCSV.GetNamespace(), outCSV.GetName()); err != nil {
 			logger.WithError(err).Warn("unable to requeue")
 		}
@@ -1244,7 +1436,7 @@ func (a *Operator) allNamespaceOperatorGroups() ([]*v1.OperatorGroup, error) {
 	return result, nil
 }
 
-func (a *Operator) syncOLMConfig(obj interface{}) (syncError error) {
+func (a *Operator) syncOLMConfig(ctx context.Context, obj interface{}) (syncError error) {
 	a.logger.Info("Processing olmConfig")
 	olmConfig, ok := obj.(*v1.OLMConfig)
 	if !ok {
@@ -1264,6 +1456,10 @@ func (a *Operator) syncOLMConfig(obj interface{}) (syncError error) {
 
 	csvIsRequeued := false
 	for _, og := range allNSOperatorGroups {
+		// CSVs aren't copied, nor are their copies deleted, while the copied CSV controller is disabled
+		if !a.disabled.Enabled(controllers.CopiedCSVs) {
+			break
+		}
 		// Get all copied CSVs owned by this operatorGroup
 		copiedCSVRequirement, err := labels.NewRequirement(v1alpha1.CopiedLabelKey, selection.Equals, []string{og.GetNamespace()})
 		if err != nil {
@@ -1278,6 +1474,10 @@ func (a *Operator) syncOLMConfig(obj interface{}) (syncError error) {
 		// Filter to unique copies
 		uniqueCopiedCSVs := map[string]struct{}{}
 		for _, copiedCSV := range copiedCSVs {
+			// Namespaces opting in to copied CSVs are expected to hold copies while they're disabled cluster-wide
+			if !olmConfig.CopiedCSVsAreEnabled() && a.copiedCSVsAreEnabledIn(copiedCSV.GetNamespace(), false) {
+				continue
+			}
 			uniqueCopiedCSVs[copiedCSV.GetName()] = struct{}{}
 		}
 
@@ -1300,10 +1500,18 @@ func (a *Operator) syncOLMConfig(obj interface{}) (syncError error) {
 	}
 
 	// Update the olmConfig status if it has changed.
-	condition := getCopiedCSVsCondition(!olmConfig.CopiedCSVsAreEnabled(), csvIsRequeued)
-	if !isStatusConditionPresentAndAreTypeReasonMessageStatusEqual(olmConfig.Status.Conditions, condition) {
-		meta.SetStatusCondition(&olmConfig.Status.Conditions, condition)
-		if _, err := a.client.OperatorsV1().OLMConfigs().UpdateStatus(context.TODO(), olmConfig, metav1.UpdateOptions{}); err != nil {
+	changed := false
+	for _, condition := range []metav1.Condition{
+		getCopiedCSVsCondition(!olmConfig.CopiedCSVsAreEnabled(), csvIsRequeued),
+		controllers.Condition(DisabledControllersConditionType, a.disabled),
+	} {
+		if !isStatusConditionPresentAndAreTypeReasonMessageStatusEqual(olmConfig.Status.Conditions, condition) {
+			meta.SetStatusCondition(&olmConfig.Status.Conditions, condition)
+			changed = true
+		}
+	}
+	if changed {
+		if _, err := a.client.OperatorsV1().OLMConfigs().UpdateStatus(ctx, olmConfig, metav1.UpdateOptions{}); err != nil {
 			return err
 		}
 	}
@@ -1311,6 +1519,10 @@ func (a *Operator) syncOLMConfig(obj interface{}) (syncError error) {
 	return nil
 }
 
+// DisabledControllersConditionType is the type of the condition of the cluster OLMConfig reporting the optional
+// controllers of the olm-operator that are disabled.
+const DisabledControllersConditionType = "OLMControllersDisabled"
+
 func isStatusConditionPresentAndAreTypeReasonMessageStatusEqual(conditions []metav1.Condition, condition metav1.Condition) bool {
 	foundCondition := meta.FindStatusCondition(conditions, condition.Type)
 	if foundCondition == nil {
@@ -1329,7 +1541,7 @@ func getCopiedCSVsCondition(isDisabled, csvIsRequeued bool) metav1.Condition {
 		Status:             metav1.ConditionFalse,
 	}
 	if !isDisabled {
-		condition.Reason = "CopiedCSVsEnabled"
+		condition.Reason = string(reasons.OLMConfigCopiedCSVsEnabled)
 		condition.Message = "Copied CSVs are enabled and present across the cluster"
 		if csvIsRequeued {
 			condition.Message = "Copied CSVs are enabled and at least one copied CSVs is missing"
@@ -1338,26 +1550,26 @@ func getCopiedCSVsCondition(isDisabled, csvIsRequeued bool) metav1.Condition {
 	}
 
 	if csvIsRequeued {
-		condition.Reason = "CopiedCSVsFound"
+		condition.Reason = string(reasons.OLMConfigCopiedCSVsFound)
 		condition.Message = "Copied CSVs are disabled and at least one copied CSV was found for an operator installed in AllNamespace mode"
 		return condition
 	}
 
 	condition.Status = metav1.ConditionTrue
-	condition.Reason = "NoCopiedCSVsFound"
+	condition.Reason = string(reasons.OLMConfigNoCopiedCSVsFound)
 	condition.Message = "Copied CSVs are disabled and none were found for operators installed in AllNamespace mode"
 
 	return condition
 }
 
-func (a *Operator) syncCopyCSV(obj interface{}) (syncError error) {
+func (a *Operator) syncCopyCSV(ctx context.Context, obj interface{}) (syncError error) {
 	clusterServiceVersion, ok := obj.(*v1alpha1.ClusterServiceVersion)
 	if !ok {
 		a.logger.Debugf("wrong type: %#v", obj)
 		return fmt.Errorf("casting ClusterServiceVersion failed")
 	}
 
-	olmConfig, err := a.client.OperatorsV1().OLMConfigs().Get(context.TODO(), "cluster", metav1.GetOptions{})
+	olmConfig, err := a.client.OperatorsV1().OLMConfigs().Get(ctx, "cluster", metav1.GetOptions{})
 	if err != nil && !k8serrors.IsNotFound(err) {
 		return err
 	}
@@ -1387,47 +1599,49 @@ func (a *Operator) syncCopyCSV(obj interface{}) (syncError error) {
 		"targetNamespaces": strings.Join(operatorGroup.Status.Namespaces, ","),
 	}).Debug("copying csv to targets")
 
-	copiedCSVsAreEnabled, err := a.copiedCSVsAreEnabled()
+	copiedCSVsAreEnabled, err := a.copiedCSVsAreEnabled(ctx)
 	if err != nil {
 		return err
 	}
+	slimCopies := olmConfig != nil && olmConfig.GetAnnotations()[SlimCopiedCSVsAnnotationKey] == "true"
 
-	// Check if we need to do any copying / annotation for the operatorgroup
-	namespaceSet := NewNamespaceSet(operatorGroup.Status.Namespaces)
-	if copiedCSVsAreEnabled || !namespaceSet.IsAllNamespaces() {
-		if err := a.ensureCSVsInNamespaces(clusterServiceVersion, operatorGroup, namespaceSet); err != nil {
-			logger.WithError(err).Info("couldn't copy CSV to target namespaces")
-			syncError = err
-		}
-
-		// If the CSV was installed in AllNamespace mode, remove any "CSV Copying Disabled" events
-		// in which the related object's name, namespace, and uid match the given CSV's.
-		if namespaceSet.IsAllNamespaces() {
-			if err := a.deleteCSVCopyingDisabledEvent(clusterServiceVersion); err != nil {
-				return err
-			}
-		}
-		return
-	}
-
-	requirement, err := labels.NewRequirement(v1alpha1.CopiedLabelKey, selection.Equals, []string{clusterServiceVersion.Namespace})
+	// An upgrade is copied to the canary namespaces of the operatorgroup first, if it has any
+	canaries, err := a.syncStagedUpgrade(ctx, logger, clusterServiceVersion, operatorGroup)
 	if err != nil {
 		return err
 	}
 
-	copiedCSVs, err := a.copiedCSVLister.List(labels.NewSelector().Add(*requirement))
+	// Copies are made according to the cluster-wide setting, unless the target namespace overrides it
+	namespaceSet := NewNamespaceSet(operatorGroup.Status.Namespaces)
+	failures, err := a.ensureCSVsInNamespaces(ctx, clusterServiceVersion, operatorGroup, namespaceSet, canaries, copiedCSVsAreEnabled, slimCopies)
 	if err != nil {
-		return err
+		logger.WithError(err).Info("couldn't copy CSV to target namespaces")
+		syncError = err
+	}
+	// failures are unknown if the namespaces couldn't be listed
+	if failures != nil {
+		if err := a.syncCopyFailures(ctx, clusterServiceVersion, failures); err != nil {
+			logger.WithError(err).Info("couldn't record copy failures")
+		}
+	}
+	if syncError == nil && len(failures) > 0 {
+		// retried with backoff, rather than on the next resync or in a tight loop
+		syncError = copyFailedError{namespaces: len(failures)}
+	}
+	if !namespaceSet.IsAllNamespaces() {
+		return
 	}
 
-	for _, copiedCSV := range copiedCSVs {
-		err := a.client.OperatorsV1alpha1().ClusterServiceVersions(copiedCSV.Namespace).Delete(context.TODO(), copiedCSV.Name, metav1.DeleteOptions{})
-		if err != nil && !k8serrors.IsNotFound(err) {
+	// If the CSV was installed in AllNamespace mode, remove any "CSV Copying Disabled" events
+	// in which the related object's name, namespace, and uid match the given CSV's.
+	if copiedCSVsAreEnabled {
+		if err := a.deleteCSVCopyingDisabledEvent(ctx, clusterServiceVersion); err != nil {
 			return err
 		}
+		return
 	}
 
-	if err := a.createCSVCopyingDisabledEvent(clusterServiceVersion); err != nil {
+	if err := a.createCSVCopyingDisabledEvent(ctx, clusterServiceVersion); err != nil {
 		return err
 	}
 
@@ -1446,8 +1660,8 @@ func (a *Operator) syncCopyCSV(obj interface{}) (syncError error) {
 //
 // If the "cluster" olmConfig resource is not found, true will be returned
 // without an error.
-func (a *Operator) copiedCSVsAreEnabled() (bool, error) {
-	olmConfig, err := a.client.OperatorsV1().OLMConfigs().Get(context.TODO(), "cluster", metav1.GetOptions{})
+func (a *Operator) copiedCSVsAreEnabled(ctx context.Context) (bool, error) {
+	olmConfig, err := a.client.OperatorsV1().OLMConfigs().Get(ctx, "cluster", metav1.GetOptions{})
 	if err != nil {
 		// Default to true if olmConfig singleton cannot be found
 		if k8serrors.IsNotFound(err) {
@@ -1461,13 +1675,67 @@ func (a *Operator) copiedCSVsAreEnabled() (bool, error) {
 	return olmConfig.CopiedCSVsAreEnabled(), nil
 }
 
-func (a *Operator) getCopiedCSVDisabledEventsForCSV(csv *v1alpha1.ClusterServiceVersion) ([]corev1.Event, error) {
+// olmConfigAnnotations returns the annotations of the cluster OLMConfig, or nil if it can't be found.
+func (a *Operator) olmConfigAnnotations() map[string]string {
+	olmConfig, err := a.olmConfigLister.Get("cluster")
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			a.logger.WithError(err).Warn("unable to get olmConfig, using defaults")
+		}
+		return nil
+	}
+	return olmConfig.GetAnnotations()
+}
+
+// clusterCertLifetime returns the lifetime of generated certs set on the cluster OLMConfig, which CSVs may override.
+// Invalid overrides set on the OLMConfig are ignored.
+func (a *Operator) clusterCertLifetime() install.CertLifetime {
+	lifetime, err := install.CertLifetimeFromAnnotations(install.DefaultCertLifetime(), a.olmConfigAnnotations())
+	if err != nil {
+		a.logger.WithError(err).Warn("ignoring invalid cert lifetime set on olmConfig")
+	}
+	return lifetime
+}
+
+// validCertLifetime returns an error if the cert lifetime overrides set on the given CSV are invalid over the cluster
+// cert lifetime, which is the lifetime certLifetimeFor merges them over.
+func (a *Operator) validCertLifetime(csv *v1alpha1.ClusterServiceVersion) error {
+	_, err := install.CertLifetimeFromAnnotations(a.clusterCertLifetime(), csv.GetAnnotations())
+	return err
+}
+
+// certLifetimeFor returns the lifetime of the certs generated for the given CSV. Overrides set on the CSV take
+// precedence over those set on the cluster OLMConfig. CSVs with invalid overrides fail before they're installed, so
+// the cluster cert lifetime is only used here if the OLMConfig changed since.
+func (a *Operator) certLifetimeFor(owner ownerutil.Owner) install.CertLifetime {
+	lifetime, err := install.CertLifetimeFromAnnotations(a.clusterCertLifetime(), owner.GetAnnotations())
+	if err != nil {
+		a.logger.WithError(err).WithField("csv", owner.GetName()).Warn("ignoring invalid cert lifetime set on csv")
+	}
+	return lifetime
+}
+
+// certManagerIssuerFor returns the cert-manager issuer requested by the given CSV, or nil if cert-manager integration
+// isn't enabled on the cluster OLMConfig.
+func (a *Operator) certManagerIssuerFor(owner ownerutil.Owner) *install.CertManagerIssuer {
+	issuer, err := install.CertManagerIssuerFor(owner)
+	if err != nil || issuer == nil {
+		return nil
+	}
+	if a.olmConfigAnnotations()[install.CertManagerEnabledAnnotationKey] != "true" {
+		a.logger.WithField("csv", owner.GetName()).Debug("cert-manager integration disabled, ignoring requested issuer")
+		return nil
+	}
+	return issuer
+}
+
+func (a *Operator) getCopiedCSVDisabledEventsForCSV(ctx context.Context, csv *v1alpha1.ClusterServiceVersion) ([]corev1.Event, error) {
 	result := []corev1.Event{}
 	if csv == nil {
 		return result, nil
 	}
 
-	events, err := a.opClient.KubernetesInterface().CoreV1().Events(csv.GetNamespace()).List(context.TODO(), metav1.ListOptions{})
+	events, err := a.opClient.KubernetesInterface().CoreV1().Events(csv.GetNamespace()).List(ctx, metav1.ListOptions{})
 	if err != nil {
 		return nil, err
 	}
@@ -1484,19 +1752,19 @@ func (a *Operator) getCopiedCSVDisabledEventsForCSV(csv *v1alpha1.ClusterService
 	return result, nil
 }
 
-func (a *Operator) deleteCSVCopyingDisabledEvent(csv *v1alpha1.ClusterServiceVersion) error {
-	events, err := a.getCopiedCSVDisabledEventsForCSV(csv)
+func (a *Operator) deleteCSVCopyingDisabledEvent(ctx context.Context, csv *v1alpha1.ClusterServiceVersion) error {
+	events, err := a.getCopiedCSVDisabledEventsForCSV(ctx, csv)
 	if err != nil {
 		return err
 	}
 
 	// Remove existing events.
-	return a.deleteEvents(events)
+	return a.deleteEvents(ctx, events)
 }
 
-func (a *Operator) deleteEvents(events []corev1.Event) error {
+func (a *Operator) deleteEvents(ctx context.Context, events []corev1.Event) error {
 	for _, event := range events {
-		err := a.opClient.KubernetesInterface().EventsV1().Events(event.GetNamespace()).Delete(context.TODO(), event.GetName(), metav1.DeleteOptions{})
+		err := a.opClient.KubernetesInterface().EventsV1().Events(event.GetNamespace()).Delete(ctx, event.GetName(), metav1.DeleteOptions{})
 		if err != nil && !k8serrors.IsNotFound(err) {
 			return err
 		}
@@ -1504,8 +1772,8 @@ func (a *Operator) deleteEvents(events []corev1.Event) error {
 	return nil
 }
 
-func (a *Operator) createCSVCopyingDisabledEvent(csv *v1alpha1.ClusterServiceVersion) error {
-	events, err := a.getCopiedCSVDisabledEventsForCSV(csv)
+func (a *Operator) createCSVCopyingDisabledEvent(ctx context.Context, csv *v1alpha1.ClusterServiceVersion) error {
+	events, err := a.getCopiedCSVDisabledEventsForCSV(ctx, csv)
 	if err != nil {
 		return err
 	}
@@ -1516,7 +1784,7 @@ func (a *Operator) createCSVCopyingDisabledEvent(csv *v1alpha1.ClusterServiceVer
 
 	// Remove existing events.
 	if len(events) > 1 {
-		if err := a.deleteEvents(events); err != nil {
+		if err := a.deleteEvents(ctx, events); err != nil {
 			return err
 		}
 	}
@@ -1526,14 +1794,14 @@ func (a *Operator) createCSVCopyingDisabledEvent(csv *v1alpha1.ClusterServiceVer
 	return nil
 }
 
-func (a *Operator) syncGcCsv(obj interface{}) (syncError error) {
+func (a *Operator) syncGcCsv(ctx context.Context, obj interface{}) (syncError error) {
 	clusterServiceVersion, ok := obj.(*v1alpha1.ClusterServiceVersion)
 	if !ok {
 		a.logger.Debugf("wrong type: %#v", obj)
 		return fmt.Errorf("casting ClusterServiceVersion failed")
 	}
 	if clusterServiceVersion.IsCopied() {
-		syncError = a.removeDanglingChildCSVs(clusterServiceVersion)
+		syncError = a.removeDanglingChildCSVs(ctx, clusterServiceVersion)
 		return
 	}
 	return
@@ -1589,7 +1857,7 @@ func (a *Operator) operatorGroupFromAnnotations(logger *logrus.Entry, csv *v1alp
 	return operatorGroup
 }
 
-func (a *Operator) operatorGroupForCSV(csv *v1alpha1.ClusterServiceVersion, logger *logrus.Entry) (*v1.OperatorGroup, error) {
+func (a *Operator) operatorGroupForCSV(ctx context.Context, csv *v1alpha1.ClusterServiceVersion, logger *logrus.Entry) (*v1.OperatorGroup, error) {
 	now := a.now()
 
 	// Attempt to associate an OperatorGroup with the CSV.
@@ -1611,7 +1879,7 @@ func (a *Operator) operatorGroupForCSV(csv *v1alpha1.ClusterServiceVersion, logg
 		logger = logger.WithField("opgroup", operatorGroup.GetName())
 		if a.operatorGroupAnnotationsDiffer(&csv.ObjectMeta, operatorGroup) {
 			a.setOperatorGroupAnnotations(&csv.ObjectMeta, operatorGroup, true)
-			if _, err := a.client.OperatorsV1alpha1().ClusterServiceVersions(csv.GetNamespace()).Update(context.TODO(), csv, metav1.UpdateOptions{}); err != nil {
+			if _, err := a.client.OperatorsV1alpha1().ClusterServiceVersions(csv.GetNamespace()).Update(ctx, csv, metav1.UpdateOptions{}); err != nil {
 				logger.WithError(err).Warn("error adding operatorgroup annotations")
 				return nil, err
 			}
@@ -1640,7 +1908,7 @@ func (a *Operator) operatorGroupForCSV(csv *v1alpha1.ClusterServiceVersion, logg
 
 // transitionCSVState moves the CSV status state machine along based on the current value and the current cluster state.
 // SyncError should be returned when an additional reconcile of the CSV might fix the issue.
-func (a *Operator) transitionCSVState(in v1alpha1.ClusterServiceVersion) (out *v1alpha1.ClusterServiceVersion, syncError error) {
+func (a *Operator) transitionCSVState(ctx context.Context, in v1alpha1.ClusterServiceVersion) (out *v1alpha1.ClusterServiceVersion, syncError error) {
 	logger := a.logger.WithFields(logrus.Fields{
 		"id":        queueinformer.NewLoopID(),
 		"csv":       in.GetName(),
@@ -1668,7 +1936,7 @@ func (a *Operator) transitionCSVState(in v1alpha1.ClusterServiceVersion) (out *v
 	if labelSets, err := a.apiLabeler.LabelSetsFor(operatorSurface); err != nil {
 		logger.WithError(err).Warn("couldn't create label set")
 	} else if len(labelSets) > 0 {
-		updated, err := a.ensureLabels(out, labelSets...)
+		updated, err := a.ensureLabels(ctx, out, labelSets...)
 		if err != nil {
 			logger.WithError(err).Warn("issue ensuring csv api labels")
 			syncError = err
@@ -1679,7 +1947,7 @@ func (a *Operator) transitionCSVState(in v1alpha1.ClusterServiceVersion) (out *v
 	}
 
 	// Verify CSV operatorgroup (and update annotations if needed)
-	operatorGroup, err := a.operatorGroupForCSV(out, logger)
+	operatorGroup, err := a.operatorGroupForCSV(ctx, out, logger)
 	if operatorGroup == nil {
 		// when err is nil, we still want to exit, but we don't want to re-add the csv ratelimited to the queue
 		syncError = err
@@ -1733,7 +2001,7 @@ func (a *Operator) transitionCSVState(in v1alpha1.ClusterServiceVersion) (out *v
 		if out.Status.Reason != v1alpha1.CSVReasonInterOperatorGroupOwnerConflict {
 			logger.WithField("apis", providedAPIs).Warn("cannot modify provided apis of static provided api operatorgroup")
 			out.SetPhaseWithEvent(v1alpha1.CSVPhaseFailed, v1alpha1.CSVReasonCannotModifyStaticOperatorGroupProvidedAPIs, "static provided api operatorgroup cannot be modified by these apis", now, a.recorder)
-			a.cleanupCSVDeployments(logger, out)
+			a.cleanupCSVDeployments(ctx, logger, out)
 		}
 		return
 	case result == APIConflict:
@@ -1741,7 +2009,7 @@ func (a *Operator) transitionCSVState(in v1alpha1.ClusterServiceVersion) (out *v
 		if out.Status.Reason != v1alpha1.CSVReasonInterOperatorGroupOwnerConflict {
 			logger.WithField("apis", providedAPIs).Warn("intersecting operatorgroups provide the same apis")
 			out.SetPhaseWithEvent(v1alpha1.CSVPhaseFailed, v1alpha1.CSVReasonInterOperatorGroupOwnerConflict, "intersecting operatorgroups provide the same apis", now, a.recorder)
-			a.cleanupCSVDeployments(logger, out)
+			a.cleanupCSVDeployments(ctx, logger, out)
 		}
 		return
 	case result == AddAPIs:
@@ -1760,7 +2028,7 @@ func (a *Operator) transitionCSVState(in v1alpha1.ClusterServiceVersion) (out *v
 		}
 		unionedAnnotations[v1.OperatorGroupProvidedAPIsAnnotationKey] = union.String()
 		operatorGroup.SetAnnotations(unionedAnnotations)
-		if _, err := a.client.OperatorsV1().OperatorGroups(operatorGroup.GetNamespace()).Update(context.TODO(), operatorGroup, metav1.UpdateOptions{}); err != nil && !k8serrors.IsNotFound(err) {
+		if _, err := a.client.OperatorsV1().OperatorGroups(operatorGroup.GetNamespace()).Update(ctx, operatorGroup, metav1.UpdateOptions{}); err != nil && !k8serrors.IsNotFound(err) {
 			syncError = fmt.Errorf("could not update operatorgroups %s annotation: %v", v1.OperatorGroupProvidedAPIsAnnotationKey, err)
 		}
 		if err := a.csvQueueSet.Requeue(out.GetNamespace(), out.GetName()); err != nil {
@@ -1774,7 +2042,7 @@ func (a *Operator) transitionCSVState(in v1alpha1.ClusterServiceVersion) (out *v
 		if diffedAnnotations := operatorGroup.GetAnnotations(); diffedAnnotations != nil {
 			diffedAnnotations[v1.OperatorGroupProvidedAPIsAnnotationKey] = difference.String()
 			operatorGroup.SetAnnotations(diffedAnnotations)
-			if _, err := a.client.OperatorsV1().OperatorGroups(operatorGroup.GetNamespace()).Update(context.TODO(), operatorGroup, metav1.UpdateOptions{}); err != nil && !k8serrors.IsNotFound(err) {
+			if _, err := a.client.OperatorsV1().OperatorGroups(operatorGroup.GetNamespace()).Update(ctx, operatorGroup, metav1.UpdateOptions{}); err != nil && !k8serrors.IsNotFound(err) {
 				syncError = fmt.Errorf("could not update operatorgroups %s annotation: %v", v1.OperatorGroupProvidedAPIsAnnotationKey, err)
 			}
 		}
@@ -1788,9 +2056,38 @@ func (a *Operator) transitionCSVState(in v1alpha1.ClusterServiceVersion) (out *v
 
 	switch out.Status.Phase {
 	case v1alpha1.CSVPhaseNone:
+		missing, err := a.replacedCSVMissing(ctx, out)
+		if err != nil {
+			syncError = err
+			return
+		}
+		if missing {
+			// Note the missing predecessor in the conditions, whether the CSV is installed regardless or not
+			logger.WithField("replaces", out.Spec.Replaces).Info("replaced ClusterServiceVersion not found")
+			if a.missingReplacedCSVPolicy() == MissingReplacedCSVPolicyBlock {
+				out.SetPhaseWithEvent(v1alpha1.CSVPhasePending, CSVReasonReplacedCSVNotFound, fmt.Sprintf("replaced csv %s not found, waiting for it to exist", out.Spec.Replaces), now, a.recorder)
+			} else {
+				out.SetPhaseWithEvent(v1alpha1.CSVPhasePending, CSVReasonReplacedCSVNotFound, fmt.Sprintf("replaced csv %s not found, installing without replacing it", out.Spec.Replaces), now, a.recorder)
+			}
+			return
+		}
 		logger.Info("scheduling ClusterServiceVersion for requirement verification")
 		out.SetPhaseWithEvent(v1alpha1.CSVPhasePending, v1alpha1.CSVReasonRequirementsUnknown, "requirements not yet checked", now, a.recorder)
 	case v1alpha1.CSVPhasePending:
+		// Hold the install until the replaced CSV exists if the cluster requires it
+		if out.Spec.Replaces != "" && a.missingReplacedCSVPolicy() == MissingReplacedCSVPolicyBlock {
+			missing, err := a.replacedCSVMissing(ctx, out)
+			if err != nil {
+				syncError = err
+				return
+			}
+			if missing {
+				out.SetPhaseWithEventIfChanged(v1alpha1.CSVPhasePending, CSVReasonReplacedCSVNotFound, fmt.Sprintf("replaced csv %s not found, waiting for it to exist", out.Spec.Replaces), now, a.recorder)
+				syncError = ErrReplacedCSVNotFound
+				return
+			}
+		}
+
 		// Check previous version's Upgradeable condition
 		replacedCSV := a.isReplacing(out)
 		if replacedCSV != nil {
@@ -1799,8 +2096,14 @@ func (a *Operator) transitionCSVState(in v1alpha1.ClusterServiceVersion) (out *v
 				out.SetPhaseWithEventIfChanged(v1alpha1.CSVPhasePending, v1alpha1.CSVReasonOperatorConditionNotUpgradeable, fmt.Sprintf("operator is not upgradeable: %s", condErr), now, a.recorder)
 				return
 			}
+
+			// Give the replaced operator a chance to finish what it's doing before its deployments are replaced
+			if held, err := a.waitForUpgradeDisruption(ctx, out, replacedCSV, now); err != nil || held {
+				syncError = err
+				return
+			}
 		}
-		met, statuses, err := a.requirementAndPermissionStatus(out)
+		met, statuses, err := a.requirementAndPermissionStatus(ctx, out)
 		if err != nil {
 			// TODO: account for Bad Rule as well
 			logger.Info("invalid install strategy")
@@ -1821,10 +2124,30 @@ func (a *Operator) transitionCSVState(in v1alpha1.ClusterServiceVersion) (out *v
 		if !met {
 			logger.Info("requirements were not met")
 			out.SetPhaseWithEventIfChanged(v1alpha1.CSVPhasePending, v1alpha1.CSVReasonRequirementsNotMet, "one or more requirements couldn't be found", now, a.recorder)
+			if interval := a.requirementsRequeueInterval(); interval > 0 {
+				if err := a.csvQueueSet.RequeueAfter(out.GetNamespace(), out.GetName(), interval); err != nil {
+					logger.WithError(err).Warn("unable to requeue CSV with unmet requirements")
+					syncError = ErrRequirementsNotMet
+				}
+				return
+			}
 			syncError = ErrRequirementsNotMet
 			return
 		}
 
+		// Hold the install while it can't coexist with the instances installed in other namespaces
+		conflicts, err := a.checkMultiInstanceConflicts(out)
+		if err != nil {
+			syncError = err
+			return
+		}
+		if len(conflicts) > 0 {
+			logger.WithField("conflicts", conflicts).Info("conflicts with instances in other namespaces")
+			out.SetPhaseWithEventIfChanged(v1alpha1.CSVPhasePending, CSVReasonMultiInstanceConflict, strings.Join(conflicts, "; "), now, a.recorder)
+			syncError = ErrMultiInstanceConflict
+			return
+		}
+
 		// Create a map to track unique names
 		webhookNames := map[string]struct{}{}
 		// Check if Webhooks have valid rules and unique names
@@ -1843,6 +2166,63 @@ func (a *Operator) transitionCSVState(in v1alpha1.ClusterServiceVersion) (out *v
 				return
 			}
 		}
+		scopes, err := install.WebhookScopes(out)
+		if err == nil {
+			err = install.ValidWebhookScopes(scopes, out.Spec.WebhookDefinitions)
+		}
+		if err != nil {
+			logger.WithError(err).Warn("CSV contains invalid webhook matchConditions or namespace selectors")
+			out.SetPhaseWithEventIfChanged(v1alpha1.CSVPhaseFailed, v1alpha1.CSVReasonInvalidWebhookDescription, err.Error(), now, a.recorder)
+			return
+		}
+		if err = install.ValidConversionWebhooks(out); err != nil {
+			logger.WithError(err).Warn("CSV contains invalid conversion webhooks")
+			out.SetPhaseWithEventIfChanged(v1alpha1.CSVPhaseFailed, v1alpha1.CSVReasonInvalidWebhookDescription, err.Error(), now, a.recorder)
+			return
+		}
+
+		// Check if ValidatingAdmissionPolicies are well formed and have valid rules
+		policyDescs, err := install.ValidatingAdmissionPolicyDescriptions(out)
+		if err == nil {
+			err = install.ValidAdmissionPolicyDescriptions(policyDescs)
+		}
+		if err != nil {
+			logger.WithError(err).Warn("CSV contains invalid ValidatingAdmissionPolicy descriptions")
+			out.SetPhaseWithEventIfChanged(v1alpha1.CSVPhaseFailed, v1alpha1.ConditionReason(reasons.CSVInvalidAdmissionPolicyDescription), err.Error(), now, a.recorder)
+			return
+		}
+
+		// Check if the requested kubeconfigs are well formed and within the operator's permissions
+		kubeconfigDescs, err := install.KubeconfigDescriptions(out)
+		if err == nil {
+			err = install.ValidKubeconfigDescriptions(out, kubeconfigDescs)
+		}
+		if err != nil {
+			logger.WithError(err).Warn("CSV contains invalid kubeconfig descriptions")
+			out.SetPhaseWithEventIfChanged(v1alpha1.CSVPhaseFailed, v1alpha1.ConditionReason(reasons.CSVInvalidKubeconfigDescription), err.Error(), now, a.recorder)
+			return
+		}
+
+		// Check if overrides of the generated certs' lifetime are valid
+		if err := a.validCertLifetime(out); err != nil {
+			logger.WithError(err).Warn("CSV contains an invalid cert lifetime")
+			out.SetPhaseWithEventIfChanged(v1alpha1.CSVPhaseFailed, v1alpha1.ConditionReason(reasons.CSVInvalidCertLifetime), err.Error(), now, a.recorder)
+			return
+		}
+
+		// Check if the requested cert-manager issuer is well formed
+		if _, err := install.CertManagerIssuerFor(out); err != nil {
+			logger.WithError(err).Warn("CSV requests an invalid cert-manager issuer")
+			out.SetPhaseWithEventIfChanged(v1alpha1.CSVPhaseFailed, v1alpha1.ConditionReason(reasons.CSVInvalidCertManagerIssuer), err.Error(), now, a.recorder)
+			return
+		}
+
+		// Check if the CA bundle of an operator managing its own serving certs is valid
+		if _, _, err := install.SelfManagedCABundle(out); err != nil {
+			logger.WithError(err).Warn("CSV provides an invalid self-managed CA bundle")
+			out.SetPhaseWithEventIfChanged(v1alpha1.CSVPhaseFailed, v1alpha1.ConditionReason(reasons.CSVInvalidSelfManagedCABundle), err.Error(), now, a.recorder)
+			return
+		}
 
 		// Check for CRD ownership conflicts
 		if syncError = a.crdOwnerConflicts(out, a.csvSet(out.GetNamespace(), v1alpha1.CSVPhaseAny)); syncError != nil {
@@ -1876,7 +2256,14 @@ func (a *Operator) transitionCSVState(in v1alpha1.ClusterServiceVersion) (out *v
 			return
 		}
 
-		if syncError = installer.Install(strategy); syncError != nil {
+		// The cluster cert lifetime may have changed since the CSV's overrides were checked
+		if err := a.validCertLifetime(out); err != nil {
+			logger.WithError(err).Warn("CSV contains an invalid cert lifetime")
+			out.SetPhaseWithEventIfChanged(v1alpha1.CSVPhaseFailed, v1alpha1.ConditionReason(reasons.CSVInvalidCertLifetime), err.Error(), now, a.recorder)
+			return
+		}
+
+		if syncError = installer.Install(ctx, strategy); syncError != nil {
 			if install.IsErrorUnrecoverable(syncError) {
 				logger.Infof("Setting CSV reason to failed without retry: %v", syncError)
 				out.SetPhaseWithEvent(v1alpha1.CSVPhaseFailed, v1alpha1.CSVReasonComponentFailedNoRetry, fmt.Sprintf("install strategy failed: %s", syncError), now, a.recorder)
@@ -1886,11 +2273,13 @@ func (a *Operator) transitionCSVState(in v1alpha1.ClusterServiceVersion) (out *v
 			return
 		}
 
-		if out.HasCAResources() {
+		if _, selfManaged, _ := install.SelfManagedCABundle(out); selfManaged {
+			// Rotation is up to the operator itself
+			out.Status.CertsLastUpdated = nil
+			out.Status.CertsRotateAt = nil
+		} else if out.HasCAResources() {
 			now := metav1.Now()
-			expiration := now.Add(install.DefaultCertValidFor)
-			rotateAt := expiration.Add(-1 * install.DefaultCertMinFresh)
-			rotateTime := metav1.NewTime(rotateAt)
+			rotateTime := metav1.NewTime(a.certLifetimeFor(out).RotateAt(now.Time))
 			out.Status.CertsLastUpdated = &now
 			out.Status.CertsRotateAt = &rotateTime
 		}
@@ -1908,13 +2297,13 @@ func (a *Operator) transitionCSVState(in v1alpha1.ClusterServiceVersion) (out *v
 			return
 		}
 
-		strategy, err := a.updateDeploymentSpecsWithAPIServiceData(out, strategy)
+		strategy, err := a.updateDeploymentSpecsWithAPIServiceData(ctx, out, strategy)
 		if err != nil {
 			logger.WithError(err).Debug("Unable to calculate expected deployment")
 			out.SetPhaseWithEvent(v1alpha1.CSVPhasePending, v1alpha1.CSVReasonNeedsReinstall, "calculated deployment install is bad", now, a.recorder)
 			return
 		}
-		if installErr := a.updateInstallStatus(out, installer, strategy, v1alpha1.CSVPhaseInstalling, v1alpha1.CSVReasonWaiting); installErr != nil {
+		if installErr := a.updateInstallStatus(ctx, out, installer, strategy, v1alpha1.CSVPhaseInstalling, v1alpha1.CSVReasonWaiting); installErr != nil {
 			// Re-sync if kube-apiserver was unavailable
 			if k8serrors.IsServiceUnavailable(installErr) {
 				logger.WithError(installErr).Info("could not update install status")
@@ -1957,7 +2346,7 @@ func (a *Operator) transitionCSVState(in v1alpha1.ClusterServiceVersion) (out *v
 		}
 
 		// Ensure requirements are still present
-		met, statuses, err := a.requirementAndPermissionStatus(out)
+		met, statuses, err := a.requirementAndPermissionStatus(ctx, out)
 		if err != nil {
 			logger.Info("invalid install strategy")
 			out.SetPhaseWithEvent(v1alpha1.CSVPhaseFailed, v1alpha1.CSVReasonInvalidStrategy, fmt.Sprintf("install strategy invalid: %s", err.Error()), now, a.recorder)
@@ -1970,13 +2359,13 @@ func (a *Operator) transitionCSVState(in v1alpha1.ClusterServiceVersion) (out *v
 		}
 
 		// Check install status
-		strategy, err = a.updateDeploymentSpecsWithAPIServiceData(out, strategy)
+		strategy, err = a.updateDeploymentSpecsWithAPIServiceData(ctx, out, strategy)
 		if err != nil {
 			logger.WithError(err).Debug("Unable to calculate expected deployment")
 			out.SetPhaseWithEvent(v1alpha1.CSVPhasePending, v1alpha1.CSVReasonNeedsReinstall, "calculated deployment install is bad", now, a.recorder)
 			return
 		}
-		if installErr := a.updateInstallStatus(out, installer, strategy, v1alpha1.CSVPhaseFailed, v1alpha1.CSVReasonComponentUnhealthy); installErr != nil {
+		if installErr := a.updateInstallStatus(ctx, out, installer, strategy, v1alpha1.CSVPhaseFailed, v1alpha1.CSVReasonComponentUnhealthy); installErr != nil {
 			// Re-sync if kube-apiserver was unavailable
 			if k8serrors.IsServiceUnavailable(installErr) {
 				logger.WithError(installErr).Info("could not update install status")
@@ -1987,8 +2376,14 @@ func (a *Operator) transitionCSVState(in v1alpha1.ClusterServiceVersion) (out *v
 			return
 		}
 
-		// Ensure cluster roles exist for using provided apis
-		if err := a.ensureClusterRolesForCSV(out); err != nil {
+		// Ensure cluster roles exist for using provided apis, unless the operatorgroup manages them itself
+		if roleAggregationDisabled(operatorGroup) {
+			if err := a.deleteClusterRolesForCSV(ctx, out); err != nil {
+				logger.WithError(err).Info("couldn't delete clusterroles for provided api types")
+				syncError = err
+				return
+			}
+		} else if err := a.ensureClusterRolesForCSV(ctx, out); err != nil {
 			logger.WithError(err).Info("couldn't ensure clusterroles for provided api types")
 			syncError = err
 			return
@@ -2028,7 +2423,7 @@ func (a *Operator) transitionCSVState(in v1alpha1.ClusterServiceVersion) (out *v
 		}
 
 		// Check if requirements exist
-		met, statuses, err := a.requirementAndPermissionStatus(out)
+		met, statuses, err := a.requirementAndPermissionStatus(ctx, out)
 		if err != nil && out.Status.Reason != v1alpha1.CSVReasonInvalidStrategy {
 			logger.Warn("invalid install strategy")
 			out.SetPhaseWithEvent(v1alpha1.CSVPhaseFailed, v1alpha1.CSVReasonInvalidStrategy, fmt.Sprintf("install strategy invalid: %s", err.Error()), now, a.recorder)
@@ -2058,13 +2453,13 @@ func (a *Operator) transitionCSVState(in v1alpha1.ClusterServiceVersion) (out *v
 		}
 
 		// Check install status
-		strategy, err = a.updateDeploymentSpecsWithAPIServiceData(out, strategy)
+		strategy, err = a.updateDeploymentSpecsWithAPIServiceData(ctx, out, strategy)
 		if err != nil {
 			logger.WithError(err).Debug("Unable to calculate expected deployment")
 			out.SetPhaseWithEvent(v1alpha1.CSVPhasePending, v1alpha1.CSVReasonNeedsReinstall, "calculated deployment install is bad", now, a.recorder)
 			return
 		}
-		if installErr := a.updateInstallStatus(out, installer, strategy, v1alpha1.CSVPhasePending, v1alpha1.CSVReasonNeedsReinstall); installErr != nil {
+		if installErr := a.updateInstallStatus(ctx, out, installer, strategy, v1alpha1.CSVPhasePending, v1alpha1.CSVReasonNeedsReinstall); installErr != nil {
 			// Re-sync if kube-apiserver was unavailable
 			if k8serrors.IsServiceUnavailable(installErr) {
 				logger.WithError(installErr).Info("could not update install status")
@@ -2087,7 +2482,29 @@ func (a *Operator) transitionCSVState(in v1alpha1.ClusterServiceVersion) (out *v
 
 		// If there is a succeeded replacement, mark this for deletion
 		if next := a.isBeingReplaced(out, a.csvSet(out.GetNamespace(), v1alpha1.CSVPhaseAny)); next != nil {
-			if next.Status.Phase == v1alpha1.CSVPhaseSucceeded {
+			if next.Status.Phase == v1alpha1.CSVPhaseSucceeded && stagedUpgradeInProgress(next) {
+				// the replacement requeues this csv once its staged upgrade completes
+				logger.Debugf("replacement %s is in a staged upgrade, skipping gc", next.GetName())
+			} else if next.Status.Phase == v1alpha1.CSVPhaseSucceeded {
+				blocking, err := a.blockingConditions(next)
+				if err != nil {
+					syncError = err
+					return
+				}
+				if len(blocking) > 0 {
+					logger.Debugf("replacement %s reports %s, skipping gc", next.GetName(), describeConditions(blocking))
+					syncError = a.csvQueueSet.RequeueAfter(out.GetNamespace(), out.GetName(), blockingConditionsRequeueInterval)
+					return
+				}
+				if retained := replacedCSVRetainedFor(next, a.replacedCSVRetention(), now); retained > 0 {
+					logger.Debugf("replaced by %s, retained for %s before gc", next.GetName(), retained)
+					if err := a.scaleDownRetainedDeployments(ctx, out, next); err != nil {
+						syncError = err
+						return
+					}
+					syncError = a.csvQueueSet.RequeueAfter(out.GetNamespace(), out.GetName(), retained)
+					return
+				}
 				out.SetPhaseWithEvent(v1alpha1.CSVPhaseDeleting, v1alpha1.CSVReasonReplaced, "has been replaced by a newer ClusterServiceVersion that has successfully installed.", now, a.recorder)
 			} else {
 				// If there's a replacement, but it's not yet succeeded, requeue both (this is an active replacement)
@@ -2099,10 +2516,16 @@ func (a *Operator) transitionCSVState(in v1alpha1.ClusterServiceVersion) (out *v
 				}
 			}
 		} else {
-			syncError = fmt.Errorf("marked as replacement, but no replacement CSV found in cluster")
+			// the replacement was removed, e.g. by a subscription rollback or within the retention: resume this csv,
+			// scaling back up the deployments scaled down while it was retained
+			if err := a.restoreRetainedDeployments(ctx, out); err != nil {
+				syncError = err
+				return
+			}
+			out.SetPhaseWithEvent(v1alpha1.CSVPhasePending, v1alpha1.CSVReasonNeedsReinstall, "replacement csv not found, reinstalling", now, a.recorder)
 		}
 	case v1alpha1.CSVPhaseDeleting:
-		syncError = a.client.OperatorsV1alpha1().ClusterServiceVersions(out.GetNamespace()).Delete(context.TODO(), out.GetName(), *metav1.NewDeleteOptions(0))
+		syncError = a.client.OperatorsV1alpha1().ClusterServiceVersions(out.GetNamespace()).Delete(ctx, out.GetName(), *metav1.NewDeleteOptions(0))
 		if syncError != nil {
 			logger.Debugf("unable to get delete csv marked for deletion: %s", syncError.Error())
 		}
@@ -2132,8 +2555,8 @@ func (a *Operator) checkReplacementsAndUpdateStatus(csv *v1alpha1.ClusterService
 	return nil
 }
 
-func (a *Operator) updateInstallStatus(csv *v1alpha1.ClusterServiceVersion, installer install.StrategyInstaller, strategy install.Strategy, requeuePhase v1alpha1.ClusterServiceVersionPhase, requeueConditionReason v1alpha1.ConditionReason) error {
-	strategyInstalled, strategyErr := installer.CheckInstalled(strategy)
+func (a *Operator) updateInstallStatus(ctx context.Context, csv *v1alpha1.ClusterServiceVersion, installer install.StrategyInstaller, strategy install.Strategy, requeuePhase v1alpha1.ClusterServiceVersionPhase, requeueConditionReason v1alpha1.ConditionReason) error {
+	strategyInstalled, strategyErr := installer.CheckInstalled(ctx, strategy)
 	now := a.now()
 
 	if strategyErr != nil {
@@ -2141,7 +2564,15 @@ func (a *Operator) updateInstallStatus(csv *v1alpha1.ClusterServiceVersion, inst
 	}
 
 	apiServicesInstalled, apiServiceErr := a.areAPIServicesAvailable(csv)
-	webhooksInstalled, webhookErr := a.areWebhooksAvailable(csv)
+	webhooksInstalled, webhookErr := a.areWebhooksAvailable(ctx, csv)
+	if webhookErr == nil {
+		webhookErr = a.ensureAdmissionPolicies(ctx, csv)
+		webhooksInstalled = webhooksInstalled && webhookErr == nil
+	}
+	if webhookErr == nil {
+		webhookErr = a.ensureKubeconfigs(ctx, csv)
+		webhooksInstalled = webhooksInstalled && webhookErr == nil
+	}
 
 	if strategyInstalled && apiServicesInstalled && webhooksInstalled {
 		// if there's no error, we're successfully running
@@ -2174,6 +2605,12 @@ func (a *Operator) updateInstallStatus(csv *v1alpha1.ClusterServiceVersion, inst
 		return fmt.Errorf(msg)
 	}
 
+	var conflict install.OwnerConflictError
+	if errors.As(webhookErr, &conflict) {
+		csv.SetPhaseWithEventIfChanged(v1alpha1.CSVPhaseFailed, v1alpha1.CSVReasonOwnerConflict, conflict.Error(), now, a.recorder)
+		return webhookErr
+	}
+
 	if !webhooksInstalled || webhookErr != nil {
 		msg := "webhooks not installed"
 		csv.SetPhaseWithEventIfChanged(requeuePhase, requeueConditionReason, msg, now, a.recorder)
@@ -2355,7 +2792,7 @@ func (a *Operator) isReplacing(in *v1alpha1.ClusterServiceVersion) *v1alpha1.Clu
 	return a.csvReplaceFinder.IsReplacing(in)
 }
 
-func (a *Operator) handleDeletion(obj interface{}) {
+func (a *Operator) handleDeletion(ctx context.Context, obj interface{}) {
 	metaObj, ok := obj.(metav1.Object)
 	if !ok {
 		tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
@@ -2445,7 +2882,7 @@ func (a *Operator) requeueOwnerCSVs(ownee metav1.Object) {
 	}
 }
 
-func (a *Operator) cleanupCSVDeployments(logger *logrus.Entry, csv *v1alpha1.ClusterServiceVersion) {
+func (a *Operator) cleanupCSVDeployments(ctx context.Context, logger *logrus.Entry, csv *v1alpha1.ClusterServiceVersion) {
 	// Extract the InstallStrategy for the deployment
 	strategy, err := a.resolver.UnmarshalStrategy(csv.Spec.InstallStrategy)
 	if err != nil {
@@ -2464,14 +2901,16 @@ func (a *Operator) cleanupCSVDeployments(logger *logrus.Entry, csv *v1alpha1.Clu
 	for _, spec := range strategyDetailsDeployment.DeploymentSpecs {
 		logger := logger.WithField("deployment", spec.Name)
 		logger.Debug("cleaning up CSV deployment")
-		if err := a.opClient.DeleteDeployment(csv.GetNamespace(), spec.Name, &metav1.DeleteOptions{}); err != nil {
+		if err := a.opClient.DeleteDeployment(ctx, csv.GetNamespace(), spec.Name, &metav1.DeleteOptions{}); err != nil {
 			logger.WithField("err", err).Warn("error cleaning up CSV deployment")
+			continue
 		}
+		a.auditor.Record(csv, audit.Mutation{Action: audit.Deleted, Kind: "Deployment", Namespace: csv.GetNamespace(), Name: spec.Name, CSV: csv.GetName()})
 	}
 }
 
 // ensureLabels merges a label set with a CSV's labels and attempts to update the CSV if the merged set differs from the CSV's original labels.
-func (a *Operator) ensureLabels(in *v1alpha1.ClusterServiceVersion, labelSets ...labels.Set) (*v1alpha1.ClusterServiceVersion, error) {
+func (a *Operator) ensureLabels(ctx context.Context, in *v1alpha1.ClusterServiceVersion, labelSets ...labels.Set) (*v1alpha1.ClusterServiceVersion, error) {
 	csvLabelSet := labels.Set(in.GetLabels())
 	merged := csvLabelSet
 	for _, labelSet := range labelSets {
@@ -2486,6 +2925,6 @@ func (a *Operator) ensureLabels(in *v1alpha1.ClusterServiceVersion, labelSets ..
 
 	out := in.DeepCopy()
 	out.SetLabels(merged)
-	out, err := a.client.OperatorsV1alpha1().ClusterServiceVersions(out.GetNamespace()).Update(context.TODO(), out, metav1.UpdateOptions{})
+	out, err := a.client.OperatorsV1alpha1().ClusterServiceVersions(out.GetNamespace()).Update(ctx, out, metav1.UpdateOptions{})
 	return out, err
 }
@@ -32,11 +32,13 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	utilclock "k8s.io/apimachinery/pkg/util/clock"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/wait"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
 	k8sfake "k8s.io/client-go/kubernetes/fake"
 	k8sscheme "k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/pkg/version"
@@ -83,11 +85,11 @@ func NewTestInstaller(installErr error, checkInstallErr error) install.StrategyI
 	}
 }
 
-func (i *TestInstaller) Install(s install.Strategy) error {
+func (i *TestInstaller) Install(ctx context.Context, s install.Strategy) error {
 	return i.installErr
 }
 
-func (i *TestInstaller) CheckInstalled(s install.Strategy) (bool, error) {
+func (i *TestInstaller) CheckInstalled(ctx context.Context, s install.Strategy) (bool, error) {
 	if i.checkInstallErr != nil {
 		return false, i.checkInstallErr
 	}
@@ -233,6 +235,9 @@ func NewFakeOperator(ctx context.Context, options ...fakeOperatorOption) (*Opera
 			apiReconciler:     APIIntersectionReconcileFunc(ReconcileAPIIntersection),
 			apiLabeler:        labeler.Func(LabelSetsFor),
 			restConfig:        &rest.Config{},
+			copiedCSVWorkers:  2,
+			copiedCSVQPS:      5,
+			copiedCSVBurst:    50,
 		},
 		recorder: &record.FakeRecorder{},
 		// default expected namespaces
@@ -285,6 +290,10 @@ func NewFakeOperator(ctx context.Context, options ...fakeOperatorOption) (*Opera
 		operatorClient:   config.operatorClient,
 		kubernetesClient: config.externalClient,
 	}
+	op.admissionPolicyClient = install.NewAdmissionPolicyClient(dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		install.ValidatingAdmissionPolicyGVR:        "ValidatingAdmissionPolicyList",
+		install.ValidatingAdmissionPolicyBindingGVR: "ValidatingAdmissionPolicyBindingList",
+	}))
 
 	return op, nil
 }
@@ -927,6 +936,84 @@ func TestTransitionCSV(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "SingleCSVNoneToPending/ReplacedCSVNotFound",
+			initial: initial{
+				csvs: []runtime.Object{
+					csvWithAnnotations(csv("csv2",
+						namespace,
+						"0.0.0",
+						"csv1",
+						installStrategy("csv2-dep1", nil, nil),
+						[]*apiextensionsv1.CustomResourceDefinition{crd("c1", "v1", "g1")},
+						[]*apiextensionsv1.CustomResourceDefinition{},
+						v1alpha1.CSVPhaseNone,
+					), defaultTemplateAnnotations),
+				},
+				clientObjs: []runtime.Object{addAnnotation(defaultOperatorGroup, v1.OperatorGroupProvidedAPIsAnnotationKey, "c1.v1.g1")},
+			},
+			expected: expected{
+				csvStates: map[string]csvState{
+					"csv2": {exists: true, phase: v1alpha1.CSVPhasePending, reason: CSVReasonReplacedCSVNotFound},
+				},
+			},
+		},
+		{
+			name: "SingleCSVPendingToPending/ReplacedCSVNotFound/Install",
+			initial: initial{
+				csvs: []runtime.Object{
+					csvWithAnnotations(csv("csv2",
+						namespace,
+						"0.0.0",
+						"csv1",
+						installStrategy("csv2-dep1", nil, nil),
+						[]*apiextensionsv1.CustomResourceDefinition{crd("c1", "v1", "g1")},
+						[]*apiextensionsv1.CustomResourceDefinition{},
+						v1alpha1.CSVPhasePending,
+					), defaultTemplateAnnotations),
+				},
+				clientObjs: []runtime.Object{addAnnotation(defaultOperatorGroup, v1.OperatorGroupProvidedAPIsAnnotationKey, "c1.v1.g1")},
+			},
+			expected: expected{
+				csvStates: map[string]csvState{
+					"csv2": {exists: true, phase: v1alpha1.CSVPhasePending, reason: v1alpha1.CSVReasonRequirementsNotMet},
+				},
+				err: map[string]error{
+					"csv2": ErrRequirementsNotMet,
+				},
+			},
+		},
+		{
+			name: "SingleCSVPendingToPending/ReplacedCSVNotFound/Block",
+			initial: initial{
+				csvs: []runtime.Object{
+					csvWithAnnotations(csv("csv2",
+						namespace,
+						"0.0.0",
+						"csv1",
+						installStrategy("csv2-dep1", nil, nil),
+						[]*apiextensionsv1.CustomResourceDefinition{crd("c1", "v1", "g1")},
+						[]*apiextensionsv1.CustomResourceDefinition{},
+						v1alpha1.CSVPhasePending,
+					), defaultTemplateAnnotations),
+				},
+				clientObjs: []runtime.Object{
+					addAnnotation(defaultOperatorGroup, v1.OperatorGroupProvidedAPIsAnnotationKey, "c1.v1.g1"),
+					&v1.OLMConfig{ObjectMeta: metav1.ObjectMeta{
+						Name:        "cluster",
+						Annotations: map[string]string{MissingReplacedCSVPolicyAnnotationKey: MissingReplacedCSVPolicyBlock},
+					}},
+				},
+			},
+			expected: expected{
+				csvStates: map[string]csvState{
+					"csv2": {exists: true, phase: v1alpha1.CSVPhasePending, reason: CSVReasonReplacedCSVNotFound},
+				},
+				err: map[string]error{
+					"csv2": ErrReplacedCSVNotFound,
+				},
+			},
+		},
 		{
 			name: "SingleCSVNoneToPending/APIService/Required",
 			initial: initial{
@@ -3059,7 +3146,7 @@ func TestTransitionCSV(t *testing.T) {
 
 			// run csv sync for each CSV
 			for _, csv := range tt.initial.csvs {
-				err := op.syncClusterServiceVersion(csv)
+				err := op.syncClusterServiceVersion(ctx, csv)
 				expectedErr := tt.expected.err[csv.(*v1alpha1.ClusterServiceVersion).Name]
 				require.Equal(t, expectedErr, err)
 			}
@@ -3317,7 +3404,7 @@ func TestWebhookCABundleRetrieval(t *testing.T) {
 
 			// run csv sync for each CSV
 			for _, csv := range tt.initial.csvs {
-				caBundle, err := op.getWebhookCABundle(csv.(*v1alpha1.ClusterServiceVersion), &tt.initial.desc)
+				caBundle, err := op.getWebhookCABundle(ctx, csv.(*v1alpha1.ClusterServiceVersion), &tt.initial.desc)
 				require.Equal(t, tt.expected.err, err)
 				require.Equal(t, tt.expected.caBundle, caBundle)
 			}
@@ -3393,8 +3480,8 @@ func TestUpdates(t *testing.T) {
 		v1alpha1.CSVPhaseNone)
 
 	simulateSuccessfulRollout := func(csv *v1alpha1.ClusterServiceVersion, client operatorclient.ClientInterface) {
-		// get the deployment, which should exist
-		dep, err := client.GetDeployment(namespace, deploymentName)
+		// get the latest deployment from the apiserver, which should exist
+		dep, err := client.KubernetesInterface().AppsV1().Deployments(namespace).Get(context.TODO(), deploymentName, metav1.GetOptions{})
 		require.NoError(t, err)
 
 		// force it healthy
@@ -3559,7 +3646,7 @@ func TestUpdates(t *testing.T) {
 			// helper to sync a set of csvs, in order, and return the latest view from the cluster
 			syncCSVs := func(csvsToSync map[string]*v1alpha1.ClusterServiceVersion, deleted map[string]struct{}) (out map[string]*v1alpha1.ClusterServiceVersion) {
 				for name, csv := range csvsToSync {
-					_ = op.syncClusterServiceVersion(csv)
+					_ = op.syncClusterServiceVersion(ctx, csv)
 					if _, ok := deleted[name]; !ok {
 						require.NoError(t, err)
 					}
@@ -3612,7 +3699,7 @@ func TestUpdates(t *testing.T) {
 					other := csvsToSync[name]
 					for other.Status.Phase != phase {
 						fmt.Printf("waiting for %s to be %s\n", name, phase)
-						_ = op.syncClusterServiceVersion(other)
+						_ = op.syncClusterServiceVersion(ctx, other)
 						other, err = op.client.OperatorsV1alpha1().ClusterServiceVersions(namespace).Get(context.TODO(), name, metav1.GetOptions{})
 						require.NoError(t, err)
 					}
@@ -4220,7 +4307,7 @@ func TestSyncOperatorGroups(t *testing.T) {
 							APIVersion: rbacv1.GroupName,
 						},
 						ObjectMeta: metav1.ObjectMeta{
-							Name: "csv-role",
+							Name: singletonRBACName(operatorNamespace, "csv-role"),
 							Labels: map[string]string{
 								"olm.owner":           "csv1",
 								"olm.owner.namespace": "operator-ns",
@@ -4239,7 +4326,7 @@ func TestSyncOperatorGroups(t *testing.T) {
 							APIVersion: rbacv1.GroupName,
 						},
 						ObjectMeta: metav1.ObjectMeta{
-							Name: "csv-rolebinding",
+							Name: singletonRBACName(operatorNamespace, "csv-rolebinding"),
 							Labels: map[string]string{
 								"olm.owner":           "csv1",
 								"olm.owner.namespace": "operator-ns",
@@ -4256,7 +4343,7 @@ func TestSyncOperatorGroups(t *testing.T) {
 						RoleRef: rbacv1.RoleRef{
 							APIGroup: rbacv1.GroupName,
 							Kind:     "ClusterRole",
-							Name:     "csv-role",
+							Name:     singletonRBACName(operatorNamespace, "csv-role"),
 						},
 					},
 				},
@@ -4446,7 +4533,7 @@ func TestSyncOperatorGroups(t *testing.T) {
 			simulateSuccessfulRollout := func(csv *v1alpha1.ClusterServiceVersion) {
 				// Get the deployment, which should exist
 				namespace := operatorGroup.GetNamespace()
-				dep, err := op.opClient.GetDeployment(namespace, deploymentName)
+				dep, err := op.opClient.GetDeployment(context.TODO(), namespace, deploymentName)
 				require.NoError(t, err)
 
 				// Force it healthy
@@ -4478,7 +4565,7 @@ func TestSyncOperatorGroups(t *testing.T) {
 				require.NoError(t, err)
 			}
 
-			err = op.syncOperatorGroups(operatorGroup)
+			err = op.syncOperatorGroups(ctx, operatorGroup)
 			require.NoError(t, err)
 
 			// Wait on operator group updated status to be in the cache as it is required for later CSV operations
@@ -4502,7 +4589,7 @@ func TestSyncOperatorGroups(t *testing.T) {
 			// This must be done (at least) twice to have annotateCSVs run in syncOperatorGroups and to catch provided API changes
 			// syncOperatorGroups is eventually consistent and may return errors until the cache has caught up with the cluster (fake client here)
 			wait.PollImmediateWithContext(ctx, tick, timeout, func(ctx context.Context) (bool, error) { // Throw away timeout errors since any timeout will coincide with err != nil anyway
-				err = op.syncOperatorGroups(operatorGroup)
+				err = op.syncOperatorGroups(ctx, operatorGroup)
 				return err == nil, nil
 			})
 			require.NoError(t, err)
@@ -4519,11 +4606,11 @@ func TestSyncOperatorGroups(t *testing.T) {
 						simulateSuccessfulRollout(&csv)
 					}
 
-					if err := op.syncClusterServiceVersion(&csv); err != nil {
+					if err := op.syncClusterServiceVersion(ctx, &csv); err != nil {
 						return false, err
 					}
 
-					if err := op.syncCopyCSV(&csv); err != nil && !tt.ignoreCopyError {
+					if err := op.syncCopyCSV(ctx, &csv); err != nil && !tt.ignoreCopyError {
 						return false, err
 					}
 				}
@@ -4723,7 +4810,7 @@ func TestOperatorGroupConditions(t *testing.T) {
 			)
 			require.NoError(t, err)
 
-			err = op.syncOperatorGroups(tt.initial.operatorGroup)
+			err = op.syncOperatorGroups(ctx, tt.initial.operatorGroup)
 			if !tt.expectError {
 				require.NoError(t, err)
 			}
@@ -4783,15 +4870,15 @@ func RequireObjectsInNamespace(t *testing.T, opClient operatorclient.ClientInter
 		var fetched runtime.Object
 		switch o := object.(type) {
 		case *appsv1.Deployment:
-			fetched, err = opClient.GetDeployment(namespace, o.GetName())
+			fetched, err = opClient.GetDeployment(context.TODO(), namespace, o.GetName())
 		case *rbacv1.ClusterRole:
-			fetched, err = opClient.GetClusterRole(o.GetName())
+			fetched, err = opClient.GetClusterRole(context.TODO(), o.GetName())
 		case *rbacv1.Role:
-			fetched, err = opClient.GetRole(namespace, o.GetName())
+			fetched, err = opClient.GetRole(context.TODO(), namespace, o.GetName())
 		case *rbacv1.ClusterRoleBinding:
-			fetched, err = opClient.GetClusterRoleBinding(o.GetName())
+			fetched, err = opClient.GetClusterRoleBinding(context.TODO(), o.GetName())
 		case *rbacv1.RoleBinding:
-			fetched, err = opClient.GetRoleBinding(namespace, o.GetName())
+			fetched, err = opClient.GetRoleBinding(context.TODO(), namespace, o.GetName())
 		case *v1alpha1.ClusterServiceVersion:
 			fetched, err = client.OperatorsV1alpha1().ClusterServiceVersions(namespace).Get(context.TODO(), o.GetName(), metav1.GetOptions{})
 			// This protects against small timing issues in sync tests
@@ -1,8 +1,14 @@
 package olm
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"sort"
+	"strings"
+	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	meta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -53,3 +59,211 @@ func (a *Operator) isOperatorUpgradeable(csv *v1alpha1.ClusterServiceVersion) (b
 
 	return true, nil
 }
+
+const (
+	// OperatorConditionDegraded is the OperatorCondition condition an operator sets to True while it can't reconcile
+	// its operands.
+	OperatorConditionDegraded = "Degraded"
+
+	// OperatorConditionMaintenanceInProgress is the OperatorCondition condition an operator sets to True while it
+	// performs maintenance that mustn't be interrupted, e.g. a data migration.
+	OperatorConditionMaintenanceInProgress = "MaintenanceInProgress"
+
+	// OperatorConditionsAnnotationKey is the CSV annotation reflecting, as a JSON-encoded list of conditions, the
+	// conditions other than Upgradeable that the operator reports on its OperatorCondition.
+	OperatorConditionsAnnotationKey = "operatorframework.io/operator-conditions"
+
+	// BlockingConditionsAnnotationKey is the CSV annotation listing, comma-separated, the OperatorCondition conditions
+	// that hold the uninstall of the CSV, and the garbage collection of the CSVs it replaces, while they're True.
+	BlockingConditionsAnnotationKey = "operatorframework.io/blocking-conditions"
+
+	// OperatorConditionsFinalizer is the finalizer holding the deletion of CSVs declaring blocking conditions until
+	// none of them is True.
+	OperatorConditionsFinalizer = "operatorframework.io/operator-conditions"
+
+	blockingConditionsRequeueInterval = 30 * time.Second
+)
+
+// effectiveConditions returns the conditions other than Upgradeable of the given OperatorCondition, sorted by type.
+// Overrides take precedence over the conditions reported by the operator, which are ignored while outdated.
+func effectiveConditions(cond *operatorsv2.OperatorCondition) []metav1.Condition {
+	types := map[string]struct{}{}
+	for _, c := range append(append([]metav1.Condition{}, cond.Spec.Overrides...), cond.Status.Conditions...) {
+		if c.Type != operatorsv2.Upgradeable {
+			types[c.Type] = struct{}{}
+		}
+	}
+
+	var conditions []metav1.Condition
+	for t := range types {
+		if o := meta.FindStatusCondition(cond.Spec.Overrides, t); o != nil {
+			conditions = append(conditions, *o)
+			continue
+		}
+		if c := meta.FindStatusCondition(cond.Status.Conditions, t); c != nil && c.ObservedGeneration == cond.GetGeneration() {
+			conditions = append(conditions, *c)
+		}
+	}
+	sort.Slice(conditions, func(i, j int) bool {
+		return conditions[i].Type < conditions[j].Type
+	})
+	return conditions
+}
+
+// operatorConditions returns the effective conditions other than Upgradeable of the OperatorCondition of the given
+// CSV, if any.
+func (a *Operator) operatorConditions(csv *v1alpha1.ClusterServiceVersion) ([]metav1.Condition, error) {
+	cond, err := a.lister.OperatorsV2().OperatorConditionLister().OperatorConditions(csv.GetNamespace()).Get(csv.GetName())
+	if k8serrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return effectiveConditions(cond), nil
+}
+
+// blockingConditionTypes returns the condition types declared as blocking by the given CSV.
+func blockingConditionTypes(csv *v1alpha1.ClusterServiceVersion) []string {
+	var types []string
+	for _, t := range strings.Split(csv.GetAnnotations()[BlockingConditionsAnnotationKey], ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			types = append(types, t)
+		}
+	}
+	return types
+}
+
+// blockingConditions returns the conditions declared as blocking by the given CSV that its operator reports as True.
+func (a *Operator) blockingConditions(csv *v1alpha1.ClusterServiceVersion) ([]metav1.Condition, error) {
+	types := blockingConditionTypes(csv)
+	if len(types) == 0 {
+		return nil, nil
+	}
+	conditions, err := a.operatorConditions(csv)
+	if err != nil {
+		return nil, err
+	}
+
+	var blocking []metav1.Condition
+	for _, t := range types {
+		if meta.IsStatusConditionTrue(conditions, t) {
+			blocking = append(blocking, *meta.FindStatusCondition(conditions, t))
+		}
+	}
+	return blocking, nil
+}
+
+func describeConditions(conditions []metav1.Condition) string {
+	var described []string
+	for _, c := range conditions {
+		if c.Message == "" {
+			described = append(described, c.Type)
+			continue
+		}
+		described = append(described, fmt.Sprintf("%s (%s)", c.Type, c.Message))
+	}
+	return strings.Join(described, ", ")
+}
+
+func hasOperatorConditionsFinalizer(csv *v1alpha1.ClusterServiceVersion) bool {
+	for _, f := range csv.GetFinalizers() {
+		if f == OperatorConditionsFinalizer {
+			return true
+		}
+	}
+	return false
+}
+
+// syncOperatorConditions reflects the conditions of the OperatorCondition of the given CSV onto its annotations,
+// recording an event for each condition whose status changed, and adds the OperatorCondition finalizer to CSVs
+// declaring blocking conditions or removes it from CSVs that no longer do. It returns true if the CSV was updated.
+func (a *Operator) syncOperatorConditions(ctx context.Context, logger *logrus.Entry, csv *v1alpha1.ClusterServiceVersion) (bool, error) {
+	conditions, err := a.operatorConditions(csv)
+	if err != nil {
+		return false, err
+	}
+	var reflected string
+	if len(conditions) > 0 {
+		b, err := json.Marshal(conditions)
+		if err != nil {
+			return false, err
+		}
+		reflected = string(b)
+	}
+
+	out := csv.DeepCopy()
+	updated := false
+	if previous, ok := csv.GetAnnotations()[OperatorConditionsAnnotationKey]; previous != reflected || (ok && reflected == "") {
+		annotations := out.GetAnnotations()
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		if reflected == "" {
+			delete(annotations, OperatorConditionsAnnotationKey)
+		} else {
+			annotations[OperatorConditionsAnnotationKey] = reflected
+		}
+		out.SetAnnotations(annotations)
+		updated = true
+
+		var previousConditions []metav1.Condition
+		if previous != "" {
+			if err := json.Unmarshal([]byte(previous), &previousConditions); err != nil {
+				logger.WithError(err).Debug("ignoring invalid reflected operator conditions")
+			}
+		}
+		a.recordOperatorConditionChanges(csv, previousConditions, conditions)
+	}
+
+	if blocks := len(blockingConditionTypes(csv)) > 0; blocks != hasOperatorConditionsFinalizer(csv) {
+		if blocks {
+			out.SetFinalizers(append(out.GetFinalizers(), OperatorConditionsFinalizer))
+		} else {
+			out.SetFinalizers(removeFinalizer(out.GetFinalizers(), OperatorConditionsFinalizer))
+		}
+		updated = true
+	}
+
+	if !updated {
+		return false, nil
+	}
+	_, err = a.client.OperatorsV1alpha1().ClusterServiceVersions(out.GetNamespace()).Update(ctx, out, metav1.UpdateOptions{})
+	return err == nil, err
+}
+
+func (a *Operator) recordOperatorConditionChanges(csv *v1alpha1.ClusterServiceVersion, previous, conditions []metav1.Condition) {
+	for _, c := range conditions {
+		if p := meta.FindStatusCondition(previous, c.Type); p != nil && p.Status == c.Status {
+			continue
+		}
+		eventType := corev1.EventTypeNormal
+		if c.Type == OperatorConditionDegraded && c.Status == metav1.ConditionTrue {
+			eventType = corev1.EventTypeWarning
+		}
+		a.recorder.Eventf(csv, eventType, "OperatorConditionChanged", "%s is %s: %s", c.Type, c.Status, c.Message)
+	}
+}
+
+// handleBlockingConditions removes the OperatorCondition finalizer from the given CSV, which is being deleted, once
+// none of the conditions it declares as blocking is True.
+func (a *Operator) handleBlockingConditions(ctx context.Context, logger *logrus.Entry, csv *v1alpha1.ClusterServiceVersion) error {
+	blocking, err := a.blockingConditions(csv)
+	if err != nil {
+		return err
+	}
+	if len(blocking) > 0 {
+		msg := fmt.Sprintf("deletion held while the operator reports %s", describeConditions(blocking))
+		logger.Info(msg)
+		a.recorder.Event(csv, corev1.EventTypeWarning, "DeletionBlocked", msg)
+		return a.csvQueueSet.RequeueAfter(csv.GetNamespace(), csv.GetName(), blockingConditionsRequeueInterval)
+	}
+
+	out := csv.DeepCopy()
+	out.SetFinalizers(removeFinalizer(out.GetFinalizers(), OperatorConditionsFinalizer))
+	_, err = a.client.OperatorsV1alpha1().ClusterServiceVersions(out.GetNamespace()).Update(ctx, out, metav1.UpdateOptions{})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
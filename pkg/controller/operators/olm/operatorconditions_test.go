@@ -0,0 +1,156 @@
+package olm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	operatorsv2 "github.com/operator-framework/api/pkg/operators/v2"
+)
+
+func TestEffectiveConditions(t *testing.T) {
+	condition := func(conditionType string, status metav1.ConditionStatus, generation int64) metav1.Condition {
+		return metav1.Condition{Type: conditionType, Status: status, ObservedGeneration: generation}
+	}
+
+	tests := []struct {
+		name      string
+		overrides []metav1.Condition
+		status    []metav1.Condition
+		expected  []metav1.Condition
+	}{
+		{
+			name: "NoConditions",
+		},
+		{
+			name: "UpgradeableIgnored",
+			status: []metav1.Condition{
+				condition(operatorsv2.Upgradeable, metav1.ConditionFalse, 2),
+				condition(OperatorConditionMaintenanceInProgress, metav1.ConditionTrue, 2),
+				condition(OperatorConditionDegraded, metav1.ConditionFalse, 2),
+			},
+			expected: []metav1.Condition{
+				condition(OperatorConditionDegraded, metav1.ConditionFalse, 2),
+				condition(OperatorConditionMaintenanceInProgress, metav1.ConditionTrue, 2),
+			},
+		},
+		{
+			name:      "Overridden",
+			overrides: []metav1.Condition{condition(OperatorConditionMaintenanceInProgress, metav1.ConditionFalse, 0)},
+			status:    []metav1.Condition{condition(OperatorConditionMaintenanceInProgress, metav1.ConditionTrue, 2)},
+			expected:  []metav1.Condition{condition(OperatorConditionMaintenanceInProgress, metav1.ConditionFalse, 0)},
+		},
+		{
+			name:   "Outdated",
+			status: []metav1.Condition{condition(OperatorConditionDegraded, metav1.ConditionTrue, 1)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cond := &operatorsv2.OperatorCondition{
+				ObjectMeta: metav1.ObjectMeta{Name: "csv", Namespace: "ns", Generation: 2},
+				Spec:       operatorsv2.OperatorConditionSpec{Overrides: tt.overrides},
+				Status:     operatorsv2.OperatorConditionStatus{Conditions: tt.status},
+			}
+			require.Equal(t, tt.expected, effectiveConditions(cond))
+		})
+	}
+}
+
+func TestBlockingConditions(t *testing.T) {
+	deleted := metav1.Now()
+	csv := func(blocking string) *v1alpha1.ClusterServiceVersion {
+		return &v1alpha1.ClusterServiceVersion{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "csv",
+				Namespace:         "ns",
+				Annotations:       map[string]string{BlockingConditionsAnnotationKey: blocking},
+				Finalizers:        []string{OperatorConditionsFinalizer},
+				DeletionTimestamp: &deleted,
+			},
+		}
+	}
+	operatorCondition := &operatorsv2.OperatorCondition{
+		ObjectMeta: metav1.ObjectMeta{Name: "csv", Namespace: "ns"},
+		Status: operatorsv2.OperatorConditionStatus{Conditions: []metav1.Condition{
+			{Type: OperatorConditionMaintenanceInProgress, Status: metav1.ConditionTrue, Message: "migrating data"},
+			{Type: OperatorConditionDegraded, Status: metav1.ConditionFalse},
+		}},
+	}
+
+	tests := []struct {
+		name     string
+		csv      *v1alpha1.ClusterServiceVersion
+		blocking []string
+	}{
+		{
+			name:     "Blocked",
+			csv:      csv("Degraded, MaintenanceInProgress"),
+			blocking: []string{OperatorConditionMaintenanceInProgress},
+		},
+		{
+			name: "NotBlocked",
+			csv:  csv(OperatorConditionDegraded),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.TODO())
+			defer cancel()
+
+			op, err := NewFakeOperator(ctx, withNamespaces("ns"), withClientObjs(tt.csv, operatorCondition))
+			require.NoError(t, err)
+
+			blocking, err := op.blockingConditions(tt.csv)
+			require.NoError(t, err)
+			var types []string
+			for _, c := range blocking {
+				types = append(types, c.Type)
+			}
+			require.Equal(t, tt.blocking, types)
+
+			require.NoError(t, op.handleBlockingConditions(ctx, logrus.NewEntry(op.logger), tt.csv))
+			out, err := op.client.OperatorsV1alpha1().ClusterServiceVersions("ns").Get(ctx, "csv", metav1.GetOptions{})
+			require.NoError(t, err)
+			require.Equal(t, len(tt.blocking) > 0, hasOperatorConditionsFinalizer(out))
+		})
+	}
+}
+
+func TestSyncOperatorConditions(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+
+	csv := &v1alpha1.ClusterServiceVersion{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "csv",
+			Namespace:   "ns",
+			Annotations: map[string]string{BlockingConditionsAnnotationKey: OperatorConditionMaintenanceInProgress},
+		},
+	}
+	operatorCondition := &operatorsv2.OperatorCondition{
+		ObjectMeta: metav1.ObjectMeta{Name: "csv", Namespace: "ns"},
+		Status: operatorsv2.OperatorConditionStatus{Conditions: []metav1.Condition{
+			{Type: OperatorConditionDegraded, Status: metav1.ConditionTrue, Reason: "Unreachable", Message: "database unreachable"},
+		}},
+	}
+	op, err := NewFakeOperator(ctx, withNamespaces("ns"), withClientObjs(csv, operatorCondition))
+	require.NoError(t, err)
+
+	updated, err := op.syncOperatorConditions(ctx, logrus.NewEntry(op.logger), csv)
+	require.NoError(t, err)
+	require.True(t, updated)
+
+	out, err := op.client.OperatorsV1alpha1().ClusterServiceVersions("ns").Get(ctx, "csv", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.True(t, hasOperatorConditionsFinalizer(out))
+	require.JSONEq(t, `[{"type":"Degraded","status":"True","lastTransitionTime":null,"reason":"Unreachable","message":"database unreachable"}]`, out.GetAnnotations()[OperatorConditionsAnnotationKey])
+
+	updated, err = op.syncOperatorConditions(ctx, logrus.NewEntry(op.logger), out)
+	require.NoError(t, err)
+	require.False(t, updated)
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/install"
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/operators/decorators"
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/registry/resolver/cache"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/controllers"
 	hashutil "github.com/operator-framework/operator-lifecycle-manager/pkg/lib/kubernetes/pkg/util/hash"
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/ownerutil"
 	opregistry "github.com/operator-framework/operator-registry/pkg/registry"
@@ -53,7 +54,7 @@ func aggregationLabelFromAPIKey(k opregistry.APIKey, suffix string) (string, err
 	return fmt.Sprintf("olm.opgroup.permissions/aggregate-to-%s-%s", hash, suffix), nil
 }
 
-func (a *Operator) syncOperatorGroups(obj interface{}) error {
+func (a *Operator) syncOperatorGroups(ctx context.Context, obj interface{}) error {
 	op, ok := obj.(*v1.OperatorGroup)
 	if !ok {
 		a.logger.Debugf("wrong type: %#v\n", obj)
@@ -79,7 +80,7 @@ func (a *Operator) syncOperatorGroups(obj interface{}) error {
 			if og.GetName() == op.GetName() {
 				meta.RemoveStatusCondition(&op.Status.Conditions, v1.MutlipleOperatorGroupCondition)
 			}
-			_, err = a.client.OperatorsV1().OperatorGroups(op.GetNamespace()).UpdateStatus(context.TODO(), og, metav1.UpdateOptions{})
+			_, err = a.client.OperatorsV1().OperatorGroups(op.GetNamespace()).UpdateStatus(ctx, og, metav1.UpdateOptions{})
 			if err != nil {
 				logger.Warnf("fail to upgrade operator group status og=%s with condition %+v: %s", og.GetName(), c, err.Error())
 			}
@@ -101,7 +102,7 @@ func (a *Operator) syncOperatorGroups(obj interface{}) error {
 			if og.GetName() == op.GetName() {
 				meta.SetStatusCondition(&op.Status.Conditions, cond)
 			}
-			_, err = a.client.OperatorsV1().OperatorGroups(op.GetNamespace()).UpdateStatus(context.TODO(), og, metav1.UpdateOptions{})
+			_, err = a.client.OperatorsV1().OperatorGroups(op.GetNamespace()).UpdateStatus(ctx, og, metav1.UpdateOptions{})
 			if err != nil {
 				logger.Warnf("fail to upgrade operator group status og=%s with condition %+v: %s", og.GetName(), cond, err.Error())
 			}
@@ -125,7 +126,7 @@ func (a *Operator) syncOperatorGroups(obj interface{}) error {
 			}
 			if csv.Status.Reason == v1alpha1.CSVReasonComponentFailedNoRetry {
 				csv.SetPhase(v1alpha1.CSVPhasePending, v1alpha1.CSVReasonDetectedClusterChange, "Cluster resources changed state", a.now())
-				_, err := a.client.OperatorsV1alpha1().ClusterServiceVersions(csv.GetNamespace()).UpdateStatus(context.TODO(), csv, metav1.UpdateOptions{})
+				_, err := a.client.OperatorsV1alpha1().ClusterServiceVersions(csv.GetNamespace()).UpdateStatus(ctx, csv, metav1.UpdateOptions{})
 				if err != nil {
 					return err
 				}
@@ -156,7 +157,7 @@ func (a *Operator) syncOperatorGroups(obj interface{}) error {
 			Conditions:  op.Status.Conditions,
 		}
 
-		if _, err = a.client.OperatorsV1().OperatorGroups(op.GetNamespace()).UpdateStatus(context.TODO(), op, metav1.UpdateOptions{}); err != nil && !k8serrors.IsNotFound(err) {
+		if _, err = a.client.OperatorsV1().OperatorGroups(op.GetNamespace()).UpdateStatus(ctx, op, metav1.UpdateOptions{}); err != nil && !k8serrors.IsNotFound(err) {
 			logger.WithError(err).Warn("operatorgroup update failed")
 			return err
 		}
@@ -175,7 +176,7 @@ func (a *Operator) syncOperatorGroups(obj interface{}) error {
 	}
 
 	logger.Debug("check that operatorgroup has updated CSV anotations")
-	err = a.annotateCSVs(op, targetNamespaces, logger)
+	err = a.annotateCSVs(ctx, op, logger)
 	if err != nil {
 		logger.WithError(err).Warn("failed to annotate CSVs in operatorgroup after group change")
 		return err
@@ -196,11 +197,18 @@ func (a *Operator) syncOperatorGroups(obj interface{}) error {
 		providedAPIsForGroup[api] = struct{}{}
 	}
 
-	if err := a.ensureOpGroupClusterRoles(op, providedAPIsForGroup); err != nil {
-		logger.WithError(err).Warn("failed to ensure operatorgroup clusterroles")
-		return err
+	if roleAggregationDisabled(op) {
+		if err := a.deleteOpGroupClusterRoles(ctx, op); err != nil {
+			logger.WithError(err).Warn("failed to delete operatorgroup clusterroles")
+			return err
+		}
+	} else {
+		if err := a.ensureOpGroupClusterRoles(ctx, op, providedAPIsForGroup); err != nil {
+			logger.WithError(err).Warn("failed to ensure operatorgroup clusterroles")
+			return err
+		}
+		logger.Debug("operatorgroup clusterroles ensured")
 	}
-	logger.Debug("operatorgroup clusterroles ensured")
 
 	csvs, err := a.findCSVsThatProvideAnyOf(providedAPIsForGroup)
 	if err != nil {
@@ -216,11 +224,11 @@ func (a *Operator) syncOperatorGroups(obj interface{}) error {
 		}
 	}
 
-	a.pruneProvidedAPIs(op, groupProvidedAPIs, providedAPIsForCSVs, logger)
+	a.pruneProvidedAPIs(ctx, op, groupProvidedAPIs, providedAPIsForCSVs, logger)
 	return nil
 }
 
-func (a *Operator) operatorGroupDeleted(obj interface{}) {
+func (a *Operator) operatorGroupDeleted(ctx context.Context, obj interface{}) {
 	op, ok := obj.(*v1.OperatorGroup)
 	if !ok {
 		a.logger.Debugf("casting OperatorGroup failed, wrong type: %#v\n", obj)
@@ -238,7 +246,7 @@ func (a *Operator) operatorGroupDeleted(obj interface{}) {
 		return
 	}
 	for _, clusterRole := range clusterRoles {
-		err = a.opClient.KubernetesInterface().RbacV1().ClusterRoles().Delete(context.TODO(), clusterRole.GetName(), metav1.DeleteOptions{})
+		err = a.opClient.KubernetesInterface().RbacV1().ClusterRoles().Delete(ctx, clusterRole.GetName(), metav1.DeleteOptions{})
 		if err != nil {
 			logger.WithError(err).Error("failed to delete ClusterRole during garbage collection")
 		}
@@ -252,9 +260,8 @@ func (a *Operator) operatorGroupDeleted(obj interface{}) {
 	}
 }
 
-func (a *Operator) annotateCSVs(group *v1.OperatorGroup, targetNamespaces []string, logger *logrus.Entry) error {
+func (a *Operator) annotateCSVs(ctx context.Context, group *v1.OperatorGroup, logger *logrus.Entry) error {
 	updateErrs := []error{}
-	targetNamespaceSet := NewNamespaceSet(targetNamespaces)
 
 	for _, csv := range a.csvSet(group.GetNamespace(), v1alpha1.CSVPhaseAny) {
 		if csv.IsCopied() {
@@ -262,34 +269,21 @@ func (a *Operator) annotateCSVs(group *v1.OperatorGroup, targetNamespaces []stri
 		}
 		logger := logger.WithField("csv", csv.GetName())
 
-		originalNamespacesAnnotation := a.copyOperatorGroupAnnotations(&csv.ObjectMeta)[v1.OperatorGroupTargetsAnnotationKey]
-		originalNamespaceSet := NewNamespaceSetFromString(originalNamespacesAnnotation)
-
 		if a.operatorGroupAnnotationsDiffer(&csv.ObjectMeta, group) {
 			a.setOperatorGroupAnnotations(&csv.ObjectMeta, group, true)
 			// CRDs don't support strategic merge patching, but in the future if they do this should be updated to patch
-			if _, err := a.client.OperatorsV1alpha1().ClusterServiceVersions(csv.GetNamespace()).Update(context.TODO(), csv, metav1.UpdateOptions{}); err != nil && !k8serrors.IsNotFound(err) {
+			if _, err := a.client.OperatorsV1alpha1().ClusterServiceVersions(csv.GetNamespace()).Update(ctx, csv, metav1.UpdateOptions{}); err != nil && !k8serrors.IsNotFound(err) {
 				logger.WithError(err).Warnf("error adding operatorgroup annotations")
 				updateErrs = append(updateErrs, err)
 				continue
 			}
 		}
 
-		// requeue csvs in original namespaces or in new target namespaces (to capture removed/added namespaces)
-		requeueNamespaces := originalNamespaceSet.Union(targetNamespaceSet)
-		if !requeueNamespaces.IsAllNamespaces() {
-			for ns := range requeueNamespaces {
-				if err := a.csvQueueSet.Requeue(ns, csv.GetName()); err != nil {
-					logger.WithError(err).Warn("could not requeue csv")
-				}
-			}
-		}
-		// have to requeue in all namespaces, previous or new targets were AllNamespaces
-		if namespaces, err := a.lister.CoreV1().NamespaceLister().List(labels.Everything()); err != nil {
-			for _, ns := range namespaces {
-				if err := a.csvQueueSet.Requeue(ns.GetName(), csv.GetName()); err != nil {
-					logger.WithError(err).Warn("could not requeue csv")
-				}
+		// requeue the csv for copying, to project it into added target namespaces and out of removed ones; its copies
+		// aren't reconciled by the csv queues
+		if !csv.IsUncopiable() && a.disabled.Enabled(controllers.CopiedCSVs) {
+			if err := a.csvCopyQueueSet.Requeue(csv.GetNamespace(), csv.GetName()); err != nil {
+				logger.WithError(err).Warn("could not requeue csv for copying")
 			}
 		}
 	}
@@ -321,7 +315,7 @@ func (a *Operator) providedAPIsFromCSVs(group *v1.OperatorGroup, logger *logrus.
 	return providedAPIsFromCSVs
 }
 
-func (a *Operator) pruneProvidedAPIs(group *v1.OperatorGroup, groupProvidedAPIs cache.APISet, providedAPIsFromCSVs map[opregistry.APIKey]*v1alpha1.ClusterServiceVersion, logger *logrus.Entry) {
+func (a *Operator) pruneProvidedAPIs(ctx context.Context, group *v1.OperatorGroup, groupProvidedAPIs cache.APISet, providedAPIsFromCSVs map[opregistry.APIKey]*v1alpha1.ClusterServiceVersion, logger *logrus.Entry) {
 	// Don't prune providedAPIsFromCSVs if static
 	if group.Spec.StaticProvidedAPIs {
 		a.logger.Debug("group has static provided apis. skipping provided api pruning")
@@ -361,14 +355,14 @@ func (a *Operator) pruneProvidedAPIs(group *v1.OperatorGroup, groupProvidedAPIs
 		annotations[v1.OperatorGroupProvidedAPIsAnnotationKey] = intersection.String()
 		group.SetAnnotations(annotations)
 		logger.Debug("removing provided apis from annotation to match cluster state")
-		if _, err := a.client.OperatorsV1().OperatorGroups(group.GetNamespace()).Update(context.TODO(), group, metav1.UpdateOptions{}); err != nil && !k8serrors.IsNotFound(err) {
+		if _, err := a.client.OperatorsV1().OperatorGroups(group.GetNamespace()).Update(ctx, group, metav1.UpdateOptions{}); err != nil && !k8serrors.IsNotFound(err) {
 			logger.WithError(err).Warn("could not update provided api annotations")
 		}
 	}
 }
 
 // ensureProvidedAPIClusterRole ensures that a clusterrole exists (admin, edit, or view) for a single provided API Type
-func (a *Operator) ensureProvidedAPIClusterRole(namePrefix, suffix string, verbs []string, group, resource string, resourceNames []string, api ownerutil.Owner, key opregistry.APIKey) error {
+func (a *Operator) ensureProvidedAPIClusterRole(ctx context.Context, namePrefix, suffix string, verbs []string, group, resource string, resourceNames []string, api ownerutil.Owner, key opregistry.APIKey) error {
 	aggregationLabel, err := aggregationLabelFromAPIKey(key, suffix)
 	if err != nil {
 		return err
@@ -392,7 +386,7 @@ func (a *Operator) ensureProvidedAPIClusterRole(namePrefix, suffix string, verbs
 		return err
 	}
 	if k8serrors.IsNotFound(err) {
-		existingCR, err = a.opClient.KubernetesInterface().RbacV1().ClusterRoles().Create(context.TODO(), clusterRole, metav1.CreateOptions{})
+		existingCR, err = a.opClient.KubernetesInterface().RbacV1().ClusterRoles().Create(ctx, clusterRole, metav1.CreateOptions{})
 		if err == nil {
 			return nil
 		}
@@ -406,7 +400,7 @@ func (a *Operator) ensureProvidedAPIClusterRole(namePrefix, suffix string, verbs
 		return nil
 	}
 
-	if _, err := a.opClient.UpdateClusterRole(clusterRole); err != nil {
+	if _, err := a.opClient.UpdateClusterRole(ctx, clusterRole); err != nil {
 		a.logger.WithError(err).Errorf("Update existing cluster role failed: %v", clusterRole)
 		return err
 	}
@@ -414,7 +408,7 @@ func (a *Operator) ensureProvidedAPIClusterRole(namePrefix, suffix string, verbs
 }
 
 // ensureClusterRolesForCSV ensures that ClusterRoles for writing and reading provided APIs exist for each operator
-func (a *Operator) ensureClusterRolesForCSV(csv *v1alpha1.ClusterServiceVersion) error {
+func (a *Operator) ensureClusterRolesForCSV(ctx context.Context, csv *v1alpha1.ClusterServiceVersion) error {
 	for _, owned := range csv.Spec.CustomResourceDefinitions.Owned {
 		crd, err := a.lister.APIExtensionsV1().CustomResourceDefinitionLister().Get(owned.Name)
 		if err != nil {
@@ -429,11 +423,11 @@ func (a *Operator) ensureClusterRolesForCSV(csv *v1alpha1.ClusterServiceVersion)
 		namePrefix := fmt.Sprintf("%s-%s-", owned.Name, owned.Version)
 		key := opregistry.APIKey{Group: group, Version: owned.Version, Kind: owned.Kind, Plural: plural}
 		for suffix, verbs := range VerbsForSuffix {
-			if err := a.ensureProvidedAPIClusterRole(namePrefix, suffix, verbs, group, plural, nil, crd, key); err != nil {
+			if err := a.ensureProvidedAPIClusterRole(ctx, namePrefix, suffix, verbs, group, plural, nil, crd, key); err != nil {
 				return err
 			}
 		}
-		if err := a.ensureProvidedAPIClusterRole(namePrefix+"crd", ViewSuffix, []string{"get"}, "apiextensions.k8s.io", "customresourcedefinitions", []string{owned.Name}, crd, key); err != nil {
+		if err := a.ensureProvidedAPIClusterRole(ctx, namePrefix+"crd", ViewSuffix, []string{"get"}, "apiextensions.k8s.io", "customresourcedefinitions", []string{owned.Name}, crd, key); err != nil {
 			return err
 		}
 	}
@@ -446,7 +440,7 @@ func (a *Operator) ensureClusterRolesForCSV(csv *v1alpha1.ClusterServiceVersion)
 		namePrefix := fmt.Sprintf("%s-%s-", owned.Name, owned.Version)
 		key := opregistry.APIKey{Group: owned.Group, Version: owned.Version, Kind: owned.Kind}
 		for suffix, verbs := range VerbsForSuffix {
-			if err := a.ensureProvidedAPIClusterRole(namePrefix, suffix, verbs, owned.Group, owned.Name, nil, svc, key); err != nil {
+			if err := a.ensureProvidedAPIClusterRole(ctx, namePrefix, suffix, verbs, owned.Group, owned.Name, nil, svc, key); err != nil {
 				return err
 			}
 		}
@@ -454,7 +448,7 @@ func (a *Operator) ensureClusterRolesForCSV(csv *v1alpha1.ClusterServiceVersion)
 	return nil
 }
 
-func (a *Operator) ensureRBACInTargetNamespace(csv *v1alpha1.ClusterServiceVersion, operatorGroup *v1.OperatorGroup) error {
+func (a *Operator) ensureRBACInTargetNamespace(ctx context.Context, csv *v1alpha1.ClusterServiceVersion, operatorGroup *v1.OperatorGroup) error {
 	targetNamespaces := operatorGroup.Status.Namespaces
 	if targetNamespaces == nil {
 		return nil
@@ -469,7 +463,7 @@ func (a *Operator) ensureRBACInTargetNamespace(csv *v1alpha1.ClusterServiceVersi
 	if !ok {
 		return fmt.Errorf("could not cast install strategy as type %T", strategyDetailsDeployment)
 	}
-	ruleChecker := install.NewCSVRuleChecker(a.lister.RbacV1().RoleLister(), a.lister.RbacV1().RoleBindingLister(), a.lister.RbacV1().ClusterRoleLister(), a.lister.RbacV1().ClusterRoleBindingLister(), csv)
+	ruleChecker := a.ruleChecker(csv)
 
 	logger := a.logger.WithField("opgroup", operatorGroup.GetName()).WithField("csv", csv.GetName())
 
@@ -480,18 +474,22 @@ func (a *Operator) ensureRBACInTargetNamespace(csv *v1alpha1.ClusterServiceVersi
 		// synthesize cluster permissions to verify rbac
 		strategyDetailsDeployment.ClusterPermissions = append(strategyDetailsDeployment.ClusterPermissions, strategyDetailsDeployment.Permissions...)
 		strategyDetailsDeployment.Permissions = nil
-		permMet, _, err := a.permissionStatus(strategyDetailsDeployment, ruleChecker, corev1.NamespaceAll, csv)
+		permMet, _, err := a.permissionStatus(ctx, strategyDetailsDeployment, ruleChecker, corev1.NamespaceAll, csv)
 		if err != nil {
 			return err
 		}
 
-		// operator already has access at the cluster scope
-		if permMet {
+		// operator already has access at the cluster scope, and none of it is granted under legacy names
+		legacy, err := a.hasLegacySingletonRBAC(operatorGroup.GetNamespace(), csv)
+		if err != nil {
+			return err
+		}
+		if permMet && !legacy {
 			logger.Debug("global operator has correct global permissions")
 			return nil
 		}
 		logger.Debug("lift roles/rolebindings to clusterroles/rolebindings")
-		if err := a.ensureSingletonRBAC(operatorGroup.GetNamespace(), csv); err != nil {
+		if err := a.ensureSingletonRBAC(ctx, operatorGroup.GetNamespace(), csv); err != nil {
 			return err
 		}
 
@@ -501,7 +499,7 @@ func (a *Operator) ensureRBACInTargetNamespace(csv *v1alpha1.ClusterServiceVersi
 	return nil
 }
 
-func (a *Operator) ensureSingletonRBAC(operatorNamespace string, csv *v1alpha1.ClusterServiceVersion) error {
+func (a *Operator) ensureSingletonRBAC(ctx context.Context, operatorNamespace string, csv *v1alpha1.ClusterServiceVersion) error {
 	ownerSelector := ownerutil.CSVOwnerSelector(csv)
 	ownedRoles, err := a.lister.RbacV1().RoleLister().Roles(operatorNamespace).List(ownerSelector)
 	if err != nil {
@@ -510,18 +508,22 @@ func (a *Operator) ensureSingletonRBAC(operatorNamespace string, csv *v1alpha1.C
 	if len(ownedRoles) == 0 {
 		return fmt.Errorf("no owned roles found")
 	}
+	ownedClusterRoles, err := clusterRBACOwnedBy(a.clusterRoleIndexer, csv)
+	if err != nil {
+		return err
+	}
 
 	for _, r := range ownedRoles {
 		a.logger.Debug("processing role")
-		_, err := a.lister.RbacV1().ClusterRoleLister().Get(r.GetName())
-		if err != nil {
+		name := singletonRBACName(operatorNamespace, r.GetName())
+		if _, ok := ownedClusterRoles[name]; !ok {
 			clusterRole := &rbacv1.ClusterRole{
 				TypeMeta: metav1.TypeMeta{
 					Kind:       "ClusterRole",
 					APIVersion: r.APIVersion,
 				},
 				ObjectMeta: metav1.ObjectMeta{
-					Name:   r.GetName(),
+					Name:   name,
 					Labels: r.GetLabels(),
 				},
 				Rules: append(r.Rules, rbacv1.PolicyRule{
@@ -530,8 +532,7 @@ func (a *Operator) ensureSingletonRBAC(operatorNamespace string, csv *v1alpha1.C
 					Resources: []string{"namespaces"},
 				}),
 			}
-			// TODO: this should do something smarter if the cluster role already exists
-			if cr, err := a.opClient.CreateClusterRole(clusterRole); err != nil {
+			if cr, err := a.opClient.CreateClusterRole(ctx, clusterRole); err != nil {
 				// If the CR already exists, but the label is correct, the cache is just behind
 				if k8serrors.IsAlreadyExists(err) && cr != nil && ownerutil.IsOwnedByLabel(cr, csv) {
 					continue
@@ -549,28 +550,31 @@ func (a *Operator) ensureSingletonRBAC(operatorNamespace string, csv *v1alpha1.C
 	if len(ownedRoleBindings) == 0 {
 		return fmt.Errorf("no owned rolebindings found")
 	}
+	ownedClusterRoleBindings, err := clusterRBACOwnedBy(a.clusterRoleBindingIndexer, csv)
+	if err != nil {
+		return err
+	}
 
 	for _, r := range ownedRoleBindings {
-		_, err := a.lister.RbacV1().ClusterRoleBindingLister().Get(r.GetName())
-		if err != nil {
+		name := singletonRBACName(operatorNamespace, r.GetName())
+		if _, ok := ownedClusterRoleBindings[name]; !ok {
 			clusterRoleBinding := &rbacv1.ClusterRoleBinding{
 				TypeMeta: metav1.TypeMeta{
 					Kind:       "ClusterRoleBinding",
 					APIVersion: r.APIVersion,
 				},
 				ObjectMeta: metav1.ObjectMeta{
-					Name:   r.GetName(),
+					Name:   name,
 					Labels: r.GetLabels(),
 				},
 				Subjects: r.Subjects,
 				RoleRef: rbacv1.RoleRef{
 					APIGroup: r.RoleRef.APIGroup,
 					Kind:     "ClusterRole",
-					Name:     r.RoleRef.Name,
+					Name:     singletonRBACName(operatorNamespace, r.RoleRef.Name),
 				},
 			}
-			// TODO: this should do something smarter if the cluster role binding already exists
-			if crb, err := a.opClient.CreateClusterRoleBinding(clusterRoleBinding); err != nil {
+			if crb, err := a.opClient.CreateClusterRoleBinding(ctx, clusterRoleBinding); err != nil {
 				// If the CRB already exists, but the label is correct, the cache is just behind
 				if k8serrors.IsAlreadyExists(err) && crb != nil && ownerutil.IsOwnedByLabel(crb, csv) {
 					continue
@@ -579,10 +583,12 @@ func (a *Operator) ensureSingletonRBAC(operatorNamespace string, csv *v1alpha1.C
 			}
 		}
 	}
-	return nil
+
+	// Only once the permissions are granted under the new names, revoke those granted under the legacy ones
+	return a.deleteLegacySingletonRBAC(ctx, operatorNamespace, ownedRoles, ownedRoleBindings, ownedClusterRoles, ownedClusterRoleBindings)
 }
 
-func (a *Operator) ensureTenantRBAC(operatorNamespace, targetNamespace string, csv *v1alpha1.ClusterServiceVersion, targetCSV *v1alpha1.ClusterServiceVersion) error {
+func (a *Operator) ensureTenantRBAC(ctx context.Context, operatorNamespace, targetNamespace string, csv *v1alpha1.ClusterServiceVersion, targetCSV *v1alpha1.ClusterServiceVersion) error {
 	if operatorNamespace == targetNamespace {
 		return nil
 	}
@@ -619,7 +625,7 @@ func (a *Operator) ensureTenantRBAC(operatorNamespace, targetNamespace string, c
 		// role already exists, update the rules
 		if ok {
 			existing.Rules = ownedRole.Rules
-			if _, err := a.opClient.UpdateRole(existing); err != nil {
+			if _, err := a.opClient.UpdateRole(ctx, existing); err != nil {
 				return err
 			}
 			continue
@@ -635,7 +641,7 @@ func (a *Operator) ensureTenantRBAC(operatorNamespace, targetNamespace string, c
 			return err
 		}
 		targetRole.SetLabels(utillabels.AddLabel(targetRole.GetLabels(), v1alpha1.CopiedLabelKey, operatorNamespace))
-		if _, err := a.opClient.CreateRole(targetRole); err != nil {
+		if _, err := a.opClient.CreateRole(ctx, targetRole); err != nil {
 			return err
 		}
 	}
@@ -679,38 +685,58 @@ func (a *Operator) ensureTenantRBAC(operatorNamespace, targetNamespace string, c
 			return err
 		}
 		ownedRoleBinding.SetLabels(utillabels.AddLabel(ownedRoleBinding.GetLabels(), v1alpha1.CopiedLabelKey, operatorNamespace))
-		if _, err := a.opClient.CreateRoleBinding(ownedRoleBinding); err != nil {
+		if _, err := a.opClient.CreateRoleBinding(ctx, ownedRoleBinding); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
-func (a *Operator) ensureCSVsInNamespaces(csv *v1alpha1.ClusterServiceVersion, operatorGroup *v1.OperatorGroup, targets NamespaceSet) error {
+// ensureCSVsInNamespaces copies the given CSV into the target namespaces of its OperatorGroup and prunes its copies from
+// other namespaces. For AllNamespaces OperatorGroups, copiedCSVsEnabled is the cluster-wide default that namespaces
+// may override. If slimCopies is set, the copies are stripped of their large fields. A non-nil canaries set limits the
+// copies to the canary namespaces of a staged upgrade, leaving the other target namespaces as they are. The namespaces
+// the CSV couldn't be copied to are returned along with the reason.
+func (a *Operator) ensureCSVsInNamespaces(ctx context.Context, csv *v1alpha1.ClusterServiceVersion, operatorGroup *v1.OperatorGroup, targets, canaries NamespaceSet, copiedCSVsEnabled, slimCopies bool) (map[string]copyFailure, error) {
 	namespaces, err := a.lister.CoreV1().NamespaceLister().List(labels.Everything())
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	strategyDetailsDeployment := &csv.Spec.InstallStrategy.StrategySpec
-	ruleChecker := install.NewCSVRuleChecker(a.lister.RbacV1().RoleLister(), a.lister.RbacV1().RoleBindingLister(), a.lister.RbacV1().ClusterRoleLister(), a.lister.RbacV1().ClusterRoleBindingLister(), csv)
+	ruleChecker := a.ruleChecker(csv)
 
 	logger := a.logger.WithField("opgroup", operatorGroup.GetName()).WithField("csv", csv.GetName())
 
 	targetCSVs := make(map[string]*v1alpha1.ClusterServiceVersion)
+	failures := make(map[string]copyFailure)
 
 	var copyPrototype v1alpha1.ClusterServiceVersion
 	csvCopyPrototype(csv, &copyPrototype)
+	if slimCopies {
+		slimCopyPrototype(&copyPrototype)
+	}
 	nonstatus, status := copyableCSVHash(&copyPrototype)
+	exclusion := a.copiedCSVExclusion(operatorGroup)
 
 	for _, ns := range namespaces {
 		if ns.GetName() == operatorGroup.Namespace {
 			continue
 		}
+		if targets.IsAllNamespaces() && (copiedCSVsAreExcludedFrom(ns, exclusion) || !a.namespaceFeatureIsEnabled(ns, CopiedCSVsNamespaceAnnotationKey, copiedCSVsEnabled)) {
+			if err := a.deleteCopiedCSV(ctx, csv, ns.GetName()); err != nil {
+				a.logger.WithError(err).Debug("error deleting copy from target")
+			}
+			continue
+		}
+		if canaries != nil && targets.Contains(ns.GetName()) && !canaries.Contains(ns.GetName()) {
+			continue
+		}
 		if targets.Contains(ns.GetName()) {
 			var targetCSV *v1alpha1.ClusterServiceVersion
-			if targetCSV, err = a.copyToNamespace(&copyPrototype, csv.GetNamespace(), ns.GetName(), nonstatus, status); err != nil {
+			if targetCSV, err = a.copyToNamespace(ctx, &copyPrototype, csv.GetNamespace(), ns.GetName(), nonstatus, status); err != nil {
 				a.logger.WithError(err).Debug("error copying to target")
+				failures[ns.GetName()] = copyFailureFor(err)
 				continue
 			}
 			targetCSVs[ns.GetName()] = targetCSV
@@ -724,18 +750,21 @@ func (a *Operator) ensureCSVsInNamespaces(csv *v1alpha1.ClusterServiceVersion, o
 	targetNamespaces := operatorGroup.Status.Namespaces
 	if targetNamespaces == nil {
 		a.logger.Errorf("operatorgroup '%v' should have non-nil status", operatorGroup.GetName())
-		return nil
+		return failures, nil
 	}
 	if len(targetNamespaces) == 1 && targetNamespaces[0] == corev1.NamespaceAll {
 		// global operator group handled by ensureRBACInTargetNamespace
-		return nil
+		return failures, nil
 	}
 	for _, ns := range targetNamespaces {
+		if canaries != nil && !canaries.Contains(ns) {
+			continue
+		}
 		// create roles/rolebindings for each target namespace
-		permMet, _, err := a.permissionStatus(strategyDetailsDeployment, ruleChecker, ns, csv)
+		permMet, _, err := a.permissionStatus(ctx, strategyDetailsDeployment, ruleChecker, ns, csv)
 		if err != nil {
 			logger.WithError(err).Debug("permission status")
-			return err
+			return failures, err
 		}
 		logger.WithField("target", ns).WithField("permMet", permMet).Debug("permission status")
 
@@ -749,16 +778,19 @@ func (a *Operator) ensureCSVsInNamespaces(csv *v1alpha1.ClusterServiceVersion, o
 
 		targetCSV, ok := targetCSVs[ns]
 		if !ok {
-			return fmt.Errorf("bug: no target CSV for namespace %v", ns)
+			if _, failed := failures[ns]; failed {
+				continue
+			}
+			return failures, fmt.Errorf("bug: no target CSV for namespace %v", ns)
 		}
-		if err := a.ensureTenantRBAC(operatorGroup.GetNamespace(), ns, csv, targetCSV); err != nil {
+		if err := a.ensureTenantRBAC(ctx, operatorGroup.GetNamespace(), ns, csv, targetCSV); err != nil {
 			logger.WithError(err).Debug("ensuring tenant rbac")
-			return err
+			return failures, err
 		}
 		logger.Debug("permissions created")
 	}
 
-	return nil
+	return failures, nil
 }
 
 // copyableCSVHash returns a hash of the parts of the given CSV that
@@ -786,7 +818,7 @@ func copyableCSVHash(original *v1alpha1.ClusterServiceVersion) (string, string)
 
 // If returned error is not nil, the returned ClusterServiceVersion
 // has only the Name, Namespace, and UID fields set.
-func (a *Operator) copyToNamespace(prototype *v1alpha1.ClusterServiceVersion, nsFrom, nsTo, nonstatus, status string) (*v1alpha1.ClusterServiceVersion, error) {
+func (a *Operator) copyToNamespace(ctx context.Context, prototype *v1alpha1.ClusterServiceVersion, nsFrom, nsTo, nonstatus, status string) (*v1alpha1.ClusterServiceVersion, error) {
 	if nsFrom == nsTo {
 		return nil, fmt.Errorf("bug: can not copy to active namespace %v", nsFrom)
 	}
@@ -797,12 +829,12 @@ func (a *Operator) copyToNamespace(prototype *v1alpha1.ClusterServiceVersion, ns
 
 	existing, err := a.copiedCSVLister.ClusterServiceVersions(nsTo).Get(prototype.GetName())
 	if k8serrors.IsNotFound(err) {
-		created, err := a.client.OperatorsV1alpha1().ClusterServiceVersions(nsTo).Create(context.TODO(), prototype, metav1.CreateOptions{})
+		created, err := a.client.OperatorsV1alpha1().ClusterServiceVersions(nsTo).Create(ctx, prototype, metav1.CreateOptions{})
 		if err != nil {
 			return nil, err
 		}
 		created.Status = prototype.Status
-		if _, err := a.client.OperatorsV1alpha1().ClusterServiceVersions(nsTo).UpdateStatus(context.TODO(), created, metav1.UpdateOptions{}); err != nil {
+		if _, err := a.client.OperatorsV1alpha1().ClusterServiceVersions(nsTo).UpdateStatus(ctx, created, metav1.UpdateOptions{}); err != nil {
 			return nil, err
 		}
 		return &v1alpha1.ClusterServiceVersion{
@@ -823,7 +855,7 @@ func (a *Operator) copyToNamespace(prototype *v1alpha1.ClusterServiceVersion, ns
 	existingStatus := existing.Annotations["$copyhash-status"]
 
 	if existingNonStatus != nonstatus {
-		if existing, err = a.client.OperatorsV1alpha1().ClusterServiceVersions(nsTo).Update(context.TODO(), prototype, metav1.UpdateOptions{}); err != nil {
+		if existing, err = a.client.OperatorsV1alpha1().ClusterServiceVersions(nsTo).Update(ctx, prototype, metav1.UpdateOptions{}); err != nil {
 			return nil, err
 		}
 	} else {
@@ -833,7 +865,7 @@ func (a *Operator) copyToNamespace(prototype *v1alpha1.ClusterServiceVersion, ns
 
 	if existingStatus != status {
 		existing.Status = prototype.Status
-		if _, err = a.client.OperatorsV1alpha1().ClusterServiceVersions(nsTo).UpdateStatus(context.TODO(), existing, metav1.UpdateOptions{}); err != nil {
+		if _, err = a.client.OperatorsV1alpha1().ClusterServiceVersions(nsTo).UpdateStatus(ctx, existing, metav1.UpdateOptions{}); err != nil {
 			return nil, err
 		}
 	}
@@ -846,6 +878,26 @@ func (a *Operator) copyToNamespace(prototype *v1alpha1.ClusterServiceVersion, ns
 	}, nil
 }
 
+// deleteCopiedCSV deletes the copy of the given CSV from the given namespace, if any.
+func (a *Operator) deleteCopiedCSV(ctx context.Context, csv *v1alpha1.ClusterServiceVersion, namespace string) error {
+	copiedCSV, err := a.copiedCSVLister.ClusterServiceVersions(namespace).Get(csv.GetName())
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if copiedCSV.GetLabels()[v1alpha1.CopiedLabelKey] != csv.GetNamespace() {
+		return nil
+	}
+
+	err = a.client.OperatorsV1alpha1().ClusterServiceVersions(namespace).Delete(ctx, copiedCSV.GetName(), metav1.DeleteOptions{})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
 func (a *Operator) pruneFromNamespace(operatorGroupName, namespace string) error {
 	fetchedCSVs, err := a.copiedCSVLister.ClusterServiceVersions(namespace).List(labels.Everything())
 	if err != nil {
@@ -975,7 +1027,7 @@ func (a *Operator) updateNamespaceList(op *v1.OperatorGroup) ([]string, error) {
 	return namespaceList, nil
 }
 
-func (a *Operator) ensureOpGroupClusterRole(op *v1.OperatorGroup, suffix string, apis cache.APISet) error {
+func (a *Operator) ensureOpGroupClusterRole(ctx context.Context, op *v1.OperatorGroup, suffix string, apis cache.APISet) error {
 	clusterRole := &rbacv1.ClusterRole{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: strings.Join([]string{op.GetName(), suffix}, "-"),
@@ -1008,7 +1060,7 @@ func (a *Operator) ensureOpGroupClusterRole(op *v1.OperatorGroup, suffix string,
 		return err
 	}
 	if k8serrors.IsNotFound(err) {
-		existingRole, err = a.opClient.KubernetesInterface().RbacV1().ClusterRoles().Create(context.TODO(), clusterRole, metav1.CreateOptions{})
+		existingRole, err = a.opClient.KubernetesInterface().RbacV1().ClusterRoles().Create(ctx, clusterRole, metav1.CreateOptions{})
 		if err == nil {
 			return nil
 		}
@@ -1022,16 +1074,16 @@ func (a *Operator) ensureOpGroupClusterRole(op *v1.OperatorGroup, suffix string,
 		return nil
 	}
 
-	if _, err := a.opClient.UpdateClusterRole(clusterRole); err != nil {
+	if _, err := a.opClient.UpdateClusterRole(ctx, clusterRole); err != nil {
 		a.logger.WithError(err).Errorf("Update existing cluster role failed: %v", clusterRole)
 		return err
 	}
 	return nil
 }
 
-func (a *Operator) ensureOpGroupClusterRoles(op *v1.OperatorGroup, apis cache.APISet) error {
+func (a *Operator) ensureOpGroupClusterRoles(ctx context.Context, op *v1.OperatorGroup, apis cache.APISet) error {
 	for _, suffix := range Suffices {
-		if err := a.ensureOpGroupClusterRole(op, suffix, apis); err != nil {
+		if err := a.ensureOpGroupClusterRole(ctx, op, suffix, apis); err != nil {
 			return err
 		}
 	}
@@ -1111,6 +1163,9 @@ func csvCopyPrototype(src, dst *v1alpha1.ClusterServiceVersion) {
 		if k == "kubectl.kubernetes.io/last-applied-configuration" {
 			continue // big
 		}
+		if k == CopyFailuresAnnotationKey {
+			continue
+		}
 		dst.Annotations[k] = v
 	}
 	for k, v := range src.Labels {
@@ -1,6 +1,7 @@
 package olm
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
@@ -312,7 +313,7 @@ func TestCopyToNamespace(t *testing.T) {
 				logger:          logger,
 			}
 
-			result, err := o.copyToNamespace(tc.Prototype.DeepCopy(), tc.FromNamespace, tc.ToNamespace, tc.Hash, tc.StatusHash)
+			result, err := o.copyToNamespace(context.TODO(), tc.Prototype.DeepCopy(), tc.FromNamespace, tc.ToNamespace, tc.Hash, tc.StatusHash)
 
 			if tc.ExpectedError == nil {
 				require.NoError(t, err)
@@ -0,0 +1,45 @@
+package overrides
+
+import (
+	"context"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/imagearch"
+)
+
+// ArchitectureAffinityAnnotationKey is the annotation opting the deployments of a CSV into being scheduled on the
+// architectures their images support, like OLM's own infrastructure pods. OLM's packageserver sets it to "true".
+const ArchitectureAffinityAnnotationKey = "operatorframework.io/architecture-affinity"
+
+// architectureLookupTimeout bounds the lookup of the architectures supported by the images of a deployment.
+const architectureLookupTimeout = 10 * time.Second
+
+// architectureAffinityEnabled returns true if the deployments of the CSV with the given annotations are scheduled on
+// the architectures their images support.
+func architectureAffinityEnabled(csvAnnotations map[string]string) bool {
+	return csvAnnotations[ArchitectureAffinityAnnotationKey] == "true"
+}
+
+// injectArchitectureAffinity requires the pods of the given spec to be scheduled on the architectures supported by
+// all of their images, unless overridden by the cluster OLMConfig. Pods are scheduled on any architecture if they
+// can't be looked up.
+func (d *DeploymentInitializer) injectArchitectureAffinity(olmConfigAnnotations map[string]string, podSpec *corev1.PodSpec) {
+	var images []string
+	for _, c := range podSpec.InitContainers {
+		images = append(images, c.Image)
+	}
+	for _, c := range podSpec.Containers {
+		images = append(images, c.Image)
+	}
+
+	ctx, cancel := context.WithTimeout(context.TODO(), architectureLookupTimeout)
+	defer cancel()
+	architectures, err := d.architectures.Required(ctx, olmConfigAnnotations, images...)
+	if err != nil {
+		d.logger.WithError(err).WithField("images", images).Warn("scheduling deployment on any architecture")
+		return
+	}
+	imagearch.SetAffinity(podSpec, architectures)
+}
@@ -0,0 +1,57 @@
+package overrides
+
+import (
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/operators/olm/overrides/inject"
+)
+
+// SchedulingDefaultsAnnotationKey is the annotation of the cluster OLMConfig holding, as JSON SchedulingDefaults, the
+// scheduling constraints applied to the pods of all operator deployments, e.g. to confine operators to infra nodes.
+const SchedulingDefaultsAnnotationKey = "operatorframework.io/default-scheduling"
+
+// SchedulingDefaults are the cluster wide defaults of the scheduling constraints of operator pods. The config of the
+// Subscription of an operator takes precedence over them.
+type SchedulingDefaults struct {
+	// NodeSelector applies to pods without a node selector.
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	// Tolerations are added to the tolerations of pods.
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+	// PriorityClassName applies to pods without a priority class.
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+}
+
+// SchedulingDefaultsFor returns the scheduling defaults set by the given OLMConfig annotations, if any.
+func SchedulingDefaultsFor(annotations map[string]string) (*SchedulingDefaults, error) {
+	value, ok := annotations[SchedulingDefaultsAnnotationKey]
+	if !ok {
+		return nil, nil
+	}
+	defaults := &SchedulingDefaults{}
+	if err := json.Unmarshal([]byte(value), defaults); err != nil {
+		return nil, fmt.Errorf("invalid %s annotation: %v", SchedulingDefaultsAnnotationKey, err)
+	}
+	return defaults, nil
+}
+
+// apply applies the scheduling defaults to the given pod spec.
+func (s *SchedulingDefaults) apply(podSpec *corev1.PodSpec) error {
+	if s == nil {
+		return nil
+	}
+	if len(podSpec.NodeSelector) == 0 && len(s.NodeSelector) > 0 {
+		if err := inject.InjectNodeSelectorIntoDeployment(podSpec, s.NodeSelector); err != nil {
+			return err
+		}
+	}
+	if err := inject.InjectTolerationsIntoDeployment(podSpec, s.Tolerations); err != nil {
+		return err
+	}
+	if podSpec.PriorityClassName == "" {
+		podSpec.PriorityClassName = s.PriorityClassName
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package overrides
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestSchedulingDefaults(t *testing.T) {
+	defaults, err := SchedulingDefaultsFor(map[string]string{
+		SchedulingDefaultsAnnotationKey: `{"nodeSelector":{"node-role.kubernetes.io/infra":""},"tolerations":[{"key":"infra","operator":"Exists","effect":"NoSchedule"}],"priorityClassName":"system-cluster-critical"}`,
+	})
+	require.NoError(t, err)
+
+	infra := corev1.Toleration{Key: "infra", Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoSchedule}
+	podSpec := &corev1.PodSpec{}
+	require.NoError(t, defaults.apply(podSpec))
+	require.Equal(t, &corev1.PodSpec{
+		NodeSelector:      map[string]string{"node-role.kubernetes.io/infra": ""},
+		Tolerations:       []corev1.Toleration{infra},
+		PriorityClassName: "system-cluster-critical",
+	}, podSpec)
+
+	// Pods keep their own constraints, and gain the default tolerations
+	master := corev1.Toleration{Key: "master", Operator: corev1.TolerationOpExists}
+	podSpec = &corev1.PodSpec{
+		NodeSelector:      map[string]string{"kubernetes.io/os": "linux"},
+		Tolerations:       []corev1.Toleration{master},
+		PriorityClassName: "operators",
+	}
+	require.NoError(t, defaults.apply(podSpec))
+	require.Equal(t, map[string]string{"kubernetes.io/os": "linux"}, podSpec.NodeSelector)
+	require.Equal(t, []corev1.Toleration{master, infra}, podSpec.Tolerations)
+	require.Equal(t, "operators", podSpec.PriorityClassName)
+
+	defaults, err = SchedulingDefaultsFor(nil)
+	require.NoError(t, err)
+	require.NoError(t, defaults.apply(podSpec))
+
+	_, err = SchedulingDefaultsFor(map[string]string{SchedulingDefaultsAnnotationKey: "infra"})
+	require.Error(t, err)
+}
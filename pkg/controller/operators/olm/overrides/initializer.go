@@ -5,6 +5,7 @@ import (
 
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/install"
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/operators/olm/overrides/inject"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/imagearch"
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/operatorlister"
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/ownerutil"
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/proxy"
@@ -15,10 +16,13 @@ import (
 
 // NewDeploymentInitializer returns a function that accepts a Deployment object
 // and initializes it with env variables specified in operator configuration.
-func NewDeploymentInitializer(logger *logrus.Logger, querier proxy.Querier, lister operatorlister.OperatorLister) *DeploymentInitializer {
+// Cluster-wide settings are read from the OLMConfig annotations returned by olmConfigAnnotations.
+func NewDeploymentInitializer(logger *logrus.Logger, querier proxy.Querier, lister operatorlister.OperatorLister, olmConfigAnnotations func() map[string]string) *DeploymentInitializer {
 	return &DeploymentInitializer{
-		logger:  logger,
-		querier: querier,
+		logger:               logger,
+		querier:              querier,
+		olmConfigAnnotations: olmConfigAnnotations,
+		architectures:        imagearch.NewResolver(),
 		config: &operatorConfig{
 			lister: lister,
 			logger: logger,
@@ -27,9 +31,11 @@ func NewDeploymentInitializer(logger *logrus.Logger, querier proxy.Querier, list
 }
 
 type DeploymentInitializer struct {
-	logger  *logrus.Logger
-	querier proxy.Querier
-	config  *operatorConfig
+	logger               *logrus.Logger
+	querier              proxy.Querier
+	olmConfigAnnotations func() map[string]string
+	architectures        *imagearch.Resolver
+	config               *operatorConfig
 }
 
 func (d *DeploymentInitializer) GetDeploymentInitializer(ownerCSV ownerutil.Owner) install.DeploymentInitializerFunc {
@@ -67,7 +73,16 @@ func (d *DeploymentInitializer) initialize(ownerCSV ownerutil.Owner, deployment
 		d.logger.WithField("csv", ownerCSV.GetName()).Debug("no env var to inject into csv")
 	}
 
+	var olmConfigAnnotations map[string]string
+	if d.olmConfigAnnotations != nil {
+		olmConfigAnnotations = d.olmConfigAnnotations()
+	}
+
 	podSpec := &deployment.Spec.Template.Spec
+	if err := d.schedulingDefaults(olmConfigAnnotations).apply(podSpec); err != nil {
+		return fmt.Errorf("failed to apply scheduling defaults to deployment spec name=%s - %v", deployment.Name, err)
+	}
+
 	if err := inject.InjectEnvIntoDeployment(podSpec, merged); err != nil {
 		return fmt.Errorf("failed to inject proxy env variable(s) into deployment spec name=%s - %v", deployment.Name, err)
 	}
@@ -92,9 +107,28 @@ func (d *DeploymentInitializer) initialize(ownerCSV ownerutil.Owner, deployment
 		return fmt.Errorf("failed to inject nodeSelector into deployment spec name=%s - %v", deployment.Name, err)
 	}
 
+	if restrictedSecurityContextEnabled(olmConfigAnnotations, ownerCSV.GetAnnotations()) {
+		injectRestrictedSecurityContext(podSpec)
+	}
+
+	if architectureAffinityEnabled(ownerCSV.GetAnnotations()) {
+		d.injectArchitectureAffinity(olmConfigAnnotations, podSpec)
+	}
+
 	return nil
 }
 
+// schedulingDefaults returns the scheduling defaults set by the annotations of the cluster OLMConfig. Invalid defaults
+// are ignored.
+func (d *DeploymentInitializer) schedulingDefaults(olmConfigAnnotations map[string]string) *SchedulingDefaults {
+	defaults, err := SchedulingDefaultsFor(olmConfigAnnotations)
+	if err != nil {
+		d.logger.WithError(err).Warn("ignoring invalid scheduling defaults set on olmConfig")
+		return nil
+	}
+	return defaults
+}
+
 func dropEmptyProxyEnv(in []corev1.EnvVar) (out []corev1.EnvVar) {
 	out = make([]corev1.EnvVar, 0)
 	for i := range in {
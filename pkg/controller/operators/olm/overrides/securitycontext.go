@@ -0,0 +1,58 @@
+package overrides
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+// RestrictedSecurityContextAnnotationKey is the annotation opting operator deployments into, or out of, the
+// injection of a securityContext complying with the restricted Pod Security Standard. Set to "true" on the cluster
+// OLMConfig, it opts all operators in. Set to "false" on a CSV, it opts that operator out.
+const RestrictedSecurityContextAnnotationKey = "operatorframework.io/restricted-security-context"
+
+// restrictedSecurityContextEnabled returns true if the restricted securityContext is injected into the deployments of
+// the CSV with the given annotations.
+func restrictedSecurityContextEnabled(olmConfigAnnotations, csvAnnotations map[string]string) bool {
+	return olmConfigAnnotations[RestrictedSecurityContextAnnotationKey] == "true" && csvAnnotations[RestrictedSecurityContextAnnotationKey] != "false"
+}
+
+// injectRestrictedSecurityContext completes the securityContexts of the given pod spec to comply with the restricted
+// Pod Security Standard: the pod runs as non-root with the runtime default seccomp profile, and its containers can't
+// escalate privileges and drop all capabilities. Settings made by the pod spec are kept.
+func injectRestrictedSecurityContext(podSpec *corev1.PodSpec) {
+	if podSpec.SecurityContext == nil {
+		podSpec.SecurityContext = &corev1.PodSecurityContext{}
+	}
+	if podSpec.SecurityContext.RunAsNonRoot == nil {
+		runAsNonRoot := true
+		podSpec.SecurityContext.RunAsNonRoot = &runAsNonRoot
+	}
+	if podSpec.SecurityContext.SeccompProfile == nil {
+		podSpec.SecurityContext.SeccompProfile = &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault}
+	}
+
+	for i := range podSpec.InitContainers {
+		restrictContainer(&podSpec.InitContainers[i])
+	}
+	for i := range podSpec.Containers {
+		restrictContainer(&podSpec.Containers[i])
+	}
+}
+
+func restrictContainer(container *corev1.Container) {
+	if container.SecurityContext == nil {
+		container.SecurityContext = &corev1.SecurityContext{}
+	}
+	if container.SecurityContext.AllowPrivilegeEscalation == nil {
+		allowPrivilegeEscalation := false
+		container.SecurityContext.AllowPrivilegeEscalation = &allowPrivilegeEscalation
+	}
+	if container.SecurityContext.Capabilities == nil {
+		container.SecurityContext.Capabilities = &corev1.Capabilities{}
+	}
+	for _, c := range container.SecurityContext.Capabilities.Drop {
+		if c == "ALL" {
+			return
+		}
+	}
+	container.SecurityContext.Capabilities.Drop = append(container.SecurityContext.Capabilities.Drop, "ALL")
+}
@@ -0,0 +1,50 @@
+package overrides
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestRestrictedSecurityContextEnabled(t *testing.T) {
+	enabled := map[string]string{RestrictedSecurityContextAnnotationKey: "true"}
+	require.False(t, restrictedSecurityContextEnabled(nil, nil), "opt-in")
+	require.True(t, restrictedSecurityContextEnabled(enabled, nil))
+	require.False(t, restrictedSecurityContextEnabled(enabled, map[string]string{RestrictedSecurityContextAnnotationKey: "false"}), "csvs opt out")
+}
+
+func TestInjectRestrictedSecurityContext(t *testing.T) {
+	yes, no := true, false
+	podSpec := &corev1.PodSpec{
+		InitContainers: []corev1.Container{{Name: "init"}},
+		Containers: []corev1.Container{
+			{Name: "operator"},
+			{Name: "proxy", SecurityContext: &corev1.SecurityContext{
+				AllowPrivilegeEscalation: &yes,
+				Capabilities:             &corev1.Capabilities{Add: []corev1.Capability{"NET_BIND_SERVICE"}, Drop: []corev1.Capability{"ALL"}},
+			}},
+		},
+	}
+	injectRestrictedSecurityContext(podSpec)
+
+	require.Equal(t, &corev1.PodSecurityContext{
+		RunAsNonRoot:   &yes,
+		SeccompProfile: &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+	}, podSpec.SecurityContext)
+	restricted := &corev1.SecurityContext{
+		AllowPrivilegeEscalation: &no,
+		Capabilities:             &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}},
+	}
+	require.Equal(t, restricted, podSpec.InitContainers[0].SecurityContext)
+	require.Equal(t, restricted, podSpec.Containers[0].SecurityContext)
+	require.Equal(t, &corev1.SecurityContext{
+		AllowPrivilegeEscalation: &yes,
+		Capabilities:             &corev1.Capabilities{Add: []corev1.Capability{"NET_BIND_SERVICE"}, Drop: []corev1.Capability{"ALL"}},
+	}, podSpec.Containers[1].SecurityContext, "settings of the pod spec are kept")
+
+	// Pods opting out of the restrictions keep their settings
+	podSpec = &corev1.PodSpec{SecurityContext: &corev1.PodSecurityContext{RunAsNonRoot: &no}}
+	injectRestrictedSecurityContext(podSpec)
+	require.False(t, *podSpec.SecurityContext.RunAsNonRoot)
+}
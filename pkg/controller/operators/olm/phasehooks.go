@@ -0,0 +1,208 @@
+package olm
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+)
+
+const (
+	// PhaseHooksAnnotationKey is the annotation of the cluster OLMConfig registering, as a JSON-encoded list of
+	// PhaseHook, the HTTP callbacks OLM invokes when a CSV transitions between phases, so that external systems can
+	// track installs without watching every CSV.
+	PhaseHooksAnnotationKey = "operatorframework.io/phase-hooks"
+
+	// PhaseHookSecretKey is the key of the hook Secret holding the key the payloads are signed with.
+	PhaseHookSecretKey = "key"
+
+	// PhaseHookNameHeader and PhaseHookSignatureHeader are the headers of the requests made to phase hooks holding
+	// the name of the hook and the "sha256=" prefixed, hex-encoded HMAC-SHA256 of the request body.
+	PhaseHookNameHeader      = "X-OLM-Hook"
+	PhaseHookSignatureHeader = "X-OLM-Signature"
+
+	// PhaseHookFailedReason is the reason of the event recorded on a CSV when one of its phase transitions couldn't
+	// be delivered to a hook.
+	PhaseHookFailedReason = "PhaseHookFailed"
+
+	phaseHookTimeout = 10 * time.Second
+)
+
+// PhaseHook is an HTTP callback invoked on CSV phase transitions.
+type PhaseHook struct {
+	Name string `json:"name"`
+	// URL is the URL the phase transitions are POSTed to.
+	URL string `json:"url"`
+	// SecretName is the name of the Secret, in the namespace of OLM, holding the key the payloads are signed with.
+	SecretName string `json:"secretName"`
+	// Phases restricts the hook to transitions into the given phases. All transitions are sent if empty.
+	Phases []v1alpha1.ClusterServiceVersionPhase `json:"phases,omitempty"`
+}
+
+func (h PhaseHook) wants(phase v1alpha1.ClusterServiceVersionPhase) bool {
+	if len(h.Phases) == 0 {
+		return true
+	}
+	for _, p := range h.Phases {
+		if p == phase {
+			return true
+		}
+	}
+	return false
+}
+
+// PhaseTransition is the payload sent to phase hooks.
+type PhaseTransition struct {
+	Name      string    `json:"name"`
+	Namespace string    `json:"namespace"`
+	UID       types.UID `json:"uid"`
+
+	Before  v1alpha1.ClusterServiceVersionPhase `json:"before"`
+	After   v1alpha1.ClusterServiceVersionPhase `json:"after"`
+	Reason  v1alpha1.ConditionReason            `json:"reason"`
+	Message string                              `json:"message,omitempty"`
+	Time    metav1.Time                         `json:"time"`
+
+	// Subscription is the name of the Subscription the CSV was installed through, if any.
+	Subscription string `json:"subscription,omitempty"`
+}
+
+// PhaseHooksFor returns the phase hooks registered with the given OLMConfig annotations.
+func PhaseHooksFor(annotations map[string]string) ([]PhaseHook, error) {
+	value, ok := annotations[PhaseHooksAnnotationKey]
+	if !ok {
+		return nil, nil
+	}
+	var hooks []PhaseHook
+	if err := json.Unmarshal([]byte(value), &hooks); err != nil {
+		return nil, fmt.Errorf("invalid %s annotation: %v", PhaseHooksAnnotationKey, err)
+	}
+	for _, h := range hooks {
+		if h.Name == "" || h.URL == "" || h.SecretName == "" {
+			return nil, fmt.Errorf("invalid %s annotation: hooks must have a name, url and secretName", PhaseHooksAnnotationKey)
+		}
+	}
+	return hooks, nil
+}
+
+// PhaseHookSignature returns the value of the signature header of a phase hook request with the given body, signed
+// with the given key. Receivers compare it to the header to authenticate requests.
+func PhaseHookSignature(key, body []byte) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// notifyPhaseHooks sends the phase transition of the given CSV to the hooks registered on the cluster OLMConfig. The
+// requests are made in the background and failures are only reported, so that a slow or unavailable hook never holds
+// up the install of a CSV.
+func (a *Operator) notifyPhaseHooks(ctx context.Context, logger *logrus.Entry, in, out *v1alpha1.ClusterServiceVersion) {
+	hooks, err := PhaseHooksFor(a.olmConfigAnnotations())
+	if err != nil {
+		logger.WithError(err).Warn("ignoring phase hooks")
+		return
+	}
+	if len(hooks) == 0 {
+		return
+	}
+
+	transitioned := a.now()
+	if t := out.Status.LastTransitionTime; t != nil {
+		transitioned = t
+	}
+	transition := PhaseTransition{
+		Name:         out.GetName(),
+		Namespace:    out.GetNamespace(),
+		UID:          out.GetUID(),
+		Before:       in.Status.Phase,
+		After:        out.Status.Phase,
+		Reason:       out.Status.Reason,
+		Message:      out.Status.Message,
+		Time:         transitioned.Rfc3339Copy(),
+		Subscription: a.owningSubscription(out),
+	}
+	body, err := json.Marshal(transition)
+	if err != nil {
+		logger.WithError(err).Warn("unable to encode phase transition")
+		return
+	}
+
+	for _, hook := range hooks {
+		if !hook.wants(out.Status.Phase) {
+			continue
+		}
+		secret, err := a.opClient.KubernetesInterface().CoreV1().Secrets(a.operatorNamespace).Get(ctx, hook.SecretName, metav1.GetOptions{})
+		if err != nil {
+			a.phaseHookFailed(logger, out, hook, fmt.Errorf("unable to get secret %s/%s: %v", a.operatorNamespace, hook.SecretName, err))
+			continue
+		}
+		key, ok := secret.Data[PhaseHookSecretKey]
+		if !ok || len(key) == 0 {
+			a.phaseHookFailed(logger, out, hook, fmt.Errorf("secret %s/%s has no %q key", a.operatorNamespace, hook.SecretName, PhaseHookSecretKey))
+			continue
+		}
+
+		go func(hook PhaseHook, key []byte) {
+			if err := a.deliverPhaseHook(hook, key, body); err != nil {
+				a.phaseHookFailed(logger, out, hook, err)
+			}
+		}(hook, key)
+	}
+}
+
+// deliverPhaseHook POSTs the given payload to the given hook, signed with the given key.
+func (a *Operator) deliverPhaseHook(hook PhaseHook, key, body []byte) error {
+	ctx, cancel := context.WithTimeout(context.Background(), phaseHookTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hook.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(PhaseHookNameHeader, hook.Name)
+	req.Header.Set(PhaseHookSignatureHeader, PhaseHookSignature(key, body))
+
+	resp, err := a.phaseHookClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected response status %s", resp.Status)
+	}
+	return nil
+}
+
+func (a *Operator) phaseHookFailed(logger *logrus.Entry, csv *v1alpha1.ClusterServiceVersion, hook PhaseHook, err error) {
+	logger.WithField("hook", hook.Name).WithError(err).Warn("unable to notify phase hook")
+	a.recorder.Eventf(csv, corev1.EventTypeWarning, PhaseHookFailedReason, "unable to notify phase hook %s of transition to %s: %v", hook.Name, csv.Status.Phase, err)
+}
+
+// owningSubscription returns the name of the Subscription that installed the given CSV, or the empty string if it
+// wasn't installed through a Subscription.
+func (a *Operator) owningSubscription(csv *v1alpha1.ClusterServiceVersion) string {
+	subs, err := a.lister.OperatorsV1alpha1().SubscriptionLister().Subscriptions(csv.GetNamespace()).List(labels.Everything())
+	if err != nil {
+		return ""
+	}
+	for _, sub := range subs {
+		if sub.Status.InstalledCSV == csv.GetName() || sub.Status.CurrentCSV == csv.GetName() {
+			return sub.GetName()
+		}
+	}
+	return ""
+}
@@ -0,0 +1,134 @@
+package olm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	operatorsv1 "github.com/operator-framework/api/pkg/operators/v1"
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+)
+
+func TestPhaseHooksFor(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		expected    []PhaseHook
+		expectedErr bool
+	}{
+		{name: "NoHooks"},
+		{
+			name:        "Hooks",
+			annotations: map[string]string{PhaseHooksAnnotationKey: `[{"name":"cmdb","url":"https://cmdb.example.com/olm","secretName":"cmdb-key","phases":["Succeeded","Failed"]}]`},
+			expected: []PhaseHook{
+				{Name: "cmdb", URL: "https://cmdb.example.com/olm", SecretName: "cmdb-key", Phases: []v1alpha1.ClusterServiceVersionPhase{v1alpha1.CSVPhaseSucceeded, v1alpha1.CSVPhaseFailed}},
+			},
+		},
+		{
+			name:        "Invalid",
+			annotations: map[string]string{PhaseHooksAnnotationKey: `{"name":"cmdb"}`},
+			expectedErr: true,
+		},
+		{
+			name:        "Unsigned",
+			annotations: map[string]string{PhaseHooksAnnotationKey: `[{"name":"cmdb","url":"https://cmdb.example.com/olm"}]`},
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hooks, err := PhaseHooksFor(tt.annotations)
+			if tt.expectedErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, hooks)
+		})
+	}
+}
+
+func TestNotifyPhaseHooks(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+
+	type request struct {
+		header http.Header
+		body   []byte
+	}
+	requests := make(chan request, 2)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		requests <- request{header: r.Header, body: body}
+	}))
+	defer server.Close()
+
+	hooks := fmt.Sprintf(`[{"name":"cmdb","url":%q,"secretName":"cmdb-key"},{"name":"failures","url":%q,"secretName":"cmdb-key","phases":["Failed"]}]`, server.URL, server.URL)
+	olmConfig := &operatorsv1.OLMConfig{ObjectMeta: metav1.ObjectMeta{
+		Name:        "cluster",
+		Annotations: map[string]string{PhaseHooksAnnotationKey: hooks},
+	}}
+	sub := &v1alpha1.Subscription{
+		ObjectMeta: metav1.ObjectMeta{Name: "sub", Namespace: "ns"},
+		Status:     v1alpha1.SubscriptionStatus{CurrentCSV: "csv1", InstalledCSV: "csv1"},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "cmdb-key", Namespace: "olm"},
+		Data:       map[string][]byte{PhaseHookSecretKey: []byte("s3cr3t")},
+	}
+	op, err := NewFakeOperator(ctx, withNamespaces("ns"), withOperatorNamespace("olm"), withClientObjs([]runtime.Object{olmConfig, sub}...), withK8sObjs(secret))
+	require.NoError(t, err)
+
+	in := &v1alpha1.ClusterServiceVersion{
+		ObjectMeta: metav1.ObjectMeta{Name: "csv1", Namespace: "ns", UID: "csv-uid"},
+		Status:     v1alpha1.ClusterServiceVersionStatus{Phase: v1alpha1.CSVPhaseInstalling},
+	}
+	out := in.DeepCopy()
+	transitioned := metav1.Date(2021, time.March, 1, 12, 0, 0, 0, time.Local)
+	out.SetPhase(v1alpha1.CSVPhaseSucceeded, v1alpha1.CSVReasonInstallSuccessful, "install strategy completed with no errors", &transitioned)
+
+	op.notifyPhaseHooks(context.TODO(), logrus.NewEntry(op.logger), in, out)
+
+	var received request
+	select {
+	case received = <-requests:
+	case <-time.After(5 * time.Second):
+		t.Fatal("phase hook wasn't invoked")
+	}
+	require.Equal(t, "cmdb", received.header.Get(PhaseHookNameHeader))
+	require.Equal(t, PhaseHookSignature([]byte("s3cr3t"), received.body), received.header.Get(PhaseHookSignatureHeader))
+
+	var transition PhaseTransition
+	require.NoError(t, json.Unmarshal(received.body, &transition))
+	require.Equal(t, PhaseTransition{
+		Name:         "csv1",
+		Namespace:    "ns",
+		UID:          "csv-uid",
+		Before:       v1alpha1.CSVPhaseInstalling,
+		After:        v1alpha1.CSVPhaseSucceeded,
+		Reason:       v1alpha1.CSVReasonInstallSuccessful,
+		Message:      "install strategy completed with no errors",
+		Time:         transitioned,
+		Subscription: "sub",
+	}, transition)
+
+	// The hook restricted to failures isn't invoked
+	select {
+	case received = <-requests:
+		t.Fatalf("unexpected phase hook invocation: %s", received.header.Get(PhaseHookNameHeader))
+	case <-time.After(100 * time.Millisecond):
+	}
+}
@@ -0,0 +1,227 @@
+package olm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/ownerutil"
+)
+
+const (
+	// PreDeleteJobAnnotationKey is the CSV annotation declaring, as a JSON-encoded JobSpec, a Job that is run to
+	// completion when the CSV is deleted, before its deployments and RBAC are torn down, so that the operator can
+	// deprovision the external resources it manages.
+	PreDeleteJobAnnotationKey = "operatorframework.io/pre-delete-job"
+
+	// PreDeleteJobTimeoutAnnotationKey is the CSV annotation setting how long, as a duration from the deletion of the
+	// CSV, the pre-delete Job may take to be created and run before it's considered failed. Defaults to
+	// defaultPreDeleteJobTimeout.
+	PreDeleteJobTimeoutAnnotationKey = "operatorframework.io/pre-delete-job-timeout"
+
+	// PreDeleteJobFailurePolicyAnnotationKey is the CSV annotation setting what happens when the pre-delete Job fails
+	// or times out: with PreDeleteJobFailurePolicyIgnore, the default, the CSV is deleted anyway, and with
+	// PreDeleteJobFailurePolicyBlock, the CSV is kept until the failure is resolved or the finalizer removed by hand.
+	PreDeleteJobFailurePolicyAnnotationKey = "operatorframework.io/pre-delete-job-failure-policy"
+
+	PreDeleteJobFailurePolicyIgnore = "Ignore"
+	PreDeleteJobFailurePolicyBlock  = "Block"
+
+	// PreDeleteJobFinalizer is the finalizer holding the deletion of CSVs declaring a pre-delete Job until the Job has
+	// run.
+	PreDeleteJobFinalizer = "operatorframework.io/pre-delete-job"
+
+	defaultPreDeleteJobTimeout  = 5 * time.Minute
+	preDeleteJobRequeueInterval = 10 * time.Second
+	preDeleteJobNameSuffix      = "-pre-delete"
+
+	// defaultPreDeleteJobTTL is how long finished pre-delete Jobs are kept for inspection unless the declared JobSpec
+	// says otherwise.
+	defaultPreDeleteJobTTL int32 = 60 * 60
+)
+
+// hasPreDeleteJob returns true if the given CSV declares a pre-delete Job.
+func hasPreDeleteJob(csv *v1alpha1.ClusterServiceVersion) bool {
+	_, ok := csv.GetAnnotations()[PreDeleteJobAnnotationKey]
+	return ok
+}
+
+func hasPreDeleteJobFinalizer(csv *v1alpha1.ClusterServiceVersion) bool {
+	for _, f := range csv.GetFinalizers() {
+		if f == PreDeleteJobFinalizer {
+			return true
+		}
+	}
+	return false
+}
+
+// syncPreDeleteJobFinalizer adds the pre-delete Job finalizer to CSVs declaring a pre-delete Job and removes it from
+// CSVs that no longer do. It returns true if the CSV was updated.
+func (a *Operator) syncPreDeleteJobFinalizer(ctx context.Context, csv *v1alpha1.ClusterServiceVersion) (bool, error) {
+	if hasPreDeleteJob(csv) == hasPreDeleteJobFinalizer(csv) {
+		return false, nil
+	}
+
+	out := csv.DeepCopy()
+	if hasPreDeleteJob(csv) {
+		out.SetFinalizers(append(out.GetFinalizers(), PreDeleteJobFinalizer))
+	} else {
+		out.SetFinalizers(removeFinalizer(out.GetFinalizers(), PreDeleteJobFinalizer))
+	}
+	_, err := a.client.OperatorsV1alpha1().ClusterServiceVersions(out.GetNamespace()).Update(ctx, out, metav1.UpdateOptions{})
+	return err == nil, err
+}
+
+// handlePreDeleteJob runs the pre-delete Job of the given CSV, which is being deleted, and removes the pre-delete Job
+// finalizer once the Job has completed, or has failed under the Ignore failure policy. CSVs deleted because they've
+// been replaced by an upgrade don't run their Job, since the operator isn't being uninstalled.
+func (a *Operator) handlePreDeleteJob(ctx context.Context, logger *logrus.Entry, csv *v1alpha1.ClusterServiceVersion) error {
+	logger = logger.WithField("job", preDeleteJobName(csv.GetName()))
+
+	done, err := a.runPreDeleteJob(ctx, logger, csv)
+	if err != nil {
+		return err
+	}
+	if !done {
+		return a.csvQueueSet.RequeueAfter(csv.GetNamespace(), csv.GetName(), preDeleteJobRequeueInterval)
+	}
+
+	out := csv.DeepCopy()
+	out.SetFinalizers(removeFinalizer(out.GetFinalizers(), PreDeleteJobFinalizer))
+	_, err = a.client.OperatorsV1alpha1().ClusterServiceVersions(out.GetNamespace()).Update(ctx, out, metav1.UpdateOptions{})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// runPreDeleteJob creates the pre-delete Job of the given CSV if it doesn't exist, and returns true once the CSV can be
+// deleted.
+func (a *Operator) runPreDeleteJob(ctx context.Context, logger *logrus.Entry, csv *v1alpha1.ClusterServiceVersion) (bool, error) {
+	if csv.Status.Phase == v1alpha1.CSVPhaseReplacing || csv.Status.Phase == v1alpha1.CSVPhaseDeleting {
+		logger.Debug("csv has been replaced, skipping pre-delete job")
+		return true, nil
+	}
+
+	block := csv.GetAnnotations()[PreDeleteJobFailurePolicyAnnotationKey] == PreDeleteJobFailurePolicyBlock
+	fail := func(reason, message string) (bool, error) {
+		a.recorder.Event(csv, corev1.EventTypeWarning, reason, message)
+		if block {
+			logger.Warn(message + ", blocking csv deletion")
+			return false, nil
+		}
+		logger.Warn(message + ", ignoring")
+		return true, nil
+	}
+
+	job, err := preDeleteJob(csv)
+	if err != nil {
+		return fail("PreDeleteJobInvalid", err.Error())
+	}
+	timeout := defaultPreDeleteJobTimeout
+	if value, ok := csv.GetAnnotations()[PreDeleteJobTimeoutAnnotationKey]; ok {
+		if timeout, err = time.ParseDuration(value); err != nil {
+			return fail("PreDeleteJobInvalid", fmt.Sprintf("invalid %s annotation: %v", PreDeleteJobTimeoutAnnotationKey, err))
+		}
+	}
+
+	// The timeout runs from the deletion of the CSV rather than the creation of the Job, which may never be created,
+	// e.g. in a terminating namespace
+	deleted := a.now().Time
+	if csv.GetDeletionTimestamp() != nil {
+		deleted = csv.GetDeletionTimestamp().Time
+	}
+	timedOut := a.now().Time.Sub(deleted) > timeout
+
+	jobs := a.opClient.KubernetesInterface().BatchV1().Jobs(csv.GetNamespace())
+	existing, err := jobs.Get(ctx, job.GetName(), metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		logger.Info("running pre-delete job")
+		_, err = jobs.Create(ctx, job, metav1.CreateOptions{})
+		switch {
+		case err == nil, k8serrors.IsAlreadyExists(err):
+			return false, nil
+		case k8serrors.IsForbidden(err):
+			// Jobs can't be created in terminating namespaces, which retrying won't change
+			return fail("PreDeleteJobFailed", fmt.Sprintf("pre-delete job %s couldn't be created: %v", job.GetName(), err))
+		case timedOut:
+			return fail("PreDeleteJobTimedOut", fmt.Sprintf("pre-delete job %s couldn't be created within %s: %v", job.GetName(), timeout, err))
+		}
+		return false, err
+	}
+	if err != nil {
+		return false, err
+	}
+
+	for _, cond := range existing.Status.Conditions {
+		if cond.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch cond.Type {
+		case batchv1.JobComplete:
+			logger.Info("pre-delete job completed")
+			return true, nil
+		case batchv1.JobFailed:
+			return fail("PreDeleteJobFailed", fmt.Sprintf("pre-delete job %s failed: %s", existing.GetName(), cond.Message))
+		}
+	}
+
+	if timedOut {
+		return fail("PreDeleteJobTimedOut", fmt.Sprintf("pre-delete job %s did not complete within %s", existing.GetName(), timeout))
+	}
+	return false, nil
+}
+
+// preDeleteJob returns the pre-delete Job declared by the given CSV. The Job is labeled as owned by the CSV, but has no
+// owner reference, so that it outlives the CSV and is cleaned up once finished by the TTL controller instead.
+func preDeleteJob(csv *v1alpha1.ClusterServiceVersion) (*batchv1.Job, error) {
+	var spec batchv1.JobSpec
+	if err := json.Unmarshal([]byte(csv.GetAnnotations()[PreDeleteJobAnnotationKey]), &spec); err != nil {
+		return nil, fmt.Errorf("invalid %s annotation: %v", PreDeleteJobAnnotationKey, err)
+	}
+	if spec.Template.Spec.RestartPolicy == "" || spec.Template.Spec.RestartPolicy == corev1.RestartPolicyAlways {
+		spec.Template.Spec.RestartPolicy = corev1.RestartPolicyOnFailure
+	}
+	if spec.TTLSecondsAfterFinished == nil {
+		ttl := defaultPreDeleteJobTTL
+		spec.TTLSecondsAfterFinished = &ttl
+	}
+
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      preDeleteJobName(csv.GetName()),
+			Namespace: csv.GetNamespace(),
+		},
+		Spec: spec,
+	}
+	ownerutil.AddOwnerLabelsForKind(job, csv, v1alpha1.ClusterServiceVersionKind)
+	return job, nil
+}
+
+// preDeleteJobName returns the name of the pre-delete Job of the named CSV, truncating the CSV name so that the Job's
+// name is a valid label value, as required of Job names.
+func preDeleteJobName(csvName string) string {
+	const maxLength = 63
+	if len(csvName)+len(preDeleteJobNameSuffix) > maxLength {
+		csvName = csvName[:maxLength-len(preDeleteJobNameSuffix)]
+	}
+	return csvName + preDeleteJobNameSuffix
+}
+
+func removeFinalizer(finalizers []string, finalizer string) []string {
+	out := make([]string, 0, len(finalizers))
+	for _, f := range finalizers {
+		if f != finalizer {
+			out = append(out, f)
+		}
+	}
+	return out
+}
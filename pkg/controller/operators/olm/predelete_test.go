@@ -0,0 +1,182 @@
+package olm
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilclock "k8s.io/apimachinery/pkg/util/clock"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	clienttesting "k8s.io/client-go/testing"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+)
+
+func TestPreDeleteJob(t *testing.T) {
+	now := time.Date(2021, time.June, 1, 12, 0, 0, 0, time.UTC)
+	csv := func(annotations map[string]string, phase v1alpha1.ClusterServiceVersionPhase) *v1alpha1.ClusterServiceVersion {
+		deleted := metav1.NewTime(now.Add(-time.Minute))
+		return &v1alpha1.ClusterServiceVersion{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "csv",
+				Namespace:         "ns",
+				Annotations:       annotations,
+				Finalizers:        []string{PreDeleteJobFinalizer},
+				DeletionTimestamp: &deleted,
+			},
+			Status: v1alpha1.ClusterServiceVersionStatus{Phase: phase},
+		}
+	}
+	job := func(created time.Time, conditions ...batchv1.JobConditionType) *batchv1.Job {
+		job := &batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: "csv-pre-delete", Namespace: "ns", CreationTimestamp: metav1.NewTime(created)}}
+		for _, condition := range conditions {
+			job.Status.Conditions = append(job.Status.Conditions, batchv1.JobCondition{Type: condition, Status: corev1.ConditionTrue, Message: "BackoffLimitExceeded"})
+		}
+		return job
+	}
+	spec := `{"template":{"spec":{"containers":[{"name":"cleanup","image":"cleanup:latest"}]}}}`
+
+	forbidden := k8serrors.NewForbidden(batchv1.Resource("jobs"), "csv-pre-delete", errors.New("namespace ns is being terminated"))
+	unavailable := k8serrors.NewServiceUnavailable("unavailable")
+
+	tests := []struct {
+		name      string
+		csv       *v1alpha1.ClusterServiceVersion
+		job       *batchv1.Job
+		createErr error
+		done      bool
+		created   bool
+		wantErr   bool
+	}{
+		{
+			name:    "NoJob/Created",
+			csv:     csv(map[string]string{PreDeleteJobAnnotationKey: spec}, v1alpha1.CSVPhaseSucceeded),
+			created: true,
+		},
+		{
+			name: "Replaced/Skipped",
+			csv:  csv(map[string]string{PreDeleteJobAnnotationKey: spec}, v1alpha1.CSVPhaseReplacing),
+			done: true,
+		},
+		{
+			name: "Running",
+			csv:  csv(map[string]string{PreDeleteJobAnnotationKey: spec}, v1alpha1.CSVPhaseSucceeded),
+			job:  job(now.Add(-time.Minute)),
+		},
+		{
+			name: "Complete",
+			csv:  csv(map[string]string{PreDeleteJobAnnotationKey: spec}, v1alpha1.CSVPhaseSucceeded),
+			job:  job(now.Add(-time.Minute), batchv1.JobComplete),
+			done: true,
+		},
+		{
+			name: "Failed/Ignored",
+			csv:  csv(map[string]string{PreDeleteJobAnnotationKey: spec}, v1alpha1.CSVPhaseSucceeded),
+			job:  job(now.Add(-time.Minute), batchv1.JobFailed),
+			done: true,
+		},
+		{
+			name: "Failed/Blocked",
+			csv: csv(map[string]string{
+				PreDeleteJobAnnotationKey:              spec,
+				PreDeleteJobFailurePolicyAnnotationKey: PreDeleteJobFailurePolicyBlock,
+			}, v1alpha1.CSVPhaseSucceeded),
+			job: job(now.Add(-time.Minute), batchv1.JobFailed),
+		},
+		{
+			name: "TimedOut/Ignored",
+			csv: csv(map[string]string{
+				PreDeleteJobAnnotationKey:        spec,
+				PreDeleteJobTimeoutAnnotationKey: "30s",
+			}, v1alpha1.CSVPhaseSucceeded),
+			job:  job(now.Add(-time.Minute)),
+			done: true,
+		},
+		{
+			name:      "CreateForbidden/Ignored",
+			csv:       csv(map[string]string{PreDeleteJobAnnotationKey: spec}, v1alpha1.CSVPhaseSucceeded),
+			createErr: forbidden,
+			done:      true,
+		},
+		{
+			name: "CreateForbidden/Blocked",
+			csv: csv(map[string]string{
+				PreDeleteJobAnnotationKey:              spec,
+				PreDeleteJobFailurePolicyAnnotationKey: PreDeleteJobFailurePolicyBlock,
+			}, v1alpha1.CSVPhaseSucceeded),
+			createErr: forbidden,
+		},
+		{
+			name:      "CreateFailing/Retried",
+			csv:       csv(map[string]string{PreDeleteJobAnnotationKey: spec}, v1alpha1.CSVPhaseSucceeded),
+			createErr: unavailable,
+			wantErr:   true,
+		},
+		{
+			name: "CreateFailing/TimedOut",
+			csv: csv(map[string]string{
+				PreDeleteJobAnnotationKey:        spec,
+				PreDeleteJobTimeoutAnnotationKey: "30s",
+			}, v1alpha1.CSVPhaseSucceeded),
+			createErr: unavailable,
+			done:      true,
+		},
+		{
+			name: "Invalid/Ignored",
+			csv:  csv(map[string]string{PreDeleteJobAnnotationKey: "{"}, v1alpha1.CSVPhaseSucceeded),
+			done: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.TODO())
+			defer cancel()
+
+			var k8sObjs []runtime.Object
+			if tt.job != nil {
+				k8sObjs = append(k8sObjs, tt.job)
+			}
+			op, err := NewFakeOperator(ctx, withNamespaces("ns"), withClock(utilclock.NewFakeClock(now)), withClientObjs(tt.csv), withK8sObjs(k8sObjs...))
+			require.NoError(t, err)
+			if tt.createErr != nil {
+				op.opClient.KubernetesInterface().(*k8sfake.Clientset).PrependReactor("create", "jobs", func(clienttesting.Action) (bool, runtime.Object, error) {
+					return true, nil, tt.createErr
+				})
+			}
+
+			done, err := op.runPreDeleteJob(ctx, logrus.NewEntry(op.logger), tt.csv)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.done, done)
+
+			created, err := op.opClient.KubernetesInterface().BatchV1().Jobs("ns").Get(ctx, "csv-pre-delete", metav1.GetOptions{})
+			if !tt.created {
+				if tt.job == nil {
+					require.True(t, k8serrors.IsNotFound(err))
+				}
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, corev1.RestartPolicyOnFailure, created.Spec.Template.Spec.RestartPolicy)
+			require.Equal(t, defaultPreDeleteJobTTL, *created.Spec.TTLSecondsAfterFinished)
+			require.Equal(t, "csv", created.GetLabels()["olm.owner"])
+		})
+	}
+}
+
+func TestPreDeleteJobName(t *testing.T) {
+	require.Equal(t, "csv-pre-delete", preDeleteJobName("csv"))
+	require.Len(t, preDeleteJobName(strings.Repeat("a", 100)), 63)
+}
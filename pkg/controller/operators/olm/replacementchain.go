@@ -0,0 +1,91 @@
+package olm
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/queueinformer"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/metrics"
+)
+
+// replacementChainGracePeriod is how long the CSVs superseded by a succeeded CSV are left to the garbage collection of
+// the Replacing phase, before the replacement chain cleanup considers them stuck.
+const replacementChainGracePeriod = 2 * time.Minute
+
+// stuckSupersededCSVs returns the CSVs superseded by the given succeeded CSV, through its replacement chain or its
+// skips, that are still in the Replacing phase. The Replacing phase only garbage collects the earliest CSV of a
+// replacement chain once the CSV replacing it succeeds, so the intermediate CSVs of a chain whose head jumped several
+// versions, e.g. through its olm.skipRange, can otherwise wait on each other forever.
+func stuckSupersededCSVs(head *v1alpha1.ClusterServiceVersion, csvsInNamespace map[string]*v1alpha1.ClusterServiceVersion) (stuck []*v1alpha1.ClusterServiceVersion, chainLength int) {
+	edge := evaluateUpgradeEdge(head, csvsInNamespace)
+	if edge == nil {
+		return nil, 0
+	}
+	for _, s := range edge.Superseded {
+		if s.InChain {
+			chainLength++
+		}
+		if csv := csvsInNamespace[s.Name]; csv.Status.Phase == v1alpha1.CSVPhaseReplacing {
+			stuck = append(stuck, csv)
+		}
+	}
+	// the chain includes its head
+	return stuck, chainLength + 1
+}
+
+// syncReplacementChain forces the CSVs superseded by a succeeded CSV that are stuck in the Replacing phase into the
+// Deleting phase, once the grace period, the replaced CSV retention and the blocking conditions of the succeeded CSV
+// allow it.
+func (a *Operator) syncReplacementChain(ctx context.Context, obj interface{}) error {
+	head, ok := obj.(*v1alpha1.ClusterServiceVersion)
+	if !ok {
+		a.logger.Debugf("wrong type: %#v", obj)
+		return fmt.Errorf("casting ClusterServiceVersion failed")
+	}
+	logger := a.logger.WithFields(logrus.Fields{
+		"id":        queueinformer.NewLoopID(),
+		"csv":       head.GetName(),
+		"namespace": head.GetNamespace(),
+	})
+
+	if head.Status.Phase != v1alpha1.CSVPhaseSucceeded || head.Spec.Replaces == "" || head.Status.LastTransitionTime == nil || stagedUpgradeInProgress(head) {
+		return nil
+	}
+	stuck, chainLength := stuckSupersededCSVs(head, a.csvSet(head.GetNamespace(), v1alpha1.CSVPhaseAny))
+	if len(stuck) == 0 {
+		return nil
+	}
+
+	now := a.now()
+	wait := head.Status.LastTransitionTime.Add(replacementChainGracePeriod).Sub(now.Time)
+	if retained := replacedCSVRetainedFor(head, a.replacedCSVRetention(), now); retained > wait {
+		wait = retained
+	}
+	if wait > 0 {
+		return a.csvChainQueueSet.RequeueAfter(head.GetNamespace(), head.GetName(), wait)
+	}
+	blocking, err := a.blockingConditions(head)
+	if err != nil {
+		return err
+	}
+	if len(blocking) > 0 {
+		logger.Debugf("reports %s, skipping replacement chain cleanup", describeConditions(blocking))
+		return a.csvChainQueueSet.RequeueAfter(head.GetNamespace(), head.GetName(), blockingConditionsRequeueInterval)
+	}
+
+	for _, csv := range stuck {
+		out := csv.DeepCopy()
+		out.SetPhaseWithEvent(v1alpha1.CSVPhaseDeleting, v1alpha1.CSVReasonReplaced, fmt.Sprintf("has been superseded by %s, which has successfully installed.", head.GetName()), now, a.recorder)
+		if _, err := a.client.OperatorsV1alpha1().ClusterServiceVersions(out.GetNamespace()).UpdateStatus(ctx, out, metav1.UpdateOptions{}); err != nil {
+			return err
+		}
+		logger.WithField("superseded", csv.GetName()).Info("forced stuck superseded csv into deletion")
+	}
+	metrics.EmitReplacementChainCleanup(chainLength, now.Sub(head.Status.LastTransitionTime.Time))
+	return nil
+}
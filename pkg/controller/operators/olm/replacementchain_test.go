@@ -0,0 +1,72 @@
+package olm
+
+import (
+	"testing"
+
+	"github.com/blang/semver/v4"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/operator-framework/api/pkg/lib/version"
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+)
+
+func TestStuckSupersededCSVs(t *testing.T) {
+	newCSV := func(name, ver, replaces string, phase v1alpha1.ClusterServiceVersionPhase) *v1alpha1.ClusterServiceVersion {
+		return &v1alpha1.ClusterServiceVersion{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: name},
+			Spec: v1alpha1.ClusterServiceVersionSpec{
+				Version:  version.OperatorVersion{Version: semver.MustParse(ver)},
+				Replaces: replaces,
+			},
+			Status: v1alpha1.ClusterServiceVersionStatus{Phase: phase},
+		}
+	}
+	set := func(csvs ...*v1alpha1.ClusterServiceVersion) map[string]*v1alpha1.ClusterServiceVersion {
+		m := map[string]*v1alpha1.ClusterServiceVersion{}
+		for _, csv := range csvs {
+			m[csv.GetName()] = csv
+		}
+		return m
+	}
+	names := func(csvs []*v1alpha1.ClusterServiceVersion) []string {
+		var n []string
+		for _, csv := range csvs {
+			n = append(n, csv.GetName())
+		}
+		return n
+	}
+
+	t.Run("Chain", func(t *testing.T) {
+		head := newCSV("v3", "3.0.0", "v2", v1alpha1.CSVPhaseSucceeded)
+		head.SetAnnotations(map[string]string{skipRangeAnnotationKey: "<3.0.0"})
+		stuck, length := stuckSupersededCSVs(head, set(
+			head,
+			newCSV("v2", "2.0.0", "v1", v1alpha1.CSVPhaseReplacing),
+			newCSV("v1", "1.0.0", "v0", v1alpha1.CSVPhaseReplacing),
+			newCSV("v0", "0.1.0", "", v1alpha1.CSVPhaseDeleting),
+		))
+		require.Equal(t, []string{"v2", "v1"}, names(stuck))
+		require.Equal(t, 4, length)
+	})
+
+	t.Run("Skips", func(t *testing.T) {
+		head := newCSV("v3", "3.0.0", "v2", v1alpha1.CSVPhaseSucceeded)
+		head.Spec.Skips = []string{"v1"}
+		stuck, length := stuckSupersededCSVs(head, set(
+			head,
+			newCSV("v2", "2.0.0", "", v1alpha1.CSVPhaseReplacing),
+			newCSV("v1", "1.0.0", "", v1alpha1.CSVPhaseReplacing),
+			newCSV("other", "1.0.0", "", v1alpha1.CSVPhaseReplacing),
+		))
+		require.Equal(t, []string{"v2", "v1"}, names(stuck))
+		require.Equal(t, 2, length)
+	})
+
+	t.Run("ReplacedMissing", func(t *testing.T) {
+		head := newCSV("v3", "3.0.0", "v2", v1alpha1.CSVPhaseSucceeded)
+		stuck, length := stuckSupersededCSVs(head, set(head))
+		require.Empty(t, stuck)
+		require.Zero(t, length)
+	})
+}
@@ -0,0 +1,26 @@
+package olm
+
+import (
+	"time"
+)
+
+// CSVRequeueIntervalAnnotationKey is the annotation of the cluster OLMConfig that overrides, as a duration, how long
+// OLM waits before re-checking the requirements of a CSV whose requirements are unmet. "0s" retries with exponential
+// backoff.
+const CSVRequeueIntervalAnnotationKey = "operatorframework.io/csv-requeue-interval"
+
+// requirementsRequeueInterval returns how long to wait before re-checking the requirements of a CSV whose
+// requirements are unmet, or 0 to retry with exponential backoff. The cluster OLMConfig takes precedence over the
+// operator's configuration, and invalid overrides are ignored.
+func (a *Operator) requirementsRequeueInterval() time.Duration {
+	value, ok := a.olmConfigAnnotations()[CSVRequeueIntervalAnnotationKey]
+	if !ok {
+		return a.csvRequeueInterval
+	}
+	interval, err := time.ParseDuration(value)
+	if err != nil || interval < 0 {
+		a.logger.WithField("interval", value).Warn("ignoring invalid csv requeue interval set on olmConfig")
+		return a.csvRequeueInterval
+	}
+	return interval
+}
@@ -0,0 +1,52 @@
+package olm
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	operatorsv1 "github.com/operator-framework/api/pkg/operators/v1"
+)
+
+func TestRequirementsRequeueInterval(t *testing.T) {
+	olmConfig := func(interval string) *operatorsv1.OLMConfig {
+		return &operatorsv1.OLMConfig{ObjectMeta: metav1.ObjectMeta{
+			Name:        "cluster",
+			Annotations: map[string]string{CSVRequeueIntervalAnnotationKey: interval},
+		}}
+	}
+
+	tests := []struct {
+		name      string
+		olmConfig *operatorsv1.OLMConfig
+		expected  time.Duration
+	}{
+		{name: "NoOLMConfig", expected: time.Minute},
+		{name: "NoOverride", olmConfig: &operatorsv1.OLMConfig{ObjectMeta: metav1.ObjectMeta{Name: "cluster"}}, expected: time.Minute},
+		{name: "Override", olmConfig: olmConfig("5m"), expected: 5 * time.Minute},
+		{name: "Backoff", olmConfig: olmConfig("0s"), expected: 0},
+		{name: "Invalid", olmConfig: olmConfig("soon"), expected: time.Minute},
+		{name: "Negative", olmConfig: olmConfig("-1m"), expected: time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.TODO())
+			defer cancel()
+
+			var clientObjs []runtime.Object
+			if tt.olmConfig != nil {
+				clientObjs = append(clientObjs, tt.olmConfig)
+			}
+			op, err := NewFakeOperator(ctx, withClientObjs(clientObjs...))
+			require.NoError(t, err)
+			op.csvRequeueInterval = time.Minute
+
+			require.Equal(t, tt.expected, op.requirementsRequeueInterval())
+		})
+	}
+}
@@ -0,0 +1,199 @@
+package olm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/sirupsen/logrus"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/install"
+)
+
+const (
+	// VerboseRequirementsAnnotationKey is the CSV annotation that, when set to "true", has OLM record the details of
+	// the evaluation of the requirements of the CSV in its RequirementDetailsAnnotationKey annotation. The details are
+	// removed once the annotation is removed.
+	VerboseRequirementsAnnotationKey = "operatorframework.io/verbose-requirements"
+
+	// RequirementDetailsAnnotationKey is the CSV annotation OLM records, as a JSON-encoded list of RequirementDetail,
+	// the objects checked for each requirement of the CSV and, for its service accounts, how each request allowed by
+	// their policy rules was authorized.
+	RequirementDetailsAnnotationKey = "operatorframework.io/requirement-details"
+)
+
+// RequirementDetail details the evaluation of a requirement of a CSV, complementing its requirement status.
+type RequirementDetail struct {
+	Group   string                `json:"group"`
+	Version string                `json:"version"`
+	Kind    string                `json:"kind"`
+	Name    string                `json:"name"`
+	Status  v1alpha1.StatusReason `json:"status"`
+	Message string                `json:"message,omitempty"`
+
+	// Checked are the objects inspected to evaluate the requirement.
+	Checked []CheckedObject `json:"checked,omitempty"`
+	// Rules are the policy rules evaluated for service account requirements.
+	Rules []RuleDetail `json:"rules,omitempty"`
+}
+
+// CheckedObject identifies an object inspected to evaluate a requirement, at the version inspected.
+type CheckedObject struct {
+	APIVersion      string `json:"apiVersion"`
+	Kind            string `json:"kind"`
+	Namespace       string `json:"namespace,omitempty"`
+	Name            string `json:"name"`
+	UID             string `json:"uid,omitempty"`
+	ResourceVersion string `json:"resourceVersion,omitempty"`
+}
+
+// RuleDetail details the evaluation of a policy rule required by a service account.
+type RuleDetail struct {
+	// Scope is "namespaced" for the rules of spec.install.spec.permissions, and "cluster" for those of
+	// spec.install.spec.clusterPermissions.
+	Scope     string                 `json:"scope"`
+	Rule      rbacv1.PolicyRule      `json:"rule"`
+	Satisfied bool                   `json:"satisfied"`
+	Reviews   []install.AccessReview `json:"reviews,omitempty"`
+	Error     string                 `json:"error,omitempty"`
+}
+
+// RequirementDetailsFor returns the requirement details recorded on the given CSV, or nil if none have been recorded.
+func RequirementDetailsFor(csv *v1alpha1.ClusterServiceVersion) ([]RequirementDetail, error) {
+	value, ok := csv.GetAnnotations()[RequirementDetailsAnnotationKey]
+	if !ok {
+		return nil, nil
+	}
+	var details []RequirementDetail
+	if err := json.Unmarshal([]byte(value), &details); err != nil {
+		return nil, fmt.Errorf("invalid %s annotation: %v", RequirementDetailsAnnotationKey, err)
+	}
+	return details, nil
+}
+
+func checkedObject(apiVersion, kind string, obj metav1.Object) CheckedObject {
+	return CheckedObject{
+		APIVersion:      apiVersion,
+		Kind:            kind,
+		Namespace:       obj.GetNamespace(),
+		Name:            obj.GetName(),
+		UID:             string(obj.GetUID()),
+		ResourceVersion: obj.GetResourceVersion(),
+	}
+}
+
+// requirementDetails returns the details of the evaluation of the requirements reported in the status of the given
+// CSV, sorted by group, kind and name.
+func (a *Operator) requirementDetails(ctx context.Context, csv *v1alpha1.ClusterServiceVersion) ([]RequirementDetail, error) {
+	strategy, err := (&install.StrategyResolver{}).UnmarshalStrategy(csv.Spec.InstallStrategy)
+	if err != nil {
+		return nil, err
+	}
+	strategyDetailsDeployment, ok := strategy.(*v1alpha1.StrategyDetailsDeployment)
+	if !ok {
+		return nil, fmt.Errorf("could not cast install strategy as type %T", strategyDetailsDeployment)
+	}
+
+	rbacLister := a.lister.RbacV1()
+	ruleChecker := install.NewCSVRuleChecker(rbacLister.RoleLister(), rbacLister.RoleBindingLister(), rbacLister.ClusterRoleLister(), rbacLister.ClusterRoleBindingLister(), csv)
+
+	details := make([]RequirementDetail, 0, len(csv.Status.RequirementStatus))
+	for _, status := range csv.Status.RequirementStatus {
+		detail := RequirementDetail{
+			Group:   status.Group,
+			Version: status.Version,
+			Kind:    status.Kind,
+			Name:    status.Name,
+			Status:  status.Status,
+			Message: status.Message,
+		}
+
+		switch {
+		case status.Group == "apiextensions.k8s.io" && status.Kind == "CustomResourceDefinition":
+			if crd, err := a.lister.APIExtensionsV1().CustomResourceDefinitionLister().Get(status.Name); err == nil {
+				detail.Checked = append(detail.Checked, checkedObject("apiextensions.k8s.io/v1", status.Kind, crd))
+			}
+		case status.Group == "apiregistration.k8s.io" && status.Kind == "APIService":
+			if apiService, err := a.lister.APIRegistrationV1().APIServiceLister().Get(status.Name); err == nil {
+				detail.Checked = append(detail.Checked, checkedObject("apiregistration.k8s.io/v1", status.Kind, apiService))
+			}
+		case status.Group == "" && status.Kind == "ServiceAccount":
+			sa, err := a.opClient.GetServiceAccount(ctx, csv.GetNamespace(), status.Name)
+			if err != nil {
+				break
+			}
+			detail.Checked = append(detail.Checked, checkedObject("v1", status.Kind, sa))
+
+			review := func(permissions []v1alpha1.StrategyDeploymentPermissions, scope, namespace string) {
+				for _, perm := range permissions {
+					if perm.ServiceAccountName != status.Name {
+						continue
+					}
+					for _, rule := range perm.Rules {
+						rd := RuleDetail{Scope: scope, Rule: rule, Satisfied: true}
+						reviews, err := ruleChecker.ReviewRule(sa, namespace, rule)
+						if err != nil {
+							rd.Satisfied = false
+							rd.Error = err.Error()
+						}
+						for _, r := range reviews {
+							rd.Satisfied = rd.Satisfied && r.Allowed
+						}
+						rd.Reviews = reviews
+						detail.Rules = append(detail.Rules, rd)
+					}
+				}
+			}
+			review(strategyDetailsDeployment.Permissions, "namespaced", csv.GetNamespace())
+			review(strategyDetailsDeployment.ClusterPermissions, "cluster", metav1.NamespaceAll)
+		}
+
+		details = append(details, detail)
+	}
+
+	sort.SliceStable(details, func(i, j int) bool {
+		if details[i].Group != details[j].Group {
+			return details[i].Group < details[j].Group
+		}
+		if details[i].Kind != details[j].Kind {
+			return details[i].Kind < details[j].Kind
+		}
+		return details[i].Name < details[j].Name
+	})
+	return details, nil
+}
+
+// recordRequirementDetails records the details of the evaluation of the requirements of the given CSV on it if it
+// asks for verbose requirements, or removes them once it no longer does.
+func (a *Operator) recordRequirementDetails(ctx context.Context, logger *logrus.Entry, csv *v1alpha1.ClusterServiceVersion) {
+	current, recorded := csv.GetAnnotations()[RequirementDetailsAnnotationKey]
+	out := csv.DeepCopy()
+	if csv.GetAnnotations()[VerboseRequirementsAnnotationKey] != "true" {
+		if !recorded {
+			return
+		}
+		delete(out.Annotations, RequirementDetailsAnnotationKey)
+	} else {
+		details, err := a.requirementDetails(ctx, csv)
+		if err != nil {
+			logger.WithError(err).Warn("unable to evaluate requirement details")
+			return
+		}
+		value, err := json.Marshal(details)
+		if err != nil {
+			logger.WithError(err).Warn("unable to record requirement details")
+			return
+		}
+		if current == string(value) {
+			return
+		}
+		out.Annotations[RequirementDetailsAnnotationKey] = string(value)
+	}
+	if _, err := a.client.OperatorsV1alpha1().ClusterServiceVersions(out.GetNamespace()).Update(ctx, out, metav1.UpdateOptions{}); err != nil {
+		logger.WithError(err).Debug("unable to record requirement details")
+	}
+}
@@ -0,0 +1,104 @@
+package olm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+)
+
+func TestRecordRequirementDetails(t *testing.T) {
+	namespace := "ns"
+	rules := []rbacv1.PolicyRule{
+		{APIGroups: []string{""}, Verbs: []string{"get", "list"}, Resources: []string{"donuts"}},
+	}
+	newCSV := func(annotations map[string]string) *v1alpha1.ClusterServiceVersion {
+		c := csv("csv1", namespace, "0.0.0", "",
+			installStrategy("csv1-dep", []v1alpha1.StrategyDeploymentPermissions{{ServiceAccountName: "sa", Rules: rules}}, nil),
+			[]*apiextensionsv1.CustomResourceDefinition{crd("c1", "v1", "g1")},
+			nil,
+			v1alpha1.CSVPhasePending,
+		)
+		c.SetAnnotations(annotations)
+		c.Status.RequirementStatus = []v1alpha1.RequirementStatus{
+			{Version: "v1", Kind: "ServiceAccount", Name: "sa", Status: v1alpha1.RequirementStatusReasonPresentNotSatisfied, Message: "Policy rule not satisfied for service account"},
+			{Group: "apiextensions.k8s.io", Version: "v1", Kind: "CustomResourceDefinition", Name: "c1.g1", Status: v1alpha1.RequirementStatusReasonPresent},
+		}
+		return c
+	}
+	k8sObjs := []runtime.Object{
+		&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "sa", Namespace: namespace, UID: "sa-uid"}},
+		&rbacv1.Role{
+			ObjectMeta: metav1.ObjectMeta{Name: "role", Namespace: namespace},
+			Rules:      []rbacv1.PolicyRule{{APIGroups: []string{""}, Verbs: []string{"get"}, Resources: []string{"donuts"}}},
+		},
+		&rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "roleBinding", Namespace: namespace},
+			Subjects:   []rbacv1.Subject{{Kind: "ServiceAccount", Name: "sa", Namespace: namespace}},
+			RoleRef:    rbacv1.RoleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "Role", Name: "role"},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		recorded    bool
+	}{
+		{
+			name:        "Verbose",
+			annotations: map[string]string{VerboseRequirementsAnnotationKey: "true"},
+			recorded:    true,
+		},
+		{
+			name:        "NotVerbose",
+			annotations: map[string]string{RequirementDetailsAnnotationKey: "[]"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.TODO())
+			defer cancel()
+
+			in := newCSV(tt.annotations)
+			op, err := NewFakeOperator(ctx, withNamespaces(namespace), withOperatorNamespace(namespace), withClientObjs(in), withK8sObjs(k8sObjs...), withExtObjs(crd("c1", "v1", "g1")))
+			require.NoError(t, err)
+
+			op.recordRequirementDetails(context.TODO(), logrus.NewEntry(logrus.New()), in)
+
+			out, err := op.client.OperatorsV1alpha1().ClusterServiceVersions(namespace).Get(ctx, in.GetName(), metav1.GetOptions{})
+			require.NoError(t, err)
+			details, err := RequirementDetailsFor(out)
+			require.NoError(t, err)
+			if !tt.recorded {
+				require.Nil(t, details)
+				return
+			}
+
+			require.Len(t, details, 2)
+			require.Equal(t, "ServiceAccount", details[0].Kind)
+			require.Equal(t, []CheckedObject{{APIVersion: "v1", Kind: "ServiceAccount", Namespace: namespace, Name: "sa", UID: "sa-uid"}}, details[0].Checked)
+			require.Len(t, details[0].Rules, 1)
+			rule := details[0].Rules[0]
+			require.Equal(t, "namespaced", rule.Scope)
+			require.False(t, rule.Satisfied)
+			require.Len(t, rule.Reviews, 2)
+			require.Equal(t, "get", rule.Reviews[0].Verb)
+			require.True(t, rule.Reviews[0].Allowed)
+			require.Contains(t, rule.Reviews[0].Reason, `RoleBinding "roleBinding/ns"`)
+			require.Equal(t, "list", rule.Reviews[1].Verb)
+			require.False(t, rule.Reviews[1].Allowed)
+
+			require.Equal(t, "apiextensions.k8s.io", details[1].Group)
+			require.Len(t, details[1].Checked, 1)
+			require.Equal(t, "c1.g1", details[1].Checked[0].Name)
+		})
+	}
+}
@@ -0,0 +1,228 @@
+package olm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/metrics"
+)
+
+const (
+	// RequirementProbesAnnotationKey is the CSV annotation OLM records, as a JSON-encoded list of RequirementProbe, when
+	// each of the requirements of the CSV was last probed, when its status last changed, and how many consecutive
+	// probes found it unmet. It complements the requirement statuses of the CSV, and is only kept while one of its
+	// requirements is unmet.
+	RequirementProbesAnnotationKey = "operatorframework.io/requirement-probes"
+
+	// requirementProbesRecordInterval is the minimum time between two recordings of the requirement probes of a CSV
+	// with unmet requirements whose statuses haven't changed, which bounds the updates made to CSVs stuck Pending.
+	requirementProbesRecordInterval = time.Minute
+)
+
+// RequirementProbe is the probing history of a requirement of a CSV.
+type RequirementProbe struct {
+	Group   string `json:"group"`
+	Version string `json:"version"`
+	Kind    string `json:"kind"`
+	Name    string `json:"name"`
+
+	Status             v1alpha1.StatusReason `json:"status"`
+	LastProbeTime      metav1.Time           `json:"lastProbeTime"`
+	LastTransitionTime metav1.Time           `json:"lastTransitionTime"`
+	// Failures is the number of consecutive probes that found the requirement unmet.
+	Failures int `json:"failures"`
+}
+
+func (p *RequirementProbe) gvk() string {
+	return fmt.Sprintf("%s/%s, Kind=%s", p.Group, p.Version, p.Kind)
+}
+
+// RequirementProbesFor returns the requirement probes recorded on the given CSV, or nil if none have been recorded.
+func RequirementProbesFor(csv *v1alpha1.ClusterServiceVersion) ([]RequirementProbe, error) {
+	value, ok := csv.GetAnnotations()[RequirementProbesAnnotationKey]
+	if !ok {
+		return nil, nil
+	}
+	var probes []RequirementProbe
+	if err := json.Unmarshal([]byte(value), &probes); err != nil {
+		return nil, fmt.Errorf("invalid %s annotation: %v", RequirementProbesAnnotationKey, err)
+	}
+	return probes, nil
+}
+
+// requirementMet returns true if a requirement status reports the requirement as met.
+func requirementMet(status v1alpha1.StatusReason) bool {
+	return status == v1alpha1.RequirementStatusReasonPresent || status == v1alpha1.DependentStatusReasonSatisfied
+}
+
+type requirementProbeKey struct {
+	group, version, kind, name string
+}
+
+type csvRequirementProbes struct {
+	probes map[requirementProbeKey]*RequirementProbe
+	// changed is true if a requirement status changed since the probes were last recorded.
+	changed    bool
+	recordedAt time.Time
+}
+
+// requirementProber tracks the probing history of the requirements of CSVs across syncs.
+type requirementProber struct {
+	mu   sync.Mutex
+	csvs map[types.UID]*csvRequirementProbes
+}
+
+func newRequirementProber() *requirementProber {
+	return &requirementProber{csvs: map[types.UID]*csvRequirementProbes{}}
+}
+
+// observe updates the probing history of the requirements of the given CSV with the statuses of a probe made at the
+// given time.
+func (p *requirementProber) observe(csv *v1alpha1.ClusterServiceVersion, statuses []v1alpha1.RequirementStatus, now metav1.Time) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	c, ok := p.csvs[csv.GetUID()]
+	if !ok {
+		c = &csvRequirementProbes{probes: map[requirementProbeKey]*RequirementProbe{}}
+		p.csvs[csv.GetUID()] = c
+	}
+
+	seen := map[requirementProbeKey]bool{}
+	for _, status := range statuses {
+		key := requirementProbeKey{group: status.Group, version: status.Version, kind: status.Kind, name: status.Name}
+		seen[key] = true
+		probe, ok := c.probes[key]
+		if !ok {
+			probe = &RequirementProbe{Group: status.Group, Version: status.Version, Kind: status.Kind, Name: status.Name, LastTransitionTime: now}
+			c.probes[key] = probe
+			c.changed = true
+		} else if probe.Status != status.Status {
+			probe.LastTransitionTime = now
+			c.changed = true
+		}
+		probe.Status = status.Status
+		probe.LastProbeTime = now
+		if requirementMet(status.Status) {
+			probe.Failures = 0
+		} else {
+			probe.Failures++
+		}
+		metrics.EmitCSVRequirementFailures(csv, probe.gvk(), probe.Name, probe.Failures)
+	}
+
+	// Requirements no longer reported were removed from the CSV
+	for key, probe := range c.probes {
+		if !seen[key] {
+			metrics.DeleteCSVRequirementMetric(csv, probe.gvk(), probe.Name)
+			delete(c.probes, key)
+			c.changed = true
+		}
+	}
+}
+
+// pending returns the probes of the given CSV to record at the given time, and whether they're due: either a
+// requirement status changed since they were last recorded, or a requirement is unmet and they were last recorded
+// more than the record interval ago. The probes are nil once all requirements are met.
+func (p *requirementProber) pending(csv *v1alpha1.ClusterServiceVersion, now time.Time) ([]RequirementProbe, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	c, ok := p.csvs[csv.GetUID()]
+	if !ok {
+		return nil, false
+	}
+	probes := make([]RequirementProbe, 0, len(c.probes))
+	failing := false
+	for _, probe := range c.probes {
+		probes = append(probes, *probe)
+		failing = failing || probe.Failures > 0
+	}
+	if !failing {
+		return nil, true
+	}
+	if !c.changed && now.Sub(c.recordedAt) < requirementProbesRecordInterval {
+		return nil, false
+	}
+	sort.Slice(probes, func(i, j int) bool {
+		if probes[i].Group != probes[j].Group {
+			return probes[i].Group < probes[j].Group
+		}
+		if probes[i].Kind != probes[j].Kind {
+			return probes[i].Kind < probes[j].Kind
+		}
+		return probes[i].Name < probes[j].Name
+	})
+	return probes, true
+}
+
+// recorded marks the probes of the given CSV as recorded at the given time.
+func (p *requirementProber) recorded(csv *v1alpha1.ClusterServiceVersion, now time.Time) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if c, ok := p.csvs[csv.GetUID()]; ok {
+		c.changed = false
+		c.recordedAt = now
+	}
+}
+
+func (p *requirementProber) forget(csv *v1alpha1.ClusterServiceVersion) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if c, ok := p.csvs[csv.GetUID()]; ok {
+		for _, probe := range c.probes {
+			metrics.DeleteCSVRequirementMetric(csv, probe.gvk(), probe.Name)
+		}
+	}
+	delete(p.csvs, csv.GetUID())
+}
+
+// recordRequirementProbes records the probing history of the requirements of the given CSV on it if it's due, or
+// removes it once all requirements are met.
+func (a *Operator) recordRequirementProbes(ctx context.Context, logger *logrus.Entry, csv *v1alpha1.ClusterServiceVersion) {
+	now := a.clock.Now()
+	probes, due := a.requirementProber.pending(csv, now)
+	if !due {
+		return
+	}
+
+	current, recorded := csv.GetAnnotations()[RequirementProbesAnnotationKey]
+	out := csv.DeepCopy()
+	if probes == nil {
+		if !recorded {
+			a.requirementProber.recorded(csv, now)
+			return
+		}
+		delete(out.Annotations, RequirementProbesAnnotationKey)
+	} else {
+		value, err := json.Marshal(probes)
+		if err != nil {
+			logger.WithError(err).Warn("unable to record requirement probes")
+			return
+		}
+		if current == string(value) {
+			a.requirementProber.recorded(csv, now)
+			return
+		}
+		if out.Annotations == nil {
+			out.Annotations = map[string]string{}
+		}
+		out.Annotations[RequirementProbesAnnotationKey] = string(value)
+	}
+	if _, err := a.client.OperatorsV1alpha1().ClusterServiceVersions(out.GetNamespace()).Update(ctx, out, metav1.UpdateOptions{}); err != nil {
+		logger.WithError(err).Debug("unable to record requirement probes")
+		return
+	}
+	a.requirementProber.recorded(csv, now)
+}
@@ -1,6 +1,7 @@
 package olm
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"strings"
@@ -72,6 +73,75 @@ func (a *Operator) minKubeVersionStatus(name string, minKubeVersion string) (met
 	return
 }
 
+// MaxKubeVersionAnnotationKey is the CSV annotation holding the latest Kubernetes version an operator supports, as a
+// complement to spec.minKubeVersion. Versions omitting the patch or minor version cover all of their patch or minor
+// releases, so "1.29" admits 1.29.5 but not 1.30.0.
+const MaxKubeVersionAnnotationKey = "operatorframework.io/max-kube-version"
+
+func (a *Operator) maxKubeVersionStatus(name string, maxKubeVersion string) (met bool, statuses []v1alpha1.RequirementStatus) {
+	if maxKubeVersion == "" {
+		return true, nil
+	}
+
+	status := v1alpha1.RequirementStatus{
+		Group:   "operators.coreos.com",
+		Version: "v1alpha1",
+		Kind:    "ClusterServiceVersion",
+		Name:    name,
+	}
+
+	// Retrieve server k8s version
+	serverVersionInfo, err := a.opClient.KubernetesInterface().Discovery().ServerVersion()
+	if err != nil {
+		status.Status = v1alpha1.RequirementStatusReasonPresentNotSatisfied
+		status.Message = "Server version discovery error"
+		statuses = append(statuses, status)
+		return
+	}
+
+	serverVersion, err := semver.NewVersion(strings.Split(strings.TrimPrefix(serverVersionInfo.String(), "v"), "-")[0])
+	if err != nil {
+		status.Status = v1alpha1.RequirementStatusReasonPresentNotSatisfied
+		status.Message = "Server version parsing error"
+		statuses = append(statuses, status)
+		return
+	}
+
+	// Pad the max version to a full version, and truncate the server version to the same precision
+	parts := strings.SplitN(strings.TrimPrefix(maxKubeVersion, "v"), ".", 3)
+	precision := len(parts)
+	for len(parts) < 3 {
+		parts = append(parts, "0")
+	}
+	csvVersionInfo, err := semver.NewVersion(strings.Join(parts, "."))
+	if err != nil {
+		status.Status = v1alpha1.RequirementStatusReasonPresentNotSatisfied
+		status.Message = "CSV version parsing error"
+		statuses = append(statuses, status)
+		return
+	}
+	comparable := *serverVersion
+	if precision < 3 {
+		comparable.Patch = 0
+	}
+	if precision < 2 {
+		comparable.Minor = 0
+	}
+
+	if comparable.Compare(*csvVersionInfo) > 0 {
+		status.Status = v1alpha1.RequirementStatusReasonPresentNotSatisfied
+		status.Message = fmt.Sprintf("CSV version requirement not met: maxKubeVersion (%s) < server version (%s)", maxKubeVersion, serverVersion.String())
+		statuses = append(statuses, status)
+		return
+	}
+
+	status.Status = v1alpha1.RequirementStatusReasonPresent
+	status.Message = fmt.Sprintf("CSV maxKubeVersion (%s) not less than server version (%s)", maxKubeVersion, serverVersionInfo.String())
+	met = true
+	statuses = append(statuses, status)
+	return
+}
+
 func (a *Operator) requirementStatus(strategyDetailsDeployment *v1alpha1.StrategyDetailsDeployment, csv *v1alpha1.ClusterServiceVersion) (met bool, statuses []v1alpha1.RequirementStatus) {
 	ownedCRDNames := make(map[string]bool)
 	for _, owned := range csv.Spec.CustomResourceDefinitions.Owned {
@@ -253,7 +323,7 @@ func (a *Operator) requirementStatus(strategyDetailsDeployment *v1alpha1.Strateg
 }
 
 // permissionStatus checks whether the given CSV's RBAC requirements are met in its namespace
-func (a *Operator) permissionStatus(strategyDetailsDeployment *v1alpha1.StrategyDetailsDeployment, ruleChecker install.RuleChecker, targetNamespace string, csv *v1alpha1.ClusterServiceVersion) (bool, []v1alpha1.RequirementStatus, error) {
+func (a *Operator) permissionStatus(ctx context.Context, strategyDetailsDeployment *v1alpha1.StrategyDetailsDeployment, ruleChecker install.RuleChecker, targetNamespace string, csv *v1alpha1.ClusterServiceVersion) (bool, []v1alpha1.RequirementStatus, error) {
 	statusesSet := map[string]v1alpha1.RequirementStatus{}
 
 	checkPermissions := func(permissions []v1alpha1.StrategyDeploymentPermissions, namespace string) (bool, error) {
@@ -277,7 +347,7 @@ func (a *Operator) permissionStatus(strategyDetailsDeployment *v1alpha1.Strategy
 			}
 
 			// Ensure the ServiceAccount exists
-			sa, err := a.opClient.GetServiceAccount(csv.GetNamespace(), perm.ServiceAccountName)
+			sa, err := a.opClient.GetServiceAccount(ctx, csv.GetNamespace(), perm.ServiceAccountName)
 			if err != nil {
 				met = false
 				status.Status = v1alpha1.RequirementStatusReasonNotPresent
@@ -357,8 +427,15 @@ func (a *Operator) permissionStatus(strategyDetailsDeployment *v1alpha1.Strategy
 	return permMet && clusterPermMet, statuses, nil
 }
 
+// ruleChecker returns a RuleChecker for the given CSV, whose results are cached until the RBAC they depend on changes.
+func (a *Operator) ruleChecker(csv *v1alpha1.ClusterServiceVersion) install.RuleChecker {
+	rbacLister := a.lister.RbacV1()
+	ruleChecker := install.NewCSVRuleChecker(rbacLister.RoleLister(), rbacLister.RoleBindingLister(), rbacLister.ClusterRoleLister(), rbacLister.ClusterRoleBindingLister(), csv)
+	return a.ruleCheckCache.Checker(ruleChecker, csv)
+}
+
 // requirementAndPermissionStatus returns the aggregate requirement and permissions statuses for the given CSV
-func (a *Operator) requirementAndPermissionStatus(csv *v1alpha1.ClusterServiceVersion) (bool, []v1alpha1.RequirementStatus, error) {
+func (a *Operator) requirementAndPermissionStatus(ctx context.Context, csv *v1alpha1.ClusterServiceVersion) (bool, []v1alpha1.RequirementStatus, error) {
 	allReqStatuses := []v1alpha1.RequirementStatus{}
 	// Use a StrategyResolver to unmarshal
 	strategyResolver := install.StrategyResolver{}
@@ -378,27 +455,29 @@ func (a *Operator) requirementAndPermissionStatus(csv *v1alpha1.ClusterServiceVe
 	if minKubeStatus != nil {
 		allReqStatuses = append(allReqStatuses, minKubeStatus...)
 	}
+	maxKubeMet, maxKubeStatus := a.maxKubeVersionStatus(csv.GetName(), csv.GetAnnotations()[MaxKubeVersionAnnotationKey])
+	if maxKubeStatus != nil {
+		allReqStatuses = append(allReqStatuses, maxKubeStatus...)
+	}
+	capabilityMet, capabilityStatuses := a.capabilityStatus(ctx, csv)
+	allReqStatuses = append(allReqStatuses, capabilityStatuses...)
+	serviceAccountMet, serviceAccountStatuses := a.operatorGroupServiceAccountStatus(csv)
+	allReqStatuses = append(allReqStatuses, serviceAccountStatuses...)
 
 	reqMet, reqStatuses := a.requirementStatus(strategyDetailsDeployment, csv)
 	allReqStatuses = append(allReqStatuses, reqStatuses...)
 
-	rbacLister := a.lister.RbacV1()
-	roleLister := rbacLister.RoleLister()
-	roleBindingLister := rbacLister.RoleBindingLister()
-	clusterRoleLister := rbacLister.ClusterRoleLister()
-	clusterRoleBindingLister := rbacLister.ClusterRoleBindingLister()
-
-	ruleChecker := install.NewCSVRuleChecker(roleLister, roleBindingLister, clusterRoleLister, clusterRoleBindingLister, csv)
-	permMet, permStatuses, err := a.permissionStatus(strategyDetailsDeployment, ruleChecker, csv.GetNamespace(), csv)
+	permMet, permStatuses, err := a.permissionStatus(ctx, strategyDetailsDeployment, a.ruleChecker(csv), csv.GetNamespace(), csv)
 	if err != nil {
 		return false, nil, err
 	}
 
 	// Aggregate requirement and permissions statuses
 	statuses := append(allReqStatuses, permStatuses...)
-	met := minKubeMet && reqMet && permMet
+	a.requirementProber.observe(csv, statuses, *a.now())
+	met := minKubeMet && maxKubeMet && capabilityMet && serviceAccountMet && reqMet && permMet
 	if !met {
-		a.logger.WithField("minKubeMet", minKubeMet).WithField("reqMet", reqMet).WithField("permMet", permMet).Debug("permissions/requirements not met")
+		a.logger.WithField("minKubeMet", minKubeMet).WithField("maxKubeMet", maxKubeMet).WithField("capabilityMet", capabilityMet).WithField("serviceAccountMet", serviceAccountMet).WithField("reqMet", reqMet).WithField("permMet", permMet).Debug("permissions/requirements not met")
 	}
 
 	return met, statuses, nil
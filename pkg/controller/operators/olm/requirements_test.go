@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
@@ -11,9 +12,13 @@ import (
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/version"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
 
 	"github.com/operator-framework/api/pkg/operators/v1alpha1"
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/operators/internal/alongside"
@@ -911,7 +916,7 @@ func TestRequirementAndPermissionStatus(t *testing.T) {
 			require.NoError(t, err)
 
 			// Get the permission status
-			met, statuses, err := op.requirementAndPermissionStatus(test.csv)
+			met, statuses, err := op.requirementAndPermissionStatus(context.TODO(), test.csv)
 
 			if test.expectedError != nil {
 				require.Error(t, err)
@@ -1040,6 +1045,108 @@ func TestMinKubeVersionStatus(t *testing.T) {
 	}
 }
 
+func TestMaxKubeVersionStatus(t *testing.T) {
+	tests := []struct {
+		maxKubeVersion string
+		expectedMet    bool
+		expectedStatus v1alpha1.StatusReason
+	}{
+		{maxKubeVersion: "", expectedMet: true},
+		{maxKubeVersion: "1.29", expectedMet: true, expectedStatus: v1alpha1.RequirementStatusReasonPresent},
+		{maxKubeVersion: "v1.29.5", expectedMet: true, expectedStatus: v1alpha1.RequirementStatusReasonPresent},
+		{maxKubeVersion: "1", expectedMet: true, expectedStatus: v1alpha1.RequirementStatusReasonPresent},
+		{maxKubeVersion: "1.29.4", expectedMet: false, expectedStatus: v1alpha1.RequirementStatusReasonPresentNotSatisfied},
+		{maxKubeVersion: "1.28", expectedMet: false, expectedStatus: v1alpha1.RequirementStatusReasonPresentNotSatisfied},
+		{maxKubeVersion: "a.b", expectedMet: false, expectedStatus: v1alpha1.RequirementStatusReasonPresentNotSatisfied},
+	}
+
+	for _, test := range tests {
+		t.Run(test.maxKubeVersion, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.TODO())
+			defer cancel()
+			op, err := NewFakeOperator(ctx, withNamespaces("ns"), withOperatorNamespace("ns"))
+			require.NoError(t, err)
+			op.opClient.KubernetesInterface().Discovery().(*fakediscovery.FakeDiscovery).FakedServerVersion = &version.Info{GitVersion: "v1.29.5"}
+
+			met, statuses := op.maxKubeVersionStatus("csv", test.maxKubeVersion)
+			require.Equal(t, test.expectedMet, met)
+			if test.maxKubeVersion == "" {
+				require.Nil(t, statuses)
+				return
+			}
+			require.Len(t, statuses, 1)
+			require.Equal(t, test.expectedStatus, statuses[0].Status)
+			require.Equal(t, "csv", statuses[0].Name)
+		})
+	}
+}
+
+func TestCapabilityStatus(t *testing.T) {
+	nodes := func(n int) []runtime.Object {
+		var objs []runtime.Object
+		for i := 0; i < n; i++ {
+			node := &unstructured.Unstructured{}
+			node.SetAPIVersion("v1")
+			node.SetKind("Node")
+			node.SetName(fmt.Sprintf("node-%d", i))
+			objs = append(objs, node)
+		}
+		return objs
+	}
+
+	tests := []struct {
+		description    string
+		annotation     string
+		nodes          int
+		expectedMet    bool
+		expectedStatus []v1alpha1.StatusReason
+	}{
+		{description: "NoAnnotation", expectedMet: true},
+		{description: "InvalidAnnotation", annotation: "{", expectedStatus: []v1alpha1.StatusReason{v1alpha1.RequirementStatusReasonPresentNotSatisfied}},
+		{description: "UnknownCapability", annotation: `[{"name":"unknown"}]`, expectedStatus: []v1alpha1.StatusReason{v1alpha1.RequirementStatusReasonPresentNotSatisfied}},
+		{description: "WellKnownPresent", annotation: `[{"name":"scc"}]`, expectedMet: true, expectedStatus: []v1alpha1.StatusReason{v1alpha1.RequirementStatusReasonPresent}},
+		{description: "WellKnownMissing", annotation: `[{"name":"metrics-server"}]`, expectedStatus: []v1alpha1.StatusReason{v1alpha1.RequirementStatusReasonNotPresent}},
+		{description: "MinCountMet", annotation: `[{"version":"v1","resource":"nodes","minCount":2}]`, nodes: 2, expectedMet: true, expectedStatus: []v1alpha1.StatusReason{v1alpha1.RequirementStatusReasonPresent}},
+		{description: "MinCountNotMet", annotation: `[{"version":"v1","resource":"nodes","minCount":3}]`, nodes: 2, expectedStatus: []v1alpha1.StatusReason{v1alpha1.RequirementStatusReasonPresentNotSatisfied}},
+		{
+			description: "Mixed",
+			annotation:  `[{"name":"scc"},{"name":"monitoring","group":"monitoring.coreos.com","version":"v1","resource":"servicemonitors"}]`,
+			expectedStatus: []v1alpha1.StatusReason{
+				v1alpha1.RequirementStatusReasonPresent,
+				v1alpha1.RequirementStatusReasonNotPresent,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.TODO())
+			defer cancel()
+			op, err := NewFakeOperator(ctx, withNamespaces("ns"), withOperatorNamespace("ns"))
+			require.NoError(t, err)
+			op.opClient.KubernetesInterface().Discovery().(*fakediscovery.FakeDiscovery).Resources = []*metav1.APIResourceList{
+				{GroupVersion: "v1", APIResources: []metav1.APIResource{{Name: "nodes", Kind: "Node"}}},
+				{GroupVersion: "security.openshift.io/v1", APIResources: []metav1.APIResource{{Name: "securitycontextconstraints", Kind: "SecurityContextConstraints"}}},
+			}
+			op.dynamicClient = dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+				{Version: "v1", Resource: "nodes"}: "NodeList",
+			}, nodes(test.nodes)...)
+
+			csv := &v1alpha1.ClusterServiceVersion{ObjectMeta: metav1.ObjectMeta{Name: "csv", Namespace: "ns"}}
+			if test.annotation != "" {
+				csv.SetAnnotations(map[string]string{RequiredCapabilitiesAnnotationKey: test.annotation})
+			}
+
+			met, statuses := op.capabilityStatus(context.TODO(), csv)
+			require.Equal(t, test.expectedMet, met)
+			require.Len(t, statuses, len(test.expectedStatus))
+			for i, status := range statuses {
+				require.Equal(t, test.expectedStatus[i], status.Status)
+			}
+		})
+	}
+}
+
 func TestOthersInstalledAlongside(t *testing.T) {
 	for _, tc := range []struct {
 		Name        string
@@ -1274,3 +1381,58 @@ func TestOthersInstalledAlongside(t *testing.T) {
 		})
 	}
 }
+
+func TestRequirementProber(t *testing.T) {
+	csv := &v1alpha1.ClusterServiceVersion{ObjectMeta: metav1.ObjectMeta{Name: "csv", Namespace: "ns", UID: "uid"}}
+	crd := func(status v1alpha1.StatusReason) v1alpha1.RequirementStatus {
+		return v1alpha1.RequirementStatus{Group: "apiextensions.k8s.io", Version: "v1", Kind: "CustomResourceDefinition", Name: "crd", Status: status}
+	}
+	sa := v1alpha1.RequirementStatus{Version: "v1", Kind: "ServiceAccount", Name: "sa", Status: v1alpha1.DependentStatusReasonSatisfied}
+	start := time.Date(2021, time.June, 1, 12, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) metav1.Time { return metav1.NewTime(start.Add(d)) }
+
+	p := newRequirementProber()
+	probes, due := p.pending(csv, start)
+	require.False(t, due)
+
+	// The first probe is always due
+	p.observe(csv, []v1alpha1.RequirementStatus{crd(v1alpha1.RequirementStatusReasonNotPresent), sa}, at(0))
+	probes, due = p.pending(csv, start)
+	require.True(t, due)
+	require.Equal(t, []RequirementProbe{
+		{Version: "v1", Kind: "ServiceAccount", Name: "sa", Status: v1alpha1.DependentStatusReasonSatisfied, LastProbeTime: at(0), LastTransitionTime: at(0)},
+		{Group: "apiextensions.k8s.io", Version: "v1", Kind: "CustomResourceDefinition", Name: "crd", Status: v1alpha1.RequirementStatusReasonNotPresent, LastProbeTime: at(0), LastTransitionTime: at(0), Failures: 1},
+	}, probes)
+	p.recorded(csv, start)
+
+	// Unchanged failing probes are only due once the record interval has passed
+	p.observe(csv, []v1alpha1.RequirementStatus{crd(v1alpha1.RequirementStatusReasonNotPresent), sa}, at(10*time.Second))
+	_, due = p.pending(csv, start.Add(10*time.Second))
+	require.False(t, due)
+	probes, due = p.pending(csv, start.Add(requirementProbesRecordInterval))
+	require.True(t, due)
+	require.Len(t, probes, 2)
+	require.Equal(t, 2, probes[1].Failures)
+	require.Equal(t, at(10*time.Second), probes[1].LastProbeTime)
+	require.Equal(t, at(0), probes[1].LastTransitionTime)
+	p.recorded(csv, start.Add(requirementProbesRecordInterval))
+
+	// Transitions are due right away, and removed requirements are dropped
+	p.observe(csv, []v1alpha1.RequirementStatus{crd(v1alpha1.RequirementStatusReasonNotAvailable)}, at(2*time.Minute))
+	probes, due = p.pending(csv, start.Add(2*time.Minute))
+	require.True(t, due)
+	require.Len(t, probes, 1)
+	require.Equal(t, 3, probes[0].Failures)
+	require.Equal(t, at(2*time.Minute), probes[0].LastTransitionTime)
+	p.recorded(csv, start.Add(2*time.Minute))
+
+	// Once all requirements are met, no probes are recorded
+	p.observe(csv, []v1alpha1.RequirementStatus{crd(v1alpha1.RequirementStatusReasonPresent)}, at(3*time.Minute))
+	probes, due = p.pending(csv, start.Add(3*time.Minute))
+	require.True(t, due)
+	require.Nil(t, probes)
+
+	p.forget(csv)
+	_, due = p.pending(csv, start.Add(time.Hour))
+	require.False(t, due)
+}
@@ -0,0 +1,115 @@
+package olm
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/ownerutil"
+)
+
+// ReplacedCSVRetentionAnnotationKey is the annotation of the cluster OLMConfig setting, as a duration, how long a
+// replaced CSV is kept in the Replacing phase after its replacement succeeded, so that the upgrade can be rolled back
+// by deleting the replacement, or investigated, before the replaced CSV is deleted. Replaced CSVs are deleted as soon
+// as their replacement succeeds by default.
+const ReplacedCSVRetentionAnnotationKey = "operatorframework.io/replaced-csv-retention"
+
+// RetainedReplicasAnnotationKey is the annotation of a deployment of a retained replaced CSV recording the replicas it
+// was scaled down from, which are restored if the upgrade is rolled back.
+const RetainedReplicasAnnotationKey = "operatorframework.io/retained-replicas"
+
+// replacedCSVRetention returns the retention of replaced CSVs set on the cluster OLMConfig. Invalid retentions are
+// ignored.
+func (a *Operator) replacedCSVRetention() time.Duration {
+	value, ok := a.olmConfigAnnotations()[ReplacedCSVRetentionAnnotationKey]
+	if !ok {
+		return 0
+	}
+	retention, err := time.ParseDuration(value)
+	if err != nil || retention < 0 {
+		a.logger.WithField("retention", value).Warn("ignoring invalid replaced csv retention set on olmConfig")
+		return 0
+	}
+	return retention
+}
+
+// replacedCSVRetainedFor returns how much longer a CSV replaced by the given succeeded replacement is retained, or a
+// non-positive duration if it can be deleted.
+func replacedCSVRetainedFor(next *v1alpha1.ClusterServiceVersion, retention time.Duration, now *metav1.Time) time.Duration {
+	if retention <= 0 || next.Status.LastTransitionTime == nil {
+		return 0
+	}
+	return next.Status.LastTransitionTime.Add(retention).Sub(now.Time)
+}
+
+// scaleDownRetainedDeployments scales the deployments of the given retained replaced CSV that its replacement didn't
+// adopt down to zero, so that both versions of the operator don't run side by side while the replaced CSV is retained.
+// The replicas they are scaled down from are recorded on them.
+func (a *Operator) scaleDownRetainedDeployments(ctx context.Context, csv, next *v1alpha1.ClusterServiceVersion) error {
+	adopted := map[string]struct{}{}
+	if next.Spec.InstallStrategy.StrategyName == v1alpha1.InstallStrategyNameDeployment {
+		for _, spec := range next.Spec.InstallStrategy.StrategySpec.DeploymentSpecs {
+			adopted[spec.Name] = struct{}{}
+		}
+	}
+
+	deployments, err := a.lister.AppsV1().DeploymentLister().Deployments(csv.GetNamespace()).List(ownerutil.CSVOwnerSelector(csv))
+	if err != nil {
+		return err
+	}
+	for _, deployment := range deployments {
+		if _, ok := adopted[deployment.GetName()]; ok || ownerutil.IsOwnedBy(deployment, next) {
+			continue
+		}
+		if _, ok := deployment.GetAnnotations()[RetainedReplicasAnnotationKey]; ok {
+			continue
+		}
+		replicas := int32(1)
+		if deployment.Spec.Replicas != nil {
+			replicas = *deployment.Spec.Replicas
+		}
+		out := deployment.DeepCopy()
+		if out.Annotations == nil {
+			out.Annotations = map[string]string{}
+		}
+		out.Annotations[RetainedReplicasAnnotationKey] = strconv.Itoa(int(replicas))
+		zero := int32(0)
+		out.Spec.Replicas = &zero
+		if _, err := a.opClient.KubernetesInterface().AppsV1().Deployments(out.GetNamespace()).Update(ctx, out, metav1.UpdateOptions{}); err != nil {
+			return fmt.Errorf("scaling down deployment %s of retained csv: %v", out.GetName(), err)
+		}
+	}
+	return nil
+}
+
+// restoreRetainedDeployments scales the deployments of the given CSV that were scaled down while it was retained back
+// to the replicas they were scaled down from, as its upgrade was rolled back.
+func (a *Operator) restoreRetainedDeployments(ctx context.Context, csv *v1alpha1.ClusterServiceVersion) error {
+	deployments, err := a.lister.AppsV1().DeploymentLister().Deployments(csv.GetNamespace()).List(ownerutil.CSVOwnerSelector(csv))
+	if err != nil {
+		return err
+	}
+	for _, deployment := range deployments {
+		value, ok := deployment.GetAnnotations()[RetainedReplicasAnnotationKey]
+		if !ok {
+			continue
+		}
+		out := deployment.DeepCopy()
+		delete(out.Annotations, RetainedReplicasAnnotationKey)
+		if replicas, err := strconv.ParseInt(value, 10, 32); err == nil {
+			r := int32(replicas)
+			out.Spec.Replicas = &r
+		} else {
+			// The install strategy sets the replicas of the deployment when the csv reinstalls
+			out.Spec.Replicas = nil
+		}
+		if _, err := a.opClient.KubernetesInterface().AppsV1().Deployments(out.GetNamespace()).Update(ctx, out, metav1.UpdateOptions{}); err != nil {
+			return fmt.Errorf("restoring deployment %s of retained csv: %v", out.GetName(), err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package olm
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	operatorsv1 "github.com/operator-framework/api/pkg/operators/v1"
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/ownerutil"
+)
+
+func TestReplacedCSVRetention(t *testing.T) {
+	olmConfig := func(retention string) *operatorsv1.OLMConfig {
+		return &operatorsv1.OLMConfig{ObjectMeta: metav1.ObjectMeta{
+			Name:        "cluster",
+			Annotations: map[string]string{ReplacedCSVRetentionAnnotationKey: retention},
+		}}
+	}
+
+	tests := []struct {
+		name      string
+		olmConfig *operatorsv1.OLMConfig
+		expected  time.Duration
+	}{
+		{name: "NoOLMConfig"},
+		{name: "NoRetention", olmConfig: &operatorsv1.OLMConfig{ObjectMeta: metav1.ObjectMeta{Name: "cluster"}}},
+		{name: "Retention", olmConfig: olmConfig("24h"), expected: 24 * time.Hour},
+		{name: "Invalid", olmConfig: olmConfig("a day")},
+		{name: "Negative", olmConfig: olmConfig("-1h")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.TODO())
+			defer cancel()
+
+			var clientObjs []runtime.Object
+			if tt.olmConfig != nil {
+				clientObjs = append(clientObjs, tt.olmConfig)
+			}
+			op, err := NewFakeOperator(ctx, withClientObjs(clientObjs...))
+			require.NoError(t, err)
+
+			require.Equal(t, tt.expected, op.replacedCSVRetention())
+		})
+	}
+}
+
+func TestReplacedCSVRetainedFor(t *testing.T) {
+	succeeded := metav1.NewTime(time.Date(2021, time.October, 1, 12, 0, 0, 0, time.UTC))
+	next := &v1alpha1.ClusterServiceVersion{Status: v1alpha1.ClusterServiceVersionStatus{
+		Phase:              v1alpha1.CSVPhaseSucceeded,
+		LastTransitionTime: &succeeded,
+	}}
+	at := func(d time.Duration) *metav1.Time {
+		t := metav1.NewTime(succeeded.Add(d))
+		return &t
+	}
+
+	require.Equal(t, time.Duration(0), replacedCSVRetainedFor(next, 0, at(time.Minute)), "no retention")
+	require.Equal(t, 59*time.Minute, replacedCSVRetainedFor(next, time.Hour, at(time.Minute)))
+	require.LessOrEqual(t, replacedCSVRetainedFor(next, time.Hour, at(2*time.Hour)), time.Duration(0), "the retention is over")
+	require.Equal(t, time.Duration(0), replacedCSVRetainedFor(&v1alpha1.ClusterServiceVersion{}, time.Hour, at(0)))
+}
+
+func TestRetainedDeployments(t *testing.T) {
+	const namespace = "ns"
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+
+	replaced := csv("csv1", namespace, "0.0.0", "", installStrategy("csv1-dep1", nil, nil), nil, nil, v1alpha1.CSVPhaseReplacing)
+	next := csv("csv2", namespace, "0.0.0", "csv1", installStrategy("csv1-dep1", nil, nil), nil, nil, v1alpha1.CSVPhaseSucceeded)
+	owned := func(name string, replicas int32) *appsv1.Deployment {
+		d := deployment(name, namespace, "sa", nil)
+		d.Spec.Replicas = &replicas
+		d.SetLabels(ownerutil.OwnerLabel(replaced, v1alpha1.ClusterServiceVersionKind))
+		return d
+	}
+
+	op, err := NewFakeOperator(ctx,
+		withNamespaces(namespace),
+		withClientObjs(replaced, next),
+		withK8sObjs(owned("csv1-dep1", 2), owned("csv1-dep2", 3)),
+	)
+	require.NoError(t, err)
+
+	get := func(name string) *appsv1.Deployment {
+		d, err := op.opClient.KubernetesInterface().AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+		require.NoError(t, err)
+		return d
+	}
+	awaitLister := func(name string, replicas int32) {
+		require.Eventually(t, func() bool {
+			d, err := op.lister.AppsV1().DeploymentLister().Deployments(namespace).Get(name)
+			return err == nil && *d.Spec.Replicas == replicas
+		}, 5*time.Second, 10*time.Millisecond)
+	}
+
+	require.NoError(t, op.scaleDownRetainedDeployments(ctx, replaced, next))
+	require.Equal(t, int32(2), *get("csv1-dep1").Spec.Replicas, "the deployment adopted by the replacement keeps running")
+	scaledDown := get("csv1-dep2")
+	require.Equal(t, int32(0), *scaledDown.Spec.Replicas)
+	require.Equal(t, "3", scaledDown.GetAnnotations()[RetainedReplicasAnnotationKey])
+
+	// Scaling down again keeps the replicas recorded
+	awaitLister("csv1-dep2", 0)
+	require.NoError(t, op.scaleDownRetainedDeployments(ctx, replaced, next))
+	require.Equal(t, "3", get("csv1-dep2").GetAnnotations()[RetainedReplicasAnnotationKey])
+
+	require.NoError(t, op.restoreRetainedDeployments(ctx, replaced))
+	restored := get("csv1-dep2")
+	require.Equal(t, int32(3), *restored.Spec.Replicas)
+	require.NotContains(t, restored.GetAnnotations(), RetainedReplicasAnnotationKey)
+	require.Equal(t, int32(2), *get("csv1-dep1").Spec.Replicas)
+}
@@ -0,0 +1,181 @@
+package olm
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	v1 "github.com/operator-framework/api/pkg/operators/v1"
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/ownerutil"
+	opregistry "github.com/operator-framework/operator-registry/pkg/registry"
+)
+
+// DisableRoleAggregationAnnotationKey is the OperatorGroup annotation that, when set to "true", stops OLM from managing
+// the admin, edit and view ClusterRoles of the APIs provided by the operators of the group, and of the group itself,
+// so that they can be managed by cluster administrators instead. Roles created before the annotation was set are deleted,
+// except for the roles of APIs that operators in groups without the annotation provide as well.
+const DisableRoleAggregationAnnotationKey = "operatorframework.io/disable-role-aggregation"
+
+// roleAggregationDisabled returns true if the given OperatorGroup opts out of the ClusterRoles aggregating access to
+// the APIs provided by its operators.
+func roleAggregationDisabled(op *v1.OperatorGroup) bool {
+	return op != nil && op.GetAnnotations()[DisableRoleAggregationAnnotationKey] == "true"
+}
+
+// deleteOpGroupClusterRoles deletes the admin, edit and view ClusterRoles of the given OperatorGroup.
+func (a *Operator) deleteOpGroupClusterRoles(ctx context.Context, op *v1.OperatorGroup) error {
+	clusterRoles, err := a.lister.RbacV1().ClusterRoleLister().List(labels.SelectorFromSet(ownerutil.OwnerLabel(op, "OperatorGroup")))
+	if err != nil {
+		return err
+	}
+
+	var names []string
+	for _, suffix := range Suffices {
+		name := strings.Join([]string{op.GetName(), suffix}, "-")
+		for _, clusterRole := range clusterRoles {
+			if clusterRole.GetName() == name {
+				names = append(names, name)
+			}
+		}
+	}
+	return a.deleteClusterRoles(ctx, names)
+}
+
+// providedAPIClusterRole is a ClusterRole ensureClusterRolesForCSV creates for an API provided by a CSV.
+type providedAPIClusterRole struct {
+	name string
+	// ownerKind and ownerName identify the CustomResourceDefinition or APIService the ClusterRole is owned by.
+	ownerKind, ownerName string
+	aggregationLabel     string
+}
+
+// providedAPIClusterRoles returns the ClusterRoles ensureClusterRolesForCSV creates for the APIs provided by the given
+// CSV, by the name prefix shared by the ClusterRoles of each API.
+func providedAPIClusterRoles(csv *v1alpha1.ClusterServiceVersion) (map[string][]providedAPIClusterRole, error) {
+	roles := map[string][]providedAPIClusterRole{}
+	add := func(namePrefix, name, suffix, ownerKind, ownerName string, key opregistry.APIKey) error {
+		aggregationLabel, err := aggregationLabelFromAPIKey(key, suffix)
+		if err != nil {
+			return err
+		}
+		roles[namePrefix] = append(roles[namePrefix], providedAPIClusterRole{
+			name:             name,
+			ownerKind:        ownerKind,
+			ownerName:        ownerName,
+			aggregationLabel: aggregationLabel,
+		})
+		return nil
+	}
+	for _, owned := range csv.Spec.CustomResourceDefinitions.Owned {
+		nameGroupPair := strings.SplitN(owned.Name, ".", 2)
+		if len(nameGroupPair) != 2 {
+			return nil, fmt.Errorf("invalid parsing of name '%v', got %v", owned.Name, nameGroupPair)
+		}
+		namePrefix := fmt.Sprintf("%s-%s-", owned.Name, owned.Version)
+		key := opregistry.APIKey{Group: nameGroupPair[1], Version: owned.Version, Kind: owned.Kind, Plural: nameGroupPair[0]}
+		for _, suffix := range Suffices {
+			if err := add(namePrefix, namePrefix+suffix, suffix, "CustomResourceDefinition", owned.Name, key); err != nil {
+				return nil, err
+			}
+		}
+		if err := add(namePrefix, namePrefix+"crd"+ViewSuffix, ViewSuffix, "CustomResourceDefinition", owned.Name, key); err != nil {
+			return nil, err
+		}
+	}
+	for _, owned := range csv.Spec.APIServiceDefinitions.Owned {
+		namePrefix := fmt.Sprintf("%s-%s-", owned.Name, owned.Version)
+		key := opregistry.APIKey{Group: owned.Group, Version: owned.Version, Kind: owned.Kind}
+		for _, suffix := range Suffices {
+			if err := add(namePrefix, namePrefix+suffix, suffix, "APIService", strings.Join([]string{owned.Version, owned.Group}, "."), key); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return roles, nil
+}
+
+// createdBy returns true if the given ClusterRole is the one ensureClusterRolesForCSV creates for the provided API.
+func (r providedAPIClusterRole) createdBy(clusterRole *rbacv1.ClusterRole) bool {
+	if clusterRole.GetLabels()[r.aggregationLabel] != "true" {
+		return false
+	}
+	for _, oref := range ownerutil.GetOwnersByKind(clusterRole, r.ownerKind) {
+		if oref.Name == r.ownerName {
+			return true
+		}
+	}
+	return false
+}
+
+// deleteClusterRolesForCSV deletes the ClusterRoles for writing and reading the APIs provided by the given CSV created
+// by ensureClusterRolesForCSV. These ClusterRoles are shared by all the CSVs providing the same API, so the ones of APIs
+// also provided by a CSV in an OperatorGroup that doesn't disable role aggregation are kept, as are ClusterRoles of the
+// same names that OLM didn't create.
+func (a *Operator) deleteClusterRolesForCSV(ctx context.Context, csv *v1alpha1.ClusterServiceVersion) error {
+	roles, err := providedAPIClusterRoles(csv)
+	if err != nil {
+		return err
+	}
+	if len(roles) == 0 {
+		return nil
+	}
+
+	csvs, err := a.lister.OperatorsV1alpha1().ClusterServiceVersionLister().ClusterServiceVersions(metav1.NamespaceAll).List(labels.Everything())
+	if err != nil {
+		return err
+	}
+	for _, other := range csvs {
+		if other.IsCopied() || (other.GetNamespace() == csv.GetNamespace() && other.GetName() == csv.GetName()) {
+			continue
+		}
+		provided, err := providedAPIClusterRoles(other)
+		if err != nil || len(provided) == 0 {
+			continue
+		}
+		groups, err := a.lister.OperatorsV1().OperatorGroupLister().OperatorGroups(other.GetNamespace()).List(labels.Everything())
+		if err != nil {
+			return err
+		}
+		if len(groups) != 1 || roleAggregationDisabled(groups[0]) {
+			continue
+		}
+		for namePrefix := range provided {
+			delete(roles, namePrefix)
+		}
+	}
+
+	var existing []string
+	for _, apiRoles := range roles {
+		for _, role := range apiRoles {
+			clusterRole, err := a.lister.RbacV1().ClusterRoleLister().Get(role.name)
+			if k8serrors.IsNotFound(err) {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			if role.createdBy(clusterRole) {
+				existing = append(existing, role.name)
+			}
+		}
+	}
+	sort.Strings(existing)
+	return a.deleteClusterRoles(ctx, existing)
+}
+
+func (a *Operator) deleteClusterRoles(ctx context.Context, names []string) error {
+	for _, name := range names {
+		a.logger.WithField("clusterrole", name).Info("deleting clusterrole, role aggregation is disabled")
+		if err := a.opClient.KubernetesInterface().RbacV1().ClusterRoles().Delete(ctx, name, metav1.DeleteOptions{}); err != nil && !k8serrors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package olm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	rbacv1 "k8s.io/api/rbac/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/operator-framework/api/pkg/operators/v1"
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/ownerutil"
+	opregistry "github.com/operator-framework/operator-registry/pkg/registry"
+)
+
+func TestDeleteAggregatedClusterRoles(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+
+	og := &v1.OperatorGroup{ObjectMeta: metav1.ObjectMeta{Name: "og", Namespace: "ns"}}
+	other := &v1.OperatorGroup{ObjectMeta: metav1.ObjectMeta{Name: "og", Namespace: "other"}}
+	clusterRole := func(name string, owner *v1.OperatorGroup) *rbacv1.ClusterRole {
+		cr := &rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		if owner != nil {
+			ownerutil.AddOwnerLabelsForKind(cr, owner, v1.OperatorGroupKind)
+		}
+		return cr
+	}
+	providedAPIRole := func(name, crd, suffix string, key opregistry.APIKey) *rbacv1.ClusterRole {
+		aggregationLabel, err := aggregationLabelFromAPIKey(key, suffix)
+		require.NoError(t, err)
+		cr := clusterRole(name, nil)
+		cr.SetLabels(map[string]string{aggregationLabel: "true"})
+		cr.SetOwnerReferences([]metav1.OwnerReference{{APIVersion: "apiextensions.k8s.io/v1", Kind: "CustomResourceDefinition", Name: crd}})
+		return cr
+	}
+	fooKey := opregistry.APIKey{Group: "example.com", Version: "v1", Kind: "Foo", Plural: "foos"}
+	sharedKey := opregistry.APIKey{Group: "example.com", Version: "v1", Kind: "Shared", Plural: "shareds"}
+	csvProviding := func(name, namespace string, owned ...v1alpha1.CRDDescription) *v1alpha1.ClusterServiceVersion {
+		return &v1alpha1.ClusterServiceVersion{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec: v1alpha1.ClusterServiceVersionSpec{
+				CustomResourceDefinitions: v1alpha1.CustomResourceDefinitions{Owned: owned},
+			},
+		}
+	}
+	foo := v1alpha1.CRDDescription{Name: "foos.example.com", Version: "v1", Kind: "Foo"}
+	shared := v1alpha1.CRDDescription{Name: "shareds.example.com", Version: "v1", Kind: "Shared"}
+	csv := csvProviding("csv", "ns", foo, shared)
+
+	op, err := NewFakeOperator(ctx, withNamespaces("ns", "other"), withClientObjs(
+		og, other, csv, csvProviding("csv", "other", shared),
+	), withK8sObjs(
+		clusterRole("og-admin", og),
+		clusterRole("og-edit", other),
+		clusterRole("og-view", nil),
+		providedAPIRole("foos.example.com-v1-admin", foo.Name, AdminSuffix, fooKey),
+		providedAPIRole("foos.example.com-v1-crdview", foo.Name, ViewSuffix, fooKey),
+		clusterRole("foos.example.com-v1-edit", nil),
+		providedAPIRole("shareds.example.com-v1-admin", shared.Name, AdminSuffix, sharedKey),
+		clusterRole("bars.example.com-v1-admin", nil),
+	))
+	require.NoError(t, err)
+
+	require.False(t, roleAggregationDisabled(nil))
+	require.False(t, roleAggregationDisabled(og))
+	og.SetAnnotations(map[string]string{DisableRoleAggregationAnnotationKey: "true"})
+	require.True(t, roleAggregationDisabled(og))
+
+	require.NoError(t, op.deleteOpGroupClusterRoles(ctx, og))
+	require.NoError(t, op.deleteClusterRolesForCSV(ctx, csv))
+
+	exists := func(name string) bool {
+		_, err := op.opClient.KubernetesInterface().RbacV1().ClusterRoles().Get(ctx, name, metav1.GetOptions{})
+		if k8serrors.IsNotFound(err) {
+			return false
+		}
+		require.NoError(t, err)
+		return true
+	}
+	require.False(t, exists("og-admin"))
+	require.False(t, exists("foos.example.com-v1-admin"))
+	require.False(t, exists("foos.example.com-v1-crdview"))
+
+	// Roles that aren't the group's or the CSV's are left alone
+	require.True(t, exists("og-edit"))
+	require.True(t, exists("og-view"))
+	require.True(t, exists("bars.example.com-v1-admin"))
+
+	// So are roles of the CSV's APIs that OLM didn't create, or that a CSV in a group aggregating roles provides too
+	require.True(t, exists("foos.example.com-v1-edit"))
+	require.True(t, exists("shareds.example.com-v1-admin"))
+}
@@ -0,0 +1,86 @@
+package olm
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+)
+
+// operatorGroupServiceAccountStatus returns the status of the ServiceAccount of the scoped OperatorGroup of the given
+// CSV, which installs the CSV. Without it, the install would fail with permission errors, so the CSV's requirements
+// aren't met while it is missing.
+func (a *Operator) operatorGroupServiceAccountStatus(csv *v1alpha1.ClusterServiceVersion) (met bool, statuses []v1alpha1.RequirementStatus) {
+	groups, err := a.lister.OperatorsV1().OperatorGroupLister().OperatorGroups(csv.GetNamespace()).List(labels.Everything())
+	if err != nil || len(groups) != 1 || groups[0].Spec.ServiceAccountName == "" {
+		// missing or multiple operatorgroups are reported by the operatorgroup checks
+		return true, nil
+	}
+	group := groups[0]
+
+	status := v1alpha1.RequirementStatus{
+		Group:   "",
+		Version: "v1",
+		Kind:    "ServiceAccount",
+		Name:    group.Spec.ServiceAccountName,
+		Status:  v1alpha1.RequirementStatusReasonPresent,
+	}
+	_, err = a.lister.CoreV1().ServiceAccountLister().ServiceAccounts(csv.GetNamespace()).Get(group.Spec.ServiceAccountName)
+	switch {
+	case k8serrors.IsNotFound(err):
+		status.Status = v1alpha1.RequirementStatusReasonNotPresent
+		status.Message = fmt.Sprintf("ServiceAccount %s of OperatorGroup %s not found", group.Spec.ServiceAccountName, group.GetName())
+	case err != nil:
+		status.Status = v1alpha1.RequirementStatusReasonNotAvailable
+		status.Message = err.Error()
+	}
+	return status.Status == v1alpha1.RequirementStatusReasonPresent, []v1alpha1.RequirementStatus{status}
+}
+
+// serviceAccountAddedOrRemoved requeues the OperatorGroups installing with the given ServiceAccount, and their member
+// CSVs, so that they notice the ServiceAccount is missing, or recover once it reappears.
+func (a *Operator) serviceAccountAddedOrRemoved(obj interface{}) {
+	sa, ok := obj.(*corev1.ServiceAccount)
+	if !ok {
+		tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			return
+		}
+		if sa, ok = tombstone.Obj.(*corev1.ServiceAccount); !ok {
+			return
+		}
+	}
+
+	logger := a.logger.WithField("serviceaccount", sa.GetNamespace()+"/"+sa.GetName())
+	groups, err := a.lister.OperatorsV1().OperatorGroupLister().OperatorGroups(sa.GetNamespace()).List(labels.Everything())
+	if err != nil {
+		logger.WithError(err).Warn("lister failed")
+		return
+	}
+	for _, group := range groups {
+		if group.Spec.ServiceAccountName != sa.GetName() {
+			continue
+		}
+		if err := a.ogQueueSet.Requeue(group.GetNamespace(), group.GetName()); err != nil {
+			logger.WithError(err).Warn("error requeuing operatorgroup")
+		}
+
+		csvs, err := a.lister.OperatorsV1alpha1().ClusterServiceVersionLister().ClusterServiceVersions(sa.GetNamespace()).List(labels.Everything())
+		if err != nil {
+			logger.WithError(err).Warn("lister failed")
+			return
+		}
+		for _, csv := range csvs {
+			if csv.IsCopied() {
+				continue
+			}
+			if err := a.csvQueueSet.Requeue(csv.GetNamespace(), csv.GetName()); err != nil {
+				logger.WithError(err).Warn("error requeuing csv")
+			}
+		}
+	}
+}
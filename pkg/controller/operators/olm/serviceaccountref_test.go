@@ -0,0 +1,73 @@
+package olm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	operatorsv1 "github.com/operator-framework/api/pkg/operators/v1"
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+)
+
+func TestOperatorGroupServiceAccountStatus(t *testing.T) {
+	csv := &v1alpha1.ClusterServiceVersion{ObjectMeta: metav1.ObjectMeta{Name: "csv", Namespace: "ns"}}
+	operatorGroup := func(serviceAccountName string) *operatorsv1.OperatorGroup {
+		return &operatorsv1.OperatorGroup{
+			ObjectMeta: metav1.ObjectMeta{Name: "og", Namespace: "ns"},
+			Spec:       operatorsv1.OperatorGroupSpec{ServiceAccountName: serviceAccountName},
+		}
+	}
+	sa := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "installer", Namespace: "ns"}}
+
+	tests := []struct {
+		name     string
+		group    *operatorsv1.OperatorGroup
+		k8sObjs  []runtime.Object
+		met      bool
+		expected []v1alpha1.RequirementStatus
+	}{
+		{
+			name:  "NotScoped",
+			group: operatorGroup(""),
+			met:   true,
+		},
+		{
+			name:    "ServiceAccountPresent",
+			group:   operatorGroup("installer"),
+			k8sObjs: []runtime.Object{sa},
+			met:     true,
+			expected: []v1alpha1.RequirementStatus{
+				{Version: "v1", Kind: "ServiceAccount", Name: "installer", Status: v1alpha1.RequirementStatusReasonPresent},
+			},
+		},
+		{
+			name:  "ServiceAccountMissing",
+			group: operatorGroup("installer"),
+			expected: []v1alpha1.RequirementStatus{{
+				Version: "v1",
+				Kind:    "ServiceAccount",
+				Name:    "installer",
+				Status:  v1alpha1.RequirementStatusReasonNotPresent,
+				Message: "ServiceAccount installer of OperatorGroup og not found",
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.TODO())
+			defer cancel()
+
+			op, err := NewFakeOperator(ctx, withNamespaces("ns"), withClientObjs(csv, tt.group), withK8sObjs(tt.k8sObjs...))
+			require.NoError(t, err)
+
+			met, statuses := op.operatorGroupServiceAccountStatus(csv)
+			require.Equal(t, tt.met, met)
+			require.Equal(t, tt.expected, statuses)
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package olm
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/queueinformer"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/metrics"
+)
+
+// shardNamespace returns the namespace whose shard reconciles the given object, i.e. its own name for Namespaces.
+func shardNamespace(obj interface{}) (string, bool) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	if ns, ok := obj.(*corev1.Namespace); ok {
+		return ns.GetName(), true
+	}
+	m, err := meta.Accessor(obj)
+	if err != nil {
+		return "", false
+	}
+	return m.GetNamespace(), true
+}
+
+// inShard returns a sync handler syncing the objects of the namespaces assigned to the shard of the operator, and
+// dropping the others, which are reconciled by other replicas.
+func (a *Operator) inShard(sync queueinformer.SyncHandler) queueinformer.SyncHandler {
+	if a.shard == nil {
+		return sync
+	}
+	return func(ctx context.Context, obj interface{}) error {
+		if ns, ok := shardNamespace(obj); ok && !a.shard.Owns(ns) {
+			return nil
+		}
+		return sync(ctx, obj)
+	}
+}
+
+// inShardDelete is inShard for deletion handlers.
+func (a *Operator) inShardDelete(handle func(ctx context.Context, obj interface{})) func(ctx context.Context, obj interface{}) {
+	if a.shard == nil {
+		return handle
+	}
+	return func(ctx context.Context, obj interface{}) {
+		if ns, ok := shardNamespace(obj); ok && !a.shard.Owns(ns) {
+			return
+		}
+		handle(ctx, obj)
+	}
+}
+
+// shardNamespaceCounter counts the namespaces assigned to the shard of the operator.
+func (a *Operator) shardNamespaceCounter() cache.ResourceEventHandler {
+	count := func(obj interface{}, n int) {
+		if ns, ok := shardNamespace(obj); ok && a.shard.Owns(ns) {
+			metrics.AddShardNamespaces(n)
+		}
+	}
+	return cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj interface{}) { count(obj, 1) },
+		DeleteFunc: func(obj interface{}) { count(obj, -1) },
+	}
+}
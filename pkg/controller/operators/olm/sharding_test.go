@@ -0,0 +1,70 @@
+package olm
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/sharding"
+)
+
+func TestInShard(t *testing.T) {
+	shards := make([]*Operator, 3)
+	for i := range shards {
+		s, err := sharding.New(i, len(shards))
+		require.NoError(t, err)
+		shards[i] = &Operator{shard: s}
+	}
+
+	objects := []interface{}{
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "cluster"}},
+		cache.DeletedFinalStateUnknown{Obj: &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "deleted"}}},
+	}
+	for i := 0; i < 20; i++ {
+		objects = append(objects, &v1alpha1.ClusterServiceVersion{ObjectMeta: metav1.ObjectMeta{
+			Name:      "csv",
+			Namespace: fmt.Sprintf("ns-%d", i),
+		}})
+	}
+
+	// Each object is synced by exactly one shard
+	for _, obj := range objects {
+		synced, deleted := 0, 0
+		for _, op := range shards {
+			require.NoError(t, op.inShard(func(ctx context.Context, obj interface{}) error {
+				synced++
+				return nil
+			})(context.Background(), obj))
+			op.inShardDelete(func(_ context.Context, obj interface{}) { deleted++ })(context.TODO(), obj)
+		}
+		require.Equal(t, 1, synced, "object %v", obj)
+		require.Equal(t, 1, deleted, "object %v", obj)
+	}
+
+	// Cluster-scoped objects are synced by the first shard
+	var synced []int
+	for i, op := range shards {
+		require.NoError(t, op.inShard(func(ctx context.Context, obj interface{}) error {
+			synced = append(synced, i)
+			return nil
+		})(context.Background(), &v1alpha1.ClusterServiceVersion{ObjectMeta: metav1.ObjectMeta{Name: "cluster"}}))
+	}
+	require.Equal(t, []int{0}, synced)
+
+	// Unsharded operators sync everything
+	unsharded := &Operator{}
+	var all int
+	for _, obj := range objects {
+		require.NoError(t, unsharded.inShard(func(ctx context.Context, obj interface{}) error {
+			all++
+			return nil
+		})(context.Background(), obj))
+	}
+	require.Equal(t, len(objects), all)
+}
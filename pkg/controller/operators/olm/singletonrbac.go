@@ -0,0 +1,108 @@
+package olm
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	index "github.com/operator-framework/operator-lifecycle-manager/pkg/lib/index"
+	hashutil "github.com/operator-framework/operator-lifecycle-manager/pkg/lib/kubernetes/pkg/util/hash"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/names"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/ownerutil"
+)
+
+// singletonRBACName returns the name of the ClusterRole or ClusterRoleBinding lifting the named Role or RoleBinding of
+// an operator installed in AllNamespaces mode to the cluster scope. Role names only hash the CSV name and permissions,
+// so the hash of the operator namespace is appended to keep the same CSV installed in different namespaces over time
+// from colliding on cluster-scoped names.
+func singletonRBACName(operatorNamespace, name string) string {
+	return names.Hashed(name, []string{operatorNamespace, name})
+}
+
+// legacySingletonRBACNames returns the names the named Role or RoleBinding was lifted to the cluster scope under by
+// older versions of OLM: its own name, and the hashed name before its length was bounded, if it differs.
+func legacySingletonRBACNames(operatorNamespace, name string) []string {
+	hasher := fnv.New32a()
+	hashutil.DeepHashObject(hasher, []string{operatorNamespace, name})
+	unbounded := fmt.Sprintf("%s-%s", name, utilrand.SafeEncodeString(fmt.Sprint(hasher.Sum32())))
+	if unbounded == singletonRBACName(operatorNamespace, name) {
+		return []string{name}
+	}
+	return []string{name, unbounded}
+}
+
+// clusterRBACOwnedBy returns the objects of the given ClusterRole or ClusterRoleBinding indexer owned by the given CSV,
+// keyed by name.
+func clusterRBACOwnedBy(indexer cache.Indexer, csv *v1alpha1.ClusterServiceVersion) (map[string]metav1.Object, error) {
+	objs, err := indexer.ByIndex(index.CSVOwnerIndexFuncKey, index.CSVOwnerIndexKey(csv.GetNamespace(), csv.GetName()))
+	if err != nil {
+		return nil, err
+	}
+
+	owned := make(map[string]metav1.Object, len(objs))
+	for _, obj := range objs {
+		m, ok := obj.(metav1.Object)
+		if !ok {
+			continue
+		}
+		owned[m.GetName()] = m
+	}
+	return owned, nil
+}
+
+// hasLegacySingletonRBAC returns whether any Role of the given CSV is still lifted to the cluster scope under a legacy
+// name, as OLM did before namespacing and bounding those names.
+func (a *Operator) hasLegacySingletonRBAC(operatorNamespace string, csv *v1alpha1.ClusterServiceVersion) (bool, error) {
+	ownedRoles, err := a.lister.RbacV1().RoleLister().Roles(operatorNamespace).List(ownerutil.CSVOwnerSelector(csv))
+	if err != nil {
+		return false, err
+	}
+	ownedClusterRoles, err := clusterRBACOwnedBy(a.clusterRoleIndexer, csv)
+	if err != nil {
+		return false, err
+	}
+	for _, r := range ownedRoles {
+		for _, name := range legacySingletonRBACNames(operatorNamespace, r.GetName()) {
+			if _, ok := ownedClusterRoles[name]; ok {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// deleteLegacySingletonRBAC deletes the ClusterRoleBindings and ClusterRoles that lifted the given Roles and
+// RoleBindings under their legacy names. Only objects owned by the same CSV are deleted, leaving those of another CSV
+// that happened to generate the same names untouched.
+func (a *Operator) deleteLegacySingletonRBAC(ctx context.Context, operatorNamespace string, ownedRoles []*rbacv1.Role, ownedRoleBindings []*rbacv1.RoleBinding, ownedClusterRoles, ownedClusterRoleBindings map[string]metav1.Object) error {
+	for _, rb := range ownedRoleBindings {
+		for _, name := range legacySingletonRBACNames(operatorNamespace, rb.GetName()) {
+			if _, ok := ownedClusterRoleBindings[name]; !ok {
+				continue
+			}
+			if err := a.opClient.DeleteClusterRoleBinding(ctx, name, &metav1.DeleteOptions{}); err != nil && !k8serrors.IsNotFound(err) {
+				return err
+			}
+			a.logger.WithField("clusterrolebinding", name).Info("deleted legacy cluster role binding")
+		}
+	}
+	for _, r := range ownedRoles {
+		for _, name := range legacySingletonRBACNames(operatorNamespace, r.GetName()) {
+			if _, ok := ownedClusterRoles[name]; !ok {
+				continue
+			}
+			if err := a.opClient.DeleteClusterRole(ctx, name, &metav1.DeleteOptions{}); err != nil && !k8serrors.IsNotFound(err) {
+				return err
+			}
+			a.logger.WithField("clusterrole", name).Info("deleted legacy cluster role")
+		}
+	}
+	return nil
+}
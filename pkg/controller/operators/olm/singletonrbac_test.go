@@ -0,0 +1,74 @@
+package olm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	rbacv1 "k8s.io/api/rbac/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/ownerutil"
+)
+
+func TestSingletonRBACName(t *testing.T) {
+	require.Equal(t, singletonRBACName("a", "role"), singletonRBACName("a", "role"))
+	require.NotEqual(t, singletonRBACName("a", "role"), singletonRBACName("b", "role"))
+}
+
+func TestEnsureSingletonRBACMigratesLegacyNames(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+
+	csv := &v1alpha1.ClusterServiceVersion{ObjectMeta: metav1.ObjectMeta{Name: "csv", Namespace: "operators"}}
+	other := &v1alpha1.ClusterServiceVersion{ObjectMeta: metav1.ObjectMeta{Name: "csv", Namespace: "elsewhere"}}
+	ownerLabels := ownerutil.OwnerLabel(csv, v1alpha1.ClusterServiceVersionKind)
+	rules := []rbacv1.PolicyRule{{Verbs: []string{"get"}, APIGroups: []string{""}, Resources: []string{"pods"}}}
+
+	role := &rbacv1.Role{
+		ObjectMeta: metav1.ObjectMeta{Name: "csv-role", Namespace: "operators", Labels: ownerLabels},
+		Rules:      rules,
+	}
+	roleBinding := &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: "csv-role", Namespace: "operators", Labels: ownerLabels},
+		Subjects:   []rbacv1.Subject{{Kind: rbacv1.ServiceAccountKind, Name: "operator", Namespace: "operators"}},
+		RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "Role", Name: "csv-role"},
+	}
+	legacyClusterRole := &rbacv1.ClusterRole{
+		ObjectMeta: metav1.ObjectMeta{Name: "csv-role", Labels: ownerLabels},
+		Rules:      rules,
+	}
+	legacyClusterRoleBinding := &rbacv1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: "csv-role", Labels: ownerLabels},
+		Subjects:   roleBinding.Subjects,
+		RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "csv-role"},
+	}
+
+	op, err := NewFakeOperator(ctx, withNamespaces("operators"), withK8sObjs(role, roleBinding, legacyClusterRole, legacyClusterRoleBinding))
+	require.NoError(t, err)
+
+	legacy, err := op.hasLegacySingletonRBAC("operators", csv)
+	require.NoError(t, err)
+	require.True(t, legacy)
+	legacy, err = op.hasLegacySingletonRBAC("operators", other)
+	require.NoError(t, err)
+	require.False(t, legacy)
+
+	require.NoError(t, op.ensureSingletonRBAC(context.TODO(), "operators", csv))
+
+	rbac := op.opClient.KubernetesInterface().RbacV1()
+	name := singletonRBACName("operators", "csv-role")
+	clusterRole, err := rbac.ClusterRoles().Get(ctx, name, metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, ownerLabels, clusterRole.GetLabels())
+	clusterRoleBinding, err := rbac.ClusterRoleBindings().Get(ctx, name, metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, name, clusterRoleBinding.RoleRef.Name)
+
+	_, err = rbac.ClusterRoles().Get(ctx, "csv-role", metav1.GetOptions{})
+	require.True(t, k8serrors.IsNotFound(err))
+	_, err = rbac.ClusterRoleBindings().Get(ctx, "csv-role", metav1.GetOptions{})
+	require.True(t, k8serrors.IsNotFound(err))
+}
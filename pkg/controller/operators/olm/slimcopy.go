@@ -0,0 +1,36 @@
+package olm
+
+import (
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+)
+
+const (
+	// SlimCopiedCSVsAnnotationKey is the annotation of the cluster OLMConfig that, when "true", strips copied CSVs of
+	// the large fields consoles don't need to list operators in target namespaces, reducing their etcd footprint.
+	SlimCopiedCSVsAnnotationKey = "operatorframework.io/slim-copied-csvs"
+
+	// SlimCopyAnnotationKey marks a copied CSV as slimmed, telling clients to read the full CSV from the operator
+	// namespace named by olm.operatorNamespace.
+	SlimCopyAnnotationKey = "operatorframework.io/slim-copy"
+
+	// almExamplesAnnotationKey holds the example custom resources of a CSV, which can be hundreds of kilobytes.
+	almExamplesAnnotationKey = "alm-examples"
+
+	// shortDescriptionAnnotationKey holds the one-line description of a CSV shown on catalog tiles.
+	shortDescriptionAnnotationKey = "description"
+
+	// maxSlimDescriptionLength is the length in bytes above which the description of a slim copy is replaced by the
+	// CSV's short description.
+	maxSlimDescriptionLength = 1024
+)
+
+// slimCopyPrototype strips the icons, examples and long description off the given copy prototype, keeping the
+// metadata consoles show for operators available in a namespace.
+func slimCopyPrototype(prototype *v1alpha1.ClusterServiceVersion) {
+	prototype.Spec.Icon = nil
+	if len(prototype.Spec.Description) > maxSlimDescriptionLength {
+		prototype.Spec.Description = prototype.Annotations[shortDescriptionAnnotationKey]
+	}
+	delete(prototype.Annotations, almExamplesAnnotationKey)
+	prototype.Annotations[SlimCopyAnnotationKey] = "true"
+}
@@ -0,0 +1,53 @@
+package olm
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+)
+
+func TestSlimCopyPrototype(t *testing.T) {
+	src := v1alpha1.ClusterServiceVersion{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "name",
+			Namespace: "operators",
+			Annotations: map[string]string{
+				almExamplesAnnotationKey:      `[{"kind": "Foo"}]`,
+				shortDescriptionAnnotationKey: "Manages foos",
+				"capabilities":                "Basic Install",
+			},
+		},
+		Spec: v1alpha1.ClusterServiceVersionSpec{
+			DisplayName: "Foo Operator",
+			Description: strings.Repeat("long ", maxSlimDescriptionLength),
+			Icon:        []v1alpha1.Icon{{Data: "base64", MediaType: "image/png"}},
+			Keywords:    []string{"foo"},
+		},
+	}
+
+	var dst v1alpha1.ClusterServiceVersion
+	csvCopyPrototype(&src, &dst)
+	slimCopyPrototype(&dst)
+
+	require.Equal(t, map[string]string{
+		shortDescriptionAnnotationKey: "Manages foos",
+		"capabilities":                "Basic Install",
+		SlimCopyAnnotationKey:         "true",
+	}, dst.GetAnnotations())
+	require.Equal(t, v1alpha1.ClusterServiceVersionSpec{
+		DisplayName: "Foo Operator",
+		Description: "Manages foos",
+		Keywords:    []string{"foo"},
+	}, dst.Spec)
+
+	// The original CSV is untouched and short descriptions are kept
+	require.Len(t, src.Spec.Icon, 1)
+	src.Spec.Description = "short"
+	csvCopyPrototype(&src, &dst)
+	slimCopyPrototype(&dst)
+	require.Equal(t, "short", dst.Spec.Description)
+}
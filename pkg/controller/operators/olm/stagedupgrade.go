@@ -0,0 +1,234 @@
+package olm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/operator-framework/api/pkg/operators/v1"
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+)
+
+const (
+	// CanaryNamespacesAnnotationKey is the OperatorGroup annotation listing, comma-separated, the target namespaces a
+	// CSV of the group replacing another is copied to first. The CSV is copied to its other target namespaces, and the
+	// CSV it replaces is garbage collected, once it has stayed healthy for the canary duration.
+	CanaryNamespacesAnnotationKey = "operatorframework.io/canary-namespaces"
+
+	// CanaryDurationAnnotationKey is the OperatorGroup annotation holding how long, as a duration string (e.g. "10m"),
+	// an upgraded CSV has to stay in the Succeeded phase before its upgrade proceeds past the canary namespaces.
+	CanaryDurationAnnotationKey = "operatorframework.io/canary-duration"
+
+	// StagedUpgradeAnnotationKey is the CSV annotation OLM tracks the stage of a staged upgrade in.
+	StagedUpgradeAnnotationKey = "operatorframework.io/staged-upgrade"
+
+	// defaultCanaryDuration is the canary duration of OperatorGroups that don't set one.
+	defaultCanaryDuration = 5 * time.Minute
+
+	// stagedUpgradeReason is the reason of the events emitted when a staged upgrade changes stage.
+	stagedUpgradeReason = "StagedUpgrade"
+)
+
+// The stages of a staged upgrade.
+const (
+	// StagedUpgradeCanary is the stage of a CSV copied to the canary namespaces only, whose health is being verified.
+	StagedUpgradeCanary = "Canary"
+	// StagedUpgradeHalted is the stage of a CSV that failed while copied to the canary namespaces only. The CSV it
+	// replaces keeps serving the other target namespaces, and the upgrade resumes if the CSV recovers.
+	StagedUpgradeHalted = "Halted"
+	// StagedUpgradeComplete is the stage of a CSV copied to all its target namespaces.
+	StagedUpgradeComplete = "Complete"
+)
+
+// StagedUpgrade is the state of the staged upgrade of a CSV.
+type StagedUpgrade struct {
+	// Replaces is the name of the CSV upgraded from.
+	Replaces string `json:"replaces"`
+	Stage    string `json:"stage"`
+	// Namespaces are the canary namespaces the CSV is copied to first.
+	Namespaces []string `json:"namespaces"`
+	// HealthySince is when the CSV was last observed reaching the Succeeded phase during the canary stage.
+	HealthySince *metav1.Time `json:"healthySince,omitempty"`
+	Message      string       `json:"message,omitempty"`
+}
+
+// StagedUpgradeFor returns the staged upgrade recorded on the given CSV, or nil if none has been recorded.
+func StagedUpgradeFor(csv *v1alpha1.ClusterServiceVersion) (*StagedUpgrade, error) {
+	value, ok := csv.GetAnnotations()[StagedUpgradeAnnotationKey]
+	if !ok {
+		return nil, nil
+	}
+	upgrade := &StagedUpgrade{}
+	if err := json.Unmarshal([]byte(value), upgrade); err != nil {
+		return nil, fmt.Errorf("invalid %s annotation: %v", StagedUpgradeAnnotationKey, err)
+	}
+	return upgrade, nil
+}
+
+// stagedUpgradeInProgress returns true if the given CSV is being upgraded to in stages that haven't completed yet.
+func stagedUpgradeInProgress(csv *v1alpha1.ClusterServiceVersion) bool {
+	upgrade, err := StagedUpgradeFor(csv)
+	return err == nil && upgrade != nil && upgrade.Stage != StagedUpgradeComplete
+}
+
+// canaryDurationFor returns the canary duration set on the given OperatorGroup, or the default if it's unset or
+// invalid.
+func canaryDurationFor(operatorGroup *v1.OperatorGroup) time.Duration {
+	duration, err := time.ParseDuration(operatorGroup.GetAnnotations()[CanaryDurationAnnotationKey])
+	if err != nil || duration <= 0 {
+		return defaultCanaryDuration
+	}
+	return duration
+}
+
+// canaryNamespacesFor returns the sorted canary namespaces set on the given OperatorGroup that are among its target
+// namespaces.
+func canaryNamespacesFor(operatorGroup *v1.OperatorGroup) []string {
+	value, ok := operatorGroup.GetAnnotations()[CanaryNamespacesAnnotationKey]
+	if !ok {
+		return nil
+	}
+	targets := NewNamespaceSet(operatorGroup.Status.Namespaces)
+	canaries := NamespaceSet{}
+	for _, namespace := range strings.Split(value, ",") {
+		namespace = strings.TrimSpace(namespace)
+		if namespace == "" || namespace == operatorGroup.GetNamespace() || !targets.Contains(namespace) {
+			continue
+		}
+		canaries[namespace] = struct{}{}
+	}
+	namespaces := make([]string, 0, len(canaries))
+	for namespace := range canaries {
+		namespaces = append(namespaces, namespace)
+	}
+	sort.Strings(namespaces)
+	return namespaces
+}
+
+// advanceStagedUpgrade returns the stage the given staged upgrade of a CSV moves to, given the copies of the CSV
+// missing from the canary namespaces.
+func advanceStagedUpgrade(upgrade StagedUpgrade, csv *v1alpha1.ClusterServiceVersion, now *metav1.Time, duration time.Duration, missingCopies []string) StagedUpgrade {
+	switch csv.Status.Phase {
+	case v1alpha1.CSVPhaseSucceeded:
+		if upgrade.Stage != StagedUpgradeCanary || upgrade.HealthySince == nil {
+			upgrade.Stage = StagedUpgradeCanary
+			upgrade.HealthySince = now
+			upgrade.Message = fmt.Sprintf("verifying health in canary namespaces %s for %s", strings.Join(upgrade.Namespaces, ", "), duration)
+			return upgrade
+		}
+		if now.Sub(upgrade.HealthySince.Time) < duration {
+			return upgrade
+		}
+		if len(missingCopies) > 0 {
+			upgrade.Message = fmt.Sprintf("waiting for copies in canary namespaces %s", strings.Join(missingCopies, ", "))
+			return upgrade
+		}
+		upgrade.Stage = StagedUpgradeComplete
+		upgrade.HealthySince = nil
+		upgrade.Message = fmt.Sprintf("healthy in canary namespaces %s for %s, upgrading all target namespaces", strings.Join(upgrade.Namespaces, ", "), duration)
+	case v1alpha1.CSVPhaseFailed:
+		upgrade.Stage = StagedUpgradeHalted
+		upgrade.HealthySince = nil
+		upgrade.Message = fmt.Sprintf("failed in canary namespaces %s: %s", strings.Join(upgrade.Namespaces, ", "), csv.Status.Message)
+	default:
+		// health is verified again once the csv reaches the succeeded phase
+		upgrade.HealthySince = nil
+		if upgrade.Stage == StagedUpgradeCanary {
+			upgrade.Message = fmt.Sprintf("upgrading canary namespaces %s, waiting for the csv to succeed", strings.Join(upgrade.Namespaces, ", "))
+		}
+	}
+	return upgrade
+}
+
+// syncStagedUpgrade starts or advances the staged upgrade of the given CSV, recording its stage on the CSV. It
+// returns the canary namespaces the CSV is limited to, or nil if it's copied to all its target namespaces.
+func (a *Operator) syncStagedUpgrade(ctx context.Context, logger *logrus.Entry, csv *v1alpha1.ClusterServiceVersion, operatorGroup *v1.OperatorGroup) (NamespaceSet, error) {
+	upgrade, err := StagedUpgradeFor(csv)
+	if err != nil {
+		logger.WithError(err).Warn("ignoring invalid staged upgrade")
+		upgrade = nil
+	}
+
+	canaries := canaryNamespacesFor(operatorGroup)
+	var next StagedUpgrade
+	switch {
+	case upgrade == nil:
+		// only upgrades from a csv that's still installed are staged
+		if csv.Spec.Replaces == "" || len(canaries) == 0 {
+			return nil, nil
+		}
+		if _, err := a.lister.OperatorsV1alpha1().ClusterServiceVersionLister().ClusterServiceVersions(csv.GetNamespace()).Get(csv.Spec.Replaces); err != nil {
+			return nil, nil
+		}
+		next = StagedUpgrade{Replaces: csv.Spec.Replaces, Stage: StagedUpgradeCanary, Namespaces: canaries}
+		next = advanceStagedUpgrade(next, csv, a.now(), canaryDurationFor(operatorGroup), nil)
+	case upgrade.Stage == StagedUpgradeComplete:
+		return nil, nil
+	case len(canaries) == 0:
+		next = *upgrade
+		next.Stage = StagedUpgradeComplete
+		next.HealthySince = nil
+		next.Message = "canary namespaces removed from the operatorgroup, upgrading all target namespaces"
+	default:
+		var missing []string
+		for _, namespace := range upgrade.Namespaces {
+			if _, err := a.copiedCSVLister.ClusterServiceVersions(namespace).Get(csv.GetName()); err != nil {
+				missing = append(missing, namespace)
+			}
+		}
+		next = advanceStagedUpgrade(*upgrade, csv, a.now(), canaryDurationFor(operatorGroup), missing)
+	}
+
+	if upgrade == nil || next.Stage != upgrade.Stage || next.Message != upgrade.Message || !next.HealthySince.Equal(upgrade.HealthySince) {
+		if err := a.setStagedUpgrade(ctx, csv, &next); err != nil {
+			return nil, err
+		}
+		if upgrade == nil || next.Stage != upgrade.Stage {
+			logger.WithField("stage", next.Stage).Info(next.Message)
+			eventType := corev1.EventTypeNormal
+			if next.Stage == StagedUpgradeHalted {
+				eventType = corev1.EventTypeWarning
+			}
+			a.recorder.Eventf(csv, eventType, stagedUpgradeReason, "%s: %s", next.Stage, next.Message)
+		}
+	}
+
+	if next.Stage == StagedUpgradeComplete {
+		// the csv upgraded from is held in the replacing phase until now
+		if err := a.csvQueueSet.Requeue(csv.GetNamespace(), next.Replaces); err != nil {
+			logger.WithError(err).Debug("unable to requeue replaced csv")
+		}
+		return nil, nil
+	}
+	if next.HealthySince != nil {
+		remaining := canaryDurationFor(operatorGroup) - a.now().Sub(next.HealthySince.Time)
+		if remaining < time.Second {
+			remaining = time.Second
+		}
+		if err := a.csvCopyQueueSet.RequeueAfter(csv.GetNamespace(), csv.GetName(), remaining); err != nil {
+			logger.WithError(err).Debug("unable to requeue csv until the end of its canary stage")
+		}
+	}
+	return NewNamespaceSet(next.Namespaces), nil
+}
+
+func (a *Operator) setStagedUpgrade(ctx context.Context, csv *v1alpha1.ClusterServiceVersion, upgrade *StagedUpgrade) error {
+	value, err := json.Marshal(upgrade)
+	if err != nil {
+		return err
+	}
+	out := csv.DeepCopy()
+	if out.Annotations == nil {
+		out.Annotations = map[string]string{}
+	}
+	out.Annotations[StagedUpgradeAnnotationKey] = string(value)
+	_, err = a.client.OperatorsV1alpha1().ClusterServiceVersions(out.GetNamespace()).Update(ctx, out, metav1.UpdateOptions{})
+	return err
+}
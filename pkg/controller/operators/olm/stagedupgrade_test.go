@@ -0,0 +1,159 @@
+package olm
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilclock "k8s.io/apimachinery/pkg/util/clock"
+
+	v1 "github.com/operator-framework/api/pkg/operators/v1"
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+)
+
+func TestCanaryNamespacesFor(t *testing.T) {
+	group := func(canaries string, targets ...string) *v1.OperatorGroup {
+		return &v1.OperatorGroup{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "operators", Name: "group", Annotations: map[string]string{CanaryNamespacesAnnotationKey: canaries}},
+			Status:     v1.OperatorGroupStatus{Namespaces: targets},
+		}
+	}
+
+	require.Nil(t, canaryNamespacesFor(&v1.OperatorGroup{}))
+	require.Equal(t, []string{"a", "c"}, canaryNamespacesFor(group("c, a,,a", "a", "b", "c")))
+	require.Equal(t, []string{"a"}, canaryNamespacesFor(group("a,d,operators", "a", "b", "operators")))
+	require.Equal(t, []string{"a", "d"}, canaryNamespacesFor(group("a,d,operators", metav1.NamespaceAll)))
+}
+
+func TestAdvanceStagedUpgrade(t *testing.T) {
+	now := metav1.NewTime(time.Date(2021, time.October, 1, 12, 0, 0, 0, time.UTC))
+	since := func(d time.Duration) *metav1.Time {
+		t := metav1.NewTime(now.Add(-d))
+		return &t
+	}
+	csv := func(phase v1alpha1.ClusterServiceVersionPhase) *v1alpha1.ClusterServiceVersion {
+		return &v1alpha1.ClusterServiceVersion{Status: v1alpha1.ClusterServiceVersionStatus{Phase: phase, Message: "deployment unhealthy"}}
+	}
+	upgrade := func(stage string, healthySince *metav1.Time) StagedUpgrade {
+		return StagedUpgrade{Replaces: "v1", Stage: stage, Namespaces: []string{"a"}, HealthySince: healthySince}
+	}
+
+	tests := []struct {
+		name         string
+		upgrade      StagedUpgrade
+		csv          *v1alpha1.ClusterServiceVersion
+		missing      []string
+		stage        string
+		healthySince *metav1.Time
+	}{
+		{
+			name:    "Installing",
+			upgrade: upgrade(StagedUpgradeCanary, nil),
+			csv:     csv(v1alpha1.CSVPhaseInstalling),
+			stage:   StagedUpgradeCanary,
+		},
+		{
+			name:         "Succeeded/StartsVerifying",
+			upgrade:      upgrade(StagedUpgradeCanary, nil),
+			csv:          csv(v1alpha1.CSVPhaseSucceeded),
+			stage:        StagedUpgradeCanary,
+			healthySince: &now,
+		},
+		{
+			name:         "Succeeded/Verifying",
+			upgrade:      upgrade(StagedUpgradeCanary, since(time.Minute)),
+			csv:          csv(v1alpha1.CSVPhaseSucceeded),
+			stage:        StagedUpgradeCanary,
+			healthySince: since(time.Minute),
+		},
+		{
+			name:    "Succeeded/Verified",
+			upgrade: upgrade(StagedUpgradeCanary, since(10*time.Minute)),
+			csv:     csv(v1alpha1.CSVPhaseSucceeded),
+			stage:   StagedUpgradeComplete,
+		},
+		{
+			name:         "Succeeded/CopiesMissing",
+			upgrade:      upgrade(StagedUpgradeCanary, since(10*time.Minute)),
+			csv:          csv(v1alpha1.CSVPhaseSucceeded),
+			missing:      []string{"a"},
+			stage:        StagedUpgradeCanary,
+			healthySince: since(10 * time.Minute),
+		},
+		{
+			name:    "Failed/Halts",
+			upgrade: upgrade(StagedUpgradeCanary, since(time.Minute)),
+			csv:     csv(v1alpha1.CSVPhaseFailed),
+			stage:   StagedUpgradeHalted,
+		},
+		{
+			name:         "Halted/Recovers",
+			upgrade:      upgrade(StagedUpgradeHalted, nil),
+			csv:          csv(v1alpha1.CSVPhaseSucceeded),
+			stage:        StagedUpgradeCanary,
+			healthySince: &now,
+		},
+		{
+			name:    "Pending/ResetsVerification",
+			upgrade: upgrade(StagedUpgradeCanary, since(time.Minute)),
+			csv:     csv(v1alpha1.CSVPhasePending),
+			stage:   StagedUpgradeCanary,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next := advanceStagedUpgrade(tt.upgrade, tt.csv, &now, 5*time.Minute, tt.missing)
+			require.Equal(t, tt.stage, next.Stage)
+			require.Equal(t, tt.healthySince, next.HealthySince)
+		})
+	}
+}
+
+func TestSyncStagedUpgrade(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+
+	now := time.Date(2021, time.October, 1, 12, 0, 0, 0, time.UTC)
+	group := &v1.OperatorGroup{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "operators", Name: "group", Annotations: map[string]string{CanaryNamespacesAnnotationKey: "a"}},
+		Status:     v1.OperatorGroupStatus{Namespaces: []string{"a", "b"}},
+	}
+	replaced := &v1alpha1.ClusterServiceVersion{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "operators", Name: "v1"},
+		Status:     v1alpha1.ClusterServiceVersionStatus{Phase: v1alpha1.CSVPhaseReplacing},
+	}
+	csv := &v1alpha1.ClusterServiceVersion{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "operators", Name: "v2"},
+		Spec:       v1alpha1.ClusterServiceVersionSpec{Replaces: "v1"},
+		Status:     v1alpha1.ClusterServiceVersionStatus{Phase: v1alpha1.CSVPhaseInstalling},
+	}
+
+	op, err := NewFakeOperator(ctx, withNamespaces("operators"), withClock(utilclock.NewFakeClock(now)), withClientObjs(group, replaced, csv))
+	require.NoError(t, err)
+
+	canaries, err := op.syncStagedUpgrade(ctx, logrus.NewEntry(op.logger), csv, group)
+	require.NoError(t, err)
+	require.Equal(t, NewNamespaceSet([]string{"a"}), canaries)
+
+	out, err := op.client.OperatorsV1alpha1().ClusterServiceVersions("operators").Get(ctx, "v2", metav1.GetOptions{})
+	require.NoError(t, err)
+	upgrade, err := StagedUpgradeFor(out)
+	require.NoError(t, err)
+	require.Equal(t, StagedUpgradeCanary, upgrade.Stage)
+	require.Equal(t, "v1", upgrade.Replaces)
+	require.Equal(t, []string{"a"}, upgrade.Namespaces)
+	require.True(t, stagedUpgradeInProgress(out))
+
+	// without canary namespaces, the upgrade completes
+	delete(group.Annotations, CanaryNamespacesAnnotationKey)
+	canaries, err = op.syncStagedUpgrade(ctx, logrus.NewEntry(op.logger), out, group)
+	require.NoError(t, err)
+	require.Nil(t, canaries)
+
+	out, err = op.client.OperatorsV1alpha1().ClusterServiceVersions("operators").Get(ctx, "v2", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.False(t, stagedUpgradeInProgress(out))
+}
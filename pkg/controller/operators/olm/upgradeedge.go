@@ -0,0 +1,171 @@
+package olm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/blang/semver/v4"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+)
+
+const (
+	// UpgradeEdgeAnnotationKey is the CSV annotation OLM records the upgrade edge of a CSV replacing another in, along
+	// with how its skips and olm.skipRange were evaluated against each CSV it supersedes, so that the garbage
+	// collection of the superseded CSVs can be audited after the fact.
+	UpgradeEdgeAnnotationKey = "operatorframework.io/upgrade-edge"
+
+	// skipRangeAnnotationKey is the CSV annotation holding the range of versions a CSV skips.
+	skipRangeAnnotationKey = "olm.skipRange"
+
+	// upgradeEdgeReason is the reason of the event emitted when the upgrade edge of a CSV is recorded.
+	upgradeEdgeReason = "UpgradeEdge"
+)
+
+// The ways a CSV can replace the CSV named in its spec.replaces.
+const (
+	UpgradeEdgeReplaces  = "Replaces"
+	UpgradeEdgeSkips     = "Skips"
+	UpgradeEdgeSkipRange = "SkipRange"
+)
+
+// UpgradeEdge describes how a CSV replaces the CSVs installed before it.
+type UpgradeEdge struct {
+	// Replaces is the name of the CSV directly replaced.
+	Replaces string `json:"replaces"`
+	// Edge is how the replaced CSV was selected: through the replaces, skips or skipRange of the CSV.
+	Edge      string `json:"edge"`
+	SkipRange string `json:"skipRange,omitempty"`
+	// Superseded lists the CSVs of the namespace in the replacement chain or skips of the CSV.
+	Superseded []SupersededCSV `json:"superseded,omitempty"`
+}
+
+// SupersededCSV is the evaluation of a CSV superseded by a newer one.
+type SupersededCSV struct {
+	Name        string `json:"name"`
+	Version     string `json:"version,omitempty"`
+	InChain     bool   `json:"inChain"`
+	InSkips     bool   `json:"inSkips"`
+	InSkipRange bool   `json:"inSkipRange"`
+	Reason      string `json:"reason"`
+}
+
+// UpgradeEdgeFor returns the upgrade edge recorded on the given CSV, or nil if none has been recorded.
+func UpgradeEdgeFor(csv *v1alpha1.ClusterServiceVersion) (*UpgradeEdge, error) {
+	value, ok := csv.GetAnnotations()[UpgradeEdgeAnnotationKey]
+	if !ok {
+		return nil, nil
+	}
+	edge := &UpgradeEdge{}
+	if err := json.Unmarshal([]byte(value), edge); err != nil {
+		return nil, fmt.Errorf("invalid %s annotation: %v", UpgradeEdgeAnnotationKey, err)
+	}
+	return edge, nil
+}
+
+// evaluateUpgradeEdge evaluates the replaces, skips and skipRange of the given CSV against the other CSVs of its
+// namespace. It returns nil if the CSV doesn't replace a CSV that's still installed.
+func evaluateUpgradeEdge(csv *v1alpha1.ClusterServiceVersion, csvsInNamespace map[string]*v1alpha1.ClusterServiceVersion) *UpgradeEdge {
+	replaced, ok := csvsInNamespace[csv.Spec.Replaces]
+	if csv.Spec.Replaces == "" || !ok {
+		return nil
+	}
+
+	skips := map[string]bool{}
+	for _, name := range csv.Spec.Skips {
+		skips[name] = true
+	}
+	skipRangeValue := csv.GetAnnotations()[skipRangeAnnotationKey]
+	skipRange, err := semver.ParseRange(skipRangeValue)
+	if err != nil {
+		skipRange = nil
+	}
+
+	edge := &UpgradeEdge{Replaces: replaced.GetName(), SkipRange: skipRangeValue}
+	evaluate := func(c *v1alpha1.ClusterServiceVersion, replacedBy string) SupersededCSV {
+		s := SupersededCSV{Name: c.GetName(), InChain: replacedBy != "", InSkips: skips[c.GetName()]}
+		var reasons []string
+		if replacedBy == csv.GetName() {
+			reasons = append(reasons, "replaced directly")
+		} else if replacedBy != "" {
+			reasons = append(reasons, fmt.Sprintf("replaced by %s", replacedBy))
+		}
+		if s.InSkips {
+			reasons = append(reasons, "listed in skips")
+		}
+		if version := c.Spec.Version.Version; !version.Equals(semver.Version{}) {
+			s.Version = version.String()
+			if skipRange != nil {
+				s.InSkipRange = skipRange(version)
+				if s.InSkipRange {
+					reasons = append(reasons, fmt.Sprintf("version in skipRange %q", skipRangeValue))
+				} else {
+					reasons = append(reasons, fmt.Sprintf("version not in skipRange %q", skipRangeValue))
+				}
+			}
+		}
+		s.Reason = strings.Join(reasons, ", ")
+		return s
+	}
+
+	// Walk the replacement chain, which OLM garbage collects once the CSV succeeds
+	seen := map[string]bool{csv.GetName(): true}
+	for next, current := csv, replaced; current != nil && !seen[current.GetName()]; next, current = current, csvsInNamespace[current.Spec.Replaces] {
+		seen[current.GetName()] = true
+		edge.Superseded = append(edge.Superseded, evaluate(current, next.GetName()))
+	}
+	for _, name := range csv.Spec.Skips {
+		if c, ok := csvsInNamespace[name]; ok && !seen[name] {
+			seen[name] = true
+			edge.Superseded = append(edge.Superseded, evaluate(c, ""))
+		}
+	}
+
+	switch direct := edge.Superseded[0]; {
+	case direct.InSkips:
+		edge.Edge = UpgradeEdgeSkips
+	case direct.InSkipRange:
+		edge.Edge = UpgradeEdgeSkipRange
+	default:
+		edge.Edge = UpgradeEdgeReplaces
+	}
+	return edge
+}
+
+// syncUpgradeEdge records the upgrade edge of the given CSV if it replaces an installed CSV and the edge hasn't been
+// recorded yet. It returns true if the CSV was updated.
+func (a *Operator) syncUpgradeEdge(ctx context.Context, logger *logrus.Entry, csv *v1alpha1.ClusterServiceVersion) (bool, error) {
+	if _, ok := csv.GetAnnotations()[UpgradeEdgeAnnotationKey]; ok {
+		return false, nil
+	}
+	edge := evaluateUpgradeEdge(csv, a.csvSet(csv.GetNamespace(), v1alpha1.CSVPhaseAny))
+	if edge == nil {
+		return false, nil
+	}
+
+	value, err := json.Marshal(edge)
+	if err != nil {
+		return false, err
+	}
+	out := csv.DeepCopy()
+	if out.Annotations == nil {
+		out.Annotations = map[string]string{}
+	}
+	out.Annotations[UpgradeEdgeAnnotationKey] = string(value)
+	if _, err := a.client.OperatorsV1alpha1().ClusterServiceVersions(out.GetNamespace()).Update(ctx, out, metav1.UpdateOptions{}); err != nil {
+		return false, err
+	}
+
+	superseded := make([]string, 0, len(edge.Superseded))
+	for _, s := range edge.Superseded {
+		superseded = append(superseded, fmt.Sprintf("%s (%s)", s.Name, s.Reason))
+	}
+	logger.WithField("replaces", edge.Replaces).WithField("edge", edge.Edge).Info("recorded upgrade edge")
+	a.recorder.Eventf(out, corev1.EventTypeNormal, upgradeEdgeReason, "replaces %s through its %s: supersedes %s", edge.Replaces, strings.ToLower(edge.Edge), strings.Join(superseded, "; "))
+	return true, nil
+}
@@ -0,0 +1,111 @@
+package olm
+
+import (
+	"testing"
+
+	"github.com/blang/semver/v4"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/operator-framework/api/pkg/lib/version"
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+)
+
+func TestEvaluateUpgradeEdge(t *testing.T) {
+	csv := func(name, ver, replaces, skipRange string, skips ...string) *v1alpha1.ClusterServiceVersion {
+		c := &v1alpha1.ClusterServiceVersion{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ns"},
+			Spec: v1alpha1.ClusterServiceVersionSpec{
+				Version:  version.OperatorVersion{Version: semver.MustParse(ver)},
+				Replaces: replaces,
+				Skips:    skips,
+			},
+		}
+		if skipRange != "" {
+			c.SetAnnotations(map[string]string{skipRangeAnnotationKey: skipRange})
+		}
+		return c
+	}
+	set := func(csvs ...*v1alpha1.ClusterServiceVersion) map[string]*v1alpha1.ClusterServiceVersion {
+		m := map[string]*v1alpha1.ClusterServiceVersion{}
+		for _, c := range csvs {
+			m[c.GetName()] = c
+		}
+		return m
+	}
+
+	tests := []struct {
+		name     string
+		csv      *v1alpha1.ClusterServiceVersion
+		csvs     map[string]*v1alpha1.ClusterServiceVersion
+		expected *UpgradeEdge
+	}{
+		{
+			name: "NoReplaces",
+			csv:  csv("a.v2", "2.0.0", "", ""),
+			csvs: set(csv("a.v1", "1.0.0", "", "")),
+		},
+		{
+			name: "ReplacedNotInstalled",
+			csv:  csv("a.v2", "2.0.0", "a.v1", ""),
+			csvs: set(),
+		},
+		{
+			name: "Replaces",
+			csv:  csv("a.v2", "2.0.0", "a.v1", ""),
+			csvs: set(csv("a.v1", "1.0.0", "", "")),
+			expected: &UpgradeEdge{
+				Replaces: "a.v1",
+				Edge:     UpgradeEdgeReplaces,
+				Superseded: []SupersededCSV{
+					{Name: "a.v1", Version: "1.0.0", InChain: true, Reason: "replaced directly"},
+				},
+			},
+		},
+		{
+			name: "SkipRange",
+			csv:  csv("a.v3", "3.0.0", "a.v2", ">=1.0.0 <3.0.0"),
+			csvs: set(csv("a.v2", "2.0.0", "a.v1", ""), csv("a.v1", "0.9.0", "", "")),
+			expected: &UpgradeEdge{
+				Replaces:  "a.v2",
+				Edge:      UpgradeEdgeSkipRange,
+				SkipRange: ">=1.0.0 <3.0.0",
+				Superseded: []SupersededCSV{
+					{Name: "a.v2", Version: "2.0.0", InChain: true, InSkipRange: true, Reason: `replaced directly, version in skipRange ">=1.0.0 <3.0.0"`},
+					{Name: "a.v1", Version: "0.9.0", InChain: true, Reason: `replaced by a.v2, version not in skipRange ">=1.0.0 <3.0.0"`},
+				},
+			},
+		},
+		{
+			name: "Skips",
+			csv:  csv("a.v3", "3.0.0", "a.v2", "", "a.v2", "b.v1"),
+			csvs: set(csv("a.v2", "2.0.0", "", ""), csv("b.v1", "1.0.0", "", "")),
+			expected: &UpgradeEdge{
+				Replaces: "a.v2",
+				Edge:     UpgradeEdgeSkips,
+				Superseded: []SupersededCSV{
+					{Name: "a.v2", Version: "2.0.0", InChain: true, InSkips: true, Reason: "replaced directly, listed in skips"},
+					{Name: "b.v1", Version: "1.0.0", InSkips: true, Reason: "listed in skips"},
+				},
+			},
+		},
+		{
+			name: "ReplacementCycle",
+			csv:  csv("a.v2", "2.0.0", "a.v1", ""),
+			csvs: set(csv("a.v1", "1.0.0", "a.v2", ""), csv("a.v2", "2.0.0", "a.v1", "")),
+			expected: &UpgradeEdge{
+				Replaces: "a.v1",
+				Edge:     UpgradeEdgeReplaces,
+				Superseded: []SupersededCSV{
+					{Name: "a.v1", Version: "1.0.0", InChain: true, Reason: "replaced directly"},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, evaluateUpgradeEdge(tt.csv, tt.csvs))
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package olm
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/install"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/ownerutil"
+)
+
+// WebhookDriftRepairedReason is the reason of the event recorded on a CSV when the caBundle or service reference of
+// one of its webhook configurations was changed by something other than OLM, and has been restored.
+const WebhookDriftRepairedReason = "DriftRepaired"
+
+// syncWebhookConfiguration restores the caBundle and service reference of the webhooks of the given
+// (Validating|Mutating)WebhookConfiguration to the values OLM set, before handing it to syncObject. Without this, a
+// caBundle overwritten by another tool silently breaks admission until the next certificate rotation.
+func (a *Operator) syncWebhookConfiguration(ctx context.Context, obj interface{}) error {
+	metaObj, ok := obj.(metav1.Object)
+	if !ok {
+		return fmt.Errorf("casting webhook configuration failed")
+	}
+	if err := a.repairWebhookDrift(ctx, metaObj); err != nil {
+		return err
+	}
+	return a.syncObject(ctx, obj)
+}
+
+func (a *Operator) repairWebhookDrift(ctx context.Context, obj metav1.Object) error {
+	name, namespace, ok := ownerutil.GetOwnerByKindLabel(obj, v1alpha1.ClusterServiceVersionKind)
+	if !ok {
+		return nil
+	}
+	csv, err := a.lister.OperatorsV1alpha1().ClusterServiceVersionLister().ClusterServiceVersions(namespace).Get(name)
+	if k8serrors.IsNotFound(err) {
+		// Garbage collected by syncObject
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	// While a CSV is installing, e.g. rotating its certificates, its webhooks are in flux
+	if csv.Status.Phase != v1alpha1.CSVPhaseSucceeded {
+		return nil
+	}
+
+	var desc *v1alpha1.WebhookDescription
+	for i, d := range csv.Spec.WebhookDefinitions {
+		if d.GenerateName == obj.GetLabels()[install.WebhookDescKey] {
+			desc = &csv.Spec.WebhookDefinitions[i]
+		}
+	}
+	if desc == nil {
+		// Removed from the CSV, cleaned up by the CSV sync
+		return nil
+	}
+
+	caPEM, selfManaged, err := install.SelfManagedCABundle(csv)
+	if err != nil {
+		// The CSV fails for its invalid CA bundle
+		return nil
+	}
+	if !selfManaged {
+		secretName := install.SecretName(install.ServiceName(desc.DeploymentName))
+		secret, err := a.lister.CoreV1().SecretLister().Secrets(csv.GetNamespace()).Get(secretName)
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		caPEM = secret.Data[install.OLMCAPEMKey]
+	}
+	if len(caPEM) == 0 {
+		return nil
+	}
+
+	logger := a.logger.WithFields(logrus.Fields{
+		"csv":       csv.GetName(),
+		"namespace": csv.GetNamespace(),
+		"webhook":   obj.GetName(),
+	})
+
+	var drifted bool
+	switch webhook := obj.(type) {
+	case *admissionregistrationv1.ValidatingWebhookConfiguration:
+		expected := desc.GetValidatingWebhook(csv.GetNamespace(), nil, caPEM).ClientConfig
+		out := webhook.DeepCopy()
+		for i := range out.Webhooks {
+			drifted = repairClientConfig(&out.Webhooks[i].ClientConfig, expected) || drifted
+		}
+		if drifted {
+			_, err = a.opClient.KubernetesInterface().AdmissionregistrationV1().ValidatingWebhookConfigurations().Update(ctx, out, metav1.UpdateOptions{})
+		}
+	case *admissionregistrationv1.MutatingWebhookConfiguration:
+		expected := desc.GetMutatingWebhook(csv.GetNamespace(), nil, caPEM).ClientConfig
+		out := webhook.DeepCopy()
+		for i := range out.Webhooks {
+			drifted = repairClientConfig(&out.Webhooks[i].ClientConfig, expected) || drifted
+		}
+		if drifted {
+			_, err = a.opClient.KubernetesInterface().AdmissionregistrationV1().MutatingWebhookConfigurations().Update(ctx, out, metav1.UpdateOptions{})
+		}
+	}
+	if err != nil {
+		return err
+	}
+	if drifted {
+		logger.Info("repaired drifted webhook client config")
+		a.recorder.Eventf(csv, corev1.EventTypeWarning, WebhookDriftRepairedReason, "The caBundle or service of webhook configuration %s was changed outside of OLM and has been restored", obj.GetName())
+	}
+	return nil
+}
+
+// repairClientConfig sets the caBundle and service of the given client config to the expected ones, returning true
+// if either had drifted.
+func repairClientConfig(config *admissionregistrationv1.WebhookClientConfig, expected admissionregistrationv1.WebhookClientConfig) bool {
+	drifted := false
+	if !bytes.Equal(config.CABundle, expected.CABundle) {
+		config.CABundle = expected.CABundle
+		drifted = true
+	}
+	// The apiserver defaults the port, so compare what OLM sets field by field
+	if config.URL != nil || config.Service == nil ||
+		config.Service.Name != expected.Service.Name ||
+		config.Service.Namespace != expected.Service.Namespace ||
+		!equality.Semantic.DeepEqual(config.Service.Path, expected.Service.Path) ||
+		(expected.Service.Port != nil && *expected.Service.Port != 0 && !equality.Semantic.DeepEqual(config.Service.Port, expected.Service.Port)) {
+		config.URL = nil
+		config.Service = expected.Service
+		drifted = true
+	}
+	return drifted
+}
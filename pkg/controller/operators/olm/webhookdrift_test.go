@@ -0,0 +1,129 @@
+package olm
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/certs"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/install"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/ownerutil"
+)
+
+func TestRepairWebhookDrift(t *testing.T) {
+	path := "/validate"
+	desc := v1alpha1.WebhookDescription{
+		GenerateName:   "validate.example.com",
+		Type:           v1alpha1.ValidatingAdmissionWebhook,
+		DeploymentName: "operator",
+		ContainerPort:  443,
+		WebhookPath:    &path,
+	}
+	csv := &v1alpha1.ClusterServiceVersion{
+		ObjectMeta: metav1.ObjectMeta{Name: "csv", Namespace: "ns"},
+		Spec:       v1alpha1.ClusterServiceVersionSpec{WebhookDefinitions: []v1alpha1.WebhookDescription{desc}},
+		Status:     v1alpha1.ClusterServiceVersionStatus{Phase: v1alpha1.CSVPhaseSucceeded},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      install.SecretName(install.ServiceName("operator")),
+			Namespace: "ns",
+			Labels:    map[string]string{install.OLMManagedLabelKey: install.OLMManagedLabelValue},
+		},
+		Data: map[string][]byte{install.OLMCAPEMKey: []byte("ca")},
+	}
+	webhook := func(caBundle string, service string) *admissionregistrationv1.ValidatingWebhookConfiguration {
+		wh := desc.GetValidatingWebhook("ns", nil, []byte(caBundle))
+		wh.ClientConfig.Service.Name = service
+		config := &admissionregistrationv1.ValidatingWebhookConfiguration{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "validate.example.com-abcde",
+				Labels: map[string]string{install.WebhookDescKey: desc.GenerateName},
+			},
+			Webhooks: []admissionregistrationv1.ValidatingWebhook{wh},
+		}
+		ownerutil.AddOwnerLabelsForKind(config, csv, v1alpha1.ClusterServiceVersionKind)
+		return config
+	}
+
+	ca, err := certs.GenerateCA(time.Now().Add(time.Hour), install.Organization)
+	require.NoError(t, err)
+	selfManagedCA, _, err := ca.ToPEM()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		phase       v1alpha1.ClusterServiceVersionPhase
+		selfManaged bool
+		existing    *admissionregistrationv1.ValidatingWebhookConfiguration
+		repaired    bool
+	}{
+		{
+			name:     "InSync",
+			phase:    v1alpha1.CSVPhaseSucceeded,
+			existing: webhook("ca", "operator-service"),
+		},
+		{
+			name:     "CABundleDrifted",
+			phase:    v1alpha1.CSVPhaseSucceeded,
+			existing: webhook("other", "operator-service"),
+			repaired: true,
+		},
+		{
+			name:     "ServiceDrifted",
+			phase:    v1alpha1.CSVPhaseSucceeded,
+			existing: webhook("ca", "other-service"),
+			repaired: true,
+		},
+		{
+			name:        "SelfManaged",
+			phase:       v1alpha1.CSVPhaseSucceeded,
+			selfManaged: true,
+			existing:    webhook("ca", "operator-service"),
+			repaired:    true,
+		},
+		{
+			name:     "Installing",
+			phase:    v1alpha1.CSVPhaseInstalling,
+			existing: webhook("other", "operator-service"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.TODO())
+			defer cancel()
+
+			csv := csv.DeepCopy()
+			csv.Status.Phase = tt.phase
+			expectedCA := []byte("ca")
+			if tt.selfManaged {
+				csv.SetAnnotations(map[string]string{install.SelfManagedCABundleAnnotationKey: string(selfManagedCA)})
+				expectedCA = selfManagedCA
+			}
+			op, err := NewFakeOperator(ctx, withNamespaces("ns"), withClientObjs(csv), withK8sObjs(secret, tt.existing))
+			require.NoError(t, err)
+			recorder := record.NewFakeRecorder(1)
+			op.recorder = recorder
+
+			require.NoError(t, op.repairWebhookDrift(ctx, tt.existing))
+
+			out, err := op.opClient.KubernetesInterface().AdmissionregistrationV1().ValidatingWebhookConfigurations().Get(ctx, tt.existing.GetName(), metav1.GetOptions{})
+			require.NoError(t, err)
+			if !tt.repaired {
+				require.Equal(t, tt.existing.Webhooks, out.Webhooks)
+				require.Empty(t, recorder.Events)
+				return
+			}
+			require.Equal(t, expectedCA, out.Webhooks[0].ClientConfig.CABundle)
+			require.Equal(t, "operator-service", out.Webhooks[0].ClientConfig.Service.Name)
+			require.Contains(t, <-recorder.Events, WebhookDriftRepairedReason)
+		})
+	}
+}
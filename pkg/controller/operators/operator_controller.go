@@ -156,12 +156,17 @@ func (r *OperatorReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 		return reconcile.Result{Requeue: true}, nil
 	}
 
-	if err = r.updateComponents(ctx, operator); err != nil {
+	components, err := r.updateComponents(ctx, operator)
+	if err != nil {
 		log.Error(err, "Could not update components")
 		return reconcile.Result{Requeue: true}, nil
 	}
 
 	if create {
+		if _, err := operator.SetUIExtensions(components...); err != nil {
+			log.Error(err, "Could not set UI extensions")
+			return reconcile.Result{Requeue: true}, nil
+		}
 		if err := r.Create(context.Background(), operator.Operator); err != nil && !apierrors.IsAlreadyExists(err) {
 			r.log.Error(err, "Could not create Operator", "operator", name)
 			return ctrl.Result{Requeue: true}, nil
@@ -171,6 +176,19 @@ func (r *OperatorReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 			log.Error(err, "Could not update Operator status")
 			return ctrl.Result{Requeue: true}, nil
 		}
+
+		// The status update overwrites the metadata with the stored one, so UI extensions are set afterwards
+		changed, err := operator.SetUIExtensions(components...)
+		if err != nil {
+			log.Error(err, "Could not set UI extensions")
+			return reconcile.Result{Requeue: true}, nil
+		}
+		if changed {
+			if err := r.Update(ctx, operator.Operator); err != nil {
+				log.Error(err, "Could not update Operator UI extensions")
+				return ctrl.Result{Requeue: true}, nil
+			}
+		}
 	}
 
 	// Only set the resource version if it already exists.
@@ -181,18 +199,18 @@ func (r *OperatorReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 	return ctrl.Result{}, nil
 }
 
-func (r *OperatorReconciler) updateComponents(ctx context.Context, operator *decorators.Operator) error {
+func (r *OperatorReconciler) updateComponents(ctx context.Context, operator *decorators.Operator) ([]runtime.Object, error) {
 	selector, err := operator.ComponentSelector()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	components, err := r.listComponents(ctx, selector)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return operator.SetComponents(components...)
+	return components, operator.SetComponents(components...)
 }
 
 func (r *OperatorReconciler) listComponents(ctx context.Context, selector labels.Selector) ([]runtime.Object, error) {
@@ -0,0 +1,438 @@
+package operatorinstall
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	operatorsv1 "github.com/operator-framework/api/pkg/operators/v1"
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/api/client/clientset/versioned"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/api/client/informers/externalversions"
+	operatorsv1listers "github.com/operator-framework/operator-lifecycle-manager/pkg/api/client/listers/operators/v1"
+	operatorsv1alpha1listers "github.com/operator-framework/operator-lifecycle-manager/pkg/api/client/listers/operators/v1alpha1"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/api/reasons"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/operatorclient"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/queueinformer"
+)
+
+// Operator reconciles OperatorInstalls into the OperatorGroups and Subscriptions installing their operators.
+type Operator struct {
+	queueinformer.Operator
+	logger          *logrus.Logger                                       // common logger
+	namespace       string                                               // global catalog namespace
+	client          versioned.Interface                                  // client used for OLM CRs
+	dynamicClient   dynamic.Interface                                    // client used for OperatorInstalls
+	installIndexer  cache.Indexer                                        // OperatorInstalls, indexed by namespace
+	ogLister        operatorsv1listers.OperatorGroupLister               // OperatorGroups of all namespaces
+	subLister       operatorsv1alpha1listers.SubscriptionLister          // Subscriptions of all namespaces
+	csvLister       operatorsv1alpha1listers.ClusterServiceVersionLister // CSVs of all namespaces
+	installQueueSet *queueinformer.ResourceQueueSet                      // work queues for OperatorInstalls
+	resyncPeriod    func() time.Duration                                 // period of time between resync
+}
+
+func NewOperator(ctx context.Context, config *rest.Config, logger *logrus.Logger, resync time.Duration, operatorNamespace string) (*Operator, error) {
+	resyncPeriod := queueinformer.ResyncWithJitter(resync, 0.2)
+
+	// Create a new client for OLM types (CRs)
+	crClient, err := versioned.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create a new client for OperatorInstalls, which have no typed client
+	dynamicClient, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create a new queueinformer-based operator.
+	opClient, err := operatorclient.NewClientFromRestConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	queueOperator, err := queueinformer.NewOperator(opClient.KubernetesInterface().Discovery(), queueinformer.WithOperatorLogger(logger))
+	if err != nil {
+		return nil, err
+	}
+
+	op := &Operator{
+		Operator:        queueOperator,
+		logger:          logger,
+		namespace:       operatorNamespace,
+		client:          crClient,
+		dynamicClient:   dynamicClient,
+		installQueueSet: queueinformer.NewEmptyResourceQueueSet(),
+		resyncPeriod:    resyncPeriod,
+	}
+
+	// Wire OperatorInstalls
+	installs := dynamicClient.Resource(OperatorInstallResource).Namespace(metav1.NamespaceAll)
+	installInformer := cache.NewSharedIndexInformer(&cache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+			return installs.List(ctx, options)
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			return installs.Watch(ctx, options)
+		},
+	}, &unstructured.Unstructured{}, resyncPeriod(), cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	op.installIndexer = installInformer.GetIndexer()
+	installQueue := workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "operatorInstall")
+	op.installQueueSet.Set(metav1.NamespaceAll, installQueue)
+	installQueueInformer, err := queueinformer.NewQueueInformer(
+		ctx,
+		queueinformer.WithLogger(op.logger),
+		queueinformer.WithQueue(installQueue),
+		queueinformer.WithInformer(installInformer),
+		queueinformer.WithSyncer(queueinformer.SyncHandler(op.syncOperatorInstall).ToSyncer()),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := op.RegisterQueueInformer(installQueueInformer); err != nil {
+		return nil, err
+	}
+
+	// Wire the OperatorGroups, Subscriptions and CSVs of OperatorInstalls, requeuing the OperatorInstalls of their
+	// namespace when they change
+	crInformerFactory := externalversions.NewSharedInformerFactoryWithOptions(op.client, op.resyncPeriod())
+	ogInformer := crInformerFactory.Operators().V1().OperatorGroups()
+	op.ogLister = ogInformer.Lister()
+	subInformer := crInformerFactory.Operators().V1alpha1().Subscriptions()
+	op.subLister = subInformer.Lister()
+	csvInformer := crInformerFactory.Operators().V1alpha1().ClusterServiceVersions()
+	op.csvLister = csvInformer.Lister()
+	for _, informer := range []cache.SharedIndexInformer{ogInformer.Informer(), subInformer.Informer(), csvInformer.Informer()} {
+		informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc:    op.requeueOperatorInstalls,
+			UpdateFunc: func(_, obj interface{}) { op.requeueOperatorInstalls(obj) },
+			DeleteFunc: op.requeueOperatorInstalls,
+		})
+		if err := op.RegisterInformer(informer); err != nil {
+			return nil, err
+		}
+	}
+
+	return op, nil
+}
+
+// requeueOperatorInstalls requeues the OperatorInstalls of the namespace of the given resource.
+func (o *Operator) requeueOperatorInstalls(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return
+	}
+	installs, err := o.installIndexer.ByIndex(cache.NamespaceIndex, accessor.GetNamespace())
+	if err != nil {
+		return
+	}
+	for _, install := range installs {
+		if install, ok := install.(metav1.Object); ok {
+			if err := o.installQueueSet.Requeue(install.GetNamespace(), install.GetName()); err != nil {
+				o.logger.WithError(err).Debug("couldn't requeue OperatorInstall")
+			}
+		}
+	}
+}
+
+// installError is a problem with an OperatorInstall that retrying won't fix until it or its namespace changes. It's
+// reported on its Installed condition.
+type installError struct {
+	reason  reasons.Reason
+	message string
+}
+
+func (e installError) Error() string {
+	return e.message
+}
+
+func (o *Operator) syncOperatorInstall(ctx context.Context, obj interface{}) error {
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		o.logger.Debugf("wrong type: %#v", obj)
+		return nil
+	}
+	in := &OperatorInstall{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.UnstructuredContent(), in); err != nil {
+		return err
+	}
+	if in.GetDeletionTimestamp() != nil {
+		// the OperatorGroup and Subscription it owns are garbage collected
+		return nil
+	}
+
+	logger := o.logger.WithFields(logrus.Fields{
+		"namespace": in.GetNamespace(),
+		"name":      in.GetName(),
+		"id":        queueinformer.NewLoopID(),
+	})
+	logger.Debug("syncing operator install")
+
+	status := OperatorInstallStatus{
+		ObservedGeneration: in.GetGeneration(),
+		Conditions:         append([]metav1.Condition(nil), in.Status.Conditions...),
+	}
+	err := o.reconcile(ctx, in, &status)
+	var ierr installError
+	if errors.As(err, &ierr) {
+		logger.WithError(err).Info("operator install can't proceed")
+		setInstalledCondition(&status, metav1.ConditionFalse, ierr.reason, ierr.message)
+	} else if err != nil {
+		return err
+	}
+	return o.updateStatus(ctx, in, status)
+}
+
+// reconcile ensures the OperatorGroup and Subscription of the given OperatorInstall, and sets its status from them.
+func (o *Operator) reconcile(ctx context.Context, in *OperatorInstall, status *OperatorInstallStatus) error {
+	if err := validate(in); err != nil {
+		return err
+	}
+
+	og, err := o.ensureOperatorGroup(ctx, in)
+	if err != nil {
+		return err
+	}
+	status.OperatorGroup = og.GetName()
+	setOperatorGroupMismatchCondition(status, in, og)
+
+	sub, err := o.ensureSubscription(ctx, in)
+	if err != nil {
+		return err
+	}
+	status.Subscription = sub.GetName()
+	status.CurrentCSV = sub.Status.CurrentCSV
+	status.InstalledCSV = sub.Status.InstalledCSV
+
+	var csv *v1alpha1.ClusterServiceVersion
+	if sub.Status.InstalledCSV != "" {
+		csv, err = o.csvLister.ClusterServiceVersions(in.GetNamespace()).Get(sub.Status.InstalledCSV)
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return err
+		}
+		if csv != nil {
+			status.Phase = csv.Status.Phase
+		}
+	}
+	conditionStatus, reason, message := installProgress(sub, csv)
+	setInstalledCondition(status, conditionStatus, reason, message)
+	return nil
+}
+
+func validate(in *OperatorInstall) error {
+	switch {
+	case in.Spec.Package == "":
+		return installError{reasons.OperatorInstallInvalidSpec, "spec.package must be set"}
+	case in.Spec.Source == "":
+		return installError{reasons.OperatorInstallInvalidSpec, "spec.source must be set"}
+	case in.Spec.AllNamespaces && len(in.Spec.TargetNamespaces) > 0:
+		return installError{reasons.OperatorInstallInvalidSpec, "spec.targetNamespaces must not be set with spec.allNamespaces"}
+	}
+	return nil
+}
+
+// ownerReference returns the controller reference of resources owned by the given OperatorInstall.
+func ownerReference(in *OperatorInstall) metav1.OwnerReference {
+	return *metav1.NewControllerRef(in, SchemeGroupVersion.WithKind(OperatorInstallKind))
+}
+
+// targetNamespaces returns the target namespaces of the OperatorGroup of the given OperatorInstall.
+func targetNamespaces(in *OperatorInstall) []string {
+	switch {
+	case in.Spec.AllNamespaces:
+		return nil
+	case len(in.Spec.TargetNamespaces) > 0:
+		return in.Spec.TargetNamespaces
+	}
+	return []string{in.GetNamespace()}
+}
+
+// ensureOperatorGroup returns the OperatorGroup of the namespace of the given OperatorInstall, creating one owned by
+// it if there is none. OperatorGroups it doesn't own are used as is.
+func (o *Operator) ensureOperatorGroup(ctx context.Context, in *OperatorInstall) (*operatorsv1.OperatorGroup, error) {
+	ogs, err := o.ogLister.OperatorGroups(in.GetNamespace()).List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	switch len(ogs) {
+	case 0:
+		og := &operatorsv1.OperatorGroup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            in.GetName(),
+				Namespace:       in.GetNamespace(),
+				OwnerReferences: []metav1.OwnerReference{ownerReference(in)},
+			},
+			Spec: operatorsv1.OperatorGroupSpec{TargetNamespaces: targetNamespaces(in)},
+		}
+		return o.client.OperatorsV1().OperatorGroups(og.GetNamespace()).Create(ctx, og, metav1.CreateOptions{})
+	case 1:
+	default:
+		return nil, installError{reasons.OperatorInstallTooManyOperatorGroups, fmt.Sprintf("namespace %s has %d OperatorGroups, operators can only be installed in namespaces with one", in.GetNamespace(), len(ogs))}
+	}
+
+	og := ogs[0]
+	if !metav1.IsControlledBy(og, in) || equality.Semantic.DeepEqual(og.Spec.TargetNamespaces, targetNamespaces(in)) {
+		return og, nil
+	}
+	og = og.DeepCopy()
+	og.Spec.TargetNamespaces = targetNamespaces(in)
+	return o.client.OperatorsV1().OperatorGroups(og.GetNamespace()).Update(ctx, og, metav1.UpdateOptions{})
+}
+
+// setOperatorGroupMismatchCondition sets the OperatorGroupMismatch condition on the given status if the given
+// OperatorGroup, which is used as is when the OperatorInstall doesn't own it, targets other namespaces than its spec, and
+// removes it otherwise. The namespaces of OperatorGroups selecting them are only known once their status is set.
+func setOperatorGroupMismatchCondition(status *OperatorInstallStatus, in *OperatorInstall, og *operatorsv1.OperatorGroup) {
+	current := sets.NewString(og.Spec.TargetNamespaces...)
+	if og.Spec.Selector != nil {
+		if len(og.Status.Namespaces) == 0 {
+			return
+		}
+		current = sets.NewString(og.Status.Namespaces...)
+	}
+	// OperatorGroups targeting all namespaces target no namespace or the empty one
+	current.Delete(metav1.NamespaceAll)
+	wanted := sets.NewString(targetNamespaces(in)...)
+	if current.Equal(wanted) {
+		meta.RemoveStatusCondition(&status.Conditions, OperatorGroupMismatchCondition)
+		return
+	}
+	meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:               OperatorGroupMismatchCondition,
+		Status:             metav1.ConditionTrue,
+		Reason:             string(reasons.OperatorInstallOperatorGroupMismatch),
+		Message:            fmt.Sprintf("OperatorGroup %s isn't owned by the OperatorInstall and targets %s rather than %s", og.GetName(), describeNamespaces(current), describeNamespaces(wanted)),
+		ObservedGeneration: status.ObservedGeneration,
+	})
+}
+
+// describeNamespaces describes the given target namespaces of an OperatorGroup, all namespaces if there are none.
+func describeNamespaces(namespaces sets.String) string {
+	if namespaces.Len() == 0 {
+		return "all namespaces"
+	}
+	return "namespaces " + strings.Join(namespaces.List(), ", ")
+}
+
+// subscriptionSpec returns the spec of the Subscription of the given OperatorInstall.
+func (o *Operator) subscriptionSpec(in *OperatorInstall) *v1alpha1.SubscriptionSpec {
+	spec := &v1alpha1.SubscriptionSpec{
+		CatalogSource:          in.Spec.Source,
+		CatalogSourceNamespace: in.Spec.SourceNamespace,
+		Package:                in.Spec.Package,
+		Channel:                in.Spec.Channel,
+		StartingCSV:            in.Spec.StartingCSV,
+		InstallPlanApproval:    in.Spec.InstallPlanApproval,
+		Config:                 in.Spec.Config,
+	}
+	if spec.CatalogSourceNamespace == "" {
+		spec.CatalogSourceNamespace = o.namespace
+	}
+	if spec.InstallPlanApproval == "" {
+		spec.InstallPlanApproval = v1alpha1.ApprovalAutomatic
+	}
+	return spec
+}
+
+// ensureSubscription creates or updates the Subscription of the given OperatorInstall, named after it.
+func (o *Operator) ensureSubscription(ctx context.Context, in *OperatorInstall) (*v1alpha1.Subscription, error) {
+	spec := o.subscriptionSpec(in)
+	sub, err := o.subLister.Subscriptions(in.GetNamespace()).Get(in.GetName())
+	if k8serrors.IsNotFound(err) {
+		sub = &v1alpha1.Subscription{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            in.GetName(),
+				Namespace:       in.GetNamespace(),
+				OwnerReferences: []metav1.OwnerReference{ownerReference(in)},
+			},
+			Spec: spec,
+		}
+		return o.client.OperatorsV1alpha1().Subscriptions(sub.GetNamespace()).Create(ctx, sub, metav1.CreateOptions{})
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !metav1.IsControlledBy(sub, in) {
+		return nil, installError{reasons.OperatorInstallSubscriptionConflict, fmt.Sprintf("subscription %s already exists and isn't owned by the OperatorInstall", sub.GetName())}
+	}
+	if equality.Semantic.DeepEqual(sub.Spec, spec) {
+		return sub, nil
+	}
+	sub = sub.DeepCopy()
+	sub.Spec = spec
+	return o.client.OperatorsV1alpha1().Subscriptions(sub.GetNamespace()).Update(ctx, sub, metav1.UpdateOptions{})
+}
+
+// installProgress returns the status, reason and message of the Installed condition of an OperatorInstall, given its
+// Subscription and the CSV it installed, if any.
+func installProgress(sub *v1alpha1.Subscription, csv *v1alpha1.ClusterServiceVersion) (metav1.ConditionStatus, reasons.Reason, string) {
+	if csv != nil && csv.Status.Phase == v1alpha1.CSVPhaseSucceeded {
+		return metav1.ConditionTrue, reasons.OperatorInstallInstallSucceeded, fmt.Sprintf("%s is installed", csv.GetName())
+	}
+	if cond := sub.Status.GetCondition(v1alpha1.SubscriptionResolutionFailed); cond.Status == corev1.ConditionTrue {
+		return metav1.ConditionFalse, reasons.OperatorInstallResolutionFailed, cond.Message
+	}
+	if cond := sub.Status.GetCondition(v1alpha1.SubscriptionInstallPlanFailed); cond.Status == corev1.ConditionTrue {
+		return metav1.ConditionFalse, reasons.OperatorInstallInstallPlanFailed, cond.Message
+	}
+	if cond := sub.Status.GetCondition(v1alpha1.SubscriptionInstallPlanPending); cond.Status == corev1.ConditionTrue && cond.Reason == string(v1alpha1.InstallPlanPhaseRequiresApproval) {
+		message := "the InstallPlan of the install requires approval"
+		if sub.Status.InstallPlanRef != nil {
+			message = fmt.Sprintf("InstallPlan %s requires approval", sub.Status.InstallPlanRef.Name)
+		}
+		return metav1.ConditionFalse, reasons.OperatorInstallRequiresApproval, message
+	}
+	if csv != nil && csv.Status.Phase == v1alpha1.CSVPhaseFailed {
+		return metav1.ConditionFalse, reasons.OperatorInstallInstallFailed, csv.Status.Message
+	}
+	if csv != nil {
+		return metav1.ConditionFalse, reasons.OperatorInstallInstalling, fmt.Sprintf("waiting for %s to succeed, it's %s", csv.GetName(), csv.Status.Phase)
+	}
+	return metav1.ConditionFalse, reasons.OperatorInstallInstalling, fmt.Sprintf("waiting for subscription %s to install %s", sub.GetName(), sub.Spec.Package)
+}
+
+func setInstalledCondition(status *OperatorInstallStatus, conditionStatus metav1.ConditionStatus, reason reasons.Reason, message string) {
+	meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:               InstalledCondition,
+		Status:             conditionStatus,
+		Reason:             string(reason),
+		Message:            message,
+		ObservedGeneration: status.ObservedGeneration,
+	})
+}
+
+// updateStatus sets the given status on the given OperatorInstall, if it changed.
+func (o *Operator) updateStatus(ctx context.Context, in *OperatorInstall, status OperatorInstallStatus) error {
+	if equality.Semantic.DeepEqual(in.Status, status) {
+		return nil
+	}
+	in.Status = status
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(in)
+	if err != nil {
+		return err
+	}
+	_, err = o.dynamicClient.Resource(OperatorInstallResource).Namespace(in.GetNamespace()).UpdateStatus(ctx, &unstructured.Unstructured{Object: content}, metav1.UpdateOptions{})
+	return err
+}
@@ -0,0 +1,252 @@
+package operatorinstall
+
+import (
+	"context"
+	"testing"
+
+	operatorsv1 "github.com/operator-framework/api/pkg/operators/v1"
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/api/client/clientset/versioned/fake"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/api/reasons"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/operators/internal/installfake"
+)
+
+func operatorInstall(spec OperatorInstallSpec) *OperatorInstall {
+	return &OperatorInstall{
+		TypeMeta:   metav1.TypeMeta{APIVersion: SchemeGroupVersion.String(), Kind: OperatorInstallKind},
+		ObjectMeta: metav1.ObjectMeta{Name: "etcd", Namespace: "team-a", UID: "install-uid", Generation: 2},
+		Spec:       spec,
+	}
+}
+
+// fakeOperator returns an Operator serving the given OperatorInstall and OLM resources.
+func fakeOperator(t *testing.T, in *OperatorInstall, objs ...runtime.Object) *Operator {
+	listers := installfake.NewListers(t, objs...)
+	listKinds := map[schema.GroupVersionResource]string{OperatorInstallResource: "OperatorInstallList"}
+	return &Operator{
+		logger:        logrus.New(),
+		namespace:     "olm",
+		client:        fake.NewSimpleClientset(objs...),
+		dynamicClient: installfake.NewDynamicClient(listKinds, installfake.ToUnstructured(t, in)),
+		ogLister:      listers.OperatorGroups,
+		subLister:     listers.Subscriptions,
+		csvLister:     listers.CSVs,
+	}
+}
+
+// syncInstall syncs the given OperatorInstall and returns it as updated.
+func syncInstall(t *testing.T, o *Operator, in *OperatorInstall) *OperatorInstall {
+	require.NoError(t, o.syncOperatorInstall(context.TODO(), installfake.ToUnstructured(t, in)))
+	out := &OperatorInstall{}
+	installfake.Get(t, o.dynamicClient, OperatorInstallResource, in.GetNamespace(), in.GetName(), out)
+	return out
+}
+
+func requireInstalledCondition(t *testing.T, in *OperatorInstall, status metav1.ConditionStatus, reason reasons.Reason) {
+	cond := meta.FindStatusCondition(in.Status.Conditions, InstalledCondition)
+	require.NotNil(t, cond)
+	require.Equal(t, status, cond.Status, cond.Message)
+	require.Equal(t, string(reason), cond.Reason, cond.Message)
+}
+
+func TestSyncOperatorInstall(t *testing.T) {
+	in := operatorInstall(OperatorInstallSpec{Package: "etcd", Source: "operatorhubio", Channel: "alpha"})
+	o := fakeOperator(t, in)
+
+	out := syncInstall(t, o, in)
+	og, err := o.client.OperatorsV1().OperatorGroups("team-a").Get(context.TODO(), "etcd", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, []string{"team-a"}, og.Spec.TargetNamespaces)
+	require.True(t, metav1.IsControlledBy(og, in))
+	sub, err := o.client.OperatorsV1alpha1().Subscriptions("team-a").Get(context.TODO(), "etcd", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, &v1alpha1.SubscriptionSpec{
+		CatalogSource:          "operatorhubio",
+		CatalogSourceNamespace: "olm",
+		Package:                "etcd",
+		Channel:                "alpha",
+		InstallPlanApproval:    v1alpha1.ApprovalAutomatic,
+	}, sub.Spec)
+	require.True(t, metav1.IsControlledBy(sub, in))
+	require.Equal(t, "etcd", out.Status.OperatorGroup)
+	require.Equal(t, "etcd", out.Status.Subscription)
+	require.Equal(t, int64(2), out.Status.ObservedGeneration)
+	requireInstalledCondition(t, out, metav1.ConditionFalse, reasons.OperatorInstallInstalling)
+
+	// Changing the spec updates the resources it owns, and the install succeeds with its CSV
+	out.Spec.AllNamespaces = true
+	out.Spec.InstallPlanApproval = v1alpha1.ApprovalManual
+	sub.Status.InstalledCSV = "etcdoperator.v0.9.4"
+	sub.Status.CurrentCSV = "etcdoperator.v0.9.4"
+	csv := &v1alpha1.ClusterServiceVersion{
+		ObjectMeta: metav1.ObjectMeta{Name: "etcdoperator.v0.9.4", Namespace: "team-a"},
+		Status:     v1alpha1.ClusterServiceVersionStatus{Phase: v1alpha1.CSVPhaseSucceeded},
+	}
+	o = fakeOperator(t, out, og, sub, csv)
+	out = syncInstall(t, o, out)
+	og, err = o.client.OperatorsV1().OperatorGroups("team-a").Get(context.TODO(), "etcd", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Empty(t, og.Spec.TargetNamespaces)
+	sub, err = o.client.OperatorsV1alpha1().Subscriptions("team-a").Get(context.TODO(), "etcd", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, v1alpha1.ApprovalManual, sub.Spec.InstallPlanApproval)
+	require.Equal(t, "etcdoperator.v0.9.4", out.Status.InstalledCSV)
+	require.Equal(t, v1alpha1.CSVPhaseSucceeded, out.Status.Phase)
+	requireInstalledCondition(t, out, metav1.ConditionTrue, reasons.OperatorInstallInstallSucceeded)
+}
+
+func TestSyncOperatorInstallConflicts(t *testing.T) {
+	spec := OperatorInstallSpec{Package: "etcd", Source: "operatorhubio"}
+	og := func(name string) *operatorsv1.OperatorGroup {
+		return &operatorsv1.OperatorGroup{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "team-a"}}
+	}
+	for _, tt := range []struct {
+		name       string
+		spec       OperatorInstallSpec
+		objs       []runtime.Object
+		wantReason reasons.Reason
+	}{
+		{
+			name:       "NoSource",
+			spec:       OperatorInstallSpec{Package: "etcd"},
+			wantReason: reasons.OperatorInstallInvalidSpec,
+		},
+		{
+			name:       "AllAndTargetNamespaces",
+			spec:       OperatorInstallSpec{Package: "etcd", Source: "operatorhubio", AllNamespaces: true, TargetNamespaces: []string{"team-b"}},
+			wantReason: reasons.OperatorInstallInvalidSpec,
+		},
+		{
+			name:       "TooManyOperatorGroups",
+			spec:       spec,
+			objs:       []runtime.Object{og("a"), og("b")},
+			wantReason: reasons.OperatorInstallTooManyOperatorGroups,
+		},
+		{
+			name: "SubscriptionNotOwned",
+			spec: spec,
+			objs: []runtime.Object{og("a"), &v1alpha1.Subscription{
+				ObjectMeta: metav1.ObjectMeta{Name: "etcd", Namespace: "team-a"},
+				Spec:       &v1alpha1.SubscriptionSpec{Package: "etcd"},
+			}},
+			wantReason: reasons.OperatorInstallSubscriptionConflict,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			in := operatorInstall(tt.spec)
+			out := syncInstall(t, fakeOperator(t, in, tt.objs...), in)
+			requireInstalledCondition(t, out, metav1.ConditionFalse, tt.wantReason)
+		})
+	}
+
+	// An existing OperatorGroup is used as is
+	in := operatorInstall(spec)
+	o := fakeOperator(t, in, og("a"))
+	out := syncInstall(t, o, in)
+	require.Equal(t, "a", out.Status.OperatorGroup)
+	ogs, err := o.client.OperatorsV1().OperatorGroups("team-a").List(context.TODO(), metav1.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, ogs.Items, 1)
+	require.Nil(t, ogs.Items[0].Spec.TargetNamespaces)
+
+	// but the install reports it targets all namespaces rather than the namespace of the install, until it's changed
+	cond := meta.FindStatusCondition(out.Status.Conditions, OperatorGroupMismatchCondition)
+	require.NotNil(t, cond)
+	require.Equal(t, metav1.ConditionTrue, cond.Status)
+	require.Equal(t, string(reasons.OperatorInstallOperatorGroupMismatch), cond.Reason)
+	require.Equal(t, "OperatorGroup a isn't owned by the OperatorInstall and targets all namespaces rather than namespaces team-a", cond.Message)
+	requireInstalledCondition(t, out, metav1.ConditionFalse, reasons.OperatorInstallInstalling)
+
+	matching := og("a")
+	matching.Spec.TargetNamespaces = []string{"team-a"}
+	out = syncInstall(t, fakeOperator(t, out, matching), out)
+	require.Nil(t, meta.FindStatusCondition(out.Status.Conditions, OperatorGroupMismatchCondition))
+}
+
+func TestInstallProgress(t *testing.T) {
+	condition := func(conditionType v1alpha1.SubscriptionConditionType, reason string) v1alpha1.SubscriptionCondition {
+		return v1alpha1.SubscriptionCondition{Type: conditionType, Status: corev1.ConditionTrue, Reason: reason, Message: "message"}
+	}
+	csv := func(phase v1alpha1.ClusterServiceVersionPhase) *v1alpha1.ClusterServiceVersion {
+		return &v1alpha1.ClusterServiceVersion{
+			ObjectMeta: metav1.ObjectMeta{Name: "etcdoperator.v0.9.4"},
+			Status:     v1alpha1.ClusterServiceVersionStatus{Phase: phase, Message: "message"},
+		}
+	}
+	for _, tt := range []struct {
+		name       string
+		conditions []v1alpha1.SubscriptionCondition
+		csv        *v1alpha1.ClusterServiceVersion
+		wantStatus metav1.ConditionStatus
+		wantReason reasons.Reason
+	}{
+		{
+			name:       "Resolving",
+			wantStatus: metav1.ConditionFalse,
+			wantReason: reasons.OperatorInstallInstalling,
+		},
+		{
+			name:       "ResolutionFailed",
+			conditions: []v1alpha1.SubscriptionCondition{condition(v1alpha1.SubscriptionResolutionFailed, "ConstraintsNotSatisfiable")},
+			wantStatus: metav1.ConditionFalse,
+			wantReason: reasons.OperatorInstallResolutionFailed,
+		},
+		{
+			name:       "RequiresApproval",
+			conditions: []v1alpha1.SubscriptionCondition{condition(v1alpha1.SubscriptionInstallPlanPending, "RequiresApproval")},
+			wantStatus: metav1.ConditionFalse,
+			wantReason: reasons.OperatorInstallRequiresApproval,
+		},
+		{
+			name:       "InstallPlanInstalling",
+			conditions: []v1alpha1.SubscriptionCondition{condition(v1alpha1.SubscriptionInstallPlanPending, "Installing")},
+			wantStatus: metav1.ConditionFalse,
+			wantReason: reasons.OperatorInstallInstalling,
+		},
+		{
+			name:       "InstallPlanFailed",
+			conditions: []v1alpha1.SubscriptionCondition{condition(v1alpha1.SubscriptionInstallPlanFailed, "InstallComponentFailed")},
+			wantStatus: metav1.ConditionFalse,
+			wantReason: reasons.OperatorInstallInstallPlanFailed,
+		},
+		{
+			name:       "CSVInstalling",
+			csv:        csv(v1alpha1.CSVPhaseInstalling),
+			wantStatus: metav1.ConditionFalse,
+			wantReason: reasons.OperatorInstallInstalling,
+		},
+		{
+			name:       "CSVFailed",
+			csv:        csv(v1alpha1.CSVPhaseFailed),
+			wantStatus: metav1.ConditionFalse,
+			wantReason: reasons.OperatorInstallInstallFailed,
+		},
+		{
+			name:       "CSVSucceeded",
+			conditions: []v1alpha1.SubscriptionCondition{condition(v1alpha1.SubscriptionResolutionFailed, "ConstraintsNotSatisfiable")},
+			csv:        csv(v1alpha1.CSVPhaseSucceeded),
+			wantStatus: metav1.ConditionTrue,
+			wantReason: reasons.OperatorInstallInstallSucceeded,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			sub := &v1alpha1.Subscription{
+				ObjectMeta: metav1.ObjectMeta{Name: "etcd"},
+				Spec:       &v1alpha1.SubscriptionSpec{Package: "etcd"},
+				Status:     v1alpha1.SubscriptionStatus{Conditions: tt.conditions},
+			}
+			status, reason, message := installProgress(sub, tt.csv)
+			require.Equal(t, tt.wantStatus, status)
+			require.Equal(t, tt.wantReason, reason)
+			require.NotEmpty(t, message)
+		})
+	}
+}
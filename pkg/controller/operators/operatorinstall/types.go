@@ -0,0 +1,93 @@
+package operatorinstall
+
+import (
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	OperatorInstallKind = "OperatorInstall"
+
+	// InstalledCondition is the condition of OperatorInstalls reporting whether their operator is installed.
+	InstalledCondition = "Installed"
+
+	// OperatorGroupMismatchCondition is the condition of OperatorInstalls set while the OperatorGroup of their namespace,
+	// which they don't own, targets other namespaces than their spec.
+	OperatorGroupMismatchCondition = "OperatorGroupMismatch"
+)
+
+var (
+	// SchemeGroupVersion is the group version of OperatorInstalls, which are served by the operatorinstalls CRD
+	// alongside the other operators.coreos.com/v1alpha1 types.
+	SchemeGroupVersion = v1alpha1.SchemeGroupVersion
+
+	// OperatorInstallResource is the resource of OperatorInstalls.
+	OperatorInstallResource = SchemeGroupVersion.WithResource("operatorinstalls")
+)
+
+// OperatorInstall installs an operator in its namespace from a single resource. The catalog operator creates the
+// OperatorGroup and Subscription the install needs, owned by the OperatorInstall, and reports the progress of the
+// install in its status.
+type OperatorInstall struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   OperatorInstallSpec   `json:"spec"`
+	Status OperatorInstallStatus `json:"status,omitempty"`
+}
+
+// OperatorInstallSpec is the operator an OperatorInstall installs.
+type OperatorInstallSpec struct {
+	// Package is the name of the package to install.
+	Package string `json:"package"`
+
+	// Channel is the channel of the package to install from. It defaults to the default channel of the package.
+	Channel string `json:"channel,omitempty"`
+
+	// Source is the name of the CatalogSource providing the package.
+	Source string `json:"source"`
+
+	// SourceNamespace is the namespace of the CatalogSource. It defaults to the global catalog namespace.
+	SourceNamespace string `json:"sourceNamespace,omitempty"`
+
+	// StartingCSV is the CSV to install instead of the head of the channel.
+	StartingCSV string `json:"startingCSV,omitempty"`
+
+	// InstallPlanApproval is the approval of the InstallPlans of the install. It defaults to Automatic.
+	InstallPlanApproval v1alpha1.Approval `json:"installPlanApproval,omitempty"`
+
+	// TargetNamespaces are the namespaces the operator watches. They default to the namespace of the OperatorInstall.
+	TargetNamespaces []string `json:"targetNamespaces,omitempty"`
+
+	// AllNamespaces makes the operator watch all namespaces. TargetNamespaces must not be set with it.
+	AllNamespaces bool `json:"allNamespaces,omitempty"`
+
+	// Config overrides the configuration of the operator's deployments, as on Subscriptions.
+	Config *v1alpha1.SubscriptionConfig `json:"config,omitempty"`
+}
+
+// OperatorInstallStatus is the progress of an OperatorInstall.
+type OperatorInstallStatus struct {
+	// ObservedGeneration is the generation of the OperatorInstall the status was computed for.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// OperatorGroup is the name of the OperatorGroup of the namespace the operator is installed with.
+	OperatorGroup string `json:"operatorGroup,omitempty"`
+
+	// Subscription is the name of the Subscription installing the operator.
+	Subscription string `json:"subscription,omitempty"`
+
+	// CurrentCSV is the CSV the Subscription is installing or upgrading to.
+	CurrentCSV string `json:"currentCSV,omitempty"`
+
+	// InstalledCSV is the CSV the Subscription installed.
+	InstalledCSV string `json:"installedCSV,omitempty"`
+
+	// Phase is the phase of the installed CSV.
+	Phase v1alpha1.ClusterServiceVersionPhase `json:"phase,omitempty"`
+
+	// Conditions are the conditions of the OperatorInstall. The Installed condition is true once the installed CSV
+	// succeeded, and otherwise explains what the install is waiting on or why it failed. The OperatorGroupMismatch
+	// condition is set while the OperatorGroup used targets other namespaces than the spec.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
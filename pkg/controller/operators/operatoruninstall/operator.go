@@ -0,0 +1,621 @@
+package operatoruninstall
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/sirupsen/logrus"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apiextensionsclient "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/api/client/clientset/versioned"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/api/client/informers/externalversions"
+	operatorsv1alpha1listers "github.com/operator-framework/operator-lifecycle-manager/pkg/api/client/listers/operators/v1alpha1"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/api/reasons"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/operators/operatorinstall"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/operatorclient"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/queueinformer"
+)
+
+// removalRequeueInterval is how often OperatorUninstalls waiting on CRs and CRDs to be deleted are requeued, since
+// their deletion isn't watched.
+const removalRequeueInterval = 10 * time.Second
+
+// Operator reconciles OperatorUninstalls, removing the Subscriptions, CSVs, CRs and CRDs of their operators.
+type Operator struct {
+	queueinformer.Operator
+	logger            *logrus.Logger                                       // common logger
+	client            versioned.Interface                                  // client used for OLM CRs
+	dynamicClient     dynamic.Interface                                    // client used for OperatorUninstalls, OperatorInstalls and CRs
+	crdClient         apiextensionsclient.Interface                        // client used for CRDs
+	uninstallIndexer  cache.Indexer                                        // OperatorUninstalls, indexed by namespace
+	subLister         operatorsv1alpha1listers.SubscriptionLister          // Subscriptions of all namespaces
+	csvLister         operatorsv1alpha1listers.ClusterServiceVersionLister // CSVs of all namespaces
+	uninstallQueueSet *queueinformer.ResourceQueueSet                      // work queues for OperatorUninstalls
+	resyncPeriod      func() time.Duration                                 // period of time between resync
+}
+
+func NewOperator(ctx context.Context, config *rest.Config, logger *logrus.Logger, resync time.Duration) (*Operator, error) {
+	resyncPeriod := queueinformer.ResyncWithJitter(resync, 0.2)
+
+	// Create a new client for OLM types (CRs)
+	crClient, err := versioned.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create a new client for OperatorUninstalls and the CRs of operators, which have no typed client
+	dynamicClient, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	crdClient, err := apiextensionsclient.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create a new queueinformer-based operator.
+	opClient, err := operatorclient.NewClientFromRestConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	queueOperator, err := queueinformer.NewOperator(opClient.KubernetesInterface().Discovery(), queueinformer.WithOperatorLogger(logger))
+	if err != nil {
+		return nil, err
+	}
+
+	op := &Operator{
+		Operator:          queueOperator,
+		logger:            logger,
+		client:            crClient,
+		dynamicClient:     dynamicClient,
+		crdClient:         crdClient,
+		uninstallQueueSet: queueinformer.NewEmptyResourceQueueSet(),
+		resyncPeriod:      resyncPeriod,
+	}
+
+	// Wire OperatorUninstalls
+	uninstalls := dynamicClient.Resource(OperatorUninstallResource).Namespace(metav1.NamespaceAll)
+	uninstallInformer := cache.NewSharedIndexInformer(&cache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+			return uninstalls.List(ctx, options)
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			return uninstalls.Watch(ctx, options)
+		},
+	}, &unstructured.Unstructured{}, resyncPeriod(), cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	op.uninstallIndexer = uninstallInformer.GetIndexer()
+	uninstallQueue := workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "operatorUninstall")
+	op.uninstallQueueSet.Set(metav1.NamespaceAll, uninstallQueue)
+	uninstallQueueInformer, err := queueinformer.NewQueueInformer(
+		ctx,
+		queueinformer.WithLogger(op.logger),
+		queueinformer.WithQueue(uninstallQueue),
+		queueinformer.WithInformer(uninstallInformer),
+		queueinformer.WithSyncer(queueinformer.SyncHandler(op.syncOperatorUninstall).ToSyncer()),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := op.RegisterQueueInformer(uninstallQueueInformer); err != nil {
+		return nil, err
+	}
+
+	// Wire the Subscriptions and CSVs of OperatorUninstalls, requeuing the OperatorUninstalls of their namespace when
+	// they change. The CSVs of all namespaces are also used to tell which CRDs are shared.
+	crInformerFactory := externalversions.NewSharedInformerFactoryWithOptions(op.client, op.resyncPeriod())
+	subInformer := crInformerFactory.Operators().V1alpha1().Subscriptions()
+	op.subLister = subInformer.Lister()
+	csvInformer := crInformerFactory.Operators().V1alpha1().ClusterServiceVersions()
+	op.csvLister = csvInformer.Lister()
+	for _, informer := range []cache.SharedIndexInformer{subInformer.Informer(), csvInformer.Informer()} {
+		informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc:    op.requeueOperatorUninstalls,
+			UpdateFunc: func(_, obj interface{}) { op.requeueOperatorUninstalls(obj) },
+			DeleteFunc: op.requeueOperatorUninstalls,
+		})
+		if err := op.RegisterInformer(informer); err != nil {
+			return nil, err
+		}
+	}
+
+	return op, nil
+}
+
+// requeueOperatorUninstalls requeues the OperatorUninstalls of the namespace of the given resource.
+func (o *Operator) requeueOperatorUninstalls(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return
+	}
+	uninstalls, err := o.uninstallIndexer.ByIndex(cache.NamespaceIndex, accessor.GetNamespace())
+	if err != nil {
+		return
+	}
+	for _, uninstall := range uninstalls {
+		if uninstall, ok := uninstall.(metav1.Object); ok {
+			if err := o.uninstallQueueSet.Requeue(uninstall.GetNamespace(), uninstall.GetName()); err != nil {
+				o.logger.WithError(err).Debug("couldn't requeue OperatorUninstall")
+			}
+		}
+	}
+}
+
+// uninstallError is a problem with an OperatorUninstall that retrying won't fix until it or its namespace changes.
+// It's reported on its Uninstalled condition.
+type uninstallError struct {
+	reason  reasons.Reason
+	message string
+}
+
+func (e uninstallError) Error() string {
+	return e.message
+}
+
+func (o *Operator) syncOperatorUninstall(ctx context.Context, obj interface{}) error {
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		o.logger.Debugf("wrong type: %#v", obj)
+		return nil
+	}
+	un := &OperatorUninstall{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.UnstructuredContent(), un); err != nil {
+		return err
+	}
+
+	logger := o.logger.WithFields(logrus.Fields{
+		"namespace": un.GetNamespace(),
+		"name":      un.GetName(),
+		"id":        queueinformer.NewLoopID(),
+	})
+	logger.Debug("syncing operator uninstall")
+
+	// Finalizer updates requeue the OperatorUninstall, its status is updated on the next sync
+	switch {
+	case un.GetDeletionTimestamp() != nil:
+		if !hasUninstallFinalizer(un) {
+			return nil
+		}
+		logger.Info("operator uninstall deleted, releasing csv")
+		if err := o.releaseCSV(ctx, un.GetNamespace(), un.Status.CSV); err != nil {
+			return err
+		}
+		return o.setOwnFinalizer(ctx, u, false)
+	case meta.IsStatusConditionTrue(un.Status.Conditions, UninstalledCondition):
+		// the uninstall is done, nothing holds its deletion anymore
+		if hasUninstallFinalizer(un) {
+			return o.setOwnFinalizer(ctx, u, false)
+		}
+		return nil
+	case un.Status.Cascade == "" && cascade(un) == CascadeRemoveOperands && !hasUninstallFinalizer(un):
+		// hold the deletion of the uninstall, so that it releases the CSV if deleted before it's done
+		return o.setOwnFinalizer(ctx, u, true)
+	}
+
+	status := OperatorUninstallStatus{
+		ObservedGeneration: un.GetGeneration(),
+		Subscription:       un.Status.Subscription,
+		Cascade:            un.Status.Cascade,
+		CSV:                un.Status.CSV,
+		CRDs:               un.Status.CRDs,
+		KeptCRDs:           un.Status.KeptCRDs,
+		Conditions:         append([]metav1.Condition(nil), un.Status.Conditions...),
+	}
+	err := o.reconcile(ctx, logger, un, &status)
+	var uerr uninstallError
+	if errors.As(err, &uerr) {
+		logger.WithError(err).Info("operator uninstall can't proceed")
+		setUninstalledCondition(&status, metav1.ConditionFalse, uerr.reason, uerr.message)
+	} else if err != nil {
+		return err
+	}
+	return o.updateStatus(ctx, un, status)
+}
+
+// reconcile takes the next step of the given OperatorUninstall, and sets its status to what it's waiting on.
+func (o *Operator) reconcile(ctx context.Context, logger *logrus.Entry, un *OperatorUninstall, status *OperatorUninstallStatus) error {
+	if status.Cascade == "" {
+		// The uninstall starts by recording its Subscription, CSV and CRDs, which must be known once they're removed
+		return o.start(ctx, un, status)
+	}
+
+	sub, err := o.subLister.Subscriptions(un.GetNamespace()).Get(status.Subscription)
+	if k8serrors.IsNotFound(err) {
+		sub = nil
+	} else if err != nil {
+		return err
+	}
+	var csv *v1alpha1.ClusterServiceVersion
+	if status.CSV != "" {
+		csv, err = o.csvLister.ClusterServiceVersions(un.GetNamespace()).Get(status.CSV)
+		if k8serrors.IsNotFound(err) {
+			csv = nil
+		} else if err != nil {
+			return err
+		}
+	}
+
+	// Hold the CSV, and so the operator, until its operands are removed
+	if status.Cascade == CascadeRemoveOperands && csv != nil && csv.GetDeletionTimestamp() == nil && !hasUninstallFinalizer(csv) {
+		logger.WithField("csv", csv.GetName()).Info("holding csv deletion until its operands are removed")
+		out := csv.DeepCopy()
+		out.SetFinalizers(append(out.GetFinalizers(), UninstallFinalizer))
+		_, err := o.client.OperatorsV1alpha1().ClusterServiceVersions(out.GetNamespace()).Update(ctx, out, metav1.UpdateOptions{})
+		// The update requeues the OperatorUninstall
+		return err
+	}
+
+	if sub != nil {
+		if err := o.removeSubscription(ctx, logger, sub); err != nil {
+			return err
+		}
+	}
+	if status.Cascade == CascadeKeepEverything {
+		setUninstalledCondition(status, metav1.ConditionTrue, reasons.OperatorUninstallSucceeded, fmt.Sprintf("subscription %s removed, the operator and its CRDs are kept", status.Subscription))
+		return nil
+	}
+
+	if csv != nil && csv.GetDeletionTimestamp() == nil {
+		logger.WithField("csv", csv.GetName()).Info("deleting csv")
+		if err := o.client.OperatorsV1alpha1().ClusterServiceVersions(csv.GetNamespace()).Delete(ctx, csv.GetName(), metav1.DeleteOptions{}); err != nil && !k8serrors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	if status.Cascade == CascadeRemoveOperands {
+		if err := o.keepSharedCRDs(logger, un.GetNamespace(), status); err != nil {
+			return err
+		}
+		remaining, err := o.removeOperands(ctx, logger, status.CRDs)
+		if err != nil {
+			return err
+		}
+		if remaining > 0 {
+			setUninstalledCondition(status, metav1.ConditionFalse, reasons.OperatorUninstallRemovingOperands, fmt.Sprintf("waiting for %d custom resources of %s to be deleted", remaining, strings.Join(status.CRDs, ", ")))
+			return o.uninstallQueueSet.RequeueAfter(un.GetNamespace(), un.GetName(), removalRequeueInterval)
+		}
+	}
+	if csv != nil && hasUninstallFinalizer(csv) {
+		// The update requeues the OperatorUninstall
+		return o.releaseCSV(ctx, csv.GetNamespace(), csv.GetName())
+	}
+	if csv != nil {
+		setUninstalledCondition(status, metav1.ConditionFalse, reasons.OperatorUninstallRemovingOperator, fmt.Sprintf("waiting for csv %s to be deleted", csv.GetName()))
+		return nil
+	}
+
+	if status.Cascade == CascadeRemoveOperands {
+		if err := o.keepSharedCRDs(logger, un.GetNamespace(), status); err != nil {
+			return err
+		}
+		remaining, err := o.removeCRDs(ctx, logger, status.CRDs)
+		if err != nil {
+			return err
+		}
+		if len(remaining) > 0 {
+			setUninstalledCondition(status, metav1.ConditionFalse, reasons.OperatorUninstallRemovingCRDs, fmt.Sprintf("waiting for crds %s to be deleted", strings.Join(remaining, ", ")))
+			return o.uninstallQueueSet.RequeueAfter(un.GetNamespace(), un.GetName(), removalRequeueInterval)
+		}
+	}
+
+	message := fmt.Sprintf("subscription %s and csv %s removed", status.Subscription, status.CSV)
+	if status.Cascade == CascadeRemoveOperands {
+		message += fmt.Sprintf(", %d crds removed", len(status.CRDs))
+	}
+	setUninstalledCondition(status, metav1.ConditionTrue, reasons.OperatorUninstallSucceeded, message)
+	return nil
+}
+
+// start records the Subscription, cascade, CSV and CRDs of the given OperatorUninstall in its status.
+func (o *Operator) start(ctx context.Context, un *OperatorUninstall, status *OperatorUninstallStatus) error {
+	if err := validate(un); err != nil {
+		return err
+	}
+	sub, err := o.subLister.Subscriptions(un.GetNamespace()).Get(un.Spec.Subscription)
+	if k8serrors.IsNotFound(err) {
+		return uninstallError{reasons.OperatorUninstallSubscriptionNotFound, fmt.Sprintf("subscription %s not found", un.Spec.Subscription)}
+	}
+	if err != nil {
+		return err
+	}
+
+	status.Subscription = sub.GetName()
+	status.Cascade = cascade(un)
+	status.CSV = sub.Status.InstalledCSV
+	if status.CSV == "" {
+		status.CSV = sub.Status.CurrentCSV
+	}
+	if status.CSV != "" && status.Cascade == CascadeRemoveOperands {
+		csv, err := o.csvLister.ClusterServiceVersions(un.GetNamespace()).Get(status.CSV)
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return err
+		}
+		if csv != nil {
+			if status.CRDs, status.KeptCRDs, err = o.partitionCRDs(csv); err != nil {
+				return err
+			}
+		}
+	}
+
+	reason := reasons.OperatorUninstallRemovingOperator
+	if status.Cascade == CascadeRemoveOperands {
+		reason = reasons.OperatorUninstallRemovingOperands
+	}
+	setUninstalledCondition(status, metav1.ConditionFalse, reason, fmt.Sprintf("uninstalling subscription %s with cascade %s", status.Subscription, status.Cascade))
+	return nil
+}
+
+func validate(un *OperatorUninstall) error {
+	if un.Spec.Subscription == "" {
+		return uninstallError{reasons.OperatorUninstallInvalidSpec, "spec.subscription must be set"}
+	}
+	switch cascade(un) {
+	case CascadeKeepEverything, CascadeRemoveOperatorOnly, CascadeRemoveOperands:
+		return nil
+	}
+	return uninstallError{reasons.OperatorUninstallInvalidSpec, fmt.Sprintf("unknown spec.cascade %q", un.Spec.Cascade)}
+}
+
+// cascade returns the cascade of the spec of the given OperatorUninstall.
+func cascade(un *OperatorUninstall) Cascade {
+	if un.Spec.Cascade == "" {
+		return CascadeRemoveOperatorOnly
+	}
+	return un.Spec.Cascade
+}
+
+// partitionCRDs returns the CRDs owned by the given CSV that no other CSV owns or requires, and those that other CSVs
+// do. Copied CSVs aren't other CSVs.
+func (o *Operator) partitionCRDs(csv *v1alpha1.ClusterServiceVersion) (exclusive, shared []string, err error) {
+	var owned []string
+	seen := map[string]struct{}{}
+	for _, crd := range csv.Spec.CustomResourceDefinitions.Owned {
+		if _, ok := seen[crd.Name]; ok {
+			continue
+		}
+		seen[crd.Name] = struct{}{}
+		owned = append(owned, crd.Name)
+	}
+	return o.partitionCRDNames(csv.GetNamespace(), csv.GetName(), owned)
+}
+
+// partitionCRDNames returns the named CRDs that no CSV other than the named one owns or requires, and those that other
+// CSVs do. Copied CSVs aren't other CSVs.
+func (o *Operator) partitionCRDNames(namespace, csvName string, crdNames []string) (exclusive, shared []string, err error) {
+	csvs, err := o.csvLister.List(labels.Everything())
+	if err != nil {
+		return nil, nil, err
+	}
+	used := map[string]struct{}{}
+	for _, other := range csvs {
+		if other.IsCopied() || (other.GetNamespace() == namespace && other.GetName() == csvName) {
+			continue
+		}
+		for _, crd := range other.Spec.CustomResourceDefinitions.Owned {
+			used[crd.Name] = struct{}{}
+		}
+		for _, crd := range other.Spec.CustomResourceDefinitions.Required {
+			used[crd.Name] = struct{}{}
+		}
+	}
+
+	for _, name := range crdNames {
+		if _, ok := used[name]; ok {
+			shared = append(shared, name)
+		} else {
+			exclusive = append(exclusive, name)
+		}
+	}
+	sort.Strings(exclusive)
+	sort.Strings(shared)
+	return exclusive, shared, nil
+}
+
+// keepSharedCRDs moves the CRDs of the given status that other CSVs came to own or require since the uninstall started,
+// e.g. operators installed meanwhile, to its kept CRDs, so that neither they nor their CRs are removed.
+func (o *Operator) keepSharedCRDs(logger *logrus.Entry, namespace string, status *OperatorUninstallStatus) error {
+	exclusive, shared, err := o.partitionCRDNames(namespace, status.CSV, status.CRDs)
+	if err != nil || len(shared) == 0 {
+		return err
+	}
+	logger.WithField("crds", shared).Info("keeping crds other csvs came to own or require")
+	status.CRDs = exclusive
+	status.KeptCRDs = append(append([]string(nil), status.KeptCRDs...), shared...)
+	sort.Strings(status.KeptCRDs)
+	return nil
+}
+
+// removeSubscription deletes the given Subscription, or the OperatorInstall controlling it, which would otherwise
+// recreate it.
+func (o *Operator) removeSubscription(ctx context.Context, logger *logrus.Entry, sub *v1alpha1.Subscription) error {
+	if sub.GetDeletionTimestamp() != nil {
+		return nil
+	}
+	var err error
+	if ref := metav1.GetControllerOf(sub); ref != nil && ref.Kind == operatorinstall.OperatorInstallKind && ref.APIVersion == operatorinstall.SchemeGroupVersion.String() {
+		logger.WithField("operatorinstall", ref.Name).Info("deleting operator install")
+		err = o.dynamicClient.Resource(operatorinstall.OperatorInstallResource).Namespace(sub.GetNamespace()).Delete(ctx, ref.Name, metav1.DeleteOptions{})
+	} else {
+		logger.WithField("subscription", sub.GetName()).Info("deleting subscription")
+		err = o.client.OperatorsV1alpha1().Subscriptions(sub.GetNamespace()).Delete(ctx, sub.GetName(), metav1.DeleteOptions{})
+	}
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// removeOperands deletes the CRs of the named CRDs, in all namespaces rather than only the target namespaces of the
+// operator's OperatorGroup, since the CRDs are removed next along with any CR left, and returns how many are left.
+func (o *Operator) removeOperands(ctx context.Context, logger *logrus.Entry, crdNames []string) (int, error) {
+	remaining := 0
+	for _, name := range crdNames {
+		crd, err := o.crdClient.ApiextensionsV1().CustomResourceDefinitions().Get(ctx, name, metav1.GetOptions{})
+		if k8serrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return 0, err
+		}
+		resource := o.dynamicClient.Resource(crdResource(crd))
+		crs, err := resource.Namespace(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return 0, err
+		}
+		for _, cr := range crs.Items {
+			remaining++
+			if cr.GetDeletionTimestamp() != nil {
+				continue
+			}
+			logger.WithFields(logrus.Fields{"crd": name, "cr": cr.GetName(), "crNamespace": cr.GetNamespace()}).Info("deleting operand")
+			if err := resource.Namespace(cr.GetNamespace()).Delete(ctx, cr.GetName(), metav1.DeleteOptions{}); err != nil && !k8serrors.IsNotFound(err) {
+				return 0, err
+			}
+		}
+	}
+	return remaining, nil
+}
+
+// removeCRDs deletes the named CRDs and returns those left.
+func (o *Operator) removeCRDs(ctx context.Context, logger *logrus.Entry, crdNames []string) ([]string, error) {
+	var remaining []string
+	crds := o.crdClient.ApiextensionsV1().CustomResourceDefinitions()
+	for _, name := range crdNames {
+		crd, err := crds.Get(ctx, name, metav1.GetOptions{})
+		if k8serrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		remaining = append(remaining, name)
+		if crd.GetDeletionTimestamp() != nil {
+			continue
+		}
+		logger.WithField("crd", name).Info("deleting crd")
+		if err := crds.Delete(ctx, name, metav1.DeleteOptions{}); err != nil && !k8serrors.IsNotFound(err) {
+			return nil, err
+		}
+	}
+	return remaining, nil
+}
+
+// crdResource returns the resource of the CRs of the given CRD, at its storage version.
+func crdResource(crd *apiextensionsv1.CustomResourceDefinition) schema.GroupVersionResource {
+	version := ""
+	for _, v := range crd.Spec.Versions {
+		if v.Storage {
+			version = v.Name
+			break
+		}
+		if version == "" && v.Served {
+			version = v.Name
+		}
+	}
+	return schema.GroupVersionResource{Group: crd.Spec.Group, Version: version, Resource: crd.Spec.Names.Plural}
+}
+
+func hasUninstallFinalizer(obj metav1.Object) bool {
+	for _, f := range obj.GetFinalizers() {
+		if f == UninstallFinalizer {
+			return true
+		}
+	}
+	return false
+}
+
+func removeFinalizer(finalizers []string, finalizer string) []string {
+	out := make([]string, 0, len(finalizers))
+	for _, f := range finalizers {
+		if f != finalizer {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+// releaseCSV removes the uninstall finalizer from the named CSV, if it exists.
+func (o *Operator) releaseCSV(ctx context.Context, namespace, name string) error {
+	if name == "" {
+		return nil
+	}
+	csv, err := o.csvLister.ClusterServiceVersions(namespace).Get(name)
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !hasUninstallFinalizer(csv) {
+		return nil
+	}
+	out := csv.DeepCopy()
+	out.SetFinalizers(removeFinalizer(out.GetFinalizers(), UninstallFinalizer))
+	_, err = o.client.OperatorsV1alpha1().ClusterServiceVersions(out.GetNamespace()).Update(ctx, out, metav1.UpdateOptions{})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// setOwnFinalizer adds or removes the uninstall finalizer of the given OperatorUninstall.
+func (o *Operator) setOwnFinalizer(ctx context.Context, u *unstructured.Unstructured, set bool) error {
+	out := u.DeepCopy()
+	finalizers := removeFinalizer(out.GetFinalizers(), UninstallFinalizer)
+	if set {
+		finalizers = append(finalizers, UninstallFinalizer)
+	}
+	out.SetFinalizers(finalizers)
+	_, err := o.dynamicClient.Resource(OperatorUninstallResource).Namespace(out.GetNamespace()).Update(ctx, out, metav1.UpdateOptions{})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+func setUninstalledCondition(status *OperatorUninstallStatus, conditionStatus metav1.ConditionStatus, reason reasons.Reason, message string) {
+	meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:               UninstalledCondition,
+		Status:             conditionStatus,
+		Reason:             string(reason),
+		Message:            message,
+		ObservedGeneration: status.ObservedGeneration,
+	})
+}
+
+// updateStatus sets the given status on the given OperatorUninstall, if it changed.
+func (o *Operator) updateStatus(ctx context.Context, un *OperatorUninstall, status OperatorUninstallStatus) error {
+	if equality.Semantic.DeepEqual(un.Status, status) {
+		return nil
+	}
+	un.Status = status
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(un)
+	if err != nil {
+		return err
+	}
+	_, err = o.dynamicClient.Resource(OperatorUninstallResource).Namespace(un.GetNamespace()).UpdateStatus(ctx, &unstructured.Unstructured{Object: content}, metav1.UpdateOptions{})
+	return err
+}
@@ -0,0 +1,357 @@
+package operatoruninstall
+
+import (
+	"context"
+	"testing"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apiextensionsfake "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/fake"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clienttesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/util/workqueue"
+
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/api/client/clientset/versioned/fake"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/api/reasons"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/operators/internal/installfake"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/operators/operatorinstall"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/queueinformer"
+)
+
+var etcdClustersResource = schema.GroupVersionResource{Group: "etcd.database.coreos.com", Version: "v1beta2", Resource: "etcdclusters"}
+
+func operatorUninstall(spec OperatorUninstallSpec) *OperatorUninstall {
+	return &OperatorUninstall{
+		TypeMeta:   metav1.TypeMeta{APIVersion: SchemeGroupVersion.String(), Kind: OperatorUninstallKind},
+		ObjectMeta: metav1.ObjectMeta{Name: "etcd", Namespace: "team-a", Generation: 1},
+		Spec:       spec,
+	}
+}
+
+func subscription(owners ...metav1.OwnerReference) *v1alpha1.Subscription {
+	return &v1alpha1.Subscription{
+		ObjectMeta: metav1.ObjectMeta{Name: "etcd", Namespace: "team-a", OwnerReferences: owners},
+		Spec:       &v1alpha1.SubscriptionSpec{Package: "etcd"},
+		Status:     v1alpha1.SubscriptionStatus{InstalledCSV: "etcdoperator.v0.9.4"},
+	}
+}
+
+func csv(namespace, name string, owned, required []string) *v1alpha1.ClusterServiceVersion {
+	out := &v1alpha1.ClusterServiceVersion{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}}
+	for _, crd := range owned {
+		out.Spec.CustomResourceDefinitions.Owned = append(out.Spec.CustomResourceDefinitions.Owned, v1alpha1.CRDDescription{Name: crd})
+	}
+	for _, crd := range required {
+		out.Spec.CustomResourceDefinitions.Required = append(out.Spec.CustomResourceDefinitions.Required, v1alpha1.CRDDescription{Name: crd})
+	}
+	return out
+}
+
+func crd(name, group, plural string) *apiextensionsv1.CustomResourceDefinition {
+	return &apiextensionsv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+			Group: group,
+			Names: apiextensionsv1.CustomResourceDefinitionNames{Plural: plural},
+			Versions: []apiextensionsv1.CustomResourceDefinitionVersion{
+				{Name: "v1beta1", Served: true},
+				{Name: "v1beta2", Served: true, Storage: true},
+			},
+		},
+	}
+}
+
+func etcdCluster(namespace, name string) *unstructured.Unstructured {
+	cr := &unstructured.Unstructured{}
+	cr.SetAPIVersion("etcd.database.coreos.com/v1beta2")
+	cr.SetKind("EtcdCluster")
+	cr.SetNamespace(namespace)
+	cr.SetName(name)
+	return cr
+}
+
+// fakeOperator returns an Operator serving the given resources. CSVs are deleted as by the API server: once they have
+// no finalizers left.
+func fakeOperator(t *testing.T, objs []runtime.Object, crds []runtime.Object, dynamicObjs ...runtime.Object) *Operator {
+	client := fake.NewSimpleClientset(objs...)
+	client.PrependReactor("delete", "clusterserviceversions", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		name := action.(clienttesting.DeleteAction).GetName()
+		obj, err := client.Tracker().Get(action.GetResource(), action.GetNamespace(), name)
+		if err != nil || len(obj.(metav1.Object).GetFinalizers()) == 0 {
+			return false, nil, nil
+		}
+		now := metav1.Now()
+		obj.(metav1.Object).SetDeletionTimestamp(&now)
+		return true, nil, client.Tracker().Update(action.GetResource(), obj, action.GetNamespace())
+	})
+	client.PrependReactor("update", "clusterserviceversions", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		obj := action.(clienttesting.UpdateAction).GetObject().(metav1.Object)
+		if obj.GetDeletionTimestamp() == nil || len(obj.GetFinalizers()) > 0 {
+			return false, nil, nil
+		}
+		return true, nil, client.Tracker().Delete(action.GetResource(), action.GetNamespace(), obj.GetName())
+	})
+
+	listKinds := map[schema.GroupVersionResource]string{
+		OperatorUninstallResource:               "OperatorUninstallList",
+		operatorinstall.OperatorInstallResource: "OperatorInstallList",
+		etcdClustersResource:                    "EtcdClusterList",
+	}
+	queueSet := queueinformer.NewEmptyResourceQueueSet()
+	queueSet.Set(metav1.NamespaceAll, workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "operatorUninstall"))
+	return &Operator{
+		logger:            logrus.New(),
+		client:            client,
+		dynamicClient:     installfake.NewDynamicClient(listKinds, dynamicObjs...),
+		crdClient:         apiextensionsfake.NewSimpleClientset(crds...),
+		uninstallQueueSet: queueSet,
+	}
+}
+
+// refreshListers sets the listers of the given Operator to the Subscriptions and CSVs of its client.
+func refreshListers(t *testing.T, o *Operator) {
+	ctx := context.TODO()
+	var objs []runtime.Object
+	subList, err := o.client.OperatorsV1alpha1().Subscriptions(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	for i := range subList.Items {
+		objs = append(objs, &subList.Items[i])
+	}
+	csvList, err := o.client.OperatorsV1alpha1().ClusterServiceVersions(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	for i := range csvList.Items {
+		objs = append(objs, &csvList.Items[i])
+	}
+	listers := installfake.NewListers(t, objs...)
+	o.subLister = listers.Subscriptions
+	o.csvLister = listers.CSVs
+}
+
+// step syncs the named OperatorUninstall and returns it as updated.
+func step(t *testing.T, o *Operator, name string) *OperatorUninstall {
+	ctx := context.TODO()
+	refreshListers(t, o)
+	u, err := o.dynamicClient.Resource(OperatorUninstallResource).Namespace("team-a").Get(ctx, name, metav1.GetOptions{})
+	require.NoError(t, err)
+	require.NoError(t, o.syncOperatorUninstall(ctx, u))
+	out := &OperatorUninstall{}
+	installfake.Get(t, o.dynamicClient, OperatorUninstallResource, "team-a", name, out)
+	return out
+}
+
+func requireUninstalledCondition(t *testing.T, un *OperatorUninstall, status metav1.ConditionStatus, reason reasons.Reason) {
+	cond := meta.FindStatusCondition(un.Status.Conditions, UninstalledCondition)
+	require.NotNil(t, cond)
+	require.Equal(t, status, cond.Status, cond.Message)
+	require.Equal(t, string(reason), cond.Reason, cond.Message)
+}
+
+func getCSV(t *testing.T, o *Operator, name string) *v1alpha1.ClusterServiceVersion {
+	out, err := o.client.OperatorsV1alpha1().ClusterServiceVersions("team-a").Get(context.TODO(), name, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	require.NoError(t, err)
+	return out
+}
+
+func TestSyncOperatorUninstallRemoveOperands(t *testing.T) {
+	ctx := context.TODO()
+	etcdCSV := csv("team-a", "etcdoperator.v0.9.4", []string{"etcdclusters.etcd.database.coreos.com", "etcdbackups.etcd.database.coreos.com"}, nil)
+	copied := csv("team-b", "etcdoperator.v0.9.4", []string{"etcdclusters.etcd.database.coreos.com", "etcdbackups.etcd.database.coreos.com"}, nil)
+	copied.Status.Reason = v1alpha1.CSVReasonCopied
+	backup := csv("team-b", "backup-operator.v1.0.0", nil, []string{"etcdbackups.etcd.database.coreos.com"})
+	o := fakeOperator(t,
+		[]runtime.Object{subscription(), etcdCSV, copied, backup},
+		[]runtime.Object{
+			crd("etcdclusters.etcd.database.coreos.com", "etcd.database.coreos.com", "etcdclusters"),
+			crd("etcdbackups.etcd.database.coreos.com", "etcd.database.coreos.com", "etcdbackups"),
+		},
+		installfake.ToUnstructured(t, operatorUninstall(OperatorUninstallSpec{Subscription: "etcd", Cascade: CascadeRemoveOperands})),
+		etcdCluster("team-b", "example"),
+	)
+
+	// The uninstall holds its own deletion, then records what it removes
+	un := step(t, o, "etcd")
+	require.Equal(t, []string{UninstallFinalizer}, un.GetFinalizers())
+	require.Empty(t, un.Status.Conditions)
+	un = step(t, o, "etcd")
+	require.Equal(t, OperatorUninstallStatus{
+		ObservedGeneration: 1,
+		Subscription:       "etcd",
+		Cascade:            CascadeRemoveOperands,
+		CSV:                "etcdoperator.v0.9.4",
+		CRDs:               []string{"etcdclusters.etcd.database.coreos.com"},
+		KeptCRDs:           []string{"etcdbackups.etcd.database.coreos.com"},
+		Conditions:         un.Status.Conditions,
+	}, un.Status)
+	requireUninstalledCondition(t, un, metav1.ConditionFalse, reasons.OperatorUninstallRemovingOperands)
+
+	// The CSV is held, then the Subscription, CSV and CRs are deleted while the operator keeps running
+	step(t, o, "etcd")
+	require.Equal(t, []string{UninstallFinalizer}, getCSV(t, o, "etcdoperator.v0.9.4").GetFinalizers())
+	un = step(t, o, "etcd")
+	requireUninstalledCondition(t, un, metav1.ConditionFalse, reasons.OperatorUninstallRemovingOperands)
+	_, err := o.client.OperatorsV1alpha1().Subscriptions("team-a").Get(ctx, "etcd", metav1.GetOptions{})
+	require.True(t, k8serrors.IsNotFound(err))
+	require.NotNil(t, getCSV(t, o, "etcdoperator.v0.9.4").GetDeletionTimestamp())
+	crs, err := o.dynamicClient.Resource(etcdClustersResource).Namespace(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	require.Empty(t, crs.Items)
+
+	// Once the CRs are gone, the CSV is released, and then its owned CRDs no other CSV uses are deleted
+	step(t, o, "etcd")
+	require.Nil(t, getCSV(t, o, "etcdoperator.v0.9.4"))
+	un = step(t, o, "etcd")
+	requireUninstalledCondition(t, un, metav1.ConditionFalse, reasons.OperatorUninstallRemovingCRDs)
+	crdList, err := o.crdClient.ApiextensionsV1().CustomResourceDefinitions().List(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, crdList.Items, 1)
+	require.Equal(t, "etcdbackups.etcd.database.coreos.com", crdList.Items[0].GetName())
+	un = step(t, o, "etcd")
+	requireUninstalledCondition(t, un, metav1.ConditionTrue, reasons.OperatorUninstallSucceeded)
+
+	// Nothing holds the deletion of a finished uninstall
+	un = step(t, o, "etcd")
+	require.Empty(t, un.GetFinalizers())
+}
+
+func TestSyncOperatorUninstallRemoveOperandsNewlyShared(t *testing.T) {
+	ctx := context.TODO()
+	o := fakeOperator(t,
+		[]runtime.Object{subscription(), csv("team-a", "etcdoperator.v0.9.4", []string{"etcdclusters.etcd.database.coreos.com"}, nil)},
+		[]runtime.Object{crd("etcdclusters.etcd.database.coreos.com", "etcd.database.coreos.com", "etcdclusters")},
+		installfake.ToUnstructured(t, operatorUninstall(OperatorUninstallSpec{Subscription: "etcd", Cascade: CascadeRemoveOperands})),
+		etcdCluster("team-a", "example"),
+	)
+
+	// The uninstall removes the operator and its CRs
+	step(t, o, "etcd")
+	un := step(t, o, "etcd")
+	require.Equal(t, []string{"etcdclusters.etcd.database.coreos.com"}, un.Status.CRDs)
+	step(t, o, "etcd")
+	step(t, o, "etcd")
+	step(t, o, "etcd")
+	require.Nil(t, getCSV(t, o, "etcdoperator.v0.9.4"))
+
+	// An operator requiring the CRD installed meanwhile keeps it
+	_, err := o.client.OperatorsV1alpha1().ClusterServiceVersions("team-b").Create(ctx, csv("team-b", "backup-operator.v1.0.0", nil, []string{"etcdclusters.etcd.database.coreos.com"}), metav1.CreateOptions{})
+	require.NoError(t, err)
+	un = step(t, o, "etcd")
+	requireUninstalledCondition(t, un, metav1.ConditionTrue, reasons.OperatorUninstallSucceeded)
+	require.Empty(t, un.Status.CRDs)
+	require.Equal(t, []string{"etcdclusters.etcd.database.coreos.com"}, un.Status.KeptCRDs)
+	_, err = o.crdClient.ApiextensionsV1().CustomResourceDefinitions().Get(ctx, "etcdclusters.etcd.database.coreos.com", metav1.GetOptions{})
+	require.NoError(t, err)
+}
+
+func TestSyncOperatorUninstallRemoveOperatorOnly(t *testing.T) {
+	o := fakeOperator(t,
+		[]runtime.Object{subscription(), csv("team-a", "etcdoperator.v0.9.4", []string{"etcdclusters.etcd.database.coreos.com"}, nil)},
+		[]runtime.Object{crd("etcdclusters.etcd.database.coreos.com", "etcd.database.coreos.com", "etcdclusters")},
+		installfake.ToUnstructured(t, operatorUninstall(OperatorUninstallSpec{Subscription: "etcd"})),
+	)
+
+	un := step(t, o, "etcd")
+	require.Equal(t, CascadeRemoveOperatorOnly, un.Status.Cascade)
+	require.Empty(t, un.Status.CRDs)
+	require.Empty(t, un.GetFinalizers())
+	requireUninstalledCondition(t, un, metav1.ConditionFalse, reasons.OperatorUninstallRemovingOperator)
+
+	un = step(t, o, "etcd")
+	requireUninstalledCondition(t, un, metav1.ConditionFalse, reasons.OperatorUninstallRemovingOperator)
+	require.Nil(t, getCSV(t, o, "etcdoperator.v0.9.4"))
+	un = step(t, o, "etcd")
+	requireUninstalledCondition(t, un, metav1.ConditionTrue, reasons.OperatorUninstallSucceeded)
+	_, err := o.crdClient.ApiextensionsV1().CustomResourceDefinitions().Get(context.TODO(), "etcdclusters.etcd.database.coreos.com", metav1.GetOptions{})
+	require.NoError(t, err)
+}
+
+func TestSyncOperatorUninstallKeepEverything(t *testing.T) {
+	ctx := context.TODO()
+	install := &unstructured.Unstructured{}
+	install.SetAPIVersion(operatorinstall.SchemeGroupVersion.String())
+	install.SetKind(operatorinstall.OperatorInstallKind)
+	install.SetNamespace("team-a")
+	install.SetName("etcd-install")
+	install.SetUID("install-uid")
+	o := fakeOperator(t,
+		[]runtime.Object{
+			subscription(*metav1.NewControllerRef(install, operatorinstall.SchemeGroupVersion.WithKind(operatorinstall.OperatorInstallKind))),
+			csv("team-a", "etcdoperator.v0.9.4", nil, nil),
+		},
+		nil,
+		installfake.ToUnstructured(t, operatorUninstall(OperatorUninstallSpec{Subscription: "etcd", Cascade: CascadeKeepEverything})),
+		install,
+	)
+
+	step(t, o, "etcd")
+	un := step(t, o, "etcd")
+	requireUninstalledCondition(t, un, metav1.ConditionTrue, reasons.OperatorUninstallSucceeded)
+
+	// The OperatorInstall owning the Subscription is removed instead of it, and the CSV is kept
+	_, err := o.dynamicClient.Resource(operatorinstall.OperatorInstallResource).Namespace("team-a").Get(ctx, "etcd-install", metav1.GetOptions{})
+	require.True(t, k8serrors.IsNotFound(err))
+	_, err = o.client.OperatorsV1alpha1().Subscriptions("team-a").Get(ctx, "etcd", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.NotNil(t, getCSV(t, o, "etcdoperator.v0.9.4"))
+}
+
+func TestSyncOperatorUninstallDeleted(t *testing.T) {
+	held := csv("team-a", "etcdoperator.v0.9.4", nil, nil)
+	held.SetFinalizers([]string{"other", UninstallFinalizer})
+	un := operatorUninstall(OperatorUninstallSpec{Subscription: "etcd", Cascade: CascadeRemoveOperands})
+	un.SetFinalizers([]string{UninstallFinalizer})
+	un.Status = OperatorUninstallStatus{Subscription: "etcd", Cascade: CascadeRemoveOperands, CSV: "etcdoperator.v0.9.4"}
+	o := fakeOperator(t, []runtime.Object{held}, nil, installfake.ToUnstructured(t, un))
+
+	// Deleting an unfinished uninstall releases the CSV it holds
+	now := metav1.Now()
+	un.SetDeletionTimestamp(&now)
+	refreshListers(t, o)
+	require.NoError(t, o.syncOperatorUninstall(context.TODO(), installfake.ToUnstructured(t, un)))
+	require.Equal(t, []string{"other"}, getCSV(t, o, "etcdoperator.v0.9.4").GetFinalizers())
+	u, err := o.dynamicClient.Resource(OperatorUninstallResource).Namespace("team-a").Get(context.TODO(), "etcd", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Empty(t, u.GetFinalizers())
+}
+
+func TestSyncOperatorUninstallCantStart(t *testing.T) {
+	for _, tt := range []struct {
+		name       string
+		spec       OperatorUninstallSpec
+		wantReason reasons.Reason
+	}{
+		{
+			name:       "NoSubscription",
+			spec:       OperatorUninstallSpec{},
+			wantReason: reasons.OperatorUninstallInvalidSpec,
+		},
+		{
+			name:       "UnknownCascade",
+			spec:       OperatorUninstallSpec{Subscription: "etcd", Cascade: "RemoveEverything"},
+			wantReason: reasons.OperatorUninstallInvalidSpec,
+		},
+		{
+			name:       "SubscriptionNotFound",
+			spec:       OperatorUninstallSpec{Subscription: "prometheus"},
+			wantReason: reasons.OperatorUninstallSubscriptionNotFound,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			o := fakeOperator(t, []runtime.Object{subscription()}, nil, installfake.ToUnstructured(t, operatorUninstall(tt.spec)))
+			un := step(t, o, "etcd")
+			requireUninstalledCondition(t, un, metav1.ConditionFalse, tt.wantReason)
+			require.Empty(t, un.Status.Cascade)
+			_, err := o.client.OperatorsV1alpha1().Subscriptions("team-a").Get(context.TODO(), "etcd", metav1.GetOptions{})
+			require.NoError(t, err)
+		})
+	}
+}
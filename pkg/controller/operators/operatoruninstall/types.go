@@ -0,0 +1,88 @@
+package operatoruninstall
+
+import (
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	OperatorUninstallKind = "OperatorUninstall"
+
+	// UninstalledCondition is the condition of OperatorUninstalls reporting whether their operator is uninstalled.
+	UninstalledCondition = "Uninstalled"
+
+	// UninstallFinalizer is the finalizer OperatorUninstalls removing operands hold on the CSV of their operator, so
+	// that the operator keeps running until its operands are gone, and on themselves, so that the CSV is released if
+	// they're deleted before they're done.
+	UninstallFinalizer = "operatorframework.io/uninstall"
+)
+
+var (
+	// SchemeGroupVersion is the group version of OperatorUninstalls, which are served by the operatoruninstalls CRD
+	// alongside the other operators.coreos.com/v1alpha1 types.
+	SchemeGroupVersion = v1alpha1.SchemeGroupVersion
+
+	// OperatorUninstallResource is the resource of OperatorUninstalls.
+	OperatorUninstallResource = SchemeGroupVersion.WithResource("operatoruninstalls")
+)
+
+// Cascade is what an OperatorUninstall removes along with the Subscription of its operator.
+type Cascade string
+
+const (
+	// CascadeKeepEverything removes the Subscription only, keeping the CSV, CRDs and CRs of the operator.
+	CascadeKeepEverything Cascade = "KeepEverything"
+	// CascadeRemoveOperatorOnly removes the Subscription and CSV, keeping the CRDs and CRs of the operator.
+	CascadeRemoveOperatorOnly Cascade = "RemoveOperatorOnly"
+	// CascadeRemoveOperands removes the Subscription, the CSV, the CRs of the CRDs the CSV owns and then the CRDs. CRDs
+	// owned or required by other CSVs are kept, along with their CRs. The CRs are removed in all namespaces, including
+	// those outside the target namespaces of the operator's OperatorGroup, as their CRDs are cluster-scoped.
+	CascadeRemoveOperands Cascade = "RemoveOperands"
+)
+
+// OperatorUninstall uninstalls the operator a Subscription of its namespace installed. The catalog operator removes
+// the Subscription and what its cascade says, in order, and reports the progress of the uninstall in its status.
+type OperatorUninstall struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   OperatorUninstallSpec   `json:"spec"`
+	Status OperatorUninstallStatus `json:"status,omitempty"`
+}
+
+// OperatorUninstallSpec is the operator an OperatorUninstall uninstalls. It's read when the uninstall starts, later
+// changes are ignored.
+type OperatorUninstallSpec struct {
+	// Subscription is the name of the Subscription of the operator to uninstall. If it's owned by an OperatorInstall,
+	// the OperatorInstall is removed instead.
+	Subscription string `json:"subscription"`
+
+	// Cascade is what is removed along with the Subscription. It defaults to RemoveOperatorOnly.
+	Cascade Cascade `json:"cascade,omitempty"`
+}
+
+// OperatorUninstallStatus is the progress of an OperatorUninstall.
+type OperatorUninstallStatus struct {
+	// ObservedGeneration is the generation of the OperatorUninstall the status was computed for.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Subscription is the name of the Subscription the uninstall started with.
+	Subscription string `json:"subscription,omitempty"`
+
+	// Cascade is the cascade the uninstall started with.
+	Cascade Cascade `json:"cascade,omitempty"`
+
+	// CSV is the CSV of the Subscription when the uninstall started.
+	CSV string `json:"csv,omitempty"`
+
+	// CRDs are the CRDs owned by the CSV that are removed, with their CRs, under the RemoveOperands cascade.
+	CRDs []string `json:"crds,omitempty"`
+
+	// KeptCRDs are the CRDs owned by the CSV that are kept under the RemoveOperands cascade, since other CSVs own or
+	// require them, including CRDs moved from CRDs when other CSVs came to own or require them during the uninstall.
+	KeptCRDs []string `json:"keptCRDs,omitempty"`
+
+	// Conditions are the conditions of the OperatorUninstall. The Uninstalled condition is true once everything the
+	// cascade removes is gone, and otherwise explains what the uninstall is waiting on.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
@@ -0,0 +1,159 @@
+package operators
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	operatorsv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+)
+
+const (
+	// PodSecurityLevelAnnotationKey is the annotation of a CSV declaring the Pod Security level its pods need, i.e.
+	// one of privileged, baseline or restricted.
+	PodSecurityLevelAnnotationKey = "operatorframework.io/pod-security-level"
+
+	// PodSecurityEnforceLabelKey is the label of a namespace setting the Pod Security level enforced in it.
+	PodSecurityEnforceLabelKey = "pod-security.kubernetes.io/enforce"
+
+	// PodSecurityManagedAnnotationKey is the annotation of a namespace recording the Pod Security level OLM enforces in
+	// it, so that OLM tells the labels it set apart from those set by admins.
+	PodSecurityManagedAnnotationKey = "operatorframework.io/pod-security-enforce"
+)
+
+// podSecurityLevels ranks the Pod Security levels from the most to the least restrictive.
+var podSecurityLevels = map[string]int{
+	"restricted": 0,
+	"baseline":   1,
+	"privileged": 2,
+}
+
+// NamespacePodSecurityReconciler labels the namespaces of CSVs declaring the Pod Security level they need with the
+// least restrictive of those levels, and removes the label once no CSV needs it anymore. Labels set by others are
+// left alone.
+type NamespacePodSecurityReconciler struct {
+	client.Client
+
+	log logr.Logger
+}
+
+// +kubebuilder:rbac:groups=core,resources=namespaces,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=operators.coreos.com,resources=clusterserviceversions,verbs=get;list;watch
+
+// SetupWithManager adds the NamespacePodSecurityReconciler to the given controller manager.
+func (r *NamespacePodSecurityReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	enqueueNamespace := handler.EnqueueRequestsFromMapFunc(func(obj client.Object) []reconcile.Request {
+		return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: obj.GetNamespace()}}}
+	})
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("namespace-pod-security").
+		For(&corev1.Namespace{}).
+		Watches(&source.Kind{Type: &operatorsv1alpha1.ClusterServiceVersion{}}, enqueueNamespace).
+		Complete(r)
+}
+
+// NewNamespacePodSecurityReconciler constructs and returns a NamespacePodSecurityReconciler.
+// As a side effect, the given scheme has operator discovery types added to it.
+func NewNamespacePodSecurityReconciler(cli client.Client, log logr.Logger, scheme *runtime.Scheme) (*NamespacePodSecurityReconciler, error) {
+	// Add watched types to scheme.
+	if err := AddToScheme(scheme); err != nil {
+		return nil, err
+	}
+
+	return &NamespacePodSecurityReconciler{
+		Client: cli,
+		log:    log,
+	}, nil
+}
+
+// Implement reconcile.Reconciler so the controller can reconcile objects
+var _ reconcile.Reconciler = &NamespacePodSecurityReconciler{}
+
+func (r *NamespacePodSecurityReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.log.WithValues("request", req)
+
+	ns := &corev1.Namespace{}
+	if err := r.Get(ctx, req.NamespacedName, ns); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if !ns.GetDeletionTimestamp().IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	csvs := &operatorsv1alpha1.ClusterServiceVersionList{}
+	if err := r.List(ctx, csvs, client.InNamespace(ns.GetName())); err != nil {
+		return ctrl.Result{}, err
+	}
+	required := requiredPodSecurityLevel(log, csvs.Items)
+
+	current, managed := ns.GetLabels()[PodSecurityEnforceLabelKey], ns.GetAnnotations()[PodSecurityManagedAnnotationKey]
+	labels, annotations := ns.GetLabels(), ns.GetAnnotations()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	switch {
+	case current != "" && current != managed:
+		// set by an admin, who stays in charge of the namespace
+		if managed == "" {
+			return ctrl.Result{}, nil
+		}
+		delete(annotations, PodSecurityManagedAnnotationKey)
+	case required == "":
+		if managed == "" {
+			return ctrl.Result{}, nil
+		}
+		delete(labels, PodSecurityEnforceLabelKey)
+		delete(annotations, PodSecurityManagedAnnotationKey)
+	default:
+		if current == required && managed == required {
+			return ctrl.Result{}, nil
+		}
+		labels[PodSecurityEnforceLabelKey] = required
+		annotations[PodSecurityManagedAnnotationKey] = required
+	}
+	ns.SetLabels(labels)
+	ns.SetAnnotations(annotations)
+
+	log.V(1).Info("updating namespace pod security level", "level", required)
+	if err := r.Update(ctx, ns); err != nil && !apierrors.IsNotFound(err) {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
+}
+
+// requiredPodSecurityLevel returns the least restrictive Pod Security level declared by the given CSVs, or an empty
+// string if none declares one. Copied CSVs and invalid levels are ignored.
+func requiredPodSecurityLevel(log logr.Logger, csvs []operatorsv1alpha1.ClusterServiceVersion) string {
+	required := ""
+	for _, csv := range csvs {
+		if csv.IsCopied() {
+			continue
+		}
+		level, ok := csv.GetAnnotations()[PodSecurityLevelAnnotationKey]
+		if !ok {
+			continue
+		}
+		rank, ok := podSecurityLevels[level]
+		if !ok {
+			log.Info("ignoring invalid pod security level", "csv", csv.GetName(), "level", level)
+			continue
+		}
+		if required == "" || rank > podSecurityLevels[required] {
+			required = level
+		}
+	}
+	return required
+}
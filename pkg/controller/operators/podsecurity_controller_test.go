@@ -0,0 +1,101 @@
+package operators
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	operatorsv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+)
+
+func TestNamespacePodSecurityReconciler(t *testing.T) {
+	namespace := func(labels, annotations map[string]string) *corev1.Namespace {
+		return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ns", Labels: labels, Annotations: annotations}}
+	}
+	csv := func(name, level string, labels map[string]string) *operatorsv1alpha1.ClusterServiceVersion {
+		c := &operatorsv1alpha1.ClusterServiceVersion{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: name, Labels: labels}}
+		if level != "" {
+			c.SetAnnotations(map[string]string{PodSecurityLevelAnnotationKey: level})
+		}
+		return c
+	}
+	managed := func(level string) (map[string]string, map[string]string) {
+		return map[string]string{PodSecurityEnforceLabelKey: level}, map[string]string{PodSecurityManagedAnnotationKey: level}
+	}
+
+	tests := []struct {
+		name            string
+		objs            []client.Object
+		expectedLabel   string
+		expectedManaged string
+	}{
+		{
+			name:            "LeastRestrictiveLevel",
+			objs:            []client.Object{namespace(nil, nil), csv("a", "baseline", nil), csv("b", "privileged", nil), csv("c", "", nil)},
+			expectedLabel:   "privileged",
+			expectedManaged: "privileged",
+		},
+		{
+			name: "IgnoresCopiedAndInvalid",
+			objs: []client.Object{
+				namespace(nil, nil),
+				csv("a", "restricted", nil),
+				csv("b", "privileged", map[string]string{operatorsv1alpha1.CopiedLabelKey: "other"}),
+				csv("c", "root", nil),
+			},
+			expectedLabel:   "restricted",
+			expectedManaged: "restricted",
+		},
+		{
+			name:            "UpdatesManagedLevel",
+			objs:            []client.Object{namespace(managed("privileged")), csv("a", "baseline", nil)},
+			expectedLabel:   "baseline",
+			expectedManaged: "baseline",
+		},
+		{
+			name: "RemovesManagedLevel",
+			objs: []client.Object{namespace(managed("privileged")), csv("a", "", nil)},
+		},
+		{
+			name:          "KeepsAdminLevel",
+			objs:          []client.Object{namespace(map[string]string{PodSecurityEnforceLabelKey: "restricted"}, nil), csv("a", "privileged", nil)},
+			expectedLabel: "restricted",
+		},
+		{
+			name: "StopsManagingAdminLevel",
+			objs: []client.Object{
+				namespace(map[string]string{PodSecurityEnforceLabelKey: "restricted"}, map[string]string{PodSecurityManagedAnnotationKey: "privileged"}),
+				csv("a", "privileged", nil),
+			},
+			expectedLabel: "restricted",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			require.NoError(t, clientgoscheme.AddToScheme(scheme))
+			require.NoError(t, AddToScheme(scheme))
+			cli := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tt.objs...).Build()
+			r, err := NewNamespacePodSecurityReconciler(cli, logr.Discard(), scheme)
+			require.NoError(t, err)
+
+			_, err = r.Reconcile(context.TODO(), ctrl.Request{NamespacedName: types.NamespacedName{Name: "ns"}})
+			require.NoError(t, err)
+
+			ns := &corev1.Namespace{}
+			require.NoError(t, cli.Get(context.TODO(), types.NamespacedName{Name: "ns"}, ns))
+			require.Equal(t, tt.expectedLabel, ns.GetLabels()[PodSecurityEnforceLabelKey])
+			require.Equal(t, tt.expectedManaged, ns.GetAnnotations()[PodSecurityManagedAnnotationKey])
+		})
+	}
+}
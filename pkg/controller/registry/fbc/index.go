@@ -0,0 +1,626 @@
+// Package fbc serves file-based catalogs from memory over the registry gRPC API, without a registry pod.
+package fbc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/operator-framework/operator-registry/pkg/api"
+	"github.com/operator-framework/operator-registry/pkg/registry"
+	"k8s.io/apimachinery/pkg/util/yaml"
+
+	olmregistry "github.com/operator-framework/operator-lifecycle-manager/pkg/controller/registry"
+)
+
+const (
+	schemaPackage = "olm.package"
+	schemaChannel = "olm.channel"
+	schemaBundle  = "olm.bundle"
+	// schemaDeprecations documents deprecate packages, channels and bundles
+	schemaDeprecations = "olm.deprecations"
+
+	propertyPackage         = "olm.package"
+	propertyChannel         = "olm.channel"
+	propertySkips           = "olm.skips"
+	propertySkipRange       = "olm.skipRange"
+	propertyGVK             = "olm.gvk"
+	propertyGVKRequired     = "olm.gvk.required"
+	propertyPackageRequired = "olm.package.required"
+	propertyBundleObject    = "olm.bundle.object"
+)
+
+// declaration is a document of a file-based catalog, with the fields of all the schemas this package understands.
+type declaration struct {
+	Schema         string         `json:"schema"`
+	Package        string         `json:"package,omitempty"`
+	Name           string         `json:"name"`
+	DefaultChannel string         `json:"defaultChannel,omitempty"`
+	Entries        []channelEntry `json:"entries,omitempty"`
+	Image          string         `json:"image,omitempty"`
+	Properties     []property     `json:"properties,omitempty"`
+}
+
+type channelEntry struct {
+	Name      string   `json:"name"`
+	Replaces  string   `json:"replaces,omitempty"`
+	Skips     []string `json:"skips,omitempty"`
+	SkipRange string   `json:"skipRange,omitempty"`
+
+	// Reference and Message are set instead on the entries of olm.deprecations documents.
+	Reference *deprecationReference `json:"reference,omitempty"`
+	Message   string                `json:"message,omitempty"`
+}
+
+type deprecationReference struct {
+	Schema string `json:"schema"`
+	Name   string `json:"name,omitempty"`
+}
+
+type property struct {
+	Type  string          `json:"type"`
+	Value json.RawMessage `json:"value"`
+}
+
+type gvk struct {
+	Group   string `json:"group"`
+	Version string `json:"version"`
+	Kind    string `json:"kind"`
+}
+
+type packageRequired struct {
+	PackageName  string `json:"packageName"`
+	VersionRange string `json:"versionRange"`
+}
+
+type catalogPackage struct {
+	name           string
+	defaultChannel string
+	// channels are indexed by name, and hold the bundles of each channel indexed by name
+	channels map[string]map[string]*api.Bundle
+}
+
+// Index is an in-memory index of a file-based catalog, answering the queries of the registry gRPC API.
+type Index struct {
+	packages map[string]*catalogPackage
+}
+
+var _ registry.GRPCQuery = &Index{}
+
+// Load indexes the file-based catalog made of the given files, keyed by their names. Files without a .json, .yaml or
+// .yml extension are ignored. The documents of the olm.package, olm.channel and olm.bundle schemas are indexed, as well
+// as the olm.channel properties of bundles; other documents are ignored.
+func Load(files map[string][]byte) (*Index, error) {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		switch filepath.Ext(name) {
+		case ".json", ".yaml", ".yml":
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	var decls []declaration
+	for _, name := range names {
+		dec := yaml.NewYAMLOrJSONDecoder(bytes.NewReader(files[name]), 4096)
+		for {
+			var decl declaration
+			err := dec.Decode(&decl)
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return nil, fmt.Errorf("error decoding %s: %v", name, err)
+			}
+			decls = append(decls, decl)
+		}
+	}
+	return newIndex(decls)
+}
+
+// LoadDir indexes the file-based catalog stored in the given directory, like Load indexes the files of the directory
+// and of its subdirectories.
+func LoadDir(dir string) (*Index, error) {
+	files := map[string][]byte{}
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		files[rel] = content
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return Load(files)
+}
+
+func newIndex(decls []declaration) (*Index, error) {
+	idx := &Index{packages: map[string]*catalogPackage{}}
+	for _, decl := range decls {
+		if decl.Schema != schemaPackage {
+			continue
+		}
+		if _, ok := idx.packages[decl.Name]; ok {
+			return nil, fmt.Errorf("package %q declared more than once", decl.Name)
+		}
+		idx.packages[decl.Name] = &catalogPackage{
+			name:           decl.Name,
+			defaultChannel: decl.DefaultChannel,
+			channels:       map[string]map[string]*api.Bundle{},
+		}
+	}
+
+	// bundles by package and name, with the channel entries declared in their properties
+	bundles := map[string]map[string]declaration{}
+	entries := map[string]map[string][]channelEntry{}
+	addEntry := func(pkg, channel string, entry channelEntry) {
+		if entries[pkg] == nil {
+			entries[pkg] = map[string][]channelEntry{}
+		}
+		entries[pkg][channel] = append(entries[pkg][channel], entry)
+	}
+	for _, decl := range decls {
+		switch decl.Schema {
+		case schemaBundle:
+			if _, ok := idx.packages[decl.Package]; !ok {
+				return nil, fmt.Errorf("bundle %q of undeclared package %q", decl.Name, decl.Package)
+			}
+			if bundles[decl.Package] == nil {
+				bundles[decl.Package] = map[string]declaration{}
+			}
+			if _, ok := bundles[decl.Package][decl.Name]; ok {
+				return nil, fmt.Errorf("bundle %q of package %q declared more than once", decl.Name, decl.Package)
+			}
+			bundles[decl.Package][decl.Name] = decl
+
+			var skips []string
+			var skipRange string
+			for _, p := range decl.Properties {
+				switch p.Type {
+				case propertySkips:
+					var skip string
+					if err := json.Unmarshal(p.Value, &skip); err != nil {
+						return nil, fmt.Errorf("invalid %s property of bundle %q: %v", p.Type, decl.Name, err)
+					}
+					skips = append(skips, skip)
+				case propertySkipRange:
+					if err := json.Unmarshal(p.Value, &skipRange); err != nil {
+						return nil, fmt.Errorf("invalid %s property of bundle %q: %v", p.Type, decl.Name, err)
+					}
+				}
+			}
+			for _, p := range decl.Properties {
+				if p.Type != propertyChannel {
+					continue
+				}
+				var ch struct {
+					Name     string `json:"name"`
+					Replaces string `json:"replaces,omitempty"`
+				}
+				if err := json.Unmarshal(p.Value, &ch); err != nil {
+					return nil, fmt.Errorf("invalid %s property of bundle %q: %v", p.Type, decl.Name, err)
+				}
+				addEntry(decl.Package, ch.Name, channelEntry{Name: decl.Name, Replaces: ch.Replaces, Skips: skips, SkipRange: skipRange})
+			}
+		case schemaChannel:
+			if _, ok := idx.packages[decl.Package]; !ok {
+				return nil, fmt.Errorf("channel %q of undeclared package %q", decl.Name, decl.Package)
+			}
+			for _, entry := range decl.Entries {
+				addEntry(decl.Package, decl.Name, entry)
+			}
+		}
+	}
+
+	deprecations, err := indexDeprecations(decls, idx.packages)
+	if err != nil {
+		return nil, err
+	}
+
+	for pkgName, channels := range entries {
+		pkg := idx.packages[pkgName]
+		for channel, channelEntries := range channels {
+			pkg.channels[channel] = map[string]*api.Bundle{}
+			for _, entry := range channelEntries {
+				decl, ok := bundles[pkgName][entry.Name]
+				if !ok {
+					return nil, fmt.Errorf("channel %q of package %q references undeclared bundle %q", channel, pkgName, entry.Name)
+				}
+				b, err := apiBundle(decl, channel, entry)
+				if err != nil {
+					return nil, err
+				}
+				if b.Properties, err = deprecations.addTo(b.Properties, pkgName, channel, entry.Name); err != nil {
+					return nil, err
+				}
+				pkg.channels[channel][entry.Name] = b
+			}
+		}
+	}
+	for _, pkg := range idx.packages {
+		if _, ok := pkg.channels[pkg.defaultChannel]; !ok && len(pkg.channels) > 0 {
+			return nil, fmt.Errorf("default channel %q of package %q not found", pkg.defaultChannel, pkg.name)
+		}
+	}
+
+	return idx, nil
+}
+
+// deprecations are the deprecations of the packages of a catalog, indexed by package and by the schema and name of the
+// deprecated object.
+type deprecations map[string]map[deprecationReference]string
+
+// indexDeprecations indexes the entries of the olm.deprecations documents of the given declarations.
+func indexDeprecations(decls []declaration, packages map[string]*catalogPackage) (deprecations, error) {
+	index := deprecations{}
+	for _, decl := range decls {
+		if decl.Schema != schemaDeprecations {
+			continue
+		}
+		if _, ok := packages[decl.Package]; !ok {
+			return nil, fmt.Errorf("deprecations of undeclared package %q", decl.Package)
+		}
+		if _, ok := index[decl.Package]; ok {
+			return nil, fmt.Errorf("deprecations of package %q declared more than once", decl.Package)
+		}
+		index[decl.Package] = map[deprecationReference]string{}
+		for _, entry := range decl.Entries {
+			if entry.Reference == nil {
+				return nil, fmt.Errorf("deprecation of package %q without a reference", decl.Package)
+			}
+			ref := *entry.Reference
+			switch ref.Schema {
+			case schemaPackage:
+				ref.Name = ""
+			case schemaChannel, schemaBundle:
+				if ref.Name == "" {
+					return nil, fmt.Errorf("deprecated %s of package %q has no name", ref.Schema, decl.Package)
+				}
+			default:
+				return nil, fmt.Errorf("deprecation of package %q references unknown schema %q", decl.Package, ref.Schema)
+			}
+			index[decl.Package][ref] = entry.Message
+		}
+	}
+	return index, nil
+}
+
+// addTo adds the deprecations applying to the given bundle of the given channel of a package to the given properties.
+func (d deprecations) addTo(properties []*api.Property, pkg, channel, bundle string) ([]*api.Property, error) {
+	for _, ref := range []deprecationReference{{Schema: schemaPackage}, {Schema: schemaChannel, Name: channel}, {Schema: schemaBundle, Name: bundle}} {
+		message, ok := d[pkg][ref]
+		if !ok {
+			continue
+		}
+		value, err := json.Marshal(olmregistry.Deprecation{Schema: ref.Schema, Name: ref.Name, Message: message})
+		if err != nil {
+			return nil, err
+		}
+		properties = append(properties, &api.Property{Type: olmregistry.DeprecationPropertyType, Value: string(value)})
+	}
+	return properties, nil
+}
+
+// apiBundle converts the given bundle declaration, in the given channel, to its registry gRPC API representation.
+func apiBundle(decl declaration, channel string, entry channelEntry) (*api.Bundle, error) {
+	b := &api.Bundle{
+		CsvName:     decl.Name,
+		PackageName: decl.Package,
+		ChannelName: channel,
+		BundlePath:  decl.Image,
+		Replaces:    entry.Replaces,
+		Skips:       entry.Skips,
+		SkipRange:   entry.SkipRange,
+	}
+	for _, p := range decl.Properties {
+		switch p.Type {
+		case propertyPackage:
+			var pkg struct {
+				Version string `json:"version"`
+			}
+			if err := json.Unmarshal(p.Value, &pkg); err != nil {
+				return nil, fmt.Errorf("invalid %s property of bundle %q: %v", p.Type, decl.Name, err)
+			}
+			b.Version = pkg.Version
+		case propertyGVK, propertyGVKRequired:
+			var g gvk
+			if err := json.Unmarshal(p.Value, &g); err != nil {
+				return nil, fmt.Errorf("invalid %s property of bundle %q: %v", p.Type, decl.Name, err)
+			}
+			apiGVK := &api.GroupVersionKind{Group: g.Group, Version: g.Version, Kind: g.Kind}
+			if p.Type == propertyGVK {
+				b.ProvidedApis = append(b.ProvidedApis, apiGVK)
+			} else {
+				b.RequiredApis = append(b.RequiredApis, apiGVK)
+				b.Dependencies = append(b.Dependencies, &api.Dependency{Type: propertyGVK, Value: string(p.Value)})
+			}
+		case propertyPackageRequired:
+			var req packageRequired
+			if err := json.Unmarshal(p.Value, &req); err != nil {
+				return nil, fmt.Errorf("invalid %s property of bundle %q: %v", p.Type, decl.Name, err)
+			}
+			value, err := json.Marshal(map[string]string{"packageName": req.PackageName, "version": req.VersionRange})
+			if err != nil {
+				return nil, err
+			}
+			b.Dependencies = append(b.Dependencies, &api.Dependency{Type: propertyPackage, Value: string(value)})
+		case propertyBundleObject:
+			// bundle objects are unpacked from the bundle image instead
+			continue
+		}
+		b.Properties = append(b.Properties, &api.Property{Type: p.Type, Value: string(p.Value)})
+	}
+	if b.Version == "" {
+		return nil, fmt.Errorf("bundle %q has no %s property", decl.Name, propertyPackage)
+	}
+	return b, nil
+}
+
+// head returns the bundle of the given channel that no other bundle of the channel replaces or skips.
+func head(channel map[string]*api.Bundle) (*api.Bundle, error) {
+	replaced := map[string]struct{}{}
+	for _, b := range channel {
+		if b.Replaces != "" {
+			replaced[b.Replaces] = struct{}{}
+		}
+		for _, skip := range b.Skips {
+			replaced[skip] = struct{}{}
+		}
+	}
+	var heads []*api.Bundle
+	for name, b := range channel {
+		if _, ok := replaced[name]; !ok {
+			heads = append(heads, b)
+		}
+	}
+	if len(heads) != 1 {
+		return nil, fmt.Errorf("expected exactly one channel head, found %d", len(heads))
+	}
+	return heads[0], nil
+}
+
+// replaces returns true if the given bundle replaces or skips the named bundle.
+func replaces(b *api.Bundle, name string) bool {
+	if b.Replaces == name {
+		return true
+	}
+	for _, skip := range b.Skips {
+		if skip == name {
+			return true
+		}
+	}
+	return false
+}
+
+// provides returns true if the given bundle provides the given api.
+func provides(b *api.Bundle, group, version, kind string) bool {
+	for _, g := range b.ProvidedApis {
+		if g.Group == group && g.Version == version && g.Kind == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// withoutGraph returns a copy of the given bundle without its upgrade edges, as the registry API doesn't return them
+// from single bundle queries.
+func withoutGraph(b *api.Bundle) *api.Bundle {
+	return &api.Bundle{
+		CsvName:      b.CsvName,
+		PackageName:  b.PackageName,
+		ChannelName:  b.ChannelName,
+		BundlePath:   b.BundlePath,
+		ProvidedApis: b.ProvidedApis,
+		RequiredApis: b.RequiredApis,
+		Version:      b.Version,
+		SkipRange:    b.SkipRange,
+		Dependencies: b.Dependencies,
+		Properties:   b.Properties,
+	}
+}
+
+// sortedNames returns the keys of the given map, sorted.
+func sortedNames(m interface{}) []string {
+	var names []string
+	switch m := m.(type) {
+	case map[string]*catalogPackage:
+		for name := range m {
+			names = append(names, name)
+		}
+	case map[string]map[string]*api.Bundle:
+		for name := range m {
+			names = append(names, name)
+		}
+	case map[string]*api.Bundle:
+		for name := range m {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (i *Index) channel(pkgName, channelName string) (map[string]*api.Bundle, error) {
+	pkg, ok := i.packages[pkgName]
+	if !ok {
+		return nil, fmt.Errorf("package %q not found", pkgName)
+	}
+	ch, ok := pkg.channels[channelName]
+	if !ok {
+		return nil, fmt.Errorf("package %q, channel %q not found", pkgName, channelName)
+	}
+	return ch, nil
+}
+
+// ListPackages returns the names of the packages of the catalog.
+func (i *Index) ListPackages(_ context.Context) ([]string, error) {
+	return sortedNames(i.packages), nil
+}
+
+// ListBundles returns the bundles of the catalog, once for each of their channels.
+func (i *Index) ListBundles(_ context.Context) ([]*api.Bundle, error) {
+	var bundles []*api.Bundle
+	for _, pkgName := range sortedNames(i.packages) {
+		pkg := i.packages[pkgName]
+		for _, channelName := range sortedNames(pkg.channels) {
+			for _, name := range sortedNames(pkg.channels[channelName]) {
+				bundles = append(bundles, pkg.channels[channelName][name])
+			}
+		}
+	}
+	return bundles, nil
+}
+
+// GetPackage returns the channels and the default channel of the named package.
+func (i *Index) GetPackage(_ context.Context, name string) (*registry.PackageManifest, error) {
+	pkg, ok := i.packages[name]
+	if !ok {
+		return nil, fmt.Errorf("package %q not found", name)
+	}
+	manifest := &registry.PackageManifest{
+		PackageName:        pkg.name,
+		DefaultChannelName: pkg.defaultChannel,
+	}
+	for _, channelName := range sortedNames(pkg.channels) {
+		h, err := head(pkg.channels[channelName])
+		if err != nil {
+			return nil, fmt.Errorf("package %q, channel %q has invalid head: %v", name, channelName, err)
+		}
+		manifest.Channels = append(manifest.Channels, registry.PackageChannel{Name: channelName, CurrentCSVName: h.CsvName})
+	}
+	return manifest, nil
+}
+
+// GetBundle returns the named bundle of the given package and channel.
+func (i *Index) GetBundle(_ context.Context, pkgName, channelName, csvName string) (*api.Bundle, error) {
+	ch, err := i.channel(pkgName, channelName)
+	if err != nil {
+		return nil, err
+	}
+	b, ok := ch[csvName]
+	if !ok {
+		return nil, fmt.Errorf("package %q, channel %q, bundle %q not found", pkgName, channelName, csvName)
+	}
+	return withoutGraph(b), nil
+}
+
+// GetBundleForChannel returns the head of the given package and channel.
+func (i *Index) GetBundleForChannel(_ context.Context, pkgName string, channelName string) (*api.Bundle, error) {
+	ch, err := i.channel(pkgName, channelName)
+	if err != nil {
+		return nil, err
+	}
+	h, err := head(ch)
+	if err != nil {
+		return nil, fmt.Errorf("package %q, channel %q has invalid head: %v", pkgName, channelName, err)
+	}
+	return withoutGraph(h), nil
+}
+
+// GetChannelEntriesThatReplace returns the channel entries of the bundles replacing or skipping the named bundle.
+func (i *Index) GetChannelEntriesThatReplace(_ context.Context, name string) ([]*registry.ChannelEntry, error) {
+	var entries []*registry.ChannelEntry
+	for _, pkgName := range sortedNames(i.packages) {
+		pkg := i.packages[pkgName]
+		for _, channelName := range sortedNames(pkg.channels) {
+			for _, bundleName := range sortedNames(pkg.channels[channelName]) {
+				if replaces(pkg.channels[channelName][bundleName], name) {
+					entries = append(entries, &registry.ChannelEntry{PackageName: pkgName, ChannelName: channelName, BundleName: bundleName, Replaces: name})
+				}
+			}
+		}
+	}
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("no channel entries found that replace %s", name)
+	}
+	return entries, nil
+}
+
+// GetBundleThatReplaces returns the bundle of the given package and channel replacing or skipping the named bundle.
+func (i *Index) GetBundleThatReplaces(_ context.Context, name, pkgName, channelName string) (*api.Bundle, error) {
+	ch, err := i.channel(pkgName, channelName)
+	if err != nil {
+		return nil, err
+	}
+	for _, bundleName := range sortedNames(ch) {
+		if replaces(ch[bundleName], name) {
+			return withoutGraph(ch[bundleName]), nil
+		}
+	}
+	return nil, fmt.Errorf("no entry found for package %q, channel %q", pkgName, channelName)
+}
+
+// GetChannelEntriesThatProvide returns the channel entries of the bundles providing the given api.
+func (i *Index) GetChannelEntriesThatProvide(_ context.Context, group, version, kind string) ([]*registry.ChannelEntry, error) {
+	var entries []*registry.ChannelEntry
+	for _, pkgName := range sortedNames(i.packages) {
+		pkg := i.packages[pkgName]
+		for _, channelName := range sortedNames(pkg.channels) {
+			for _, bundleName := range sortedNames(pkg.channels[channelName]) {
+				b := pkg.channels[channelName][bundleName]
+				if provides(b, group, version, kind) {
+					entries = append(entries, &registry.ChannelEntry{PackageName: pkgName, ChannelName: channelName, BundleName: bundleName, Replaces: b.Replaces})
+				}
+			}
+		}
+	}
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("no channel entries found that provide group:%q version:%q kind:%q", group, version, kind)
+	}
+	return entries, nil
+}
+
+// GetLatestChannelEntriesThatProvide returns the channel entries of the channel heads providing the given api.
+func (i *Index) GetLatestChannelEntriesThatProvide(_ context.Context, group, version, kind string) ([]*registry.ChannelEntry, error) {
+	var entries []*registry.ChannelEntry
+	for _, pkgName := range sortedNames(i.packages) {
+		pkg := i.packages[pkgName]
+		for _, channelName := range sortedNames(pkg.channels) {
+			h, err := head(pkg.channels[channelName])
+			if err != nil {
+				return nil, fmt.Errorf("package %q, channel %q has invalid head: %v", pkgName, channelName, err)
+			}
+			if provides(h, group, version, kind) {
+				entries = append(entries, &registry.ChannelEntry{PackageName: pkgName, ChannelName: channelName, BundleName: h.CsvName, Replaces: h.Replaces})
+			}
+		}
+	}
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("no channel entries found that provide group:%q version:%q kind:%q", group, version, kind)
+	}
+	return entries, nil
+}
+
+// GetBundleThatProvides returns the head of the default channel of the first package, by name, providing the given api.
+func (i *Index) GetBundleThatProvides(_ context.Context, group, version, kind string) (*api.Bundle, error) {
+	for _, pkgName := range sortedNames(i.packages) {
+		pkg := i.packages[pkgName]
+		ch, ok := pkg.channels[pkg.defaultChannel]
+		if !ok {
+			continue
+		}
+		h, err := head(ch)
+		if err != nil {
+			return nil, fmt.Errorf("package %q, channel %q has invalid head: %v", pkgName, pkg.defaultChannel, err)
+		}
+		if provides(h, group, version, kind) {
+			return withoutGraph(h), nil
+		}
+	}
+	return nil, fmt.Errorf("no entry found that provides group:%q version:%q kind:%q", group, version, kind)
+}
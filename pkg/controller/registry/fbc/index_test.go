@@ -0,0 +1,349 @@
+package fbc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/operator-framework/operator-registry/pkg/api"
+	"github.com/operator-framework/operator-registry/pkg/registry"
+	"github.com/stretchr/testify/require"
+)
+
+const testCatalog = `---
+schema: olm.package
+name: etcd
+defaultChannel: stable
+---
+schema: olm.channel
+package: etcd
+name: stable
+entries:
+- name: etcdoperator.v0.9.0
+- name: etcdoperator.v0.9.2
+  replaces: etcdoperator.v0.9.0
+- name: etcdoperator.v0.9.4
+  replaces: etcdoperator.v0.9.2
+  skips:
+  - etcdoperator.v0.9.3
+  skipRange: <0.9.4
+---
+schema: olm.channel
+package: etcd
+name: alpha
+entries:
+- name: etcdoperator.v0.9.2
+---
+schema: olm.bundle
+package: etcd
+name: etcdoperator.v0.9.0
+image: quay.io/etcd/bundle:v0.9.0
+properties:
+- type: olm.package
+  value:
+    packageName: etcd
+    version: 0.9.0
+- type: olm.gvk
+  value:
+    group: etcd.database.coreos.com
+    version: v1beta2
+    kind: EtcdCluster
+---
+schema: olm.bundle
+package: etcd
+name: etcdoperator.v0.9.2
+image: quay.io/etcd/bundle:v0.9.2
+properties:
+- type: olm.package
+  value:
+    packageName: etcd
+    version: 0.9.2
+- type: olm.gvk
+  value:
+    group: etcd.database.coreos.com
+    version: v1beta2
+    kind: EtcdCluster
+---
+schema: olm.bundle
+package: etcd
+name: etcdoperator.v0.9.4
+image: quay.io/etcd/bundle:v0.9.4
+properties:
+- type: olm.package
+  value:
+    packageName: etcd
+    version: 0.9.4
+- type: olm.gvk
+  value:
+    group: etcd.database.coreos.com
+    version: v1beta2
+    kind: EtcdCluster
+- type: olm.package.required
+  value:
+    packageName: prometheus
+    versionRange: '>=0.14.0'
+- type: olm.bundle.object
+  value:
+    data: e30=
+`
+
+const testLegacyCatalog = `{"schema": "olm.package", "name": "prometheus", "defaultChannel": "beta"}
+{"schema": "olm.bundle", "package": "prometheus", "name": "prometheusoperator.0.14.0", "image": "quay.io/prometheus/bundle:0.14.0", "properties": [{"type": "olm.package", "value": {"packageName": "prometheus", "version": "0.14.0"}}, {"type": "olm.channel", "value": {"name": "beta"}}]}
+{"schema": "olm.bundle", "package": "prometheus", "name": "prometheusoperator.0.15.0", "image": "quay.io/prometheus/bundle:0.15.0", "properties": [{"type": "olm.package", "value": {"packageName": "prometheus", "version": "0.15.0"}}, {"type": "olm.channel", "value": {"name": "beta", "replaces": "prometheusoperator.0.14.0"}}, {"type": "olm.skipRange", "value": "<0.15.0"}]}
+`
+
+func testIndex(t *testing.T) *Index {
+	idx, err := Load(map[string][]byte{
+		"etcd.yaml":       []byte(testCatalog),
+		"prometheus.json": []byte(testLegacyCatalog),
+		"README.md":       []byte("not a catalog file"),
+	})
+	require.NoError(t, err)
+	return idx
+}
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string][]byte
+		err   string
+	}{
+		{
+			name: "UndeclaredPackage",
+			files: map[string][]byte{"catalog.yaml": []byte(`
+schema: olm.channel
+package: etcd
+name: stable
+`)},
+			err: `channel "stable" of undeclared package "etcd"`,
+		},
+		{
+			name: "UndeclaredBundle",
+			files: map[string][]byte{"catalog.yaml": []byte(`
+schema: olm.package
+name: etcd
+defaultChannel: stable
+---
+schema: olm.channel
+package: etcd
+name: stable
+entries:
+- name: etcdoperator.v0.9.0
+`)},
+			err: `channel "stable" of package "etcd" references undeclared bundle "etcdoperator.v0.9.0"`,
+		},
+		{
+			name: "MissingDefaultChannel",
+			files: map[string][]byte{"catalog.yaml": []byte(`
+schema: olm.package
+name: etcd
+defaultChannel: beta
+---
+schema: olm.channel
+package: etcd
+name: stable
+entries:
+- name: etcdoperator.v0.9.0
+---
+schema: olm.bundle
+package: etcd
+name: etcdoperator.v0.9.0
+properties:
+- type: olm.package
+  value:
+    packageName: etcd
+    version: 0.9.0
+`)},
+			err: `default channel "beta" of package "etcd" not found`,
+		},
+		{
+			name: "MissingVersion",
+			files: map[string][]byte{"catalog.yaml": []byte(`
+schema: olm.package
+name: etcd
+defaultChannel: stable
+---
+schema: olm.channel
+package: etcd
+name: stable
+entries:
+- name: etcdoperator.v0.9.0
+---
+schema: olm.bundle
+package: etcd
+name: etcdoperator.v0.9.0
+`)},
+			err: `bundle "etcdoperator.v0.9.0" has no olm.package property`,
+		},
+		{
+			name: "UnknownDeprecationSchema",
+			files: map[string][]byte{"catalog.yaml": []byte(`
+schema: olm.package
+name: etcd
+defaultChannel: stable
+---
+schema: olm.channel
+package: etcd
+name: stable
+entries:
+- name: etcdoperator.v0.9.0
+---
+schema: olm.bundle
+package: etcd
+name: etcdoperator.v0.9.0
+properties:
+- type: olm.package
+  value:
+    packageName: etcd
+    version: 0.9.0
+---
+schema: olm.deprecations
+package: etcd
+entries:
+- reference:
+    schema: olm.csv
+    name: etcdoperator.v0.9.0
+  message: use the stable channel
+`)},
+			err: `deprecation of package "etcd" references unknown schema "olm.csv"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Load(tt.files)
+			require.EqualError(t, err, tt.err)
+		})
+	}
+}
+
+func TestIndexPackages(t *testing.T) {
+	ctx := context.Background()
+	idx := testIndex(t)
+
+	packages, err := idx.ListPackages(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []string{"etcd", "prometheus"}, packages)
+
+	pkg, err := idx.GetPackage(ctx, "etcd")
+	require.NoError(t, err)
+	require.Equal(t, &registry.PackageManifest{
+		PackageName:        "etcd",
+		DefaultChannelName: "stable",
+		Channels: []registry.PackageChannel{
+			{Name: "alpha", CurrentCSVName: "etcdoperator.v0.9.2"},
+			{Name: "stable", CurrentCSVName: "etcdoperator.v0.9.4"},
+		},
+	}, pkg)
+
+	pkg, err = idx.GetPackage(ctx, "prometheus")
+	require.NoError(t, err)
+	require.Equal(t, []registry.PackageChannel{{Name: "beta", CurrentCSVName: "prometheusoperator.0.15.0"}}, pkg.Channels)
+
+	_, err = idx.GetPackage(ctx, "missing")
+	require.EqualError(t, err, `package "missing" not found`)
+
+	bundles, err := idx.ListBundles(ctx)
+	require.NoError(t, err)
+	require.Len(t, bundles, 6)
+}
+
+func TestIndexBundles(t *testing.T) {
+	ctx := context.Background()
+	idx := testIndex(t)
+
+	head, err := idx.GetBundleForChannel(ctx, "etcd", "stable")
+	require.NoError(t, err)
+	require.Equal(t, "etcdoperator.v0.9.4", head.CsvName)
+	require.Equal(t, "0.9.4", head.Version)
+	require.Equal(t, "quay.io/etcd/bundle:v0.9.4", head.BundlePath)
+	require.Equal(t, "<0.9.4", head.SkipRange)
+	require.Empty(t, head.Replaces)
+	require.Empty(t, head.Skips)
+	require.Equal(t, []*api.GroupVersionKind{{Group: "etcd.database.coreos.com", Version: "v1beta2", Kind: "EtcdCluster"}}, head.ProvidedApis)
+	require.Len(t, head.Dependencies, 1)
+	require.Equal(t, "olm.package", head.Dependencies[0].Type)
+	require.JSONEq(t, `{"packageName":"prometheus","version":">=0.14.0"}`, head.Dependencies[0].Value)
+	for _, p := range head.Properties {
+		require.NotEqual(t, "olm.bundle.object", p.Type)
+	}
+
+	bundle, err := idx.GetBundle(ctx, "prometheus", "beta", "prometheusoperator.0.15.0")
+	require.NoError(t, err)
+	require.Equal(t, "<0.15.0", bundle.SkipRange)
+
+	_, err = idx.GetBundle(ctx, "etcd", "alpha", "etcdoperator.v0.9.4")
+	require.EqualError(t, err, `package "etcd", channel "alpha", bundle "etcdoperator.v0.9.4" not found`)
+
+	replacing, err := idx.GetBundleThatReplaces(ctx, "etcdoperator.v0.9.3", "etcd", "stable")
+	require.NoError(t, err)
+	require.Equal(t, "etcdoperator.v0.9.4", replacing.CsvName)
+
+	entries, err := idx.GetChannelEntriesThatReplace(ctx, "etcdoperator.v0.9.2")
+	require.NoError(t, err)
+	require.Equal(t, []*registry.ChannelEntry{
+		{PackageName: "etcd", ChannelName: "stable", BundleName: "etcdoperator.v0.9.4", Replaces: "etcdoperator.v0.9.2"},
+	}, entries)
+}
+
+func TestIndexProviders(t *testing.T) {
+	ctx := context.Background()
+	idx := testIndex(t)
+
+	entries, err := idx.GetChannelEntriesThatProvide(ctx, "etcd.database.coreos.com", "v1beta2", "EtcdCluster")
+	require.NoError(t, err)
+	require.Len(t, entries, 4)
+
+	latest, err := idx.GetLatestChannelEntriesThatProvide(ctx, "etcd.database.coreos.com", "v1beta2", "EtcdCluster")
+	require.NoError(t, err)
+	require.Equal(t, []*registry.ChannelEntry{
+		{PackageName: "etcd", ChannelName: "alpha", BundleName: "etcdoperator.v0.9.2"},
+		{PackageName: "etcd", ChannelName: "stable", BundleName: "etcdoperator.v0.9.4", Replaces: "etcdoperator.v0.9.2"},
+	}, latest)
+
+	bundle, err := idx.GetBundleThatProvides(ctx, "etcd.database.coreos.com", "v1beta2", "EtcdCluster")
+	require.NoError(t, err)
+	require.Equal(t, "etcdoperator.v0.9.4", bundle.CsvName)
+
+	_, err = idx.GetBundleThatProvides(ctx, "monitoring.coreos.com", "v1", "Prometheus")
+	require.Error(t, err)
+}
+
+func TestIndexDeprecations(t *testing.T) {
+	ctx := context.Background()
+	idx, err := Load(map[string][]byte{"catalog.yaml": []byte(testCatalog + `
+---
+schema: olm.deprecations
+package: etcd
+entries:
+- reference:
+    schema: olm.channel
+    name: alpha
+  message: alpha is no longer updated, use stable
+- reference:
+    schema: olm.bundle
+    name: etcdoperator.v0.9.0
+  message: etcdoperator.v0.9.0 has a known data loss bug
+`)})
+	require.NoError(t, err)
+
+	deprecations := func(b *api.Bundle) []string {
+		var values []string
+		for _, p := range b.Properties {
+			if p.Type == "olm.deprecations" {
+				values = append(values, p.Value)
+			}
+		}
+		return values
+	}
+
+	bundle, err := idx.GetBundle(ctx, "etcd", "alpha", "etcdoperator.v0.9.2")
+	require.NoError(t, err)
+	require.Equal(t, []string{`{"schema":"olm.channel","name":"alpha","message":"alpha is no longer updated, use stable"}`}, deprecations(bundle))
+
+	bundle, err = idx.GetBundle(ctx, "etcd", "stable", "etcdoperator.v0.9.2")
+	require.NoError(t, err)
+	require.Empty(t, deprecations(bundle))
+
+	bundle, err = idx.GetBundle(ctx, "etcd", "stable", "etcdoperator.v0.9.0")
+	require.NoError(t, err)
+	require.Equal(t, []string{`{"schema":"olm.bundle","name":"etcdoperator.v0.9.0","message":"etcdoperator.v0.9.0 has a known data loss bug"}`}, deprecations(bundle))
+}
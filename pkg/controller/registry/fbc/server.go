@@ -0,0 +1,87 @@
+package fbc
+
+import (
+	"net"
+	"sync"
+
+	"github.com/operator-framework/operator-registry/pkg/api"
+	health "github.com/operator-framework/operator-registry/pkg/api/grpc_health_v1"
+	"github.com/operator-framework/operator-registry/pkg/server"
+	"google.golang.org/grpc"
+
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/registry"
+)
+
+// served is a catalog served from memory.
+type served struct {
+	server   *grpc.Server
+	address  string
+	revision string
+}
+
+// Servers serves in-memory catalogs over the registry gRPC API, one loopback server per catalog, so that they're
+// queried like the catalogs of registry pods.
+type Servers struct {
+	mu       sync.RWMutex
+	catalogs map[registry.CatalogKey]*served
+}
+
+// NewServers returns a Servers serving no catalog.
+func NewServers() *Servers {
+	return &Servers{catalogs: map[registry.CatalogKey]*served{}}
+}
+
+// Serve serves the given index of the given revision of a catalog, replacing the index previously served for the
+// catalog, and returns the address it's served at.
+func (s *Servers) Serve(key registry.CatalogKey, revision string, index *Index) (string, error) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", err
+	}
+	srv := grpc.NewServer()
+	api.RegisterRegistryServer(srv, server.NewRegistryServer(index))
+	health.RegisterHealthServer(srv, server.NewHealthServer())
+	go srv.Serve(lis)
+
+	s.mu.Lock()
+	previous := s.catalogs[key]
+	s.catalogs[key] = &served{server: srv, address: lis.Addr().String(), revision: revision}
+	s.mu.Unlock()
+
+	if previous != nil {
+		previous.server.GracefulStop()
+	}
+	return lis.Addr().String(), nil
+}
+
+// Address returns the address the given catalog is served at, or an empty string if it isn't served.
+func (s *Servers) Address(key registry.CatalogKey) string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if c, ok := s.catalogs[key]; ok {
+		return c.address
+	}
+	return ""
+}
+
+// Revision returns the revision of the given catalog being served, or an empty string if it isn't served.
+func (s *Servers) Revision(key registry.CatalogKey) string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if c, ok := s.catalogs[key]; ok {
+		return c.revision
+	}
+	return ""
+}
+
+// Stop stops serving the given catalog.
+func (s *Servers) Stop(key registry.CatalogKey) {
+	s.mu.Lock()
+	c, ok := s.catalogs[key]
+	delete(s.catalogs, key)
+	s.mu.Unlock()
+
+	if ok {
+		c.server.Stop()
+	}
+}
@@ -0,0 +1,120 @@
+package grpc
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"strings"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"google.golang.org/grpc/credentials"
+	corev1 "k8s.io/api/core/v1"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+)
+
+// TokenSecretKey is the key of the bearer token in a secret authenticating to an external registry server.
+const TokenSecretKey = "token"
+
+// Credentials authenticate a connection to an external registry server.
+type Credentials struct {
+	// TLS secures the connection, presenting client certificates if any.
+	TLS *tls.Config
+	// Token is the bearer token sent with each request, if any.
+	Token string
+	// Revision identifies the secrets the credentials were built from, to reconnect when they change.
+	Revision string
+}
+
+// SourceCredentials returns the credentials authenticating to the external registry server of an addressable grpc
+// CatalogSource, from the secrets it references, or nil if it has none.
+func SourceCredentials(secrets corev1listers.SecretLister, source *v1alpha1.CatalogSource) (*Credentials, error) {
+	if source.Spec.SourceType != v1alpha1.SourceTypeGrpc || source.Spec.Image != "" || len(source.Spec.Secrets) == 0 {
+		return nil, nil
+	}
+
+	var referenced []*corev1.Secret
+	for _, name := range source.Spec.Secrets {
+		secret, err := secrets.Secrets(source.GetNamespace()).Get(name)
+		if err != nil {
+			return nil, err
+		}
+		referenced = append(referenced, secret)
+	}
+	return CredentialsFromSecrets(referenced)
+}
+
+// CredentialsFromSecrets builds the credentials of an external registry server from the given secrets, or returns
+// nil if none of them holds any:
+//
+// - the tls.crt and tls.key keys hold a client certificate, as in kubernetes.io/tls secrets
+// - the ca.crt key holds the certificates of the CAs the registry server is verified with, instead of the system's
+// - the token key holds a bearer token
+//
+// Other secrets, like image pull secrets, are ignored. Tokens are only sent over TLS.
+func CredentialsFromSecrets(secrets []*corev1.Secret) (*Credentials, error) {
+	var (
+		creds     Credentials
+		found     bool
+		revisions []string
+	)
+	for _, secret := range secrets {
+		revisions = append(revisions, secret.GetName()+"@"+secret.GetResourceVersion())
+		data := secret.Data
+
+		if crt, key := data[corev1.TLSCertKey], data[corev1.TLSPrivateKeyKey]; len(crt) > 0 || len(key) > 0 {
+			cert, err := tls.X509KeyPair(crt, key)
+			if err != nil {
+				return nil, fmt.Errorf("invalid client certificate in secret %s: %v", secret.GetName(), err)
+			}
+			if creds.TLS == nil {
+				creds.TLS = &tls.Config{}
+			}
+			creds.TLS.Certificates = append(creds.TLS.Certificates, cert)
+			found = true
+		}
+
+		if ca := data[corev1.ServiceAccountRootCAKey]; len(ca) > 0 {
+			if creds.TLS == nil {
+				creds.TLS = &tls.Config{}
+			}
+			if creds.TLS.RootCAs == nil {
+				creds.TLS.RootCAs = x509.NewCertPool()
+			}
+			if !creds.TLS.RootCAs.AppendCertsFromPEM(ca) {
+				return nil, fmt.Errorf("invalid CA certificates in secret %s", secret.GetName())
+			}
+			found = true
+		}
+
+		if token := strings.TrimSpace(string(data[TokenSecretKey])); token != "" {
+			if creds.Token != "" {
+				return nil, fmt.Errorf("secret %s holds a second bearer token", secret.GetName())
+			}
+			creds.Token = token
+			found = true
+		}
+	}
+	if !found {
+		return nil, nil
+	}
+
+	if creds.TLS == nil {
+		creds.TLS = &tls.Config{}
+	}
+	creds.Revision = strings.Join(revisions, ",")
+	return &creds, nil
+}
+
+// tokenCredentials sends a bearer token with each request.
+type tokenCredentials string
+
+var _ credentials.PerRPCCredentials = tokenCredentials("")
+
+func (t tokenCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + string(t)}, nil
+}
+
+func (t tokenCredentials) RequireTransportSecurity() bool {
+	return true
+}
@@ -0,0 +1,177 @@
+package grpc
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/certs"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/fakes"
+	"github.com/operator-framework/operator-registry/pkg/api"
+	opregistry "github.com/operator-framework/operator-registry/pkg/registry"
+	opserver "github.com/operator-framework/operator-registry/pkg/server"
+
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/registry"
+)
+
+func testKeyPair(t *testing.T, ca *certs.KeyPair) (certPEM, keyPEM []byte) {
+	kp, err := certs.CreateSignedServingPair(time.Now().Add(time.Hour), "olm", ca, []string{"localhost"})
+	require.NoError(t, err)
+	certPEM, keyPEM, err = kp.ToPEM()
+	require.NoError(t, err)
+	return
+}
+
+func testSecret(name string, data map[string][]byte) *corev1.Secret {
+	return &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: name, ResourceVersion: "1"}, Data: data}
+}
+
+func TestCredentialsFromSecrets(t *testing.T) {
+	ca, err := certs.GenerateCA(time.Now().Add(time.Hour), "olm")
+	require.NoError(t, err)
+	caPEM, _, err := ca.ToPEM()
+	require.NoError(t, err)
+	certPEM, keyPEM := testKeyPair(t, ca)
+
+	pullSecret := testSecret("pull", map[string][]byte{corev1.DockerConfigJsonKey: []byte("{}")})
+	creds, err := CredentialsFromSecrets([]*corev1.Secret{pullSecret})
+	require.NoError(t, err)
+	require.Nil(t, creds, "image pull secrets hold no credentials")
+
+	creds, err = CredentialsFromSecrets([]*corev1.Secret{
+		pullSecret,
+		testSecret("client", map[string][]byte{corev1.TLSCertKey: certPEM, corev1.TLSPrivateKeyKey: keyPEM, corev1.ServiceAccountRootCAKey: caPEM}),
+		testSecret("token", map[string][]byte{TokenSecretKey: []byte("s3cr3t\n")}),
+	})
+	require.NoError(t, err)
+	require.Len(t, creds.TLS.Certificates, 1)
+	require.NotNil(t, creds.TLS.RootCAs)
+	require.Equal(t, "s3cr3t", creds.Token)
+	require.Equal(t, "pull@1,client@1,token@1", creds.Revision)
+
+	creds, err = CredentialsFromSecrets([]*corev1.Secret{testSecret("token", map[string][]byte{TokenSecretKey: []byte("s3cr3t")})})
+	require.NoError(t, err)
+	require.NotNil(t, creds.TLS, "tokens are only sent over TLS")
+	require.Nil(t, creds.TLS.RootCAs)
+
+	_, err = CredentialsFromSecrets([]*corev1.Secret{testSecret("client", map[string][]byte{corev1.TLSCertKey: certPEM})})
+	require.Error(t, err)
+	_, err = CredentialsFromSecrets([]*corev1.Secret{testSecret("ca", map[string][]byte{corev1.ServiceAccountRootCAKey: []byte("not a cert")})})
+	require.EqualError(t, err, "invalid CA certificates in secret ca")
+	_, err = CredentialsFromSecrets([]*corev1.Secret{
+		testSecret("a", map[string][]byte{TokenSecretKey: []byte("a")}),
+		testSecret("b", map[string][]byte{TokenSecretKey: []byte("b")}),
+	})
+	require.EqualError(t, err, "secret b holds a second bearer token")
+}
+
+func TestSourceCredentials(t *testing.T) {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	token := testSecret("token", map[string][]byte{TokenSecretKey: []byte("s3cr3t")})
+	token.SetNamespace("ns")
+	require.NoError(t, indexer.Add(token))
+	secrets := corev1listers.NewSecretLister(indexer)
+
+	source := &v1alpha1.CatalogSource{
+		ObjectMeta: metav1.ObjectMeta{Name: "catalog", Namespace: "ns"},
+		Spec: v1alpha1.CatalogSourceSpec{
+			SourceType: v1alpha1.SourceTypeGrpc,
+			Address:    "registry.example.com:50051",
+			Secrets:    []string{"token"},
+		},
+	}
+	creds, err := SourceCredentials(secrets, source)
+	require.NoError(t, err)
+	require.Equal(t, "s3cr3t", creds.Token)
+
+	// Secrets of catalogs served from images are image pull secrets
+	image := source.DeepCopy()
+	image.Spec.Image = "quay.io/example/catalog:latest"
+	creds, err = SourceCredentials(secrets, image)
+	require.NoError(t, err)
+	require.Nil(t, creds)
+
+	missing := source.DeepCopy()
+	missing.Spec.Secrets = []string{"missing"}
+	_, err = SourceCredentials(secrets, missing)
+	require.Error(t, err)
+}
+
+func TestAddWithCredentials(t *testing.T) {
+	ca, err := certs.GenerateCA(time.Now().Add(time.Hour), "olm")
+	require.NoError(t, err)
+	caPEM, _, err := ca.ToPEM()
+	require.NoError(t, err)
+	pool := x509.NewCertPool()
+	pool.AddCert(ca.Cert)
+
+	serverCertPEM, serverKeyPEM := testKeyPair(t, ca)
+	serverCert, err := tls.X509KeyPair(serverCertPEM, serverKeyPEM)
+	require.NoError(t, err)
+
+	// The server requires a client certificate signed by the CA, and a bearer token
+	s := grpc.NewServer(
+		grpc.Creds(credentials.NewTLS(&tls.Config{Certificates: []tls.Certificate{serverCert}, ClientCAs: pool, ClientAuth: tls.RequireAndVerifyClientCert})),
+		grpc.UnaryInterceptor(func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			md, _ := metadata.FromIncomingContext(ctx)
+			if auth := md.Get("authorization"); len(auth) != 1 || auth[0] != "Bearer s3cr3t" {
+				return nil, status.Error(codes.Unauthenticated, "invalid token")
+			}
+			return handler(ctx, req)
+		}),
+	)
+	store := &fakes.FakeQuery{}
+	store.GetPackageReturns(&opregistry.PackageManifest{PackageName: "etcd"}, nil)
+	api.RegisterRegistryServer(s, opserver.NewRegistryServer(store))
+	lis, err := net.Listen("tcp", "localhost:")
+	require.NoError(t, err)
+	go s.Serve(lis)
+	defer s.Stop()
+	_, port, err := net.SplitHostPort(lis.Addr().String())
+	require.NoError(t, err)
+	address := net.JoinHostPort("localhost", port)
+
+	clientCertPEM, clientKeyPEM := testKeyPair(t, ca)
+	secrets := []*corev1.Secret{
+		testSecret("client", map[string][]byte{corev1.TLSCertKey: clientCertPEM, corev1.TLSPrivateKeyKey: clientKeyPEM, corev1.ServiceAccountRootCAKey: caPEM}),
+		testSecret("token", map[string][]byte{TokenSecretKey: []byte("s3cr3t")}),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	sources := NewSourceStore(logrus.New(), time.Second, time.Second, func(SourceState) {})
+	sources.Start(ctx)
+	key := registry.CatalogKey{Name: "external", Namespace: "olm"}
+
+	creds, err := CredentialsFromSecrets(secrets[:1])
+	require.NoError(t, err)
+	source, err := sources.AddWithCredentials(key, address, creds)
+	require.NoError(t, err)
+	_, err = api.NewRegistryClient(source.Conn).GetPackage(ctx, &api.GetPackageRequest{Name: "etcd"})
+	require.Equal(t, codes.Unauthenticated, status.Code(err), "missing token")
+
+	creds, err = CredentialsFromSecrets(secrets)
+	require.NoError(t, err)
+	source, err = sources.AddWithCredentials(key, address, creds)
+	require.NoError(t, err)
+	require.Equal(t, "client@1,token@1", source.CredentialsRevision)
+	_, err = api.NewRegistryClient(source.Conn).GetPackage(ctx, &api.GetPackageRequest{Name: "etcd"})
+	require.NoError(t, err)
+	require.NoError(t, sources.Remove(key))
+}
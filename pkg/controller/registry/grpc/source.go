@@ -15,6 +15,7 @@ import (
 	"golang.org/x/net/proxy"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/credentials"
 
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/registry"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -24,6 +25,8 @@ type SourceMeta struct {
 	Address         string
 	LastConnect     metav1.Time
 	ConnectionState connectivity.State
+	// CredentialsRevision is the revision of the credentials the source was dialed with, if any.
+	CredentialsRevision string
 }
 
 type SourceState struct {
@@ -146,8 +149,14 @@ func getEnvAny(names ...string) string {
 	return ""
 }
 
-func grpcConnection(address string) (*grpc.ClientConn, error) {
+func grpcConnection(address string, creds *Credentials) (*grpc.ClientConn, error) {
 	dialOptions := []grpc.DialOption{grpc.WithInsecure()}
+	if creds != nil {
+		dialOptions = []grpc.DialOption{grpc.WithTransportCredentials(credentials.NewTLS(creds.TLS))}
+		if creds.Token != "" {
+			dialOptions = append(dialOptions, grpc.WithPerRPCCredentials(tokenCredentials(creds.Token)))
+		}
+	}
 	proxyURL, err := grpcProxyURL(address)
 	if err != nil {
 		return nil, err
@@ -167,19 +176,30 @@ func grpcConnection(address string) (*grpc.ClientConn, error) {
 }
 
 func (s *SourceStore) Add(key registry.CatalogKey, address string) (*SourceConn, error) {
+	return s.AddWithCredentials(key, address, nil)
+}
+
+// AddWithCredentials connects to the registry server of the given address, authenticating with the given credentials
+// if not nil.
+func (s *SourceStore) AddWithCredentials(key registry.CatalogKey, address string, creds *Credentials) (*SourceConn, error) {
 	_ = s.Remove(key)
 
-	conn, err := grpcConnection(address)
+	conn, err := grpcConnection(address, creds)
 	if err != nil {
 		return nil, err
 	}
+	var revision string
+	if creds != nil {
+		revision = creds.Revision
+	}
 
 	ctx, cancel := context.WithCancel(context.Background())
 	source := SourceConn{
 		SourceMeta: SourceMeta{
-			Address:         address,
-			LastConnect:     metav1.Now(),
-			ConnectionState: connectivity.Idle,
+			Address:             address,
+			LastConnect:         metav1.Now(),
+			ConnectionState:     connectivity.Idle,
+			CredentialsRevision: revision,
 		},
 		Conn:   conn,
 		cancel: cancel,
@@ -236,6 +256,17 @@ func (s *SourceStore) watch(ctx context.Context, key registry.CatalogKey, source
 	}
 }
 
+// States returns the connection states of the sources in the store.
+func (s *SourceStore) States() []SourceState {
+	s.sourcesLock.RLock()
+	defer s.sourcesLock.RUnlock()
+	states := make([]SourceState, 0, len(s.sources))
+	for key, source := range s.sources {
+		states = append(states, SourceState{Key: key, State: source.ConnectionState})
+	}
+	return states
+}
+
 func (s *SourceStore) Remove(key registry.CatalogKey) error {
 	s.sourcesLock.RLock()
 	source, ok := s.sources[key]
@@ -0,0 +1,110 @@
+package reconciler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/operatorlister"
+)
+
+const (
+	// SourceTypeAggregate is the sourceType of a CatalogSource serving the merged content of a list of member catalogs.
+	SourceTypeAggregate v1alpha1.SourceType = "aggregate"
+
+	// AggregateMembersAnnotationKey is the annotation listing the members of an aggregate CatalogSource, as a JSON list
+	// of {"name": ..., "namespace": ...} objects in decreasing order of precedence. The namespace defaults to the
+	// namespace of the aggregate.
+	AggregateMembersAnnotationKey = "operatorframework.io/aggregate-members"
+)
+
+// AggregateMember references a member catalog of an aggregate CatalogSource.
+type AggregateMember struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// AggregateMembers returns the members of the given aggregate CatalogSource in decreasing order of precedence.
+func AggregateMembers(source *v1alpha1.CatalogSource) ([]AggregateMember, error) {
+	value, ok := source.GetAnnotations()[AggregateMembersAnnotationKey]
+	if !ok {
+		return nil, fmt.Errorf("%s annotation unset: must be set for sourcetype: %s", AggregateMembersAnnotationKey, SourceTypeAggregate)
+	}
+
+	var members []AggregateMember
+	if err := json.Unmarshal([]byte(value), &members); err != nil {
+		return nil, fmt.Errorf("invalid %s annotation: %v", AggregateMembersAnnotationKey, err)
+	}
+	if len(members) == 0 {
+		return nil, fmt.Errorf("invalid %s annotation: at least one member must be listed", AggregateMembersAnnotationKey)
+	}
+
+	seen := make(map[AggregateMember]struct{}, len(members))
+	for i, member := range members {
+		if member.Name == "" {
+			return nil, fmt.Errorf("invalid %s annotation: member %d has no name", AggregateMembersAnnotationKey, i)
+		}
+		if member.Namespace == "" {
+			member.Namespace = source.GetNamespace()
+			members[i] = member
+		}
+		if member.Name == source.GetName() && member.Namespace == source.GetNamespace() {
+			return nil, fmt.Errorf("invalid %s annotation: an aggregate can't be a member of itself", AggregateMembersAnnotationKey)
+		}
+		if _, ok := seen[member]; ok {
+			return nil, fmt.Errorf("invalid %s annotation: member %s/%s listed more than once", AggregateMembersAnnotationKey, member.Namespace, member.Name)
+		}
+		seen[member] = struct{}{}
+	}
+
+	return members, nil
+}
+
+// AggregateRegistryReconciler reconciles aggregate CatalogSources. Aggregates have no registry server of their own: they
+// are healthy when all of their members are.
+type AggregateRegistryReconciler struct {
+	now     nowFunc
+	Lister  operatorlister.OperatorLister
+	factory RegistryReconcilerFactory
+}
+
+var _ RegistryReconciler = &AggregateRegistryReconciler{}
+
+// EnsureRegistryServer ensures a registry server exists for the given CatalogSource.
+func (a *AggregateRegistryReconciler) EnsureRegistryServer(ctx context.Context, catalogSource *v1alpha1.CatalogSource) error {
+	catalogSource.Status.RegistryServiceStatus = &v1alpha1.RegistryServiceStatus{
+		CreatedAt: a.now(),
+		Protocol:  string(SourceTypeAggregate),
+	}
+
+	return nil
+}
+
+// CheckRegistryServer returns true if the given CatalogSource is considered healthy; false otherwise.
+func (a *AggregateRegistryReconciler) CheckRegistryServer(ctx context.Context, catalogSource *v1alpha1.CatalogSource) (healthy bool, err error) {
+	members, err := AggregateMembers(catalogSource)
+	if err != nil {
+		return false, err
+	}
+
+	for _, member := range members {
+		source, err := a.Lister.OperatorsV1alpha1().CatalogSourceLister().CatalogSources(member.Namespace).Get(member.Name)
+		if err != nil {
+			return false, fmt.Errorf("couldn't get member catalog %s/%s: %v", member.Namespace, member.Name, err)
+		}
+		if source.Spec.SourceType == SourceTypeAggregate {
+			return false, fmt.Errorf("member catalog %s/%s is itself an aggregate", member.Namespace, member.Name)
+		}
+
+		rec := a.factory.ReconcilerForSource(source)
+		if rec == nil {
+			return false, fmt.Errorf("no reconciler for member catalog %s/%s", member.Namespace, member.Name)
+		}
+		if healthy, err := rec.CheckRegistryServer(ctx, source); err != nil || !healthy {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
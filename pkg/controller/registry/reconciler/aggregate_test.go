@@ -0,0 +1,67 @@
+package reconciler
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+)
+
+func TestAggregateMembers(t *testing.T) {
+	tests := []struct {
+		description string
+		annotations map[string]string
+		want        []AggregateMember
+		wantErr     bool
+	}{
+		{
+			description: "Unset",
+			wantErr:     true,
+		},
+		{
+			description: "Empty",
+			annotations: map[string]string{AggregateMembersAnnotationKey: `[]`},
+			wantErr:     true,
+		},
+		{
+			description: "DefaultsNamespace",
+			annotations: map[string]string{AggregateMembersAnnotationKey: `[{"name":"internal"},{"name":"community","namespace":"olm"}]`},
+			want: []AggregateMember{
+				{Name: "internal", Namespace: "ns"},
+				{Name: "community", Namespace: "olm"},
+			},
+		},
+		{
+			description: "Self",
+			annotations: map[string]string{AggregateMembersAnnotationKey: `[{"name":"aggregate"}]`},
+			wantErr:     true,
+		},
+		{
+			description: "Duplicate",
+			annotations: map[string]string{AggregateMembersAnnotationKey: `[{"name":"internal"},{"name":"internal","namespace":"ns"}]`},
+			wantErr:     true,
+		},
+		{
+			description: "Malformed",
+			annotations: map[string]string{AggregateMembersAnnotationKey: `internal`},
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			source := &v1alpha1.CatalogSource{
+				ObjectMeta: metav1.ObjectMeta{Name: "aggregate", Namespace: "ns", Annotations: tt.annotations},
+				Spec:       v1alpha1.CatalogSourceSpec{SourceType: SourceTypeAggregate},
+			}
+			got, err := AggregateMembers(source)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
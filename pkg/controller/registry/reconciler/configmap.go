@@ -15,6 +15,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/intstr"
 
 	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/imagearch"
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/operatorclient"
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/operatorlister"
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/ownerutil"
@@ -23,6 +24,9 @@ import (
 // configMapCatalogSourceDecorator wraps CatalogSource to add additional methods
 type configMapCatalogSourceDecorator struct {
 	*v1alpha1.CatalogSource
+
+	// fileBased is true if the ConfigMap of the CatalogSource holds a file-based catalog.
+	fileBased bool
 }
 
 const (
@@ -61,7 +65,7 @@ func (s *configMapCatalogSourceDecorator) Labels() map[string]string {
 
 func (s *configMapCatalogSourceDecorator) Annotations() map[string]string {
 	// TODO: Maybe something better than just a copy of all annotations would be to have a specific 'podMetadata' section in the CatalogSource?
-	return s.GetAnnotations()
+	return podAnnotations(s.CatalogSource)
 }
 
 func (s *configMapCatalogSourceDecorator) ConfigMapChanges(configMap *v1.ConfigMap) bool {
@@ -103,7 +107,24 @@ func (s *configMapCatalogSourceDecorator) Service() *v1.Service {
 func (s *configMapCatalogSourceDecorator) Pod(image string) *v1.Pod {
 	pod := Pod(s.CatalogSource, "configmap-registry-server", image, "", s.Labels(), s.Annotations(), 5, 5)
 	pod.Spec.ServiceAccountName = s.GetName() + ConfigMapServerPostfix
-	pod.Spec.Containers[0].Command = []string{"configmap-server", "-c", s.Spec.ConfigMap, "-n", s.GetNamespace()}
+	pod.Spec.Containers[0].Command = []string{"configmap-server", "-c", CatalogConfigMapName(s.CatalogSource), "-n", s.GetNamespace()}
+	if s.fileBased {
+		// file-based catalogs are served from the mounted configmap, with each key a file of the catalog
+		pod.Spec.Volumes = append(pod.Spec.Volumes, v1.Volume{
+			Name: "catalog",
+			VolumeSource: v1.VolumeSource{
+				ConfigMap: &v1.ConfigMapVolumeSource{
+					LocalObjectReference: v1.LocalObjectReference{Name: CatalogConfigMapName(s.CatalogSource)},
+				},
+			},
+		})
+		pod.Spec.Containers[0].VolumeMounts = append(pod.Spec.Containers[0].VolumeMounts, v1.VolumeMount{
+			Name:      "catalog",
+			MountPath: fileBasedCatalogDir,
+			ReadOnly:  true,
+		})
+		pod.Spec.Containers[0].Command = []string{"opm", "serve", fileBasedCatalogDir}
+	}
 	ownerutil.AddOwner(pod, s.CatalogSource, false, false)
 	return pod
 }
@@ -130,7 +151,7 @@ func (s *configMapCatalogSourceDecorator) Role() *rbacv1.Role {
 				Verbs:         []string{"get"},
 				APIGroups:     []string{""},
 				Resources:     []string{"configmaps"},
-				ResourceNames: []string{s.Spec.ConfigMap},
+				ResourceNames: []string{CatalogConfigMapName(s.CatalogSource)},
 			},
 		},
 	}
@@ -166,6 +187,8 @@ type ConfigMapRegistryReconciler struct {
 	Lister   operatorlister.OperatorLister
 	OpClient operatorclient.ClientInterface
 	Image    string
+	// Architectures schedules registry pods onto the architectures their image supports.
+	Architectures imagearch.RequiredFunc
 }
 
 var _ RegistryEnsurer = &ConfigMapRegistryReconciler{}
@@ -239,8 +262,8 @@ func (c *ConfigMapRegistryReconciler) currentPodsWithCorrectResourceVersion(sour
 }
 
 // EnsureRegistryServer ensures that all components of registry server are up to date.
-func (c *ConfigMapRegistryReconciler) EnsureRegistryServer(catalogSource *v1alpha1.CatalogSource) error {
-	source := configMapCatalogSourceDecorator{catalogSource}
+func (c *ConfigMapRegistryReconciler) EnsureRegistryServer(ctx context.Context, catalogSource *v1alpha1.CatalogSource) error {
+	source := configMapCatalogSourceDecorator{CatalogSource: catalogSource}
 
 	image := c.Image
 	if source.Spec.SourceType == "grpc" {
@@ -249,6 +272,10 @@ func (c *ConfigMapRegistryReconciler) EnsureRegistryServer(catalogSource *v1alph
 	if image == "" {
 		return fmt.Errorf("no image for registry")
 	}
+	podConfig, err := registryPodConfigFor(catalogSource)
+	if err != nil {
+		return err
+	}
 
 	// if service status is nil, we force create every object to ensure they're created the first time
 	overwrite := source.Status.RegistryServiceStatus == nil
@@ -256,10 +283,11 @@ func (c *ConfigMapRegistryReconciler) EnsureRegistryServer(catalogSource *v1alph
 
 	if source.Spec.SourceType == v1alpha1.SourceTypeConfigmap || source.Spec.SourceType == v1alpha1.SourceTypeInternal {
 		// fetch configmap first, exit early if we can't find it
-		configMap, err := c.Lister.CoreV1().ConfigMapLister().ConfigMaps(source.GetNamespace()).Get(source.Spec.ConfigMap)
+		configMap, err := c.Lister.CoreV1().ConfigMapLister().ConfigMaps(source.GetNamespace()).Get(CatalogConfigMapName(catalogSource))
 		if err != nil {
-			return fmt.Errorf("unable to get configmap %s/%s from cache", source.GetNamespace(), source.Spec.ConfigMap)
+			return fmt.Errorf("unable to get configmap %s/%s from cache", source.GetNamespace(), CatalogConfigMapName(catalogSource))
 		}
+		source.fileBased = IsFileBasedCatalog(configMap)
 
 		if source.ConfigMapChanges(configMap) {
 			catalogSource.Status.ConfigMapResource = &v1alpha1.ConfigMapResourceReference{
@@ -281,21 +309,24 @@ func (c *ConfigMapRegistryReconciler) EnsureRegistryServer(catalogSource *v1alph
 	}
 
 	//TODO: if any of these error out, we should write a status back (possibly set RegistryServiceStatus to nil so they get recreated)
-	if err := c.ensureServiceAccount(source, overwrite); err != nil {
+	if err := c.ensureServiceAccount(ctx, source, overwrite); err != nil {
 		return errors.Wrapf(err, "error ensuring service account: %s", source.serviceAccountName())
 	}
-	if err := c.ensureRole(source, overwrite); err != nil {
+	if err := c.ensureRole(ctx, source, overwrite); err != nil {
 		return errors.Wrapf(err, "error ensuring role: %s", source.roleName())
 	}
-	if err := c.ensureRoleBinding(source, overwrite); err != nil {
+	if err := c.ensureRoleBinding(ctx, source, overwrite); err != nil {
 		return errors.Wrapf(err, "error ensuring rolebinding: %s", source.RoleBinding().GetName())
 	}
-	if err := c.ensurePod(source, overwritePod); err != nil {
+	if err := c.ensurePod(ctx, source, overwritePod); err != nil {
 		return errors.Wrapf(err, "error ensuring pod: %s", source.Pod(image).GetName())
 	}
-	if err := c.ensureService(source, overwrite); err != nil {
+	if err := c.ensureService(ctx, source, overwrite); err != nil {
 		return errors.Wrapf(err, "error ensuring service: %s", source.Service().GetName())
 	}
+	if err := ensurePodDisruptionBudget(ctx, c.OpClient.KubernetesInterface(), catalogSource, podConfig); err != nil {
+		return errors.Wrapf(err, "error ensuring pod disruption budget: %s", source.GetName())
+	}
 
 	if overwritePod {
 		now := c.now()
@@ -310,49 +341,49 @@ func (c *ConfigMapRegistryReconciler) EnsureRegistryServer(catalogSource *v1alph
 	return nil
 }
 
-func (c *ConfigMapRegistryReconciler) ensureServiceAccount(source configMapCatalogSourceDecorator, overwrite bool) error {
+func (c *ConfigMapRegistryReconciler) ensureServiceAccount(ctx context.Context, source configMapCatalogSourceDecorator, overwrite bool) error {
 	serviceAccount := source.ServiceAccount()
 	if c.currentServiceAccount(source) != nil {
 		if !overwrite {
 			return nil
 		}
-		if err := c.OpClient.DeleteServiceAccount(serviceAccount.GetNamespace(), serviceAccount.GetName(), metav1.NewDeleteOptions(0)); err != nil && !k8serrors.IsNotFound(err) {
+		if err := c.OpClient.DeleteServiceAccount(ctx, serviceAccount.GetNamespace(), serviceAccount.GetName(), metav1.NewDeleteOptions(0)); err != nil && !k8serrors.IsNotFound(err) {
 			return err
 		}
 	}
-	_, err := c.OpClient.CreateServiceAccount(serviceAccount)
+	_, err := c.OpClient.CreateServiceAccount(ctx, serviceAccount)
 	return err
 }
 
-func (c *ConfigMapRegistryReconciler) ensureRole(source configMapCatalogSourceDecorator, overwrite bool) error {
+func (c *ConfigMapRegistryReconciler) ensureRole(ctx context.Context, source configMapCatalogSourceDecorator, overwrite bool) error {
 	role := source.Role()
 	if c.currentRole(source) != nil {
 		if !overwrite {
 			return nil
 		}
-		if err := c.OpClient.DeleteRole(role.GetNamespace(), role.GetName(), metav1.NewDeleteOptions(0)); err != nil && !k8serrors.IsNotFound(err) {
+		if err := c.OpClient.DeleteRole(ctx, role.GetNamespace(), role.GetName(), metav1.NewDeleteOptions(0)); err != nil && !k8serrors.IsNotFound(err) {
 			return err
 		}
 	}
-	_, err := c.OpClient.CreateRole(role)
+	_, err := c.OpClient.CreateRole(ctx, role)
 	return err
 }
 
-func (c *ConfigMapRegistryReconciler) ensureRoleBinding(source configMapCatalogSourceDecorator, overwrite bool) error {
+func (c *ConfigMapRegistryReconciler) ensureRoleBinding(ctx context.Context, source configMapCatalogSourceDecorator, overwrite bool) error {
 	roleBinding := source.RoleBinding()
 	if c.currentRoleBinding(source) != nil {
 		if !overwrite {
 			return nil
 		}
-		if err := c.OpClient.DeleteRoleBinding(roleBinding.GetNamespace(), roleBinding.GetName(), metav1.NewDeleteOptions(0)); err != nil && !k8serrors.IsNotFound(err) {
+		if err := c.OpClient.DeleteRoleBinding(ctx, roleBinding.GetNamespace(), roleBinding.GetName(), metav1.NewDeleteOptions(0)); err != nil && !k8serrors.IsNotFound(err) {
 			return err
 		}
 	}
-	_, err := c.OpClient.CreateRoleBinding(roleBinding)
+	_, err := c.OpClient.CreateRoleBinding(ctx, roleBinding)
 	return err
 }
 
-func (c *ConfigMapRegistryReconciler) ensurePod(source configMapCatalogSourceDecorator, overwrite bool) error {
+func (c *ConfigMapRegistryReconciler) ensurePod(ctx context.Context, source configMapCatalogSourceDecorator, overwrite bool) error {
 	pod := source.Pod(c.Image)
 	currentPods := c.currentPods(source, c.Image)
 	if len(currentPods) > 0 {
@@ -360,36 +391,37 @@ func (c *ConfigMapRegistryReconciler) ensurePod(source configMapCatalogSourceDec
 			return nil
 		}
 		for _, p := range currentPods {
-			if err := c.OpClient.KubernetesInterface().CoreV1().Pods(pod.GetNamespace()).Delete(context.TODO(), p.GetName(), *metav1.NewDeleteOptions(1)); err != nil && !k8serrors.IsNotFound(err) {
+			if err := c.OpClient.KubernetesInterface().CoreV1().Pods(pod.GetNamespace()).Delete(ctx, p.GetName(), *metav1.NewDeleteOptions(1)); err != nil && !k8serrors.IsNotFound(err) {
 				return errors.Wrapf(err, "error deleting old pod: %s", p.GetName())
 			}
 		}
 	}
-	_, err := c.OpClient.KubernetesInterface().CoreV1().Pods(pod.GetNamespace()).Create(context.TODO(), pod, metav1.CreateOptions{})
+	c.Architectures.Apply(ctx, &pod.Spec, c.Image)
+	_, err := c.OpClient.KubernetesInterface().CoreV1().Pods(pod.GetNamespace()).Create(ctx, pod, metav1.CreateOptions{})
 	if err == nil {
 		return nil
 	}
 	return errors.Wrapf(err, "error creating new pod: %s", pod.GetGenerateName())
 }
 
-func (c *ConfigMapRegistryReconciler) ensureService(source configMapCatalogSourceDecorator, overwrite bool) error {
+func (c *ConfigMapRegistryReconciler) ensureService(ctx context.Context, source configMapCatalogSourceDecorator, overwrite bool) error {
 	service := source.Service()
 	svc := c.currentService(source)
 	if svc != nil {
 		if !overwrite && ServiceHashMatch(svc, service) {
 			return nil
 		}
-		if err := c.OpClient.DeleteService(service.GetNamespace(), service.GetName(), metav1.NewDeleteOptions(0)); err != nil && !k8serrors.IsNotFound(err) {
+		if err := c.OpClient.DeleteService(ctx, service.GetNamespace(), service.GetName(), metav1.NewDeleteOptions(0)); err != nil && !k8serrors.IsNotFound(err) {
 			return err
 		}
 	}
-	_, err := c.OpClient.CreateService(service)
+	_, err := c.OpClient.CreateService(ctx, service)
 	return err
 }
 
 // CheckRegistryServer returns true if the given CatalogSource is considered healthy; false otherwise.
-func (c *ConfigMapRegistryReconciler) CheckRegistryServer(catalogSource *v1alpha1.CatalogSource) (healthy bool, err error) {
-	source := configMapCatalogSourceDecorator{catalogSource}
+func (c *ConfigMapRegistryReconciler) CheckRegistryServer(ctx context.Context, catalogSource *v1alpha1.CatalogSource) (healthy bool, err error) {
+	source := configMapCatalogSourceDecorator{CatalogSource: catalogSource}
 
 	image := c.Image
 	if source.Spec.SourceType == "grpc" {
@@ -401,9 +433,9 @@ func (c *ConfigMapRegistryReconciler) CheckRegistryServer(catalogSource *v1alpha
 	}
 
 	if source.Spec.SourceType == v1alpha1.SourceTypeConfigmap || source.Spec.SourceType == v1alpha1.SourceTypeInternal {
-		configMap, err := c.Lister.CoreV1().ConfigMapLister().ConfigMaps(source.GetNamespace()).Get(source.Spec.ConfigMap)
+		configMap, err := c.Lister.CoreV1().ConfigMapLister().ConfigMaps(source.GetNamespace()).Get(CatalogConfigMapName(catalogSource))
 		if err != nil {
-			return false, fmt.Errorf("unable to get configmap %s/%s from cache", source.GetNamespace(), source.Spec.ConfigMap)
+			return false, fmt.Errorf("unable to get configmap %s/%s from cache", source.GetNamespace(), CatalogConfigMapName(catalogSource))
 		}
 
 		if source.ConfigMapChanges(configMap) {
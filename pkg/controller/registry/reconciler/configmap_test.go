@@ -23,7 +23,10 @@ import (
 
 	"github.com/operator-framework/api/pkg/operators/v1alpha1"
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/registry"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/registry/fbc"
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/clientfake"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/imagearch"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/imagemirror"
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/operatorclient"
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/operatorlister"
 )
@@ -38,6 +41,9 @@ type fakeReconcilerConfig struct {
 	k8sObjs              []runtime.Object
 	k8sClientOptions     []clientfake.Option
 	configMapServerImage string
+	architectures        imagearch.RequiredFunc
+	resolveDigest        ResolveDigestFunc
+	mirrorImage          imagemirror.RewriteFunc
 }
 
 type fakeReconcilerOption func(*fakeReconcilerConfig)
@@ -60,6 +66,24 @@ func withK8sClientOptions(options ...clientfake.Option) fakeReconcilerOption {
 	}
 }
 
+func withArchitectures(architectures imagearch.RequiredFunc) fakeReconcilerOption {
+	return func(config *fakeReconcilerConfig) {
+		config.architectures = architectures
+	}
+}
+
+func withResolveDigest(resolveDigest ResolveDigestFunc) fakeReconcilerOption {
+	return func(config *fakeReconcilerConfig) {
+		config.resolveDigest = resolveDigest
+	}
+}
+
+func withMirrorImage(mirrorImage imagemirror.RewriteFunc) fakeReconcilerOption {
+	return func(config *fakeReconcilerConfig) {
+		config.mirrorImage = mirrorImage
+	}
+}
+
 func fakeReconcilerFactory(t *testing.T, stopc <-chan struct{}, options ...fakeReconcilerOption) (RegistryReconcilerFactory, operatorclient.ClientInterface) {
 	config := &fakeReconcilerConfig{
 		now:                  metav1.Now,
@@ -104,6 +128,12 @@ func fakeReconcilerFactory(t *testing.T, stopc <-chan struct{}, options ...fakeR
 		OpClient:             opClientFake,
 		Lister:               lister,
 		ConfigMapServerImage: config.configMapServerImage,
+		OPMImage:             "test:opm",
+		UtilImage:            "test:util",
+		CatalogServers:       fbc.NewServers(),
+		Architectures:        config.architectures,
+		ResolveDigest:        config.resolveDigest,
+		MirrorImage:          config.mirrorImage,
 	}
 
 	var hasSyncedCheckFns []cache.InformerSynced
@@ -186,7 +216,7 @@ func objectsForCatalogSource(catsrc *v1alpha1.CatalogSource) []runtime.Object {
 	var objs []runtime.Object
 	switch catsrc.Spec.SourceType {
 	case v1alpha1.SourceTypeInternal, v1alpha1.SourceTypeConfigmap:
-		decorated := configMapCatalogSourceDecorator{catsrc}
+		decorated := configMapCatalogSourceDecorator{CatalogSource: catsrc}
 		objs = clientfake.AddSimpleGeneratedNames(
 			clientfake.AddSimpleGeneratedName(decorated.Pod(registryImageName)),
 			decorated.Service(),
@@ -441,7 +471,7 @@ func TestConfigMapRegistryReconciler(t *testing.T) {
 			factory, client := fakeReconcilerFactory(t, stopc, withNow(now), withK8sObjs(tt.in.cluster.k8sObjs...), withK8sClientOptions(clientfake.WithNameGeneration(t)))
 			rec := factory.ReconcilerForSource(tt.in.catsrc)
 
-			err := rec.EnsureRegistryServer(tt.in.catsrc)
+			err := rec.EnsureRegistryServer(context.TODO(), tt.in.catsrc)
 
 			require.Equal(t, tt.out.err, err)
 			require.Equal(t, tt.out.status, tt.in.catsrc.Status.RegistryServiceStatus)
@@ -451,7 +481,7 @@ func TestConfigMapRegistryReconciler(t *testing.T) {
 			}
 
 			// if no error, the reconciler should create the same set of kube objects every time
-			decorated := configMapCatalogSourceDecorator{tt.in.catsrc}
+			decorated := configMapCatalogSourceDecorator{CatalogSource: tt.in.catsrc}
 
 			pod := decorated.Pod(registryImageName)
 			listOptions := metav1.ListOptions{LabelSelector: labels.SelectorFromSet(labels.Set{CatalogSourceLabelKey: tt.in.catsrc.GetName()}).String()}
@@ -0,0 +1,83 @@
+package reconciler
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/registry"
+)
+
+const (
+	// InlineCatalogAnnotationKey is the annotation of a configmap CatalogSource holding a file-based catalog inline, as
+	// a stream of JSON or YAML documents. The catalog operator writes it to a ConfigMap owned by the CatalogSource,
+	// which is then served like any other file-based catalog ConfigMap; spec.configMap may be left unset.
+	InlineCatalogAnnotationKey = "operatorframework.io/inline-catalog"
+
+	// InlineCatalogConfigMapPostfix is a postfix appended to the name of a CatalogSource to name the ConfigMap holding
+	// its inline catalog.
+	InlineCatalogConfigMapPostfix = "-inline-catalog"
+
+	// InlineCatalogKey is the key of the ConfigMap data holding an inline catalog.
+	InlineCatalogKey = "catalog.json"
+
+	// fileBasedCatalogDir is the directory file-based catalog ConfigMaps are mounted at in registry pods.
+	fileBasedCatalogDir = "/configs"
+)
+
+// HasInlineCatalog returns true if the given CatalogSource holds its catalog inline.
+func HasInlineCatalog(source *v1alpha1.CatalogSource) bool {
+	_, ok := source.GetAnnotations()[InlineCatalogAnnotationKey]
+	return ok
+}
+
+// CatalogConfigMapName returns the name of the ConfigMap holding the catalog of the given configmap CatalogSource.
+func CatalogConfigMapName(source *v1alpha1.CatalogSource) string {
+	if HasInlineCatalog(source) {
+		return source.GetName() + InlineCatalogConfigMapPostfix
+	}
+	return source.Spec.ConfigMap
+}
+
+// IsFileBasedCatalog returns true if the given ConfigMap holds a file-based catalog rather than a catalog in the legacy
+// packagemanifest format. The data keys of file-based catalogs are file names, with a .json or .yaml extension.
+func IsFileBasedCatalog(configMap *v1.ConfigMap) bool {
+	if _, ok := configMap.Data[registry.ConfigMapPackageName]; ok {
+		return false
+	}
+	for key := range configMap.Data {
+		switch filepath.Ext(key) {
+		case ".json", ".yaml", ".yml":
+			return true
+		}
+	}
+	return false
+}
+
+// CatalogContentHash returns the sha256 digest of the catalog held by the given ConfigMap, over its data and binary data
+// in key order, so that it only changes when the content of the catalog does.
+func CatalogContentHash(configMap *v1.ConfigMap) string {
+	keys := make([]string, 0, len(configMap.Data)+len(configMap.BinaryData))
+	for key := range configMap.Data {
+		keys = append(keys, key)
+	}
+	for key := range configMap.BinaryData {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	h := sha256.New()
+	for _, key := range keys {
+		content, ok := configMap.BinaryData[key]
+		if !ok {
+			content = []byte(configMap.Data[key])
+		}
+		fmt.Fprintf(h, "%s\x00%d\x00", key, len(content))
+		h.Write(content)
+	}
+	return fmt.Sprintf("sha256:%x", h.Sum(nil))
+}
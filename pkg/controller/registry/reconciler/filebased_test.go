@@ -0,0 +1,67 @@
+package reconciler
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/registry"
+)
+
+func TestIsFileBasedCatalog(t *testing.T) {
+	require.True(t, IsFileBasedCatalog(&corev1.ConfigMap{Data: map[string]string{"catalog.json": "{}"}}))
+	require.True(t, IsFileBasedCatalog(&corev1.ConfigMap{Data: map[string]string{"etcd.yaml": "", "README": ""}}))
+	require.False(t, IsFileBasedCatalog(&corev1.ConfigMap{Data: map[string]string{registry.ConfigMapPackageName: "", "catalog.json": ""}}))
+	require.False(t, IsFileBasedCatalog(validConfigMap()))
+	require.False(t, IsFileBasedCatalog(&corev1.ConfigMap{}))
+}
+
+func TestCatalogContentHash(t *testing.T) {
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "catalog", ResourceVersion: "1"},
+		Data:       map[string]string{"catalog.json": `{"schema":"olm.package","name":"etcd"}`},
+	}
+	hash := CatalogContentHash(cm)
+	require.Regexp(t, "^sha256:[0-9a-f]{64}$", hash)
+
+	moved := cm.DeepCopy()
+	moved.ResourceVersion = "2"
+	moved.BinaryData = map[string][]byte{"catalog.json": []byte(cm.Data["catalog.json"])}
+	moved.Data = nil
+	require.Equal(t, hash, CatalogContentHash(moved), "the hash depends on the content only")
+
+	changed := cm.DeepCopy()
+	changed.Data["catalog.json"] = `{"schema":"olm.package","name":"etcd","defaultChannel":"alpha"}`
+	require.NotEqual(t, hash, CatalogContentHash(changed))
+}
+
+func TestCatalogConfigMapName(t *testing.T) {
+	source := &v1alpha1.CatalogSource{
+		ObjectMeta: metav1.ObjectMeta{Name: "cool-catalog"},
+		Spec:       v1alpha1.CatalogSourceSpec{ConfigMap: "cool-configmap"},
+	}
+	require.Equal(t, "cool-configmap", CatalogConfigMapName(source))
+
+	source.SetAnnotations(map[string]string{InlineCatalogAnnotationKey: "{}"})
+	require.True(t, HasInlineCatalog(source))
+	require.Equal(t, "cool-catalog"+InlineCatalogConfigMapPostfix, CatalogConfigMapName(source))
+}
+
+func TestFileBasedConfigMapPod(t *testing.T) {
+	source := validConfigMapCatalogSource(validConfigMap())
+
+	decorated := configMapCatalogSourceDecorator{CatalogSource: source}
+	legacy := decorated.Pod("image")
+	require.Equal(t, []string{"configmap-server", "-c", "cool-configmap", "-n", source.GetNamespace()}, legacy.Spec.Containers[0].Command)
+	require.Empty(t, legacy.Spec.Volumes)
+
+	decorated.fileBased = true
+	fileBased := decorated.Pod("image")
+	require.Equal(t, []string{"opm", "serve", fileBasedCatalogDir}, fileBased.Spec.Containers[0].Command)
+	require.Len(t, fileBased.Spec.Volumes, 1)
+	require.Equal(t, "cool-configmap", fileBased.Spec.Volumes[0].ConfigMap.Name)
+	require.Equal(t, []corev1.VolumeMount{{Name: "catalog", MountPath: fileBasedCatalogDir, ReadOnly: true}}, fileBased.Spec.Containers[0].VolumeMounts)
+}
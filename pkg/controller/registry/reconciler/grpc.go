@@ -17,6 +17,8 @@ import (
 
 	"github.com/operator-framework/api/pkg/operators/v1alpha1"
 	controllerclient "github.com/operator-framework/operator-lifecycle-manager/pkg/lib/controller-runtime/client"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/imagearch"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/imagemirror"
 	hashutil "github.com/operator-framework/operator-lifecycle-manager/pkg/lib/kubernetes/pkg/util/hash"
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/operatorclient"
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/operatorlister"
@@ -63,7 +65,7 @@ func (s *grpcCatalogSourceDecorator) Labels() map[string]string {
 
 func (s *grpcCatalogSourceDecorator) Annotations() map[string]string {
 	// TODO: Maybe something better than just a copy of all annotations would be to have a specific 'podMetadata' section in the CatalogSource?
-	return s.GetAnnotations()
+	return podAnnotations(s.CatalogSource)
 }
 
 func (s *grpcCatalogSourceDecorator) Service() *corev1.Service {
@@ -122,7 +124,7 @@ func (s *grpcCatalogSourceDecorator) ServiceAccount() *corev1.ServiceAccount {
 }
 
 func (s *grpcCatalogSourceDecorator) Pod(saName string) *corev1.Pod {
-	pod := Pod(s.CatalogSource, "registry-server", s.Spec.Image, saName, s.Labels(), s.Annotations(), 5, 10)
+	pod := Pod(s.CatalogSource, "registry-server", pinnedImage(s.CatalogSource), saName, s.Labels(), s.Annotations(), 5, 10)
 	ownerutil.AddOwner(pod, s.CatalogSource, false, false)
 	return pod
 }
@@ -132,6 +134,19 @@ type GrpcRegistryReconciler struct {
 	Lister    operatorlister.OperatorLister
 	OpClient  operatorclient.ClientInterface
 	SSAClient *controllerclient.ServerSideApplier
+	Polls     *PollScheduler
+	// Architectures schedules registry pods onto the architectures their catalog image supports.
+	Architectures imagearch.RequiredFunc
+	// ResolveDigest resolves the image tags of catalogs pinned to digests by their update strategy.
+	ResolveDigest ResolveDigestFunc
+	// MirrorImage rewrites catalog images to the mirrors of their repositories, which registry pods pull them from.
+	MirrorImage imagemirror.RewriteFunc
+}
+
+// registryImage returns the image the registry pods of the given CatalogSource run: its pinned image, in the mirror of
+// its repository if any.
+func (c *GrpcRegistryReconciler) registryImage(source *v1alpha1.CatalogSource) string {
+	return c.MirrorImage.Rewrite(pinnedImage(source))
 }
 
 var _ RegistryReconciler = &GrpcRegistryReconciler{}
@@ -178,8 +193,9 @@ func (c *GrpcRegistryReconciler) currentPodsWithCorrectImageAndSpec(source grpcC
 	}
 	found := []*corev1.Pod{}
 	newPod := source.Pod(saName)
+	image := c.registryImage(source.CatalogSource)
 	for _, p := range pods {
-		if p.Spec.Containers[0].Image == source.Spec.Image && podHashMatch(p, newPod) {
+		if p.Spec.Containers[0].Image == image && podHashMatch(p, newPod) {
 			found = append(found, p)
 		}
 	}
@@ -187,32 +203,51 @@ func (c *GrpcRegistryReconciler) currentPodsWithCorrectImageAndSpec(source grpcC
 }
 
 // EnsureRegistryServer ensures that all components of registry server are up to date.
-func (c *GrpcRegistryReconciler) EnsureRegistryServer(catalogSource *v1alpha1.CatalogSource) error {
+func (c *GrpcRegistryReconciler) EnsureRegistryServer(ctx context.Context, catalogSource *v1alpha1.CatalogSource) error {
 	source := grpcCatalogSourceDecorator{catalogSource}
+	podConfig, err := registryPodConfigFor(catalogSource)
+	if err != nil {
+		return err
+	}
+	// registry pods of catalogs pinned to digests roll once their tag resolves to a new digest, rather than being polled
+	if digestPinned(catalogSource) {
+		if err := c.pinDigest(ctx, catalogSource); err != nil {
+			return errors.Wrapf(err, "error resolving catalog image digest: %s", catalogSource.Spec.Image)
+		}
+	}
 
 	// if service status is nil, we force create every object to ensure they're created the first time
 	overwrite := source.Status.RegistryServiceStatus == nil || !isRegistryServiceStatusValid(&source)
 
 	//TODO: if any of these error out, we should write a status back (possibly set RegistryServiceStatus to nil so they get recreated)
-	sa, err := c.ensureSA(source)
+	sa, err := c.ensureSA(ctx, source)
 	// recreate the pod if no existing pod is serving the latest image or correct spec
 	overwritePod := overwrite || len(c.currentPodsWithCorrectImageAndSpec(source, sa.GetName())) == 0
 
 	if err != nil && !k8serror.IsAlreadyExists(err) {
 		return errors.Wrapf(err, "error ensuring service account: %s", source.GetName())
 	}
-	if err := c.ensurePod(source, sa.GetName(), overwritePod); err != nil {
+	if err := c.ensurePod(ctx, source, sa.GetName(), overwritePod); err != nil {
 		return errors.Wrapf(err, "error ensuring pod: %s", source.Pod(sa.Name).GetName())
 	}
-	if err := c.ensureUpdatePod(source, sa.Name); err != nil {
-		if _, ok := err.(UpdateNotReadyErr); ok {
+	// a held back poll is retried once the rest of the registry server is ensured
+	var throttled error
+	if err := c.ensureUpdatePod(ctx, source, sa.Name); err != nil {
+		switch err.(type) {
+		case UpdateNotReadyErr:
 			return err
+		case PollThrottledErr:
+			throttled = err
+		default:
+			return errors.Wrapf(err, "error ensuring updated catalog source pod: %s", source.Pod(sa.Name).GetName())
 		}
-		return errors.Wrapf(err, "error ensuring updated catalog source pod: %s", source.Pod(sa.Name).GetName())
 	}
-	if err := c.ensureService(source, overwrite); err != nil {
+	if err := c.ensureService(ctx, source, overwrite); err != nil {
 		return errors.Wrapf(err, "error ensuring service: %s", source.Service().GetName())
 	}
+	if err := ensurePodDisruptionBudget(ctx, c.OpClient.KubernetesInterface(), catalogSource, podConfig); err != nil {
+		return errors.Wrapf(err, "error ensuring pod disruption budget: %s", source.GetName())
+	}
 
 	if overwritePod {
 		now := c.now()
@@ -225,7 +260,7 @@ func (c *GrpcRegistryReconciler) EnsureRegistryServer(catalogSource *v1alpha1.Ca
 			Port:             getPort(service),
 		}
 	}
-	return nil
+	return throttled
 }
 
 func getPort(service *corev1.Service) string {
@@ -243,7 +278,7 @@ func isRegistryServiceStatusValid(source *grpcCatalogSourceDecorator) bool {
 	return true
 }
 
-func (c *GrpcRegistryReconciler) ensurePod(source grpcCatalogSourceDecorator, saName string, overwrite bool) error {
+func (c *GrpcRegistryReconciler) ensurePod(ctx context.Context, source grpcCatalogSourceDecorator, saName string, overwrite bool) error {
 	// currentLivePods refers to the currently live instances of the catalog source
 	currentLivePods := c.currentPods(source)
 	if len(currentLivePods) > 0 {
@@ -251,12 +286,16 @@ func (c *GrpcRegistryReconciler) ensurePod(source grpcCatalogSourceDecorator, sa
 			return nil
 		}
 		for _, p := range currentLivePods {
-			if err := c.OpClient.KubernetesInterface().CoreV1().Pods(source.GetNamespace()).Delete(context.TODO(), p.GetName(), *metav1.NewDeleteOptions(1)); err != nil && !k8serror.IsNotFound(err) {
+			if err := c.OpClient.KubernetesInterface().CoreV1().Pods(source.GetNamespace()).Delete(ctx, p.GetName(), *metav1.NewDeleteOptions(1)); err != nil && !k8serror.IsNotFound(err) {
 				return errors.Wrapf(err, "error deleting old pod: %s", p.GetName())
 			}
 		}
 	}
-	_, err := c.OpClient.KubernetesInterface().CoreV1().Pods(source.GetNamespace()).Create(context.TODO(), source.Pod(saName), metav1.CreateOptions{})
+	pod := source.Pod(saName)
+	image := c.registryImage(source.CatalogSource)
+	pod.Spec.Containers[0].Image = image
+	c.Architectures.Apply(ctx, &pod.Spec, image)
+	_, err := c.OpClient.KubernetesInterface().CoreV1().Pods(source.GetNamespace()).Create(ctx, pod, metav1.CreateOptions{})
 	if err != nil {
 		return errors.Wrapf(err, "error creating new pod: %s", source.Pod(saName).GetGenerateName())
 	}
@@ -265,8 +304,8 @@ func (c *GrpcRegistryReconciler) ensurePod(source grpcCatalogSourceDecorator, sa
 }
 
 // ensureUpdatePod checks that for the same catalog source version the same container imageID is running
-func (c *GrpcRegistryReconciler) ensureUpdatePod(source grpcCatalogSourceDecorator, saName string) error {
-	if !source.Poll() {
+func (c *GrpcRegistryReconciler) ensureUpdatePod(ctx context.Context, source grpcCatalogSourceDecorator, saName string) error {
+	if !source.Poll() || digestPinned(source.CatalogSource) {
 		return nil
 	}
 
@@ -274,9 +313,18 @@ func (c *GrpcRegistryReconciler) ensureUpdatePod(source grpcCatalogSourceDecorat
 	currentUpdatePods := c.currentUpdatePods(source)
 
 	if source.Update() && len(currentUpdatePods) == 0 {
+		updatePods, err := c.Lister.CoreV1().PodLister().List(updatePodSelector())
+		if err != nil {
+			return errors.Wrapf(err, "listing catalog source update pods")
+		}
+		if !c.Polls.admit(updatePods, source.CatalogSource, c.now().Time) {
+			logrus.WithField("CatalogSource", source.GetName()).Debug("catalog update held back: too many catalog polls in flight")
+			return PollThrottledErr{catalogName: source.GetName()}
+		}
 		logrus.WithField("CatalogSource", source.GetName()).Infof("catalog update required at %s", time.Now().String())
-		pod, err := c.createUpdatePod(source, saName)
+		pod, err := c.createUpdatePod(ctx, source, saName)
 		if err != nil {
+			c.Polls.release(source.CatalogSource)
 			return errors.Wrapf(err, "creating update catalog source pod")
 		}
 		source.SetLastUpdateTime()
@@ -286,7 +334,7 @@ func (c *GrpcRegistryReconciler) ensureUpdatePod(source grpcCatalogSourceDecorat
 	// check if update pod is ready - if not requeue the sync
 	// if update pod failed (potentially due to a bad catalog image) delete it
 	for _, p := range currentUpdatePods {
-		fail, err := c.podFailed(p)
+		fail, err := c.podFailed(ctx, p)
 		if err != nil {
 			return err
 		}
@@ -301,12 +349,12 @@ func (c *GrpcRegistryReconciler) ensureUpdatePod(source grpcCatalogSourceDecorat
 	for _, updatePod := range currentUpdatePods {
 		// if container imageID IDs are different, switch the serving pods
 		if imageChanged(updatePod, currentLivePods) {
-			err := c.promoteCatalog(updatePod, source.GetName())
+			err := c.promoteCatalog(ctx, updatePod, source.GetName())
 			if err != nil {
 				return fmt.Errorf("detected imageID change: error during update: %s", err)
 			}
 			// remove old catalog source pod
-			err = c.removePods(currentLivePods, source.GetNamespace())
+			err = c.removePods(ctx, currentLivePods, source.GetNamespace())
 			if err != nil {
 				return errors.Wrapf(err, "detected imageID change: error deleting old catalog source pod")
 			}
@@ -316,7 +364,7 @@ func (c *GrpcRegistryReconciler) ensureUpdatePod(source grpcCatalogSourceDecorat
 		}
 		// delete update pod right away, since the digest match, to prevent long-lived duplicate catalog pods
 		logrus.WithField("CatalogSource", source.GetName()).Info("catalog polling result: no update")
-		err := c.removePods([]*corev1.Pod{updatePod}, source.GetNamespace())
+		err := c.removePods(ctx, []*corev1.Pod{updatePod}, source.GetNamespace())
 		if err != nil {
 			return errors.Wrapf(err, "error deleting duplicate catalog polling pod: %s", updatePod.GetName())
 		}
@@ -325,7 +373,7 @@ func (c *GrpcRegistryReconciler) ensureUpdatePod(source grpcCatalogSourceDecorat
 	return nil
 }
 
-func (c *GrpcRegistryReconciler) ensureService(source grpcCatalogSourceDecorator, overwrite bool) error {
+func (c *GrpcRegistryReconciler) ensureService(ctx context.Context, source grpcCatalogSourceDecorator, overwrite bool) error {
 	service := source.Service()
 	svc := c.currentService(source)
 	if svc != nil {
@@ -333,17 +381,17 @@ func (c *GrpcRegistryReconciler) ensureService(source grpcCatalogSourceDecorator
 			return nil
 		}
 		// TODO(tflannag): Do we care about force deleting services?
-		if err := c.OpClient.DeleteService(service.GetNamespace(), service.GetName(), metav1.NewDeleteOptions(0)); err != nil && !k8serror.IsNotFound(err) {
+		if err := c.OpClient.DeleteService(ctx, service.GetNamespace(), service.GetName(), metav1.NewDeleteOptions(0)); err != nil && !k8serror.IsNotFound(err) {
 			return err
 		}
 	}
-	_, err := c.OpClient.CreateService(service)
+	_, err := c.OpClient.CreateService(ctx, service)
 	return err
 }
 
-func (c *GrpcRegistryReconciler) ensureSA(source grpcCatalogSourceDecorator) (*corev1.ServiceAccount, error) {
+func (c *GrpcRegistryReconciler) ensureSA(ctx context.Context, source grpcCatalogSourceDecorator) (*corev1.ServiceAccount, error) {
 	sa := source.ServiceAccount()
-	if _, err := c.OpClient.CreateServiceAccount(sa); err != nil {
+	if _, err := c.OpClient.CreateServiceAccount(ctx, sa); err != nil {
 		return sa, err
 	}
 	return sa, nil
@@ -383,12 +431,15 @@ func HashServiceSpec(spec corev1.ServiceSpec) string {
 }
 
 // createUpdatePod is an internal method that creates a pod using the latest catalog source.
-func (c *GrpcRegistryReconciler) createUpdatePod(source grpcCatalogSourceDecorator, saName string) (*corev1.Pod, error) {
+func (c *GrpcRegistryReconciler) createUpdatePod(ctx context.Context, source grpcCatalogSourceDecorator, saName string) (*corev1.Pod, error) {
 	// remove label from pod to ensure service does not accidentally route traffic to the pod
 	p := source.Pod(saName)
 	p = swapLabels(p, "", source.Name)
+	image := c.registryImage(source.CatalogSource)
+	p.Spec.Containers[0].Image = image
+	c.Architectures.Apply(ctx, &p.Spec, image)
 
-	pod, err := c.OpClient.KubernetesInterface().CoreV1().Pods(source.GetNamespace()).Create(context.TODO(), p, metav1.CreateOptions{})
+	pod, err := c.OpClient.KubernetesInterface().CoreV1().Pods(source.GetNamespace()).Create(ctx, p, metav1.CreateOptions{})
 	if err != nil {
 		logrus.WithField("pod", source.Pod(saName).GetName()).Warn("couldn't create new catalogsource pod")
 		return nil, err
@@ -426,9 +477,9 @@ func imageID(pod *corev1.Pod) string {
 	return pod.Status.ContainerStatuses[0].ImageID
 }
 
-func (c *GrpcRegistryReconciler) removePods(pods []*corev1.Pod, namespace string) error {
+func (c *GrpcRegistryReconciler) removePods(ctx context.Context, pods []*corev1.Pod, namespace string) error {
 	for _, p := range pods {
-		if err := c.OpClient.KubernetesInterface().CoreV1().Pods(namespace).Delete(context.TODO(), p.GetName(), *metav1.NewDeleteOptions(1)); err != nil && !k8serror.IsNotFound(err) {
+		if err := c.OpClient.KubernetesInterface().CoreV1().Pods(namespace).Delete(ctx, p.GetName(), *metav1.NewDeleteOptions(1)); err != nil && !k8serror.IsNotFound(err) {
 			return errors.Wrapf(err, "error deleting pod: %s", p.GetName())
 		}
 	}
@@ -436,7 +487,7 @@ func (c *GrpcRegistryReconciler) removePods(pods []*corev1.Pod, namespace string
 }
 
 // CheckRegistryServer returns true if the given CatalogSource is considered healthy; false otherwise.
-func (c *GrpcRegistryReconciler) CheckRegistryServer(catalogSource *v1alpha1.CatalogSource) (healthy bool, err error) {
+func (c *GrpcRegistryReconciler) CheckRegistryServer(ctx context.Context, catalogSource *v1alpha1.CatalogSource) (healthy bool, err error) {
 	source := grpcCatalogSourceDecorator{catalogSource}
 	// Check on registry resources
 	// TODO: add gRPC health check
@@ -452,9 +503,9 @@ func (c *GrpcRegistryReconciler) CheckRegistryServer(catalogSource *v1alpha1.Cat
 
 // promoteCatalog swaps the labels on the update pod so that the update pod is now reachable by the catalog service.
 // By updating the catalog on cluster it promotes the update pod to act as the new version of the catalog on-cluster.
-func (c *GrpcRegistryReconciler) promoteCatalog(updatePod *corev1.Pod, key string) error {
+func (c *GrpcRegistryReconciler) promoteCatalog(ctx context.Context, updatePod *corev1.Pod, key string) error {
 	// Update the update pod to promote it to serving pod via the SSA client
-	err := c.SSAClient.Apply(context.TODO(), updatePod, func(p *corev1.Pod) error {
+	err := c.SSAClient.Apply(ctx, updatePod, func(p *corev1.Pod) error {
 		p.Labels[CatalogSourceLabelKey] = key
 		p.Labels[CatalogSourceUpdateKey] = ""
 		return nil
@@ -483,10 +534,10 @@ func swapLabels(pod *corev1.Pod, labelKey, updateKey string) *corev1.Pod {
 }
 
 // podFailed checks whether the pod status is in a failed or unknown state, and deletes the pod if so.
-func (c *GrpcRegistryReconciler) podFailed(pod *corev1.Pod) (bool, error) {
+func (c *GrpcRegistryReconciler) podFailed(ctx context.Context, pod *corev1.Pod) (bool, error) {
 	if pod.Status.Phase == corev1.PodFailed || pod.Status.Phase == corev1.PodUnknown {
 		logrus.WithField("UpdatePod", pod.GetName()).Infof("catalog polling result: update pod %s failed to start", pod.GetName())
-		err := c.removePods([]*corev1.Pod{pod}, pod.GetNamespace())
+		err := c.removePods(ctx, []*corev1.Pod{pod}, pod.GetNamespace())
 		if err != nil {
 			return true, errors.Wrapf(err, "error deleting failed catalog polling pod: %s", pod.GetName())
 		}
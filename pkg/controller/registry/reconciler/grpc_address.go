@@ -1,6 +1,8 @@
 package reconciler
 
 import (
+	"context"
+
 	"github.com/operator-framework/api/pkg/operators/v1alpha1"
 )
 
@@ -13,7 +15,7 @@ var _ RegistryChecker = &GrpcAddressRegistryReconciler{}
 var _ RegistryReconciler = &GrpcAddressRegistryReconciler{}
 
 // EnsureRegistryServer ensures a registry server exists for the given CatalogSource.
-func (g *GrpcAddressRegistryReconciler) EnsureRegistryServer(catalogSource *v1alpha1.CatalogSource) error {
+func (g *GrpcAddressRegistryReconciler) EnsureRegistryServer(ctx context.Context, catalogSource *v1alpha1.CatalogSource) error {
 	now := g.now()
 	catalogSource.Status.RegistryServiceStatus = &v1alpha1.RegistryServiceStatus{
 		CreatedAt: now,
@@ -24,7 +26,7 @@ func (g *GrpcAddressRegistryReconciler) EnsureRegistryServer(catalogSource *v1al
 }
 
 // CheckRegistryServer returns true if the given CatalogSource is considered healthy; false otherwise.
-func (g *GrpcAddressRegistryReconciler) CheckRegistryServer(catalogSource *v1alpha1.CatalogSource) (healthy bool, err error) {
+func (g *GrpcAddressRegistryReconciler) CheckRegistryServer(ctx context.Context, catalogSource *v1alpha1.CatalogSource) (healthy bool, err error) {
 	// TODO: add gRPC health check
 	healthy = true
 	return
@@ -0,0 +1,139 @@
+package reconciler
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/distribution/distribution/reference"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/operator-framework/operator-registry/pkg/image/containerdregistry"
+)
+
+const (
+	// CatalogUpdateStrategyAnnotationKey is the CatalogSource annotation selecting how the registry pods of grpc
+	// catalogs referencing their image by tag are updated. With DigestUpdateStrategy, the catalog operator resolves the
+	// tag to a digest, runs the registry pods on that digest, and only rolls them when polling resolves a new digest.
+	CatalogUpdateStrategyAnnotationKey = "operatorframework.io/catalog-update-strategy"
+	DigestUpdateStrategy               = "Digest"
+
+	// CatalogImageDigestsAnnotationKey is the CatalogSource annotation recording, as a JSON list, the digests its image
+	// tag resolved to with the DigestUpdateStrategy, latest first. The registry pods run the latest digest.
+	CatalogImageDigestsAnnotationKey = "operatorframework.io/catalog-image-digests"
+
+	// CatalogImageDigestHistoryLimitAnnotationKey is the CatalogSource annotation setting how many digests are recorded
+	// with the DigestUpdateStrategy. It defaults to 5.
+	CatalogImageDigestHistoryLimitAnnotationKey = "operatorframework.io/catalog-image-digest-history-limit"
+
+	defaultDigestHistoryLimit = 5
+	digestResolutionTimeout   = 30 * time.Second
+)
+
+// ImageDigest is a digest the image tag of a CatalogSource resolved to.
+type ImageDigest struct {
+	// Image is the image tag, as set on the CatalogSource when it was resolved.
+	Image string `json:"image"`
+	// Digest is the digest the tag resolved to.
+	Digest string `json:"digest"`
+	// ResolvedAt is when the tag first resolved to the digest.
+	ResolvedAt metav1.Time `json:"resolvedAt"`
+}
+
+// ResolveDigestFunc returns the digest the given image references.
+type ResolveDigestFunc func(ctx context.Context, image string) (string, error)
+
+// ResolveImageDigest returns the digest the given image references, querying its registry anonymously.
+func ResolveImageDigest(ctx context.Context, image string) (string, error) {
+	named, err := reference.ParseNormalizedNamed(image)
+	if err != nil {
+		return "", err
+	}
+	resolver, err := containerdregistry.NewResolver("", false, nil)
+	if err != nil {
+		return "", err
+	}
+	_, desc, err := resolver.Resolve(ctx, reference.TagNameOnly(named).String())
+	if err != nil {
+		return "", err
+	}
+	return desc.Digest.String(), nil
+}
+
+// digestPinned returns true if the registry pods of the given CatalogSource run the digest its image tag resolves to.
+func digestPinned(source *v1alpha1.CatalogSource) bool {
+	return source.Spec.Image != "" && !strings.Contains(source.Spec.Image, "@") &&
+		source.GetAnnotations()[CatalogUpdateStrategyAnnotationKey] == DigestUpdateStrategy
+}
+
+// ImageDigests returns the digests recorded on the given CatalogSource, latest first. Invalid records are ignored.
+func ImageDigests(source *v1alpha1.CatalogSource) []ImageDigest {
+	var digests []ImageDigest
+	if err := json.Unmarshal([]byte(source.GetAnnotations()[CatalogImageDigestsAnnotationKey]), &digests); err != nil {
+		return nil
+	}
+	return digests
+}
+
+// pinnedImage returns the image the registry pods of the given CatalogSource run: its image at the latest digest its
+// tag resolved to if it's pinned, or its image otherwise.
+func pinnedImage(source *v1alpha1.CatalogSource) string {
+	if !digestPinned(source) {
+		return source.Spec.Image
+	}
+	digests := ImageDigests(source)
+	if len(digests) == 0 || digests[0].Image != source.Spec.Image {
+		return source.Spec.Image
+	}
+	named, err := reference.ParseNormalizedNamed(source.Spec.Image)
+	if err != nil {
+		return source.Spec.Image
+	}
+	return named.Name() + "@" + digests[0].Digest
+}
+
+// pinDigest resolves the image tag of the given CatalogSource when it isn't pinned yet, its image changed, or it's
+// time to poll it, and records the digest it resolved to if it's new.
+func (c *GrpcRegistryReconciler) pinDigest(ctx context.Context, source *v1alpha1.CatalogSource) error {
+	digests := ImageDigests(source)
+	if len(digests) > 0 && digests[0].Image == source.Spec.Image && !source.Update() {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, digestResolutionTimeout)
+	defer cancel()
+	// mirrored images are resolved in the mirror of their repository, which their registry pods pull them from
+	digest, err := c.ResolveDigest(ctx, c.MirrorImage.Rewrite(source.Spec.Image))
+	if err != nil {
+		return err
+	}
+	if source.Poll() {
+		source.SetLastUpdateTime()
+	}
+	if len(digests) > 0 && digests[0].Image == source.Spec.Image && digests[0].Digest == digest {
+		return nil
+	}
+
+	limit := defaultDigestHistoryLimit
+	if value, err := strconv.Atoi(source.GetAnnotations()[CatalogImageDigestHistoryLimitAnnotationKey]); err == nil && value > 0 {
+		limit = value
+	}
+	digests = append([]ImageDigest{{Image: source.Spec.Image, Digest: digest, ResolvedAt: c.now()}}, digests...)
+	if len(digests) > limit {
+		digests = digests[:limit]
+	}
+	record, err := json.Marshal(digests)
+	if err != nil {
+		return err
+	}
+	annotations := make(map[string]string, len(source.GetAnnotations())+1)
+	for key, value := range source.GetAnnotations() {
+		annotations[key] = value
+	}
+	annotations[CatalogImageDigestsAnnotationKey] = string(record)
+	source.SetAnnotations(annotations)
+	return nil
+}
@@ -0,0 +1,128 @@
+package reconciler
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+)
+
+const (
+	digestA = "sha256:aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+	digestB = "sha256:bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb"
+	digestC = "sha256:cccccccccccccccccccccccccccccccccccccccccccccccccccccccccccccccc"
+)
+
+func digestPinnedCatalogSource(image string) *v1alpha1.CatalogSource {
+	catsrc := validGrpcCatalogSource(image, "")
+	catsrc.SetAnnotations(map[string]string{
+		CatalogUpdateStrategyAnnotationKey:          DigestUpdateStrategy,
+		CatalogImageDigestHistoryLimitAnnotationKey: "2",
+	})
+	catsrc.Spec.UpdateStrategy = &v1alpha1.UpdateStrategy{RegistryPoll: &v1alpha1.RegistryPoll{Interval: &metav1.Duration{Duration: 10 * time.Minute}}}
+	return catsrc
+}
+
+func TestGrpcRegistryReconcilerDigestPinning(t *testing.T) {
+	stopc := make(chan struct{})
+	defer close(stopc)
+
+	catsrc := digestPinnedCatalogSource("quay.io/example/catalog:latest")
+	factory, client := fakeReconcilerFactory(t, stopc, withResolveDigest(func(ctx context.Context, image string) (string, error) {
+		require.Equal(t, "quay.io/example/catalog:latest", image)
+		return digestA, nil
+	}))
+	rec := factory.ReconcilerForSource(catsrc)
+	require.NoError(t, rec.EnsureRegistryServer(context.TODO(), catsrc))
+
+	digests := ImageDigests(catsrc)
+	require.Len(t, digests, 1)
+	require.Equal(t, digestA, digests[0].Digest)
+	require.NotNil(t, catsrc.Status.LatestImageRegistryPoll)
+
+	listOptions := metav1.ListOptions{LabelSelector: labels.SelectorFromSet(labels.Set{CatalogSourceLabelKey: catsrc.GetName()}).String()}
+	pods, err := client.KubernetesInterface().CoreV1().Pods(catsrc.GetNamespace()).List(context.TODO(), listOptions)
+	require.NoError(t, err)
+	require.Len(t, pods.Items, 1)
+	require.Equal(t, "quay.io/example/catalog@"+digestA, pods.Items[0].Spec.Containers[0].Image)
+	require.NotContains(t, pods.Items[0].GetAnnotations(), CatalogImageDigestsAnnotationKey)
+}
+
+func TestPinDigest(t *testing.T) {
+	now := metav1.NewTime(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	digest := digestA
+	resolutions := 0
+	rec := &GrpcRegistryReconciler{
+		now: func() metav1.Time { return now },
+		ResolveDigest: func(ctx context.Context, image string) (string, error) {
+			resolutions++
+			return digest, nil
+		},
+	}
+	catsrc := digestPinnedCatalogSource("quay.io/example/catalog:latest")
+	poll := func() {
+		catsrc.Status.LatestImageRegistryPoll = &metav1.Time{Time: time.Now().Add(-time.Hour)}
+		require.NoError(t, rec.pinDigest(context.TODO(), catsrc))
+	}
+
+	require.NoError(t, rec.pinDigest(context.TODO(), catsrc))
+	require.Equal(t, "quay.io/example/catalog@"+digestA, pinnedImage(catsrc))
+
+	// The tag isn't resolved again until the next poll
+	require.NoError(t, rec.pinDigest(context.TODO(), catsrc))
+	require.Equal(t, 1, resolutions)
+
+	// Polls resolving the same digest don't record it again
+	poll()
+	require.Equal(t, 2, resolutions)
+	require.Len(t, ImageDigests(catsrc), 1)
+
+	// New digests are recorded, up to the history limit
+	digest = digestB
+	poll()
+	digest = digestC
+	poll()
+	digests := ImageDigests(catsrc)
+	require.Len(t, digests, 2)
+	for i, want := range []string{digestC, digestB} {
+		require.Equal(t, "quay.io/example/catalog:latest", digests[i].Image)
+		require.Equal(t, want, digests[i].Digest)
+		require.True(t, now.Equal(&digests[i].ResolvedAt))
+	}
+	require.Equal(t, "quay.io/example/catalog@"+digestC, pinnedImage(catsrc))
+
+	// Changing the image resolves it right away, and runs it unpinned until then
+	catsrc.Spec.Image = "quay.io/example/other:latest"
+	require.Equal(t, "quay.io/example/other:latest", pinnedImage(catsrc))
+	require.NoError(t, rec.pinDigest(context.TODO(), catsrc))
+	require.Equal(t, 5, resolutions)
+	require.Equal(t, "quay.io/example/other@"+digestC, pinnedImage(catsrc))
+
+	// Images referenced by digest aren't pinned
+	require.False(t, digestPinned(digestPinnedCatalogSource("quay.io/example/catalog@"+digestA)))
+}
+
+func TestPinDigestImageMirrors(t *testing.T) {
+	rec := &GrpcRegistryReconciler{
+		now: metav1.Now,
+		ResolveDigest: func(ctx context.Context, image string) (string, error) {
+			require.Equal(t, "registry.internal/example/catalog:latest", image, "mirrored tags are resolved in their mirror")
+			return digestA, nil
+		},
+		MirrorImage: func(image string) string {
+			return strings.Replace(image, "quay.io/example", "registry.internal/example", 1)
+		},
+	}
+	catsrc := digestPinnedCatalogSource("quay.io/example/catalog:latest")
+	require.NoError(t, rec.pinDigest(context.TODO(), catsrc))
+
+	// the digest is recorded for the image of the CatalogSource, and pulled from its mirror
+	require.Equal(t, "quay.io/example/catalog:latest", ImageDigests(catsrc)[0].Image)
+	require.Equal(t, "registry.internal/example/catalog@"+digestA, rec.registryImage(catsrc))
+}
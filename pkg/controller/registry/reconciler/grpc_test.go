@@ -2,6 +2,7 @@ package reconciler
 
 import (
 	"context"
+	"strings"
 	"testing"
 	"time"
 
@@ -321,7 +322,7 @@ func TestGrpcRegistryReconciler(t *testing.T) {
 			factory, client := fakeReconcilerFactory(t, stopc, withNow(now), withK8sObjs(tt.in.cluster.k8sObjs...), withK8sClientOptions(clientfake.WithNameGeneration(t)))
 			rec := factory.ReconcilerForSource(tt.in.catsrc)
 
-			err := rec.EnsureRegistryServer(tt.in.catsrc)
+			err := rec.EnsureRegistryServer(context.TODO(), tt.in.catsrc)
 
 			require.Equal(t, tt.out.err, err)
 			require.Equal(t, tt.out.status, tt.in.catsrc.Status.RegistryServiceStatus)
@@ -417,7 +418,7 @@ func TestRegistryPodPriorityClass(t *testing.T) {
 			factory, client := fakeReconcilerFactory(t, stopc, withNow(now), withK8sObjs(tt.in.cluster.k8sObjs...), withK8sClientOptions(clientfake.WithNameGeneration(t)))
 			rec := factory.ReconcilerForSource(tt.in.catsrc)
 
-			err := rec.EnsureRegistryServer(tt.in.catsrc)
+			err := rec.EnsureRegistryServer(context.TODO(), tt.in.catsrc)
 			require.NoError(t, err)
 
 			// Check for resource existence
@@ -434,6 +435,63 @@ func TestRegistryPodPriorityClass(t *testing.T) {
 	}
 }
 
+func TestRegistryPodArchitectures(t *testing.T) {
+	stopc := make(chan struct{})
+	defer close(stopc)
+
+	catsrc := validGrpcCatalogSource("test-img", "")
+	architectures := func(_ context.Context, images ...string) []string {
+		require.Equal(t, []string{"test-img"}, images)
+		return []string{"amd64", "arm64"}
+	}
+	factory, client := fakeReconcilerFactory(t, stopc, withArchitectures(architectures))
+	rec := factory.ReconcilerForSource(catsrc)
+	require.NoError(t, rec.EnsureRegistryServer(context.TODO(), catsrc))
+
+	decorated := grpcCatalogSourceDecorator{catsrc}
+	pod := decorated.Pod(catsrc.GetName())
+	listOptions := metav1.ListOptions{LabelSelector: labels.SelectorFromSet(labels.Set{CatalogSourceLabelKey: catsrc.GetName()}).String()}
+	outPods, err := client.KubernetesInterface().CoreV1().Pods(catsrc.GetNamespace()).List(context.TODO(), listOptions)
+	require.NoError(t, err)
+	require.Len(t, outPods.Items, 1)
+	outPod := outPods.Items[0]
+	require.Equal(t, []corev1.NodeSelectorTerm{{MatchExpressions: []corev1.NodeSelectorRequirement{{
+		Key:      corev1.LabelArchStable,
+		Operator: corev1.NodeSelectorOpIn,
+		Values:   []string{"amd64", "arm64"},
+	}}}}, outPod.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms)
+	require.Equal(t, pod.GetLabels()[PodHashLabelKey], outPod.GetLabels()[PodHashLabelKey], "the affinity doesn't change the pod hash")
+}
+
+func TestRegistryPodImageMirrors(t *testing.T) {
+	stopc := make(chan struct{})
+	defer close(stopc)
+
+	catsrc := validGrpcCatalogSource("quay.io/example/catalog:latest", "")
+	mirror := func(image string) string {
+		return strings.Replace(image, "quay.io/example", "registry.internal/example", 1)
+	}
+	architectures := func(_ context.Context, images ...string) []string {
+		require.Equal(t, []string{"registry.internal/example/catalog:latest"}, images)
+		return nil
+	}
+	factory, client := fakeReconcilerFactory(t, stopc, withMirrorImage(mirror), withArchitectures(architectures))
+	rec := factory.ReconcilerForSource(catsrc)
+	require.NoError(t, rec.EnsureRegistryServer(context.TODO(), catsrc))
+
+	listOptions := metav1.ListOptions{LabelSelector: labels.SelectorFromSet(labels.Set{CatalogSourceLabelKey: catsrc.GetName()}).String()}
+	outPods, err := client.KubernetesInterface().CoreV1().Pods(catsrc.GetNamespace()).List(context.TODO(), listOptions)
+	require.NoError(t, err)
+	require.Len(t, outPods.Items, 1)
+	require.Equal(t, "registry.internal/example/catalog:latest", outPods.Items[0].Spec.Containers[0].Image)
+
+	// the pod pulling from the mirror is current, and isn't recreated
+	decorated := grpcCatalogSourceDecorator{catsrc}
+	require.Eventually(t, func() bool {
+		return len(rec.(*GrpcRegistryReconciler).currentPodsWithCorrectImageAndSpec(decorated, catsrc.GetName())) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
 func TestGrpcRegistryChecker(t *testing.T) {
 	type cluster struct {
 		k8sObjs []runtime.Object
@@ -572,7 +630,7 @@ func TestGrpcRegistryChecker(t *testing.T) {
 			factory, _ := fakeReconcilerFactory(t, stopc, withK8sObjs(tt.in.cluster.k8sObjs...))
 			rec := factory.ReconcilerForSource(tt.in.catsrc)
 
-			healthy, err := rec.CheckRegistryServer(tt.in.catsrc)
+			healthy, err := rec.CheckRegistryServer(context.TODO(), tt.in.catsrc)
 
 			require.Equal(t, tt.out.err, err)
 			if tt.out.err != nil {
@@ -0,0 +1,392 @@
+package reconciler
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/operator-framework/operator-registry/pkg/configmap"
+	"github.com/operator-framework/operator-registry/pkg/lib/encoding"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/install"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/registry"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/registry/fbc"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/imagearch"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/imagemirror"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/operatorclient"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/operatorlister"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/ownerutil"
+)
+
+const (
+	// SourceTypeOCI is the sourceType of a CatalogSource whose spec.image is a file-based catalog image, unpacked by a
+	// Job and served from memory by the catalog operator instead of by a registry pod.
+	SourceTypeOCI v1alpha1.SourceType = "oci"
+
+	// OCICatalogPostfix is a postfix appended to the name of an oci CatalogSource to name the resources unpacking its
+	// catalog image.
+	OCICatalogPostfix = "-oci-catalog"
+
+	// ociCatalogDir is the directory of file-based catalog images holding their catalog.
+	ociCatalogDir = "/configs"
+)
+
+// ociCatalogSourceDecorator wraps CatalogSource to add additional methods
+type ociCatalogSourceDecorator struct {
+	*v1alpha1.CatalogSource
+}
+
+func (s *ociCatalogSourceDecorator) key() registry.CatalogKey {
+	return registry.CatalogKey{Name: s.GetName(), Namespace: s.GetNamespace()}
+}
+
+// name returns the name of the ConfigMap the catalog image is unpacked to, and of the Job and RBAC unpacking it.
+func (s *ociCatalogSourceDecorator) name() string {
+	return s.GetName() + OCICatalogPostfix
+}
+
+func (s *ociCatalogSourceDecorator) ConfigMap() *v1.ConfigMap {
+	cm := &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      s.name(),
+			Namespace: s.GetNamespace(),
+			Labels: map[string]string{
+				install.OLMManagedLabelKey: install.OLMManagedLabelValue,
+				CatalogSourceLabelKey:      s.GetName(),
+			},
+		},
+	}
+	ownerutil.AddOwner(cm, s.CatalogSource, false, false)
+	return cm
+}
+
+func (s *ociCatalogSourceDecorator) Role() *rbacv1.Role {
+	role := &rbacv1.Role{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      s.name(),
+			Namespace: s.GetNamespace(),
+		},
+		Rules: []rbacv1.PolicyRule{
+			{
+				Verbs:         []string{"create", "get", "update"},
+				APIGroups:     []string{""},
+				Resources:     []string{"configmaps"},
+				ResourceNames: []string{s.name()},
+			},
+		},
+	}
+	ownerutil.AddOwner(role, s.CatalogSource, false, false)
+	return role
+}
+
+func (s *ociCatalogSourceDecorator) RoleBinding() *rbacv1.RoleBinding {
+	rb := &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      s.name(),
+			Namespace: s.GetNamespace(),
+		},
+		Subjects: []rbacv1.Subject{
+			{
+				Kind:      "ServiceAccount",
+				Name:      "default",
+				Namespace: s.GetNamespace(),
+			},
+		},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: "rbac.authorization.k8s.io",
+			Kind:     "Role",
+			Name:     s.name(),
+		},
+	}
+	ownerutil.AddOwner(rb, s.CatalogSource, false, false)
+	return rb
+}
+
+// Job returns the Job unpacking the catalog image to the ConfigMap of the CatalogSource: the catalog image copies its
+// catalog to a shared volume with the cpb utility, and opm writes it gzipped to the ConfigMap. The catalog image is
+// pulled from the given image, e.g. its mirror, but recorded as the image of the CatalogSource.
+func (s *ociCatalogSourceDecorator) Job(opmImage, utilImage, catalogImage string) *batchv1.Job {
+	requests := v1.ResourceRequirements{
+		Requests: v1.ResourceList{
+			v1.ResourceCPU:    resource.MustParse("10m"),
+			v1.ResourceMemory: resource.MustParse("50Mi"),
+		},
+	}
+	backoffLimit := int32(3)
+
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      s.name(),
+			Namespace: s.GetNamespace(),
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: &backoffLimit,
+			Template: v1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{CatalogSourceLabelKey: s.GetName()},
+				},
+				Spec: v1.PodSpec{
+					RestartPolicy: v1.RestartPolicyNever,
+					Containers: []v1.Container{
+						{
+							Name:  "extract",
+							Image: opmImage,
+							Command: []string{"opm", "alpha", "bundle", "extract",
+								"-m", "/bundle/",
+								"-n", s.GetNamespace(),
+								"-c", s.name(),
+								"-z",
+							},
+							Env: []v1.EnvVar{
+								{
+									Name:  configmap.EnvContainerImage,
+									Value: s.Spec.Image,
+								},
+							},
+							VolumeMounts: []v1.VolumeMount{
+								{
+									Name:      "bundle",
+									MountPath: "/bundle",
+								},
+							},
+							Resources: requests,
+						},
+					},
+					InitContainers: []v1.Container{
+						{
+							Name:         "util",
+							Image:        utilImage,
+							Command:      []string{"/bin/cp", "-Rv", "/bin/cpb", "/util/cpb"},
+							VolumeMounts: []v1.VolumeMount{{Name: "util", MountPath: "/util"}},
+							Resources:    requests,
+						},
+						{
+							Name:            "pull",
+							Image:           catalogImage,
+							ImagePullPolicy: v1.PullAlways,
+							Command:         []string{"/util/cpb", "--catalog", ociCatalogDir, "/bundle"},
+							VolumeMounts: []v1.VolumeMount{
+								{Name: "bundle", MountPath: "/bundle"},
+								{Name: "util", MountPath: "/util"},
+							},
+							Resources: requests,
+						},
+					},
+					Volumes: []v1.Volume{
+						{
+							Name:         "bundle",
+							VolumeSource: v1.VolumeSource{EmptyDir: &v1.EmptyDirVolumeSource{}},
+						},
+						{
+							Name:         "util",
+							VolumeSource: v1.VolumeSource{EmptyDir: &v1.EmptyDirVolumeSource{}},
+						},
+					},
+					NodeSelector: map[string]string{
+						"kubernetes.io/os": "linux",
+					},
+				},
+			},
+		},
+	}
+	if s.Spec.GrpcPodConfig != nil {
+		if s.Spec.GrpcPodConfig.NodeSelector != nil {
+			job.Spec.Template.Spec.NodeSelector = s.Spec.GrpcPodConfig.NodeSelector
+		}
+		job.Spec.Template.Spec.Tolerations = s.Spec.GrpcPodConfig.Tolerations
+		if s.Spec.GrpcPodConfig.PriorityClassName != nil {
+			job.Spec.Template.Spec.PriorityClassName = *s.Spec.GrpcPodConfig.PriorityClassName
+		}
+	}
+	for _, secret := range s.Spec.Secrets {
+		job.Spec.Template.Spec.ImagePullSecrets = append(job.Spec.Template.Spec.ImagePullSecrets, v1.LocalObjectReference{Name: secret})
+	}
+	ownerutil.AddOwner(job, s.CatalogSource, false, false)
+	return job
+}
+
+// unpacked returns true if the given ConfigMap holds the catalog of the current image of the CatalogSource.
+func (s *ociCatalogSourceDecorator) unpacked(cm *v1.ConfigMap) bool {
+	return cm.GetAnnotations()[configmap.ConfigMapImageAnnotationKey] == s.Spec.Image && len(cm.BinaryData) > 0
+}
+
+// OCIRegistryReconciler reconciles oci CatalogSources. It unpacks their catalog image to a ConfigMap with a Job, and
+// serves the unpacked catalog from memory.
+type OCIRegistryReconciler struct {
+	now       nowFunc
+	Lister    operatorlister.OperatorLister
+	OpClient  operatorclient.ClientInterface
+	OPMImage  string
+	UtilImage string
+	Servers   *fbc.Servers
+	// Architectures schedules unpack jobs onto the architectures the opm and utility images support. The catalog
+	// image only holds files, copied by the utility image.
+	Architectures imagearch.RequiredFunc
+	// MirrorImage rewrites catalog images to the mirrors of their repositories, which unpack jobs pull them from.
+	MirrorImage imagemirror.RewriteFunc
+}
+
+var _ RegistryReconciler = &OCIRegistryReconciler{}
+
+// EnsureRegistryServer ensures the catalog image of the given CatalogSource is unpacked and its catalog served. It
+// returns an UpdateNotReadyErr while the catalog image is being unpacked.
+func (o *OCIRegistryReconciler) EnsureRegistryServer(ctx context.Context, catalogSource *v1alpha1.CatalogSource) error {
+	source := ociCatalogSourceDecorator{catalogSource}
+	if o.Servers == nil {
+		return fmt.Errorf("no in-memory catalog servers for sourcetype: %s", SourceTypeOCI)
+	}
+
+	cm, err := o.ensureConfigMap(ctx, source)
+	if err != nil {
+		return fmt.Errorf("error ensuring configmap: %s: %v", source.name(), err)
+	}
+
+	if !source.unpacked(cm) {
+		if err := o.ensureRole(ctx, source); err != nil {
+			return fmt.Errorf("error ensuring role: %s: %v", source.name(), err)
+		}
+		if err := o.ensureRoleBinding(ctx, source); err != nil {
+			return fmt.Errorf("error ensuring rolebinding: %s: %v", source.name(), err)
+		}
+		job, err := o.ensureJob(ctx, source)
+		if err != nil {
+			return fmt.Errorf("error ensuring unpack job: %s: %v", source.name(), err)
+		}
+		if job != nil {
+			for _, cond := range job.Status.Conditions {
+				if cond.Type == batchv1.JobFailed && cond.Status == v1.ConditionTrue {
+					return fmt.Errorf("unpack job %s failed: %s", job.GetName(), cond.Message)
+				}
+			}
+		}
+		return UpdateNotReadyErr{catalogName: source.GetName(), podName: source.name()}
+	}
+
+	if o.Servers.Revision(source.key()) == cm.GetResourceVersion() && catalogSource.Status.RegistryServiceStatus != nil {
+		return nil
+	}
+
+	files := make(map[string][]byte, len(cm.BinaryData))
+	for name, content := range cm.BinaryData {
+		decoded, err := encoding.GzipBase64Decode(content)
+		if err != nil {
+			return fmt.Errorf("error decoding catalog file %s: %v", name, err)
+		}
+		files[name] = decoded
+	}
+	index, err := fbc.Load(files)
+	if err != nil {
+		return fmt.Errorf("error loading catalog of image %s: %v", source.Spec.Image, err)
+	}
+	if _, err := o.Servers.Serve(source.key(), cm.GetResourceVersion(), index); err != nil {
+		return fmt.Errorf("error serving catalog of image %s: %v", source.Spec.Image, err)
+	}
+
+	catalogSource.Status.RegistryServiceStatus = &v1alpha1.RegistryServiceStatus{
+		CreatedAt: o.now(),
+		Protocol:  "grpc",
+	}
+	return nil
+}
+
+// ensureConfigMap creates the ConfigMap the catalog image of the given CatalogSource is unpacked to, and returns it.
+func (o *OCIRegistryReconciler) ensureConfigMap(ctx context.Context, source ociCatalogSourceDecorator) (*v1.ConfigMap, error) {
+	fresh := source.ConfigMap()
+	cm, err := o.Lister.CoreV1().ConfigMapLister().ConfigMaps(fresh.GetNamespace()).Get(fresh.GetName())
+	if !k8serrors.IsNotFound(err) {
+		return cm, err
+	}
+	cm, err = o.OpClient.KubernetesInterface().CoreV1().ConfigMaps(fresh.GetNamespace()).Create(ctx, fresh, metav1.CreateOptions{})
+	if k8serrors.IsAlreadyExists(err) {
+		// the cache is behind, fall back to the live config map
+		cm, err = o.OpClient.KubernetesInterface().CoreV1().ConfigMaps(fresh.GetNamespace()).Get(ctx, fresh.GetName(), metav1.GetOptions{})
+	}
+	return cm, err
+}
+
+func (o *OCIRegistryReconciler) ensureRole(ctx context.Context, source ociCatalogSourceDecorator) error {
+	role := source.Role()
+	if _, err := o.Lister.RbacV1().RoleLister().Roles(role.GetNamespace()).Get(role.GetName()); err == nil {
+		return nil
+	}
+	_, err := o.OpClient.CreateRole(ctx, role)
+	if k8serrors.IsAlreadyExists(err) {
+		return nil
+	}
+	return err
+}
+
+func (o *OCIRegistryReconciler) ensureRoleBinding(ctx context.Context, source ociCatalogSourceDecorator) error {
+	roleBinding := source.RoleBinding()
+	if _, err := o.Lister.RbacV1().RoleBindingLister().RoleBindings(roleBinding.GetNamespace()).Get(roleBinding.GetName()); err == nil {
+		return nil
+	}
+	_, err := o.OpClient.CreateRoleBinding(ctx, roleBinding)
+	if k8serrors.IsAlreadyExists(err) {
+		return nil
+	}
+	return err
+}
+
+// ensureJob creates the Job unpacking the catalog image of the given CatalogSource, and returns it. A Job unpacking
+// another image, or a completed Job whose catalog has since been lost, is deleted to be recreated on a later sync, in
+// which case no Job is returned.
+func (o *OCIRegistryReconciler) ensureJob(ctx context.Context, source ociCatalogSourceDecorator) (*batchv1.Job, error) {
+	fresh := source.Job(o.OPMImage, o.UtilImage, o.MirrorImage.Rewrite(source.Spec.Image))
+	client := o.OpClient.KubernetesInterface().BatchV1().Jobs(fresh.GetNamespace())
+
+	job, err := client.Get(ctx, fresh.GetName(), metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		o.Architectures.Apply(ctx, &fresh.Spec.Template.Spec, o.OPMImage, o.UtilImage)
+		return client.Create(ctx, fresh, metav1.CreateOptions{})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	stale := !equality.Semantic.DeepDerivative(fresh.Spec.Template.Spec, job.Spec.Template.Spec)
+	for _, cond := range job.Status.Conditions {
+		if cond.Type == batchv1.JobComplete && cond.Status == v1.ConditionTrue {
+			// the config map is only written by the job, check it live rather than through a possibly stale cache
+			cm, err := o.OpClient.KubernetesInterface().CoreV1().ConfigMaps(fresh.GetNamespace()).Get(ctx, source.name(), metav1.GetOptions{})
+			if err != nil {
+				return nil, err
+			}
+			stale = stale || !source.unpacked(cm)
+		}
+	}
+	if !stale {
+		return job, nil
+	}
+
+	background := metav1.DeletePropagationBackground
+	err = client.Delete(ctx, job.GetName(), metav1.DeleteOptions{PropagationPolicy: &background})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return nil, err
+	}
+	return nil, nil
+}
+
+// CheckRegistryServer returns true if the catalog of the current image of the given CatalogSource is being served.
+func (o *OCIRegistryReconciler) CheckRegistryServer(ctx context.Context, catalogSource *v1alpha1.CatalogSource) (healthy bool, err error) {
+	source := ociCatalogSourceDecorator{catalogSource}
+	if o.Servers == nil {
+		return false, fmt.Errorf("no in-memory catalog servers for sourcetype: %s", SourceTypeOCI)
+	}
+
+	cm, err := o.Lister.CoreV1().ConfigMapLister().ConfigMaps(source.GetNamespace()).Get(source.name())
+	if k8serrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return source.unpacked(cm) && o.Servers.Revision(source.key()) == cm.GetResourceVersion(), nil
+}
@@ -0,0 +1,162 @@
+package reconciler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/operator-framework/operator-registry/pkg/client"
+	"github.com/operator-framework/operator-registry/pkg/configmap"
+	"github.com/operator-framework/operator-registry/pkg/lib/encoding"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/install"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/registry"
+)
+
+const ociTestCatalog = `{"schema": "olm.package", "name": "etcd", "defaultChannel": "stable"}
+{"schema": "olm.channel", "package": "etcd", "name": "stable", "entries": [{"name": "etcdoperator.v0.9.2"}]}
+{"schema": "olm.bundle", "package": "etcd", "name": "etcdoperator.v0.9.2", "image": "quay.io/etcd/bundle:v0.9.2", "properties": [{"type": "olm.package", "value": {"packageName": "etcd", "version": "0.9.2"}}]}
+`
+
+func ociCatalogSource() *v1alpha1.CatalogSource {
+	return &v1alpha1.CatalogSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "oci-catalog",
+			Namespace: testNamespace,
+			UID:       "oci-catalog-uid",
+		},
+		Spec: v1alpha1.CatalogSourceSpec{
+			SourceType: SourceTypeOCI,
+			Image:      "quay.io/catalogs/etcd:latest",
+			Secrets:    []string{"pull-secret"},
+		},
+	}
+}
+
+func unpackedOCICatalog(t *testing.T, source *v1alpha1.CatalogSource, image string) *corev1.ConfigMap {
+	content, err := encoding.GzipBase64Encode([]byte(ociTestCatalog))
+	require.NoError(t, err)
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            source.GetName() + OCICatalogPostfix,
+			Namespace:       source.GetNamespace(),
+			ResourceVersion: "1",
+			Labels:          map[string]string{install.OLMManagedLabelKey: install.OLMManagedLabelValue},
+			Annotations: map[string]string{
+				configmap.ConfigMapImageAnnotationKey:    image,
+				configmap.ConfigMapEncodingAnnotationKey: configmap.ConfigMapEncodingAnnotationGzip,
+			},
+		},
+		BinaryData: map[string][]byte{"catalog.json": content},
+	}
+}
+
+func TestOCICatalogSourceJob(t *testing.T) {
+	source := ociCatalogSource()
+	job := (&ociCatalogSourceDecorator{source}).Job("opm:image", "util:image", "registry.internal/catalog:v1")
+
+	require.Equal(t, "oci-catalog"+OCICatalogPostfix, job.GetName())
+	require.Equal(t, []corev1.LocalObjectReference{{Name: "pull-secret"}}, job.Spec.Template.Spec.ImagePullSecrets)
+
+	pull := job.Spec.Template.Spec.InitContainers[1]
+	require.Equal(t, "registry.internal/catalog:v1", pull.Image, "the catalog image is pulled from its mirror")
+	require.Equal(t, []string{"/util/cpb", "--catalog", ociCatalogDir, "/bundle"}, pull.Command)
+
+	extract := job.Spec.Template.Spec.Containers[0]
+	require.Equal(t, "opm:image", extract.Image)
+	require.Contains(t, extract.Command, "oci-catalog"+OCICatalogPostfix)
+	require.Contains(t, extract.Env, corev1.EnvVar{Name: configmap.EnvContainerImage, Value: source.Spec.Image})
+}
+
+func TestOCIRegistryReconciler(t *testing.T) {
+	tests := []struct {
+		name      string
+		k8sObjs   func(t *testing.T, source *v1alpha1.CatalogSource) []runtime.Object
+		unpacking bool
+	}{
+		{
+			name: "Unpacking",
+			k8sObjs: func(t *testing.T, source *v1alpha1.CatalogSource) []runtime.Object {
+				return nil
+			},
+			unpacking: true,
+		},
+		{
+			name: "UnpackedOtherImage",
+			k8sObjs: func(t *testing.T, source *v1alpha1.CatalogSource) []runtime.Object {
+				return []runtime.Object{unpackedOCICatalog(t, source, "quay.io/catalogs/etcd:old")}
+			},
+			unpacking: true,
+		},
+		{
+			name: "Unpacked",
+			k8sObjs: func(t *testing.T, source *v1alpha1.CatalogSource) []runtime.Object {
+				return []runtime.Object{unpackedOCICatalog(t, source, source.Spec.Image)}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stopc := make(chan struct{})
+			defer close(stopc)
+
+			source := ociCatalogSource()
+			factory, opClient := fakeReconcilerFactory(t, stopc, withK8sObjs(tt.k8sObjs(t, source)...))
+			rec := factory.ReconcilerForSource(source)
+			defer factory.(*registryReconcilerFactory).CatalogServers.Stop(registry.CatalogKey{Name: source.GetName(), Namespace: source.GetNamespace()})
+
+			err := rec.EnsureRegistryServer(context.TODO(), source)
+			if tt.unpacking {
+				require.IsType(t, UpdateNotReadyErr{}, err)
+				require.Nil(t, source.Status.RegistryServiceStatus)
+
+				job, err := opClient.KubernetesInterface().BatchV1().Jobs(testNamespace).Get(context.TODO(), source.GetName()+OCICatalogPostfix, metav1.GetOptions{})
+				require.NoError(t, err)
+				require.Equal(t, source.Spec.Image, job.Spec.Template.Spec.InitContainers[1].Image)
+				_, err = opClient.KubernetesInterface().RbacV1().Roles(testNamespace).Get(context.TODO(), source.GetName()+OCICatalogPostfix, metav1.GetOptions{})
+				require.NoError(t, err)
+
+				healthy, err := rec.CheckRegistryServer(context.TODO(), source)
+				require.NoError(t, err)
+				require.False(t, healthy)
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotNil(t, source.Status.RegistryServiceStatus)
+
+			healthy, err := rec.CheckRegistryServer(context.TODO(), source)
+			require.NoError(t, err)
+			require.True(t, healthy)
+
+			_, err = opClient.KubernetesInterface().BatchV1().Jobs(testNamespace).Get(context.TODO(), source.GetName()+OCICatalogPostfix, metav1.GetOptions{})
+			require.Error(t, err, "no unpack job expected for an unpacked catalog")
+		})
+	}
+}
+
+func TestOCIRegistryReconcilerServesCatalog(t *testing.T) {
+	stopc := make(chan struct{})
+	defer close(stopc)
+
+	source := ociCatalogSource()
+	factory, _ := fakeReconcilerFactory(t, stopc, withK8sObjs(unpackedOCICatalog(t, source, source.Spec.Image)))
+	servers := factory.(*registryReconcilerFactory).CatalogServers
+	key := registry.CatalogKey{Name: source.GetName(), Namespace: source.GetNamespace()}
+	defer servers.Stop(key)
+
+	require.NoError(t, factory.ReconcilerForSource(source).EnsureRegistryServer(context.TODO(), source))
+
+	c, err := client.NewClient(servers.Address(key))
+	require.NoError(t, err)
+	defer c.Close()
+
+	bundle, err := c.GetBundleInPackageChannel(context.TODO(), "etcd", "stable")
+	require.NoError(t, err)
+	require.Equal(t, "etcdoperator.v0.9.2", bundle.GetCsvName())
+	require.Equal(t, "quay.io/etcd/bundle:v0.9.2", bundle.GetBundlePath())
+}
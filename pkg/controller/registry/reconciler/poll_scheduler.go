@@ -0,0 +1,157 @@
+package reconciler
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/queueinformer"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/metrics"
+)
+
+const (
+	// pollRetryJitter is the jitter factor of the period after which a CatalogSource whose poll was held back retries,
+	// so that the held back polls don't all retry at once.
+	pollRetryJitter = 0.5
+
+	// pollWaiterExpiry is how long a CatalogSource whose poll was held back keeps its place in the queue without
+	// retrying, e.g. because it was deleted, and how long a created update pod counts as in flight before the pod
+	// lister sees it.
+	pollWaiterExpiry = 4 * CatalogPollingRequeuePeriod
+)
+
+// PollThrottledErr is returned when a CatalogSource has to poll its image, but its poll is held back because too many
+// polls are in flight or CatalogSources of a higher priority, or waiting for longer, are queued.
+type PollThrottledErr struct {
+	catalogName string
+}
+
+func (p PollThrottledErr) Error() string {
+	return fmt.Sprintf("catalog polling: %s waiting for in-flight catalog polls to complete", p.catalogName)
+}
+
+// pollWaiter is a CatalogSource whose poll is held back.
+type pollWaiter struct {
+	priority int
+	since    time.Time
+	seen     time.Time
+}
+
+// PollScheduler limits the image polls of CatalogSources in flight across the cluster, i.e. their update pods, so that
+// the image checks and pod restarts of CatalogSources polling on similar intervals don't cluster together. Held back
+// polls are admitted by decreasing CatalogSource priority, then in the order they were held back.
+type PollScheduler struct {
+	// maxParallel is the maximum number of polls in flight, or 0 if unlimited.
+	maxParallel int
+
+	mu sync.Mutex
+	// created are the keys of the CatalogSources whose update pod was created since it was last seen by the pod lister,
+	// and when
+	created map[string]time.Time
+	waiting map[string]*pollWaiter
+}
+
+// NewPollScheduler returns a PollScheduler admitting at most maxParallel polls in flight, or any number of them if
+// maxParallel is 0.
+func NewPollScheduler(maxParallel int) *PollScheduler {
+	return &PollScheduler{
+		maxParallel: maxParallel,
+		created:     map[string]time.Time{},
+		waiting:     map[string]*pollWaiter{},
+	}
+}
+
+// RetryAfter returns the jittered period after which a CatalogSource whose poll was held back retries.
+func (s *PollScheduler) RetryAfter() time.Duration {
+	return queueinformer.ResyncWithJitter(CatalogPollingRequeuePeriod, pollRetryJitter)()
+}
+
+// admit returns true if the given CatalogSource can create its update pod, given the update pods in flight, and
+// records its creation if so.
+func (s *PollScheduler) admit(updatePods []*corev1.Pod, source *v1alpha1.CatalogSource, now time.Time) bool {
+	if s == nil || s.maxParallel <= 0 {
+		return true
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := source.GetNamespace() + "/" + source.GetName()
+	inFlight := map[string]struct{}{}
+	for _, pod := range updatePods {
+		podKey := pod.GetNamespace() + "/" + pod.GetLabels()[CatalogSourceUpdateKey]
+		delete(s.created, podKey)
+		inFlight[podKey] = struct{}{}
+	}
+	for k, at := range s.created {
+		if now.Sub(at) > pollWaiterExpiry {
+			// the update pod was deleted before the pod lister saw it
+			delete(s.created, k)
+			continue
+		}
+		inFlight[k] = struct{}{}
+	}
+
+	w, ok := s.waiting[key]
+	if !ok {
+		w = &pollWaiter{since: now}
+		s.waiting[key] = w
+	}
+	w.priority = source.Spec.Priority
+	w.seen = now
+
+	if len(inFlight) < s.maxParallel && !s.preempted(key, w, now) {
+		delete(s.waiting, key)
+		s.created[key] = now
+		metrics.EmitCatalogPollQueueWait(now.Sub(w.since))
+		metrics.SetCatalogPollsWaiting(len(s.waiting))
+		return true
+	}
+	metrics.SetCatalogPollsWaiting(len(s.waiting))
+	return false
+}
+
+// preempted returns true if another CatalogSource of a higher priority, or of the same priority and waiting for
+// longer, is queued ahead of the given one. Waiters that stopped retrying are forgotten.
+func (s *PollScheduler) preempted(key string, w *pollWaiter, now time.Time) bool {
+	for k, other := range s.waiting {
+		if k == key {
+			continue
+		}
+		if now.Sub(other.seen) > pollWaiterExpiry {
+			delete(s.waiting, k)
+			continue
+		}
+		if other.priority > w.priority || (other.priority == w.priority && other.since.Before(w.since)) {
+			return true
+		}
+	}
+	return false
+}
+
+// release forgets the creation of the update pod of the given CatalogSource, when it failed.
+func (s *PollScheduler) release(source *v1alpha1.CatalogSource) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.created, source.GetNamespace()+"/"+source.GetName())
+}
+
+// updatePodSelector selects the update pods of all CatalogSources. Promoted update pods keep an empty update label.
+func updatePodSelector() labels.Selector {
+	exists, err := labels.NewRequirement(CatalogSourceUpdateKey, selection.Exists, nil)
+	if err != nil {
+		panic(err)
+	}
+	notEmpty, err := labels.NewRequirement(CatalogSourceUpdateKey, selection.NotEquals, []string{""})
+	if err != nil {
+		panic(err)
+	}
+	return labels.NewSelector().Add(*exists, *notEmpty)
+}
@@ -0,0 +1,77 @@
+package reconciler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+)
+
+func TestPollScheduler(t *testing.T) {
+	now := time.Date(2021, time.October, 1, 12, 0, 0, 0, time.UTC)
+	catsrc := func(name string, priority int) *v1alpha1.CatalogSource {
+		return &v1alpha1.CatalogSource{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "olm", Name: name},
+			Spec:       v1alpha1.CatalogSourceSpec{Priority: priority},
+		}
+	}
+	updatePod := func(catalog string) *corev1.Pod {
+		return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Namespace: "olm",
+			Name:      catalog + "-update",
+			Labels:    map[string]string{CatalogSourceUpdateKey: catalog},
+		}}
+	}
+
+	t.Run("Unlimited", func(t *testing.T) {
+		var s *PollScheduler
+		require.True(t, s.admit([]*corev1.Pod{updatePod("a")}, catsrc("b", 0), now))
+		require.True(t, NewPollScheduler(0).admit([]*corev1.Pod{updatePod("a")}, catsrc("b", 0), now))
+	})
+
+	t.Run("Limit", func(t *testing.T) {
+		s := NewPollScheduler(1)
+		require.True(t, s.admit(nil, catsrc("a", 0), now))
+		require.False(t, s.admit(nil, catsrc("b", 0), now), "created update pods are in flight until seen")
+		require.False(t, s.admit([]*corev1.Pod{updatePod("a")}, catsrc("b", 0), now))
+		require.True(t, s.admit(nil, catsrc("b", 0), now.Add(time.Second)), "the update pod of a completed poll is gone")
+		s.release(catsrc("b", 0))
+		require.True(t, s.admit(nil, catsrc("c", 0), now.Add(time.Second)), "update pods that failed to be created aren't in flight")
+	})
+
+	t.Run("Priority", func(t *testing.T) {
+		s := NewPollScheduler(1)
+		inFlight := []*corev1.Pod{updatePod("busy")}
+		require.False(t, s.admit(inFlight, catsrc("low", 0), now))
+		require.False(t, s.admit(inFlight, catsrc("high", 10), now.Add(time.Second)))
+		require.False(t, s.admit(inFlight, catsrc("later", 0), now.Add(2*time.Second)))
+
+		require.False(t, s.admit(nil, catsrc("low", 0), now.Add(3*time.Second)), "higher priority catalogs go first")
+		require.True(t, s.admit(nil, catsrc("high", 10), now.Add(3*time.Second)))
+
+		inFlight = []*corev1.Pod{updatePod("high")}
+		require.False(t, s.admit(inFlight, catsrc("low", 0), now.Add(4*time.Second)))
+		require.False(t, s.admit(nil, catsrc("later", 0), now.Add(5*time.Second)), "catalogs held back for longer go first")
+		require.True(t, s.admit(nil, catsrc("low", 0), now.Add(5*time.Second)))
+	})
+
+	t.Run("Expiry", func(t *testing.T) {
+		s := NewPollScheduler(1)
+		require.False(t, s.admit([]*corev1.Pod{updatePod("busy")}, catsrc("deleted", 10), now))
+		require.False(t, s.admit(nil, catsrc("a", 0), now.Add(time.Second)))
+		require.True(t, s.admit(nil, catsrc("a", 0), now.Add(pollWaiterExpiry+2*time.Second)), "catalogs that stopped retrying lose their place")
+		require.True(t, s.admit(nil, catsrc("b", 0), now.Add(3*pollWaiterExpiry)), "unseen update pods stop counting as in flight")
+	})
+}
+
+func TestUpdatePodSelector(t *testing.T) {
+	selector := updatePodSelector()
+	require.True(t, selector.Matches(labels.Set{CatalogSourceUpdateKey: "catalog"}))
+	require.False(t, selector.Matches(labels.Set{CatalogSourceUpdateKey: "", CatalogSourceLabelKey: "catalog"}), "promoted update pods aren't in flight")
+	require.False(t, selector.Matches(labels.Set{CatalogSourceLabelKey: "catalog"}))
+}
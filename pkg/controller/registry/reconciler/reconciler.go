@@ -2,6 +2,7 @@
 package reconciler
 
 import (
+	"context"
 	"fmt"
 	"hash/fnv"
 	"strings"
@@ -12,7 +13,10 @@ import (
 	"k8s.io/apimachinery/pkg/util/rand"
 
 	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/registry/fbc"
 	controllerclient "github.com/operator-framework/operator-lifecycle-manager/pkg/lib/controller-runtime/client"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/imagearch"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/imagemirror"
 	hashutil "github.com/operator-framework/operator-lifecycle-manager/pkg/lib/kubernetes/pkg/util/hash"
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/operatorclient"
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/operatorlister"
@@ -27,18 +31,21 @@ const (
 	CatalogPriorityClassKey string = "operatorframework.io/priorityclass"
 	// PodHashLabelKey is the key of a label for podspec hash information
 	PodHashLabelKey = "olm.pod-spec-hash"
+	// QuarantinedBundlesAnnotationKey is the key of the CatalogSource annotation reporting the bundles excluded from
+	// resolution because their CSV fails static validation.
+	QuarantinedBundlesAnnotationKey = "operatorframework.io/quarantined-bundles"
 )
 
 // RegistryEnsurer describes methods for ensuring a registry exists.
 type RegistryEnsurer interface {
 	// EnsureRegistryServer ensures a registry server exists for the given CatalogSource.
-	EnsureRegistryServer(catalogSource *v1alpha1.CatalogSource) error
+	EnsureRegistryServer(ctx context.Context, catalogSource *v1alpha1.CatalogSource) error
 }
 
 // RegistryChecker describes methods for checking a registry.
 type RegistryChecker interface {
 	// CheckRegistryServer returns true if the given CatalogSource is considered healthy; false otherwise.
-	CheckRegistryServer(catalogSource *v1alpha1.CatalogSource) (healthy bool, err error)
+	CheckRegistryServer(ctx context.Context, catalogSource *v1alpha1.CatalogSource) (healthy bool, err error)
 }
 
 // RegistryReconciler knows how to reconcile a registry.
@@ -58,7 +65,14 @@ type registryReconcilerFactory struct {
 	Lister               operatorlister.OperatorLister
 	OpClient             operatorclient.ClientInterface
 	ConfigMapServerImage string
+	OPMImage             string
+	UtilImage            string
+	CatalogServers       *fbc.Servers
 	SSAClient            *controllerclient.ServerSideApplier
+	Polls                *PollScheduler
+	Architectures        imagearch.RequiredFunc
+	ResolveDigest        ResolveDigestFunc
+	MirrorImage          imagemirror.RewriteFunc
 }
 
 // ReconcilerForSource returns a RegistryReconciler based on the configuration of the given CatalogSource.
@@ -67,39 +81,90 @@ func (r *registryReconcilerFactory) ReconcilerForSource(source *v1alpha1.Catalog
 	switch source.Spec.SourceType {
 	case v1alpha1.SourceTypeInternal, v1alpha1.SourceTypeConfigmap:
 		return &ConfigMapRegistryReconciler{
-			now:      r.now,
-			Lister:   r.Lister,
-			OpClient: r.OpClient,
-			Image:    r.ConfigMapServerImage,
+			now:           r.now,
+			Lister:        r.Lister,
+			OpClient:      r.OpClient,
+			Image:         r.ConfigMapServerImage,
+			Architectures: r.Architectures,
 		}
 	case v1alpha1.SourceTypeGrpc:
 		if source.Spec.Image != "" {
 			return &GrpcRegistryReconciler{
-				now:       r.now,
-				Lister:    r.Lister,
-				OpClient:  r.OpClient,
-				SSAClient: r.SSAClient,
+				now:           r.now,
+				Lister:        r.Lister,
+				OpClient:      r.OpClient,
+				SSAClient:     r.SSAClient,
+				Polls:         r.Polls,
+				Architectures: r.Architectures,
+				ResolveDigest: r.ResolveDigest,
+				MirrorImage:   r.MirrorImage,
 			}
 		} else if source.Spec.Address != "" {
 			return &GrpcAddressRegistryReconciler{
 				now: r.now,
 			}
 		}
+	case SourceTypeOCI:
+		return &OCIRegistryReconciler{
+			now:           r.now,
+			Lister:        r.Lister,
+			OpClient:      r.OpClient,
+			OPMImage:      r.OPMImage,
+			UtilImage:     r.UtilImage,
+			Servers:       r.CatalogServers,
+			Architectures: r.Architectures,
+			MirrorImage:   r.MirrorImage,
+		}
+	case SourceTypeAggregate:
+		return &AggregateRegistryReconciler{
+			now:     r.now,
+			Lister:  r.Lister,
+			factory: r,
+		}
 	}
 	return nil
 }
 
 // NewRegistryReconcilerFactory returns an initialized RegistryReconcilerFactory.
-func NewRegistryReconcilerFactory(lister operatorlister.OperatorLister, opClient operatorclient.ClientInterface, configMapServerImage string, now nowFunc, ssaClient *controllerclient.ServerSideApplier) RegistryReconcilerFactory {
+func NewRegistryReconcilerFactory(lister operatorlister.OperatorLister, opClient operatorclient.ClientInterface, configMapServerImage, opmImage, utilImage string, catalogServers *fbc.Servers, now nowFunc, ssaClient *controllerclient.ServerSideApplier, polls *PollScheduler, architectures imagearch.RequiredFunc, mirrorImage imagemirror.RewriteFunc) RegistryReconcilerFactory {
 	return &registryReconcilerFactory{
 		now:                  now,
 		Lister:               lister,
 		OpClient:             opClient,
 		ConfigMapServerImage: configMapServerImage,
+		OPMImage:             opmImage,
+		UtilImage:            utilImage,
+		CatalogServers:       catalogServers,
 		SSAClient:            ssaClient,
+		Polls:                polls,
+		Architectures:        architectures,
+		ResolveDigest:        ResolveImageDigest,
+		MirrorImage:          mirrorImage,
 	}
 }
 
+// statusAnnotations are the annotations OLM reports the state of CatalogSources in, which aren't copied onto their
+// registry pods.
+var statusAnnotations = map[string]struct{}{
+	QuarantinedBundlesAnnotationKey:  {},
+	CatalogImageDigestsAnnotationKey: {},
+}
+
+// podAnnotations returns the annotations of the given CatalogSource to copy onto its registry pods.
+func podAnnotations(source *v1alpha1.CatalogSource) map[string]string {
+	var annotations map[string]string
+	for key, value := range source.GetAnnotations() {
+		if _, ok := statusAnnotations[key]; ok {
+			continue
+		}
+		if annotations == nil {
+			annotations = make(map[string]string, len(source.GetAnnotations()))
+		}
+		annotations[key] = value
+	}
+	return annotations
+}
+
 func Pod(source *v1alpha1.CatalogSource, name string, image string, saName string, labels map[string]string, annotations map[string]string, readinessDelay int32, livenessDelay int32) *v1.Pod {
 	// Ensure the catalog image is always pulled if the image is not based on a digest, measured by whether an "@" is included.
 	// See https://github.com/docker/distribution/blob/master/reference/reference.go for more info.
@@ -195,6 +260,12 @@ func Pod(source *v1alpha1.CatalogSource, name string, image string, saName strin
 		}
 	}
 
+	// Set the affinity and topology spread constraints of the CatalogSource annotations
+	// Invalid annotations are reported when the registry server is ensured
+	if config, err := registryPodConfigFor(source); err == nil {
+		config.apply(source, &pod.Spec)
+	}
+
 	// Set priorityclass if its annotation exists
 	if prio, ok := annotations[CatalogPriorityClassKey]; ok && prio != "" {
 		pod.Spec.PriorityClassName = prio
@@ -0,0 +1,137 @@
+package reconciler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/ownerutil"
+)
+
+const (
+	// RegistryPodAffinityAnnotationKey is the CatalogSource annotation setting, as JSON, the affinity of its registry
+	// pods. Node affinity required for the architectures of the catalog image is added to it.
+	RegistryPodAffinityAnnotationKey = "operatorframework.io/registry-pod-affinity"
+
+	// RegistryPodTopologySpreadConstraintsAnnotationKey is the CatalogSource annotation setting, as a JSON list, the
+	// topology spread constraints of its registry pods. Constraints without a label selector select its registry pods.
+	RegistryPodTopologySpreadConstraintsAnnotationKey = "operatorframework.io/registry-pod-topology-spread-constraints"
+
+	// RegistryPodDisruptionBudgetAnnotationKey is the CatalogSource annotation setting, as JSON, the minAvailable or
+	// maxUnavailable of a PodDisruptionBudget covering its registry pods. The budget is deleted with the annotation.
+	RegistryPodDisruptionBudgetAnnotationKey = "operatorframework.io/registry-pod-disruption-budget"
+)
+
+// registryPodConfig is the scheduling and disruption configuration of the registry pods of a CatalogSource, set by its
+// annotations.
+type registryPodConfig struct {
+	affinity                  *v1.Affinity
+	topologySpreadConstraints []v1.TopologySpreadConstraint
+	disruptionBudget          *policyv1.PodDisruptionBudgetSpec
+}
+
+// registryPodConfigFor returns the registry pod configuration set by the annotations of the given CatalogSource.
+func registryPodConfigFor(source *v1alpha1.CatalogSource) (registryPodConfig, error) {
+	var config registryPodConfig
+	annotations := source.GetAnnotations()
+	if value, ok := annotations[RegistryPodAffinityAnnotationKey]; ok {
+		config.affinity = &v1.Affinity{}
+		if err := json.Unmarshal([]byte(value), config.affinity); err != nil {
+			return registryPodConfig{}, fmt.Errorf("invalid %s annotation: %v", RegistryPodAffinityAnnotationKey, err)
+		}
+	}
+	if value, ok := annotations[RegistryPodTopologySpreadConstraintsAnnotationKey]; ok {
+		if err := json.Unmarshal([]byte(value), &config.topologySpreadConstraints); err != nil {
+			return registryPodConfig{}, fmt.Errorf("invalid %s annotation: %v", RegistryPodTopologySpreadConstraintsAnnotationKey, err)
+		}
+		for _, constraint := range config.topologySpreadConstraints {
+			if constraint.MaxSkew < 1 || constraint.TopologyKey == "" {
+				return registryPodConfig{}, fmt.Errorf("invalid %s annotation: constraints must set a positive maxSkew and a topologyKey", RegistryPodTopologySpreadConstraintsAnnotationKey)
+			}
+		}
+	}
+	if value, ok := annotations[RegistryPodDisruptionBudgetAnnotationKey]; ok {
+		config.disruptionBudget = &policyv1.PodDisruptionBudgetSpec{}
+		if err := json.Unmarshal([]byte(value), config.disruptionBudget); err != nil {
+			return registryPodConfig{}, fmt.Errorf("invalid %s annotation: %v", RegistryPodDisruptionBudgetAnnotationKey, err)
+		}
+		if (config.disruptionBudget.MinAvailable == nil) == (config.disruptionBudget.MaxUnavailable == nil) {
+			return registryPodConfig{}, fmt.Errorf("invalid %s annotation: exactly one of minAvailable and maxUnavailable must be set", RegistryPodDisruptionBudgetAnnotationKey)
+		}
+	}
+	return config, nil
+}
+
+// apply sets the affinity and topology spread constraints of the registry pods of the given CatalogSource on the given
+// pod spec.
+func (c registryPodConfig) apply(source *v1alpha1.CatalogSource, spec *v1.PodSpec) {
+	if c.affinity != nil {
+		spec.Affinity = c.affinity.DeepCopy()
+	}
+	for _, constraint := range c.topologySpreadConstraints {
+		constraint := *constraint.DeepCopy()
+		if constraint.LabelSelector == nil {
+			constraint.LabelSelector = &metav1.LabelSelector{MatchLabels: map[string]string{CatalogSourceLabelKey: source.GetName()}}
+		}
+		spec.TopologySpreadConstraints = append(spec.TopologySpreadConstraints, constraint)
+	}
+}
+
+// podDisruptionBudget returns the PodDisruptionBudget covering the serving registry pods of the given CatalogSource.
+func (c registryPodConfig) podDisruptionBudget(source *v1alpha1.CatalogSource) *policyv1.PodDisruptionBudget {
+	pdb := &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      source.GetName(),
+			Namespace: source.GetNamespace(),
+			Labels:    map[string]string{CatalogSourceLabelKey: source.GetName()},
+		},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			MinAvailable:   c.disruptionBudget.MinAvailable,
+			MaxUnavailable: c.disruptionBudget.MaxUnavailable,
+			Selector:       &metav1.LabelSelector{MatchLabels: map[string]string{CatalogSourceLabelKey: source.GetName()}},
+		},
+	}
+	ownerutil.AddOwner(pdb, source, false, false)
+	return pdb
+}
+
+// ensurePodDisruptionBudget creates, updates or deletes the PodDisruptionBudget of the registry pods of the given
+// CatalogSource, depending on its registry pod configuration. PodDisruptionBudgets it doesn't own are left alone.
+func ensurePodDisruptionBudget(ctx context.Context, client kubernetes.Interface, source *v1alpha1.CatalogSource, config registryPodConfig) error {
+	pdbs := client.PolicyV1().PodDisruptionBudgets(source.GetNamespace())
+	current, err := pdbs.Get(ctx, source.GetName(), metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		if config.disruptionBudget == nil {
+			return nil
+		}
+		_, err = pdbs.Create(ctx, config.podDisruptionBudget(source), metav1.CreateOptions{})
+		return err
+	}
+	if err != nil {
+		return err
+	}
+	if !ownerutil.IsOwnedBy(current, source) {
+		return nil
+	}
+	if config.disruptionBudget == nil {
+		if err := pdbs.Delete(ctx, current.GetName(), metav1.DeleteOptions{}); err != nil && !k8serrors.IsNotFound(err) {
+			return err
+		}
+		return nil
+	}
+	desired := config.podDisruptionBudget(source)
+	if equality.Semantic.DeepEqual(current.Spec, desired.Spec) {
+		return nil
+	}
+	current.Spec = desired.Spec
+	_, err = pdbs.Update(ctx, current, metav1.UpdateOptions{})
+	return err
+}
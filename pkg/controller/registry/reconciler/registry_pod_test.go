@@ -0,0 +1,132 @@
+package reconciler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+)
+
+func catalogSourceWithAnnotations(annotations map[string]string) *v1alpha1.CatalogSource {
+	return &v1alpha1.CatalogSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "test",
+			Namespace:   "testns",
+			UID:         "catalog-uid",
+			Annotations: annotations,
+		},
+	}
+}
+
+func TestRegistryPodConfigFor(t *testing.T) {
+	for _, tt := range []struct {
+		name        string
+		annotations map[string]string
+		wantErr     string
+	}{
+		{
+			name: "Valid",
+			annotations: map[string]string{
+				RegistryPodAffinityAnnotationKey:                  `{"podAntiAffinity":{"preferredDuringSchedulingIgnoredDuringExecution":[{"weight":100,"podAffinityTerm":{"topologyKey":"kubernetes.io/hostname"}}]}}`,
+				RegistryPodTopologySpreadConstraintsAnnotationKey: `[{"maxSkew":1,"topologyKey":"topology.kubernetes.io/zone","whenUnsatisfiable":"ScheduleAnyway"}]`,
+				RegistryPodDisruptionBudgetAnnotationKey:          `{"maxUnavailable":1}`,
+			},
+		},
+		{
+			name:        "MalformedAffinity",
+			annotations: map[string]string{RegistryPodAffinityAnnotationKey: `[]`},
+			wantErr:     "invalid operatorframework.io/registry-pod-affinity annotation: json: cannot unmarshal array into Go value of type v1.Affinity",
+		},
+		{
+			name:        "NoTopologyKey",
+			annotations: map[string]string{RegistryPodTopologySpreadConstraintsAnnotationKey: `[{"maxSkew":1}]`},
+			wantErr:     "invalid operatorframework.io/registry-pod-topology-spread-constraints annotation: constraints must set a positive maxSkew and a topologyKey",
+		},
+		{
+			name:        "BothBudgets",
+			annotations: map[string]string{RegistryPodDisruptionBudgetAnnotationKey: `{"minAvailable":1,"maxUnavailable":1}`},
+			wantErr:     "invalid operatorframework.io/registry-pod-disruption-budget annotation: exactly one of minAvailable and maxUnavailable must be set",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := registryPodConfigFor(catalogSourceWithAnnotations(tt.annotations))
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestPodRegistryPodConfig(t *testing.T) {
+	source := catalogSourceWithAnnotations(map[string]string{
+		RegistryPodAffinityAnnotationKey:                  `{"nodeAffinity":{"requiredDuringSchedulingIgnoredDuringExecution":{"nodeSelectorTerms":[{"matchExpressions":[{"key":"node-role.kubernetes.io/infra","operator":"Exists"}]}]}}}`,
+		RegistryPodTopologySpreadConstraintsAnnotationKey: `[{"maxSkew":1,"topologyKey":"topology.kubernetes.io/zone","whenUnsatisfiable":"ScheduleAnyway"}]`,
+	})
+	pod := Pod(source, "hello", "busybox", "", map[string]string{}, source.GetAnnotations(), int32(0), int32(0))
+
+	require.Equal(t, "node-role.kubernetes.io/infra", pod.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[0].MatchExpressions[0].Key)
+	require.Equal(t, []corev1.TopologySpreadConstraint{{
+		MaxSkew:           1,
+		TopologyKey:       "topology.kubernetes.io/zone",
+		WhenUnsatisfiable: corev1.ScheduleAnyway,
+		LabelSelector:     &metav1.LabelSelector{MatchLabels: map[string]string{CatalogSourceLabelKey: "test"}},
+	}}, pod.Spec.TopologySpreadConstraints)
+
+	// Changing the configuration changes the pod spec hash, recreating the registry pods
+	source.Annotations[RegistryPodTopologySpreadConstraintsAnnotationKey] = `[{"maxSkew":2,"topologyKey":"topology.kubernetes.io/zone","whenUnsatisfiable":"ScheduleAnyway"}]`
+	updated := Pod(source, "hello", "busybox", "", map[string]string{}, source.GetAnnotations(), int32(0), int32(0))
+	require.NotEqual(t, pod.GetLabels()[PodHashLabelKey], updated.GetLabels()[PodHashLabelKey])
+}
+
+func TestEnsurePodDisruptionBudget(t *testing.T) {
+	client := k8sfake.NewSimpleClientset()
+	source := catalogSourceWithAnnotations(map[string]string{RegistryPodDisruptionBudgetAnnotationKey: `{"minAvailable":1}`})
+	get := func() (*policyv1.PodDisruptionBudget, error) {
+		return client.PolicyV1().PodDisruptionBudgets("testns").Get(context.TODO(), "test", metav1.GetOptions{})
+	}
+	ensure := func() {
+		config, err := registryPodConfigFor(source)
+		require.NoError(t, err)
+		require.NoError(t, ensurePodDisruptionBudget(context.TODO(), client, source, config))
+	}
+
+	ensure()
+	pdb, err := get()
+	require.NoError(t, err)
+	one := intstr.FromInt(1)
+	require.Equal(t, &one, pdb.Spec.MinAvailable)
+	require.Equal(t, map[string]string{CatalogSourceLabelKey: "test"}, pdb.Spec.Selector.MatchLabels)
+	require.Equal(t, source.GetUID(), pdb.GetOwnerReferences()[0].UID)
+
+	source.Annotations[RegistryPodDisruptionBudgetAnnotationKey] = `{"maxUnavailable":"50%"}`
+	ensure()
+	pdb, err = get()
+	require.NoError(t, err)
+	half := intstr.FromString("50%")
+	require.Nil(t, pdb.Spec.MinAvailable)
+	require.Equal(t, &half, pdb.Spec.MaxUnavailable)
+
+	delete(source.Annotations, RegistryPodDisruptionBudgetAnnotationKey)
+	ensure()
+	_, err = get()
+	require.True(t, k8serrors.IsNotFound(err))
+
+	// PodDisruptionBudgets the CatalogSource doesn't own are left alone
+	_, err = client.PolicyV1().PodDisruptionBudgets("testns").Create(context.TODO(), &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "testns"},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	ensure()
+	_, err = get()
+	require.NoError(t, err)
+}
@@ -11,8 +11,10 @@ import (
 	"github.com/sirupsen/logrus"
 	"k8s.io/apimachinery/pkg/util/errors"
 
+	operatorsv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/api/client/listers/operators/v1alpha1"
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/operatorlister"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/metrics"
 )
 
 const existingOperatorKey = "@existing"
@@ -166,28 +168,31 @@ func (c *Cache) Namespaced(namespaces ...string) MultiCatalogOperatorFinder {
 		snapshots: make(map[SourceKey]*snapshotHeader),
 	}
 
+	// Snapshots are only valid for the revision of their catalog source they were taken at
+	revisions := make(map[SourceKey]string, len(sources))
+	priorities := make(map[SourceKey]int, len(sources))
+	for key := range sources {
+		// Ignoring error and treat catsrc priority as 0 if not found.
+		if catsrc, _ := c.catsrcLister.CatalogSources(key.Namespace).Get(key.Name); catsrc != nil {
+			revisions[key] = catalogRevision(catsrc)
+			priorities[key] = catsrc.Spec.Priority
+		}
+	}
+
 	var misses []SourceKey
 	func() {
 		c.m.RLock()
 		defer c.m.RUnlock()
 		for key := range sources {
 			snapshot, ok := c.snapshots[key]
-			if ok {
-				func() {
-					snapshot.m.RLock()
-					defer snapshot.m.RUnlock()
-					if snapshot.Valid(now) {
-						result.snapshots[key] = snapshot
-					} else {
-						misses = append(misses, key)
-					}
-				}()
-			}
-			if !ok {
+			if ok && snapshot.Valid(now) && snapshot.revision == revisions[key] {
+				result.snapshots[key] = snapshot
+			} else {
 				misses = append(misses, key)
 			}
 		}
 	}()
+	metrics.EmitCatalogSnapshotCacheLookups(len(result.snapshots), len(misses))
 
 	if len(misses) == 0 {
 		return &result
@@ -211,7 +216,7 @@ func (c *Cache) Namespaced(namespaces ...string) MultiCatalogOperatorFinder {
 	// Check for any snapshots that were populated while waiting to acquire the lock.
 	var found int
 	for i := range misses {
-		if hdr, ok := c.snapshots[misses[i]]; ok && hdr.Valid(now) {
+		if hdr, ok := c.snapshots[misses[i]]; ok && hdr.Valid(now) && hdr.revision == revisions[misses[i]] {
 			result.snapshots[misses[i]] = hdr
 			misses[found], misses[i] = misses[i], misses[found]
 			found++
@@ -223,14 +228,11 @@ func (c *Cache) Namespaced(namespaces ...string) MultiCatalogOperatorFinder {
 		ctx, cancel := context.WithTimeout(context.Background(), CachePopulateTimeout)
 
 		hdr := snapshotHeader{
-			key:    miss,
-			expiry: now.Add(c.ttl),
-			pop:    cancel,
-		}
-
-		// Ignoring error and treat catsrc priority as 0 if not found.
-		if catsrc, _ := c.catsrcLister.CatalogSources(miss.Namespace).Get(miss.Name); catsrc != nil {
-			hdr.priority = catsrc.Spec.Priority
+			key:      miss,
+			expiry:   now.Add(c.ttl),
+			pop:      cancel,
+			revision: revisions[miss],
+			priority: priorities[miss],
 		}
 
 		hdr.m.Lock()
@@ -306,7 +308,9 @@ func (s *Snapshot) Snapshot(context.Context) (*Snapshot, error) {
 type snapshotHeader struct {
 	snapshot *Snapshot
 
-	key      SourceKey
+	key SourceKey
+	// revision is the revision of the catalog source the snapshot was taken at, see catalogRevision.
+	revision string
 	expiry   time.Time
 	m        sync.RWMutex
 	pop      context.CancelFunc
@@ -314,6 +318,19 @@ type snapshotHeader struct {
 	priority int
 }
 
+// catalogRevision identifies the content served by a catalog source: it changes when its spec is updated or its
+// registry server is rolled out or moved, but not on connection state updates.
+func catalogRevision(catsrc *operatorsv1alpha1.CatalogSource) string {
+	var createdAt, address string
+	if status := catsrc.Status.RegistryServiceStatus; status != nil {
+		createdAt = status.CreatedAt.UTC().Format(time.RFC3339)
+	}
+	if state := catsrc.Status.GRPCConnectionState; state != nil {
+		address = state.Address
+	}
+	return fmt.Sprintf("%d/%s/%s", catsrc.GetGeneration(), createdAt, address)
+}
+
 func (hdr *snapshotHeader) Cancel() {
 	hdr.pop()
 }
@@ -11,6 +11,11 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	toolscache "k8s.io/client-go/tools/cache"
+
+	operatorsv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/api/client/listers/operators/v1alpha1"
 )
 
 func TestOperatorCacheConcurrency(t *testing.T) {
@@ -105,6 +110,47 @@ func TestOperatorCacheReuse(t *testing.T) {
 	require.Len(t, c.Namespaced("dummynamespace").Catalog(key).Find(CSVNamePredicate("v1")), 1)
 }
 
+func TestOperatorCacheCatalogRevision(t *testing.T) {
+	key := SourceKey{Namespace: "dummynamespace", Name: "dummyname"}
+	catsrc := &operatorsv1alpha1.CatalogSource{
+		ObjectMeta: metav1.ObjectMeta{Namespace: key.Namespace, Name: key.Name, Generation: 1, ResourceVersion: "1"},
+		Status: operatorsv1alpha1.CatalogSourceStatus{
+			RegistryServiceStatus: &operatorsv1alpha1.RegistryServiceStatus{CreatedAt: metav1.Unix(1, 0)},
+			GRPCConnectionState:   &operatorsv1alpha1.GRPCConnectionState{Address: "dummyname.dummynamespace.svc:50051", LastObservedState: "READY"},
+		},
+	}
+	indexer := toolscache.NewIndexer(toolscache.MetaNamespaceKeyFunc, toolscache.Indexers{toolscache.NamespaceIndex: toolscache.MetaNamespaceIndexFunc})
+	require.NoError(t, indexer.Add(catsrc))
+
+	ssp := make(StaticSourceProvider)
+	c := New(ssp, WithCatalogSourceLister(v1alpha1.NewCatalogSourceLister(indexer)))
+	ssp[key] = &Snapshot{Entries: []*Entry{{Name: "v1"}}}
+	require.Len(t, c.Namespaced("dummynamespace").Catalog(key).Find(CSVNamePredicate("v1")), 1)
+
+	// connection state updates keep the snapshot
+	ssp[key] = &Snapshot{Entries: []*Entry{{Name: "v2"}}}
+	catsrc = catsrc.DeepCopy()
+	catsrc.ResourceVersion = "2"
+	catsrc.Status.GRPCConnectionState.LastObservedState = "TRANSIENT_FAILURE"
+	require.NoError(t, indexer.Update(catsrc))
+	require.Len(t, c.Namespaced("dummynamespace").Catalog(key).Find(CSVNamePredicate("v1")), 1)
+
+	// registry server rollouts invalidate it
+	catsrc = catsrc.DeepCopy()
+	catsrc.ResourceVersion = "3"
+	catsrc.Status.RegistryServiceStatus.CreatedAt = metav1.Unix(2, 0)
+	require.NoError(t, indexer.Update(catsrc))
+	require.Len(t, c.Namespaced("dummynamespace").Catalog(key).Find(CSVNamePredicate("v2")), 1)
+
+	// so do spec updates
+	ssp[key] = &Snapshot{Entries: []*Entry{{Name: "v3"}}}
+	catsrc = catsrc.DeepCopy()
+	catsrc.ResourceVersion = "4"
+	catsrc.Generation = 2
+	require.NoError(t, indexer.Update(catsrc))
+	require.Len(t, c.Namespaced("dummynamespace").Catalog(key).Find(CSVNamePredicate("v3")), 1)
+}
+
 func TestCatalogSnapshotValid(t *testing.T) {
 	type tc struct {
 		Name     string
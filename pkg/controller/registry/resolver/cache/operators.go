@@ -1,6 +1,7 @@
 package cache
 
 import (
+	"encoding/json"
 	"fmt"
 	"hash/fnv"
 	"sort"
@@ -12,6 +13,8 @@ import (
 	"github.com/operator-framework/api/pkg/operators/v1alpha1"
 	"github.com/operator-framework/operator-registry/pkg/api"
 	opregistry "github.com/operator-framework/operator-registry/pkg/registry"
+
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/registry"
 )
 
 type APISet map[opregistry.APIKey]struct{}
@@ -191,14 +194,29 @@ type OperatorSourceInfo struct {
 	Channel        string
 	StartingCSV    string
 	Catalog        SourceKey
+	// Aggregate is the aggregate CatalogSource the operator is served through, if any. Catalog is then the member
+	// CatalogSource providing it, which its bundle is unpacked from.
+	Aggregate      SourceKey
 	DefaultChannel bool
 	Subscription   *v1alpha1.Subscription
 }
 
 func (i *OperatorSourceInfo) String() string {
+	if !i.Aggregate.Empty() {
+		return fmt.Sprintf("%s/%s in %s/%s via %s/%s", i.Package, i.Channel, i.Catalog.Name, i.Catalog.Namespace, i.Aggregate.Name, i.Aggregate.Namespace)
+	}
 	return fmt.Sprintf("%s/%s in %s/%s", i.Package, i.Channel, i.Catalog.Name, i.Catalog.Namespace)
 }
 
+// Source returns the key of the source the operator is served from, which subscriptions reference: the aggregate
+// CatalogSource serving it, if any, or else the CatalogSource providing it.
+func (i *OperatorSourceInfo) Source() SourceKey {
+	if !i.Aggregate.Empty() {
+		return i.Aggregate
+	}
+	return i.Catalog
+}
+
 var NoCatalog = SourceKey{Name: "", Namespace: ""}
 var ExistingOperator = OperatorSourceInfo{Package: "", Channel: "", StartingCSV: "", Catalog: NoCatalog, DefaultChannel: false}
 
@@ -234,3 +252,20 @@ func (o *Entry) Channel() string {
 	}
 	return ""
 }
+
+// Deprecations returns the deprecations of the package, channel and bundle of the entry declared by its catalog.
+// Invalid deprecation properties are ignored.
+func (o *Entry) Deprecations() []registry.Deprecation {
+	var deprecations []registry.Deprecation
+	for _, p := range o.Properties {
+		if p.GetType() != registry.DeprecationPropertyType {
+			continue
+		}
+		var d registry.Deprecation
+		if err := json.Unmarshal([]byte(p.GetValue()), &d); err != nil {
+			continue
+		}
+		deprecations = append(deprecations, d)
+	}
+	return deprecations
+}
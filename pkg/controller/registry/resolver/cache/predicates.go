@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/blang/semver/v4"
 
@@ -147,7 +148,7 @@ func CatalogPredicate(key SourceKey) Predicate {
 }
 
 func (c catalogPredicate) Test(o *Entry) bool {
-	return c.key.Equal(o.SourceInfo.Catalog)
+	return c.key.Equal(o.SourceInfo.Source())
 }
 
 func (c catalogPredicate) String() string {
@@ -309,6 +310,21 @@ func (p notPredicate) String() string {
 	return b.String()
 }
 
+type deprecatedPredicate struct{}
+
+// DeprecatedPredicate matches the entries whose package, channel or bundle is deprecated by their catalog.
+func DeprecatedPredicate() Predicate {
+	return deprecatedPredicate{}
+}
+
+func (deprecatedPredicate) Test(o *Entry) bool {
+	return len(o.Deprecations()) > 0
+}
+
+func (deprecatedPredicate) String() string {
+	return "deprecated"
+}
+
 type booleanPredicate struct {
 	result bool
 }
@@ -364,23 +380,37 @@ type celPredicate struct {
 }
 
 func (cp *celPredicate) Test(entry *Entry) bool {
-	props := make([]map[string]interface{}, len(entry.Properties))
-	for i, p := range entry.Properties {
+	ok, err := cp.evaluate(entry)
+	if err != nil {
+		return false
+	}
+	return ok
+}
+
+func (cp *celPredicate) evaluate(entry *Entry) (bool, error) {
+	props := make([]map[string]interface{}, 0, len(entry.Properties))
+	for _, p := range entry.Properties {
 		var v interface{}
 		if err := json.Unmarshal([]byte(p.Value), &v); err != nil {
+			// a nil element would fail the evaluation of any rule iterating over the properties
 			continue
 		}
-		props[i] = map[string]interface{}{
+		props = append(props, map[string]interface{}{
 			"type":  p.Type,
 			"value": v,
-		}
+		})
 	}
+	return cp.program.Evaluate(map[string]interface{}{constraints.PropertiesKey: props})
+}
 
-	ok, err := cp.program.Evaluate(map[string]interface{}{"properties": props})
-	if err != nil {
-		return false
+func (cp *celPredicate) explain(entry *Entry) string {
+	if _, err := cp.evaluate(entry); err != nil {
+		return fmt.Sprintf("constraint %q failed to evaluate: %v", cp.rule, err)
 	}
-	return ok
+	if cp.message != "" {
+		return cp.message
+	}
+	return fmt.Sprintf("constraint %q", cp.rule)
 }
 
 func CreateCelPredicate(env *constraints.CelEnvironment, rule string, message string) (Predicate, error) {
@@ -394,3 +424,76 @@ func CreateCelPredicate(env *constraints.CelEnvironment, rule string, message st
 func (cp *celPredicate) String() string {
 	return fmt.Sprintf("with constraint: %q and message: %q", cp.rule, cp.message)
 }
+
+// explainer is implemented by predicates that explain why they rule out an entry better than their String does.
+type explainer interface {
+	explain(*Entry) string
+}
+
+// Explain returns why the given predicate rules out the given entry, as the descriptions of the innermost predicates
+// it fails, or nil if the predicate matches the entry.
+func Explain(p Predicate, o *Entry) []string {
+	if p.Test(o) {
+		return nil
+	}
+	switch p := p.(type) {
+	case andPredicate:
+		var reasons []string
+		for _, predicate := range p.predicates {
+			reasons = append(reasons, Explain(predicate, o)...)
+		}
+		return reasons
+	case orPredicate:
+		var reasons []string
+		for _, predicate := range p.predicates {
+			reasons = append(reasons, Explain(predicate, o)...)
+		}
+		return []string{fmt.Sprintf("none of (%s)", strings.Join(reasons, ", "))}
+	case notPredicate:
+		var reasons []string
+		for _, predicate := range p.predicates {
+			if predicate.Test(o) {
+				reasons = append(reasons, "not "+predicate.String())
+			}
+		}
+		return reasons
+	case countingPredicate:
+		return Explain(p.p, o)
+	case explainer:
+		return []string{p.explain(o)}
+	}
+	return []string{p.String()}
+}
+
+// Candidates returns a predicate matching the entries the given predicate is about, e.g. the bundles of the package
+// it requires whatever their version, or nil if it can't be narrowed down.
+func Candidates(p Predicate) Predicate {
+	switch p := p.(type) {
+	case pkgPredicate, csvNamePredicate:
+		return p
+	case andPredicate:
+		var candidates []Predicate
+		for _, predicate := range p.predicates {
+			if c := Candidates(predicate); c != nil {
+				candidates = append(candidates, c)
+			}
+		}
+		if len(candidates) == 0 {
+			return nil
+		}
+		return And(candidates...)
+	case orPredicate:
+		candidates := make([]Predicate, 0, len(p.predicates))
+		for _, predicate := range p.predicates {
+			c := Candidates(predicate)
+			if c == nil {
+				return nil
+			}
+			candidates = append(candidates, c)
+		}
+		return Or(candidates...)
+	case countingPredicate:
+		return Candidates(p.p)
+	}
+	return nil
+}
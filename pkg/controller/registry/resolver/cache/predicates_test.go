@@ -61,3 +61,23 @@ func TestCountingPredicate(t *testing.T) {
 		})
 	}
 }
+
+func TestExplain(t *testing.T) {
+	entry := &Entry{
+		Name:       "packageA.v1.0.0",
+		SourceInfo: &OperatorSourceInfo{Package: "packageA", Channel: "stable"},
+	}
+
+	assert.Nil(t, Explain(And(PkgPredicate("packageA"), ChannelPredicate("stable")), entry))
+	assert.Equal(t, []string{ChannelPredicate("alpha").String()}, Explain(And(PkgPredicate("packageA"), ChannelPredicate("alpha")), entry))
+	assert.Equal(t, []string{"none of (" + PkgPredicate("packageB").String() + ", " + ChannelPredicate("alpha").String() + ")"}, Explain(Or(PkgPredicate("packageB"), ChannelPredicate("alpha")), entry))
+	assert.Equal(t, []string{"not " + PkgPredicate("packageA").String()}, Explain(Not(PkgPredicate("packageA"), ChannelPredicate("alpha")), entry))
+}
+
+func TestCandidates(t *testing.T) {
+	assert.Nil(t, Candidates(ChannelPredicate("stable")))
+	assert.Equal(t, PkgPredicate("packageA"), Candidates(PkgPredicate("packageA")))
+	assert.Equal(t, And(PkgPredicate("packageA")), Candidates(And(PkgPredicate("packageA"), ChannelPredicate("stable"))))
+	assert.Nil(t, Candidates(Or(PkgPredicate("packageA"), ChannelPredicate("stable"))))
+	assert.Equal(t, Or(PkgPredicate("packageA"), PkgPredicate("packageB")), Candidates(Or(PkgPredicate("packageA"), PkgPredicate("packageB"))))
+}
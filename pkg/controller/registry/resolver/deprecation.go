@@ -0,0 +1,68 @@
+package resolver
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/registry/resolver/cache"
+)
+
+// SubscriptionDeprecatedBundlesAnnotationKey is the Subscription annotation setting how the resolver treats the bundles
+// its catalog deprecates, either directly or through their package or channel.
+const SubscriptionDeprecatedBundlesAnnotationKey = "operatorframework.io/deprecated-bundles"
+
+// DeprecationPolicy is how the resolver treats deprecated bundles.
+type DeprecationPolicy string
+
+const (
+	// DeprecationPolicyAllow resolves deprecated bundles like any other.
+	DeprecationPolicyAllow DeprecationPolicy = "Allow"
+	// DeprecationPolicyDeprioritize prefers any bundle that isn't deprecated over deprecated ones. It is the default.
+	DeprecationPolicyDeprioritize DeprecationPolicy = "Deprioritize"
+	// DeprecationPolicyRefuse never installs nor upgrades to deprecated bundles.
+	DeprecationPolicyRefuse DeprecationPolicy = "Refuse"
+)
+
+// DeprecationPolicyFor returns the deprecation policy set on the given Subscription.
+func DeprecationPolicyFor(sub *v1alpha1.Subscription) (DeprecationPolicy, error) {
+	value, ok := sub.GetAnnotations()[SubscriptionDeprecatedBundlesAnnotationKey]
+	if !ok {
+		return DeprecationPolicyDeprioritize, nil
+	}
+	switch policy := DeprecationPolicy(value); policy {
+	case DeprecationPolicyAllow, DeprecationPolicyDeprioritize, DeprecationPolicyRefuse:
+		return policy, nil
+	}
+	return "", fmt.Errorf("invalid %s annotation %q on subscription %s: must be one of %s, %s or %s", SubscriptionDeprecatedBundlesAnnotationKey, value, sub.GetName(), DeprecationPolicyAllow, DeprecationPolicyDeprioritize, DeprecationPolicyRefuse)
+}
+
+// Apply orders or filters the given candidates, sorted by preference, according to the policy.
+func (p DeprecationPolicy) Apply(candidates []*cache.Entry) []*cache.Entry {
+	switch p {
+	case DeprecationPolicyDeprioritize:
+		out := make([]*cache.Entry, 0, len(candidates))
+		out = append(out, cache.Filter(candidates, cache.Not(cache.DeprecatedPredicate()))...)
+		return append(out, cache.Filter(candidates, cache.DeprecatedPredicate())...)
+	case DeprecationPolicyRefuse:
+		return cache.Filter(candidates, cache.Not(cache.DeprecatedPredicate()))
+	}
+	return candidates
+}
+
+// deprecationMessages returns the distinct deprecation messages of the given entries, sorted.
+func deprecationMessages(entries []*cache.Entry) string {
+	seen := map[string]bool{}
+	var messages []string
+	for _, e := range entries {
+		for _, d := range e.Deprecations() {
+			if m := d.String(); !seen[m] {
+				seen[m] = true
+				messages = append(messages, m)
+			}
+		}
+	}
+	sort.Strings(messages)
+	return strings.Join(messages, "; ")
+}
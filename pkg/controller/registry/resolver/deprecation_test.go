@@ -0,0 +1,153 @@
+package resolver
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/blang/semver/v4"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/operator-framework/api/pkg/lib/version"
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/operator-framework/operator-registry/pkg/api"
+
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/registry"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/registry/resolver/cache"
+)
+
+func withDeprecationPolicy(policy string) subOption {
+	return func(s *v1alpha1.Subscription) {
+		s.SetAnnotations(map[string]string{SubscriptionDeprecatedBundlesAnnotationKey: policy})
+	}
+}
+
+func deprecated(e *cache.Entry, d registry.Deprecation) *cache.Entry {
+	value, _ := json.Marshal(d)
+	e.Properties = append(e.Properties, &api.Property{Type: registry.DeprecationPropertyType, Value: string(value)})
+	return e
+}
+
+func TestDeprecationPolicyFor(t *testing.T) {
+	catalog := cache.SourceKey{Name: "test-catalog", Namespace: "test-namespace"}
+
+	policy, err := DeprecationPolicyFor(newSub(catalog.Namespace, "packageA", "alpha", catalog))
+	require.NoError(t, err)
+	require.Equal(t, DeprecationPolicyDeprioritize, policy)
+
+	policy, err = DeprecationPolicyFor(newSub(catalog.Namespace, "packageA", "alpha", catalog, withDeprecationPolicy("Refuse")))
+	require.NoError(t, err)
+	require.Equal(t, DeprecationPolicyRefuse, policy)
+
+	_, err = DeprecationPolicyFor(newSub(catalog.Namespace, "packageA", "alpha", catalog, withDeprecationPolicy("refuse")))
+	require.Error(t, err)
+}
+
+func TestSolveOperators_WithDeprecations(t *testing.T) {
+	const namespace = "test-namespace"
+	catalog := cache.SourceKey{Name: "test-catalog", Namespace: namespace}
+
+	entries := func(deprecations map[string]registry.Deprecation) []*cache.Entry {
+		ops := []*cache.Entry{
+			genOperator("packageA.v1.0.0", "1.0.0", "", "packageA", "alpha", catalog.Name, catalog.Namespace, nil, nil, nil, "", false),
+			genOperator("packageA.v1.1.0", "1.1.0", "packageA.v1.0.0", "packageA", "alpha", catalog.Name, catalog.Namespace, nil, nil, nil, "", false),
+			genOperator("packageA.v2.0.0", "2.0.0", "packageA.v1.1.0", "packageA", "alpha", catalog.Name, catalog.Namespace, nil, nil, nil, "", false),
+		}
+		for _, op := range ops {
+			if d, ok := deprecations[op.Name]; ok {
+				deprecated(op, d)
+			}
+		}
+		return ops
+	}
+	headDeprecated := map[string]registry.Deprecation{
+		"packageA.v2.0.0": {Schema: "olm.bundle", Name: "packageA.v2.0.0", Message: "use 1.1.0"},
+	}
+	channel := registry.Deprecation{Schema: "olm.channel", Name: "alpha", Message: "use beta"}
+	channelDeprecated := map[string]registry.Deprecation{
+		"packageA.v1.0.0": channel,
+		"packageA.v1.1.0": channel,
+		"packageA.v2.0.0": channel,
+	}
+
+	installed := func() []*v1alpha1.ClusterServiceVersion {
+		csv := existingOperator(namespace, "packageA.v1.0.0", "packageA", "alpha", "", nil, nil, nil, nil)
+		csv.Spec.Version = version.OperatorVersion{Version: semver.MustParse("1.0.0")}
+		return []*v1alpha1.ClusterServiceVersion{csv}
+	}
+	installedSub := func(policy string) *v1alpha1.Subscription {
+		sub := existingSub(namespace, "packageA.v1.0.0", "packageA", "alpha", catalog)
+		withDeprecationPolicy(policy)(sub)
+		return sub
+	}
+
+	tests := []struct {
+		name     string
+		entries  []*cache.Entry
+		csvs     []*v1alpha1.ClusterServiceVersion
+		sub      *v1alpha1.Subscription
+		expected string
+		err      string
+	}{
+		{
+			name:     "DeprioritizesByDefault",
+			entries:  entries(headDeprecated),
+			sub:      newSub(namespace, "packageA", "alpha", catalog),
+			expected: "packageA.v1.1.0",
+		},
+		{
+			name:     "Allow",
+			entries:  entries(headDeprecated),
+			sub:      newSub(namespace, "packageA", "alpha", catalog, withDeprecationPolicy("Allow")),
+			expected: "packageA.v2.0.0",
+		},
+		{
+			name:     "DeprioritizedBundlesRemainCandidates",
+			entries:  entries(channelDeprecated),
+			sub:      newSub(namespace, "packageA", "alpha", catalog),
+			expected: "packageA.v2.0.0",
+		},
+		{
+			name:    "RefusesDeprecatedChannel",
+			entries: entries(channelDeprecated),
+			sub:     newSub(namespace, "packageA", "alpha", catalog, withDeprecationPolicy("Refuse")),
+			err:     "are deprecated: channel alpha is deprecated: use beta",
+		},
+		{
+			name:    "DoesNotUpgradeToRefusedBundles",
+			entries: entries(channelDeprecated),
+			csvs:    installed(),
+			sub:     installedSub("Refuse"),
+		},
+		{
+			name:    "InvalidPolicy",
+			entries: entries(nil),
+			sub:     newSub(namespace, "packageA", "alpha", catalog, withDeprecationPolicy("Never")),
+			err:     "invalid operatorframework.io/deprecated-bundles annotation",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			satResolver := SatResolver{
+				cache: cache.New(cache.StaticSourceProvider{
+					catalog: &cache.Snapshot{Entries: tt.entries},
+				}),
+				log: logrus.New(),
+			}
+
+			operators, err := satResolver.SolveOperators([]string{namespace}, tt.csvs, []*v1alpha1.Subscription{tt.sub})
+			if tt.err != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tt.err)
+				return
+			}
+			require.NoError(t, err)
+			if tt.expected == "" {
+				require.Empty(t, operators)
+				return
+			}
+			require.Len(t, operators, 1)
+			require.Contains(t, operators, tt.expected)
+		})
+	}
+}
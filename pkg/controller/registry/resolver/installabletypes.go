@@ -59,7 +59,7 @@ func NewBundleInstallableFromOperator(o *cache.Entry) (BundleInstallable, error)
 	if o.SourceInfo == nil {
 		return BundleInstallable{}, fmt.Errorf("unable to resolve the source of bundle %s", o.Name)
 	}
-	id := bundleID(o.Name, o.Channel(), o.SourceInfo.Catalog)
+	id := bundleID(o.Name, o.Channel(), o.SourceInfo.Source())
 	var constraints []solver.Constraint
 	if o.SourceInfo.Catalog.Virtual() && o.SourceInfo.Subscription == nil {
 		// CSVs already associated with a Subscription
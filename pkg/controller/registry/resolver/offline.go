@@ -0,0 +1,65 @@
+package resolver
+
+import (
+	"context"
+
+	"github.com/operator-framework/api/pkg/constraints"
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/operator-framework/operator-registry/pkg/client"
+	"github.com/sirupsen/logrus"
+
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/registry"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/registry/resolver/cache"
+)
+
+// StaticRegistryClientProvider provides the clients of a fixed set of catalogs.
+type StaticRegistryClientProvider map[registry.CatalogKey]client.Interface
+
+func (p StaticRegistryClientProvider) ClientsForNamespaces(namespaces ...string) map[registry.CatalogKey]client.Interface {
+	result := make(map[registry.CatalogKey]client.Interface)
+	for key, c := range p {
+		for _, namespace := range namespaces {
+			if key.Namespace == namespace {
+				result[key] = c
+				break
+			}
+		}
+	}
+	return result
+}
+
+// ResolveOffline resolves the Subscriptions of a namespace, given the CSVs installed in it, against the catalogs of the
+// given clients, like the catalog operator resolves the namespace against the catalogs of the given namespaces: the
+// namespace itself, followed by the global catalog namespace. It returns the resolved operators, including those
+// already installed, and the report of the decisions of the resolver, if the resolution got to solve constraints.
+func ResolveOffline(clients RegistryClientProvider, namespaces []string, csvs []*v1alpha1.ClusterServiceVersion, subs []*v1alpha1.Subscription, logger logrus.FieldLogger) (cache.OperatorSet, *ResolutionReport, error) {
+	var stdLogger logrus.StdLogger = logrus.StandardLogger()
+	if l, ok := logger.(logrus.StdLogger); ok {
+		stdLogger = l
+	}
+	r := &SatResolver{
+		cache: cache.New(SourceProviderFromRegistryClientProvider(clients, stdLogger), cache.WithLogger(logger)),
+		log:   logger,
+		pc: &predicateConverter{
+			celEnv: constraints.NewCelEnvironment(),
+		},
+	}
+
+	var report *ResolutionReport
+	operators, err := r.solveOperators(context.TODO(), namespaces, withoutCopiedCSVs(csvs), subs, func(_ context.Context, _ string, _ []*v1alpha1.Subscription, r *ResolutionReport) {
+		report = r
+	})
+	return operators, report, err
+}
+
+// withoutCopiedCSVs omits the copied CSVs of the given ones, which indicate that APIs are provided to their namespace,
+// not by it.
+func withoutCopiedCSVs(csvs []*v1alpha1.ClusterServiceVersion) []*v1alpha1.ClusterServiceVersion {
+	var out []*v1alpha1.ClusterServiceVersion
+	for _, csv := range csvs {
+		if !csv.IsCopied() {
+			out = append(out, csv)
+		}
+	}
+	return out
+}
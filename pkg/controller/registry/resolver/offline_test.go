@@ -0,0 +1,94 @@
+package resolver
+
+import (
+	"testing"
+
+	"github.com/operator-framework/operator-registry/pkg/client"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/registry"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/registry/fbc"
+)
+
+const offlineCatalog = `---
+schema: olm.package
+name: etcd
+defaultChannel: stable
+---
+schema: olm.channel
+package: etcd
+name: stable
+entries:
+- name: etcdoperator.v0.9.0
+- name: etcdoperator.v0.9.2
+  replaces: etcdoperator.v0.9.0
+---
+schema: olm.bundle
+package: etcd
+name: etcdoperator.v0.9.0
+image: quay.io/etcd/bundle:v0.9.0
+properties:
+- type: olm.package
+  value:
+    packageName: etcd
+    version: 0.9.0
+---
+schema: olm.bundle
+package: etcd
+name: etcdoperator.v0.9.2
+image: quay.io/etcd/bundle:v0.9.2
+properties:
+- type: olm.package
+  value:
+    packageName: etcd
+    version: 0.9.2
+- type: olm.package.required
+  value:
+    packageName: backup
+    versionRange: '>=1.0.0'
+`
+
+func TestResolveOffline(t *testing.T) {
+	index, err := fbc.Load(map[string][]byte{"catalog.yaml": []byte(offlineCatalog)})
+	require.NoError(t, err)
+	key := registry.CatalogKey{Namespace: "olm", Name: "catalog"}
+	servers := fbc.NewServers()
+	address, err := servers.Serve(key, "", index)
+	require.NoError(t, err)
+	defer servers.Stop(key)
+	c, err := client.NewClient(address)
+	require.NoError(t, err)
+	clients := StaticRegistryClientProvider{key: c}
+
+	sub := func(startingCSV string) *v1alpha1.Subscription {
+		return &v1alpha1.Subscription{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "etcd"},
+			Spec: &v1alpha1.SubscriptionSpec{
+				CatalogSource:          "catalog",
+				CatalogSourceNamespace: "olm",
+				Package:                "etcd",
+				Channel:                "stable",
+				StartingCSV:            startingCSV,
+			},
+		}
+	}
+	logger := logrus.New()
+
+	operators, report, err := ResolveOffline(clients, []string{"ns", "olm"}, nil, []*v1alpha1.Subscription{sub("etcdoperator.v0.9.0")}, logger)
+	require.NoError(t, err)
+	require.Contains(t, operators, "etcdoperator.v0.9.0")
+	require.Equal(t, key.Name, operators["etcdoperator.v0.9.0"].SourceInfo.Catalog.Name)
+	require.NotNil(t, report)
+	require.Empty(t, report.Conflicts)
+
+	_, report, err = ResolveOffline(clients, []string{"ns", "olm"}, nil, []*v1alpha1.Subscription{sub("etcdoperator.v0.9.2")}, logger)
+	require.Error(t, err)
+	require.NotNil(t, report)
+	require.NotEmpty(t, report.Conflicts, "the missing dependency is explained")
+
+	require.Empty(t, clients.ClientsForNamespaces("other"))
+}
@@ -0,0 +1,93 @@
+package resolver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/operator-framework/api/pkg/validation"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/api/client/clientset/versioned"
+	v1alpha1listers "github.com/operator-framework/operator-lifecycle-manager/pkg/api/client/listers/operators/v1alpha1"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/registry/reconciler"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/registry/resolver/cache"
+	"github.com/operator-framework/operator-registry/pkg/api"
+)
+
+// QuarantinedBundle is a bundle excluded from resolution because its CSV fails static validation.
+type QuarantinedBundle struct {
+	Name    string   `json:"name"`
+	Package string   `json:"package"`
+	Errors  []string `json:"errors"`
+}
+
+// quarantineFunc is notified of the bundles of a catalog excluded from resolution each time the catalog is snapshotted.
+type quarantineFunc func(key cache.SourceKey, quarantined []QuarantinedBundle)
+
+// validateBundle runs the CSV validators of the operator-framework api on the CSV of the given bundle, when it's
+// served, and returns the errors found.
+func validateBundle(bundle *api.Bundle) []string {
+	if bundle.CsvJson == "" {
+		return nil
+	}
+
+	csv := &v1alpha1.ClusterServiceVersion{}
+	if err := json.Unmarshal([]byte(bundle.CsvJson), csv); err != nil {
+		return []string{fmt.Sprintf("failed to unmarshal csv: %v", err)}
+	}
+
+	var errs []string
+	for _, result := range validation.ClusterServiceVersionValidator.Validate(csv) {
+		for _, err := range result.Errors {
+			errs = append(errs, err.Error())
+		}
+	}
+	return errs
+}
+
+// quarantineRecorder records the bundles of a catalog excluded from resolution in an annotation of its CatalogSource.
+type quarantineRecorder struct {
+	client       versioned.Interface
+	catsrcLister v1alpha1listers.CatalogSourceLister
+	logger       logrus.FieldLogger
+}
+
+func (r *quarantineRecorder) record(key cache.SourceKey, quarantined []QuarantinedBundle) {
+	catsrc, err := r.catsrcLister.CatalogSources(key.Namespace).Get(key.Name)
+	if err != nil {
+		return
+	}
+
+	var value interface{}
+	if len(quarantined) > 0 {
+		report, err := json.Marshal(quarantined)
+		if err != nil {
+			r.logger.WithError(err).Warn("failed to marshal quarantined bundles")
+			return
+		}
+		if string(report) == catsrc.GetAnnotations()[reconciler.QuarantinedBundlesAnnotationKey] {
+			return
+		}
+		value = string(report)
+	} else if _, ok := catsrc.GetAnnotations()[reconciler.QuarantinedBundlesAnnotationKey]; !ok {
+		return
+	}
+
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]interface{}{reconciler.QuarantinedBundlesAnnotationKey: value},
+		},
+	})
+	if err != nil {
+		r.logger.WithError(err).Warn("failed to build quarantined bundles patch")
+		return
+	}
+	if _, err := r.client.OperatorsV1alpha1().CatalogSources(key.Namespace).Patch(context.TODO(), key.Name, types.MergePatchType, patch, metav1.PatchOptions{}); err != nil && !k8serrors.IsNotFound(err) {
+		r.logger.WithError(err).WithField("catalog", key.String()).Warn("failed to record quarantined bundles")
+	}
+}
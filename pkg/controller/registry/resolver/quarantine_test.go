@@ -0,0 +1,81 @@
+package resolver
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/api/client/clientset/versioned/fake"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/registry/reconciler"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/registry/resolver/cache"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/registry/resolver/fakes"
+	"github.com/operator-framework/operator-registry/pkg/api"
+	"github.com/operator-framework/operator-registry/pkg/client"
+)
+
+type bundleStream []*api.Bundle
+
+func (s *bundleStream) Recv() (*api.Bundle, error) {
+	if len(*s) == 0 {
+		return nil, io.EOF
+	}
+	next := (*s)[0]
+	*s = (*s)[1:]
+	return next, nil
+}
+
+func TestRegistrySourceQuarantinesInvalidBundles(t *testing.T) {
+	key := cache.SourceKey{Name: "catalog", Namespace: "ns"}
+	stream := bundleStream{
+		{CsvName: "a.v1", PackageName: "a", ChannelName: "stable", Version: "1.0.0"},
+		{CsvName: "b.v1", PackageName: "b", ChannelName: "stable", Version: "1.0.0", CsvJson: `{"metadata":{"name":"b.v1"}}`},
+	}
+	registryClient := &fakes.FakeClientInterface{}
+	registryClient.ListBundlesReturns(client.NewBundleIterator(&stream), nil)
+	registryClient.GetPackageReturns(&api.Package{DefaultChannelName: "stable"}, nil)
+
+	var reported []QuarantinedBundle
+	source := &registrySource{
+		key:    key,
+		client: registryClient,
+		logger: logrus.New(),
+		quarantine: func(k cache.SourceKey, quarantined []QuarantinedBundle) {
+			require.Equal(t, key, k)
+			reported = quarantined
+		},
+	}
+
+	snapshot, err := source.Snapshot(context.Background())
+	require.NoError(t, err)
+	require.Len(t, snapshot.Entries, 1)
+	require.Equal(t, "a.v1", snapshot.Entries[0].Name)
+
+	require.Len(t, reported, 1)
+	require.Equal(t, "b.v1", reported[0].Name)
+	require.Equal(t, "b", reported[0].Package)
+	require.NotEmpty(t, reported[0].Errors)
+}
+
+func TestQuarantineRecorder(t *testing.T) {
+	catsrc := &v1alpha1.CatalogSource{ObjectMeta: metav1.ObjectMeta{Name: "catalog", Namespace: "ns"}}
+	client := fake.NewSimpleClientset(catsrc)
+	lister := &stubCatalogSourceLister{catsrcs: []*v1alpha1.CatalogSource{catsrc}}
+	recorder := &quarantineRecorder{client: client, catsrcLister: lister, logger: logrus.New()}
+	key := cache.SourceKey{Name: "catalog", Namespace: "ns"}
+
+	recorder.record(key, []QuarantinedBundle{{Name: "b.v1", Package: "b", Errors: []string{"Error: 'kind' is missing"}}})
+	got, err := client.OperatorsV1alpha1().CatalogSources("ns").Get(context.Background(), "catalog", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.JSONEq(t, `[{"name":"b.v1","package":"b","errors":["Error: 'kind' is missing"]}]`, got.GetAnnotations()[reconciler.QuarantinedBundlesAnnotationKey])
+
+	lister.catsrcs = []*v1alpha1.CatalogSource{got}
+	recorder.record(key, nil)
+	got, err = client.OperatorsV1alpha1().CatalogSources("ns").Get(context.Background(), "catalog", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.NotContains(t, got.GetAnnotations(), reconciler.QuarantinedBundlesAnnotationKey)
+}
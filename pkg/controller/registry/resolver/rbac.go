@@ -12,22 +12,72 @@ import (
 	"github.com/operator-framework/api/pkg/operators/v1alpha1"
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/install"
 	hashutil "github.com/operator-framework/operator-lifecycle-manager/pkg/lib/kubernetes/pkg/util/hash"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/names"
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/ownerutil"
 )
 
-const maxNameLength = 63
+const legacyMaxNameLength = 63
 
-func generateName(base string, o interface{}) string {
+// legacyGenerateName is how the names of the RBAC of CSVs were generated before names.Hashed, which could exceed
+// legacyMaxNameLength by a character and truncated names could only be told apart by a short hash.
+func legacyGenerateName(base string, o interface{}) string {
 	hasher := fnv.New32a()
 	hashutil.DeepHashObject(hasher, o)
 	hash := utilrand.SafeEncodeString(fmt.Sprint(hasher.Sum32()))
-	if len(base)+len(hash) > maxNameLength {
-		base = base[:maxNameLength-len(hash)-1]
+	if len(base)+len(hash) > legacyMaxNameLength {
+		base = base[:legacyMaxNameLength-len(hash)-1]
 	}
 
 	return fmt.Sprintf("%s-%s", base, hash)
 }
 
+func roleName(csv *v1alpha1.ClusterServiceVersion, permission v1alpha1.StrategyDeploymentPermissions, generate func(string, interface{}) string) string {
+	return generate(fmt.Sprintf("%s-%s", csv.GetName(), permission.ServiceAccountName), []interface{}{csv.GetName(), permission})
+}
+
+func clusterRoleName(csv *v1alpha1.ClusterServiceVersion, permission v1alpha1.StrategyDeploymentPermissions, generate func(string, interface{}) string) string {
+	return generate(csv.GetName(), []interface{}{csv.GetName(), csv.GetNamespace(), permission})
+}
+
+func deploymentStrategy(csv *v1alpha1.ClusterServiceVersion) (*v1alpha1.StrategyDetailsDeployment, error) {
+	// Use a StrategyResolver to get the strategy details
+	strategyResolver := install.StrategyResolver{}
+	strategy, err := strategyResolver.UnmarshalStrategy(csv.Spec.InstallStrategy)
+	if err != nil {
+		return nil, err
+	}
+
+	// Assume the strategy is for a deployment
+	strategyDetailsDeployment, ok := strategy.(*v1alpha1.StrategyDetailsDeployment)
+	if !ok {
+		return nil, fmt.Errorf("could not assert strategy implementation as deployment for CSV %s", csv.GetName())
+	}
+	return strategyDetailsDeployment, nil
+}
+
+// LegacyRBACNames returns the legacy names of the Roles and RoleBindings, and of the ClusterRoles and
+// ClusterRoleBindings, generated for the given CSV by older versions of OLM, keyed by their current names. Only the
+// names that changed are returned, i.e. those that had to be truncated.
+func LegacyRBACNames(csv *v1alpha1.ClusterServiceVersion) (namespaced, cluster map[string]string, err error) {
+	strategyDetailsDeployment, err := deploymentStrategy(csv)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	namespaced, cluster = map[string]string{}, map[string]string{}
+	for _, permission := range strategyDetailsDeployment.Permissions {
+		if name, legacy := roleName(csv, permission, names.Hashed), roleName(csv, permission, legacyGenerateName); name != legacy {
+			namespaced[name] = legacy
+		}
+	}
+	for _, permission := range strategyDetailsDeployment.ClusterPermissions {
+		if name, legacy := clusterRoleName(csv, permission, names.Hashed), clusterRoleName(csv, permission, legacyGenerateName); name != legacy {
+			cluster[name] = legacy
+		}
+	}
+	return namespaced, cluster, nil
+}
+
 type OperatorPermissions struct {
 	ServiceAccount      *corev1.ServiceAccount
 	Roles               []*rbacv1.Role
@@ -65,19 +115,11 @@ func (o *OperatorPermissions) AddClusterRoleBinding(clusterRoleBinding *rbacv1.C
 func RBACForClusterServiceVersion(csv *v1alpha1.ClusterServiceVersion) (map[string]*OperatorPermissions, error) {
 	permissions := map[string]*OperatorPermissions{}
 
-	// Use a StrategyResolver to get the strategy details
-	strategyResolver := install.StrategyResolver{}
-	strategy, err := strategyResolver.UnmarshalStrategy(csv.Spec.InstallStrategy)
+	strategyDetailsDeployment, err := deploymentStrategy(csv)
 	if err != nil {
 		return nil, err
 	}
 
-	// Assume the strategy is for a deployment
-	strategyDetailsDeployment, ok := strategy.(*v1alpha1.StrategyDetailsDeployment)
-	if !ok {
-		return nil, fmt.Errorf("could not assert strategy implementation as deployment for CSV %s", csv.GetName())
-	}
-
 	// Resolve Permissions
 	for _, permission := range strategyDetailsDeployment.Permissions {
 		// Create ServiceAccount if necessary
@@ -93,7 +135,7 @@ func RBACForClusterServiceVersion(csv *v1alpha1.ClusterServiceVersion) (map[stri
 		// Create Role
 		role := &rbacv1.Role{
 			ObjectMeta: metav1.ObjectMeta{
-				Name:            generateName(fmt.Sprintf("%s-%s", csv.GetName(), permission.ServiceAccountName), []interface{}{csv.GetName(), permission}),
+				Name:            roleName(csv, permission, names.Hashed),
 				Namespace:       csv.GetNamespace(),
 				OwnerReferences: []metav1.OwnerReference{ownerutil.NonBlockingOwner(csv)},
 				Labels:          ownerutil.OwnerLabel(csv, v1alpha1.ClusterServiceVersionKind),
@@ -137,7 +179,7 @@ func RBACForClusterServiceVersion(csv *v1alpha1.ClusterServiceVersion) (map[stri
 		// Create ClusterRole
 		role := &rbacv1.ClusterRole{
 			ObjectMeta: metav1.ObjectMeta{
-				Name:   generateName(csv.GetName(), []interface{}{csv.GetName(), csv.GetNamespace(), permission}),
+				Name:   clusterRoleName(csv, permission, names.Hashed),
 				Labels: ownerutil.OwnerLabel(csv, v1alpha1.ClusterServiceVersionKind),
 			},
 			Rules: permission.Rules,
@@ -13,9 +13,10 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/names"
 )
 
-func TestGenerateName(t *testing.T) {
+func TestLegacyGenerateName(t *testing.T) {
 	type args struct {
 		base string
 		o    interface{}
@@ -44,30 +45,107 @@ func TestGenerateName(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := generateName(tt.args.base, tt.args.o)
+			got := legacyGenerateName(tt.args.base, tt.args.o)
 			require.Equal(t, tt.want, got)
-			require.LessOrEqual(t, len(got), maxNameLength)
 		})
 	}
 }
 
-var runeSet = []rune("abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-")
+var runeSet = []rune("abcdefghijklmnopqrstuvwxyz0123456789-.")
 
-type validKubeName string
+// longName generates names of up to four times the maximum length of generated names.
+type longName string
 
-func (validKubeName) Generate(rand *rand.Rand, size int) reflect.Value {
-	b := make([]rune, size)
-	for i := range b {
+func (longName) Generate(rand *rand.Rand, size int) reflect.Value {
+	b := make([]rune, 1+rand.Intn(4*names.MaxLength))
+	b[0] = runeSet[rand.Intn(36)]
+	for i := 1; i < len(b); i++ {
 		b[i] = runeSet[rand.Intn(len(runeSet))]
 	}
-	return reflect.ValueOf(validKubeName(b))
+	return reflect.ValueOf(longName(b))
 }
 
-func TestGeneratesWithinRange(t *testing.T) {
-	f := func(base validKubeName, o string) bool {
-		return len(generateName(string(base), o)) <= maxNameLength
+func permissionsCSV(name, serviceAccountName string) *v1alpha1.ClusterServiceVersion {
+	permissions := []v1alpha1.StrategyDeploymentPermissions{{
+		ServiceAccountName: serviceAccountName,
+		Rules:              []rbacv1.PolicyRule{{Verbs: []string{"get"}, APIGroups: []string{""}, Resources: []string{"pods"}}},
+	}}
+	return &v1alpha1.ClusterServiceVersion{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "operators"},
+		Spec: v1alpha1.ClusterServiceVersionSpec{
+			InstallStrategy: v1alpha1.NamedInstallStrategy{
+				StrategyName: v1alpha1.InstallStrategyNameDeployment,
+				StrategySpec: v1alpha1.StrategyDetailsDeployment{
+					Permissions:        permissions,
+					ClusterPermissions: permissions,
+				},
+			},
+		},
+	}
+}
+
+func TestRBACNamesFuzz(t *testing.T) {
+	rbacNames := func(csv *v1alpha1.ClusterServiceVersion) []string {
+		permissions, err := RBACForClusterServiceVersion(csv)
+		require.NoError(t, err)
+		var generated []string
+		for _, p := range permissions {
+			for _, r := range p.Roles {
+				generated = append(generated, r.GetName())
+			}
+			for _, r := range p.ClusterRoles {
+				generated = append(generated, r.GetName())
+			}
+		}
+		return generated
 	}
-	require.NoError(t, quick.Check(f, nil))
+
+	// Generated names fit, whatever the length of the CSV and ServiceAccount names
+	require.NoError(t, quick.Check(func(csvName, serviceAccountName longName) bool {
+		for _, name := range rbacNames(permissionsCSV(string(csvName), string(serviceAccountName))) {
+			if len(name) > names.MaxLength {
+				return false
+			}
+		}
+		return true
+	}, &quick.Config{MaxCount: 1000}))
+
+	// Long CSV names that only differ past the truncation don't collide
+	require.NoError(t, quick.Check(func(a, b longName) bool {
+		long := strings.Repeat("operator", 10)
+		if a == b {
+			return true
+		}
+		x, y := rbacNames(permissionsCSV(long+string(a), "operator")), rbacNames(permissionsCSV(long+string(b), "operator"))
+		for i := range x {
+			if x[i] == y[i] {
+				return false
+			}
+		}
+		return true
+	}, &quick.Config{MaxCount: 1000}))
+}
+
+func TestLegacyRBACNames(t *testing.T) {
+	// Short names didn't change
+	namespaced, cluster, err := LegacyRBACNames(permissionsCSV("etcd.v0.9.0", "etcd-operator"))
+	require.NoError(t, err)
+	require.Empty(t, namespaced)
+	require.Empty(t, cluster)
+
+	// Truncated names did
+	csv := permissionsCSV(strings.Repeat("operator", 10)+".v1.0.0", "operator")
+	namespaced, cluster, err = LegacyRBACNames(csv)
+	require.NoError(t, err)
+	permissions, err := RBACForClusterServiceVersion(csv)
+	require.NoError(t, err)
+	role, clusterRole := permissions["operator"].Roles[0], permissions["operator"].ClusterRoles[0]
+	require.Equal(t, map[string]string{
+		role.GetName(): legacyGenerateName(strings.Repeat("operator", 10)+".v1.0.0-operator", []interface{}{csv.GetName(), csv.Spec.InstallStrategy.StrategySpec.Permissions[0]}),
+	}, namespaced)
+	require.Equal(t, map[string]string{
+		clusterRole.GetName(): legacyGenerateName(csv.GetName(), []interface{}{csv.GetName(), csv.GetNamespace(), csv.Spec.InstallStrategy.StrategySpec.ClusterPermissions[0]}),
+	}, cluster)
 }
 
 func TestRBACForClusterServiceVersion(t *testing.T) {
@@ -0,0 +1,240 @@
+package resolver
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strconv"
+
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/install"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/registry/resolver/solver"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/ownerutil"
+)
+
+const (
+	OperatorResolutionKind = "OperatorResolution"
+
+	// ResolutionReportName is the name of the OperatorResolution the outcome of the last resolution of a namespace is
+	// recorded in.
+	ResolutionReportName = "operator-resolution"
+
+	// maxResolutionReportSize bounds the size of the recorded ResolutionReports, well below the size limit of objects.
+	maxResolutionReportSize = 512 * 1024
+)
+
+// OperatorResolutionResource is the resource of OperatorResolutions, which are served by the operatorresolutions CRD
+// alongside the other operators.coreos.com/v1alpha1 types.
+var OperatorResolutionResource = v1alpha1.SchemeGroupVersion.WithResource("operatorresolutions")
+
+// OperatorResolution records the ResolutionReport of the last resolution of its namespace. It is owned by the
+// Subscriptions resolved.
+type OperatorResolution struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Status ResolutionReport `json:"status,omitempty"`
+}
+
+// ResolutionReport describes the decisions of a resolution run.
+type ResolutionReport struct {
+	// Candidates are the bundles, subscriptions and invariants considered by the resolver.
+	Candidates []ResolutionCandidate `json:"candidates"`
+	// Conflicts are the constraints that made resolution impossible, if it failed.
+	Conflicts []string `json:"conflicts,omitempty"`
+	// ConstraintsOmitted is true if the constraints of the candidates that weren't selected were left out of the
+	// report to bound its size.
+	ConstraintsOmitted bool `json:"constraintsOmitted,omitempty"`
+	// OmittedCandidates is the number of candidates that weren't selected left out of the report to bound its size.
+	OmittedCandidates int `json:"omittedCandidates,omitempty"`
+}
+
+// ResolutionCandidate describes a candidate considered by the resolver.
+type ResolutionCandidate struct {
+	Identifier  string   `json:"identifier"`
+	Selected    bool     `json:"selected"`
+	Constraints []string `json:"constraints,omitempty"`
+	// Reason explains why a candidate wasn't selected.
+	Reason string `json:"reason,omitempty"`
+}
+
+// reportFunc is notified of the decisions of each resolution run of a namespace, and of the subscriptions resolved.
+type reportFunc func(ctx context.Context, namespace string, subs []*v1alpha1.Subscription, report *ResolutionReport)
+
+// newResolutionReport describes the outcome of solving the given installables, where selected is the solution and
+// conflicts are the constraints that made a solution impossible.
+func newResolutionReport(installables []solver.Installable, selected []solver.Installable, conflicts solver.NotSatisfiable) *ResolutionReport {
+	solution := make(map[solver.Identifier]struct{}, len(selected))
+	for _, i := range selected {
+		solution[i.Identifier()] = struct{}{}
+	}
+	conflicting := make(map[solver.Identifier][]string)
+	report := &ResolutionReport{}
+	for _, c := range conflicts {
+		conflicting[c.Installable.Identifier()] = append(conflicting[c.Installable.Identifier()], c.String())
+		report.Conflicts = append(report.Conflicts, c.String())
+	}
+
+	for _, i := range installables {
+		candidate := ResolutionCandidate{Identifier: i.Identifier().String()}
+		_, candidate.Selected = solution[i.Identifier()]
+		var prohibited []string
+		for _, c := range i.Constraints() {
+			candidate.Constraints = append(candidate.Constraints, c.String(i.Identifier()))
+			if isProhibited(c) {
+				prohibited = append(prohibited, c.String(i.Identifier()))
+			}
+		}
+
+		switch {
+		case candidate.Selected:
+		case len(prohibited) > 0:
+			candidate.Reason = prohibited[0]
+		case len(conflicting[i.Identifier()]) > 0:
+			candidate.Reason = conflicting[i.Identifier()][0]
+		case len(conflicts) > 0:
+			candidate.Reason = "resolution failed"
+		default:
+			candidate.Reason = "another candidate was preferred"
+		}
+		report.Candidates = append(report.Candidates, candidate)
+	}
+	sort.Slice(report.Candidates, func(i, j int) bool {
+		return report.Candidates[i].Identifier < report.Candidates[j].Identifier
+	})
+	return report
+}
+
+// isProhibited returns true if the given constraint excludes its installable from every solution.
+func isProhibited(c solver.Constraint) bool {
+	if pc, ok := c.(prettyConstraint); ok {
+		c = pc.Constraint
+	}
+	return c == solver.Prohibited()
+}
+
+// boundResolutionReport returns the given report, or a copy of it trimmed to the given size once marshaled. The
+// constraints of the candidates that weren't selected are left out first, and then those candidates themselves, and
+// the conflicts.
+func boundResolutionReport(report *ResolutionReport, size int) (*ResolutionReport, error) {
+	data, err := json.Marshal(report)
+	if err != nil || len(data) <= size {
+		return report, err
+	}
+
+	bounded := &ResolutionReport{Conflicts: report.Conflicts, ConstraintsOmitted: true}
+	var rejected []ResolutionCandidate
+	for _, candidate := range report.Candidates {
+		if candidate.Selected {
+			bounded.Candidates = append(bounded.Candidates, candidate)
+			continue
+		}
+		candidate.Constraints = nil
+		rejected = append(rejected, candidate)
+	}
+	// Sizes are estimated from the report without the rejected candidates, plus each of them in turn, leaving room for
+	// the number of candidates omitted
+	if data, err = json.Marshal(bounded); err != nil {
+		return nil, err
+	}
+	total := len(data) + len(`,"omittedCandidates":`+strconv.Itoa(len(rejected)))
+	for i, candidate := range rejected {
+		data, err := json.Marshal(candidate)
+		if err != nil {
+			return nil, err
+		}
+		if total+len(data)+1 > size {
+			bounded.OmittedCandidates = len(rejected) - i
+			break
+		}
+		total += len(data) + 1
+		bounded.Candidates = append(bounded.Candidates, candidate)
+	}
+	for len(bounded.Conflicts) > 0 && total > size {
+		last := bounded.Conflicts[len(bounded.Conflicts)-1]
+		bounded.Conflicts = bounded.Conflicts[:len(bounded.Conflicts)-1]
+		total -= len(last) + 3
+	}
+	sort.Slice(bounded.Candidates, func(i, j int) bool {
+		return bounded.Candidates[i].Identifier < bounded.Candidates[j].Identifier
+	})
+	return bounded, nil
+}
+
+// reportRecorder records the ResolutionReport of a namespace in an OperatorResolution of that namespace.
+type reportRecorder struct {
+	client dynamic.Interface
+	logger logrus.FieldLogger
+}
+
+func (r *reportRecorder) record(ctx context.Context, namespace string, subs []*v1alpha1.Subscription, report *ResolutionReport) {
+	logger := r.logger.WithField("namespace", namespace)
+	if len(subs) == 0 {
+		return
+	}
+	report, err := boundResolutionReport(report, maxResolutionReportSize)
+	if err != nil {
+		logger.WithError(err).Warn("failed to marshal resolution report")
+		return
+	}
+
+	desired := &OperatorResolution{
+		TypeMeta: metav1.TypeMeta{APIVersion: v1alpha1.SchemeGroupVersion.String(), Kind: OperatorResolutionKind},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ResolutionReportName,
+			Namespace: namespace,
+			Labels:    map[string]string{install.OLMManagedLabelKey: install.OLMManagedLabelValue},
+		},
+		Status: *report,
+	}
+	for _, sub := range subs {
+		desired.OwnerReferences = append(desired.OwnerReferences, ownerutil.NonBlockingOwner(sub))
+	}
+	sort.Slice(desired.OwnerReferences, func(i, j int) bool {
+		return desired.OwnerReferences[i].Name < desired.OwnerReferences[j].Name
+	})
+
+	client := r.client.Resource(OperatorResolutionResource).Namespace(namespace)
+	existing, err := client.Get(ctx, ResolutionReportName, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(desired)
+		if err != nil {
+			logger.WithError(err).Warn("failed to convert resolution report")
+			return
+		}
+		if _, err := client.Create(ctx, &unstructured.Unstructured{Object: obj}, metav1.CreateOptions{}); err != nil {
+			logger.WithError(err).Warn("failed to record resolution report")
+		}
+		return
+	}
+	if err != nil {
+		logger.WithError(err).Warn("failed to get resolution report")
+		return
+	}
+
+	current := &OperatorResolution{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(existing.UnstructuredContent(), current); err == nil &&
+		equality.Semantic.DeepEqual(current.Status, desired.Status) &&
+		equality.Semantic.DeepEqual(current.OwnerReferences, desired.OwnerReferences) &&
+		current.GetLabels()[install.OLMManagedLabelKey] == install.OLMManagedLabelValue {
+		return
+	}
+
+	desired.ResourceVersion = existing.GetResourceVersion()
+	obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(desired)
+	if err != nil {
+		logger.WithError(err).Warn("failed to convert resolution report")
+		return
+	}
+	if _, err := client.Update(ctx, &unstructured.Unstructured{Object: obj}, metav1.UpdateOptions{}); err != nil {
+		logger.WithError(err).Warn("failed to record resolution report")
+	}
+}
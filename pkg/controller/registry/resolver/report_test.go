@@ -0,0 +1,126 @@
+package resolver
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	fakedynamic "k8s.io/client-go/dynamic/fake"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/install"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/registry/resolver/solver"
+)
+
+func TestNewResolutionReport(t *testing.T) {
+	a := GenericInstallable{identifier: "a", constraints: []solver.Constraint{solver.Dependency("b", "c")}}
+	b := GenericInstallable{identifier: "b", constraints: []solver.Constraint{PrettyConstraint(solver.Prohibited(), "b is deprecated")}}
+	c := GenericInstallable{identifier: "c"}
+	d := GenericInstallable{identifier: "d"}
+	installables := []solver.Installable{d, c, b, a}
+
+	report := newResolutionReport(installables, []solver.Installable{a, c}, nil)
+	require.Empty(t, report.Conflicts)
+	require.Equal(t, []ResolutionCandidate{
+		{Identifier: "a", Selected: true, Constraints: []string{"a requires at least one of b, c"}},
+		{Identifier: "b", Constraints: []string{"b is deprecated"}, Reason: "b is deprecated"},
+		{Identifier: "c", Selected: true},
+		{Identifier: "d", Reason: "another candidate was preferred"},
+	}, report.Candidates)
+
+	conflicts := solver.NotSatisfiable{{Installable: d, Constraint: solver.Mandatory()}, {Installable: c, Constraint: solver.Conflict("d")}}
+	report = newResolutionReport(installables, nil, conflicts)
+	require.Equal(t, []string{"d is mandatory", "c conflicts with d"}, report.Conflicts)
+	require.Equal(t, "resolution failed", report.Candidates[0].Reason)
+	require.Equal(t, "b is deprecated", report.Candidates[1].Reason)
+	require.Equal(t, "c conflicts with d", report.Candidates[2].Reason)
+	require.Equal(t, "d is mandatory", report.Candidates[3].Reason)
+}
+
+// newFakeReportClient returns a fake dynamic client serving OperatorResolutions.
+func newFakeReportClient(objs ...runtime.Object) *fakedynamic.FakeDynamicClient {
+	return fakedynamic.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		OperatorResolutionResource: "OperatorResolutionList",
+	}, objs...)
+}
+
+func TestBoundResolutionReport(t *testing.T) {
+	report := &ResolutionReport{
+		Candidates: []ResolutionCandidate{
+			{Identifier: "a", Selected: true, Constraints: []string{"a is mandatory"}},
+			{Identifier: "b", Constraints: []string{"b is deprecated"}, Reason: "b is deprecated"},
+			{Identifier: "c", Constraints: []string{"c requires at least one of d"}, Reason: "another candidate was preferred"},
+		},
+	}
+	bounded, err := boundResolutionReport(report, 1024)
+	require.NoError(t, err)
+	require.Equal(t, report, bounded)
+
+	// Constraints of the rejected candidates go first
+	bounded, err = boundResolutionReport(report, 280)
+	require.NoError(t, err)
+	require.True(t, bounded.ConstraintsOmitted)
+	require.Equal(t, []ResolutionCandidate{
+		{Identifier: "a", Selected: true, Constraints: []string{"a is mandatory"}},
+		{Identifier: "b", Reason: "b is deprecated"},
+		{Identifier: "c", Reason: "another candidate was preferred"},
+	}, bounded.Candidates)
+	data, err := json.Marshal(bounded)
+	require.NoError(t, err)
+	require.LessOrEqual(t, len(data), 280)
+
+	// Then the rejected candidates themselves
+	bounded, err = boundResolutionReport(report, 200)
+	require.NoError(t, err)
+	require.Equal(t, 1, bounded.OmittedCandidates)
+	require.Len(t, bounded.Candidates, 2)
+	data, err = json.Marshal(bounded)
+	require.NoError(t, err)
+	require.LessOrEqual(t, len(data), 200)
+}
+
+func TestReportRecorder(t *testing.T) {
+	ctx := context.TODO()
+	client := newFakeReportClient()
+	recorder := &reportRecorder{client: client, logger: logrus.New()}
+	get := func() *OperatorResolution {
+		u, err := client.Resource(OperatorResolutionResource).Namespace("ns").Get(ctx, ResolutionReportName, metav1.GetOptions{})
+		require.NoError(t, err)
+		resolution := &OperatorResolution{}
+		require.NoError(t, runtime.DefaultUnstructuredConverter.FromUnstructured(u.UnstructuredContent(), resolution))
+		return resolution
+	}
+	sub := func(name string) *v1alpha1.Subscription {
+		return &v1alpha1.Subscription{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ns", UID: types.UID(name)}}
+	}
+
+	// Namespaces without subscriptions have nothing to own the report
+	first := &ResolutionReport{Candidates: []ResolutionCandidate{{Identifier: "a", Selected: true}}}
+	recorder.record(ctx, "ns", nil, first)
+	require.Empty(t, client.Actions())
+
+	recorder.record(ctx, "ns", []*v1alpha1.Subscription{sub("b"), sub("a")}, first)
+	resolution := get()
+	require.Equal(t, *first, resolution.Status)
+	require.Equal(t, install.OLMManagedLabelValue, resolution.GetLabels()[install.OLMManagedLabelKey])
+	require.Len(t, resolution.GetOwnerReferences(), 2)
+	require.Equal(t, "a", resolution.GetOwnerReferences()[0].Name)
+	require.Equal(t, v1alpha1.SubscriptionKind, resolution.GetOwnerReferences()[0].Kind)
+
+	// An unchanged report isn't written again
+	actions := len(client.Actions())
+	recorder.record(ctx, "ns", []*v1alpha1.Subscription{sub("a"), sub("b")}, first)
+	require.Len(t, client.Actions(), actions+1)
+
+	second := &ResolutionReport{Candidates: []ResolutionCandidate{{Identifier: "a", Reason: "resolution failed"}}, Conflicts: []string{"a is prohibited"}}
+	recorder.record(ctx, "ns", []*v1alpha1.Subscription{sub("a")}, second)
+	resolution = get()
+	require.Equal(t, *second, resolution.Status)
+	require.Len(t, resolution.GetOwnerReferences(), 1)
+}
@@ -30,6 +30,7 @@ type SatResolver struct {
 	log                       logrus.FieldLogger
 	pc                        *predicateConverter
 	systemConstraintsProvider solver.ConstraintProvider
+	report                    reportFunc
 }
 
 func NewDefaultSatResolver(rcp cache.SourceProvider, catsrcLister v1alpha1listers.CatalogSourceLister, logger logrus.FieldLogger) *SatResolver {
@@ -53,6 +54,11 @@ func (w *debugWriter) Write(b []byte) (int, error) {
 }
 
 func (r *SatResolver) SolveOperators(namespaces []string, csvs []*v1alpha1.ClusterServiceVersion, subs []*v1alpha1.Subscription) (cache.OperatorSet, error) {
+	return r.solveOperators(context.TODO(), namespaces, csvs, subs, r.report)
+}
+
+// solveOperators resolves the given subscriptions, recording the resolution with the given report func if not nil.
+func (r *SatResolver) solveOperators(ctx context.Context, namespaces []string, csvs []*v1alpha1.ClusterServiceVersion, subs []*v1alpha1.Subscription, report reportFunc) (cache.OperatorSet, error) {
 	var errs []error
 
 	installables := make(map[solver.Identifier]solver.Installable)
@@ -125,7 +131,10 @@ func (r *SatResolver) SolveOperators(namespaces []string, csvs []*v1alpha1.Clust
 	if err != nil {
 		return nil, err
 	}
-	solvedInstallables, err := s.Solve(context.TODO())
+	solvedInstallables, err := s.Solve(ctx)
+	if conflicts, ok := err.(solver.NotSatisfiable); report != nil && (ok || err == nil) {
+		report(ctx, namespaces[0], subs, newResolutionReport(input, solvedInstallables, conflicts))
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -178,6 +187,32 @@ func (r *SatResolver) SolveOperators(namespaces []string, csvs []*v1alpha1.Clust
 	return operators, nil
 }
 
+// sameVersionBundleNames returns the names of the bundles of the subscribed package with the version of the currently
+// installed operator but a different name. Packages renaming their CSVs list the new names in replaces and skips, so
+// the installed operator is identified by package and version in addition to its name.
+func (r *SatResolver) sameVersionBundleNames(sub *v1alpha1.Subscription, current *cache.Entry, finder cache.OperatorFinder) []string {
+	version := *current.Version
+	sameVersion := cache.VersionInRangePredicate(func(v semver.Version) bool { return v.Equals(version) }, "="+version.String())
+
+	var names []string
+	seen := map[string]struct{}{current.Name: {}}
+	for _, entry := range finder.Find(cache.PkgPredicate(sub.Spec.Package), sameVersion) {
+		if _, ok := seen[entry.Name]; ok {
+			continue
+		}
+		seen[entry.Name] = struct{}{}
+		names = append(names, entry.Name)
+		r.log.WithFields(logrus.Fields{
+			"subscription": sub.GetName(),
+			"namespace":    sub.GetNamespace(),
+			"installed":    current.Name,
+			"bundle":       entry.Name,
+			"version":      version.String(),
+		}).Debug("installed CSV name differs from the bundle of the same package and version")
+	}
+	return names
+}
+
 // newBundleInstallableFromEntry converts an entry into a bundle installable with
 // system constraints applied, if they are defined for the entry
 func (r *SatResolver) newBundleInstallableFromEntry(entry *cache.Entry) (*BundleInstallable, error) {
@@ -206,18 +241,44 @@ func (r *SatResolver) getSubscriptionInstallables(sub *v1alpha1.Subscription, cu
 		Namespace: sub.Spec.CatalogSourceNamespace,
 	}
 
+	pin, err := VersionPinFor(sub)
+	if err != nil {
+		si := NewInvalidSubscriptionInstallable(sub.GetName(), err.Error())
+		installables[si.Identifier()] = si
+		return installables, nil
+	}
+	deprecationPolicy, err := DeprecationPolicyFor(sub)
+	if err != nil {
+		si := NewInvalidSubscriptionInstallable(sub.GetName(), err.Error())
+		installables[si.Identifier()] = si
+		return installables, nil
+	}
+
 	var entries []*cache.Entry
 	{
 		var nall, npkg, nch, ncsv int
 
 		csvPredicate := cache.True()
 		if current != nil {
-			// if we found an existing installed operator, we should filter the channel by operators that can replace it
-			channelPredicates = append(channelPredicates, cache.Or(cache.SkipRangeIncludesPredicate(*current.Version), cache.ReplacesPredicate(current.Name)))
+			// if we found an existing installed operator, we should filter the channel by operators that can replace it,
+			// either by name or by the name of any bundle of the package with the same version
+			replacesPredicates := []cache.Predicate{cache.SkipRangeIncludesPredicate(*current.Version), cache.ReplacesPredicate(current.Name)}
+			for _, name := range r.sameVersionBundleNames(sub, current, namespacedCache.Catalog(catalog)) {
+				replacesPredicates = append(replacesPredicates, cache.ReplacesPredicate(name))
+			}
+			channelPredicates = append(channelPredicates, cache.Or(replacesPredicates...))
 		} else if sub.Spec.StartingCSV != "" {
 			// if no operator is installed and we have a startingCSV, filter for it
 			csvPredicate = cache.CSVNamePredicate(sub.Spec.StartingCSV)
 		}
+		if pin != nil {
+			// whether installing or upgrading, only operators within the pinned version are candidates
+			channelPredicates = append(channelPredicates, pin.Predicate())
+		}
+		if rollback, err := RollbackFor(sub); err == nil && rollback != nil && rollback.FailedCSV != "" {
+			// never upgrade again to an operator the subscription was rolled back from
+			channelPredicates = append(channelPredicates, cache.Not(cache.CSVNamePredicate(rollback.FailedCSV)))
+		}
 
 		cachePredicates = append(cachePredicates, cache.And(
 			cache.CountingPredicate(cache.True(), &nall),
@@ -237,6 +298,8 @@ func (r *SatResolver) getSubscriptionInstallables(sub *v1alpha1.Subscription, cu
 			si = NewInvalidSubscriptionInstallable(sub.GetName(), fmt.Sprintf("no operators found in channel %s of package %s in the catalog referenced by subscription %s", sub.Spec.Channel, sub.Spec.Package, sub.GetName()))
 		case ncsv == 0:
 			si = NewInvalidSubscriptionInstallable(sub.GetName(), fmt.Sprintf("no operators found with name %s in channel %s of package %s in the catalog referenced by subscription %s", sub.Spec.StartingCSV, sub.Spec.Channel, sub.Spec.Package, sub.GetName()))
+		case pin != nil && current == nil && len(cache.Filter(entries, pin.Predicate())) == 0:
+			si = NewInvalidSubscriptionInstallable(sub.GetName(), fmt.Sprintf("no operators found with version %s in channel %s of package %s in the catalog referenced by subscription %s", pin.Constraint, sub.Spec.Channel, sub.Spec.Package, sub.GetName()))
 		}
 
 		if si != nil {
@@ -283,8 +346,17 @@ func (r *SatResolver) getSubscriptionInstallables(sub *v1alpha1.Subscription, cu
 		}
 	}
 
+	channelBundles := cache.Filter(sortedBundles, channelPredicates...)
+	allowedBundles := deprecationPolicy.Apply(channelBundles)
+	if current == nil && len(channelBundles) > 0 && len(allowedBundles) == 0 {
+		// the subscription refuses deprecated bundles, and all of its candidates are
+		si := NewInvalidSubscriptionInstallable(sub.GetName(), fmt.Sprintf("all operators found in channel %s of package %s in the catalog referenced by subscription %s are deprecated: %s", sub.Spec.Channel, sub.Spec.Package, sub.GetName(), deprecationMessages(channelBundles)))
+		installables[si.Identifier()] = si
+		return installables, nil
+	}
+
 	candidates := make([]*BundleInstallable, 0)
-	for _, o := range cache.Filter(sortedBundles, channelPredicates...) {
+	for _, o := range allowedBundles {
 		predicates := append(cachePredicates, cache.CSVNamePredicate(o.Name))
 		stack := namespacedCache.Catalog(catalog).Find(predicates...)
 		id, installable, err := r.getBundleInstallables(sub.Namespace, stack, namespacedCache, visited)
@@ -399,7 +471,8 @@ func (r *SatResolver) getBundleInstallables(preferredNamespace string, bundleSta
 				}
 			}
 
-			sortedBundles, err := r.sortBundles(namespacedCache.FindPreferred(&bundle.SourceInfo.Catalog, preferredNamespace, sourcePredicate))
+			preferred := bundle.SourceInfo.Source()
+			sortedBundles, err := r.sortBundles(namespacedCache.FindPreferred(&preferred, preferredNamespace, sourcePredicate))
 			if err != nil {
 				errs = append(errs, err)
 				continue
@@ -418,9 +491,13 @@ func (r *SatResolver) getBundleInstallables(preferredNamespace string, bundleSta
 				bundleDependencies = append(bundleDependencies, i.Identifier())
 				bundleStack = append(bundleStack, b)
 			}
+			message := fmt.Sprintf("bundle %s requires an operator %s", bundle.Name, d.String())
+			if len(bundleDependencies) == 0 {
+				message += explainUnsatisfiedDependency(d, namespacedCache)
+			}
 			bundleInstallable.AddConstraint(PrettyConstraint(
 				solver.Dependency(bundleDependencies...),
-				fmt.Sprintf("bundle %s requires an operator %s", bundle.Name, d.String()),
+				message,
 			))
 		}
 
@@ -439,6 +516,43 @@ func (r *SatResolver) getBundleInstallables(preferredNamespace string, bundleSta
 	return ids, installables, nil
 }
 
+// maxExplainedCandidates bounds the number of candidates whose rejection is explained for an unsatisfiable dependency.
+const maxExplainedCandidates = 5
+
+// explainUnsatisfiedDependency returns, if the candidates of the given dependency can be narrowed down, which of its
+// constraints ruled out each of them.
+func explainUnsatisfiedDependency(d cache.Predicate, namespacedCache cache.MultiCatalogOperatorFinder) string {
+	candidates := cache.Candidates(d)
+	if candidates == nil {
+		return ""
+	}
+	entries := namespacedCache.Find(candidates)
+	if len(entries) == 0 {
+		return ""
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Name < entries[j].Name
+	})
+
+	// the same bundle may be found in several channels
+	var distinct []*cache.Entry
+	for i, e := range entries {
+		if i == 0 || e.Name != entries[i-1].Name {
+			distinct = append(distinct, e)
+		}
+	}
+
+	var explanations []string
+	for i, e := range distinct {
+		if i == maxExplainedCandidates {
+			explanations = append(explanations, fmt.Sprintf("and %d more", len(distinct)-i))
+			break
+		}
+		explanations = append(explanations, fmt.Sprintf("%s ruled out by %s", e.Name, strings.Join(cache.Explain(d, e), " and ")))
+	}
+	return ": " + strings.Join(explanations, ", ")
+}
+
 func (r *SatResolver) inferProperties(csv *v1alpha1.ClusterServiceVersion, subs []*v1alpha1.Subscription) ([]*api.Property, error) {
 	var properties []*api.Property
 
@@ -625,15 +739,16 @@ func (r *SatResolver) sortBundles(bundles []*cache.Entry) ([]*cache.Entry, error
 			Channel:        b.Channel(),
 			DefaultChannel: b.SourceInfo.DefaultChannel,
 		}
-		if _, ok := partitionedBundles[b.SourceInfo.Catalog]; !ok {
-			catalogOrder = append(catalogOrder, b.SourceInfo.Catalog)
-			partitionedBundles[b.SourceInfo.Catalog] = make(map[PackageChannel][]*cache.Entry)
+		source := b.SourceInfo.Source()
+		if _, ok := partitionedBundles[source]; !ok {
+			catalogOrder = append(catalogOrder, source)
+			partitionedBundles[source] = make(map[PackageChannel][]*cache.Entry)
 		}
-		if _, ok := partitionedBundles[b.SourceInfo.Catalog][pc]; !ok {
-			channelOrder[b.SourceInfo.Catalog] = append(channelOrder[b.SourceInfo.Catalog], pc)
-			partitionedBundles[b.SourceInfo.Catalog][pc] = make([]*cache.Entry, 0)
+		if _, ok := partitionedBundles[source][pc]; !ok {
+			channelOrder[source] = append(channelOrder[source], pc)
+			partitionedBundles[source][pc] = make([]*cache.Entry, 0)
 		}
-		partitionedBundles[b.SourceInfo.Catalog][pc] = append(partitionedBundles[b.SourceInfo.Catalog][pc], b)
+		partitionedBundles[source][pc] = append(partitionedBundles[source][pc], b)
 	}
 
 	for catalog := range partitionedBundles {
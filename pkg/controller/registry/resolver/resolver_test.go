@@ -1764,6 +1764,35 @@ func TestSolveOperators_WithSkips(t *testing.T) {
 	require.EqualValues(t, expected, operators)
 }
 
+func TestSolveOperators_ReplacesRenamedCSVByVersion(t *testing.T) {
+	const namespace = "test-namespace"
+	catalog := cache.SourceKey{Name: "test-catalog", Namespace: namespace}
+
+	// The package renamed its CSVs after packageA-operator.v1.0.0 was installed
+	csv := existingOperator(namespace, "packageA-operator.v1.0.0", "packageA", "alpha", "", nil, nil, nil, nil)
+	csv.Spec.Version = opver.OperatorVersion{Version: semver.MustParse("1.0.0")}
+	csvs := []*v1alpha1.ClusterServiceVersion{csv}
+	subs := []*v1alpha1.Subscription{existingSub(namespace, "packageA-operator.v1.0.0", "packageA", "alpha", catalog)}
+
+	opA1 := genOperator("packageA.v1.0.0", "1.0.0", "", "packageA", "alpha", catalog.Name, catalog.Namespace, nil, nil, nil, "", false)
+	opA2 := genOperator("packageA.v1.1.0", "1.1.0", "packageA.v1.0.0", "packageA", "alpha", catalog.Name, catalog.Namespace, nil, nil, nil, "", false)
+
+	satResolver := SatResolver{
+		cache: cache.New(cache.StaticSourceProvider{
+			catalog: &cache.Snapshot{
+				Entries: []*cache.Entry{opA1, opA2},
+			},
+		}),
+		log: logrus.New(),
+	}
+
+	operators, err := satResolver.SolveOperators([]string{namespace}, csvs, subs)
+	require.NoError(t, err)
+	require.Len(t, operators, 1)
+	require.Contains(t, operators, "packageA.v1.1.0")
+	require.Equal(t, "packageA-operator.v1.0.0", operators["packageA.v1.1.0"].Replaces)
+}
+
 func TestSolveOperatorsWithSkipsPreventingSelection(t *testing.T) {
 	const namespace = "test-namespace"
 	catalog := cache.SourceKey{Name: "test-catalog", Namespace: namespace}
@@ -2508,6 +2537,21 @@ func TestSolveOperators_GenericConstraint(t *testing.T) {
 		},
 	}
 
+	deps4 := []*api.Dependency{
+		{
+			Type: "olm.constraint",
+			Value: `{"message":"compound-constraint",
+				"all":{"constraints":[
+					{"package":{"packageName":"packageB","versionRange":">=1.0.1"}},
+					{"message":"property foo=bar is required","cel":{"rule":"properties.exists(p, p.type == 'foo' && p.value == 'bar')"}}
+				]}}`,
+		},
+	}
+	withFoo := func(e *cache.Entry, value string) *cache.Entry {
+		e.Properties = append(e.Properties, &api.Property{Type: "foo", Value: `"` + value + `"`})
+		return e
+	}
+
 	tests := []struct {
 		name     string
 		isErr    bool
@@ -2572,6 +2616,23 @@ func TestSolveOperators_GenericConstraint(t *testing.T) {
 				"opB.v1.0.1": genOperator("opB.v1.0.1", "1.0.1", "opB.v1.0.0", "packageB", "stable", catalog.Name, catalog.Namespace, nil, nil, nil, "stable", false),
 			},
 		},
+		{
+			// compound constraint reporting which of its constraints ruled out each candidate
+			name:  "Generic Constraint/NotSatisfiable Compound Dependency",
+			isErr: true,
+			subs: []*v1alpha1.Subscription{
+				newSub(namespace, "packageA", "stable", catalog),
+			},
+			catalog: &cache.Snapshot{
+				Entries: []*cache.Entry{
+					genOperator("opA.v1.0.0", "1.0.0", "", "packageA", "stable", catalog.Name, catalog.Namespace, nil, nil, deps4, "", false),
+					withFoo(genOperator("opB.v1.0.0", "1.0.0", "", "packageB", "stable", catalog.Name, catalog.Namespace, nil, nil, nil, "", false), "bar"),
+					withFoo(genOperator("opB.v1.0.1", "1.0.1", "opB.v1.0.0", "packageB", "stable", catalog.Name, catalog.Namespace, nil, nil, nil, "stable", false), "baz"),
+				},
+			},
+			expected: cache.OperatorSet{},
+			message:  "opB.v1.0.0 ruled out by with version in range: >=1.0.1, opB.v1.0.1 ruled out by property foo=bar is required",
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,40 @@
+package resolver
+
+import (
+	"encoding/json"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+)
+
+// SubscriptionRollbackAnnotationKey is the Subscription annotation in which OLM records, as a JSON-encoded Rollback,
+// the operator a Subscription opted into rollbacks falls back to when an upgrade fails, and the operator it last
+// rolled back from.
+const SubscriptionRollbackAnnotationKey = "operatorframework.io/rollback"
+
+// Rollback is the rollback state of a Subscription.
+type Rollback struct {
+	// CSV is the last CSV installed by the Subscription that reached the Succeeded phase.
+	CSV string `json:"csv"`
+	// InstallPlan is the InstallPlan that installed CSV, which is retained to re-apply it.
+	InstallPlan string `json:"installPlan"`
+	// FailedCSV is the CSV the Subscription was rolled back from. The resolver doesn't upgrade to it again.
+	FailedCSV string `json:"failedCSV,omitempty"`
+	// RolledBackAt is the time of the last rollback.
+	RolledBackAt *metav1.Time `json:"rolledBackAt,omitempty"`
+}
+
+// RollbackFor returns the rollback state recorded on the given Subscription, or nil if none has been recorded.
+func RollbackFor(sub *v1alpha1.Subscription) (*Rollback, error) {
+	value, ok := sub.GetAnnotations()[SubscriptionRollbackAnnotationKey]
+	if !ok {
+		return nil, nil
+	}
+	rollback := &Rollback{}
+	if err := json.Unmarshal([]byte(value), rollback); err != nil {
+		return nil, fmt.Errorf("invalid %s annotation on subscription %s: %v", SubscriptionRollbackAnnotationKey, sub.GetName(), err)
+	}
+	return rollback, nil
+}
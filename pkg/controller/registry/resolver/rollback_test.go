@@ -0,0 +1,66 @@
+package resolver
+
+import (
+	"testing"
+
+	"github.com/blang/semver/v4"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/operator-framework/api/pkg/lib/version"
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/registry/resolver/cache"
+)
+
+func TestSolveOperators_WithRollback(t *testing.T) {
+	const namespace = "test-namespace"
+	catalog := cache.SourceKey{Name: "test-catalog", Namespace: namespace}
+
+	csv := existingOperator(namespace, "packageA.v1.0.0", "packageA", "alpha", "", nil, nil, nil, nil)
+	csv.Spec.Version = version.OperatorVersion{Version: semver.MustParse("1.0.0")}
+	csvs := []*v1alpha1.ClusterServiceVersion{csv}
+
+	opA1 := genOperator("packageA.v1.0.0", "1.0.0", "", "packageA", "alpha", catalog.Name, catalog.Namespace, nil, nil, nil, "", false)
+	opA2 := genOperator("packageA.v1.1.0", "1.1.0", "packageA.v1.0.0", "packageA", "alpha", catalog.Name, catalog.Namespace, nil, nil, nil, "", false)
+	opA3 := genOperator("packageA.v1.2.0", "1.2.0", "packageA.v1.1.0", "packageA", "alpha", catalog.Name, catalog.Namespace, nil, nil, nil, "", false)
+	opA3.Skips = []string{"packageA.v1.0.0"}
+
+	tests := []struct {
+		name     string
+		entries  []*cache.Entry
+		expected []string
+	}{
+		{
+			name:    "DoesNotUpgradeToFailedCSV",
+			entries: []*cache.Entry{opA1, opA2},
+		},
+		{
+			name:     "UpgradesPastFailedCSV",
+			entries:  []*cache.Entry{opA1, opA2, opA3},
+			expected: []string{"packageA.v1.2.0"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sub := existingSub(namespace, "packageA.v1.0.0", "packageA", "alpha", catalog)
+			sub.SetAnnotations(map[string]string{
+				SubscriptionRollbackAnnotationKey: `{"csv":"packageA.v1.0.0","installPlan":"install-1","failedCSV":"packageA.v1.1.0"}`,
+			})
+
+			satResolver := SatResolver{
+				cache: cache.New(cache.StaticSourceProvider{
+					catalog: &cache.Snapshot{Entries: tt.entries},
+				}),
+				log: logrus.New(),
+			}
+
+			operators, err := satResolver.SolveOperators([]string{namespace}, csvs, []*v1alpha1.Subscription{sub})
+			require.NoError(t, err)
+			var names []string
+			for name := range operators {
+				names = append(names, name)
+			}
+			require.ElementsMatch(t, tt.expected, names)
+		})
+	}
+}
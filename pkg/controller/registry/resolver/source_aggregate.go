@@ -0,0 +1,116 @@
+package resolver
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/api/client/listers/operators/v1alpha1"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/registry/reconciler"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/registry/resolver/cache"
+)
+
+// aggregateSourceProvider adds the aggregate CatalogSources of the requested namespaces to the sources of an
+// underlying provider.
+type aggregateSourceProvider struct {
+	sp                     cache.SourceProvider
+	catsrcLister           v1alpha1.CatalogSourceLister
+	globalCatalogNamespace string
+	logger                 logrus.StdLogger
+}
+
+// SourceProviderWithAggregates returns a SourceProvider serving the aggregate CatalogSources of the requested
+// namespaces alongside the sources of the given provider. Aggregates may only list members from their own namespace
+// or from the global catalog namespace.
+func SourceProviderWithAggregates(sp cache.SourceProvider, catsrcLister v1alpha1.CatalogSourceLister, globalCatalogNamespace string, logger logrus.StdLogger) cache.SourceProvider {
+	return &aggregateSourceProvider{
+		sp:                     sp,
+		catsrcLister:           catsrcLister,
+		globalCatalogNamespace: globalCatalogNamespace,
+		logger:                 logger,
+	}
+}
+
+func (p *aggregateSourceProvider) Sources(namespaces ...string) map[cache.SourceKey]cache.Source {
+	result := p.sp.Sources(namespaces...)
+	for _, namespace := range namespaces {
+		catsrcs, err := p.catsrcLister.CatalogSources(namespace).List(labels.Everything())
+		if err != nil {
+			p.logger.Printf("failed to list catalogsources in namespace %s, continuing: %v", namespace, err)
+			continue
+		}
+		for _, catsrc := range catsrcs {
+			if catsrc.Spec.SourceType != reconciler.SourceTypeAggregate {
+				continue
+			}
+			members, err := reconciler.AggregateMembers(catsrc)
+			if err != nil {
+				p.logger.Printf("failed to get members of aggregate catalogsource %s/%s, continuing: %v", catsrc.GetNamespace(), catsrc.GetName(), err)
+				continue
+			}
+
+			key := cache.SourceKey{Name: catsrc.GetName(), Namespace: catsrc.GetNamespace()}
+			source := &aggregateSource{key: key}
+			for _, member := range members {
+				if member.Namespace != catsrc.GetNamespace() && member.Namespace != p.globalCatalogNamespace {
+					p.logger.Printf("member %s/%s of aggregate catalogsource %s is not visible from its namespace, continuing", member.Namespace, member.Name, key.String())
+					continue
+				}
+				memberKey := cache.SourceKey{Name: member.Name, Namespace: member.Namespace}
+				memberSource, ok := p.sp.Sources(member.Namespace)[memberKey]
+				if !ok {
+					p.logger.Printf("member %s of aggregate catalogsource %s has no source yet, continuing", memberKey.String(), key.String())
+					continue
+				}
+				source.members = append(source.members, memberSource)
+			}
+			result[key] = source
+		}
+	}
+	return result
+}
+
+// aggregateSource merges the snapshots of its members. A package is served entirely from the first member, in order of
+// precedence, that provides it, so that upgrade graphs are never stitched together from different catalogs. Entries
+// keep the key of the member providing them, which their bundles are unpacked from, and record the aggregate serving
+// them.
+type aggregateSource struct {
+	key     cache.SourceKey
+	members []cache.Source
+}
+
+func (s *aggregateSource) Snapshot(ctx context.Context) (*cache.Snapshot, error) {
+	var entries []*cache.Entry
+	served := make(map[string]struct{})
+	for _, member := range s.members {
+		snapshot, err := member.Snapshot(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to snapshot member of aggregate catalogsource %s: %w", s.key.String(), err)
+		}
+
+		provided := make(map[string]struct{})
+		for _, entry := range snapshot.Entries {
+			pkg := entry.Package()
+			if _, ok := served[pkg]; ok {
+				continue
+			}
+			provided[pkg] = struct{}{}
+
+			// Entries are copied since member snapshots may also be cached on their own
+			e := *entry
+			if entry.SourceInfo != nil {
+				si := *entry.SourceInfo
+				si.Aggregate = s.key
+				e.SourceInfo = &si
+			}
+			entries = append(entries, &e)
+		}
+		for pkg := range provided {
+			served[pkg] = struct{}{}
+		}
+	}
+
+	return &cache.Snapshot{Entries: entries}, nil
+}
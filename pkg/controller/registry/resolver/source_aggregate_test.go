@@ -0,0 +1,64 @@
+package resolver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/registry/reconciler"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/registry/resolver/cache"
+)
+
+func TestAggregateSourceSnapshot(t *testing.T) {
+	internal := cache.SourceKey{Name: "internal", Namespace: "ns"}
+	community := cache.SourceKey{Name: "community", Namespace: "olm"}
+	aggregate := cache.SourceKey{Name: "aggregate", Namespace: "ns"}
+
+	sp := cache.StaticSourceProvider{
+		internal: &cache.Snapshot{Entries: []*cache.Entry{
+			genOperator("a.v1", "1.0.0", "", "a", "stable", internal.Name, internal.Namespace, nil, nil, nil, "stable", false),
+		}},
+		community: &cache.Snapshot{Entries: []*cache.Entry{
+			genOperator("a.v2", "2.0.0", "a.v1", "a", "stable", community.Name, community.Namespace, nil, nil, nil, "stable", false),
+			genOperator("b.v1", "1.0.0", "", "b", "stable", community.Name, community.Namespace, nil, nil, nil, "stable", false),
+		}},
+	}
+	lister := &stubCatalogSourceLister{catsrcs: []*v1alpha1.CatalogSource{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      aggregate.Name,
+				Namespace: aggregate.Namespace,
+				Annotations: map[string]string{
+					reconciler.AggregateMembersAnnotationKey: `[{"name":"internal"},{"name":"community","namespace":"olm"},{"name":"hidden","namespace":"other"}]`,
+				},
+			},
+			Spec: v1alpha1.CatalogSourceSpec{SourceType: reconciler.SourceTypeAggregate},
+		},
+	}}
+
+	sources := SourceProviderWithAggregates(sp, lister, "olm", logrus.New()).Sources("ns", "olm")
+	require.Len(t, sources, 3)
+	require.Contains(t, sources, aggregate)
+
+	snapshot, err := sources[aggregate].Snapshot(context.Background())
+	require.NoError(t, err)
+
+	served := map[string]cache.SourceKey{}
+	for _, entry := range snapshot.Entries {
+		require.Equal(t, aggregate, entry.SourceInfo.Aggregate)
+		require.Equal(t, aggregate, entry.SourceInfo.Source())
+		served[entry.Name] = entry.SourceInfo.Catalog
+	}
+	// a is served from internal only, since it takes precedence over community, and entries keep their member's key
+	require.Equal(t, map[string]cache.SourceKey{"a.v1": internal, "b.v1": community}, served)
+
+	// member snapshots are left untouched
+	memberSnapshot, err := sources[community].Snapshot(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, community, memberSnapshot.Entries[1].SourceInfo.Catalog)
+	require.True(t, memberSnapshot.Entries[1].SourceInfo.Aggregate.Empty())
+}
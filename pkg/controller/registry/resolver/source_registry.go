@@ -19,8 +19,9 @@ type RegistryClientProvider interface {
 }
 
 type registryClientAdapter struct {
-	rcp    RegistryClientProvider
-	logger logrus.StdLogger
+	rcp        RegistryClientProvider
+	logger     logrus.StdLogger
+	quarantine quarantineFunc
 }
 
 func SourceProviderFromRegistryClientProvider(rcp RegistryClientProvider, logger logrus.StdLogger) cache.SourceProvider {
@@ -31,9 +32,10 @@ func SourceProviderFromRegistryClientProvider(rcp RegistryClientProvider, logger
 }
 
 type registrySource struct {
-	key    cache.SourceKey
-	client client.Interface
-	logger logrus.StdLogger
+	key        cache.SourceKey
+	client     client.Interface
+	logger     logrus.StdLogger
+	quarantine quarantineFunc
 }
 
 func (s *registrySource) Snapshot(ctx context.Context) (*cache.Snapshot, error) {
@@ -48,7 +50,13 @@ func (s *registrySource) Snapshot(ctx context.Context) (*cache.Snapshot, error)
 	}
 
 	var operators []*cache.Entry
+	var quarantined []QuarantinedBundle
 	for b := it.Next(); b != nil; b = it.Next() {
+		if errs := validateBundle(b); len(errs) > 0 {
+			s.logger.Printf("quarantining bundle %s of package %s that fails validation, continuing: %v", b.CsvName, b.PackageName, errs)
+			quarantined = append(quarantined, QuarantinedBundle{Name: b.CsvName, Package: b.PackageName, Errors: errs})
+			continue
+		}
 		defaultChannel, ok := defaultChannels[b.PackageName]
 		if !ok {
 			if p, err := s.client.GetPackage(ctx, b.PackageName); err != nil {
@@ -73,6 +81,9 @@ func (s *registrySource) Snapshot(ctx context.Context) (*cache.Snapshot, error)
 	if err := it.Error(); err != nil {
 		return nil, fmt.Errorf("error encountered while listing bundles: %w", err)
 	}
+	if s.quarantine != nil {
+		s.quarantine(s.key, quarantined)
+	}
 
 	return &cache.Snapshot{Entries: operators}, nil
 }
@@ -81,9 +92,10 @@ func (a *registryClientAdapter) Sources(namespaces ...string) map[cache.SourceKe
 	result := make(map[cache.SourceKey]cache.Source)
 	for key, client := range a.rcp.ClientsForNamespaces(namespaces...) {
 		result[cache.SourceKey(key)] = &registrySource{
-			key:    cache.SourceKey(key),
-			client: client,
-			logger: a.logger,
+			key:        cache.SourceKey(key),
+			client:     client,
+			logger:     a.logger,
+			quarantine: a.quarantine,
 		}
 	}
 	return result
@@ -10,6 +10,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 
 	"github.com/operator-framework/api/pkg/operators/v1alpha1"
@@ -29,7 +30,7 @@ const (
 var initHooks []stepResolverInitHook
 
 type StepResolver interface {
-	ResolveSteps(namespace string) ([]*v1alpha1.Step, []v1alpha1.BundleLookup, []*v1alpha1.Subscription, error)
+	ResolveSteps(ctx context.Context, namespace string) ([]*v1alpha1.Step, []v1alpha1.BundleLookup, []*v1alpha1.Subscription, error)
 	Expire(key cache.SourceKey)
 }
 
@@ -46,8 +47,18 @@ type OperatorStepResolver struct {
 
 var _ StepResolver = &OperatorStepResolver{}
 
-func NewOperatorStepResolver(lister operatorlister.OperatorLister, client versioned.Interface, kubeclient kubernetes.Interface,
+func NewOperatorStepResolver(lister operatorlister.OperatorLister, client versioned.Interface, kubeclient kubernetes.Interface, dynamicClient dynamic.Interface,
 	globalCatalogNamespace string, provider RegistryClientProvider, log logrus.FieldLogger) *OperatorStepResolver {
+	registrySources := &registryClientAdapter{
+		rcp:    provider,
+		logger: log,
+		quarantine: (&quarantineRecorder{
+			client:       client,
+			catsrcLister: lister.OperatorsV1alpha1().CatalogSourceLister(),
+			logger:       log,
+		}).record,
+	}
+	sourceProvider := SourceProviderWithAggregates(registrySources, lister.OperatorsV1alpha1().CatalogSourceLister(), globalCatalogNamespace, log)
 	stepResolver := &OperatorStepResolver{
 		subLister:              lister.OperatorsV1alpha1().SubscriptionLister(),
 		csvLister:              lister.OperatorsV1alpha1().ClusterServiceVersionLister(),
@@ -55,9 +66,13 @@ func NewOperatorStepResolver(lister operatorlister.OperatorLister, client versio
 		client:                 client,
 		kubeclient:             kubeclient,
 		globalCatalogNamespace: globalCatalogNamespace,
-		satResolver:            NewDefaultSatResolver(SourceProviderFromRegistryClientProvider(provider, log), lister.OperatorsV1alpha1().CatalogSourceLister(), log),
+		satResolver:            NewDefaultSatResolver(sourceProvider, lister.OperatorsV1alpha1().CatalogSourceLister(), log),
 		log:                    log,
 	}
+	stepResolver.satResolver.report = (&reportRecorder{
+		client: dynamicClient,
+		logger: log,
+	}).record
 
 	// init hooks can be added to the downstream to
 	// modify resolver behaviour
@@ -73,7 +88,17 @@ func (r *OperatorStepResolver) Expire(key cache.SourceKey) {
 	r.satResolver.cache.Expire(key)
 }
 
-func (r *OperatorStepResolver) ResolveSteps(namespace string) ([]*v1alpha1.Step, []v1alpha1.BundleLookup, []*v1alpha1.Subscription, error) {
+func (r *OperatorStepResolver) ResolveSteps(ctx context.Context, namespace string) ([]*v1alpha1.Step, []v1alpha1.BundleLookup, []*v1alpha1.Subscription, error) {
+	return r.resolveSteps(ctx, namespace, nil, r.satResolver.report)
+}
+
+// PreviewSteps resolves the given namespace as if the given subscription existed in it, without recording the
+// resolution. The updated subscriptions returned include the previewed one, if resolved.
+func (r *OperatorStepResolver) PreviewSteps(ctx context.Context, namespace string, sub *v1alpha1.Subscription) ([]*v1alpha1.Step, []v1alpha1.BundleLookup, []*v1alpha1.Subscription, error) {
+	return r.resolveSteps(ctx, namespace, sub, nil)
+}
+
+func (r *OperatorStepResolver) resolveSteps(ctx context.Context, namespace string, preview *v1alpha1.Subscription, report reportFunc) ([]*v1alpha1.Step, []v1alpha1.BundleLookup, []*v1alpha1.Subscription, error) {
 	// create a generation - a representation of the current set of installed operators and their provided/required apis
 	allCSVs, err := r.csvLister.ClusterServiceVersions(namespace).List(labels.Everything())
 	if err != nil {
@@ -81,22 +106,26 @@ func (r *OperatorStepResolver) ResolveSteps(namespace string) ([]*v1alpha1.Step,
 	}
 
 	// TODO: build this index ahead of time
-	// omit copied csvs from generation - they indicate that apis are provided to the namespace, not by the namespace
-	var csvs []*v1alpha1.ClusterServiceVersion
-	for i := range allCSVs {
-		if !allCSVs[i].IsCopied() {
-			csvs = append(csvs, allCSVs[i])
-		}
-	}
+	csvs := withoutCopiedCSVs(allCSVs)
 
 	subs, err := r.listSubscriptions(namespace)
 	if err != nil {
 		return nil, nil, nil, err
 	}
+	subs = withoutHeldTransactions(subs)
+	if preview != nil {
+		previewed := subs[:0]
+		for _, sub := range subs {
+			if sub.GetName() != preview.GetName() {
+				previewed = append(previewed, sub)
+			}
+		}
+		subs = append(previewed, preview.DeepCopy())
+	}
 
 	var operators cache.OperatorSet
 	namespaces := []string{namespace, r.globalCatalogNamespace}
-	operators, err = r.satResolver.SolveOperators(namespaces, csvs, subs)
+	operators, err = r.satResolver.solveOperators(ctx, namespaces, csvs, subs, report)
 	if err != nil {
 		return nil, nil, nil, err
 	}
@@ -121,7 +150,7 @@ func (r *OperatorStepResolver) ResolveSteps(namespace string) ([]*v1alpha1.Step,
 				Name:      sub.Spec.CatalogSource,
 				Namespace: sub.Spec.CatalogSourceNamespace,
 			}
-			if !subCatalogKey.Empty() && !subCatalogKey.Equal(sourceInfo.Catalog) {
+			if !subCatalogKey.Empty() && !subCatalogKey.Equal(sourceInfo.Source()) {
 				continue
 			}
 			alreadyExists, err := r.hasExistingCurrentCSV(sub)
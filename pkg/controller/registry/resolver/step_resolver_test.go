@@ -1,6 +1,7 @@
 package resolver
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"testing"
@@ -54,7 +55,7 @@ func TestInitHooks(t *testing.T) {
 	log := logrus.New()
 
 	// no init hooks
-	resolver := NewOperatorStepResolver(lister, clientFake, kClientFake, "", nil, log)
+	resolver := NewOperatorStepResolver(lister, clientFake, kClientFake, newFakeReportClient(), "", nil, log)
 	require.NotNil(t, resolver.satResolver)
 
 	// with init hook
@@ -70,7 +71,7 @@ func TestInitHooks(t *testing.T) {
 		initHooks = nil
 	}()
 
-	resolver = NewOperatorStepResolver(lister, clientFake, kClientFake, "", nil, log)
+	resolver = NewOperatorStepResolver(lister, clientFake, kClientFake, newFakeReportClient(), "", nil, log)
 	require.Nil(t, resolver.satResolver)
 }
 
@@ -869,10 +870,10 @@ func TestResolver(t *testing.T) {
 				cache: resolvercache.New(ssp),
 				log:   log,
 			}
-			resolver := NewOperatorStepResolver(lister, clientFake, kClientFake, "", nil, log)
+			resolver := NewOperatorStepResolver(lister, clientFake, kClientFake, newFakeReportClient(), "", nil, log)
 			resolver.satResolver = satresolver
 
-			steps, lookups, subs, err := resolver.ResolveSteps(namespace)
+			steps, lookups, subs, err := resolver.ResolveSteps(context.TODO(), namespace)
 			if tt.out.solverError == nil {
 				if tt.out.errAssert == nil {
 					assert.NoError(t, err)
@@ -899,6 +900,43 @@ func TestResolver(t *testing.T) {
 	}
 }
 
+func TestPreviewSteps(t *testing.T) {
+	const namespace = "catsrc-namespace"
+	catalog := resolvercache.SourceKey{Name: "catsrc", Namespace: namespace}
+	b := bundle("a.v1", "a", "alpha", "", nil, nil, nil, nil)
+
+	stopc := make(chan struct{})
+	defer func() {
+		stopc <- struct{}{}
+	}()
+	clientFake, informerFactory, _ := StartResolverInformers(namespace, stopc)
+	lister := operatorlister.NewLister()
+	lister.OperatorsV1alpha1().RegisterSubscriptionLister(namespace, informerFactory.Operators().V1alpha1().Subscriptions().Lister())
+	lister.OperatorsV1alpha1().RegisterClusterServiceVersionLister(namespace, informerFactory.Operators().V1alpha1().ClusterServiceVersions().Lister())
+
+	op, err := newOperatorFromBundle(b, "", catalog, "")
+	require.NoError(t, err)
+	log := logrus.New()
+	resolver := NewOperatorStepResolver(lister, clientFake, k8sfake.NewSimpleClientset(), newFakeReportClient(), "", nil, log)
+	resolver.satResolver = &SatResolver{
+		cache: resolvercache.New(resolvercache.StaticSourceProvider{catalog: &resolvercache.Snapshot{Entries: []*resolvercache.Entry{op}}}),
+		log:   log,
+		report: func(context.Context, string, []*v1alpha1.Subscription, *ResolutionReport) {
+			t.Error("previews must not record resolution reports")
+		},
+	}
+
+	steps, lookups, subs, err := resolver.PreviewSteps(context.TODO(), namespace, newSub(namespace, "a", "alpha", catalog))
+	require.NoError(t, err)
+	RequireStepsEqual(t, bundleSteps(b, namespace, "", catalog), steps)
+	require.Empty(t, lookups)
+	require.Equal(t, []*v1alpha1.Subscription{updatedSub(namespace, "a.v1", "", "a", "alpha", catalog)}, subs)
+
+	list, err := clientFake.OperatorsV1alpha1().Subscriptions(namespace).List(context.TODO(), metav1.ListOptions{})
+	require.NoError(t, err)
+	require.Empty(t, list.Items, "previewed subscriptions must not be persisted")
+}
+
 func TestNamespaceResolverRBAC(t *testing.T) {
 	namespace := "catsrc-namespace"
 	catalog := resolvercache.SourceKey{Name: "catsrc", Namespace: namespace}
@@ -1000,9 +1038,9 @@ func TestNamespaceResolverRBAC(t *testing.T) {
 					catalog: stubSnapshot,
 				}),
 			}
-			resolver := NewOperatorStepResolver(lister, clientFake, kClientFake, "", nil, logrus.New())
+			resolver := NewOperatorStepResolver(lister, clientFake, kClientFake, newFakeReportClient(), "", nil, logrus.New())
 			resolver.satResolver = satresolver
-			steps, _, subs, err := resolver.ResolveSteps(namespace)
+			steps, _, subs, err := resolver.ResolveSteps(context.TODO(), namespace)
 			require.Equal(t, tt.out.err, err)
 			RequireStepsEqual(t, expectedSteps, steps)
 			require.ElementsMatch(t, tt.out.subs, subs)
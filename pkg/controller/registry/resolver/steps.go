@@ -19,6 +19,7 @@ import (
 	"github.com/operator-framework/api/pkg/operators/v1alpha1"
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/registry/resolver/cache"
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/registry/resolver/projection"
+	csvutility "github.com/operator-framework/operator-lifecycle-manager/pkg/lib/csv"
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/ownerutil"
 )
 
@@ -87,15 +88,18 @@ func NewStepResourceFromObject(obj runtime.Object, catalogSourceName, catalogSou
 	return resource, nil
 }
 
+// NewSubscriptionStepResource returns the step creating a subscription to the given operator, referencing the source
+// it's served from, so that operators served through an aggregate CatalogSource keep being updated through it.
 func NewSubscriptionStepResource(namespace string, info cache.OperatorSourceInfo) (v1alpha1.StepResource, error) {
+	source := info.Source()
 	return NewStepResourceFromObject(&v1alpha1.Subscription{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: namespace,
-			Name:      strings.Join([]string{info.Package, info.Channel, info.Catalog.Name, info.Catalog.Namespace}, "-"),
+			Name:      strings.Join([]string{info.Package, info.Channel, source.Name, source.Namespace}, "-"),
 		},
 		Spec: &v1alpha1.SubscriptionSpec{
-			CatalogSource:          info.Catalog.Name,
-			CatalogSourceNamespace: info.Catalog.Namespace,
+			CatalogSource:          source.Name,
+			CatalogSourceNamespace: source.Namespace,
 			Package:                info.Package,
 			Channel:                info.Channel,
 			StartingCSV:            info.StartingCSV,
@@ -132,6 +136,14 @@ func NewStepResourceFromBundle(bundle *api.Bundle, namespace, replaces, catalogS
 	annos[projection.PropertiesAnnotationKey] = anno
 	csv.SetAnnotations(annos)
 
+	extensions, err := csvutility.UIExtensionsFor(annos, bundle.Properties)
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect ui extensions of %q: %w", csv.GetName(), err)
+	}
+	if _, err := csvutility.SetUIExtensions(csv, extensions); err != nil {
+		return nil, err
+	}
+
 	step, err := NewStepResourceFromObject(csv, catalogSourceName, catalogSourceNamespace)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,151 @@
+package resolver
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+)
+
+const (
+	// TransactionLabelKey is the Subscription label grouping the Subscriptions of a namespace into an install
+	// transaction. The Subscriptions of a transaction are resolved and installed together: either all of their
+	// operators reach the Succeeded phase, or OLM rolls the transaction back.
+	TransactionLabelKey = "operatorframework.io/transaction"
+	// TransactionSizeAnnotationKey is the annotation setting the number of Subscriptions of a transaction. The
+	// Subscriptions of a transaction aren't resolved until that many of them exist.
+	TransactionSizeAnnotationKey = "operatorframework.io/transaction-size"
+	// TransactionStateAnnotationKey is the Subscription annotation in which OLM records, as a JSON-encoded
+	// TransactionStatus, the outcome of the transaction of a Subscription.
+	TransactionStateAnnotationKey = "operatorframework.io/transaction-state"
+)
+
+// TransactionState is the outcome of a transaction.
+type TransactionState string
+
+const (
+	// TransactionCommitted is the state of a transaction whose operators all reached the Succeeded phase. Committed
+	// Subscriptions are resolved on their own from then on.
+	TransactionCommitted TransactionState = "Committed"
+	// TransactionRolledBack is the state of a transaction whose operators were uninstalled after one of them failed.
+	// Rolled back Subscriptions aren't resolved again until they're labeled with a new transaction.
+	TransactionRolledBack TransactionState = "RolledBack"
+)
+
+// TransactionStatus is the outcome of the transaction of a Subscription.
+type TransactionStatus struct {
+	// ID is the transaction the Subscription was labeled with.
+	ID string `json:"id"`
+	// State is the outcome of the transaction.
+	State TransactionState `json:"state"`
+}
+
+// TransactionStatusFor returns the outcome recorded on the given Subscription for the transaction it's labeled with,
+// or nil if none has been recorded.
+func TransactionStatusFor(sub *v1alpha1.Subscription) (*TransactionStatus, error) {
+	value, ok := sub.GetAnnotations()[TransactionStateAnnotationKey]
+	if !ok {
+		return nil, nil
+	}
+	status := &TransactionStatus{}
+	if err := json.Unmarshal([]byte(value), status); err != nil {
+		return nil, fmt.Errorf("invalid %s annotation on subscription %s: %v", TransactionStateAnnotationKey, sub.GetName(), err)
+	}
+	if status.ID != sub.GetLabels()[TransactionLabelKey] {
+		// the subscription was moved to another transaction
+		return nil, nil
+	}
+	return status, nil
+}
+
+// Transaction is a set of Subscriptions of a namespace installed as a unit.
+type Transaction struct {
+	// ID is the value of the transaction label of the Subscriptions.
+	ID string
+	// Size is the number of Subscriptions of the transaction, or zero if it isn't set.
+	Size int
+	// Subscriptions are the existing Subscriptions of the transaction.
+	Subscriptions []*v1alpha1.Subscription
+}
+
+// Transactions groups the given Subscriptions by the transaction they're labeled with.
+func Transactions(subs []*v1alpha1.Subscription) map[string]*Transaction {
+	transactions := map[string]*Transaction{}
+	for _, sub := range subs {
+		id := sub.GetLabels()[TransactionLabelKey]
+		if id == "" {
+			continue
+		}
+		t, ok := transactions[id]
+		if !ok {
+			t = &Transaction{ID: id}
+			transactions[id] = t
+		}
+		if size, err := strconv.Atoi(sub.GetAnnotations()[TransactionSizeAnnotationKey]); err == nil && size > t.Size {
+			t.Size = size
+		}
+		t.Subscriptions = append(t.Subscriptions, sub)
+	}
+	return transactions
+}
+
+// Missing returns the number of Subscriptions of the transaction that don't exist yet.
+func (t *Transaction) Missing() int {
+	if missing := t.Size - len(t.Subscriptions); missing > 0 {
+		return missing
+	}
+	return 0
+}
+
+// Pending returns true if the transaction waits for some of its Subscriptions to be created before being resolved.
+// Transactions stop waiting once any of their Subscriptions was resolved.
+func (t *Transaction) Pending() bool {
+	if t.Missing() == 0 {
+		return false
+	}
+	for _, sub := range t.Subscriptions {
+		if sub.Status.CurrentCSV != "" || sub.Status.InstallPlanRef != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// State returns the outcome of the transaction: RolledBack if any of its Subscriptions was rolled back, Committed if
+// all of them were committed, and the empty state while it's in progress.
+func (t *Transaction) State() TransactionState {
+	committed := 0
+	for _, sub := range t.Subscriptions {
+		status, err := TransactionStatusFor(sub)
+		if err != nil || status == nil {
+			continue
+		}
+		switch status.State {
+		case TransactionRolledBack:
+			return TransactionRolledBack
+		case TransactionCommitted:
+			committed++
+		}
+	}
+	if committed > 0 && committed == len(t.Subscriptions) {
+		return TransactionCommitted
+	}
+	return ""
+}
+
+// withoutHeldTransactions returns the given Subscriptions but those of pending and rolled back transactions, which
+// aren't resolved.
+func withoutHeldTransactions(subs []*v1alpha1.Subscription) []*v1alpha1.Subscription {
+	held := map[string]bool{}
+	for id, t := range Transactions(subs) {
+		held[id] = t.Pending() || t.State() == TransactionRolledBack
+	}
+	var out []*v1alpha1.Subscription
+	for _, sub := range subs {
+		if !held[sub.GetLabels()[TransactionLabelKey]] {
+			out = append(out, sub)
+		}
+	}
+	return out
+}
@@ -0,0 +1,72 @@
+package resolver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+)
+
+func TestTransactions(t *testing.T) {
+	sub := func(name, transaction string, annotations map[string]string) *v1alpha1.Subscription {
+		s := &v1alpha1.Subscription{ObjectMeta: metav1.ObjectMeta{Name: name, Annotations: annotations}}
+		if transaction != "" {
+			s.SetLabels(map[string]string{TransactionLabelKey: transaction})
+		}
+		return s
+	}
+	size := func(n string) map[string]string {
+		return map[string]string{TransactionSizeAnnotationKey: n}
+	}
+	state := func(id string, state TransactionState) map[string]string {
+		return map[string]string{TransactionStateAnnotationKey: `{"id":"` + id + `","state":"` + string(state) + `"}`}
+	}
+
+	unlabeled := sub("unlabeled", "", nil)
+	pendingA := sub("pending-a", "pending", size("3"))
+	pendingB := sub("pending-b", "pending", nil)
+	complete := sub("complete", "complete", size("1"))
+	unsized := sub("unsized", "unsized", nil)
+	rolledBack := sub("rolled-back", "rolled-back", state("rolled-back", TransactionRolledBack))
+	retried := sub("retried", "retry", state("rolled-back", TransactionRolledBack))
+	resolved := sub("resolved", "resolved", size("2"))
+	resolved.Status.InstallPlanRef = &corev1.ObjectReference{Name: "install-1"}
+
+	subs := []*v1alpha1.Subscription{unlabeled, pendingA, pendingB, complete, unsized, rolledBack, retried, resolved}
+	transactions := Transactions(subs)
+	require.Len(t, transactions, 6)
+
+	pending := transactions["pending"]
+	require.Equal(t, 3, pending.Size)
+	require.Equal(t, 1, pending.Missing())
+	require.True(t, pending.Pending())
+	require.False(t, transactions["complete"].Pending())
+	require.False(t, transactions["unsized"].Pending())
+	require.False(t, transactions["resolved"].Pending(), "resolved transactions stop waiting")
+
+	require.Equal(t, TransactionRolledBack, transactions["rolled-back"].State())
+	require.Empty(t, transactions["retry"].State(), "states of previous transactions are ignored")
+
+	require.Equal(t, []*v1alpha1.Subscription{unlabeled, complete, unsized, retried, resolved}, withoutHeldTransactions(subs))
+}
+
+func TestTransactionState(t *testing.T) {
+	sub := func(state TransactionState) *v1alpha1.Subscription {
+		return &v1alpha1.Subscription{ObjectMeta: metav1.ObjectMeta{
+			Labels:      map[string]string{TransactionLabelKey: "suite"},
+			Annotations: map[string]string{TransactionStateAnnotationKey: `{"id":"suite","state":"` + string(state) + `"}`},
+		}}
+	}
+	require.Equal(t, TransactionCommitted, (&Transaction{Subscriptions: []*v1alpha1.Subscription{sub(TransactionCommitted), sub(TransactionCommitted)}}).State())
+	require.Empty(t, (&Transaction{Subscriptions: []*v1alpha1.Subscription{sub(TransactionCommitted), {}}}).State())
+	require.Equal(t, TransactionRolledBack, (&Transaction{Subscriptions: []*v1alpha1.Subscription{sub(TransactionCommitted), sub(TransactionRolledBack)}}).State())
+
+	_, err := TransactionStatusFor(&v1alpha1.Subscription{ObjectMeta: metav1.ObjectMeta{
+		Name:        "sub",
+		Annotations: map[string]string{TransactionStateAnnotationKey: "{"},
+	}})
+	require.Error(t, err)
+}
@@ -0,0 +1,41 @@
+package resolver
+
+import (
+	"fmt"
+
+	"github.com/blang/semver/v4"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/registry/resolver/cache"
+)
+
+// SubscriptionVersionAnnotationKey is the Subscription annotation pinning the operator it installs to an exact version
+// (e.g. "1.2.3") or a semver range (e.g. ">=1.2.0 <1.3.0"). Unlike spec.startingCSV, which only selects the bundle
+// initially installed, the pin is honored by every resolution: bundles outside of it are neither installed nor
+// upgraded to.
+const SubscriptionVersionAnnotationKey = "operatorframework.io/version"
+
+// VersionPin is the range of versions a Subscription is pinned to.
+type VersionPin struct {
+	Range semver.Range
+	// Constraint is the range as set on the Subscription.
+	Constraint string
+}
+
+// VersionPinFor returns the version pin set on the given Subscription, or nil if it isn't pinned.
+func VersionPinFor(sub *v1alpha1.Subscription) (*VersionPin, error) {
+	constraint, ok := sub.GetAnnotations()[SubscriptionVersionAnnotationKey]
+	if !ok {
+		return nil, nil
+	}
+	r, err := semver.ParseRange(constraint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s annotation %q on subscription %s: %v", SubscriptionVersionAnnotationKey, constraint, sub.GetName(), err)
+	}
+	return &VersionPin{Range: r, Constraint: constraint}, nil
+}
+
+// Predicate returns a predicate matching the entries within the pin.
+func (p *VersionPin) Predicate() cache.Predicate {
+	return cache.VersionInRangePredicate(p.Range, p.Constraint)
+}
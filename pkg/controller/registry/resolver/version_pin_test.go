@@ -0,0 +1,127 @@
+package resolver
+
+import (
+	"testing"
+
+	"github.com/blang/semver/v4"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/operator-framework/api/pkg/lib/version"
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/registry/resolver/cache"
+)
+
+func withVersionPin(constraint string) subOption {
+	return func(s *v1alpha1.Subscription) {
+		s.SetAnnotations(map[string]string{SubscriptionVersionAnnotationKey: constraint})
+	}
+}
+
+func TestVersionPinFor(t *testing.T) {
+	catalog := cache.SourceKey{Name: "test-catalog", Namespace: "test-namespace"}
+
+	pin, err := VersionPinFor(newSub(catalog.Namespace, "packageA", "alpha", catalog))
+	require.NoError(t, err)
+	require.Nil(t, pin)
+
+	pin, err = VersionPinFor(newSub(catalog.Namespace, "packageA", "alpha", catalog, withVersionPin("1.2.3")))
+	require.NoError(t, err)
+	require.Equal(t, "1.2.3", pin.Constraint)
+	require.True(t, pin.Range(semver.MustParse("1.2.3")))
+	require.False(t, pin.Range(semver.MustParse("1.2.4")))
+
+	pin, err = VersionPinFor(newSub(catalog.Namespace, "packageA", "alpha", catalog, withVersionPin(">=1.2.0 <1.3.0")))
+	require.NoError(t, err)
+	require.True(t, pin.Range(semver.MustParse("1.2.4")))
+	require.False(t, pin.Range(semver.MustParse("1.3.0")))
+
+	_, err = VersionPinFor(newSub(catalog.Namespace, "packageA", "alpha", catalog, withVersionPin("latest")))
+	require.Error(t, err)
+}
+
+func TestSolveOperators_WithVersionPin(t *testing.T) {
+	const namespace = "test-namespace"
+	catalog := cache.SourceKey{Name: "test-catalog", Namespace: namespace}
+
+	opA1 := genOperator("packageA.v1.0.0", "1.0.0", "", "packageA", "alpha", catalog.Name, catalog.Namespace, nil, nil, nil, "", false)
+	opA2 := genOperator("packageA.v1.1.0", "1.1.0", "packageA.v1.0.0", "packageA", "alpha", catalog.Name, catalog.Namespace, nil, nil, nil, "", false)
+	opA3 := genOperator("packageA.v2.0.0", "2.0.0", "packageA.v1.1.0", "packageA", "alpha", catalog.Name, catalog.Namespace, nil, nil, nil, "", false)
+
+	installed := func(name, v string) []*v1alpha1.ClusterServiceVersion {
+		csv := existingOperator(namespace, name, "packageA", "alpha", "", nil, nil, nil, nil)
+		csv.Spec.Version = version.OperatorVersion{Version: semver.MustParse(v)}
+		return []*v1alpha1.ClusterServiceVersion{csv}
+	}
+	installedSub := func(name, constraint string) *v1alpha1.Subscription {
+		sub := existingSub(namespace, name, "packageA", "alpha", catalog)
+		withVersionPin(constraint)(sub)
+		return sub
+	}
+
+	tests := []struct {
+		name     string
+		csvs     []*v1alpha1.ClusterServiceVersion
+		sub      *v1alpha1.Subscription
+		expected string
+		err      string
+	}{
+		{
+			name:     "InstallsExactVersion",
+			sub:      newSub(namespace, "packageA", "alpha", catalog, withVersionPin("1.0.0")),
+			expected: "packageA.v1.0.0",
+		},
+		{
+			name:     "InstallsLatestVersionInRange",
+			sub:      newSub(namespace, "packageA", "alpha", catalog, withVersionPin("<2.0.0")),
+			expected: "packageA.v1.1.0",
+		},
+		{
+			name:     "UpgradesWithinRange",
+			csvs:     installed("packageA.v1.0.0", "1.0.0"),
+			sub:      installedSub("packageA.v1.0.0", "<2.0.0"),
+			expected: "packageA.v1.1.0",
+		},
+		{
+			name: "DoesNotUpgradePastPin",
+			csvs: installed("packageA.v1.1.0", "1.1.0"),
+			sub:  installedSub("packageA.v1.1.0", "<2.0.0"),
+		},
+		{
+			name: "NoVersionInRange",
+			sub:  newSub(namespace, "packageA", "alpha", catalog, withVersionPin(">=3.0.0")),
+			err:  "no operators found with version >=3.0.0 in channel alpha of package packageA",
+		},
+		{
+			name: "InvalidPin",
+			sub:  newSub(namespace, "packageA", "alpha", catalog, withVersionPin("latest")),
+			err:  "invalid operatorframework.io/version annotation",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			satResolver := SatResolver{
+				cache: cache.New(cache.StaticSourceProvider{
+					catalog: &cache.Snapshot{
+						Entries: []*cache.Entry{opA1, opA2, opA3},
+					},
+				}),
+				log: logrus.New(),
+			}
+
+			operators, err := satResolver.SolveOperators([]string{namespace}, tt.csvs, []*v1alpha1.Subscription{tt.sub})
+			if tt.err != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tt.err)
+				return
+			}
+			require.NoError(t, err)
+			if tt.expected == "" {
+				require.Empty(t, operators)
+				return
+			}
+			require.Len(t, operators, 1)
+			require.Contains(t, operators, tt.expected)
+		})
+	}
+}
@@ -87,3 +87,29 @@ type PackageChannel struct {
 func (pc PackageChannel) IsDefaultChannel(pm PackageManifest) bool {
 	return pc.Name == pm.DefaultChannelName || len(pm.Channels) == 1
 }
+
+// DeprecationPropertyType is the type of the bundle properties carrying the olm.deprecations of file-based catalogs
+// that apply to a bundle, as JSON-encoded Deprecations.
+const DeprecationPropertyType = "olm.deprecations"
+
+// Deprecation is a deprecation of a package, of a channel or of a bundle.
+type Deprecation struct {
+	// Schema is the schema of the deprecated object: olm.package, olm.channel or olm.bundle.
+	Schema string `json:"schema"`
+	// Name is the name of the deprecated channel or bundle.
+	Name string `json:"name,omitempty"`
+	// Message explains the deprecation, e.g. which channel to move to.
+	Message string `json:"message"`
+}
+
+// String returns the deprecation message, prefixed with the deprecated object.
+func (d Deprecation) String() string {
+	switch d.Schema {
+	case "olm.package":
+		return fmt.Sprintf("package is deprecated: %s", d.Message)
+	case "olm.channel":
+		return fmt.Sprintf("channel %s is deprecated: %s", d.Name, d.Message)
+	default:
+		return fmt.Sprintf("bundle %s is deprecated: %s", d.Name, d.Message)
+	}
+}
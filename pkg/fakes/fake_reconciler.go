@@ -2,6 +2,7 @@
 package fakes
 
 import (
+	"context"
 	"sync"
 
 	"github.com/operator-framework/api/pkg/operators/v1alpha1"
@@ -9,10 +10,11 @@ import (
 )
 
 type FakeRegistryReconciler struct {
-	CheckRegistryServerStub        func(*v1alpha1.CatalogSource) (bool, error)
+	CheckRegistryServerStub        func(context.Context, *v1alpha1.CatalogSource) (bool, error)
 	checkRegistryServerMutex       sync.RWMutex
 	checkRegistryServerArgsForCall []struct {
-		arg1 *v1alpha1.CatalogSource
+		arg1 context.Context
+		arg2 *v1alpha1.CatalogSource
 	}
 	checkRegistryServerReturns struct {
 		result1 bool
@@ -22,10 +24,11 @@ type FakeRegistryReconciler struct {
 		result1 bool
 		result2 error
 	}
-	EnsureRegistryServerStub        func(*v1alpha1.CatalogSource) error
+	EnsureRegistryServerStub        func(context.Context, *v1alpha1.CatalogSource) error
 	ensureRegistryServerMutex       sync.RWMutex
 	ensureRegistryServerArgsForCall []struct {
-		arg1 *v1alpha1.CatalogSource
+		arg1 context.Context
+		arg2 *v1alpha1.CatalogSource
 	}
 	ensureRegistryServerReturns struct {
 		result1 error
@@ -37,16 +40,17 @@ type FakeRegistryReconciler struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeRegistryReconciler) CheckRegistryServer(arg1 *v1alpha1.CatalogSource) (bool, error) {
+func (fake *FakeRegistryReconciler) CheckRegistryServer(arg1 context.Context, arg2 *v1alpha1.CatalogSource) (bool, error) {
 	fake.checkRegistryServerMutex.Lock()
 	ret, specificReturn := fake.checkRegistryServerReturnsOnCall[len(fake.checkRegistryServerArgsForCall)]
 	fake.checkRegistryServerArgsForCall = append(fake.checkRegistryServerArgsForCall, struct {
-		arg1 *v1alpha1.CatalogSource
-	}{arg1})
-	fake.recordInvocation("CheckRegistryServer", []interface{}{arg1})
+		arg1 context.Context
+		arg2 *v1alpha1.CatalogSource
+	}{arg1, arg2})
+	fake.recordInvocation("CheckRegistryServer", []interface{}{arg1, arg2})
 	fake.checkRegistryServerMutex.Unlock()
 	if fake.CheckRegistryServerStub != nil {
-		return fake.CheckRegistryServerStub(arg1)
+		return fake.CheckRegistryServerStub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -61,17 +65,17 @@ func (fake *FakeRegistryReconciler) CheckRegistryServerCallCount() int {
 	return len(fake.checkRegistryServerArgsForCall)
 }
 
-func (fake *FakeRegistryReconciler) CheckRegistryServerCalls(stub func(*v1alpha1.CatalogSource) (bool, error)) {
+func (fake *FakeRegistryReconciler) CheckRegistryServerCalls(stub func(context.Context, *v1alpha1.CatalogSource) (bool, error)) {
 	fake.checkRegistryServerMutex.Lock()
 	defer fake.checkRegistryServerMutex.Unlock()
 	fake.CheckRegistryServerStub = stub
 }
 
-func (fake *FakeRegistryReconciler) CheckRegistryServerArgsForCall(i int) *v1alpha1.CatalogSource {
+func (fake *FakeRegistryReconciler) CheckRegistryServerArgsForCall(i int) (context.Context, *v1alpha1.CatalogSource) {
 	fake.checkRegistryServerMutex.RLock()
 	defer fake.checkRegistryServerMutex.RUnlock()
 	argsForCall := fake.checkRegistryServerArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeRegistryReconciler) CheckRegistryServerReturns(result1 bool, result2 error) {
@@ -100,16 +104,17 @@ func (fake *FakeRegistryReconciler) CheckRegistryServerReturnsOnCall(i int, resu
 	}{result1, result2}
 }
 
-func (fake *FakeRegistryReconciler) EnsureRegistryServer(arg1 *v1alpha1.CatalogSource) error {
+func (fake *FakeRegistryReconciler) EnsureRegistryServer(arg1 context.Context, arg2 *v1alpha1.CatalogSource) error {
 	fake.ensureRegistryServerMutex.Lock()
 	ret, specificReturn := fake.ensureRegistryServerReturnsOnCall[len(fake.ensureRegistryServerArgsForCall)]
 	fake.ensureRegistryServerArgsForCall = append(fake.ensureRegistryServerArgsForCall, struct {
-		arg1 *v1alpha1.CatalogSource
-	}{arg1})
-	fake.recordInvocation("EnsureRegistryServer", []interface{}{arg1})
+		arg1 context.Context
+		arg2 *v1alpha1.CatalogSource
+	}{arg1, arg2})
+	fake.recordInvocation("EnsureRegistryServer", []interface{}{arg1, arg2})
 	fake.ensureRegistryServerMutex.Unlock()
 	if fake.EnsureRegistryServerStub != nil {
-		return fake.EnsureRegistryServerStub(arg1)
+		return fake.EnsureRegistryServerStub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1
@@ -124,17 +129,17 @@ func (fake *FakeRegistryReconciler) EnsureRegistryServerCallCount() int {
 	return len(fake.ensureRegistryServerArgsForCall)
 }
 
-func (fake *FakeRegistryReconciler) EnsureRegistryServerCalls(stub func(*v1alpha1.CatalogSource) error) {
+func (fake *FakeRegistryReconciler) EnsureRegistryServerCalls(stub func(context.Context, *v1alpha1.CatalogSource) error) {
 	fake.ensureRegistryServerMutex.Lock()
 	defer fake.ensureRegistryServerMutex.Unlock()
 	fake.EnsureRegistryServerStub = stub
 }
 
-func (fake *FakeRegistryReconciler) EnsureRegistryServerArgsForCall(i int) *v1alpha1.CatalogSource {
+func (fake *FakeRegistryReconciler) EnsureRegistryServerArgsForCall(i int) (context.Context, *v1alpha1.CatalogSource) {
 	fake.ensureRegistryServerMutex.RLock()
 	defer fake.ensureRegistryServerMutex.RUnlock()
 	argsForCall := fake.ensureRegistryServerArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeRegistryReconciler) EnsureRegistryServerReturns(result1 error) {
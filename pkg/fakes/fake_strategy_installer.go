@@ -2,16 +2,18 @@
 package fakes
 
 import (
+	"context"
 	"sync"
 
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/install"
 )
 
 type FakeStrategyInstaller struct {
-	CheckInstalledStub        func(install.Strategy) (bool, error)
+	CheckInstalledStub        func(context.Context, install.Strategy) (bool, error)
 	checkInstalledMutex       sync.RWMutex
 	checkInstalledArgsForCall []struct {
-		arg1 install.Strategy
+		arg1 context.Context
+		arg2 install.Strategy
 	}
 	checkInstalledReturns struct {
 		result1 bool
@@ -21,10 +23,11 @@ type FakeStrategyInstaller struct {
 		result1 bool
 		result2 error
 	}
-	InstallStub        func(install.Strategy) error
+	InstallStub        func(context.Context, install.Strategy) error
 	installMutex       sync.RWMutex
 	installArgsForCall []struct {
-		arg1 install.Strategy
+		arg1 context.Context
+		arg2 install.Strategy
 	}
 	installReturns struct {
 		result1 error
@@ -36,16 +39,17 @@ type FakeStrategyInstaller struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeStrategyInstaller) CheckInstalled(arg1 install.Strategy) (bool, error) {
+func (fake *FakeStrategyInstaller) CheckInstalled(arg1 context.Context, arg2 install.Strategy) (bool, error) {
 	fake.checkInstalledMutex.Lock()
 	ret, specificReturn := fake.checkInstalledReturnsOnCall[len(fake.checkInstalledArgsForCall)]
 	fake.checkInstalledArgsForCall = append(fake.checkInstalledArgsForCall, struct {
-		arg1 install.Strategy
-	}{arg1})
-	fake.recordInvocation("CheckInstalled", []interface{}{arg1})
+		arg1 context.Context
+		arg2 install.Strategy
+	}{arg1, arg2})
+	fake.recordInvocation("CheckInstalled", []interface{}{arg1, arg2})
 	fake.checkInstalledMutex.Unlock()
 	if fake.CheckInstalledStub != nil {
-		return fake.CheckInstalledStub(arg1)
+		return fake.CheckInstalledStub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -60,17 +64,17 @@ func (fake *FakeStrategyInstaller) CheckInstalledCallCount() int {
 	return len(fake.checkInstalledArgsForCall)
 }
 
-func (fake *FakeStrategyInstaller) CheckInstalledCalls(stub func(install.Strategy) (bool, error)) {
+func (fake *FakeStrategyInstaller) CheckInstalledCalls(stub func(context.Context, install.Strategy) (bool, error)) {
 	fake.checkInstalledMutex.Lock()
 	defer fake.checkInstalledMutex.Unlock()
 	fake.CheckInstalledStub = stub
 }
 
-func (fake *FakeStrategyInstaller) CheckInstalledArgsForCall(i int) install.Strategy {
+func (fake *FakeStrategyInstaller) CheckInstalledArgsForCall(i int) (context.Context, install.Strategy) {
 	fake.checkInstalledMutex.RLock()
 	defer fake.checkInstalledMutex.RUnlock()
 	argsForCall := fake.checkInstalledArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeStrategyInstaller) CheckInstalledReturns(result1 bool, result2 error) {
@@ -99,16 +103,17 @@ func (fake *FakeStrategyInstaller) CheckInstalledReturnsOnCall(i int, result1 bo
 	}{result1, result2}
 }
 
-func (fake *FakeStrategyInstaller) Install(arg1 install.Strategy) error {
+func (fake *FakeStrategyInstaller) Install(arg1 context.Context, arg2 install.Strategy) error {
 	fake.installMutex.Lock()
 	ret, specificReturn := fake.installReturnsOnCall[len(fake.installArgsForCall)]
 	fake.installArgsForCall = append(fake.installArgsForCall, struct {
-		arg1 install.Strategy
-	}{arg1})
-	fake.recordInvocation("Install", []interface{}{arg1})
+		arg1 context.Context
+		arg2 install.Strategy
+	}{arg1, arg2})
+	fake.recordInvocation("Install", []interface{}{arg1, arg2})
 	fake.installMutex.Unlock()
 	if fake.InstallStub != nil {
-		return fake.InstallStub(arg1)
+		return fake.InstallStub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1
@@ -123,17 +128,17 @@ func (fake *FakeStrategyInstaller) InstallCallCount() int {
 	return len(fake.installArgsForCall)
 }
 
-func (fake *FakeStrategyInstaller) InstallCalls(stub func(install.Strategy) error) {
+func (fake *FakeStrategyInstaller) InstallCalls(stub func(context.Context, install.Strategy) error) {
 	fake.installMutex.Lock()
 	defer fake.installMutex.Unlock()
 	fake.InstallStub = stub
 }
 
-func (fake *FakeStrategyInstaller) InstallArgsForCall(i int) install.Strategy {
+func (fake *FakeStrategyInstaller) InstallArgsForCall(i int) (context.Context, install.Strategy) {
 	fake.installMutex.RLock()
 	defer fake.installMutex.RUnlock()
 	argsForCall := fake.installArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeStrategyInstaller) InstallReturns(result1 error) {
@@ -16,6 +16,19 @@ const (
 	// owner: @njhale
 	// alpha: v0.15.0
 	OperatorLifecycleManagerV1 featuregate.Feature = "OperatorLifecycleManagerV1"
+
+	// PodWorkloadBackend allows CSVs to run their install strategy's deployments as bare Pods.
+	// alpha: v0.20.0
+	PodWorkloadBackend featuregate.Feature = "PodWorkloadBackend"
+
+	// JobWorkloadBackend allows CSVs to run their install strategy's deployments as Jobs.
+	// alpha: v0.20.0
+	JobWorkloadBackend featuregate.Feature = "JobWorkloadBackend"
+
+	// NamespacePodSecurityLabeler labels the namespaces of CSVs declaring the Pod Security level they need with that
+	// level.
+	// alpha: v0.20.0
+	NamespacePodSecurityLabeler featuregate.Feature = "NamespacePodSecurityLabeler"
 )
 
 var (
@@ -35,5 +48,8 @@ func AddFlag(fs *pflag.FlagSet) {
 }
 
 var featureGates = map[featuregate.Feature]featuregate.FeatureSpec{
-	OperatorLifecycleManagerV1: {Default: true, LockToDefault: true, PreRelease: featuregate.GA},
+	OperatorLifecycleManagerV1:  {Default: true, LockToDefault: true, PreRelease: featuregate.GA},
+	PodWorkloadBackend:          {Default: false, PreRelease: featuregate.Alpha},
+	JobWorkloadBackend:          {Default: false, PreRelease: featuregate.Alpha},
+	NamespacePodSecurityLabeler: {Default: false, PreRelease: featuregate.Alpha},
 }
@@ -0,0 +1,190 @@
+// Package audit records the resources OLM creates, updates and deletes on behalf of CSVs and InstallPlans, as Events
+// on their owner and as structured log entries, for compliance teams tracking cluster mutations.
+package audit
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+)
+
+// Action is a mutation of a resource.
+type Action string
+
+const (
+	Created Action = "Created"
+	Updated Action = "Updated"
+	Deleted Action = "Deleted"
+)
+
+const (
+	// maxDepth is the depth of the field paths listed by the summary of the changes to a resource, e.g.
+	// spec.template.spec for the changes to the containers of a Deployment.
+	maxDepth = 3
+
+	// maxChanges is the number of field paths listed by the Events recording updates. Logs list them all.
+	maxChanges = 10
+)
+
+// Mutation is a resource created, updated or deleted by OLM.
+type Mutation struct {
+	Action    Action
+	Kind      string
+	Namespace string
+	Name      string
+
+	// CSV is the name of the CSV the resource is created for, if any.
+	CSV string
+
+	// Changes are the paths of the fields an update changed.
+	Changes []string
+}
+
+func (m Mutation) resource() string {
+	if m.Namespace == "" {
+		return fmt.Sprintf("%s %s", m.Kind, m.Name)
+	}
+	return fmt.Sprintf("%s %s/%s", m.Kind, m.Namespace, m.Name)
+}
+
+func (m Mutation) message() string {
+	msg := fmt.Sprintf("%s %s", strings.ToLower(string(m.Action)), m.resource())
+	if m.CSV != "" {
+		msg += fmt.Sprintf(" for csv %s", m.CSV)
+	}
+	if len(m.Changes) > 0 {
+		changes := m.Changes
+		if len(changes) > maxChanges {
+			changes = append(changes[:maxChanges:maxChanges], fmt.Sprintf("%d more", len(m.Changes)-maxChanges))
+		}
+		msg += fmt.Sprintf(": changed %s", strings.Join(changes, ", "))
+	}
+	return msg
+}
+
+// Recorder records Mutations. A nil Recorder records nothing.
+type Recorder struct {
+	events record.EventRecorder
+	logger logrus.FieldLogger
+}
+
+// NewRecorder returns a Recorder emitting Events with the given recorder and logging with the given logger.
+func NewRecorder(events record.EventRecorder, logger logrus.FieldLogger) *Recorder {
+	return &Recorder{
+		events: events,
+		logger: logger,
+	}
+}
+
+// Record records a Mutation made on behalf of the given owner, i.e. a CSV or an InstallPlan.
+func (r *Recorder) Record(owner runtime.Object, m Mutation) {
+	if r == nil {
+		return
+	}
+
+	fields := logrus.Fields{
+		"audit":     true,
+		"action":    m.Action,
+		"kind":      m.Kind,
+		"namespace": m.Namespace,
+		"name":      m.Name,
+		"ownerKind": kindOf(owner),
+	}
+	if o, err := meta.Accessor(owner); err == nil {
+		fields["ownerNamespace"] = o.GetNamespace()
+		fields["ownerName"] = o.GetName()
+	}
+	if m.CSV != "" {
+		fields["csv"] = m.CSV
+	}
+	if len(m.Changes) > 0 {
+		fields["changes"] = m.Changes
+	}
+	r.logger.WithFields(fields).Info("resource mutated")
+
+	r.events.Event(owner, corev1.EventTypeNormal, "Resource"+string(m.Action), m.message())
+}
+
+func kindOf(obj runtime.Object) string {
+	if kind := obj.GetObjectKind().GroupVersionKind().Kind; kind != "" {
+		return kind
+	}
+	t := reflect.TypeOf(obj)
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Name()
+}
+
+// ignoredPaths are the fields whose changes aren't mutations made by OLM.
+var ignoredPaths = map[string]struct{}{
+	"status":                     {},
+	"metadata.resourceVersion":   {},
+	"metadata.generation":        {},
+	"metadata.managedFields":     {},
+	"metadata.creationTimestamp": {},
+	"metadata.uid":               {},
+	"metadata.selfLink":          {},
+}
+
+// Changes returns the sorted paths of the fields that differ between the given versions of a resource, down to a depth
+// of three, e.g. spec.template.spec. The values of the fields aren't part of the summary, so that the changes to
+// Secrets don't disclose their data.
+func Changes(old, new interface{}) ([]string, error) {
+	o, err := toUnstructured(old)
+	if err != nil {
+		return nil, err
+	}
+	n, err := toUnstructured(new)
+	if err != nil {
+		return nil, err
+	}
+	var changes []string
+	diff("", o, n, 1, &changes)
+	sort.Strings(changes)
+	return changes, nil
+}
+
+func toUnstructured(obj interface{}) (map[string]interface{}, error) {
+	if u, ok := obj.(runtime.Unstructured); ok {
+		return u.UnstructuredContent(), nil
+	}
+	return runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+}
+
+func diff(path string, old, new map[string]interface{}, depth int, changes *[]string) {
+	keys := map[string]struct{}{}
+	for k := range old {
+		keys[k] = struct{}{}
+	}
+	for k := range new {
+		keys[k] = struct{}{}
+	}
+	for k := range keys {
+		p := k
+		if path != "" {
+			p = path + "." + k
+		}
+		if _, ok := ignoredPaths[p]; ok {
+			continue
+		}
+		o, n := old[k], new[k]
+		if reflect.DeepEqual(o, n) {
+			continue
+		}
+		om, oIsMap := o.(map[string]interface{})
+		nm, nIsMap := n.(map[string]interface{})
+		if depth < maxDepth && oIsMap && nIsMap {
+			diff(p, om, nm, depth+1, changes)
+			continue
+		}
+		*changes = append(*changes, p)
+	}
+}
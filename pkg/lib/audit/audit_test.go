@@ -0,0 +1,91 @@
+package audit
+
+import (
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+)
+
+func TestChanges(t *testing.T) {
+	replicas := func(n int32) *int32 { return &n }
+	old := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "operator", ResourceVersion: "1", Labels: map[string]string{"app": "operator"}},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: replicas(1),
+			Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: "operator", Image: "operator:v1"}},
+			}},
+		},
+		Status: appsv1.DeploymentStatus{Replicas: 1},
+	}
+	new := old.DeepCopy()
+	new.SetResourceVersion("2")
+	new.Labels["olm.owner"] = "operator.v2"
+	new.Spec.Replicas = replicas(2)
+	new.Spec.Template.Spec.Containers[0].Image = "operator:v2"
+	new.Status.Replicas = 2
+
+	changes, err := Changes(old, new)
+	require.NoError(t, err)
+	require.Equal(t, []string{"metadata.labels.olm.owner", "spec.replicas", "spec.template.spec"}, changes)
+
+	changes, err = Changes(old, old.DeepCopy())
+	require.NoError(t, err)
+	require.Empty(t, changes)
+
+	secret := func(data string) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]interface{}{
+			"metadata": map[string]interface{}{"name": "operator-service-cert"},
+			"data":     map[string]interface{}{"tls.crt": data},
+		}}
+	}
+	changes, err = Changes(secret("old"), secret("new"))
+	require.NoError(t, err)
+	require.Equal(t, []string{"data.tls.crt"}, changes, "values aren't disclosed")
+}
+
+func TestRecord(t *testing.T) {
+	csv := &v1alpha1.ClusterServiceVersion{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "operator.v2"}}
+	m := Mutation{Action: Updated, Kind: "Deployment", Namespace: "ns", Name: "operator", CSV: "operator.v2", Changes: []string{"spec.replicas"}}
+
+	var nilRecorder *Recorder
+	nilRecorder.Record(csv, m)
+
+	events := record.NewFakeRecorder(1)
+	logger, hook := test.NewNullLogger()
+	NewRecorder(events, logger).Record(csv, m)
+
+	require.Equal(t, "Normal ResourceUpdated updated Deployment ns/operator for csv operator.v2: changed spec.replicas", <-events.Events)
+	entry := hook.LastEntry()
+	require.Equal(t, logrus.InfoLevel, entry.Level)
+	require.Equal(t, logrus.Fields{
+		"audit":          true,
+		"action":         Updated,
+		"kind":           "Deployment",
+		"namespace":      "ns",
+		"name":           "operator",
+		"ownerKind":      "ClusterServiceVersion",
+		"ownerNamespace": "ns",
+		"ownerName":      "operator.v2",
+		"csv":            "operator.v2",
+		"changes":        []string{"spec.replicas"},
+	}, entry.Data)
+}
+
+func TestMessageTruncatesChanges(t *testing.T) {
+	m := Mutation{Action: Updated, Kind: "ClusterRoleBinding", Name: "binding"}
+	for i := 0; i < maxChanges+2; i++ {
+		m.Changes = append(m.Changes, "subjects")
+	}
+	require.Equal(t, "updated ClusterRoleBinding binding: changed subjects, subjects, subjects, subjects, subjects, subjects, subjects, subjects, subjects, subjects, 2 more", m.message())
+	require.Len(t, m.Changes, maxChanges+2)
+}
@@ -10,3 +10,23 @@ func SetWarningHandler(wh rest.WarningHandler) ConfigTransformer {
 		return config
 	})
 }
+
+// SetRateLimits sets the client-side rate limits of the config. A
+// negative qps disables client-side rate limiting, leaving requests
+// to be bounded by the apiserver's priority and fairness settings.
+func SetRateLimits(qps float32, burst int) ConfigTransformer {
+	return ConfigTransformerFunc(func(config *rest.Config) *rest.Config {
+		config.QPS = qps
+		config.Burst = burst
+		return config
+	})
+}
+
+// SetUserAgent appends the given component name to the default user
+// agent of the config, so that the requests of each OLM component can
+// be told apart in audit logs.
+func SetUserAgent(component string) ConfigTransformer {
+	return ConfigTransformerFunc(func(config *rest.Config) *rest.Config {
+		return rest.AddUserAgent(config, component)
+	})
+}
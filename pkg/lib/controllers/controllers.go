@@ -0,0 +1,102 @@
+// Package controllers names the optional controllers of OLM, which can be disabled for slimmer deployments on small
+// clusters, and reports the disabled ones on the cluster OLMConfig.
+package controllers
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/api/reasons"
+)
+
+// Name is the name of an optional controller, as given to the --disable-controllers flag of the operator running it.
+type Name string
+
+const (
+	// CopiedCSVs copies the CSVs of operators into the target namespaces of their OperatorGroup. It is run by the
+	// olm-operator.
+	CopiedCSVs Name = "copied-csvs"
+
+	// OperatorAggregator aggregates the components of installed operators into Operator resources. It is run by the
+	// olm-operator.
+	OperatorAggregator Name = "operators"
+
+	// OperatorConditions generates the OperatorConditions of CSVs and propagates them to their deployments. It is run
+	// by the olm-operator.
+	OperatorConditions Name = "operatorconditions"
+
+	// ConfigMapCatalogs serves CatalogSources of the legacy configmap and internal source types. It is run by the
+	// catalog-operator.
+	ConfigMapCatalogs Name = "configmap-catalogs"
+)
+
+// OLM lists the optional controllers of the olm-operator.
+var OLM = []Name{CopiedCSVs, OperatorAggregator, OperatorConditions}
+
+// Catalog lists the optional controllers of the catalog-operator.
+var Catalog = []Name{ConfigMapCatalogs}
+
+// Disabled is a set of disabled controllers.
+type Disabled map[Name]struct{}
+
+// Parse returns the set of the given disabled controllers, which must be among the given known ones.
+func Parse(names []string, known []Name) (Disabled, error) {
+	disabled := Disabled{}
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		found := false
+		knownNames := make([]string, 0, len(known))
+		for _, k := range known {
+			found = found || Name(name) == k
+			knownNames = append(knownNames, string(k))
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown controller %q, must be one of %s", name, strings.Join(knownNames, ", "))
+		}
+		disabled[Name(name)] = struct{}{}
+	}
+	return disabled, nil
+}
+
+// Enabled returns whether the given controller isn't disabled.
+func (d Disabled) Enabled(name Name) bool {
+	_, ok := d[name]
+	return !ok
+}
+
+// String returns the names of the disabled controllers, sorted and separated by commas.
+func (d Disabled) String() string {
+	names := make([]string, 0, len(d))
+	for name := range d {
+		names = append(names, string(name))
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+// Condition returns the condition of the given type reporting the given disabled controllers on the cluster OLMConfig.
+// It is true when at least one controller is disabled.
+func Condition(conditionType string, disabled Disabled) metav1.Condition {
+	if len(disabled) == 0 {
+		return metav1.Condition{
+			Type:               conditionType,
+			Status:             metav1.ConditionFalse,
+			Reason:             string(reasons.OLMConfigAllControllersEnabled),
+			Message:            "All controllers are enabled",
+			LastTransitionTime: metav1.Now(),
+		}
+	}
+	return metav1.Condition{
+		Type:               conditionType,
+		Status:             metav1.ConditionTrue,
+		Reason:             string(reasons.OLMConfigControllersDisabled),
+		Message:            fmt.Sprintf("Disabled controllers: %s", disabled),
+		LastTransitionTime: metav1.Now(),
+	}
+}
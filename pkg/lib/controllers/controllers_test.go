@@ -0,0 +1,37 @@
+package controllers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/api/reasons"
+)
+
+func TestParse(t *testing.T) {
+	disabled, err := Parse([]string{"operators", " copied-csvs", ""}, OLM)
+	require.NoError(t, err)
+	require.False(t, disabled.Enabled(CopiedCSVs))
+	require.False(t, disabled.Enabled(OperatorAggregator))
+	require.True(t, disabled.Enabled(OperatorConditions))
+	require.Equal(t, "copied-csvs, operators", disabled.String())
+
+	_, err = Parse([]string{"configmap-catalogs"}, OLM)
+	require.EqualError(t, err, `unknown controller "configmap-catalogs", must be one of copied-csvs, operators, operatorconditions`)
+
+	disabled, err = Parse(nil, Catalog)
+	require.NoError(t, err)
+	require.Empty(t, disabled)
+}
+
+func TestCondition(t *testing.T) {
+	c := Condition("ControllersDisabled", Disabled{})
+	require.Equal(t, metav1.ConditionFalse, c.Status)
+	require.Equal(t, string(reasons.OLMConfigAllControllersEnabled), c.Reason)
+
+	c = Condition("ControllersDisabled", Disabled{ConfigMapCatalogs: {}})
+	require.Equal(t, metav1.ConditionTrue, c.Status)
+	require.Equal(t, string(reasons.OLMConfigControllersDisabled), c.Reason)
+	require.Equal(t, "Disabled controllers: configmap-catalogs", c.Message)
+}
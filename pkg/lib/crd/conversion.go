@@ -0,0 +1,31 @@
+package crd
+
+import (
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+// ConversionWebhookAnnotationKey is the CRD annotation holding the generateName of the CSV conversion webhook OLM
+// configured on the CRD.
+const ConversionWebhookAnnotationKey = "olm.operatorframework.io/conversion-webhook"
+
+// KeepManagedConversion carries the conversion webhook OLM configured on the existing CRD over to the updated CRD,
+// unless the updated CRD declares a conversion webhook of its own. Bundle CRDs of operators with conversion webhooks
+// usually don't declare any conversion, and updating the CRD with them would otherwise leave it without its webhook
+// until the CSV owning it is installed again.
+func KeepManagedConversion(existing, updated *apiextensionsv1.CustomResourceDefinition) {
+	webhook, ok := existing.GetAnnotations()[ConversionWebhookAnnotationKey]
+	if !ok || existing.Spec.Conversion == nil || existing.Spec.Conversion.Strategy != apiextensionsv1.WebhookConverter {
+		return
+	}
+	if updated.Spec.Conversion != nil && updated.Spec.Conversion.Strategy == apiextensionsv1.WebhookConverter {
+		return
+	}
+
+	updated.Spec.Conversion = existing.Spec.Conversion.DeepCopy()
+	annotations := updated.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[ConversionWebhookAnnotationKey] = webhook
+	updated.SetAnnotations(annotations)
+}
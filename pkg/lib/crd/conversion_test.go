@@ -0,0 +1,44 @@
+package crd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestKeepManagedConversion(t *testing.T) {
+	webhookConversion := &apiextensionsv1.CustomResourceConversion{
+		Strategy: apiextensionsv1.WebhookConverter,
+		Webhook: &apiextensionsv1.WebhookConversion{
+			ClientConfig:             &apiextensionsv1.WebhookClientConfig{CABundle: []byte("ca")},
+			ConversionReviewVersions: []string{"v1"},
+		},
+	}
+	crd := func(annotations map[string]string, conversion *apiextensionsv1.CustomResourceConversion) *apiextensionsv1.CustomResourceDefinition {
+		return &apiextensionsv1.CustomResourceDefinition{
+			ObjectMeta: metav1.ObjectMeta{Name: crdName, Annotations: annotations},
+			Spec:       apiextensionsv1.CustomResourceDefinitionSpec{Conversion: conversion},
+		}
+	}
+	managed := map[string]string{ConversionWebhookAnnotationKey: "cwebhook.example.com"}
+	none := &apiextensionsv1.CustomResourceConversion{Strategy: apiextensionsv1.NoneConverter}
+
+	// The webhook configured by OLM is kept
+	updated := crd(nil, none)
+	KeepManagedConversion(crd(managed, webhookConversion), updated)
+	require.Equal(t, crd(managed, webhookConversion), updated)
+
+	// Webhooks not configured by OLM aren't
+	updated = crd(nil, none)
+	KeepManagedConversion(crd(nil, webhookConversion), updated)
+	require.Equal(t, crd(nil, none), updated)
+
+	// Neither are they when the updated CRD declares its own webhook
+	own := webhookConversion.DeepCopy()
+	own.Webhook.ClientConfig.CABundle = []byte("other")
+	updated = crd(nil, own)
+	KeepManagedConversion(crd(managed, webhookConversion), updated)
+	require.Equal(t, crd(nil, own), updated)
+}
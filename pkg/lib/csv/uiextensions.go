@@ -0,0 +1,149 @@
+package csv
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/operator-framework/operator-registry/pkg/api"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// ConsolePluginsAnnotationKey is the CSV annotation listing, as a JSON array, the names of the console plugins
+	// an operator provides.
+	ConsolePluginsAnnotationKey = "console.openshift.io/plugins"
+
+	// UIExtensionsAnnotationKey is the annotation listing, as a JSON array of UIExtensions, the UI extensions declared
+	// by a CSV, or provided by the CSVs of an Operator.
+	UIExtensionsAnnotationKey = "operatorframework.io/ui-extensions"
+
+	// UIExtensionPropertyType is the type of the bundle properties declaring a UIExtension.
+	UIExtensionPropertyType = "olm.ui-extension"
+
+	// ConsolePluginUIExtensionType is the type of the UIExtensions of console plugins.
+	ConsolePluginUIExtensionType = "console-plugin"
+)
+
+// UIExtension is a frontend provided by an operator, e.g. a console plugin, which UIs discover generically.
+type UIExtension struct {
+	// Type is the kind of extension, e.g. console-plugin, which tells UIs how to load it.
+	Type string `json:"type"`
+	// Name identifies the extension among those of its type.
+	Name string `json:"name"`
+	// Config is the opaque configuration of the extension, if any.
+	Config json.RawMessage `json:"config,omitempty"`
+
+	// Namespace and ClusterServiceVersion identify the CSV providing the extension in the aggregate of an Operator.
+	Namespace             string `json:"namespace,omitempty"`
+	ClusterServiceVersion string `json:"clusterServiceVersion,omitempty"`
+}
+
+// UIExtensionsFor returns the UI extensions declared by the given CSV annotations and bundle properties, sorted by
+// type and name. Extensions declared more than once are only returned once.
+func UIExtensionsFor(annotations map[string]string, properties []*api.Property) ([]UIExtension, error) {
+	var extensions []UIExtension
+	if value, ok := annotations[ConsolePluginsAnnotationKey]; ok {
+		var plugins []string
+		if err := json.Unmarshal([]byte(value), &plugins); err != nil {
+			return nil, fmt.Errorf("invalid %s annotation: %v", ConsolePluginsAnnotationKey, err)
+		}
+		for _, plugin := range plugins {
+			extensions = append(extensions, UIExtension{Type: ConsolePluginUIExtensionType, Name: plugin})
+		}
+	}
+	if value, ok := annotations[UIExtensionsAnnotationKey]; ok {
+		var declared []UIExtension
+		if err := json.Unmarshal([]byte(value), &declared); err != nil {
+			return nil, fmt.Errorf("invalid %s annotation: %v", UIExtensionsAnnotationKey, err)
+		}
+		extensions = append(extensions, declared...)
+	}
+	for _, property := range properties {
+		if property.Type != UIExtensionPropertyType {
+			continue
+		}
+		var extension UIExtension
+		if err := json.Unmarshal([]byte(property.Value), &extension); err != nil {
+			return nil, fmt.Errorf("invalid %s property: %v", UIExtensionPropertyType, err)
+		}
+		extensions = append(extensions, extension)
+	}
+
+	for _, extension := range extensions {
+		if extension.Type == "" || extension.Name == "" {
+			return nil, fmt.Errorf("ui extensions must have a type and a name")
+		}
+	}
+	return dedupUIExtensions(extensions), nil
+}
+
+// UIExtensions returns the UI extensions listed by the UIExtensionsAnnotationKey annotation of the given object, i.e.
+// those declared by a CSV installed by OLM, or provided by the CSVs of an Operator.
+func UIExtensions(obj metav1.Object) ([]UIExtension, error) {
+	value, ok := obj.GetAnnotations()[UIExtensionsAnnotationKey]
+	if !ok {
+		return nil, nil
+	}
+	var extensions []UIExtension
+	if err := json.Unmarshal([]byte(value), &extensions); err != nil {
+		return nil, fmt.Errorf("invalid %s annotation: %v", UIExtensionsAnnotationKey, err)
+	}
+	return extensions, nil
+}
+
+// SetUIExtensions lists the given UI extensions in the UIExtensionsAnnotationKey annotation of the given object, or
+// removes it if there are none, and returns true if the annotation changed.
+func SetUIExtensions(obj metav1.Object, extensions []UIExtension) (bool, error) {
+	annotations := obj.GetAnnotations()
+	current, ok := annotations[UIExtensionsAnnotationKey]
+	if len(extensions) == 0 {
+		if !ok {
+			return false, nil
+		}
+		delete(annotations, UIExtensionsAnnotationKey)
+		obj.SetAnnotations(annotations)
+		return true, nil
+	}
+
+	value, err := json.Marshal(dedupUIExtensions(extensions))
+	if err != nil {
+		return false, err
+	}
+	if ok && current == string(value) {
+		return false, nil
+	}
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[UIExtensionsAnnotationKey] = string(value)
+	obj.SetAnnotations(annotations)
+	return true, nil
+}
+
+func dedupUIExtensions(extensions []UIExtension) []UIExtension {
+	sort.SliceStable(extensions, func(i, j int) bool {
+		a, b := extensions[i], extensions[j]
+		if a.Type != b.Type {
+			return a.Type < b.Type
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.ClusterServiceVersion < b.ClusterServiceVersion
+	})
+	var deduped []UIExtension
+	for i, extension := range extensions {
+		if i > 0 {
+			prev := extensions[i-1]
+			if prev.Type == extension.Type && prev.Name == extension.Name && prev.Namespace == extension.Namespace && prev.ClusterServiceVersion == extension.ClusterServiceVersion {
+				continue
+			}
+		}
+		deduped = append(deduped, extension)
+	}
+	return deduped
+}
@@ -0,0 +1,294 @@
+// Package imagearch schedules the pods of OLM's own infrastructure, e.g. bundle unpack jobs and registry pods, onto
+// nodes of the architectures their images support, so that they don't crash loop on the nodes of mixed amd64/arm64
+// clusters their images weren't built for.
+package imagearch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/distribution/distribution/reference"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/operator-framework/operator-registry/pkg/image/containerdregistry"
+)
+
+const (
+	// ArchitecturesAnnotationKey is the annotation of the cluster OLMConfig overriding the architectures OLM's
+	// infrastructure pods are scheduled on: either a comma-separated list of architectures, e.g. "amd64,arm64", used
+	// instead of the architectures supported by their images, or Disabled, to schedule them on any architecture.
+	ArchitecturesAnnotationKey = "operatorframework.io/infrastructure-architectures"
+
+	// Disabled turns off the architecture affinity of OLM's infrastructure pods.
+	Disabled = "Disabled"
+
+	// cacheTTL is how long the architectures supported by an image referenced by tag are cached. Images referenced by
+	// digest are immutable, and cached as long as the Resolver.
+	cacheTTL = time.Hour
+
+	// maxManifestSize is the size above which image manifests and configs aren't read.
+	maxManifestSize = 4 << 20
+)
+
+type cacheEntry struct {
+	architectures []string
+	expires       time.Time
+}
+
+// Resolver looks up the architectures supported by images from their registries, and caches them.
+type Resolver struct {
+	fetch func(ctx context.Context, image string) ([]string, error)
+	now   func() time.Time
+
+	mu    sync.Mutex
+	cache map[string]cacheEntry
+}
+
+// NewResolver returns a Resolver querying registries anonymously.
+func NewResolver() *Resolver {
+	return newResolver(fetchArchitectures, time.Now)
+}
+
+func newResolver(fetch func(ctx context.Context, image string) ([]string, error), now func() time.Time) *Resolver {
+	return &Resolver{
+		fetch: fetch,
+		now:   now,
+		cache: map[string]cacheEntry{},
+	}
+}
+
+// Architectures returns the architectures supported by the given image.
+func (r *Resolver) Architectures(ctx context.Context, image string) ([]string, error) {
+	now := r.now()
+	r.mu.Lock()
+	entry, ok := r.cache[image]
+	r.mu.Unlock()
+	if ok && (entry.expires.IsZero() || now.Before(entry.expires)) {
+		return entry.architectures, nil
+	}
+
+	architectures, err := r.fetch(ctx, image)
+	if err != nil {
+		return nil, err
+	}
+	entry = cacheEntry{architectures: architectures}
+	if !strings.Contains(image, "@") {
+		entry.expires = now.Add(cacheTTL)
+	}
+	r.mu.Lock()
+	r.cache[image] = entry
+	r.mu.Unlock()
+	return architectures, nil
+}
+
+// Required returns the architectures the pods running the given images must be scheduled on, given the annotations
+// of the cluster OLMConfig: those supported by all images, unless overridden. It returns nil if the pods can be
+// scheduled on any architecture.
+func (r *Resolver) Required(ctx context.Context, olmConfigAnnotations map[string]string, images ...string) ([]string, error) {
+	if override, ok := olmConfigAnnotations[ArchitecturesAnnotationKey]; ok {
+		return parseOverride(override)
+	}
+	if r == nil {
+		return nil, nil
+	}
+
+	var required []string
+	for i, image := range images {
+		architectures, err := r.Architectures(ctx, image)
+		if err != nil {
+			return nil, fmt.Errorf("unable to look up the architectures of image %s: %v", image, err)
+		}
+		if i == 0 {
+			required = architectures
+		} else {
+			required = intersect(required, architectures)
+		}
+	}
+	if len(images) > 0 && len(required) == 0 {
+		return nil, fmt.Errorf("images %s support no common architecture", strings.Join(images, ", "))
+	}
+	return required, nil
+}
+
+func parseOverride(override string) ([]string, error) {
+	if override == Disabled {
+		return nil, nil
+	}
+	var architectures []string
+	for _, arch := range strings.Split(override, ",") {
+		if arch = strings.TrimSpace(arch); arch != "" {
+			architectures = append(architectures, arch)
+		}
+	}
+	if len(architectures) == 0 {
+		return nil, fmt.Errorf("invalid %s annotation %q", ArchitecturesAnnotationKey, override)
+	}
+	sort.Strings(architectures)
+	return architectures, nil
+}
+
+func intersect(a, b []string) []string {
+	in := map[string]bool{}
+	for _, arch := range b {
+		in[arch] = true
+	}
+	var out []string
+	for _, arch := range a {
+		if in[arch] {
+			out = append(out, arch)
+		}
+	}
+	return out
+}
+
+// SetAffinity requires the pods of the given spec to be scheduled on nodes of the given architectures, in addition to
+// the node affinity already required. Nothing is required if no architecture is given.
+func SetAffinity(spec *corev1.PodSpec, architectures []string) {
+	if len(architectures) == 0 {
+		return
+	}
+	requirement := corev1.NodeSelectorRequirement{
+		Key:      corev1.LabelArchStable,
+		Operator: corev1.NodeSelectorOpIn,
+		Values:   architectures,
+	}
+
+	if spec.Affinity == nil {
+		spec.Affinity = &corev1.Affinity{}
+	}
+	if spec.Affinity.NodeAffinity == nil {
+		spec.Affinity.NodeAffinity = &corev1.NodeAffinity{}
+	}
+	required := spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution
+	if required == nil || len(required.NodeSelectorTerms) == 0 {
+		spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution = &corev1.NodeSelector{
+			NodeSelectorTerms: []corev1.NodeSelectorTerm{{MatchExpressions: []corev1.NodeSelectorRequirement{requirement}}},
+		}
+		return
+	}
+	// Terms are ORed, so the architecture is required by each of them
+	for i := range required.NodeSelectorTerms {
+		required.NodeSelectorTerms[i].MatchExpressions = append(required.NodeSelectorTerms[i].MatchExpressions, requirement)
+	}
+}
+
+// The media types of image manifest lists and manifests.
+const (
+	mediaTypeImageIndex         = "application/vnd.oci.image.index.v1+json"
+	mediaTypeImageManifest      = "application/vnd.oci.image.manifest.v1+json"
+	mediaTypeDockerList         = "application/vnd.docker.distribution.manifest.list.v2+json"
+	mediaTypeDockerManifest     = "application/vnd.docker.distribution.manifest.v2+json"
+	unknownPlatformArchitecture = "unknown"
+)
+
+type platform struct {
+	Architecture string `json:"architecture"`
+	OS           string `json:"os"`
+}
+
+type imageIndex struct {
+	Manifests []struct {
+		Platform *platform `json:"platform,omitempty"`
+	} `json:"manifests"`
+}
+
+type imageManifest struct {
+	Config json.RawMessage `json:"config"`
+}
+
+// fetchArchitectures reads the architectures supported by the given image from its manifest list, or from its config
+// if it's a single-architecture image.
+func fetchArchitectures(ctx context.Context, image string) ([]string, error) {
+	named, err := reference.ParseNormalizedNamed(image)
+	if err != nil {
+		return nil, err
+	}
+	ref := reference.TagNameOnly(named).String()
+
+	resolver, err := containerdregistry.NewResolver("", false, nil)
+	if err != nil {
+		return nil, err
+	}
+	name, desc, err := resolver.Resolve(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	fetcher, err := resolver.Fetcher(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	fetch := func(size int64, v interface{}) error {
+		if size > maxManifestSize {
+			return fmt.Errorf("manifest of %d bytes is too large", size)
+		}
+		rc, err := fetcher.Fetch(ctx, desc)
+		if err != nil {
+			return err
+		}
+		defer rc.Close()
+		data, err := ioutil.ReadAll(rc)
+		if err != nil {
+			return err
+		}
+		return json.Unmarshal(data, v)
+	}
+
+	switch desc.MediaType {
+	case mediaTypeImageIndex, mediaTypeDockerList:
+		var index imageIndex
+		if err := fetch(desc.Size, &index); err != nil {
+			return nil, err
+		}
+		set := map[string]bool{}
+		for _, m := range index.Manifests {
+			// attestation manifests have an unknown platform
+			if p := m.Platform; p != nil && p.Architecture != "" && p.Architecture != unknownPlatformArchitecture && (p.OS == "" || p.OS == "linux") {
+				set[p.Architecture] = true
+			}
+		}
+		architectures := make([]string, 0, len(set))
+		for arch := range set {
+			architectures = append(architectures, arch)
+		}
+		sort.Strings(architectures)
+		return architectures, nil
+	case mediaTypeImageManifest, mediaTypeDockerManifest:
+		var manifest imageManifest
+		if err := fetch(desc.Size, &manifest); err != nil {
+			return nil, err
+		}
+		// the config is fetched through its descriptor
+		if err := json.Unmarshal(manifest.Config, &desc); err != nil {
+			return nil, err
+		}
+		var config platform
+		if err := fetch(desc.Size, &config); err != nil {
+			return nil, err
+		}
+		if config.Architecture == "" {
+			return nil, fmt.Errorf("image config doesn't specify an architecture")
+		}
+		return []string{config.Architecture}, nil
+	default:
+		return nil, fmt.Errorf("unsupported manifest media type %s", desc.MediaType)
+	}
+}
+
+// RequiredFunc returns the architectures the pods running the given images must be scheduled on, or nil if they can
+// be scheduled on any architecture.
+type RequiredFunc func(ctx context.Context, images ...string) []string
+
+// Apply requires the pods of the given spec to be scheduled on the architectures required for the given images, if
+// any.
+func (f RequiredFunc) Apply(ctx context.Context, spec *corev1.PodSpec, images ...string) {
+	if f == nil {
+		return
+	}
+	SetAffinity(spec, f(ctx, images...))
+}
@@ -0,0 +1,147 @@
+package imagearch
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestRequired(t *testing.T) {
+	supported := map[string][]string{
+		"quay.io/operator-framework/opm:latest": {"amd64", "arm64", "ppc64le"},
+		"quay.io/operator-framework/olm:latest": {"amd64", "arm64"},
+		"quay.io/example/amd64-only:v1":         {"amd64"},
+		"quay.io/example/arm64-only:v1":         {"arm64"},
+	}
+	r := newResolver(func(ctx context.Context, image string) ([]string, error) {
+		archs, ok := supported[image]
+		if !ok {
+			return nil, fmt.Errorf("image %s not found", image)
+		}
+		return archs, nil
+	}, time.Now)
+
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		images      []string
+		expected    []string
+		expectedErr bool
+	}{
+		{
+			name:     "Intersection",
+			images:   []string{"quay.io/operator-framework/opm:latest", "quay.io/operator-framework/olm:latest"},
+			expected: []string{"amd64", "arm64"},
+		},
+		{
+			name:        "NoCommonArchitecture",
+			images:      []string{"quay.io/example/amd64-only:v1", "quay.io/example/arm64-only:v1"},
+			expectedErr: true,
+		},
+		{
+			name:        "LookupFailure",
+			images:      []string{"quay.io/example/missing:v1"},
+			expectedErr: true,
+		},
+		{
+			name:        "Override",
+			annotations: map[string]string{ArchitecturesAnnotationKey: "s390x, amd64"},
+			images:      []string{"quay.io/example/missing:v1"},
+			expected:    []string{"amd64", "s390x"},
+		},
+		{
+			name:        "Disabled",
+			annotations: map[string]string{ArchitecturesAnnotationKey: Disabled},
+			images:      []string{"quay.io/example/amd64-only:v1"},
+		},
+		{
+			name:        "InvalidOverride",
+			annotations: map[string]string{ArchitecturesAnnotationKey: " , "},
+			expectedErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			archs, err := r.Required(context.TODO(), tt.annotations, tt.images...)
+			if tt.expectedErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, archs)
+		})
+	}
+
+	var nilResolver *Resolver
+	archs, err := nilResolver.Required(context.TODO(), nil, "quay.io/example/amd64-only:v1")
+	require.NoError(t, err)
+	require.Nil(t, archs)
+}
+
+func TestArchitecturesCache(t *testing.T) {
+	now := time.Date(2021, time.October, 1, 12, 0, 0, 0, time.UTC)
+	fetches := 0
+	r := newResolver(func(ctx context.Context, image string) ([]string, error) {
+		fetches++
+		return []string{"amd64"}, nil
+	}, func() time.Time { return now })
+
+	for _, image := range []string{"quay.io/example/operator:v1", "quay.io/example/operator@sha256:abc"} {
+		_, err := r.Architectures(context.TODO(), image)
+		require.NoError(t, err)
+		_, err = r.Architectures(context.TODO(), image)
+		require.NoError(t, err)
+	}
+	require.Equal(t, 2, fetches)
+
+	now = now.Add(cacheTTL + time.Second)
+	for _, image := range []string{"quay.io/example/operator:v1", "quay.io/example/operator@sha256:abc"} {
+		_, err := r.Architectures(context.TODO(), image)
+		require.NoError(t, err)
+	}
+	require.Equal(t, 3, fetches, "only tags expire")
+}
+
+func TestSetAffinity(t *testing.T) {
+	archRequirement := corev1.NodeSelectorRequirement{Key: corev1.LabelArchStable, Operator: corev1.NodeSelectorOpIn, Values: []string{"amd64", "arm64"}}
+	zoneRequirement := corev1.NodeSelectorRequirement{Key: corev1.LabelTopologyZone, Operator: corev1.NodeSelectorOpIn, Values: []string{"a"}}
+
+	spec := &corev1.PodSpec{}
+	SetAffinity(spec, nil)
+	require.Nil(t, spec.Affinity)
+
+	SetAffinity(spec, []string{"amd64", "arm64"})
+	require.Equal(t, []corev1.NodeSelectorTerm{{MatchExpressions: []corev1.NodeSelectorRequirement{archRequirement}}},
+		spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms)
+
+	spec = &corev1.PodSpec{Affinity: &corev1.Affinity{NodeAffinity: &corev1.NodeAffinity{
+		RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{NodeSelectorTerms: []corev1.NodeSelectorTerm{
+			{MatchExpressions: []corev1.NodeSelectorRequirement{zoneRequirement}},
+			{},
+		}},
+	}}}
+	SetAffinity(spec, []string{"amd64", "arm64"})
+	require.Equal(t, []corev1.NodeSelectorTerm{
+		{MatchExpressions: []corev1.NodeSelectorRequirement{zoneRequirement, archRequirement}},
+		{MatchExpressions: []corev1.NodeSelectorRequirement{archRequirement}},
+	}, spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms)
+}
+
+func TestRequiredFuncApply(t *testing.T) {
+	spec := &corev1.PodSpec{}
+	var f RequiredFunc
+	f.Apply(context.TODO(), spec, "quay.io/example/operator:v1")
+	require.Nil(t, spec.Affinity)
+
+	f = func(_ context.Context, images ...string) []string {
+		require.Equal(t, []string{"quay.io/example/operator:v1"}, images)
+		return []string{"arm64"}
+	}
+	f.Apply(context.TODO(), spec, "quay.io/example/operator:v1")
+	require.Equal(t, []string{"arm64"}, spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[0].MatchExpressions[0].Values)
+}
@@ -0,0 +1,103 @@
+// Package imagemirror rewrites the catalog and bundle images OLM runs to the mirrors of their repositories set on the
+// cluster OLMConfig, so that disconnected clusters pull them from a local registry without rebuilding the catalogs
+// referencing them.
+package imagemirror
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/distribution/distribution/reference"
+)
+
+// MirrorsAnnotationKey is the annotation of the cluster OLMConfig holding the JSON list of repository mirrors catalog
+// and bundle images are rewritten with, e.g. [{"source":"quay.io/operatorhubio","mirror":"registry.internal/operatorhubio"}].
+const MirrorsAnnotationKey = "operatorframework.io/image-mirrors"
+
+// Mirror maps the images of a source repository, or of the repositories under it, to a mirror.
+type Mirror struct {
+	// Source is the repository, or repository prefix, of the images mirrored, including its registry host, e.g.
+	// quay.io/operatorhubio.
+	Source string `json:"source"`
+	// Mirror is the repository, or repository prefix, the images are mirrored to, e.g. registry.internal/operatorhubio.
+	Mirror string `json:"mirror"`
+}
+
+// Mirrors are the repository mirrors of the cluster OLMConfig.
+type Mirrors []Mirror
+
+// ParseMirrors returns the repository mirrors held by the given annotations of the cluster OLMConfig, or nil if there
+// are none.
+func ParseMirrors(olmConfigAnnotations map[string]string) (Mirrors, error) {
+	raw, ok := olmConfigAnnotations[MirrorsAnnotationKey]
+	if !ok {
+		return nil, nil
+	}
+	var mirrors Mirrors
+	if err := json.Unmarshal([]byte(raw), &mirrors); err != nil {
+		return nil, fmt.Errorf("unable to parse %s annotation: %v", MirrorsAnnotationKey, err)
+	}
+	for _, m := range mirrors {
+		for _, repository := range []string{m.Source, m.Mirror} {
+			if !validRepository(repository) {
+				return nil, fmt.Errorf("invalid %s annotation: %q isn't a repository without tag or digest", MirrorsAnnotationKey, repository)
+			}
+		}
+	}
+	return mirrors, nil
+}
+
+// validRepository returns true if the given value is a repository, or repository prefix, without tag or digest.
+func validRepository(repository string) bool {
+	if repository == "" || strings.Contains(repository, "@") || strings.HasSuffix(repository, "/") {
+		return false
+	}
+	// prefixes only parse once followed by a repository path component
+	_, err := reference.ParseNormalizedNamed(repository + "/x")
+	return err == nil
+}
+
+// Rewrite returns the given image in the mirror of its repository, keeping its tag and digest, or the image itself if
+// none of the mirrors has it. The mirror with the longest source matching the repository of the image is used.
+func (m Mirrors) Rewrite(image string) string {
+	named, err := reference.ParseNormalizedNamed(image)
+	if err != nil {
+		return image
+	}
+	name := named.Name()
+	var match *Mirror
+	for i := range m {
+		source := m[i].Source
+		if name != source && !strings.HasPrefix(name, source+"/") {
+			continue
+		}
+		if match == nil || len(source) > len(match.Source) {
+			match = &m[i]
+		}
+	}
+	if match == nil {
+		return image
+	}
+
+	rewritten := match.Mirror + strings.TrimPrefix(name, match.Source)
+	if tagged, ok := named.(reference.Tagged); ok {
+		rewritten += ":" + tagged.Tag()
+	}
+	if digested, ok := named.(reference.Digested); ok {
+		rewritten += "@" + digested.Digest().String()
+	}
+	return rewritten
+}
+
+// RewriteFunc returns the given catalog or bundle image in the mirror of its repository, or the image itself if it
+// isn't mirrored.
+type RewriteFunc func(image string) string
+
+// Rewrite returns the given image in the mirror of its repository. Images are left as is by a nil RewriteFunc.
+func (f RewriteFunc) Rewrite(image string) string {
+	if f == nil {
+		return image
+	}
+	return f(image)
+}
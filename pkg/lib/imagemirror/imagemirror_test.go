@@ -0,0 +1,80 @@
+package imagemirror
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMirrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		mirrors string
+		wantErr string
+	}{
+		{
+			name:    "Valid",
+			mirrors: `[{"source":"quay.io/operatorhubio","mirror":"registry.internal:5000/operatorhubio"},{"source":"quay.io","mirror":"registry.internal:5000/quay"}]`,
+		},
+		{
+			name:    "Malformed",
+			mirrors: `{}`,
+			wantErr: "unable to parse operatorframework.io/image-mirrors annotation: json: cannot unmarshal object into Go value of type imagemirror.Mirrors",
+		},
+		{
+			name:    "NoMirror",
+			mirrors: `[{"source":"quay.io/operatorhubio"}]`,
+			wantErr: `invalid operatorframework.io/image-mirrors annotation: "" isn't a repository without tag or digest`,
+		},
+		{
+			name:    "Tagged",
+			mirrors: `[{"source":"quay.io/operatorhubio/catalog:latest","mirror":"registry.internal/catalog"}]`,
+			wantErr: `invalid operatorframework.io/image-mirrors annotation: "quay.io/operatorhubio/catalog:latest" isn't a repository without tag or digest`,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			mirrors, err := ParseMirrors(map[string]string{MirrorsAnnotationKey: tt.mirrors})
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, mirrors, 2)
+		})
+	}
+
+	none, err := ParseMirrors(nil)
+	require.NoError(t, err)
+	require.Nil(t, none)
+}
+
+func TestRewrite(t *testing.T) {
+	mirrors := Mirrors{
+		{Source: "quay.io", Mirror: "registry.internal:5000/quay"},
+		{Source: "quay.io/operatorhubio", Mirror: "registry.internal:5000/operatorhubio"},
+		{Source: "docker.io/library/busybox", Mirror: "registry.internal:5000/busybox"},
+		{Source: "registry.example.com/team", Mirror: "registry.internal:5000/team"},
+	}
+	for image, expected := range map[string]string{
+		// the longest source matching wins
+		"quay.io/operatorhubio/catalog:latest":           "registry.internal:5000/operatorhubio/catalog:latest",
+		"quay.io/example/bundle:v1":                      "registry.internal:5000/quay/example/bundle:v1",
+		"quay.io/operatorhubio/catalog@sha256:" + digest: "registry.internal:5000/operatorhubio/catalog@sha256:" + digest,
+		// images are matched by their normalized name
+		"busybox": "registry.internal:5000/busybox",
+		// sources only match whole path components
+		"registry.example.com/teams/bundle:v1": "registry.example.com/teams/bundle:v1",
+		"registry.example.com/team/bundle:v1":  "registry.internal:5000/team/bundle:v1",
+		"ghcr.io/example/bundle:v1":            "ghcr.io/example/bundle:v1",
+		"not a reference":                      "not a reference",
+	} {
+		require.Equal(t, expected, mirrors.Rewrite(image), image)
+	}
+
+	var none RewriteFunc
+	require.Equal(t, "quay.io/example/bundle:v1", none.Rewrite("quay.io/example/bundle:v1"))
+}
+
+const digest = "6c3c624b58dbbcd3c0dd82b4c53f04194d1247c6eebdaab7c610cf7d66709b3b"
@@ -0,0 +1,187 @@
+package imagesig
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/containerd/containerd/errdefs"
+	"github.com/containerd/containerd/remotes"
+	"github.com/distribution/distribution/reference"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/operator-framework/operator-registry/pkg/image/containerdregistry"
+)
+
+// The annotations of the layers of cosign signature images.
+const (
+	signatureAnnotationKey   = "dev.cosignproject.cosign/signature"
+	certificateAnnotationKey = "dev.sigstore.cosign/certificate"
+	chainAnnotationKey       = "dev.sigstore.cosign/chain"
+	bundleAnnotationKey      = "dev.sigstore.cosign/bundle"
+)
+
+// maxBlobSize is the size above which signature manifests and payloads aren't read.
+const maxBlobSize = 4 << 20
+
+type signatureManifest struct {
+	Layers []json.RawMessage `json:"layers"`
+}
+
+type signatureLayer struct {
+	Annotations map[string]string `json:"annotations"`
+}
+
+// dockerConfig returns the docker config.json holding the registry credentials of the given image pull secrets, or
+// nil if none of them holds any. Secrets of other types are ignored.
+func dockerConfig(pullSecrets []*corev1.Secret) ([]byte, error) {
+	auths := map[string]json.RawMessage{}
+	for _, secret := range pullSecrets {
+		var secretAuths map[string]json.RawMessage
+		switch secret.Type {
+		case corev1.SecretTypeDockerConfigJson:
+			var config struct {
+				Auths map[string]json.RawMessage `json:"auths"`
+			}
+			if err := json.Unmarshal(secret.Data[corev1.DockerConfigJsonKey], &config); err != nil {
+				return nil, fmt.Errorf("invalid image pull secret %s: %v", secret.GetName(), err)
+			}
+			secretAuths = config.Auths
+		case corev1.SecretTypeDockercfg:
+			if err := json.Unmarshal(secret.Data[corev1.DockerConfigKey], &secretAuths); err != nil {
+				return nil, fmt.Errorf("invalid image pull secret %s: %v", secret.GetName(), err)
+			}
+		}
+		// The first secret holding credentials for a registry wins, as for pods
+		for registry, auth := range secretAuths {
+			if _, ok := auths[registry]; !ok {
+				auths[registry] = auth
+			}
+		}
+	}
+	if len(auths) == 0 {
+		return nil, nil
+	}
+	return json.Marshal(map[string]interface{}{"auths": auths})
+}
+
+// newResolver returns a resolver authenticating to registries with the credentials of the given image pull secrets,
+// and a func removing the docker config they were written to.
+func newResolver(pullSecrets []*corev1.Secret) (remotes.Resolver, func(), error) {
+	config, err := dockerConfig(pullSecrets)
+	if err != nil || config == nil {
+		resolver, err := containerdregistry.NewResolver("", false, nil)
+		return resolver, func() {}, err
+	}
+
+	dir, err := ioutil.TempDir("", "imagesig-")
+	if err != nil {
+		return nil, nil, err
+	}
+	cleanup := func() { _ = os.RemoveAll(dir) }
+	if err := ioutil.WriteFile(filepath.Join(dir, "config.json"), config, 0600); err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	resolver, err := containerdregistry.NewResolver(dir, false, nil)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	return resolver, cleanup, nil
+}
+
+// fetchSignatures returns the digest the given image resolves to, and the cosign signatures stored in the signature
+// image tagged after that digest in the same repository. The registry is authenticated to with the given image pull
+// secrets.
+func fetchSignatures(ctx context.Context, image string, pullSecrets []*corev1.Secret) (string, []Signature, error) {
+	named, err := reference.ParseNormalizedNamed(image)
+	if err != nil {
+		return "", nil, err
+	}
+	resolver, cleanup, err := newResolver(pullSecrets)
+	if err != nil {
+		return "", nil, err
+	}
+	defer cleanup()
+	_, desc, err := resolver.Resolve(ctx, reference.TagNameOnly(named).String())
+	if err != nil {
+		return "", nil, fmt.Errorf("unable to resolve image %s: %v", image, err)
+	}
+	digest := desc.Digest.String()
+
+	// cosign tags the signatures of sha256:<hex> as sha256-<hex>.sig
+	sigRef := fmt.Sprintf("%s:%s.sig", named.Name(), strings.Replace(digest, ":", "-", 1))
+	name, desc, err := resolver.Resolve(ctx, sigRef)
+	if errdefs.IsNotFound(err) {
+		return digest, nil, nil
+	}
+	if err != nil {
+		return "", nil, fmt.Errorf("unable to resolve signatures %s: %v", sigRef, err)
+	}
+	fetcher, err := resolver.Fetcher(ctx, name)
+	if err != nil {
+		return "", nil, err
+	}
+	fetch := func() ([]byte, error) {
+		if desc.Size > maxBlobSize {
+			return nil, fmt.Errorf("blob %s of %d bytes is too large", desc.Digest, desc.Size)
+		}
+		rc, err := fetcher.Fetch(ctx, desc)
+		if err != nil {
+			return nil, err
+		}
+		defer rc.Close()
+		data, err := ioutil.ReadAll(rc)
+		if err != nil {
+			return nil, err
+		}
+		// Signatures are only trusted for the content their signature image references
+		if desc.Digest.Algorithm().FromBytes(data) != desc.Digest {
+			return nil, fmt.Errorf("blob %s doesn't match its digest", desc.Digest)
+		}
+		return data, nil
+	}
+
+	data, err := fetch()
+	if err != nil {
+		return "", nil, err
+	}
+	var manifest signatureManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return "", nil, fmt.Errorf("unable to parse signature manifest %s: %v", sigRef, err)
+	}
+	manifestDesc := desc
+	var signatures []Signature
+	for _, raw := range manifest.Layers {
+		var layer signatureLayer
+		if err := json.Unmarshal(raw, &layer); err != nil {
+			return "", nil, fmt.Errorf("unable to parse signature manifest %s: %v", sigRef, err)
+		}
+		sig, ok := layer.Annotations[signatureAnnotationKey]
+		if !ok {
+			continue
+		}
+		// the payload is fetched through its descriptor
+		desc = manifestDesc
+		if err := json.Unmarshal(raw, &desc); err != nil {
+			return "", nil, err
+		}
+		payload, err := fetch()
+		if err != nil {
+			return "", nil, err
+		}
+		signatures = append(signatures, Signature{
+			Payload:     payload,
+			Signature:   sig,
+			Certificate: []byte(layer.Annotations[certificateAnnotationKey]),
+			Chain:       []byte(layer.Annotations[chainAnnotationKey]),
+			Bundle:      []byte(layer.Annotations[bundleAnnotationKey]),
+		})
+	}
+	return digest, signatures, nil
+}
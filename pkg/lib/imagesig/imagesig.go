@@ -0,0 +1,205 @@
+// Package imagesig verifies the cosign signatures of the catalog and bundle images OLM runs, against the signature
+// policy of the cluster OLMConfig, before registry pods and bundle unpack jobs pull them.
+package imagesig
+
+import (
+	"context"
+	"crypto"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/distribution/distribution/reference"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/lru"
+)
+
+const (
+	// PolicyAnnotationKey is the annotation of the cluster OLMConfig holding the JSON signature policy the catalog and
+	// bundle images must satisfy. Images aren't verified when it isn't set.
+	PolicyAnnotationKey = "operatorframework.io/image-signature-policy"
+
+	// cacheTTL is how long the digest verified for an image referenced by tag is trusted before it's verified again,
+	// as the tag may have moved. Images referenced by digest are immutable, and trusted until they're evicted.
+	cacheTTL = 10 * time.Minute
+
+	// cacheSize is the number of verified images cached, the least recently verified being evicted first.
+	cacheSize = 1024
+)
+
+// Policy is the signature policy images must satisfy: they must have a cosign signature made either with one of the
+// public keys, or keylessly by one of the identities.
+type Policy struct {
+	// PublicKeys are PEM-encoded ECDSA, RSA or Ed25519 public keys.
+	PublicKeys []string `json:"publicKeys,omitempty"`
+	// Keyless configures the verification of signatures made with short-lived certificates.
+	Keyless *KeylessPolicy `json:"keyless,omitempty"`
+
+	raw      string
+	keys     []crypto.PublicKey
+	roots    *x509.CertPool
+	rekorKey crypto.PublicKey
+}
+
+// KeylessPolicy trusts the signatures made with certificates issued by the Fulcio roots to one of the identities,
+// which have been recorded in the Rekor transparency log while the certificate was valid.
+type KeylessPolicy struct {
+	// FulcioRoots are the PEM-encoded certificates of the certificate authorities issuing the signing certificates.
+	FulcioRoots string `json:"fulcioRoots"`
+	// RekorPublicKey is the PEM-encoded public key of the transparency log signing the entries of the signatures.
+	RekorPublicKey string `json:"rekorPublicKey"`
+	// Identities are the identities allowed to sign images.
+	Identities []Identity `json:"identities"`
+}
+
+// Identity is the identity of a keyless signer.
+type Identity struct {
+	// Issuer is the OIDC issuer that authenticated the signer, e.g. https://token.actions.githubusercontent.com.
+	Issuer string `json:"issuer"`
+	// Subject is the email or URI of the signer, e.g. the workflow of a GitHub Action.
+	Subject string `json:"subject"`
+}
+
+// ParsePolicy returns the signature policy held by the given annotations of the cluster OLMConfig, or nil if there's
+// none.
+func ParsePolicy(olmConfigAnnotations map[string]string) (*Policy, error) {
+	raw, ok := olmConfigAnnotations[PolicyAnnotationKey]
+	if !ok {
+		return nil, nil
+	}
+	policy := &Policy{raw: raw}
+	if err := json.Unmarshal([]byte(raw), policy); err != nil {
+		return nil, fmt.Errorf("unable to parse %s annotation: %v", PolicyAnnotationKey, err)
+	}
+	if len(policy.PublicKeys) == 0 && policy.Keyless == nil {
+		return nil, fmt.Errorf("%s annotation must specify public keys or keyless identities", PolicyAnnotationKey)
+	}
+	for _, key := range policy.PublicKeys {
+		parsed, err := parsePublicKey(key)
+		if err != nil {
+			return nil, err
+		}
+		policy.keys = append(policy.keys, parsed)
+	}
+	if keyless := policy.Keyless; keyless != nil {
+		if len(keyless.Identities) == 0 {
+			return nil, fmt.Errorf("keyless signature policy must specify identities")
+		}
+		for _, identity := range keyless.Identities {
+			if identity.Issuer == "" || identity.Subject == "" {
+				return nil, fmt.Errorf("keyless identities must have an issuer and a subject")
+			}
+		}
+		policy.roots = x509.NewCertPool()
+		if !policy.roots.AppendCertsFromPEM([]byte(keyless.FulcioRoots)) {
+			return nil, fmt.Errorf("keyless signature policy must specify PEM-encoded Fulcio roots")
+		}
+		var err error
+		if policy.rekorKey, err = parsePublicKey(keyless.RekorPublicKey); err != nil {
+			return nil, fmt.Errorf("invalid Rekor public key: %v", err)
+		}
+	}
+	return policy, nil
+}
+
+func parsePublicKey(key string) (crypto.PublicKey, error) {
+	block, _ := pem.Decode([]byte(key))
+	if block == nil {
+		return nil, fmt.Errorf("public key isn't PEM-encoded")
+	}
+	parsed, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse public key: %v", err)
+	}
+	return parsed, nil
+}
+
+// VerificationError reports an image that doesn't satisfy the signature policy, as opposed to a failure to look its
+// signatures up.
+type VerificationError struct {
+	Image  string
+	Reason string
+}
+
+func (e *VerificationError) Error() string {
+	return fmt.Sprintf("signature verification of image %s failed: %s", e.Image, e.Reason)
+}
+
+// VerifyFunc verifies the signatures of the given image, and returns the image to pull: the image at the digest
+// verified, or the image itself if it isn't verified. The image pull secrets of the given names in the given namespace
+// authenticate to its registry. It returns a *VerificationError if the image doesn't satisfy the signature policy.
+type VerifyFunc func(ctx context.Context, image, namespace string, pullSecrets []string) (string, error)
+
+type cacheEntry struct {
+	digest  string
+	expires time.Time
+}
+
+// Verifier looks up the cosign signatures of images from their registries, verifies them, and caches the digests
+// verified.
+type Verifier struct {
+	fetch func(ctx context.Context, image string, pullSecrets []*corev1.Secret) (string, []Signature, error)
+	now   func() time.Time
+
+	verified *lru.Cache
+}
+
+// NewVerifier returns a Verifier querying registries with the image pull secrets it's given, if any.
+func NewVerifier() *Verifier {
+	return newVerifier(fetchSignatures, time.Now)
+}
+
+func newVerifier(fetch func(ctx context.Context, image string, pullSecrets []*corev1.Secret) (string, []Signature, error), now func() time.Time) *Verifier {
+	return &Verifier{
+		fetch:    fetch,
+		now:      now,
+		verified: lru.New(cacheSize),
+	}
+}
+
+// Verify checks that the given image has a signature satisfying the given policy, and returns the digest verified,
+// which the image must be pulled by for what's pulled to be what's verified. The digest and signatures are looked up
+// where the image is pulled from, pullImage, which is either the image or its mirror, while the signatures must be
+// made for the repository of the image itself, and are looked up with the given image pull secrets. Any image satisfies
+// a nil policy, for which no digest is returned.
+func (v *Verifier) Verify(ctx context.Context, policy *Policy, image, pullImage string, pullSecrets []*corev1.Secret) (string, error) {
+	if policy == nil {
+		return "", nil
+	}
+	named, err := reference.ParseNormalizedNamed(image)
+	if err != nil {
+		return "", &VerificationError{Image: image, Reason: err.Error()}
+	}
+	key := policy.raw + "\x00" + image + "\x00" + pullImage
+	now := v.now()
+	if cached, ok := v.verified.Get(key); ok {
+		if entry := cached.(cacheEntry); entry.expires.IsZero() || now.Before(entry.expires) {
+			return entry.digest, nil
+		}
+	}
+
+	digest, signatures, err := v.fetch(ctx, pullImage, pullSecrets)
+	if err != nil {
+		return "", err
+	}
+	if len(signatures) == 0 {
+		return "", &VerificationError{Image: image, Reason: "no signatures found"}
+	}
+	var reasons []string
+	for _, signature := range signatures {
+		err := policy.verify(digest, named.Name(), signature)
+		if err == nil {
+			entry := cacheEntry{digest: digest}
+			if !strings.Contains(pullImage, "@") {
+				entry.expires = now.Add(cacheTTL)
+			}
+			v.verified.Add(key, entry)
+			return digest, nil
+		}
+		reasons = append(reasons, err.Error())
+	}
+	return "", &VerificationError{Image: image, Reason: fmt.Sprintf("no signature satisfies the policy: %s", strings.Join(reasons, "; "))}
+}
@@ -0,0 +1,232 @@
+package imagesig
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"time"
+
+	"github.com/distribution/distribution/reference"
+)
+
+// Signature is a cosign signature of an image, as stored in a layer of its signature image.
+type Signature struct {
+	// Payload is the signed simple signing payload.
+	Payload []byte
+	// Signature is the base64-encoded signature of the payload.
+	Signature string
+	// Certificate is the PEM-encoded signing certificate of keyless signatures.
+	Certificate []byte
+	// Chain is the PEM-encoded chain of the signing certificate up to its root.
+	Chain []byte
+	// Bundle is the JSON transparency log entry of the signature.
+	Bundle []byte
+}
+
+// The object identifiers of the extensions of Fulcio certificates holding the OIDC issuer of the signer.
+var (
+	issuerV1OID = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 57264, 1, 1}
+	issuerV2OID = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 57264, 1, 8}
+)
+
+type simpleSigning struct {
+	Critical struct {
+		Identity struct {
+			DockerReference string `json:"docker-reference"`
+		} `json:"identity"`
+		Image struct {
+			DockerManifestDigest string `json:"docker-manifest-digest"`
+		} `json:"image"`
+	} `json:"critical"`
+}
+
+type rekorBundle struct {
+	SignedEntryTimestamp []byte `json:"SignedEntryTimestamp"`
+	Payload              struct {
+		Body           string `json:"body"`
+		IntegratedTime int64  `json:"integratedTime"`
+		LogIndex       int64  `json:"logIndex"`
+		LogID          string `json:"logID"`
+	} `json:"Payload"`
+}
+
+type hashedRekord struct {
+	Spec struct {
+		Data struct {
+			Hash struct {
+				Algorithm string `json:"algorithm"`
+				Value     string `json:"value"`
+			} `json:"hash"`
+		} `json:"data"`
+		Signature struct {
+			Content string `json:"content"`
+		} `json:"signature"`
+	} `json:"spec"`
+}
+
+// verify checks that the given signature is a signature of the image of the given repository with the given digest
+// satisfying the policy. Signatures made for other repositories are rejected, so that the signed images of a
+// repository can't be substituted for those of another signed with the same key.
+func (p *Policy) verify(digest, repository string, signature Signature) error {
+	var payload simpleSigning
+	if err := json.Unmarshal(signature.Payload, &payload); err != nil {
+		return fmt.Errorf("unable to parse signature payload: %v", err)
+	}
+	if signed := payload.Critical.Image.DockerManifestDigest; signed != digest {
+		return fmt.Errorf("signature is for digest %s", signed)
+	}
+	signed, err := reference.ParseNormalizedNamed(payload.Critical.Identity.DockerReference)
+	if err != nil || signed.Name() != repository {
+		return fmt.Errorf("signature is for repository %q", payload.Critical.Identity.DockerReference)
+	}
+	raw, err := base64.StdEncoding.DecodeString(signature.Signature)
+	if err != nil {
+		return fmt.Errorf("unable to decode signature: %v", err)
+	}
+
+	if len(signature.Certificate) == 0 {
+		for _, key := range p.keys {
+			if verifySignature(key, signature.Payload, raw) == nil {
+				return nil
+			}
+		}
+		return fmt.Errorf("signature isn't made with any of the public keys")
+	}
+	if p.Keyless == nil {
+		return fmt.Errorf("keyless signatures aren't trusted")
+	}
+	return p.verifyKeyless(signature, raw)
+}
+
+// verifyKeyless checks that the given keyless signature is made with a certificate issued by the Fulcio roots to one
+// of the identities, and recorded in the transparency log while the certificate was valid.
+func (p *Policy) verifyKeyless(signature Signature, raw []byte) error {
+	block, _ := pem.Decode(signature.Certificate)
+	if block == nil {
+		return fmt.Errorf("signing certificate isn't PEM-encoded")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return fmt.Errorf("unable to parse signing certificate: %v", err)
+	}
+	if err := verifySignature(cert.PublicKey, signature.Payload, raw); err != nil {
+		return err
+	}
+
+	integrated, err := p.verifyBundle(signature)
+	if err != nil {
+		return err
+	}
+	intermediates := x509.NewCertPool()
+	intermediates.AppendCertsFromPEM(signature.Chain)
+	if _, err := cert.Verify(x509.VerifyOptions{
+		Roots:         p.roots,
+		Intermediates: intermediates,
+		CurrentTime:   integrated,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+	}); err != nil {
+		return fmt.Errorf("signing certificate isn't trusted: %v", err)
+	}
+
+	issuer := certificateIssuer(cert)
+	subjects := cert.EmailAddresses
+	for _, uri := range cert.URIs {
+		subjects = append(subjects, uri.String())
+	}
+	for _, identity := range p.Keyless.Identities {
+		if identity.Issuer != issuer {
+			continue
+		}
+		for _, subject := range subjects {
+			if identity.Subject == subject {
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("signer %v authenticated by %s isn't a trusted identity", subjects, issuer)
+}
+
+// verifyBundle checks that the given signature has been recorded in the transparency log, and returns when.
+func (p *Policy) verifyBundle(signature Signature) (time.Time, error) {
+	if len(signature.Bundle) == 0 {
+		return time.Time{}, fmt.Errorf("keyless signature isn't recorded in the transparency log")
+	}
+	var bundle rekorBundle
+	if err := json.Unmarshal(signature.Bundle, &bundle); err != nil {
+		return time.Time{}, fmt.Errorf("unable to parse transparency log bundle: %v", err)
+	}
+	// The entry timestamp is signed over the canonical JSON of the payload, whose keys marshalling a map sorts
+	canonical, err := json.Marshal(map[string]interface{}{
+		"body":           bundle.Payload.Body,
+		"integratedTime": bundle.Payload.IntegratedTime,
+		"logIndex":       bundle.Payload.LogIndex,
+		"logID":          bundle.Payload.LogID,
+	})
+	if err != nil {
+		return time.Time{}, err
+	}
+	if err := verifySignature(p.rekorKey, canonical, bundle.SignedEntryTimestamp); err != nil {
+		return time.Time{}, fmt.Errorf("transparency log entry isn't signed by the Rekor public key")
+	}
+
+	body, err := base64.StdEncoding.DecodeString(bundle.Payload.Body)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("unable to decode transparency log entry: %v", err)
+	}
+	var entry hashedRekord
+	if err := json.Unmarshal(body, &entry); err != nil {
+		return time.Time{}, fmt.Errorf("unable to parse transparency log entry: %v", err)
+	}
+	hash := sha256.Sum256(signature.Payload)
+	if entry.Spec.Signature.Content != signature.Signature || entry.Spec.Data.Hash.Value != hex.EncodeToString(hash[:]) {
+		return time.Time{}, fmt.Errorf("transparency log entry is for another signature")
+	}
+	return time.Unix(bundle.Payload.IntegratedTime, 0), nil
+}
+
+func certificateIssuer(cert *x509.Certificate) string {
+	var issuer string
+	for _, ext := range cert.Extensions {
+		switch {
+		case ext.Id.Equal(issuerV2OID):
+			var v2 string
+			if _, err := asn1.Unmarshal(ext.Value, &v2); err == nil {
+				return v2
+			}
+		case ext.Id.Equal(issuerV1OID):
+			issuer = string(ext.Value)
+		}
+	}
+	return issuer
+}
+
+// verifySignature checks the given signature of the given payload, made with the private key of the given public key.
+func verifySignature(key crypto.PublicKey, payload, signature []byte) error {
+	hash := sha256.Sum256(payload)
+	switch k := key.(type) {
+	case *ecdsa.PublicKey:
+		if ecdsa.VerifyASN1(k, hash[:], signature) {
+			return nil
+		}
+	case *rsa.PublicKey:
+		if rsa.VerifyPKCS1v15(k, crypto.SHA256, hash[:], signature) == nil {
+			return nil
+		}
+	case ed25519.PublicKey:
+		if ed25519.Verify(k, payload, signature) {
+			return nil
+		}
+	default:
+		return fmt.Errorf("unsupported public key type %T", key)
+	}
+	return fmt.Errorf("invalid signature")
+}
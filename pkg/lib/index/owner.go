@@ -0,0 +1,36 @@
+package indexer
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/ownerutil"
+)
+
+const (
+	// CSVOwnerIndexFuncKey is the recommended key to use for registering the index func with an indexer.
+	CSVOwnerIndexFuncKey string = "csvownerindexfunc"
+)
+
+// CSVOwnerIndexFunc returns the index of the ClusterServiceVersion owning the given object by label, if any. It is
+// meant for cluster-scoped objects, which can't be owned through OwnerReferences, and for objects OLM copies to other
+// namespaces than their owner's.
+func CSVOwnerIndexFunc(obj interface{}) ([]string, error) {
+	m, err := meta.Accessor(obj)
+	if err != nil {
+		return nil, fmt.Errorf("object has no meta: %v", err)
+	}
+
+	name, namespace, ok := ownerutil.GetOwnerByKindLabel(m, v1alpha1.ClusterServiceVersionKind)
+	if !ok {
+		return nil, nil
+	}
+	return []string{CSVOwnerIndexKey(namespace, name)}, nil
+}
+
+// CSVOwnerIndexKey returns the index of the objects owned by the given ClusterServiceVersion.
+func CSVOwnerIndexKey(namespace, name string) string {
+	return namespace + "/" + name
+}
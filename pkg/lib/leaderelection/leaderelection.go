@@ -0,0 +1,185 @@
+// Package leaderelection elects a single active replica among the replicas of an OLM operator, so that they can be
+// run highly available.
+package leaderelection
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+)
+
+const (
+	DefaultLeaseDuration = 15 * time.Second
+	DefaultRenewDeadline = 10 * time.Second
+	DefaultRetryPeriod   = 2 * time.Second
+
+	serviceAccountNamespacePath = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
+)
+
+// Config configures the leader election of an operator.
+type Config struct {
+	// Enabled runs the operator only once it's elected leader. Disabled, it runs right away, e.g. in single-replica
+	// development environments.
+	Enabled bool
+
+	// ResourceLock is the kind of lock holding the lease: leases, or configmapsleases to migrate from operators holding
+	// a ConfigMap lock.
+	ResourceLock string
+
+	// Namespace and Name are the namespace and name of the lock.
+	Namespace string
+	Name      string
+
+	// LeaseDuration is how long replicas wait before taking over the lease of a leader that stopped renewing it.
+	LeaseDuration time.Duration
+	// RenewDeadline is how long the leader retries renewing its lease before giving it up.
+	RenewDeadline time.Duration
+	// RetryPeriod is how long replicas wait between attempts to acquire or renew the lease.
+	RetryPeriod time.Duration
+}
+
+// FlagSet is implemented by the flag sets of both the flag and pflag packages.
+type FlagSet interface {
+	BoolVar(p *bool, name string, value bool, usage string)
+	StringVar(p *string, name string, value string, usage string)
+	DurationVar(p *time.Duration, name string, value time.Duration, usage string)
+}
+
+// AddFlags adds the flags configuring the leader election to the given flag set. The lock is named after the given
+// name by default, and its namespace defaults to the namespace of the operator.
+func (c *Config) AddFlags(fs FlagSet, name string) {
+	fs.BoolVar(&c.Enabled, "leader-elect", true, "run the operator only once it's elected leader among its replicas, disable in single-replica development environments to start without waiting for a lease")
+	fs.StringVar(&c.ResourceLock, "leader-election-resource-lock", resourcelock.LeasesResourceLock, "the kind of lock holding the leader lease: leases, or configmapsleases to migrate from operators holding a configmap lock")
+	fs.StringVar(&c.Namespace, "leader-election-namespace", "", "the namespace of the leader lease lock, defaults to the namespace of the operator")
+	fs.StringVar(&c.Name, "leader-election-id", name, "the name of the leader lease lock")
+	fs.DurationVar(&c.LeaseDuration, "leader-election-lease-duration", DefaultLeaseDuration, "how long replicas wait before taking over the lease of a leader that stopped renewing it")
+	fs.DurationVar(&c.RenewDeadline, "leader-election-renew-deadline", DefaultRenewDeadline, "how long the leader retries renewing its lease before giving it up, shorter than the lease duration")
+	fs.DurationVar(&c.RetryPeriod, "leader-election-retry-period", DefaultRetryPeriod, "how long replicas wait between attempts to acquire or renew the lease")
+}
+
+// Elector runs an operator once it's elected leader.
+type Elector struct {
+	config  Config
+	elector *leaderelection.LeaderElector
+	logger  logrus.FieldLogger
+	run     func(ctx context.Context)
+	leading int32
+}
+
+// NewElector returns an Elector electing the leader with the given config. Its lock defaults to the given namespace.
+func NewElector(config Config, namespace string, client kubernetes.Interface, logger logrus.FieldLogger) (*Elector, error) {
+	if !config.Enabled {
+		return &Elector{config: config, logger: logger}, nil
+	}
+	switch config.ResourceLock {
+	case resourcelock.LeasesResourceLock, resourcelock.ConfigMapsLeasesResourceLock:
+	default:
+		return nil, fmt.Errorf("unsupported leader election resource lock %q, must be %s or %s", config.ResourceLock, resourcelock.LeasesResourceLock, resourcelock.ConfigMapsLeasesResourceLock)
+	}
+	if config.Namespace == "" {
+		config.Namespace = namespace
+	}
+	if config.Namespace == "" {
+		// Fall back to the namespace of the pod, e.g. when run without the namespace flag
+		if ns, err := ioutil.ReadFile(serviceAccountNamespacePath); err == nil {
+			config.Namespace = strings.TrimSpace(string(ns))
+		}
+	}
+	if config.Namespace == "" {
+		return nil, fmt.Errorf("leader election namespace must be set")
+	}
+	e := &Elector{config: config, logger: logger}
+
+	// Identities are the pod name followed by a unique suffix, so that restarted containers don't reuse the lease of
+	// their previous instance
+	hostname, err := os.Hostname()
+	if err != nil {
+		return nil, err
+	}
+	lock, err := resourcelock.New(config.ResourceLock, config.Namespace, config.Name, client.CoreV1(), client.CoordinationV1(), resourcelock.ResourceLockConfig{
+		Identity: hostname + "_" + string(uuid.NewUUID()),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	e.elector, err = leaderelection.NewLeaderElector(leaderelection.LeaderElectionConfig{
+		Lock:            lock,
+		LeaseDuration:   config.LeaseDuration,
+		RenewDeadline:   config.RenewDeadline,
+		RetryPeriod:     config.RetryPeriod,
+		ReleaseOnCancel: true,
+		Name:            config.Name,
+		Callbacks: leaderelection.LeaderCallbacks{
+			OnStartedLeading: func(ctx context.Context) {
+				atomic.StoreInt32(&e.leading, 1)
+				logger.WithField("identity", lock.Identity()).Info("acquired leader lease")
+				e.run(ctx)
+			},
+			OnStoppedLeading: func() {
+				atomic.StoreInt32(&e.leading, 0)
+			},
+			OnNewLeader: func(identity string) {
+				logger.WithField("leader", identity).Info("new leader elected")
+			},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("invalid leader election config: %v", err)
+	}
+	return e, nil
+}
+
+// Run runs the given function once elected leader, or right away if leader election is disabled, until the given
+// context is done. The process exits if the lease is lost before, since the informers and queues of the operator can't
+// be restarted.
+func (e *Elector) Run(ctx context.Context, run func(ctx context.Context)) {
+	if e.elector == nil {
+		run(ctx)
+		return
+	}
+
+	e.logger.WithField("lock", e.config.Namespace+"/"+e.config.Name).Info("waiting for leader lease")
+	var elected int32
+	done := make(chan struct{})
+	e.run = func(ctx context.Context) {
+		defer close(done)
+		atomic.StoreInt32(&elected, 1)
+		run(ctx)
+	}
+	e.elector.Run(ctx)
+	if ctx.Err() == nil {
+		e.logger.Fatal("lost leader lease")
+	}
+
+	// Let the operator shut down if it was elected
+	if atomic.LoadInt32(&elected) == 1 {
+		<-done
+	}
+}
+
+// Leading returns true while the operator is elected leader, or if leader election is disabled.
+func (e *Elector) Leading() bool {
+	return e.elector == nil || atomic.LoadInt32(&e.leading) == 1
+}
+
+// WhenLeading returns a readiness check passing while the operator isn't elected leader, so that standby replicas are
+// ready, and running the given check once elected.
+func (e *Elector) WhenLeading(check func() error) func() error {
+	return func() error {
+		if !e.Leading() {
+			return nil
+		}
+		return check()
+	}
+}
@@ -0,0 +1,156 @@
+package leaderelection
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestAddFlags(t *testing.T) {
+	defaults := Config{
+		Enabled:       true,
+		ResourceLock:  "leases",
+		Name:          "olm-operator-lock",
+		LeaseDuration: DefaultLeaseDuration,
+		RenewDeadline: DefaultRenewDeadline,
+		RetryPeriod:   DefaultRetryPeriod,
+	}
+	args := []string{
+		"--leader-elect=false",
+		"--leader-election-resource-lock=configmapsleases",
+		"--leader-election-namespace=ns",
+		"--leader-election-id=lock",
+		"--leader-election-lease-duration=30s",
+		"--leader-election-renew-deadline=20s",
+		"--leader-election-retry-period=5s",
+	}
+	parsed := Config{
+		ResourceLock:  "configmapsleases",
+		Namespace:     "ns",
+		Name:          "lock",
+		LeaseDuration: 30 * time.Second,
+		RenewDeadline: 20 * time.Second,
+		RetryPeriod:   5 * time.Second,
+	}
+
+	t.Run("flag", func(t *testing.T) {
+		var c Config
+		fs := flag.NewFlagSet("catalog", flag.ContinueOnError)
+		c.AddFlags(fs, "olm-operator-lock")
+		require.NoError(t, fs.Parse(nil))
+		require.Equal(t, defaults, c)
+		require.NoError(t, fs.Parse(args))
+		require.Equal(t, parsed, c)
+	})
+
+	t.Run("pflag", func(t *testing.T) {
+		var c Config
+		fs := pflag.NewFlagSet("olm", pflag.ContinueOnError)
+		c.AddFlags(fs, "olm-operator-lock")
+		require.NoError(t, fs.Parse(nil))
+		require.Equal(t, defaults, c)
+		require.NoError(t, fs.Parse(args))
+		require.Equal(t, parsed, c)
+	})
+}
+
+func TestNewElector(t *testing.T) {
+	valid := Config{
+		Enabled:       true,
+		ResourceLock:  "leases",
+		Name:          "lock",
+		LeaseDuration: DefaultLeaseDuration,
+		RenewDeadline: DefaultRenewDeadline,
+		RetryPeriod:   DefaultRetryPeriod,
+	}
+	tests := []struct {
+		name   string
+		config func(c *Config)
+		err    string
+	}{
+		{
+			name:   "Valid",
+			config: func(c *Config) {},
+		},
+		{
+			name: "Disabled",
+			config: func(c *Config) {
+				c.Enabled = false
+				c.ResourceLock = "unknown"
+			},
+		},
+		{
+			name:   "UnsupportedLock",
+			config: func(c *Config) { c.ResourceLock = "endpoints" },
+			err:    `unsupported leader election resource lock "endpoints", must be leases or configmapsleases`,
+		},
+		{
+			name:   "RenewDeadlineLongerThanLease",
+			config: func(c *Config) { c.RenewDeadline = 20 * time.Second },
+			err:    "invalid leader election config: leaseDuration must be greater than renewDeadline",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger, _ := test.NewNullLogger()
+			c := valid
+			tt.config(&c)
+			_, err := NewElector(c, "olm", fake.NewSimpleClientset(), logger)
+			if tt.err != "" {
+				require.EqualError(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestElectorRun(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	client := fake.NewSimpleClientset()
+	e, err := NewElector(Config{
+		Enabled:       true,
+		ResourceLock:  "leases",
+		Name:          "lock",
+		LeaseDuration: DefaultLeaseDuration,
+		RenewDeadline: DefaultRenewDeadline,
+		RetryPeriod:   DefaultRetryPeriod,
+	}, "olm", client, logger)
+	require.NoError(t, err)
+
+	failing := e.WhenLeading(func() error { return errors.New("not synced") })
+	require.False(t, e.Leading())
+	require.NoError(t, failing(), "standby replicas should be ready")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	e.Run(ctx, func(ctx context.Context) {
+		require.True(t, e.Leading())
+		require.Error(t, failing())
+		lease, err := client.CoordinationV1().Leases("olm").Get(ctx, "lock", metav1.GetOptions{})
+		require.NoError(t, err)
+		require.NotEmpty(t, lease.Spec.HolderIdentity)
+		cancel()
+	})
+	require.False(t, e.Leading())
+}
+
+func TestElectorDisabled(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	e, err := NewElector(Config{}, "", fake.NewSimpleClientset(), logger)
+	require.NoError(t, err)
+	require.True(t, e.Leading())
+	require.Error(t, e.WhenLeading(func() error { return errors.New("not synced") })())
+
+	var ran bool
+	e.Run(context.Background(), func(ctx context.Context) { ran = true })
+	require.True(t, ran)
+}
@@ -0,0 +1,322 @@
+// Package migration converts the Subscriptions of a cluster into the ClusterExtensions and ClusterCatalogs of OLMv1
+// (operator-controller), reporting the features in use that OLMv1 can't express, so that operators can be migrated
+// one at a time.
+package migration
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	operatorsv1 "github.com/operator-framework/api/pkg/operators/v1"
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/api/client/clientset/versioned"
+)
+
+// Severity is the severity of a migration finding.
+type Severity string
+
+const (
+	// SeverityBlocking findings prevent a Subscription from being migrated. No ClusterExtension is produced for it.
+	SeverityBlocking Severity = "Blocking"
+	// SeverityWarning findings are features that behave differently, or need manual steps, under OLMv1.
+	SeverityWarning Severity = "Warning"
+)
+
+// Finding is a feature in use by a Subscription that doesn't map onto OLMv1 as is.
+type Finding struct {
+	// Subscription is the namespace/name of the Subscription.
+	Subscription string   `json:"subscription"`
+	Severity     Severity `json:"severity"`
+	Message      string   `json:"message"`
+}
+
+// Input is the OLM state to migrate.
+type Input struct {
+	Subscriptions          []v1alpha1.Subscription
+	OperatorGroups         []operatorsv1.OperatorGroup
+	ClusterServiceVersions []v1alpha1.ClusterServiceVersion
+	CatalogSources         []v1alpha1.CatalogSource
+}
+
+// Result is the outcome of a migration.
+type Result struct {
+	ClusterExtensions []ClusterExtension `json:"clusterExtensions,omitempty"`
+	ClusterCatalogs   []ClusterCatalog   `json:"clusterCatalogs,omitempty"`
+	Findings          []Finding          `json:"findings,omitempty"`
+}
+
+// Blocked returns true if the findings block the migration of the given Subscription.
+func (r *Result) Blocked(subscription string) bool {
+	for _, f := range r.Findings {
+		if f.Subscription == subscription && f.Severity == SeverityBlocking {
+			return true
+		}
+	}
+	return false
+}
+
+// Gather lists the OLM state to migrate from the cluster, restricted to the given namespace unless it's
+// metav1.NamespaceAll. CatalogSources are always listed in all namespaces, since Subscriptions can reference the
+// global catalog namespace.
+func Gather(ctx context.Context, client versioned.Interface, namespace string) (*Input, error) {
+	in := &Input{}
+
+	subs, err := client.OperatorsV1alpha1().Subscriptions(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error listing subscriptions: %w", err)
+	}
+	in.Subscriptions = subs.Items
+
+	ogs, err := client.OperatorsV1().OperatorGroups(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error listing operatorgroups: %w", err)
+	}
+	in.OperatorGroups = ogs.Items
+
+	csvs, err := client.OperatorsV1alpha1().ClusterServiceVersions(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error listing clusterserviceversions: %w", err)
+	}
+	in.ClusterServiceVersions = csvs.Items
+
+	catsrcs, err := client.OperatorsV1alpha1().CatalogSources(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error listing catalogsources: %w", err)
+	}
+	in.CatalogSources = catsrcs.Items
+
+	return in, nil
+}
+
+// Migrate converts each Subscription of the given input into a ClusterExtension installing the same package from the
+// same catalog, and each image-based CatalogSource referenced into a ClusterCatalog. Subscriptions with blocking
+// findings are left out of the result, so that the rest can be migrated in the meantime.
+func Migrate(in *Input) *Result {
+	m := &migrator{
+		in:              in,
+		result:          &Result{},
+		extensionNames:  uniqueNames(subscriptionKeys(in.Subscriptions)),
+		catalogNames:    uniqueNames(catalogSourceKeys(in.CatalogSources)),
+		migratedCatalog: map[string]bool{},
+	}
+
+	subs := append([]v1alpha1.Subscription(nil), in.Subscriptions...)
+	sort.Slice(subs, func(i, j int) bool { return key(&subs[i]) < key(&subs[j]) })
+	for i := range subs {
+		m.migrate(&subs[i])
+	}
+	return m.result
+}
+
+type migrator struct {
+	in              *Input
+	result          *Result
+	extensionNames  map[string]string
+	catalogNames    map[string]string
+	migratedCatalog map[string]bool
+}
+
+func (m *migrator) report(sub *v1alpha1.Subscription, severity Severity, format string, args ...interface{}) {
+	m.result.Findings = append(m.result.Findings, Finding{
+		Subscription: key(sub),
+		Severity:     severity,
+		Message:      fmt.Sprintf(format, args...),
+	})
+}
+
+func (m *migrator) migrate(sub *v1alpha1.Subscription) {
+	if sub.Spec == nil {
+		m.report(sub, SeverityBlocking, "subscription has no spec")
+		return
+	}
+
+	serviceAccount := m.checkOperatorGroup(sub)
+	csv := m.checkClusterServiceVersion(sub)
+	catalog := m.checkCatalogSource(sub)
+
+	if sub.Spec.Config != nil && !equality.Semantic.DeepEqual(sub.Spec.Config, &v1alpha1.SubscriptionConfig{}) {
+		m.report(sub, SeverityWarning, "subscription config (env, resources, tolerations, ...) has no OLMv1 equivalent and is dropped")
+	}
+	if m.result.Blocked(key(sub)) {
+		return
+	}
+
+	name := m.extensionNames[key(sub)]
+	if serviceAccount == "" {
+		serviceAccount = name + "-installer"
+		m.report(sub, SeverityWarning, "OLMv1 installs packages with a service account of the cluster administrator's choosing: create service account %s/%s with the permissions to manage the package's resources", sub.GetNamespace(), serviceAccount)
+	}
+
+	filter := &CatalogFilter{
+		PackageName: sub.Spec.Package,
+		Selector:    &metav1.LabelSelector{MatchLabels: map[string]string{CatalogNameLabelKey: m.catalogNames[catalog]}},
+	}
+	if sub.Spec.Channel != "" {
+		filter.Channels = []string{sub.Spec.Channel}
+	}
+	version := csv.Spec.Version.String()
+	if sub.Spec.InstallPlanApproval == v1alpha1.ApprovalManual {
+		// OLMv1 has no approval step, so pin the installed version until upgrades are made by hand
+		filter.Version = version
+		m.report(sub, SeverityWarning, "OLMv1 has no manual approval of upgrades: the extension is pinned to version %s, change spec.source.catalog.version to upgrade", version)
+	} else {
+		filter.Version = ">=" + version
+	}
+
+	m.result.ClusterExtensions = append(m.result.ClusterExtensions, ClusterExtension{
+		TypeMeta:   metav1.TypeMeta{APIVersion: GroupVersion, Kind: ClusterExtensionKind},
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: ClusterExtensionSpec{
+			Namespace:      sub.GetNamespace(),
+			ServiceAccount: ServiceAccountReference{Name: serviceAccount},
+			Source:         SourceConfig{SourceType: SourceTypeCatalog, Catalog: filter},
+		},
+	})
+	m.addClusterCatalog(catalog)
+}
+
+// checkOperatorGroup reports the OperatorGroup features of the Subscription's namespace OLMv1 can't express, and
+// returns the service account of the group, if any.
+func (m *migrator) checkOperatorGroup(sub *v1alpha1.Subscription) string {
+	var groups []*operatorsv1.OperatorGroup
+	for i := range m.in.OperatorGroups {
+		if m.in.OperatorGroups[i].GetNamespace() == sub.GetNamespace() {
+			groups = append(groups, &m.in.OperatorGroups[i])
+		}
+	}
+	if len(groups) != 1 {
+		m.report(sub, SeverityBlocking, "namespace %s has %d operatorgroups, expected exactly one", sub.GetNamespace(), len(groups))
+		return ""
+	}
+
+	og := groups[0]
+	targets := og.Status.Namespaces
+	switch {
+	case len(targets) == 1 && targets[0] == corev1.NamespaceAll:
+		// AllNamespaces is the install mode of OLMv1
+	case len(targets) == 1 && targets[0] == og.GetNamespace():
+		m.report(sub, SeverityWarning, "operatorgroup %s targets its own namespace: OLMv1 only supports watching the install namespace behind a feature gate, and installs for all namespaces otherwise", og.GetName())
+	default:
+		m.report(sub, SeverityBlocking, "operatorgroup %s targets namespaces %v: OLMv1 has no equivalent of targeting a subset of namespaces", og.GetName(), targets)
+	}
+	return og.Spec.ServiceAccountName
+}
+
+// checkClusterServiceVersion reports the features of the Subscription's installed CSV OLMv1 can't express, and
+// returns the CSV.
+func (m *migrator) checkClusterServiceVersion(sub *v1alpha1.Subscription) *v1alpha1.ClusterServiceVersion {
+	var csv *v1alpha1.ClusterServiceVersion
+	for i := range m.in.ClusterServiceVersions {
+		c := &m.in.ClusterServiceVersions[i]
+		if c.GetNamespace() == sub.GetNamespace() && c.GetName() == sub.Status.InstalledCSV {
+			csv = c
+		}
+	}
+	if csv == nil {
+		m.report(sub, SeverityBlocking, "subscription has no installed csv, migrate it once installed")
+		return nil
+	}
+
+	if len(csv.Spec.APIServiceDefinitions.Owned) > 0 {
+		m.report(sub, SeverityBlocking, "csv %s provides aggregated apiservices, which OLMv1 doesn't support", csv.GetName())
+	}
+	if len(csv.Spec.WebhookDefinitions) > 0 {
+		m.report(sub, SeverityWarning, "csv %s defines webhooks, which OLMv1 only supports behind a feature gate, with cert-manager or the openshift service-ca providing their certificates", csv.GetName())
+	}
+	if len(csv.Spec.CustomResourceDefinitions.Required) > 0 || len(csv.Spec.APIServiceDefinitions.Required) > 0 {
+		m.report(sub, SeverityWarning, "csv %s requires apis provided by other operators: OLMv1 doesn't resolve dependencies, so they must be installed first", csv.GetName())
+	}
+	return csv
+}
+
+// checkCatalogSource reports CatalogSources that can't be served as a ClusterCatalog, and returns the key of the
+// Subscription's CatalogSource.
+func (m *migrator) checkCatalogSource(sub *v1alpha1.Subscription) string {
+	catalogKey := sub.Spec.CatalogSourceNamespace + "/" + sub.Spec.CatalogSource
+	catsrc := m.catalogSource(catalogKey)
+	if catsrc == nil {
+		m.report(sub, SeverityBlocking, "catalogsource %s not found", catalogKey)
+		return catalogKey
+	}
+	if catsrc.Spec.Image == "" {
+		m.report(sub, SeverityBlocking, "catalogsource %s has no image: OLMv1 only serves file-based catalog images, build one from the catalog's content", catalogKey)
+	}
+	return catalogKey
+}
+
+func (m *migrator) catalogSource(catalogKey string) *v1alpha1.CatalogSource {
+	for i := range m.in.CatalogSources {
+		if key(&m.in.CatalogSources[i]) == catalogKey {
+			return &m.in.CatalogSources[i]
+		}
+	}
+	return nil
+}
+
+// addClusterCatalog adds a ClusterCatalog serving the image of the given CatalogSource to the result, once.
+func (m *migrator) addClusterCatalog(catalogKey string) {
+	if m.migratedCatalog[catalogKey] {
+		return
+	}
+	m.migratedCatalog[catalogKey] = true
+
+	catsrc := m.catalogSource(catalogKey)
+	image := &ImageSource{Ref: catsrc.Spec.Image}
+	if catsrc.Spec.UpdateStrategy != nil && catsrc.Spec.UpdateStrategy.RegistryPoll != nil && catsrc.Spec.UpdateStrategy.Interval != nil {
+		// ClusterCatalogs poll at a granularity of minutes
+		minutes := int(math.Ceil(catsrc.Spec.UpdateStrategy.Interval.Minutes()))
+		image.PollIntervalMinutes = &minutes
+	}
+	m.result.ClusterCatalogs = append(m.result.ClusterCatalogs, ClusterCatalog{
+		TypeMeta:   metav1.TypeMeta{APIVersion: GroupVersion, Kind: ClusterCatalogKind},
+		ObjectMeta: metav1.ObjectMeta{Name: m.catalogNames[catalogKey]},
+		Spec: ClusterCatalogSpec{
+			Source:   CatalogSource{Type: SourceTypeImage, Image: image},
+			Priority: int32(catsrc.Spec.Priority),
+		},
+	})
+}
+
+// uniqueNames maps the given namespace/name keys to cluster-scoped names: the name alone when it's unique across
+// namespaces, and namespace-name otherwise.
+func uniqueNames(keys [][2]string) map[string]string {
+	count := map[string]int{}
+	for _, k := range keys {
+		count[k[1]]++
+	}
+	names := map[string]string{}
+	for _, k := range keys {
+		if count[k[1]] > 1 {
+			names[k[0]+"/"+k[1]] = k[0] + "-" + k[1]
+		} else {
+			names[k[0]+"/"+k[1]] = k[1]
+		}
+	}
+	return names
+}
+
+func subscriptionKeys(subs []v1alpha1.Subscription) [][2]string {
+	keys := make([][2]string, 0, len(subs))
+	for _, sub := range subs {
+		keys = append(keys, [2]string{sub.GetNamespace(), sub.GetName()})
+	}
+	return keys
+}
+
+func catalogSourceKeys(catsrcs []v1alpha1.CatalogSource) [][2]string {
+	keys := make([][2]string, 0, len(catsrcs))
+	for _, catsrc := range catsrcs {
+		keys = append(keys, [2]string{catsrc.GetNamespace(), catsrc.GetName()})
+	}
+	return keys
+}
+
+func key(obj metav1.Object) string {
+	return obj.GetNamespace() + "/" + obj.GetName()
+}
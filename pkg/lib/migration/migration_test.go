@@ -0,0 +1,131 @@
+package migration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/blang/semver/v4"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/operator-framework/api/pkg/lib/version"
+	operatorsv1 "github.com/operator-framework/api/pkg/operators/v1"
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+)
+
+func TestMigrate(t *testing.T) {
+	og := func(namespace string, targets ...string) operatorsv1.OperatorGroup {
+		return operatorsv1.OperatorGroup{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "og"},
+			Status:     operatorsv1.OperatorGroupStatus{Namespaces: targets},
+		}
+	}
+	sub := func(namespace, name string, approval v1alpha1.Approval) v1alpha1.Subscription {
+		return v1alpha1.Subscription{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+			Spec: &v1alpha1.SubscriptionSpec{
+				CatalogSource:          "operatorhubio",
+				CatalogSourceNamespace: "olm",
+				Package:                name,
+				Channel:                "stable",
+				InstallPlanApproval:    approval,
+			},
+			Status: v1alpha1.SubscriptionStatus{InstalledCSV: name + ".v1.2.3"},
+		}
+	}
+	csv := func(namespace, name string) v1alpha1.ClusterServiceVersion {
+		return v1alpha1.ClusterServiceVersion{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name + ".v1.2.3"},
+			Spec:       v1alpha1.ClusterServiceVersionSpec{Version: version.OperatorVersion{Version: semver.MustParse("1.2.3")}},
+		}
+	}
+
+	webhooks := csv("own", "webhooks")
+	webhooks.Spec.WebhookDefinitions = []v1alpha1.WebhookDescription{{GenerateName: "webhook"}}
+	apiservice := csv("all", "apiservice")
+	apiservice.Spec.APIServiceDefinitions.Owned = []v1alpha1.APIServiceDescription{{Name: "foos", Group: "example.com", Version: "v1"}}
+
+	in := &Input{
+		OperatorGroups: []operatorsv1.OperatorGroup{
+			og("all", corev1.NamespaceAll),
+			og("own", "own"),
+			og("multi", "a", "b"),
+		},
+		Subscriptions: []v1alpha1.Subscription{
+			sub("all", "etcd", v1alpha1.ApprovalAutomatic),
+			sub("all", "apiservice", v1alpha1.ApprovalAutomatic),
+			sub("own", "webhooks", v1alpha1.ApprovalManual),
+			sub("multi", "etcd", v1alpha1.ApprovalAutomatic),
+			sub("none", "pending", v1alpha1.ApprovalAutomatic),
+		},
+		ClusterServiceVersions: []v1alpha1.ClusterServiceVersion{
+			csv("all", "etcd"),
+			apiservice,
+			webhooks,
+			csv("multi", "etcd"),
+		},
+		CatalogSources: []v1alpha1.CatalogSource{{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "olm", Name: "operatorhubio"},
+			Spec: v1alpha1.CatalogSourceSpec{
+				Image:          "quay.io/operatorhubio/catalog:latest",
+				Priority:       -100,
+				UpdateStrategy: &v1alpha1.UpdateStrategy{RegistryPoll: &v1alpha1.RegistryPoll{Interval: &metav1.Duration{Duration: 45 * time.Minute}}},
+			},
+		}},
+	}
+
+	result := Migrate(in)
+
+	selector := &metav1.LabelSelector{MatchLabels: map[string]string{CatalogNameLabelKey: "operatorhubio"}}
+	require.Equal(t, []ClusterExtension{
+		{
+			TypeMeta:   metav1.TypeMeta{APIVersion: GroupVersion, Kind: ClusterExtensionKind},
+			ObjectMeta: metav1.ObjectMeta{Name: "all-etcd"},
+			Spec: ClusterExtensionSpec{
+				Namespace:      "all",
+				ServiceAccount: ServiceAccountReference{Name: "all-etcd-installer"},
+				Source: SourceConfig{SourceType: SourceTypeCatalog, Catalog: &CatalogFilter{
+					PackageName: "etcd", Version: ">=1.2.3", Channels: []string{"stable"}, Selector: selector,
+				}},
+			},
+		},
+		{
+			TypeMeta:   metav1.TypeMeta{APIVersion: GroupVersion, Kind: ClusterExtensionKind},
+			ObjectMeta: metav1.ObjectMeta{Name: "webhooks"},
+			Spec: ClusterExtensionSpec{
+				Namespace:      "own",
+				ServiceAccount: ServiceAccountReference{Name: "webhooks-installer"},
+				Source: SourceConfig{SourceType: SourceTypeCatalog, Catalog: &CatalogFilter{
+					PackageName: "webhooks", Version: "1.2.3", Channels: []string{"stable"}, Selector: selector,
+				}},
+			},
+		},
+	}, result.ClusterExtensions)
+
+	pollInterval := 45
+	require.Equal(t, []ClusterCatalog{{
+		TypeMeta:   metav1.TypeMeta{APIVersion: GroupVersion, Kind: ClusterCatalogKind},
+		ObjectMeta: metav1.ObjectMeta{Name: "operatorhubio"},
+		Spec: ClusterCatalogSpec{
+			Source:   CatalogSource{Type: SourceTypeImage, Image: &ImageSource{Ref: "quay.io/operatorhubio/catalog:latest", PollIntervalMinutes: &pollInterval}},
+			Priority: -100,
+		},
+	}}, result.ClusterCatalogs)
+
+	require.True(t, result.Blocked("all/apiservice"))
+	require.True(t, result.Blocked("multi/etcd"))
+	require.True(t, result.Blocked("none/pending"))
+	require.False(t, result.Blocked("all/etcd"))
+	require.False(t, result.Blocked("own/webhooks"))
+
+	warnings := 0
+	for _, f := range result.Findings {
+		if f.Subscription == "own/webhooks" {
+			require.Equal(t, SeverityWarning, f.Severity)
+			warnings++
+		}
+	}
+	// Own namespace targeting, webhooks, manual approval and the missing service account
+	require.Equal(t, 4, warnings)
+}
@@ -0,0 +1,78 @@
+package migration
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// The types below mirror the parts of the olm.operatorframework.io/v1 API of operator-controller used by the
+// migration. They're declared here, rather than imported, so that OLM doesn't depend on operator-controller.
+
+const (
+	// GroupVersion is the API version of the OLMv1 resources produced by a migration.
+	GroupVersion = "olm.operatorframework.io/v1"
+
+	ClusterExtensionKind = "ClusterExtension"
+	ClusterCatalogKind   = "ClusterCatalog"
+
+	// CatalogNameLabelKey is the label operator-controller sets on every ClusterCatalog to its name, which
+	// ClusterExtensions select catalogs by.
+	CatalogNameLabelKey = "olm.operatorframework.io/metadata.name"
+
+	SourceTypeCatalog = "Catalog"
+	SourceTypeImage   = "Image"
+)
+
+// ClusterExtension installs a package from a ClusterCatalog.
+type ClusterExtension struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ClusterExtensionSpec `json:"spec"`
+}
+
+type ClusterExtensionSpec struct {
+	// Namespace is the namespace the package's namespaced resources are installed into.
+	Namespace string `json:"namespace"`
+	// ServiceAccount is the service account, in Namespace, the package is installed with.
+	ServiceAccount ServiceAccountReference `json:"serviceAccount"`
+	Source         SourceConfig            `json:"source"`
+}
+
+type ServiceAccountReference struct {
+	Name string `json:"name"`
+}
+
+type SourceConfig struct {
+	SourceType string         `json:"sourceType"`
+	Catalog    *CatalogFilter `json:"catalog,omitempty"`
+}
+
+type CatalogFilter struct {
+	PackageName string                `json:"packageName"`
+	Version     string                `json:"version,omitempty"`
+	Channels    []string              `json:"channels,omitempty"`
+	Selector    *metav1.LabelSelector `json:"selector,omitempty"`
+}
+
+// ClusterCatalog makes the content of a file-based catalog image available to the cluster.
+type ClusterCatalog struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ClusterCatalogSpec `json:"spec"`
+}
+
+type ClusterCatalogSpec struct {
+	Source   CatalogSource `json:"source"`
+	Priority int32         `json:"priority,omitempty"`
+}
+
+type CatalogSource struct {
+	Type  string       `json:"type"`
+	Image *ImageSource `json:"image,omitempty"`
+}
+
+type ImageSource struct {
+	Ref                 string `json:"ref"`
+	PollIntervalMinutes *int   `json:"pollIntervalMinutes,omitempty"`
+}
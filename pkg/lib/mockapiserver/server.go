@@ -0,0 +1,272 @@
+// Package mockapiserver implements a mock extension apiserver: an HTTPS server serving the discovery documents of
+// configurable API group versions, and empty lists of their resources, so that APIServices backed by it become
+// available. It allows testing how OLM installs and upgrades operators providing aggregated APIs without running a
+// real extension apiserver.
+package mockapiserver
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// DefaultPort is the port the mock is served on by default.
+	DefaultPort = 5443
+
+	// DefaultCertDir is the directory OLM mounts the serving certificate of the deployments of APIServices to, as
+	// apiserver.crt and apiserver.key.
+	DefaultCertDir = "/apiserver.local.config/certificates"
+)
+
+// GroupVersion is an API group version served by the mock, along with the kinds of its resources.
+type GroupVersion struct {
+	Group   string
+	Version string
+	Kinds   []string
+}
+
+func (gv GroupVersion) String() string {
+	return fmt.Sprintf("%s/%s=%s", gv.Group, gv.Version, strings.Join(gv.Kinds, ","))
+}
+
+// ParseGroupVersion parses a group version of the form <group>/<version>=<kind>[,<kind>...].
+func ParseGroupVersion(s string) (GroupVersion, error) {
+	split := strings.SplitN(s, "=", 2)
+	if len(split) != 2 {
+		return GroupVersion{}, fmt.Errorf("invalid group version %q: expected <group>/<version>=<kind>[,<kind>...]", s)
+	}
+	group, version := path.Split(split[0])
+	gv := GroupVersion{Group: strings.TrimSuffix(group, "/"), Version: version}
+	for _, kind := range strings.Split(split[1], ",") {
+		if kind = strings.TrimSpace(kind); kind != "" {
+			gv.Kinds = append(gv.Kinds, kind)
+		}
+	}
+	if gv.Group == "" || gv.Version == "" || len(gv.Kinds) == 0 {
+		return GroupVersion{}, fmt.Errorf("invalid group version %q: expected <group>/<version>=<kind>[,<kind>...]", s)
+	}
+	return gv, nil
+}
+
+// ResourceName returns the name of the resource of the given kind served by the mock.
+func ResourceName(kind string) string {
+	return strings.ToLower(kind) + "s"
+}
+
+// Config configures a mock extension apiserver.
+type Config struct {
+	// GroupVersions are the API group versions served.
+	GroupVersions []GroupVersion
+	// Port is the port the mock is served on. DefaultPort is used if zero.
+	Port int
+	// CertFile and KeyFile are the serving certificate and key of the mock. The certificate OLM generates for
+	// APIServices is used if empty.
+	CertFile, KeyFile string
+}
+
+// Run serves the mock extension apiserver described by the given configuration until the given context is done.
+func Run(ctx context.Context, config Config, logger logrus.FieldLogger) error {
+	handler, err := NewHandler(config.GroupVersions)
+	if err != nil {
+		return err
+	}
+
+	port := config.Port
+	if port == 0 {
+		port = DefaultPort
+	}
+	certFile, keyFile := config.CertFile, config.KeyFile
+	if certFile == "" {
+		certFile = path.Join(DefaultCertDir, "apiserver.crt")
+	}
+	if keyFile == "" {
+		keyFile = path.Join(DefaultCertDir, "apiserver.key")
+	}
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return fmt.Errorf("error loading serving certificate: %v", err)
+	}
+
+	server := &http.Server{
+		Addr: fmt.Sprintf(":%d", port),
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			logger.WithField("method", r.Method).WithField("path", r.URL.Path).Debug("serving request")
+			handler.ServeHTTP(w, r)
+		}),
+		TLSConfig: &tls.Config{
+			Certificates: []tls.Certificate{cert},
+			MinVersion:   tls.VersionTLS12,
+		},
+	}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx)
+	}()
+
+	logger.WithField("port", port).Info("serving mock extension apiserver")
+	if err := server.ListenAndServeTLS("", ""); err != nil && err != http.ErrServerClosed {
+		return err
+	}
+	return nil
+}
+
+// NewHandler returns a handler serving the given API group versions. Its responses only depend on the group versions,
+// so that mocks serving the same group versions are indistinguishable.
+func NewHandler(groupVersions []GroupVersion) (http.Handler, error) {
+	h := &handler{
+		groups:    map[string]*metav1.APIGroup{},
+		resources: map[string]*metav1.APIResourceList{},
+	}
+	for _, gv := range groupVersions {
+		if gv.Group == "" || gv.Version == "" || len(gv.Kinds) == 0 {
+			return nil, fmt.Errorf("invalid group version %s: group, version and kinds are required", gv)
+		}
+		groupVersion := gv.Group + "/" + gv.Version
+		if _, ok := h.resources[groupVersion]; ok {
+			return nil, fmt.Errorf("group version %s is configured more than once", groupVersion)
+		}
+
+		group, ok := h.groups[gv.Group]
+		if !ok {
+			// The first version of a group is its preferred version
+			group = &metav1.APIGroup{
+				TypeMeta:         metav1.TypeMeta{Kind: "APIGroup", APIVersion: "v1"},
+				Name:             gv.Group,
+				PreferredVersion: metav1.GroupVersionForDiscovery{GroupVersion: groupVersion, Version: gv.Version},
+			}
+			h.groups[gv.Group] = group
+		}
+		group.Versions = append(group.Versions, metav1.GroupVersionForDiscovery{GroupVersion: groupVersion, Version: gv.Version})
+
+		resources := &metav1.APIResourceList{
+			TypeMeta:     metav1.TypeMeta{Kind: "APIResourceList", APIVersion: "v1"},
+			GroupVersion: groupVersion,
+		}
+		for _, kind := range gv.Kinds {
+			resources.APIResources = append(resources.APIResources, metav1.APIResource{
+				Name:         ResourceName(kind),
+				SingularName: strings.ToLower(kind),
+				Namespaced:   true,
+				Kind:         kind,
+				Verbs:        metav1.Verbs{"get", "list", "watch"},
+			})
+		}
+		sort.Slice(resources.APIResources, func(i, j int) bool {
+			return resources.APIResources[i].Name < resources.APIResources[j].Name
+		})
+		h.resources[groupVersion] = resources
+	}
+	return h, nil
+}
+
+type handler struct {
+	groups map[string]*metav1.APIGroup
+	// resources holds the resources of each group version
+	resources map[string]*metav1.APIResourceList
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		writeStatus(w, http.StatusMethodNotAllowed, metav1.StatusReasonMethodNotAllowed, fmt.Sprintf("%s is not supported", r.Method))
+		return
+	}
+
+	p := strings.Trim(r.URL.Path, "/")
+	switch p {
+	case "healthz", "livez", "readyz":
+		_, _ = w.Write([]byte("ok"))
+		return
+	case "openapi/v2":
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"swagger": "2.0",
+			"info":    map[string]string{"title": "mock-extension-apiserver", "version": "v0"},
+			"paths":   map[string]interface{}{},
+		})
+		return
+	case "apis":
+		list := &metav1.APIGroupList{TypeMeta: metav1.TypeMeta{Kind: "APIGroupList", APIVersion: "v1"}, Groups: []metav1.APIGroup{}}
+		for _, group := range h.groups {
+			list.Groups = append(list.Groups, *group)
+		}
+		sort.Slice(list.Groups, func(i, j int) bool { return list.Groups[i].Name < list.Groups[j].Name })
+		writeJSON(w, http.StatusOK, list)
+		return
+	}
+
+	segments := strings.Split(p, "/")
+	if segments[0] != "apis" {
+		writeStatus(w, http.StatusNotFound, metav1.StatusReasonNotFound, "the server could not find the requested resource")
+		return
+	}
+	if len(segments) == 2 {
+		group, ok := h.groups[segments[1]]
+		if !ok {
+			writeStatus(w, http.StatusNotFound, metav1.StatusReasonNotFound, fmt.Sprintf("group %s not found", segments[1]))
+			return
+		}
+		writeJSON(w, http.StatusOK, group)
+		return
+	}
+
+	groupVersion := segments[1] + "/" + segments[2]
+	resources, ok := h.resources[groupVersion]
+	if !ok {
+		writeStatus(w, http.StatusNotFound, metav1.StatusReasonNotFound, fmt.Sprintf("group version %s not found", groupVersion))
+		return
+	}
+	segments = segments[3:]
+	if len(segments) == 0 {
+		writeJSON(w, http.StatusOK, resources)
+		return
+	}
+
+	// Resources are either listed across namespaces, or in a namespace
+	if segments[0] == "namespaces" && len(segments) >= 3 {
+		segments = segments[2:]
+	}
+	for _, resource := range resources.APIResources {
+		if resource.Name != segments[0] {
+			continue
+		}
+		if len(segments) > 1 {
+			writeStatus(w, http.StatusNotFound, metav1.StatusReasonNotFound, fmt.Sprintf("%s %q not found", resource.Name, segments[1]))
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"kind":       resource.Kind + "List",
+			"apiVersion": groupVersion,
+			"metadata":   map[string]interface{}{},
+			"items":      []interface{}{},
+		})
+		return
+	}
+	writeStatus(w, http.StatusNotFound, metav1.StatusReasonNotFound, fmt.Sprintf("resource %s not found in %s", segments[0], groupVersion))
+}
+
+func writeJSON(w http.ResponseWriter, code int, obj interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(obj)
+}
+
+func writeStatus(w http.ResponseWriter, code int, reason metav1.StatusReason, message string) {
+	writeJSON(w, code, &metav1.Status{
+		TypeMeta: metav1.TypeMeta{Kind: "Status", APIVersion: "v1"},
+		Status:   metav1.StatusFailure,
+		Message:  message,
+		Reason:   reason,
+		Code:     int32(code),
+	})
+}
@@ -0,0 +1,102 @@
+package mockapiserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestParseGroupVersion(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    GroupVersion
+		wantErr bool
+	}{
+		{in: "example.com/v1=Fez", want: GroupVersion{Group: "example.com", Version: "v1", Kinds: []string{"Fez"}}},
+		{in: "example.com/v1alpha1=Fez, Fedora", want: GroupVersion{Group: "example.com", Version: "v1alpha1", Kinds: []string{"Fez", "Fedora"}}},
+		{in: "example.com/v1", wantErr: true},
+		{in: "v1=Fez", wantErr: true},
+		{in: "example.com/v1=", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			gv, err := ParseGroupVersion(tt.in)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, gv)
+		})
+	}
+}
+
+func TestHandler(t *testing.T) {
+	handler, err := NewHandler([]GroupVersion{
+		{Group: "example.com", Version: "v1", Kinds: []string{"Fez", "Fedora"}},
+		{Group: "example.com", Version: "v1alpha1", Kinds: []string{"Fez"}},
+		{Group: "another.example.com", Version: "v1", Kinds: []string{"Beret"}},
+	})
+	require.NoError(t, err)
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	get := func(path string, obj interface{}) int {
+		resp, err := server.Client().Get(server.URL + path)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		if obj != nil {
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(obj))
+		}
+		return resp.StatusCode
+	}
+
+	var groups metav1.APIGroupList
+	require.Equal(t, http.StatusOK, get("/apis", &groups))
+	require.Len(t, groups.Groups, 2)
+	require.Equal(t, "another.example.com", groups.Groups[0].Name)
+	require.Equal(t, "example.com", groups.Groups[1].Name)
+	require.Equal(t, "example.com/v1", groups.Groups[1].PreferredVersion.GroupVersion)
+	require.Len(t, groups.Groups[1].Versions, 2)
+
+	var group metav1.APIGroup
+	require.Equal(t, http.StatusOK, get("/apis/example.com", &group))
+	require.Equal(t, groups.Groups[1], group)
+
+	var resources metav1.APIResourceList
+	require.Equal(t, http.StatusOK, get("/apis/example.com/v1", &resources))
+	require.Equal(t, "example.com/v1", resources.GroupVersion)
+	require.Len(t, resources.APIResources, 2)
+	require.Equal(t, "fedoras", resources.APIResources[0].Name)
+	require.Equal(t, "fezs", resources.APIResources[1].Name)
+	require.Equal(t, "Fez", resources.APIResources[1].Kind)
+
+	var list map[string]interface{}
+	require.Equal(t, http.StatusOK, get("/apis/example.com/v1/namespaces/default/fezs", &list))
+	require.Equal(t, "FezList", list["kind"])
+	require.Equal(t, []interface{}{}, list["items"])
+	require.Equal(t, http.StatusOK, get("/apis/example.com/v1alpha1/fezs", nil))
+
+	var status metav1.Status
+	require.Equal(t, http.StatusNotFound, get("/apis/example.com/v1/namespaces/default/fezs/missing", &status))
+	require.Equal(t, metav1.StatusReasonNotFound, status.Reason)
+	require.Equal(t, http.StatusNotFound, get("/apis/example.com/v1alpha1/fedoras", nil))
+	require.Equal(t, http.StatusNotFound, get("/apis/example.com/v2", nil))
+	require.Equal(t, http.StatusNotFound, get("/apis/missing.example.com", nil))
+	require.Equal(t, http.StatusOK, get("/healthz", nil))
+}
+
+func TestNewHandlerInvalid(t *testing.T) {
+	_, err := NewHandler([]GroupVersion{{Group: "example.com", Version: "v1"}})
+	require.Error(t, err)
+
+	_, err = NewHandler([]GroupVersion{
+		{Group: "example.com", Version: "v1", Kinds: []string{"Fez"}},
+		{Group: "example.com", Version: "v1", Kinds: []string{"Fedora"}},
+	})
+	require.Error(t, err)
+}
@@ -0,0 +1,45 @@
+// Package names generates the names of the objects OLM derives from others, such as the RBAC of CSVs.
+package names
+
+import (
+	"fmt"
+	"hash"
+	"hash/fnv"
+	"strings"
+
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
+
+	hashutil "github.com/operator-framework/operator-lifecycle-manager/pkg/lib/kubernetes/pkg/util/hash"
+)
+
+// MaxLength is the maximum length of generated names, that of DNS labels, so that they can be used as label values
+// and in the names of other objects.
+const MaxLength = 63
+
+func encodedHash(hasher hash.Hash, o interface{}) string {
+	hashutil.DeepHashObject(hasher, o)
+	switch h := hasher.(type) {
+	case hash.Hash32:
+		return utilrand.SafeEncodeString(fmt.Sprint(h.Sum32()))
+	case hash.Hash64:
+		return utilrand.SafeEncodeString(fmt.Sprint(h.Sum64()))
+	}
+	return utilrand.SafeEncodeString(fmt.Sprintf("%x", hasher.Sum(nil)))
+}
+
+// Hashed returns the given base suffixed with a hash of the given object, which is stable for equal objects. Names
+// that would exceed MaxLength have their base truncated, and are suffixed with a longer hash of the whole base and
+// object instead, since their base no longer tells them apart.
+func Hashed(base string, o interface{}) string {
+	suffix := encodedHash(fnv.New32a(), o)
+	if len(base)+len(suffix) < MaxLength {
+		return fmt.Sprintf("%s-%s", base, suffix)
+	}
+
+	suffix = encodedHash(fnv.New64a(), []interface{}{base, o})
+	base = strings.TrimRight(base[:MaxLength-len(suffix)-1], "-.")
+	if base == "" {
+		return suffix
+	}
+	return fmt.Sprintf("%s-%s", base, suffix)
+}
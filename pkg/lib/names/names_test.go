@@ -0,0 +1,97 @@
+package names
+
+import (
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/quick"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+func TestHashed(t *testing.T) {
+	tests := []struct {
+		name string
+		base string
+		o    interface{}
+		want string
+	}{
+		{
+			name: "Short",
+			base: "myname",
+			o:    []string{"something"},
+			want: "myname-9c895f74f",
+		},
+		{
+			name: "Longest",
+			base: strings.Repeat("a", 52),
+			o:    []string{"something"},
+			want: strings.Repeat("a", 52) + "-9c895f74f",
+		},
+		{
+			name: "Truncated",
+			base: strings.Repeat("name", 100),
+			o:    []string{"something", "else"},
+			want: "namenamenamenamenamenamenamenamenamenamena-5b747bc987f58c4694d4",
+		},
+		{
+			name: "TruncatedOnSeparators",
+			base: strings.Repeat("a", 40) + "-.-" + strings.Repeat("b", 40),
+			o:    []string{"something"},
+			want: strings.Repeat("a", 40) + "-565d849d977bdc5f49bf",
+		},
+		{
+			name: "OnlySeparators",
+			base: strings.Repeat("-", 100),
+			o:    []string{"something"},
+			want: "766cb6fbbd964d8856c",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Hashed(tt.base, tt.o)
+			require.Equal(t, tt.want, got)
+			require.LessOrEqual(t, len(got), MaxLength)
+		})
+	}
+}
+
+var runeSet = []rune("abcdefghijklmnopqrstuvwxyz0123456789-.")
+
+// longName generates names of up to four times MaxLength, made of the characters of DNS subdomains.
+type longName string
+
+func (longName) Generate(rand *rand.Rand, size int) reflect.Value {
+	b := make([]rune, 1+rand.Intn(4*MaxLength))
+	b[0] = runeSet[rand.Intn(36)]
+	for i := 1; i < len(b); i++ {
+		b[i] = runeSet[rand.Intn(len(runeSet))]
+	}
+	return reflect.ValueOf(longName(b))
+}
+
+func TestHashedFuzz(t *testing.T) {
+	config := &quick.Config{MaxCount: 10000}
+
+	// Names fit in MaxLength and are valid DNS subdomains when their base is
+	require.NoError(t, quick.Check(func(base longName, o string) bool {
+		name := Hashed(string(base), o)
+		if len(name) > MaxLength {
+			return false
+		}
+		return len(validation.IsDNS1123Subdomain(string(base))) > 0 || len(validation.IsDNS1123Subdomain(name)) == 0
+	}, config))
+
+	// Names are stable
+	require.NoError(t, quick.Check(func(base longName, o string) bool {
+		return Hashed(string(base), o) == Hashed(string(base), o)
+	}, config))
+
+	// Bases that only differ past the truncation don't collide
+	require.NoError(t, quick.Check(func(base, a, b longName) bool {
+		long := strings.Repeat("x", MaxLength) + string(base)
+		return a == b || Hashed(long+string(a), nil) != Hashed(long+string(b), nil)
+	}, config))
+}
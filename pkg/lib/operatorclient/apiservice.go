@@ -11,24 +11,24 @@ import (
 )
 
 // CreateAPIService creates the APIService.
-func (c *Client) CreateAPIService(ig *apiregistrationv1.APIService) (*apiregistrationv1.APIService, error) {
-	return c.ApiregistrationV1Interface().ApiregistrationV1().APIServices().Create(context.TODO(), ig, metav1.CreateOptions{})
+func (c *Client) CreateAPIService(ctx context.Context, ig *apiregistrationv1.APIService) (*apiregistrationv1.APIService, error) {
+	return c.ApiregistrationV1Interface().ApiregistrationV1().APIServices().Create(ctx, ig, metav1.CreateOptions{})
 }
 
 // GetAPIService returns the existing APIService.
-func (c *Client) GetAPIService(name string) (*apiregistrationv1.APIService, error) {
-	return c.ApiregistrationV1Interface().ApiregistrationV1().APIServices().Get(context.TODO(), name, metav1.GetOptions{})
+func (c *Client) GetAPIService(ctx context.Context, name string) (*apiregistrationv1.APIService, error) {
+	return c.ApiregistrationV1Interface().ApiregistrationV1().APIServices().Get(ctx, name, metav1.GetOptions{})
 }
 
 // DeleteAPIService deletes the APIService.
-func (c *Client) DeleteAPIService(name string, options *metav1.DeleteOptions) error {
-	return c.ApiregistrationV1Interface().ApiregistrationV1().APIServices().Delete(context.TODO(), name, *options)
+func (c *Client) DeleteAPIService(ctx context.Context, name string, options *metav1.DeleteOptions) error {
+	return c.ApiregistrationV1Interface().ApiregistrationV1().APIServices().Delete(ctx, name, *options)
 }
 
 // UpdateAPIService will update the given APIService resource.
-func (c *Client) UpdateAPIService(apiService *apiregistrationv1.APIService) (*apiregistrationv1.APIService, error) {
+func (c *Client) UpdateAPIService(ctx context.Context, apiService *apiregistrationv1.APIService) (*apiregistrationv1.APIService, error) {
 	klog.V(4).Infof("[UPDATE APIService]: %s", apiService.GetName())
-	oldAPIService, err := c.GetAPIService(apiService.GetName())
+	oldAPIService, err := c.GetAPIService(ctx, apiService.GetName())
 	if err != nil {
 		return nil, err
 	}
@@ -36,5 +36,5 @@ func (c *Client) UpdateAPIService(apiService *apiregistrationv1.APIService) (*ap
 	if err != nil {
 		return nil, fmt.Errorf("error creating patch for APIService: %v", err)
 	}
-	return c.ApiregistrationV1Interface().ApiregistrationV1().APIServices().Patch(context.TODO(), apiService.GetName(), types.StrategicMergePatchType, patchBytes, metav1.PatchOptions{})
+	return c.ApiregistrationV1Interface().ApiregistrationV1().APIServices().Patch(ctx, apiService.GetName(), types.StrategicMergePatchType, patchBytes, metav1.PatchOptions{})
 }
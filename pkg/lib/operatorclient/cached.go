@@ -0,0 +1,53 @@
+package operatorclient
+
+import (
+	"context"
+
+	appsv1 "k8s.io/api/apps/v1"
+	appsv1listers "k8s.io/client-go/listers/apps/v1"
+	"k8s.io/klog"
+	apiregistrationv1 "k8s.io/kube-aggregator/pkg/apis/apiregistration/v1"
+	apiregistrationv1listers "k8s.io/kube-aggregator/pkg/client/listers/apiregistration/v1"
+)
+
+// cachedClient is a ClientInterface that serves Deployment and APIService reads from informer caches.
+type cachedClient struct {
+	ClientInterface
+
+	deployments appsv1listers.DeploymentLister
+	apiServices apiregistrationv1listers.APIServiceLister
+}
+
+// NewCachedClient returns a ClientInterface that serves GetDeployment and GetAPIService from the given listers,
+// and delegates everything else to the given client. Reads fall back to the apiserver when a lister is nil or
+// can't serve the object, so objects that aren't (yet) in a cache are still found. Writes always go through the
+// given client, which reads the latest version of an object before patching it.
+func NewCachedClient(client ClientInterface, deployments appsv1listers.DeploymentLister, apiServices apiregistrationv1listers.APIServiceLister) ClientInterface {
+	return &cachedClient{
+		ClientInterface: client,
+		deployments:     deployments,
+		apiServices:     apiServices,
+	}
+}
+
+// GetDeployment returns the Deployment object for the given namespace and name.
+func (c *cachedClient) GetDeployment(ctx context.Context, namespace, name string) (*appsv1.Deployment, error) {
+	if c.deployments != nil {
+		if dep, err := c.deployments.Deployments(namespace).Get(name); err == nil {
+			return dep.DeepCopy(), nil
+		}
+	}
+	klog.V(4).Infof("[GET Deployment] cache miss: %s:%s", namespace, name)
+	return c.ClientInterface.GetDeployment(ctx, namespace, name)
+}
+
+// GetAPIService returns the existing APIService.
+func (c *cachedClient) GetAPIService(ctx context.Context, name string) (*apiregistrationv1.APIService, error) {
+	if c.apiServices != nil {
+		if apiService, err := c.apiServices.Get(name); err == nil {
+			return apiService.DeepCopy(), nil
+		}
+	}
+	klog.V(4).Infof("[GET APIService] cache miss: %s", name)
+	return c.ClientInterface.GetAPIService(ctx, name)
+}
@@ -0,0 +1,58 @@
+package operatorclient
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	appsv1listers "k8s.io/client-go/listers/apps/v1"
+	"k8s.io/client-go/tools/cache"
+	apiregistrationv1 "k8s.io/kube-aggregator/pkg/apis/apiregistration/v1"
+	apiregistrationfake "k8s.io/kube-aggregator/pkg/client/clientset_generated/clientset/fake"
+	apiregistrationv1listers "k8s.io/kube-aggregator/pkg/client/listers/apiregistration/v1"
+)
+
+func TestCachedClient(t *testing.T) {
+	cached := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "cached"}}
+	uncached := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "uncached"}}
+	cachedAPIService := &apiregistrationv1.APIService{ObjectMeta: metav1.ObjectMeta{Name: "v1.cached"}}
+	uncachedAPIService := &apiregistrationv1.APIService{ObjectMeta: metav1.ObjectMeta{Name: "v1.uncached"}}
+
+	k8sClient := fake.NewSimpleClientset(uncached)
+	regClient := apiregistrationfake.NewSimpleClientset(uncachedAPIService)
+	depIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	require.NoError(t, depIndexer.Add(cached))
+	apiServiceIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	require.NoError(t, apiServiceIndexer.Add(cachedAPIService))
+
+	client := NewCachedClient(NewClient(k8sClient, nil, regClient), appsv1listers.NewDeploymentLister(depIndexer), apiregistrationv1listers.NewAPIServiceLister(apiServiceIndexer))
+
+	// Cached objects are served without reaching the apiserver
+	dep, err := client.GetDeployment(context.TODO(), "ns", "cached")
+	require.NoError(t, err)
+	require.Equal(t, cached, dep)
+	apiService, err := client.GetAPIService(context.TODO(), "v1.cached")
+	require.NoError(t, err)
+	require.Equal(t, cachedAPIService, apiService)
+	require.Empty(t, k8sClient.Actions())
+	require.Empty(t, regClient.Actions())
+
+	// Cache misses fall back to the apiserver
+	dep, err = client.GetDeployment(context.TODO(), "ns", "uncached")
+	require.NoError(t, err)
+	require.Equal(t, uncached, dep)
+	apiService, err = client.GetAPIService(context.TODO(), "v1.uncached")
+	require.NoError(t, err)
+	require.Equal(t, uncachedAPIService, apiService)
+	require.Len(t, k8sClient.Actions(), 1)
+	require.Len(t, regClient.Actions(), 1)
+
+	// Callers can't mutate the cache
+	dep, err = client.GetDeployment(context.TODO(), "ns", "cached")
+	require.NoError(t, err)
+	dep.SetLabels(map[string]string{"mutated": "true"})
+	require.Empty(t, cached.GetLabels())
+}
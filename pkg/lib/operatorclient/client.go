@@ -2,6 +2,8 @@
 package operatorclient
 
 import (
+	"context"
+
 	"github.com/sirupsen/logrus"
 	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
@@ -36,104 +38,104 @@ type ClientInterface interface {
 
 // CustomResourceClient contains methods for the Custom Resource.
 type CustomResourceClient interface {
-	GetCustomResource(apiGroup, version, namespace, resourceKind, resourceName string) (*unstructured.Unstructured, error)
-	GetCustomResourceRaw(apiGroup, version, namespace, resourceKind, resourceName string) ([]byte, error)
-	CreateCustomResource(item *unstructured.Unstructured) error
-	CreateCustomResourceRaw(apiGroup, version, namespace, kind string, data []byte) error
-	CreateCustomResourceRawIfNotFound(apiGroup, version, namespace, kind, name string, data []byte) (bool, error)
-	UpdateCustomResource(item *unstructured.Unstructured) error
-	UpdateCustomResourceRaw(apiGroup, version, namespace, resourceKind, resourceName string, data []byte) error
-	CreateOrUpdateCustomeResourceRaw(apiGroup, version, namespace, resourceKind, resourceName string, data []byte) error
-	DeleteCustomResource(apiGroup, version, namespace, resourceKind, resourceName string) error
-	AtomicModifyCustomResource(apiGroup, version, namespace, resourceKind, resourceName string, f CustomResourceModifier, data interface{}) error
-	ListCustomResource(apiGroup, version, namespace, resourceKind string) (*CustomResourceList, error)
+	GetCustomResource(ctx context.Context, apiGroup, version, namespace, resourceKind, resourceName string) (*unstructured.Unstructured, error)
+	GetCustomResourceRaw(ctx context.Context, apiGroup, version, namespace, resourceKind, resourceName string) ([]byte, error)
+	CreateCustomResource(ctx context.Context, item *unstructured.Unstructured) error
+	CreateCustomResourceRaw(ctx context.Context, apiGroup, version, namespace, kind string, data []byte) error
+	CreateCustomResourceRawIfNotFound(ctx context.Context, apiGroup, version, namespace, kind, name string, data []byte) (bool, error)
+	UpdateCustomResource(ctx context.Context, item *unstructured.Unstructured) error
+	UpdateCustomResourceRaw(ctx context.Context, apiGroup, version, namespace, resourceKind, resourceName string, data []byte) error
+	CreateOrUpdateCustomeResourceRaw(ctx context.Context, apiGroup, version, namespace, resourceKind, resourceName string, data []byte) error
+	DeleteCustomResource(ctx context.Context, apiGroup, version, namespace, resourceKind, resourceName string) error
+	AtomicModifyCustomResource(ctx context.Context, apiGroup, version, namespace, resourceKind, resourceName string, f CustomResourceModifier, data interface{}) error
+	ListCustomResource(ctx context.Context, apiGroup, version, namespace, resourceKind string) (*CustomResourceList, error)
 }
 
 // APIServiceClient contains methods for manipulating APIServiceBindings.
 type APIServiceClient interface {
-	CreateAPIService(*apiregistrationv1.APIService) (*apiregistrationv1.APIService, error)
-	GetAPIService(name string) (*apiregistrationv1.APIService, error)
-	UpdateAPIService(modified *apiregistrationv1.APIService) (*apiregistrationv1.APIService, error)
-	DeleteAPIService(name string, options *metav1.DeleteOptions) error
+	CreateAPIService(context.Context, *apiregistrationv1.APIService) (*apiregistrationv1.APIService, error)
+	GetAPIService(ctx context.Context, name string) (*apiregistrationv1.APIService, error)
+	UpdateAPIService(ctx context.Context, modified *apiregistrationv1.APIService) (*apiregistrationv1.APIService, error)
+	DeleteAPIService(ctx context.Context, name string, options *metav1.DeleteOptions) error
 }
 
 // SecretClient contains methods for manipulating Secrets
 type SecretClient interface {
-	CreateSecret(*v1.Secret) (*v1.Secret, error)
-	GetSecret(namespace, name string) (*v1.Secret, error)
-	UpdateSecret(modified *v1.Secret) (*v1.Secret, error)
-	DeleteSecret(namespace, name string, options *metav1.DeleteOptions) error
+	CreateSecret(context.Context, *v1.Secret) (*v1.Secret, error)
+	GetSecret(ctx context.Context, namespace, name string) (*v1.Secret, error)
+	UpdateSecret(ctx context.Context, modified *v1.Secret) (*v1.Secret, error)
+	DeleteSecret(ctx context.Context, namespace, name string, options *metav1.DeleteOptions) error
 }
 
 // ServiceClient contains methods for manipulating Services
 type ServiceClient interface {
-	CreateService(*v1.Service) (*v1.Service, error)
-	GetService(namespace, name string) (*v1.Service, error)
-	UpdateService(modified *v1.Service) (*v1.Service, error)
-	DeleteService(namespace, name string, options *metav1.DeleteOptions) error
+	CreateService(context.Context, *v1.Service) (*v1.Service, error)
+	GetService(ctx context.Context, namespace, name string) (*v1.Service, error)
+	UpdateService(ctx context.Context, modified *v1.Service) (*v1.Service, error)
+	DeleteService(ctx context.Context, namespace, name string, options *metav1.DeleteOptions) error
 }
 
 // ServiceAccountClient contains methods for manipulating ServiceAccounts.
 type ServiceAccountClient interface {
-	CreateServiceAccount(*v1.ServiceAccount) (*v1.ServiceAccount, error)
-	GetServiceAccount(namespace, name string) (*v1.ServiceAccount, error)
-	UpdateServiceAccount(modified *v1.ServiceAccount) (*v1.ServiceAccount, error)
-	DeleteServiceAccount(namespace, name string, options *metav1.DeleteOptions) error
+	CreateServiceAccount(context.Context, *v1.ServiceAccount) (*v1.ServiceAccount, error)
+	GetServiceAccount(ctx context.Context, namespace, name string) (*v1.ServiceAccount, error)
+	UpdateServiceAccount(ctx context.Context, modified *v1.ServiceAccount) (*v1.ServiceAccount, error)
+	DeleteServiceAccount(ctx context.Context, namespace, name string, options *metav1.DeleteOptions) error
 }
 
 // RoleClient contains methods for manipulating Roles.
 type RoleClient interface {
-	CreateRole(*rbacv1.Role) (*rbacv1.Role, error)
-	GetRole(namespace, name string) (*rbacv1.Role, error)
-	UpdateRole(modified *rbacv1.Role) (*rbacv1.Role, error)
-	DeleteRole(namespace, name string, options *metav1.DeleteOptions) error
+	CreateRole(context.Context, *rbacv1.Role) (*rbacv1.Role, error)
+	GetRole(ctx context.Context, namespace, name string) (*rbacv1.Role, error)
+	UpdateRole(ctx context.Context, modified *rbacv1.Role) (*rbacv1.Role, error)
+	DeleteRole(ctx context.Context, namespace, name string, options *metav1.DeleteOptions) error
 }
 
 // RoleBindingClient contains methods for manipulating RoleBindings.
 type RoleBindingClient interface {
-	CreateRoleBinding(*rbacv1.RoleBinding) (*rbacv1.RoleBinding, error)
-	GetRoleBinding(namespace, name string) (*rbacv1.RoleBinding, error)
-	UpdateRoleBinding(modified *rbacv1.RoleBinding) (*rbacv1.RoleBinding, error)
-	DeleteRoleBinding(namespace, name string, options *metav1.DeleteOptions) error
+	CreateRoleBinding(context.Context, *rbacv1.RoleBinding) (*rbacv1.RoleBinding, error)
+	GetRoleBinding(ctx context.Context, namespace, name string) (*rbacv1.RoleBinding, error)
+	UpdateRoleBinding(ctx context.Context, modified *rbacv1.RoleBinding) (*rbacv1.RoleBinding, error)
+	DeleteRoleBinding(ctx context.Context, namespace, name string, options *metav1.DeleteOptions) error
 }
 
 // ClusterRoleClient contains methods for manipulating ClusterRoleBindings.
 type ClusterRoleClient interface {
-	CreateClusterRole(*rbacv1.ClusterRole) (*rbacv1.ClusterRole, error)
-	GetClusterRole(name string) (*rbacv1.ClusterRole, error)
-	UpdateClusterRole(modified *rbacv1.ClusterRole) (*rbacv1.ClusterRole, error)
-	DeleteClusterRole(name string, options *metav1.DeleteOptions) error
+	CreateClusterRole(context.Context, *rbacv1.ClusterRole) (*rbacv1.ClusterRole, error)
+	GetClusterRole(ctx context.Context, name string) (*rbacv1.ClusterRole, error)
+	UpdateClusterRole(ctx context.Context, modified *rbacv1.ClusterRole) (*rbacv1.ClusterRole, error)
+	DeleteClusterRole(ctx context.Context, name string, options *metav1.DeleteOptions) error
 }
 
 // ClusterRoleBindingClient contains methods for manipulating ClusterRoleBindings.
 type ClusterRoleBindingClient interface {
-	CreateClusterRoleBinding(*rbacv1.ClusterRoleBinding) (*rbacv1.ClusterRoleBinding, error)
-	GetClusterRoleBinding(name string) (*rbacv1.ClusterRoleBinding, error)
-	UpdateClusterRoleBinding(modified *rbacv1.ClusterRoleBinding) (*rbacv1.ClusterRoleBinding, error)
-	DeleteClusterRoleBinding(name string, options *metav1.DeleteOptions) error
+	CreateClusterRoleBinding(context.Context, *rbacv1.ClusterRoleBinding) (*rbacv1.ClusterRoleBinding, error)
+	GetClusterRoleBinding(ctx context.Context, name string) (*rbacv1.ClusterRoleBinding, error)
+	UpdateClusterRoleBinding(ctx context.Context, modified *rbacv1.ClusterRoleBinding) (*rbacv1.ClusterRoleBinding, error)
+	DeleteClusterRoleBinding(ctx context.Context, name string, options *metav1.DeleteOptions) error
 }
 
 // DeploymentClient contains methods for the Deployment resource.
 type DeploymentClient interface {
-	GetDeployment(namespace, name string) (*appsv1.Deployment, error)
-	CreateDeployment(*appsv1.Deployment) (*appsv1.Deployment, error)
-	DeleteDeployment(namespace, name string, options *metav1.DeleteOptions) error
-	UpdateDeployment(*appsv1.Deployment) (*appsv1.Deployment, bool, error)
-	PatchDeployment(*appsv1.Deployment, *appsv1.Deployment) (*appsv1.Deployment, bool, error)
-	RollingUpdateDeployment(*appsv1.Deployment) (*appsv1.Deployment, bool, error)
-	RollingPatchDeployment(*appsv1.Deployment, *appsv1.Deployment) (*appsv1.Deployment, bool, error)
-	RollingUpdateDeploymentMigrations(namespace, name string, f UpdateFunction) (*appsv1.Deployment, bool, error)
-	RollingPatchDeploymentMigrations(namespace, name string, f PatchFunction) (*appsv1.Deployment, bool, error)
-	CreateOrRollingUpdateDeployment(*appsv1.Deployment) (*appsv1.Deployment, bool, error)
-	ListDeploymentsWithLabels(namespace string, labels labels.Set) (*appsv1.DeploymentList, error)
+	GetDeployment(ctx context.Context, namespace, name string) (*appsv1.Deployment, error)
+	CreateDeployment(context.Context, *appsv1.Deployment) (*appsv1.Deployment, error)
+	DeleteDeployment(ctx context.Context, namespace, name string, options *metav1.DeleteOptions) error
+	UpdateDeployment(context.Context, *appsv1.Deployment) (*appsv1.Deployment, bool, error)
+	PatchDeployment(context.Context, *appsv1.Deployment, *appsv1.Deployment) (*appsv1.Deployment, bool, error)
+	RollingUpdateDeployment(context.Context, *appsv1.Deployment) (*appsv1.Deployment, bool, error)
+	RollingPatchDeployment(context.Context, *appsv1.Deployment, *appsv1.Deployment) (*appsv1.Deployment, bool, error)
+	RollingUpdateDeploymentMigrations(ctx context.Context, namespace, name string, f UpdateFunction) (*appsv1.Deployment, bool, error)
+	RollingPatchDeploymentMigrations(ctx context.Context, namespace, name string, f PatchFunction) (*appsv1.Deployment, bool, error)
+	CreateOrRollingUpdateDeployment(context.Context, *appsv1.Deployment) (*appsv1.Deployment, bool, error)
+	ListDeploymentsWithLabels(ctx context.Context, namespace string, labels labels.Set) (*appsv1.DeploymentList, error)
 }
 
 // ConfigMapClient contains methods for the ConfigMap resource
 type ConfigMapClient interface {
-	CreateConfigMap(*v1.ConfigMap) (*v1.ConfigMap, error)
-	GetConfigMap(namespace, name string) (*v1.ConfigMap, error)
-	UpdateConfigMap(modified *v1.ConfigMap) (*v1.ConfigMap, error)
-	DeleteConfigMap(namespace, name string, options *metav1.DeleteOptions) error
+	CreateConfigMap(context.Context, *v1.ConfigMap) (*v1.ConfigMap, error)
+	GetConfigMap(ctx context.Context, namespace, name string) (*v1.ConfigMap, error)
+	UpdateConfigMap(ctx context.Context, modified *v1.ConfigMap) (*v1.ConfigMap, error)
+	DeleteConfigMap(ctx context.Context, namespace, name string, options *metav1.DeleteOptions) error
 }
 
 // Interface assertion.
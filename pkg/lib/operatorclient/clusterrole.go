@@ -11,24 +11,24 @@ import (
 )
 
 // CreateClusterRole creates the ClusterRole.
-func (c *Client) CreateClusterRole(r *rbacv1.ClusterRole) (*rbacv1.ClusterRole, error) {
-	return c.RbacV1().ClusterRoles().Create(context.TODO(), r, metav1.CreateOptions{})
+func (c *Client) CreateClusterRole(ctx context.Context, r *rbacv1.ClusterRole) (*rbacv1.ClusterRole, error) {
+	return c.RbacV1().ClusterRoles().Create(ctx, r, metav1.CreateOptions{})
 }
 
 // GetClusterRole returns the existing ClusterRole.
-func (c *Client) GetClusterRole(name string) (*rbacv1.ClusterRole, error) {
-	return c.RbacV1().ClusterRoles().Get(context.TODO(), name, metav1.GetOptions{})
+func (c *Client) GetClusterRole(ctx context.Context, name string) (*rbacv1.ClusterRole, error) {
+	return c.RbacV1().ClusterRoles().Get(ctx, name, metav1.GetOptions{})
 }
 
 // DeleteClusterRole deletes the ClusterRole
-func (c *Client) DeleteClusterRole(name string, options *metav1.DeleteOptions) error {
-	return c.RbacV1().ClusterRoles().Delete(context.TODO(), name, *options)
+func (c *Client) DeleteClusterRole(ctx context.Context, name string, options *metav1.DeleteOptions) error {
+	return c.RbacV1().ClusterRoles().Delete(ctx, name, *options)
 }
 
 // UpdateClusterRole will update the given ClusterRole.
-func (c *Client) UpdateClusterRole(crb *rbacv1.ClusterRole) (*rbacv1.ClusterRole, error) {
+func (c *Client) UpdateClusterRole(ctx context.Context, crb *rbacv1.ClusterRole) (*rbacv1.ClusterRole, error) {
 	klog.V(4).Infof("[UPDATE Role]: %s", crb.GetName())
-	oldCrb, err := c.GetClusterRole(crb.GetName())
+	oldCrb, err := c.GetClusterRole(ctx, crb.GetName())
 	if err != nil {
 		return nil, err
 	}
@@ -36,5 +36,5 @@ func (c *Client) UpdateClusterRole(crb *rbacv1.ClusterRole) (*rbacv1.ClusterRole
 	if err != nil {
 		return nil, fmt.Errorf("error creating patch for Role: %v", err)
 	}
-	return c.RbacV1().ClusterRoles().Patch(context.TODO(), crb.GetName(), types.StrategicMergePatchType, patchBytes, metav1.PatchOptions{})
+	return c.RbacV1().ClusterRoles().Patch(ctx, crb.GetName(), types.StrategicMergePatchType, patchBytes, metav1.PatchOptions{})
 }
@@ -11,24 +11,24 @@ import (
 )
 
 // CreateRoleBinding creates the roleBinding.
-func (c *Client) CreateClusterRoleBinding(ig *rbacv1.ClusterRoleBinding) (*rbacv1.ClusterRoleBinding, error) {
-	return c.RbacV1().ClusterRoleBindings().Create(context.TODO(), ig, metav1.CreateOptions{})
+func (c *Client) CreateClusterRoleBinding(ctx context.Context, ig *rbacv1.ClusterRoleBinding) (*rbacv1.ClusterRoleBinding, error) {
+	return c.RbacV1().ClusterRoleBindings().Create(ctx, ig, metav1.CreateOptions{})
 }
 
 // GetRoleBinding returns the existing roleBinding.
-func (c *Client) GetClusterRoleBinding(name string) (*rbacv1.ClusterRoleBinding, error) {
-	return c.RbacV1().ClusterRoleBindings().Get(context.TODO(), name, metav1.GetOptions{})
+func (c *Client) GetClusterRoleBinding(ctx context.Context, name string) (*rbacv1.ClusterRoleBinding, error) {
+	return c.RbacV1().ClusterRoleBindings().Get(ctx, name, metav1.GetOptions{})
 }
 
 // DeleteRoleBinding deletes the roleBinding.
-func (c *Client) DeleteClusterRoleBinding(name string, options *metav1.DeleteOptions) error {
-	return c.RbacV1().ClusterRoleBindings().Delete(context.TODO(), name, *options)
+func (c *Client) DeleteClusterRoleBinding(ctx context.Context, name string, options *metav1.DeleteOptions) error {
+	return c.RbacV1().ClusterRoleBindings().Delete(ctx, name, *options)
 }
 
 // UpdateRoleBinding will update the given RoleBinding resource.
-func (c *Client) UpdateClusterRoleBinding(crb *rbacv1.ClusterRoleBinding) (*rbacv1.ClusterRoleBinding, error) {
+func (c *Client) UpdateClusterRoleBinding(ctx context.Context, crb *rbacv1.ClusterRoleBinding) (*rbacv1.ClusterRoleBinding, error) {
 	klog.V(4).Infof("[UPDATE RoleBinding]: %s", crb.GetName())
-	oldCrb, err := c.GetClusterRoleBinding(crb.GetName())
+	oldCrb, err := c.GetClusterRoleBinding(ctx, crb.GetName())
 	if err != nil {
 		return nil, err
 	}
@@ -36,5 +36,5 @@ func (c *Client) UpdateClusterRoleBinding(crb *rbacv1.ClusterRoleBinding) (*rbac
 	if err != nil {
 		return nil, fmt.Errorf("error creating patch for RoleBinding: %v", err)
 	}
-	return c.RbacV1().ClusterRoleBindings().Patch(context.TODO(), crb.GetName(), types.StrategicMergePatchType, patchBytes, metav1.PatchOptions{})
+	return c.RbacV1().ClusterRoleBindings().Patch(ctx, crb.GetName(), types.StrategicMergePatchType, patchBytes, metav1.PatchOptions{})
 }
@@ -11,24 +11,24 @@ import (
 )
 
 // CreateConfigMap creates the ConfigMap.
-func (c *Client) CreateConfigMap(ig *corev1.ConfigMap) (*corev1.ConfigMap, error) {
-	return c.CoreV1().ConfigMaps(ig.GetNamespace()).Create(context.TODO(), ig, metav1.CreateOptions{})
+func (c *Client) CreateConfigMap(ctx context.Context, ig *corev1.ConfigMap) (*corev1.ConfigMap, error) {
+	return c.CoreV1().ConfigMaps(ig.GetNamespace()).Create(ctx, ig, metav1.CreateOptions{})
 }
 
 // GetConfigMap returns the existing ConfigMap.
-func (c *Client) GetConfigMap(namespace, name string) (*corev1.ConfigMap, error) {
-	return c.CoreV1().ConfigMaps(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+func (c *Client) GetConfigMap(ctx context.Context, namespace, name string) (*corev1.ConfigMap, error) {
+	return c.CoreV1().ConfigMaps(namespace).Get(ctx, name, metav1.GetOptions{})
 }
 
 // DeleteConfigMap deletes the ConfigMap.
-func (c *Client) DeleteConfigMap(namespace, name string, options *metav1.DeleteOptions) error {
-	return c.CoreV1().ConfigMaps(namespace).Delete(context.TODO(), name, *options)
+func (c *Client) DeleteConfigMap(ctx context.Context, namespace, name string, options *metav1.DeleteOptions) error {
+	return c.CoreV1().ConfigMaps(namespace).Delete(ctx, name, *options)
 }
 
 // UpdateConfigMap will update the given ConfigMap resource.
-func (c *Client) UpdateConfigMap(configmap *corev1.ConfigMap) (*corev1.ConfigMap, error) {
+func (c *Client) UpdateConfigMap(ctx context.Context, configmap *corev1.ConfigMap) (*corev1.ConfigMap, error) {
 	klog.V(4).Infof("[UPDATE ConfigMap]: %s", configmap.GetName())
-	oldSa, err := c.GetConfigMap(configmap.GetNamespace(), configmap.GetName())
+	oldSa, err := c.GetConfigMap(ctx, configmap.GetNamespace(), configmap.GetName())
 	if err != nil {
 		return nil, err
 	}
@@ -36,5 +36,5 @@ func (c *Client) UpdateConfigMap(configmap *corev1.ConfigMap) (*corev1.ConfigMap
 	if err != nil {
 		return nil, fmt.Errorf("error creating patch for ConfigMap: %v", err)
 	}
-	return c.CoreV1().ConfigMaps(configmap.GetNamespace()).Patch(context.TODO(), configmap.GetName(), types.StrategicMergePatchType, patchBytes, metav1.PatchOptions{})
+	return c.CoreV1().ConfigMaps(configmap.GetNamespace()).Patch(ctx, configmap.GetName(), types.StrategicMergePatchType, patchBytes, metav1.PatchOptions{})
 }
@@ -25,11 +25,11 @@ type CustomResourceList struct {
 }
 
 // GetCustomResource returns the custom resource as *unstructured.Unstructured by the given name.
-func (c *Client) GetCustomResource(apiGroup, version, namespace, resourcePlural, resourceName string) (*unstructured.Unstructured, error) {
+func (c *Client) GetCustomResource(ctx context.Context, apiGroup, version, namespace, resourcePlural, resourceName string) (*unstructured.Unstructured, error) {
 	klog.V(4).Infof("[GET CUSTOM RESOURCE]: %s:%s", namespace, resourceName)
 	var object unstructured.Unstructured
 
-	b, err := c.GetCustomResourceRaw(apiGroup, version, namespace, resourcePlural, resourceName)
+	b, err := c.GetCustomResourceRaw(ctx, apiGroup, version, namespace, resourcePlural, resourceName)
 	if err != nil {
 		return nil, err
 	}
@@ -41,17 +41,17 @@ func (c *Client) GetCustomResource(apiGroup, version, namespace, resourcePlural,
 }
 
 // GetCustomResourceRaw returns the custom resource's raw body data by the given name.
-func (c *Client) GetCustomResourceRaw(apiGroup, version, namespace, resourcePlural, resourceName string) ([]byte, error) {
+func (c *Client) GetCustomResourceRaw(ctx context.Context, apiGroup, version, namespace, resourcePlural, resourceName string) ([]byte, error) {
 	klog.V(4).Infof("[GET CUSTOM RESOURCE RAW]: %s:%s", namespace, resourceName)
 	httpRestClient := c.extInterface.ApiextensionsV1beta1().RESTClient()
 	uri := customResourceURI(apiGroup, version, namespace, resourcePlural, resourceName)
 	klog.V(4).Infof("[GET]: %s", uri)
 
-	return httpRestClient.Get().RequestURI(uri).DoRaw(context.TODO())
+	return httpRestClient.Get().RequestURI(uri).DoRaw(ctx)
 }
 
 // CreateCustomResource creates the custom resource.
-func (c *Client) CreateCustomResource(item *unstructured.Unstructured) error {
+func (c *Client) CreateCustomResource(ctx context.Context, item *unstructured.Unstructured) error {
 	klog.V(4).Infof("[CREATE CUSTOM RESOURCE]: %s:%s", item.GetNamespace(), item.GetName())
 	kind := item.GetKind()
 	namespace := item.GetNamespace()
@@ -66,18 +66,18 @@ func (c *Client) CreateCustomResource(item *unstructured.Unstructured) error {
 		return err
 	}
 
-	return c.CreateCustomResourceRaw(apiGroup, version, namespace, kind, data)
+	return c.CreateCustomResourceRaw(ctx, apiGroup, version, namespace, kind, data)
 }
 
 // CreateCustomResourceRaw creates the raw bytes of the custom resource.
-func (c *Client) CreateCustomResourceRaw(apiGroup, version, namespace, kind string, data []byte) error {
+func (c *Client) CreateCustomResourceRaw(ctx context.Context, apiGroup, version, namespace, kind string, data []byte) error {
 	klog.V(4).Infof("[CREATE CUSTOM RESOURCE RAW]: %s:%s", namespace, kind)
 	var statusCode int
 
 	httpRestClient := c.extInterface.ApiextensionsV1beta1().RESTClient()
 	uri := customResourceDefinitionURI(apiGroup, version, namespace, kind)
 	klog.V(4).Infof("[POST]: %s", uri)
-	result := httpRestClient.Post().RequestURI(uri).Body(data).Do(context.TODO())
+	result := httpRestClient.Post().RequestURI(uri).Body(data).Do(ctx)
 
 	if result.Error() != nil {
 		return result.Error()
@@ -94,16 +94,16 @@ func (c *Client) CreateCustomResourceRaw(apiGroup, version, namespace, kind stri
 
 // CreateCustomResourceRawIfNotFound creates the raw bytes of the custom resource if it doesn't exist.
 // It also returns a boolean to indicate whether a new custom resource is created.
-func (c *Client) CreateCustomResourceRawIfNotFound(apiGroup, version, namespace, kind, name string, data []byte) (bool, error) {
+func (c *Client) CreateCustomResourceRawIfNotFound(ctx context.Context, apiGroup, version, namespace, kind, name string, data []byte) (bool, error) {
 	klog.V(4).Infof("[CREATE CUSTOM RESOURCE RAW if not found]: %s:%s", namespace, name)
-	_, err := c.GetCustomResource(apiGroup, version, namespace, kind, name)
+	_, err := c.GetCustomResource(ctx, apiGroup, version, namespace, kind, name)
 	if err == nil {
 		return false, nil
 	}
 	if !errors.IsNotFound(err) {
 		return false, err
 	}
-	err = c.CreateCustomResourceRaw(apiGroup, version, namespace, kind, data)
+	err = c.CreateCustomResourceRaw(ctx, apiGroup, version, namespace, kind, data)
 	if err != nil {
 		return false, err
 	}
@@ -112,7 +112,7 @@ func (c *Client) CreateCustomResourceRawIfNotFound(apiGroup, version, namespace,
 
 // UpdateCustomResource updates the custom resource.
 // To do an atomic update, use AtomicModifyCustomResource().
-func (c *Client) UpdateCustomResource(item *unstructured.Unstructured) error {
+func (c *Client) UpdateCustomResource(ctx context.Context, item *unstructured.Unstructured) error {
 	klog.V(4).Infof("[UPDATE CUSTOM RESOURCE]: %s:%s", item.GetNamespace(), item.GetName())
 	kind := item.GetKind()
 	name := item.GetName()
@@ -128,18 +128,18 @@ func (c *Client) UpdateCustomResource(item *unstructured.Unstructured) error {
 		return err
 	}
 
-	return c.UpdateCustomResourceRaw(apiGroup, version, namespace, kind, name, data)
+	return c.UpdateCustomResourceRaw(ctx, apiGroup, version, namespace, kind, name, data)
 }
 
 // UpdateCustomResourceRaw updates the thirdparty resource with the raw data.
-func (c *Client) UpdateCustomResourceRaw(apiGroup, version, namespace, resourcePlural, resourceName string, data []byte) error {
+func (c *Client) UpdateCustomResourceRaw(ctx context.Context, apiGroup, version, namespace, resourcePlural, resourceName string, data []byte) error {
 	klog.V(4).Infof("[UPDATE CUSTOM RESOURCE RAW]: %s:%s", namespace, resourceName)
 	var statusCode int
 
 	httpRestClient := c.extInterface.ApiextensionsV1beta1().RESTClient()
 	uri := customResourceURI(apiGroup, version, namespace, resourcePlural, resourceName)
 	klog.V(4).Infof("[PUT]: %s", uri)
-	result := httpRestClient.Put().RequestURI(uri).Body(data).Do(context.TODO())
+	result := httpRestClient.Put().RequestURI(uri).Body(data).Do(ctx)
 
 	if result.Error() != nil {
 		return result.Error()
@@ -156,14 +156,14 @@ func (c *Client) UpdateCustomResourceRaw(apiGroup, version, namespace, resourceP
 
 // CreateOrUpdateCustomeResourceRaw creates the custom resource if it doesn't exist.
 // If the custom resource exists, it updates the existing one.
-func (c *Client) CreateOrUpdateCustomeResourceRaw(apiGroup, version, namespace, resourcePlural, resourceName string, data []byte) error {
+func (c *Client) CreateOrUpdateCustomeResourceRaw(ctx context.Context, apiGroup, version, namespace, resourcePlural, resourceName string, data []byte) error {
 	klog.V(4).Infof("[CREATE OR UPDATE UPDATE CUSTOM RESOURCE RAW]: %s:%s", namespace, resourceName)
-	old, err := c.GetCustomResourceRaw(apiGroup, version, namespace, resourcePlural, resourceName)
+	old, err := c.GetCustomResourceRaw(ctx, apiGroup, version, namespace, resourcePlural, resourceName)
 	if err != nil {
 		if !errors.IsNotFound(err) {
 			return err
 		}
-		return c.CreateCustomResourceRaw(apiGroup, version, namespace, resourcePlural, data)
+		return c.CreateCustomResourceRaw(ctx, apiGroup, version, namespace, resourcePlural, data)
 	}
 
 	var oldSpec, newSpec unstructured.Unstructured
@@ -182,17 +182,17 @@ func (c *Client) CreateOrUpdateCustomeResourceRaw(apiGroup, version, namespace,
 		return err
 	}
 
-	return c.UpdateCustomResourceRaw(apiGroup, version, namespace, resourcePlural, resourceName, data)
+	return c.UpdateCustomResourceRaw(ctx, apiGroup, version, namespace, resourcePlural, resourceName, data)
 }
 
 // DeleteCustomResource deletes the  with the given name.
-func (c *Client) DeleteCustomResource(apiGroup, version, namespace, resourcePlural, resourceName string) error {
+func (c *Client) DeleteCustomResource(ctx context.Context, apiGroup, version, namespace, resourcePlural, resourceName string) error {
 	klog.V(4).Infof("[DELETE CUSTOM RESOURCE]: %s:%s", namespace, resourceName)
 	httpRestClient := c.extInterface.ApiextensionsV1beta1().RESTClient()
 	uri := customResourceURI(apiGroup, version, namespace, resourcePlural, resourceName)
 
 	klog.V(4).Infof("[DELETE]: %s", uri)
-	_, err := httpRestClient.Delete().RequestURI(uri).DoRaw(context.TODO())
+	_, err := httpRestClient.Delete().RequestURI(uri).DoRaw(ctx)
 	return err
 }
 
@@ -201,11 +201,11 @@ type CustomResourceModifier func(*unstructured.Unstructured, interface{}) error
 
 // AtomicModifyCustomResource gets the custom resource, modifies it and writes it back.
 // If it's modified by other writers, we will retry until it succeeds.
-func (c *Client) AtomicModifyCustomResource(apiGroup, version, namespace, resourcePlural, resourceName string, f CustomResourceModifier, data interface{}) error {
+func (c *Client) AtomicModifyCustomResource(ctx context.Context, apiGroup, version, namespace, resourcePlural, resourceName string, f CustomResourceModifier, data interface{}) error {
 	klog.V(4).Infof("[ATOMIC MODIFY CUSTOM RESOURCE]: %s:%s", namespace, resourceName)
 	return wait.PollInfinite(time.Second, func() (bool, error) {
 		var customResource unstructured.Unstructured
-		b, err := c.GetCustomResourceRaw(apiGroup, version, namespace, resourcePlural, resourceName)
+		b, err := c.GetCustomResourceRaw(ctx, apiGroup, version, namespace, resourcePlural, resourceName)
 		if err != nil {
 			klog.Errorf("Failed to get CUSTOM RESOURCE %q, kind:%q: %v", resourceName, resourcePlural, err)
 			return false, err
@@ -221,7 +221,7 @@ func (c *Client) AtomicModifyCustomResource(apiGroup, version, namespace, resour
 			return false, err
 		}
 
-		if err := c.UpdateCustomResource(&customResource); err != nil {
+		if err := c.UpdateCustomResource(ctx, &customResource); err != nil {
 			if errors.IsConflict(err) {
 				klog.Errorf("Failed to update CUSTOM RESOURCE %q, kind:%q: %v, will retry", resourceName, resourcePlural, err)
 				return false, nil
@@ -273,7 +273,7 @@ func customResourceDefinitionURI(apiGroup, version, namespace, resourcePlural st
 }
 
 // ListCustomResource lists all custom resources for the given namespace.
-func (c *Client) ListCustomResource(apiGroup, version, namespace, resourcePlural string) (*CustomResourceList, error) {
+func (c *Client) ListCustomResource(ctx context.Context, apiGroup, version, namespace, resourcePlural string) (*CustomResourceList, error) {
 	klog.V(4).Infof("LIST CUSTOM RESOURCE]: %s", resourcePlural)
 
 	var crList CustomResourceList
@@ -281,7 +281,7 @@ func (c *Client) ListCustomResource(apiGroup, version, namespace, resourcePlural
 	httpRestClient := c.extInterface.ApiextensionsV1beta1().RESTClient()
 	uri := customResourceDefinitionURI(apiGroup, version, namespace, resourcePlural)
 	klog.V(4).Infof("[GET]: %s", uri)
-	bytes, err := httpRestClient.Get().RequestURI(uri).DoRaw(context.TODO())
+	bytes, err := httpRestClient.Get().RequestURI(uri).DoRaw(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get custom resource list: %v", err)
 	}
@@ -20,40 +20,40 @@ const (
 )
 
 // GetDeployment returns the Deployment object for the given namespace and name.
-func (c *Client) GetDeployment(namespace, name string) (*appsv1.Deployment, error) {
+func (c *Client) GetDeployment(ctx context.Context, namespace, name string) (*appsv1.Deployment, error) {
 	klog.V(4).Infof("[GET Deployment]: %s:%s", namespace, name)
-	return c.AppsV1().Deployments(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+	return c.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
 }
 
 // CreateDeployment creates the Deployment object.
-func (c *Client) CreateDeployment(dep *appsv1.Deployment) (*appsv1.Deployment, error) {
+func (c *Client) CreateDeployment(ctx context.Context, dep *appsv1.Deployment) (*appsv1.Deployment, error) {
 	klog.V(4).Infof("[CREATE Deployment]: %s:%s", dep.Namespace, dep.Name)
-	return c.AppsV1().Deployments(dep.Namespace).Create(context.TODO(), dep, metav1.CreateOptions{})
+	return c.AppsV1().Deployments(dep.Namespace).Create(ctx, dep, metav1.CreateOptions{})
 }
 
 // DeleteDeployment deletes the Deployment object.
-func (c *Client) DeleteDeployment(namespace, name string, options *metav1.DeleteOptions) error {
+func (c *Client) DeleteDeployment(ctx context.Context, namespace, name string, options *metav1.DeleteOptions) error {
 	klog.V(4).Infof("[DELETE Deployment]: %s:%s", namespace, name)
-	return c.AppsV1().Deployments(namespace).Delete(context.TODO(), name, *options)
+	return c.AppsV1().Deployments(namespace).Delete(ctx, name, *options)
 }
 
 // UpdateDeployment updates a Deployment object by performing a 2-way patch between the existing
 // Deployment and the result of the UpdateFunction.
 //
 // Returns the latest Deployment and true if it was updated, or an error.
-func (c *Client) UpdateDeployment(dep *appsv1.Deployment) (*appsv1.Deployment, bool, error) {
-	return c.PatchDeployment(nil, dep)
+func (c *Client) UpdateDeployment(ctx context.Context, dep *appsv1.Deployment) (*appsv1.Deployment, bool, error) {
+	return c.PatchDeployment(ctx, nil, dep)
 }
 
 // PatchDeployment updates a Deployment object by performing a 3-way patch merge between the existing
 // Deployment and `original` and `modified` manifests.
 //
 // Returns the latest Deployment and true if it was updated, or an error.
-func (c *Client) PatchDeployment(original, modified *appsv1.Deployment) (*appsv1.Deployment, bool, error) {
+func (c *Client) PatchDeployment(ctx context.Context, original, modified *appsv1.Deployment) (*appsv1.Deployment, bool, error) {
 	namespace, name := modified.Namespace, modified.Name
 	klog.V(4).Infof("[PATCH Deployment]: %s:%s", namespace, name)
 
-	current, err := c.AppsV1().Deployments(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+	current, err := c.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
 		return nil, false, err
 	}
@@ -68,7 +68,7 @@ func (c *Client) PatchDeployment(original, modified *appsv1.Deployment) (*appsv1
 	if err != nil {
 		return nil, false, err
 	}
-	updated, err := c.AppsV1().Deployments(namespace).Patch(context.TODO(), name, types.StrategicMergePatchType, patchBytes, metav1.PatchOptions{})
+	updated, err := c.AppsV1().Deployments(namespace).Patch(ctx, name, types.StrategicMergePatchType, patchBytes, metav1.PatchOptions{})
 	if err != nil {
 		return nil, false, err
 	}
@@ -77,8 +77,8 @@ func (c *Client) PatchDeployment(original, modified *appsv1.Deployment) (*appsv1
 
 // RollingUpdateDeployment performs a rolling update on the given Deployment. It requires that the
 // Deployment uses the RollingUpdateDeploymentStrategyType update strategy.
-func (c *Client) RollingUpdateDeployment(dep *appsv1.Deployment) (*appsv1.Deployment, bool, error) {
-	return c.RollingUpdateDeploymentMigrations(dep.Namespace, dep.Name, Update(dep))
+func (c *Client) RollingUpdateDeployment(ctx context.Context, dep *appsv1.Deployment) (*appsv1.Deployment, bool, error) {
+	return c.RollingUpdateDeploymentMigrations(ctx, dep.Namespace, dep.Name, Update(dep))
 }
 
 // RollingUpdateDeploymentMigrations performs a rolling update on the given Deployment. It
@@ -86,9 +86,9 @@ func (c *Client) RollingUpdateDeployment(dep *appsv1.Deployment) (*appsv1.Deploy
 //
 // RollingUpdateDeploymentMigrations will run any before / during / after migrations that have been
 // specified in the upgrade options.
-func (c *Client) RollingUpdateDeploymentMigrations(namespace, name string, f UpdateFunction) (*appsv1.Deployment, bool, error) {
+func (c *Client) RollingUpdateDeploymentMigrations(ctx context.Context, namespace, name string, f UpdateFunction) (*appsv1.Deployment, bool, error) {
 	klog.V(4).Infof("[ROLLING UPDATE Deployment]: %s:%s", namespace, name)
-	return c.RollingPatchDeploymentMigrations(namespace, name, updateToPatch(f))
+	return c.RollingPatchDeploymentMigrations(ctx, namespace, name, updateToPatch(f))
 }
 
 // RollingPatchDeployment performs a 3-way patch merge followed by rolling update on the given
@@ -97,8 +97,8 @@ func (c *Client) RollingUpdateDeploymentMigrations(namespace, name string, f Upd
 //
 // RollingPatchDeployment will run any before / after migrations that have been specified in the
 // upgrade options.
-func (c *Client) RollingPatchDeployment(original, modified *appsv1.Deployment) (*appsv1.Deployment, bool, error) {
-	return c.RollingPatchDeploymentMigrations(modified.Namespace, modified.Name, Patch(original, modified))
+func (c *Client) RollingPatchDeployment(ctx context.Context, original, modified *appsv1.Deployment) (*appsv1.Deployment, bool, error) {
+	return c.RollingPatchDeploymentMigrations(ctx, modified.Namespace, modified.Name, Patch(original, modified))
 }
 
 // RollingPatchDeploymentMigrations performs a 3-way patch merge followed by rolling update on
@@ -107,10 +107,10 @@ func (c *Client) RollingPatchDeployment(original, modified *appsv1.Deployment) (
 //
 // RollingPatchDeploymentMigrations will run any before / after migrations that have been specified
 // in the upgrade options.
-func (c *Client) RollingPatchDeploymentMigrations(namespace, name string, f PatchFunction) (*appsv1.Deployment, bool, error) {
+func (c *Client) RollingPatchDeploymentMigrations(ctx context.Context, namespace, name string, f PatchFunction) (*appsv1.Deployment, bool, error) {
 	klog.V(4).Infof("[ROLLING PATCH Deployment]: %s:%s", namespace, name)
 
-	current, err := c.AppsV1().Deployments(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+	current, err := c.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
 		return nil, false, err
 	}
@@ -142,7 +142,7 @@ func (c *Client) RollingPatchDeploymentMigrations(namespace, name string, f Patc
 	if err != nil {
 		return nil, false, err
 	}
-	updated, err := c.AppsV1().Deployments(namespace).Patch(context.TODO(), name, types.StrategicMergePatchType, patchBytes, metav1.PatchOptions{})
+	updated, err := c.AppsV1().Deployments(namespace).Patch(ctx, name, types.StrategicMergePatchType, patchBytes, metav1.PatchOptions{})
 	if err != nil {
 		return nil, false, err
 	}
@@ -158,9 +158,9 @@ func checkDeploymentRollingUpdateEnabled(dep *appsv1.Deployment) error {
 	return nil
 }
 
-func (c *Client) waitForDeploymentRollout(dep *appsv1.Deployment) error {
-	return wait.PollInfinite(deploymentRolloutPollInterval, func() (bool, error) {
-		d, err := c.GetDeployment(dep.Namespace, dep.Name)
+func (c *Client) waitForDeploymentRollout(ctx context.Context, dep *appsv1.Deployment) error {
+	return wait.PollUntil(deploymentRolloutPollInterval, func() (bool, error) {
+		d, err := c.GetDeployment(ctx, dep.Namespace, dep.Name)
 		if err != nil {
 			// Do not return error here, as we could be updating the API Server itself, in which case we
 			// want to continue waiting.
@@ -171,34 +171,34 @@ func (c *Client) waitForDeploymentRollout(dep *appsv1.Deployment) error {
 			return true, nil
 		}
 		return false, nil
-	})
+	}, ctx.Done())
 }
 
 // CreateOrRollingUpdateDeployment creates the Deployment if it doesn't exist. If the Deployment
 // already exists, it will update the Deployment and wait for it to rollout. Returns true if the
 // Deployment was created or updated, false if there was no update.
-func (c *Client) CreateOrRollingUpdateDeployment(dep *appsv1.Deployment) (*appsv1.Deployment, bool, error) {
+func (c *Client) CreateOrRollingUpdateDeployment(ctx context.Context, dep *appsv1.Deployment) (*appsv1.Deployment, bool, error) {
 	klog.V(4).Infof("[CREATE OR ROLLING UPDATE Deployment]: %s:%s", dep.Namespace, dep.Name)
 
-	_, err := c.GetDeployment(dep.Namespace, dep.Name)
+	_, err := c.GetDeployment(ctx, dep.Namespace, dep.Name)
 	if err != nil {
 		if !apierrors.IsNotFound(err) {
 			return nil, false, err
 		}
-		created, err := c.CreateDeployment(dep)
+		created, err := c.CreateDeployment(ctx, dep)
 		if err != nil {
 			return nil, false, err
 		}
 		return created, true, err
 	}
-	return c.RollingUpdateDeployment(dep)
+	return c.RollingUpdateDeployment(ctx, dep)
 }
 
 // ListDeploymentsWithLabels returns a list of deployments that matches the label selector.
 // An empty list will be returned if no such deployments is found.
-func (c *Client) ListDeploymentsWithLabels(namespace string, labels labels.Set) (*appsv1.DeploymentList, error) {
+func (c *Client) ListDeploymentsWithLabels(ctx context.Context, namespace string, labels labels.Set) (*appsv1.DeploymentList, error) {
 	klog.V(4).Infof("[LIST Deployments] in %s, labels: %v", namespace, labels)
 
 	opts := metav1.ListOptions{LabelSelector: labels.String()}
-	return c.AppsV1().Deployments(namespace).List(context.TODO(), opts)
+	return c.AppsV1().Deployments(namespace).List(ctx, opts)
 }
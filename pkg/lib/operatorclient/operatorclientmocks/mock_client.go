@@ -5,6 +5,7 @@
 package operatorclientmocks
 
 import (
+	context "context"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -73,141 +74,141 @@ func (mr *MockClientInterfaceMockRecorder) ApiregistrationV1Interface() *gomock.
 }
 
 // AtomicModifyCustomResource mocks base method.
-func (m *MockClientInterface) AtomicModifyCustomResource(apiGroup, version, namespace, resourceKind, resourceName string, f operatorclient.CustomResourceModifier, data interface{}) error {
+func (m *MockClientInterface) AtomicModifyCustomResource(ctx context.Context, apiGroup, version, namespace, resourceKind, resourceName string, f operatorclient.CustomResourceModifier, data interface{}) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AtomicModifyCustomResource", apiGroup, version, namespace, resourceKind, resourceName, f, data)
+	ret := m.ctrl.Call(m, "AtomicModifyCustomResource", ctx, apiGroup, version, namespace, resourceKind, resourceName, f, data)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // AtomicModifyCustomResource indicates an expected call of AtomicModifyCustomResource.
-func (mr *MockClientInterfaceMockRecorder) AtomicModifyCustomResource(apiGroup, version, namespace, resourceKind, resourceName, f, data interface{}) *gomock.Call {
+func (mr *MockClientInterfaceMockRecorder) AtomicModifyCustomResource(ctx, apiGroup, version, namespace, resourceKind, resourceName, f, data interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AtomicModifyCustomResource", reflect.TypeOf((*MockClientInterface)(nil).AtomicModifyCustomResource), apiGroup, version, namespace, resourceKind, resourceName, f, data)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AtomicModifyCustomResource", reflect.TypeOf((*MockClientInterface)(nil).AtomicModifyCustomResource), ctx, apiGroup, version, namespace, resourceKind, resourceName, f, data)
 }
 
 // CreateAPIService mocks base method.
-func (m *MockClientInterface) CreateAPIService(arg0 *v13.APIService) (*v13.APIService, error) {
+func (m *MockClientInterface) CreateAPIService(arg0 context.Context, arg1 *v13.APIService) (*v13.APIService, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateAPIService", arg0)
+	ret := m.ctrl.Call(m, "CreateAPIService", arg0, arg1)
 	ret0, _ := ret[0].(*v13.APIService)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateAPIService indicates an expected call of CreateAPIService.
-func (mr *MockClientInterfaceMockRecorder) CreateAPIService(arg0 interface{}) *gomock.Call {
+func (mr *MockClientInterfaceMockRecorder) CreateAPIService(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAPIService", reflect.TypeOf((*MockClientInterface)(nil).CreateAPIService), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAPIService", reflect.TypeOf((*MockClientInterface)(nil).CreateAPIService), arg0, arg1)
 }
 
 // CreateClusterRole mocks base method.
-func (m *MockClientInterface) CreateClusterRole(arg0 *v11.ClusterRole) (*v11.ClusterRole, error) {
+func (m *MockClientInterface) CreateClusterRole(arg0 context.Context, arg1 *v11.ClusterRole) (*v11.ClusterRole, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateClusterRole", arg0)
+	ret := m.ctrl.Call(m, "CreateClusterRole", arg0, arg1)
 	ret0, _ := ret[0].(*v11.ClusterRole)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateClusterRole indicates an expected call of CreateClusterRole.
-func (mr *MockClientInterfaceMockRecorder) CreateClusterRole(arg0 interface{}) *gomock.Call {
+func (mr *MockClientInterfaceMockRecorder) CreateClusterRole(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateClusterRole", reflect.TypeOf((*MockClientInterface)(nil).CreateClusterRole), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateClusterRole", reflect.TypeOf((*MockClientInterface)(nil).CreateClusterRole), arg0, arg1)
 }
 
 // CreateClusterRoleBinding mocks base method.
-func (m *MockClientInterface) CreateClusterRoleBinding(arg0 *v11.ClusterRoleBinding) (*v11.ClusterRoleBinding, error) {
+func (m *MockClientInterface) CreateClusterRoleBinding(arg0 context.Context, arg1 *v11.ClusterRoleBinding) (*v11.ClusterRoleBinding, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateClusterRoleBinding", arg0)
+	ret := m.ctrl.Call(m, "CreateClusterRoleBinding", arg0, arg1)
 	ret0, _ := ret[0].(*v11.ClusterRoleBinding)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateClusterRoleBinding indicates an expected call of CreateClusterRoleBinding.
-func (mr *MockClientInterfaceMockRecorder) CreateClusterRoleBinding(arg0 interface{}) *gomock.Call {
+func (mr *MockClientInterfaceMockRecorder) CreateClusterRoleBinding(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateClusterRoleBinding", reflect.TypeOf((*MockClientInterface)(nil).CreateClusterRoleBinding), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateClusterRoleBinding", reflect.TypeOf((*MockClientInterface)(nil).CreateClusterRoleBinding), arg0, arg1)
 }
 
 // CreateConfigMap mocks base method.
-func (m *MockClientInterface) CreateConfigMap(arg0 *v10.ConfigMap) (*v10.ConfigMap, error) {
+func (m *MockClientInterface) CreateConfigMap(arg0 context.Context, arg1 *v10.ConfigMap) (*v10.ConfigMap, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateConfigMap", arg0)
+	ret := m.ctrl.Call(m, "CreateConfigMap", arg0, arg1)
 	ret0, _ := ret[0].(*v10.ConfigMap)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateConfigMap indicates an expected call of CreateConfigMap.
-func (mr *MockClientInterfaceMockRecorder) CreateConfigMap(arg0 interface{}) *gomock.Call {
+func (mr *MockClientInterfaceMockRecorder) CreateConfigMap(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateConfigMap", reflect.TypeOf((*MockClientInterface)(nil).CreateConfigMap), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateConfigMap", reflect.TypeOf((*MockClientInterface)(nil).CreateConfigMap), arg0, arg1)
 }
 
 // CreateCustomResource mocks base method.
-func (m *MockClientInterface) CreateCustomResource(item *unstructured.Unstructured) error {
+func (m *MockClientInterface) CreateCustomResource(ctx context.Context, item *unstructured.Unstructured) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateCustomResource", item)
+	ret := m.ctrl.Call(m, "CreateCustomResource", ctx, item)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // CreateCustomResource indicates an expected call of CreateCustomResource.
-func (mr *MockClientInterfaceMockRecorder) CreateCustomResource(item interface{}) *gomock.Call {
+func (mr *MockClientInterfaceMockRecorder) CreateCustomResource(ctx, item interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCustomResource", reflect.TypeOf((*MockClientInterface)(nil).CreateCustomResource), item)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCustomResource", reflect.TypeOf((*MockClientInterface)(nil).CreateCustomResource), ctx, item)
 }
 
 // CreateCustomResourceRaw mocks base method.
-func (m *MockClientInterface) CreateCustomResourceRaw(apiGroup, version, namespace, kind string, data []byte) error {
+func (m *MockClientInterface) CreateCustomResourceRaw(ctx context.Context, apiGroup, version, namespace, kind string, data []byte) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateCustomResourceRaw", apiGroup, version, namespace, kind, data)
+	ret := m.ctrl.Call(m, "CreateCustomResourceRaw", ctx, apiGroup, version, namespace, kind, data)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // CreateCustomResourceRaw indicates an expected call of CreateCustomResourceRaw.
-func (mr *MockClientInterfaceMockRecorder) CreateCustomResourceRaw(apiGroup, version, namespace, kind, data interface{}) *gomock.Call {
+func (mr *MockClientInterfaceMockRecorder) CreateCustomResourceRaw(ctx, apiGroup, version, namespace, kind, data interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCustomResourceRaw", reflect.TypeOf((*MockClientInterface)(nil).CreateCustomResourceRaw), apiGroup, version, namespace, kind, data)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCustomResourceRaw", reflect.TypeOf((*MockClientInterface)(nil).CreateCustomResourceRaw), ctx, apiGroup, version, namespace, kind, data)
 }
 
 // CreateCustomResourceRawIfNotFound mocks base method.
-func (m *MockClientInterface) CreateCustomResourceRawIfNotFound(apiGroup, version, namespace, kind, name string, data []byte) (bool, error) {
+func (m *MockClientInterface) CreateCustomResourceRawIfNotFound(ctx context.Context, apiGroup, version, namespace, kind, name string, data []byte) (bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateCustomResourceRawIfNotFound", apiGroup, version, namespace, kind, name, data)
+	ret := m.ctrl.Call(m, "CreateCustomResourceRawIfNotFound", ctx, apiGroup, version, namespace, kind, name, data)
 	ret0, _ := ret[0].(bool)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateCustomResourceRawIfNotFound indicates an expected call of CreateCustomResourceRawIfNotFound.
-func (mr *MockClientInterfaceMockRecorder) CreateCustomResourceRawIfNotFound(apiGroup, version, namespace, kind, name, data interface{}) *gomock.Call {
+func (mr *MockClientInterfaceMockRecorder) CreateCustomResourceRawIfNotFound(ctx, apiGroup, version, namespace, kind, name, data interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCustomResourceRawIfNotFound", reflect.TypeOf((*MockClientInterface)(nil).CreateCustomResourceRawIfNotFound), apiGroup, version, namespace, kind, name, data)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCustomResourceRawIfNotFound", reflect.TypeOf((*MockClientInterface)(nil).CreateCustomResourceRawIfNotFound), ctx, apiGroup, version, namespace, kind, name, data)
 }
 
 // CreateDeployment mocks base method.
-func (m *MockClientInterface) CreateDeployment(arg0 *v1.Deployment) (*v1.Deployment, error) {
+func (m *MockClientInterface) CreateDeployment(arg0 context.Context, arg1 *v1.Deployment) (*v1.Deployment, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateDeployment", arg0)
+	ret := m.ctrl.Call(m, "CreateDeployment", arg0, arg1)
 	ret0, _ := ret[0].(*v1.Deployment)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateDeployment indicates an expected call of CreateDeployment.
-func (mr *MockClientInterfaceMockRecorder) CreateDeployment(arg0 interface{}) *gomock.Call {
+func (mr *MockClientInterfaceMockRecorder) CreateDeployment(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDeployment", reflect.TypeOf((*MockClientInterface)(nil).CreateDeployment), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDeployment", reflect.TypeOf((*MockClientInterface)(nil).CreateDeployment), arg0, arg1)
 }
 
 // CreateOrRollingUpdateDeployment mocks base method.
-func (m *MockClientInterface) CreateOrRollingUpdateDeployment(arg0 *v1.Deployment) (*v1.Deployment, bool, error) {
+func (m *MockClientInterface) CreateOrRollingUpdateDeployment(arg0 context.Context, arg1 *v1.Deployment) (*v1.Deployment, bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateOrRollingUpdateDeployment", arg0)
+	ret := m.ctrl.Call(m, "CreateOrRollingUpdateDeployment", arg0, arg1)
 	ret0, _ := ret[0].(*v1.Deployment)
 	ret1, _ := ret[1].(bool)
 	ret2, _ := ret[2].(error)
@@ -215,432 +216,432 @@ func (m *MockClientInterface) CreateOrRollingUpdateDeployment(arg0 *v1.Deploymen
 }
 
 // CreateOrRollingUpdateDeployment indicates an expected call of CreateOrRollingUpdateDeployment.
-func (mr *MockClientInterfaceMockRecorder) CreateOrRollingUpdateDeployment(arg0 interface{}) *gomock.Call {
+func (mr *MockClientInterfaceMockRecorder) CreateOrRollingUpdateDeployment(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrRollingUpdateDeployment", reflect.TypeOf((*MockClientInterface)(nil).CreateOrRollingUpdateDeployment), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrRollingUpdateDeployment", reflect.TypeOf((*MockClientInterface)(nil).CreateOrRollingUpdateDeployment), arg0, arg1)
 }
 
 // CreateOrUpdateCustomeResourceRaw mocks base method.
-func (m *MockClientInterface) CreateOrUpdateCustomeResourceRaw(apiGroup, version, namespace, resourceKind, resourceName string, data []byte) error {
+func (m *MockClientInterface) CreateOrUpdateCustomeResourceRaw(ctx context.Context, apiGroup, version, namespace, resourceKind, resourceName string, data []byte) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateOrUpdateCustomeResourceRaw", apiGroup, version, namespace, resourceKind, resourceName, data)
+	ret := m.ctrl.Call(m, "CreateOrUpdateCustomeResourceRaw", ctx, apiGroup, version, namespace, resourceKind, resourceName, data)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // CreateOrUpdateCustomeResourceRaw indicates an expected call of CreateOrUpdateCustomeResourceRaw.
-func (mr *MockClientInterfaceMockRecorder) CreateOrUpdateCustomeResourceRaw(apiGroup, version, namespace, resourceKind, resourceName, data interface{}) *gomock.Call {
+func (mr *MockClientInterfaceMockRecorder) CreateOrUpdateCustomeResourceRaw(ctx, apiGroup, version, namespace, resourceKind, resourceName, data interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrUpdateCustomeResourceRaw", reflect.TypeOf((*MockClientInterface)(nil).CreateOrUpdateCustomeResourceRaw), apiGroup, version, namespace, resourceKind, resourceName, data)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrUpdateCustomeResourceRaw", reflect.TypeOf((*MockClientInterface)(nil).CreateOrUpdateCustomeResourceRaw), ctx, apiGroup, version, namespace, resourceKind, resourceName, data)
 }
 
 // CreateRole mocks base method.
-func (m *MockClientInterface) CreateRole(arg0 *v11.Role) (*v11.Role, error) {
+func (m *MockClientInterface) CreateRole(arg0 context.Context, arg1 *v11.Role) (*v11.Role, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateRole", arg0)
+	ret := m.ctrl.Call(m, "CreateRole", arg0, arg1)
 	ret0, _ := ret[0].(*v11.Role)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateRole indicates an expected call of CreateRole.
-func (mr *MockClientInterfaceMockRecorder) CreateRole(arg0 interface{}) *gomock.Call {
+func (mr *MockClientInterfaceMockRecorder) CreateRole(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRole", reflect.TypeOf((*MockClientInterface)(nil).CreateRole), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRole", reflect.TypeOf((*MockClientInterface)(nil).CreateRole), arg0, arg1)
 }
 
 // CreateRoleBinding mocks base method.
-func (m *MockClientInterface) CreateRoleBinding(arg0 *v11.RoleBinding) (*v11.RoleBinding, error) {
+func (m *MockClientInterface) CreateRoleBinding(arg0 context.Context, arg1 *v11.RoleBinding) (*v11.RoleBinding, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateRoleBinding", arg0)
+	ret := m.ctrl.Call(m, "CreateRoleBinding", arg0, arg1)
 	ret0, _ := ret[0].(*v11.RoleBinding)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateRoleBinding indicates an expected call of CreateRoleBinding.
-func (mr *MockClientInterfaceMockRecorder) CreateRoleBinding(arg0 interface{}) *gomock.Call {
+func (mr *MockClientInterfaceMockRecorder) CreateRoleBinding(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRoleBinding", reflect.TypeOf((*MockClientInterface)(nil).CreateRoleBinding), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRoleBinding", reflect.TypeOf((*MockClientInterface)(nil).CreateRoleBinding), arg0, arg1)
 }
 
 // CreateSecret mocks base method.
-func (m *MockClientInterface) CreateSecret(arg0 *v10.Secret) (*v10.Secret, error) {
+func (m *MockClientInterface) CreateSecret(arg0 context.Context, arg1 *v10.Secret) (*v10.Secret, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateSecret", arg0)
+	ret := m.ctrl.Call(m, "CreateSecret", arg0, arg1)
 	ret0, _ := ret[0].(*v10.Secret)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateSecret indicates an expected call of CreateSecret.
-func (mr *MockClientInterfaceMockRecorder) CreateSecret(arg0 interface{}) *gomock.Call {
+func (mr *MockClientInterfaceMockRecorder) CreateSecret(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSecret", reflect.TypeOf((*MockClientInterface)(nil).CreateSecret), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSecret", reflect.TypeOf((*MockClientInterface)(nil).CreateSecret), arg0, arg1)
 }
 
 // CreateService mocks base method.
-func (m *MockClientInterface) CreateService(arg0 *v10.Service) (*v10.Service, error) {
+func (m *MockClientInterface) CreateService(arg0 context.Context, arg1 *v10.Service) (*v10.Service, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateService", arg0)
+	ret := m.ctrl.Call(m, "CreateService", arg0, arg1)
 	ret0, _ := ret[0].(*v10.Service)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateService indicates an expected call of CreateService.
-func (mr *MockClientInterfaceMockRecorder) CreateService(arg0 interface{}) *gomock.Call {
+func (mr *MockClientInterfaceMockRecorder) CreateService(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateService", reflect.TypeOf((*MockClientInterface)(nil).CreateService), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateService", reflect.TypeOf((*MockClientInterface)(nil).CreateService), arg0, arg1)
 }
 
 // CreateServiceAccount mocks base method.
-func (m *MockClientInterface) CreateServiceAccount(arg0 *v10.ServiceAccount) (*v10.ServiceAccount, error) {
+func (m *MockClientInterface) CreateServiceAccount(arg0 context.Context, arg1 *v10.ServiceAccount) (*v10.ServiceAccount, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateServiceAccount", arg0)
+	ret := m.ctrl.Call(m, "CreateServiceAccount", arg0, arg1)
 	ret0, _ := ret[0].(*v10.ServiceAccount)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateServiceAccount indicates an expected call of CreateServiceAccount.
-func (mr *MockClientInterfaceMockRecorder) CreateServiceAccount(arg0 interface{}) *gomock.Call {
+func (mr *MockClientInterfaceMockRecorder) CreateServiceAccount(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateServiceAccount", reflect.TypeOf((*MockClientInterface)(nil).CreateServiceAccount), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateServiceAccount", reflect.TypeOf((*MockClientInterface)(nil).CreateServiceAccount), arg0, arg1)
 }
 
 // DeleteAPIService mocks base method.
-func (m *MockClientInterface) DeleteAPIService(name string, options *v12.DeleteOptions) error {
+func (m *MockClientInterface) DeleteAPIService(ctx context.Context, name string, options *v12.DeleteOptions) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteAPIService", name, options)
+	ret := m.ctrl.Call(m, "DeleteAPIService", ctx, name, options)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteAPIService indicates an expected call of DeleteAPIService.
-func (mr *MockClientInterfaceMockRecorder) DeleteAPIService(name, options interface{}) *gomock.Call {
+func (mr *MockClientInterfaceMockRecorder) DeleteAPIService(ctx, name, options interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAPIService", reflect.TypeOf((*MockClientInterface)(nil).DeleteAPIService), name, options)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAPIService", reflect.TypeOf((*MockClientInterface)(nil).DeleteAPIService), ctx, name, options)
 }
 
 // DeleteClusterRole mocks base method.
-func (m *MockClientInterface) DeleteClusterRole(name string, options *v12.DeleteOptions) error {
+func (m *MockClientInterface) DeleteClusterRole(ctx context.Context, name string, options *v12.DeleteOptions) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteClusterRole", name, options)
+	ret := m.ctrl.Call(m, "DeleteClusterRole", ctx, name, options)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteClusterRole indicates an expected call of DeleteClusterRole.
-func (mr *MockClientInterfaceMockRecorder) DeleteClusterRole(name, options interface{}) *gomock.Call {
+func (mr *MockClientInterfaceMockRecorder) DeleteClusterRole(ctx, name, options interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteClusterRole", reflect.TypeOf((*MockClientInterface)(nil).DeleteClusterRole), name, options)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteClusterRole", reflect.TypeOf((*MockClientInterface)(nil).DeleteClusterRole), ctx, name, options)
 }
 
 // DeleteClusterRoleBinding mocks base method.
-func (m *MockClientInterface) DeleteClusterRoleBinding(name string, options *v12.DeleteOptions) error {
+func (m *MockClientInterface) DeleteClusterRoleBinding(ctx context.Context, name string, options *v12.DeleteOptions) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteClusterRoleBinding", name, options)
+	ret := m.ctrl.Call(m, "DeleteClusterRoleBinding", ctx, name, options)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteClusterRoleBinding indicates an expected call of DeleteClusterRoleBinding.
-func (mr *MockClientInterfaceMockRecorder) DeleteClusterRoleBinding(name, options interface{}) *gomock.Call {
+func (mr *MockClientInterfaceMockRecorder) DeleteClusterRoleBinding(ctx, name, options interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteClusterRoleBinding", reflect.TypeOf((*MockClientInterface)(nil).DeleteClusterRoleBinding), name, options)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteClusterRoleBinding", reflect.TypeOf((*MockClientInterface)(nil).DeleteClusterRoleBinding), ctx, name, options)
 }
 
 // DeleteConfigMap mocks base method.
-func (m *MockClientInterface) DeleteConfigMap(namespace, name string, options *v12.DeleteOptions) error {
+func (m *MockClientInterface) DeleteConfigMap(ctx context.Context, namespace, name string, options *v12.DeleteOptions) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteConfigMap", namespace, name, options)
+	ret := m.ctrl.Call(m, "DeleteConfigMap", ctx, namespace, name, options)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteConfigMap indicates an expected call of DeleteConfigMap.
-func (mr *MockClientInterfaceMockRecorder) DeleteConfigMap(namespace, name, options interface{}) *gomock.Call {
+func (mr *MockClientInterfaceMockRecorder) DeleteConfigMap(ctx, namespace, name, options interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteConfigMap", reflect.TypeOf((*MockClientInterface)(nil).DeleteConfigMap), namespace, name, options)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteConfigMap", reflect.TypeOf((*MockClientInterface)(nil).DeleteConfigMap), ctx, namespace, name, options)
 }
 
 // DeleteCustomResource mocks base method.
-func (m *MockClientInterface) DeleteCustomResource(apiGroup, version, namespace, resourceKind, resourceName string) error {
+func (m *MockClientInterface) DeleteCustomResource(ctx context.Context, apiGroup, version, namespace, resourceKind, resourceName string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteCustomResource", apiGroup, version, namespace, resourceKind, resourceName)
+	ret := m.ctrl.Call(m, "DeleteCustomResource", ctx, apiGroup, version, namespace, resourceKind, resourceName)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteCustomResource indicates an expected call of DeleteCustomResource.
-func (mr *MockClientInterfaceMockRecorder) DeleteCustomResource(apiGroup, version, namespace, resourceKind, resourceName interface{}) *gomock.Call {
+func (mr *MockClientInterfaceMockRecorder) DeleteCustomResource(ctx, apiGroup, version, namespace, resourceKind, resourceName interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCustomResource", reflect.TypeOf((*MockClientInterface)(nil).DeleteCustomResource), apiGroup, version, namespace, resourceKind, resourceName)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCustomResource", reflect.TypeOf((*MockClientInterface)(nil).DeleteCustomResource), ctx, apiGroup, version, namespace, resourceKind, resourceName)
 }
 
 // DeleteDeployment mocks base method.
-func (m *MockClientInterface) DeleteDeployment(namespace, name string, options *v12.DeleteOptions) error {
+func (m *MockClientInterface) DeleteDeployment(ctx context.Context, namespace, name string, options *v12.DeleteOptions) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteDeployment", namespace, name, options)
+	ret := m.ctrl.Call(m, "DeleteDeployment", ctx, namespace, name, options)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteDeployment indicates an expected call of DeleteDeployment.
-func (mr *MockClientInterfaceMockRecorder) DeleteDeployment(namespace, name, options interface{}) *gomock.Call {
+func (mr *MockClientInterfaceMockRecorder) DeleteDeployment(ctx, namespace, name, options interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDeployment", reflect.TypeOf((*MockClientInterface)(nil).DeleteDeployment), namespace, name, options)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDeployment", reflect.TypeOf((*MockClientInterface)(nil).DeleteDeployment), ctx, namespace, name, options)
 }
 
 // DeleteRole mocks base method.
-func (m *MockClientInterface) DeleteRole(namespace, name string, options *v12.DeleteOptions) error {
+func (m *MockClientInterface) DeleteRole(ctx context.Context, namespace, name string, options *v12.DeleteOptions) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteRole", namespace, name, options)
+	ret := m.ctrl.Call(m, "DeleteRole", ctx, namespace, name, options)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteRole indicates an expected call of DeleteRole.
-func (mr *MockClientInterfaceMockRecorder) DeleteRole(namespace, name, options interface{}) *gomock.Call {
+func (mr *MockClientInterfaceMockRecorder) DeleteRole(ctx, namespace, name, options interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRole", reflect.TypeOf((*MockClientInterface)(nil).DeleteRole), namespace, name, options)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRole", reflect.TypeOf((*MockClientInterface)(nil).DeleteRole), ctx, namespace, name, options)
 }
 
 // DeleteRoleBinding mocks base method.
-func (m *MockClientInterface) DeleteRoleBinding(namespace, name string, options *v12.DeleteOptions) error {
+func (m *MockClientInterface) DeleteRoleBinding(ctx context.Context, namespace, name string, options *v12.DeleteOptions) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteRoleBinding", namespace, name, options)
+	ret := m.ctrl.Call(m, "DeleteRoleBinding", ctx, namespace, name, options)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteRoleBinding indicates an expected call of DeleteRoleBinding.
-func (mr *MockClientInterfaceMockRecorder) DeleteRoleBinding(namespace, name, options interface{}) *gomock.Call {
+func (mr *MockClientInterfaceMockRecorder) DeleteRoleBinding(ctx, namespace, name, options interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRoleBinding", reflect.TypeOf((*MockClientInterface)(nil).DeleteRoleBinding), namespace, name, options)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRoleBinding", reflect.TypeOf((*MockClientInterface)(nil).DeleteRoleBinding), ctx, namespace, name, options)
 }
 
 // DeleteSecret mocks base method.
-func (m *MockClientInterface) DeleteSecret(namespace, name string, options *v12.DeleteOptions) error {
+func (m *MockClientInterface) DeleteSecret(ctx context.Context, namespace, name string, options *v12.DeleteOptions) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteSecret", namespace, name, options)
+	ret := m.ctrl.Call(m, "DeleteSecret", ctx, namespace, name, options)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteSecret indicates an expected call of DeleteSecret.
-func (mr *MockClientInterfaceMockRecorder) DeleteSecret(namespace, name, options interface{}) *gomock.Call {
+func (mr *MockClientInterfaceMockRecorder) DeleteSecret(ctx, namespace, name, options interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSecret", reflect.TypeOf((*MockClientInterface)(nil).DeleteSecret), namespace, name, options)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSecret", reflect.TypeOf((*MockClientInterface)(nil).DeleteSecret), ctx, namespace, name, options)
 }
 
 // DeleteService mocks base method.
-func (m *MockClientInterface) DeleteService(namespace, name string, options *v12.DeleteOptions) error {
+func (m *MockClientInterface) DeleteService(ctx context.Context, namespace, name string, options *v12.DeleteOptions) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteService", namespace, name, options)
+	ret := m.ctrl.Call(m, "DeleteService", ctx, namespace, name, options)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteService indicates an expected call of DeleteService.
-func (mr *MockClientInterfaceMockRecorder) DeleteService(namespace, name, options interface{}) *gomock.Call {
+func (mr *MockClientInterfaceMockRecorder) DeleteService(ctx, namespace, name, options interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteService", reflect.TypeOf((*MockClientInterface)(nil).DeleteService), namespace, name, options)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteService", reflect.TypeOf((*MockClientInterface)(nil).DeleteService), ctx, namespace, name, options)
 }
 
 // DeleteServiceAccount mocks base method.
-func (m *MockClientInterface) DeleteServiceAccount(namespace, name string, options *v12.DeleteOptions) error {
+func (m *MockClientInterface) DeleteServiceAccount(ctx context.Context, namespace, name string, options *v12.DeleteOptions) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteServiceAccount", namespace, name, options)
+	ret := m.ctrl.Call(m, "DeleteServiceAccount", ctx, namespace, name, options)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteServiceAccount indicates an expected call of DeleteServiceAccount.
-func (mr *MockClientInterfaceMockRecorder) DeleteServiceAccount(namespace, name, options interface{}) *gomock.Call {
+func (mr *MockClientInterfaceMockRecorder) DeleteServiceAccount(ctx, namespace, name, options interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteServiceAccount", reflect.TypeOf((*MockClientInterface)(nil).DeleteServiceAccount), namespace, name, options)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteServiceAccount", reflect.TypeOf((*MockClientInterface)(nil).DeleteServiceAccount), ctx, namespace, name, options)
 }
 
 // GetAPIService mocks base method.
-func (m *MockClientInterface) GetAPIService(name string) (*v13.APIService, error) {
+func (m *MockClientInterface) GetAPIService(ctx context.Context, name string) (*v13.APIService, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAPIService", name)
+	ret := m.ctrl.Call(m, "GetAPIService", ctx, name)
 	ret0, _ := ret[0].(*v13.APIService)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAPIService indicates an expected call of GetAPIService.
-func (mr *MockClientInterfaceMockRecorder) GetAPIService(name interface{}) *gomock.Call {
+func (mr *MockClientInterfaceMockRecorder) GetAPIService(ctx, name interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAPIService", reflect.TypeOf((*MockClientInterface)(nil).GetAPIService), name)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAPIService", reflect.TypeOf((*MockClientInterface)(nil).GetAPIService), ctx, name)
 }
 
 // GetClusterRole mocks base method.
-func (m *MockClientInterface) GetClusterRole(name string) (*v11.ClusterRole, error) {
+func (m *MockClientInterface) GetClusterRole(ctx context.Context, name string) (*v11.ClusterRole, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetClusterRole", name)
+	ret := m.ctrl.Call(m, "GetClusterRole", ctx, name)
 	ret0, _ := ret[0].(*v11.ClusterRole)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetClusterRole indicates an expected call of GetClusterRole.
-func (mr *MockClientInterfaceMockRecorder) GetClusterRole(name interface{}) *gomock.Call {
+func (mr *MockClientInterfaceMockRecorder) GetClusterRole(ctx, name interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClusterRole", reflect.TypeOf((*MockClientInterface)(nil).GetClusterRole), name)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClusterRole", reflect.TypeOf((*MockClientInterface)(nil).GetClusterRole), ctx, name)
 }
 
 // GetClusterRoleBinding mocks base method.
-func (m *MockClientInterface) GetClusterRoleBinding(name string) (*v11.ClusterRoleBinding, error) {
+func (m *MockClientInterface) GetClusterRoleBinding(ctx context.Context, name string) (*v11.ClusterRoleBinding, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetClusterRoleBinding", name)
+	ret := m.ctrl.Call(m, "GetClusterRoleBinding", ctx, name)
 	ret0, _ := ret[0].(*v11.ClusterRoleBinding)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetClusterRoleBinding indicates an expected call of GetClusterRoleBinding.
-func (mr *MockClientInterfaceMockRecorder) GetClusterRoleBinding(name interface{}) *gomock.Call {
+func (mr *MockClientInterfaceMockRecorder) GetClusterRoleBinding(ctx, name interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClusterRoleBinding", reflect.TypeOf((*MockClientInterface)(nil).GetClusterRoleBinding), name)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClusterRoleBinding", reflect.TypeOf((*MockClientInterface)(nil).GetClusterRoleBinding), ctx, name)
 }
 
 // GetConfigMap mocks base method.
-func (m *MockClientInterface) GetConfigMap(namespace, name string) (*v10.ConfigMap, error) {
+func (m *MockClientInterface) GetConfigMap(ctx context.Context, namespace, name string) (*v10.ConfigMap, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetConfigMap", namespace, name)
+	ret := m.ctrl.Call(m, "GetConfigMap", ctx, namespace, name)
 	ret0, _ := ret[0].(*v10.ConfigMap)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetConfigMap indicates an expected call of GetConfigMap.
-func (mr *MockClientInterfaceMockRecorder) GetConfigMap(namespace, name interface{}) *gomock.Call {
+func (mr *MockClientInterfaceMockRecorder) GetConfigMap(ctx, namespace, name interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConfigMap", reflect.TypeOf((*MockClientInterface)(nil).GetConfigMap), namespace, name)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConfigMap", reflect.TypeOf((*MockClientInterface)(nil).GetConfigMap), ctx, namespace, name)
 }
 
 // GetCustomResource mocks base method.
-func (m *MockClientInterface) GetCustomResource(apiGroup, version, namespace, resourceKind, resourceName string) (*unstructured.Unstructured, error) {
+func (m *MockClientInterface) GetCustomResource(ctx context.Context, apiGroup, version, namespace, resourceKind, resourceName string) (*unstructured.Unstructured, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCustomResource", apiGroup, version, namespace, resourceKind, resourceName)
+	ret := m.ctrl.Call(m, "GetCustomResource", ctx, apiGroup, version, namespace, resourceKind, resourceName)
 	ret0, _ := ret[0].(*unstructured.Unstructured)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetCustomResource indicates an expected call of GetCustomResource.
-func (mr *MockClientInterfaceMockRecorder) GetCustomResource(apiGroup, version, namespace, resourceKind, resourceName interface{}) *gomock.Call {
+func (mr *MockClientInterfaceMockRecorder) GetCustomResource(ctx, apiGroup, version, namespace, resourceKind, resourceName interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCustomResource", reflect.TypeOf((*MockClientInterface)(nil).GetCustomResource), apiGroup, version, namespace, resourceKind, resourceName)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCustomResource", reflect.TypeOf((*MockClientInterface)(nil).GetCustomResource), ctx, apiGroup, version, namespace, resourceKind, resourceName)
 }
 
 // GetCustomResourceRaw mocks base method.
-func (m *MockClientInterface) GetCustomResourceRaw(apiGroup, version, namespace, resourceKind, resourceName string) ([]byte, error) {
+func (m *MockClientInterface) GetCustomResourceRaw(ctx context.Context, apiGroup, version, namespace, resourceKind, resourceName string) ([]byte, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCustomResourceRaw", apiGroup, version, namespace, resourceKind, resourceName)
+	ret := m.ctrl.Call(m, "GetCustomResourceRaw", ctx, apiGroup, version, namespace, resourceKind, resourceName)
 	ret0, _ := ret[0].([]byte)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetCustomResourceRaw indicates an expected call of GetCustomResourceRaw.
-func (mr *MockClientInterfaceMockRecorder) GetCustomResourceRaw(apiGroup, version, namespace, resourceKind, resourceName interface{}) *gomock.Call {
+func (mr *MockClientInterfaceMockRecorder) GetCustomResourceRaw(ctx, apiGroup, version, namespace, resourceKind, resourceName interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCustomResourceRaw", reflect.TypeOf((*MockClientInterface)(nil).GetCustomResourceRaw), apiGroup, version, namespace, resourceKind, resourceName)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCustomResourceRaw", reflect.TypeOf((*MockClientInterface)(nil).GetCustomResourceRaw), ctx, apiGroup, version, namespace, resourceKind, resourceName)
 }
 
 // GetDeployment mocks base method.
-func (m *MockClientInterface) GetDeployment(namespace, name string) (*v1.Deployment, error) {
+func (m *MockClientInterface) GetDeployment(ctx context.Context, namespace, name string) (*v1.Deployment, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetDeployment", namespace, name)
+	ret := m.ctrl.Call(m, "GetDeployment", ctx, namespace, name)
 	ret0, _ := ret[0].(*v1.Deployment)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetDeployment indicates an expected call of GetDeployment.
-func (mr *MockClientInterfaceMockRecorder) GetDeployment(namespace, name interface{}) *gomock.Call {
+func (mr *MockClientInterfaceMockRecorder) GetDeployment(ctx, namespace, name interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeployment", reflect.TypeOf((*MockClientInterface)(nil).GetDeployment), namespace, name)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeployment", reflect.TypeOf((*MockClientInterface)(nil).GetDeployment), ctx, namespace, name)
 }
 
 // GetRole mocks base method.
-func (m *MockClientInterface) GetRole(namespace, name string) (*v11.Role, error) {
+func (m *MockClientInterface) GetRole(ctx context.Context, namespace, name string) (*v11.Role, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetRole", namespace, name)
+	ret := m.ctrl.Call(m, "GetRole", ctx, namespace, name)
 	ret0, _ := ret[0].(*v11.Role)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetRole indicates an expected call of GetRole.
-func (mr *MockClientInterfaceMockRecorder) GetRole(namespace, name interface{}) *gomock.Call {
+func (mr *MockClientInterfaceMockRecorder) GetRole(ctx, namespace, name interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRole", reflect.TypeOf((*MockClientInterface)(nil).GetRole), namespace, name)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRole", reflect.TypeOf((*MockClientInterface)(nil).GetRole), ctx, namespace, name)
 }
 
 // GetRoleBinding mocks base method.
-func (m *MockClientInterface) GetRoleBinding(namespace, name string) (*v11.RoleBinding, error) {
+func (m *MockClientInterface) GetRoleBinding(ctx context.Context, namespace, name string) (*v11.RoleBinding, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetRoleBinding", namespace, name)
+	ret := m.ctrl.Call(m, "GetRoleBinding", ctx, namespace, name)
 	ret0, _ := ret[0].(*v11.RoleBinding)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetRoleBinding indicates an expected call of GetRoleBinding.
-func (mr *MockClientInterfaceMockRecorder) GetRoleBinding(namespace, name interface{}) *gomock.Call {
+func (mr *MockClientInterfaceMockRecorder) GetRoleBinding(ctx, namespace, name interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRoleBinding", reflect.TypeOf((*MockClientInterface)(nil).GetRoleBinding), namespace, name)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRoleBinding", reflect.TypeOf((*MockClientInterface)(nil).GetRoleBinding), ctx, namespace, name)
 }
 
 // GetSecret mocks base method.
-func (m *MockClientInterface) GetSecret(namespace, name string) (*v10.Secret, error) {
+func (m *MockClientInterface) GetSecret(ctx context.Context, namespace, name string) (*v10.Secret, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetSecret", namespace, name)
+	ret := m.ctrl.Call(m, "GetSecret", ctx, namespace, name)
 	ret0, _ := ret[0].(*v10.Secret)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetSecret indicates an expected call of GetSecret.
-func (mr *MockClientInterfaceMockRecorder) GetSecret(namespace, name interface{}) *gomock.Call {
+func (mr *MockClientInterfaceMockRecorder) GetSecret(ctx, namespace, name interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecret", reflect.TypeOf((*MockClientInterface)(nil).GetSecret), namespace, name)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecret", reflect.TypeOf((*MockClientInterface)(nil).GetSecret), ctx, namespace, name)
 }
 
 // GetService mocks base method.
-func (m *MockClientInterface) GetService(namespace, name string) (*v10.Service, error) {
+func (m *MockClientInterface) GetService(ctx context.Context, namespace, name string) (*v10.Service, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetService", namespace, name)
+	ret := m.ctrl.Call(m, "GetService", ctx, namespace, name)
 	ret0, _ := ret[0].(*v10.Service)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetService indicates an expected call of GetService.
-func (mr *MockClientInterfaceMockRecorder) GetService(namespace, name interface{}) *gomock.Call {
+func (mr *MockClientInterfaceMockRecorder) GetService(ctx, namespace, name interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetService", reflect.TypeOf((*MockClientInterface)(nil).GetService), namespace, name)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetService", reflect.TypeOf((*MockClientInterface)(nil).GetService), ctx, namespace, name)
 }
 
 // GetServiceAccount mocks base method.
-func (m *MockClientInterface) GetServiceAccount(namespace, name string) (*v10.ServiceAccount, error) {
+func (m *MockClientInterface) GetServiceAccount(ctx context.Context, namespace, name string) (*v10.ServiceAccount, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetServiceAccount", namespace, name)
+	ret := m.ctrl.Call(m, "GetServiceAccount", ctx, namespace, name)
 	ret0, _ := ret[0].(*v10.ServiceAccount)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetServiceAccount indicates an expected call of GetServiceAccount.
-func (mr *MockClientInterfaceMockRecorder) GetServiceAccount(namespace, name interface{}) *gomock.Call {
+func (mr *MockClientInterfaceMockRecorder) GetServiceAccount(ctx, namespace, name interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetServiceAccount", reflect.TypeOf((*MockClientInterface)(nil).GetServiceAccount), namespace, name)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetServiceAccount", reflect.TypeOf((*MockClientInterface)(nil).GetServiceAccount), ctx, namespace, name)
 }
 
 // KubernetesInterface mocks base method.
@@ -658,39 +659,39 @@ func (mr *MockClientInterfaceMockRecorder) KubernetesInterface() *gomock.Call {
 }
 
 // ListCustomResource mocks base method.
-func (m *MockClientInterface) ListCustomResource(apiGroup, version, namespace, resourceKind string) (*operatorclient.CustomResourceList, error) {
+func (m *MockClientInterface) ListCustomResource(ctx context.Context, apiGroup, version, namespace, resourceKind string) (*operatorclient.CustomResourceList, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListCustomResource", apiGroup, version, namespace, resourceKind)
+	ret := m.ctrl.Call(m, "ListCustomResource", ctx, apiGroup, version, namespace, resourceKind)
 	ret0, _ := ret[0].(*operatorclient.CustomResourceList)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ListCustomResource indicates an expected call of ListCustomResource.
-func (mr *MockClientInterfaceMockRecorder) ListCustomResource(apiGroup, version, namespace, resourceKind interface{}) *gomock.Call {
+func (mr *MockClientInterfaceMockRecorder) ListCustomResource(ctx, apiGroup, version, namespace, resourceKind interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCustomResource", reflect.TypeOf((*MockClientInterface)(nil).ListCustomResource), apiGroup, version, namespace, resourceKind)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCustomResource", reflect.TypeOf((*MockClientInterface)(nil).ListCustomResource), ctx, apiGroup, version, namespace, resourceKind)
 }
 
 // ListDeploymentsWithLabels mocks base method.
-func (m *MockClientInterface) ListDeploymentsWithLabels(namespace string, labels labels.Set) (*v1.DeploymentList, error) {
+func (m *MockClientInterface) ListDeploymentsWithLabels(ctx context.Context, namespace string, labels labels.Set) (*v1.DeploymentList, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListDeploymentsWithLabels", namespace, labels)
+	ret := m.ctrl.Call(m, "ListDeploymentsWithLabels", ctx, namespace, labels)
 	ret0, _ := ret[0].(*v1.DeploymentList)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ListDeploymentsWithLabels indicates an expected call of ListDeploymentsWithLabels.
-func (mr *MockClientInterfaceMockRecorder) ListDeploymentsWithLabels(namespace, labels interface{}) *gomock.Call {
+func (mr *MockClientInterfaceMockRecorder) ListDeploymentsWithLabels(ctx, namespace, labels interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeploymentsWithLabels", reflect.TypeOf((*MockClientInterface)(nil).ListDeploymentsWithLabels), namespace, labels)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeploymentsWithLabels", reflect.TypeOf((*MockClientInterface)(nil).ListDeploymentsWithLabels), ctx, namespace, labels)
 }
 
 // PatchDeployment mocks base method.
-func (m *MockClientInterface) PatchDeployment(arg0, arg1 *v1.Deployment) (*v1.Deployment, bool, error) {
+func (m *MockClientInterface) PatchDeployment(arg0 context.Context, arg1, arg2 *v1.Deployment) (*v1.Deployment, bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "PatchDeployment", arg0, arg1)
+	ret := m.ctrl.Call(m, "PatchDeployment", arg0, arg1, arg2)
 	ret0, _ := ret[0].(*v1.Deployment)
 	ret1, _ := ret[1].(bool)
 	ret2, _ := ret[2].(error)
@@ -698,15 +699,15 @@ func (m *MockClientInterface) PatchDeployment(arg0, arg1 *v1.Deployment) (*v1.De
 }
 
 // PatchDeployment indicates an expected call of PatchDeployment.
-func (mr *MockClientInterfaceMockRecorder) PatchDeployment(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockClientInterfaceMockRecorder) PatchDeployment(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchDeployment", reflect.TypeOf((*MockClientInterface)(nil).PatchDeployment), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchDeployment", reflect.TypeOf((*MockClientInterface)(nil).PatchDeployment), arg0, arg1, arg2)
 }
 
 // RollingPatchDeployment mocks base method.
-func (m *MockClientInterface) RollingPatchDeployment(arg0, arg1 *v1.Deployment) (*v1.Deployment, bool, error) {
+func (m *MockClientInterface) RollingPatchDeployment(arg0 context.Context, arg1, arg2 *v1.Deployment) (*v1.Deployment, bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RollingPatchDeployment", arg0, arg1)
+	ret := m.ctrl.Call(m, "RollingPatchDeployment", arg0, arg1, arg2)
 	ret0, _ := ret[0].(*v1.Deployment)
 	ret1, _ := ret[1].(bool)
 	ret2, _ := ret[2].(error)
@@ -714,15 +715,15 @@ func (m *MockClientInterface) RollingPatchDeployment(arg0, arg1 *v1.Deployment)
 }
 
 // RollingPatchDeployment indicates an expected call of RollingPatchDeployment.
-func (mr *MockClientInterfaceMockRecorder) RollingPatchDeployment(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockClientInterfaceMockRecorder) RollingPatchDeployment(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RollingPatchDeployment", reflect.TypeOf((*MockClientInterface)(nil).RollingPatchDeployment), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RollingPatchDeployment", reflect.TypeOf((*MockClientInterface)(nil).RollingPatchDeployment), arg0, arg1, arg2)
 }
 
 // RollingPatchDeploymentMigrations mocks base method.
-func (m *MockClientInterface) RollingPatchDeploymentMigrations(namespace, name string, f operatorclient.PatchFunction) (*v1.Deployment, bool, error) {
+func (m *MockClientInterface) RollingPatchDeploymentMigrations(ctx context.Context, namespace, name string, f operatorclient.PatchFunction) (*v1.Deployment, bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RollingPatchDeploymentMigrations", namespace, name, f)
+	ret := m.ctrl.Call(m, "RollingPatchDeploymentMigrations", ctx, namespace, name, f)
 	ret0, _ := ret[0].(*v1.Deployment)
 	ret1, _ := ret[1].(bool)
 	ret2, _ := ret[2].(error)
@@ -730,15 +731,15 @@ func (m *MockClientInterface) RollingPatchDeploymentMigrations(namespace, name s
 }
 
 // RollingPatchDeploymentMigrations indicates an expected call of RollingPatchDeploymentMigrations.
-func (mr *MockClientInterfaceMockRecorder) RollingPatchDeploymentMigrations(namespace, name, f interface{}) *gomock.Call {
+func (mr *MockClientInterfaceMockRecorder) RollingPatchDeploymentMigrations(ctx, namespace, name, f interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RollingPatchDeploymentMigrations", reflect.TypeOf((*MockClientInterface)(nil).RollingPatchDeploymentMigrations), namespace, name, f)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RollingPatchDeploymentMigrations", reflect.TypeOf((*MockClientInterface)(nil).RollingPatchDeploymentMigrations), ctx, namespace, name, f)
 }
 
 // RollingUpdateDeployment mocks base method.
-func (m *MockClientInterface) RollingUpdateDeployment(arg0 *v1.Deployment) (*v1.Deployment, bool, error) {
+func (m *MockClientInterface) RollingUpdateDeployment(arg0 context.Context, arg1 *v1.Deployment) (*v1.Deployment, bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RollingUpdateDeployment", arg0)
+	ret := m.ctrl.Call(m, "RollingUpdateDeployment", arg0, arg1)
 	ret0, _ := ret[0].(*v1.Deployment)
 	ret1, _ := ret[1].(bool)
 	ret2, _ := ret[2].(error)
@@ -746,15 +747,15 @@ func (m *MockClientInterface) RollingUpdateDeployment(arg0 *v1.Deployment) (*v1.
 }
 
 // RollingUpdateDeployment indicates an expected call of RollingUpdateDeployment.
-func (mr *MockClientInterfaceMockRecorder) RollingUpdateDeployment(arg0 interface{}) *gomock.Call {
+func (mr *MockClientInterfaceMockRecorder) RollingUpdateDeployment(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RollingUpdateDeployment", reflect.TypeOf((*MockClientInterface)(nil).RollingUpdateDeployment), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RollingUpdateDeployment", reflect.TypeOf((*MockClientInterface)(nil).RollingUpdateDeployment), arg0, arg1)
 }
 
 // RollingUpdateDeploymentMigrations mocks base method.
-func (m *MockClientInterface) RollingUpdateDeploymentMigrations(namespace, name string, f operatorclient.UpdateFunction) (*v1.Deployment, bool, error) {
+func (m *MockClientInterface) RollingUpdateDeploymentMigrations(ctx context.Context, namespace, name string, f operatorclient.UpdateFunction) (*v1.Deployment, bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RollingUpdateDeploymentMigrations", namespace, name, f)
+	ret := m.ctrl.Call(m, "RollingUpdateDeploymentMigrations", ctx, namespace, name, f)
 	ret0, _ := ret[0].(*v1.Deployment)
 	ret1, _ := ret[1].(bool)
 	ret2, _ := ret[2].(error)
@@ -762,103 +763,103 @@ func (m *MockClientInterface) RollingUpdateDeploymentMigrations(namespace, name
 }
 
 // RollingUpdateDeploymentMigrations indicates an expected call of RollingUpdateDeploymentMigrations.
-func (mr *MockClientInterfaceMockRecorder) RollingUpdateDeploymentMigrations(namespace, name, f interface{}) *gomock.Call {
+func (mr *MockClientInterfaceMockRecorder) RollingUpdateDeploymentMigrations(ctx, namespace, name, f interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RollingUpdateDeploymentMigrations", reflect.TypeOf((*MockClientInterface)(nil).RollingUpdateDeploymentMigrations), namespace, name, f)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RollingUpdateDeploymentMigrations", reflect.TypeOf((*MockClientInterface)(nil).RollingUpdateDeploymentMigrations), ctx, namespace, name, f)
 }
 
 // UpdateAPIService mocks base method.
-func (m *MockClientInterface) UpdateAPIService(modified *v13.APIService) (*v13.APIService, error) {
+func (m *MockClientInterface) UpdateAPIService(ctx context.Context, modified *v13.APIService) (*v13.APIService, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateAPIService", modified)
+	ret := m.ctrl.Call(m, "UpdateAPIService", ctx, modified)
 	ret0, _ := ret[0].(*v13.APIService)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpdateAPIService indicates an expected call of UpdateAPIService.
-func (mr *MockClientInterfaceMockRecorder) UpdateAPIService(modified interface{}) *gomock.Call {
+func (mr *MockClientInterfaceMockRecorder) UpdateAPIService(ctx, modified interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAPIService", reflect.TypeOf((*MockClientInterface)(nil).UpdateAPIService), modified)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAPIService", reflect.TypeOf((*MockClientInterface)(nil).UpdateAPIService), ctx, modified)
 }
 
 // UpdateClusterRole mocks base method.
-func (m *MockClientInterface) UpdateClusterRole(modified *v11.ClusterRole) (*v11.ClusterRole, error) {
+func (m *MockClientInterface) UpdateClusterRole(ctx context.Context, modified *v11.ClusterRole) (*v11.ClusterRole, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateClusterRole", modified)
+	ret := m.ctrl.Call(m, "UpdateClusterRole", ctx, modified)
 	ret0, _ := ret[0].(*v11.ClusterRole)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpdateClusterRole indicates an expected call of UpdateClusterRole.
-func (mr *MockClientInterfaceMockRecorder) UpdateClusterRole(modified interface{}) *gomock.Call {
+func (mr *MockClientInterfaceMockRecorder) UpdateClusterRole(ctx, modified interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateClusterRole", reflect.TypeOf((*MockClientInterface)(nil).UpdateClusterRole), modified)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateClusterRole", reflect.TypeOf((*MockClientInterface)(nil).UpdateClusterRole), ctx, modified)
 }
 
 // UpdateClusterRoleBinding mocks base method.
-func (m *MockClientInterface) UpdateClusterRoleBinding(modified *v11.ClusterRoleBinding) (*v11.ClusterRoleBinding, error) {
+func (m *MockClientInterface) UpdateClusterRoleBinding(ctx context.Context, modified *v11.ClusterRoleBinding) (*v11.ClusterRoleBinding, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateClusterRoleBinding", modified)
+	ret := m.ctrl.Call(m, "UpdateClusterRoleBinding", ctx, modified)
 	ret0, _ := ret[0].(*v11.ClusterRoleBinding)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpdateClusterRoleBinding indicates an expected call of UpdateClusterRoleBinding.
-func (mr *MockClientInterfaceMockRecorder) UpdateClusterRoleBinding(modified interface{}) *gomock.Call {
+func (mr *MockClientInterfaceMockRecorder) UpdateClusterRoleBinding(ctx, modified interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateClusterRoleBinding", reflect.TypeOf((*MockClientInterface)(nil).UpdateClusterRoleBinding), modified)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateClusterRoleBinding", reflect.TypeOf((*MockClientInterface)(nil).UpdateClusterRoleBinding), ctx, modified)
 }
 
 // UpdateConfigMap mocks base method.
-func (m *MockClientInterface) UpdateConfigMap(modified *v10.ConfigMap) (*v10.ConfigMap, error) {
+func (m *MockClientInterface) UpdateConfigMap(ctx context.Context, modified *v10.ConfigMap) (*v10.ConfigMap, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateConfigMap", modified)
+	ret := m.ctrl.Call(m, "UpdateConfigMap", ctx, modified)
 	ret0, _ := ret[0].(*v10.ConfigMap)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpdateConfigMap indicates an expected call of UpdateConfigMap.
-func (mr *MockClientInterfaceMockRecorder) UpdateConfigMap(modified interface{}) *gomock.Call {
+func (mr *MockClientInterfaceMockRecorder) UpdateConfigMap(ctx, modified interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateConfigMap", reflect.TypeOf((*MockClientInterface)(nil).UpdateConfigMap), modified)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateConfigMap", reflect.TypeOf((*MockClientInterface)(nil).UpdateConfigMap), ctx, modified)
 }
 
 // UpdateCustomResource mocks base method.
-func (m *MockClientInterface) UpdateCustomResource(item *unstructured.Unstructured) error {
+func (m *MockClientInterface) UpdateCustomResource(ctx context.Context, item *unstructured.Unstructured) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateCustomResource", item)
+	ret := m.ctrl.Call(m, "UpdateCustomResource", ctx, item)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateCustomResource indicates an expected call of UpdateCustomResource.
-func (mr *MockClientInterfaceMockRecorder) UpdateCustomResource(item interface{}) *gomock.Call {
+func (mr *MockClientInterfaceMockRecorder) UpdateCustomResource(ctx, item interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCustomResource", reflect.TypeOf((*MockClientInterface)(nil).UpdateCustomResource), item)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCustomResource", reflect.TypeOf((*MockClientInterface)(nil).UpdateCustomResource), ctx, item)
 }
 
 // UpdateCustomResourceRaw mocks base method.
-func (m *MockClientInterface) UpdateCustomResourceRaw(apiGroup, version, namespace, resourceKind, resourceName string, data []byte) error {
+func (m *MockClientInterface) UpdateCustomResourceRaw(ctx context.Context, apiGroup, version, namespace, resourceKind, resourceName string, data []byte) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateCustomResourceRaw", apiGroup, version, namespace, resourceKind, resourceName, data)
+	ret := m.ctrl.Call(m, "UpdateCustomResourceRaw", ctx, apiGroup, version, namespace, resourceKind, resourceName, data)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateCustomResourceRaw indicates an expected call of UpdateCustomResourceRaw.
-func (mr *MockClientInterfaceMockRecorder) UpdateCustomResourceRaw(apiGroup, version, namespace, resourceKind, resourceName, data interface{}) *gomock.Call {
+func (mr *MockClientInterfaceMockRecorder) UpdateCustomResourceRaw(ctx, apiGroup, version, namespace, resourceKind, resourceName, data interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCustomResourceRaw", reflect.TypeOf((*MockClientInterface)(nil).UpdateCustomResourceRaw), apiGroup, version, namespace, resourceKind, resourceName, data)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCustomResourceRaw", reflect.TypeOf((*MockClientInterface)(nil).UpdateCustomResourceRaw), ctx, apiGroup, version, namespace, resourceKind, resourceName, data)
 }
 
 // UpdateDeployment mocks base method.
-func (m *MockClientInterface) UpdateDeployment(arg0 *v1.Deployment) (*v1.Deployment, bool, error) {
+func (m *MockClientInterface) UpdateDeployment(arg0 context.Context, arg1 *v1.Deployment) (*v1.Deployment, bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateDeployment", arg0)
+	ret := m.ctrl.Call(m, "UpdateDeployment", arg0, arg1)
 	ret0, _ := ret[0].(*v1.Deployment)
 	ret1, _ := ret[1].(bool)
 	ret2, _ := ret[2].(error)
@@ -866,84 +867,84 @@ func (m *MockClientInterface) UpdateDeployment(arg0 *v1.Deployment) (*v1.Deploym
 }
 
 // UpdateDeployment indicates an expected call of UpdateDeployment.
-func (mr *MockClientInterfaceMockRecorder) UpdateDeployment(arg0 interface{}) *gomock.Call {
+func (mr *MockClientInterfaceMockRecorder) UpdateDeployment(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDeployment", reflect.TypeOf((*MockClientInterface)(nil).UpdateDeployment), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDeployment", reflect.TypeOf((*MockClientInterface)(nil).UpdateDeployment), arg0, arg1)
 }
 
 // UpdateRole mocks base method.
-func (m *MockClientInterface) UpdateRole(modified *v11.Role) (*v11.Role, error) {
+func (m *MockClientInterface) UpdateRole(ctx context.Context, modified *v11.Role) (*v11.Role, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateRole", modified)
+	ret := m.ctrl.Call(m, "UpdateRole", ctx, modified)
 	ret0, _ := ret[0].(*v11.Role)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpdateRole indicates an expected call of UpdateRole.
-func (mr *MockClientInterfaceMockRecorder) UpdateRole(modified interface{}) *gomock.Call {
+func (mr *MockClientInterfaceMockRecorder) UpdateRole(ctx, modified interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRole", reflect.TypeOf((*MockClientInterface)(nil).UpdateRole), modified)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRole", reflect.TypeOf((*MockClientInterface)(nil).UpdateRole), ctx, modified)
 }
 
 // UpdateRoleBinding mocks base method.
-func (m *MockClientInterface) UpdateRoleBinding(modified *v11.RoleBinding) (*v11.RoleBinding, error) {
+func (m *MockClientInterface) UpdateRoleBinding(ctx context.Context, modified *v11.RoleBinding) (*v11.RoleBinding, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateRoleBinding", modified)
+	ret := m.ctrl.Call(m, "UpdateRoleBinding", ctx, modified)
 	ret0, _ := ret[0].(*v11.RoleBinding)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpdateRoleBinding indicates an expected call of UpdateRoleBinding.
-func (mr *MockClientInterfaceMockRecorder) UpdateRoleBinding(modified interface{}) *gomock.Call {
+func (mr *MockClientInterfaceMockRecorder) UpdateRoleBinding(ctx, modified interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRoleBinding", reflect.TypeOf((*MockClientInterface)(nil).UpdateRoleBinding), modified)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRoleBinding", reflect.TypeOf((*MockClientInterface)(nil).UpdateRoleBinding), ctx, modified)
 }
 
 // UpdateSecret mocks base method.
-func (m *MockClientInterface) UpdateSecret(modified *v10.Secret) (*v10.Secret, error) {
+func (m *MockClientInterface) UpdateSecret(ctx context.Context, modified *v10.Secret) (*v10.Secret, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateSecret", modified)
+	ret := m.ctrl.Call(m, "UpdateSecret", ctx, modified)
 	ret0, _ := ret[0].(*v10.Secret)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpdateSecret indicates an expected call of UpdateSecret.
-func (mr *MockClientInterfaceMockRecorder) UpdateSecret(modified interface{}) *gomock.Call {
+func (mr *MockClientInterfaceMockRecorder) UpdateSecret(ctx, modified interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSecret", reflect.TypeOf((*MockClientInterface)(nil).UpdateSecret), modified)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSecret", reflect.TypeOf((*MockClientInterface)(nil).UpdateSecret), ctx, modified)
 }
 
 // UpdateService mocks base method.
-func (m *MockClientInterface) UpdateService(modified *v10.Service) (*v10.Service, error) {
+func (m *MockClientInterface) UpdateService(ctx context.Context, modified *v10.Service) (*v10.Service, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateService", modified)
+	ret := m.ctrl.Call(m, "UpdateService", ctx, modified)
 	ret0, _ := ret[0].(*v10.Service)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpdateService indicates an expected call of UpdateService.
-func (mr *MockClientInterfaceMockRecorder) UpdateService(modified interface{}) *gomock.Call {
+func (mr *MockClientInterfaceMockRecorder) UpdateService(ctx, modified interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateService", reflect.TypeOf((*MockClientInterface)(nil).UpdateService), modified)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateService", reflect.TypeOf((*MockClientInterface)(nil).UpdateService), ctx, modified)
 }
 
 // UpdateServiceAccount mocks base method.
-func (m *MockClientInterface) UpdateServiceAccount(modified *v10.ServiceAccount) (*v10.ServiceAccount, error) {
+func (m *MockClientInterface) UpdateServiceAccount(ctx context.Context, modified *v10.ServiceAccount) (*v10.ServiceAccount, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateServiceAccount", modified)
+	ret := m.ctrl.Call(m, "UpdateServiceAccount", ctx, modified)
 	ret0, _ := ret[0].(*v10.ServiceAccount)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpdateServiceAccount indicates an expected call of UpdateServiceAccount.
-func (mr *MockClientInterfaceMockRecorder) UpdateServiceAccount(modified interface{}) *gomock.Call {
+func (mr *MockClientInterfaceMockRecorder) UpdateServiceAccount(ctx, modified interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateServiceAccount", reflect.TypeOf((*MockClientInterface)(nil).UpdateServiceAccount), modified)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateServiceAccount", reflect.TypeOf((*MockClientInterface)(nil).UpdateServiceAccount), ctx, modified)
 }
 
 // MockCustomResourceClient is a mock of CustomResourceClient interface.
@@ -970,161 +971,161 @@ func (m *MockCustomResourceClient) EXPECT() *MockCustomResourceClientMockRecorde
 }
 
 // AtomicModifyCustomResource mocks base method.
-func (m *MockCustomResourceClient) AtomicModifyCustomResource(apiGroup, version, namespace, resourceKind, resourceName string, f operatorclient.CustomResourceModifier, data interface{}) error {
+func (m *MockCustomResourceClient) AtomicModifyCustomResource(ctx context.Context, apiGroup, version, namespace, resourceKind, resourceName string, f operatorclient.CustomResourceModifier, data interface{}) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AtomicModifyCustomResource", apiGroup, version, namespace, resourceKind, resourceName, f, data)
+	ret := m.ctrl.Call(m, "AtomicModifyCustomResource", ctx, apiGroup, version, namespace, resourceKind, resourceName, f, data)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // AtomicModifyCustomResource indicates an expected call of AtomicModifyCustomResource.
-func (mr *MockCustomResourceClientMockRecorder) AtomicModifyCustomResource(apiGroup, version, namespace, resourceKind, resourceName, f, data interface{}) *gomock.Call {
+func (mr *MockCustomResourceClientMockRecorder) AtomicModifyCustomResource(ctx, apiGroup, version, namespace, resourceKind, resourceName, f, data interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AtomicModifyCustomResource", reflect.TypeOf((*MockCustomResourceClient)(nil).AtomicModifyCustomResource), apiGroup, version, namespace, resourceKind, resourceName, f, data)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AtomicModifyCustomResource", reflect.TypeOf((*MockCustomResourceClient)(nil).AtomicModifyCustomResource), ctx, apiGroup, version, namespace, resourceKind, resourceName, f, data)
 }
 
 // CreateCustomResource mocks base method.
-func (m *MockCustomResourceClient) CreateCustomResource(item *unstructured.Unstructured) error {
+func (m *MockCustomResourceClient) CreateCustomResource(ctx context.Context, item *unstructured.Unstructured) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateCustomResource", item)
+	ret := m.ctrl.Call(m, "CreateCustomResource", ctx, item)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // CreateCustomResource indicates an expected call of CreateCustomResource.
-func (mr *MockCustomResourceClientMockRecorder) CreateCustomResource(item interface{}) *gomock.Call {
+func (mr *MockCustomResourceClientMockRecorder) CreateCustomResource(ctx, item interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCustomResource", reflect.TypeOf((*MockCustomResourceClient)(nil).CreateCustomResource), item)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCustomResource", reflect.TypeOf((*MockCustomResourceClient)(nil).CreateCustomResource), ctx, item)
 }
 
 // CreateCustomResourceRaw mocks base method.
-func (m *MockCustomResourceClient) CreateCustomResourceRaw(apiGroup, version, namespace, kind string, data []byte) error {
+func (m *MockCustomResourceClient) CreateCustomResourceRaw(ctx context.Context, apiGroup, version, namespace, kind string, data []byte) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateCustomResourceRaw", apiGroup, version, namespace, kind, data)
+	ret := m.ctrl.Call(m, "CreateCustomResourceRaw", ctx, apiGroup, version, namespace, kind, data)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // CreateCustomResourceRaw indicates an expected call of CreateCustomResourceRaw.
-func (mr *MockCustomResourceClientMockRecorder) CreateCustomResourceRaw(apiGroup, version, namespace, kind, data interface{}) *gomock.Call {
+func (mr *MockCustomResourceClientMockRecorder) CreateCustomResourceRaw(ctx, apiGroup, version, namespace, kind, data interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCustomResourceRaw", reflect.TypeOf((*MockCustomResourceClient)(nil).CreateCustomResourceRaw), apiGroup, version, namespace, kind, data)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCustomResourceRaw", reflect.TypeOf((*MockCustomResourceClient)(nil).CreateCustomResourceRaw), ctx, apiGroup, version, namespace, kind, data)
 }
 
 // CreateCustomResourceRawIfNotFound mocks base method.
-func (m *MockCustomResourceClient) CreateCustomResourceRawIfNotFound(apiGroup, version, namespace, kind, name string, data []byte) (bool, error) {
+func (m *MockCustomResourceClient) CreateCustomResourceRawIfNotFound(ctx context.Context, apiGroup, version, namespace, kind, name string, data []byte) (bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateCustomResourceRawIfNotFound", apiGroup, version, namespace, kind, name, data)
+	ret := m.ctrl.Call(m, "CreateCustomResourceRawIfNotFound", ctx, apiGroup, version, namespace, kind, name, data)
 	ret0, _ := ret[0].(bool)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateCustomResourceRawIfNotFound indicates an expected call of CreateCustomResourceRawIfNotFound.
-func (mr *MockCustomResourceClientMockRecorder) CreateCustomResourceRawIfNotFound(apiGroup, version, namespace, kind, name, data interface{}) *gomock.Call {
+func (mr *MockCustomResourceClientMockRecorder) CreateCustomResourceRawIfNotFound(ctx, apiGroup, version, namespace, kind, name, data interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCustomResourceRawIfNotFound", reflect.TypeOf((*MockCustomResourceClient)(nil).CreateCustomResourceRawIfNotFound), apiGroup, version, namespace, kind, name, data)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCustomResourceRawIfNotFound", reflect.TypeOf((*MockCustomResourceClient)(nil).CreateCustomResourceRawIfNotFound), ctx, apiGroup, version, namespace, kind, name, data)
 }
 
 // CreateOrUpdateCustomeResourceRaw mocks base method.
-func (m *MockCustomResourceClient) CreateOrUpdateCustomeResourceRaw(apiGroup, version, namespace, resourceKind, resourceName string, data []byte) error {
+func (m *MockCustomResourceClient) CreateOrUpdateCustomeResourceRaw(ctx context.Context, apiGroup, version, namespace, resourceKind, resourceName string, data []byte) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateOrUpdateCustomeResourceRaw", apiGroup, version, namespace, resourceKind, resourceName, data)
+	ret := m.ctrl.Call(m, "CreateOrUpdateCustomeResourceRaw", ctx, apiGroup, version, namespace, resourceKind, resourceName, data)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // CreateOrUpdateCustomeResourceRaw indicates an expected call of CreateOrUpdateCustomeResourceRaw.
-func (mr *MockCustomResourceClientMockRecorder) CreateOrUpdateCustomeResourceRaw(apiGroup, version, namespace, resourceKind, resourceName, data interface{}) *gomock.Call {
+func (mr *MockCustomResourceClientMockRecorder) CreateOrUpdateCustomeResourceRaw(ctx, apiGroup, version, namespace, resourceKind, resourceName, data interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrUpdateCustomeResourceRaw", reflect.TypeOf((*MockCustomResourceClient)(nil).CreateOrUpdateCustomeResourceRaw), apiGroup, version, namespace, resourceKind, resourceName, data)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrUpdateCustomeResourceRaw", reflect.TypeOf((*MockCustomResourceClient)(nil).CreateOrUpdateCustomeResourceRaw), ctx, apiGroup, version, namespace, resourceKind, resourceName, data)
 }
 
 // DeleteCustomResource mocks base method.
-func (m *MockCustomResourceClient) DeleteCustomResource(apiGroup, version, namespace, resourceKind, resourceName string) error {
+func (m *MockCustomResourceClient) DeleteCustomResource(ctx context.Context, apiGroup, version, namespace, resourceKind, resourceName string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteCustomResource", apiGroup, version, namespace, resourceKind, resourceName)
+	ret := m.ctrl.Call(m, "DeleteCustomResource", ctx, apiGroup, version, namespace, resourceKind, resourceName)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteCustomResource indicates an expected call of DeleteCustomResource.
-func (mr *MockCustomResourceClientMockRecorder) DeleteCustomResource(apiGroup, version, namespace, resourceKind, resourceName interface{}) *gomock.Call {
+func (mr *MockCustomResourceClientMockRecorder) DeleteCustomResource(ctx, apiGroup, version, namespace, resourceKind, resourceName interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCustomResource", reflect.TypeOf((*MockCustomResourceClient)(nil).DeleteCustomResource), apiGroup, version, namespace, resourceKind, resourceName)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCustomResource", reflect.TypeOf((*MockCustomResourceClient)(nil).DeleteCustomResource), ctx, apiGroup, version, namespace, resourceKind, resourceName)
 }
 
 // GetCustomResource mocks base method.
-func (m *MockCustomResourceClient) GetCustomResource(apiGroup, version, namespace, resourceKind, resourceName string) (*unstructured.Unstructured, error) {
+func (m *MockCustomResourceClient) GetCustomResource(ctx context.Context, apiGroup, version, namespace, resourceKind, resourceName string) (*unstructured.Unstructured, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCustomResource", apiGroup, version, namespace, resourceKind, resourceName)
+	ret := m.ctrl.Call(m, "GetCustomResource", ctx, apiGroup, version, namespace, resourceKind, resourceName)
 	ret0, _ := ret[0].(*unstructured.Unstructured)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetCustomResource indicates an expected call of GetCustomResource.
-func (mr *MockCustomResourceClientMockRecorder) GetCustomResource(apiGroup, version, namespace, resourceKind, resourceName interface{}) *gomock.Call {
+func (mr *MockCustomResourceClientMockRecorder) GetCustomResource(ctx, apiGroup, version, namespace, resourceKind, resourceName interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCustomResource", reflect.TypeOf((*MockCustomResourceClient)(nil).GetCustomResource), apiGroup, version, namespace, resourceKind, resourceName)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCustomResource", reflect.TypeOf((*MockCustomResourceClient)(nil).GetCustomResource), ctx, apiGroup, version, namespace, resourceKind, resourceName)
 }
 
 // GetCustomResourceRaw mocks base method.
-func (m *MockCustomResourceClient) GetCustomResourceRaw(apiGroup, version, namespace, resourceKind, resourceName string) ([]byte, error) {
+func (m *MockCustomResourceClient) GetCustomResourceRaw(ctx context.Context, apiGroup, version, namespace, resourceKind, resourceName string) ([]byte, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCustomResourceRaw", apiGroup, version, namespace, resourceKind, resourceName)
+	ret := m.ctrl.Call(m, "GetCustomResourceRaw", ctx, apiGroup, version, namespace, resourceKind, resourceName)
 	ret0, _ := ret[0].([]byte)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetCustomResourceRaw indicates an expected call of GetCustomResourceRaw.
-func (mr *MockCustomResourceClientMockRecorder) GetCustomResourceRaw(apiGroup, version, namespace, resourceKind, resourceName interface{}) *gomock.Call {
+func (mr *MockCustomResourceClientMockRecorder) GetCustomResourceRaw(ctx, apiGroup, version, namespace, resourceKind, resourceName interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCustomResourceRaw", reflect.TypeOf((*MockCustomResourceClient)(nil).GetCustomResourceRaw), apiGroup, version, namespace, resourceKind, resourceName)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCustomResourceRaw", reflect.TypeOf((*MockCustomResourceClient)(nil).GetCustomResourceRaw), ctx, apiGroup, version, namespace, resourceKind, resourceName)
 }
 
 // ListCustomResource mocks base method.
-func (m *MockCustomResourceClient) ListCustomResource(apiGroup, version, namespace, resourceKind string) (*operatorclient.CustomResourceList, error) {
+func (m *MockCustomResourceClient) ListCustomResource(ctx context.Context, apiGroup, version, namespace, resourceKind string) (*operatorclient.CustomResourceList, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListCustomResource", apiGroup, version, namespace, resourceKind)
+	ret := m.ctrl.Call(m, "ListCustomResource", ctx, apiGroup, version, namespace, resourceKind)
 	ret0, _ := ret[0].(*operatorclient.CustomResourceList)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ListCustomResource indicates an expected call of ListCustomResource.
-func (mr *MockCustomResourceClientMockRecorder) ListCustomResource(apiGroup, version, namespace, resourceKind interface{}) *gomock.Call {
+func (mr *MockCustomResourceClientMockRecorder) ListCustomResource(ctx, apiGroup, version, namespace, resourceKind interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCustomResource", reflect.TypeOf((*MockCustomResourceClient)(nil).ListCustomResource), apiGroup, version, namespace, resourceKind)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCustomResource", reflect.TypeOf((*MockCustomResourceClient)(nil).ListCustomResource), ctx, apiGroup, version, namespace, resourceKind)
 }
 
 // UpdateCustomResource mocks base method.
-func (m *MockCustomResourceClient) UpdateCustomResource(item *unstructured.Unstructured) error {
+func (m *MockCustomResourceClient) UpdateCustomResource(ctx context.Context, item *unstructured.Unstructured) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateCustomResource", item)
+	ret := m.ctrl.Call(m, "UpdateCustomResource", ctx, item)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateCustomResource indicates an expected call of UpdateCustomResource.
-func (mr *MockCustomResourceClientMockRecorder) UpdateCustomResource(item interface{}) *gomock.Call {
+func (mr *MockCustomResourceClientMockRecorder) UpdateCustomResource(ctx, item interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCustomResource", reflect.TypeOf((*MockCustomResourceClient)(nil).UpdateCustomResource), item)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCustomResource", reflect.TypeOf((*MockCustomResourceClient)(nil).UpdateCustomResource), ctx, item)
 }
 
 // UpdateCustomResourceRaw mocks base method.
-func (m *MockCustomResourceClient) UpdateCustomResourceRaw(apiGroup, version, namespace, resourceKind, resourceName string, data []byte) error {
+func (m *MockCustomResourceClient) UpdateCustomResourceRaw(ctx context.Context, apiGroup, version, namespace, resourceKind, resourceName string, data []byte) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateCustomResourceRaw", apiGroup, version, namespace, resourceKind, resourceName, data)
+	ret := m.ctrl.Call(m, "UpdateCustomResourceRaw", ctx, apiGroup, version, namespace, resourceKind, resourceName, data)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateCustomResourceRaw indicates an expected call of UpdateCustomResourceRaw.
-func (mr *MockCustomResourceClientMockRecorder) UpdateCustomResourceRaw(apiGroup, version, namespace, resourceKind, resourceName, data interface{}) *gomock.Call {
+func (mr *MockCustomResourceClientMockRecorder) UpdateCustomResourceRaw(ctx, apiGroup, version, namespace, resourceKind, resourceName, data interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCustomResourceRaw", reflect.TypeOf((*MockCustomResourceClient)(nil).UpdateCustomResourceRaw), apiGroup, version, namespace, resourceKind, resourceName, data)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCustomResourceRaw", reflect.TypeOf((*MockCustomResourceClient)(nil).UpdateCustomResourceRaw), ctx, apiGroup, version, namespace, resourceKind, resourceName, data)
 }
 
 // MockAPIServiceClient is a mock of APIServiceClient interface.
@@ -1151,62 +1152,62 @@ func (m *MockAPIServiceClient) EXPECT() *MockAPIServiceClientMockRecorder {
 }
 
 // CreateAPIService mocks base method.
-func (m *MockAPIServiceClient) CreateAPIService(arg0 *v13.APIService) (*v13.APIService, error) {
+func (m *MockAPIServiceClient) CreateAPIService(arg0 context.Context, arg1 *v13.APIService) (*v13.APIService, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateAPIService", arg0)
+	ret := m.ctrl.Call(m, "CreateAPIService", arg0, arg1)
 	ret0, _ := ret[0].(*v13.APIService)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateAPIService indicates an expected call of CreateAPIService.
-func (mr *MockAPIServiceClientMockRecorder) CreateAPIService(arg0 interface{}) *gomock.Call {
+func (mr *MockAPIServiceClientMockRecorder) CreateAPIService(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAPIService", reflect.TypeOf((*MockAPIServiceClient)(nil).CreateAPIService), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAPIService", reflect.TypeOf((*MockAPIServiceClient)(nil).CreateAPIService), arg0, arg1)
 }
 
 // DeleteAPIService mocks base method.
-func (m *MockAPIServiceClient) DeleteAPIService(name string, options *v12.DeleteOptions) error {
+func (m *MockAPIServiceClient) DeleteAPIService(ctx context.Context, name string, options *v12.DeleteOptions) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteAPIService", name, options)
+	ret := m.ctrl.Call(m, "DeleteAPIService", ctx, name, options)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteAPIService indicates an expected call of DeleteAPIService.
-func (mr *MockAPIServiceClientMockRecorder) DeleteAPIService(name, options interface{}) *gomock.Call {
+func (mr *MockAPIServiceClientMockRecorder) DeleteAPIService(ctx, name, options interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAPIService", reflect.TypeOf((*MockAPIServiceClient)(nil).DeleteAPIService), name, options)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAPIService", reflect.TypeOf((*MockAPIServiceClient)(nil).DeleteAPIService), ctx, name, options)
 }
 
 // GetAPIService mocks base method.
-func (m *MockAPIServiceClient) GetAPIService(name string) (*v13.APIService, error) {
+func (m *MockAPIServiceClient) GetAPIService(ctx context.Context, name string) (*v13.APIService, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAPIService", name)
+	ret := m.ctrl.Call(m, "GetAPIService", ctx, name)
 	ret0, _ := ret[0].(*v13.APIService)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAPIService indicates an expected call of GetAPIService.
-func (mr *MockAPIServiceClientMockRecorder) GetAPIService(name interface{}) *gomock.Call {
+func (mr *MockAPIServiceClientMockRecorder) GetAPIService(ctx, name interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAPIService", reflect.TypeOf((*MockAPIServiceClient)(nil).GetAPIService), name)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAPIService", reflect.TypeOf((*MockAPIServiceClient)(nil).GetAPIService), ctx, name)
 }
 
 // UpdateAPIService mocks base method.
-func (m *MockAPIServiceClient) UpdateAPIService(modified *v13.APIService) (*v13.APIService, error) {
+func (m *MockAPIServiceClient) UpdateAPIService(ctx context.Context, modified *v13.APIService) (*v13.APIService, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateAPIService", modified)
+	ret := m.ctrl.Call(m, "UpdateAPIService", ctx, modified)
 	ret0, _ := ret[0].(*v13.APIService)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpdateAPIService indicates an expected call of UpdateAPIService.
-func (mr *MockAPIServiceClientMockRecorder) UpdateAPIService(modified interface{}) *gomock.Call {
+func (mr *MockAPIServiceClientMockRecorder) UpdateAPIService(ctx, modified interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAPIService", reflect.TypeOf((*MockAPIServiceClient)(nil).UpdateAPIService), modified)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAPIService", reflect.TypeOf((*MockAPIServiceClient)(nil).UpdateAPIService), ctx, modified)
 }
 
 // MockSecretClient is a mock of SecretClient interface.
@@ -1233,62 +1234,62 @@ func (m *MockSecretClient) EXPECT() *MockSecretClientMockRecorder {
 }
 
 // CreateSecret mocks base method.
-func (m *MockSecretClient) CreateSecret(arg0 *v10.Secret) (*v10.Secret, error) {
+func (m *MockSecretClient) CreateSecret(arg0 context.Context, arg1 *v10.Secret) (*v10.Secret, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateSecret", arg0)
+	ret := m.ctrl.Call(m, "CreateSecret", arg0, arg1)
 	ret0, _ := ret[0].(*v10.Secret)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateSecret indicates an expected call of CreateSecret.
-func (mr *MockSecretClientMockRecorder) CreateSecret(arg0 interface{}) *gomock.Call {
+func (mr *MockSecretClientMockRecorder) CreateSecret(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSecret", reflect.TypeOf((*MockSecretClient)(nil).CreateSecret), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSecret", reflect.TypeOf((*MockSecretClient)(nil).CreateSecret), arg0, arg1)
 }
 
 // DeleteSecret mocks base method.
-func (m *MockSecretClient) DeleteSecret(namespace, name string, options *v12.DeleteOptions) error {
+func (m *MockSecretClient) DeleteSecret(ctx context.Context, namespace, name string, options *v12.DeleteOptions) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteSecret", namespace, name, options)
+	ret := m.ctrl.Call(m, "DeleteSecret", ctx, namespace, name, options)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteSecret indicates an expected call of DeleteSecret.
-func (mr *MockSecretClientMockRecorder) DeleteSecret(namespace, name, options interface{}) *gomock.Call {
+func (mr *MockSecretClientMockRecorder) DeleteSecret(ctx, namespace, name, options interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSecret", reflect.TypeOf((*MockSecretClient)(nil).DeleteSecret), namespace, name, options)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSecret", reflect.TypeOf((*MockSecretClient)(nil).DeleteSecret), ctx, namespace, name, options)
 }
 
 // GetSecret mocks base method.
-func (m *MockSecretClient) GetSecret(namespace, name string) (*v10.Secret, error) {
+func (m *MockSecretClient) GetSecret(ctx context.Context, namespace, name string) (*v10.Secret, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetSecret", namespace, name)
+	ret := m.ctrl.Call(m, "GetSecret", ctx, namespace, name)
 	ret0, _ := ret[0].(*v10.Secret)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetSecret indicates an expected call of GetSecret.
-func (mr *MockSecretClientMockRecorder) GetSecret(namespace, name interface{}) *gomock.Call {
+func (mr *MockSecretClientMockRecorder) GetSecret(ctx, namespace, name interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecret", reflect.TypeOf((*MockSecretClient)(nil).GetSecret), namespace, name)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecret", reflect.TypeOf((*MockSecretClient)(nil).GetSecret), ctx, namespace, name)
 }
 
 // UpdateSecret mocks base method.
-func (m *MockSecretClient) UpdateSecret(modified *v10.Secret) (*v10.Secret, error) {
+func (m *MockSecretClient) UpdateSecret(ctx context.Context, modified *v10.Secret) (*v10.Secret, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateSecret", modified)
+	ret := m.ctrl.Call(m, "UpdateSecret", ctx, modified)
 	ret0, _ := ret[0].(*v10.Secret)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpdateSecret indicates an expected call of UpdateSecret.
-func (mr *MockSecretClientMockRecorder) UpdateSecret(modified interface{}) *gomock.Call {
+func (mr *MockSecretClientMockRecorder) UpdateSecret(ctx, modified interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSecret", reflect.TypeOf((*MockSecretClient)(nil).UpdateSecret), modified)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSecret", reflect.TypeOf((*MockSecretClient)(nil).UpdateSecret), ctx, modified)
 }
 
 // MockServiceClient is a mock of ServiceClient interface.
@@ -1315,62 +1316,62 @@ func (m *MockServiceClient) EXPECT() *MockServiceClientMockRecorder {
 }
 
 // CreateService mocks base method.
-func (m *MockServiceClient) CreateService(arg0 *v10.Service) (*v10.Service, error) {
+func (m *MockServiceClient) CreateService(arg0 context.Context, arg1 *v10.Service) (*v10.Service, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateService", arg0)
+	ret := m.ctrl.Call(m, "CreateService", arg0, arg1)
 	ret0, _ := ret[0].(*v10.Service)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateService indicates an expected call of CreateService.
-func (mr *MockServiceClientMockRecorder) CreateService(arg0 interface{}) *gomock.Call {
+func (mr *MockServiceClientMockRecorder) CreateService(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateService", reflect.TypeOf((*MockServiceClient)(nil).CreateService), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateService", reflect.TypeOf((*MockServiceClient)(nil).CreateService), arg0, arg1)
 }
 
 // DeleteService mocks base method.
-func (m *MockServiceClient) DeleteService(namespace, name string, options *v12.DeleteOptions) error {
+func (m *MockServiceClient) DeleteService(ctx context.Context, namespace, name string, options *v12.DeleteOptions) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteService", namespace, name, options)
+	ret := m.ctrl.Call(m, "DeleteService", ctx, namespace, name, options)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteService indicates an expected call of DeleteService.
-func (mr *MockServiceClientMockRecorder) DeleteService(namespace, name, options interface{}) *gomock.Call {
+func (mr *MockServiceClientMockRecorder) DeleteService(ctx, namespace, name, options interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteService", reflect.TypeOf((*MockServiceClient)(nil).DeleteService), namespace, name, options)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteService", reflect.TypeOf((*MockServiceClient)(nil).DeleteService), ctx, namespace, name, options)
 }
 
 // GetService mocks base method.
-func (m *MockServiceClient) GetService(namespace, name string) (*v10.Service, error) {
+func (m *MockServiceClient) GetService(ctx context.Context, namespace, name string) (*v10.Service, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetService", namespace, name)
+	ret := m.ctrl.Call(m, "GetService", ctx, namespace, name)
 	ret0, _ := ret[0].(*v10.Service)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetService indicates an expected call of GetService.
-func (mr *MockServiceClientMockRecorder) GetService(namespace, name interface{}) *gomock.Call {
+func (mr *MockServiceClientMockRecorder) GetService(ctx, namespace, name interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetService", reflect.TypeOf((*MockServiceClient)(nil).GetService), namespace, name)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetService", reflect.TypeOf((*MockServiceClient)(nil).GetService), ctx, namespace, name)
 }
 
 // UpdateService mocks base method.
-func (m *MockServiceClient) UpdateService(modified *v10.Service) (*v10.Service, error) {
+func (m *MockServiceClient) UpdateService(ctx context.Context, modified *v10.Service) (*v10.Service, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateService", modified)
+	ret := m.ctrl.Call(m, "UpdateService", ctx, modified)
 	ret0, _ := ret[0].(*v10.Service)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpdateService indicates an expected call of UpdateService.
-func (mr *MockServiceClientMockRecorder) UpdateService(modified interface{}) *gomock.Call {
+func (mr *MockServiceClientMockRecorder) UpdateService(ctx, modified interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateService", reflect.TypeOf((*MockServiceClient)(nil).UpdateService), modified)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateService", reflect.TypeOf((*MockServiceClient)(nil).UpdateService), ctx, modified)
 }
 
 // MockServiceAccountClient is a mock of ServiceAccountClient interface.
@@ -1397,62 +1398,62 @@ func (m *MockServiceAccountClient) EXPECT() *MockServiceAccountClientMockRecorde
 }
 
 // CreateServiceAccount mocks base method.
-func (m *MockServiceAccountClient) CreateServiceAccount(arg0 *v10.ServiceAccount) (*v10.ServiceAccount, error) {
+func (m *MockServiceAccountClient) CreateServiceAccount(arg0 context.Context, arg1 *v10.ServiceAccount) (*v10.ServiceAccount, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateServiceAccount", arg0)
+	ret := m.ctrl.Call(m, "CreateServiceAccount", arg0, arg1)
 	ret0, _ := ret[0].(*v10.ServiceAccount)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateServiceAccount indicates an expected call of CreateServiceAccount.
-func (mr *MockServiceAccountClientMockRecorder) CreateServiceAccount(arg0 interface{}) *gomock.Call {
+func (mr *MockServiceAccountClientMockRecorder) CreateServiceAccount(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateServiceAccount", reflect.TypeOf((*MockServiceAccountClient)(nil).CreateServiceAccount), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateServiceAccount", reflect.TypeOf((*MockServiceAccountClient)(nil).CreateServiceAccount), arg0, arg1)
 }
 
 // DeleteServiceAccount mocks base method.
-func (m *MockServiceAccountClient) DeleteServiceAccount(namespace, name string, options *v12.DeleteOptions) error {
+func (m *MockServiceAccountClient) DeleteServiceAccount(ctx context.Context, namespace, name string, options *v12.DeleteOptions) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteServiceAccount", namespace, name, options)
+	ret := m.ctrl.Call(m, "DeleteServiceAccount", ctx, namespace, name, options)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteServiceAccount indicates an expected call of DeleteServiceAccount.
-func (mr *MockServiceAccountClientMockRecorder) DeleteServiceAccount(namespace, name, options interface{}) *gomock.Call {
+func (mr *MockServiceAccountClientMockRecorder) DeleteServiceAccount(ctx, namespace, name, options interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteServiceAccount", reflect.TypeOf((*MockServiceAccountClient)(nil).DeleteServiceAccount), namespace, name, options)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteServiceAccount", reflect.TypeOf((*MockServiceAccountClient)(nil).DeleteServiceAccount), ctx, namespace, name, options)
 }
 
 // GetServiceAccount mocks base method.
-func (m *MockServiceAccountClient) GetServiceAccount(namespace, name string) (*v10.ServiceAccount, error) {
+func (m *MockServiceAccountClient) GetServiceAccount(ctx context.Context, namespace, name string) (*v10.ServiceAccount, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetServiceAccount", namespace, name)
+	ret := m.ctrl.Call(m, "GetServiceAccount", ctx, namespace, name)
 	ret0, _ := ret[0].(*v10.ServiceAccount)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetServiceAccount indicates an expected call of GetServiceAccount.
-func (mr *MockServiceAccountClientMockRecorder) GetServiceAccount(namespace, name interface{}) *gomock.Call {
+func (mr *MockServiceAccountClientMockRecorder) GetServiceAccount(ctx, namespace, name interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetServiceAccount", reflect.TypeOf((*MockServiceAccountClient)(nil).GetServiceAccount), namespace, name)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetServiceAccount", reflect.TypeOf((*MockServiceAccountClient)(nil).GetServiceAccount), ctx, namespace, name)
 }
 
 // UpdateServiceAccount mocks base method.
-func (m *MockServiceAccountClient) UpdateServiceAccount(modified *v10.ServiceAccount) (*v10.ServiceAccount, error) {
+func (m *MockServiceAccountClient) UpdateServiceAccount(ctx context.Context, modified *v10.ServiceAccount) (*v10.ServiceAccount, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateServiceAccount", modified)
+	ret := m.ctrl.Call(m, "UpdateServiceAccount", ctx, modified)
 	ret0, _ := ret[0].(*v10.ServiceAccount)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpdateServiceAccount indicates an expected call of UpdateServiceAccount.
-func (mr *MockServiceAccountClientMockRecorder) UpdateServiceAccount(modified interface{}) *gomock.Call {
+func (mr *MockServiceAccountClientMockRecorder) UpdateServiceAccount(ctx, modified interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateServiceAccount", reflect.TypeOf((*MockServiceAccountClient)(nil).UpdateServiceAccount), modified)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateServiceAccount", reflect.TypeOf((*MockServiceAccountClient)(nil).UpdateServiceAccount), ctx, modified)
 }
 
 // MockRoleClient is a mock of RoleClient interface.
@@ -1479,62 +1480,62 @@ func (m *MockRoleClient) EXPECT() *MockRoleClientMockRecorder {
 }
 
 // CreateRole mocks base method.
-func (m *MockRoleClient) CreateRole(arg0 *v11.Role) (*v11.Role, error) {
+func (m *MockRoleClient) CreateRole(arg0 context.Context, arg1 *v11.Role) (*v11.Role, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateRole", arg0)
+	ret := m.ctrl.Call(m, "CreateRole", arg0, arg1)
 	ret0, _ := ret[0].(*v11.Role)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateRole indicates an expected call of CreateRole.
-func (mr *MockRoleClientMockRecorder) CreateRole(arg0 interface{}) *gomock.Call {
+func (mr *MockRoleClientMockRecorder) CreateRole(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRole", reflect.TypeOf((*MockRoleClient)(nil).CreateRole), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRole", reflect.TypeOf((*MockRoleClient)(nil).CreateRole), arg0, arg1)
 }
 
 // DeleteRole mocks base method.
-func (m *MockRoleClient) DeleteRole(namespace, name string, options *v12.DeleteOptions) error {
+func (m *MockRoleClient) DeleteRole(ctx context.Context, namespace, name string, options *v12.DeleteOptions) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteRole", namespace, name, options)
+	ret := m.ctrl.Call(m, "DeleteRole", ctx, namespace, name, options)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteRole indicates an expected call of DeleteRole.
-func (mr *MockRoleClientMockRecorder) DeleteRole(namespace, name, options interface{}) *gomock.Call {
+func (mr *MockRoleClientMockRecorder) DeleteRole(ctx, namespace, name, options interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRole", reflect.TypeOf((*MockRoleClient)(nil).DeleteRole), namespace, name, options)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRole", reflect.TypeOf((*MockRoleClient)(nil).DeleteRole), ctx, namespace, name, options)
 }
 
 // GetRole mocks base method.
-func (m *MockRoleClient) GetRole(namespace, name string) (*v11.Role, error) {
+func (m *MockRoleClient) GetRole(ctx context.Context, namespace, name string) (*v11.Role, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetRole", namespace, name)
+	ret := m.ctrl.Call(m, "GetRole", ctx, namespace, name)
 	ret0, _ := ret[0].(*v11.Role)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetRole indicates an expected call of GetRole.
-func (mr *MockRoleClientMockRecorder) GetRole(namespace, name interface{}) *gomock.Call {
+func (mr *MockRoleClientMockRecorder) GetRole(ctx, namespace, name interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRole", reflect.TypeOf((*MockRoleClient)(nil).GetRole), namespace, name)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRole", reflect.TypeOf((*MockRoleClient)(nil).GetRole), ctx, namespace, name)
 }
 
 // UpdateRole mocks base method.
-func (m *MockRoleClient) UpdateRole(modified *v11.Role) (*v11.Role, error) {
+func (m *MockRoleClient) UpdateRole(ctx context.Context, modified *v11.Role) (*v11.Role, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateRole", modified)
+	ret := m.ctrl.Call(m, "UpdateRole", ctx, modified)
 	ret0, _ := ret[0].(*v11.Role)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpdateRole indicates an expected call of UpdateRole.
-func (mr *MockRoleClientMockRecorder) UpdateRole(modified interface{}) *gomock.Call {
+func (mr *MockRoleClientMockRecorder) UpdateRole(ctx, modified interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRole", reflect.TypeOf((*MockRoleClient)(nil).UpdateRole), modified)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRole", reflect.TypeOf((*MockRoleClient)(nil).UpdateRole), ctx, modified)
 }
 
 // MockRoleBindingClient is a mock of RoleBindingClient interface.
@@ -1561,62 +1562,62 @@ func (m *MockRoleBindingClient) EXPECT() *MockRoleBindingClientMockRecorder {
 }
 
 // CreateRoleBinding mocks base method.
-func (m *MockRoleBindingClient) CreateRoleBinding(arg0 *v11.RoleBinding) (*v11.RoleBinding, error) {
+func (m *MockRoleBindingClient) CreateRoleBinding(arg0 context.Context, arg1 *v11.RoleBinding) (*v11.RoleBinding, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateRoleBinding", arg0)
+	ret := m.ctrl.Call(m, "CreateRoleBinding", arg0, arg1)
 	ret0, _ := ret[0].(*v11.RoleBinding)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateRoleBinding indicates an expected call of CreateRoleBinding.
-func (mr *MockRoleBindingClientMockRecorder) CreateRoleBinding(arg0 interface{}) *gomock.Call {
+func (mr *MockRoleBindingClientMockRecorder) CreateRoleBinding(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRoleBinding", reflect.TypeOf((*MockRoleBindingClient)(nil).CreateRoleBinding), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRoleBinding", reflect.TypeOf((*MockRoleBindingClient)(nil).CreateRoleBinding), arg0, arg1)
 }
 
 // DeleteRoleBinding mocks base method.
-func (m *MockRoleBindingClient) DeleteRoleBinding(namespace, name string, options *v12.DeleteOptions) error {
+func (m *MockRoleBindingClient) DeleteRoleBinding(ctx context.Context, namespace, name string, options *v12.DeleteOptions) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteRoleBinding", namespace, name, options)
+	ret := m.ctrl.Call(m, "DeleteRoleBinding", ctx, namespace, name, options)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteRoleBinding indicates an expected call of DeleteRoleBinding.
-func (mr *MockRoleBindingClientMockRecorder) DeleteRoleBinding(namespace, name, options interface{}) *gomock.Call {
+func (mr *MockRoleBindingClientMockRecorder) DeleteRoleBinding(ctx, namespace, name, options interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRoleBinding", reflect.TypeOf((*MockRoleBindingClient)(nil).DeleteRoleBinding), namespace, name, options)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRoleBinding", reflect.TypeOf((*MockRoleBindingClient)(nil).DeleteRoleBinding), ctx, namespace, name, options)
 }
 
 // GetRoleBinding mocks base method.
-func (m *MockRoleBindingClient) GetRoleBinding(namespace, name string) (*v11.RoleBinding, error) {
+func (m *MockRoleBindingClient) GetRoleBinding(ctx context.Context, namespace, name string) (*v11.RoleBinding, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetRoleBinding", namespace, name)
+	ret := m.ctrl.Call(m, "GetRoleBinding", ctx, namespace, name)
 	ret0, _ := ret[0].(*v11.RoleBinding)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetRoleBinding indicates an expected call of GetRoleBinding.
-func (mr *MockRoleBindingClientMockRecorder) GetRoleBinding(namespace, name interface{}) *gomock.Call {
+func (mr *MockRoleBindingClientMockRecorder) GetRoleBinding(ctx, namespace, name interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRoleBinding", reflect.TypeOf((*MockRoleBindingClient)(nil).GetRoleBinding), namespace, name)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRoleBinding", reflect.TypeOf((*MockRoleBindingClient)(nil).GetRoleBinding), ctx, namespace, name)
 }
 
 // UpdateRoleBinding mocks base method.
-func (m *MockRoleBindingClient) UpdateRoleBinding(modified *v11.RoleBinding) (*v11.RoleBinding, error) {
+func (m *MockRoleBindingClient) UpdateRoleBinding(ctx context.Context, modified *v11.RoleBinding) (*v11.RoleBinding, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateRoleBinding", modified)
+	ret := m.ctrl.Call(m, "UpdateRoleBinding", ctx, modified)
 	ret0, _ := ret[0].(*v11.RoleBinding)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpdateRoleBinding indicates an expected call of UpdateRoleBinding.
-func (mr *MockRoleBindingClientMockRecorder) UpdateRoleBinding(modified interface{}) *gomock.Call {
+func (mr *MockRoleBindingClientMockRecorder) UpdateRoleBinding(ctx, modified interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRoleBinding", reflect.TypeOf((*MockRoleBindingClient)(nil).UpdateRoleBinding), modified)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRoleBinding", reflect.TypeOf((*MockRoleBindingClient)(nil).UpdateRoleBinding), ctx, modified)
 }
 
 // MockClusterRoleClient is a mock of ClusterRoleClient interface.
@@ -1643,62 +1644,62 @@ func (m *MockClusterRoleClient) EXPECT() *MockClusterRoleClientMockRecorder {
 }
 
 // CreateClusterRole mocks base method.
-func (m *MockClusterRoleClient) CreateClusterRole(arg0 *v11.ClusterRole) (*v11.ClusterRole, error) {
+func (m *MockClusterRoleClient) CreateClusterRole(arg0 context.Context, arg1 *v11.ClusterRole) (*v11.ClusterRole, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateClusterRole", arg0)
+	ret := m.ctrl.Call(m, "CreateClusterRole", arg0, arg1)
 	ret0, _ := ret[0].(*v11.ClusterRole)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateClusterRole indicates an expected call of CreateClusterRole.
-func (mr *MockClusterRoleClientMockRecorder) CreateClusterRole(arg0 interface{}) *gomock.Call {
+func (mr *MockClusterRoleClientMockRecorder) CreateClusterRole(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateClusterRole", reflect.TypeOf((*MockClusterRoleClient)(nil).CreateClusterRole), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateClusterRole", reflect.TypeOf((*MockClusterRoleClient)(nil).CreateClusterRole), arg0, arg1)
 }
 
 // DeleteClusterRole mocks base method.
-func (m *MockClusterRoleClient) DeleteClusterRole(name string, options *v12.DeleteOptions) error {
+func (m *MockClusterRoleClient) DeleteClusterRole(ctx context.Context, name string, options *v12.DeleteOptions) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteClusterRole", name, options)
+	ret := m.ctrl.Call(m, "DeleteClusterRole", ctx, name, options)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteClusterRole indicates an expected call of DeleteClusterRole.
-func (mr *MockClusterRoleClientMockRecorder) DeleteClusterRole(name, options interface{}) *gomock.Call {
+func (mr *MockClusterRoleClientMockRecorder) DeleteClusterRole(ctx, name, options interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteClusterRole", reflect.TypeOf((*MockClusterRoleClient)(nil).DeleteClusterRole), name, options)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteClusterRole", reflect.TypeOf((*MockClusterRoleClient)(nil).DeleteClusterRole), ctx, name, options)
 }
 
 // GetClusterRole mocks base method.
-func (m *MockClusterRoleClient) GetClusterRole(name string) (*v11.ClusterRole, error) {
+func (m *MockClusterRoleClient) GetClusterRole(ctx context.Context, name string) (*v11.ClusterRole, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetClusterRole", name)
+	ret := m.ctrl.Call(m, "GetClusterRole", ctx, name)
 	ret0, _ := ret[0].(*v11.ClusterRole)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetClusterRole indicates an expected call of GetClusterRole.
-func (mr *MockClusterRoleClientMockRecorder) GetClusterRole(name interface{}) *gomock.Call {
+func (mr *MockClusterRoleClientMockRecorder) GetClusterRole(ctx, name interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClusterRole", reflect.TypeOf((*MockClusterRoleClient)(nil).GetClusterRole), name)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClusterRole", reflect.TypeOf((*MockClusterRoleClient)(nil).GetClusterRole), ctx, name)
 }
 
 // UpdateClusterRole mocks base method.
-func (m *MockClusterRoleClient) UpdateClusterRole(modified *v11.ClusterRole) (*v11.ClusterRole, error) {
+func (m *MockClusterRoleClient) UpdateClusterRole(ctx context.Context, modified *v11.ClusterRole) (*v11.ClusterRole, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateClusterRole", modified)
+	ret := m.ctrl.Call(m, "UpdateClusterRole", ctx, modified)
 	ret0, _ := ret[0].(*v11.ClusterRole)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpdateClusterRole indicates an expected call of UpdateClusterRole.
-func (mr *MockClusterRoleClientMockRecorder) UpdateClusterRole(modified interface{}) *gomock.Call {
+func (mr *MockClusterRoleClientMockRecorder) UpdateClusterRole(ctx, modified interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateClusterRole", reflect.TypeOf((*MockClusterRoleClient)(nil).UpdateClusterRole), modified)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateClusterRole", reflect.TypeOf((*MockClusterRoleClient)(nil).UpdateClusterRole), ctx, modified)
 }
 
 // MockClusterRoleBindingClient is a mock of ClusterRoleBindingClient interface.
@@ -1725,62 +1726,62 @@ func (m *MockClusterRoleBindingClient) EXPECT() *MockClusterRoleBindingClientMoc
 }
 
 // CreateClusterRoleBinding mocks base method.
-func (m *MockClusterRoleBindingClient) CreateClusterRoleBinding(arg0 *v11.ClusterRoleBinding) (*v11.ClusterRoleBinding, error) {
+func (m *MockClusterRoleBindingClient) CreateClusterRoleBinding(arg0 context.Context, arg1 *v11.ClusterRoleBinding) (*v11.ClusterRoleBinding, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateClusterRoleBinding", arg0)
+	ret := m.ctrl.Call(m, "CreateClusterRoleBinding", arg0, arg1)
 	ret0, _ := ret[0].(*v11.ClusterRoleBinding)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateClusterRoleBinding indicates an expected call of CreateClusterRoleBinding.
-func (mr *MockClusterRoleBindingClientMockRecorder) CreateClusterRoleBinding(arg0 interface{}) *gomock.Call {
+func (mr *MockClusterRoleBindingClientMockRecorder) CreateClusterRoleBinding(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateClusterRoleBinding", reflect.TypeOf((*MockClusterRoleBindingClient)(nil).CreateClusterRoleBinding), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateClusterRoleBinding", reflect.TypeOf((*MockClusterRoleBindingClient)(nil).CreateClusterRoleBinding), arg0, arg1)
 }
 
 // DeleteClusterRoleBinding mocks base method.
-func (m *MockClusterRoleBindingClient) DeleteClusterRoleBinding(name string, options *v12.DeleteOptions) error {
+func (m *MockClusterRoleBindingClient) DeleteClusterRoleBinding(ctx context.Context, name string, options *v12.DeleteOptions) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteClusterRoleBinding", name, options)
+	ret := m.ctrl.Call(m, "DeleteClusterRoleBinding", ctx, name, options)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteClusterRoleBinding indicates an expected call of DeleteClusterRoleBinding.
-func (mr *MockClusterRoleBindingClientMockRecorder) DeleteClusterRoleBinding(name, options interface{}) *gomock.Call {
+func (mr *MockClusterRoleBindingClientMockRecorder) DeleteClusterRoleBinding(ctx, name, options interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteClusterRoleBinding", reflect.TypeOf((*MockClusterRoleBindingClient)(nil).DeleteClusterRoleBinding), name, options)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteClusterRoleBinding", reflect.TypeOf((*MockClusterRoleBindingClient)(nil).DeleteClusterRoleBinding), ctx, name, options)
 }
 
 // GetClusterRoleBinding mocks base method.
-func (m *MockClusterRoleBindingClient) GetClusterRoleBinding(name string) (*v11.ClusterRoleBinding, error) {
+func (m *MockClusterRoleBindingClient) GetClusterRoleBinding(ctx context.Context, name string) (*v11.ClusterRoleBinding, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetClusterRoleBinding", name)
+	ret := m.ctrl.Call(m, "GetClusterRoleBinding", ctx, name)
 	ret0, _ := ret[0].(*v11.ClusterRoleBinding)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetClusterRoleBinding indicates an expected call of GetClusterRoleBinding.
-func (mr *MockClusterRoleBindingClientMockRecorder) GetClusterRoleBinding(name interface{}) *gomock.Call {
+func (mr *MockClusterRoleBindingClientMockRecorder) GetClusterRoleBinding(ctx, name interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClusterRoleBinding", reflect.TypeOf((*MockClusterRoleBindingClient)(nil).GetClusterRoleBinding), name)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClusterRoleBinding", reflect.TypeOf((*MockClusterRoleBindingClient)(nil).GetClusterRoleBinding), ctx, name)
 }
 
 // UpdateClusterRoleBinding mocks base method.
-func (m *MockClusterRoleBindingClient) UpdateClusterRoleBinding(modified *v11.ClusterRoleBinding) (*v11.ClusterRoleBinding, error) {
+func (m *MockClusterRoleBindingClient) UpdateClusterRoleBinding(ctx context.Context, modified *v11.ClusterRoleBinding) (*v11.ClusterRoleBinding, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateClusterRoleBinding", modified)
+	ret := m.ctrl.Call(m, "UpdateClusterRoleBinding", ctx, modified)
 	ret0, _ := ret[0].(*v11.ClusterRoleBinding)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpdateClusterRoleBinding indicates an expected call of UpdateClusterRoleBinding.
-func (mr *MockClusterRoleBindingClientMockRecorder) UpdateClusterRoleBinding(modified interface{}) *gomock.Call {
+func (mr *MockClusterRoleBindingClientMockRecorder) UpdateClusterRoleBinding(ctx, modified interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateClusterRoleBinding", reflect.TypeOf((*MockClusterRoleBindingClient)(nil).UpdateClusterRoleBinding), modified)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateClusterRoleBinding", reflect.TypeOf((*MockClusterRoleBindingClient)(nil).UpdateClusterRoleBinding), ctx, modified)
 }
 
 // MockDeploymentClient is a mock of DeploymentClient interface.
@@ -1807,24 +1808,24 @@ func (m *MockDeploymentClient) EXPECT() *MockDeploymentClientMockRecorder {
 }
 
 // CreateDeployment mocks base method.
-func (m *MockDeploymentClient) CreateDeployment(arg0 *v1.Deployment) (*v1.Deployment, error) {
+func (m *MockDeploymentClient) CreateDeployment(arg0 context.Context, arg1 *v1.Deployment) (*v1.Deployment, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateDeployment", arg0)
+	ret := m.ctrl.Call(m, "CreateDeployment", arg0, arg1)
 	ret0, _ := ret[0].(*v1.Deployment)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateDeployment indicates an expected call of CreateDeployment.
-func (mr *MockDeploymentClientMockRecorder) CreateDeployment(arg0 interface{}) *gomock.Call {
+func (mr *MockDeploymentClientMockRecorder) CreateDeployment(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDeployment", reflect.TypeOf((*MockDeploymentClient)(nil).CreateDeployment), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDeployment", reflect.TypeOf((*MockDeploymentClient)(nil).CreateDeployment), arg0, arg1)
 }
 
 // CreateOrRollingUpdateDeployment mocks base method.
-func (m *MockDeploymentClient) CreateOrRollingUpdateDeployment(arg0 *v1.Deployment) (*v1.Deployment, bool, error) {
+func (m *MockDeploymentClient) CreateOrRollingUpdateDeployment(arg0 context.Context, arg1 *v1.Deployment) (*v1.Deployment, bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateOrRollingUpdateDeployment", arg0)
+	ret := m.ctrl.Call(m, "CreateOrRollingUpdateDeployment", arg0, arg1)
 	ret0, _ := ret[0].(*v1.Deployment)
 	ret1, _ := ret[1].(bool)
 	ret2, _ := ret[2].(error)
@@ -1832,59 +1833,59 @@ func (m *MockDeploymentClient) CreateOrRollingUpdateDeployment(arg0 *v1.Deployme
 }
 
 // CreateOrRollingUpdateDeployment indicates an expected call of CreateOrRollingUpdateDeployment.
-func (mr *MockDeploymentClientMockRecorder) CreateOrRollingUpdateDeployment(arg0 interface{}) *gomock.Call {
+func (mr *MockDeploymentClientMockRecorder) CreateOrRollingUpdateDeployment(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrRollingUpdateDeployment", reflect.TypeOf((*MockDeploymentClient)(nil).CreateOrRollingUpdateDeployment), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrRollingUpdateDeployment", reflect.TypeOf((*MockDeploymentClient)(nil).CreateOrRollingUpdateDeployment), arg0, arg1)
 }
 
 // DeleteDeployment mocks base method.
-func (m *MockDeploymentClient) DeleteDeployment(namespace, name string, options *v12.DeleteOptions) error {
+func (m *MockDeploymentClient) DeleteDeployment(ctx context.Context, namespace, name string, options *v12.DeleteOptions) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteDeployment", namespace, name, options)
+	ret := m.ctrl.Call(m, "DeleteDeployment", ctx, namespace, name, options)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteDeployment indicates an expected call of DeleteDeployment.
-func (mr *MockDeploymentClientMockRecorder) DeleteDeployment(namespace, name, options interface{}) *gomock.Call {
+func (mr *MockDeploymentClientMockRecorder) DeleteDeployment(ctx, namespace, name, options interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDeployment", reflect.TypeOf((*MockDeploymentClient)(nil).DeleteDeployment), namespace, name, options)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDeployment", reflect.TypeOf((*MockDeploymentClient)(nil).DeleteDeployment), ctx, namespace, name, options)
 }
 
 // GetDeployment mocks base method.
-func (m *MockDeploymentClient) GetDeployment(namespace, name string) (*v1.Deployment, error) {
+func (m *MockDeploymentClient) GetDeployment(ctx context.Context, namespace, name string) (*v1.Deployment, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetDeployment", namespace, name)
+	ret := m.ctrl.Call(m, "GetDeployment", ctx, namespace, name)
 	ret0, _ := ret[0].(*v1.Deployment)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetDeployment indicates an expected call of GetDeployment.
-func (mr *MockDeploymentClientMockRecorder) GetDeployment(namespace, name interface{}) *gomock.Call {
+func (mr *MockDeploymentClientMockRecorder) GetDeployment(ctx, namespace, name interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeployment", reflect.TypeOf((*MockDeploymentClient)(nil).GetDeployment), namespace, name)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeployment", reflect.TypeOf((*MockDeploymentClient)(nil).GetDeployment), ctx, namespace, name)
 }
 
 // ListDeploymentsWithLabels mocks base method.
-func (m *MockDeploymentClient) ListDeploymentsWithLabels(namespace string, labels labels.Set) (*v1.DeploymentList, error) {
+func (m *MockDeploymentClient) ListDeploymentsWithLabels(ctx context.Context, namespace string, labels labels.Set) (*v1.DeploymentList, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListDeploymentsWithLabels", namespace, labels)
+	ret := m.ctrl.Call(m, "ListDeploymentsWithLabels", ctx, namespace, labels)
 	ret0, _ := ret[0].(*v1.DeploymentList)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ListDeploymentsWithLabels indicates an expected call of ListDeploymentsWithLabels.
-func (mr *MockDeploymentClientMockRecorder) ListDeploymentsWithLabels(namespace, labels interface{}) *gomock.Call {
+func (mr *MockDeploymentClientMockRecorder) ListDeploymentsWithLabels(ctx, namespace, labels interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeploymentsWithLabels", reflect.TypeOf((*MockDeploymentClient)(nil).ListDeploymentsWithLabels), namespace, labels)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeploymentsWithLabels", reflect.TypeOf((*MockDeploymentClient)(nil).ListDeploymentsWithLabels), ctx, namespace, labels)
 }
 
 // PatchDeployment mocks base method.
-func (m *MockDeploymentClient) PatchDeployment(arg0, arg1 *v1.Deployment) (*v1.Deployment, bool, error) {
+func (m *MockDeploymentClient) PatchDeployment(arg0 context.Context, arg1, arg2 *v1.Deployment) (*v1.Deployment, bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "PatchDeployment", arg0, arg1)
+	ret := m.ctrl.Call(m, "PatchDeployment", arg0, arg1, arg2)
 	ret0, _ := ret[0].(*v1.Deployment)
 	ret1, _ := ret[1].(bool)
 	ret2, _ := ret[2].(error)
@@ -1892,15 +1893,15 @@ func (m *MockDeploymentClient) PatchDeployment(arg0, arg1 *v1.Deployment) (*v1.D
 }
 
 // PatchDeployment indicates an expected call of PatchDeployment.
-func (mr *MockDeploymentClientMockRecorder) PatchDeployment(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockDeploymentClientMockRecorder) PatchDeployment(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchDeployment", reflect.TypeOf((*MockDeploymentClient)(nil).PatchDeployment), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchDeployment", reflect.TypeOf((*MockDeploymentClient)(nil).PatchDeployment), arg0, arg1, arg2)
 }
 
 // RollingPatchDeployment mocks base method.
-func (m *MockDeploymentClient) RollingPatchDeployment(arg0, arg1 *v1.Deployment) (*v1.Deployment, bool, error) {
+func (m *MockDeploymentClient) RollingPatchDeployment(arg0 context.Context, arg1, arg2 *v1.Deployment) (*v1.Deployment, bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RollingPatchDeployment", arg0, arg1)
+	ret := m.ctrl.Call(m, "RollingPatchDeployment", arg0, arg1, arg2)
 	ret0, _ := ret[0].(*v1.Deployment)
 	ret1, _ := ret[1].(bool)
 	ret2, _ := ret[2].(error)
@@ -1908,15 +1909,15 @@ func (m *MockDeploymentClient) RollingPatchDeployment(arg0, arg1 *v1.Deployment)
 }
 
 // RollingPatchDeployment indicates an expected call of RollingPatchDeployment.
-func (mr *MockDeploymentClientMockRecorder) RollingPatchDeployment(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockDeploymentClientMockRecorder) RollingPatchDeployment(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RollingPatchDeployment", reflect.TypeOf((*MockDeploymentClient)(nil).RollingPatchDeployment), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RollingPatchDeployment", reflect.TypeOf((*MockDeploymentClient)(nil).RollingPatchDeployment), arg0, arg1, arg2)
 }
 
 // RollingPatchDeploymentMigrations mocks base method.
-func (m *MockDeploymentClient) RollingPatchDeploymentMigrations(namespace, name string, f operatorclient.PatchFunction) (*v1.Deployment, bool, error) {
+func (m *MockDeploymentClient) RollingPatchDeploymentMigrations(ctx context.Context, namespace, name string, f operatorclient.PatchFunction) (*v1.Deployment, bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RollingPatchDeploymentMigrations", namespace, name, f)
+	ret := m.ctrl.Call(m, "RollingPatchDeploymentMigrations", ctx, namespace, name, f)
 	ret0, _ := ret[0].(*v1.Deployment)
 	ret1, _ := ret[1].(bool)
 	ret2, _ := ret[2].(error)
@@ -1924,15 +1925,15 @@ func (m *MockDeploymentClient) RollingPatchDeploymentMigrations(namespace, name
 }
 
 // RollingPatchDeploymentMigrations indicates an expected call of RollingPatchDeploymentMigrations.
-func (mr *MockDeploymentClientMockRecorder) RollingPatchDeploymentMigrations(namespace, name, f interface{}) *gomock.Call {
+func (mr *MockDeploymentClientMockRecorder) RollingPatchDeploymentMigrations(ctx, namespace, name, f interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RollingPatchDeploymentMigrations", reflect.TypeOf((*MockDeploymentClient)(nil).RollingPatchDeploymentMigrations), namespace, name, f)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RollingPatchDeploymentMigrations", reflect.TypeOf((*MockDeploymentClient)(nil).RollingPatchDeploymentMigrations), ctx, namespace, name, f)
 }
 
 // RollingUpdateDeployment mocks base method.
-func (m *MockDeploymentClient) RollingUpdateDeployment(arg0 *v1.Deployment) (*v1.Deployment, bool, error) {
+func (m *MockDeploymentClient) RollingUpdateDeployment(arg0 context.Context, arg1 *v1.Deployment) (*v1.Deployment, bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RollingUpdateDeployment", arg0)
+	ret := m.ctrl.Call(m, "RollingUpdateDeployment", arg0, arg1)
 	ret0, _ := ret[0].(*v1.Deployment)
 	ret1, _ := ret[1].(bool)
 	ret2, _ := ret[2].(error)
@@ -1940,15 +1941,15 @@ func (m *MockDeploymentClient) RollingUpdateDeployment(arg0 *v1.Deployment) (*v1
 }
 
 // RollingUpdateDeployment indicates an expected call of RollingUpdateDeployment.
-func (mr *MockDeploymentClientMockRecorder) RollingUpdateDeployment(arg0 interface{}) *gomock.Call {
+func (mr *MockDeploymentClientMockRecorder) RollingUpdateDeployment(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RollingUpdateDeployment", reflect.TypeOf((*MockDeploymentClient)(nil).RollingUpdateDeployment), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RollingUpdateDeployment", reflect.TypeOf((*MockDeploymentClient)(nil).RollingUpdateDeployment), arg0, arg1)
 }
 
 // RollingUpdateDeploymentMigrations mocks base method.
-func (m *MockDeploymentClient) RollingUpdateDeploymentMigrations(namespace, name string, f operatorclient.UpdateFunction) (*v1.Deployment, bool, error) {
+func (m *MockDeploymentClient) RollingUpdateDeploymentMigrations(ctx context.Context, namespace, name string, f operatorclient.UpdateFunction) (*v1.Deployment, bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RollingUpdateDeploymentMigrations", namespace, name, f)
+	ret := m.ctrl.Call(m, "RollingUpdateDeploymentMigrations", ctx, namespace, name, f)
 	ret0, _ := ret[0].(*v1.Deployment)
 	ret1, _ := ret[1].(bool)
 	ret2, _ := ret[2].(error)
@@ -1956,15 +1957,15 @@ func (m *MockDeploymentClient) RollingUpdateDeploymentMigrations(namespace, name
 }
 
 // RollingUpdateDeploymentMigrations indicates an expected call of RollingUpdateDeploymentMigrations.
-func (mr *MockDeploymentClientMockRecorder) RollingUpdateDeploymentMigrations(namespace, name, f interface{}) *gomock.Call {
+func (mr *MockDeploymentClientMockRecorder) RollingUpdateDeploymentMigrations(ctx, namespace, name, f interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RollingUpdateDeploymentMigrations", reflect.TypeOf((*MockDeploymentClient)(nil).RollingUpdateDeploymentMigrations), namespace, name, f)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RollingUpdateDeploymentMigrations", reflect.TypeOf((*MockDeploymentClient)(nil).RollingUpdateDeploymentMigrations), ctx, namespace, name, f)
 }
 
 // UpdateDeployment mocks base method.
-func (m *MockDeploymentClient) UpdateDeployment(arg0 *v1.Deployment) (*v1.Deployment, bool, error) {
+func (m *MockDeploymentClient) UpdateDeployment(arg0 context.Context, arg1 *v1.Deployment) (*v1.Deployment, bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateDeployment", arg0)
+	ret := m.ctrl.Call(m, "UpdateDeployment", arg0, arg1)
 	ret0, _ := ret[0].(*v1.Deployment)
 	ret1, _ := ret[1].(bool)
 	ret2, _ := ret[2].(error)
@@ -1972,9 +1973,9 @@ func (m *MockDeploymentClient) UpdateDeployment(arg0 *v1.Deployment) (*v1.Deploy
 }
 
 // UpdateDeployment indicates an expected call of UpdateDeployment.
-func (mr *MockDeploymentClientMockRecorder) UpdateDeployment(arg0 interface{}) *gomock.Call {
+func (mr *MockDeploymentClientMockRecorder) UpdateDeployment(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDeployment", reflect.TypeOf((*MockDeploymentClient)(nil).UpdateDeployment), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDeployment", reflect.TypeOf((*MockDeploymentClient)(nil).UpdateDeployment), arg0, arg1)
 }
 
 // MockConfigMapClient is a mock of ConfigMapClient interface.
@@ -2001,60 +2002,60 @@ func (m *MockConfigMapClient) EXPECT() *MockConfigMapClientMockRecorder {
 }
 
 // CreateConfigMap mocks base method.
-func (m *MockConfigMapClient) CreateConfigMap(arg0 *v10.ConfigMap) (*v10.ConfigMap, error) {
+func (m *MockConfigMapClient) CreateConfigMap(arg0 context.Context, arg1 *v10.ConfigMap) (*v10.ConfigMap, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateConfigMap", arg0)
+	ret := m.ctrl.Call(m, "CreateConfigMap", arg0, arg1)
 	ret0, _ := ret[0].(*v10.ConfigMap)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateConfigMap indicates an expected call of CreateConfigMap.
-func (mr *MockConfigMapClientMockRecorder) CreateConfigMap(arg0 interface{}) *gomock.Call {
+func (mr *MockConfigMapClientMockRecorder) CreateConfigMap(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateConfigMap", reflect.TypeOf((*MockConfigMapClient)(nil).CreateConfigMap), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateConfigMap", reflect.TypeOf((*MockConfigMapClient)(nil).CreateConfigMap), arg0, arg1)
 }
 
 // DeleteConfigMap mocks base method.
-func (m *MockConfigMapClient) DeleteConfigMap(namespace, name string, options *v12.DeleteOptions) error {
+func (m *MockConfigMapClient) DeleteConfigMap(ctx context.Context, namespace, name string, options *v12.DeleteOptions) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteConfigMap", namespace, name, options)
+	ret := m.ctrl.Call(m, "DeleteConfigMap", ctx, namespace, name, options)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteConfigMap indicates an expected call of DeleteConfigMap.
-func (mr *MockConfigMapClientMockRecorder) DeleteConfigMap(namespace, name, options interface{}) *gomock.Call {
+func (mr *MockConfigMapClientMockRecorder) DeleteConfigMap(ctx, namespace, name, options interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteConfigMap", reflect.TypeOf((*MockConfigMapClient)(nil).DeleteConfigMap), namespace, name, options)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteConfigMap", reflect.TypeOf((*MockConfigMapClient)(nil).DeleteConfigMap), ctx, namespace, name, options)
 }
 
 // GetConfigMap mocks base method.
-func (m *MockConfigMapClient) GetConfigMap(namespace, name string) (*v10.ConfigMap, error) {
+func (m *MockConfigMapClient) GetConfigMap(ctx context.Context, namespace, name string) (*v10.ConfigMap, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetConfigMap", namespace, name)
+	ret := m.ctrl.Call(m, "GetConfigMap", ctx, namespace, name)
 	ret0, _ := ret[0].(*v10.ConfigMap)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetConfigMap indicates an expected call of GetConfigMap.
-func (mr *MockConfigMapClientMockRecorder) GetConfigMap(namespace, name interface{}) *gomock.Call {
+func (mr *MockConfigMapClientMockRecorder) GetConfigMap(ctx, namespace, name interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConfigMap", reflect.TypeOf((*MockConfigMapClient)(nil).GetConfigMap), namespace, name)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConfigMap", reflect.TypeOf((*MockConfigMapClient)(nil).GetConfigMap), ctx, namespace, name)
 }
 
 // UpdateConfigMap mocks base method.
-func (m *MockConfigMapClient) UpdateConfigMap(modified *v10.ConfigMap) (*v10.ConfigMap, error) {
+func (m *MockConfigMapClient) UpdateConfigMap(ctx context.Context, modified *v10.ConfigMap) (*v10.ConfigMap, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateConfigMap", modified)
+	ret := m.ctrl.Call(m, "UpdateConfigMap", ctx, modified)
 	ret0, _ := ret[0].(*v10.ConfigMap)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpdateConfigMap indicates an expected call of UpdateConfigMap.
-func (mr *MockConfigMapClientMockRecorder) UpdateConfigMap(modified interface{}) *gomock.Call {
+func (mr *MockConfigMapClientMockRecorder) UpdateConfigMap(ctx, modified interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateConfigMap", reflect.TypeOf((*MockConfigMapClient)(nil).UpdateConfigMap), modified)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateConfigMap", reflect.TypeOf((*MockConfigMapClient)(nil).UpdateConfigMap), ctx, modified)
 }
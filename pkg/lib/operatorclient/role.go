@@ -11,24 +11,24 @@ import (
 )
 
 // CreateRole creates the role.
-func (c *Client) CreateRole(r *rbacv1.Role) (*rbacv1.Role, error) {
-	return c.RbacV1().Roles(r.GetNamespace()).Create(context.TODO(), r, metav1.CreateOptions{})
+func (c *Client) CreateRole(ctx context.Context, r *rbacv1.Role) (*rbacv1.Role, error) {
+	return c.RbacV1().Roles(r.GetNamespace()).Create(ctx, r, metav1.CreateOptions{})
 }
 
 // GetRole returns the existing role.
-func (c *Client) GetRole(namespace, name string) (*rbacv1.Role, error) {
-	return c.RbacV1().Roles(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+func (c *Client) GetRole(ctx context.Context, namespace, name string) (*rbacv1.Role, error) {
+	return c.RbacV1().Roles(namespace).Get(ctx, name, metav1.GetOptions{})
 }
 
 // DeleteRole deletes the role.
-func (c *Client) DeleteRole(namespace, name string, options *metav1.DeleteOptions) error {
-	return c.RbacV1().Roles(namespace).Delete(context.TODO(), name, *options)
+func (c *Client) DeleteRole(ctx context.Context, namespace, name string, options *metav1.DeleteOptions) error {
+	return c.RbacV1().Roles(namespace).Delete(ctx, name, *options)
 }
 
 // UpdateRole will update the given Role resource.
-func (c *Client) UpdateRole(crb *rbacv1.Role) (*rbacv1.Role, error) {
+func (c *Client) UpdateRole(ctx context.Context, crb *rbacv1.Role) (*rbacv1.Role, error) {
 	klog.V(4).Infof("[UPDATE Role]: %s", crb.GetName())
-	oldCrb, err := c.GetRole(crb.GetNamespace(), crb.GetName())
+	oldCrb, err := c.GetRole(ctx, crb.GetNamespace(), crb.GetName())
 	if err != nil {
 		return nil, err
 	}
@@ -36,5 +36,5 @@ func (c *Client) UpdateRole(crb *rbacv1.Role) (*rbacv1.Role, error) {
 	if err != nil {
 		return nil, fmt.Errorf("error creating patch for Role: %v", err)
 	}
-	return c.RbacV1().Roles(crb.GetNamespace()).Patch(context.TODO(), crb.GetName(), types.StrategicMergePatchType, patchBytes, metav1.PatchOptions{})
+	return c.RbacV1().Roles(crb.GetNamespace()).Patch(ctx, crb.GetName(), types.StrategicMergePatchType, patchBytes, metav1.PatchOptions{})
 }
@@ -11,24 +11,24 @@ import (
 )
 
 // CreateRoleBinding creates the roleBinding.
-func (c *Client) CreateRoleBinding(ig *rbacv1.RoleBinding) (*rbacv1.RoleBinding, error) {
-	return c.RbacV1().RoleBindings(ig.GetNamespace()).Create(context.TODO(), ig, metav1.CreateOptions{})
+func (c *Client) CreateRoleBinding(ctx context.Context, ig *rbacv1.RoleBinding) (*rbacv1.RoleBinding, error) {
+	return c.RbacV1().RoleBindings(ig.GetNamespace()).Create(ctx, ig, metav1.CreateOptions{})
 }
 
 // GetRoleBinding returns the existing roleBinding.
-func (c *Client) GetRoleBinding(namespace, name string) (*rbacv1.RoleBinding, error) {
-	return c.RbacV1().RoleBindings(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+func (c *Client) GetRoleBinding(ctx context.Context, namespace, name string) (*rbacv1.RoleBinding, error) {
+	return c.RbacV1().RoleBindings(namespace).Get(ctx, name, metav1.GetOptions{})
 }
 
 // DeleteRoleBinding deletes the roleBinding.
-func (c *Client) DeleteRoleBinding(namespace, name string, options *metav1.DeleteOptions) error {
-	return c.RbacV1().RoleBindings(namespace).Delete(context.TODO(), name, *options)
+func (c *Client) DeleteRoleBinding(ctx context.Context, namespace, name string, options *metav1.DeleteOptions) error {
+	return c.RbacV1().RoleBindings(namespace).Delete(ctx, name, *options)
 }
 
 // UpdateRoleBinding will update the given RoleBinding resource.
-func (c *Client) UpdateRoleBinding(crb *rbacv1.RoleBinding) (*rbacv1.RoleBinding, error) {
+func (c *Client) UpdateRoleBinding(ctx context.Context, crb *rbacv1.RoleBinding) (*rbacv1.RoleBinding, error) {
 	klog.V(4).Infof("[UPDATE RoleBinding]: %s", crb.GetName())
-	oldCrb, err := c.GetRoleBinding(crb.GetNamespace(), crb.GetName())
+	oldCrb, err := c.GetRoleBinding(ctx, crb.GetNamespace(), crb.GetName())
 	if err != nil {
 		return nil, err
 	}
@@ -36,5 +36,5 @@ func (c *Client) UpdateRoleBinding(crb *rbacv1.RoleBinding) (*rbacv1.RoleBinding
 	if err != nil {
 		return nil, fmt.Errorf("error creating patch for RoleBinding: %v", err)
 	}
-	return c.RbacV1().RoleBindings(crb.GetNamespace()).Patch(context.TODO(), crb.GetName(), types.StrategicMergePatchType, patchBytes, metav1.PatchOptions{})
+	return c.RbacV1().RoleBindings(crb.GetNamespace()).Patch(ctx, crb.GetName(), types.StrategicMergePatchType, patchBytes, metav1.PatchOptions{})
 }
@@ -11,24 +11,24 @@ import (
 )
 
 // CreateSecret creates the Secret.
-func (c *Client) CreateSecret(ig *v1.Secret) (*v1.Secret, error) {
-	return c.CoreV1().Secrets(ig.GetNamespace()).Create(context.TODO(), ig, metav1.CreateOptions{})
+func (c *Client) CreateSecret(ctx context.Context, ig *v1.Secret) (*v1.Secret, error) {
+	return c.CoreV1().Secrets(ig.GetNamespace()).Create(ctx, ig, metav1.CreateOptions{})
 }
 
 // GetSecret returns the existing Secret.
-func (c *Client) GetSecret(namespace, name string) (*v1.Secret, error) {
-	return c.CoreV1().Secrets(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+func (c *Client) GetSecret(ctx context.Context, namespace, name string) (*v1.Secret, error) {
+	return c.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
 }
 
 // DeleteSecret deletes the Secret.
-func (c *Client) DeleteSecret(namespace, name string, options *metav1.DeleteOptions) error {
-	return c.CoreV1().Secrets(namespace).Delete(context.TODO(), name, *options)
+func (c *Client) DeleteSecret(ctx context.Context, namespace, name string, options *metav1.DeleteOptions) error {
+	return c.CoreV1().Secrets(namespace).Delete(ctx, name, *options)
 }
 
 // UpdateSecret will update the given Secret resource.
-func (c *Client) UpdateSecret(secret *v1.Secret) (*v1.Secret, error) {
+func (c *Client) UpdateSecret(ctx context.Context, secret *v1.Secret) (*v1.Secret, error) {
 	klog.V(4).Infof("[UPDATE Secret]: %s", secret.GetName())
-	oldSa, err := c.GetSecret(secret.GetNamespace(), secret.GetName())
+	oldSa, err := c.GetSecret(ctx, secret.GetNamespace(), secret.GetName())
 	if err != nil {
 		return nil, err
 	}
@@ -36,5 +36,5 @@ func (c *Client) UpdateSecret(secret *v1.Secret) (*v1.Secret, error) {
 	if err != nil {
 		return nil, fmt.Errorf("error creating patch for Secret: %v", err)
 	}
-	return c.CoreV1().Secrets(secret.GetNamespace()).Patch(context.TODO(), secret.GetName(), types.StrategicMergePatchType, patchBytes, metav1.PatchOptions{})
+	return c.CoreV1().Secrets(secret.GetNamespace()).Patch(ctx, secret.GetName(), types.StrategicMergePatchType, patchBytes, metav1.PatchOptions{})
 }
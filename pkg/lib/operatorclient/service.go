@@ -11,24 +11,24 @@ import (
 )
 
 // CreateService creates the Service.
-func (c *Client) CreateService(ig *v1.Service) (*v1.Service, error) {
-	return c.CoreV1().Services(ig.GetNamespace()).Create(context.TODO(), ig, metav1.CreateOptions{})
+func (c *Client) CreateService(ctx context.Context, ig *v1.Service) (*v1.Service, error) {
+	return c.CoreV1().Services(ig.GetNamespace()).Create(ctx, ig, metav1.CreateOptions{})
 }
 
 // GetService returns the existing Service.
-func (c *Client) GetService(namespace, name string) (*v1.Service, error) {
-	return c.CoreV1().Services(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+func (c *Client) GetService(ctx context.Context, namespace, name string) (*v1.Service, error) {
+	return c.CoreV1().Services(namespace).Get(ctx, name, metav1.GetOptions{})
 }
 
 // DeleteService deletes the Service.
-func (c *Client) DeleteService(namespace, name string, options *metav1.DeleteOptions) error {
-	return c.CoreV1().Services(namespace).Delete(context.TODO(), name, *options)
+func (c *Client) DeleteService(ctx context.Context, namespace, name string, options *metav1.DeleteOptions) error {
+	return c.CoreV1().Services(namespace).Delete(ctx, name, *options)
 }
 
 // UpdateService will update the given Service resource.
-func (c *Client) UpdateService(service *v1.Service) (*v1.Service, error) {
+func (c *Client) UpdateService(ctx context.Context, service *v1.Service) (*v1.Service, error) {
 	klog.V(4).Infof("[UPDATE Service]: %s", service.GetName())
-	old, err := c.GetService(service.GetNamespace(), service.GetName())
+	old, err := c.GetService(ctx, service.GetNamespace(), service.GetName())
 	if err != nil {
 		return nil, err
 	}
@@ -47,5 +47,5 @@ func (c *Client) UpdateService(service *v1.Service) (*v1.Service, error) {
 	if err != nil {
 		return nil, fmt.Errorf("error creating patch for Service: %v", err)
 	}
-	return c.CoreV1().Services(service.GetNamespace()).Patch(context.TODO(), service.GetName(), types.StrategicMergePatchType, patchBytes, metav1.PatchOptions{})
+	return c.CoreV1().Services(service.GetNamespace()).Patch(ctx, service.GetName(), types.StrategicMergePatchType, patchBytes, metav1.PatchOptions{})
 }
@@ -1,6 +1,7 @@
 package operatorclient
 
 import (
+	"context"
 	"testing"
 
 	corev1 "k8s.io/api/core/v1"
@@ -170,7 +171,7 @@ func TestUpdateService(t *testing.T) {
 				Interface: kube,
 			}
 
-			_, err := c.UpdateService(tc.New)
+			_, err := c.UpdateService(context.TODO(), tc.New)
 			require.NoError(err)
 
 			actual := kube.Actions()
@@ -11,24 +11,24 @@ import (
 )
 
 // CreateServiceAccount creates the serviceAccount.
-func (c *Client) CreateServiceAccount(ig *v1.ServiceAccount) (*v1.ServiceAccount, error) {
-	return c.CoreV1().ServiceAccounts(ig.GetNamespace()).Create(context.TODO(), ig, metav1.CreateOptions{})
+func (c *Client) CreateServiceAccount(ctx context.Context, ig *v1.ServiceAccount) (*v1.ServiceAccount, error) {
+	return c.CoreV1().ServiceAccounts(ig.GetNamespace()).Create(ctx, ig, metav1.CreateOptions{})
 }
 
 // GetServiceAccount returns the existing serviceAccount.
-func (c *Client) GetServiceAccount(namespace, name string) (*v1.ServiceAccount, error) {
-	return c.CoreV1().ServiceAccounts(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+func (c *Client) GetServiceAccount(ctx context.Context, namespace, name string) (*v1.ServiceAccount, error) {
+	return c.CoreV1().ServiceAccounts(namespace).Get(ctx, name, metav1.GetOptions{})
 }
 
 // DeleteServiceAccount deletes the serviceAccount.
-func (c *Client) DeleteServiceAccount(namespace, name string, options *metav1.DeleteOptions) error {
-	return c.CoreV1().ServiceAccounts(namespace).Delete(context.TODO(), name, *options)
+func (c *Client) DeleteServiceAccount(ctx context.Context, namespace, name string, options *metav1.DeleteOptions) error {
+	return c.CoreV1().ServiceAccounts(namespace).Delete(ctx, name, *options)
 }
 
 // UpdateServiceAccount will update the given ServiceAccount resource.
-func (c *Client) UpdateServiceAccount(sa *v1.ServiceAccount) (*v1.ServiceAccount, error) {
+func (c *Client) UpdateServiceAccount(ctx context.Context, sa *v1.ServiceAccount) (*v1.ServiceAccount, error) {
 	klog.V(4).Infof("[UPDATE ServiceAccount]: %s", sa.GetName())
-	oldSa, err := c.GetServiceAccount(sa.GetNamespace(), sa.GetName())
+	oldSa, err := c.GetServiceAccount(ctx, sa.GetNamespace(), sa.GetName())
 	if err != nil {
 		return nil, err
 	}
@@ -36,5 +36,5 @@ func (c *Client) UpdateServiceAccount(sa *v1.ServiceAccount) (*v1.ServiceAccount
 	if err != nil {
 		return nil, fmt.Errorf("error creating patch for ServiceAccount: %v", err)
 	}
-	return c.CoreV1().ServiceAccounts(sa.GetNamespace()).Patch(context.TODO(), sa.GetName(), types.StrategicMergePatchType, patchBytes, metav1.PatchOptions{})
+	return c.CoreV1().ServiceAccounts(sa.GetNamespace()).Patch(ctx, sa.GetName(), types.StrategicMergePatchType, patchBytes, metav1.PatchOptions{})
 }
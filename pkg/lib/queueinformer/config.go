@@ -1,6 +1,8 @@
 package queueinformer
 
 import (
+	"time"
+
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	"k8s.io/client-go/discovery"
@@ -19,6 +21,7 @@ type queueInformerConfig struct {
 	indexer  cache.Indexer
 	keyFunc  KeyFunc
 	syncer   kubestate.Syncer
+	workers  int
 }
 
 // Option applies an option to the given queue informer config.
@@ -57,6 +60,8 @@ func (c *queueInformerConfig) validateQueueInformer() (err error) {
 		err = newInvalidConfigError("nil key function")
 	case config.syncer == nil:
 		err = newInvalidConfigError("nil syncer")
+	case config.workers < 0:
+		err = newInvalidConfigError("negative number of workers")
 	}
 
 	return
@@ -160,12 +165,21 @@ func WithSyncer(syncer kubestate.Syncer) Option {
 	}
 }
 
+// WithWorkers sets the number of workers an Operator uses to process the queue of a QueueInformer, overriding the
+// number of workers the Operator uses for each queue. Specifying zero keeps the Operator's number of workers.
+func WithWorkers(workers int) Option {
+	return func(config *queueInformerConfig) {
+		config.workers = workers
+	}
+}
+
 type operatorConfig struct {
 	serverVersion  discovery.ServerVersionInterface
 	queueInformers []*QueueInformer
 	informers      []cache.SharedIndexInformer
 	logger         *logrus.Logger
 	numWorkers     int
+	syncTimeout    time.Duration
 }
 
 type OperatorOption func(*operatorConfig)
@@ -214,6 +228,15 @@ func WithNumWorkers(numWorkers int) OperatorOption {
 	}
 }
 
+// WithSyncTimeout sets the deadline of the context each queue item is synced with, after which the client calls made
+// by the sync are cancelled and the item is requeued. Specifying zero disables the deadline; items are then only
+// cancelled when the Operator is shut down.
+func WithSyncTimeout(syncTimeout time.Duration) OperatorOption {
+	return func(config *operatorConfig) {
+		config.syncTimeout = syncTimeout
+	}
+}
+
 // validate returns an error if the config isn't valid.
 func (c *operatorConfig) validate() (err error) {
 	switch config := c; {
@@ -221,6 +244,8 @@ func (c *operatorConfig) validate() (err error) {
 		err = newInvalidOperatorConfigError("discovery client nil")
 	case config.numWorkers < 1:
 		err = newInvalidOperatorConfigError("must specify at least one worker per queue")
+	case config.syncTimeout < 0:
+		err = newInvalidOperatorConfigError("sync timeout must not be negative")
 	}
 
 	return
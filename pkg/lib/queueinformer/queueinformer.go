@@ -28,6 +28,7 @@ type QueueInformer struct {
 	indexer  cache.Indexer
 	keyFunc  KeyFunc
 	syncer   kubestate.Syncer
+	workers  int
 }
 
 // Sync invokes all registered sync handlers in the QueueInformer's chain
@@ -147,6 +148,7 @@ func newQueueInformerFromConfig(ctx context.Context, config *queueInformerConfig
 		informer:        config.informer,
 		keyFunc:         config.keyFunc,
 		syncer:          config.syncer,
+		workers:         config.workers,
 	}
 
 	// Register event handlers for resource and metrics
@@ -158,27 +160,29 @@ func newQueueInformerFromConfig(ctx context.Context, config *queueInformerConfig
 	return queueInformer, nil
 }
 
-// LegacySyncHandler is a deprecated signature for syncing resources.
-type LegacySyncHandler func(obj interface{}) error
+// SyncHandler syncs resources with the context of the queue item being processed, which is cancelled when the sync
+// exceeds its deadline or the Operator shuts down.
+type SyncHandler func(ctx context.Context, obj interface{}) error
 
 // ToSyncer returns the Syncer equivalent of the sync handler.
-func (l LegacySyncHandler) ToSyncer() kubestate.Syncer {
-	return l.ToSyncerWithDelete(nil)
+func (s SyncHandler) ToSyncer() kubestate.Syncer {
+	return s.ToSyncerWithDelete(nil)
 }
 
-// ToSyncerWithDelete returns the Syncer equivalent of the given sync handler and delete function.
-func (l LegacySyncHandler) ToSyncerWithDelete(onDelete func(obj interface{})) kubestate.Syncer {
+// ToSyncerWithDelete returns the Syncer equivalent of the given sync handler and delete function. The delete function
+// is called with the context of the queue item being processed, like the sync handler.
+func (s SyncHandler) ToSyncerWithDelete(onDelete func(ctx context.Context, obj interface{})) kubestate.Syncer {
 	var syncer kubestate.SyncFunc = func(ctx context.Context, event kubestate.ResourceEvent) error {
 		switch event.Type() {
 		case kubestate.ResourceDeleted:
 			if onDelete != nil {
-				onDelete(event.Resource())
+				onDelete(ctx, event.Resource())
 			}
 		case kubestate.ResourceAdded:
 			// Added and updated are treated the same
 			fallthrough
 		case kubestate.ResourceUpdated:
-			return l(event.Resource())
+			return s(ctx, event.Resource())
 		default:
 			return errors.Errorf("unexpected resource event type: %s", event.Type())
 		}
@@ -188,3 +192,24 @@ func (l LegacySyncHandler) ToSyncerWithDelete(onDelete func(obj interface{})) ku
 
 	return syncer
 }
+
+// LegacySyncHandler is a deprecated signature for syncing resources.
+type LegacySyncHandler func(obj interface{}) error
+
+// ToSyncer returns the Syncer equivalent of the sync handler.
+func (l LegacySyncHandler) ToSyncer() kubestate.Syncer {
+	return l.ToSyncerWithDelete(nil)
+}
+
+// ToSyncerWithDelete returns the Syncer equivalent of the given sync handler and delete function.
+func (l LegacySyncHandler) ToSyncerWithDelete(onDelete func(obj interface{})) kubestate.Syncer {
+	var onDeleteWithContext func(context.Context, interface{})
+	if onDelete != nil {
+		onDeleteWithContext = func(_ context.Context, obj interface{}) {
+			onDelete(obj)
+		}
+	}
+	return SyncHandler(func(_ context.Context, obj interface{}) error {
+		return l(obj)
+	}).ToSyncerWithDelete(onDeleteWithContext)
+}
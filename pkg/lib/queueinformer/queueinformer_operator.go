@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/kubestate"
 	"github.com/pkg/errors"
@@ -30,6 +31,12 @@ type Operator interface {
 	// HasSynced returns true if the Operator's Informers have synced, false otherwise.
 	HasSynced() bool
 
+	// CheckSynced returns an error if the Operator's Informers haven't been started or haven't synced.
+	CheckSynced() error
+
+	// CheckDiscovery returns an error if the Operator can't discover the version of the API server.
+	CheckDiscovery() error
+
 	// RegisterQueueInformer registers the given QueueInformer with the Operator.
 	// This method returns an error if the Operator has already been started.
 	RegisterQueueInformer(queueInformer *QueueInformer) error
@@ -52,6 +59,7 @@ type operator struct {
 	hasSynced        cache.InformerSynced
 	mu               sync.RWMutex
 	numWorkers       int
+	syncTimeout      time.Duration
 	runInformersOnce sync.Once
 	reconcileOnce    sync.Once
 	logger           *logrus.Logger
@@ -78,6 +86,23 @@ func (o *operator) HasSynced() bool {
 	return o.hasSynced()
 }
 
+func (o *operator) CheckSynced() error {
+	if !o.Started() {
+		return fmt.Errorf("informers not started")
+	}
+	if o.hasSynced != nil && !o.hasSynced() {
+		return fmt.Errorf("informer caches not synced")
+	}
+	return nil
+}
+
+func (o *operator) CheckDiscovery() error {
+	if _, err := o.serverVersion.ServerVersion(); err != nil {
+		return errors.Wrap(err, "communicating with server failed")
+	}
+	return nil
+}
+
 func (o *operator) Started() bool {
 	o.mu.RLock()
 	defer o.mu.RUnlock()
@@ -217,7 +242,11 @@ func (o *operator) start(ctx context.Context) error {
 
 	o.logger.Info("starting workers...")
 	for _, queueInformer := range o.queueInformers {
-		for w := 0; w < o.numWorkers; w++ {
+		workers := o.numWorkers
+		if queueInformer.workers > 0 {
+			workers = queueInformer.workers
+		}
+		for w := 0; w < workers; w++ {
 			go o.worker(ctx, queueInformer)
 		}
 	}
@@ -283,6 +312,13 @@ func (o *operator) processNextWorkItem(ctx context.Context, loop *QueueInformer)
 		}
 	}
 
+	// Bound the sync, which is also cancelled when the operator shuts down
+	if o.syncTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, o.syncTimeout)
+		defer cancel()
+	}
+
 	// Sync and requeue on error (throw out failed deletion syncs)
 	err := loop.Sync(ctx, event)
 	if requeues := queue.NumRequeues(item); err != nil && requeues < 8 && event.Type() != kubestate.ResourceDeleted {
@@ -318,6 +354,7 @@ func newOperatorFromConfig(config *operatorConfig) (*operator, error) {
 	op := &operator{
 		serverVersion: config.serverVersion,
 		numWorkers:    config.numWorkers,
+		syncTimeout:   config.syncTimeout,
 		logger:        config.logger,
 		ready:         make(chan struct{}),
 		done:          make(chan struct{}),
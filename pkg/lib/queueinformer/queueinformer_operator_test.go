@@ -7,6 +7,8 @@ import (
 
 	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/version"
+
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/kubestate"
 )
 
 type versionFunc func() (*version.Info, error)
@@ -92,3 +94,48 @@ func TestOperatorRunChannelClosure(t *testing.T) {
 		})
 	}
 }
+
+func TestProcessNextWorkItemSyncTimeout(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		syncTimeout time.Duration
+		deadline    bool
+	}{
+		{
+			name:        "sync timeout bounds the sync",
+			syncTimeout: time.Minute,
+			deadline:    true,
+		},
+		{
+			name: "no sync timeout",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			var deadline bool
+			queue, err := NewQueue(ctx, WithSyncer(SyncHandler(func(ctx context.Context, obj interface{}) error {
+				_, deadline = ctx.Deadline()
+				return nil
+			}).ToSyncer()))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			o, err := newOperatorFromConfig(defaultOperatorConfig())
+			if err != nil {
+				t.Fatal(err)
+			}
+			o.syncTimeout = tc.syncTimeout
+
+			queue.Enqueue(kubestate.NewResourceEvent(kubestate.ResourceAdded, "ns/name"))
+			if !o.processNextWorkItem(ctx, queue) {
+				t.Fatal("queue unexpectedly shut down")
+			}
+			if deadline != tc.deadline {
+				t.Errorf("expected sync context deadline set to be %t, got %t", tc.deadline, deadline)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package server
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+)
+
+type namedCheck struct {
+	name  string
+	check func() error
+}
+
+// readyzHandler serves the results of the given checks: 200 if they all pass, 503 otherwise. The failed checks are
+// listed in the response body, along with the passed ones if the verbose query parameter is set.
+func readyzHandler(checks []namedCheck) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, verbose := r.URL.Query()["verbose"]
+
+		var out bytes.Buffer
+		failed := false
+		for _, c := range checks {
+			if err := c.check(); err != nil {
+				failed = true
+				fmt.Fprintf(&out, "[-]%s failed: %v\n", c.name, err)
+			} else if verbose {
+				fmt.Fprintf(&out, "[+]%s ok\n", c.name)
+			}
+		}
+
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		if failed {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			fmt.Fprint(w, out.String())
+			fmt.Fprint(w, "readyz check failed\n")
+			return
+		}
+		fmt.Fprint(w, out.String())
+		fmt.Fprint(w, "ok\n")
+	})
+}
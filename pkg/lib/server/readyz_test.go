@@ -0,0 +1,33 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadyzHandler(t *testing.T) {
+	ok := namedCheck{name: "informers", check: func() error { return nil }}
+	failing := namedCheck{name: "discovery", check: func() error { return errors.New("connection refused") }}
+
+	serve := func(target string, checks ...namedCheck) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		readyzHandler(checks).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		return rec
+	}
+
+	rec := serve("/readyz")
+	require.Equal(t, http.StatusOK, rec.Code, "no checks")
+	require.Equal(t, "ok\n", rec.Body.String())
+
+	rec = serve("/readyz?verbose", ok)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "[+]informers ok\nok\n", rec.Body.String())
+
+	rec = serve("/readyz", ok, failing)
+	require.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	require.Equal(t, "[-]discovery failed: connection refused\nreadyz check failed\n", rec.Body.String())
+}
@@ -43,12 +43,32 @@ func WithDebug(debug bool) Option {
 	}
 }
 
+// WithHandler serves the given handler on the given pattern, next to the health, metric and profiling handlers.
+func WithHandler(pattern string, handler http.Handler) Option {
+	return func(sc *serverConfig) {
+		if sc.handlers == nil {
+			sc.handlers = map[string]http.Handler{}
+		}
+		sc.handlers[pattern] = handler
+	}
+}
+
+// WithReadyCheck adds a named check to the readiness endpoint, which reports the server as not ready while the check
+// returns an error.
+func WithReadyCheck(name string, check func() error) Option {
+	return func(sc *serverConfig) {
+		sc.readyChecks = append(sc.readyChecks, namedCheck{name: name, check: check})
+	}
+}
+
 type serverConfig struct {
 	logger       *logrus.Logger
 	tlsCertPath  *string
 	tlsKeyPath   *string
 	clientCAPath *string
 	debug        bool
+	handlers     map[string]http.Handler
+	readyChecks  []namedCheck
 }
 
 func (sc *serverConfig) apply(options []Option) {
@@ -94,7 +114,11 @@ func (sc serverConfig) getListenAndServeFunc() (func() error, error) {
 	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
 	})
+	mux.Handle("/readyz", readyzHandler(sc.readyChecks))
 	profile.RegisterHandlers(mux, profile.WithTLS(tlsEnabled || !sc.debug))
+	for pattern, handler := range sc.handlers {
+		mux.Handle(pattern, handler)
+	}
 
 	s := http.Server{
 		Handler: mux,
@@ -0,0 +1,106 @@
+// Package sharding partitions namespaces among the replicas of an operator, so that the reconciliation of the resources
+// in them scales horizontally.
+package sharding
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strconv"
+)
+
+// virtualNodes is the number of points each shard has on the ring. More points spread namespaces more evenly among
+// shards.
+const virtualNodes = 128
+
+// Ring is a consistent hash ring over namespace names. Changing the number of shards only moves the namespaces of the
+// shards added or removed, so that replicas resharding don't hand over most namespaces.
+type Ring struct {
+	points []point
+}
+
+type point struct {
+	hash  uint64
+	shard int
+}
+
+// NewRing returns a Ring partitioning namespaces among the given number of shards.
+func NewRing(shards int) *Ring {
+	r := &Ring{points: make([]point, 0, shards*virtualNodes)}
+	for shard := 0; shard < shards; shard++ {
+		for v := 0; v < virtualNodes; v++ {
+			r.points = append(r.points, point{
+				hash:  hash(strconv.Itoa(shard) + "-" + strconv.Itoa(v)),
+				shard: shard,
+			})
+		}
+	}
+	sort.Slice(r.points, func(i, j int) bool {
+		return r.points[i].hash < r.points[j].hash
+	})
+	return r
+}
+
+// Shard returns the shard the given namespace is assigned to, i.e. the shard of the first point of the ring following
+// the hash of its name.
+func (r *Ring) Shard(namespace string) int {
+	h := hash(namespace)
+	i := sort.Search(len(r.points), func(i int) bool {
+		return r.points[i].hash >= h
+	})
+	if i == len(r.points) {
+		i = 0
+	}
+	return r.points[i].shard
+}
+
+// hash returns the FNV-1a hash of the given key, mixed so that keys differing only in their last characters, e.g.
+// numbered namespaces, spread over the whole ring.
+func hash(key string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(key))
+	x := h.Sum64()
+	x ^= x >> 33
+	x *= 0xff51afd7ed558ccd
+	x ^= x >> 33
+	x *= 0xc4ceb9fe1a85ec53
+	x ^= x >> 33
+	return x
+}
+
+// Shard is the shard of the namespaces reconciled by a replica. A nil Shard owns all namespaces.
+type Shard struct {
+	Index int
+	Count int
+	ring  *Ring
+}
+
+// New returns the shard with the given index among the given number of shards.
+func New(index, count int) (*Shard, error) {
+	if count < 1 {
+		return nil, fmt.Errorf("invalid shard count %d, must be at least 1", count)
+	}
+	if index < 0 || index >= count {
+		return nil, fmt.Errorf("invalid shard %d, must be between 0 and %d", index, count-1)
+	}
+	return &Shard{Index: index, Count: count, ring: NewRing(count)}, nil
+}
+
+// Owns returns true if the shard reconciles the resources of the given namespace. Cluster-scoped resources, i.e. those
+// of the empty namespace, are owned by the first shard.
+func (s *Shard) Owns(namespace string) bool {
+	if s == nil || s.Count == 1 {
+		return true
+	}
+	if namespace == "" {
+		return s.Index == 0
+	}
+	return s.ring.Shard(namespace) == s.Index
+}
+
+func (s *Shard) String() string {
+	if s == nil {
+		return "0/1"
+	}
+	return fmt.Sprintf("%d/%d", s.Index, s.Count)
+}
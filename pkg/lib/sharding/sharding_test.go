@@ -0,0 +1,76 @@
+package sharding
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	_, err := New(0, 0)
+	require.EqualError(t, err, "invalid shard count 0, must be at least 1")
+	_, err = New(3, 3)
+	require.EqualError(t, err, "invalid shard 3, must be between 0 and 2")
+	_, err = New(-1, 3)
+	require.EqualError(t, err, "invalid shard -1, must be between 0 and 2")
+
+	s, err := New(1, 3)
+	require.NoError(t, err)
+	require.Equal(t, "1/3", s.String())
+}
+
+func TestOwns(t *testing.T) {
+	namespaces := make([]string, 1000)
+	for i := range namespaces {
+		namespaces[i] = fmt.Sprintf("namespace-%d", i)
+	}
+
+	const count = 4
+	shards := make([]*Shard, count)
+	for i := range shards {
+		var err error
+		shards[i], err = New(i, count)
+		require.NoError(t, err)
+	}
+
+	// Each namespace is owned by exactly one shard, and shards own similar numbers of namespaces
+	owned := make([]int, count)
+	for _, ns := range namespaces {
+		owners := 0
+		for i, s := range shards {
+			if s.Owns(ns) {
+				owners++
+				owned[i]++
+			}
+		}
+		require.Equal(t, 1, owners, "namespace %s", ns)
+	}
+	for i, n := range owned {
+		require.InDelta(t, len(namespaces)/count, n, float64(len(namespaces))/count/2, "shard %d owns %d namespaces", i, n)
+	}
+
+	// Cluster-scoped resources are owned by the first shard
+	require.True(t, shards[0].Owns(""))
+	require.False(t, shards[1].Owns(""))
+
+	// A nil shard owns everything
+	var s *Shard
+	require.True(t, s.Owns("namespace-0"))
+	require.True(t, s.Owns(""))
+}
+
+func TestRingResharding(t *testing.T) {
+	before, after := NewRing(4), NewRing(5)
+	moved := 0
+	const namespaces = 1000
+	for i := 0; i < namespaces; i++ {
+		ns := fmt.Sprintf("namespace-%d", i)
+		if b, a := before.Shard(ns), after.Shard(ns); b != a {
+			// Namespaces only move to the added shard
+			require.Equal(t, 4, a, "namespace %s moved from shard %d", ns, b)
+			moved++
+		}
+	}
+	require.InDelta(t, namespaces/5, moved, namespaces/10)
+}
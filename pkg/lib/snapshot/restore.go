@@ -0,0 +1,140 @@
+package snapshot
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/api/client/clientset/versioned"
+)
+
+// Restore creates the objects of the given snapshot on the cluster, in the order OLM depends on them: namespaces, the
+// OLMConfig, CatalogSources, OperatorGroups and, last, Subscriptions, from which OLM recreates InstallPlans and
+// installs the captured CSVs. Objects that already exist are left as they are, so a partial restore can be resumed.
+func Restore(ctx context.Context, kubeClient kubernetes.Interface, client versioned.Interface, s *Snapshot, logger logrus.FieldLogger) error {
+	for _, ns := range s.Namespaces() {
+		namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: ns}}
+		_, err := kubeClient.CoreV1().Namespaces().Create(ctx, namespace, metav1.CreateOptions{})
+		if err := created(logger, "namespace", ns, err); err != nil {
+			return err
+		}
+	}
+
+	if s.OLMConfig != nil {
+		_, err := client.OperatorsV1().OLMConfigs().Create(ctx, s.OLMConfig.DeepCopy(), metav1.CreateOptions{})
+		if err := created(logger, "olmconfig", s.OLMConfig.GetName(), err); err != nil {
+			return err
+		}
+	}
+
+	for i := range s.CatalogSources {
+		catsrc := s.CatalogSources[i].DeepCopy()
+		_, err := client.OperatorsV1alpha1().CatalogSources(catsrc.GetNamespace()).Create(ctx, catsrc, metav1.CreateOptions{})
+		if err := created(logger, "catalogsource", key(catsrc), err); err != nil {
+			return err
+		}
+	}
+
+	for i := range s.OperatorGroups {
+		og := s.OperatorGroups[i].DeepCopy()
+		_, err := client.OperatorsV1().OperatorGroups(og.GetNamespace()).Create(ctx, og, metav1.CreateOptions{})
+		if err := created(logger, "operatorgroup", key(og), err); err != nil {
+			return err
+		}
+	}
+
+	for i := range s.Subscriptions {
+		sub := s.Subscriptions[i].DeepCopy()
+		_, err := client.OperatorsV1alpha1().Subscriptions(sub.GetNamespace()).Create(ctx, sub, metav1.CreateOptions{})
+		if err := created(logger, "subscription", key(sub), err); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ApproveInstallPlans approves the pending InstallPlans of manually approved Subscriptions that only install CSVs
+// captured in the given snapshot, so that a restore reinstalls the captured operators without approving any upgrade
+// past them. It returns the number of InstallPlans approved.
+func ApproveInstallPlans(ctx context.Context, client versioned.Interface, s *Snapshot, logger logrus.FieldLogger) (int, error) {
+	captured := map[string]struct{}{}
+	for _, csv := range s.ClusterServiceVersions {
+		captured[csv.Namespace+"/"+csv.Name] = struct{}{}
+	}
+
+	approved := 0
+	for _, ns := range s.Namespaces() {
+		plans, err := client.OperatorsV1alpha1().InstallPlans(ns).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return approved, fmt.Errorf("error listing installplans in namespace %s: %w", ns, err)
+		}
+		for i := range plans.Items {
+			plan := &plans.Items[i]
+			if plan.Spec.Approved || plan.Spec.Approval != v1alpha1.ApprovalManual || len(plan.Spec.ClusterServiceVersionNames) == 0 {
+				continue
+			}
+			if !capturedPlan(captured, plan) {
+				logger.WithField("installplan", key(plan)).Info("skipping installplan, it installs csvs not in the snapshot")
+				continue
+			}
+
+			plan.Spec.Approved = true
+			if _, err := client.OperatorsV1alpha1().InstallPlans(ns).Update(ctx, plan, metav1.UpdateOptions{}); err != nil {
+				return approved, fmt.Errorf("error approving installplan %s: %w", key(plan), err)
+			}
+			logger.WithField("installplan", key(plan)).Info("approved installplan")
+			approved++
+		}
+	}
+	return approved, nil
+}
+
+// Pending returns the CSVs of the given snapshot that haven't yet succeeded on the cluster.
+func Pending(ctx context.Context, client versioned.Interface, s *Snapshot) (pending []CSVIdentity, err error) {
+	for _, identity := range s.ClusterServiceVersions {
+		csv, err := client.OperatorsV1alpha1().ClusterServiceVersions(identity.Namespace).Get(ctx, identity.Name, metav1.GetOptions{})
+		if k8serrors.IsNotFound(err) || (err == nil && csv.Status.Phase != v1alpha1.CSVPhaseSucceeded) {
+			pending = append(pending, identity)
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error getting csv %s/%s: %w", identity.Namespace, identity.Name, err)
+		}
+	}
+	return pending, nil
+}
+
+func capturedPlan(captured map[string]struct{}, plan *v1alpha1.InstallPlan) bool {
+	for _, name := range plan.Spec.ClusterServiceVersionNames {
+		if _, ok := captured[plan.GetNamespace()+"/"+name]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// created logs the outcome of creating the named object, returning the error of the creation unless the object
+// already existed.
+func created(logger logrus.FieldLogger, kind, name string, err error) error {
+	logger = logger.WithField(kind, name)
+	switch {
+	case err == nil:
+		logger.Info("created")
+	case k8serrors.IsAlreadyExists(err):
+		logger.Info("already exists, skipping")
+	default:
+		return fmt.Errorf("error creating %s %s: %w", kind, name, err)
+	}
+	return nil
+}
+
+func key(obj metav1.Object) string {
+	return obj.GetNamespace() + "/" + obj.GetName()
+}
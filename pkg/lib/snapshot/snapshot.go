@@ -0,0 +1,205 @@
+// Package snapshot captures the OLM state of a cluster into a portable archive, and restores it onto another cluster.
+package snapshot
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	operatorsv1 "github.com/operator-framework/api/pkg/operators/v1"
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/api/client/clientset/versioned"
+)
+
+// Version is the version of the snapshot format written by Write.
+const Version = "snapshot.olm.operatorframework.io/v1"
+
+// olmConfigName is the name of the cluster's singleton OLMConfig.
+const olmConfigName = "cluster"
+
+// Snapshot is the OLM state of a cluster: the objects a cluster administrator creates to install operators, and the
+// identities of the operators installed.
+type Snapshot struct {
+	Version string `json:"version"`
+
+	OLMConfig      *operatorsv1.OLMConfig      `json:"olmConfig,omitempty"`
+	CatalogSources []v1alpha1.CatalogSource    `json:"catalogSources,omitempty"`
+	OperatorGroups []operatorsv1.OperatorGroup `json:"operatorGroups,omitempty"`
+	Subscriptions  []v1alpha1.Subscription     `json:"subscriptions,omitempty"`
+
+	// ClusterServiceVersions identifies the operators installed when the snapshot was captured. Copied CSVs are
+	// omitted, since OLM recreates them.
+	ClusterServiceVersions []CSVIdentity `json:"clusterServiceVersions,omitempty"`
+}
+
+// CSVIdentity identifies an installed ClusterServiceVersion.
+type CSVIdentity struct {
+	Name      string                              `json:"name"`
+	Namespace string                              `json:"namespace"`
+	Version   string                              `json:"version,omitempty"`
+	Phase     v1alpha1.ClusterServiceVersionPhase `json:"phase,omitempty"`
+}
+
+// Namespaces returns the sorted namespaces of the namespaced objects of the snapshot.
+func (s *Snapshot) Namespaces() []string {
+	set := map[string]struct{}{}
+	for _, catsrc := range s.CatalogSources {
+		set[catsrc.GetNamespace()] = struct{}{}
+	}
+	for _, og := range s.OperatorGroups {
+		set[og.GetNamespace()] = struct{}{}
+	}
+	for _, sub := range s.Subscriptions {
+		set[sub.GetNamespace()] = struct{}{}
+	}
+
+	namespaces := make([]string, 0, len(set))
+	for ns := range set {
+		namespaces = append(namespaces, ns)
+	}
+	sort.Strings(namespaces)
+	return namespaces
+}
+
+// Capture returns a snapshot of the OLM state of the cluster. Only the CatalogSources referenced by Subscriptions are
+// captured, and the status of every object is dropped, except for the installed CSV of each Subscription, which is
+// restored as its starting CSV.
+func Capture(ctx context.Context, client versioned.Interface) (*Snapshot, error) {
+	s := &Snapshot{Version: Version}
+
+	config, err := client.OperatorsV1().OLMConfigs().Get(ctx, olmConfigName, metav1.GetOptions{})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return nil, fmt.Errorf("error getting olmconfig: %w", err)
+	}
+	if err == nil {
+		s.OLMConfig = &operatorsv1.OLMConfig{ObjectMeta: portableMeta(config.ObjectMeta), Spec: config.Spec}
+	}
+
+	subs, err := client.OperatorsV1alpha1().Subscriptions(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error listing subscriptions: %w", err)
+	}
+	catalogs := map[string]struct{}{}
+	for _, sub := range subs.Items {
+		out := v1alpha1.Subscription{ObjectMeta: portableMeta(sub.ObjectMeta), Spec: sub.Spec.DeepCopy()}
+		if out.Spec == nil {
+			out.Spec = &v1alpha1.SubscriptionSpec{}
+		}
+		if sub.Status.InstalledCSV != "" {
+			out.Spec.StartingCSV = sub.Status.InstalledCSV
+		}
+		s.Subscriptions = append(s.Subscriptions, out)
+		catalogs[out.Spec.CatalogSourceNamespace+"/"+out.Spec.CatalogSource] = struct{}{}
+	}
+
+	catsrcs, err := client.OperatorsV1alpha1().CatalogSources(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error listing catalogsources: %w", err)
+	}
+	for _, catsrc := range catsrcs.Items {
+		if _, ok := catalogs[catsrc.GetNamespace()+"/"+catsrc.GetName()]; !ok {
+			continue
+		}
+		s.CatalogSources = append(s.CatalogSources, v1alpha1.CatalogSource{ObjectMeta: portableMeta(catsrc.ObjectMeta), Spec: catsrc.Spec})
+	}
+
+	ogs, err := client.OperatorsV1().OperatorGroups(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error listing operatorgroups: %w", err)
+	}
+	for _, og := range ogs.Items {
+		// OLM recomputes the APIs provided by the group
+		out := operatorsv1.OperatorGroup{ObjectMeta: portableMeta(og.ObjectMeta, operatorsv1.OperatorGroupProvidedAPIsAnnotationKey), Spec: og.Spec}
+		s.OperatorGroups = append(s.OperatorGroups, out)
+	}
+
+	csvs, err := client.OperatorsV1alpha1().ClusterServiceVersions(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error listing clusterserviceversions: %w", err)
+	}
+	for _, csv := range csvs.Items {
+		if csv.IsCopied() {
+			continue
+		}
+		s.ClusterServiceVersions = append(s.ClusterServiceVersions, CSVIdentity{
+			Name:      csv.GetName(),
+			Namespace: csv.GetNamespace(),
+			Version:   csv.Spec.Version.String(),
+			Phase:     csv.Status.Phase,
+		})
+	}
+
+	return s, nil
+}
+
+// portableMeta returns the parts of the given object metadata that can be restored onto another cluster, without the
+// given annotations.
+func portableMeta(meta metav1.ObjectMeta, dropAnnotations ...string) metav1.ObjectMeta {
+	out := metav1.ObjectMeta{
+		Name:      meta.GetName(),
+		Namespace: meta.GetNamespace(),
+	}
+	if len(meta.GetLabels()) > 0 {
+		out.Labels = map[string]string{}
+		for k, v := range meta.GetLabels() {
+			out.Labels[k] = v
+		}
+	}
+	// Drop the client-side apply record, which refers to the original object
+	dropAnnotations = append(dropAnnotations, corev1.LastAppliedConfigAnnotation)
+	for k, v := range meta.GetAnnotations() {
+		if contains(dropAnnotations, k) {
+			continue
+		}
+		if out.Annotations == nil {
+			out.Annotations = map[string]string{}
+		}
+		out.Annotations[k] = v
+	}
+	return out
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// Write writes the given snapshot to w as a gzip-compressed JSON document.
+func Write(w io.Writer, s *Snapshot) error {
+	zw := gzip.NewWriter(w)
+	encoder := json.NewEncoder(zw)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(s); err != nil {
+		return err
+	}
+	return zw.Close()
+}
+
+// Read reads a snapshot written by Write from r.
+func Read(r io.Reader) (*Snapshot, error) {
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("error reading snapshot: %w", err)
+	}
+	defer zr.Close()
+
+	s := &Snapshot{}
+	if err := json.NewDecoder(zr).Decode(s); err != nil {
+		return nil, fmt.Errorf("error decoding snapshot: %w", err)
+	}
+	if s.Version != Version {
+		return nil, fmt.Errorf("unsupported snapshot version %q, expected %q", s.Version, Version)
+	}
+	return s, nil
+}
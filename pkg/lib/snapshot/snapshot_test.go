@@ -0,0 +1,131 @@
+package snapshot
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+
+	operatorsv1 "github.com/operator-framework/api/pkg/operators/v1"
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/api/client/clientset/versioned/fake"
+)
+
+func TestCaptureAndRestore(t *testing.T) {
+	ctx := context.TODO()
+	logger, _ := test.NewNullLogger()
+	logger.SetLevel(logrus.DebugLevel)
+
+	meta := func(namespace, name string) metav1.ObjectMeta {
+		return metav1.ObjectMeta{
+			Namespace:       namespace,
+			Name:            name,
+			UID:             "uid",
+			ResourceVersion: "42",
+			Annotations:     map[string]string{operatorsv1.OperatorGroupProvidedAPIsAnnotationKey: "Foo.v1.example.com"},
+		}
+	}
+	source := fake.NewSimpleClientset(
+		&operatorsv1.OLMConfig{ObjectMeta: metav1.ObjectMeta{Name: "cluster"}, Spec: operatorsv1.OLMConfigSpec{Features: &operatorsv1.Features{DisableCopiedCSVs: func() *bool { b := true; return &b }()}}},
+		&v1alpha1.CatalogSource{ObjectMeta: meta("olm", "operatorhubio"), Spec: v1alpha1.CatalogSourceSpec{SourceType: v1alpha1.SourceTypeGrpc, Image: "quay.io/operatorhubio/catalog:latest"}},
+		&v1alpha1.CatalogSource{ObjectMeta: meta("olm", "unused"), Spec: v1alpha1.CatalogSourceSpec{SourceType: v1alpha1.SourceTypeGrpc}},
+		&operatorsv1.OperatorGroup{ObjectMeta: meta("ns", "og")},
+		&v1alpha1.Subscription{
+			ObjectMeta: meta("ns", "sub"),
+			Spec:       &v1alpha1.SubscriptionSpec{CatalogSource: "operatorhubio", CatalogSourceNamespace: "olm", Package: "etcd", Channel: "stable"},
+			Status:     v1alpha1.SubscriptionStatus{InstalledCSV: "etcd.v1.0.0", CurrentCSV: "etcd.v1.0.1"},
+		},
+		&v1alpha1.ClusterServiceVersion{ObjectMeta: meta("ns", "etcd.v1.0.0"), Status: v1alpha1.ClusterServiceVersionStatus{Phase: v1alpha1.CSVPhaseSucceeded}},
+		&v1alpha1.ClusterServiceVersion{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "other", Name: "etcd.v1.0.0", Labels: map[string]string{v1alpha1.CopiedLabelKey: "ns"}},
+			Status:     v1alpha1.ClusterServiceVersionStatus{Reason: v1alpha1.CSVReasonCopied},
+		},
+	)
+
+	s, err := Capture(ctx, source)
+	require.NoError(t, err)
+
+	require.NotNil(t, s.OLMConfig)
+	require.True(t, *s.OLMConfig.Spec.Features.DisableCopiedCSVs)
+	require.Len(t, s.CatalogSources, 1)
+	require.Equal(t, "operatorhubio", s.CatalogSources[0].GetName())
+	require.Empty(t, s.CatalogSources[0].GetUID())
+	require.Empty(t, s.CatalogSources[0].GetResourceVersion())
+	require.Len(t, s.OperatorGroups, 1)
+	require.NotContains(t, s.OperatorGroups[0].GetAnnotations(), operatorsv1.OperatorGroupProvidedAPIsAnnotationKey)
+	require.Len(t, s.Subscriptions, 1)
+	require.Equal(t, "etcd.v1.0.0", s.Subscriptions[0].Spec.StartingCSV)
+	require.Empty(t, s.Subscriptions[0].Status)
+	require.Equal(t, []CSVIdentity{{Name: "etcd.v1.0.0", Namespace: "ns", Version: "0.0.0", Phase: v1alpha1.CSVPhaseSucceeded}}, s.ClusterServiceVersions)
+	require.Equal(t, []string{"ns", "olm"}, s.Namespaces())
+
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, s))
+	read, err := Read(&buf)
+	require.NoError(t, err)
+	require.Equal(t, s, read)
+
+	kubeClient := k8sfake.NewSimpleClientset()
+	target := fake.NewSimpleClientset(&operatorsv1.OperatorGroup{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "og"}})
+	require.NoError(t, Restore(ctx, kubeClient, target, read, logger))
+	// Restoring again is a no-op
+	require.NoError(t, Restore(ctx, kubeClient, target, read, logger))
+
+	_, err = kubeClient.CoreV1().Namespaces().Get(ctx, "olm", metav1.GetOptions{})
+	require.NoError(t, err)
+	_, err = target.OperatorsV1().OLMConfigs().Get(ctx, "cluster", metav1.GetOptions{})
+	require.NoError(t, err)
+	_, err = target.OperatorsV1alpha1().CatalogSources("olm").Get(ctx, "operatorhubio", metav1.GetOptions{})
+	require.NoError(t, err)
+	sub, err := target.OperatorsV1alpha1().Subscriptions("ns").Get(ctx, "sub", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, "etcd.v1.0.0", sub.Spec.StartingCSV)
+
+	pending, err := Pending(ctx, target, read)
+	require.NoError(t, err)
+	require.Equal(t, read.ClusterServiceVersions, pending)
+}
+
+func TestApproveInstallPlans(t *testing.T) {
+	ctx := context.TODO()
+	logger, _ := test.NewNullLogger()
+
+	s := &Snapshot{
+		Version:                Version,
+		Subscriptions:          []v1alpha1.Subscription{{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "sub"}}},
+		ClusterServiceVersions: []CSVIdentity{{Namespace: "ns", Name: "a.v1"}, {Namespace: "ns", Name: "b.v1"}},
+	}
+	plan := func(name string, approval v1alpha1.Approval, csvs ...string) *v1alpha1.InstallPlan {
+		return &v1alpha1.InstallPlan{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: name},
+			Spec:       v1alpha1.InstallPlanSpec{Approval: approval, ClusterServiceVersionNames: csvs},
+		}
+	}
+	client := fake.NewSimpleClientset(
+		plan("captured", v1alpha1.ApprovalManual, "a.v1", "b.v1"),
+		plan("upgrade", v1alpha1.ApprovalManual, "a.v2"),
+		plan("automatic", v1alpha1.ApprovalAutomatic, "a.v1"),
+	)
+
+	approved, err := ApproveInstallPlans(ctx, client, s, logger)
+	require.NoError(t, err)
+	require.Equal(t, 1, approved)
+
+	for name, expected := range map[string]bool{"captured": true, "upgrade": false, "automatic": false} {
+		plan, err := client.OperatorsV1alpha1().InstallPlans("ns").Get(ctx, name, metav1.GetOptions{})
+		require.NoError(t, err)
+		require.Equal(t, expected, plan.Spec.Approved, name)
+	}
+}
+
+func TestReadRejectsUnknownVersion(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, &Snapshot{Version: "snapshot.olm.operatorframework.io/v0"}))
+	_, err := Read(&buf)
+	require.Error(t, err)
+}
@@ -1,32 +1,39 @@
 package metrics
 
 import (
+	"strconv"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"google.golang.org/grpc/connectivity"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/component-base/metrics/legacyregistry"
 
 	olmv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
 	v1alpha1 "github.com/operator-framework/operator-lifecycle-manager/pkg/api/client/listers/operators/v1alpha1"
 )
 
 const (
-	NameLabel      = "name"
-	InstalledLabel = "installed"
-	NamespaceLabel = "namespace"
-	ChannelLabel   = "channel"
-	VersionLabel   = "version"
-	PhaseLabel     = "phase"
-	ReasonLabel    = "reason"
-	PackageLabel   = "package"
-	Outcome        = "outcome"
-	Succeeded      = "succeeded"
-	Failed         = "failed"
-	ApprovalLabel  = "approval"
-	WarningLabel   = "warning"
-	GVKLabel       = "gvk"
+	NameLabel        = "name"
+	InstalledLabel   = "installed"
+	NamespaceLabel   = "namespace"
+	ChannelLabel     = "channel"
+	VersionLabel     = "version"
+	PhaseLabel       = "phase"
+	FromPhaseLabel   = "from_phase"
+	ReasonLabel      = "reason"
+	PackageLabel     = "package"
+	Outcome          = "outcome"
+	Succeeded        = "succeeded"
+	Failed           = "failed"
+	ApprovalLabel    = "approval"
+	WarningLabel     = "warning"
+	GVKLabel         = "gvk"
+	ResourceLabel    = "resource"
+	RequirementLabel = "requirement"
+	ResultLabel      = "result"
+	RegistryLabel    = "registry"
 )
 
 type MetricsProvider interface {
@@ -183,6 +190,47 @@ var (
 		[]string{NamespaceLabel, NameLabel, VersionLabel, PhaseLabel, ReasonLabel},
 	)
 
+	csvPhaseTransitions = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "csv_phase_transitions_total",
+			Help: "Monotonic count of CSV phase transitions, by phase transitioned from and to and by reason of the new phase",
+		},
+		[]string{FromPhaseLabel, PhaseLabel, ReasonLabel},
+	)
+
+	csvSyncDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "csv_sync_duration_seconds",
+			Help:    "The duration of a CSV reconcile",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{NamespaceLabel, NameLabel},
+	)
+
+	csvResourceBaseline = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "csv_resource_baseline",
+			Help: "Baseline resource consumption of the pods of a succeeded CSV, in cores for cpu and bytes for memory",
+		},
+		[]string{NamespaceLabel, NameLabel, VersionLabel, ResourceLabel},
+	)
+
+	csvRequirementFailures = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "csv_requirement_failures",
+			Help: "Number of consecutive probes of a CSV requirement that found it unmet, reset to 0 once the requirement is met",
+		},
+		[]string{NamespaceLabel, NameLabel, GVKLabel, RequirementLabel},
+	)
+
+	ruleCheckCacheLookups = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "rule_check_cache_lookups_total",
+			Help: "Monotonic count of lookups of the results of CSV permission checks, by whether the result was cached (hit) or had to be evaluated (miss)",
+		},
+		[]string{ResultLabel},
+	)
+
 	dependencyResolutionSummary = prometheus.NewSummaryVec(
 		prometheus.SummaryOpts{
 			Name:       "olm_resolution_duration_seconds",
@@ -199,6 +247,117 @@ var (
 		},
 	)
 
+	catalogSnapshotCacheLookups = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "resolver_catalog_snapshot_cache_lookups_total",
+			Help: "Monotonic count of lookups of catalog snapshots by the resolver, by whether the snapshot was cached (hit) or had to be listed from its catalog (miss)",
+		},
+		[]string{ResultLabel},
+	)
+
+	bundleUnpackThrottled = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "bundle_unpack_throttled_total",
+			Help: "Monotonic count of bundle unpack jobs held back, by whether too many were in flight (UnpackThrottled) or their registry was backed off from (RegistryBackoff)",
+		},
+		[]string{ReasonLabel},
+	)
+
+	registryThrottledPulls = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "bundle_unpack_registry_throttled_pulls_total",
+			Help: "Monotonic count of bundle image pulls a registry rate limited or failed with a 5xx status",
+		},
+		[]string{RegistryLabel},
+	)
+
+	registryBackoff = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "bundle_unpack_registry_backoff_seconds",
+			Help: "Current backoff from a registry after throttled bundle image pulls, removed after its next successful pull",
+		},
+		[]string{RegistryLabel},
+	)
+
+	catalogPollQueueWait = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "catalog_source_poll_queue_wait_seconds",
+			Help:    "The time CatalogSource image polls were held back before their update pod was created",
+			Buckets: []float64{0, 1, 5, 15, 30, 60, 120, 300, 600, 1800},
+		},
+	)
+
+	catalogPollsWaiting = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "catalog_source_polls_waiting",
+			Help: "Number of CatalogSource image polls currently held back",
+		},
+	)
+
+	replacementChainLength = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "csv_replacement_chain_length",
+			Help:    "Number of CSVs in the replacement chains whose stuck superseded CSVs were cleaned up, including the succeeded CSV heading the chain",
+			Buckets: []float64{2, 3, 4, 5, 7, 10, 15, 20},
+		},
+	)
+
+	replacementChainCleanupLatency = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "csv_replacement_chain_cleanup_latency_seconds",
+			Help:    "The time between a CSV succeeding and its stuck superseded CSVs being cleaned up",
+			Buckets: []float64{120, 300, 600, 1800, 3600, 21600, 86400},
+		},
+	)
+
+	shardInfo = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "olm_shard_info",
+			Help: "The shard of the namespaces whose CSVs this olm-operator replica reconciles, among the given number of shards",
+		},
+		[]string{"shard", "shards"},
+	)
+
+	shardNamespaces = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "olm_shard_namespaces",
+			Help: "Number of namespaces assigned to the shard of this olm-operator replica",
+		},
+	)
+
+	copiedCSVQueueDepth = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "olm_copied_csv_queue_depth",
+			Help: "Number of CSVs waiting to be copied to the target namespaces of their OperatorGroup",
+		},
+		[]string{NamespaceLabel},
+	)
+
+	copiedCSVQueueLatency = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "olm_copied_csv_queue_latency_seconds",
+			Help:    "The time CSVs waited to be copied to the target namespaces of their OperatorGroup, once due",
+			Buckets: []float64{0.01, 0.1, 0.5, 1, 5, 15, 30, 60, 120, 300, 600},
+		},
+	)
+
+	packageServerCatalogRefreshDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "packageserver_catalog_refresh_duration_seconds",
+			Help:    "The duration of a refresh of the PackageManifests of a CatalogSource by the package-server",
+			Buckets: []float64{0.1, 0.5, 1, 2.5, 5, 10, 30, 60, 120, 300},
+		},
+		[]string{NamespaceLabel, NameLabel},
+	)
+
+	packageServerPackageRenders = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "packageserver_package_renders_total",
+			Help: "Monotonic count of the packages refreshed by the package-server, by whether their PackageManifest was cached (hit) or had to be rendered (miss)",
+		},
+		[]string{ResultLabel},
+	)
+
 	// subscriptionSyncCounters keeps a record of the Prometheus counters emitted by
 	// Subscription objects. The key of a record is the Subscription name, while the value
 	//  is struct containing label values used in the counter
@@ -217,6 +376,17 @@ func RegisterOLM() {
 	prometheus.MustRegister(csvSucceeded)
 	prometheus.MustRegister(csvAbnormal)
 	prometheus.MustRegister(CSVUpgradeCount)
+	prometheus.MustRegister(csvResourceBaseline)
+	prometheus.MustRegister(csvPhaseTransitions)
+	prometheus.MustRegister(csvSyncDuration)
+	prometheus.MustRegister(csvRequirementFailures)
+	prometheus.MustRegister(ruleCheckCacheLookups)
+	prometheus.MustRegister(replacementChainLength)
+	prometheus.MustRegister(replacementChainCleanupLatency)
+	prometheus.MustRegister(shardInfo)
+	prometheus.MustRegister(shardNamespaces)
+	prometheus.MustRegister(copiedCSVQueueDepth)
+	prometheus.MustRegister(copiedCSVQueueLatency)
 }
 
 func RegisterCatalog() {
@@ -227,6 +397,18 @@ func RegisterCatalog() {
 	prometheus.MustRegister(SubscriptionSyncCount)
 	prometheus.MustRegister(dependencyResolutionSummary)
 	prometheus.MustRegister(installPlanWarningCount)
+	prometheus.MustRegister(catalogSnapshotCacheLookups)
+	prometheus.MustRegister(bundleUnpackThrottled)
+	prometheus.MustRegister(registryThrottledPulls)
+	prometheus.MustRegister(registryBackoff)
+	prometheus.MustRegister(catalogPollQueueWait)
+	prometheus.MustRegister(catalogPollsWaiting)
+}
+
+// RegisterPackageServer registers the metrics of the package-server with the registry its API server serves.
+func RegisterPackageServer() {
+	legacyregistry.RawMustRegister(packageServerCatalogRefreshDuration)
+	legacyregistry.RawMustRegister(packageServerPackageRenders)
 }
 
 func CounterForSubscription(name, installedCSV, channelName, packageName, planApprovalStrategy string) prometheus.Counter {
@@ -250,6 +432,45 @@ func DeleteCSVMetric(oldCSV *olmv1alpha1.ClusterServiceVersion) {
 	// Delete the old CSV metrics
 	csvAbnormal.DeleteLabelValues(oldCSV.Namespace, oldCSV.Name, oldCSV.Spec.Version.String(), string(oldCSV.Status.Phase), string(oldCSV.Status.Reason))
 	csvSucceeded.DeleteLabelValues(oldCSV.Namespace, oldCSV.Name, oldCSV.Spec.Version.String())
+	csvResourceBaseline.DeleteLabelValues(oldCSV.Namespace, oldCSV.Name, oldCSV.Spec.Version.String(), "cpu")
+	csvResourceBaseline.DeleteLabelValues(oldCSV.Namespace, oldCSV.Name, oldCSV.Spec.Version.String(), "memory")
+	csvSyncDuration.DeleteLabelValues(oldCSV.Namespace, oldCSV.Name)
+}
+
+// EmitCSVSyncDuration records the duration of a reconcile of the given CSV.
+func EmitCSVSyncDuration(csv *olmv1alpha1.ClusterServiceVersion, duration time.Duration) {
+	csvSyncDuration.WithLabelValues(csv.Namespace, csv.Name).Observe(duration.Seconds())
+}
+
+func EmitCSVResourceBaseline(csv *olmv1alpha1.ClusterServiceVersion, cpuCores, memoryBytes float64) {
+	csvResourceBaseline.WithLabelValues(csv.Namespace, csv.Name, csv.Spec.Version.String(), "cpu").Set(cpuCores)
+	csvResourceBaseline.WithLabelValues(csv.Namespace, csv.Name, csv.Spec.Version.String(), "memory").Set(memoryBytes)
+}
+
+// EmitCSVRequirementFailures records the number of consecutive failed probes of a requirement of the given CSV.
+func EmitCSVRequirementFailures(csv *olmv1alpha1.ClusterServiceVersion, gvk, requirement string, failures int) {
+	csvRequirementFailures.WithLabelValues(csv.Namespace, csv.Name, gvk, requirement).Set(float64(failures))
+}
+
+// DeleteCSVRequirementMetric deletes the metrics of a requirement of the given CSV.
+func DeleteCSVRequirementMetric(csv *olmv1alpha1.ClusterServiceVersion, gvk, requirement string) {
+	csvRequirementFailures.DeleteLabelValues(csv.Namespace, csv.Name, gvk, requirement)
+}
+
+// EmitRuleCheckCacheLookup counts a lookup of the result of a CSV permission check.
+func EmitRuleCheckCacheLookup(hit bool) {
+	if hit {
+		ruleCheckCacheLookups.WithLabelValues("hit").Inc()
+		return
+	}
+	ruleCheckCacheLookups.WithLabelValues("miss").Inc()
+}
+
+// EmitReplacementChainCleanup records the cleanup of the stuck superseded CSVs of a replacement chain of the given
+// length, the given time after the CSV heading it succeeded.
+func EmitReplacementChainCleanup(length int, latency time.Duration) {
+	replacementChainLength.Observe(float64(length))
+	replacementChainCleanupLatency.Observe(latency.Seconds())
 }
 
 func EmitCSVMetric(oldCSV *olmv1alpha1.ClusterServiceVersion, newCSV *olmv1alpha1.ClusterServiceVersion) {
@@ -265,6 +486,10 @@ func EmitCSVMetric(oldCSV *olmv1alpha1.ClusterServiceVersion, newCSV *olmv1alpha
 	// Delete the old CSV metrics
 	csvAbnormal.DeleteLabelValues(oldCSV.Namespace, oldCSV.Name, oldCSV.Spec.Version.String(), string(oldCSV.Status.Phase), string(oldCSV.Status.Reason))
 
+	if oldCSV.Status.Phase != newCSV.Status.Phase {
+		csvPhaseTransitions.WithLabelValues(string(oldCSV.Status.Phase), string(newCSV.Status.Phase), string(newCSV.Status.Reason)).Inc()
+	}
+
 	// Get the phase of the new CSV
 	newCSVPhase := string(newCSV.Status.Phase)
 	csvSucceededGauge := csvSucceeded.WithLabelValues(newCSV.Namespace, newCSV.Name, newCSV.Spec.Version.String())
@@ -332,3 +557,72 @@ func RegisterDependencyResolutionFailure(duration time.Duration) {
 func EmitInstallPlanWarning() {
 	installPlanWarningCount.Inc()
 }
+
+// EmitCatalogSnapshotCacheLookups records the given numbers of catalog snapshots found in and missing from the
+// resolver's cache.
+func EmitCatalogSnapshotCacheLookups(hits, misses int) {
+	catalogSnapshotCacheLookups.WithLabelValues("hit").Add(float64(hits))
+	catalogSnapshotCacheLookups.WithLabelValues("miss").Add(float64(misses))
+}
+
+// EmitBundleUnpackThrottled records a bundle unpack job held back for the given reason.
+func EmitBundleUnpackThrottled(reason string) {
+	bundleUnpackThrottled.WithLabelValues(reason).Inc()
+}
+
+// EmitRegistryThrottledPull records a bundle image pull throttled by the given registry, and its resulting backoff.
+func EmitRegistryThrottledPull(registry string, backoff time.Duration) {
+	registryThrottledPulls.WithLabelValues(registry).Inc()
+	registryBackoff.WithLabelValues(registry).Set(backoff.Seconds())
+}
+
+func DeleteRegistryBackoffMetric(registry string) {
+	registryBackoff.DeleteLabelValues(registry)
+}
+
+// EmitCatalogPollQueueWait records how long the image poll of a CatalogSource was held back.
+func EmitCatalogPollQueueWait(wait time.Duration) {
+	catalogPollQueueWait.Observe(wait.Seconds())
+}
+
+// SetShardInfo records the shard of the olm-operator replica among the given number of shards.
+func SetShardInfo(shard, shards int) {
+	shardInfo.WithLabelValues(strconv.Itoa(shard), strconv.Itoa(shards)).Set(1)
+}
+
+// AddShardNamespaces adds the given number, possibly negative, to the number of namespaces assigned to the shard of
+// the olm-operator replica.
+func AddShardNamespaces(n int) {
+	shardNamespaces.Add(float64(n))
+}
+
+// SetCopiedCSVQueueDepth records the number of CSVs waiting to be copied by the copied CSV queue of the given
+// namespace, which is empty for the queue of all namespaces.
+func SetCopiedCSVQueueDepth(namespace string, depth int) {
+	copiedCSVQueueDepth.WithLabelValues(namespace).Set(float64(depth))
+}
+
+// EmitCopiedCSVQueueLatency records how long a CSV waited in the copied CSV queue before being copied.
+func EmitCopiedCSVQueueLatency(latency time.Duration) {
+	copiedCSVQueueLatency.Observe(latency.Seconds())
+}
+
+// SetCatalogPollsWaiting records the number of CatalogSource image polls held back.
+func SetCatalogPollsWaiting(n int) {
+	catalogPollsWaiting.Set(float64(n))
+}
+
+// EmitCatalogRefreshDuration records the duration of a refresh of the PackageManifests of the given CatalogSource.
+func EmitCatalogRefreshDuration(namespace, name string, duration time.Duration) {
+	packageServerCatalogRefreshDuration.WithLabelValues(namespace, name).Observe(duration.Seconds())
+}
+
+func DeleteCatalogRefreshDurationMetric(namespace, name string) {
+	packageServerCatalogRefreshDuration.DeleteLabelValues(namespace, name)
+}
+
+// EmitPackageRenders records the given numbers of refreshed packages whose PackageManifest was cached, and rendered.
+func EmitPackageRenders(hits, misses int) {
+	packageServerPackageRenders.WithLabelValues("hit").Add(float64(hits))
+	packageServerPackageRenders.WithLabelValues("miss").Add(float64(misses))
+}
@@ -81,6 +81,34 @@ type PackageChannel struct {
 
 	// CurrentCSVSpec holds the spec of the current CSV
 	CurrentCSVDesc CSVDescription
+
+	// Properties are the olm.gvk, olm.package and olm.constraint properties of the bundle of the current CSV
+	// +optional
+	// +listType=atomic
+	Properties []ChannelEntryProperty
+
+	// Dependencies are the dependencies declared by the bundle of the current CSV
+	// +optional
+	// +listType=atomic
+	Dependencies []ChannelEntryDependency
+}
+
+// ChannelEntryProperty defines a property of the bundle of a channel's current CSV
+type ChannelEntryProperty struct {
+	// Type is the type of the property, e.g. `olm.gvk`
+	Type string
+
+	// Value is the JSON encoded value of the property
+	Value string
+}
+
+// ChannelEntryDependency defines a dependency declared by the bundle of a channel's current CSV
+type ChannelEntryDependency struct {
+	// Type is the type of the dependency, e.g. `olm.package`
+	Type string
+
+	// Value is the JSON encoded value of the dependency
+	Value string
 }
 
 // CSVDescription defines a description of a CSV
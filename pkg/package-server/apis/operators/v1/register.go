@@ -1,6 +1,8 @@
 package v1
 
 import (
+	"fmt"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -41,5 +43,14 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 	)
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
 
-	return nil
+	return scheme.AddFieldLabelConversionFunc(SchemeGroupVersion.WithKind(PackageManifestKind), PackageManifestFieldLabelConversionFunc)
+}
+
+// PackageManifestFieldLabelConversionFunc accepts the fields PackageManifests can be listed by.
+func PackageManifestFieldLabelConversionFunc(label, value string) (string, string, error) {
+	switch label {
+	case "metadata.name", "metadata.namespace", "status.packageName", "status.catalogSource", "status.catalogSourceNamespace", "status.defaultChannel":
+		return label, value, nil
+	}
+	return "", "", fmt.Errorf("field label not supported: %s", label)
 }
@@ -57,6 +57,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*ChannelEntryDependency)(nil), (*operators.ChannelEntryDependency)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_ChannelEntryDependency_To_operators_ChannelEntryDependency(a.(*ChannelEntryDependency), b.(*operators.ChannelEntryDependency), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*operators.ChannelEntryDependency)(nil), (*ChannelEntryDependency)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_operators_ChannelEntryDependency_To_v1_ChannelEntryDependency(a.(*operators.ChannelEntryDependency), b.(*ChannelEntryDependency), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ChannelEntryProperty)(nil), (*operators.ChannelEntryProperty)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_ChannelEntryProperty_To_operators_ChannelEntryProperty(a.(*ChannelEntryProperty), b.(*operators.ChannelEntryProperty), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*operators.ChannelEntryProperty)(nil), (*ChannelEntryProperty)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_operators_ChannelEntryProperty_To_v1_ChannelEntryProperty(a.(*operators.ChannelEntryProperty), b.(*ChannelEntryProperty), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*Icon)(nil), (*operators.Icon)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_Icon_To_operators_Icon(a.(*Icon), b.(*operators.Icon), scope)
 	}); err != nil {
@@ -206,6 +226,50 @@ func Convert_operators_CSVDescription_To_v1_CSVDescription(in *operators.CSVDesc
 	return autoConvert_operators_CSVDescription_To_v1_CSVDescription(in, out, s)
 }
 
+func autoConvert_v1_ChannelEntryDependency_To_operators_ChannelEntryDependency(in *ChannelEntryDependency, out *operators.ChannelEntryDependency, s conversion.Scope) error {
+	out.Type = in.Type
+	out.Value = in.Value
+	return nil
+}
+
+// Convert_v1_ChannelEntryDependency_To_operators_ChannelEntryDependency is an autogenerated conversion function.
+func Convert_v1_ChannelEntryDependency_To_operators_ChannelEntryDependency(in *ChannelEntryDependency, out *operators.ChannelEntryDependency, s conversion.Scope) error {
+	return autoConvert_v1_ChannelEntryDependency_To_operators_ChannelEntryDependency(in, out, s)
+}
+
+func autoConvert_operators_ChannelEntryDependency_To_v1_ChannelEntryDependency(in *operators.ChannelEntryDependency, out *ChannelEntryDependency, s conversion.Scope) error {
+	out.Type = in.Type
+	out.Value = in.Value
+	return nil
+}
+
+// Convert_operators_ChannelEntryDependency_To_v1_ChannelEntryDependency is an autogenerated conversion function.
+func Convert_operators_ChannelEntryDependency_To_v1_ChannelEntryDependency(in *operators.ChannelEntryDependency, out *ChannelEntryDependency, s conversion.Scope) error {
+	return autoConvert_operators_ChannelEntryDependency_To_v1_ChannelEntryDependency(in, out, s)
+}
+
+func autoConvert_v1_ChannelEntryProperty_To_operators_ChannelEntryProperty(in *ChannelEntryProperty, out *operators.ChannelEntryProperty, s conversion.Scope) error {
+	out.Type = in.Type
+	out.Value = in.Value
+	return nil
+}
+
+// Convert_v1_ChannelEntryProperty_To_operators_ChannelEntryProperty is an autogenerated conversion function.
+func Convert_v1_ChannelEntryProperty_To_operators_ChannelEntryProperty(in *ChannelEntryProperty, out *operators.ChannelEntryProperty, s conversion.Scope) error {
+	return autoConvert_v1_ChannelEntryProperty_To_operators_ChannelEntryProperty(in, out, s)
+}
+
+func autoConvert_operators_ChannelEntryProperty_To_v1_ChannelEntryProperty(in *operators.ChannelEntryProperty, out *ChannelEntryProperty, s conversion.Scope) error {
+	out.Type = in.Type
+	out.Value = in.Value
+	return nil
+}
+
+// Convert_operators_ChannelEntryProperty_To_v1_ChannelEntryProperty is an autogenerated conversion function.
+func Convert_operators_ChannelEntryProperty_To_v1_ChannelEntryProperty(in *operators.ChannelEntryProperty, out *ChannelEntryProperty, s conversion.Scope) error {
+	return autoConvert_operators_ChannelEntryProperty_To_v1_ChannelEntryProperty(in, out, s)
+}
+
 func autoConvert_v1_Icon_To_operators_Icon(in *Icon, out *operators.Icon, s conversion.Scope) error {
 	out.Base64Data = in.Base64Data
 	out.Mediatype = in.Mediatype
@@ -256,6 +320,8 @@ func autoConvert_v1_PackageChannel_To_operators_PackageChannel(in *PackageChanne
 	if err := Convert_v1_CSVDescription_To_operators_CSVDescription(&in.CurrentCSVDesc, &out.CurrentCSVDesc, s); err != nil {
 		return err
 	}
+	out.Properties = *(*[]operators.ChannelEntryProperty)(unsafe.Pointer(&in.Properties))
+	out.Dependencies = *(*[]operators.ChannelEntryDependency)(unsafe.Pointer(&in.Dependencies))
 	return nil
 }
 
@@ -270,6 +336,8 @@ func autoConvert_operators_PackageChannel_To_v1_PackageChannel(in *operators.Pac
 	if err := Convert_operators_CSVDescription_To_v1_CSVDescription(&in.CurrentCSVDesc, &out.CurrentCSVDesc, s); err != nil {
 		return err
 	}
+	out.Properties = *(*[]ChannelEntryProperty)(unsafe.Pointer(&in.Properties))
+	out.Dependencies = *(*[]ChannelEntryDependency)(unsafe.Pointer(&in.Dependencies))
 	return nil
 }
 
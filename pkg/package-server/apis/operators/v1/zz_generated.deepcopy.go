@@ -104,6 +104,38 @@ func (in *CSVDescription) DeepCopy() *CSVDescription {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ChannelEntryDependency) DeepCopyInto(out *ChannelEntryDependency) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChannelEntryDependency.
+func (in *ChannelEntryDependency) DeepCopy() *ChannelEntryDependency {
+	if in == nil {
+		return nil
+	}
+	out := new(ChannelEntryDependency)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ChannelEntryProperty) DeepCopyInto(out *ChannelEntryProperty) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChannelEntryProperty.
+func (in *ChannelEntryProperty) DeepCopy() *ChannelEntryProperty {
+	if in == nil {
+		return nil
+	}
+	out := new(ChannelEntryProperty)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Icon) DeepCopyInto(out *Icon) {
 	*out = *in
@@ -140,6 +172,16 @@ func (in *Maintainer) DeepCopy() *Maintainer {
 func (in *PackageChannel) DeepCopyInto(out *PackageChannel) {
 	*out = *in
 	in.CurrentCSVDesc.DeepCopyInto(&out.CurrentCSVDesc)
+	if in.Properties != nil {
+		in, out := &in.Properties, &out.Properties
+		*out = make([]ChannelEntryProperty, len(*in))
+		copy(*out, *in)
+	}
+	if in.Dependencies != nil {
+		in, out := &in.Dependencies, &out.Dependencies
+		*out = make([]ChannelEntryDependency, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -31,95 +31,97 @@ import (
 
 func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenAPIDefinition {
 	return map[string]common.OpenAPIDefinition{
-		"github.com/operator-framework/api/pkg/lib/version.OperatorVersion":                                                   schema_api_pkg_lib_version_OperatorVersion(ref),
-		"github.com/operator-framework/api/pkg/operators/v1alpha1.APIResourceReference":                                       schema_api_pkg_operators_v1alpha1_APIResourceReference(ref),
-		"github.com/operator-framework/api/pkg/operators/v1alpha1.APIServiceDefinitions":                                      schema_api_pkg_operators_v1alpha1_APIServiceDefinitions(ref),
-		"github.com/operator-framework/api/pkg/operators/v1alpha1.APIServiceDescription":                                      schema_api_pkg_operators_v1alpha1_APIServiceDescription(ref),
-		"github.com/operator-framework/api/pkg/operators/v1alpha1.ActionDescriptor":                                           schema_api_pkg_operators_v1alpha1_ActionDescriptor(ref),
-		"github.com/operator-framework/api/pkg/operators/v1alpha1.AppLink":                                                    schema_api_pkg_operators_v1alpha1_AppLink(ref),
-		"github.com/operator-framework/api/pkg/operators/v1alpha1.CRDDescription":                                             schema_api_pkg_operators_v1alpha1_CRDDescription(ref),
-		"github.com/operator-framework/api/pkg/operators/v1alpha1.CleanupSpec":                                                schema_api_pkg_operators_v1alpha1_CleanupSpec(ref),
-		"github.com/operator-framework/api/pkg/operators/v1alpha1.CleanupStatus":                                              schema_api_pkg_operators_v1alpha1_CleanupStatus(ref),
-		"github.com/operator-framework/api/pkg/operators/v1alpha1.ClusterServiceVersionCondition":                             schema_api_pkg_operators_v1alpha1_ClusterServiceVersionCondition(ref),
-		"github.com/operator-framework/api/pkg/operators/v1alpha1.ClusterServiceVersionSpec":                                  schema_api_pkg_operators_v1alpha1_ClusterServiceVersionSpec(ref),
-		"github.com/operator-framework/api/pkg/operators/v1alpha1.ClusterServiceVersionStatus":                                schema_api_pkg_operators_v1alpha1_ClusterServiceVersionStatus(ref),
-		"github.com/operator-framework/api/pkg/operators/v1alpha1.CustomResourceDefinitions":                                  schema_api_pkg_operators_v1alpha1_CustomResourceDefinitions(ref),
-		"github.com/operator-framework/api/pkg/operators/v1alpha1.DependentStatus":                                            schema_api_pkg_operators_v1alpha1_DependentStatus(ref),
-		"github.com/operator-framework/api/pkg/operators/v1alpha1.Icon":                                                       schema_api_pkg_operators_v1alpha1_Icon(ref),
-		"github.com/operator-framework/api/pkg/operators/v1alpha1.InstallMode":                                                schema_api_pkg_operators_v1alpha1_InstallMode(ref),
-		"github.com/operator-framework/api/pkg/operators/v1alpha1.Maintainer":                                                 schema_api_pkg_operators_v1alpha1_Maintainer(ref),
-		"github.com/operator-framework/api/pkg/operators/v1alpha1.NamedInstallStrategy":                                       schema_api_pkg_operators_v1alpha1_NamedInstallStrategy(ref),
-		"github.com/operator-framework/api/pkg/operators/v1alpha1.RelatedImage":                                               schema_api_pkg_operators_v1alpha1_RelatedImage(ref),
-		"github.com/operator-framework/api/pkg/operators/v1alpha1.RequirementStatus":                                          schema_api_pkg_operators_v1alpha1_RequirementStatus(ref),
-		"github.com/operator-framework/api/pkg/operators/v1alpha1.ResourceInstance":                                           schema_api_pkg_operators_v1alpha1_ResourceInstance(ref),
-		"github.com/operator-framework/api/pkg/operators/v1alpha1.ResourceList":                                               schema_api_pkg_operators_v1alpha1_ResourceList(ref),
-		"github.com/operator-framework/api/pkg/operators/v1alpha1.SpecDescriptor":                                             schema_api_pkg_operators_v1alpha1_SpecDescriptor(ref),
-		"github.com/operator-framework/api/pkg/operators/v1alpha1.StatusDescriptor":                                           schema_api_pkg_operators_v1alpha1_StatusDescriptor(ref),
-		"github.com/operator-framework/api/pkg/operators/v1alpha1.StrategyDeploymentPermissions":                              schema_api_pkg_operators_v1alpha1_StrategyDeploymentPermissions(ref),
-		"github.com/operator-framework/api/pkg/operators/v1alpha1.StrategyDeploymentSpec":                                     schema_api_pkg_operators_v1alpha1_StrategyDeploymentSpec(ref),
-		"github.com/operator-framework/api/pkg/operators/v1alpha1.StrategyDetailsDeployment":                                  schema_api_pkg_operators_v1alpha1_StrategyDetailsDeployment(ref),
-		"github.com/operator-framework/api/pkg/operators/v1alpha1.WebhookDescription":                                         schema_api_pkg_operators_v1alpha1_WebhookDescription(ref),
-		"github.com/operator-framework/operator-lifecycle-manager/pkg/package-server/apis/operators/v1.AppLink":               schema_package_server_apis_operators_v1_AppLink(ref),
-		"github.com/operator-framework/operator-lifecycle-manager/pkg/package-server/apis/operators/v1.CSVDescription":        schema_package_server_apis_operators_v1_CSVDescription(ref),
-		"github.com/operator-framework/operator-lifecycle-manager/pkg/package-server/apis/operators/v1.Icon":                  schema_package_server_apis_operators_v1_Icon(ref),
-		"github.com/operator-framework/operator-lifecycle-manager/pkg/package-server/apis/operators/v1.Maintainer":            schema_package_server_apis_operators_v1_Maintainer(ref),
-		"github.com/operator-framework/operator-lifecycle-manager/pkg/package-server/apis/operators/v1.PackageChannel":        schema_package_server_apis_operators_v1_PackageChannel(ref),
-		"github.com/operator-framework/operator-lifecycle-manager/pkg/package-server/apis/operators/v1.PackageManifest":       schema_package_server_apis_operators_v1_PackageManifest(ref),
-		"github.com/operator-framework/operator-lifecycle-manager/pkg/package-server/apis/operators/v1.PackageManifestList":   schema_package_server_apis_operators_v1_PackageManifestList(ref),
-		"github.com/operator-framework/operator-lifecycle-manager/pkg/package-server/apis/operators/v1.PackageManifestSpec":   schema_package_server_apis_operators_v1_PackageManifestSpec(ref),
-		"github.com/operator-framework/operator-lifecycle-manager/pkg/package-server/apis/operators/v1.PackageManifestStatus": schema_package_server_apis_operators_v1_PackageManifestStatus(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.APIGroup":                                                                       schema_pkg_apis_meta_v1_APIGroup(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.APIGroupList":                                                                   schema_pkg_apis_meta_v1_APIGroupList(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.APIResource":                                                                    schema_pkg_apis_meta_v1_APIResource(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.APIResourceList":                                                                schema_pkg_apis_meta_v1_APIResourceList(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.APIVersions":                                                                    schema_pkg_apis_meta_v1_APIVersions(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.ApplyOptions":                                                                   schema_pkg_apis_meta_v1_ApplyOptions(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.Condition":                                                                      schema_pkg_apis_meta_v1_Condition(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.CreateOptions":                                                                  schema_pkg_apis_meta_v1_CreateOptions(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.DeleteOptions":                                                                  schema_pkg_apis_meta_v1_DeleteOptions(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.Duration":                                                                       schema_pkg_apis_meta_v1_Duration(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.FieldsV1":                                                                       schema_pkg_apis_meta_v1_FieldsV1(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.GetOptions":                                                                     schema_pkg_apis_meta_v1_GetOptions(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.GroupKind":                                                                      schema_pkg_apis_meta_v1_GroupKind(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.GroupResource":                                                                  schema_pkg_apis_meta_v1_GroupResource(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.GroupVersion":                                                                   schema_pkg_apis_meta_v1_GroupVersion(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.GroupVersionForDiscovery":                                                       schema_pkg_apis_meta_v1_GroupVersionForDiscovery(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.GroupVersionKind":                                                               schema_pkg_apis_meta_v1_GroupVersionKind(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.GroupVersionResource":                                                           schema_pkg_apis_meta_v1_GroupVersionResource(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.InternalEvent":                                                                  schema_pkg_apis_meta_v1_InternalEvent(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector":                                                                  schema_pkg_apis_meta_v1_LabelSelector(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelectorRequirement":                                                       schema_pkg_apis_meta_v1_LabelSelectorRequirement(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.List":                                                                           schema_pkg_apis_meta_v1_List(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta":                                                                       schema_pkg_apis_meta_v1_ListMeta(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.ListOptions":                                                                    schema_pkg_apis_meta_v1_ListOptions(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.ManagedFieldsEntry":                                                             schema_pkg_apis_meta_v1_ManagedFieldsEntry(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.MicroTime":                                                                      schema_pkg_apis_meta_v1_MicroTime(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta":                                                                     schema_pkg_apis_meta_v1_ObjectMeta(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.OwnerReference":                                                                 schema_pkg_apis_meta_v1_OwnerReference(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.PartialObjectMetadata":                                                          schema_pkg_apis_meta_v1_PartialObjectMetadata(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.PartialObjectMetadataList":                                                      schema_pkg_apis_meta_v1_PartialObjectMetadataList(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.Patch":                                                                          schema_pkg_apis_meta_v1_Patch(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.PatchOptions":                                                                   schema_pkg_apis_meta_v1_PatchOptions(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.Preconditions":                                                                  schema_pkg_apis_meta_v1_Preconditions(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.RootPaths":                                                                      schema_pkg_apis_meta_v1_RootPaths(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.ServerAddressByClientCIDR":                                                      schema_pkg_apis_meta_v1_ServerAddressByClientCIDR(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.Status":                                                                         schema_pkg_apis_meta_v1_Status(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.StatusCause":                                                                    schema_pkg_apis_meta_v1_StatusCause(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.StatusDetails":                                                                  schema_pkg_apis_meta_v1_StatusDetails(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.Table":                                                                          schema_pkg_apis_meta_v1_Table(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.TableColumnDefinition":                                                          schema_pkg_apis_meta_v1_TableColumnDefinition(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.TableOptions":                                                                   schema_pkg_apis_meta_v1_TableOptions(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.TableRow":                                                                       schema_pkg_apis_meta_v1_TableRow(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.TableRowCondition":                                                              schema_pkg_apis_meta_v1_TableRowCondition(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.Time":                                                                           schema_pkg_apis_meta_v1_Time(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.Timestamp":                                                                      schema_pkg_apis_meta_v1_Timestamp(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.TypeMeta":                                                                       schema_pkg_apis_meta_v1_TypeMeta(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.UpdateOptions":                                                                  schema_pkg_apis_meta_v1_UpdateOptions(ref),
-		"k8s.io/apimachinery/pkg/apis/meta/v1.WatchEvent":                                                                     schema_pkg_apis_meta_v1_WatchEvent(ref),
-		"k8s.io/apimachinery/pkg/runtime.RawExtension":                                                                        schema_k8sio_apimachinery_pkg_runtime_RawExtension(ref),
-		"k8s.io/apimachinery/pkg/runtime.TypeMeta":                                                                            schema_k8sio_apimachinery_pkg_runtime_TypeMeta(ref),
-		"k8s.io/apimachinery/pkg/runtime.Unknown":                                                                             schema_k8sio_apimachinery_pkg_runtime_Unknown(ref),
-		"k8s.io/apimachinery/pkg/version.Info":                                                                                schema_k8sio_apimachinery_pkg_version_Info(ref),
+		"github.com/operator-framework/api/pkg/lib/version.OperatorVersion":                                                    schema_api_pkg_lib_version_OperatorVersion(ref),
+		"github.com/operator-framework/api/pkg/operators/v1alpha1.APIResourceReference":                                        schema_api_pkg_operators_v1alpha1_APIResourceReference(ref),
+		"github.com/operator-framework/api/pkg/operators/v1alpha1.APIServiceDefinitions":                                       schema_api_pkg_operators_v1alpha1_APIServiceDefinitions(ref),
+		"github.com/operator-framework/api/pkg/operators/v1alpha1.APIServiceDescription":                                       schema_api_pkg_operators_v1alpha1_APIServiceDescription(ref),
+		"github.com/operator-framework/api/pkg/operators/v1alpha1.ActionDescriptor":                                            schema_api_pkg_operators_v1alpha1_ActionDescriptor(ref),
+		"github.com/operator-framework/api/pkg/operators/v1alpha1.AppLink":                                                     schema_api_pkg_operators_v1alpha1_AppLink(ref),
+		"github.com/operator-framework/api/pkg/operators/v1alpha1.CRDDescription":                                              schema_api_pkg_operators_v1alpha1_CRDDescription(ref),
+		"github.com/operator-framework/api/pkg/operators/v1alpha1.CleanupSpec":                                                 schema_api_pkg_operators_v1alpha1_CleanupSpec(ref),
+		"github.com/operator-framework/api/pkg/operators/v1alpha1.CleanupStatus":                                               schema_api_pkg_operators_v1alpha1_CleanupStatus(ref),
+		"github.com/operator-framework/api/pkg/operators/v1alpha1.ClusterServiceVersionCondition":                              schema_api_pkg_operators_v1alpha1_ClusterServiceVersionCondition(ref),
+		"github.com/operator-framework/api/pkg/operators/v1alpha1.ClusterServiceVersionSpec":                                   schema_api_pkg_operators_v1alpha1_ClusterServiceVersionSpec(ref),
+		"github.com/operator-framework/api/pkg/operators/v1alpha1.ClusterServiceVersionStatus":                                 schema_api_pkg_operators_v1alpha1_ClusterServiceVersionStatus(ref),
+		"github.com/operator-framework/api/pkg/operators/v1alpha1.CustomResourceDefinitions":                                   schema_api_pkg_operators_v1alpha1_CustomResourceDefinitions(ref),
+		"github.com/operator-framework/api/pkg/operators/v1alpha1.DependentStatus":                                             schema_api_pkg_operators_v1alpha1_DependentStatus(ref),
+		"github.com/operator-framework/api/pkg/operators/v1alpha1.Icon":                                                        schema_api_pkg_operators_v1alpha1_Icon(ref),
+		"github.com/operator-framework/api/pkg/operators/v1alpha1.InstallMode":                                                 schema_api_pkg_operators_v1alpha1_InstallMode(ref),
+		"github.com/operator-framework/api/pkg/operators/v1alpha1.Maintainer":                                                  schema_api_pkg_operators_v1alpha1_Maintainer(ref),
+		"github.com/operator-framework/api/pkg/operators/v1alpha1.NamedInstallStrategy":                                        schema_api_pkg_operators_v1alpha1_NamedInstallStrategy(ref),
+		"github.com/operator-framework/api/pkg/operators/v1alpha1.RelatedImage":                                                schema_api_pkg_operators_v1alpha1_RelatedImage(ref),
+		"github.com/operator-framework/api/pkg/operators/v1alpha1.RequirementStatus":                                           schema_api_pkg_operators_v1alpha1_RequirementStatus(ref),
+		"github.com/operator-framework/api/pkg/operators/v1alpha1.ResourceInstance":                                            schema_api_pkg_operators_v1alpha1_ResourceInstance(ref),
+		"github.com/operator-framework/api/pkg/operators/v1alpha1.ResourceList":                                                schema_api_pkg_operators_v1alpha1_ResourceList(ref),
+		"github.com/operator-framework/api/pkg/operators/v1alpha1.SpecDescriptor":                                              schema_api_pkg_operators_v1alpha1_SpecDescriptor(ref),
+		"github.com/operator-framework/api/pkg/operators/v1alpha1.StatusDescriptor":                                            schema_api_pkg_operators_v1alpha1_StatusDescriptor(ref),
+		"github.com/operator-framework/api/pkg/operators/v1alpha1.StrategyDeploymentPermissions":                               schema_api_pkg_operators_v1alpha1_StrategyDeploymentPermissions(ref),
+		"github.com/operator-framework/api/pkg/operators/v1alpha1.StrategyDeploymentSpec":                                      schema_api_pkg_operators_v1alpha1_StrategyDeploymentSpec(ref),
+		"github.com/operator-framework/api/pkg/operators/v1alpha1.StrategyDetailsDeployment":                                   schema_api_pkg_operators_v1alpha1_StrategyDetailsDeployment(ref),
+		"github.com/operator-framework/api/pkg/operators/v1alpha1.WebhookDescription":                                          schema_api_pkg_operators_v1alpha1_WebhookDescription(ref),
+		"github.com/operator-framework/operator-lifecycle-manager/pkg/package-server/apis/operators/v1.AppLink":                schema_package_server_apis_operators_v1_AppLink(ref),
+		"github.com/operator-framework/operator-lifecycle-manager/pkg/package-server/apis/operators/v1.CSVDescription":         schema_package_server_apis_operators_v1_CSVDescription(ref),
+		"github.com/operator-framework/operator-lifecycle-manager/pkg/package-server/apis/operators/v1.ChannelEntryDependency": schema_package_server_apis_operators_v1_ChannelEntryDependency(ref),
+		"github.com/operator-framework/operator-lifecycle-manager/pkg/package-server/apis/operators/v1.ChannelEntryProperty":   schema_package_server_apis_operators_v1_ChannelEntryProperty(ref),
+		"github.com/operator-framework/operator-lifecycle-manager/pkg/package-server/apis/operators/v1.Icon":                   schema_package_server_apis_operators_v1_Icon(ref),
+		"github.com/operator-framework/operator-lifecycle-manager/pkg/package-server/apis/operators/v1.Maintainer":             schema_package_server_apis_operators_v1_Maintainer(ref),
+		"github.com/operator-framework/operator-lifecycle-manager/pkg/package-server/apis/operators/v1.PackageChannel":         schema_package_server_apis_operators_v1_PackageChannel(ref),
+		"github.com/operator-framework/operator-lifecycle-manager/pkg/package-server/apis/operators/v1.PackageManifest":        schema_package_server_apis_operators_v1_PackageManifest(ref),
+		"github.com/operator-framework/operator-lifecycle-manager/pkg/package-server/apis/operators/v1.PackageManifestList":    schema_package_server_apis_operators_v1_PackageManifestList(ref),
+		"github.com/operator-framework/operator-lifecycle-manager/pkg/package-server/apis/operators/v1.PackageManifestSpec":    schema_package_server_apis_operators_v1_PackageManifestSpec(ref),
+		"github.com/operator-framework/operator-lifecycle-manager/pkg/package-server/apis/operators/v1.PackageManifestStatus":  schema_package_server_apis_operators_v1_PackageManifestStatus(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.APIGroup":                                                                        schema_pkg_apis_meta_v1_APIGroup(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.APIGroupList":                                                                    schema_pkg_apis_meta_v1_APIGroupList(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.APIResource":                                                                     schema_pkg_apis_meta_v1_APIResource(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.APIResourceList":                                                                 schema_pkg_apis_meta_v1_APIResourceList(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.APIVersions":                                                                     schema_pkg_apis_meta_v1_APIVersions(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.ApplyOptions":                                                                    schema_pkg_apis_meta_v1_ApplyOptions(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.Condition":                                                                       schema_pkg_apis_meta_v1_Condition(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.CreateOptions":                                                                   schema_pkg_apis_meta_v1_CreateOptions(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.DeleteOptions":                                                                   schema_pkg_apis_meta_v1_DeleteOptions(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.Duration":                                                                        schema_pkg_apis_meta_v1_Duration(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.FieldsV1":                                                                        schema_pkg_apis_meta_v1_FieldsV1(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.GetOptions":                                                                      schema_pkg_apis_meta_v1_GetOptions(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.GroupKind":                                                                       schema_pkg_apis_meta_v1_GroupKind(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.GroupResource":                                                                   schema_pkg_apis_meta_v1_GroupResource(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.GroupVersion":                                                                    schema_pkg_apis_meta_v1_GroupVersion(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.GroupVersionForDiscovery":                                                        schema_pkg_apis_meta_v1_GroupVersionForDiscovery(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.GroupVersionKind":                                                                schema_pkg_apis_meta_v1_GroupVersionKind(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.GroupVersionResource":                                                            schema_pkg_apis_meta_v1_GroupVersionResource(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.InternalEvent":                                                                   schema_pkg_apis_meta_v1_InternalEvent(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector":                                                                   schema_pkg_apis_meta_v1_LabelSelector(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelectorRequirement":                                                        schema_pkg_apis_meta_v1_LabelSelectorRequirement(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.List":                                                                            schema_pkg_apis_meta_v1_List(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta":                                                                        schema_pkg_apis_meta_v1_ListMeta(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.ListOptions":                                                                     schema_pkg_apis_meta_v1_ListOptions(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.ManagedFieldsEntry":                                                              schema_pkg_apis_meta_v1_ManagedFieldsEntry(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.MicroTime":                                                                       schema_pkg_apis_meta_v1_MicroTime(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta":                                                                      schema_pkg_apis_meta_v1_ObjectMeta(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.OwnerReference":                                                                  schema_pkg_apis_meta_v1_OwnerReference(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.PartialObjectMetadata":                                                           schema_pkg_apis_meta_v1_PartialObjectMetadata(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.PartialObjectMetadataList":                                                       schema_pkg_apis_meta_v1_PartialObjectMetadataList(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.Patch":                                                                           schema_pkg_apis_meta_v1_Patch(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.PatchOptions":                                                                    schema_pkg_apis_meta_v1_PatchOptions(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.Preconditions":                                                                   schema_pkg_apis_meta_v1_Preconditions(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.RootPaths":                                                                       schema_pkg_apis_meta_v1_RootPaths(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.ServerAddressByClientCIDR":                                                       schema_pkg_apis_meta_v1_ServerAddressByClientCIDR(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.Status":                                                                          schema_pkg_apis_meta_v1_Status(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.StatusCause":                                                                     schema_pkg_apis_meta_v1_StatusCause(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.StatusDetails":                                                                   schema_pkg_apis_meta_v1_StatusDetails(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.Table":                                                                           schema_pkg_apis_meta_v1_Table(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.TableColumnDefinition":                                                           schema_pkg_apis_meta_v1_TableColumnDefinition(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.TableOptions":                                                                    schema_pkg_apis_meta_v1_TableOptions(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.TableRow":                                                                        schema_pkg_apis_meta_v1_TableRow(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.TableRowCondition":                                                               schema_pkg_apis_meta_v1_TableRowCondition(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.Time":                                                                            schema_pkg_apis_meta_v1_Time(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.Timestamp":                                                                       schema_pkg_apis_meta_v1_Timestamp(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.TypeMeta":                                                                        schema_pkg_apis_meta_v1_TypeMeta(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.UpdateOptions":                                                                   schema_pkg_apis_meta_v1_UpdateOptions(ref),
+		"k8s.io/apimachinery/pkg/apis/meta/v1.WatchEvent":                                                                      schema_pkg_apis_meta_v1_WatchEvent(ref),
+		"k8s.io/apimachinery/pkg/runtime.RawExtension":                                                                         schema_k8sio_apimachinery_pkg_runtime_RawExtension(ref),
+		"k8s.io/apimachinery/pkg/runtime.TypeMeta":                                                                             schema_k8sio_apimachinery_pkg_runtime_TypeMeta(ref),
+		"k8s.io/apimachinery/pkg/runtime.Unknown":                                                                              schema_k8sio_apimachinery_pkg_runtime_Unknown(ref),
+		"k8s.io/apimachinery/pkg/version.Info":                                                                                 schema_k8sio_apimachinery_pkg_version_Info(ref),
 	}
 }
 
@@ -1926,6 +1928,66 @@ func schema_package_server_apis_operators_v1_CSVDescription(ref common.Reference
 	}
 }
 
+func schema_package_server_apis_operators_v1_ChannelEntryDependency(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ChannelEntryDependency defines a dependency declared by the bundle of a channel's current CSV",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"type": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Type is the type of the dependency, e.g. `olm.package`",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"value": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Value is the JSON encoded value of the dependency",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"type", "value"},
+			},
+		},
+	}
+}
+
+func schema_package_server_apis_operators_v1_ChannelEntryProperty(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ChannelEntryProperty defines a property of the bundle of a channel's current CSV",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"type": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Type is the type of the property, e.g. `olm.gvk`",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"value": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Value is the JSON encoded value of the property",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"type", "value"},
+			},
+		},
+	}
+}
+
 func schema_package_server_apis_operators_v1_Icon(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -2006,12 +2068,50 @@ func schema_package_server_apis_operators_v1_PackageChannel(ref common.Reference
 							Ref:         ref("github.com/operator-framework/operator-lifecycle-manager/pkg/package-server/apis/operators/v1.CSVDescription"),
 						},
 					},
+					"properties": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Properties are the olm.gvk, olm.package and olm.constraint properties of the bundle of the current CSV",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/operator-framework/operator-lifecycle-manager/pkg/package-server/apis/operators/v1.ChannelEntryProperty"),
+									},
+								},
+							},
+						},
+					},
+					"dependencies": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Dependencies are the dependencies declared by the bundle of the current CSV",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/operator-framework/operator-lifecycle-manager/pkg/package-server/apis/operators/v1.ChannelEntryDependency"),
+									},
+								},
+							},
+						},
+					},
 				},
 				Required: []string{"name", "currentCSV"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/operator-framework/operator-lifecycle-manager/pkg/package-server/apis/operators/v1.CSVDescription"},
+			"github.com/operator-framework/operator-lifecycle-manager/pkg/package-server/apis/operators/v1.CSVDescription", "github.com/operator-framework/operator-lifecycle-manager/pkg/package-server/apis/operators/v1.ChannelEntryDependency", "github.com/operator-framework/operator-lifecycle-manager/pkg/package-server/apis/operators/v1.ChannelEntryProperty"},
 	}
 }
 
@@ -0,0 +1,115 @@
+package provider
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sync"
+
+	"k8s.io/utils/lru"
+
+	operatorsv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/registry"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/package-server/apis/operators"
+	"github.com/operator-framework/operator-registry/pkg/api"
+)
+
+// renderedCacheSize is the number of rendered PackageManifests kept across all catalogs.
+const renderedCacheSize = 4096
+
+// renderedCache caches the PackageManifests rendered from the packages of catalogs, so that refreshing a catalog only
+// fetches the bundles of, and renders, the packages whose channel heads or CatalogSource changed. The bundles of a
+// channel head may still be rebuilt in place: invalidating a catalog, once a new connection to its registry server
+// is ready, renders all its packages again.
+type renderedCache struct {
+	cache *lru.Cache
+
+	mu          sync.Mutex
+	generations map[registry.CatalogKey]int
+}
+
+func newRenderedCache(size int) *renderedCache {
+	return &renderedCache{
+		cache:       lru.New(size),
+		generations: map[registry.CatalogKey]int{},
+	}
+}
+
+type renderedKey struct {
+	catalog     registry.CatalogKey
+	generation  int
+	fingerprint string
+}
+
+// key returns the key of the PackageManifest rendered from the given package of the given catalog.
+func (c *renderedCache) key(catsrc *operatorsv1alpha1.CatalogSource, pkg *api.Package) (renderedKey, error) {
+	catalog := registry.CatalogKey{Namespace: catsrc.GetNamespace(), Name: catsrc.GetName()}
+	fingerprint, err := packageFingerprint(catsrc, pkg)
+	if err != nil {
+		return renderedKey{}, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return renderedKey{catalog: catalog, generation: c.generations[catalog], fingerprint: fingerprint}, nil
+}
+
+func (c *renderedCache) get(key renderedKey) (*operators.PackageManifest, bool) {
+	manifest, ok := c.cache.Get(key)
+	if !ok {
+		return nil, false
+	}
+	return manifest.(*operators.PackageManifest), true
+}
+
+func (c *renderedCache) add(key renderedKey, manifest *operators.PackageManifest) {
+	c.cache.Add(key, manifest)
+}
+
+// invalidate renders the packages of the given catalog again on its next refresh. The PackageManifests cached for it
+// are evicted as the cache fills up.
+func (c *renderedCache) invalidate(catalog registry.CatalogKey) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.generations[catalog]++
+}
+
+// forget drops the generation of a deleted catalog.
+func (c *renderedCache) forget(catalog registry.CatalogKey) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.generations, catalog)
+}
+
+// packageFingerprint returns a digest of the package and of the CatalogSource fields its PackageManifest is rendered
+// from, except for the bundles of its channel heads.
+func packageFingerprint(catsrc *operatorsv1alpha1.CatalogSource, pkg *api.Package) (string, error) {
+	type channel struct {
+		Name    string `json:"name"`
+		CSVName string `json:"csvName"`
+	}
+	fingerprinted := struct {
+		Labels            map[string]string `json:"labels"`
+		DisplayName       string            `json:"displayName"`
+		Publisher         string            `json:"publisher"`
+		CreationTimestamp string            `json:"creationTimestamp"`
+		Package           string            `json:"package"`
+		DefaultChannel    string            `json:"defaultChannel"`
+		Channels          []channel         `json:"channels"`
+	}{
+		Labels:            catsrc.GetLabels(),
+		DisplayName:       catsrc.Spec.DisplayName,
+		Publisher:         catsrc.Spec.Publisher,
+		CreationTimestamp: catsrc.GetCreationTimestamp().UTC().String(),
+		Package:           pkg.GetName(),
+		DefaultChannel:    pkg.GetDefaultChannelName(),
+	}
+	for _, ch := range pkg.GetChannels() {
+		fingerprinted.Channels = append(fingerprinted.Channels, channel{Name: ch.GetName(), CSVName: ch.GetCsvName()})
+	}
+	raw, err := json.Marshal(fingerprinted)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,77 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	operatorsv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/registry"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/package-server/apis/operators"
+	"github.com/operator-framework/operator-registry/pkg/api"
+)
+
+func TestRenderedCache(t *testing.T) {
+	catsrc := &operatorsv1alpha1.CatalogSource{
+		ObjectMeta: metav1.ObjectMeta{Name: "cool-operators", Namespace: "ns", Labels: map[string]string{"team": "a"}},
+		Spec:       operatorsv1alpha1.CatalogSourceSpec{DisplayName: "Cool Operators"},
+	}
+	pkg := &api.Package{
+		Name:               "etcd",
+		DefaultChannelName: "stable",
+		Channels:           []*api.Channel{{Name: "stable", CsvName: "etcdoperator.v0.9.2"}},
+	}
+	c := newRenderedCache(2)
+
+	key, err := c.key(catsrc, pkg)
+	require.NoError(t, err)
+	_, ok := c.get(key)
+	require.False(t, ok)
+	manifest := &operators.PackageManifest{ObjectMeta: metav1.ObjectMeta{Name: "etcd"}}
+	c.add(key, manifest)
+
+	// unchanged packages are cached, even as their CatalogSource status changes
+	catsrc.Status.Message = "polled"
+	unchanged, err := c.key(catsrc, pkg)
+	require.NoError(t, err)
+	cached, ok := c.get(unchanged)
+	require.True(t, ok)
+	require.Same(t, manifest, cached)
+
+	for name, change := range map[string]func(*operatorsv1alpha1.CatalogSource, *api.Package){
+		"ChannelHead": func(catsrc *operatorsv1alpha1.CatalogSource, pkg *api.Package) {
+			pkg.Channels[0].CsvName = "etcdoperator.v0.9.4"
+		},
+		"NewChannel": func(catsrc *operatorsv1alpha1.CatalogSource, pkg *api.Package) {
+			pkg.Channels = append(pkg.Channels, &api.Channel{Name: "alpha", CsvName: "etcdoperator.v0.9.4"})
+		},
+		"DefaultChannel": func(catsrc *operatorsv1alpha1.CatalogSource, pkg *api.Package) {
+			pkg.DefaultChannelName = "alpha"
+		},
+		"CatalogSourceLabels": func(catsrc *operatorsv1alpha1.CatalogSource, pkg *api.Package) {
+			catsrc.Labels = map[string]string{"team": "b"}
+		},
+		"CatalogSourceDisplayName": func(catsrc *operatorsv1alpha1.CatalogSource, pkg *api.Package) {
+			catsrc.Spec.DisplayName = "Cooler Operators"
+		},
+	} {
+		changedCatsrc, changedPkg := catsrc.DeepCopy(), &api.Package{
+			Name:               pkg.Name,
+			DefaultChannelName: pkg.DefaultChannelName,
+			Channels:           []*api.Channel{{Name: pkg.Channels[0].Name, CsvName: pkg.Channels[0].CsvName}},
+		}
+		change(changedCatsrc, changedPkg)
+		changed, err := c.key(changedCatsrc, changedPkg)
+		require.NoError(t, err)
+		_, ok := c.get(changed)
+		require.False(t, ok, name)
+	}
+
+	// invalidated catalogs are rendered again
+	c.invalidate(registry.CatalogKey{Namespace: "ns", Name: "cool-operators"})
+	invalidated, err := c.key(catsrc, pkg)
+	require.NoError(t, err)
+	_, ok = c.get(invalidated)
+	require.False(t, ok)
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -14,12 +15,18 @@ import (
 	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/connectivity"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	corev1listers "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
 
+	"github.com/operator-framework/api/pkg/constraints"
 	operatorsv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/api/client/clientset/versioned"
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/api/client/informers/externalversions"
@@ -27,9 +34,11 @@ import (
 	registrygrpc "github.com/operator-framework/operator-lifecycle-manager/pkg/controller/registry/grpc"
 	utillabels "github.com/operator-framework/operator-lifecycle-manager/pkg/lib/kubernetes/pkg/util/labels"
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/queueinformer"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/metrics"
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/package-server/apis/operators"
 	pkglisters "github.com/operator-framework/operator-lifecycle-manager/pkg/package-server/client/listers/operators/internalversion"
 	"github.com/operator-framework/operator-registry/pkg/api"
+	opregistry "github.com/operator-framework/operator-registry/pkg/registry"
 )
 
 const (
@@ -121,11 +130,13 @@ type RegistryProvider struct {
 	cache           cache.Indexer
 	pkgLister       pkglisters.PackageManifestLister
 	catsrcLister    operatorslisters.CatalogSourceLister
+	secretLister    corev1listers.SecretLister
+	rendered        *renderedCache
 }
 
 var _ PackageManifestProvider = &RegistryProvider{}
 
-func NewRegistryProvider(ctx context.Context, crClient versioned.Interface, operator queueinformer.Operator, wakeupInterval time.Duration, globalNamespace string) (*RegistryProvider, error) {
+func NewRegistryProvider(ctx context.Context, crClient versioned.Interface, kubeClient kubernetes.Interface, operator queueinformer.Operator, wakeupInterval time.Duration, globalNamespace string) (*RegistryProvider, error) {
 	p := &RegistryProvider{
 		Operator: operator,
 
@@ -134,6 +145,7 @@ func NewRegistryProvider(ctx context.Context, crClient versioned.Interface, oper
 			cache.NamespaceIndex: cache.MetaNamespaceIndexFunc,
 			catalogIndex:         catalogIndexFunc,
 		}),
+		rendered: newRenderedCache(renderedCacheSize),
 	}
 	p.sources = registrygrpc.NewSourceStore(logrus.New(), stateTimeout, readyTimeout, p.syncSourceState)
 	p.pkgLister = pkglisters.NewPackageManifestLister(p.cache)
@@ -154,6 +166,15 @@ func NewRegistryProvider(ctx context.Context, crClient versioned.Interface, oper
 	}
 	p.catsrcLister = catsrcInformer.Lister()
 
+	// Secrets referenced by CatalogSources authenticate connections to external registry servers
+	secretInformer := informers.NewSharedInformerFactoryWithOptions(kubeClient, wakeupInterval, informers.WithTweakListOptions(func(options *metav1.ListOptions) {
+		options.FieldSelector = fields.OneTermNotEqualSelector("type", string(corev1.SecretTypeServiceAccountToken)).String()
+	})).Core().V1().Secrets()
+	if err := p.RegisterInformer(secretInformer.Informer()); err != nil {
+		return nil, err
+	}
+	p.secretLister = secretInformer.Lister()
+
 	return p, nil
 }
 
@@ -191,7 +212,18 @@ func (p *RegistryProvider) syncCatalogSource(obj interface{}) (syncError error)
 		Name:      source.GetName(),
 	}
 
-	if sourceMeta := p.sources.GetMeta(key); sourceMeta != nil && sourceMeta.Address == address {
+	creds, err := registrygrpc.SourceCredentials(p.secretLister, source)
+	if err != nil {
+		logger.WithError(err).Warn("failed to load registry credentials")
+		syncError = err
+		return
+	}
+	var credsRevision string
+	if creds != nil {
+		credsRevision = creds.Revision
+	}
+
+	if sourceMeta := p.sources.GetMeta(key); sourceMeta != nil && sourceMeta.Address == address && sourceMeta.CredentialsRevision == credsRevision {
 		logger.Infof("updating PackageManifest based on CatalogSource changes: %v", key)
 		timeout, cancel := context.WithTimeout(context.Background(), cacheTimeout)
 		defer cancel()
@@ -205,7 +237,7 @@ func (p *RegistryProvider) syncCatalogSource(obj interface{}) (syncError error)
 	}
 
 	logger.Info("connecting to source")
-	if _, syncError = p.sources.Add(key, address); syncError != nil {
+	if _, syncError = p.sources.AddWithCredentials(key, address, creds); syncError != nil {
 		logger.Warn("failed to create a new source")
 	}
 
@@ -227,6 +259,8 @@ func (p *RegistryProvider) syncSourceState(state registrygrpc.SourceState) {
 	var err error
 	switch state.State {
 	case connectivity.Ready:
+		// the registry server may have been replaced by one serving other bundles under the same channel heads
+		p.rendered.invalidate(key)
 		var client *registryClient
 		client, err = p.registryClient(key)
 		if err == nil {
@@ -266,6 +300,9 @@ func (p *RegistryProvider) registryClient(key registry.CatalogKey) (client *regi
 	return
 }
 
+// refreshCache caches the PackageManifests of the packages the registry server of the given client streams, and
+// garbage collects those of the packages it no longer serves. Packages whose channel heads and CatalogSource haven't
+// changed since they were last rendered aren't rendered again.
 func (p *RegistryProvider) refreshCache(ctx context.Context, client *registryClient) error {
 	key, err := client.key()
 	if err != nil {
@@ -276,6 +313,10 @@ func (p *RegistryProvider) refreshCache(ctx context.Context, client *registryCli
 		"action": "refresh cache",
 		"source": key,
 	})
+	start := time.Now()
+	defer func() {
+		metrics.EmitCatalogRefreshDuration(key.Namespace, key.Name, time.Since(start))
+	}()
 
 	stream, err := client.ListPackages(ctx, &api.ListPackageRequest{})
 	if err != nil {
@@ -284,9 +325,10 @@ func (p *RegistryProvider) refreshCache(ctx context.Context, client *registryCli
 	}
 
 	var (
-		added = map[string]struct{}{}
-		mu    sync.Mutex
-		wg    sync.WaitGroup
+		added        = map[string]struct{}{}
+		hits, misses int
+		mu           sync.Mutex
+		wg           sync.WaitGroup
 	)
 	for {
 		pkgName, err := stream.Recv()
@@ -307,11 +349,20 @@ func (p *RegistryProvider) refreshCache(ctx context.Context, client *registryCli
 				return
 			}
 
-			newPkg, err := newPackageManifest(ctx, logger, pkg, client)
+			renderedKey, err := p.rendered.key(client.catsrc, pkg)
 			if err != nil {
-				logger.WithField("err", err.Error()).Warnf("eliding package: error converting to packagemanifest")
+				logger.WithField("err", err.Error()).Warnf("eliding package: error fingerprinting package")
 				return
 			}
+			newPkg, cached := p.rendered.get(renderedKey)
+			if !cached {
+				newPkg, err = newPackageManifest(ctx, logger, pkg, client)
+				if err != nil {
+					logger.WithField("err", err.Error()).Warnf("eliding package: error converting to packagemanifest")
+					return
+				}
+				p.rendered.add(renderedKey, newPkg)
+			}
 
 			if err := p.cache.Add(newPkg); err != nil {
 				logger.WithField("err", err.Error()).Warnf("eliding package: failed to add to cache")
@@ -321,12 +372,18 @@ func (p *RegistryProvider) refreshCache(ctx context.Context, client *registryCli
 			mu.Lock()
 			defer mu.Unlock()
 			added[newPkg.GetName()] = struct{}{}
+			if cached {
+				hits++
+			} else {
+				misses++
+			}
 		}()
 	}
 
 	logger.Debug("caching new packages...")
 	wg.Wait()
-	logger.Debug("new packages cached")
+	logger.WithFields(logrus.Fields{"rendered": misses, "unchanged": hits}).Debug("new packages cached")
+	metrics.EmitPackageRenders(hits, misses)
 
 	// Garbage collect orphaned packagemanifests from the cache
 	return p.gcPackages(key, added)
@@ -388,6 +445,8 @@ func (p *RegistryProvider) catalogSourceDeleted(obj interface{}) {
 	if err := p.sources.Remove(key); err != nil {
 		logger.WithError(err).Warn("failed to remove source")
 	}
+	p.rendered.forget(key)
+	metrics.DeleteCatalogRefreshDurationMetric(key.Namespace, key.Name)
 
 	if err := p.gcPackages(key, nil); err != nil {
 		logger.WithError(err).Warn("failed to gc orphaned packages in cache")
@@ -454,6 +513,49 @@ func (p *RegistryProvider) List(namespace string, selector labels.Selector) (*op
 	return pkgList, nil
 }
 
+// channelEntryPropertyTypes are the types of the bundle properties listed on PackageManifest channels. Other types,
+// e.g. olm.bundle.object, are left out since they can be large and aren't needed to tell what a bundle provides.
+var channelEntryPropertyTypes = map[string]struct{}{
+	opregistry.GVKType:            {},
+	opregistry.PackageType:        {},
+	constraints.OLMConstraintType: {},
+}
+
+// channelEntryProperties returns the given bundle properties that are listed on PackageManifest channels, sorted by
+// type and value since registries don't return them in a stable order.
+func channelEntryProperties(properties []*api.Property) []operators.ChannelEntryProperty {
+	var entries []operators.ChannelEntryProperty
+	for _, property := range properties {
+		if _, ok := channelEntryPropertyTypes[property.GetType()]; !ok {
+			continue
+		}
+		entries = append(entries, operators.ChannelEntryProperty{Type: property.GetType(), Value: property.GetValue()})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Type != entries[j].Type {
+			return entries[i].Type < entries[j].Type
+		}
+		return entries[i].Value < entries[j].Value
+	})
+	return entries
+}
+
+// channelEntryDependencies returns the given bundle dependencies as listed on PackageManifest channels, sorted by type
+// and value.
+func channelEntryDependencies(dependencies []*api.Dependency) []operators.ChannelEntryDependency {
+	var entries []operators.ChannelEntryDependency
+	for _, dependency := range dependencies {
+		entries = append(entries, operators.ChannelEntryDependency{Type: dependency.GetType(), Value: dependency.GetValue()})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Type != entries[j].Type {
+			return entries[i].Type < entries[j].Type
+		}
+		return entries[i].Value < entries[j].Value
+	})
+	return entries
+}
+
 func newPackageManifest(ctx context.Context, logger *logrus.Entry, pkg *api.Package, client *registryClient) (*operators.PackageManifest, error) {
 	pkgChannels := pkg.GetChannels()
 	catsrc := client.catsrc
@@ -503,6 +605,8 @@ func newPackageManifest(ctx context.Context, logger *logrus.Entry, pkg *api.Pack
 			Name:           pkgChannel.GetName(),
 			CurrentCSV:     csv.GetName(),
 			CurrentCSVDesc: operators.CreateCSVDescription(&csv, bundle.GetCsvJson()),
+			Properties:     channelEntryProperties(bundle.GetProperties()),
+			Dependencies:   channelEntryDependencies(bundle.GetDependencies()),
 		})
 
 		if manifest.Status.DefaultChannel != "" && pkgChannel.GetName() == manifest.Status.DefaultChannel || !providerSet {
@@ -92,7 +92,7 @@ func NewFakeRegistryProvider(ctx context.Context, clientObjs []runtime.Object, k
 
 	resyncInterval := 5 * time.Minute
 
-	return NewRegistryProvider(ctx, clientFake, op, resyncInterval, globalNamespace)
+	return NewRegistryProvider(ctx, clientFake, k8sClientFake, op, resyncInterval, globalNamespace)
 }
 
 func catalogSource(name, namespace string) *operatorsv1alpha1.CatalogSource {
@@ -135,6 +135,22 @@ var (
 	prometheusCSVJSON     = `{"apiVersion":"operators.coreos.com/v1alpha1","kind":"ClusterServiceVersion","metadata":{"annotations":{"alm-examples":"[{\"apiVersion\":\"monitoring.coreos.com/v1\",\"kind\":\"Prometheus\",\"metadata\":{\"name\":\"example\",\"labels\":{\"prometheus\":\"k8s\"}},\"spec\":{\"replicas\":2,\"version\":\"v2.3.2\",\"serviceAccountName\":\"prometheus-k8s\",\"securityContext\": {}, \"serviceMonitorSelector\":{\"matchExpressions\":[{\"key\":\"k8s-app\",\"operator\":\"Exists\"}]},\"ruleSelector\":{\"matchLabels\":{\"role\":\"prometheus-rulefiles\",\"prometheus\":\"k8s\"}},\"alerting\":{\"alertmanagers\":[{\"namespace\":\"monitoring\",\"name\":\"alertmanager-main\",\"port\":\"web\"}]}}},{\"apiVersion\":\"monitoring.coreos.com/v1\",\"kind\":\"ServiceMonitor\",\"metadata\":{\"name\":\"example\",\"labels\":{\"k8s-app\":\"prometheus\"}},\"spec\":{\"selector\":{\"matchLabels\":{\"k8s-app\":\"prometheus\"}},\"endpoints\":[{\"port\":\"web\",\"interval\":\"30s\"}]}},{\"apiVersion\":\"monitoring.coreos.com/v1\",\"kind\":\"Alertmanager\",\"metadata\":{\"name\":\"alertmanager-main\"},\"spec\":{\"replicas\":3, \"securityContext\": {}}}]"},"name":"prometheusoperator.0.22.2","namespace":"placeholder"},"spec":{"customresourcedefinitions":{"owned":[{"description":"A running Prometheus instance","displayName":"Prometheus","kind":"Prometheus","name":"prometheuses.monitoring.coreos.com","resources":[{"kind":"StatefulSet","version":"v1beta2"},{"kind":"Pod","version":"v1"}],"specDescriptors":[{"description":"Desired number of Pods for the cluster","displayName":"Size","path":"replicas","x-descriptors":["urn:alm:descriptor:com.tectonic.ui:podCount"]},{"description":"A selector for the ConfigMaps from which to load rule files","displayName":"Rule Config Map Selector","path":"ruleSelector","x-descriptors":["urn:alm:descriptor:com.tectonic.ui:selector:core:v1:ConfigMap"]},{"description":"ServiceMonitors to be selected for target discovery","displayName":"Service Monitor Selector","path":"serviceMonitorSelector","x-descriptors":["urn:alm:descriptor:com.tectonic.ui:selector:monitoring.coreos.com:v1:ServiceMonitor"]},{"description":"The ServiceAccount to use to run the Prometheus pods","displayName":"Service Account","path":"serviceAccountName","x-descriptors":["urn:alm:descriptor:io.kubernetes:ServiceAccount"]},{"description":"Limits describes the minimum/maximum amount of compute resources required/allowed","displayName":"Resource Requirements","path":"resources","x-descriptors":["urn:alm:descriptor:com.tectonic.ui:resourceRequirements"]}],"version":"v1"},{"description":"A Prometheus Rule configures groups of sequentially evaluated recording and alerting rules.","displayName":"Prometheus Rule","kind":"PrometheusRule","name":"prometheusrules.monitoring.coreos.com","version":"v1"},{"description":"Configures prometheus to monitor a particular k8s service","displayName":"Service Monitor","kind":"ServiceMonitor","name":"servicemonitors.monitoring.coreos.com","resources":[{"kind":"Pod","version":"v1"}],"specDescriptors":[{"description":"The label to use to retrieve the job name from","displayName":"Job Label","path":"jobLabel","x-descriptors":["urn:alm:descriptor:com.tectonic.ui:label"]},{"description":"A list of endpoints allowed as part of this ServiceMonitor","displayName":"Endpoints","path":"endpoints","x-descriptors":["urn:alm:descriptor:com.tectonic.ui:endpointList"]}],"version":"v1"},{"description":"Configures an Alertmanager for the namespace","displayName":"Alertmanager","kind":"Alertmanager","name":"alertmanagers.monitoring.coreos.com","resources":[{"kind":"StatefulSet","version":"v1beta2"},{"kind":"Pod","version":"v1"}],"specDescriptors":[{"description":"Desired number of Pods for the cluster","displayName":"Size","path":"replicas","x-descriptors":["urn:alm:descriptor:com.tectonic.ui:podCount"]},{"description":"Limits describes the minimum/maximum amount of compute resources required/allowed","displayName":"Resource Requirements","path":"resources","x-descriptors":["urn:alm:descriptor:com.tectonic.ui:resourceRequirements"]}],"version":"v1"}]},"description":"The Prometheus Operator for Kubernetes provides easy monitoring definitions for Kubernetes services and deployment and management of Prometheus instances.\n\nOnce installed, the Prometheus Operator provides the following features:\n\n* **Create/Destroy**: Easily launch a Prometheus instance for your Kubernetes namespace, a specific application or team easily using the Operator.\n\n* **Simple Configuration**: Configure the fundamentals of Prometheus like versions, persistence, retention policies, and replicas from a native Kubernetes resource.\n\n* **Target Services via Labels**: Automatically generate monitoring target configurations based on familiar Kubernetes label queries; no need to learn a Prometheus specific configuration language.\n\n### Other Supported Features\n\n**High availability**\n\nMultiple instances are run across failure zones and data is replicated. This keeps your monitoring available during an outage, when you need it most.\n\n**Updates via automated operations**\n\nNew Prometheus versions are deployed using a rolling update with no downtime, making it easy to stay up to date.\n\n**Handles the dynamic nature of containers**\n\nAlerting rules are attached to groups of containers instead of individual instances, which is ideal for the highly dynamic nature of container deployment.\n","displayName":"Prometheus Operator","icon":[{"base64data":"PHN2ZyB3aWR0aD0iMjQ5MCIgaGVpZ2h0PSIyNTAwIiB2aWV3Qm94PSIwIDAgMjU2IDI1NyIgeG1sbnM9Imh0dHA6Ly93d3cudzMub3JnLzIwMDAvc3ZnIiBwcmVzZXJ2ZUFzcGVjdFJhdGlvPSJ4TWlkWU1pZCI+PHBhdGggZD0iTTEyOC4wMDEuNjY3QzU3LjMxMS42NjcgMCA1Ny45NzEgMCAxMjguNjY0YzAgNzAuNjkgNTcuMzExIDEyNy45OTggMTI4LjAwMSAxMjcuOTk4UzI1NiAxOTkuMzU0IDI1NiAxMjguNjY0QzI1NiA1Ny45NyAxOTguNjg5LjY2NyAxMjguMDAxLjY2N3ptMCAyMzkuNTZjLTIwLjExMiAwLTM2LjQxOS0xMy40MzUtMzYuNDE5LTMwLjAwNGg3Mi44MzhjMCAxNi41NjYtMTYuMzA2IDMwLjAwNC0zNi40MTkgMzAuMDA0em02MC4xNTMtMzkuOTRINjcuODQyVjE3OC40N2gxMjAuMzE0djIxLjgxNmgtLjAwMnptLS40MzItMzMuMDQ1SDY4LjE4NWMtLjM5OC0uNDU4LS44MDQtLjkxLTEuMTg4LTEuMzc1LTEyLjMxNS0xNC45NTQtMTUuMjE2LTIyLjc2LTE4LjAzMi0zMC43MTYtLjA0OC0uMjYyIDE0LjkzMyAzLjA2IDI1LjU1NiA1LjQ1IDAgMCA1LjQ2NiAxLjI2NSAxMy40NTggMi43MjItNy42NzMtOC45OTQtMTIuMjMtMjAuNDI4LTEyLjIzLTMyLjExNiAwLTI1LjY1OCAxOS42OC00OC4wNzkgMTIuNTgtNjYuMjAxIDYuOTEuNTYyIDE0LjMgMTQuNTgzIDE0LjggMzYuNTA1IDcuMzQ2LTEwLjE1MiAxMC40Mi0yOC42OSAxMC40Mi00MC4wNTYgMC0xMS43NjkgNy43NTUtMjUuNDQgMTUuNTEyLTI1LjkwNy02LjkxNSAxMS4zOTYgMS43OSAyMS4xNjUgOS41MyA0NS40IDIuOTAyIDkuMTAzIDIuNTMyIDI0LjQyMyA0Ljc3MiAzNC4xMzguNzQ0LTIwLjE3OCA0LjIxMy00OS42MiAxNy4wMTQtNTkuNzg0LTUuNjQ3IDEyLjguODM2IDI4LjgxOCA1LjI3IDM2LjUxOCA3LjE1NCAxMi40MjQgMTEuNDkgMjEuODM2IDExLjQ5IDM5LjYzOCAwIDExLjkzNi00LjQwNyAyMy4xNzMtMTEuODQgMzEuOTU4IDguNDUyLTEuNTg2IDE0LjI4OS0zLjAxNiAxNC4yODktMy4wMTZsMjcuNDUtNS4zNTVjLjAwMi0uMDAyLTMuOTg3IDE2LjQwMS0xOS4zMTQgMzIuMTk3eiIgZmlsbD0iI0RBNEUzMSIvPjwvc3ZnPg==","mediatype":"image/svg+xml"}],"install":{"spec":{"deployments":[{"name":"prometheus-operator","spec":{"replicas":1,"selector":{"matchLabels":{"k8s-app":"prometheus-operator"}},"template":{"metadata":{"labels":{"k8s-app":"prometheus-operator"}},"spec":{"containers":[{"args":["-namespace=$(K8S_NAMESPACE)","-manage-crds=false","-logtostderr=true","--config-reloader-image=quay.io/coreos/configmap-reload:v0.0.1","--prometheus-config-reloader=quay.io/coreos/prometheus-config-reloader:v0.22.2"],"env":[{"name":"K8S_NAMESPACE","valueFrom":{"fieldRef":{"fieldPath":"metadata.namespace"}}}],"image":"quay.io/coreos/prometheus-operator@sha256:3daa69a8c6c2f1d35dcf1fe48a7cd8b230e55f5229a1ded438f687debade5bcf","name":"prometheus-operator","ports":[{"containerPort":8080,"name":"http"}],"resources":{"limits":{"cpu":"200m","memory":"100Mi"},"requests":{"cpu":"100m","memory":"50Mi"}},"securityContext":{"allowPrivilegeEscalation":false,"readOnlyRootFilesystem":true}}],"nodeSelector":{"kubernetes.io/os":"linux"},"serviceAccount":"prometheus-operator-0-22-2"}}}}],"permissions":[{"rules":[{"apiGroups":[""],"resources":["nodes","services","endpoints","pods"],"verbs":["get","list","watch"]},{"apiGroups":[""],"resources":["configmaps"],"verbs":["get"]}],"serviceAccountName":"prometheus-k8s"},{"rules":[{"apiGroups":["apiextensions.k8s.io"],"resources":["customresourcedefinitions"],"verbs":["*"]},{"apiGroups":["monitoring.coreos.com"],"resources":["alertmanagers","prometheuses","prometheuses/finalizers","alertmanagers/finalizers","servicemonitors","prometheusrules"],"verbs":["*"]},{"apiGroups":["apps"],"resources":["statefulsets"],"verbs":["*"]},{"apiGroups":[""],"resources":["configmaps","secrets"],"verbs":["*"]},{"apiGroups":[""],"resources":["pods"],"verbs":["list","delete"]},{"apiGroups":[""],"resources":["services","endpoints"],"verbs":["get","create","update"]},{"apiGroups":[""],"resources":["nodes"],"verbs":["list","watch"]},{"apiGroups":[""],"resources":["namespaces"],"verbs":["list","watch"]}],"serviceAccountName":"prometheus-operator-0-22-2"}]},"strategy":"deployment"},"keywords":["prometheus","monitoring","tsdb","alerting"],"labels":{"alm-owner-prometheus":"prometheusoperator","alm-status-descriptors":"prometheusoperator.0.22.2"},"links":[{"name":"Prometheus","url":"https://www.prometheus.io/"},{"name":"Documentation","url":"https://coreos.com/operators/prometheus/docs/latest/"},{"name":"Prometheus Operator","url":"https://github.com/coreos/prometheus-operator"}],"maintainers":[{"email":"openshift-operators@redhat.com","name":"Red Hat"}],"maturity":"beta","provider":{"name":"Red Hat"},"replaces":"prometheusoperator.0.15.0","selector":{"matchLabels":{"alm-owner-prometheus":"prometheusoperator"}},"version":"0.22.2"}}`
 )
 
+var (
+	etcdChannelProperties = []operators.ChannelEntryProperty{
+		{Type: "olm.gvk", Value: `{"group":"etcd.database.coreos.com","kind":"EtcdBackup","version":"v1beta2"}`},
+		{Type: "olm.gvk", Value: `{"group":"etcd.database.coreos.com","kind":"EtcdCluster","version":"v1beta2"}`},
+		{Type: "olm.gvk", Value: `{"group":"etcd.database.coreos.com","kind":"EtcdRestore","version":"v1beta2"}`},
+		{Type: "olm.package", Value: `{"packageName":"etcd","version":"0.9.2"}`},
+	}
+	prometheusChannelProperties = []operators.ChannelEntryProperty{
+		{Type: "olm.gvk", Value: `{"group":"monitoring.coreos.com","kind":"Alertmanager","version":"v1"}`},
+		{Type: "olm.gvk", Value: `{"group":"monitoring.coreos.com","kind":"Prometheus","version":"v1"}`},
+		{Type: "olm.gvk", Value: `{"group":"monitoring.coreos.com","kind":"PrometheusRule","version":"v1"}`},
+		{Type: "olm.gvk", Value: `{"group":"monitoring.coreos.com","kind":"ServiceMonitor","version":"v1"}`},
+		{Type: "olm.package", Value: `{"packageName":"prometheus","version":"0.22.2"}`},
+	}
+)
+
 func TestToPackageManifest(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -205,6 +221,81 @@ func TestToPackageManifest(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "GoodBundle/PropertiesAndDependencies",
+			apiPkg: &api.Package{
+				Name: "etcd",
+				Channels: []*api.Channel{
+					{
+						Name:    "alpha",
+						CsvName: "etcdoperator.v0.9.2",
+					},
+				},
+				DefaultChannelName: "alpha",
+			},
+			catalogSource: catalogSource("cool-operators", "ns"),
+			bundle: &api.Bundle{
+				CsvName:     "etcdoperator.v0.9.2",
+				PackageName: "etcd",
+				ChannelName: "alpha",
+				CsvJson:     etcdCSVJSON,
+				Properties: []*api.Property{
+					{Type: "olm.package", Value: `{"packageName":"etcd","version":"0.9.2"}`},
+					{Type: "olm.bundle.object", Value: `{"data":"eyJraW5kIjoiQ3VzdG9tUmVzb3VyY2VEZWZpbml0aW9uIn0="}`},
+					{Type: "olm.gvk", Value: `{"group":"etcd.database.coreos.com","kind":"EtcdCluster","version":"v1beta2"}`},
+					{Type: "olm.constraint", Value: `{"failureMessage":"requires prometheus","package":{"packageName":"prometheus","versionRange":">=0.22.0"}}`},
+					{Type: "olm.maxOpenShiftVersion", Value: `"4.12"`},
+				},
+				Dependencies: []*api.Dependency{
+					{Type: "olm.package", Value: `{"packageName":"prometheus","version":">=0.22.0"}`},
+					{Type: "olm.gvk", Value: `{"group":"monitoring.coreos.com","kind":"Prometheus","version":"v1"}`},
+				},
+			},
+			expectedErr: "",
+			expected: &operators.PackageManifest{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "etcd",
+					Namespace: "ns",
+					Labels: labels.Set{
+						"catalog":                         "cool-operators",
+						"catalog-namespace":               "ns",
+						"provider":                        "CoreOS, Inc",
+						"provider-url":                    "",
+						"operatorframework.io/arch.amd64": "supported",
+						"operatorframework.io/os.linux":   "supported",
+					},
+				},
+				Status: operators.PackageManifestStatus{
+					CatalogSource:          "cool-operators",
+					CatalogSourceNamespace: "ns",
+					PackageName:            "etcd",
+					Provider: operators.AppLink{
+						Name: "CoreOS, Inc",
+					},
+					DefaultChannel: "alpha",
+					Channels: []operators.PackageChannel{
+						{
+							Name:       "alpha",
+							CurrentCSV: "etcdoperator.v0.9.2",
+							CurrentCSVDesc: func() operators.CSVDescription {
+								csv := operatorsv1alpha1.ClusterServiceVersion{}
+								require.NoError(t, json.Unmarshal([]byte(etcdCSVJSON), &csv))
+								return operators.CreateCSVDescription(&csv, etcdCSVJSON)
+							}(),
+							Properties: []operators.ChannelEntryProperty{
+								{Type: "olm.constraint", Value: `{"failureMessage":"requires prometheus","package":{"packageName":"prometheus","versionRange":">=0.22.0"}}`},
+								{Type: "olm.gvk", Value: `{"group":"etcd.database.coreos.com","kind":"EtcdCluster","version":"v1beta2"}`},
+								{Type: "olm.package", Value: `{"packageName":"etcd","version":"0.9.2"}`},
+							},
+							Dependencies: []operators.ChannelEntryDependency{
+								{Type: "olm.gvk", Value: `{"group":"monitoring.coreos.com","kind":"Prometheus","version":"v1"}`},
+								{Type: "olm.package", Value: `{"packageName":"prometheus","version":">=0.22.0"}`},
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "GoodBundle/ExtraLabels",
 			apiPkg: &api.Package{
@@ -512,6 +603,7 @@ func TestRegistryProviderGet(t *testing.T) {
 								require.NoError(t, json.Unmarshal([]byte(etcdCSVJSON), &csv))
 								return operators.CreateCSVDescription(&csv, etcdCSVJSON)
 							}(),
+							Properties: etcdChannelProperties,
 						},
 					},
 				},
@@ -561,6 +653,7 @@ func TestRegistryProviderGet(t *testing.T) {
 								require.NoError(t, json.Unmarshal([]byte(etcdCSVJSON), &csv))
 								return operators.CreateCSVDescription(&csv, etcdCSVJSON)
 							}(),
+							Properties: etcdChannelProperties,
 						},
 					},
 				},
@@ -609,6 +702,7 @@ func TestRegistryProviderGet(t *testing.T) {
 								require.NoError(t, json.Unmarshal([]byte(etcdCSVJSON), &csv))
 								return operators.CreateCSVDescription(&csv, etcdCSVJSON)
 							}(),
+							Properties: etcdChannelProperties,
 						},
 					},
 				},
@@ -696,6 +790,7 @@ func TestRegistryProviderList(t *testing.T) {
 									require.NoError(t, json.Unmarshal([]byte(prometheusCSVJSON), &csv))
 									return operators.CreateCSVDescription(&csv, prometheusCSVJSON)
 								}(),
+								Properties: prometheusChannelProperties,
 							},
 						},
 					},
@@ -730,6 +825,7 @@ func TestRegistryProviderList(t *testing.T) {
 									require.NoError(t, json.Unmarshal([]byte(etcdCSVJSON), &csv))
 									return operators.CreateCSVDescription(&csv, etcdCSVJSON)
 								}(),
+								Properties: etcdChannelProperties,
 							},
 						},
 					},
@@ -776,6 +872,7 @@ func TestRegistryProviderList(t *testing.T) {
 									require.NoError(t, json.Unmarshal([]byte(prometheusCSVJSON), &csv))
 									return operators.CreateCSVDescription(&csv, prometheusCSVJSON)
 								}(),
+								Properties: prometheusChannelProperties,
 							},
 						},
 					},
@@ -810,6 +907,7 @@ func TestRegistryProviderList(t *testing.T) {
 									require.NoError(t, json.Unmarshal([]byte(etcdCSVJSON), &csv))
 									return operators.CreateCSVDescription(&csv, etcdCSVJSON)
 								}(),
+								Properties: etcdChannelProperties,
 							},
 						},
 					},
@@ -856,6 +954,7 @@ func TestRegistryProviderList(t *testing.T) {
 									require.NoError(t, json.Unmarshal([]byte(prometheusCSVJSON), &csv))
 									return operators.CreateCSVDescription(&csv, prometheusCSVJSON)
 								}(),
+								Properties: prometheusChannelProperties,
 							},
 						},
 					},
@@ -890,6 +989,7 @@ func TestRegistryProviderList(t *testing.T) {
 									require.NoError(t, json.Unmarshal([]byte(etcdCSVJSON), &csv))
 									return operators.CreateCSVDescription(&csv, etcdCSVJSON)
 								}(),
+								Properties: etcdChannelProperties,
 							},
 						},
 					},
@@ -924,6 +1024,7 @@ func TestRegistryProviderList(t *testing.T) {
 									require.NoError(t, json.Unmarshal([]byte(prometheusCSVJSON), &csv))
 									return operators.CreateCSVDescription(&csv, prometheusCSVJSON)
 								}(),
+								Properties: prometheusChannelProperties,
 							},
 						},
 					},
@@ -958,6 +1059,7 @@ func TestRegistryProviderList(t *testing.T) {
 									require.NoError(t, json.Unmarshal([]byte(etcdCSVJSON), &csv))
 									return operators.CreateCSVDescription(&csv, etcdCSVJSON)
 								}(),
+								Properties: etcdChannelProperties,
 							},
 						},
 					},
@@ -1139,6 +1241,7 @@ func TestRegistryProviderListLabels(t *testing.T) {
 									require.NoError(t, json.Unmarshal([]byte(prometheusCSVJSON), &csv))
 									return operators.CreateCSVDescription(&csv, prometheusCSVJSON)
 								}(),
+								Properties: prometheusChannelProperties,
 							},
 						},
 					},
@@ -1173,6 +1276,7 @@ func TestRegistryProviderListLabels(t *testing.T) {
 									require.NoError(t, json.Unmarshal([]byte(etcdCSVJSON), &csv))
 									return operators.CreateCSVDescription(&csv, etcdCSVJSON)
 								}(),
+								Properties: etcdChannelProperties,
 							},
 						},
 					},
@@ -1222,6 +1326,7 @@ func TestRegistryProviderListLabels(t *testing.T) {
 									require.NoError(t, json.Unmarshal([]byte(prometheusCSVJSON), &csv))
 									return operators.CreateCSVDescription(&csv, prometheusCSVJSON)
 								}(),
+								Properties: prometheusChannelProperties,
 							},
 						},
 					},
@@ -1256,6 +1361,7 @@ func TestRegistryProviderListLabels(t *testing.T) {
 									require.NoError(t, json.Unmarshal([]byte(etcdCSVJSON), &csv))
 									return operators.CreateCSVDescription(&csv, etcdCSVJSON)
 								}(),
+								Properties: etcdChannelProperties,
 							},
 						},
 					},
@@ -1315,6 +1421,7 @@ func TestRegistryProviderListLabels(t *testing.T) {
 									require.NoError(t, json.Unmarshal([]byte(prometheusCSVJSON), &csv))
 									return operators.CreateCSVDescription(&csv, prometheusCSVJSON)
 								}(),
+								Properties: prometheusChannelProperties,
 							},
 						},
 					},
@@ -1349,6 +1456,7 @@ func TestRegistryProviderListLabels(t *testing.T) {
 									require.NoError(t, json.Unmarshal([]byte(etcdCSVJSON), &csv))
 									return operators.CreateCSVDescription(&csv, etcdCSVJSON)
 								}(),
+								Properties: etcdChannelProperties,
 							},
 						},
 					},
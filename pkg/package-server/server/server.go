@@ -19,6 +19,7 @@ import (
 
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/api/client"
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/queueinformer"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/metrics"
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/package-server/apiserver"
 	genericpackageserver "github.com/operator-framework/operator-lifecycle-manager/pkg/package-server/apiserver/generic"
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/package-server/provider"
@@ -185,6 +186,7 @@ func (o *PackageServerOptions) Run(ctx context.Context) error {
 		return err
 	}
 	config.GenericConfig.EnableMetrics = true
+	metrics.RegisterPackageServer()
 
 	// Set up the client config
 	var clientConfig *rest.Config
@@ -215,7 +217,7 @@ func (o *PackageServerOptions) Run(ctx context.Context) error {
 		return err
 	}
 
-	sourceProvider, err := provider.NewRegistryProvider(ctx, crClient, queueOperator, o.WakeupInterval, o.GlobalNamespace)
+	sourceProvider, err := provider.NewRegistryProvider(ctx, crClient, kubeClient, queueOperator, o.WakeupInterval, o.GlobalNamespace)
 	if err != nil {
 		return err
 	}
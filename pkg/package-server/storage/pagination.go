@@ -0,0 +1,85 @@
+package storage
+
+import (
+	"encoding/base64"
+	"encoding/json"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/package-server/apis/operators"
+)
+
+// packageManifestKey orders PackageManifests across pages. Packages of the same name are served by several catalogs.
+type packageManifestKey struct {
+	Namespace              string `json:"namespace"`
+	Name                   string `json:"name"`
+	CatalogSourceNamespace string `json:"catalogSourceNamespace"`
+	CatalogSource          string `json:"catalogSource"`
+}
+
+func keyOf(pm *operators.PackageManifest) packageManifestKey {
+	return packageManifestKey{
+		Namespace:              pm.GetNamespace(),
+		Name:                   pm.GetName(),
+		CatalogSourceNamespace: pm.Status.CatalogSourceNamespace,
+		CatalogSource:          pm.Status.CatalogSource,
+	}
+}
+
+func (k packageManifestKey) less(other packageManifestKey) bool {
+	if k.Namespace != other.Namespace {
+		return k.Namespace < other.Namespace
+	}
+	if k.Name != other.Name {
+		return k.Name < other.Name
+	}
+	if k.CatalogSourceNamespace != other.CatalogSourceNamespace {
+		return k.CatalogSourceNamespace < other.CatalogSourceNamespace
+	}
+	return k.CatalogSource < other.CatalogSource
+}
+
+// page is a page of a list of PackageManifests.
+type page struct {
+	items []operators.PackageManifest
+	// continueToken lists the next page, if any.
+	continueToken string
+	// remaining is the number of PackageManifests listed after the page, if any.
+	remaining *int64
+}
+
+// paginate returns the page of at most limit of the given sorted PackageManifests following the last PackageManifest
+// of the previous page, which the continue token holds the key of. PackageManifests aren't versioned, so pages list
+// the PackageManifests served when they are requested: those added or removed between pages are listed or not
+// depending on their position.
+func paginate(items []operators.PackageManifest, limit int64, continueToken string) (page, error) {
+	start := 0
+	if continueToken != "" {
+		raw, err := base64.RawURLEncoding.DecodeString(continueToken)
+		if err != nil {
+			return page{}, k8serrors.NewBadRequest("invalid continue token")
+		}
+		var last packageManifestKey
+		if err := json.Unmarshal(raw, &last); err != nil {
+			return page{}, k8serrors.NewBadRequest("invalid continue token")
+		}
+		for start < len(items) && !last.less(keyOf(&items[start])) {
+			start++
+		}
+	}
+	items = items[start:]
+	if limit <= 0 || int64(len(items)) <= limit {
+		return page{items: items}, nil
+	}
+
+	raw, err := json.Marshal(keyOf(&items[limit-1]))
+	if err != nil {
+		return page{}, k8serrors.NewInternalError(err)
+	}
+	remaining := int64(len(items)) - limit
+	return page{
+		items:         items[:limit],
+		continueToken: base64.RawURLEncoding.EncodeToString(raw),
+		remaining:     &remaining,
+	}, nil
+}
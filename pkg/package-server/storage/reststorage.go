@@ -3,6 +3,7 @@ package storage
 import (
 	"context"
 	"fmt"
+	"sort"
 
 	"k8s.io/apimachinery/pkg/fields"
 
@@ -60,18 +61,26 @@ func (m *PackageManifestStorage) NewList() runtime.Object {
 	return &operators.PackageManifestList{}
 }
 
-// List satisfies part of the Lister interface
+// List satisfies part of the Lister interface. PackageManifests are filtered by the label and field selectors, and
+// listed in pages of the requested limit, ordered by namespace, name and catalog.
 func (m *PackageManifestStorage) List(ctx context.Context, options *metainternalversion.ListOptions) (runtime.Object, error) {
 	namespace := genericreq.NamespaceValue(ctx)
+	if options == nil {
+		options = &metainternalversion.ListOptions{}
+	}
 
 	labelSelector := labels.Everything()
-	if options != nil && options.LabelSelector != nil {
+	if options.LabelSelector != nil {
 		labelSelector = options.LabelSelector
 	}
-
-	name, err := nameFor(options.FieldSelector)
-	if err != nil {
-		return nil, err
+	fieldSelector := fields.Everything()
+	if options.FieldSelector != nil {
+		fieldSelector = options.FieldSelector
+	}
+	for _, requirement := range fieldSelector.Requirements() {
+		if _, ok := packageManifestFields(&operators.PackageManifest{})[requirement.Field]; !ok {
+			return nil, k8serrors.NewBadRequest(fmt.Sprintf("field label not supported: %s", requirement.Field))
+		}
 	}
 
 	res, err := m.prov.List(namespace, labelSelector)
@@ -81,17 +90,26 @@ func (m *PackageManifestStorage) List(ctx context.Context, options *metainternal
 
 	filtered := []operators.PackageManifest{}
 	for _, manifest := range res.Items {
-		if matches(manifest, name) {
+		if fieldSelector.Matches(packageManifestFields(&manifest)) {
 			filtered = append(filtered, manifest)
 		}
 	}
+	sort.Slice(filtered, func(i, j int) bool {
+		return keyOf(&filtered[i]).less(keyOf(&filtered[j]))
+	})
 
-	for i := range filtered {
-		for j := range filtered[i].Status.Channels {
-			filtered[i].Status.Channels[j].CurrentCSVDesc.Icon = []operators.Icon{}
+	page, err := paginate(filtered, options.Limit, options.Continue)
+	if err != nil {
+		return nil, err
+	}
+	for i := range page.items {
+		for j := range page.items[i].Status.Channels {
+			page.items[i].Status.Channels[j].CurrentCSVDesc.Icon = []operators.Icon{}
 		}
 	}
-	res.Items = filtered
+	res.Items = page.items
+	res.Continue = page.continueToken
+	res.RemainingItemCount = page.remaining
 
 	return res, nil
 }
@@ -116,22 +134,14 @@ func (m *PackageManifestStorage) NamespaceScoped() bool {
 	return true
 }
 
-func nameFor(fs fields.Selector) (string, error) {
-	if fs == nil {
-		fs = fields.Everything()
-	}
-	name := ""
-	if value, found := fs.RequiresExactMatch("metadata.name"); found {
-		name = value
-	} else if !fs.Empty() {
-		return "", fmt.Errorf("field label not supported: %s", fs.Requirements()[0].Field)
-	}
-	return name, nil
-}
-
-func matches(pm operators.PackageManifest, name string) bool {
-	if name == "" {
-		name = pm.GetName()
+// packageManifestFields returns the fields the given PackageManifest can be selected by.
+func packageManifestFields(pm *operators.PackageManifest) fields.Set {
+	return fields.Set{
+		"metadata.name":                 pm.GetName(),
+		"metadata.namespace":            pm.GetNamespace(),
+		"status.packageName":            pm.Status.PackageName,
+		"status.catalogSource":          pm.Status.CatalogSource,
+		"status.catalogSourceNamespace": pm.Status.CatalogSourceNamespace,
+		"status.defaultChannel":         pm.Status.DefaultChannel,
 	}
-	return pm.GetName() == name
 }
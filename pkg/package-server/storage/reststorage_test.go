@@ -0,0 +1,127 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metainternalversion "k8s.io/apimachinery/pkg/apis/meta/internalversion"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	genericreq "k8s.io/apiserver/pkg/endpoints/request"
+
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/package-server/apis/operators"
+	v1 "github.com/operator-framework/operator-lifecycle-manager/pkg/package-server/apis/operators/v1"
+)
+
+func packageManifest(name, catalog, defaultChannel string) *operators.PackageManifest {
+	pkg := testPackage()
+	pkg.SetName(name)
+	pkg.SetNamespace("ns")
+	pkg.SetLabels(map[string]string{"catalog": catalog})
+	pkg.Status.PackageName = name
+	pkg.Status.CatalogSource = catalog
+	pkg.Status.CatalogSourceNamespace = "ns"
+	pkg.Status.DefaultChannel = defaultChannel
+	return pkg
+}
+
+func listedKeys(t *testing.T, obj runtime.Object) []string {
+	var keys []string
+	for _, pkg := range obj.(*operators.PackageManifestList).Items {
+		keys = append(keys, pkg.Status.CatalogSource+"/"+pkg.GetName())
+		for _, channel := range pkg.Status.Channels {
+			require.Empty(t, channel.CurrentCSVDesc.Icon, "icons are stripped from lists")
+		}
+	}
+	return keys
+}
+
+func TestPackageManifestStorageList(t *testing.T) {
+	prov := &fakeProvider{packages: []*operators.PackageManifest{
+		packageManifest("pkg-c", "catalog-a", "stable"),
+		packageManifest("pkg-a", "catalog-b", "alpha"),
+		packageManifest("pkg-a", "catalog-a", "stable"),
+		packageManifest("pkg-b", "catalog-b", "stable"),
+	}}
+	storage := NewStorage(v1.Resource("packagemanifests"), prov, runtime.NewScheme())
+	ctx := genericreq.WithNamespace(context.TODO(), "ns")
+	list := func(options *metainternalversion.ListOptions) (*operators.PackageManifestList, error) {
+		obj, err := storage.List(ctx, options)
+		if err != nil {
+			return nil, err
+		}
+		return obj.(*operators.PackageManifestList), nil
+	}
+
+	all, err := storage.List(ctx, nil)
+	require.NoError(t, err)
+	require.Equal(t, []string{"catalog-a/pkg-a", "catalog-b/pkg-a", "catalog-b/pkg-b", "catalog-a/pkg-c"}, listedKeys(t, all))
+
+	t.Run("Selectors", func(t *testing.T) {
+		res, err := storage.List(ctx, &metainternalversion.ListOptions{
+			LabelSelector: labels.SelectorFromSet(labels.Set{"catalog": "catalog-a"}),
+			FieldSelector: fields.OneTermEqualSelector("status.defaultChannel", "stable"),
+		})
+		require.NoError(t, err)
+		require.Equal(t, []string{"catalog-a/pkg-a", "catalog-a/pkg-c"}, listedKeys(t, res))
+
+		res, err = storage.List(ctx, &metainternalversion.ListOptions{
+			FieldSelector: fields.AndSelectors(fields.OneTermEqualSelector("metadata.name", "pkg-a"), fields.OneTermNotEqualSelector("status.catalogSource", "catalog-a")),
+		})
+		require.NoError(t, err)
+		require.Equal(t, []string{"catalog-b/pkg-a"}, listedKeys(t, res))
+
+		_, err = list(&metainternalversion.ListOptions{FieldSelector: fields.OneTermEqualSelector("spec.foo", "bar")})
+		require.True(t, k8serrors.IsBadRequest(err))
+		require.EqualError(t, err, "field label not supported: spec.foo")
+	})
+
+	t.Run("Pagination", func(t *testing.T) {
+		var keys []string
+		options := &metainternalversion.ListOptions{Limit: 3}
+		first, err := list(options)
+		require.NoError(t, err)
+		require.Len(t, first.Items, 3)
+		require.NotEmpty(t, first.Continue)
+		require.Equal(t, int64(1), *first.RemainingItemCount)
+		keys = append(keys, listedKeys(t, first)...)
+
+		// packages added before the last page was listed are listed in the pages following them
+		prov.packages = append(prov.packages, packageManifest("pkg-d", "catalog-a", "stable"))
+		options.Continue = first.Continue
+		last, err := list(options)
+		require.NoError(t, err)
+		require.Empty(t, last.Continue)
+		require.Nil(t, last.RemainingItemCount)
+		keys = append(keys, listedKeys(t, last)...)
+		require.Equal(t, []string{"catalog-a/pkg-a", "catalog-b/pkg-a", "catalog-b/pkg-b", "catalog-a/pkg-c", "catalog-a/pkg-d"}, keys)
+
+		_, err = list(&metainternalversion.ListOptions{Limit: 3, Continue: "not-a-token"})
+		require.True(t, k8serrors.IsBadRequest(err))
+	})
+}
+
+func TestPackageManifestFieldLabelConversion(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1.AddToScheme(scheme))
+	gvk := v1.SchemeGroupVersion.WithKind(v1.PackageManifestKind)
+
+	for _, label := range []string{"metadata.name", "status.catalogSource", "status.catalogSourceNamespace", "status.defaultChannel"} {
+		converted, value, err := scheme.ConvertFieldLabel(gvk, label, "value")
+		require.NoError(t, err)
+		require.Equal(t, label, converted)
+		require.Equal(t, "value", value)
+	}
+	_, _, err := scheme.ConvertFieldLabel(gvk, "spec.foo", "bar")
+	require.Error(t, err)
+
+	// every field PackageManifests can be listed by is accepted by the API
+	for field := range packageManifestFields(&operators.PackageManifest{ObjectMeta: metav1.ObjectMeta{}}) {
+		_, _, err := scheme.ConvertFieldLabel(gvk, field, "value")
+		require.NoError(t, err, field)
+	}
+}
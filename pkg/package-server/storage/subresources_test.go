@@ -28,7 +28,13 @@ func (p *fakeProvider) Get(namespace, name string) (*operators.PackageManifest,
 }
 
 func (p *fakeProvider) List(namespace string, selector labels.Selector) (*operators.PackageManifestList, error) {
-	return &operators.PackageManifestList{}, nil
+	list := &operators.PackageManifestList{}
+	for _, pkg := range p.packages {
+		if selector.Matches(labels.Set(pkg.GetLabels())) {
+			list.Items = append(list.Items, *pkg.DeepCopy())
+		}
+	}
+	return list, nil
 }
 
 var _ provider.PackageManifestProvider = &fakeProvider{}
@@ -7,6 +7,8 @@ import (
 
 	"github.com/ghodss/yaml"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	schedulingv1 "k8s.io/api/scheduling/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -15,6 +17,7 @@ import (
 	. "github.com/onsi/gomega"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/dynamic"
 
 	"github.com/operator-framework/api/pkg/operators/v1alpha1"
@@ -57,10 +60,7 @@ var _ = Describe("Installing bundles with new object types", func() {
 
 		BeforeEach(func() {
 			By("first installing the VPA CRD on cluster")
-			const (
-				sourceName = "test-catalog"
-				imageName  = "quay.io/olmtest/single-bundle-index:pdb-v1"
-			)
+			const sourceName = "test-catalog"
 
 			// create VPA CRD on cluster
 			Expect(vpaCRDRaw).ToNot(BeEmpty(), "could not read vpa bindata")
@@ -89,6 +89,37 @@ var _ = Describe("Installing bundles with new object types", func() {
 				return crdReady(&crd.Status), nil
 			}).Should(BeTrue())
 
+			By("building a catalog of a bundle with a pdb, priorityclass, and VPA object")
+			pdb := &policyv1.PodDisruptionBudget{
+				TypeMeta:   metav1.TypeMeta{APIVersion: policyv1.SchemeGroupVersion.String(), Kind: "PodDisruptionBudget"},
+				ObjectMeta: metav1.ObjectMeta{Name: "busybox-pdb"},
+				Spec: policyv1.PodDisruptionBudgetSpec{
+					MinAvailable: &intstr.IntOrString{Type: intstr.Int, IntVal: 1},
+					Selector:     &metav1.LabelSelector{MatchLabels: map[string]string{"app": "nginx"}},
+				},
+			}
+			priorityClass := &schedulingv1.PriorityClass{
+				TypeMeta:   metav1.TypeMeta{APIVersion: schedulingv1.SchemeGroupVersion.String(), Kind: "PriorityClass"},
+				ObjectMeta: metav1.ObjectMeta{Name: "super-priority"},
+				Value:      1000,
+			}
+			vpa := &unstructured.Unstructured{Object: map[string]interface{}{
+				"apiVersion": "autoscaling.k8s.io/v1",
+				"kind":       "VerticalPodAutoscaler",
+				"metadata":   map[string]interface{}{"name": "busybox-vpa"},
+				"spec": map[string]interface{}{
+					"targetRef": map[string]interface{}{
+						"apiVersion": "apps/v1",
+						"kind":       "Deployment",
+						"name":       packageName,
+					},
+					"updatePolicy": map[string]interface{}{"updateMode": "Off"},
+				},
+			}}
+			imageName, err := pushCatalogImage(context.Background(), "catalogs/bundle-e2e", generatedNamespace.GetName(),
+				busyboxBundle(packageName, "1.0.0", "", pdb, priorityClass, vpa))
+			Expect(err).ToNot(HaveOccurred(), "could not push catalog image")
+
 			source := &v1alpha1.CatalogSource{
 				TypeMeta: metav1.TypeMeta{
 					Kind:       v1alpha1.CatalogSourceKind,
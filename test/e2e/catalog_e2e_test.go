@@ -645,61 +645,17 @@ var _ = Describe("Catalog represents a store of bundles which OLM can use to ins
 		} else if err != nil {
 			Skip("Could not determine whether running in a kind cluster. Skipping.")
 		}
-		// Create an image based catalog source from public Quay image
+		// Create an image based catalog source from a catalog image pushed to the local registry
 		// Use a unique tag as identifier
-		// See https://quay.io/repository/olmtest/catsrc-update-test?namespace=olmtest for registry
 		// Push an updated version of the image with the same identifier
 		// Confirm catalog source polling feature is working as expected: a newer version of the catalog source pod comes up
-		// etcd operator updated from 0.9.0 to 0.9.2-clusterwide
+		// busybox updated from 1.0.0 to 2.0.0
 		// Subscription should detect the latest version of the operator in the new catalog source and pull it
 
-		// create internal registry for purposes of pushing/pulling IF running e2e test locally
-		// registry is insecure and for purposes of this test only
-
-		local, err := Local(c)
-		Expect(err).NotTo(HaveOccurred(), "cannot determine if test running locally or on CI: %s", err)
-
-		var registryURL string
-		var registryAuth string
-		if local {
-			registryURL, err = createDockerRegistry(c, testNamespace)
-			Expect(err).NotTo(HaveOccurred(), "error creating container registry: %s", err)
-			defer deleteDockerRegistry(c, testNamespace)
-
-			// ensure registry pod is ready before attempting port-forwarding
-			_ = awaitPod(GinkgoT(), c, testNamespace, registryName, podReady)
-
-			err = registryPortForward(testNamespace)
-			Expect(err).NotTo(HaveOccurred(), "port-forwarding local registry: %s", err)
-		} else {
-			registryURL = openshiftregistryFQDN
-			registryAuth, err = openshiftRegistryAuth(c, testNamespace)
-			Expect(err).NotTo(HaveOccurred(), "error getting openshift registry authentication: %s", err)
-		}
-
-		// testImage is the name of the image used throughout the test - the image overwritten by skopeo
-		// the tag is generated randomly and appended to the end of the testImage
-		testImage := fmt.Sprint("docker://", registryURL, "/catsrc-update", ":")
+		// 1. push the old catalog image to a test-specific tag of the local registry
 		tag := genName("x")
-
-		// 1. copy old catalog image into test-specific tag in internal docker registry
-		// create skopeo pod to actually do the work of copying (on openshift) or exec out to local skopeo
-		if local {
-			_, err := skopeoLocalCopy(testImage, tag, catsrcImage, "old")
-			Expect(err).NotTo(HaveOccurred(), "error copying old registry file: %s", err)
-		} else {
-			skopeoArgs := skopeoCopyCmd(testImage, tag, catsrcImage, "old", registryAuth)
-			err = createSkopeoPod(c, skopeoArgs, testNamespace)
-			Expect(err).NotTo(HaveOccurred(), "error creating skopeo pod: %s", err)
-
-			// wait for skopeo pod to exit successfully
-			awaitPod(GinkgoT(), c, testNamespace, skopeo, func(pod *corev1.Pod) bool {
-				return pod.Status.Phase == corev1.PodSucceeded
-			})
-
-			err = deleteSkopeoPod(c, testNamespace)
-			Expect(err).NotTo(HaveOccurred(), "error deleting skopeo pod: %s", err)
-		}
+		image, err := pushCatalogImage(context.Background(), "catalogs/catsrc-update", tag, catsrcUpdateTestBundles(false)...)
+		Expect(err).NotTo(HaveOccurred(), "error pushing old catalog image: %s", err)
 
 		// 2. setup catalog source
 
@@ -707,11 +663,7 @@ var _ = Describe("Catalog represents a store of bundles which OLM can use to ins
 		packageName := "busybox"
 		channelName := "alpha"
 
-		// Create gRPC CatalogSource using an external registry image and poll interval
-		var image string
-		image = testImage[9:] // strip off docker://
-		image = fmt.Sprint(image, tag)
-
+		// Create gRPC CatalogSource using the pushed image and poll interval
 		source := &v1alpha1.CatalogSource{
 			TypeMeta: metav1.TypeMeta{
 				Kind:       v1alpha1.CatalogSourceKind,
@@ -771,25 +723,10 @@ var _ = Describe("Catalog represents a store of bundles which OLM can use to ins
 		}
 		// get old catalog source pod
 		registryPod, err := awaitPods(GinkgoT(), c, source.GetNamespace(), selector.String(), registryCheckFunc)
-		// 3. Update image on registry via skopeo: this should trigger a newly updated version of the catalog source pod
-		// to be deployed after some time
-		// Make another skopeo pod to do the work of copying the image
-		if local {
-			_, err := skopeoLocalCopy(testImage, tag, catsrcImage, "new")
-			Expect(err).NotTo(HaveOccurred(), "error copying new registry file: %s", err)
-		} else {
-			skopeoArgs := skopeoCopyCmd(testImage, tag, catsrcImage, "new", registryAuth)
-			err = createSkopeoPod(c, skopeoArgs, testNamespace)
-			Expect(err).NotTo(HaveOccurred(), "error creating skopeo pod: %s", err)
-
-			// wait for skopeo pod to exit successfully
-			awaitPod(GinkgoT(), c, testNamespace, skopeo, func(pod *corev1.Pod) bool {
-				return pod.Status.Phase == corev1.PodSucceeded
-			})
-
-			err = deleteSkopeoPod(c, testNamespace)
-			Expect(err).NotTo(HaveOccurred(), "error deleting skopeo pod: %s", err)
-		}
+		// 3. Push the new catalog image to the same tag: this should trigger a newly updated version of the catalog source
+		// pod to be deployed after some time
+		_, err = pushCatalogImage(context.Background(), "catalogs/catsrc-update", tag, catsrcUpdateTestBundles(true)...)
+		Expect(err).NotTo(HaveOccurred(), "error pushing new catalog image: %s", err)
 
 		// update catalog source with annotation (to kick resync)
 		source, err = crc.OperatorsV1alpha1().CatalogSources(source.GetNamespace()).Get(context.Background(), source.GetName(), metav1.GetOptions{})
@@ -872,7 +809,10 @@ var _ = Describe("Catalog represents a store of bundles which OLM can use to ins
 		packageName := "busybox"
 		channelName := "alpha"
 
-		catSrcImage := "quay.io/olmtest/busybox-dependencies-index"
+		catSrcImage, err := pushCatalogImage(context.Background(), "catalogs/busybox-dependencies", genName("v1-"), busyboxDependenciesBundles(false)...)
+		Expect(err).ShouldNot(HaveOccurred())
+		newCatSrcImage, err := pushCatalogImage(context.Background(), "catalogs/busybox-dependencies", genName("v2-"), busyboxDependenciesBundles(true)...)
+		Expect(err).ShouldNot(HaveOccurred())
 
 		// Create gRPC CatalogSource
 		source := &v1alpha1.CatalogSource{
@@ -886,11 +826,11 @@ var _ = Describe("Catalog represents a store of bundles which OLM can use to ins
 			},
 			Spec: v1alpha1.CatalogSourceSpec{
 				SourceType: v1alpha1.SourceTypeGrpc,
-				Image:      catSrcImage + ":1.0.0-with-ListBundles-method",
+				Image:      catSrcImage,
 			},
 		}
 
-		source, err := crc.OperatorsV1alpha1().CatalogSources(source.GetNamespace()).Create(context.Background(), source, metav1.CreateOptions{})
+		source, err = crc.OperatorsV1alpha1().CatalogSources(source.GetNamespace()).Create(context.Background(), source, metav1.CreateOptions{})
 		Expect(err).ShouldNot(HaveOccurred())
 		defer func() {
 			err := crc.OperatorsV1alpha1().CatalogSources(source.GetNamespace()).Delete(context.Background(), source.GetName(), metav1.DeleteOptions{})
@@ -935,7 +875,7 @@ var _ = Describe("Catalog represents a store of bundles which OLM can use to ins
 			if err != nil {
 				return false, err
 			}
-			existingSource.Spec.Image = catSrcImage + ":2.0.0-with-ListBundles-method"
+			existingSource.Spec.Image = newCatSrcImage
 
 			source, err = crc.OperatorsV1alpha1().CatalogSources(source.GetNamespace()).Update(context.Background(), existingSource, metav1.UpdateOptions{})
 			if err == nil {
@@ -984,6 +924,9 @@ var _ = Describe("Catalog represents a store of bundles which OLM can use to ins
 		c := newKubeClient()
 		crc := newCRClient()
 
+		image, err := pushCatalogImage(context.Background(), "catalogs/catsrc-update", genName("new-"), catsrcUpdateTestBundles(true)...)
+		Expect(err).ToNot(HaveOccurred())
+
 		sourceName := genName("catalog-")
 		source := &v1alpha1.CatalogSource{
 			TypeMeta: metav1.TypeMeta{
@@ -997,7 +940,7 @@ var _ = Describe("Catalog represents a store of bundles which OLM can use to ins
 			},
 			Spec: v1alpha1.CatalogSourceSpec{
 				SourceType: v1alpha1.SourceTypeGrpc,
-				Image:      "quay.io/olmtest/catsrc-update-test:new",
+				Image:      image,
 				UpdateStrategy: &v1alpha1.UpdateStrategy{
 					RegistryPoll: &v1alpha1.RegistryPoll{
 						Interval: &metav1.Duration{Duration: 45 * time.Second},
@@ -1006,7 +949,7 @@ var _ = Describe("Catalog represents a store of bundles which OLM can use to ins
 			},
 		}
 
-		source, err := crc.OperatorsV1alpha1().CatalogSources(source.GetNamespace()).Create(context.Background(), source, metav1.CreateOptions{})
+		source, err = crc.OperatorsV1alpha1().CatalogSources(source.GetNamespace()).Create(context.Background(), source, metav1.CreateOptions{})
 		Expect(err).ToNot(HaveOccurred())
 
 		// wait for new catalog source pod to be created and report ready
@@ -1050,6 +993,10 @@ var _ = Describe("Catalog represents a store of bundles which OLM can use to ins
 		// This test attempts to create a catalog source, and update it with a template annotation
 		// and ensure that the image gets changed according to what's in the template as well as
 		// check the status conditions are updated accordingly
+		image, err := pushCatalogImage(context.Background(), "catalogs/catsrc-update", genName("old-"), catsrcUpdateTestBundles(false)...)
+		Expect(err).ToNot(HaveOccurred())
+		repository := image[:strings.LastIndex(image, ":")]
+
 		sourceName := genName("catalog-")
 		source := &v1alpha1.CatalogSource{
 			TypeMeta: metav1.TypeMeta{
@@ -1063,13 +1010,12 @@ var _ = Describe("Catalog represents a store of bundles which OLM can use to ins
 			},
 			Spec: v1alpha1.CatalogSourceSpec{
 				SourceType: v1alpha1.SourceTypeGrpc,
-				Image:      "quay.io/olmtest/catsrc-update-test:old",
+				Image:      image,
 			},
 		}
 
 		By("creating a catalog source")
 
-		var err error
 		Eventually(func() error {
 			source, err = crc.OperatorsV1alpha1().CatalogSources(source.GetNamespace()).Create(context.Background(), source, metav1.CreateOptions{})
 			return err
@@ -1084,7 +1030,7 @@ var _ = Describe("Catalog represents a store of bundles which OLM can use to ins
 			}
 			// create an annotation using the kube templates
 			source.SetAnnotations(map[string]string{
-				catalogsource.CatalogImageTemplateAnnotation: fmt.Sprintf("quay.io/olmtest/catsrc-update-test:%s.%s.%s", catalogsource.TemplKubeMajorV, catalogsource.TemplKubeMinorV, catalogsource.TemplKubePatchV),
+				catalogsource.CatalogImageTemplateAnnotation: fmt.Sprintf("%s:%s.%s.%s", repository, catalogsource.TemplKubeMajorV, catalogsource.TemplKubeMinorV, catalogsource.TemplKubePatchV),
 			})
 
 			// Update the catalog image
@@ -1123,7 +1069,7 @@ var _ = Describe("Catalog represents a store of bundles which OLM can use to ins
 			// if we can, try to determine the server version so we can check the resulting image
 			if serverVersion, err := crc.Discovery().ServerVersion(); err != nil {
 				if serverGitVersion, err := semver.Parse(serverVersion.GitVersion); err != nil {
-					expectedImage := fmt.Sprintf("quay.io/olmtest/catsrc-update-test:%s.%s.%s", serverVersion.Major, serverVersion.Minor, strconv.FormatUint(serverGitVersion.Patch, 10))
+					expectedImage := fmt.Sprintf("%s:%s.%s.%s", repository, serverVersion.Major, serverVersion.Minor, strconv.FormatUint(serverGitVersion.Patch, 10))
 					Expect(resolvedImageCondition.Message).To(BeIdenticalTo(expectedImage))
 				}
 			}
@@ -1131,13 +1077,8 @@ var _ = Describe("Catalog represents a store of bundles which OLM can use to ins
 	})
 })
 
-const (
-	openshiftregistryFQDN = "image-registry.openshift-image-registry.svc:5000/openshift-operators"
-	catsrcImage           = "docker://quay.io/olmtest/catsrc-update-test:"
-)
-
 func getOperatorDeployment(c operatorclient.ClientInterface, namespace string, operatorLabels labels.Set) (*appsv1.Deployment, error) {
-	deployments, err := c.ListDeploymentsWithLabels(namespace, operatorLabels)
+	deployments, err := c.ListDeploymentsWithLabels(context.Background(), namespace, operatorLabels)
 	if err != nil || deployments == nil || len(deployments.Items) != 1 {
 		return nil, fmt.Errorf("Error getting single operator deployment for label: %v", operatorLabels)
 	}
@@ -1148,14 +1089,14 @@ func rescaleDeployment(c operatorclient.ClientInterface, deployment *appsv1.Depl
 	// scale down
 	var replicas int32 = 0
 	deployment.Spec.Replicas = &replicas
-	deployment, updated, err := c.UpdateDeployment(deployment)
+	deployment, updated, err := c.UpdateDeployment(context.Background(), deployment)
 
 	if err != nil || updated == false || deployment == nil {
 		return fmt.Errorf("Failed to scale down deployment")
 	}
 
 	waitForScaleup := func() (bool, error) {
-		fetchedDeployment, err := c.GetDeployment(deployment.GetNamespace(), deployment.GetName())
+		fetchedDeployment, err := c.GetDeployment(context.Background(), deployment.GetNamespace(), deployment.GetName())
 		if err != nil {
 			return true, err
 		}
@@ -1172,7 +1113,7 @@ func rescaleDeployment(c operatorclient.ClientInterface, deployment *appsv1.Depl
 	// scale up
 	replicas = 1
 	deployment.Spec.Replicas = &replicas
-	deployment, updated, err = c.UpdateDeployment(deployment)
+	deployment, updated, err = c.UpdateDeployment(context.Background(), deployment)
 	if err != nil || updated == false || deployment == nil {
 		return fmt.Errorf("Failed to scale up deployment")
 	}
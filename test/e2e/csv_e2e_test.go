@@ -35,6 +35,7 @@ import (
 	operatorsv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/api/client/clientset/versioned"
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/install"
+	"github.com/operator-framework/operator-lifecycle-manager/pkg/controller/operators/olm"
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/operatorclient"
 	"github.com/operator-framework/operator-lifecycle-manager/pkg/lib/ownerutil"
 	"github.com/operator-framework/operator-lifecycle-manager/test/e2e/ctx"
@@ -700,6 +701,110 @@ var _ = Describe("ClusterServiceVersion", func() {
 		})
 	})
 
+	When("a ClusterServiceVersion replaces a ClusterServiceVersion that doesn't exist", func() {
+		var csv operatorsv1alpha1.ClusterServiceVersion
+
+		BeforeEach(func() {
+			csv = operatorsv1alpha1.ClusterServiceVersion{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       operatorsv1alpha1.ClusterServiceVersionKind,
+					APIVersion: operatorsv1alpha1.ClusterServiceVersionAPIVersion,
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name: genName("csv"),
+				},
+				Spec: operatorsv1alpha1.ClusterServiceVersionSpec{
+					Replaces: genName("missing-csv"),
+					InstallModes: []operatorsv1alpha1.InstallMode{
+						{
+							Type:      operatorsv1alpha1.InstallModeTypeOwnNamespace,
+							Supported: true,
+						},
+						{
+							Type:      operatorsv1alpha1.InstallModeTypeSingleNamespace,
+							Supported: true,
+						},
+						{
+							Type:      operatorsv1alpha1.InstallModeTypeMultiNamespace,
+							Supported: true,
+						},
+						{
+							Type:      operatorsv1alpha1.InstallModeTypeAllNamespaces,
+							Supported: true,
+						},
+					},
+					InstallStrategy: newNginxInstallStrategy(genName("dep-"), nil, nil),
+				},
+			}
+		})
+
+		It("installs it and notes the missing predecessor in its conditions", func() {
+			cleanupCSV, err := createCSV(c, crc, csv, testNamespace, false, false)
+			Expect(err).ShouldNot(HaveOccurred())
+			defer cleanupCSV()
+
+			fetchedCSV, err := fetchCSV(crc, csv.Name, testNamespace, csvSucceededChecker)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			Expect(fetchedCSV.Status.Conditions).Should(ContainElement(And(
+				WithTransform(func(c operatorsv1alpha1.ClusterServiceVersionCondition) operatorsv1alpha1.ConditionReason {
+					return c.Reason
+				}, Equal(olm.CSVReasonReplacedCSVNotFound)),
+				WithTransform(func(c operatorsv1alpha1.ClusterServiceVersionCondition) string { return c.Message }, ContainSubstring(csv.Spec.Replaces)),
+			)))
+		})
+
+		When("the cluster blocks CSVs replacing a missing CSV", func() {
+			setPolicy := func(policy string) {
+				Eventually(func() error {
+					var olmConfig operatorsv1.OLMConfig
+					if err := ctx.Ctx().Client().Get(context.TODO(), apitypes.NamespacedName{Name: "cluster"}, &olmConfig); err != nil {
+						return err
+					}
+					annotations := olmConfig.GetAnnotations()
+					if annotations == nil {
+						annotations = map[string]string{}
+					}
+					if policy == "" {
+						delete(annotations, olm.MissingReplacedCSVPolicyAnnotationKey)
+					} else {
+						annotations[olm.MissingReplacedCSVPolicyAnnotationKey] = policy
+					}
+					olmConfig.SetAnnotations(annotations)
+					return ctx.Ctx().Client().Update(context.TODO(), &olmConfig)
+				}).Should(Succeed())
+			}
+
+			BeforeEach(func() {
+				setPolicy(olm.MissingReplacedCSVPolicyBlock)
+			})
+
+			AfterEach(func() {
+				setPolicy("")
+			})
+
+			It("holds it pending until the replaced CSV exists", func() {
+				cleanupCSV, err := createCSV(c, crc, csv, testNamespace, false, false)
+				Expect(err).ShouldNot(HaveOccurred())
+				defer cleanupCSV()
+
+				_, err = fetchCSV(crc, csv.Name, testNamespace, buildCSVReasonChecker(olm.CSVReasonReplacedCSVNotFound))
+				Expect(err).ShouldNot(HaveOccurred())
+
+				// Shouldn't create deployment
+				Consistently(func() (operatorsv1alpha1.ClusterServiceVersionPhase, error) {
+					fetched, err := crc.OperatorsV1alpha1().ClusterServiceVersions(testNamespace).Get(context.TODO(), csv.Name, metav1.GetOptions{})
+					if err != nil {
+						return "", err
+					}
+					return fetched.Status.Phase, nil
+				}).Should(Equal(operatorsv1alpha1.CSVPhasePending))
+				_, err = c.GetDeployment(context.TODO(), testNamespace, csv.Spec.InstallStrategy.StrategySpec.DeploymentSpecs[0].Name)
+				Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+			})
+		})
+	})
+
 	It("create with unmet requirements min kube version", func() {
 
 		depName := genName("dep-")
@@ -744,7 +849,7 @@ var _ = Describe("ClusterServiceVersion", func() {
 
 		// Shouldn't create deployment
 		Consistently(func() bool {
-			_, err := c.GetDeployment(testNamespace, depName)
+			_, err := c.GetDeployment(context.TODO(), testNamespace, depName)
 			return k8serrors.IsNotFound(err)
 		}).Should(BeTrue())
 	})
@@ -804,7 +909,7 @@ var _ = Describe("ClusterServiceVersion", func() {
 
 		// Shouldn't create deployment
 		Consistently(func() bool {
-			_, err := c.GetDeployment(testNamespace, depName)
+			_, err := c.GetDeployment(context.TODO(), testNamespace, depName)
 			return k8serrors.IsNotFound(err)
 		}).Should(BeTrue())
 	})
@@ -924,7 +1029,7 @@ var _ = Describe("ClusterServiceVersion", func() {
 
 		// Shouldn't create deployment
 		Consistently(func() bool {
-			_, err := c.GetDeployment(testNamespace, depName)
+			_, err := c.GetDeployment(context.TODO(), testNamespace, depName)
 			return k8serrors.IsNotFound(err)
 		}).Should(BeTrue())
 	})
@@ -983,7 +1088,7 @@ var _ = Describe("ClusterServiceVersion", func() {
 
 		// Shouldn't create deployment
 		Consistently(func() bool {
-			_, err := c.GetDeployment(testNamespace, depName)
+			_, err := c.GetDeployment(context.TODO(), testNamespace, depName)
 			return k8serrors.IsNotFound(err)
 		}).Should(BeTrue())
 	})
@@ -1070,7 +1175,7 @@ var _ = Describe("ClusterServiceVersion", func() {
 
 		// Shouldn't create deployment
 		Consistently(func() bool {
-			_, err := c.GetDeployment(testNamespace, depName)
+			_, err := c.GetDeployment(context.TODO(), testNamespace, depName)
 			return k8serrors.IsNotFound(err)
 		}).Should(BeTrue())
 	})
@@ -1119,7 +1224,7 @@ var _ = Describe("ClusterServiceVersion", func() {
 
 		// Shouldn't create deployment
 		Consistently(func() bool {
-			_, err := c.GetDeployment(testNamespace, depName)
+			_, err := c.GetDeployment(context.TODO(), testNamespace, depName)
 			return k8serrors.IsNotFound(err)
 		}).Should(BeTrue())
 	})
@@ -1220,7 +1325,7 @@ var _ = Describe("ClusterServiceVersion", func() {
 			Kind:       operatorsv1alpha1.ClusterServiceVersionKind,
 			UID:        fetchedCSV.GetUID(),
 		}})
-		_, err = c.CreateServiceAccount(&sa)
+		_, err = c.CreateServiceAccount(context.TODO(), &sa)
 		Expect(err).ShouldNot(HaveOccurred(), "could not create ServiceAccount %#v", sa)
 
 		crd := apiextensions.CustomResourceDefinition{
@@ -1273,7 +1378,7 @@ var _ = Describe("ClusterServiceVersion", func() {
 		}
 		role.SetName(genName("dep-"))
 		role.SetNamespace(testNamespace)
-		_, err = c.CreateRole(&role)
+		_, err = c.CreateRole(context.TODO(), &role)
 		Expect(err).ShouldNot(HaveOccurred(), "could not create Role")
 
 		roleBinding := rbacv1.RoleBinding{
@@ -1293,7 +1398,7 @@ var _ = Describe("ClusterServiceVersion", func() {
 		}
 		roleBinding.SetName(genName("dep-"))
 		roleBinding.SetNamespace(testNamespace)
-		_, err = c.CreateRoleBinding(&roleBinding)
+		_, err = c.CreateRoleBinding(context.TODO(), &roleBinding)
 		Expect(err).ShouldNot(HaveOccurred(), "could not create RoleBinding")
 
 		clusterRole := rbacv1.ClusterRole{
@@ -1306,7 +1411,7 @@ var _ = Describe("ClusterServiceVersion", func() {
 			},
 		}
 		clusterRole.SetName(genName("dep-"))
-		_, err = c.CreateClusterRole(&clusterRole)
+		_, err = c.CreateClusterRole(context.TODO(), &clusterRole)
 		Expect(err).ShouldNot(HaveOccurred(), "could not create ClusterRole")
 
 		nonResourceClusterRole := rbacv1.ClusterRole{
@@ -1318,7 +1423,7 @@ var _ = Describe("ClusterServiceVersion", func() {
 			},
 		}
 		nonResourceClusterRole.SetName(genName("dep-"))
-		_, err = c.CreateClusterRole(&nonResourceClusterRole)
+		_, err = c.CreateClusterRole(context.TODO(), &nonResourceClusterRole)
 		Expect(err).ShouldNot(HaveOccurred(), "could not create ClusterRole")
 
 		clusterRoleBinding := rbacv1.ClusterRoleBinding{
@@ -1337,7 +1442,7 @@ var _ = Describe("ClusterServiceVersion", func() {
 			},
 		}
 		clusterRoleBinding.SetName(genName("dep-"))
-		_, err = c.CreateClusterRoleBinding(&clusterRoleBinding)
+		_, err = c.CreateClusterRoleBinding(context.TODO(), &clusterRoleBinding)
 		Expect(err).ShouldNot(HaveOccurred(), "could not create ClusterRoleBinding")
 
 		nonResourceClusterRoleBinding := rbacv1.ClusterRoleBinding{
@@ -1356,13 +1461,13 @@ var _ = Describe("ClusterServiceVersion", func() {
 			},
 		}
 		nonResourceClusterRoleBinding.SetName(genName("dep-"))
-		_, err = c.CreateClusterRoleBinding(&nonResourceClusterRoleBinding)
+		_, err = c.CreateClusterRoleBinding(context.TODO(), &nonResourceClusterRoleBinding)
 		Expect(err).ShouldNot(HaveOccurred(), "could not create ClusterRoleBinding")
 
 		ctx.Ctx().Logf("checking for deployment")
 		// Poll for deployment to be ready
 		Eventually(func() (bool, error) {
-			dep, err := c.GetDeployment(testNamespace, depName)
+			dep, err := c.GetDeployment(context.TODO(), testNamespace, depName)
 			if k8serrors.IsNotFound(err) {
 				ctx.Ctx().Logf("deployment %s not found\n", depName)
 				return false, nil
@@ -1404,7 +1509,7 @@ var _ = Describe("ClusterServiceVersion", func() {
 		sa := corev1.ServiceAccount{}
 		sa.SetName(genName("sa-"))
 		sa.SetNamespace(testNamespace)
-		_, err := c.CreateServiceAccount(&sa)
+		_, err := c.CreateServiceAccount(context.TODO(), &sa)
 		Expect(err).ShouldNot(HaveOccurred(), "could not create ServiceAccount")
 
 		permissions := []operatorsv1alpha1.StrategyDeploymentPermissions{
@@ -1490,7 +1595,7 @@ var _ = Describe("ClusterServiceVersion", func() {
 		}
 		role.SetName(genName("dep-"))
 		role.SetNamespace(testNamespace)
-		_, err = c.CreateRole(&role)
+		_, err = c.CreateRole(context.TODO(), &role)
 		Expect(err).ShouldNot(HaveOccurred(), "could not create Role")
 
 		roleBinding := rbacv1.RoleBinding{
@@ -1510,7 +1615,7 @@ var _ = Describe("ClusterServiceVersion", func() {
 		}
 		roleBinding.SetName(genName("dep-"))
 		roleBinding.SetNamespace(testNamespace)
-		_, err = c.CreateRoleBinding(&roleBinding)
+		_, err = c.CreateRoleBinding(context.TODO(), &roleBinding)
 		Expect(err).ShouldNot(HaveOccurred(), "could not create RoleBinding")
 
 		clusterRole := rbacv1.ClusterRole{
@@ -1523,7 +1628,7 @@ var _ = Describe("ClusterServiceVersion", func() {
 			},
 		}
 		clusterRole.SetName(genName("dep-"))
-		_, err = c.CreateClusterRole(&clusterRole)
+		_, err = c.CreateClusterRole(context.TODO(), &clusterRole)
 		Expect(err).ShouldNot(HaveOccurred(), "could not create ClusterRole")
 
 		clusterRoleBinding := rbacv1.ClusterRoleBinding{
@@ -1542,7 +1647,7 @@ var _ = Describe("ClusterServiceVersion", func() {
 			},
 		}
 		clusterRoleBinding.SetName(genName("dep-"))
-		_, err = c.CreateClusterRoleBinding(&clusterRoleBinding)
+		_, err = c.CreateClusterRoleBinding(context.TODO(), &clusterRoleBinding)
 		Expect(err).ShouldNot(HaveOccurred(), "could not create ClusterRoleBinding")
 
 		cleanupCSV, err := createCSV(c, crc, csv, testNamespace, false, false)
@@ -1655,37 +1760,37 @@ var _ = Describe("ClusterServiceVersion", func() {
 		Expect(err).ShouldNot(HaveOccurred())
 
 		// Should create Deployment
-		dep, err := c.GetDeployment(testNamespace, depName)
+		dep, err := c.GetDeployment(context.TODO(), testNamespace, depName)
 		Expect(err).ShouldNot(HaveOccurred(), "error getting expected Deployment")
 
 		// Should create APIService
-		apiService, err := c.GetAPIService(apiServiceName)
+		apiService, err := c.GetAPIService(context.TODO(), apiServiceName)
 		Expect(err).ShouldNot(HaveOccurred(), "error getting expected APIService")
 
 		// Should create Service
 		serviceName := fmt.Sprintf("%s-service", depName)
-		_, err = c.GetService(testNamespace, serviceName)
+		_, err = c.GetService(context.TODO(), testNamespace, serviceName)
 		Expect(err).ShouldNot(HaveOccurred(), "error getting expected Service")
 
 		// Should create certificate Secret
 		secretName := fmt.Sprintf("%s-cert", serviceName)
-		_, err = c.GetSecret(testNamespace, secretName)
+		_, err = c.GetSecret(context.TODO(), testNamespace, secretName)
 		Expect(err).ShouldNot(HaveOccurred(), "error getting expected Secret")
 
 		// Should create a Role for the Secret
-		_, err = c.GetRole(testNamespace, secretName)
+		_, err = c.GetRole(context.TODO(), testNamespace, secretName)
 		Expect(err).ShouldNot(HaveOccurred(), "error getting expected Secret Role")
 
 		// Should create a RoleBinding for the Secret
-		_, err = c.GetRoleBinding(testNamespace, secretName)
+		_, err = c.GetRoleBinding(context.TODO(), testNamespace, secretName)
 		Expect(err).ShouldNot(HaveOccurred(), "error getting exptected Secret RoleBinding")
 
 		// Should create a system:auth-delegator Cluster RoleBinding
-		_, err = c.GetClusterRoleBinding(fmt.Sprintf("%s-system:auth-delegator", serviceName))
+		_, err = c.GetClusterRoleBinding(context.TODO(), fmt.Sprintf("%s-system:auth-delegator", serviceName))
 		Expect(err).ShouldNot(HaveOccurred(), "error getting expected system:auth-delegator ClusterRoleBinding")
 
 		// Should create an extension-apiserver-authentication-reader RoleBinding in kube-system
-		_, err = c.GetRoleBinding("kube-system", fmt.Sprintf("%s-auth-reader", serviceName))
+		_, err = c.GetRoleBinding(context.TODO(), "kube-system", fmt.Sprintf("%s-auth-reader", serviceName))
 		Expect(err).ShouldNot(HaveOccurred(), "error getting expected extension-apiserver-authentication-reader RoleBinding")
 
 		// Store the ca sha annotation
@@ -1702,7 +1807,7 @@ var _ = Describe("ClusterServiceVersion", func() {
 
 		_, err = fetchCSV(crc, csv.Name, testNamespace, func(csv *operatorsv1alpha1.ClusterServiceVersion) bool {
 			// Should create deployment
-			dep, err = c.GetDeployment(testNamespace, depName)
+			dep, err = c.GetDeployment(context.TODO(), testNamespace, depName)
 			if err != nil {
 				return false
 			}
@@ -1727,13 +1832,13 @@ var _ = Describe("ClusterServiceVersion", func() {
 		oldAPIServiceUID := apiService.GetUID()
 
 		// Delete the APIService
-		err = c.DeleteAPIService(apiServiceName, &metav1.DeleteOptions{})
+		err = c.DeleteAPIService(context.TODO(), apiServiceName, &metav1.DeleteOptions{})
 		Expect(err).ShouldNot(HaveOccurred())
 
 		// Wait for CSV success
 		fetchedCSV, err = fetchCSV(crc, csv.GetName(), testNamespace, func(csv *operatorsv1alpha1.ClusterServiceVersion) bool {
 			// Should create an APIService
-			apiService, err := c.GetAPIService(apiServiceName)
+			apiService, err := c.GetAPIService(context.TODO(), apiServiceName)
 			if err != nil {
 				return false
 			}
@@ -1819,37 +1924,37 @@ var _ = Describe("ClusterServiceVersion", func() {
 		Expect(err).ShouldNot(HaveOccurred())
 
 		// Should create Deployment
-		_, err = c.GetDeployment(testNamespace, depName)
+		_, err = c.GetDeployment(context.TODO(), testNamespace, depName)
 		Expect(err).ShouldNot(HaveOccurred(), "error getting expected Deployment")
 
 		// Should create APIService
-		_, err = c.GetAPIService(apiServiceName)
+		_, err = c.GetAPIService(context.TODO(), apiServiceName)
 		Expect(err).ShouldNot(HaveOccurred(), "error getting expected APIService")
 
 		// Should create Service
 		serviceName := fmt.Sprintf("%s-service", depName)
-		_, err = c.GetService(testNamespace, serviceName)
+		_, err = c.GetService(context.TODO(), testNamespace, serviceName)
 		Expect(err).ShouldNot(HaveOccurred(), "error getting expected Service")
 
 		// Should create certificate Secret
 		secretName := fmt.Sprintf("%s-cert", serviceName)
-		_, err = c.GetSecret(testNamespace, secretName)
+		_, err = c.GetSecret(context.TODO(), testNamespace, secretName)
 		Expect(err).ShouldNot(HaveOccurred(), "error getting expected Secret")
 
 		// Should create a Role for the Secret
-		_, err = c.GetRole(testNamespace, secretName)
+		_, err = c.GetRole(context.TODO(), testNamespace, secretName)
 		Expect(err).ShouldNot(HaveOccurred(), "error getting expected Secret Role")
 
 		// Should create a RoleBinding for the Secret
-		_, err = c.GetRoleBinding(testNamespace, secretName)
+		_, err = c.GetRoleBinding(context.TODO(), testNamespace, secretName)
 		Expect(err).ShouldNot(HaveOccurred(), "error getting exptected Secret RoleBinding")
 
 		// Should create a system:auth-delegator Cluster RoleBinding
-		_, err = c.GetClusterRoleBinding(fmt.Sprintf("%s-system:auth-delegator", serviceName))
+		_, err = c.GetClusterRoleBinding(context.TODO(), fmt.Sprintf("%s-system:auth-delegator", serviceName))
 		Expect(err).ShouldNot(HaveOccurred(), "error getting expected system:auth-delegator ClusterRoleBinding")
 
 		// Should create an extension-apiserver-authentication-reader RoleBinding in kube-system
-		_, err = c.GetRoleBinding("kube-system", fmt.Sprintf("%s-auth-reader", serviceName))
+		_, err = c.GetRoleBinding(context.TODO(), "kube-system", fmt.Sprintf("%s-auth-reader", serviceName))
 		Expect(err).ShouldNot(HaveOccurred(), "error getting expected extension-apiserver-authentication-reader RoleBinding")
 
 		// Create a new CSV that owns the same API Service and replace the old CSV
@@ -1895,48 +2000,48 @@ var _ = Describe("ClusterServiceVersion", func() {
 		Expect(err).ShouldNot(HaveOccurred())
 
 		// Should create Deployment
-		_, err = c.GetDeployment(testNamespace, depName)
+		_, err = c.GetDeployment(context.TODO(), testNamespace, depName)
 		Expect(err).ShouldNot(HaveOccurred(), "error getting expected Deployment")
 
 		// Should create APIService
-		_, err = c.GetAPIService(apiServiceName)
+		_, err = c.GetAPIService(context.TODO(), apiServiceName)
 		Expect(err).ShouldNot(HaveOccurred(), "error getting expected APIService")
 
 		// Should create Service
 		Eventually(func() error {
-			_, err := c.GetService(testNamespace, serviceName)
+			_, err := c.GetService(context.TODO(), testNamespace, serviceName)
 			return err
 		}, timeout, interval).ShouldNot(HaveOccurred())
 
 		// Should create certificate Secret
 		secretName = fmt.Sprintf("%s-cert", serviceName)
 		Eventually(func() error {
-			_, err = c.GetSecret(testNamespace, secretName)
+			_, err = c.GetSecret(context.TODO(), testNamespace, secretName)
 			return err
 		}, timeout, interval).ShouldNot(HaveOccurred())
 
 		// Should create a Role for the Secret
-		_, err = c.GetRole(testNamespace, secretName)
+		_, err = c.GetRole(context.TODO(), testNamespace, secretName)
 		Eventually(func() error {
-			_, err = c.GetRole(testNamespace, secretName)
+			_, err = c.GetRole(context.TODO(), testNamespace, secretName)
 			return err
 		}, timeout, interval).ShouldNot(HaveOccurred())
 
 		// Should create a RoleBinding for the Secret
 		Eventually(func() error {
-			_, err = c.GetRoleBinding(testNamespace, secretName)
+			_, err = c.GetRoleBinding(context.TODO(), testNamespace, secretName)
 			return err
 		}, timeout, interval).ShouldNot(HaveOccurred())
 
 		// Should create a system:auth-delegator Cluster RoleBinding
 		Eventually(func() error {
-			_, err = c.GetClusterRoleBinding(fmt.Sprintf("%s-system:auth-delegator", serviceName))
+			_, err = c.GetClusterRoleBinding(context.TODO(), fmt.Sprintf("%s-system:auth-delegator", serviceName))
 			return err
 		}, timeout, interval).ShouldNot(HaveOccurred())
 
 		// Should create an extension-apiserver-authentication-reader RoleBinding in kube-system
 		Eventually(func() error {
-			_, err = c.GetRoleBinding("kube-system", fmt.Sprintf("%s-auth-reader", serviceName))
+			_, err = c.GetRoleBinding(context.TODO(), "kube-system", fmt.Sprintf("%s-auth-reader", serviceName))
 			return err
 		}, timeout, interval).ShouldNot(HaveOccurred())
 		Expect(err).ShouldNot(HaveOccurred(), "error getting expected extension-apiserver-authentication-reader RoleBinding")
@@ -2077,37 +2182,37 @@ var _ = Describe("ClusterServiceVersion", func() {
 		Expect(err).ShouldNot(HaveOccurred())
 
 		// Should create Deployment
-		_, err = c.GetDeployment(testNamespace, depName)
+		_, err = c.GetDeployment(context.TODO(), testNamespace, depName)
 		Expect(err).ShouldNot(HaveOccurred(), "error getting expected Deployment")
 
 		// Should create APIService
-		_, err = c.GetAPIService(apiServiceName)
+		_, err = c.GetAPIService(context.TODO(), apiServiceName)
 		Expect(err).ShouldNot(HaveOccurred(), "error getting expected APIService")
 
 		// Should create Service
 		serviceName := fmt.Sprintf("%s-service", depName)
-		_, err = c.GetService(testNamespace, serviceName)
+		_, err = c.GetService(context.TODO(), testNamespace, serviceName)
 		Expect(err).ShouldNot(HaveOccurred(), "error getting expected Service")
 
 		// Should create certificate Secret
 		secretName := fmt.Sprintf("%s-cert", serviceName)
-		_, err = c.GetSecret(testNamespace, secretName)
+		_, err = c.GetSecret(context.TODO(), testNamespace, secretName)
 		Expect(err).ShouldNot(HaveOccurred(), "error getting expected Secret")
 
 		// Should create a Role for the Secret
-		_, err = c.GetRole(testNamespace, secretName)
+		_, err = c.GetRole(context.TODO(), testNamespace, secretName)
 		Expect(err).ShouldNot(HaveOccurred(), "error getting expected Secret Role")
 
 		// Should create a RoleBinding for the Secret
-		_, err = c.GetRoleBinding(testNamespace, secretName)
+		_, err = c.GetRoleBinding(context.TODO(), testNamespace, secretName)
 		Expect(err).ShouldNot(HaveOccurred(), "error getting exptected Secret RoleBinding")
 
 		// Should create a system:auth-delegator Cluster RoleBinding
-		_, err = c.GetClusterRoleBinding(fmt.Sprintf("%s-system:auth-delegator", serviceName))
+		_, err = c.GetClusterRoleBinding(context.TODO(), fmt.Sprintf("%s-system:auth-delegator", serviceName))
 		Expect(err).ShouldNot(HaveOccurred(), "error getting expected system:auth-delegator ClusterRoleBinding")
 
 		// Should create an extension-apiserver-authentication-reader RoleBinding in kube-system
-		_, err = c.GetRoleBinding("kube-system", fmt.Sprintf("%s-auth-reader", serviceName))
+		_, err = c.GetRoleBinding(context.TODO(), "kube-system", fmt.Sprintf("%s-auth-reader", serviceName))
 		Expect(err).ShouldNot(HaveOccurred(), "error getting expected extension-apiserver-authentication-reader RoleBinding")
 
 		// Create a new CSV that owns the same API Service but in a different namespace
@@ -2191,25 +2296,25 @@ var _ = Describe("ClusterServiceVersion", func() {
 			}
 		}()
 
-		_, err = c.CreateAPIService(apiService)
+		_, err = c.CreateAPIService(context.TODO(), apiService)
 		Expect(err).ShouldNot(HaveOccurred(), "error creating expected APIService")
-		orphanedAPISvc, err := c.GetAPIService(apiServiceName)
+		orphanedAPISvc, err := c.GetAPIService(context.TODO(), apiServiceName)
 		Expect(err).ShouldNot(HaveOccurred(), "error getting expected APIService")
 
 		newLabels := map[string]string{"olm.owner": "hat-serverfd4r5", "olm.owner.kind": "ClusterServiceVersion", "olm.owner.namespace": "nonexistent-namespace"}
 		orphanedAPISvc.SetLabels(newLabels)
-		_, err = c.UpdateAPIService(orphanedAPISvc)
+		_, err = c.UpdateAPIService(context.TODO(), orphanedAPISvc)
 		Expect(err).ShouldNot(HaveOccurred(), "error updating APIService")
 		<-deleted
 
-		_, err = c.CreateAPIService(apiService)
+		_, err = c.CreateAPIService(context.TODO(), apiService)
 		Expect(err).ShouldNot(HaveOccurred(), "error creating expected APIService")
-		orphanedAPISvc, err = c.GetAPIService(apiServiceName)
+		orphanedAPISvc, err = c.GetAPIService(context.TODO(), apiServiceName)
 		Expect(err).ShouldNot(HaveOccurred(), "error getting expected APIService")
 
 		newLabels = map[string]string{"olm.owner": "hat-serverfd4r5", "olm.owner.kind": "ClusterServiceVersion", "olm.owner.namespace": testNamespace}
 		orphanedAPISvc.SetLabels(newLabels)
-		_, err = c.UpdateAPIService(orphanedAPISvc)
+		_, err = c.UpdateAPIService(context.TODO(), orphanedAPISvc)
 		Expect(err).ShouldNot(HaveOccurred(), "error updating APIService")
 		<-deleted
 	})
@@ -2279,7 +2384,7 @@ var _ = Describe("ClusterServiceVersion", func() {
 		Expect(err).ShouldNot(HaveOccurred())
 
 		// Should have created deployment
-		dep, err := c.GetDeployment(testNamespace, strategy.DeploymentSpecs[0].Name)
+		dep, err := c.GetDeployment(context.TODO(), testNamespace, strategy.DeploymentSpecs[0].Name)
 		Expect(err).ShouldNot(HaveOccurred())
 		Expect(dep).ShouldNot(BeNil())
 
@@ -2350,7 +2455,7 @@ var _ = Describe("ClusterServiceVersion", func() {
 		Expect(err).ShouldNot(HaveOccurred())
 
 		// Should have created deployment
-		dep, err := c.GetDeployment(testNamespace, strategy.DeploymentSpecs[0].Name)
+		dep, err := c.GetDeployment(context.TODO(), testNamespace, strategy.DeploymentSpecs[0].Name)
 		Expect(err).ShouldNot(HaveOccurred())
 		Expect(dep).ShouldNot(BeNil())
 
@@ -2465,7 +2570,7 @@ var _ = Describe("ClusterServiceVersion", func() {
 		Expect(err).ShouldNot(HaveOccurred())
 
 		// Should have created deployment
-		dep, err := c.GetDeployment(testNamespace, strategy.DeploymentSpecs[0].Name)
+		dep, err := c.GetDeployment(context.TODO(), testNamespace, strategy.DeploymentSpecs[0].Name)
 		Expect(err).ShouldNot(HaveOccurred())
 		Expect(dep).ShouldNot(BeNil())
 
@@ -2538,7 +2643,7 @@ var _ = Describe("ClusterServiceVersion", func() {
 		Expect(err).ShouldNot(HaveOccurred())
 
 		// Should have updated existing deployment
-		depUpdated, err := c.GetDeployment(testNamespace, strategyNew.DeploymentSpecs[0].Name)
+		depUpdated, err := c.GetDeployment(context.TODO(), testNamespace, strategyNew.DeploymentSpecs[0].Name)
 		Expect(err).ShouldNot(HaveOccurred())
 		Expect(depUpdated).ShouldNot(BeNil())
 		Expect(strategyNew.DeploymentSpecs[0].Spec.Template.Spec.Containers[0].Name).Should(Equal(depUpdated.Spec.Template.Spec.Containers[0].Name))
@@ -2656,7 +2761,7 @@ var _ = Describe("ClusterServiceVersion", func() {
 		Expect(err).ShouldNot(HaveOccurred())
 
 		// Should have created deployment
-		dep, err := c.GetDeployment(testNamespace, strategy.DeploymentSpecs[0].Name)
+		dep, err := c.GetDeployment(context.TODO(), testNamespace, strategy.DeploymentSpecs[0].Name)
 		Expect(err).ShouldNot(HaveOccurred())
 		Expect(dep).ShouldNot(BeNil())
 
@@ -2732,7 +2837,7 @@ var _ = Describe("ClusterServiceVersion", func() {
 		Expect(equality.Semantic.DeepEqual(fetchedCSV, sameCSV)).Should(BeTrue(), diff.ObjectDiff(fetchedCSV, sameCSV))
 
 		// Should have created new deployment and deleted old
-		depNew, err := c.GetDeployment(testNamespace, strategyNew.DeploymentSpecs[0].Name)
+		depNew, err := c.GetDeployment(context.TODO(), testNamespace, strategyNew.DeploymentSpecs[0].Name)
 		Expect(err).ShouldNot(HaveOccurred())
 		Expect(depNew).ShouldNot(BeNil())
 		err = waitForDeploymentToDelete(c, strategy.DeploymentSpecs[0].Name)
@@ -2846,7 +2951,7 @@ var _ = Describe("ClusterServiceVersion", func() {
 		Expect(err).ShouldNot(HaveOccurred())
 
 		// Should have created deployment
-		dep, err := c.GetDeployment(testNamespace, strategy.DeploymentSpecs[0].Name)
+		dep, err := c.GetDeployment(context.TODO(), testNamespace, strategy.DeploymentSpecs[0].Name)
 		Expect(err).ShouldNot(HaveOccurred())
 		Expect(dep).ShouldNot(BeNil())
 
@@ -2922,7 +3027,7 @@ var _ = Describe("ClusterServiceVersion", func() {
 		Expect(equality.Semantic.DeepEqual(fetchedCSV, sameCSV)).Should(BeTrue(), diff.ObjectDiff(fetchedCSV, sameCSV))
 
 		// Should have created new deployment and deleted old
-		depNew, err := c.GetDeployment(testNamespace, strategyNew.DeploymentSpecs[0].Name)
+		depNew, err := c.GetDeployment(context.TODO(), testNamespace, strategyNew.DeploymentSpecs[0].Name)
 		Expect(err).ShouldNot(HaveOccurred())
 		Expect(depNew).ShouldNot(BeNil())
 		err = waitForDeploymentToDelete(c, strategy.DeploymentSpecs[0].Name)
@@ -3037,7 +3142,7 @@ var _ = Describe("ClusterServiceVersion", func() {
 		Expect(err).ShouldNot(HaveOccurred())
 
 		// Should have created deployment
-		dep, err := c.GetDeployment(testNamespace, strategy.DeploymentSpecs[0].Name)
+		dep, err := c.GetDeployment(context.TODO(), testNamespace, strategy.DeploymentSpecs[0].Name)
 		Expect(err).ShouldNot(HaveOccurred())
 		Expect(dep).ShouldNot(BeNil())
 
@@ -3070,7 +3175,7 @@ var _ = Describe("ClusterServiceVersion", func() {
 
 		// wait for deployment spec to be updated
 		Eventually(func() (string, error) {
-			fetched, err := c.GetDeployment(testNamespace, strategyNew.DeploymentSpecs[0].Name)
+			fetched, err := c.GetDeployment(context.TODO(), testNamespace, strategyNew.DeploymentSpecs[0].Name)
 			if err != nil {
 				return "", err
 			}
@@ -3082,7 +3187,7 @@ var _ = Describe("ClusterServiceVersion", func() {
 		_, err = fetchCSV(crc, csv.Name, testNamespace, csvSucceededChecker)
 		Expect(err).ShouldNot(HaveOccurred())
 
-		depUpdated, err := c.GetDeployment(testNamespace, strategyNew.DeploymentSpecs[0].Name)
+		depUpdated, err := c.GetDeployment(context.TODO(), testNamespace, strategyNew.DeploymentSpecs[0].Name)
 		Expect(err).ShouldNot(HaveOccurred())
 		Expect(depUpdated).ShouldNot(BeNil())
 
@@ -3208,7 +3313,7 @@ var _ = Describe("ClusterServiceVersion", func() {
 		Expect(err).ShouldNot(HaveOccurred())
 
 		// Should have created deployment
-		dep, err := c.GetDeployment(testNamespace, strategy.DeploymentSpecs[0].Name)
+		dep, err := c.GetDeployment(context.TODO(), testNamespace, strategy.DeploymentSpecs[0].Name)
 		Expect(err).ShouldNot(HaveOccurred())
 		Expect(dep).ShouldNot(BeNil())
 
@@ -3292,7 +3397,7 @@ var _ = Describe("ClusterServiceVersion", func() {
 		Expect(equality.Semantic.DeepEqual(fetchedCSV, sameCSV)).Should(BeTrue(), diff.ObjectDiff(fetchedCSV, sameCSV))
 
 		// Should have created new deployment and deleted old one
-		depNew, err := c.GetDeployment(testNamespace, strategyNew.DeploymentSpecs[0].Name)
+		depNew, err := c.GetDeployment(context.TODO(), testNamespace, strategyNew.DeploymentSpecs[0].Name)
 		Expect(err).ShouldNot(HaveOccurred())
 		Expect(depNew).ShouldNot(BeNil())
 		err = waitForDeploymentToDelete(c, strategy.DeploymentSpecs[0].Name)
@@ -3371,7 +3476,7 @@ var _ = Describe("ClusterServiceVersion", func() {
 		Expect(equality.Semantic.DeepEqual(fetchedCSV, sameCSV)).Should(BeTrue(), diff.ObjectDiff(fetchedCSV, sameCSV))
 
 		// Should have created new deployment and deleted old one
-		depNew, err = c.GetDeployment(testNamespace, strategyNew2.DeploymentSpecs[0].Name)
+		depNew, err = c.GetDeployment(context.TODO(), testNamespace, strategyNew2.DeploymentSpecs[0].Name)
 		Expect(err).ShouldNot(HaveOccurred())
 		Expect(depNew).ShouldNot(BeNil())
 		err = waitForDeploymentToDelete(c, strategyNew.DeploymentSpecs[0].Name)
@@ -3488,10 +3593,10 @@ var _ = Describe("ClusterServiceVersion", func() {
 		Expect(err).ShouldNot(HaveOccurred())
 
 		// Should have created deployments
-		dep, err := c.GetDeployment(testNamespace, strategy.DeploymentSpecs[0].Name)
+		dep, err := c.GetDeployment(context.TODO(), testNamespace, strategy.DeploymentSpecs[0].Name)
 		Expect(err).ShouldNot(HaveOccurred())
 		Expect(dep).ShouldNot(BeNil())
-		dep2, err := c.GetDeployment(testNamespace, strategy.DeploymentSpecs[1].Name)
+		dep2, err := c.GetDeployment(context.TODO(), testNamespace, strategy.DeploymentSpecs[1].Name)
 		Expect(err).ShouldNot(HaveOccurred())
 		Expect(dep2).ShouldNot(BeNil())
 
@@ -3531,12 +3636,12 @@ var _ = Describe("ClusterServiceVersion", func() {
 		Expect(err).ShouldNot(HaveOccurred())
 
 		// Should have created new deployment and deleted old
-		depNew, err := c.GetDeployment(testNamespace, strategyNew.DeploymentSpecs[0].Name)
+		depNew, err := c.GetDeployment(context.TODO(), testNamespace, strategyNew.DeploymentSpecs[0].Name)
 		Expect(err).ShouldNot(HaveOccurred())
 		Expect(depNew).ShouldNot(BeNil())
 
 		// Make sure the unchanged deployment still exists
-		depNew2, err := c.GetDeployment(testNamespace, strategyNew.DeploymentSpecs[1].Name)
+		depNew2, err := c.GetDeployment(context.TODO(), testNamespace, strategyNew.DeploymentSpecs[1].Name)
 		Expect(err).ShouldNot(HaveOccurred())
 		Expect(depNew2).ShouldNot(BeNil())
 
@@ -3648,7 +3753,7 @@ var _ = Describe("ClusterServiceVersion", func() {
 		Expect(err).ShouldNot(HaveOccurred())
 
 		// Should have created deployment
-		dep, err := c.GetDeployment(testNamespace, strategy.DeploymentSpecs[0].Name)
+		dep, err := c.GetDeployment(context.TODO(), testNamespace, strategy.DeploymentSpecs[0].Name)
 		Expect(err).ShouldNot(HaveOccurred())
 		Expect(dep).ShouldNot(BeNil())
 
@@ -3681,7 +3786,7 @@ var _ = Describe("ClusterServiceVersion", func() {
 		_, err = fetchCSV(crc, csv.Name, testNamespace, func(csv *operatorsv1alpha1.ClusterServiceVersion) bool {
 
 			// Should have updated existing deployment
-			depUpdated, err := c.GetDeployment(testNamespace, strategyNew.DeploymentSpecs[0].Name)
+			depUpdated, err := c.GetDeployment(context.TODO(), testNamespace, strategyNew.DeploymentSpecs[0].Name)
 			Expect(err).ShouldNot(HaveOccurred())
 			Expect(depUpdated).ShouldNot(BeNil())
 			// container name has been updated and differs from initial CSV spec and updated CSV spec
@@ -4164,10 +4269,10 @@ var _ = Describe("ClusterServiceVersion", func() {
 		Expect(err).ShouldNot(HaveOccurred())
 
 		// Check that the APIService caBundles are equal
-		apiService1, err := c.GetAPIService(apiService1Name)
+		apiService1, err := c.GetAPIService(context.TODO(), apiService1Name)
 		Expect(err).ShouldNot(HaveOccurred())
 
-		apiService2, err := c.GetAPIService(apiService2Name)
+		apiService2, err := c.GetAPIService(context.TODO(), apiService2Name)
 		Expect(err).ShouldNot(HaveOccurred())
 
 		Expect(apiService2.Spec.CABundle).Should(Equal(apiService1.Spec.CABundle))
@@ -4596,14 +4701,11 @@ func newMockExtServerDeployment(labelName string, mGVKs []mockGroupVersionKind)
 	for _, mGVK := range mGVKs {
 		containers = append(containers, corev1.Container{
 			Name:    genName(mGVK.Name),
-			Image:   "quay.io/operator-framework/mock-extension-apiserver:master",
+			Image:   *mockExtensionAPIServerImage,
 			Command: []string{"/bin/mock-extension-apiserver"},
 			Args: []string{
-				"-v=4",
-				"--mock-kinds",
-				strings.Join(mGVK.MockKinds, ","),
-				"--mock-group-version",
-				mGVK.MockGroupVersion,
+				"--api",
+				fmt.Sprintf("%s=%s", mGVK.MockGroupVersion, strings.Join(mGVK.MockKinds, ",")),
 				"--secure-port",
 				strconv.Itoa(mGVK.Port),
 				"--debug",
@@ -4708,7 +4810,7 @@ func awaitCSV(c versioned.Interface, namespace, name string, checker csvConditio
 
 func waitForDeployment(c operatorclient.ClientInterface, name string) error {
 	return wait.Poll(pollInterval, pollDuration, func() (bool, error) {
-		_, err := c.GetDeployment(testNamespace, name)
+		_, err := c.GetDeployment(context.TODO(), testNamespace, name)
 		if err != nil {
 			if k8serrors.IsNotFound(err) {
 				return false, nil
@@ -4724,7 +4826,7 @@ func waitForDeploymentToDelete(c operatorclient.ClientInterface, name string) er
 
 	Eventually(func() (bool, error) {
 		ctx.Ctx().Logf("waiting for deployment %s to delete", name)
-		_, err := c.GetDeployment(testNamespace, name)
+		_, err := c.GetDeployment(context.TODO(), testNamespace, name)
 		if k8serrors.IsNotFound(err) {
 			ctx.Ctx().Logf("deleted %s", name)
 			return true, nil
@@ -4755,22 +4857,22 @@ func deleteLegacyAPIResources(desc operatorsv1alpha1.APIServiceDescription) {
 
 	apiServiceName := fmt.Sprintf("%s.%s", desc.Version, desc.Group)
 
-	err := c.DeleteService(testNamespace, strings.Replace(apiServiceName, ".", "-", -1), &metav1.DeleteOptions{})
+	err := c.DeleteService(context.TODO(), testNamespace, strings.Replace(apiServiceName, ".", "-", -1), &metav1.DeleteOptions{})
 	Expect(err).ShouldNot(HaveOccurred())
 
-	err = c.DeleteSecret(testNamespace, apiServiceName+"-cert", &metav1.DeleteOptions{})
+	err = c.DeleteSecret(context.TODO(), testNamespace, apiServiceName+"-cert", &metav1.DeleteOptions{})
 	Expect(err).ShouldNot(HaveOccurred())
 
-	err = c.DeleteRole(testNamespace, apiServiceName+"-cert", &metav1.DeleteOptions{})
+	err = c.DeleteRole(context.TODO(), testNamespace, apiServiceName+"-cert", &metav1.DeleteOptions{})
 	Expect(err).ShouldNot(HaveOccurred())
 
-	err = c.DeleteRoleBinding(testNamespace, apiServiceName+"-cert", &metav1.DeleteOptions{})
+	err = c.DeleteRoleBinding(context.TODO(), testNamespace, apiServiceName+"-cert", &metav1.DeleteOptions{})
 	Expect(err).ShouldNot(HaveOccurred())
 
-	err = c.DeleteClusterRoleBinding(apiServiceName+"-system:auth-delegator", &metav1.DeleteOptions{})
+	err = c.DeleteClusterRoleBinding(context.TODO(), apiServiceName+"-system:auth-delegator", &metav1.DeleteOptions{})
 	Expect(err).ShouldNot(HaveOccurred())
 
-	err = c.DeleteRoleBinding("kube-system", apiServiceName+"-auth-reader", &metav1.DeleteOptions{})
+	err = c.DeleteRoleBinding(context.TODO(), "kube-system", apiServiceName+"-auth-reader", &metav1.DeleteOptions{})
 	Expect(err).ShouldNot(HaveOccurred())
 }
 
@@ -4789,7 +4891,7 @@ func createLegacyAPIResources(csv *operatorsv1alpha1.ClusterServiceVersion, desc
 	}
 
 	service.Spec.Ports = []corev1.ServicePort{{Port: 433, TargetPort: intstr.FromInt(443)}}
-	_, err := c.CreateService(&service)
+	_, err := c.CreateService(context.TODO(), &service)
 	Expect(err).ShouldNot(HaveOccurred())
 
 	// Attempt to create the legacy secret
@@ -4801,7 +4903,7 @@ func createLegacyAPIResources(csv *operatorsv1alpha1.ClusterServiceVersion, desc
 		Expect(err).ShouldNot(HaveOccurred())
 	}
 
-	_, err = c.CreateSecret(&secret)
+	_, err = c.CreateSecret(context.TODO(), &secret)
 	if err != nil && !k8serrors.IsAlreadyExists(err) {
 		Expect(err).ShouldNot(HaveOccurred())
 	}
@@ -4814,7 +4916,7 @@ func createLegacyAPIResources(csv *operatorsv1alpha1.ClusterServiceVersion, desc
 		err = ownerutil.AddOwnerLabels(&role, csv)
 		Expect(err).ShouldNot(HaveOccurred())
 	}
-	_, err = c.CreateRole(&role)
+	_, err = c.CreateRole(context.TODO(), &role)
 	Expect(err).ShouldNot(HaveOccurred())
 
 	// Attempt to create the legacy secret role binding
@@ -4831,7 +4933,7 @@ func createLegacyAPIResources(csv *operatorsv1alpha1.ClusterServiceVersion, desc
 		Expect(err).ShouldNot(HaveOccurred())
 	}
 
-	_, err = c.CreateRoleBinding(&roleBinding)
+	_, err = c.CreateRoleBinding(context.TODO(), &roleBinding)
 	Expect(err).ShouldNot(HaveOccurred())
 
 	// Attempt to create the legacy authDelegatorClusterRoleBinding
@@ -4846,7 +4948,7 @@ func createLegacyAPIResources(csv *operatorsv1alpha1.ClusterServiceVersion, desc
 		err = ownerutil.AddOwnerLabels(&clusterRoleBinding, csv)
 		Expect(err).ShouldNot(HaveOccurred())
 	}
-	_, err = c.CreateClusterRoleBinding(&clusterRoleBinding)
+	_, err = c.CreateClusterRoleBinding(context.TODO(), &clusterRoleBinding)
 	Expect(err).ShouldNot(HaveOccurred())
 
 	// Attempt to create the legacy authReadingRoleBinding
@@ -4857,7 +4959,7 @@ func createLegacyAPIResources(csv *operatorsv1alpha1.ClusterServiceVersion, desc
 		Kind:     "Role",
 		Name:     "extension-apiserver-authentication-reader",
 	}
-	_, err = c.CreateRoleBinding(&roleBinding)
+	_, err = c.CreateRoleBinding(context.TODO(), &roleBinding)
 	Expect(err).ShouldNot(HaveOccurred())
 }
 
@@ -4866,26 +4968,26 @@ func checkLegacyAPIResources(desc operatorsv1alpha1.APIServiceDescription, expec
 	apiServiceName := fmt.Sprintf("%s.%s", desc.Version, desc.Group)
 
 	// Attempt to create the legacy service
-	_, err := c.GetService(testNamespace, strings.Replace(apiServiceName, ".", "-", -1))
+	_, err := c.GetService(context.TODO(), testNamespace, strings.Replace(apiServiceName, ".", "-", -1))
 	Expect(k8serrors.IsNotFound(err)).Should(Equal(expectedIsNotFound))
 
 	// Attempt to create the legacy secret
-	_, err = c.GetSecret(testNamespace, apiServiceName+"-cert")
+	_, err = c.GetSecret(context.TODO(), testNamespace, apiServiceName+"-cert")
 	Expect(k8serrors.IsNotFound(err)).Should(Equal(expectedIsNotFound))
 
 	// Attempt to create the legacy secret role
-	_, err = c.GetRole(testNamespace, apiServiceName+"-cert")
+	_, err = c.GetRole(context.TODO(), testNamespace, apiServiceName+"-cert")
 	Expect(k8serrors.IsNotFound(err)).Should(Equal(expectedIsNotFound))
 
 	// Attempt to create the legacy secret role binding
-	_, err = c.GetRoleBinding(testNamespace, apiServiceName+"-cert")
+	_, err = c.GetRoleBinding(context.TODO(), testNamespace, apiServiceName+"-cert")
 	Expect(k8serrors.IsNotFound(err)).Should(Equal(expectedIsNotFound))
 
 	// Attempt to create the legacy authDelegatorClusterRoleBinding
-	_, err = c.GetClusterRoleBinding(apiServiceName + "-system:auth-delegator")
+	_, err = c.GetClusterRoleBinding(context.TODO(), apiServiceName+"-system:auth-delegator")
 	Expect(k8serrors.IsNotFound(err)).Should(Equal(expectedIsNotFound))
 
 	// Attempt to create the legacy authReadingRoleBinding
-	_, err = c.GetRoleBinding("kube-system", apiServiceName+"-auth-reader")
+	_, err = c.GetRoleBinding(context.TODO(), "kube-system", apiServiceName+"-auth-reader")
 	Expect(k8serrors.IsNotFound(err)).Should(Equal(expectedIsNotFound))
 }
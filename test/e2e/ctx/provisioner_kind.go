@@ -3,6 +3,7 @@
 package ctx
 
 import (
+	"context"
 	"encoding/csv"
 	"flag"
 	"fmt"
@@ -13,16 +14,25 @@ import (
 	"sync"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/kind/pkg/apis/config/v1alpha4"
 	"sigs.k8s.io/kind/pkg/cluster"
+	"sigs.k8s.io/kind/pkg/cluster/constants"
+	"sigs.k8s.io/kind/pkg/cluster/nodes"
 	"sigs.k8s.io/kind/pkg/cluster/nodeutils"
 	"sigs.k8s.io/kind/pkg/log"
 )
 
 var (
-	images = flag.String("kind.images", "", "comma-separated list of image archives to load on cluster nodes, relative to the test binary or test package path")
-	logDir = "logs"
+	images  = flag.String("kind.images", "", "comma-separated list of image archives to load on cluster nodes, relative to the test binary or test package path")
+	mixedOS = flag.Bool("kind.mixed-os", false, "add a worker node labeled as a windows node to the cluster, to check that linux-only pods aren't scheduled on it")
+	logDir  = "logs"
 
 	verbosity int
 )
@@ -88,11 +98,16 @@ func Provision(ctx *TestContext) (func(), error) {
 		cluster.ProviderWithLogger(kindLogAdapter{ctx}),
 	)
 	name := fmt.Sprintf("kind-%s", rand.String(16))
-	if err := provider.Create(
-		name,
-		cluster.CreateWithWaitForReady(5*time.Minute),
+	options := []cluster.CreateOption{
+		cluster.CreateWithWaitForReady(5 * time.Minute),
 		cluster.CreateWithKubeconfigPath(kubeconfigPath),
-	); err != nil {
+	}
+	if *mixedOS {
+		options = append(options, cluster.CreateWithV1Alpha4Config(&v1alpha4.Cluster{
+			Nodes: []v1alpha4.Node{{Role: v1alpha4.ControlPlaneRole}, {Role: v1alpha4.WorkerRole}},
+		}))
+	}
+	if err := provider.Create(name, options...); err != nil {
 		return nil, fmt.Errorf("failed to create kind cluster: %s", err.Error())
 	}
 
@@ -136,6 +151,12 @@ func Provision(ctx *TestContext) (func(), error) {
 	}
 	ctx.restConfig = restConfig
 
+	if *mixedOS {
+		if err := labelWindowsWorkers(restConfig, nodes); err != nil {
+			return nil, err
+		}
+	}
+
 	if artifactsDir := os.Getenv("ARTIFACTS_DIR"); artifactsDir != "" {
 		ctx.artifactsDir = artifactsDir
 	}
@@ -160,3 +181,26 @@ func Provision(ctx *TestContext) (func(), error) {
 
 	return deprovision, setDerivedFields(ctx)
 }
+
+// labelWindowsWorkers labels the worker nodes of a mixed-OS cluster as windows nodes. Kind can't run windows nodes, so
+// the workers keep running linux pods, but only those tolerating any operating system should be scheduled on them.
+func labelWindowsWorkers(restConfig *rest.Config, kindNodes []nodes.Node) error {
+	client, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return fmt.Errorf("error creating kubernetes client: %s", err.Error())
+	}
+	patch := []byte(fmt.Sprintf(`{"metadata":{"labels":{%q:"windows"}}}`, corev1.LabelOSStable))
+	for _, node := range kindNodes {
+		role, err := node.Role()
+		if err != nil {
+			return fmt.Errorf("error reading the role of node %q: %s", node, err.Error())
+		}
+		if role != constants.WorkerNodeRoleValue {
+			continue
+		}
+		if _, err := client.CoreV1().Nodes().Patch(context.Background(), node.String(), types.MergePatchType, patch, metav1.PatchOptions{}); err != nil {
+			return fmt.Errorf("error labeling node %q as a windows node: %s", node, err.Error())
+		}
+	}
+	return nil
+}
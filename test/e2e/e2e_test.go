@@ -40,6 +40,26 @@ var (
 		"bitnami/nginx:latest",
 		"dummy image to treat as an operator in tests")
 
+	mockExtensionAPIServerImage = flag.String(
+		"mockExtensionAPIServerImage",
+		"quay.io/operator-framework/olm:local",
+		"image providing /bin/mock-extension-apiserver, serving the APIs of the APIServices of tests")
+
+	localRegistry = flag.String(
+		"localRegistry",
+		"localhost:5000",
+		"host of the registry the catalog and bundle images built by tests are pushed to over HTTP")
+
+	localRegistryClusterHost = flag.String(
+		"localRegistryClusterHost",
+		"",
+		"host the cluster pulls the images pushed to the local registry from, if it differs from localRegistry")
+
+	opmImage = flag.String(
+		"opmImage",
+		"operator-framework/opm:latest",
+		"image in the local registry providing /bin/opm, which the catalog images built by tests are based on")
+
 	testNamespace           = ""
 	operatorNamespace       = ""
 	communityOperatorsImage = ""
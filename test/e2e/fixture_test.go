@@ -0,0 +1,111 @@
+package e2e
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/blang/semver/v4"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/operator-framework/operator-lifecycle-manager/test/fixture"
+)
+
+// This file contains helper functions building the catalogs and bundle images of tests, and pushing them to the local
+// registry, so that tests don't depend on pre-built images hosted on public registries.
+
+// fixtureRegistry returns a client of the local registry the images built by tests are pushed to.
+func fixtureRegistry() *fixture.Registry {
+	return &fixture.Registry{Host: *localRegistry, PlainHTTP: true}
+}
+
+// clusterImage returns the reference the cluster pulls the image of the given reference, pushed to the local registry,
+// with.
+func clusterImage(ref string) string {
+	if *localRegistryClusterHost == "" {
+		return ref
+	}
+	return *localRegistryClusterHost + strings.TrimPrefix(ref, *localRegistry)
+}
+
+// pushBundleImage pushes the image of the given bundle to the local registry, and returns its reference by digest, as
+// the cluster pulls it.
+func pushBundleImage(ctx context.Context, b *fixture.Bundle) (string, error) {
+	img, err := b.Image()
+	if err != nil {
+		return "", fmt.Errorf("building image of bundle %s: %v", b.Name(), err)
+	}
+	ref, err := fixtureRegistry().Push(ctx, "bundles/"+b.Package, b.Name(), img)
+	if err != nil {
+		return "", fmt.Errorf("pushing image of bundle %s: %v", b.Name(), err)
+	}
+	return clusterImage(ref), nil
+}
+
+// pushCatalogImage pushes the images of the given bundles, and the image of a file-based catalog of them based on the
+// opm image, to the local registry. The catalog image is pushed to the given repository with the given tag, and its
+// reference by tag is returned, as the cluster pulls it, so that tests can move the tag.
+func pushCatalogImage(ctx context.Context, repository, tag string, bundles ...*fixture.Bundle) (string, error) {
+	var catalog fixture.Catalog
+	for _, b := range bundles {
+		image, err := pushBundleImage(ctx, b)
+		if err != nil {
+			return "", err
+		}
+		if err := catalog.AddBundle(b, image); err != nil {
+			return "", err
+		}
+	}
+
+	registry := fixtureRegistry()
+	base, err := registry.Pull(ctx, *opmImage)
+	if err != nil {
+		return "", fmt.Errorf("pulling catalog base image: %v", err)
+	}
+	img, err := catalog.Image(base)
+	if err != nil {
+		return "", fmt.Errorf("building catalog image: %v", err)
+	}
+	if _, err := registry.Push(ctx, repository, tag, img); err != nil {
+		return "", fmt.Errorf("pushing catalog image: %v", err)
+	}
+	return clusterImage(fmt.Sprintf("%s/%s:%s", registry.Host, repository, tag)), nil
+}
+
+// busyboxBundle returns a bundle of the given package in the alpha channel, whose CSV of the given version replaces
+// the given CSV and runs the dummy image, along with the given objects. The CSV is named <package>.v<version>.
+func busyboxBundle(packageName, version, replaces string, objects ...runtime.Object) *fixture.Bundle {
+	strategy := newNginxInstallStrategy(packageName, nil, nil)
+	csv := newCSV(fmt.Sprintf("%s.v%s", packageName, version), "", replaces, semver.MustParse(version), nil, nil, &strategy)
+	return &fixture.Bundle{
+		Package:  packageName,
+		Channels: []string{"alpha"},
+		CSV:      &csv,
+		Objects:  objects,
+	}
+}
+
+// catsrcUpdateTestBundles returns the bundles of the catalog of catalog source update tests: busybox 1.0.0, and busybox
+// 2.0.0 replacing it in the updated catalog.
+func catsrcUpdateTestBundles(updated bool) []*fixture.Bundle {
+	bundles := []*fixture.Bundle{busyboxBundle("busybox", "1.0.0", "")}
+	if updated {
+		bundles = append(bundles, busyboxBundle("busybox", "2.0.0", "busybox.v1.0.0"))
+	}
+	return bundles
+}
+
+// busyboxDependenciesBundles returns the bundles of the catalog of dependency tests: busybox 1.0.0 depending on
+// busybox-dependency 1.0.0, and in the updated catalog, busybox 2.0.0 and busybox-dependency 2.0.0 replacing them, the
+// former depending on the latter.
+func busyboxDependenciesBundles(updated bool) []*fixture.Bundle {
+	busybox := busyboxBundle("busybox", "1.0.0", "")
+	busybox.Properties = []fixture.Property{fixture.PackageRequiredProperty("busybox-dependency", ">=1.0.0")}
+	bundles := []*fixture.Bundle{busybox, busyboxBundle("busybox-dependency", "1.0.0", "")}
+	if updated {
+		busybox := busyboxBundle("busybox", "2.0.0", "busybox.v1.0.0")
+		busybox.Properties = []fixture.Property{fixture.PackageRequiredProperty("busybox-dependency", ">=2.0.0")}
+		bundles = append(bundles, busybox, busyboxBundle("busybox-dependency", "2.0.0", "busybox-dependency.v1.0.0"))
+	}
+	return bundles
+}
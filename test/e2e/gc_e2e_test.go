@@ -89,7 +89,7 @@ var _ = Describe("Garbage collection for dependent resources", func() {
 
 			// Create a ClusterRole for the crd
 			Eventually(func() error {
-				cr, err = kubeClient.CreateClusterRole(cr)
+				cr, err = kubeClient.CreateClusterRole(context.Background(), cr)
 				return err
 			}).Should(Succeed())
 		})
@@ -97,7 +97,7 @@ var _ = Describe("Garbage collection for dependent resources", func() {
 		AfterEach(func() {
 
 			// Clean up cluster role
-			IgnoreError(kubeClient.DeleteClusterRole(cr.GetName(), &metav1.DeleteOptions{}))
+			IgnoreError(kubeClient.DeleteClusterRole(context.Background(), cr.GetName(), &metav1.DeleteOptions{}))
 
 			// Clean up CRD
 			IgnoreError(kubeClient.ApiextensionsInterface().ApiextensionsV1().CustomResourceDefinitions().Delete(context.Background(), crd.GetName(), metav1.DeleteOptions{}))
@@ -115,7 +115,7 @@ var _ = Describe("Garbage collection for dependent resources", func() {
 
 			It("should delete the associated ClusterRole", func() {
 				Eventually(func() bool {
-					_, err := kubeClient.GetClusterRole(cr.GetName())
+					_, err := kubeClient.GetClusterRole(context.Background(), cr.GetName())
 					return k8serrors.IsNotFound(err)
 				}).Should(BeTrue(), "get cluster role should eventually return \"not found\"")
 			})
@@ -147,7 +147,7 @@ var _ = Describe("Garbage collection for dependent resources", func() {
 			// Create an API Service
 			var err error
 			Eventually(func() error {
-				apiService, err = kubeClient.CreateAPIService(apiService)
+				apiService, err = kubeClient.CreateAPIService(context.Background(), apiService)
 				return err
 			}).Should(Succeed())
 
@@ -160,16 +160,16 @@ var _ = Describe("Garbage collection for dependent resources", func() {
 
 			Eventually(func() error {
 				// Create a ClusterRole
-				cr, err = kubeClient.CreateClusterRole(cr)
+				cr, err = kubeClient.CreateClusterRole(context.Background(), cr)
 				return err
 			}).Should(Succeed())
 		})
 
 		AfterEach(func() {
 
-			IgnoreError(kubeClient.DeleteClusterRole(cr.GetName(), &metav1.DeleteOptions{}))
+			IgnoreError(kubeClient.DeleteClusterRole(context.Background(), cr.GetName(), &metav1.DeleteOptions{}))
 
-			IgnoreError(kubeClient.DeleteAPIService(apiService.GetName(), &metav1.DeleteOptions{}))
+			IgnoreError(kubeClient.DeleteAPIService(context.Background(), apiService.GetName(), &metav1.DeleteOptions{}))
 
 		})
 
@@ -178,14 +178,14 @@ var _ = Describe("Garbage collection for dependent resources", func() {
 			BeforeEach(func() {
 				// Delete API service
 				Eventually(func() bool {
-					err := kubeClient.DeleteAPIService(apiService.GetName(), &metav1.DeleteOptions{})
+					err := kubeClient.DeleteAPIService(context.Background(), apiService.GetName(), &metav1.DeleteOptions{})
 					return k8serrors.IsNotFound(err)
 				}).Should(BeTrue())
 			})
 
 			It("should delete the associated ClusterRole", func() {
 				Eventually(func() bool {
-					_, err := kubeClient.GetClusterRole(cr.GetName())
+					_, err := kubeClient.GetClusterRole(context.Background(), cr.GetName())
 					return k8serrors.IsNotFound(err)
 				}).Should(BeTrue(), "get cluster role should eventually return \"not found\"")
 			})
@@ -379,12 +379,12 @@ var _ = Describe("Garbage collection for dependent resources", func() {
 
 			// confirm extra bundle objects (secret and configmap) are installed
 			Eventually(func() error {
-				_, err := kubeClient.GetSecret(testNamespace, secretName)
+				_, err := kubeClient.GetSecret(context.Background(), testNamespace, secretName)
 				return err
 			}).Should(Succeed(), "expected no error getting secret object associated with CSV")
 
 			Eventually(func() error {
-				_, err := kubeClient.GetConfigMap(testNamespace, configmapName)
+				_, err := kubeClient.GetConfigMap(context.Background(), testNamespace, configmapName)
 				return err
 			}).Should(Succeed(), "expected no error getting configmap object associated with CSV")
 		})
@@ -421,12 +421,12 @@ var _ = Describe("Garbage collection for dependent resources", func() {
 			It("OLM should delete the associated configmap and secret", func() {
 				// confirm extra bundle objects (secret and configmap) are no longer installed on the cluster
 				Eventually(func() bool {
-					_, err := kubeClient.GetSecret(testNamespace, secretName)
+					_, err := kubeClient.GetSecret(context.Background(), testNamespace, secretName)
 					return k8serrors.IsNotFound(err)
 				}).Should(BeTrue())
 
 				Eventually(func() bool {
-					_, err := kubeClient.GetConfigMap(testNamespace, configmapName)
+					_, err := kubeClient.GetConfigMap(context.Background(), testNamespace, configmapName)
 					return k8serrors.IsNotFound(err)
 				}).Should(BeTrue())
 				ctx.Ctx().Logf("dependent successfully garbage collected after csv owner was deleted")
@@ -490,7 +490,7 @@ var _ = Describe("Garbage collection for dependent resources", func() {
 			Expect(err).ToNot(HaveOccurred(), "could not get installplan at complete phase")
 
 			Eventually(func() error {
-				_, err := kubeClient.GetConfigMap(testNamespace, configmapName)
+				_, err := kubeClient.GetConfigMap(context.Background(), testNamespace, configmapName)
 				return err
 			}).Should(Succeed(), "expected no error getting configmap object associated with CSV")
 		})
@@ -534,7 +534,7 @@ var _ = Describe("Garbage collection for dependent resources", func() {
 
 			It("OLM should have upgraded associated configmap in place", func() {
 				Eventually(func() (string, error) {
-					cfg, err := kubeClient.GetConfigMap(testNamespace, configmapName)
+					cfg, err := kubeClient.GetConfigMap(context.Background(), testNamespace, configmapName)
 					if err != nil {
 						return "", err
 					}
@@ -603,7 +603,7 @@ var _ = Describe("Garbage collection for dependent resources", func() {
 			Expect(err).ToNot(HaveOccurred(), "could not get installplan at complete phase")
 
 			Eventually(func() error {
-				_, err := kubeClient.GetConfigMap(testNamespace, configmapName)
+				_, err := kubeClient.GetConfigMap(context.Background(), testNamespace, configmapName)
 				return err
 			}).Should(Succeed(), "expected no error getting configmap object associated with CSV")
 		})
@@ -648,12 +648,12 @@ var _ = Describe("Garbage collection for dependent resources", func() {
 
 			It("should have removed the old configmap and put the new configmap in place", func() {
 				Eventually(func() bool {
-					_, err := kubeClient.GetConfigMap(testNamespace, configmapName)
+					_, err := kubeClient.GetConfigMap(context.Background(), testNamespace, configmapName)
 					return k8serrors.IsNotFound(err)
 				}).Should(BeTrue())
 
 				Eventually(func() error {
-					_, err := kubeClient.GetConfigMap(testNamespace, upgradedConfigMapName)
+					_, err := kubeClient.GetConfigMap(context.Background(), testNamespace, upgradedConfigMapName)
 					return err
 				}).Should(BeNil())
 				ctx.Ctx().Logf("dependent successfully updated after csv owner was updated")
@@ -2158,7 +2158,7 @@ var _ = Describe("Install Plan", func() {
 
 			// Should have the updated env var
 			err = wait.Poll(pollInterval, pollDuration, func() (bool, error) {
-				dep, err := c.GetDeployment(testNamespace, deploymentName)
+				dep, err := c.GetDeployment(context.TODO(), testNamespace, deploymentName)
 				if err != nil {
 					return false, nil
 				}
@@ -2205,7 +2205,7 @@ var _ = Describe("Install Plan", func() {
 			require.NoError(GinkgoT(), err)
 
 			// Should have created deployment and stomped on the env changes
-			updatedDep, err := c.GetDeployment(testNamespace, deploymentName)
+			updatedDep, err := c.GetDeployment(context.TODO(), testNamespace, deploymentName)
 			require.NoError(GinkgoT(), err)
 			require.NotNil(GinkgoT(), updatedDep)
 
@@ -2710,7 +2710,7 @@ var _ = Describe("Install Plan", func() {
 			// This operator was installed into a global operator group, so the roles should have been lifted to clusterroles
 			if step.Resource.Kind == "Role" {
 				err = wait.Poll(pollInterval, pollDuration, func() (bool, error) {
-					_, err = c.GetClusterRole(step.Resource.Name)
+					_, err = c.GetClusterRole(context.TODO(), step.Resource.Name)
 					if err != nil {
 						if k8serrors.IsNotFound(err) {
 							return false, nil
@@ -2723,7 +2723,7 @@ var _ = Describe("Install Plan", func() {
 			}
 			if step.Resource.Kind == "RoleBinding" {
 				err = wait.Poll(pollInterval, pollDuration, func() (bool, error) {
-					_, err = c.GetClusterRoleBinding(step.Resource.Name)
+					_, err = c.GetClusterRoleBinding(context.TODO(), step.Resource.Name)
 					if err != nil {
 						if k8serrors.IsNotFound(err) {
 							return false, nil
@@ -2816,7 +2816,7 @@ var _ = Describe("Install Plan", func() {
 		require.Emptyf(GinkgoT(), createdClusterRoleBindingNames, "unexpected cluster role binding remain: %v", createdClusterRoleBindingNames)
 
 		Eventually(func() error {
-			_, err := c.GetServiceAccount(testNamespace, serviceAccountName)
+			_, err := c.GetServiceAccount(context.TODO(), testNamespace, serviceAccountName)
 			if err == nil {
 				return fmt.Errorf("The %v/%v ServiceAccount should have been deleted", testNamespace, serviceAccountName)
 			}
@@ -0,0 +1,57 @@
+package e2e
+
+import (
+	"context"
+
+	"github.com/blang/semver/v4"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	k8scontrollerclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/operator-framework/operator-lifecycle-manager/test/e2e/ctx"
+)
+
+// Run with -kind.mixed-os, the cluster has a worker labeled as a windows node, which the pods of operators supporting
+// linux only must not be scheduled on.
+var _ = Describe("Operators declaring the platforms they support", func() {
+	var generatedNamespace corev1.Namespace
+
+	BeforeEach(func() {
+		generatedNamespace = SetupGeneratedTestNamespace(genName("node-os-e2e-"))
+	})
+
+	AfterEach(func() {
+		TeardownNamespace(generatedNamespace.GetName())
+	})
+
+	It("are only scheduled on the nodes running a supported operating system", func() {
+		strategy := newNginxInstallStrategy(genName("dep-"), nil, nil)
+		csv := newCSV(genName("linux-only-"), generatedNamespace.GetName(), "", semver.MustParse("0.1.0"), nil, nil, &strategy)
+		csv.SetLabels(map[string]string{"operatorframework.io/os.linux": "supported"})
+		Expect(ctx.Ctx().Client().Create(context.Background(), &csv)).To(Succeed())
+
+		_, err := fetchCSV(ctx.Ctx().OperatorClient(), csv.GetName(), generatedNamespace.GetName(), csvSucceededChecker)
+		Expect(err).ToNot(HaveOccurred())
+
+		var nodes corev1.NodeList
+		Expect(ctx.Ctx().Client().List(context.Background(), &nodes)).To(Succeed())
+		osByNode := map[string]string{}
+		for _, node := range nodes.Items {
+			osByNode[node.GetName()] = node.GetLabels()[corev1.LabelOSStable]
+		}
+
+		var pods corev1.PodList
+		Expect(ctx.Ctx().Client().List(context.Background(), &pods, k8scontrollerclient.InNamespace(generatedNamespace.GetName()))).To(Succeed())
+		Expect(pods.Items).ToNot(BeEmpty())
+		for _, pod := range pods.Items {
+			Expect(pod.Spec.Affinity).ToNot(BeNil())
+			Expect(pod.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms).To(ConsistOf(
+				corev1.NodeSelectorTerm{MatchExpressions: []corev1.NodeSelectorRequirement{
+					{Key: corev1.LabelOSStable, Operator: corev1.NodeSelectorOpIn, Values: []string{"linux"}},
+				}},
+			))
+			Expect(osByNode[pod.Spec.NodeName]).To(Equal("linux"))
+		}
+	})
+})
@@ -196,11 +196,11 @@ var _ = Describe("Operator Group", func() {
 		err = ownerutil.AddOwnerLabels(roleBinding, createdCSV)
 		require.NoError(GinkgoT(), err)
 
-		_, err = c.CreateServiceAccount(serviceAccount)
+		_, err = c.CreateServiceAccount(context.TODO(), serviceAccount)
 		require.NoError(GinkgoT(), err)
-		_, err = c.CreateRole(role)
+		_, err = c.CreateRole(context.TODO(), role)
 		require.NoError(GinkgoT(), err)
-		_, err = c.CreateRoleBinding(roleBinding)
+		_, err = c.CreateRoleBinding(context.TODO(), roleBinding)
 		require.NoError(GinkgoT(), err)
 
 		log("wait for CSV to succeed")
@@ -267,7 +267,7 @@ var _ = Describe("Operator Group", func() {
 
 		log("Waiting on deployment to have correct annotations")
 		err = wait.Poll(pollInterval, pollDuration, func() (bool, error) {
-			createdDeployment, err := c.GetDeployment(opGroupNamespace, deploymentName)
+			createdDeployment, err := c.GetDeployment(context.TODO(), opGroupNamespace, deploymentName)
 			if err != nil {
 				if k8serrors.IsNotFound(err) {
 					return false, nil
@@ -314,7 +314,7 @@ var _ = Describe("Operator Group", func() {
 		log("Waiting for operator to have rbac in target namespace")
 		err = wait.Poll(pollInterval, pollDuration, func() (bool, error) {
 			for _, perm := range permissions {
-				sa, err := c.GetServiceAccount(opGroupNamespace, perm.ServiceAccountName)
+				sa, err := c.GetServiceAccount(context.TODO(), opGroupNamespace, perm.ServiceAccountName)
 				require.NoError(GinkgoT(), err)
 				for _, rule := range perm.Rules {
 					satisfied, err := ruleChecker.RuleSatisfied(sa, otherNamespaceName, rule)
@@ -1397,20 +1397,20 @@ var _ = Describe("Operator Group", func() {
 				Name: role.GetName(),
 			},
 		}
-		_, err = c.CreateServiceAccount(serviceAccount)
+		_, err = c.CreateServiceAccount(context.TODO(), serviceAccount)
 		require.NoError(GinkgoT(), err)
 		defer func() {
-			c.DeleteServiceAccount(serviceAccount.GetNamespace(), serviceAccount.GetName(), metav1.NewDeleteOptions(0))
+			c.DeleteServiceAccount(context.TODO(), serviceAccount.GetNamespace(), serviceAccount.GetName(), metav1.NewDeleteOptions(0))
 		}()
-		createdRole, err := c.CreateRole(role)
+		createdRole, err := c.CreateRole(context.TODO(), role)
 		require.NoError(GinkgoT(), err)
 		defer func() {
-			c.DeleteRole(role.GetNamespace(), role.GetName(), metav1.NewDeleteOptions(0))
+			c.DeleteRole(context.TODO(), role.GetNamespace(), role.GetName(), metav1.NewDeleteOptions(0))
 		}()
-		createdRoleBinding, err := c.CreateRoleBinding(roleBinding)
+		createdRoleBinding, err := c.CreateRoleBinding(context.TODO(), roleBinding)
 		require.NoError(GinkgoT(), err)
 		defer func() {
-			c.DeleteRoleBinding(roleBinding.GetNamespace(), roleBinding.GetName(), metav1.NewDeleteOptions(0))
+			c.DeleteRoleBinding(context.TODO(), roleBinding.GetNamespace(), roleBinding.GetName(), metav1.NewDeleteOptions(0))
 		}()
 		// Create a new NamedInstallStrategy
 		deploymentName := genName("operator-deployment")
@@ -1425,12 +1425,12 @@ var _ = Describe("Operator Group", func() {
 
 		err = ownerutil.AddOwnerLabels(createdRole, createdCSV)
 		require.NoError(GinkgoT(), err)
-		_, err = c.UpdateRole(createdRole)
+		_, err = c.UpdateRole(context.TODO(), createdRole)
 		require.NoError(GinkgoT(), err)
 
 		err = ownerutil.AddOwnerLabels(createdRoleBinding, createdCSV)
 		require.NoError(GinkgoT(), err)
-		_, err = c.UpdateRoleBinding(createdRoleBinding)
+		_, err = c.UpdateRoleBinding(context.TODO(), createdRoleBinding)
 		require.NoError(GinkgoT(), err)
 		GinkgoT().Log("wait for CSV to succeed")
 		_, err = fetchCSV(crc, createdCSV.GetName(), opGroupNamespace, csvSucceededChecker)
@@ -1438,7 +1438,7 @@ var _ = Describe("Operator Group", func() {
 		GinkgoT().Log("wait for roles to be promoted to clusterroles")
 		var fetchedRole *rbacv1.ClusterRole
 		err = wait.Poll(pollInterval, pollDuration, func() (bool, error) {
-			fetchedRole, err = c.GetClusterRole(role.GetName())
+			fetchedRole, err = c.GetClusterRole(context.TODO(), role.GetName())
 			if err != nil {
 				if k8serrors.IsNotFound(err) {
 					return false, nil
@@ -1454,7 +1454,7 @@ var _ = Describe("Operator Group", func() {
 		}), fetchedRole.Rules)
 		var fetchedRoleBinding *rbacv1.ClusterRoleBinding
 		err = wait.Poll(pollInterval, pollDuration, func() (bool, error) {
-			fetchedRoleBinding, err = c.GetClusterRoleBinding(roleBinding.GetName())
+			fetchedRoleBinding, err = c.GetClusterRoleBinding(context.TODO(), roleBinding.GetName())
 			if err != nil {
 				if k8serrors.IsNotFound(err) {
 					return false, nil
@@ -1625,7 +1625,7 @@ var _ = Describe("Operator Group", func() {
 		err = ownerutil.AddOwnerLabels(serviceAccount, createdCSV)
 		require.NoError(GinkgoT(), err)
 
-		_, err = c.CreateServiceAccount(serviceAccount)
+		_, err = c.CreateServiceAccount(context.TODO(), serviceAccount)
 		require.NoError(GinkgoT(), err)
 
 		log("wait for CSV to fail")
@@ -1678,9 +1678,9 @@ var _ = Describe("Operator Group", func() {
 		err = ownerutil.AddOwnerLabels(roleBinding, createdCSV)
 		require.NoError(GinkgoT(), err)
 
-		_, err = c.CreateRole(role)
+		_, err = c.CreateRole(context.TODO(), role)
 		require.NoError(GinkgoT(), err)
-		_, err = c.CreateRoleBinding(roleBinding)
+		_, err = c.CreateRoleBinding(context.TODO(), roleBinding)
 		require.NoError(GinkgoT(), err)
 
 		log("wait for CSV to succeeed")
@@ -1760,7 +1760,7 @@ var _ = Describe("Operator Group", func() {
 		err = ownerutil.AddOwnerLabels(serviceAccount, createdCSV)
 		require.NoError(GinkgoT(), err)
 
-		_, err = c.CreateServiceAccount(serviceAccount)
+		_, err = c.CreateServiceAccount(context.TODO(), serviceAccount)
 		require.NoError(GinkgoT(), err)
 
 		log("wait for CSV to fail")
@@ -1877,20 +1877,20 @@ var _ = Describe("Operator Group", func() {
 				Name: role.GetName(),
 			},
 		}
-		_, err = c.CreateServiceAccount(serviceAccount)
+		_, err = c.CreateServiceAccount(context.TODO(), serviceAccount)
 		require.NoError(GinkgoT(), err)
 		defer func() {
-			c.DeleteServiceAccount(serviceAccount.GetNamespace(), serviceAccount.GetName(), metav1.NewDeleteOptions(0))
+			c.DeleteServiceAccount(context.TODO(), serviceAccount.GetNamespace(), serviceAccount.GetName(), metav1.NewDeleteOptions(0))
 		}()
-		createdRole, err := c.CreateRole(role)
+		createdRole, err := c.CreateRole(context.TODO(), role)
 		require.NoError(GinkgoT(), err)
 		defer func() {
-			c.DeleteRole(role.GetNamespace(), role.GetName(), metav1.NewDeleteOptions(0))
+			c.DeleteRole(context.TODO(), role.GetNamespace(), role.GetName(), metav1.NewDeleteOptions(0))
 		}()
-		createdRoleBinding, err := c.CreateRoleBinding(roleBinding)
+		createdRoleBinding, err := c.CreateRoleBinding(context.TODO(), roleBinding)
 		require.NoError(GinkgoT(), err)
 		defer func() {
-			c.DeleteRoleBinding(roleBinding.GetNamespace(), roleBinding.GetName(), metav1.NewDeleteOptions(0))
+			c.DeleteRoleBinding(context.TODO(), roleBinding.GetNamespace(), roleBinding.GetName(), metav1.NewDeleteOptions(0))
 		}()
 		// Create a new NamedInstallStrategy
 		deploymentName := genName("operator-deployment")
@@ -1905,12 +1905,12 @@ var _ = Describe("Operator Group", func() {
 
 		err = ownerutil.AddOwnerLabels(createdRole, createdCSV)
 		require.NoError(GinkgoT(), err)
-		_, err = c.UpdateRole(createdRole)
+		_, err = c.UpdateRole(context.TODO(), createdRole)
 		require.NoError(GinkgoT(), err)
 
 		err = ownerutil.AddOwnerLabels(createdRoleBinding, createdCSV)
 		require.NoError(GinkgoT(), err)
-		_, err = c.UpdateRoleBinding(createdRoleBinding)
+		_, err = c.UpdateRoleBinding(context.TODO(), createdRoleBinding)
 		require.NoError(GinkgoT(), err)
 		GinkgoT().Log("wait for CSV to succeed")
 		_, err = fetchCSV(crc, createdCSV.GetName(), opGroupNamespace, csvSucceededChecker)
@@ -1918,7 +1918,7 @@ var _ = Describe("Operator Group", func() {
 		GinkgoT().Log("wait for roles to be promoted to clusterroles")
 		var fetchedRole *rbacv1.ClusterRole
 		err = wait.Poll(pollInterval, pollDuration, func() (bool, error) {
-			fetchedRole, err = c.GetClusterRole(role.GetName())
+			fetchedRole, err = c.GetClusterRole(context.TODO(), role.GetName())
 			if err != nil {
 				if k8serrors.IsNotFound(err) {
 					return false, nil
@@ -1934,7 +1934,7 @@ var _ = Describe("Operator Group", func() {
 		}), fetchedRole.Rules)
 		var fetchedRoleBinding *rbacv1.ClusterRoleBinding
 		err = wait.Poll(pollInterval, pollDuration, func() (bool, error) {
-			fetchedRoleBinding, err = c.GetClusterRoleBinding(roleBinding.GetName())
+			fetchedRoleBinding, err = c.GetClusterRoleBinding(context.TODO(), roleBinding.GetName())
 			if err != nil {
 				if k8serrors.IsNotFound(err) {
 					return false, nil
@@ -2381,7 +2381,7 @@ func newOperatorGroup(namespace, name string, annotations map[string]string, sel
 }
 
 func createProjectAdmin(t GinkgoTInterface, c operatorclient.ClientInterface, namespace string) (string, cleanupFunc) {
-	sa, err := c.CreateServiceAccount(&corev1.ServiceAccount{
+	sa, err := c.CreateServiceAccount(context.TODO(), &corev1.ServiceAccount{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: namespace,
 			Name:      genName("padmin-"),
@@ -2389,7 +2389,7 @@ func createProjectAdmin(t GinkgoTInterface, c operatorclient.ClientInterface, na
 	})
 	require.NoError(t, err)
 
-	rb, err := c.CreateRoleBinding(&rbacv1.RoleBinding{
+	rb, err := c.CreateRoleBinding(context.TODO(), &rbacv1.RoleBinding{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      genName("padmin-"),
 			Namespace: namespace,
@@ -2410,8 +2410,8 @@ func createProjectAdmin(t GinkgoTInterface, c operatorclient.ClientInterface, na
 	require.NoError(t, err)
 
 	return "system:serviceaccount:" + namespace + ":" + sa.GetName(), func() {
-		_ = c.DeleteServiceAccount(sa.GetNamespace(), sa.GetName(), metav1.NewDeleteOptions(0))
-		_ = c.DeleteRoleBinding(rb.GetNamespace(), rb.GetName(), metav1.NewDeleteOptions(0))
+		_ = c.DeleteServiceAccount(context.TODO(), sa.GetNamespace(), sa.GetName(), metav1.NewDeleteOptions(0))
+		_ = c.DeleteRoleBinding(context.TODO(), rb.GetNamespace(), rb.GetName(), metav1.NewDeleteOptions(0))
 	}
 }
 
@@ -51,6 +51,11 @@ var (
 		"redis",
 		"dummy image to treat as an operator in tests")
 
+	mockExtensionAPIServerImage = flag.String(
+		"mockExtensionAPIServerImage",
+		"quay.io/operator-framework/olm:local",
+		"image providing /bin/mock-extension-apiserver, serving the APIs of the APIServices of tests")
+
 	testNamespace           = ""
 	operatorNamespace       = ""
 	communityOperatorsImage = ""
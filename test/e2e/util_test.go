@@ -809,7 +809,7 @@ func serializeV1CRD(t GinkgoTInterface, crd *apiextensionsv1.CustomResourceDefin
 }
 
 func createCR(c operatorclient.ClientInterface, item *unstructured.Unstructured, apiGroup, version, namespace, resourceKind, resourceName string) (cleanupFunc, error) {
-	err := c.CreateCustomResource(item)
+	err := c.CreateCustomResource(context.Background(), item)
 	if err != nil {
 		return nil, err
 	}
@@ -818,13 +818,13 @@ func createCR(c operatorclient.ClientInterface, item *unstructured.Unstructured,
 
 func buildCRCleanupFunc(c operatorclient.ClientInterface, apiGroup, version, namespace, resourceKind, resourceName string) cleanupFunc {
 	return func() {
-		err := c.DeleteCustomResource(apiGroup, version, namespace, resourceKind, resourceName)
+		err := c.DeleteCustomResource(context.Background(), apiGroup, version, namespace, resourceKind, resourceName)
 		if err != nil {
 			fmt.Println(err)
 		}
 
 		waitForDelete(func() error {
-			_, err := c.GetCustomResource(apiGroup, version, namespace, resourceKind, resourceName)
+			_, err := c.GetCustomResource(context.Background(), apiGroup, version, namespace, resourceKind, resourceName)
 			return err
 		})
 	}
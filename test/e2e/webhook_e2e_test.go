@@ -477,7 +477,7 @@ var _ = Describe("CSVs with a Webhook", func() {
 
 			_, err = fetchCSV(crc, csv.Name, namespace.Name, func(csv *operatorsv1alpha1.ClusterServiceVersion) bool {
 				// Should create deployment
-				dep, err = c.GetDeployment(namespace.Name, csv.Spec.WebhookDefinitions[0].DeploymentName)
+				dep, err = c.GetDeployment(context.TODO(), namespace.Name, csv.Spec.WebhookDefinitions[0].DeploymentName)
 				if err != nil {
 					return false
 				}
@@ -709,7 +709,7 @@ var _ = Describe("CSVs with a Webhook", func() {
 			}
 			expectedErrorMessage := "admission webhook \"vwebhooktest.kb.io\" denied the request: WebhookTest.test.operators.coreos.com \"my-cr-1\" is invalid: spec.schedule: Invalid value: false: Spec.Valid must be true"
 			Eventually(func() bool {
-				err := c.CreateCustomResource(invalidCR)
+				err := c.CreateCustomResource(context.TODO(), invalidCR)
 				if err == nil || expectedErrorMessage != err.Error() {
 					return false
 				}
@@ -735,7 +735,7 @@ var _ = Describe("CSVs with a Webhook", func() {
 			require.NoError(GinkgoT(), err, "The valid CR should have been approved by the validating webhook")
 
 			// Check that you can get v1 of the webhooktest cr
-			v1UnstructuredObject, err := c.GetCustomResource("webhook.operators.coreos.io", "v1", testNamespace, "webhooktests", "my-cr-1")
+			v1UnstructuredObject, err := c.GetCustomResource(context.TODO(), "webhook.operators.coreos.io", "v1", testNamespace, "webhooktests", "my-cr-1")
 			require.NoError(GinkgoT(), err, "Unable to get the v1 of the valid CR")
 			v1Object := v1UnstructuredObject.Object
 			v1Spec, ok := v1Object["spec"].(map[string]interface{})
@@ -749,7 +749,7 @@ var _ = Describe("CSVs with a Webhook", func() {
 			require.True(GinkgoT(), v1SpecValid, "The validating webhook should have required that the CR's spec.valid field is true")
 
 			// Check that you can get v2 of the webhooktest cr
-			v2UnstructuredObject, err := c.GetCustomResource("webhook.operators.coreos.io", "v2", testNamespace, "webhooktests", "my-cr-1")
+			v2UnstructuredObject, err := c.GetCustomResource(context.TODO(), "webhook.operators.coreos.io", "v2", testNamespace, "webhooktests", "my-cr-1")
 			require.NoError(GinkgoT(), err, "Unable to get the v2 of the valid CR")
 			v2Object := v2UnstructuredObject.Object
 			v2Spec := v2Object["spec"].(map[string]interface{})
@@ -0,0 +1,162 @@
+package fixture
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/operator-framework/operator-registry/pkg/lib/bundle"
+)
+
+// Bundle is an operator bundle in the registry+v1 format: a CSV and the other objects it installs, such as the CRDs
+// it owns, along with the package and channels the bundle belongs to.
+type Bundle struct {
+	Package        string
+	Channels       []string
+	DefaultChannel string
+
+	CSV *v1alpha1.ClusterServiceVersion
+	// Objects are the manifests of the bundle other than its CSV. Their kind must be set.
+	Objects []runtime.Object
+
+	// Properties are added to the properties derived from the CSV when the bundle is added to a catalog.
+	Properties []Property
+}
+
+// Name returns the name of the bundle, which is the name of its CSV.
+func (b *Bundle) Name() string {
+	return b.CSV.GetName()
+}
+
+// Annotations returns the annotations of the bundle, which are also the labels of its image.
+func (b *Bundle) Annotations() map[string]string {
+	defaultChannel := b.DefaultChannel
+	if defaultChannel == "" && len(b.Channels) > 0 {
+		defaultChannel = b.Channels[0]
+	}
+	return map[string]string{
+		bundle.MediatypeLabel:      bundle.RegistryV1Type,
+		bundle.ManifestsLabel:      bundle.ManifestsDir,
+		bundle.MetadataLabel:       bundle.MetadataDir,
+		bundle.PackageLabel:        b.Package,
+		bundle.ChannelsLabel:       strings.Join(b.Channels, ","),
+		bundle.ChannelDefaultLabel: defaultChannel,
+	}
+}
+
+// Files returns the files of the bundle, indexed by their path: its manifests and its annotations.
+func (b *Bundle) Files() (map[string][]byte, error) {
+	objs, err := b.objects()
+	if err != nil {
+		return nil, err
+	}
+
+	files := map[string][]byte{}
+	for _, obj := range objs {
+		m, err := meta.Accessor(obj)
+		if err != nil {
+			return nil, err
+		}
+		data, err := yaml.Marshal(obj)
+		if err != nil {
+			return nil, err
+		}
+		kind := strings.ToLower(obj.GetObjectKind().GroupVersionKind().Kind)
+		files[fmt.Sprintf("%s%s.%s.yaml", bundle.ManifestsDir, m.GetName(), kind)] = data
+	}
+
+	annotations, err := yaml.Marshal(bundle.AnnotationMetadata{Annotations: b.Annotations()})
+	if err != nil {
+		return nil, err
+	}
+	files[bundle.MetadataDir+bundle.AnnotationsFile] = annotations
+	return files, nil
+}
+
+// Image returns the image of the bundle.
+func (b *Bundle) Image() (*Image, error) {
+	files, err := b.Files()
+	if err != nil {
+		return nil, err
+	}
+	return NewImage(files, b.Annotations())
+}
+
+// Declaration returns the declaration of the bundle in a file-based catalog, for the bundle pushed as the given image.
+func (b *Bundle) Declaration(image string) (DeclaredBundle, error) {
+	objs, err := b.objects()
+	if err != nil {
+		return DeclaredBundle{}, err
+	}
+
+	csv := b.CSV
+	properties := []Property{
+		PackageProperty(b.Package, csv.Spec.Version.String()),
+	}
+	for _, crd := range csv.Spec.CustomResourceDefinitions.Owned {
+		properties = append(properties, GVKProperty(crdGroup(crd.Name), crd.Version, crd.Kind))
+	}
+	for _, crd := range csv.Spec.CustomResourceDefinitions.Required {
+		properties = append(properties, GVKRequiredProperty(crdGroup(crd.Name), crd.Version, crd.Kind))
+	}
+	for _, api := range csv.Spec.APIServiceDefinitions.Owned {
+		properties = append(properties, GVKProperty(api.Group, api.Version, api.Kind))
+	}
+	for _, api := range csv.Spec.APIServiceDefinitions.Required {
+		properties = append(properties, GVKRequiredProperty(api.Group, api.Version, api.Kind))
+	}
+	properties = append(properties, b.Properties...)
+	for _, obj := range objs {
+		// The objects of bundles are served by catalogs from their properties
+		data, err := json.Marshal(obj)
+		if err != nil {
+			return DeclaredBundle{}, err
+		}
+		properties = append(properties, BundleObjectProperty(data))
+	}
+
+	var relatedImages []RelatedImage
+	for _, related := range csv.Spec.RelatedImages {
+		relatedImages = append(relatedImages, RelatedImage{Name: related.Name, Image: related.Image})
+	}
+
+	return DeclaredBundle{
+		Schema:        SchemaBundle,
+		Name:          b.Name(),
+		Package:       b.Package,
+		Image:         image,
+		Properties:    properties,
+		RelatedImages: relatedImages,
+	}, nil
+}
+
+// objects returns the manifests of the bundle with their type set, starting with its CSV.
+func (b *Bundle) objects() ([]runtime.Object, error) {
+	if b.CSV == nil {
+		return nil, fmt.Errorf("bundle of package %s has no CSV", b.Package)
+	}
+	csv := b.CSV.DeepCopy()
+	csv.SetGroupVersionKind(v1alpha1.SchemeGroupVersion.WithKind(v1alpha1.ClusterServiceVersionKind))
+
+	objs := []runtime.Object{csv}
+	for _, obj := range b.Objects {
+		if obj.GetObjectKind().GroupVersionKind().Kind == "" {
+			return nil, fmt.Errorf("object %T of bundle %s has no kind", obj, b.Name())
+		}
+		objs = append(objs, obj)
+	}
+	return objs, nil
+}
+
+// crdGroup returns the group of the CRD with the given name.
+func crdGroup(name string) string {
+	if i := strings.Index(name, "."); i >= 0 {
+		return name[i+1:]
+	}
+	return ""
+}
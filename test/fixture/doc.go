@@ -0,0 +1,9 @@
+// Package fixture builds the catalogs and bundle images used by tests
+// programmatically, so that tests don't depend on pre-built images hosted on
+// public registries and can run in disconnected environments.
+//
+// Bundles are built from a CSV and the other objects they install, and
+// catalogs are built as file-based catalogs from those bundles. Both can be
+// turned into container images held in memory, which can then be pushed to a
+// local registry, or to an in-memory registry served by the test itself.
+package fixture
@@ -0,0 +1,282 @@
+package fixture
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+)
+
+// The schemas of the blobs of file-based catalogs.
+const (
+	SchemaPackage = "olm.package"
+	SchemaChannel = "olm.channel"
+	SchemaBundle  = "olm.bundle"
+)
+
+// The types of the properties of bundles.
+const (
+	PropertyPackage         = "olm.package"
+	PropertyGVK             = "olm.gvk"
+	PropertyPackageRequired = "olm.package.required"
+	PropertyGVKRequired     = "olm.gvk.required"
+	PropertyBundleObject    = "olm.bundle.object"
+)
+
+// ConfigsLabel is the label of catalog images naming the directory holding their file-based catalog, which is served
+// from ConfigsDir in the images built by this package.
+const (
+	ConfigsLabel = "operators.operatorframework.io.index.configs.v1"
+	ConfigsDir   = "/configs"
+)
+
+// DeclaredPackage is the declaration of a package in a file-based catalog.
+type DeclaredPackage struct {
+	Schema         string `json:"schema"`
+	Name           string `json:"name"`
+	DefaultChannel string `json:"defaultChannel"`
+	Description    string `json:"description,omitempty"`
+}
+
+// DeclaredChannel is the declaration of a channel of a package in a file-based catalog.
+type DeclaredChannel struct {
+	Schema  string         `json:"schema"`
+	Name    string         `json:"name"`
+	Package string         `json:"package"`
+	Entries []ChannelEntry `json:"entries"`
+}
+
+// ChannelEntry is a bundle of a channel, and the bundles it upgrades from.
+type ChannelEntry struct {
+	Name      string   `json:"name"`
+	Replaces  string   `json:"replaces,omitempty"`
+	Skips     []string `json:"skips,omitempty"`
+	SkipRange string   `json:"skipRange,omitempty"`
+}
+
+// DeclaredBundle is the declaration of a bundle in a file-based catalog.
+type DeclaredBundle struct {
+	Schema        string         `json:"schema"`
+	Name          string         `json:"name"`
+	Package       string         `json:"package"`
+	Image         string         `json:"image"`
+	Properties    []Property     `json:"properties,omitempty"`
+	RelatedImages []RelatedImage `json:"relatedImages,omitempty"`
+}
+
+// RelatedImage is an image a bundle depends on.
+type RelatedImage struct {
+	Name  string `json:"name"`
+	Image string `json:"image"`
+}
+
+// Property is a property of a bundle.
+type Property struct {
+	Type  string          `json:"type"`
+	Value json.RawMessage `json:"value"`
+}
+
+func property(typ string, value interface{}) Property {
+	data, err := json.Marshal(value)
+	if err != nil {
+		// The values of the properties built by this package are always encodable
+		panic(err)
+	}
+	return Property{Type: typ, Value: data}
+}
+
+// PackageProperty returns the property of bundles of the given version of the given package.
+func PackageProperty(packageName, version string) Property {
+	return property(PropertyPackage, map[string]string{"packageName": packageName, "version": version})
+}
+
+// GVKProperty returns the property of bundles providing the given API.
+func GVKProperty(group, version, kind string) Property {
+	return property(PropertyGVK, map[string]string{"group": group, "version": version, "kind": kind})
+}
+
+// PackageRequiredProperty returns the property of bundles depending on the given range of versions of the given package.
+func PackageRequiredProperty(packageName, versionRange string) Property {
+	return property(PropertyPackageRequired, map[string]string{"packageName": packageName, "versionRange": versionRange})
+}
+
+// GVKRequiredProperty returns the property of bundles depending on the given API.
+func GVKRequiredProperty(group, version, kind string) Property {
+	return property(PropertyGVKRequired, map[string]string{"group": group, "version": version, "kind": kind})
+}
+
+// BundleObjectProperty returns the property of bundles holding the given object, encoded as JSON.
+func BundleObjectProperty(data []byte) Property {
+	// []byte values are encoded in base64
+	return property(PropertyBundleObject, map[string][]byte{"data": data})
+}
+
+// Catalog is a file-based catalog.
+type Catalog struct {
+	Packages []DeclaredPackage
+	Channels []DeclaredChannel
+	Bundles  []DeclaredBundle
+}
+
+// AddBundle adds the given bundle, pushed as the given image, to the catalog, along with its package if missing. The
+// bundle is added to each of its channels, upgrading from the bundles its CSV replaces or skips.
+func (c *Catalog) AddBundle(b *Bundle, image string) error {
+	declared, err := b.Declaration(image)
+	if err != nil {
+		return err
+	}
+	if len(b.Channels) == 0 {
+		return fmt.Errorf("bundle %s has no channel", b.Name())
+	}
+
+	pkg := c.findPackage(b.Package)
+	if pkg == nil {
+		defaultChannel := b.DefaultChannel
+		if defaultChannel == "" {
+			defaultChannel = b.Channels[0]
+		}
+		c.Packages = append(c.Packages, DeclaredPackage{Schema: SchemaPackage, Name: b.Package, DefaultChannel: defaultChannel})
+	} else if b.DefaultChannel != "" {
+		pkg.DefaultChannel = b.DefaultChannel
+	}
+
+	entry := ChannelEntry{
+		Name:      b.Name(),
+		Replaces:  b.CSV.Spec.Replaces,
+		Skips:     b.CSV.Spec.Skips,
+		SkipRange: b.CSV.GetAnnotations()["olm.skipRange"],
+	}
+	for _, name := range b.Channels {
+		ch := c.findChannel(b.Package, name)
+		if ch == nil {
+			c.Channels = append(c.Channels, DeclaredChannel{Schema: SchemaChannel, Name: name, Package: b.Package})
+			ch = &c.Channels[len(c.Channels)-1]
+		}
+		ch.Entries = append(ch.Entries, entry)
+	}
+
+	c.Bundles = append(c.Bundles, declared)
+	return nil
+}
+
+// Marshal returns the catalog as a stream of JSON blobs.
+func (c *Catalog) Marshal() ([]byte, error) {
+	var buf bytes.Buffer
+	for _, pkg := range c.Packages {
+		if err := c.marshalPackage(&buf, pkg.Name); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// Files returns the files of the catalog, indexed by their path: a catalog.json file in the directory of each package.
+func (c *Catalog) Files() (map[string][]byte, error) {
+	files := map[string][]byte{}
+	for _, pkg := range c.Packages {
+		var buf bytes.Buffer
+		if err := c.marshalPackage(&buf, pkg.Name); err != nil {
+			return nil, err
+		}
+		files[path.Join(pkg.Name, "catalog.json")] = buf.Bytes()
+	}
+	return files, nil
+}
+
+// WriteDir writes the files of the catalog to the given directory.
+func (c *Catalog) WriteDir(dir string) error {
+	files, err := c.Files()
+	if err != nil {
+		return err
+	}
+	for name, data := range files {
+		name = filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(name), 0755); err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(name, data, 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Image returns the image of the catalog, built on the given image, which must provide the opm binary at /bin/opm,
+// e.g. quay.io/operator-framework/opm or a mirror of it. The image serves the catalog from ConfigsDir.
+func (c *Catalog) Image(base *Image) (*Image, error) {
+	files, err := c.Files()
+	if err != nil {
+		return nil, err
+	}
+	configs := map[string][]byte{}
+	for name, data := range files {
+		configs[path.Join(ConfigsDir, name)] = data
+	}
+	img, err := base.WithFiles(configs, map[string]string{ConfigsLabel: ConfigsDir})
+	if err != nil {
+		return nil, err
+	}
+	return img.WithCommand([]string{"/bin/opm"}, "serve", ConfigsDir), nil
+}
+
+// marshalPackage writes the blobs of the given package to the given buffer: the package, then its channels and its
+// bundles, sorted by name.
+func (c *Catalog) marshalPackage(buf *bytes.Buffer, name string) error {
+	var blobs []interface{}
+	if pkg := c.findPackage(name); pkg != nil {
+		blobs = append(blobs, pkg)
+	}
+
+	var channels []DeclaredChannel
+	for _, ch := range c.Channels {
+		if ch.Package == name {
+			channels = append(channels, ch)
+		}
+	}
+	sort.Slice(channels, func(i, j int) bool { return channels[i].Name < channels[j].Name })
+	for i := range channels {
+		blobs = append(blobs, channels[i])
+	}
+
+	var bundles []DeclaredBundle
+	for _, b := range c.Bundles {
+		if b.Package == name {
+			bundles = append(bundles, b)
+		}
+	}
+	sort.Slice(bundles, func(i, j int) bool { return bundles[i].Name < bundles[j].Name })
+	for i := range bundles {
+		blobs = append(blobs, bundles[i])
+	}
+
+	enc := json.NewEncoder(buf)
+	enc.SetIndent("", "  ")
+	for _, blob := range blobs {
+		if err := enc.Encode(blob); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *Catalog) findPackage(name string) *DeclaredPackage {
+	for i := range c.Packages {
+		if c.Packages[i].Name == name {
+			return &c.Packages[i]
+		}
+	}
+	return nil
+}
+
+func (c *Catalog) findChannel(pkg, name string) *DeclaredChannel {
+	for i := range c.Channels {
+		if c.Channels[i].Package == pkg && c.Channels[i].Name == name {
+			return &c.Channels[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,175 @@
+package fixture
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/blang/semver/v4"
+	"github.com/ghodss/yaml"
+	"github.com/stretchr/testify/require"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/operator-framework/api/pkg/lib/version"
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/operator-framework/operator-registry/pkg/lib/bundle"
+)
+
+func testBundle(name, ver, replaces string) *Bundle {
+	csv := &v1alpha1.ClusterServiceVersion{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: v1alpha1.ClusterServiceVersionSpec{
+			Version:  version.OperatorVersion{Version: semver.MustParse(ver)},
+			Replaces: replaces,
+			CustomResourceDefinitions: v1alpha1.CustomResourceDefinitions{
+				Owned: []v1alpha1.CRDDescription{{Name: "tests.example.com", Version: "v1", Kind: "Test"}},
+			},
+			RelatedImages: []v1alpha1.RelatedImage{{Name: "operator", Image: "localhost:5000/operator:v1"}},
+		},
+	}
+	crd := &apiextensionsv1.CustomResourceDefinition{
+		TypeMeta:   metav1.TypeMeta{APIVersion: apiextensionsv1.SchemeGroupVersion.String(), Kind: "CustomResourceDefinition"},
+		ObjectMeta: metav1.ObjectMeta{Name: "tests.example.com"},
+	}
+	return &Bundle{
+		Package:  "test",
+		Channels: []string{"stable"},
+		CSV:      csv,
+		Objects:  []runtime.Object{crd},
+	}
+}
+
+func TestBundleImage(t *testing.T) {
+	b := testBundle("test.v1.0.0", "1.0.0", "")
+	img, err := b.Image()
+	require.NoError(t, err)
+
+	labels := img.Labels()
+	require.Equal(t, "test", labels[bundle.PackageLabel])
+	require.Equal(t, "stable", labels[bundle.ChannelsLabel])
+	require.Equal(t, "stable", labels[bundle.ChannelDefaultLabel])
+	require.Equal(t, bundle.RegistryV1Type, labels[bundle.MediatypeLabel])
+
+	files, err := img.Files()
+	require.NoError(t, err)
+	require.Len(t, files, 3)
+
+	var csv v1alpha1.ClusterServiceVersion
+	require.NoError(t, yaml.Unmarshal(files["manifests/test.v1.0.0.clusterserviceversion.yaml"], &csv))
+	require.Equal(t, v1alpha1.ClusterServiceVersionKind, csv.Kind)
+	require.Equal(t, "1.0.0", csv.Spec.Version.String())
+	require.Contains(t, files, "manifests/tests.example.com.customresourcedefinition.yaml")
+
+	var annotations bundle.AnnotationMetadata
+	require.NoError(t, yaml.Unmarshal(files["metadata/annotations.yaml"], &annotations))
+	require.Equal(t, b.Annotations(), annotations.Annotations)
+
+	// Images of the same bundle are identical
+	again, err := b.Image()
+	require.NoError(t, err)
+	digest, err := img.Digest()
+	require.NoError(t, err)
+	againDigest, err := again.Digest()
+	require.NoError(t, err)
+	require.Equal(t, digest, againDigest)
+}
+
+func TestCatalog(t *testing.T) {
+	c := &Catalog{}
+	require.NoError(t, c.AddBundle(testBundle("test.v1.0.0", "1.0.0", ""), "localhost:5000/bundles/test:v1.0.0"))
+	require.NoError(t, c.AddBundle(testBundle("test.v1.1.0", "1.1.0", "test.v1.0.0"), "localhost:5000/bundles/test:v1.1.0"))
+
+	data, err := c.Marshal()
+	require.NoError(t, err)
+
+	var blobs []map[string]interface{}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	for dec.More() {
+		var blob map[string]interface{}
+		require.NoError(t, dec.Decode(&blob))
+		blobs = append(blobs, blob)
+	}
+	require.Len(t, blobs, 4)
+	require.Equal(t, SchemaPackage, blobs[0]["schema"])
+	require.Equal(t, "stable", blobs[0]["defaultChannel"])
+	require.Equal(t, SchemaChannel, blobs[1]["schema"])
+	require.Equal(t, []interface{}{
+		map[string]interface{}{"name": "test.v1.0.0"},
+		map[string]interface{}{"name": "test.v1.1.0", "replaces": "test.v1.0.0"},
+	}, blobs[1]["entries"])
+	require.Equal(t, SchemaBundle, blobs[2]["schema"])
+	require.Equal(t, "localhost:5000/bundles/test:v1.0.0", blobs[2]["image"])
+
+	var declared DeclaredBundle
+	blob, err := json.Marshal(blobs[2])
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(blob, &declared))
+	require.Equal(t, []RelatedImage{{Name: "operator", Image: "localhost:5000/operator:v1"}}, declared.RelatedImages)
+	require.Equal(t, PackageProperty("test", "1.0.0"), declared.Properties[0])
+	require.Equal(t, GVKProperty("example.com", "v1", "Test"), declared.Properties[1])
+	require.Equal(t, PropertyBundleObject, declared.Properties[2].Type)
+
+	dir, err := ioutil.TempDir("", "catalog-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	require.NoError(t, c.WriteDir(dir))
+	written, err := ioutil.ReadFile(filepath.Join(dir, "test", "catalog.json"))
+	require.NoError(t, err)
+	require.Equal(t, data, written)
+
+	base, err := NewImage(map[string][]byte{"bin/opm": []byte("opm")}, map[string]string{"base": "true"})
+	require.NoError(t, err)
+	img, err := c.Image(base)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"base": "true", ConfigsLabel: ConfigsDir}, img.Labels())
+	files, err := img.Files()
+	require.NoError(t, err)
+	require.Equal(t, map[string][]byte{"bin/opm": []byte("opm"), "configs/test/catalog.json": data}, files)
+}
+
+func TestRegistry(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	registry, stop := ServeInMemoryRegistry()
+	defer stop()
+
+	img, err := testBundle("test.v1.0.0", "1.0.0", "").Image()
+	require.NoError(t, err)
+	ref, err := registry.Push(ctx, "bundles/test", "v1.0.0", img)
+	require.NoError(t, err)
+	digest, err := img.Digest()
+	require.NoError(t, err)
+	require.Equal(t, registry.Host+"/bundles/test@"+digest, ref)
+
+	for _, reference := range []string{"bundles/test:v1.0.0", "bundles/test@" + digest} {
+		pulled, err := registry.Pull(ctx, reference)
+		require.NoError(t, err)
+		pulledDigest, err := pulled.Digest()
+		require.NoError(t, err)
+		require.Equal(t, digest, pulledDigest)
+	}
+
+	// Pulled images can be extended and pushed again
+	pulled, err := registry.Pull(ctx, "bundles/test:v1.0.0")
+	require.NoError(t, err)
+	extended, err := pulled.WithFiles(map[string][]byte{"extra": []byte("extra")}, nil)
+	require.NoError(t, err)
+	_, err = registry.Push(ctx, "bundles/test", "extended", extended)
+	require.NoError(t, err)
+	pulled, err = registry.Pull(ctx, "bundles/test:extended")
+	require.NoError(t, err)
+	files, err := pulled.Files()
+	require.NoError(t, err)
+	require.Len(t, files, 4)
+	require.Equal(t, []byte("extra"), files["extra"])
+
+	_, err = registry.Pull(ctx, "bundles/missing:v1.0.0")
+	require.Error(t, err)
+}
@@ -0,0 +1,315 @@
+package fixture
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path"
+	"sort"
+	"strings"
+)
+
+// The media types of the images built by this package.
+const (
+	MediaTypeImageManifest = "application/vnd.oci.image.manifest.v1+json"
+	MediaTypeImageIndex    = "application/vnd.oci.image.index.v1+json"
+	MediaTypeImageConfig   = "application/vnd.oci.image.config.v1+json"
+	MediaTypeImageLayer    = "application/vnd.oci.image.layer.v1.tar+gzip"
+)
+
+// The media types of the Docker images that can be pulled and extended.
+const (
+	MediaTypeDockerManifest     = "application/vnd.docker.distribution.manifest.v2+json"
+	MediaTypeDockerManifestList = "application/vnd.docker.distribution.manifest.list.v2+json"
+	MediaTypeDockerConfig       = "application/vnd.docker.container.image.v1+json"
+	MediaTypeDockerLayer        = "application/vnd.docker.image.rootfs.diff.tar.gzip"
+)
+
+// Descriptor describes a blob of an image.
+type Descriptor struct {
+	MediaType string    `json:"mediaType"`
+	Digest    string    `json:"digest"`
+	Size      int64     `json:"size"`
+	Platform  *Platform `json:"platform,omitempty"`
+}
+
+// Platform is the platform an image of an index runs on.
+type Platform struct {
+	Architecture string `json:"architecture"`
+	OS           string `json:"os"`
+}
+
+type manifest struct {
+	SchemaVersion int          `json:"schemaVersion"`
+	MediaType     string       `json:"mediaType,omitempty"`
+	Config        Descriptor   `json:"config"`
+	Layers        []Descriptor `json:"layers"`
+	Manifests     []Descriptor `json:"manifests,omitempty"`
+}
+
+// Layer is a compressed layer of an image.
+type Layer struct {
+	MediaType string
+	Data      []byte
+}
+
+// Image is a container image held in memory. Images are immutable: the methods adding content to an image return a
+// new image.
+type Image struct {
+	manifestMediaType string
+	configMediaType   string
+	// config is the raw image configuration, so that the configuration of pulled images is preserved
+	config map[string]interface{}
+	layers []Layer
+}
+
+// NewImage returns an image made of a single layer holding the given files, indexed by their path, with the given
+// labels.
+func NewImage(files map[string][]byte, labels map[string]string) (*Image, error) {
+	scratch := &Image{
+		manifestMediaType: MediaTypeImageManifest,
+		configMediaType:   MediaTypeImageConfig,
+		config: map[string]interface{}{
+			"architecture": "amd64",
+			"os":           "linux",
+			"config":       map[string]interface{}{},
+			"rootfs": map[string]interface{}{
+				"type":     "layers",
+				"diff_ids": []interface{}{},
+			},
+		},
+	}
+	return scratch.WithFiles(files, labels)
+}
+
+// WithFiles returns a copy of the image with an additional layer holding the given files, indexed by their path, and
+// the given labels added to its labels.
+func (i *Image) WithFiles(files map[string][]byte, labels map[string]string) (*Image, error) {
+	tarball, err := tarFiles(files)
+	if err != nil {
+		return nil, err
+	}
+	var compressed bytes.Buffer
+	gz := gzip.NewWriter(&compressed)
+	if _, err := gz.Write(tarball); err != nil {
+		return nil, err
+	}
+	if err := gz.Close(); err != nil {
+		return nil, err
+	}
+
+	mediaType := MediaTypeImageLayer
+	if i.manifestMediaType == MediaTypeDockerManifest {
+		mediaType = MediaTypeDockerLayer
+	}
+
+	out := i.copy()
+	out.layers = append(out.layers, Layer{MediaType: mediaType, Data: compressed.Bytes()})
+	rootfs, _ := out.config["rootfs"].(map[string]interface{})
+	if rootfs == nil {
+		return nil, fmt.Errorf("image configuration has no rootfs")
+	}
+	diffIDs, _ := rootfs["diff_ids"].([]interface{})
+	rootfs["diff_ids"] = append(diffIDs, digestOf(tarball))
+	if history, ok := out.config["history"].([]interface{}); ok {
+		// Runtimes expect an history entry per layer when images have a history
+		out.config["history"] = append(history, map[string]interface{}{"created_by": "fixture"})
+	}
+
+	cfg := out.containerConfig()
+	imageLabels, _ := cfg["Labels"].(map[string]interface{})
+	if imageLabels == nil {
+		imageLabels = map[string]interface{}{}
+	}
+	for k, v := range labels {
+		imageLabels[k] = v
+	}
+	cfg["Labels"] = imageLabels
+	return out, nil
+}
+
+// WithCommand returns a copy of the image running the given entrypoint with the given arguments.
+func (i *Image) WithCommand(entrypoint []string, args ...string) *Image {
+	out := i.copy()
+	cfg := out.containerConfig()
+	cfg["Entrypoint"] = stringsToInterfaces(entrypoint)
+	cfg["Cmd"] = stringsToInterfaces(args)
+	return out
+}
+
+// Labels returns the labels of the image.
+func (i *Image) Labels() map[string]string {
+	labels := map[string]string{}
+	cfg, _ := i.config["config"].(map[string]interface{})
+	imageLabels, _ := cfg["Labels"].(map[string]interface{})
+	for k, v := range imageLabels {
+		if s, ok := v.(string); ok {
+			labels[k] = s
+		}
+	}
+	return labels
+}
+
+// Layers returns the layers of the image, from the lowest to the topmost.
+func (i *Image) Layers() []Layer {
+	return append([]Layer(nil), i.layers...)
+}
+
+// Files returns the regular files of the image, indexed by their path, as they'd be seen by a container running it.
+func (i *Image) Files() (map[string][]byte, error) {
+	files := map[string][]byte{}
+	for _, layer := range i.layers {
+		var r io.Reader = bytes.NewReader(layer.Data)
+		if strings.HasSuffix(layer.MediaType, "gzip") {
+			gz, err := gzip.NewReader(r)
+			if err != nil {
+				return nil, err
+			}
+			r = gz
+		}
+		tr := tar.NewReader(r)
+		for {
+			hdr, err := tr.Next()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return nil, err
+			}
+			name := path.Clean(strings.TrimPrefix(hdr.Name, "./"))
+			dir, base := path.Split(name)
+			if strings.HasPrefix(base, ".wh.") {
+				// Whiteouts remove the files of the lower layers
+				removed := path.Join(dir, strings.TrimPrefix(base, ".wh."))
+				for f := range files {
+					if f == removed || strings.HasPrefix(f, removed+"/") {
+						delete(files, f)
+					}
+				}
+				continue
+			}
+			if hdr.Typeflag != tar.TypeReg {
+				continue
+			}
+			data, err := ioutil.ReadAll(tr)
+			if err != nil {
+				return nil, err
+			}
+			files[name] = data
+		}
+	}
+	return files, nil
+}
+
+// Digest returns the digest of the manifest of the image, which identifies the image once pushed.
+func (i *Image) Digest() (string, error) {
+	m, _, err := i.marshal()
+	if err != nil {
+		return "", err
+	}
+	return digestOf(m), nil
+}
+
+// marshal returns the manifest and configuration of the image.
+func (i *Image) marshal() (manifestData, configData []byte, err error) {
+	configData, err = json.Marshal(i.config)
+	if err != nil {
+		return nil, nil, err
+	}
+	m := manifest{
+		SchemaVersion: 2,
+		MediaType:     i.manifestMediaType,
+		Config:        Descriptor{MediaType: i.configMediaType, Digest: digestOf(configData), Size: int64(len(configData))},
+		Layers:        []Descriptor{},
+	}
+	for _, layer := range i.layers {
+		m.Layers = append(m.Layers, Descriptor{MediaType: layer.MediaType, Digest: digestOf(layer.Data), Size: int64(len(layer.Data))})
+	}
+	manifestData, err = json.Marshal(m)
+	if err != nil {
+		return nil, nil, err
+	}
+	return manifestData, configData, nil
+}
+
+func (i *Image) copy() *Image {
+	// Round-trip the configuration to deep copy it
+	data, _ := json.Marshal(i.config)
+	var config map[string]interface{}
+	_ = json.Unmarshal(data, &config)
+	return &Image{
+		manifestMediaType: i.manifestMediaType,
+		configMediaType:   i.configMediaType,
+		config:            config,
+		layers:            append([]Layer(nil), i.layers...),
+	}
+}
+
+// containerConfig returns the configuration of the containers running the image, which holds its labels and command.
+func (i *Image) containerConfig() map[string]interface{} {
+	cfg, _ := i.config["config"].(map[string]interface{})
+	if cfg == nil {
+		cfg = map[string]interface{}{}
+		i.config["config"] = cfg
+	}
+	return cfg
+}
+
+// tarFiles returns a tarball of the given files, along with their parent directories. The tarball only depends on the
+// given files, so that images built from the same files have the same digest.
+func tarFiles(files map[string][]byte) ([]byte, error) {
+	cleaned := make(map[string][]byte, len(files))
+	names := make([]string, 0, len(files))
+	dirs := map[string]struct{}{}
+	for name, data := range files {
+		name = path.Clean(strings.TrimPrefix(name, "/"))
+		cleaned[name] = data
+		names = append(names, name)
+		for dir := path.Dir(name); dir != "." && dir != "/"; dir = path.Dir(dir) {
+			dirs[dir] = struct{}{}
+		}
+	}
+	for dir := range dirs {
+		names = append(names, dir+"/")
+	}
+	sort.Strings(names)
+
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for _, name := range names {
+		if strings.HasSuffix(name, "/") {
+			if err := tw.WriteHeader(&tar.Header{Typeflag: tar.TypeDir, Name: name, Mode: 0755}); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		data := cleaned[name]
+		if err := tw.WriteHeader(&tar.Header{Typeflag: tar.TypeReg, Name: name, Mode: 0644, Size: int64(len(data))}); err != nil {
+			return nil, err
+		}
+		if _, err := tw.Write(data); err != nil {
+			return nil, err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func digestOf(data []byte) string {
+	return fmt.Sprintf("sha256:%x", sha256.Sum256(data))
+}
+
+func stringsToInterfaces(in []string) []interface{} {
+	out := make([]interface{}, 0, len(in))
+	for _, s := range in {
+		out = append(out, s)
+	}
+	return out
+}
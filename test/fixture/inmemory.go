@@ -0,0 +1,168 @@
+package fixture
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// InMemoryRegistry is a minimal registry implementing the parts of the distribution API used to push and pull images,
+// holding the images pushed to it in memory. It allows tests to exercise code pulling images without a local registry.
+type InMemoryRegistry struct {
+	lock    sync.Mutex
+	blobs   map[string][]byte
+	uploads map[string]struct{}
+	nextID  int
+	// manifests holds the manifests of each repository, indexed by tag and digest
+	manifests map[string]map[string]storedManifest
+}
+
+type storedManifest struct {
+	mediaType string
+	data      []byte
+}
+
+var _ http.Handler = &InMemoryRegistry{}
+
+// NewInMemoryRegistry returns an empty in-memory registry.
+func NewInMemoryRegistry() *InMemoryRegistry {
+	return &InMemoryRegistry{
+		blobs:     map[string][]byte{},
+		uploads:   map[string]struct{}{},
+		manifests: map[string]map[string]storedManifest{},
+	}
+}
+
+// ServeInMemoryRegistry serves an empty in-memory registry over HTTP and returns a client of it. The registry is
+// stopped by calling the returned function.
+func ServeInMemoryRegistry() (*Registry, func()) {
+	server := httptest.NewServer(NewInMemoryRegistry())
+	return &Registry{
+		Host:      strings.TrimPrefix(server.URL, "http://"),
+		PlainHTTP: true,
+		Client:    server.Client(),
+	}, server.Close
+}
+
+func (m *InMemoryRegistry) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	p := r.URL.Path
+	switch {
+	case p == "/v2/" || p == "/v2":
+		w.WriteHeader(http.StatusOK)
+	case strings.Contains(p, "/blobs/uploads/"):
+		i := strings.Index(p, "/blobs/uploads/")
+		m.serveUpload(w, r, strings.TrimPrefix(p[:i], "/v2/"), p[i+len("/blobs/uploads/"):])
+	case strings.Contains(p, "/blobs/"):
+		i := strings.LastIndex(p, "/blobs/")
+		m.serveBlob(w, r, p[i+len("/blobs/"):])
+	case strings.Contains(p, "/manifests/"):
+		i := strings.LastIndex(p, "/manifests/")
+		m.serveManifest(w, r, strings.TrimPrefix(p[:i], "/v2/"), p[i+len("/manifests/"):])
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (m *InMemoryRegistry) serveUpload(w http.ResponseWriter, r *http.Request, repository, id string) {
+	switch r.Method {
+	case http.MethodPost:
+		m.nextID++
+		id := strconv.Itoa(m.nextID)
+		m.uploads[id] = struct{}{}
+		w.Header().Set("Location", fmt.Sprintf("/v2/%s/blobs/uploads/%s", repository, id))
+		w.WriteHeader(http.StatusAccepted)
+	case http.MethodPut:
+		if _, ok := m.uploads[id]; !ok {
+			http.Error(w, "unknown upload", http.StatusNotFound)
+			return
+		}
+		data, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		digest := r.URL.Query().Get("digest")
+		if digestOf(data) != digest {
+			http.Error(w, "digest mismatch", http.StatusBadRequest)
+			return
+		}
+		delete(m.uploads, id)
+		m.blobs[digest] = data
+		w.WriteHeader(http.StatusCreated)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (m *InMemoryRegistry) serveBlob(w http.ResponseWriter, r *http.Request, digest string) {
+	data, ok := m.blobs[digest]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	switch r.Method {
+	case http.MethodHead:
+		w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+		w.WriteHeader(http.StatusOK)
+	case http.MethodGet:
+		w.Header().Set("Content-Type", "application/octet-stream")
+		_, _ = w.Write(data)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (m *InMemoryRegistry) serveManifest(w http.ResponseWriter, r *http.Request, repository, ref string) {
+	switch r.Method {
+	case http.MethodPut:
+		data, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		var manifest manifest
+		if err := json.Unmarshal(data, &manifest); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		for _, desc := range append([]Descriptor{manifest.Config}, manifest.Layers...) {
+			if _, ok := m.blobs[desc.Digest]; !ok && desc.Digest != "" {
+				http.Error(w, fmt.Sprintf("unknown blob %s", desc.Digest), http.StatusBadRequest)
+				return
+			}
+		}
+		if m.manifests[repository] == nil {
+			m.manifests[repository] = map[string]storedManifest{}
+		}
+		stored := storedManifest{mediaType: r.Header.Get("Content-Type"), data: data}
+		digest := digestOf(data)
+		m.manifests[repository][ref] = stored
+		m.manifests[repository][digest] = stored
+		w.Header().Set("Docker-Content-Digest", digest)
+		w.WriteHeader(http.StatusCreated)
+	case http.MethodGet, http.MethodHead:
+		stored, ok := m.manifests[repository][ref]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", stored.mediaType)
+		w.Header().Set("Docker-Content-Digest", digestOf(stored.data))
+		if r.Method == http.MethodHead {
+			w.Header().Set("Content-Length", strconv.Itoa(len(stored.data)))
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		_, _ = w.Write(stored.data)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
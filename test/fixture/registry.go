@@ -0,0 +1,346 @@
+package fixture
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// Registry is a client of a registry implementing the distribution API, such as a local registry:2 instance, used to
+// push the images built by this package and pull the images they're built on.
+type Registry struct {
+	// Host is the host, and optionally the port, of the registry, e.g. localhost:5000.
+	Host string
+	// PlainHTTP reaches the registry over HTTP rather than HTTPS, as local registries usually require.
+	PlainHTTP bool
+	// Username and Password authenticate to the registry, if set.
+	Username, Password string
+	// Client is the HTTP client of the registry. http.DefaultClient is used if nil.
+	Client *http.Client
+
+	tokensLock sync.Mutex
+	tokens     map[string]string
+}
+
+// Push pushes the given image to the given repository of the registry with the given tag, and returns the reference
+// of the pushed image by digest, e.g. localhost:5000/catalogs/test@sha256:...
+func (r *Registry) Push(ctx context.Context, repository, tag string, img *Image) (string, error) {
+	manifestData, configData, err := img.marshal()
+	if err != nil {
+		return "", err
+	}
+
+	blobs := [][]byte{configData}
+	for _, layer := range img.layers {
+		blobs = append(blobs, layer.Data)
+	}
+	for _, blob := range blobs {
+		if err := r.pushBlob(ctx, repository, blob); err != nil {
+			return "", err
+		}
+	}
+
+	resp, err := r.do(ctx, repository, http.MethodPut, r.url("/v2/%s/manifests/%s", repository, tag), manifestData, map[string]string{"Content-Type": img.manifestMediaType})
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		return "", unexpectedResponse(resp, "pushing manifest of %s:%s", repository, tag)
+	}
+	return fmt.Sprintf("%s/%s@%s", r.Host, repository, digestOf(manifestData)), nil
+}
+
+// Pull pulls the image with the given reference from the registry. The reference is relative to the registry, i.e.
+// <repository>:<tag> or <repository>@<digest>. For multi-platform images, the linux image of the current
+// architecture is pulled.
+func (r *Registry) Pull(ctx context.Context, reference string) (*Image, error) {
+	repository, ref := splitReference(reference)
+	data, mediaType, err := r.getManifest(ctx, repository, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	var m manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("decoding manifest of %s: %v", reference, err)
+	}
+	if mediaType == MediaTypeImageIndex || mediaType == MediaTypeDockerManifestList {
+		platformDigest := ""
+		for _, desc := range m.Manifests {
+			if desc.Platform != nil && desc.Platform.OS == "linux" && desc.Platform.Architecture == runtime.GOARCH {
+				platformDigest = desc.Digest
+				break
+			}
+		}
+		if platformDigest == "" {
+			return nil, fmt.Errorf("%s has no linux/%s image", reference, runtime.GOARCH)
+		}
+		if data, mediaType, err = r.getManifest(ctx, repository, platformDigest); err != nil {
+			return nil, err
+		}
+		m = manifest{}
+		if err := json.Unmarshal(data, &m); err != nil {
+			return nil, fmt.Errorf("decoding manifest of %s: %v", reference, err)
+		}
+	}
+	if mediaType != MediaTypeImageManifest && mediaType != MediaTypeDockerManifest {
+		return nil, fmt.Errorf("%s has unsupported manifest media type %q", reference, mediaType)
+	}
+
+	img := &Image{
+		manifestMediaType: mediaType,
+		configMediaType:   m.Config.MediaType,
+	}
+	configData, err := r.getBlob(ctx, repository, m.Config.Digest)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(configData, &img.config); err != nil {
+		return nil, fmt.Errorf("decoding configuration of %s: %v", reference, err)
+	}
+	for _, desc := range m.Layers {
+		data, err := r.getBlob(ctx, repository, desc.Digest)
+		if err != nil {
+			return nil, err
+		}
+		img.layers = append(img.layers, Layer{MediaType: desc.MediaType, Data: data})
+	}
+	return img, nil
+}
+
+func (r *Registry) pushBlob(ctx context.Context, repository string, blob []byte) error {
+	digest := digestOf(blob)
+	resp, err := r.do(ctx, repository, http.MethodHead, r.url("/v2/%s/blobs/%s", repository, digest), nil, nil)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode == http.StatusOK {
+		// The blob was already pushed
+		return nil
+	}
+
+	resp, err = r.do(ctx, repository, http.MethodPost, r.url("/v2/%s/blobs/uploads/", repository), nil, nil)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusAccepted {
+		return unexpectedResponse(resp, "starting upload of blob %s to %s", digest, repository)
+	}
+	location, err := resp.Location()
+	if err != nil {
+		return fmt.Errorf("starting upload of blob %s to %s: %v", digest, repository, err)
+	}
+	query := location.Query()
+	query.Set("digest", digest)
+	location.RawQuery = query.Encode()
+
+	resp, err = r.do(ctx, repository, http.MethodPut, location.String(), blob, map[string]string{"Content-Type": "application/octet-stream"})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		return unexpectedResponse(resp, "uploading blob %s to %s", digest, repository)
+	}
+	return nil
+}
+
+func (r *Registry) getManifest(ctx context.Context, repository, ref string) ([]byte, string, error) {
+	accept := strings.Join([]string{MediaTypeImageManifest, MediaTypeImageIndex, MediaTypeDockerManifest, MediaTypeDockerManifestList}, ", ")
+	resp, err := r.do(ctx, repository, http.MethodGet, r.url("/v2/%s/manifests/%s", repository, ref), nil, map[string]string{"Accept": accept})
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", unexpectedResponse(resp, "getting manifest %s of %s", ref, repository)
+	}
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", err
+	}
+
+	mediaType := resp.Header.Get("Content-Type")
+	if i := strings.Index(mediaType, ";"); i >= 0 {
+		mediaType = mediaType[:i]
+	}
+	if mediaType == "" || mediaType == "application/json" {
+		// Fall back on the media type of the manifest itself
+		var m manifest
+		if err := json.Unmarshal(data, &m); err == nil {
+			mediaType = m.MediaType
+		}
+	}
+	return data, mediaType, nil
+}
+
+func (r *Registry) getBlob(ctx context.Context, repository, digest string) ([]byte, error) {
+	resp, err := r.do(ctx, repository, http.MethodGet, r.url("/v2/%s/blobs/%s", repository, digest), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, unexpectedResponse(resp, "getting blob %s of %s", digest, repository)
+	}
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if actual := digestOf(data); actual != digest {
+		return nil, fmt.Errorf("blob %s of %s has digest %s", digest, repository, actual)
+	}
+	return data, nil
+}
+
+// do sends a request to the registry, authenticating with the token service of the registry when it requires so.
+func (r *Registry) do(ctx context.Context, repository, method, u string, body []byte, header map[string]string) (*http.Response, error) {
+	newRequest := func() (*http.Request, error) {
+		var reader io.Reader
+		if body != nil {
+			reader = bytes.NewReader(body)
+		}
+		req, err := http.NewRequestWithContext(ctx, method, u, reader)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range header {
+			req.Header.Set(k, v)
+		}
+		return req, nil
+	}
+
+	req, err := newRequest()
+	if err != nil {
+		return nil, err
+	}
+	r.authorize(req, repository)
+	resp, err := r.client().Do(req)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+
+	challenge := resp.Header.Get("WWW-Authenticate")
+	resp.Body.Close()
+	if err := r.authenticate(ctx, repository, challenge); err != nil {
+		return nil, err
+	}
+	if req, err = newRequest(); err != nil {
+		return nil, err
+	}
+	r.authorize(req, repository)
+	return r.client().Do(req)
+}
+
+func (r *Registry) authorize(req *http.Request, repository string) {
+	r.tokensLock.Lock()
+	token, ok := r.tokens[repository]
+	r.tokensLock.Unlock()
+	if ok {
+		req.Header.Set("Authorization", "Bearer "+token)
+		return
+	}
+	if r.Username != "" {
+		req.SetBasicAuth(r.Username, r.Password)
+	}
+}
+
+// authenticate gets a token for the given repository from the token service named by the given challenge.
+func (r *Registry) authenticate(ctx context.Context, repository, challenge string) error {
+	if !strings.HasPrefix(challenge, "Bearer ") {
+		return fmt.Errorf("unauthorized to access %s on %s", repository, r.Host)
+	}
+	params := map[string]string{}
+	for _, param := range strings.Split(strings.TrimPrefix(challenge, "Bearer "), ",") {
+		kv := strings.SplitN(strings.TrimSpace(param), "=", 2)
+		if len(kv) == 2 {
+			params[kv[0]] = strings.Trim(kv[1], `"`)
+		}
+	}
+	realm, err := url.Parse(params["realm"])
+	if err != nil || params["realm"] == "" {
+		return fmt.Errorf("invalid authentication challenge of %s: %q", r.Host, challenge)
+	}
+	query := realm.Query()
+	if service, ok := params["service"]; ok {
+		query.Set("service", service)
+	}
+	query.Set("scope", fmt.Sprintf("repository:%s:pull,push", repository))
+	realm.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, realm.String(), nil)
+	if err != nil {
+		return err
+	}
+	if r.Username != "" {
+		req.SetBasicAuth(r.Username, r.Password)
+	}
+	resp, err := r.client().Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return unexpectedResponse(resp, "getting token for %s", repository)
+	}
+	var token struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return fmt.Errorf("decoding token for %s: %v", repository, err)
+	}
+	if token.Token == "" {
+		token.Token = token.AccessToken
+	}
+
+	r.tokensLock.Lock()
+	defer r.tokensLock.Unlock()
+	if r.tokens == nil {
+		r.tokens = map[string]string{}
+	}
+	r.tokens[repository] = token.Token
+	return nil
+}
+
+func (r *Registry) url(format string, args ...interface{}) string {
+	scheme := "https"
+	if r.PlainHTTP {
+		scheme = "http"
+	}
+	return fmt.Sprintf("%s://%s%s", scheme, r.Host, fmt.Sprintf(format, args...))
+}
+
+func (r *Registry) client() *http.Client {
+	if r.Client != nil {
+		return r.Client
+	}
+	return http.DefaultClient
+}
+
+// splitReference splits the given reference, relative to a registry, into its repository and its tag or digest.
+func splitReference(reference string) (repository, ref string) {
+	if i := strings.Index(reference, "@"); i >= 0 {
+		return reference[:i], reference[i+1:]
+	}
+	if i := strings.LastIndex(reference, ":"); i > strings.LastIndex(reference, "/") {
+		return reference[:i], reference[i+1:]
+	}
+	return reference, "latest"
+}
+
+func unexpectedResponse(resp *http.Response, format string, args ...interface{}) error {
+	body, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+	return fmt.Errorf("%s: unexpected response %s: %s", fmt.Sprintf(format, args...), resp.Status, strings.TrimSpace(string(body)))
+}
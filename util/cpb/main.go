@@ -5,6 +5,7 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/operator-framework/operator-registry/pkg/lib/bundle"
 	"github.com/otiai10/copy"
@@ -12,6 +13,12 @@ import (
 	"gopkg.in/yaml.v2"
 )
 
+var catalogDir string
+
+func init() {
+	rootCmd.Flags().StringVar(&catalogDir, "catalog", "", "copy the file-based catalog in the given directory instead of a bundle")
+}
+
 func main() {
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Println(err)
@@ -26,7 +33,10 @@ var rootCmd = &cobra.Command{
 Copy traverses the filesystem from the current directory down
 until it finds an annotations.yaml representing the bundle's metadata.
 From there, it copies the annotations.yaml file and manifests into the
-specified output directory`,
+specified output directory.
+With --catalog, it copies the files of the file-based catalog in the
+given directory into the manifests directory of the output directory
+instead, flattening their paths`,
 	Run: func(cmd *cobra.Command, args []string) {
 		dest := "./bundle"
 		if len(args) > 1 {
@@ -34,7 +44,11 @@ specified output directory`,
 			dest = args[0]
 		}
 
-		if err := copyBundle(dest); err != nil {
+		copyContent := copyBundle
+		if catalogDir != "" {
+			copyContent = copyCatalog
+		}
+		if err := copyContent(dest); err != nil {
 			fmt.Println(err)
 			os.Exit(1)
 		}
@@ -53,6 +67,39 @@ func copyBundle(dest string) error {
 	return m.copy(dest)
 }
 
+// copyCatalog copies the catalog files of the catalog directory into the manifests directory of the given directory,
+// along with an empty metadata directory, so that they can be extracted like the content of a bundle.
+func copyCatalog(dest string) error {
+	manifests := filepath.Join(dest, "manifests")
+	if err := os.MkdirAll(manifests, os.ModePerm); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Join(dest, "metadata"), os.ModePerm); err != nil {
+		return err
+	}
+
+	return filepath.Walk(catalogDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		switch filepath.Ext(path) {
+		case ".json", ".yaml", ".yml":
+		default:
+			return nil
+		}
+		rel, err := filepath.Rel(catalogDir, path)
+		if err != nil {
+			return err
+		}
+		name := strings.ReplaceAll(rel, string(filepath.Separator), "_")
+		fmt.Printf("copying catalog file %s to %s\n", rel, name)
+		return copy.Copy(path, filepath.Join(manifests, name))
+	})
+}
+
 type metadata struct {
 	annotationsFile string
 	manifestDir     string